@@ -24,7 +24,7 @@ func FFT(data []byte) ([]byte, error) {
 
 	maxScale := uint8(math.Log2(float64(dataFrLenPow2)))
 
-	fs := fft.NewFFTSettings(maxScale)
+	fs := fft.GetFFTSettings(maxScale)
 
 	dataFFTFr, err := fs.FFT(dataFr, false)
 	if err != nil {
@@ -58,7 +58,7 @@ func IFFT(data []byte) ([]byte, error) {
 	maxScale := uint8(math.Log2(float64(dataFrLenPow2)))
 
 	// perform IFFT
-	fs := fft.NewFFTSettings(maxScale)
+	fs := fft.GetFFTSettings(maxScale)
 	dataIFFTFr, err := fs.FFT(paddedDataFr, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform IFFT: %w", err)
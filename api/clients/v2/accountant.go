@@ -122,6 +122,7 @@ func (a *Accountant) AccountBlob(ctx context.Context, numSymbols uint32, quorums
 		ReservationPeriod: reservationPeriod,
 		CumulativePayment: cumulativePayment,
 		Salt:              salt,
+		Timestamp:         time.Now().UnixNano(),
 	}
 
 	return pm, nil
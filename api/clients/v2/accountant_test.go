@@ -71,11 +71,14 @@ func TestAccountBlob_Reservation(t *testing.T) {
 	symbolLength := uint32(500)
 	quorums := []uint8{0, 1}
 
+	before := time.Now()
 	header, err := accountant.AccountBlob(ctx, symbolLength, quorums, salt)
 
 	assert.NoError(t, err)
 	assert.Equal(t, meterer.GetReservationPeriod(uint64(time.Now().Unix()), reservationWindow), header.ReservationPeriod)
 	assert.Equal(t, big.NewInt(0), header.CumulativePayment)
+	assert.GreaterOrEqual(t, header.Timestamp, before.UnixNano())
+	assert.LessOrEqual(t, header.Timestamp, time.Now().UnixNano())
 	assert.Equal(t, isRotation([]uint64{500, 0, 0}, mapRecordUsage(accountant.binRecords)), true)
 
 	symbolLength = uint32(700)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
@@ -19,13 +20,20 @@ type DisperserClientConfig struct {
 	Hostname          string
 	Port              string
 	UseSecureGrpcFlag bool
+	// RetentionPeriod is how long dispersed blobs should be retained. Zero retains blobs for the protocol maximum.
+	RetentionPeriod time.Duration
+	// SecurityThresholds are custom confirmation and adversary thresholds for some of the dispersal quorums.
+	// Quorums without custom thresholds use the protocol defaults.
+	SecurityThresholds []corev2.QuorumSecurityThresholds
 }
 
 type DisperserClient interface {
 	Close() error
 	DisperseBlob(ctx context.Context, data []byte, blobVersion corev2.BlobVersion, quorums []core.QuorumID, salt uint32) (*dispv2.BlobStatus, corev2.BlobKey, error)
 	GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error)
+	GetBlobStatuses(ctx context.Context, blobKeys []corev2.BlobKey) (*disperser_rpc.BlobStatusesReply, error)
 	GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error)
+	GetOnDemandBalance(ctx context.Context) (*disperser_rpc.GetOnDemandBalanceReply, error)
 }
 
 type disperserClient struct {
@@ -74,6 +82,9 @@ func NewDisperserClient(config *DisperserClientConfig, signer corev2.BlobRequest
 	if signer == nil {
 		return nil, api.NewErrorInvalidArg("signer must be provided")
 	}
+	if config.RetentionPeriod != 0 && config.RetentionPeriod < corev2.MinBlobRetentionPeriod {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("retention period must be at least %s", corev2.MinBlobRetentionPeriod))
+	}
 
 	return &disperserClient{
 		config:     config,
@@ -182,10 +193,12 @@ func (c *disperserClient) DisperseBlob(
 	}
 
 	blobHeader := &corev2.BlobHeader{
-		BlobVersion:     blobVersion,
-		BlobCommitments: blobCommitments,
-		QuorumNumbers:   quorums,
-		PaymentMetadata: *payment,
+		BlobVersion:        blobVersion,
+		BlobCommitments:    blobCommitments,
+		QuorumNumbers:      quorums,
+		PaymentMetadata:    *payment,
+		RetentionPeriod:    c.config.RetentionPeriod,
+		SecurityThresholds: c.config.SecurityThresholds,
 	}
 
 	sig, err := c.signer.SignBlobRequest(blobHeader)
@@ -228,6 +241,23 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, blobKey corev2.Blob
 	return c.client.GetBlobStatus(ctx, request)
 }
 
+// GetBlobStatuses returns the statuses of the blobs with the given blob keys, in the same order as the keys.
+func (c *disperserClient) GetBlobStatuses(ctx context.Context, blobKeys []corev2.BlobKey) (*disperser_rpc.BlobStatusesReply, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	keys := make([][]byte, len(blobKeys))
+	for i := range blobKeys {
+		keys[i] = blobKeys[i][:]
+	}
+	request := &disperser_rpc.BlobStatusesRequest{
+		BlobKeys: keys,
+	}
+	return c.client.GetBlobStatuses(ctx, request)
+}
+
 // GetPaymentState returns the payment state of the disperser client
 func (c *disperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.GetPaymentStateReply, error) {
 	err := c.initOnceGrpcConnection()
@@ -240,7 +270,8 @@ func (c *disperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.G
 		return nil, fmt.Errorf("error getting signer's account ID: %w", err)
 	}
 
-	signature, err := c.signer.SignPaymentStateRequest()
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := c.signer.SignPaymentStateRequest(timestamp)
 	if err != nil {
 		return nil, fmt.Errorf("error signing payment state request: %w", err)
 	}
@@ -248,10 +279,37 @@ func (c *disperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.G
 	request := &disperser_rpc.GetPaymentStateRequest{
 		AccountId: accountID,
 		Signature: signature,
+		Timestamp: timestamp,
 	}
 	return c.client.GetPaymentState(ctx, request)
 }
 
+// GetOnDemandBalance returns the on-demand balance remaining for the disperser client's account
+func (c *disperserClient) GetOnDemandBalance(ctx context.Context) (*disperser_rpc.GetOnDemandBalanceReply, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	accountID, err := c.signer.GetAccountID()
+	if err != nil {
+		return nil, fmt.Errorf("error getting signer's account ID: %w", err)
+	}
+
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := c.signer.SignPaymentStateRequest(timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("error signing on-demand balance request: %w", err)
+	}
+
+	request := &disperser_rpc.GetOnDemandBalanceRequest{
+		AccountId: accountID,
+		Signature: signature,
+		Timestamp: timestamp,
+	}
+	return c.client.GetOnDemandBalance(ctx, request)
+}
+
 // GetBlobCommitment is a utility method that calculates commitment for a blob payload.
 // While the blob commitment can be calculated by anyone, it requires SRS points to
 // be loaded. For service that does not have access to SRS points, this method can be
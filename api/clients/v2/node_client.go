@@ -16,6 +16,9 @@ type NodeClientConfig struct {
 	Hostname          string
 	Port              string
 	UseSecureGrpcFlag bool
+	// QuorumProfilesDigest is the digest of the quorum profiles the blobs are encoded with, which nodes check against
+	// their own
+	QuorumProfilesDigest []byte
 }
 
 type NodeClient interface {
@@ -69,6 +72,7 @@ func (c *nodeClient) StoreChunks(ctx context.Context, batch *corev2.Batch) (*cor
 			},
 			BlobCertificates: blobCerts,
 		},
+		QuorumProfilesDigest: c.config.QuorumProfilesDigest,
 	})
 	if err != nil {
 		return nil, err
@@ -30,6 +30,9 @@ type retrievalClient struct {
 	indexedChainState core.IndexedChainState
 	verifier          encoding.Verifier
 	numConnections    int
+	// quorumProfiles are the quorum profiles of each blob version, which aren't recorded onchain. They must be the
+	// profiles the disperser encoded blobs with, so that the client computes the assignments of the nodes.
+	quorumProfiles core.BlobVersionQuorumProfiles
 }
 
 // NewRetrievalClient creates a new retrieval client.
@@ -39,6 +42,7 @@ func NewRetrievalClient(
 	chainState core.IndexedChainState,
 	verifier encoding.Verifier,
 	numConnections int,
+	quorumProfiles core.BlobVersionQuorumProfiles,
 ) RetrievalClient {
 	return &retrievalClient{
 		logger:            logger.With("component", "RetrievalClient"),
@@ -46,6 +50,7 @@ func NewRetrievalClient(
 		indexedChainState: chainState,
 		verifier:          verifier,
 		numConnections:    numConnections,
+		quorumProfiles:    quorumProfiles,
 	}
 }
 
@@ -83,6 +88,10 @@ func (r *retrievalClient) GetBlob(ctx context.Context, blobHeader *corev2.BlobHe
 	if !ok {
 		return nil, fmt.Errorf("invalid blob version %d", blobHeader.BlobVersion)
 	}
+	blobParam, err = blobParam.WithQuorumProfiles(r.quorumProfiles[blobHeader.BlobVersion])
+	if err != nil {
+		return nil, fmt.Errorf("invalid quorum profiles of blob version %d: %w", blobHeader.BlobVersion, err)
+	}
 
 	encodingParams, err := blobHeader.GetEncodingParams(blobParam)
 	if err != nil {
@@ -153,7 +162,7 @@ func (r *retrievalClient) getChunksFromOperator(
 	chunksChan chan clients.RetrievedChunks,
 ) {
 	conn, err := grpc.NewClient(
-		core.OperatorSocket(opInfo.Socket).GetRetrievalSocket(),
+		core.OperatorSocket(opInfo.Socket).GetV2RetrievalSocket(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
 	defer func() {
@@ -20,15 +20,13 @@ func GenerateBlobCommitment(
 		return nil, fmt.Errorf("convert bytes to field elements, %w", err)
 	}
 
-	if len(kzgVerifier.Srs.G1) < len(inputFr) {
-		return nil, fmt.Errorf(
-			"insufficient SRS in memory: have %v, need %v",
-			len(kzgVerifier.Srs.G1),
-			len(inputFr))
+	s1, err := kzgVerifier.Srs.G1Points(uint64(len(inputFr)))
+	if err != nil {
+		return nil, fmt.Errorf("insufficient SRS: %w", err)
 	}
 
 	var commitment bn254.G1Affine
-	_, err = commitment.MultiExp(kzgVerifier.Srs.G1[:len(inputFr)], inputFr, ecc.MultiExpConfig{})
+	_, err = commitment.MultiExp(s1, inputFr, ecc.MultiExpConfig{})
 	if err != nil {
 		return nil, fmt.Errorf("MultiExp: %w", err)
 	}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"fmt"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // The canonical errors from the EigenDA gRPC API endpoints.
@@ -33,11 +36,62 @@ func NewErrorNotFound(msg string) error {
 	return newErrorGRPC(codes.NotFound, msg)
 }
 
+// HTTP Mapping: 403 Forbidden
+func NewErrorPermissionDenied(msg string) error {
+	return newErrorGRPC(codes.PermissionDenied, msg)
+}
+
 // HTTP Mapping: 429 Too Many Requests
 func NewErrorResourceExhausted(msg string) error {
 	return newErrorGRPC(codes.ResourceExhausted, msg)
 }
 
+// ErrorReasonOverReservation is the ErrorInfo reason of the errors returned by NewErrorOverReservation
+const ErrorReasonOverReservation = "OVER_RESERVATION"
+
+// HTTP Mapping: 429 Too Many Requests
+// NewErrorOverReservation is returned when a dispersal exceeds the bandwidth of the account's reservation.
+// Besides the message, the error carries an ErrorInfo detail with the ErrorReasonOverReservation reason, and a
+// RetryInfo detail with the time until the reservation has the capacity for the dispersal.
+func NewErrorOverReservation(msg string, retryAfter time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, msg).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: ErrorReasonOverReservation,
+			Domain: "eigenda",
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryAfter),
+		},
+	)
+	if err != nil {
+		return NewErrorResourceExhausted(msg)
+	}
+	return st.Err()
+}
+
+// ErrorReasonPipelineSaturated is the ErrorInfo reason of the errors returned by NewErrorPipelineSaturated
+const ErrorReasonPipelineSaturated = "PIPELINE_SATURATED"
+
+// HTTP Mapping: 429 Too Many Requests
+// NewErrorPipelineSaturated is returned when the disperser sheds load because its encoding or dispatching backlog is
+// too large to take more blobs. Besides the message, the error carries an ErrorInfo detail with the
+// ErrorReasonPipelineSaturated reason, and a RetryInfo detail with how long the client should wait before retrying.
+func NewErrorPipelineSaturated(msg string, retryAfter time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, msg).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: ErrorReasonPipelineSaturated,
+			Domain: "eigenda",
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryAfter),
+		},
+	)
+	if err != nil {
+		return NewErrorResourceExhausted(msg)
+	}
+	return st.Err()
+}
+
 // HTTP Mapping: 500 Internal Server Error
 func NewErrorInternal(msg string) error {
 	return newErrorGRPC(codes.Internal, msg)
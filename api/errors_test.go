@@ -4,6 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestErrorFailoverErrorsIs(t *testing.T) {
@@ -44,3 +49,53 @@ func TestErrorFailoverZeroValue(t *testing.T) {
 		t.Error("should return 'Failover' for zero value")
 	}
 }
+
+func TestNewErrorOverReservation(t *testing.T) {
+	err := NewErrorOverReservation("over reservation", 1500*time.Millisecond)
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		t.Fatalf("should be a ResourceExhausted grpc error, got %v", err)
+	}
+	var reason string
+	var retryDelay time.Duration
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			reason = d.GetReason()
+		case *errdetails.RetryInfo:
+			retryDelay = d.GetRetryDelay().AsDuration()
+		}
+	}
+	if reason != ErrorReasonOverReservation {
+		t.Errorf("should have the over reservation reason, got %q", reason)
+	}
+	if retryDelay != 1500*time.Millisecond {
+		t.Errorf("should have a 1.5s retry delay, got %v", retryDelay)
+	}
+}
+
+func TestNewErrorPipelineSaturated(t *testing.T) {
+	err := NewErrorPipelineSaturated("pipeline saturated", 10*time.Second)
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		t.Fatalf("should be a ResourceExhausted grpc error, got %v", err)
+	}
+	var reason string
+	var retryDelay time.Duration
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			reason = d.GetReason()
+		case *errdetails.RetryInfo:
+			retryDelay = d.GetRetryDelay().AsDuration()
+		}
+	}
+	if reason != ErrorReasonPipelineSaturated {
+		t.Errorf("should have the pipeline saturated reason, got %q", reason)
+	}
+	if retryDelay != 10*time.Second {
+		t.Errorf("should have a 10s retry delay, got %v", retryDelay)
+	}
+}
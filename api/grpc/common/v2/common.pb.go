@@ -33,6 +33,14 @@ type BlobHeader struct {
 	PaymentHeader *common.PaymentHeader  `protobuf:"bytes,4,opt,name=payment_header,json=paymentHeader,proto3" json:"payment_header,omitempty"`
 	// signature over keccak hash of the blob_header that can be verified by blob_header.account_id
 	Signature []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	// retention_period_seconds is how long the blob should be retained, bounded by the protocol minimum and maximum.
+	// Zero retains the blob for the protocol maximum. It is not part of the blob key, but the signature covers it through the request hash.
+	RetentionPeriodSeconds uint64 `protobuf:"varint,6,opt,name=retention_period_seconds,json=retentionPeriodSeconds,proto3" json:"retention_period_seconds,omitempty"`
+	// security_thresholds are custom security thresholds for some of the quorums of the blob. The blob is only certified
+	// if the signing stake of each of these quorums meets its confirmation threshold. Quorums without custom thresholds
+	// use the protocol defaults. The thresholds must satisfy the security assumptions of the blob version and can't be lower than the security params of the quorum.
+	// They are not part of the blob key, but the signature covers them through the request hash.
+	SecurityThresholds []*QuorumSecurityThresholds `protobuf:"bytes,7,rep,name=security_thresholds,json=securityThresholds,proto3" json:"security_thresholds,omitempty"`
 }
 
 func (x *BlobHeader) Reset() {
@@ -102,6 +110,20 @@ func (x *BlobHeader) GetSignature() []byte {
 	return nil
 }
 
+func (x *BlobHeader) GetRetentionPeriodSeconds() uint64 {
+	if x != nil {
+		return x.RetentionPeriodSeconds
+	}
+	return 0
+}
+
+func (x *BlobHeader) GetSecurityThresholds() []*QuorumSecurityThresholds {
+	if x != nil {
+		return x.SecurityThresholds
+	}
+	return nil
+}
+
 // BlobCertificate is what gets attested by the network
 type BlobCertificate struct {
 	state         protoimpl.MessageState
@@ -274,13 +296,79 @@ func (x *Batch) GetBlobCertificates() []*BlobCertificate {
 	return nil
 }
 
+// QuorumSecurityThresholds are the security thresholds of a quorum, as percentages of the total stake of the quorum
+type QuorumSecurityThresholds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuorumId uint32 `protobuf:"varint,1,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// confirmation_threshold is the percentage of the quorum stake that must sign for the blob to be certified
+	ConfirmationThreshold uint32 `protobuf:"varint,2,opt,name=confirmation_threshold,json=confirmationThreshold,proto3" json:"confirmation_threshold,omitempty"`
+	// adversary_threshold is the maximum percentage of the quorum stake assumed to be controlled by an adversary
+	AdversaryThreshold uint32 `protobuf:"varint,3,opt,name=adversary_threshold,json=adversaryThreshold,proto3" json:"adversary_threshold,omitempty"`
+}
+
+func (x *QuorumSecurityThresholds) Reset() {
+	*x = QuorumSecurityThresholds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_v2_common_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuorumSecurityThresholds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuorumSecurityThresholds) ProtoMessage() {}
+
+func (x *QuorumSecurityThresholds) ProtoReflect() protoreflect.Message {
+	mi := &file_common_v2_common_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuorumSecurityThresholds.ProtoReflect.Descriptor instead.
+func (*QuorumSecurityThresholds) Descriptor() ([]byte, []int) {
+	return file_common_v2_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QuorumSecurityThresholds) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+func (x *QuorumSecurityThresholds) GetConfirmationThreshold() uint32 {
+	if x != nil {
+		return x.ConfirmationThreshold
+	}
+	return 0
+}
+
+func (x *QuorumSecurityThresholds) GetAdversaryThreshold() uint32 {
+	if x != nil {
+		return x.AdversaryThreshold
+	}
+	return 0
+}
+
 var File_common_v2_common_proto protoreflect.FileDescriptor
 
 var file_common_v2_common_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x76, 0x32, 0x2f, 0x63, 0x6f, 0x6d, 0x6d,
 	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
 	0x2e, 0x76, 0x32, 0x1a, 0x13, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe1, 0x01, 0x0a, 0x0a, 0x42, 0x6c, 0x6f,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf1, 0x02, 0x0a, 0x0a, 0x42, 0x6c, 0x6f,
 	0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
 	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
 	0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
@@ -294,7 +382,16 @@ var file_common_v2_common_proto_rawDesc = []byte{
 	0x6e, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52,
 	0x0d, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1c,
 	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x61, 0x0a, 0x0f,
+	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x38, 0x0a, 0x18,
+	0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16,
+	0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x54, 0x0a, 0x13, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e,
+	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x52, 0x12, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x22, 0x61, 0x0a, 0x0f,
 	0x42, 0x6c, 0x6f, 0x62, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12,
 	0x36, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x32,
@@ -315,11 +412,21 @@ var file_common_v2_common_proto_rawDesc = []byte{
 	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
 	0x6e, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
 	0x63, 0x61, 0x74, 0x65, 0x52, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
-	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65,
-	0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x18, 0x51, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64,
+	0x12, 0x35, 0x0a, 0x16, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x64, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x72, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73,
+	0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70,
+	0x63, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -334,26 +441,28 @@ func file_common_v2_common_proto_rawDescGZIP() []byte {
 	return file_common_v2_common_proto_rawDescData
 }
 
-var file_common_v2_common_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_common_v2_common_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_common_v2_common_proto_goTypes = []interface{}{
-	(*BlobHeader)(nil),            // 0: common.v2.BlobHeader
-	(*BlobCertificate)(nil),       // 1: common.v2.BlobCertificate
-	(*BatchHeader)(nil),           // 2: common.v2.BatchHeader
-	(*Batch)(nil),                 // 3: common.v2.Batch
-	(*common.BlobCommitment)(nil), // 4: common.BlobCommitment
-	(*common.PaymentHeader)(nil),  // 5: common.PaymentHeader
+	(*BlobHeader)(nil),               // 0: common.v2.BlobHeader
+	(*BlobCertificate)(nil),          // 1: common.v2.BlobCertificate
+	(*BatchHeader)(nil),              // 2: common.v2.BatchHeader
+	(*Batch)(nil),                    // 3: common.v2.Batch
+	(*QuorumSecurityThresholds)(nil), // 4: common.v2.QuorumSecurityThresholds
+	(*common.BlobCommitment)(nil),    // 5: common.BlobCommitment
+	(*common.PaymentHeader)(nil),     // 6: common.PaymentHeader
 }
 var file_common_v2_common_proto_depIdxs = []int32{
-	4, // 0: common.v2.BlobHeader.commitment:type_name -> common.BlobCommitment
-	5, // 1: common.v2.BlobHeader.payment_header:type_name -> common.PaymentHeader
-	0, // 2: common.v2.BlobCertificate.blob_header:type_name -> common.v2.BlobHeader
-	2, // 3: common.v2.Batch.header:type_name -> common.v2.BatchHeader
-	1, // 4: common.v2.Batch.blob_certificates:type_name -> common.v2.BlobCertificate
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	5, // 0: common.v2.BlobHeader.commitment:type_name -> common.BlobCommitment
+	6, // 1: common.v2.BlobHeader.payment_header:type_name -> common.PaymentHeader
+	4, // 2: common.v2.BlobHeader.security_thresholds:type_name -> common.v2.QuorumSecurityThresholds
+	0, // 3: common.v2.BlobCertificate.blob_header:type_name -> common.v2.BlobHeader
+	2, // 4: common.v2.Batch.header:type_name -> common.v2.BatchHeader
+	1, // 5: common.v2.Batch.blob_certificates:type_name -> common.v2.BlobCertificate
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_common_v2_common_proto_init() }
@@ -410,6 +519,18 @@ func file_common_v2_common_proto_init() {
 				return nil
 			}
 		}
+		file_common_v2_common_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuorumSecurityThresholds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -417,7 +538,7 @@ func file_common_v2_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_common_v2_common_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Disperser_DisperseBlob_FullMethodName              = "/disperser.Disperser/DisperseBlob"
 	Disperser_DisperseBlobAuthenticated_FullMethodName = "/disperser.Disperser/DisperseBlobAuthenticated"
+	Disperser_DisperseBlobStream_FullMethodName        = "/disperser.Disperser/DisperseBlobStream"
 	Disperser_GetBlobStatus_FullMethodName             = "/disperser.Disperser/GetBlobStatus"
 	Disperser_RetrieveBlob_FullMethodName              = "/disperser.Disperser/RetrieveBlob"
 )
@@ -51,6 +52,16 @@ type DisperserClient interface {
 	//     AuthenticationData message.
 	//  4. The Disperser verifies the signature and returns a DisperseBlobReply message.
 	DisperseBlobAuthenticated(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobAuthenticatedClient, error)
+	// DisperseBlobStream is similar to DisperseBlob, except that the blob is uploaded in chunks, for blobs too
+	// large to be sent in a single message. The protocol is as follows:
+	//  1. The client sends a DisperseBlobStreamRequest with the DisperseBlobStreamHeader message
+	//  2. The client sends the data of the blob, in order, in as many DisperseBlobStreamRequest chunk messages
+	//     as needed.
+	//  3. The client closes the stream, and the Disperser reassembles the blob and returns a DisperseBlobReply message.
+	//
+	// The reassembled blob must meet the same requirements as the data of a DisperseBlobRequest, and the
+	// error codes are the same as DisperseBlob.
+	DisperseBlobStream(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobStreamClient, error)
 	// This API is meant to be polled for the blob status.
 	GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error)
 	// This retrieves the requested blob from the Disperser's backend.
@@ -110,6 +121,40 @@ func (x *disperserDisperseBlobAuthenticatedClient) Recv() (*AuthenticatedReply,
 	return m, nil
 }
 
+func (c *disperserClient) DisperseBlobStream(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[1], Disperser_DisperseBlobStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserDisperseBlobStreamClient{stream}
+	return x, nil
+}
+
+type Disperser_DisperseBlobStreamClient interface {
+	Send(*DisperseBlobStreamRequest) error
+	CloseAndRecv() (*DisperseBlobReply, error)
+	grpc.ClientStream
+}
+
+type disperserDisperseBlobStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserDisperseBlobStreamClient) Send(m *DisperseBlobStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobStreamClient) CloseAndRecv() (*DisperseBlobReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(DisperseBlobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *disperserClient) GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error) {
 	out := new(BlobStatusReply)
 	err := c.cc.Invoke(ctx, Disperser_GetBlobStatus_FullMethodName, in, out, opts...)
@@ -154,6 +199,16 @@ type DisperserServer interface {
 	//     AuthenticationData message.
 	//  4. The Disperser verifies the signature and returns a DisperseBlobReply message.
 	DisperseBlobAuthenticated(Disperser_DisperseBlobAuthenticatedServer) error
+	// DisperseBlobStream is similar to DisperseBlob, except that the blob is uploaded in chunks, for blobs too
+	// large to be sent in a single message. The protocol is as follows:
+	//  1. The client sends a DisperseBlobStreamRequest with the DisperseBlobStreamHeader message
+	//  2. The client sends the data of the blob, in order, in as many DisperseBlobStreamRequest chunk messages
+	//     as needed.
+	//  3. The client closes the stream, and the Disperser reassembles the blob and returns a DisperseBlobReply message.
+	//
+	// The reassembled blob must meet the same requirements as the data of a DisperseBlobRequest, and the
+	// error codes are the same as DisperseBlob.
+	DisperseBlobStream(Disperser_DisperseBlobStreamServer) error
 	// This API is meant to be polled for the blob status.
 	GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error)
 	// This retrieves the requested blob from the Disperser's backend.
@@ -176,6 +231,9 @@ func (UnimplementedDisperserServer) DisperseBlob(context.Context, *DisperseBlobR
 func (UnimplementedDisperserServer) DisperseBlobAuthenticated(Disperser_DisperseBlobAuthenticatedServer) error {
 	return status.Errorf(codes.Unimplemented, "method DisperseBlobAuthenticated not implemented")
 }
+func (UnimplementedDisperserServer) DisperseBlobStream(Disperser_DisperseBlobStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method DisperseBlobStream not implemented")
+}
 func (UnimplementedDisperserServer) GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatus not implemented")
 }
@@ -239,6 +297,32 @@ func (x *disperserDisperseBlobAuthenticatedServer) Recv() (*AuthenticatedRequest
 	return m, nil
 }
 
+func _Disperser_DisperseBlobStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisperserServer).DisperseBlobStream(&disperserDisperseBlobStreamServer{stream})
+}
+
+type Disperser_DisperseBlobStreamServer interface {
+	SendAndClose(*DisperseBlobReply) error
+	Recv() (*DisperseBlobStreamRequest, error)
+	grpc.ServerStream
+}
+
+type disperserDisperseBlobStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserDisperseBlobStreamServer) SendAndClose(m *DisperseBlobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobStreamServer) Recv() (*DisperseBlobStreamRequest, error) {
+	m := new(DisperseBlobStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Disperser_GetBlobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlobStatusRequest)
 	if err := dec(in); err != nil {
@@ -302,6 +386,11 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DisperseBlobStream",
+			Handler:       _Disperser_DisperseBlobStream_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "disperser/disperser.proto",
 }
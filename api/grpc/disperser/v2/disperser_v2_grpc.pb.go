@@ -19,10 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Disperser_DisperseBlob_FullMethodName      = "/disperser.v2.Disperser/DisperseBlob"
-	Disperser_GetBlobStatus_FullMethodName     = "/disperser.v2.Disperser/GetBlobStatus"
-	Disperser_GetBlobCommitment_FullMethodName = "/disperser.v2.Disperser/GetBlobCommitment"
-	Disperser_GetPaymentState_FullMethodName   = "/disperser.v2.Disperser/GetPaymentState"
+	Disperser_DisperseBlob_FullMethodName       = "/disperser.v2.Disperser/DisperseBlob"
+	Disperser_GetBlobStatus_FullMethodName      = "/disperser.v2.Disperser/GetBlobStatus"
+	Disperser_GetBlobStatuses_FullMethodName    = "/disperser.v2.Disperser/GetBlobStatuses"
+	Disperser_GetBlobCommitment_FullMethodName  = "/disperser.v2.Disperser/GetBlobCommitment"
+	Disperser_GetPaymentState_FullMethodName    = "/disperser.v2.Disperser/GetPaymentState"
+	Disperser_GetOnDemandBalance_FullMethodName = "/disperser.v2.Disperser/GetOnDemandBalance"
 )
 
 // DisperserClient is the client API for Disperser service.
@@ -36,10 +38,15 @@ type DisperserClient interface {
 	DisperseBlob(ctx context.Context, in *DisperseBlobRequest, opts ...grpc.CallOption) (*DisperseBlobReply, error)
 	// GetBlobStatus is meant to be polled for the blob status.
 	GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error)
+	// GetBlobStatuses returns the statuses of multiple blobs in one call.
+	// It is meant to be polled by clients tracking many in-flight blobs, instead of calling GetBlobStatus for each of them.
+	GetBlobStatuses(ctx context.Context, in *BlobStatusesRequest, opts ...grpc.CallOption) (*BlobStatusesReply, error)
 	// GetBlobCommitment is a utility method that calculates commitment for a blob payload.
 	GetBlobCommitment(ctx context.Context, in *BlobCommitmentRequest, opts ...grpc.CallOption) (*BlobCommitmentReply, error)
 	// GetPaymentState is a utility method to get the payment state of a given account.
 	GetPaymentState(ctx context.Context, in *GetPaymentStateRequest, opts ...grpc.CallOption) (*GetPaymentStateReply, error)
+	// GetOnDemandBalance is a utility method to get the remaining on-demand balance of a given account.
+	GetOnDemandBalance(ctx context.Context, in *GetOnDemandBalanceRequest, opts ...grpc.CallOption) (*GetOnDemandBalanceReply, error)
 }
 
 type disperserClient struct {
@@ -68,6 +75,15 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, in *BlobStatusReque
 	return out, nil
 }
 
+func (c *disperserClient) GetBlobStatuses(ctx context.Context, in *BlobStatusesRequest, opts ...grpc.CallOption) (*BlobStatusesReply, error) {
+	out := new(BlobStatusesReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBlobStatuses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *disperserClient) GetBlobCommitment(ctx context.Context, in *BlobCommitmentRequest, opts ...grpc.CallOption) (*BlobCommitmentReply, error) {
 	out := new(BlobCommitmentReply)
 	err := c.cc.Invoke(ctx, Disperser_GetBlobCommitment_FullMethodName, in, out, opts...)
@@ -86,6 +102,15 @@ func (c *disperserClient) GetPaymentState(ctx context.Context, in *GetPaymentSta
 	return out, nil
 }
 
+func (c *disperserClient) GetOnDemandBalance(ctx context.Context, in *GetOnDemandBalanceRequest, opts ...grpc.CallOption) (*GetOnDemandBalanceReply, error) {
+	out := new(GetOnDemandBalanceReply)
+	err := c.cc.Invoke(ctx, Disperser_GetOnDemandBalance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisperserServer is the server API for Disperser service.
 // All implementations must embed UnimplementedDisperserServer
 // for forward compatibility
@@ -97,10 +122,15 @@ type DisperserServer interface {
 	DisperseBlob(context.Context, *DisperseBlobRequest) (*DisperseBlobReply, error)
 	// GetBlobStatus is meant to be polled for the blob status.
 	GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error)
+	// GetBlobStatuses returns the statuses of multiple blobs in one call.
+	// It is meant to be polled by clients tracking many in-flight blobs, instead of calling GetBlobStatus for each of them.
+	GetBlobStatuses(context.Context, *BlobStatusesRequest) (*BlobStatusesReply, error)
 	// GetBlobCommitment is a utility method that calculates commitment for a blob payload.
 	GetBlobCommitment(context.Context, *BlobCommitmentRequest) (*BlobCommitmentReply, error)
 	// GetPaymentState is a utility method to get the payment state of a given account.
 	GetPaymentState(context.Context, *GetPaymentStateRequest) (*GetPaymentStateReply, error)
+	// GetOnDemandBalance is a utility method to get the remaining on-demand balance of a given account.
+	GetOnDemandBalance(context.Context, *GetOnDemandBalanceRequest) (*GetOnDemandBalanceReply, error)
 	mustEmbedUnimplementedDisperserServer()
 }
 
@@ -114,12 +144,18 @@ func (UnimplementedDisperserServer) DisperseBlob(context.Context, *DisperseBlobR
 func (UnimplementedDisperserServer) GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatus not implemented")
 }
+func (UnimplementedDisperserServer) GetBlobStatuses(context.Context, *BlobStatusesRequest) (*BlobStatusesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatuses not implemented")
+}
 func (UnimplementedDisperserServer) GetBlobCommitment(context.Context, *BlobCommitmentRequest) (*BlobCommitmentReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobCommitment not implemented")
 }
 func (UnimplementedDisperserServer) GetPaymentState(context.Context, *GetPaymentStateRequest) (*GetPaymentStateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentState not implemented")
 }
+func (UnimplementedDisperserServer) GetOnDemandBalance(context.Context, *GetOnDemandBalanceRequest) (*GetOnDemandBalanceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOnDemandBalance not implemented")
+}
 func (UnimplementedDisperserServer) mustEmbedUnimplementedDisperserServer() {}
 
 // UnsafeDisperserServer may be embedded to opt out of forward compatibility for this service.
@@ -169,6 +205,24 @@ func _Disperser_GetBlobStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_GetBlobStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBlobStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBlobStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBlobStatuses(ctx, req.(*BlobStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Disperser_GetBlobCommitment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlobCommitmentRequest)
 	if err := dec(in); err != nil {
@@ -205,6 +259,24 @@ func _Disperser_GetPaymentState_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_GetOnDemandBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOnDemandBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetOnDemandBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetOnDemandBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetOnDemandBalance(ctx, req.(*GetOnDemandBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Disperser_ServiceDesc is the grpc.ServiceDesc for Disperser service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -220,6 +292,10 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlobStatus",
 			Handler:    _Disperser_GetBlobStatus_Handler,
 		},
+		{
+			MethodName: "GetBlobStatuses",
+			Handler:    _Disperser_GetBlobStatuses_Handler,
+		},
 		{
 			MethodName: "GetBlobCommitment",
 			Handler:    _Disperser_GetBlobCommitment_Handler,
@@ -228,6 +304,10 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPaymentState",
 			Handler:    _Disperser_GetPaymentState_Handler,
 		},
+		{
+			MethodName: "GetOnDemandBalance",
+			Handler:    _Disperser_GetOnDemandBalance_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "disperser/v2/disperser_v2.proto",
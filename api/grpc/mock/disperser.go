@@ -3,6 +3,7 @@ package mock
 import (
 	"context"
 	"errors"
+	"io"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/disperser"
 
@@ -64,3 +65,55 @@ func (m *StreamMock) Close() {
 	close(m.sentFromServer)
 	m.closed = true
 }
+
+// BlobStreamMock is the server side of a DisperseBlobStream call, fed by SendFromClient. CloseSend ends the stream
+// like a client closing its side of it.
+type BlobStreamMock struct {
+	grpc.ServerStream
+	ctx            context.Context
+	recvToServer   chan *disperser.DisperseBlobStreamRequest
+	sentFromServer chan *disperser.DisperseBlobReply
+}
+
+func MakeBlobStreamMock(ctx context.Context) *BlobStreamMock {
+	return &BlobStreamMock{
+		ctx:            ctx,
+		recvToServer:   make(chan *disperser.DisperseBlobStreamRequest, 10),
+		sentFromServer: make(chan *disperser.DisperseBlobReply, 1),
+	}
+}
+
+func (m *BlobStreamMock) Context() context.Context {
+	return m.ctx
+}
+
+func (m *BlobStreamMock) SendAndClose(resp *disperser.DisperseBlobReply) error {
+	m.sentFromServer <- resp
+	return nil
+}
+
+func (m *BlobStreamMock) Recv() (*disperser.DisperseBlobStreamRequest, error) {
+	req, more := <-m.recvToServer
+	if !more {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+func (m *BlobStreamMock) SendFromClient(req *disperser.DisperseBlobStreamRequest) {
+	m.recvToServer <- req
+}
+
+func (m *BlobStreamMock) CloseSend() {
+	close(m.recvToServer)
+}
+
+// RecvToClient returns the reply sent by the server, or nil if it didn't send one
+func (m *BlobStreamMock) RecvToClient() *disperser.DisperseBlobReply {
+	select {
+	case resp := <-m.sentFromServer:
+		return resp
+	default:
+		return nil
+	}
+}
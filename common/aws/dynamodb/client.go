@@ -60,6 +60,7 @@ type Client interface {
 	QueryIndex(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) ([]Item, error)
 	Query(ctx context.Context, tableName string, keyCondition string, expAttributeValues ExpressionValues) ([]Item, error)
 	QueryWithInput(ctx context.Context, input *dynamodb.QueryInput) ([]Item, error)
+	QueryWithInputPagination(ctx context.Context, input *dynamodb.QueryInput) (QueryResult, error)
 	QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) (int32, error)
 	QueryIndexWithPagination(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues, limit int32, exclusiveStartKey map[string]types.AttributeValue) (QueryResult, error)
 	DeleteItem(ctx context.Context, tableName string, key Key) error
@@ -323,20 +324,43 @@ func (c *client) QueryWithInput(ctx context.Context, input *dynamodb.QueryInput)
 	return response.Items, nil
 }
 
-// QueryIndexCount returns the count of the items in the index that match the given key
-func (c *client) QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) (int32, error) {
-	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
-		TableName:                 aws.String(tableName),
-		IndexName:                 aws.String(indexName),
-		KeyConditionExpression:    aws.String(keyCondition),
-		ExpressionAttributeValues: expAttributeValues,
-		Select:                    types.SelectCount,
-	})
+// QueryWithInputPagination runs a single page of a custom query, returning the items and the pagination token.
+// With a filter expression, the page may have no items while the pagination token is set.
+func (c *client) QueryWithInputPagination(ctx context.Context, input *dynamodb.QueryInput) (QueryResult, error) {
+	response, err := c.dynamoClient.Query(ctx, input)
 	if err != nil {
-		return 0, err
+		return QueryResult{}, err
 	}
+	return QueryResult{
+		Items:            response.Items,
+		LastEvaluatedKey: response.LastEvaluatedKey,
+	}, nil
+}
+
+// QueryIndexCount returns the count of the items in the index that match the given key.
+// A single query only counts the items within 1MB of data, so the count follows the pages of the query.
+func (c *client) QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) (int32, error) {
+	var count int32
+	var exclusiveStartKey map[string]types.AttributeValue
+	for {
+		response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(tableName),
+			IndexName:                 aws.String(indexName),
+			KeyConditionExpression:    aws.String(keyCondition),
+			ExpressionAttributeValues: expAttributeValues,
+			Select:                    types.SelectCount,
+			ExclusiveStartKey:         exclusiveStartKey,
+		})
+		if err != nil {
+			return 0, err
+		}
 
-	return response.Count, nil
+		count += response.Count
+		if len(response.LastEvaluatedKey) == 0 {
+			return count, nil
+		}
+		exclusiveStartKey = response.LastEvaluatedKey
+	}
 }
 
 // QueryIndexWithPagination returns all items in the index that match the given key
@@ -38,6 +38,23 @@ func CreateTable(ctx context.Context, cfg commonaws.ClientConfig, name string, i
 	return table.TableDescription, nil
 }
 
+// EnableTimeToLive makes DynamoDB delete the items of the table once the time in the attribute, in unix seconds,
+// has passed.
+func EnableTimeToLive(ctx context.Context, cfg commonaws.ClientConfig, tableName string, attributeName string) error {
+	c, err := getClient(cfg)
+	if err != nil {
+		return err
+	}
+	_, err = c.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(tableName),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(attributeName),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	return err
+}
+
 func getClient(clientConfig commonaws.ClientConfig) (*dynamodb.Client, error) {
 	createClient := func(service, region string, options ...interface{}) (aws.Endpoint, error) {
 		if clientConfig.EndpointURL != "" {
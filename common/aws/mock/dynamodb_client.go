@@ -79,6 +79,11 @@ func (c *MockDynamoDBClient) QueryWithInput(ctx context.Context, input *awsdynam
 	return args.Get(0).([]dynamodb.Item), args.Error(1)
 }
 
+func (c *MockDynamoDBClient) QueryWithInputPagination(ctx context.Context, input *awsdynamodb.QueryInput) (dynamodb.QueryResult, error) {
+	args := c.Called()
+	return args.Get(0).(dynamodb.QueryResult), args.Error(1)
+}
+
 func (c *MockDynamoDBClient) QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues dynamodb.ExpressionValues) (int32, error) {
 	args := c.Called()
 	return args.Get(0).(int32), args.Error(1)
@@ -3,6 +3,8 @@ package mock
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
@@ -19,14 +21,17 @@ func NewS3Client() *S3Client {
 	return &S3Client{
 		bucket: make(map[string][]byte),
 		Called: map[string]int{
-			"DownloadObject":           0,
-			"HeadObject":               0,
-			"UploadObject":             0,
-			"DeleteObject":             0,
-			"ListObjects":              0,
-			"CreateBucket":             0,
-			"FragmentedUploadObject":   0,
-			"FragmentedDownloadObject": 0,
+			"DownloadObject":                0,
+			"DownloadObjectRange":           0,
+			"HeadObject":                    0,
+			"UploadObject":                  0,
+			"DeleteObject":                  0,
+			"ListObjects":                   0,
+			"CreateBucket":                  0,
+			"FragmentedUploadObject":        0,
+			"FragmentedUploadStream":        0,
+			"FragmentedDownloadObject":      0,
+			"FragmentedDownloadObjectRange": 0,
 		},
 	}
 }
@@ -40,6 +45,18 @@ func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string
 	return data, nil
 }
 
+func (s *S3Client) DownloadObjectRange(ctx context.Context, bucket string, key string, offset int, length int) ([]byte, error) {
+	s.Called["DownloadObjectRange"]++
+	data, ok := s.bucket[key]
+	if !ok {
+		return nil, s3.ErrObjectNotFound
+	}
+	if offset < 0 || length <= 0 || offset+length > len(data) {
+		return nil, fmt.Errorf("range [%d, %d) is out of the bounds of an object of %d bytes", offset, offset+length, len(data))
+	}
+	return data[offset : offset+length], nil
+}
+
 func (s *S3Client) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
 	s.Called["HeadObject"]++
 	data, ok := s.bucket[key]
@@ -95,6 +112,29 @@ func (s *S3Client) FragmentedUploadObject(
 	return nil
 }
 
+func (s *S3Client) FragmentedUploadStream(
+	ctx context.Context,
+	bucket string,
+	key string,
+	reader io.Reader,
+	fileSize int,
+	fragmentSize int) error {
+	s.Called["FragmentedUploadStream"]++
+	data := make([]byte, fileSize)
+	_, err := io.ReadFull(reader, data)
+	if err != nil {
+		return fmt.Errorf("failed to read data: %w", err)
+	}
+	fragments, err := s3.BreakIntoFragments(key, data, fragmentSize)
+	if err != nil {
+		return err
+	}
+	for _, fragment := range fragments {
+		s.bucket[fragment.FragmentKey] = fragment.Data
+	}
+	return nil
+}
+
 func (s *S3Client) FragmentedDownloadObject(
 	ctx context.Context,
 	bucket string,
@@ -102,6 +142,11 @@ func (s *S3Client) FragmentedDownloadObject(
 	fileSize int,
 	fragmentSize int) ([]byte, error) {
 	s.Called["FragmentedDownloadObject"]++
+	return s.fragmentedDownload(key, fileSize, fragmentSize)
+}
+
+// fragmentedDownload reassembles the fragments of a file
+func (s *S3Client) fragmentedDownload(key string, fileSize int, fragmentSize int) ([]byte, error) {
 	if fileSize <= 0 {
 		return nil, errors.New("fileSize must be greater than 0")
 	}
@@ -132,3 +177,22 @@ func (s *S3Client) FragmentedDownloadObject(
 	}
 	return data, nil
 }
+
+func (s *S3Client) FragmentedDownloadObjectRange(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int,
+	offset int,
+	length int) ([]byte, error) {
+	s.Called["FragmentedDownloadObjectRange"]++
+	data, err := s.fragmentedDownload(key, fileSize, fragmentSize)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 || length <= 0 || offset+length > len(data) {
+		return nil, fmt.Errorf("range [%d, %d) is out of the bounds of a file of %d bytes", offset, offset+length, len(data))
+	}
+	return data[offset : offset+length], nil
+}
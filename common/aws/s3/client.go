@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"runtime"
 	"sync"
 
@@ -138,6 +140,40 @@ func (s *client) DownloadObject(ctx context.Context, bucket string, key string)
 	return buffer.Bytes(), nil
 }
 
+func (s *client) DownloadObjectRange(ctx context.Context, bucket string, key string, offset int, length int) ([]byte, error) {
+	if offset < 0 || length <= 0 {
+		return nil, fmt.Errorf("invalid range: offset %d, length %d", offset, length)
+	}
+	return s.getObjectRange(ctx, bucket, key, offset, length)
+}
+
+// getObjectRange downloads length bytes at the given offset of an object
+func (s *client) getObjectRange(ctx context.Context, bucket string, key string, offset int, length int) ([]byte, error) {
+	ret, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = ret.Body.Close()
+	}()
+
+	// S3 returns the bytes up to the end of the object if the range goes past it
+	data := make([]byte, length)
+	_, err = io.ReadFull(ret.Body, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read range [%d, %d) of %s: %w", offset, offset+length, key, err)
+	}
+	return data, nil
+}
+
 func (s *client) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
 	output, err := s.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(bucket),
@@ -253,6 +289,60 @@ func (s *client) FragmentedUploadObject(
 	return ctx.Err()
 }
 
+func (s *client) FragmentedUploadStream(
+	ctx context.Context,
+	bucket string,
+	key string,
+	reader io.Reader,
+	fileSize int,
+	fragmentSize int) error {
+	if fileSize <= 0 {
+		return errors.New("fileSize must be greater than 0")
+	}
+
+	if fragmentSize <= 0 {
+		return errors.New("fragmentSize must be greater than 0")
+	}
+
+	fragmentKeys, err := GetFragmentKeys(key, getFragmentCount(fileSize, fragmentSize))
+	if err != nil {
+		return err
+	}
+	resultChannel := make(chan error, len(fragmentKeys))
+
+	for i, fragmentKey := range fragmentKeys {
+		// the fragment is only read once a worker is available to upload it
+		s.concurrencyLimiter <- struct{}{}
+
+		data := make([]byte, min(fragmentSize, fileSize-i*fragmentSize))
+		_, err = io.ReadFull(reader, data)
+		if err != nil {
+			<-s.concurrencyLimiter
+			return fmt.Errorf("failed to read fragment %d: %w", i, err)
+		}
+
+		fragment := &Fragment{
+			FragmentKey: fragmentKey,
+			Data:        data,
+			Index:       i,
+		}
+		go func() {
+			defer func() {
+				<-s.concurrencyLimiter
+			}()
+			s.fragmentedWriteTask(ctx, resultChannel, fragment, bucket)
+		}()
+	}
+
+	for range fragmentKeys {
+		err = <-resultChannel
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
 // fragmentedWriteTask writes a single file to S3.
 func (s *client) fragmentedWriteTask(
 	ctx context.Context,
@@ -319,6 +409,59 @@ func (s *client) FragmentedDownloadObject(
 
 }
 
+func (s *client) FragmentedDownloadObjectRange(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int,
+	offset int,
+	length int) ([]byte, error) {
+	if fileSize <= 0 {
+		return nil, errors.New("fileSize must be greater than 0")
+	}
+
+	if fragmentSize <= 0 {
+		return nil, errors.New("fragmentSize must be greater than 0")
+	}
+
+	ranges, err := getFragmentRanges(fileSize, fragmentSize, offset, length)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, length)
+	pool, poolCtx := errgroup.WithContext(ctx)
+	position := 0
+	for _, fragmentRange := range ranges {
+		fragmentKey, err := getFragmentKey(key, getFragmentCount(fileSize, fragmentSize), fragmentRange.index)
+		if err != nil {
+			return nil, err
+		}
+		fragmentRange := fragmentRange
+		target := data[position : position+fragmentRange.length]
+		position += fragmentRange.length
+
+		s.concurrencyLimiter <- struct{}{}
+		pool.Go(func() error {
+			defer func() {
+				<-s.concurrencyLimiter
+			}()
+			fragmentData, err := s.getObjectRange(poolCtx, bucket, fragmentKey, fragmentRange.offset, fragmentRange.length)
+			if err != nil {
+				return err
+			}
+			copy(target, fragmentData)
+			return nil
+		})
+	}
+
+	if err = pool.Wait(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
 // readResult is the result of a read task.
 type readResult struct {
 	fragment *Fragment
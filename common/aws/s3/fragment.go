@@ -39,6 +39,38 @@ func getFragmentKey(fileKey string, fragmentCount int, index int) (string, error
 	return fmt.Sprintf("%s-%d%s", fileKey, index, postfix), nil
 }
 
+// fragmentRange is a range of bytes of a fragment
+type fragmentRange struct {
+	// index is the index of the fragment
+	index int
+	// offset is the offset of the range in the fragment
+	offset int
+	// length is the length of the range
+	length int
+}
+
+// getFragmentRanges returns the ranges of the fragments that hold the given range of a file, in the order of the
+// fragments.
+func getFragmentRanges(fileSize int, fragmentSize int, offset int, length int) ([]fragmentRange, error) {
+	if offset < 0 || length <= 0 || offset+length > fileSize {
+		return nil, fmt.Errorf("range [%d, %d) is out of the bounds of a file of %d bytes", offset, offset+length, fileSize)
+	}
+
+	ranges := make([]fragmentRange, 0, (length+fragmentSize-1)/fragmentSize+1)
+	for position := offset; position < offset+length; {
+		index := position / fragmentSize
+		fragmentOffset := position - index*fragmentSize
+		fragmentLength := min(fragmentSize-fragmentOffset, offset+length-position)
+		ranges = append(ranges, fragmentRange{
+			index:  index,
+			offset: fragmentOffset,
+			length: fragmentLength,
+		})
+		position += fragmentLength
+	}
+	return ranges, nil
+}
+
 // Fragment is a subset of a file.
 type Fragment struct {
 	FragmentKey string
@@ -331,3 +331,38 @@ func TestSortAndCheckAllFragmentsExist(t *testing.T) {
 	}
 	require.False(t, SortAndCheckAllFragmentsExist(keys))
 }
+
+func TestGetFragmentRanges(t *testing.T) {
+	tu.InitializeRandom()
+
+	fileSize := rand.Intn(1000) + 1000
+	fragmentSize := rand.Intn(100) + 10
+	data := tu.RandomBytes(fileSize)
+	fragments, err := BreakIntoFragments("abc", data, fragmentSize)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		offset := rand.Intn(fileSize)
+		length := rand.Intn(fileSize-offset) + 1
+
+		ranges, err := getFragmentRanges(fileSize, fragmentSize, offset, length)
+		require.NoError(t, err)
+
+		result := make([]byte, 0, length)
+		for _, r := range ranges {
+			result = append(result, fragments[r.index].Data[r.offset:r.offset+r.length]...)
+		}
+		require.Equal(t, data[offset:offset+length], result)
+	}
+
+	ranges, err := getFragmentRanges(100, 10, 15, 10)
+	require.NoError(t, err)
+	require.Equal(t, []fragmentRange{{index: 1, offset: 5, length: 5}, {index: 2, offset: 0, length: 5}}, ranges)
+
+	_, err = getFragmentRanges(100, 10, 95, 10)
+	require.Error(t, err)
+	_, err = getFragmentRanges(100, 10, -1, 10)
+	require.Error(t, err)
+	_, err = getFragmentRanges(100, 10, 0, 0)
+	require.Error(t, err)
+}
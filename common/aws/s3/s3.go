@@ -1,6 +1,9 @@
 package s3
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // Client encapsulates the functionality of an S3 client.
 type Client interface {
@@ -8,6 +11,9 @@ type Client interface {
 	// DownloadObject downloads an object from S3.
 	DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error)
 
+	// DownloadObjectRange downloads length bytes at the given offset of an object from S3.
+	DownloadObjectRange(ctx context.Context, bucket string, key string, offset int, length int) ([]byte, error)
+
 	// HeadObject retrieves the size of an object in S3. Returns error if the object does not exist.
 	HeadObject(ctx context.Context, bucket string, key string) (*int64, error)
 
@@ -42,6 +48,19 @@ type Client interface {
 		data []byte,
 		fragmentSize int) error
 
+	// FragmentedUploadStream uploads a file of fileSize bytes read from a reader to S3, in the same fragments as
+	// FragmentedUploadObject. A fragment is only read once it can be uploaded, so that only the fragments being
+	// uploaded are held in memory, rather than the whole file.
+	//
+	// The notes of FragmentedUploadObject apply to this method as well.
+	FragmentedUploadStream(
+		ctx context.Context,
+		bucket string,
+		key string,
+		reader io.Reader,
+		fileSize int,
+		fragmentSize int) error
+
 	// FragmentedDownloadObject downloads a file from S3, as written by Upload. The fileSize (in bytes) and fragmentSize
 	// must be the same as the values used in the FragmentedUploadObject call.
 	//
@@ -52,4 +71,16 @@ type Client interface {
 		key string,
 		fileSize int,
 		fragmentSize int) ([]byte, error)
+
+	// FragmentedDownloadObjectRange downloads length bytes at the given offset of a file uploaded with
+	// FragmentedUploadObject or FragmentedUploadStream. Only the bytes of the range are downloaded, from the fragments
+	// that hold them. The fileSize and fragmentSize must be the same as the values used to upload the file.
+	FragmentedDownloadObjectRange(
+		ctx context.Context,
+		bucket string,
+		key string,
+		fileSize int,
+		fragmentSize int,
+		offset int,
+		length int) ([]byte, error)
 }
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"bytes"
 	"context"
 	"math"
 	"math/rand"
@@ -216,3 +217,37 @@ func TestHeadObject(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestFragmentedUploadStream(t *testing.T) {
+	tu.InitializeRandom()
+	for _, builder := range clientBuilders {
+		err := builder.start()
+		assert.NoError(t, err)
+
+		client, err := builder.build()
+		assert.NoError(t, err)
+
+		fragmentSize := rand.Intn(1000) + 1000
+		for _, size := range []int{1, fragmentSize, 3*fragmentSize + 1} {
+			key := tu.RandomString(10)
+			data := tu.RandomBytes(size)
+
+			err = client.FragmentedUploadStream(
+				context.Background(), bucket, key, bytes.NewReader(data), len(data), fragmentSize)
+			assert.NoError(t, err)
+
+			downloaded, err := client.FragmentedDownloadObject(
+				context.Background(), bucket, key, len(data), fragmentSize)
+			assert.NoError(t, err)
+			assert.Equal(t, data, downloaded)
+		}
+
+		// the reader holds less data than the file size
+		err = client.FragmentedUploadStream(
+			context.Background(), bucket, tu.RandomString(10), bytes.NewReader(make([]byte, 10)), 20, fragmentSize)
+		assert.Error(t, err)
+
+		err = builder.finish()
+		assert.NoError(t, err)
+	}
+}
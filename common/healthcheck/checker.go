@@ -0,0 +1,146 @@
+package healthcheck
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// DefaultCheckInterval is how often the subsystem checks are run by default
+	DefaultCheckInterval = 10 * time.Second
+	// DefaultCheckTimeout is the default timeout of a single subsystem check
+	DefaultCheckTimeout = 5 * time.Second
+)
+
+// Check checks the health of a subsystem, returning an error describing the problem if it is unhealthy.
+type Check func(ctx context.Context) error
+
+// HealthChecker serves the standard grpc.health.v1 service, reporting the health of the subsystems a server depends
+// on (e.g. its store or chain RPC) as well as its overall health.
+//
+// For every service name registered with Register, the checker reports:
+//   - "<name>/<subsystem>" for each subsystem, which is SERVING if the last check of the subsystem passed
+//   - "<name>", which is SERVING if the last checks of all subsystems passed
+//
+// The empty service name reports the overall health of all registered services, as grpc_health_probe checks by
+// default. All services report NOT_SERVING once the checker is stopped.
+type HealthChecker struct {
+	checks   map[string]Check
+	interval time.Duration
+	timeout  time.Duration
+	logger   logging.Logger
+
+	server *health.Server
+
+	mu       sync.Mutex
+	names    []string
+	failures map[string]error
+}
+
+// NewHealthChecker creates a HealthChecker running the given checks, keyed by subsystem name, every interval.
+func NewHealthChecker(checks map[string]Check, interval time.Duration, timeout time.Duration, logger logging.Logger) *HealthChecker {
+	return &HealthChecker{
+		checks:   checks,
+		interval: interval,
+		timeout:  timeout,
+		logger:   logger.With("component", "HealthChecker"),
+		server:   health.NewServer(),
+		failures: make(map[string]error),
+	}
+}
+
+// Register registers the health service with the given gRPC server, reporting the health of the subsystems under
+// the given service name. The same checker can be registered with several servers.
+func (c *HealthChecker) Register(name string, server *grpc.Server) {
+	grpc_health_v1.RegisterHealthServer(server, c.server)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !slices.Contains(c.names, name) {
+		c.names = append(c.names, name)
+	}
+	c.updateStatuses()
+}
+
+// Start runs the checks, then keeps running them every interval until the context is cancelled, after which all
+// services report NOT_SERVING.
+func (c *HealthChecker) Start(ctx context.Context) {
+	c.RunChecks(ctx)
+
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.RunChecks(ctx)
+			case <-ctx.Done():
+				c.server.Shutdown()
+				return
+			}
+		}
+	}()
+}
+
+// RunChecks runs all the subsystem checks concurrently and updates the reported statuses with their results.
+func (c *HealthChecker) RunChecks(ctx context.Context) {
+	failures := make(map[string]error)
+	var failuresMu sync.Mutex
+	var wg sync.WaitGroup
+	for subsystem, check := range c.checks {
+		wg.Add(1)
+		go func(subsystem string, check Check) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			if err := check(checkCtx); err != nil {
+				failuresMu.Lock()
+				failures[subsystem] = err
+				failuresMu.Unlock()
+			}
+		}(subsystem, check)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for subsystem := range c.checks {
+		_, failedBefore := c.failures[subsystem]
+		err, failed := failures[subsystem]
+		if failed && !failedBefore {
+			c.logger.Warn("subsystem became unhealthy", "subsystem", subsystem, "err", err)
+		} else if !failed && failedBefore {
+			c.logger.Info("subsystem became healthy", "subsystem", subsystem)
+		}
+	}
+	c.failures = failures
+	c.updateStatuses()
+}
+
+// updateStatuses sets the statuses of all services from the last check results. It must be called with the lock held.
+func (c *HealthChecker) updateStatuses() {
+	overall := toServingStatus(len(c.failures) == 0)
+	for _, name := range c.names {
+		for subsystem := range c.checks {
+			_, failed := c.failures[subsystem]
+			c.server.SetServingStatus(name+"/"+subsystem, toServingStatus(!failed))
+		}
+		c.server.SetServingStatus(name, overall)
+	}
+	c.server.SetServingStatus("", overall)
+}
+
+func toServingStatus(healthy bool) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if healthy {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
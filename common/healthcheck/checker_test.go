@@ -0,0 +1,100 @@
+package healthcheck_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthChecker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var chainErr atomic.Pointer[error]
+	checker := healthcheck.NewHealthChecker(map[string]healthcheck.Check{
+		"store": func(context.Context) error { return nil },
+		"chain": func(context.Context) error {
+			if err := chainErr.Load(); err != nil {
+				return *err
+			}
+			return nil
+		},
+	}, time.Hour, time.Second, logging.NewNoopLogger())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	gs := grpc.NewServer()
+	checker.Register("test.Service", gs)
+	go func() { _ = gs.Serve(listener) }()
+	defer gs.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+	requireStatus := func(service string, expected grpc_health_v1.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		reply, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, expected, reply.GetStatus(), service)
+	}
+
+	checker.Start(ctx)
+	requireStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	requireStatus("test.Service", grpc_health_v1.HealthCheckResponse_SERVING)
+	requireStatus("test.Service/store", grpc_health_v1.HealthCheckResponse_SERVING)
+	requireStatus("test.Service/chain", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// a failing subsystem makes the service unhealthy
+	failure := errors.New("rpc unreachable")
+	chainErr.Store(&failure)
+	checker.RunChecks(ctx)
+	requireStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	requireStatus("test.Service", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	requireStatus("test.Service/store", grpc_health_v1.HealthCheckResponse_SERVING)
+	requireStatus("test.Service/chain", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	// and recovers with it
+	chainErr.Store(nil)
+	checker.RunChecks(ctx)
+	requireStatus("test.Service", grpc_health_v1.HealthCheckResponse_SERVING)
+	requireStatus("test.Service/chain", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// unknown subsystems are not found
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "test.Service/encoder"})
+	assert.Error(t, err)
+
+	// stopping the checker reports all services as not serving
+	cancel()
+	require.Eventually(t, func() bool {
+		reply, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "test.Service"})
+		return err == nil && reply.GetStatus() == grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHealthCheckerTimeout(t *testing.T) {
+	ctx := context.Background()
+	checker := healthcheck.NewHealthChecker(map[string]healthcheck.Check{
+		"slow": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}, time.Hour, 10*time.Millisecond, logging.NewNoopLogger())
+	gs := grpc.NewServer()
+	checker.Register("test.Service", gs)
+
+	start := time.Now()
+	checker.RunChecks(ctx)
+	assert.Less(t, time.Since(start), time.Second)
+}
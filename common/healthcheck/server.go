@@ -1,9 +1,12 @@
 package healthcheck
 
 import (
+	"context"
+
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 )
 
 // RegisterHealthServer registers the default gRPC health check server implementation
@@ -13,3 +16,15 @@ func RegisterHealthServer(name string, server *grpc.Server) {
 	healthServer.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_SERVING)
 	grpc_health_v1.RegisterHealthServer(server, healthServer)
 }
+
+// VersionHeader is the gRPC response header in which a server reports its version
+const VersionHeader = "server-version"
+
+// VersionUnaryInterceptor returns an interceptor that reports the version of the server in the VersionHeader of
+// every unary response, including health checks, so that the version of a server can be probed without credentials.
+func VersionUnaryInterceptor(version string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(VersionHeader, version))
+		return handler(ctx, req)
+	}
+}
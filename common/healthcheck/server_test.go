@@ -0,0 +1,37 @@
+package healthcheck_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestVersionUnaryInterceptor(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(healthcheck.VersionUnaryInterceptor("v1.2.3")))
+	healthcheck.RegisterHealthServer("test", server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var header metadata.MD
+	response, err := grpc_health_v1.NewHealthClient(conn).Check(
+		context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, response.Status)
+	assert.Equal(t, []string{"v1.2.3"}, header.Get(healthcheck.VersionHeader))
+}
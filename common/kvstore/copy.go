@@ -0,0 +1,50 @@
+package kvstore
+
+import "fmt"
+
+// CopyStore copies all key-value pairs of the source store into the destination store, writing them in batches of
+// batchSize pairs. Returns the number of pairs copied. It can be used to migrate the data of a store to a store
+// with a different backend. The source store should not be modified while it is being copied.
+func CopyStore(source Store[[]byte], destination Store[[]byte], batchSize uint32) (int, error) {
+	if batchSize == 0 {
+		return 0, fmt.Errorf("batch size must be positive")
+	}
+
+	it, err := source.NewIterator(nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate over source store: %w", err)
+	}
+	defer it.Release()
+
+	count := 0
+	batch := destination.NewBatch()
+	for it.Next() {
+		// The key and value of the iterator may be reused when it is advanced.
+		key := make([]byte, len(it.Key()))
+		copy(key, it.Key())
+		value := make([]byte, len(it.Value()))
+		copy(value, it.Value())
+		batch.Put(key, value)
+
+		if batch.Size() >= batchSize {
+			err = batch.Apply()
+			if err != nil {
+				return count, fmt.Errorf("failed to write batch to destination store: %w", err)
+			}
+			count += int(batch.Size())
+			batch = destination.NewBatch()
+		}
+	}
+	if err = it.Error(); err != nil {
+		return count, fmt.Errorf("failed to iterate over source store: %w", err)
+	}
+
+	if batch.Size() > 0 {
+		err = batch.Apply()
+		if err != nil {
+			return count, fmt.Errorf("failed to write batch to destination store: %w", err)
+		}
+		count += int(batch.Size())
+	}
+	return count, nil
+}
@@ -13,6 +13,7 @@ import (
 )
 
 var _ kvstore.Store[[]byte] = &levelDBStore{}
+var _ kvstore.Compactor = &levelDBStore{}
 
 // levelDBStore implements kvstore.Store interfaces with levelDB as the backend engine.
 type levelDBStore struct {
@@ -120,6 +121,21 @@ func (m *levelDBBatch) Size() uint32 {
 	return uint32(m.batch.Len())
 }
 
+// Compact compacts the whole key range of the database, discarding deleted and overwritten data from disk.
+func (store *levelDBStore) Compact() error {
+	return store.db.CompactRange(util.Range{})
+}
+
+// DiskSize returns the total size of the tables of the database on disk.
+func (store *levelDBStore) DiskSize() (uint64, error) {
+	stats := &leveldb.DBStats{}
+	err := store.db.Stats(stats)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(stats.LevelSizes.Sum()), nil
+}
+
 // Shutdown shuts down the store.
 //
 // Warning: it is not thread safe to call this method concurrently with other methods on this class,
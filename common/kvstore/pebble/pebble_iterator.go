@@ -0,0 +1,124 @@
+package pebble
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var _ iterator.Iterator = &pebbleIterator{}
+
+// pebbleIterator adapts a Pebble iterator to the LevelDB iterator interface returned by kvstore.Store.
+//
+// A LevelDB iterator starts positioned before the first key, so the first call to Next() moves it to the first key
+// (and the first call to Prev() to the last key), whereas a Pebble iterator must be positioned explicitly.
+type pebbleIterator struct {
+	it       *pebble.Iterator
+	started  bool
+	released bool
+	releaser util.Releaser
+	err      error
+
+	// onRelease is called when the iterator is released
+	onRelease func(*pebbleIterator)
+}
+
+func newPebbleIterator(it *pebble.Iterator, onRelease func(*pebbleIterator)) *pebbleIterator {
+	return &pebbleIterator{
+		it:        it,
+		onRelease: onRelease,
+	}
+}
+
+func (p *pebbleIterator) First() bool {
+	if p.released {
+		return false
+	}
+	p.started = true
+	return p.it.First()
+}
+
+func (p *pebbleIterator) Last() bool {
+	if p.released {
+		return false
+	}
+	p.started = true
+	return p.it.Last()
+}
+
+func (p *pebbleIterator) Seek(key []byte) bool {
+	if p.released {
+		return false
+	}
+	p.started = true
+	return p.it.SeekGE(key)
+}
+
+func (p *pebbleIterator) Next() bool {
+	if p.released {
+		return false
+	}
+	if !p.started {
+		return p.First()
+	}
+	return p.it.Next()
+}
+
+func (p *pebbleIterator) Prev() bool {
+	if p.released {
+		return false
+	}
+	if !p.started {
+		return p.Last()
+	}
+	return p.it.Prev()
+}
+
+func (p *pebbleIterator) Valid() bool {
+	if p.released {
+		return false
+	}
+	return p.it.Valid()
+}
+
+func (p *pebbleIterator) Key() []byte {
+	if !p.Valid() {
+		return nil
+	}
+	return p.it.Key()
+}
+
+func (p *pebbleIterator) Value() []byte {
+	if !p.Valid() {
+		return nil
+	}
+	return p.it.Value()
+}
+
+func (p *pebbleIterator) Error() error {
+	if p.released {
+		return p.err
+	}
+	return p.it.Error()
+}
+
+// Release releases the underlying Pebble iterator. It is safe to call Release more than once.
+func (p *pebbleIterator) Release() {
+	if p.released {
+		return
+	}
+	p.released = true
+	p.err = p.it.Close()
+	p.onRelease(p)
+	if p.releaser != nil {
+		p.releaser.Release()
+		p.releaser = nil
+	}
+}
+
+func (p *pebbleIterator) SetReleaser(releaser util.Releaser) {
+	if p.released {
+		panic(util.ErrReleased)
+	}
+	p.releaser = releaser
+}
@@ -0,0 +1,233 @@
+package pebble
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var _ kvstore.Store[[]byte] = &pebbleStore{}
+var _ kvstore.Compactor = &pebbleStore{}
+
+// pebbleStore implements kvstore.Store interfaces with Pebble as the backend engine. Unlike LevelDB, Pebble
+// throttles the compactions of bursts of writes instead of stalling the writes while it catches up on them.
+type pebbleStore struct {
+	db   *pebble.DB
+	path string
+
+	logger logging.Logger
+
+	// the iterators that have not been released yet, which are released when the store shuts down
+	iterators     map[*pebbleIterator]struct{}
+	iteratorsLock sync.Mutex
+
+	shutdown bool
+}
+
+// NewStore returns a new pebbleStore built using Pebble.
+func NewStore(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pebbleStore{
+		db:        db,
+		path:      path,
+		logger:    logger,
+		iterators: make(map[*pebbleIterator]struct{}),
+	}, nil
+}
+
+// Put stores a data in the store.
+func (store *pebbleStore) Put(key []byte, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	return store.db.Set(key, value, pebble.NoSync)
+}
+
+// Get retrieves data from the store. Returns kvstore.ErrNotFound if the data is not found.
+func (store *pebbleStore) Get(key []byte) ([]byte, error) {
+	data, closer, err := store.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, kvstore.ErrNotFound
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+
+	// The returned data is only valid until the closer is closed.
+	value := make([]byte, len(data))
+	copy(value, data)
+	return value, nil
+}
+
+// NewIterator creates a new iterator. Only keys prefixed with the given prefix will be iterated.
+func (store *pebbleStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
+	keyRange := util.BytesPrefix(prefix)
+	it, err := store.db.NewIter(&pebble.IterOptions{
+		LowerBound: keyRange.Start,
+		UpperBound: keyRange.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	store.iteratorsLock.Lock()
+	defer store.iteratorsLock.Unlock()
+	pebbleIt := newPebbleIterator(it, store.releaseIterator)
+	store.iterators[pebbleIt] = struct{}{}
+	return pebbleIt, nil
+}
+
+// releaseIterator stops tracking an iterator once it has been released.
+func (store *pebbleStore) releaseIterator(it *pebbleIterator) {
+	store.iteratorsLock.Lock()
+	defer store.iteratorsLock.Unlock()
+	delete(store.iterators, it)
+}
+
+// Delete deletes data from the store.
+func (store *pebbleStore) Delete(key []byte) error {
+	return store.db.Delete(key, pebble.NoSync)
+}
+
+// NewBatch creates a new batch for the store.
+func (store *pebbleStore) NewBatch() kvstore.Batch[[]byte] {
+	return &pebbleBatch{
+		store: store,
+		batch: store.db.NewBatch(),
+	}
+}
+
+type pebbleBatch struct {
+	store *pebbleStore
+	batch *pebble.Batch
+}
+
+func (m *pebbleBatch) Put(key []byte, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	// Setting a key of a batch that has not been committed never fails.
+	_ = m.batch.Set(key, value, nil)
+}
+
+func (m *pebbleBatch) Delete(key []byte) {
+	_ = m.batch.Delete(key, nil)
+}
+
+// Apply applies the operations of the batch to the store. A committed Pebble batch can't be modified, so a copy of
+// the batch is committed instead, which keeps the batch usable afterward like a LevelDB batch.
+func (m *pebbleBatch) Apply() error {
+	batch := m.store.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	err := batch.Apply(m.batch, nil)
+	if err != nil {
+		return err
+	}
+	return batch.Commit(pebble.NoSync)
+}
+
+// Size returns the number of operations in the batch.
+func (m *pebbleBatch) Size() uint32 {
+	return m.batch.Count()
+}
+
+// Compact compacts the whole key range of the database, discarding deleted and overwritten data from disk.
+func (store *pebbleStore) Compact() error {
+	// Flush the memtable first, so that its keys are covered by the range of the tables on disk.
+	err := store.db.Flush()
+	if err != nil {
+		return err
+	}
+
+	levels, err := store.db.SSTables()
+	if err != nil {
+		return err
+	}
+	var start, end []byte
+	for _, tables := range levels {
+		for _, table := range tables {
+			if start == nil || bytes.Compare(table.Smallest.UserKey, start) < 0 {
+				start = table.Smallest.UserKey
+			}
+			if end == nil || bytes.Compare(table.Largest.UserKey, end) > 0 {
+				end = table.Largest.UserKey
+			}
+		}
+	}
+	if start == nil {
+		// The database is empty.
+		return nil
+	}
+
+	// The end of the compacted range is exclusive, and the smallest key after the largest key is that key with
+	// a zero byte appended.
+	end = append(append([]byte{}, end...), 0)
+	return store.db.Compact(start, end, true)
+}
+
+// DiskSize returns the total size of the tables of the database on disk.
+func (store *pebbleStore) DiskSize() (uint64, error) {
+	return uint64(store.db.Metrics().Total().Size), nil
+}
+
+// Shutdown shuts down the store. Pebble refuses to close while there are open iterators, so any iterator that
+// has not been released yet is released first.
+//
+// Warning: it is not thread safe to call this method concurrently with other methods on this class,
+// or while there exist unclosed iterators.
+func (store *pebbleStore) Shutdown() error {
+	store.iteratorsLock.Lock()
+	iterators := make([]*pebbleIterator, 0, len(store.iterators))
+	for it := range store.iterators {
+		iterators = append(iterators, it)
+	}
+	store.iteratorsLock.Unlock()
+	for _, it := range iterators {
+		it.Release()
+	}
+
+	err := store.db.Close()
+	if err != nil {
+		return err
+	}
+
+	store.shutdown = true
+	return nil
+}
+
+// Destroy destroys the store.
+//
+// Warning: it is not thread safe to call this method concurrently with other methods on this class,
+// or while there exist unclosed iterators.
+func (store *pebbleStore) Destroy() error {
+	if !store.shutdown {
+		err := store.Shutdown()
+		if err != nil {
+			return err
+		}
+	}
+
+	store.logger.Info(fmt.Sprintf("destroying Pebble store at path: %s", store.path))
+	err := os.RemoveAll(store.path)
+	if err != nil {
+		return err
+	}
+	return nil
+}
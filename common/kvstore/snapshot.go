@@ -0,0 +1,160 @@
+package kvstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// snapshotMagic starts every store snapshot, and identifies the version of the snapshot format.
+var snapshotMagic = []byte("EIGENDA-KV-SNAPSHOT-1")
+
+// The tags that precede each entry of a store snapshot.
+const (
+	snapshotEndTag    = byte(0)
+	snapshotRecordTag = byte(1)
+)
+
+// maxSnapshotFieldLength bounds the length of the keys and values read from a snapshot, so that a corrupted
+// snapshot can't make the import allocate unbounded memory.
+const maxSnapshotFieldLength = 1 << 30
+
+// ExportStore writes all key-value pairs of the store to the writer, and returns the number of pairs written.
+// The pairs are read from a single iterator, so the snapshot is consistent even if the store is being written to.
+//
+// The snapshot is made of the snapshot magic, followed by one record per key-value pair (a record tag, then the
+// uvarint length and bytes of the key and of the value), and terminated by an end tag followed by the uvarint
+// number of records. The trailer lets ImportStore detect truncated snapshots.
+func ExportStore(store Store[[]byte], w io.Writer) (int, error) {
+	it, err := store.NewIterator(nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate over store: %w", err)
+	}
+	defer it.Release()
+
+	if _, err = w.Write(snapshotMagic); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	count := 0
+	for it.Next() {
+		if _, err = w.Write([]byte{snapshotRecordTag}); err != nil {
+			return count, fmt.Errorf("failed to write snapshot record: %w", err)
+		}
+		if err = writeSnapshotField(w, it.Key()); err != nil {
+			return count, fmt.Errorf("failed to write snapshot record: %w", err)
+		}
+		if err = writeSnapshotField(w, it.Value()); err != nil {
+			return count, fmt.Errorf("failed to write snapshot record: %w", err)
+		}
+		count++
+	}
+	if err = it.Error(); err != nil {
+		return count, fmt.Errorf("failed to iterate over store: %w", err)
+	}
+
+	trailer := binary.AppendUvarint([]byte{snapshotEndTag}, uint64(count))
+	if _, err = w.Write(trailer); err != nil {
+		return count, fmt.Errorf("failed to write snapshot trailer: %w", err)
+	}
+	return count, nil
+}
+
+// ImportStore reads a snapshot written by ExportStore and writes its key-value pairs into the store, in batches of
+// batchSize pairs. Returns the number of pairs imported. The reader is not read past the end of the snapshot, so
+// several snapshots can be read from the same reader.
+func ImportStore(r *bufio.Reader, store Store[[]byte], batchSize uint32) (int, error) {
+	if batchSize == 0 {
+		return 0, fmt.Errorf("batch size must be positive")
+	}
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return 0, fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	if !bytes.Equal(magic, snapshotMagic) {
+		return 0, fmt.Errorf("invalid snapshot header %q", magic)
+	}
+
+	count := 0
+	batch := store.NewBatch()
+	for {
+		tag, err := r.ReadByte()
+		if err != nil {
+			return count, fmt.Errorf("failed to read snapshot record: %w", unexpectedEOF(err))
+		}
+		if tag == snapshotEndTag {
+			break
+		}
+		if tag != snapshotRecordTag {
+			return count, fmt.Errorf("invalid snapshot record tag %d", tag)
+		}
+
+		key, err := readSnapshotField(r)
+		if err != nil {
+			return count, fmt.Errorf("failed to read snapshot record: %w", err)
+		}
+		value, err := readSnapshotField(r)
+		if err != nil {
+			return count, fmt.Errorf("failed to read snapshot record: %w", err)
+		}
+		batch.Put(key, value)
+
+		if batch.Size() >= batchSize {
+			if err = batch.Apply(); err != nil {
+				return count, fmt.Errorf("failed to write batch to store: %w", err)
+			}
+			count += int(batch.Size())
+			batch = store.NewBatch()
+		}
+	}
+
+	if batch.Size() > 0 {
+		if err := batch.Apply(); err != nil {
+			return count, fmt.Errorf("failed to write batch to store: %w", err)
+		}
+		count += int(batch.Size())
+	}
+
+	expectedCount, err := binary.ReadUvarint(r)
+	if err != nil {
+		return count, fmt.Errorf("failed to read snapshot trailer: %w", unexpectedEOF(err))
+	}
+	if expectedCount != uint64(count) {
+		return count, fmt.Errorf("snapshot has %d records, expected %d", count, expectedCount)
+	}
+	return count, nil
+}
+
+func writeSnapshotField(w io.Writer, field []byte) error {
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(field)))); err != nil {
+		return err
+	}
+	_, err := w.Write(field)
+	return err
+}
+
+func readSnapshotField(r *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if length > maxSnapshotFieldLength {
+		return nil, fmt.Errorf("snapshot field length %d exceeds the maximum of %d", length, maxSnapshotFieldLength)
+	}
+	field := make([]byte, length)
+	if _, err = io.ReadFull(r, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF, since a snapshot never ends before its trailer.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -2,6 +2,8 @@ package kvstore
 
 import (
 	"errors"
+	"io"
+
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -46,3 +48,21 @@ type Store[K any] interface {
 	// or while there exist unclosed iterators.
 	Destroy() error
 }
+
+// Compactor is implemented by stores that are backed by a database that reclaims the disk space of deleted data
+// lazily, and that can be asked to do so eagerly.
+type Compactor interface {
+	// Compact compacts the underlying database, discarding deleted and overwritten data from disk.
+	Compact() error
+
+	// DiskSize returns the number of bytes of table data the underlying database keeps on disk.
+	DiskSize() (uint64, error)
+}
+
+// Exporter is implemented by stores that wrap a base store, and that can export the raw content of the base store,
+// including any data the wrapper keeps alongside the user data.
+type Exporter interface {
+	// Export writes a consistent snapshot of the raw content of the base store to the writer, in the format of
+	// ExportStore. Returns the number of key-value pairs written.
+	Export(w io.Writer) (int, error)
+}
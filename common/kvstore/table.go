@@ -54,4 +54,9 @@ type TableStore interface {
 	// NewTableIterator returns an iterator that can be used to iterate over all keys in a table.
 	// Equivalent to NewIterator(keyBuilder.Key([]byte{})).
 	NewTableIterator(keyBuilder KeyBuilder) (iterator.Iterator, error)
+
+	// GetExpirationTimes returns the expiration times of the given keys, in the same order. The zero time is
+	// returned for keys that don't have an expiration time. This scans all keys that have an expiration time,
+	// so it is meant for inspection and tooling rather than for serving requests.
+	GetExpirationTimes(keys []Key) ([]time.Time, error)
 }
@@ -5,11 +5,14 @@ import (
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"io"
 	"sync"
 	"time"
 )
 
 var _ kvstore.TableStore = &tableStore{}
+var _ kvstore.Compactor = &tableStore{}
+var _ kvstore.Exporter = &tableStore{}
 
 // tableStore is an implementation of TableStore that wraps a Store.
 type tableStore struct {
@@ -153,6 +156,30 @@ func (t *tableStore) NewTableIterator(builder kvstore.KeyBuilder) (iterator.Iter
 	return newTableStoreIterator(t.base, builder.Key([]byte{}))
 }
 
+// GetExpirationTimes returns the expiration times of the given keys, in the same order. The zero time is returned
+// for keys that don't have an expiration time.
+func (t *tableStore) GetExpirationTimes(keys []kvstore.Key) ([]time.Time, error) {
+	indices := make(map[string][]int, len(keys))
+	for i, key := range keys {
+		indices[string(key.Raw())] = append(indices[string(key.Raw())], i)
+	}
+
+	it, err := t.NewTableIterator(t.expirationKeyBuilder)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	expirationTimes := make([]time.Time, len(keys))
+	for it.Next() {
+		expiryTimestamp, baseKey := parsePrependedTimestamp(it.Key())
+		for _, i := range indices[string(baseKey)] {
+			expirationTimes[i] = expiryTimestamp
+		}
+	}
+	return expirationTimes, it.Error()
+}
+
 // ExpireKeysInBackground spawns a background goroutine that periodically checks for expired keys and deletes them.
 func (t *tableStore) expireKeysInBackground(gcPeriod time.Duration, gcBatchSize uint32) {
 	t.waitGroup.Add(1)
@@ -212,6 +239,30 @@ func (t *tableStore) expireKeys(now time.Time, gcBatchSize uint32) error {
 	return nil
 }
 
+// Compact compacts the base store. This is a no-op if the base store does not support compaction.
+func (t *tableStore) Compact() error {
+	compactor, ok := t.base.(kvstore.Compactor)
+	if !ok {
+		return nil
+	}
+	return compactor.Compact()
+}
+
+// DiskSize returns the disk size of the base store, or 0 if the base store does not report it.
+func (t *tableStore) DiskSize() (uint64, error) {
+	compactor, ok := t.base.(kvstore.Compactor)
+	if !ok {
+		return 0, nil
+	}
+	return compactor.DiskSize()
+}
+
+// Export writes a snapshot of the base store, including the table metadata and the expiration times of the keys.
+// The snapshot can be imported into a new base store, which can then be started as a table store.
+func (t *tableStore) Export(w io.Writer) (int, error) {
+	return kvstore.ExportStore(t.base, w)
+}
+
 // Shutdown shuts down the store, flushing any remaining cached data to disk.
 func (t *tableStore) Shutdown() error {
 	t.cancel()
@@ -7,6 +7,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"math"
 	"sort"
@@ -125,6 +126,11 @@ func buildBaseStore(
 		return leveldb.NewStore(logger, *path)
 	case MapStore:
 		return mapstore.NewStore(), nil
+	case PebbleDB:
+		if path == nil {
+			return nil, errors.New("path is required for PebbleDB store")
+		}
+		return pebble.NewStore(logger, *path)
 	default:
 		return nil, fmt.Errorf("unknown store type: %d", storeType)
 	}
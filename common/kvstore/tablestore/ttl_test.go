@@ -2,6 +2,7 @@ package tablestore
 
 import (
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
@@ -361,3 +362,56 @@ func TestBigBatchOfDeletions(t *testing.T) {
 	err = tStore.Shutdown()
 	assert.NoError(t, err)
 }
+
+func TestGetExpirationTimes(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	config := DefaultMapStoreConfig()
+	config.Schema = []string{"test"}
+	config.GarbageCollectionEnabled = false
+	tStore, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	kb, err := tStore.GetKeyBuilder("test")
+	assert.NoError(t, err)
+
+	startingTime := tu.RandomTime()
+	keys := make([]kvstore.Key, 0)
+	expiryTimes := make([]time.Time, 0)
+	for i := 0; i < 100; i++ {
+		key := kb.Key(tu.RandomBytes(10))
+		expiryTime := startingTime.Add(time.Duration(rand.Intn(1000)) * time.Second)
+		err = tStore.PutWithExpiration(key, tu.RandomBytes(10), expiryTime)
+		assert.NoError(t, err)
+		keys = append(keys, key)
+		expiryTimes = append(expiryTimes, expiryTime)
+	}
+
+	// A key without an expiration time, and a key that is not in the store
+	permanentKey := kb.Key(tu.RandomBytes(10))
+	err = tStore.Put(permanentKey, tu.RandomBytes(10))
+	assert.NoError(t, err)
+	keys = append(keys, permanentKey, kb.Key(tu.RandomBytes(10)))
+	expiryTimes = append(expiryTimes, time.Time{}, time.Time{})
+
+	// Look up the keys out of order, with a key requested twice
+	keys = append(keys, keys[0])
+	expiryTimes = append(expiryTimes, expiryTimes[0])
+	rand.Shuffle(len(keys), func(i, j int) {
+		keys[i], keys[j] = keys[j], keys[i]
+		expiryTimes[i], expiryTimes[j] = expiryTimes[j], expiryTimes[i]
+	})
+
+	actualExpiryTimes, err := tStore.GetExpirationTimes(keys)
+	assert.NoError(t, err)
+	assert.Len(t, actualExpiryTimes, len(keys))
+	for i := range keys {
+		assert.True(t, expiryTimes[i].Equal(actualExpiryTimes[i]))
+	}
+
+	err = tStore.Shutdown()
+	assert.NoError(t, err)
+}
@@ -1,10 +1,13 @@
 package test
 
 import (
+	"bufio"
+	"bytes"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
 	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -22,6 +25,9 @@ var storeBuilders = []func(logger logging.Logger, path string) (kvstore.Store[[]
 	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
 		return leveldb.NewStore(logger, path)
 	},
+	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+		return pebble.NewStore(logger, path)
+	},
 	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
 		config := tablestore.DefaultMapStoreConfig()
 		config.Schema = []string{"test"}
@@ -40,6 +46,15 @@ var storeBuilders = []func(logger logging.Logger, path string) (kvstore.Store[[]
 		}
 		return NewTableAsAStore(tableStore)
 	},
+	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+		config := tablestore.DefaultPebbleDBConfig(path)
+		config.Schema = []string{"test"}
+		tableStore, err := tablestore.Start(logger, config)
+		if err != nil {
+			return nil, err
+		}
+		return NewTableAsAStore(tableStore)
+	},
 }
 
 var dbPath = "test-store"
@@ -56,7 +71,6 @@ func verifyDBIsDeleted(t *testing.T) {
 
 func randomOperationsTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -132,6 +146,7 @@ func TestRandomOperations(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		randomOperationsTest(t, store)
@@ -140,7 +155,6 @@ func TestRandomOperations(t *testing.T) {
 
 func writeBatchTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	var err error
 
@@ -199,6 +213,7 @@ func TestWriteBatch(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		writeBatchTest(t, store)
@@ -207,7 +222,6 @@ func TestWriteBatch(t *testing.T) {
 
 func deleteBatchTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -265,6 +279,7 @@ func TestDeleteBatch(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		deleteBatchTest(t, store)
@@ -273,7 +288,6 @@ func TestDeleteBatch(t *testing.T) {
 
 func iterationTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -317,6 +331,7 @@ func TestIteration(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		iterationTest(t, store)
@@ -325,7 +340,6 @@ func TestIteration(t *testing.T) {
 
 func iterationWithPrefixTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	prefixA := tu.RandomBytes(8)
 	prefixB := tu.RandomBytes(8)
@@ -403,6 +417,7 @@ func TestIterationWithPrefix(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		iterationWithPrefixTest(t, store)
@@ -411,7 +426,6 @@ func TestIterationWithPrefix(t *testing.T) {
 
 func putNilTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	key := tu.RandomBytes(32)
 
@@ -432,8 +446,120 @@ func TestPutNil(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		putNilTest(t, store)
 	}
 }
+
+func compactionTest(t *testing.T, store kvstore.Store[[]byte], compactor kvstore.Compactor) {
+	tu.InitializeRandom()
+
+	// write enough data for it to be flushed from the memtable to the tables on disk
+	keys := make([][]byte, 0)
+	for i := 0; i < 1000; i++ {
+		key := tu.RandomBytes(32)
+		keys = append(keys, key)
+		err := store.Put(key, tu.RandomBytes(10*1024))
+		assert.NoError(t, err)
+	}
+	err := compactor.Compact()
+	assert.NoError(t, err)
+	sizeBefore, err := compactor.DiskSize()
+	assert.NoError(t, err)
+	assert.Greater(t, sizeBefore, uint64(1000*10*1024))
+
+	for _, key := range keys {
+		err = store.Delete(key)
+		assert.NoError(t, err)
+	}
+
+	// the deleted data is only discarded from disk by compaction
+	sizeDeleted, err := compactor.DiskSize()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, sizeDeleted, sizeBefore)
+
+	err = compactor.Compact()
+	assert.NoError(t, err)
+	sizeAfter, err := compactor.DiskSize()
+	assert.NoError(t, err)
+	assert.Less(t, sizeAfter, uint64(10*1024))
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+	verifyDBIsDeleted(t)
+}
+
+func TestCompaction(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	deleteDBDirectory(t)
+	store, err := leveldb.NewStore(logger, dbPath)
+	assert.NoError(t, err)
+	compactionTest(t, store, store.(kvstore.Compactor))
+
+	deleteDBDirectory(t)
+	store, err = pebble.NewStore(logger, dbPath)
+	assert.NoError(t, err)
+	compactionTest(t, store, store.(kvstore.Compactor))
+
+	deleteDBDirectory(t)
+	config := tablestore.DefaultLevelDBConfig(dbPath)
+	config.Schema = []string{"test"}
+	tableStore, err := tablestore.Start(logger, config)
+	assert.NoError(t, err)
+	tableAsAStore, err := NewTableAsAStore(tableStore)
+	assert.NoError(t, err)
+	compactionTest(t, tableAsAStore, tableStore.(kvstore.Compactor))
+}
+
+func snapshotTest(t *testing.T, store kvstore.Store[[]byte]) {
+	tu.InitializeRandom()
+
+	expectedData := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := tu.RandomBytes(rand.Intn(32) + 1)
+		value := tu.RandomBytes(rand.Intn(1024))
+		expectedData[string(key)] = value
+		err := store.Put(key, value)
+		assert.NoError(t, err)
+	}
+
+	snapshot := &bytes.Buffer{}
+	count, err := kvstore.ExportStore(store, snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, len(expectedData), count)
+
+	destination := mapstore.NewStore()
+	count, err = kvstore.ImportStore(bufio.NewReader(bytes.NewReader(snapshot.Bytes())), destination, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, len(expectedData), count)
+	for key, expectedValue := range expectedData {
+		value, err := destination.Get([]byte(key))
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValue, value)
+	}
+
+	// Truncated snapshots are rejected
+	truncated := bufio.NewReader(bytes.NewReader(snapshot.Bytes()[:snapshot.Len()-1]))
+	_, err = kvstore.ImportStore(truncated, mapstore.NewStore(), 7)
+	assert.Error(t, err)
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+	verifyDBIsDeleted(t)
+}
+
+func TestSnapshot(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
+		store, err := builder(logger, dbPath)
+		assert.NoError(t, err)
+		snapshotTest(t, store)
+	}
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+)
+
+// nonPublicPrefixes are the special purpose address ranges, beyond those covered by the net.IP predicates, which are
+// not reachable on the public internet or are reserved.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, which may translate to private IPv4 addresses
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+}
+
+// IsPublicIP returns whether the IP address is a unicast address reachable on the public internet, as opposed to e.g.
+// a loopback, private, link local (including cloud metadata endpoints) or reserved address.
+func IsPublicIP(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewPublicDialer returns a dialer which refuses to connect to IP addresses that are not public, see IsPublicIP.
+// The address is checked when connecting, after the host name is resolved, so that a host name resolving to a
+// private address, including by DNS rebinding after the URL was validated, can't be used to reach internal services.
+func NewPublicDialer(dialer *net.Dialer) *net.Dialer {
+	dialer.Control = func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+			return fmt.Errorf("connecting to non-public address %s is not allowed", host)
+		}
+		return nil
+	}
+	return dialer
+}
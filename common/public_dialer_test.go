@@ -0,0 +1,35 @@
+package common_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"8.8.8.8", "1.1.1.1", "2606:4700:4700::1111"} {
+		assert.True(t, common.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{
+		"127.0.0.1", "10.0.0.1", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "100.64.0.1",
+		"224.0.0.1", "255.255.255.255", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1", "::ffff:10.0.0.1",
+		"64:ff9b::a00:1",
+	} {
+		assert.False(t, common.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestPublicDialer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	dialer := common.NewPublicDialer(&net.Dialer{Timeout: time.Second})
+	_, err = dialer.DialContext(context.Background(), "tcp", listener.Addr().String())
+	assert.ErrorContains(t, err, "non-public address")
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"slices"
 	"sort"
+	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -49,6 +50,8 @@ type QuorumAttestation struct {
 	QuorumResults map[QuorumID]*QuorumResult
 	// SignerMap contains the operator IDs that signed the message
 	SignerMap map[OperatorID]bool
+	// NumReplies is the number of operator replies received before the attestation was finalized
+	NumReplies int
 }
 
 // SignatureAggregation contains the results of aggregating signatures from a set of operators across multiple quorums
@@ -73,6 +76,10 @@ type SignatureAggregation struct {
 type SignatureAggregator interface {
 	// ReceiveSignatures blocks until it receives a response for each operator in the operator state via messageChan, and then returns the attestation result by quorum.
 	ReceiveSignatures(ctx context.Context, state *IndexedOperatorState, message [32]byte, messageChan chan SigningMessage) (*QuorumAttestation, error)
+	// ReceiveSignaturesUntilThresholds is like ReceiveSignatures, but stops receiving responses once the stake signed
+	// for every quorum in thresholds reaches its threshold percentage, and at least minWait has passed since it was
+	// called. The operators that haven't responded by then are treated as non-signers.
+	ReceiveSignaturesUntilThresholds(ctx context.Context, state *IndexedOperatorState, message [32]byte, messageChan chan SigningMessage, thresholds map[QuorumID]uint8, minWait time.Duration) (*QuorumAttestation, error)
 	// AggregateSignatures takes attestation result by quorum and aggregates the signatures across them.
 	// If the aggregated signature is invalid, an error is returned.
 	AggregateSignatures(ctx context.Context, ics IndexedChainState, referenceBlockNumber uint, quorumAttestation *QuorumAttestation, quorumIDs []QuorumID) (*SignatureAggregation, error)
@@ -101,6 +108,11 @@ func NewStdSignatureAggregator(logger logging.Logger, transactor Reader) (*StdSi
 var _ SignatureAggregator = (*StdSignatureAggregator)(nil)
 
 func (a *StdSignatureAggregator) ReceiveSignatures(ctx context.Context, state *IndexedOperatorState, message [32]byte, messageChan chan SigningMessage) (*QuorumAttestation, error) {
+	return a.ReceiveSignaturesUntilThresholds(ctx, state, message, messageChan, nil, 0)
+}
+
+func (a *StdSignatureAggregator) ReceiveSignaturesUntilThresholds(ctx context.Context, state *IndexedOperatorState, message [32]byte, messageChan chan SigningMessage, thresholds map[QuorumID]uint8, minWait time.Duration) (*QuorumAttestation, error) {
+	start := time.Now()
 	quorumIDs := make([]QuorumID, 0, len(state.AggKeys))
 	for quorumID := range state.Operators {
 		quorumIDs = append(quorumIDs, quorumID)
@@ -130,9 +142,19 @@ func (a *StdSignatureAggregator) ReceiveSignatures(ctx context.Context, state *I
 	// Aggregate Signatures
 	numOperators := len(state.IndexedOperators)
 
-	for numReply := 0; numReply < numOperators; numReply++ {
+	// minWaitTimer fires when the thresholds are reached before minWait has passed. It blocks until then.
+	var minWaitTimer <-chan time.Time
+	numReplies := 0
+receive:
+	for numReplies < numOperators {
 		var err error
-		r := <-messageChan
+		var r SigningMessage
+		select {
+		case r = <-messageChan:
+		case <-minWaitTimer:
+			break receive
+		}
+		numReplies++
 		operatorIDHex := r.Operator.Hex()
 		operatorAddr, ok := a.OperatorAddresses.Get(r.Operator)
 		if !ok && a.Transactor != nil {
@@ -197,6 +219,17 @@ func (a *StdSignatureAggregator) ReceiveSignatures(ctx context.Context, state *I
 			}
 		}
 		a.Logger.Info("received signature from operator", "operatorID", operatorIDHex, "operatorAddress", operatorAddr, "socket", socket, "quorumIDs", fmt.Sprint(operatorQuorums), "batchHeaderHash", batchHeaderHashHex, "attestationLatencyMs", r.AttestationLatencyMs)
+
+		if len(thresholds) > 0 && minWaitTimer == nil && numReplies < numOperators && thresholdsReached(state, stakeSigned, thresholds) {
+			remaining := minWait - time.Since(start)
+			if remaining <= 0 {
+				break receive
+			}
+			minWaitTimer = time.After(remaining)
+		}
+	}
+	if numReplies < numOperators {
+		a.Logger.Info("confirmation thresholds reached, finalizing attestation before all operators replied", "batchHeaderHash", hex.EncodeToString(message[:]), "numReplies", numReplies, "numOperators", numOperators)
 	}
 
 	// Aggregate Non signer Pubkey Id
@@ -266,9 +299,22 @@ func (a *StdSignatureAggregator) ReceiveSignatures(ctx context.Context, state *I
 		AggSignature:     aggSigs,
 		QuorumResults:    quorumResults,
 		SignerMap:        signerMap,
+		NumReplies:       numReplies,
 	}, nil
 }
 
+// thresholdsReached returns true if the stake signed for every quorum in thresholds reaches its threshold percentage
+func thresholdsReached(state *IndexedOperatorState, stakeSigned map[QuorumID]*big.Int, thresholds map[QuorumID]uint8) bool {
+	for quorumID, threshold := range thresholds {
+		signed, ok := stakeSigned[quorumID]
+		// GetSignedPercentage overwrites the stake it's given
+		if !ok || GetSignedPercentage(state.OperatorState, quorumID, new(big.Int).Set(signed)) < threshold {
+			return false
+		}
+	}
+	return true
+}
+
 func (a *StdSignatureAggregator) AggregateSignatures(ctx context.Context, ics IndexedChainState, referenceBlockNumber uint, quorumAttestation *QuorumAttestation, quorumIDs []QuorumID) (*SignatureAggregation, error) {
 	// Aggregate the aggregated signatures. We reuse the first aggregated signature as the accumulator
 	var aggSig *Signature
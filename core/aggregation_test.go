@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/mock"
@@ -204,6 +205,49 @@ func TestAggregateSignaturesStatus(t *testing.T) {
 
 }
 
+func TestReceiveSignaturesUntilThresholds(t *testing.T) {
+	ctx := context.Background()
+	state := dat.GetTotalOperatorStateWithQuorums(ctx, 0, []core.QuorumID{0})
+	message := [32]byte{1, 2, 3, 4, 5, 6}
+	numOperators := len(state.IndexedOperators)
+	thresholds := map[core.QuorumID]uint8{0: 10}
+
+	// the attestation is finalized as soon as the threshold is reached
+	update := make(chan core.SigningMessage, numOperators)
+	simulateOperators(*state, message, update, 0)
+	aq, err := agg.ReceiveSignaturesUntilThresholds(ctx, state.IndexedOperatorState, message, update, thresholds, 0)
+	assert.NoError(t, err)
+	assert.Less(t, aq.NumReplies, numOperators)
+	assert.Len(t, aq.SignerMap, aq.NumReplies)
+	assert.GreaterOrEqual(t, aq.QuorumResults[0].PercentSigned, uint8(10))
+	sigAgg, err := agg.AggregateSignatures(ctx, dat, 0, aq, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assert.Len(t, sigAgg.NonSigners, numOperators-aq.NumReplies)
+
+	// the responses are received until the min wait passes, or until all operators responded
+	update = make(chan core.SigningMessage, numOperators)
+	simulateOperators(*state, message, update, 0)
+	aq, err = agg.ReceiveSignaturesUntilThresholds(ctx, state.IndexedOperatorState, message, update, thresholds, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, numOperators, aq.NumReplies)
+	assert.Equal(t, uint8(100), aq.QuorumResults[0].PercentSigned)
+
+	update = make(chan core.SigningMessage, numOperators)
+	for i := 0; i < 2; i++ {
+		id := mock.MakeOperatorId(i)
+		update <- core.SigningMessage{
+			Signature: state.PrivateOperators[id].KeyPair.SignMessage(message),
+			Operator:  id,
+		}
+	}
+	start := time.Now()
+	aq, err = agg.ReceiveSignaturesUntilThresholds(ctx, state.IndexedOperatorState, message, update, thresholds, 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, 2, aq.NumReplies)
+	assert.Equal(t, uint8(14), aq.QuorumResults[0].PercentSigned)
+}
+
 func TestSortNonsigners(t *testing.T) {
 	state := dat.GetTotalOperatorState(context.Background(), 0)
 
@@ -1,9 +1,11 @@
 package v2_test
 
 import (
+	"context"
 	"crypto/sha256"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
@@ -32,7 +34,7 @@ func TestAuthentication(t *testing.T) {
 
 	header.Signature = signature
 
-	err = authenticator.AuthenticateBlobRequest(header)
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
 	assert.NoError(t, err)
 
 }
@@ -55,10 +57,95 @@ func TestAuthenticationFail(t *testing.T) {
 
 	header.Signature = signature
 
-	err = authenticator.AuthenticateBlobRequest(header)
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
 	assert.Error(t, err)
 }
 
+func TestAuthenticationCoversRetentionPeriod(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	authenticator := auth.NewAuthenticator()
+
+	accountId, err := signer.GetAccountID()
+	assert.NoError(t, err)
+	header := testHeader(t, accountId)
+	header.RetentionPeriod = 48 * time.Hour
+	header.Signature, err = signer.SignBlobRequest(header)
+	assert.NoError(t, err)
+	assert.NoError(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// the retention period can't be changed or dropped without the account's signature
+	header.RetentionPeriod = 30 * 24 * time.Hour
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+	header.RetentionPeriod = 0
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+}
+
+func TestAuthenticationCoversSecurityThresholds(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	authenticator := auth.NewAuthenticator()
+
+	accountId, err := signer.GetAccountID()
+	assert.NoError(t, err)
+	header := testHeader(t, accountId)
+	header.SecurityThresholds = []corev2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 80, AdversaryThreshold: 40}}
+	header.Signature, err = signer.SignBlobRequest(header)
+	assert.NoError(t, err)
+	assert.NoError(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// the security thresholds can't be weakened or dropped without the account's signature
+	header.SecurityThresholds = []corev2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 25, AdversaryThreshold: 0}}
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+	header.SecurityThresholds = nil
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+}
+
+func TestReplayProtectedAuthentication(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	replayGuard, err := auth.NewReplayGuard(time.Minute, time.Minute)
+	assert.NoError(t, err)
+	authenticator := auth.NewReplayProtectedAuthenticator(replayGuard)
+
+	accountId, err := signer.GetAccountID()
+	assert.NoError(t, err)
+
+	// requests without a timestamp are rejected
+	header := testHeader(t, accountId)
+	header.Signature, err = signer.SignBlobRequest(header)
+	assert.NoError(t, err)
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
+	assert.ErrorContains(t, err, "request timestamp is required")
+
+	header.PaymentMetadata.Timestamp = time.Now().UnixNano()
+	header.Signature, err = signer.SignBlobRequest(header)
+	assert.NoError(t, err)
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
+	assert.NoError(t, err)
+
+	// the request can be resubmitted until it is recorded as dispersed
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
+	assert.NoError(t, err)
+	err = authenticator.RecordBlobRequest(context.Background(), header)
+	assert.NoError(t, err)
+	err = authenticator.RecordBlobRequest(context.Background(), header)
+	assert.ErrorContains(t, err, "already been received")
+
+	// the same signed request cannot be replayed
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
+	assert.ErrorContains(t, err, "already been received")
+
+	// the timestamp is covered by the signature
+	header.PaymentMetadata.Timestamp = time.Now().UnixNano()
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
+	assert.ErrorContains(t, err, "signature doesn't match")
+
+	// stale requests are rejected
+	header.PaymentMetadata.Timestamp = time.Now().Add(-time.Hour).UnixNano()
+	header.Signature, err = signer.SignBlobRequest(header)
+	assert.NoError(t, err)
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
+	assert.ErrorContains(t, err, "too far in the past")
+}
+
 func TestNoopSignerFail(t *testing.T) {
 	signer := auth.NewLocalNoopSigner()
 	accountId, err := signer.GetAccountID()
@@ -121,20 +208,57 @@ func TestAuthenticatePaymentStateRequestValid(t *testing.T) {
 	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
 	authenticator := auth.NewAuthenticator()
 
-	signature, err := signer.SignPaymentStateRequest()
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := signer.SignPaymentStateRequest(timestamp)
+	assert.NoError(t, err)
+
+	accountId, err := signer.GetAccountID()
+	assert.NoError(t, err)
+
+	err = authenticator.AuthenticatePaymentStateRequest(context.Background(), signature, accountId, timestamp)
+	assert.NoError(t, err)
+
+	// the signature covers the timestamp
+	err = authenticator.AuthenticatePaymentStateRequest(context.Background(), signature, accountId, timestamp+1)
+	assert.Error(t, err)
+}
+
+func TestAuthenticatePaymentStateRequestReplayProtection(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	replayGuard, err := auth.NewReplayGuard(time.Minute, time.Minute)
 	assert.NoError(t, err)
+	authenticator := auth.NewReplayProtectedAuthenticator(replayGuard)
 
 	accountId, err := signer.GetAccountID()
 	assert.NoError(t, err)
 
-	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId)
+	// requests without a timestamp are rejected
+	signature, err := signer.SignPaymentStateRequest(0)
+	assert.NoError(t, err)
+	err = authenticator.AuthenticatePaymentStateRequest(context.Background(), signature, accountId, 0)
+	assert.ErrorContains(t, err, "timestamp is required")
+
+	// requests are only accepted once
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err = signer.SignPaymentStateRequest(timestamp)
+	assert.NoError(t, err)
+	err = authenticator.AuthenticatePaymentStateRequest(context.Background(), signature, accountId, timestamp)
+	assert.NoError(t, err)
+	err = authenticator.AuthenticatePaymentStateRequest(context.Background(), signature, accountId, timestamp)
+	assert.ErrorContains(t, err, "already been received")
+
+	// stale requests are rejected
+	timestamp = uint64(time.Now().Add(-2 * time.Minute).UnixNano())
+	signature, err = signer.SignPaymentStateRequest(timestamp)
 	assert.NoError(t, err)
+	err = authenticator.AuthenticatePaymentStateRequest(context.Background(), signature, accountId, timestamp)
+	assert.ErrorContains(t, err, "too far in the past")
 }
 
 func TestAuthenticatePaymentStateRequestInvalidSignatureLength(t *testing.T) {
 	authenticator := auth.NewAuthenticator()
 
-	err := authenticator.AuthenticatePaymentStateRequest([]byte{1, 2, 3}, "0x123")
+	err := authenticator.AuthenticatePaymentStateRequest(context.Background(), []byte{1, 2, 3}, "0x123", 0)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "signature length is unexpected")
 }
@@ -142,7 +266,7 @@ func TestAuthenticatePaymentStateRequestInvalidSignatureLength(t *testing.T) {
 func TestAuthenticatePaymentStateRequestInvalidPublicKey(t *testing.T) {
 	authenticator := auth.NewAuthenticator()
 
-	err := authenticator.AuthenticatePaymentStateRequest(make([]byte, 65), "not-hex-encoded")
+	err := authenticator.AuthenticatePaymentStateRequest(context.Background(), make([]byte, 65), "not-hex-encoded", 0)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to decode public key")
 }
@@ -158,10 +282,10 @@ func TestAuthenticatePaymentStateRequestSignatureMismatch(t *testing.T) {
 	accountId, err := signer.GetAccountID()
 	assert.NoError(t, err)
 
-	signature, err := wrongSigner.SignPaymentStateRequest()
+	signature, err := wrongSigner.SignPaymentStateRequest(0)
 	assert.NoError(t, err)
 
-	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId)
+	err = authenticator.AuthenticatePaymentStateRequest(context.Background(), signature, accountId, 0)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "signature doesn't match with provided public key")
 }
@@ -180,6 +304,6 @@ func TestAuthenticatePaymentStateRequestCorruptedSignature(t *testing.T) {
 	// Corrupt the signature
 	signature[0] ^= 0x01
 
-	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId)
+	err = authenticator.AuthenticatePaymentStateRequest(context.Background(), signature, accountId, 0)
 	assert.Error(t, err)
 }
@@ -2,35 +2,110 @@ package v2
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"time"
 
 	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-type authenticator struct{}
+type authenticator struct {
+	// replayGuard rejects replayed blob requests, nil if replay protection is disabled
+	replayGuard *ReplayGuard
+	// typedDataVerifier verifies the EIP-712 signatures of address accounts, nil if only public key accounts are
+	// supported
+	typedDataVerifier *TypedDataVerifier
+}
 
 func NewAuthenticator() *authenticator {
 	return &authenticator{}
 }
 
+// NewReplayProtectedAuthenticator creates an authenticator that additionally rejects blob requests without a
+// timestamp, with a timestamp outside the window of the replay guard, or that were already authenticated before.
+func NewReplayProtectedAuthenticator(replayGuard *ReplayGuard) *authenticator {
+	return &authenticator{
+		replayGuard: replayGuard,
+	}
+}
+
+// NewTypedDataAuthenticator creates an authenticator that additionally accepts requests of address accounts, signed
+// over EIP-712 typed data and verified by the typed data verifier. The replay guard is optional.
+func NewTypedDataAuthenticator(replayGuard *ReplayGuard, typedDataVerifier *TypedDataVerifier) *authenticator {
+	return &authenticator{
+		replayGuard:       replayGuard,
+		typedDataVerifier: typedDataVerifier,
+	}
+}
+
 var _ core.BlobRequestAuthenticator = &authenticator{}
 
-func (*authenticator) AuthenticateBlobRequest(header *core.BlobHeader) error {
-	sig := header.Signature
+func (a *authenticator) AuthenticateBlobRequest(ctx context.Context, header *core.BlobHeader) error {
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return fmt.Errorf("failed to get blob key: %v", err)
+	}
 
-	// Ensure the signature is 65 bytes (Recovery ID is the last byte)
-	if len(sig) != 65 {
-		return fmt.Errorf("signature length is unexpected: %d", len(sig))
+	// The timestamp is checked before the signature, so that stale requests are rejected without verifying the
+	// signatures of smart contract wallets on chain
+	if a.replayGuard != nil {
+		// the timestamp is part of the blob key, so it is covered by the signature
+		if header.PaymentMetadata.Timestamp == 0 {
+			return errors.New("request timestamp is required")
+		}
+		if err := a.replayGuard.checkTimestamp(time.Unix(0, header.PaymentMetadata.Timestamp)); err != nil {
+			return fmt.Errorf("replay protection: %w", err)
+		}
+	}
+
+	if IsAddressAccountID(header.PaymentMetadata.AccountID) {
+		if a.typedDataVerifier == nil {
+			return errors.New("typed data signatures are not supported, the account ID must be a public key")
+		}
+		if err := a.typedDataVerifier.VerifyBlobRequest(ctx, header); err != nil {
+			return err
+		}
+	} else if err := authenticatePublicKeyBlobRequest(header); err != nil {
+		return err
+	}
+
+	if a.replayGuard != nil {
+		if err := a.replayGuard.CheckRequest(ctx, blobKey[:], time.Unix(0, header.PaymentMetadata.Timestamp)); err != nil {
+			return fmt.Errorf("replay protection: %w", err)
+		}
 	}
 
+	return nil
+}
+
+// RecordBlobRequest records the dispersed blob request in the replay guard, if the authenticator has one.
+func (a *authenticator) RecordBlobRequest(ctx context.Context, header *core.BlobHeader) error {
+	if a.replayGuard == nil {
+		return nil
+	}
 	blobKey, err := header.BlobKey()
 	if err != nil {
 		return fmt.Errorf("failed to get blob key: %v", err)
 	}
+	if err := a.replayGuard.RecordRequest(ctx, blobKey[:], time.Unix(0, header.PaymentMetadata.Timestamp)); err != nil {
+		return fmt.Errorf("replay protection: %w", err)
+	}
+	return nil
+}
+
+// authenticatePublicKeyBlobRequest verifies the signature of the request hash by the public key account of the request
+func authenticatePublicKeyBlobRequest(header *core.BlobHeader) error {
+	sig := header.Signature
+
+	// Ensure the signature is 65 bytes (Recovery ID is the last byte)
+	if len(sig) != 65 {
+		return fmt.Errorf("signature length is unexpected: %d", len(sig))
+	}
 
 	publicKeyBytes, err := hexutil.Decode(header.PaymentMetadata.AccountID)
 	if err != nil {
@@ -44,7 +119,11 @@ func (*authenticator) AuthenticateBlobRequest(header *core.BlobHeader) error {
 	}
 
 	// Verify the signature
-	sigPublicKeyECDSA, err := crypto.SigToPub(blobKey[:], sig)
+	requestHash, err := header.RequestHash()
+	if err != nil {
+		return fmt.Errorf("failed to get request hash: %v", err)
+	}
+	sigPublicKeyECDSA, err := crypto.SigToPub(requestHash[:], sig)
 	if err != nil {
 		return fmt.Errorf("failed to recover public key from signature: %v", err)
 	}
@@ -56,7 +135,42 @@ func (*authenticator) AuthenticateBlobRequest(header *core.BlobHeader) error {
 	return nil
 }
 
-func (*authenticator) AuthenticatePaymentStateRequest(sig []byte, accountId string) error {
+// AuthenticatePaymentStateRequest verifies the signature of a payment state request. The timestamp is required, and
+// replayed requests are rejected, if the authenticator has a replay guard.
+func (a *authenticator) AuthenticatePaymentStateRequest(ctx context.Context, sig []byte, accountId string, timestamp uint64) error {
+	if a.replayGuard != nil {
+		if timestamp == 0 {
+			return errors.New("request timestamp is required")
+		}
+		if err := a.replayGuard.checkTimestamp(time.Unix(0, int64(timestamp))); err != nil {
+			return fmt.Errorf("replay protection: %w", err)
+		}
+	}
+
+	if IsAddressAccountID(accountId) {
+		if a.typedDataVerifier == nil {
+			return errors.New("typed data signatures are not supported, the account ID must be a public key")
+		}
+		if err := a.typedDataVerifier.VerifyPaymentStateRequest(ctx, sig, accountId, timestamp); err != nil {
+			return err
+		}
+	} else if err := authenticatePublicKeyPaymentStateRequest(sig, accountId, timestamp); err != nil {
+		return err
+	}
+
+	if a.replayGuard != nil {
+		hash := paymentStateRequestHash(accountId, timestamp)
+		if err := a.replayGuard.VerifyRequest(ctx, hash[:], time.Unix(0, int64(timestamp))); err != nil {
+			return fmt.Errorf("replay protection: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// authenticatePublicKeyPaymentStateRequest verifies the signature of the account ID and timestamp by the public key
+// account of the request
+func authenticatePublicKeyPaymentStateRequest(sig []byte, accountId string, timestamp uint64) error {
 	// Ensure the signature is 65 bytes (Recovery ID is the last byte)
 	if len(sig) != 65 {
 		return fmt.Errorf("signature length is unexpected: %d", len(sig))
@@ -75,7 +189,7 @@ func (*authenticator) AuthenticatePaymentStateRequest(sig []byte, accountId stri
 	}
 
 	// Verify the signature
-	hash := sha256.Sum256([]byte(accountId))
+	hash := paymentStateRequestHash(accountId, timestamp)
 	sigPublicKeyECDSA, err := crypto.SigToPub(hash[:], sig)
 	if err != nil {
 		return fmt.Errorf("failed to recover public key from signature: %v", err)
@@ -87,3 +201,12 @@ func (*authenticator) AuthenticatePaymentStateRequest(sig []byte, accountId stri
 
 	return nil
 }
+
+// paymentStateRequestHash returns the hash a payment state request of a public key account is signed over. Requests
+// without a timestamp are signed over the account ID only.
+func paymentStateRequestHash(accountId string, timestamp uint64) [32]byte {
+	if timestamp == 0 {
+		return sha256.Sum256([]byte(accountId))
+	}
+	return sha256.Sum256(binary.BigEndian.AppendUint64([]byte(accountId), timestamp))
+}
@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	requestHashAttribute = "RequestHash"
+	// expiresAtAttribute is the expiry of the request hash in unix seconds. It is the TTL attribute of the table, so
+	// that DynamoDB deletes the expired hashes.
+	expiresAtAttribute = "ExpiresAt"
+)
+
+// dynamoDBRequestStore is a RequestStore keeping the request hashes in a DynamoDB table, so that they are shared
+// by all the API server replicas using the table.
+type dynamoDBRequestStore struct {
+	client    commondynamodb.Client
+	tableName string
+}
+
+// NewDynamoDBRequestStore creates a RequestStore keeping the request hashes in the given DynamoDB table. The table
+// is keyed by the RequestHash string attribute and expires the hashes by the ExpiresAt TTL attribute, see
+// CreateRequestStoreTable.
+func NewDynamoDBRequestStore(client commondynamodb.Client, tableName string) *dynamoDBRequestStore {
+	return &dynamoDBRequestStore{
+		client:    client,
+		tableName: tableName,
+	}
+}
+
+// Add records the request hash with a conditional put, which only succeeds if the hash is not in the table yet or
+// has expired, since DynamoDB may delete expired items long after their TTL.
+func (s *dynamoDBRequestStore) Add(ctx context.Context, requestHash []byte, expiry time.Time) (bool, error) {
+	item := commondynamodb.Item{
+		requestHashAttribute: &types.AttributeValueMemberS{Value: hexutil.Encode(requestHash)},
+		expiresAtAttribute:   &types.AttributeValueMemberN{Value: strconv.FormatInt(expiry.Unix(), 10)},
+	}
+	err := s.client.PutItemWithCondition(
+		ctx,
+		s.tableName,
+		item,
+		"attribute_not_exists(#hash) OR #expiresAt < :now",
+		map[string]string{
+			"#hash":      requestHashAttribute,
+			"#expiresAt": expiresAtAttribute,
+		},
+		map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		},
+	)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to put request hash: %w", err)
+	}
+	return true, nil
+}
+
+// Contains looks up the request hash, treating it as absent once it has expired. The read is eventually consistent,
+// which is fine since Add, with its conditional put, is what rejects concurrent requests with the same hash.
+func (s *dynamoDBRequestStore) Contains(ctx context.Context, requestHash []byte) (bool, error) {
+	item, err := s.client.GetItem(ctx, s.tableName, commondynamodb.Key{
+		requestHashAttribute: &types.AttributeValueMemberS{Value: hexutil.Encode(requestHash)},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get request hash: %w", err)
+	}
+	if item == nil {
+		return false, nil
+	}
+	expiresAt, ok := item[expiresAtAttribute].(*types.AttributeValueMemberN)
+	if !ok {
+		return true, nil
+	}
+	expiry, err := strconv.ParseInt(expiresAt.Value, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse request hash expiry: %w", err)
+	}
+	return expiry >= time.Now().Unix(), nil
+}
+
+// CreateRequestStoreTable creates the DynamoDB table of a request store, with the TTL enabled on the expiry of the
+// request hashes so that the table doesn't grow without bound.
+func CreateRequestStoreTable(clientConfig commonaws.ClientConfig, tableName string) error {
+	ctx := context.Background()
+	_, err := test_utils.CreateTable(ctx, clientConfig, tableName, GenerateRequestStoreTableSchema(tableName, 10, 10))
+	if err != nil {
+		return err
+	}
+	return test_utils.EnableTimeToLive(ctx, clientConfig, tableName, expiresAtAttribute)
+}
+
+// GenerateRequestStoreTableSchema returns the schema of the DynamoDB table of a request store. The table also needs
+// the TTL enabled on the ExpiresAt attribute, which is not part of the schema, see CreateRequestStoreTable.
+func GenerateRequestStoreTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String(requestHashAttribute),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String(requestHashAttribute),
+				KeyType:       types.KeyTypeHash,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
@@ -0,0 +1,200 @@
+package v2
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ReplayGuard rejects requests that were already seen, so that a captured signed request cannot be resubmitted.
+//
+// Each request is identified by the hash it was signed over, and carries the (signed) time it was created. A request
+// is only accepted if its timestamp is within the accepted window around the current time, and its hash was not seen
+// before. Hashes only need to be remembered while their timestamp is within the window, since a replayed request
+// outside the window is rejected by the timestamp check, so they are recorded in the request store until then.
+//
+// The guard only protects against replays to the API servers that share its request store. Deployments running more
+// than one API server replica must use a shared store, such as the one returned by NewDynamoDBRequestStore, since a
+// request replayed to another replica is not rejected by a local store.
+type ReplayGuard struct {
+	// maxTimeInPast is how old a request may be when it is received
+	maxTimeInPast time.Duration
+	// maxTimeInFuture is how far in the future the timestamp of a request may be, to tolerate clock skew
+	maxTimeInFuture time.Duration
+
+	// store records the hashes of the accepted requests that are still within the window
+	store RequestStore
+}
+
+// RequestStore records the hashes of the requests accepted by a ReplayGuard.
+type RequestStore interface {
+	// Add records the request hash until the expiry time. It returns false, without updating the expiry, if the hash
+	// was already recorded and has not expired yet.
+	Add(ctx context.Context, requestHash []byte, expiry time.Time) (bool, error)
+	// Contains returns whether the request hash is recorded and has not expired yet.
+	Contains(ctx context.Context, requestHash []byte) (bool, error)
+}
+
+// NewReplayGuard creates a ReplayGuard accepting requests with timestamps from maxTimeInPast before the current
+// time to maxTimeInFuture after it. The seen requests are kept in memory, so the guard only protects a single
+// API server replica.
+func NewReplayGuard(maxTimeInPast time.Duration, maxTimeInFuture time.Duration) (*ReplayGuard, error) {
+	return NewReplayGuardWithStore(NewLocalRequestStore(), maxTimeInPast, maxTimeInFuture)
+}
+
+// NewReplayGuardWithStore creates a ReplayGuard recording the seen requests in the given store, accepting requests
+// with timestamps from maxTimeInPast before the current time to maxTimeInFuture after it.
+func NewReplayGuardWithStore(store RequestStore, maxTimeInPast time.Duration, maxTimeInFuture time.Duration) (*ReplayGuard, error) {
+	if store == nil {
+		return nil, errors.New("request store is required")
+	}
+	if maxTimeInPast <= 0 {
+		return nil, errors.New("max time in past must be positive")
+	}
+	if maxTimeInFuture < 0 {
+		return nil, errors.New("max time in future must not be negative")
+	}
+	return &ReplayGuard{
+		maxTimeInPast:   maxTimeInPast,
+		maxTimeInFuture: maxTimeInFuture,
+		store:           store,
+	}, nil
+}
+
+// VerifyRequest checks that the request with the given hash and timestamp is within the accepted window and was
+// not seen before, and records it if so.
+func (g *ReplayGuard) VerifyRequest(ctx context.Context, requestHash []byte, requestTimestamp time.Time) error {
+	if err := g.checkTimestamp(requestTimestamp); err != nil {
+		return err
+	}
+	return g.RecordRequest(ctx, requestHash, requestTimestamp)
+}
+
+// CheckRequest checks that the request with the given hash and timestamp is within the accepted window and was not
+// recorded before, without recording it. Requests which are only recorded once they have been served, so that a
+// rejected request can be resubmitted, are checked with CheckRequest and recorded with RecordRequest.
+func (g *ReplayGuard) CheckRequest(ctx context.Context, requestHash []byte, requestTimestamp time.Time) error {
+	if err := g.checkTimestamp(requestTimestamp); err != nil {
+		return err
+	}
+	seen, err := g.store.Contains(ctx, requestHash)
+	if err != nil {
+		return fmt.Errorf("failed to look up request: %w", err)
+	}
+	if seen {
+		return errors.New("request has already been received")
+	}
+	return nil
+}
+
+// RecordRequest records the request with the given hash and timestamp, so that replays of it are rejected. It fails
+// if the request was already recorded, e.g. by a concurrent request.
+func (g *ReplayGuard) RecordRequest(ctx context.Context, requestHash []byte, requestTimestamp time.Time) error {
+	// the request is rejected by the timestamp check once its timestamp leaves the window
+	added, err := g.store.Add(ctx, requestHash, requestTimestamp.Add(g.maxTimeInPast))
+	if err != nil {
+		return fmt.Errorf("failed to record request: %w", err)
+	}
+	if !added {
+		return errors.New("request has already been received")
+	}
+	return nil
+}
+
+// checkTimestamp checks that the request timestamp is within the accepted window around the current time.
+func (g *ReplayGuard) checkTimestamp(requestTimestamp time.Time) error {
+	now := time.Now()
+	if requestTimestamp.Before(now.Add(-g.maxTimeInPast)) {
+		return fmt.Errorf("request timestamp %s is too far in the past, requests older than %s are rejected",
+			requestTimestamp.UTC().Format(time.RFC3339Nano), g.maxTimeInPast)
+	}
+	if requestTimestamp.After(now.Add(g.maxTimeInFuture)) {
+		return fmt.Errorf("request timestamp %s is too far in the future, requests more than %s ahead are rejected",
+			requestTimestamp.UTC().Format(time.RFC3339Nano), g.maxTimeInFuture)
+	}
+	return nil
+}
+
+// localRequestStore is a RequestStore keeping the request hashes in memory.
+type localRequestStore struct {
+	mu sync.Mutex
+	// seen contains the hashes of the recorded requests that have not expired yet
+	seen map[string]struct{}
+	// expirations orders the seen hashes by expiry, so that the expired ones can be pruned
+	expirations expirationHeap
+}
+
+// NewLocalRequestStore creates a RequestStore keeping the request hashes in memory. It is not shared between
+// processes.
+func NewLocalRequestStore() *localRequestStore {
+	return &localRequestStore{
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (s *localRequestStore) Add(_ context.Context, requestHash []byte, expiry time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(time.Now())
+
+	key := string(requestHash)
+	if _, ok := s.seen[key]; ok {
+		return false, nil
+	}
+	s.seen[key] = struct{}{}
+	heap.Push(&s.expirations, expiration{key: key, expiry: expiry})
+	return true, nil
+}
+
+func (s *localRequestStore) Contains(_ context.Context, requestHash []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(time.Now())
+
+	_, ok := s.seen[string(requestHash)]
+	return ok, nil
+}
+
+// Size returns the number of request hashes currently remembered.
+func (s *localRequestStore) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.seen)
+}
+
+// prune forgets the hashes of requests that have expired.
+func (s *localRequestStore) prune(now time.Time) {
+	for s.expirations.Len() > 0 && s.expirations[0].expiry.Before(now) {
+		oldest := heap.Pop(&s.expirations).(expiration)
+		delete(s.seen, oldest.key)
+	}
+}
+
+type expiration struct {
+	key    string
+	expiry time.Time
+}
+
+// expirationHeap is a min-heap of expirations ordered by expiry.
+type expirationHeap []expiration
+
+func (h expirationHeap) Len() int           { return len(h) }
+func (h expirationHeap) Less(i, j int) bool { return h[i].expiry.Before(h[j].expiry) }
+func (h expirationHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *expirationHeap) Push(x any) {
+	*h = append(*h, x.(expiration))
+}
+
+func (h *expirationHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
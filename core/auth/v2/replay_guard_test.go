@@ -0,0 +1,138 @@
+package v2_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	commonmock "github.com/Layr-Labs/eigenda/common/aws/mock"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayGuard(t *testing.T) {
+	_, err := auth.NewReplayGuard(0, time.Second)
+	assert.Error(t, err)
+	_, err = auth.NewReplayGuard(time.Second, -time.Second)
+	assert.Error(t, err)
+
+	_, err = auth.NewReplayGuardWithStore(nil, time.Second, time.Second)
+	assert.Error(t, err)
+
+	store := auth.NewLocalRequestStore()
+	guard, err := auth.NewReplayGuardWithStore(store, time.Minute, 10*time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now()
+	err = guard.VerifyRequest(ctx, []byte{1}, now)
+	assert.NoError(t, err)
+	err = guard.VerifyRequest(ctx, []byte{2}, now.Add(-30*time.Second))
+	assert.NoError(t, err)
+	err = guard.VerifyRequest(ctx, []byte{3}, now.Add(5*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, store.Size())
+
+	// replayed requests are rejected, even with a different timestamp
+	err = guard.VerifyRequest(ctx, []byte{1}, now)
+	assert.ErrorContains(t, err, "already been received")
+	err = guard.VerifyRequest(ctx, []byte{2}, now.Add(time.Second))
+	assert.ErrorContains(t, err, "already been received")
+
+	// requests outside the window are rejected
+	err = guard.VerifyRequest(ctx, []byte{4}, now.Add(-2*time.Minute))
+	assert.ErrorContains(t, err, "too far in the past")
+	err = guard.VerifyRequest(ctx, []byte{4}, now.Add(time.Minute))
+	assert.ErrorContains(t, err, "too far in the future")
+	assert.Equal(t, 3, store.Size())
+}
+
+func TestReplayGuardPruning(t *testing.T) {
+	store := auth.NewLocalRequestStore()
+	guard, err := auth.NewReplayGuardWithStore(store, 100*time.Millisecond, 0)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now()
+	err = guard.VerifyRequest(ctx, []byte{1}, now.Add(-50*time.Millisecond))
+	require.NoError(t, err)
+	err = guard.VerifyRequest(ctx, []byte{2}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.Size())
+
+	// the first request leaves the window, and is pruned when the next request is verified
+	time.Sleep(75 * time.Millisecond)
+	err = guard.VerifyRequest(ctx, []byte{3}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.Size())
+
+	// a replay of the pruned request is still rejected by its timestamp
+	err = guard.VerifyRequest(ctx, []byte{1}, now.Add(-50*time.Millisecond))
+	assert.ErrorContains(t, err, "too far in the past")
+}
+
+func TestReplayGuardCheckAndRecord(t *testing.T) {
+	store := auth.NewLocalRequestStore()
+	guard, err := auth.NewReplayGuardWithStore(store, time.Minute, 10*time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	// checking a request doesn't record it
+	require.NoError(t, guard.CheckRequest(ctx, []byte{1}, now))
+	require.NoError(t, guard.CheckRequest(ctx, []byte{1}, now))
+	assert.Equal(t, 0, store.Size())
+
+	require.NoError(t, guard.RecordRequest(ctx, []byte{1}, now))
+	assert.ErrorContains(t, guard.CheckRequest(ctx, []byte{1}, now), "already been received")
+	assert.ErrorContains(t, guard.RecordRequest(ctx, []byte{1}, now), "already been received")
+
+	err = guard.CheckRequest(ctx, []byte{2}, now.Add(-2*time.Minute))
+	assert.ErrorContains(t, err, "too far in the past")
+}
+
+func TestDynamoDBRequestStore(t *testing.T) {
+	dynamoClient := &commonmock.MockDynamoDBClient{}
+	store := auth.NewDynamoDBRequestStore(dynamoClient, "requests")
+	guard, err := auth.NewReplayGuardWithStore(store, time.Minute, 10*time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	// the conditional put fails if the request hash is already in the table
+	dynamoClient.On("PutItemWithCondition").Return(nil).Once()
+	dynamoClient.On("PutItemWithCondition").Return(commondynamodb.ErrConditionFailed).Once()
+	err = guard.VerifyRequest(ctx, []byte{1}, now)
+	assert.NoError(t, err)
+	err = guard.VerifyRequest(ctx, []byte{1}, now)
+	assert.ErrorContains(t, err, "already been received")
+
+	// store errors reject the request
+	dynamoClient.On("PutItemWithCondition").Return(errors.New("throttled")).Once()
+	err = guard.VerifyRequest(ctx, []byte{2}, now)
+	assert.ErrorContains(t, err, "throttled")
+
+	// requests outside the window are rejected without a put
+	err = guard.VerifyRequest(ctx, []byte{3}, now.Add(-2*time.Minute))
+	assert.ErrorContains(t, err, "too far in the past")
+	dynamoClient.AssertNumberOfCalls(t, "PutItemWithCondition", 3)
+
+	// expired hashes which DynamoDB hasn't deleted yet are not contained
+	dynamoClient.On("GetItem").Return(commondynamodb.Item(nil), nil).Once()
+	dynamoClient.On("GetItem").Return(commondynamodb.Item{
+		"ExpiresAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Add(time.Minute).Unix(), 10)},
+	}, nil).Once()
+	dynamoClient.On("GetItem").Return(commondynamodb.Item{
+		"ExpiresAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Add(-time.Minute).Unix(), 10)},
+	}, nil).Once()
+	assert.NoError(t, guard.CheckRequest(ctx, []byte{4}, now))
+	assert.ErrorContains(t, guard.CheckRequest(ctx, []byte{1}, now), "already been received")
+	assert.NoError(t, guard.CheckRequest(ctx, []byte{5}, now))
+}
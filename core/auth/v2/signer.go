@@ -2,14 +2,15 @@ package v2
 
 import (
 	"crypto/ecdsa"
-	"crypto/sha256"
 	"fmt"
 	"log"
+	"math/big"
 
 	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 type LocalBlobRequestSigner struct {
@@ -31,13 +32,13 @@ func NewLocalBlobRequestSigner(privateKeyHex string) *LocalBlobRequestSigner {
 }
 
 func (s *LocalBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, error) {
-	blobKey, err := header.BlobKey()
+	requestHash, err := header.RequestHash()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get blob key: %v", err)
+		return nil, fmt.Errorf("failed to get request hash: %v", err)
 	}
 
-	// Sign the blob key using the private key
-	sig, err := crypto.Sign(blobKey[:], s.PrivateKey)
+	// Sign the request hash using the private key
+	sig, err := crypto.Sign(requestHash[:], s.PrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign hash: %v", err)
 	}
@@ -45,14 +46,14 @@ func (s *LocalBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byt
 	return sig, nil
 }
 
-func (s *LocalBlobRequestSigner) SignPaymentStateRequest() ([]byte, error) {
+func (s *LocalBlobRequestSigner) SignPaymentStateRequest(timestamp uint64) ([]byte, error) {
 	accountId, err := s.GetAccountID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account ID: %v", err)
 	}
 
-	hash := sha256.Sum256([]byte(accountId))
-	// Sign the account ID using the private key
+	hash := paymentStateRequestHash(accountId, timestamp)
+	// Sign the account ID and timestamp using the private key
 	sig, err := crypto.Sign(hash[:], s.PrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign hash: %v", err)
@@ -68,6 +69,65 @@ func (s *LocalBlobRequestSigner) GetAccountID() (string, error) {
 
 }
 
+// TypedDataBlobRequestSigner signs the requests of the address account of its private key over EIP-712 typed data.
+type TypedDataBlobRequestSigner struct {
+	PrivateKey *ecdsa.PrivateKey
+	ChainID    *big.Int
+}
+
+var _ core.BlobRequestSigner = &TypedDataBlobRequestSigner{}
+
+func NewTypedDataBlobRequestSigner(privateKeyHex string, chainID *big.Int) (*TypedDataBlobRequestSigner, error) {
+	privateKey, err := crypto.ToECDSA(common.FromHex(privateKeyHex))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	if chainID == nil {
+		return nil, fmt.Errorf("chain ID is required")
+	}
+
+	return &TypedDataBlobRequestSigner{
+		PrivateKey: privateKey,
+		ChainID:    chainID,
+	}, nil
+}
+
+func (s *TypedDataBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, error) {
+	typedData, err := BlobRequestTypedData(header, s.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return s.signTypedData(typedData)
+}
+
+func (s *TypedDataBlobRequestSigner) SignPaymentStateRequest(timestamp uint64) ([]byte, error) {
+	accountId, err := s.GetAccountID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account ID: %v", err)
+	}
+	typedData, err := PaymentStateRequestTypedData(accountId, timestamp, s.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return s.signTypedData(typedData)
+}
+
+func (s *TypedDataBlobRequestSigner) GetAccountID() (string, error) {
+	return crypto.PubkeyToAddress(s.PrivateKey.PublicKey).Hex(), nil
+}
+
+func (s *TypedDataBlobRequestSigner) signTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, err := typedDataHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(hash, s.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash: %v", err)
+	}
+	return sig, nil
+}
+
 type LocalNoopSigner struct{}
 
 var _ core.BlobRequestSigner = &LocalNoopSigner{}
@@ -80,7 +140,7 @@ func (s *LocalNoopSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, erro
 	return nil, fmt.Errorf("noop signer cannot sign blob request")
 }
 
-func (s *LocalNoopSigner) SignPaymentStateRequest() ([]byte, error) {
+func (s *LocalNoopSigner) SignPaymentStateRequest(timestamp uint64) ([]byte, error) {
 	return nil, fmt.Errorf("noop signer cannot sign payment state request")
 }
 
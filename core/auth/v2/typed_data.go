@@ -0,0 +1,297 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// TypedDataDomainName and TypedDataDomainVersion identify the EIP-712 domain of the EigenDA requests
+	TypedDataDomainName    = "EigenDA"
+	TypedDataDomainVersion = "2"
+
+	// maxContractSignatureAccounts is the number of accounts whose ERC-1271 verification limiters are kept
+	maxContractSignatureAccounts = 10000
+)
+
+var (
+	// erc1271MagicValue is returned by isValidSignature for valid signatures, as specified in ERC-1271
+	erc1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+	erc1271ABI = mustParseABI(`[{"type":"function","name":"isValidSignature","stateMutability":"view",` +
+		`"inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],` +
+		`"outputs":[{"name":"magicValue","type":"bytes4"}]}]`)
+
+	typedDataDomainTypes = []apitypes.Type{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	}
+)
+
+func mustParseABI(json string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(json))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// IsAddressAccountID returns true if the account ID is an ethereum address, rather than a public key. Requests of
+// address accounts are signed over EIP-712 typed data, so that they can be signed by hardware wallets and smart
+// contract wallets.
+func IsAddressAccountID(accountID string) bool {
+	return common.IsHexAddress(accountID)
+}
+
+// BlobRequestTypedData returns the EIP-712 typed data a blob request of an address account is signed over.
+// The blob key commits to the blob header fields certified on chain, and the retention period and security
+// thresholds cover the request options the blob key leaves out. The other fields are included so that wallets can
+// display them. The timestamp is signed as a uint64, as in the payment state requests, so negative timestamps are
+// rejected.
+func BlobRequestTypedData(header *core.BlobHeader, chainID *big.Int) (apitypes.TypedData, error) {
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return apitypes.TypedData{}, fmt.Errorf("failed to get blob key: %w", err)
+	}
+	if !IsAddressAccountID(header.PaymentMetadata.AccountID) {
+		return apitypes.TypedData{}, fmt.Errorf("account ID %s is not an address", header.PaymentMetadata.AccountID)
+	}
+	if header.PaymentMetadata.Timestamp < 0 {
+		return apitypes.TypedData{}, fmt.Errorf("timestamp %d is negative", header.PaymentMetadata.Timestamp)
+	}
+	cumulativePayment := header.PaymentMetadata.CumulativePayment
+	if cumulativePayment == nil {
+		cumulativePayment = big.NewInt(0)
+	}
+	securityThresholds := make([]interface{}, len(header.SecurityThresholds))
+	for i, t := range header.SecurityThresholds {
+		securityThresholds[i] = map[string]interface{}{
+			"quorumId":              big.NewInt(int64(t.QuorumID)).String(),
+			"confirmationThreshold": big.NewInt(int64(t.ConfirmationThreshold)).String(),
+			"adversaryThreshold":    big.NewInt(int64(t.AdversaryThreshold)).String(),
+		}
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": typedDataDomainTypes,
+			"DisperseBlob": {
+				{Name: "blobKey", Type: "bytes32"},
+				{Name: "account", Type: "address"},
+				{Name: "quorumNumbers", Type: "bytes"},
+				{Name: "cumulativePayment", Type: "uint256"},
+				{Name: "timestamp", Type: "uint64"},
+				{Name: "retentionPeriod", Type: "uint64"},
+				{Name: "securityThresholds", Type: "QuorumSecurityThresholds[]"},
+			},
+			"QuorumSecurityThresholds": {
+				{Name: "quorumId", Type: "uint8"},
+				{Name: "confirmationThreshold", Type: "uint8"},
+				{Name: "adversaryThreshold", Type: "uint8"},
+			},
+		},
+		PrimaryType: "DisperseBlob",
+		Domain:      typedDataDomain(chainID),
+		Message: apitypes.TypedDataMessage{
+			"blobKey":            hexutil.Encode(blobKey[:]),
+			"account":            header.PaymentMetadata.AccountID,
+			"quorumNumbers":      hexutil.Encode(header.QuorumNumbers),
+			"cumulativePayment":  cumulativePayment.String(),
+			"timestamp":          new(big.Int).SetUint64(uint64(header.PaymentMetadata.Timestamp)).String(),
+			"retentionPeriod":    new(big.Int).SetUint64(uint64(header.RetentionPeriod / time.Second)).String(),
+			"securityThresholds": securityThresholds,
+		},
+	}, nil
+}
+
+// PaymentStateRequestTypedData returns the EIP-712 typed data a payment state request of an address account is
+// signed over. The timestamp makes each request unique, so that replayed requests can be rejected.
+func PaymentStateRequestTypedData(accountID string, timestamp uint64, chainID *big.Int) (apitypes.TypedData, error) {
+	if !IsAddressAccountID(accountID) {
+		return apitypes.TypedData{}, fmt.Errorf("account ID %s is not an address", accountID)
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": typedDataDomainTypes,
+			"GetPaymentState": {
+				{Name: "account", Type: "address"},
+				{Name: "timestamp", Type: "uint64"},
+			},
+		},
+		PrimaryType: "GetPaymentState",
+		Domain:      typedDataDomain(chainID),
+		Message: apitypes.TypedDataMessage{
+			"account":   accountID,
+			"timestamp": new(big.Int).SetUint64(timestamp).String(),
+		},
+	}, nil
+}
+
+func typedDataDomain(chainID *big.Int) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:    TypedDataDomainName,
+		Version: TypedDataDomainVersion,
+		ChainId: (*math.HexOrDecimal256)(chainID),
+	}
+}
+
+// typedDataHash returns the EIP-712 hash of the typed data, which is what is signed
+func typedDataHash(typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+	return hash, nil
+}
+
+// TypedDataVerifier verifies EIP-712 typed data signatures of address accounts. Signatures of externally owned
+// accounts are verified by recovering the signer, and, if a contract caller is given, signatures of smart contract
+// wallets are verified by the wallet contract itself, as specified in ERC-1271.
+type TypedDataVerifier struct {
+	chainID *big.Int
+	// contractCaller is used to verify ERC-1271 signatures, nil if smart contract wallets are not supported
+	contractCaller bind.ContractCaller
+	// contractSignaturesPerSecond is the rate of ERC-1271 verifications allowed per account
+	contractSignaturesPerSecond float64
+	// contractSignatureLimiters limit the rate of ERC-1271 verifications of the recently seen accounts, since any
+	// signature that doesn't recover to the account costs the RPC node an eth_getCode and possibly an eth_call. They
+	// are kept per account, so that the invalid signatures of one account don't hold up the wallets of the others.
+	contractSignatureLimiters *lru.Cache[common.Address, *rate.Limiter]
+}
+
+// NewTypedDataVerifier creates a TypedDataVerifier for the given chain. The contract caller is optional. Smart
+// contract wallet signatures are verified at up to contractSignaturesPerSecond per account, signatures beyond this
+// rate are rejected.
+func NewTypedDataVerifier(chainID *big.Int, contractCaller bind.ContractCaller, contractSignaturesPerSecond float64) (*TypedDataVerifier, error) {
+	if chainID == nil {
+		return nil, errors.New("chain ID is required")
+	}
+	if contractCaller != nil && contractSignaturesPerSecond <= 0 {
+		return nil, errors.New("contract signatures per second must be positive")
+	}
+	limiters, err := lru.New[common.Address, *rate.Limiter](maxContractSignatureAccounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create limiter cache: %w", err)
+	}
+	return &TypedDataVerifier{
+		chainID:                     chainID,
+		contractCaller:              contractCaller,
+		contractSignaturesPerSecond: contractSignaturesPerSecond,
+		contractSignatureLimiters:   limiters,
+	}, nil
+}
+
+// VerifyBlobRequest verifies the signature of a blob request of an address account.
+func (v *TypedDataVerifier) VerifyBlobRequest(ctx context.Context, header *core.BlobHeader) error {
+	typedData, err := BlobRequestTypedData(header, v.chainID)
+	if err != nil {
+		return err
+	}
+	hash, err := typedDataHash(typedData)
+	if err != nil {
+		return err
+	}
+	return v.verifySignature(ctx, hash, header.Signature, header.PaymentMetadata.AccountID)
+}
+
+// VerifyPaymentStateRequest verifies the signature of a payment state request of an address account.
+func (v *TypedDataVerifier) VerifyPaymentStateRequest(ctx context.Context, signature []byte, accountID string, timestamp uint64) error {
+	typedData, err := PaymentStateRequestTypedData(accountID, timestamp, v.chainID)
+	if err != nil {
+		return err
+	}
+	hash, err := typedDataHash(typedData)
+	if err != nil {
+		return err
+	}
+	return v.verifySignature(ctx, hash, signature, accountID)
+}
+
+func (v *TypedDataVerifier) verifySignature(ctx context.Context, hash []byte, signature []byte, accountID string) error {
+	// Address account IDs must be checksummed, so that an account can't be used under several IDs
+	account := common.HexToAddress(accountID)
+	if account.Hex() != accountID {
+		return fmt.Errorf("account ID %s is not an EIP-55 checksummed address", accountID)
+	}
+
+	if len(signature) == 65 {
+		// Wallets set the recovery ID to 27 or 28
+		sig := bytes.Clone(signature)
+		if sig[64] >= 27 {
+			sig[64] -= 27
+		}
+		pubKey, err := crypto.SigToPub(hash, sig)
+		if err == nil && crypto.PubkeyToAddress(*pubKey) == account {
+			return nil
+		}
+	}
+
+	if v.contractCaller == nil {
+		return errors.New("signature doesn't match with provided account")
+	}
+	if !v.contractSignatureLimiter(account).Allow() {
+		return errors.New("too many smart contract wallet signatures to verify, try again later")
+	}
+	return v.verifyContractSignature(ctx, hash, signature, account)
+}
+
+// contractSignatureLimiter returns the ERC-1271 verification limiter of the account
+func (v *TypedDataVerifier) contractSignatureLimiter(account common.Address) *rate.Limiter {
+	limiter, ok := v.contractSignatureLimiters.Get(account)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(v.contractSignaturesPerSecond), max(1, int(v.contractSignaturesPerSecond)))
+		// another request of the account may have added a limiter in the meantime, which is then kept
+		if previous, found, _ := v.contractSignatureLimiters.PeekOrAdd(account, limiter); found {
+			limiter = previous
+		}
+	}
+	return limiter
+}
+
+// verifyContractSignature verifies the signature of a smart contract wallet with its isValidSignature function
+func (v *TypedDataVerifier) verifyContractSignature(ctx context.Context, hash []byte, signature []byte, account common.Address) error {
+	code, err := v.contractCaller.CodeAt(ctx, account, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code of account %s: %w", account.Hex(), err)
+	}
+	if len(code) == 0 {
+		return errors.New("signature doesn't match with provided account")
+	}
+
+	callData, err := erc1271ABI.Pack("isValidSignature", [32]byte(hash), signature)
+	if err != nil {
+		return fmt.Errorf("failed to pack isValidSignature call: %w", err)
+	}
+	result, err := v.contractCaller.CallContract(ctx, ethereum.CallMsg{To: &account, Data: callData}, nil)
+	if err != nil {
+		return fmt.Errorf("isValidSignature call to %s failed: %w", account.Hex(), err)
+	}
+	outputs, err := erc1271ABI.Unpack("isValidSignature", result)
+	if err != nil || len(outputs) != 1 {
+		return fmt.Errorf("invalid isValidSignature result from %s", account.Hex())
+	}
+	magicValue, ok := outputs[0].([4]byte)
+	if !ok || magicValue != erc1271MagicValue {
+		return errors.New("signature rejected by account contract")
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package v2_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var chainID = big.NewInt(17000)
+
+// walletContract is a fake smart contract wallet accepting a fixed signature through ERC-1271
+type walletContract struct {
+	address   common.Address
+	signature []byte
+}
+
+func (w *walletContract) CodeAt(ctx context.Context, contract common.Address, _ *big.Int) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if contract == w.address {
+		return []byte{0x60}, nil
+	}
+	return nil, nil
+}
+
+func (w *walletContract) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	result := make([]byte, 32)
+	if *call.To == w.address && bytes.Contains(call.Data, w.signature) {
+		copy(result, []byte{0x16, 0x26, 0xba, 0x7e})
+	}
+	return result, nil
+}
+
+func TestTypedDataAuthentication(t *testing.T) {
+	signer, err := auth.NewTypedDataBlobRequestSigner(privateKeyHex, chainID)
+	require.NoError(t, err)
+	verifier, err := auth.NewTypedDataVerifier(chainID, nil, 0)
+	require.NoError(t, err)
+	authenticator := auth.NewTypedDataAuthenticator(nil, verifier)
+
+	accountId, err := signer.GetAccountID()
+	require.NoError(t, err)
+	assert.True(t, auth.IsAddressAccountID(accountId))
+	header := testHeader(t, accountId)
+	header.Signature, err = signer.SignBlobRequest(header)
+	require.NoError(t, err)
+	assert.NoError(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// wallets set the recovery ID to 27 or 28
+	header.Signature[64] += 27
+	assert.NoError(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// typed data signatures are only accepted if enabled
+	assert.Error(t, auth.NewAuthenticator().AuthenticateBlobRequest(context.Background(), header))
+
+	// the signature is bound to the chain
+	otherChainSigner, err := auth.NewTypedDataBlobRequestSigner(privateKeyHex, big.NewInt(1))
+	require.NoError(t, err)
+	header.Signature, err = otherChainSigner.SignBlobRequest(header)
+	require.NoError(t, err)
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// the signature is bound to the account
+	wrongSigner, err := auth.NewTypedDataBlobRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcded", chainID)
+	require.NoError(t, err)
+	header.Signature, err = wrongSigner.SignBlobRequest(header)
+	require.NoError(t, err)
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// the account ID must be checksummed
+	header = testHeader(t, strings.ToLower(accountId))
+	header.Signature, err = signer.SignBlobRequest(header)
+	require.NoError(t, err)
+	assert.ErrorContains(t, authenticator.AuthenticateBlobRequest(context.Background(), header), "checksummed")
+
+	// the signature covers the retention period
+	header = testHeader(t, accountId)
+	header.RetentionPeriod = 48 * time.Hour
+	header.Signature, err = signer.SignBlobRequest(header)
+	require.NoError(t, err)
+	assert.NoError(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+	header.RetentionPeriod = 72 * time.Hour
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// the signature covers the security thresholds
+	header = testHeader(t, accountId)
+	header.SecurityThresholds = []corev2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 80, AdversaryThreshold: 40}}
+	header.Signature, err = signer.SignBlobRequest(header)
+	require.NoError(t, err)
+	assert.NoError(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+	header.SecurityThresholds[0].ConfirmationThreshold = 55
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// the timestamp is signed as a uint64
+	header = testHeader(t, accountId)
+	header.PaymentMetadata.Timestamp = -1
+	_, err = signer.SignBlobRequest(header)
+	assert.ErrorContains(t, err, "negative")
+
+	// the signature is over the typed data, not the blob key
+	legacySigner := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	header = testHeader(t, accountId)
+	header.Signature, err = legacySigner.SignBlobRequest(header)
+	require.NoError(t, err)
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+}
+
+func TestTypedDataPaymentStateAuthentication(t *testing.T) {
+	ctx := context.Background()
+	signer, err := auth.NewTypedDataBlobRequestSigner(privateKeyHex, chainID)
+	require.NoError(t, err)
+	verifier, err := auth.NewTypedDataVerifier(chainID, nil, 0)
+	require.NoError(t, err)
+	authenticator := auth.NewTypedDataAuthenticator(nil, verifier)
+
+	accountId, err := signer.GetAccountID()
+	require.NoError(t, err)
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := signer.SignPaymentStateRequest(timestamp)
+	require.NoError(t, err)
+	assert.NoError(t, authenticator.AuthenticatePaymentStateRequest(ctx, signature, accountId, timestamp))
+	assert.Error(t, auth.NewAuthenticator().AuthenticatePaymentStateRequest(ctx, signature, accountId, timestamp))
+
+	otherAccountId := common.HexToAddress("0x00000000000000000000000000000000000000aa").Hex()
+	assert.Error(t, authenticator.AuthenticatePaymentStateRequest(ctx, signature, otherAccountId, timestamp))
+
+	// the signature covers the timestamp
+	assert.Error(t, authenticator.AuthenticatePaymentStateRequest(ctx, signature, accountId, timestamp+1))
+}
+
+func TestTypedDataContractWalletAuthentication(t *testing.T) {
+	wallet := &walletContract{
+		address:   common.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		signature: []byte("signature accepted by the wallet"),
+	}
+	verifier, err := auth.NewTypedDataVerifier(chainID, wallet, 100)
+	require.NoError(t, err)
+	authenticator := auth.NewTypedDataAuthenticator(nil, verifier)
+
+	header := testHeader(t, wallet.address.Hex())
+	header.Signature = wallet.signature
+	assert.NoError(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+	assert.NoError(t, authenticator.AuthenticatePaymentStateRequest(context.Background(), wallet.signature, wallet.address.Hex(), 1))
+
+	// rejected by the wallet
+	header.Signature = []byte("other signature")
+	assert.ErrorContains(t, authenticator.AuthenticateBlobRequest(context.Background(), header), "rejected")
+
+	// accounts without code are not contract wallets
+	header = testHeader(t, common.HexToAddress("0x00000000000000000000000000000000000000bb").Hex())
+	header.Signature = wallet.signature
+	assert.Error(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+
+	// contract wallets are only supported with a contract caller
+	verifier, err = auth.NewTypedDataVerifier(chainID, nil, 0)
+	require.NoError(t, err)
+	header = testHeader(t, wallet.address.Hex())
+	header.Signature = wallet.signature
+	assert.Error(t, auth.NewTypedDataAuthenticator(nil, verifier).AuthenticateBlobRequest(context.Background(), header))
+}
+
+func TestTypedDataContractWalletVerificationLimits(t *testing.T) {
+	wallet := &walletContract{
+		address:   common.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		signature: []byte("signature accepted by the wallet"),
+	}
+	_, err := auth.NewTypedDataVerifier(chainID, wallet, 0)
+	assert.Error(t, err)
+
+	verifier, err := auth.NewTypedDataVerifier(chainID, wallet, 1)
+	require.NoError(t, err)
+	authenticator := auth.NewTypedDataAuthenticator(nil, verifier)
+
+	// stale requests are rejected before verifying their signature, so they don't use up the limit of the account
+	replayGuard, err := auth.NewReplayGuard(time.Minute, time.Minute)
+	require.NoError(t, err)
+	header := testHeader(t, wallet.address.Hex())
+	header.PaymentMetadata.Timestamp = time.Now().Add(-time.Hour).UnixNano()
+	header.Signature = wallet.signature
+	assert.ErrorContains(t, auth.NewTypedDataAuthenticator(replayGuard, verifier).AuthenticateBlobRequest(context.Background(), header), "replay protection")
+
+	// the calls to the wallet are bound to the request context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	header = testHeader(t, wallet.address.Hex())
+	header.Signature = wallet.signature
+	assert.ErrorIs(t, authenticator.AuthenticateBlobRequest(ctx, header), context.Canceled)
+
+	// the limiter allowed the verification above, so the next one right after is rejected without calling the wallet
+	assert.ErrorContains(t, authenticator.AuthenticateBlobRequest(context.Background(), header), "too many")
+
+	// the verifications are limited per account, so other accounts are still verified
+	header = testHeader(t, common.HexToAddress("0x00000000000000000000000000000000000000bb").Hex())
+	header.Signature = wallet.signature
+	err = authenticator.AuthenticateBlobRequest(context.Background(), header)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "too many")
+
+	// signatures of externally owned accounts are not limited
+	signer, err := auth.NewTypedDataBlobRequestSigner(privateKeyHex, chainID)
+	require.NoError(t, err)
+	accountId, err := signer.GetAccountID()
+	require.NoError(t, err)
+	header = testHeader(t, accountId)
+	header.Signature, err = signer.SignBlobRequest(header)
+	require.NoError(t, err)
+	assert.NoError(t, authenticator.AuthenticateBlobRequest(context.Background(), header))
+}
@@ -124,6 +124,21 @@ type Reader interface {
 	// GetOnDemandPaymentByAccount returns on-demand payment of an account
 	GetOnDemandPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*OnDemandPayment, error)
 
+	// GetGlobalSymbolsPerSecond returns the global rate limit of on-demand dispersals in symbols per second.
+	GetGlobalSymbolsPerSecond(ctx context.Context) (uint64, error)
+
+	// GetGlobalRatePeriodInterval returns the length in seconds of the periods over which the global rate limit is metered.
+	GetGlobalRatePeriodInterval(ctx context.Context) (uint32, error)
+
+	// GetMinNumSymbols returns the min number of symbols charged for a blob.
+	GetMinNumSymbols(ctx context.Context) (uint32, error)
+
+	// GetPricePerSymbol returns the price in wei of a symbol dispersed on demand.
+	GetPricePerSymbol(ctx context.Context) (uint32, error)
+
+	// GetReservationWindow returns the length in seconds of the periods over which reservation usage is metered.
+	GetReservationWindow(ctx context.Context) (uint32, error)
+
 	// GetNumRelays returns the number of registered relays.
 	GetNumRelays(ctx context.Context) (uint32, error)
 
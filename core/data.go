@@ -4,8 +4,11 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	"slices"
 	"strconv"
+	"strings"
 
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	"github.com/Layr-Labs/eigenda/common"
@@ -497,14 +500,19 @@ type PaymentMetadata struct {
 	CumulativePayment *big.Int `json:"cumulative_payment"`
 	// Allow same blob to be dispersed multiple times within the same reservation period
 	Salt uint32 `json:"salt"`
+	// Timestamp is the time the request was created in nanoseconds since the Unix epoch, zero if not set.
+	// It lets the disperser reject replayed requests.
+	Timestamp int64 `json:"timestamp"`
 }
 
 // Hash returns the Keccak256 hash of the PaymentMetadata
+// The timestamp is only included in the hash if it is set, so that the hash of payment metadata without
+// a timestamp is unchanged.
 func (pm *PaymentMetadata) Hash() ([32]byte, error) {
 	if pm == nil {
 		return [32]byte{}, errors.New("payment metadata is nil")
 	}
-	blobHeaderType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+	components := []abi.ArgumentMarshaling{
 		{
 			Name: "accountID",
 			Type: "string",
@@ -521,7 +529,14 @@ func (pm *PaymentMetadata) Hash() ([32]byte, error) {
 			Name: "salt",
 			Type: "uint32",
 		},
-	})
+	}
+	if pm.Timestamp != 0 {
+		components = append(components, abi.ArgumentMarshaling{
+			Name: "timestamp",
+			Type: "int64",
+		})
+	}
+	blobHeaderType, err := abi.NewType("tuple", "", components)
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -557,7 +572,8 @@ func (pm *PaymentMetadata) MarshalDynamoDBAttributeValue() (types.AttributeValue
 			"CumulativePayment": &types.AttributeValueMemberN{
 				Value: pm.CumulativePayment.String(),
 			},
-			"Salt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", pm.Salt)},
+			"Salt":      &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", pm.Salt)},
+			"Timestamp": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", pm.Timestamp)},
 		},
 	}, nil
 }
@@ -579,6 +595,13 @@ func (pm *PaymentMetadata) UnmarshalDynamoDBAttributeValue(av types.AttributeVal
 		return fmt.Errorf("failed to parse Salt: %w", err)
 	}
 	pm.Salt = uint32(salt)
+	// payment metadata stored before timestamps were introduced has no timestamp
+	if timestamp, ok := m.Value["Timestamp"].(*types.AttributeValueMemberN); ok {
+		pm.Timestamp, err = strconv.ParseInt(timestamp.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse Timestamp: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -591,6 +614,7 @@ func (pm *PaymentMetadata) ToProtobuf() *commonpb.PaymentHeader {
 		ReservationPeriod: pm.ReservationPeriod,
 		CumulativePayment: pm.CumulativePayment.Bytes(),
 		Salt:              pm.Salt,
+		Timestamp:         pm.Timestamp,
 	}
 }
 
@@ -601,6 +625,7 @@ func ConvertToPaymentMetadata(ph *commonpb.PaymentHeader) *PaymentMetadata {
 		ReservationPeriod: ph.ReservationPeriod,
 		CumulativePayment: new(big.Int).SetBytes(ph.CumulativePayment),
 		Salt:              ph.Salt,
+		Timestamp:         ph.Timestamp,
 	}
 }
 
@@ -628,6 +653,164 @@ type BlobVersionParameters struct {
 	CodingRate      uint32
 	MaxNumOperators uint32
 	NumChunks       uint32
+	// QuorumProfiles overrides the coding rate and the number of chunks of specific quorums. A blob is encoded once
+	// for all of its quorums, with the largest number of chunks of its quorums, and each quorum is assigned the first
+	// chunks of its profile. The profiles must then keep the number of chunks needed to reconstruct a blob,
+	// NumChunks / CodingRate, so that all quorums share the chunk length of the blob version.
+	QuorumProfiles map[QuorumID]ReedSolomonProfile
+}
+
+// BlobVersionQuorumProfiles are the quorum profiles of each blob version
+type BlobVersionQuorumProfiles map[uint16]map[QuorumID]ReedSolomonProfile
+
+// ReedSolomonProfile is the Reed-Solomon parameterization of the chunks of a quorum
+type ReedSolomonProfile struct {
+	CodingRate uint32
+	NumChunks  uint32
+}
+
+// ForQuorum returns the parameters of the given quorum, i.e. the parameters of the blob version with the coding rate
+// and the number of chunks of the quorum profile, if any.
+func (p *BlobVersionParameters) ForQuorum(quorum QuorumID) *BlobVersionParameters {
+	if p == nil {
+		return nil
+	}
+	profile, ok := p.QuorumProfiles[quorum]
+	if !ok {
+		return p
+	}
+	return &BlobVersionParameters{
+		CodingRate:      profile.CodingRate,
+		MaxNumOperators: p.MaxNumOperators,
+		NumChunks:       profile.NumChunks,
+	}
+}
+
+// NumChunksForQuorums returns the number of chunks a blob dispersed to the given quorums is encoded into, the largest
+// number of chunks of the quorums
+func (p *BlobVersionParameters) NumChunksForQuorums(quorums []QuorumID) uint32 {
+	numChunks := uint32(0)
+	for _, quorum := range quorums {
+		numChunks = max(numChunks, p.ForQuorum(quorum).NumChunks)
+	}
+	if numChunks == 0 {
+		return p.NumChunks
+	}
+	return numChunks
+}
+
+// Validate checks that the quorum profiles are compatible with the parameters of the blob version
+func (p *BlobVersionParameters) Validate() error {
+	if p.CodingRate == 0 || p.NumChunks == 0 {
+		return errors.New("coding rate and number of chunks must be greater than 0")
+	}
+	for quorum, profile := range p.QuorumProfiles {
+		if profile.CodingRate == 0 || profile.NumChunks == 0 {
+			return fmt.Errorf("coding rate and number of chunks of quorum %d must be greater than 0", quorum)
+		}
+		if profile.NumChunks&(profile.NumChunks-1) != 0 {
+			return fmt.Errorf("number of chunks %d of quorum %d is not a power of 2", profile.NumChunks, quorum)
+		}
+		if uint64(profile.NumChunks)*uint64(p.CodingRate) != uint64(p.NumChunks)*uint64(profile.CodingRate) {
+			return fmt.Errorf("profile of quorum %d (coding rate %d, %d chunks) doesn't keep the %d chunks needed to reconstruct a blob",
+				quorum, profile.CodingRate, profile.NumChunks, p.NumChunks/p.CodingRate)
+		}
+		if profile.NumChunks < p.MaxNumOperators {
+			return fmt.Errorf("number of chunks %d of quorum %d is less than the max number of operators %d", profile.NumChunks, quorum, p.MaxNumOperators)
+		}
+	}
+	return nil
+}
+
+// ParseQuorumProfiles parses quorum profiles given as <blob version>:<quorum>:<coding rate>:<number of chunks>,
+// and returns the profiles of each blob version
+func ParseQuorumProfiles(specs []string) (BlobVersionQuorumProfiles, error) {
+	profiles := make(BlobVersionQuorumProfiles)
+	for _, spec := range specs {
+		fields := strings.Split(spec, ":")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid quorum profile %q: expected <blob version>:<quorum>:<coding rate>:<number of chunks>", spec)
+		}
+		values := make([]uint64, len(fields))
+		for i, bitSize := range []int{16, 8, 32, 32} {
+			value, err := strconv.ParseUint(strings.TrimSpace(fields[i]), 10, bitSize)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quorum profile %q: %w", spec, err)
+			}
+			values[i] = value
+		}
+
+		version, quorum := uint16(values[0]), QuorumID(values[1])
+		if _, ok := profiles[version][quorum]; ok {
+			return nil, fmt.Errorf("duplicate profile for quorum %d of blob version %d", quorum, version)
+		}
+		if profiles[version] == nil {
+			profiles[version] = make(map[QuorumID]ReedSolomonProfile)
+		}
+		profiles[version][quorum] = ReedSolomonProfile{
+			CodingRate: uint32(values[2]),
+			NumChunks:  uint32(values[3]),
+		}
+	}
+	return profiles, nil
+}
+
+// Digest returns the Keccak256 hash of the profiles in the order of their blob versions and quorums, or nil if there
+// are no profiles. Parties exchange the digest to check that they use the same profiles.
+func (p BlobVersionQuorumProfiles) Digest() []byte {
+	versions := make([]uint16, 0, len(p))
+	for version, profiles := range p {
+		if len(profiles) > 0 {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+	slices.Sort(versions)
+
+	hasher := sha3.NewLegacyKeccak256()
+	buf := make([]byte, 11)
+	for _, version := range versions {
+		quorums := make([]QuorumID, 0, len(p[version]))
+		for quorum := range p[version] {
+			quorums = append(quorums, quorum)
+		}
+		slices.Sort(quorums)
+		for _, quorum := range quorums {
+			profile := p[version][quorum]
+			binary.BigEndian.PutUint16(buf[0:2], version)
+			buf[2] = quorum
+			binary.BigEndian.PutUint32(buf[3:7], profile.CodingRate)
+			binary.BigEndian.PutUint32(buf[7:11], profile.NumChunks)
+			hasher.Write(buf)
+		}
+	}
+	return hasher.Sum(nil)
+}
+
+// WithQuorumProfiles returns the parameters of the blob version with the given quorum profiles, if any. It fails if
+// the parameters already have different profiles, or if the profiles aren't compatible with the blob version.
+func (p *BlobVersionParameters) WithQuorumProfiles(profiles map[QuorumID]ReedSolomonProfile) (*BlobVersionParameters, error) {
+	if len(profiles) == 0 {
+		return p, nil
+	}
+	if len(p.QuorumProfiles) > 0 {
+		if !maps.Equal(p.QuorumProfiles, profiles) {
+			return nil, errors.New("quorum profiles mismatch")
+		}
+		return p, nil
+	}
+	params := &BlobVersionParameters{
+		CodingRate:      p.CodingRate,
+		MaxNumOperators: p.MaxNumOperators,
+		NumChunks:       p.NumChunks,
+		QuorumProfiles:  profiles,
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return params, nil
 }
 
 // IsActive returns true if the reservation is active at the given timestamp
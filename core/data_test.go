@@ -279,3 +279,115 @@ func TestReservedPayment_IsActive(t *testing.T) {
 		})
 	}
 }
+
+func TestBlobVersionParameters_QuorumProfiles(t *testing.T) {
+	params := &core.BlobVersionParameters{
+		CodingRate:      8,
+		MaxNumOperators: 3537,
+		NumChunks:       8192,
+		QuorumProfiles: map[core.QuorumID]core.ReedSolomonProfile{
+			1: {CodingRate: 16, NumChunks: 16384},
+			2: {CodingRate: 4, NumChunks: 4096},
+		},
+	}
+	assert.NoError(t, params.Validate())
+
+	assert.Same(t, params, params.ForQuorum(0))
+	assert.Equal(t, &core.BlobVersionParameters{CodingRate: 16, MaxNumOperators: 3537, NumChunks: 16384}, params.ForQuorum(1))
+	assert.Equal(t, &core.BlobVersionParameters{CodingRate: 4, MaxNumOperators: 3537, NumChunks: 4096}, params.ForQuorum(2))
+
+	assert.Equal(t, uint32(8192), params.NumChunksForQuorums([]core.QuorumID{0}))
+	assert.Equal(t, uint32(8192), params.NumChunksForQuorums([]core.QuorumID{0, 2}))
+	assert.Equal(t, uint32(16384), params.NumChunksForQuorums([]core.QuorumID{0, 1, 2}))
+	assert.Equal(t, uint32(4096), params.NumChunksForQuorums([]core.QuorumID{2}))
+	assert.Equal(t, uint32(8192), params.NumChunksForQuorums(nil))
+
+	// the profiles must keep the number of chunks needed to reconstruct a blob
+	params.QuorumProfiles[2] = core.ReedSolomonProfile{CodingRate: 8, NumChunks: 4096}
+	assert.ErrorContains(t, params.Validate(), "doesn't keep the 1024 chunks needed to reconstruct a blob")
+	params.QuorumProfiles[2] = core.ReedSolomonProfile{CodingRate: 6, NumChunks: 6144}
+	assert.ErrorContains(t, params.Validate(), "not a power of 2")
+	params.QuorumProfiles[2] = core.ReedSolomonProfile{CodingRate: 2, NumChunks: 2048}
+	assert.ErrorContains(t, params.Validate(), "less than the max number of operators")
+	params.QuorumProfiles[2] = core.ReedSolomonProfile{}
+	assert.Error(t, params.Validate())
+}
+
+func TestBlobVersionParameters_WithQuorumProfiles(t *testing.T) {
+	params := &core.BlobVersionParameters{CodingRate: 8, MaxNumOperators: 3537, NumChunks: 8192}
+	profiles := map[core.QuorumID]core.ReedSolomonProfile{
+		1: {CodingRate: 16, NumChunks: 16384},
+	}
+
+	withProfiles, err := params.WithQuorumProfiles(profiles)
+	assert.NoError(t, err)
+	assert.Equal(t, profiles, withProfiles.QuorumProfiles)
+	assert.Empty(t, params.QuorumProfiles)
+
+	same, err := params.WithQuorumProfiles(nil)
+	assert.NoError(t, err)
+	assert.Same(t, params, same)
+	same, err = withProfiles.WithQuorumProfiles(profiles)
+	assert.NoError(t, err)
+	assert.Same(t, withProfiles, same)
+
+	_, err = withProfiles.WithQuorumProfiles(map[core.QuorumID]core.ReedSolomonProfile{
+		1: {CodingRate: 4, NumChunks: 4096},
+	})
+	assert.ErrorContains(t, err, "quorum profiles mismatch")
+	same, err = withProfiles.WithQuorumProfiles(nil)
+	assert.NoError(t, err)
+	assert.Same(t, withProfiles, same)
+	_, err = params.WithQuorumProfiles(map[core.QuorumID]core.ReedSolomonProfile{
+		1: {CodingRate: 8, NumChunks: 4096},
+	})
+	assert.Error(t, err)
+}
+
+func TestBlobVersionQuorumProfiles_Digest(t *testing.T) {
+	assert.Nil(t, core.BlobVersionQuorumProfiles(nil).Digest())
+	assert.Nil(t, core.BlobVersionQuorumProfiles{0: {}}.Digest())
+
+	profiles := core.BlobVersionQuorumProfiles{
+		0: {
+			1: {CodingRate: 16, NumChunks: 16384},
+			2: {CodingRate: 4, NumChunks: 4096},
+		},
+		1: {
+			1: {CodingRate: 2, NumChunks: 1024},
+		},
+	}
+	digest := profiles.Digest()
+	assert.Len(t, digest, 32)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, digest, profiles.Digest())
+	}
+
+	profiles[1][1] = core.ReedSolomonProfile{CodingRate: 2, NumChunks: 2048}
+	assert.NotEqual(t, digest, profiles.Digest())
+}
+
+func TestParseQuorumProfiles(t *testing.T) {
+	profiles, err := core.ParseQuorumProfiles([]string{"0:1:16:16384", "0:2:4:4096", "1:1:2:1024"})
+	assert.NoError(t, err)
+	assert.Equal(t, core.BlobVersionQuorumProfiles{
+		0: {
+			1: {CodingRate: 16, NumChunks: 16384},
+			2: {CodingRate: 4, NumChunks: 4096},
+		},
+		1: {
+			1: {CodingRate: 2, NumChunks: 1024},
+		},
+	}, profiles)
+
+	profiles, err = core.ParseQuorumProfiles(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, profiles)
+
+	for _, spec := range []string{"0:1:16", "0:1:16:16384:1", "0:256:16:16384", "a:1:16:16384", "0:1:-1:16384"} {
+		_, err = core.ParseQuorumProfiles([]string{spec})
+		assert.Error(t, err, spec)
+	}
+	_, err = core.ParseQuorumProfiles([]string{"0:1:16:16384", "0:1:4:4096"})
+	assert.ErrorContains(t, err, "duplicate profile")
+}
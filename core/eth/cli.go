@@ -0,0 +1,30 @@
+package eth
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/urfave/cli"
+)
+
+var (
+	QuorumProfilesFlagName = "blob-quorum-profiles"
+)
+
+// QuorumProfilesFlags returns the flags configuring the quorum profiles of the blob versions
+func QuorumProfilesFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringSliceFlag{
+			Name: common.PrefixFlag(flagPrefix, QuorumProfilesFlagName),
+			Usage: "Reed-Solomon profiles of specific quorums, given as <blob version>:<quorum>:<coding rate>:<number of chunks>. " +
+				"A profile must keep the number of chunks needed to reconstruct a blob of the blob version, and all profiles must be " +
+				"configured identically on the disperser, the relays and the nodes",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_QUORUM_PROFILES"),
+		},
+	}
+}
+
+// ReadQuorumProfiles reads the quorum profiles of the blob versions
+func ReadQuorumProfiles(ctx *cli.Context, flagPrefix string) (core.BlobVersionQuorumProfiles, error) {
+	return core.ParseQuorumProfiles(ctx.GlobalStringSlice(common.PrefixFlag(flagPrefix, QuorumProfilesFlagName)))
+}
@@ -3,6 +3,7 @@ package eth
 import (
 	"context"
 	"crypto/ecdsa"
+	"fmt"
 	"math/big"
 	"strings"
 
@@ -43,6 +44,7 @@ type ContractBindings struct {
 	AVSDirectory          *avsdir.ContractAVSDirectory
 	SocketRegistry        *socketreg.ContractSocketRegistry
 	PaymentVault          *paymentvault.ContractPaymentVault
+	PaymentVaultAddr      gethcommon.Address
 	RelayRegistry         *relayreg.ContractEigenDARelayRegistry
 	ThresholdRegistry     *thresholdreg.ContractEigenDAThresholdRegistry
 }
@@ -51,6 +53,9 @@ type Reader struct {
 	ethClient common.EthClient
 	logger    logging.Logger
 	bindings  *ContractBindings
+
+	// quorumProfiles are the quorum profiles of each blob version, which aren't recorded onchain
+	quorumProfiles core.BlobVersionQuorumProfiles
 }
 
 var _ core.Reader = (*Reader)(nil)
@@ -239,6 +244,7 @@ func (t *Reader) updateContractBindings(blsOperatorStateRetrieverAddr, eigenDASe
 		DelegationManager:     contractDelegationManager,
 		RelayRegistry:         contractRelayRegistry,
 		PaymentVault:          contractPaymentVault,
+		PaymentVaultAddr:      paymentVaultAddr,
 		ThresholdRegistry:     contractThresholdRegistry,
 	}
 	return nil
@@ -661,6 +667,12 @@ func (t *Reader) GetNumBlobVersions(ctx context.Context) (uint16, error) {
 	})
 }
 
+// SetQuorumProfiles sets the quorum profiles added to the parameters of each blob version. The profiles must be the
+// same for the disperser, the relays, the nodes and the retrieval clients.
+func (t *Reader) SetQuorumProfiles(profiles core.BlobVersionQuorumProfiles) {
+	t.quorumProfiles = profiles
+}
+
 func (t *Reader) GetVersionedBlobParams(ctx context.Context, blobVersion uint16) (*core.BlobVersionParameters, error) {
 	params, err := t.bindings.EigenDAServiceManager.GetBlobParams(&bind.CallOpts{
 		Context: ctx,
@@ -668,11 +680,18 @@ func (t *Reader) GetVersionedBlobParams(ctx context.Context, blobVersion uint16)
 	if err != nil {
 		return nil, err
 	}
-	return &core.BlobVersionParameters{
+	blobParams := &core.BlobVersionParameters{
 		CodingRate:      uint32(params.CodingRate),
 		NumChunks:       uint32(params.NumChunks),
 		MaxNumOperators: uint32(params.MaxNumOperators),
-	}, nil
+		QuorumProfiles:  t.quorumProfiles[blobVersion],
+	}
+	if len(blobParams.QuorumProfiles) > 0 {
+		if err := blobParams.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid quorum profiles of blob version %d: %w", blobVersion, err)
+		}
+	}
+	return blobParams, nil
 }
 
 func (t *Reader) GetAllVersionedBlobParams(ctx context.Context) (map[uint16]*core.BlobVersionParameters, error) {
@@ -704,14 +723,32 @@ func (t *Reader) GetAllVersionedBlobParams(ctx context.Context) (map[uint16]*cor
 	return res, nil
 }
 
+// PaymentVaultAddress returns the address of the payment vault contract
+func (t *Reader) PaymentVaultAddress() (gethcommon.Address, error) {
+	if t.bindings.PaymentVault == nil {
+		return gethcommon.Address{}, errors.New("payment vault not deployed")
+	}
+	return t.bindings.PaymentVaultAddr, nil
+}
+
 func (t *Reader) GetReservedPayments(ctx context.Context, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.ReservedPayment, error) {
+	return t.getReservedPayments(&bind.CallOpts{Context: ctx}, accountIDs)
+}
+
+// GetReservedPaymentsAtBlock returns the active reservations of the accounts as of the given block
+func (t *Reader) GetReservedPaymentsAtBlock(ctx context.Context, accountIDs []gethcommon.Address, blockNumber uint64) (map[gethcommon.Address]*core.ReservedPayment, error) {
+	return t.getReservedPayments(&bind.CallOpts{
+		Context:     ctx,
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+	}, accountIDs)
+}
+
+func (t *Reader) getReservedPayments(opts *bind.CallOpts, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.ReservedPayment, error) {
 	if t.bindings.PaymentVault == nil {
 		return nil, errors.New("payment vault not deployed")
 	}
 	reservationsMap := make(map[gethcommon.Address]*core.ReservedPayment)
-	reservations, err := t.bindings.PaymentVault.GetReservations(&bind.CallOpts{
-		Context: ctx,
-	}, accountIDs)
+	reservations, err := t.bindings.PaymentVault.GetReservations(opts, accountIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -744,12 +781,23 @@ func (t *Reader) GetReservedPaymentByAccount(ctx context.Context, accountID geth
 }
 
 func (t *Reader) GetOnDemandPayments(ctx context.Context, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.OnDemandPayment, error) {
+	return t.getOnDemandPayments(&bind.CallOpts{Context: ctx}, accountIDs)
+}
+
+// GetOnDemandPaymentsAtBlock returns the on-demand payments of the accounts as of the given block
+func (t *Reader) GetOnDemandPaymentsAtBlock(ctx context.Context, accountIDs []gethcommon.Address, blockNumber uint64) (map[gethcommon.Address]*core.OnDemandPayment, error) {
+	return t.getOnDemandPayments(&bind.CallOpts{
+		Context:     ctx,
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+	}, accountIDs)
+}
+
+func (t *Reader) getOnDemandPayments(opts *bind.CallOpts, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.OnDemandPayment, error) {
 	if t.bindings.PaymentVault == nil {
 		return nil, errors.New("payment vault not deployed")
 	}
 	paymentsMap := make(map[gethcommon.Address]*core.OnDemandPayment)
-	payments, err := t.bindings.PaymentVault.GetOnDemandTotalDeposits(&bind.CallOpts{
-		Context: ctx}, accountIDs)
+	payments, err := t.bindings.PaymentVault.GetOnDemandTotalDeposits(opts, accountIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -331,6 +331,12 @@ func TestMetererOnDemand(t *testing.T) {
 		err = mt.MeterRequest(ctx, *header, symbolLength, quorumNumbers)
 		assert.NoError(t, err)
 	}
+	largestCumulativePayment, err := mt.OffchainStore.GetLargestCumulativePayment(ctx, accountID2.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Mul(priceCharged, big.NewInt(9)), largestCumulativePayment)
+	largestCumulativePayment, err = mt.OffchainStore.GetLargestCumulativePayment(ctx, crypto.PubkeyToAddress(unregisteredUser.PublicKey).Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), largestCumulativePayment)
 
 	// test cumulative payment on-chain constraint
 	header = createPaymentHeader(reservationPeriod, big.NewInt(2023), accountID2)
@@ -298,10 +298,17 @@ func (s *OffchainStore) GetLargestCumulativePayment(ctx context.Context, account
 		return big.NewInt(0), nil
 	}
 
-	var payment *big.Int
-	_, success := payment.SetString(payments[0]["CumulativePayments"].(*types.AttributeValueMemberN).Value, 10)
+	cumulativePaymentsAttr, ok := payments[0]["CumulativePayments"]
+	if !ok {
+		return nil, fmt.Errorf("CumulativePayments field not found in result")
+	}
+	cumulativePaymentsNum, ok := cumulativePaymentsAttr.(*types.AttributeValueMemberN)
+	if !ok {
+		return nil, fmt.Errorf("CumulativePayments has invalid type")
+	}
+	payment, success := new(big.Int).SetString(cumulativePaymentsNum.Value, 10)
 	if !success {
-		return nil, fmt.Errorf("failed to parse payment: %w", err)
+		return nil, fmt.Errorf("failed to parse payment: %s", cumulativePaymentsNum.Value)
 	}
 
 	return payment, nil
@@ -2,12 +2,23 @@ package meterer
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	// maxUnpaidAccounts is the number of accounts without a reservation, and separately without an on-demand
+	// payment, that are remembered while the payment state is watched
+	maxUnpaidAccounts = 100_000
+	// unpaidAccountTTL is how long an account without a payment is remembered
+	unpaidAccountTTL = 10 * time.Minute
 )
 
 // PaymentAccounts (For reservations and on-demand payments)
@@ -37,8 +48,31 @@ type OnchainPaymentState struct {
 	OnDemandLocks    sync.RWMutex
 
 	PaymentVaultParams atomic.Pointer[PaymentVaultParams]
+
+	// watched is set while a PaymentVaultWatcher keeps the cached payments up to date with the payment vault events.
+	// The cache is then authoritative: accounts without payments are remembered so that they aren't read from chain
+	// on every request, and cached payments aren't re-read on refresh. The watcher unsets it while it fails to follow
+	// the events, so that the payments are read from chain directly until it caught up again.
+	watched atomic.Bool
+	// watchedBlock is the last block whose events the watcher applied. Payments missing from the cache are read as
+	// of this block, so that the events after it apply on top of them.
+	watchedBlock atomic.Uint64
+	// generation is incremented when the cached payments are invalidated, so that payments read from chain before
+	// are not cached afterwards
+	generation atomic.Uint64
+
+	// unreservedAccounts and unfundedAccounts are the accounts known to have no reservation and no on-demand payment
+	// while the payment state is watched. They expire, so that they stay bounded for any number of accounts.
+	unpaidAccountsOnce sync.Once
+	unreservedAccounts *expirable.LRU[gethcommon.Address, struct{}]
+	unfundedAccounts   *expirable.LRU[gethcommon.Address, struct{}]
 }
 
+var (
+	errNoReservation     = errors.New("reservation does not exist for given account")
+	errNoOnDemandPayment = errors.New("ondemand payment does not exist for given account")
+)
+
 type PaymentVaultParams struct {
 	GlobalSymbolsPerSecond   uint64
 	GlobalRatePeriodInterval uint32
@@ -67,7 +101,7 @@ func NewOnchainPaymentState(ctx context.Context, tx *eth.Reader) (*OnchainPaymen
 }
 
 func (pcs *OnchainPaymentState) GetPaymentVaultParams(ctx context.Context) (*PaymentVaultParams, error) {
-	quorumNumbers, err := pcs.GetOnDemandQuorumNumbers(ctx)
+	quorumNumbers, err := pcs.readOnDemandQuorumNumbers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -107,14 +141,27 @@ func (pcs *OnchainPaymentState) GetPaymentVaultParams(ctx context.Context) (*Pay
 	}, nil
 }
 
-// RefreshOnchainPaymentState returns the current onchain payment state
-func (pcs *OnchainPaymentState) RefreshOnchainPaymentState(ctx context.Context) error {
+// RefreshPaymentVaultParams reads the current payment vault parameters from chain
+func (pcs *OnchainPaymentState) RefreshPaymentVaultParams(ctx context.Context) error {
 	paymentVaultParams, err := pcs.GetPaymentVaultParams(ctx)
 	if err != nil {
 		return err
 	}
-	// These parameters should be rarely updated, but we refresh them anyway
 	pcs.PaymentVaultParams.Store(paymentVaultParams)
+	return nil
+}
+
+// RefreshOnchainPaymentState returns the current onchain payment state
+func (pcs *OnchainPaymentState) RefreshOnchainPaymentState(ctx context.Context) error {
+	// These parameters should be rarely updated, but we refresh them anyway
+	if err := pcs.RefreshPaymentVaultParams(ctx); err != nil {
+		return err
+	}
+
+	// The cached payments are kept up to date by the watcher
+	if pcs.watched.Load() {
+		return nil
+	}
 
 	pcs.ReservationsLock.Lock()
 	accountIDs := make([]gethcommon.Address, 0, len(pcs.ReservedPayments))
@@ -124,6 +171,7 @@ func (pcs *OnchainPaymentState) RefreshOnchainPaymentState(ctx context.Context)
 
 	reservedPayments, err := pcs.tx.GetReservedPayments(ctx, accountIDs)
 	if err != nil {
+		pcs.ReservationsLock.Unlock()
 		return err
 	}
 	pcs.ReservedPayments = reservedPayments
@@ -137,6 +185,7 @@ func (pcs *OnchainPaymentState) RefreshOnchainPaymentState(ctx context.Context)
 
 	onDemandPayments, err := pcs.tx.GetOnDemandPayments(ctx, accountIDs)
 	if err != nil {
+		pcs.OnDemandLocks.Unlock()
 		return err
 	}
 	pcs.OnDemandPayments = onDemandPayments
@@ -148,20 +197,40 @@ func (pcs *OnchainPaymentState) RefreshOnchainPaymentState(ctx context.Context)
 // GetReservedPaymentByAccount returns a pointer to the active reservation for the given account ID; no writes will be made to the reservation
 func (pcs *OnchainPaymentState) GetReservedPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*core.ReservedPayment, error) {
 	pcs.ReservationsLock.RLock()
-	if reservation, ok := (pcs.ReservedPayments)[accountID]; ok {
+	if reservation, ok := (pcs.ReservedPayments)[accountID]; ok && reservation != nil {
 		pcs.ReservationsLock.RUnlock()
 		return reservation, nil
 	}
 	pcs.ReservationsLock.RUnlock()
 
+	if pcs.watched.Load() {
+		unreserved, _ := pcs.unpaidAccounts()
+		if unreserved.Contains(accountID) {
+			return nil, errNoReservation
+		}
+		// accounts without a reservation are omitted from the result, and remembered so that they aren't read again
+		generation := pcs.generation.Load()
+		reservations, err := pcs.tx.GetReservedPaymentsAtBlock(ctx, []gethcommon.Address{accountID}, pcs.watchedBlock.Load())
+		if err != nil {
+			return nil, err
+		}
+		res := pcs.cacheReservedPayment(accountID, reservations[accountID], generation)
+		if res == nil {
+			return nil, errNoReservation
+		}
+		return res, nil
+	}
+
 	// pulls the chain state
 	res, err := pcs.tx.GetReservedPaymentByAccount(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
-	pcs.ReservationsLock.Lock()
-	(pcs.ReservedPayments)[accountID] = res
-	pcs.ReservationsLock.Unlock()
+	if res != nil {
+		pcs.ReservationsLock.Lock()
+		(pcs.ReservedPayments)[accountID] = res
+		pcs.ReservationsLock.Unlock()
+	}
 
 	return res, nil
 }
@@ -169,25 +238,166 @@ func (pcs *OnchainPaymentState) GetReservedPaymentByAccount(ctx context.Context,
 // GetOnDemandPaymentByAccount returns a pointer to the on-demand payment for the given account ID; no writes will be made to the payment
 func (pcs *OnchainPaymentState) GetOnDemandPaymentByAccount(ctx context.Context, accountID gethcommon.Address) (*core.OnDemandPayment, error) {
 	pcs.OnDemandLocks.RLock()
-	if payment, ok := (pcs.OnDemandPayments)[accountID]; ok {
+	if payment, ok := (pcs.OnDemandPayments)[accountID]; ok && payment != nil {
 		pcs.OnDemandLocks.RUnlock()
 		return payment, nil
 	}
 	pcs.OnDemandLocks.RUnlock()
 
+	if pcs.watched.Load() {
+		_, unfunded := pcs.unpaidAccounts()
+		if unfunded.Contains(accountID) {
+			return nil, errNoOnDemandPayment
+		}
+		// accounts without a deposit are omitted from the result, and remembered so that they aren't read again
+		generation := pcs.generation.Load()
+		payments, err := pcs.tx.GetOnDemandPaymentsAtBlock(ctx, []gethcommon.Address{accountID}, pcs.watchedBlock.Load())
+		if err != nil {
+			return nil, err
+		}
+		res := pcs.cacheOnDemandPayment(accountID, payments[accountID], generation)
+		if res == nil {
+			return nil, errNoOnDemandPayment
+		}
+		return res, nil
+	}
+
 	// pulls the chain state
 	res, err := pcs.tx.GetOnDemandPaymentByAccount(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 
-	pcs.OnDemandLocks.Lock()
-	(pcs.OnDemandPayments)[accountID] = res
-	pcs.OnDemandLocks.Unlock()
+	if res != nil {
+		pcs.OnDemandLocks.Lock()
+		(pcs.OnDemandPayments)[accountID] = res
+		pcs.OnDemandLocks.Unlock()
+	}
 	return res, nil
 }
 
+// unpaidAccounts returns the accounts known to have no reservation and no on-demand payment, which are created on
+// first use so that the payment state can be constructed directly.
+func (pcs *OnchainPaymentState) unpaidAccounts() (unreserved, unfunded *expirable.LRU[gethcommon.Address, struct{}]) {
+	pcs.unpaidAccountsOnce.Do(func() {
+		pcs.unreservedAccounts = expirable.NewLRU[gethcommon.Address, struct{}](maxUnpaidAccounts, nil, unpaidAccountTTL)
+		pcs.unfundedAccounts = expirable.NewLRU[gethcommon.Address, struct{}](maxUnpaidAccounts, nil, unpaidAccountTTL)
+	})
+	return pcs.unreservedAccounts, pcs.unfundedAccounts
+}
+
+// cacheReservedPayment caches a reservation read from chain, unless the account was updated by an event or the
+// cache was invalidated in the meantime, and returns the reservation of the account.
+func (pcs *OnchainPaymentState) cacheReservedPayment(accountID gethcommon.Address, reservation *core.ReservedPayment, generation uint64) *core.ReservedPayment {
+	unreserved, _ := pcs.unpaidAccounts()
+	pcs.ReservationsLock.Lock()
+	defer pcs.ReservationsLock.Unlock()
+	if cached, ok := pcs.ReservedPayments[accountID]; ok && cached != nil {
+		return cached
+	}
+	if unreserved.Contains(accountID) {
+		return nil
+	}
+	if pcs.generation.Load() != generation {
+		return reservation
+	}
+	if reservation == nil {
+		unreserved.Add(accountID, struct{}{})
+	} else {
+		pcs.ReservedPayments[accountID] = reservation
+	}
+	return reservation
+}
+
+// cacheOnDemandPayment caches an on-demand payment read from chain, unless the account was updated by an event or
+// the cache was invalidated in the meantime, and returns the payment of the account.
+func (pcs *OnchainPaymentState) cacheOnDemandPayment(accountID gethcommon.Address, payment *core.OnDemandPayment, generation uint64) *core.OnDemandPayment {
+	_, unfunded := pcs.unpaidAccounts()
+	pcs.OnDemandLocks.Lock()
+	defer pcs.OnDemandLocks.Unlock()
+	if cached, ok := pcs.OnDemandPayments[accountID]; ok && cached != nil {
+		return cached
+	}
+	if unfunded.Contains(accountID) {
+		return nil
+	}
+	if pcs.generation.Load() != generation {
+		return payment
+	}
+	if payment == nil {
+		unfunded.Add(accountID, struct{}{})
+	} else {
+		pcs.OnDemandPayments[accountID] = payment
+	}
+	return payment
+}
+
+// UpdateReservedPayment sets the reservation of an account, nil if the account has no reservation.
+func (pcs *OnchainPaymentState) UpdateReservedPayment(accountID gethcommon.Address, reservation *core.ReservedPayment) {
+	unreserved, _ := pcs.unpaidAccounts()
+	pcs.ReservationsLock.Lock()
+	defer pcs.ReservationsLock.Unlock()
+	if reservation == nil {
+		delete(pcs.ReservedPayments, accountID)
+		unreserved.Add(accountID, struct{}{})
+		return
+	}
+	pcs.ReservedPayments[accountID] = reservation
+	unreserved.Remove(accountID)
+}
+
+// UpdateOnDemandPayment sets the on-demand payment of an account, nil if the account has no deposit.
+func (pcs *OnchainPaymentState) UpdateOnDemandPayment(accountID gethcommon.Address, payment *core.OnDemandPayment) {
+	_, unfunded := pcs.unpaidAccounts()
+	pcs.OnDemandLocks.Lock()
+	defer pcs.OnDemandLocks.Unlock()
+	if payment == nil {
+		delete(pcs.OnDemandPayments, accountID)
+		unfunded.Add(accountID, struct{}{})
+		return
+	}
+	pcs.OnDemandPayments[accountID] = payment
+	unfunded.Remove(accountID)
+}
+
+// setWatched sets whether the cached payments are kept up to date by a watcher which applied the events up to the
+// given block. The accounts known to have no payments are forgotten when the watcher stops, since they are no
+// longer updated.
+func (pcs *OnchainPaymentState) setWatched(watched bool, block uint64) {
+	pcs.watchedBlock.Store(block)
+	if pcs.watched.Swap(watched) && !watched {
+		unreserved, unfunded := pcs.unpaidAccounts()
+		unreserved.Purge()
+		unfunded.Purge()
+	}
+}
+
+// invalidatePayments drops all cached payments, so that they are read from chain again. The watcher invalidates the
+// payments when the blocks whose events it applied were reorged out.
+func (pcs *OnchainPaymentState) invalidatePayments() {
+	unreserved, unfunded := pcs.unpaidAccounts()
+	pcs.ReservationsLock.Lock()
+	pcs.OnDemandLocks.Lock()
+	defer pcs.OnDemandLocks.Unlock()
+	defer pcs.ReservationsLock.Unlock()
+
+	pcs.generation.Add(1)
+	pcs.ReservedPayments = make(map[gethcommon.Address]*core.ReservedPayment)
+	pcs.OnDemandPayments = make(map[gethcommon.Address]*core.OnDemandPayment)
+	unreserved.Purge()
+	unfunded.Purge()
+}
+
+// GetOnDemandQuorumNumbers returns the quorums on-demand payments can be used for. While the payment state is
+// watched, the quorums are returned from the periodically refreshed payment vault parameters.
 func (pcs *OnchainPaymentState) GetOnDemandQuorumNumbers(ctx context.Context) ([]uint8, error) {
+	if pcs.watched.Load() {
+		return pcs.PaymentVaultParams.Load().OnDemandQuorumNumbers, nil
+	}
+	return pcs.readOnDemandQuorumNumbers(ctx)
+}
+
+func (pcs *OnchainPaymentState) readOnDemandQuorumNumbers(ctx context.Context) ([]uint8, error) {
 	blockNumber, err := pcs.tx.GetCurrentBlockNumber(ctx)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,262 @@
+package meterer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	paymentvault "github.com/Layr-Labs/eigenda/contracts/bindings/PaymentVault"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxWatchedBlockRange is the largest number of blocks whose logs are read in one request
+const maxWatchedBlockRange = 1000
+
+// PaymentVaultLogReader reads the logs of the payment vault contract
+type PaymentVaultLogReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// PaymentVaultWatcher keeps the payments cached by an OnchainPaymentState up to date by following the events of the
+// payment vault, so that the meterer doesn't read the payments of an account from chain more than once.
+//
+// The watcher polls the logs of new blocks rather than subscribing to them, so that it works with HTTP RPC endpoints.
+// Reservation and deposit updates are applied to the cached accounts, and parameter updates trigger a refresh of the
+// payment vault parameters.
+//
+// While polling fails, the payment state reads the payments from chain directly. The watcher checks that the last
+// block it applied is still on the chain before applying newer blocks, and drops the cached payments if it was
+// reorged out, since the events applied from the old chain can't be reverted.
+type PaymentVaultWatcher struct {
+	state        *OnchainPaymentState
+	address      gethcommon.Address
+	logReader    PaymentVaultLogReader
+	filterer     *paymentvault.ContractPaymentVaultFilterer
+	pollInterval time.Duration
+	logger       logging.Logger
+
+	// lastBlock is the last block whose events were applied, and lastBlockHash its hash
+	lastBlock     uint64
+	lastBlockHash gethcommon.Hash
+	// failing is set while polling fails, until a poll succeeds
+	failing bool
+
+	reservationUpdatedID     gethcommon.Hash
+	onDemandPaymentUpdatedID gethcommon.Hash
+	paramsUpdatedIDs         map[gethcommon.Hash]struct{}
+}
+
+func NewPaymentVaultWatcher(
+	state *OnchainPaymentState,
+	address gethcommon.Address,
+	logReader PaymentVaultLogReader,
+	pollInterval time.Duration,
+	logger logging.Logger,
+) (*PaymentVaultWatcher, error) {
+	if state == nil {
+		return nil, errors.New("payment state is required")
+	}
+	if pollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+	filterer, err := paymentvault.NewContractPaymentVaultFilterer(address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment vault filterer: %w", err)
+	}
+	vaultABI, err := paymentvault.ContractPaymentVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payment vault ABI: %w", err)
+	}
+
+	paramsUpdatedIDs := make(map[gethcommon.Hash]struct{})
+	for _, name := range []string{
+		"PriceParamsUpdated",
+		"GlobalSymbolsPerPeriodUpdated",
+		"GlobalRatePeriodIntervalUpdated",
+		"ReservationPeriodIntervalUpdated",
+	} {
+		paramsUpdatedIDs[vaultABI.Events[name].ID] = struct{}{}
+	}
+
+	return &PaymentVaultWatcher{
+		state:        state,
+		address:      address,
+		logReader:    logReader,
+		filterer:     filterer,
+		pollInterval: pollInterval,
+		logger:       logger.With("component", "PaymentVaultWatcher"),
+
+		reservationUpdatedID:     vaultABI.Events["ReservationUpdated"].ID,
+		onDemandPaymentUpdatedID: vaultABI.Events["OnDemandPaymentUpdated"].ID,
+		paramsUpdatedIDs:         paramsUpdatedIDs,
+	}, nil
+}
+
+// Start starts following the payment vault events from the current block, until the context is cancelled. From then
+// on, the payment state relies on the watcher to keep its cached payments up to date.
+func (w *PaymentVaultWatcher) Start(ctx context.Context) error {
+	head, err := w.logReader.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	headHash, err := w.blockHash(ctx, head)
+	if err != nil {
+		return err
+	}
+	w.lastBlock = head
+	w.lastBlockHash = headHash
+	w.state.setWatched(true, head)
+
+	go func() {
+		defer w.state.setWatched(false, 0)
+
+		ticker := time.NewTicker(w.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := w.Poll(ctx); err != nil {
+					w.logger.Error("failed to apply payment vault events", "lastBlock", w.lastBlock, "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Poll applies the payment vault events of the blocks after the last applied block, up to the current block. If it
+// fails, the payment state reads the payments from chain until a poll succeeds again.
+func (w *PaymentVaultWatcher) Poll(ctx context.Context) error {
+	if err := w.poll(ctx); err != nil {
+		if !w.failing {
+			w.failing = true
+			w.state.setWatched(false, 0)
+		}
+		return err
+	}
+	if w.failing {
+		// The payments read from chain while failing may be from blocks after the last applied block, which
+		// weren't checked for reorgs, so they are read again
+		w.failing = false
+		w.state.invalidatePayments()
+	}
+	w.state.setWatched(true, w.lastBlock)
+	return nil
+}
+
+func (w *PaymentVaultWatcher) poll(ctx context.Context) error {
+	head, err := w.logReader.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+
+	lastBlockHash, err := w.blockHash(ctx, w.lastBlock)
+	if err != nil {
+		return err
+	}
+	if lastBlockHash != w.lastBlockHash {
+		w.logger.Warn("payment vault events were reorged out, dropping the cached payments", "block", w.lastBlock)
+		headHash, err := w.blockHash(ctx, head)
+		if err != nil {
+			return err
+		}
+		w.state.invalidatePayments()
+		w.lastBlock = head
+		w.lastBlockHash = headHash
+		return nil
+	}
+
+	for w.lastBlock < head {
+		from := w.lastBlock + 1
+		to := min(head, from+maxWatchedBlockRange-1)
+		// The hash is read before the logs, so that a reorg in between is detected by the next poll
+		toHash, err := w.blockHash(ctx, to)
+		if err != nil {
+			return err
+		}
+		logs, err := w.logReader.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []gethcommon.Address{w.address},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get logs of blocks %d to %d: %w", from, to, err)
+		}
+
+		paramsUpdated := false
+		for _, log := range logs {
+			updated, err := w.applyLog(log)
+			if err != nil {
+				return err
+			}
+			paramsUpdated = paramsUpdated || updated
+		}
+		if paramsUpdated {
+			if err := w.state.RefreshPaymentVaultParams(ctx); err != nil {
+				return fmt.Errorf("failed to refresh payment vault params: %w", err)
+			}
+		}
+		w.lastBlock = to
+		w.lastBlockHash = toHash
+	}
+	return nil
+}
+
+func (w *PaymentVaultWatcher) blockHash(ctx context.Context, number uint64) (gethcommon.Hash, error) {
+	header, err := w.logReader.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return gethcommon.Hash{}, fmt.Errorf("failed to get header of block %d: %w", number, err)
+	}
+	return header.Hash(), nil
+}
+
+// applyLog applies a payment vault event to the payment state, returning true if the event updated the payment
+// vault parameters.
+func (w *PaymentVaultWatcher) applyLog(log types.Log) (bool, error) {
+	if len(log.Topics) == 0 {
+		return false, nil
+	}
+
+	switch log.Topics[0] {
+	case w.reservationUpdatedID:
+		event, err := w.filterer.ParseReservationUpdated(log)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse ReservationUpdated event: %w", err)
+		}
+		// zero valued reservations remove the reservation of the account
+		reservation, err := eth.ConvertToReservedPayment(event.Reservation)
+		if err != nil {
+			reservation = nil
+		}
+		w.state.UpdateReservedPayment(event.Account, reservation)
+		w.logger.Debug("applied reservation update", "account", event.Account.Hex(), "block", log.BlockNumber)
+	case w.onDemandPaymentUpdatedID:
+		event, err := w.filterer.ParseOnDemandPaymentUpdated(log)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse OnDemandPaymentUpdated event: %w", err)
+		}
+		var payment *core.OnDemandPayment
+		if event.TotalDeposit.Sign() > 0 {
+			payment = &core.OnDemandPayment{CumulativePayment: event.TotalDeposit}
+		}
+		w.state.UpdateOnDemandPayment(event.Account, payment)
+		w.logger.Debug("applied on-demand deposit", "account", event.Account.Hex(), "block", log.BlockNumber)
+	default:
+		if _, ok := w.paramsUpdatedIDs[log.Topics[0]]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,191 @@
+package meterer_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	paymentvault "github.com/Layr-Labs/eigenda/contracts/bindings/PaymentVault"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLogReader struct {
+	head    uint64
+	logs    []types.Log
+	queries []ethereum.FilterQuery
+	// fork changes the hashes of all blocks, as if they were reorged
+	fork int64
+	// err is returned by all requests if set
+	err error
+}
+
+func (r *fakeLogReader) BlockNumber(context.Context) (uint64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.head, nil
+}
+
+func (r *fakeLogReader) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &types.Header{Number: number, Time: uint64(r.fork)}, nil
+}
+
+func (r *fakeLogReader) FilterLogs(_ context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	r.queries = append(r.queries, query)
+	var logs []types.Log
+	for _, log := range r.logs {
+		if log.BlockNumber >= query.FromBlock.Uint64() && log.BlockNumber <= query.ToBlock.Uint64() {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// paymentVaultLog packs a payment vault event whose only indexed argument is the account
+func paymentVaultLog(t *testing.T, blockNumber uint64, name string, account gethcommon.Address, args ...interface{}) types.Log {
+	vaultABI, err := paymentvault.ContractPaymentVaultMetaData.GetAbi()
+	require.NoError(t, err)
+	event := vaultABI.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	require.NoError(t, err)
+	return types.Log{
+		BlockNumber: blockNumber,
+		Topics:      []gethcommon.Hash{event.ID, gethcommon.BytesToHash(account.Bytes())},
+		Data:        data,
+	}
+}
+
+func TestPaymentVaultWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	account1 := gethcommon.HexToAddress("0x1")
+	account2 := gethcommon.HexToAddress("0x2")
+	state := &meterer.OnchainPaymentState{
+		ReservedPayments: map[gethcommon.Address]*core.ReservedPayment{account2: dummyReservedPayment},
+		OnDemandPayments: map[gethcommon.Address]*core.OnDemandPayment{account2: dummyOnDemandPayment},
+	}
+	logReader := &fakeLogReader{head: 100}
+	watcher, err := meterer.NewPaymentVaultWatcher(state, gethcommon.HexToAddress("0xabc"), logReader, time.Hour, logging.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start(ctx))
+
+	reservation := paymentvault.IPaymentVaultReservation{
+		SymbolsPerSecond: 100,
+		StartTimestamp:   1000,
+		EndTimestamp:     2000,
+		QuorumNumbers:    []byte{0, 1},
+		QuorumSplits:     []byte{50, 50},
+	}
+	logReader.logs = []types.Log{
+		// events of blocks before the watcher started are ignored
+		paymentVaultLog(t, 100, "OnDemandPaymentUpdated", account1, big.NewInt(1), big.NewInt(1)),
+		paymentVaultLog(t, 101, "ReservationUpdated", account1, reservation),
+		paymentVaultLog(t, 1500, "OnDemandPaymentUpdated", account1, big.NewInt(500), big.NewInt(1500)),
+		// zero valued reservations and deposits remove the payments of the account
+		paymentVaultLog(t, 1500, "ReservationUpdated", account2, paymentvault.IPaymentVaultReservation{}),
+		paymentVaultLog(t, 1600, "OnDemandPaymentUpdated", account2, big.NewInt(0), big.NewInt(0)),
+	}
+	logReader.head = 1600
+	require.NoError(t, watcher.Poll(ctx))
+
+	// the logs are read in ranges of at most 1000 blocks
+	require.Len(t, logReader.queries, 2)
+	assert.Equal(t, uint64(101), logReader.queries[0].FromBlock.Uint64())
+	assert.Equal(t, uint64(1100), logReader.queries[0].ToBlock.Uint64())
+	assert.Equal(t, uint64(1101), logReader.queries[1].FromBlock.Uint64())
+	assert.Equal(t, uint64(1600), logReader.queries[1].ToBlock.Uint64())
+
+	res, err := state.GetReservedPaymentByAccount(ctx, account1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), res.SymbolsPerSecond)
+	assert.Equal(t, []byte{0, 1}, res.QuorumNumbers)
+	payment, err := state.GetOnDemandPaymentByAccount(ctx, account1)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1500), payment.CumulativePayment)
+
+	_, err = state.GetReservedPaymentByAccount(ctx, account2)
+	assert.Error(t, err)
+	_, err = state.GetOnDemandPaymentByAccount(ctx, account2)
+	assert.Error(t, err)
+
+	// polling again without new blocks reads nothing
+	require.NoError(t, watcher.Poll(ctx))
+	assert.Len(t, logReader.queries, 2)
+}
+
+func TestPaymentVaultWatcherReorg(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	account := gethcommon.HexToAddress("0x1")
+	state := &meterer.OnchainPaymentState{
+		ReservedPayments: map[gethcommon.Address]*core.ReservedPayment{account: dummyReservedPayment},
+		OnDemandPayments: map[gethcommon.Address]*core.OnDemandPayment{account: dummyOnDemandPayment},
+	}
+	logReader := &fakeLogReader{head: 100}
+	watcher, err := meterer.NewPaymentVaultWatcher(state, gethcommon.HexToAddress("0xabc"), logReader, time.Hour, logging.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start(ctx))
+
+	logReader.head = 110
+	require.NoError(t, watcher.Poll(ctx))
+	assert.Contains(t, state.ReservedPayments, account)
+
+	// the applied blocks were reorged out, so the cached payments are dropped and the events of the new chain are
+	// followed from its head
+	logReader.fork = 1
+	logReader.head = 120
+	logReader.queries = nil
+	require.NoError(t, watcher.Poll(ctx))
+	assert.Empty(t, state.ReservedPayments)
+	assert.Empty(t, state.OnDemandPayments)
+	assert.Empty(t, logReader.queries)
+
+	state.UpdateReservedPayment(account, dummyReservedPayment)
+	logReader.head = 130
+	require.NoError(t, watcher.Poll(ctx))
+	assert.Contains(t, state.ReservedPayments, account)
+	require.Len(t, logReader.queries, 1)
+	assert.Equal(t, uint64(121), logReader.queries[0].FromBlock.Uint64())
+}
+
+func TestPaymentVaultWatcherPollFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	account := gethcommon.HexToAddress("0x1")
+	state := &meterer.OnchainPaymentState{
+		ReservedPayments: map[gethcommon.Address]*core.ReservedPayment{account: dummyReservedPayment},
+		OnDemandPayments: map[gethcommon.Address]*core.OnDemandPayment{},
+	}
+	logReader := &fakeLogReader{head: 100}
+	watcher, err := meterer.NewPaymentVaultWatcher(state, gethcommon.HexToAddress("0xabc"), logReader, time.Hour, logging.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start(ctx))
+
+	// the cached payments are kept while polling fails, and refreshed from chain rather than by the watcher
+	logReader.err = errors.New("rpc unavailable")
+	require.Error(t, watcher.Poll(ctx))
+	assert.Contains(t, state.ReservedPayments, account)
+
+	// once polling succeeds again, the payments read from chain in the meantime are dropped
+	logReader.err = nil
+	logReader.head = 110
+	require.NoError(t, watcher.Poll(ctx))
+	assert.Empty(t, state.ReservedPayments)
+	require.Len(t, logReader.queries, 1)
+	assert.Equal(t, uint64(101), logReader.queries[0].FromBlock.Uint64())
+}
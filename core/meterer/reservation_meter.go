@@ -0,0 +1,126 @@
+package meterer
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// reservationMeterPruneInterval is how often the buckets which have drained are dropped
+const reservationMeterPruneInterval = time.Minute
+
+// ErrOverReservation is returned when a dispersal would exceed the bandwidth of the account's reservation.
+// RetryAfter is how long until the reservation has freed up enough capacity for it.
+type ErrOverReservation struct {
+	AccountID      string
+	SymbolsCharged uint64
+	RetryAfter     time.Duration
+}
+
+func (e *ErrOverReservation) Error() string {
+	return fmt.Sprintf("dispersal of %d symbols exceeds the reservation of account %s, capacity frees up in %v", e.SymbolsCharged, e.AccountID, e.RetryAfter)
+}
+
+// ReservationMeter tracks the bandwidth used by each account against its reservation with a leaky bucket. A bucket
+// holds up to the symbols of one reservation window, and drains at the symbols per second of the reservation, so
+// that capacity frees up continuously instead of at the start of the next reservation period.
+// The buckets are kept in memory, so each disperser meters the requests it serves independently.
+// The zero value is ready to use.
+type ReservationMeter struct {
+	mu        sync.Mutex
+	buckets   map[string]*reservationBucket
+	lastPrune time.Time
+}
+
+type reservationBucket struct {
+	// symbols in the bucket as of updated
+	level   float64
+	updated time.Time
+	// rate is the symbols per second the bucket drains at
+	rate float64
+}
+
+func NewReservationMeter() *ReservationMeter {
+	return &ReservationMeter{
+		buckets: make(map[string]*reservationBucket),
+	}
+}
+
+// Use adds symbolsCharged to the bucket of the account at time now, or returns an *ErrOverReservation if the
+// bucket doesn't have the capacity for them.
+func (m *ReservationMeter) Use(now time.Time, accountID string, reservation *core.ReservedPayment, reservationWindow uint32, symbolsCharged uint64) error {
+	if reservation.SymbolsPerSecond == 0 || reservationWindow == 0 {
+		return fmt.Errorf("reservation of account %s has no bandwidth", accountID)
+	}
+	rate := float64(reservation.SymbolsPerSecond)
+	capacity := rate * float64(reservationWindow)
+	if float64(symbolsCharged) > capacity {
+		return fmt.Errorf("dispersal of %d symbols exceeds the reservation capacity of %d symbols", symbolsCharged, uint64(capacity))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune(now)
+	if m.buckets == nil {
+		m.buckets = make(map[string]*reservationBucket)
+	}
+	bucket, ok := m.buckets[accountID]
+	if !ok {
+		bucket = &reservationBucket{updated: now}
+		m.buckets[accountID] = bucket
+	}
+	bucket.drain(now)
+	// The reservation may have changed since the bucket was last used
+	bucket.rate = rate
+
+	if excess := bucket.level + float64(symbolsCharged) - capacity; excess > 0 {
+		return &ErrOverReservation{
+			AccountID:      accountID,
+			SymbolsCharged: symbolsCharged,
+			RetryAfter:     time.Duration(math.Ceil(excess / rate * float64(time.Second))),
+		}
+	}
+	bucket.level += float64(symbolsCharged)
+	return nil
+}
+
+// Refund takes symbolsCharged back out of the bucket of the account at time now, for a dispersal which was
+// metered with Use but then rejected.
+func (m *ReservationMeter) Refund(now time.Time, accountID string, symbolsCharged uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bucket, ok := m.buckets[accountID]
+	if !ok {
+		return
+	}
+	bucket.drain(now)
+	bucket.level = math.Max(0, bucket.level-float64(symbolsCharged))
+}
+
+// prune drops the buckets which have drained, as they are equivalent to new buckets
+func (m *ReservationMeter) prune(now time.Time) {
+	if now.Sub(m.lastPrune) < reservationMeterPruneInterval {
+		return
+	}
+	m.lastPrune = now
+	for accountID, bucket := range m.buckets {
+		bucket.drain(now)
+		if bucket.level == 0 {
+			delete(m.buckets, accountID)
+		}
+	}
+}
+
+func (b *reservationBucket) drain(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.level = math.Max(0, b.level-elapsed*b.rate)
+	b.updated = now
+}
@@ -0,0 +1,57 @@
+package meterer_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReservationMeter(t *testing.T) {
+	m := meterer.NewReservationMeter()
+	reservation := &core.ReservedPayment{SymbolsPerSecond: 100}
+	// A 10s window holds up to 1000 symbols
+	window := uint32(10)
+	now := time.Unix(1_000_000, 0)
+
+	assert.NoError(t, m.Use(now, "account1", reservation, window, 600))
+	assert.NoError(t, m.Use(now, "account1", reservation, window, 400))
+
+	// The bucket is full; 200 symbols drain in 2s
+	err := m.Use(now, "account1", reservation, window, 200)
+	var overReservation *meterer.ErrOverReservation
+	require.True(t, errors.As(err, &overReservation))
+	assert.Equal(t, "account1", overReservation.AccountID)
+	assert.Equal(t, uint64(200), overReservation.SymbolsCharged)
+	assert.Equal(t, 2*time.Second, overReservation.RetryAfter)
+
+	// Other accounts have their own buckets
+	assert.NoError(t, m.Use(now, "account2", reservation, window, 1000))
+
+	// Half a second later, 50 symbols have drained
+	err = m.Use(now.Add(500*time.Millisecond), "account1", reservation, window, 200)
+	require.True(t, errors.As(err, &overReservation))
+	assert.Equal(t, 1500*time.Millisecond, overReservation.RetryAfter)
+	assert.NoError(t, m.Use(now.Add(2*time.Second), "account1", reservation, window, 200))
+
+	// Dispersals larger than the reservation window can never be served
+	err = m.Use(now.Add(time.Hour), "account1", reservation, window, 1001)
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &overReservation))
+	assert.NoError(t, m.Use(now.Add(time.Hour), "account1", reservation, window, 1000))
+
+	// Reservations without bandwidth are rejected
+	assert.Error(t, m.Use(now, "account3", &core.ReservedPayment{}, window, 1))
+
+	// Refunded symbols free up capacity right away
+	var zero meterer.ReservationMeter
+	assert.NoError(t, zero.Use(now, "account1", reservation, window, 1000))
+	assert.Error(t, zero.Use(now, "account1", reservation, window, 400))
+	zero.Refund(now, "account1", 400)
+	assert.NoError(t, zero.Use(now, "account1", reservation, window, 400))
+	zero.Refund(now, "account4", 400)
+}
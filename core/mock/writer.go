@@ -251,6 +251,36 @@ func (t *MockWriter) GetOnDemandPaymentByAccount(ctx context.Context, accountID
 	return result.(*core.OnDemandPayment), args.Error(1)
 }
 
+func (t *MockWriter) GetGlobalSymbolsPerSecond(ctx context.Context) (uint64, error) {
+	args := t.Called()
+	result := args.Get(0)
+	return result.(uint64), args.Error(1)
+}
+
+func (t *MockWriter) GetGlobalRatePeriodInterval(ctx context.Context) (uint32, error) {
+	args := t.Called()
+	result := args.Get(0)
+	return result.(uint32), args.Error(1)
+}
+
+func (t *MockWriter) GetMinNumSymbols(ctx context.Context) (uint32, error) {
+	args := t.Called()
+	result := args.Get(0)
+	return result.(uint32), args.Error(1)
+}
+
+func (t *MockWriter) GetPricePerSymbol(ctx context.Context) (uint32, error) {
+	args := t.Called()
+	result := args.Get(0)
+	return result.(uint32), args.Error(1)
+}
+
+func (t *MockWriter) GetReservationWindow(ctx context.Context) (uint32, error) {
+	args := t.Called()
+	result := args.Get(0)
+	return result.(uint32), args.Error(1)
+}
+
 func (t *MockWriter) GetOperatorSocket(ctx context.Context, operatorID core.OperatorID) (string, error) {
 	args := t.Called()
 	result := args.Get(0)
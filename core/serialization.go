@@ -529,32 +529,67 @@ func decode(data []byte, obj any) error {
 }
 
 func (s OperatorSocket) GetDispersalSocket() string {
-	ip, port1, _, err := extractIPAndPorts(string(s))
+	ip, ports, err := extractIPAndPorts(string(s))
 	if err != nil {
 		return ""
 	}
-	return fmt.Sprintf("%s:%s", ip, port1)
+	return fmt.Sprintf("%s:%s", ip, ports[0])
 }
 
 func (s OperatorSocket) GetRetrievalSocket() string {
-	ip, _, port2, err := extractIPAndPorts(string(s))
+	ip, ports, err := extractIPAndPorts(string(s))
 	if err != nil {
 		return ""
 	}
-	return fmt.Sprintf("%s:%s", ip, port2)
+	return fmt.Sprintf("%s:%s", ip, ports[1])
 }
 
-func extractIPAndPorts(s string) (string, string, string, error) {
-	regex := regexp.MustCompile(`^([^:]+):([^;]+);([^;]+)$`)
-	matches := regex.FindStringSubmatch(s)
+// GetV2DispersalSocket returns the socket serving v2 dispersal. Operators splitting v2 dispersal onto its own port
+// append it to the socket, as in "host:dispersalPort;retrievalPort;v2DispersalPort", otherwise v2 dispersal is served
+// on the dispersal port.
+func (s OperatorSocket) GetV2DispersalSocket() string {
+	ip, ports, err := extractIPAndPorts(string(s))
+	if err != nil {
+		return ""
+	}
+	if ports[2] == "" {
+		return fmt.Sprintf("%s:%s", ip, ports[0])
+	}
+	return fmt.Sprintf("%s:%s", ip, ports[2])
+}
 
-	if len(matches) != 4 {
-		return "", "", "", errors.New("input string does not match expected format")
+// GetV2RetrievalSocket returns the socket serving v2 retrieval. Operators splitting v2 retrieval onto its own port
+// append it after the v2 dispersal port, as in "host:dispersalPort;retrievalPort;v2DispersalPort;v2RetrievalPort",
+// otherwise v2 retrieval is served on the retrieval port. See HasV2RetrievalPort for the upgrade order.
+func (s OperatorSocket) GetV2RetrievalSocket() string {
+	ip, ports, err := extractIPAndPorts(string(s))
+	if err != nil {
+		return ""
 	}
+	if ports[3] == "" {
+		return fmt.Sprintf("%s:%s", ip, ports[1])
+	}
+	return fmt.Sprintf("%s:%s", ip, ports[3])
+}
+
+// HasV2RetrievalPort returns true if the socket has a v2 retrieval port. Releases that predate the v2 retrieval port
+// fail to parse such sockets altogether, v1 ports included, so the dispersers, retrievers and data APIs must be
+// upgraded before any node registers a v2 retrieval port. Nodes and the node plugin refuse to register one unless
+// the operator confirms that they are.
+func (s OperatorSocket) HasV2RetrievalPort() bool {
+	_, ports, err := extractIPAndPorts(string(s))
+	return err == nil && ports[3] != ""
+}
 
-	ip := matches[1]
-	port1 := matches[2]
-	port2 := matches[3]
+// extractIPAndPorts returns the IP and the dispersal, retrieval, v2 dispersal and v2 retrieval ports of the socket.
+// The v2 ports are empty if the socket doesn't have them.
+func extractIPAndPorts(s string) (string, [4]string, error) {
+	regex := regexp.MustCompile(`^([^:]+):([^;]+);([^;]+)(?:;([^;]+))?(?:;([^;]+))?$`)
+	matches := regex.FindStringSubmatch(s)
+
+	if len(matches) != 6 {
+		return "", [4]string{}, errors.New("input string does not match expected format")
+	}
 
-	return ip, port1, port2, nil
+	return matches[1], [4]string{matches[2], matches[3], matches[4], matches[5]}, nil
 }
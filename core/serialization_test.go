@@ -202,6 +202,15 @@ func TestParseOperatorSocket(t *testing.T) {
 	assert.Equal(t, "1234", dispersalPort)
 	assert.Equal(t, "5678", retrievalPort)
 
+	host, dispersalPort, retrievalPort, err = core.ParseOperatorSocket("localhost:1234;5678;9012;3456")
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+	assert.Equal(t, "1234", dispersalPort)
+	assert.Equal(t, "5678", retrievalPort)
+
+	_, _, _, err = core.ParseOperatorSocket("localhost:1234;5678;9012;3456;7890")
+	assert.NotNil(t, err)
+
 	_, _, _, err = core.ParseOperatorSocket("localhost:12345678")
 	assert.NotNil(t, err)
 	assert.Equal(t, "invalid socket address format, missing retrieval port: localhost:12345678", err.Error())
@@ -211,6 +220,53 @@ func TestParseOperatorSocket(t *testing.T) {
 	assert.Equal(t, "invalid socket address format: localhost1234;5678", err.Error())
 }
 
+func TestOperatorSocketPorts(t *testing.T) {
+	socket := core.OperatorSocket("localhost:1234;5678")
+	assert.Equal(t, "localhost:1234", socket.GetDispersalSocket())
+	assert.Equal(t, "localhost:5678", socket.GetRetrievalSocket())
+	assert.Equal(t, "localhost:1234", socket.GetV2DispersalSocket())
+
+	socket = core.OperatorSocket("localhost:1234;5678;9012")
+	assert.Equal(t, "localhost:1234", socket.GetDispersalSocket())
+	assert.Equal(t, "localhost:5678", socket.GetRetrievalSocket())
+	assert.Equal(t, "localhost:9012", socket.GetV2DispersalSocket())
+
+	assert.Equal(t, "localhost:5678", socket.GetV2RetrievalSocket())
+
+	socket = core.OperatorSocket("localhost:1234;5678;9012;3456")
+	assert.Equal(t, "localhost:1234", socket.GetDispersalSocket())
+	assert.Equal(t, "localhost:5678", socket.GetRetrievalSocket())
+	assert.Equal(t, "localhost:9012", socket.GetV2DispersalSocket())
+	assert.Equal(t, "localhost:3456", socket.GetV2RetrievalSocket())
+	assert.True(t, socket.HasV2RetrievalPort())
+	assert.False(t, core.OperatorSocket("localhost:1234;5678;9012").HasV2RetrievalPort())
+	assert.False(t, core.OperatorSocket("localhost:1234;5678").HasV2RetrievalPort())
+
+	socket = core.OperatorSocket("localhost:1234")
+	assert.Empty(t, socket.GetDispersalSocket())
+	assert.Empty(t, socket.GetRetrievalSocket())
+	assert.Empty(t, socket.GetV2DispersalSocket())
+	assert.Empty(t, socket.GetV2RetrievalSocket())
+
+	socket = core.OperatorSocket("localhost:1234;5678;9012;3456;7890")
+	assert.Empty(t, socket.GetDispersalSocket())
+	assert.Empty(t, socket.GetV2RetrievalSocket())
+}
+
+func TestMakeOperatorSocketWithV2Ports(t *testing.T) {
+	assert.Equal(t, core.OperatorSocket("localhost:1234;5678"),
+		core.MakeOperatorSocketWithV2Ports("localhost", "1234", "5678", "", ""))
+	assert.Equal(t, core.OperatorSocket("localhost:1234;5678;9012"),
+		core.MakeOperatorSocketWithV2Ports("localhost", "1234", "5678", "9012", ""))
+	assert.Equal(t, core.OperatorSocket("localhost:1234;5678;9012;3456"),
+		core.MakeOperatorSocketWithV2Ports("localhost", "1234", "5678", "9012", "3456"))
+
+	socket := core.MakeOperatorSocketWithV2Ports("localhost", "1234", "5678", "", "3456")
+	assert.Equal(t, core.OperatorSocket("localhost:1234;5678;1234;3456"), socket)
+	assert.Equal(t, "localhost:1234", socket.GetV2DispersalSocket())
+	assert.Equal(t, "localhost:3456", socket.GetV2RetrievalSocket())
+}
+
 func TestSignatureBytes(t *testing.T) {
 	sig := &core.Signature{
 		G1Point: core.NewG1Point(big.NewInt(1), big.NewInt(2)),
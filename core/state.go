@@ -22,14 +22,36 @@ func MakeOperatorSocket(nodeIP, dispersalPort, retrievalPort string) OperatorSoc
 	return OperatorSocket(fmt.Sprintf("%s:%s;%s", nodeIP, dispersalPort, retrievalPort))
 }
 
+// MakeOperatorSocketWithV2Ports makes the socket of an operator serving v2 traffic on its own ports. An empty v2 port
+// means the v2 traffic is served on the corresponding v1 port. The v2 ports are omitted if both are empty.
+func MakeOperatorSocketWithV2Ports(nodeIP, dispersalPort, retrievalPort, v2DispersalPort, v2RetrievalPort string) OperatorSocket {
+	if v2DispersalPort == "" && v2RetrievalPort == "" {
+		return MakeOperatorSocket(nodeIP, dispersalPort, retrievalPort)
+	}
+	if v2DispersalPort == "" {
+		v2DispersalPort = dispersalPort
+	}
+	socket := fmt.Sprintf("%s:%s;%s;%s", nodeIP, dispersalPort, retrievalPort, v2DispersalPort)
+	if v2RetrievalPort != "" {
+		socket = fmt.Sprintf("%s;%s", socket, v2RetrievalPort)
+	}
+	return OperatorSocket(socket)
+}
+
 type StakeAmount = *big.Int
 
+// ParseOperatorSocket returns the host, dispersal port and retrieval port of the socket. The v2 ports of the socket,
+// if any, are ignored, use the methods of OperatorSocket to get the v2 sockets.
 func ParseOperatorSocket(socket string) (host string, dispersalPort string, retrievalPort string, err error) {
 	s := strings.Split(socket, ";")
-	if len(s) != 2 {
+	if len(s) < 2 {
 		err = fmt.Errorf("invalid socket address format, missing retrieval port: %s", socket)
 		return
 	}
+	if len(s) > 4 {
+		err = fmt.Errorf("invalid socket address format, too many ports: %s", socket)
+		return
+	}
 	retrievalPort = s[1]
 
 	s = strings.Split(s[0], ":")
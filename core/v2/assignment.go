@@ -8,10 +8,13 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 )
 
+// GetAssignments returns the chunks assigned to each operator of the quorum. The chunks are assigned with the
+// parameters of the quorum profile, if any.
 func GetAssignments(state *core.OperatorState, blobParams *core.BlobVersionParameters, quorum uint8) (map[core.OperatorID]Assignment, error) {
 	if blobParams == nil {
 		return nil, fmt.Errorf("blob params cannot be nil")
 	}
+	blobParams = blobParams.ForQuorum(quorum)
 
 	ops, ok := state.Operators[quorum]
 	if !ok {
@@ -1,12 +1,18 @@
 package v2
 
+import "context"
+
 type BlobRequestAuthenticator interface {
-	AuthenticateBlobRequest(header *BlobHeader) error
-	AuthenticatePaymentStateRequest(signature []byte, accountId string) error
+	AuthenticateBlobRequest(ctx context.Context, header *BlobHeader) error
+	// RecordBlobRequest records a blob request once it has been dispersed, so that replays of it are rejected by
+	// AuthenticateBlobRequest. Requests which are rejected after authentication are not recorded, and can be
+	// resubmitted.
+	RecordBlobRequest(ctx context.Context, header *BlobHeader) error
+	AuthenticatePaymentStateRequest(ctx context.Context, signature []byte, accountId string, timestamp uint64) error
 }
 
 type BlobRequestSigner interface {
 	SignBlobRequest(header *BlobHeader) ([]byte, error)
-	SignPaymentStateRequest() ([]byte, error)
+	SignPaymentStateRequest(timestamp uint64) ([]byte, error)
 	GetAccountID() (string, error)
 }
@@ -130,6 +130,7 @@ func prepareBlobs(
 	certs []corev2.BlobCertificate,
 	blobs [][]byte,
 	referenceBlockNumber uint64,
+	blobParams *core.BlobVersionParameters,
 ) (map[core.OperatorID][]*corev2.BlobShard, core.IndexedChainState) {
 
 	cst, err := mock.MakeChainDataMock(map[uint8]int{
@@ -216,6 +217,7 @@ func checkBatchByUniversalVerifier(
 	cst core.IndexedChainState,
 	packagedBlobs map[core.OperatorID][]*corev2.BlobShard,
 	pool common.WorkerPool,
+	blobParamsMap *corev2.BlobVersionParameterMap,
 ) error {
 
 	ctx := context.Background()
@@ -274,13 +276,90 @@ func TestValidationSucceeds(t *testing.T) {
 			}
 		}
 
-		packagedBlobs, cst := prepareBlobs(t, operatorCount, headers, blobs, bn)
+		packagedBlobs, cst := prepareBlobs(t, operatorCount, headers, blobs, bn, blobParams)
 
 		t.Run(fmt.Sprintf("universal verifier operatorCount=%v over %v blobs", operatorCount, len(blobs)), func(t *testing.T) {
-			err := checkBatchByUniversalVerifier(cst, packagedBlobs, pool)
+			err := checkBatchByUniversalVerifier(cst, packagedBlobs, pool, blobParamsMap)
 			assert.NoError(t, err)
 		})
 
 	}
 
 }
+
+func TestValidationSucceedsWithQuorumProfiles(t *testing.T) {
+	// quorum 1 is assigned half of the chunks of quorum 0, at half the coding rate
+	profiledParams := &core.BlobVersionParameters{
+		NumChunks:       blobParams.NumChunks,
+		CodingRate:      blobParams.CodingRate,
+		MaxNumOperators: blobParams.MaxNumOperators,
+		QuorumProfiles: map[core.QuorumID]core.ReedSolomonProfile{
+			1: {CodingRate: blobParams.CodingRate / 2, NumChunks: blobParams.NumChunks / 2},
+		},
+	}
+	assert.NoError(t, profiledParams.Validate())
+	profiledParamsMap := v2.NewBlobVersionParameterMap(map[corev2.BlobVersion]*core.BlobVersionParameters{
+		0: profiledParams,
+	})
+
+	headers := make([]corev2.BlobCertificate, 0)
+	blobs := make([][]byte, 0)
+	for _, blobLength := range []int{1, 2} {
+		header, data := makeTestBlob(t, p, 0, blobLength, []core.QuorumID{0, 1})
+		headers = append(headers, header)
+		blobs = append(blobs, data)
+	}
+
+	packagedBlobs, cst := prepareBlobs(t, 4, headers, blobs, 1000, profiledParams)
+	numChunks := make(map[core.QuorumID]int)
+	for _, shards := range packagedBlobs {
+		for quorum, bundle := range shards[0].Bundles {
+			numChunks[quorum] += len(bundle)
+		}
+	}
+	assert.Equal(t, int(blobParams.NumChunks), numChunks[0])
+	assert.Equal(t, int(blobParams.NumChunks/2), numChunks[1])
+
+	pool := workerpool.New(1)
+	err := checkBatchByUniversalVerifier(cst, packagedBlobs, pool, profiledParamsMap)
+	assert.NoError(t, err)
+
+	// the nodes of quorum 1 expect the chunks of its profile
+	err = checkBatchByUniversalVerifier(cst, packagedBlobs, pool, blobParamsMap)
+	assert.Error(t, err)
+}
+
+func TestValidationSucceedsInParallel(t *testing.T) {
+	// The blobs of the same encoding params are verified across the workers of the pool
+	headers := make([]corev2.BlobCertificate, 0)
+	blobs := make([][]byte, 0)
+	for i := 0; i < 5; i++ {
+		header, data := makeTestBlob(t, p, 0, 1, []core.QuorumID{0, 1})
+		headers = append(headers, header)
+		blobs = append(blobs, data)
+	}
+	packagedBlobs, cst := prepareBlobs(t, 4, headers, blobs, 1000, blobParams)
+
+	for _, numWorkers := range []int{1, 3, 8, 32} {
+		pool := workerpool.New(numWorkers)
+		err := checkBatchByUniversalVerifier(cst, packagedBlobs, pool, blobParamsMap)
+		assert.NoError(t, err, "numWorkers=%d", numWorkers)
+		pool.StopWait()
+	}
+
+	// A corrupted chunk of a blob is detected whichever sub-batch it is verified in
+	corrupted := make(map[*encoding.Frame]bool)
+	for _, shards := range packagedBlobs {
+		frame := shards[len(shards)-1].Bundles[0][0]
+		if !corrupted[frame] {
+			frame.Coeffs[0].SetUint64(12345)
+			corrupted[frame] = true
+		}
+	}
+	for _, numWorkers := range []int{1, 3, 8, 32} {
+		pool := workerpool.New(numWorkers)
+		err := checkBatchByUniversalVerifier(cst, packagedBlobs, pool, blobParamsMap)
+		assert.Error(t, err, "numWorkers=%d", numWorkers)
+		pool.StopWait()
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/gob"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/wealdtech/go-merkletree/v2"
@@ -26,6 +27,11 @@ type abiBlobCommitments struct {
 	LengthProof      abiG2Commit
 	DataLength       uint32
 }
+type abiQuorumSecurityThresholds struct {
+	QuorumId              uint8
+	ConfirmationThreshold uint8
+	AdversaryThreshold    uint8
+}
 
 func (b *BlobHeader) BlobKey() (BlobKey, error) {
 	versionType, err := abi.NewType("uint16", "", nil)
@@ -185,6 +191,78 @@ func (b *BlobHeader) BlobKey() (BlobKey, error) {
 	return blobKey, nil
 }
 
+// RequestHash returns the hash a blob request is signed over. The blob key only commits to the fields of the blob
+// header that are certified on chain, so the request options, which only the disperser and the operators act on,
+// extend it when any is set: the request hash is then
+// keccak256(abi.encode(blobKey, retentionPeriodSeconds, (quorumId, confirmationThreshold, adversaryThreshold)[])).
+// Requests without options are signed over the blob key itself.
+func (b *BlobHeader) RequestHash() ([32]byte, error) {
+	blobKey, err := b.BlobKey()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if b.RetentionPeriod == 0 && len(b.SecurityThresholds) == 0 {
+		return blobKey, nil
+	}
+
+	bytes32Type, err := abi.NewType("bytes32", "", nil)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	uint64Type, err := abi.NewType("uint64", "", nil)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	securityThresholdsType, err := abi.NewType("tuple[]", "", []abi.ArgumentMarshaling{
+		{
+			Name: "quorumId",
+			Type: "uint8",
+		},
+		{
+			Name: "confirmationThreshold",
+			Type: "uint8",
+		},
+		{
+			Name: "adversaryThreshold",
+			Type: "uint8",
+		},
+	})
+	if err != nil {
+		return [32]byte{}, err
+	}
+	arguments := abi.Arguments{
+		{
+			Type: bytes32Type,
+		},
+		{
+			Type: uint64Type,
+		},
+		{
+			Type: securityThresholdsType,
+		},
+	}
+
+	securityThresholds := make([]abiQuorumSecurityThresholds, len(b.SecurityThresholds))
+	for i, t := range b.SecurityThresholds {
+		securityThresholds[i] = abiQuorumSecurityThresholds{
+			QuorumId:              t.QuorumID,
+			ConfirmationThreshold: t.ConfirmationThreshold,
+			AdversaryThreshold:    t.AdversaryThreshold,
+		}
+	}
+	packedBytes, err := arguments.Pack([32]byte(blobKey), uint64(b.RetentionPeriod/time.Second), securityThresholds)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	var requestHash [32]byte
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(packedBytes)
+	copy(requestHash[:], hasher.Sum(nil)[:32])
+
+	return requestHash, nil
+}
+
 func (c *BlobCertificate) Hash() ([32]byte, error) {
 	if c.BlobHeader == nil {
 		return [32]byte{}, fmt.Errorf("blob header is nil")
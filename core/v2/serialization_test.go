@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -18,6 +19,10 @@ func TestBlobKey(t *testing.T) {
 	bk, err := v2.HexToBlobKey(blobKey.Hex())
 	assert.NoError(t, err)
 	assert.Equal(t, blobKey, bk)
+
+	// Keys must be 32 bytes
+	_, err = v2.HexToBlobKey("0x0102")
+	assert.Error(t, err)
 }
 
 func TestPaymentHash(t *testing.T) {
@@ -31,6 +36,13 @@ func TestPaymentHash(t *testing.T) {
 	assert.NoError(t, err)
 	// 0xd0c8a7a362a45a875d9eb78ef577d563d759e3a615a5f81f71bfc5e85f6bcf59 verified in solidity
 	assert.Equal(t, "d0c8a7a362a45a875d9eb78ef577d563d759e3a615a5f81f71bfc5e85f6bcf59", hex.EncodeToString(hash[:]))
+
+	// the timestamp is part of the hash when set
+	pm.Timestamp = 1_700_000_000_000_000_000
+	timestampHash, err := pm.Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, timestampHash)
+	assert.Equal(t, "6da1f6fdbf6170f5b7fd992b996470ffbba617e6187740fb9e29eab534352200", hex.EncodeToString(timestampHash[:]))
 }
 
 func TestBlobKeyFromHeader(t *testing.T) {
@@ -58,6 +70,62 @@ func TestBlobKeyFromHeader(t *testing.T) {
 	assert.Equal(t, "22c9e31c3d79c7c4085b564113f488019cbae18198c9a4fc4ecd70a5742e8638", blobKey.Hex())
 }
 
+func TestBlobHeaderRequestHash(t *testing.T) {
+	data := codec.ConvertByPaddingEmptyByte(GETTYSBURG_ADDRESS_BYTES)
+	commitments, err := p.GetCommitmentsForPaddedLength(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bh := v2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0, 1},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         "0x123",
+			ReservationPeriod: 5,
+			CumulativePayment: big.NewInt(100),
+			Salt:              42,
+		},
+	}
+	blobKey, err := bh.BlobKey()
+	assert.NoError(t, err)
+
+	// Requests without options are signed over the blob key
+	requestHash, err := bh.RequestHash()
+	assert.NoError(t, err)
+	assert.Equal(t, [32]byte(blobKey), requestHash)
+
+	// The retention period extends the request hash, but not the blob key
+	bh.RetentionPeriod = 48 * time.Hour
+	retentionHash, err := bh.RequestHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, requestHash, retentionHash)
+	retentionBlobKey, err := bh.BlobKey()
+	assert.NoError(t, err)
+	assert.Equal(t, blobKey, retentionBlobKey)
+
+	bh.RetentionPeriod = 72 * time.Hour
+	otherRetentionHash, err := bh.RequestHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, retentionHash, otherRetentionHash)
+
+	// So do the security thresholds
+	bh.RetentionPeriod = 0
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 80, AdversaryThreshold: 40}}
+	thresholdsHash, err := bh.RequestHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, requestHash, thresholdsHash)
+	thresholdsBlobKey, err := bh.BlobKey()
+	assert.NoError(t, err)
+	assert.Equal(t, blobKey, thresholdsBlobKey)
+
+	bh.SecurityThresholds[0].ConfirmationThreshold = 55
+	otherThresholdsHash, err := bh.RequestHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, thresholdsHash, otherThresholdsHash)
+}
+
 func TestBatchHeaderHash(t *testing.T) {
 	batchRoot := [32]byte{}
 	copy(batchRoot[:], []byte("1"))
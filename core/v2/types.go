@@ -4,7 +4,10 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"time"
 
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	disperserpb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
@@ -44,7 +47,7 @@ func HexToBlobKey(h string) (BlobKey, error) {
 	if err != nil {
 		return BlobKey{}, err
 	}
-	return BlobKey(b), nil
+	return BytesToBlobKey(b)
 }
 
 func BytesToBlobKey(bytes []byte) (BlobKey, error) {
@@ -72,6 +75,24 @@ type BlobHeader struct {
 
 	// Signature is the signature of the blob header by the account ID
 	Signature []byte
+
+	// RetentionPeriod is how long the blob should be retained. Zero retains the blob for the maximum retention period.
+	// It is not part of the blob key, but it is part of the request hash, so it is covered by the signature.
+	RetentionPeriod time.Duration
+
+	// SecurityThresholds are custom security thresholds for some of the quorums of the blob. Quorums without custom
+	// thresholds use the protocol defaults. They are not part of the blob key, but they are part of the request hash, so
+	// they are covered by the signature.
+	SecurityThresholds []QuorumSecurityThresholds
+}
+
+// QuorumSecurityThresholds are the security thresholds of a quorum, as percentages of the total stake of the quorum
+type QuorumSecurityThresholds struct {
+	QuorumID core.QuorumID
+	// ConfirmationThreshold is the percentage of the quorum stake that must sign for the blob to be certified
+	ConfirmationThreshold uint8
+	// AdversaryThreshold is the maximum percentage of the quorum stake assumed to be controlled by an adversary
+	AdversaryThreshold uint8
 }
 
 func BlobHeaderFromProtobuf(proto *commonpb.BlobHeader) (*BlobHeader, error) {
@@ -110,6 +131,28 @@ func BlobHeaderFromProtobuf(proto *commonpb.BlobHeader) (*BlobHeader, error) {
 
 	paymentMetadata := core.ConvertToPaymentMetadata(proto.GetPaymentHeader())
 
+	if proto.GetRetentionPeriodSeconds() > uint64(math.MaxInt64/int64(time.Second)) {
+		return nil, errors.New("retention period is too large")
+	}
+
+	var securityThresholds []QuorumSecurityThresholds
+	if len(proto.GetSecurityThresholds()) > 0 {
+		securityThresholds = make([]QuorumSecurityThresholds, len(proto.GetSecurityThresholds()))
+		for i, t := range proto.GetSecurityThresholds() {
+			if t.GetQuorumId() > MaxQuorumID {
+				return nil, errors.New("security thresholds quorum number exceeds maximum allowed")
+			}
+			if t.GetConfirmationThreshold() > 100 || t.GetAdversaryThreshold() > 100 {
+				return nil, fmt.Errorf("security thresholds of quorum %d exceed 100", t.GetQuorumId())
+			}
+			securityThresholds[i] = QuorumSecurityThresholds{
+				QuorumID:              core.QuorumID(t.GetQuorumId()),
+				ConfirmationThreshold: uint8(t.GetConfirmationThreshold()),
+				AdversaryThreshold:    uint8(t.GetAdversaryThreshold()),
+			}
+		}
+	}
+
 	return &BlobHeader{
 		BlobVersion: BlobVersion(proto.GetVersion()),
 		BlobCommitments: encoding.BlobCommitments{
@@ -118,9 +161,11 @@ func BlobHeaderFromProtobuf(proto *commonpb.BlobHeader) (*BlobHeader, error) {
 			LengthProof:      lengthProof,
 			Length:           uint(proto.GetCommitment().GetLength()),
 		},
-		QuorumNumbers:   quorumNumbers,
-		PaymentMetadata: *paymentMetadata,
-		Signature:       proto.GetSignature(),
+		QuorumNumbers:      quorumNumbers,
+		PaymentMetadata:    *paymentMetadata,
+		Signature:          proto.GetSignature(),
+		RetentionPeriod:    time.Duration(proto.GetRetentionPeriodSeconds()) * time.Second,
+		SecurityThresholds: securityThresholds,
 	}, nil
 }
 
@@ -135,15 +180,123 @@ func (b *BlobHeader) ToProtobuf() (*commonpb.BlobHeader, error) {
 		return nil, fmt.Errorf("failed to convert blob commitments to protobuf: %v", err)
 	}
 
+	var securityThresholds []*commonpb.QuorumSecurityThresholds
+	if len(b.SecurityThresholds) > 0 {
+		securityThresholds = make([]*commonpb.QuorumSecurityThresholds, len(b.SecurityThresholds))
+		for i, t := range b.SecurityThresholds {
+			securityThresholds[i] = &commonpb.QuorumSecurityThresholds{
+				QuorumId:              uint32(t.QuorumID),
+				ConfirmationThreshold: uint32(t.ConfirmationThreshold),
+				AdversaryThreshold:    uint32(t.AdversaryThreshold),
+			}
+		}
+	}
+
 	return &commonpb.BlobHeader{
-		Version:       uint32(b.BlobVersion),
-		QuorumNumbers: quorums,
-		Commitment:    commitments,
-		PaymentHeader: b.PaymentMetadata.ToProtobuf(),
-		Signature:     b.Signature,
+		Version:                uint32(b.BlobVersion),
+		QuorumNumbers:          quorums,
+		Commitment:             commitments,
+		PaymentHeader:          b.PaymentMetadata.ToProtobuf(),
+		Signature:              b.Signature,
+		RetentionPeriodSeconds: uint64(b.RetentionPeriod / time.Second),
+		SecurityThresholds:     securityThresholds,
 	}, nil
 }
 
+// GetSecurityThresholds returns the custom security thresholds of a quorum of the blob, if any.
+func (b *BlobHeader) GetSecurityThresholds(quorum core.QuorumID) (QuorumSecurityThresholds, bool) {
+	for _, t := range b.SecurityThresholds {
+		if t.QuorumID == quorum {
+			return t, true
+		}
+	}
+	return QuorumSecurityThresholds{}, false
+}
+
+// ValidateSecurityThresholds checks that the custom security thresholds of the blob, if any, are for quorums of the
+// blob, that they are at least as strict as the security params of their quorum, and that they satisfy the security
+// assumptions of the blob version.
+func (b *BlobHeader) ValidateSecurityThresholds(blobParams *core.BlobVersionParameters, securityParams map[core.QuorumID]core.SecurityParam) error {
+	seen := make(map[core.QuorumID]struct{}, len(b.SecurityThresholds))
+	for _, t := range b.SecurityThresholds {
+		if _, ok := seen[t.QuorumID]; ok {
+			return fmt.Errorf("duplicate security thresholds for quorum %d", t.QuorumID)
+		}
+		seen[t.QuorumID] = struct{}{}
+		if !slices.Contains(b.QuorumNumbers, t.QuorumID) {
+			return fmt.Errorf("security thresholds for quorum %d, which the blob is not dispersed to", t.QuorumID)
+		}
+		minimum, ok := securityParams[t.QuorumID]
+		if !ok {
+			return fmt.Errorf("no security params for quorum %d", t.QuorumID)
+		}
+		if err := t.Validate(blobParams.ForQuorum(t.QuorumID), minimum); err != nil {
+			return fmt.Errorf("invalid security thresholds for quorum %d: %w", t.QuorumID, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the thresholds are at least as strict as the minimum security params of the quorum, and that
+// they satisfy the security assumptions of a blob version, i.e. that any set of operators holding the difference
+// between the confirmation and adversary thresholds of the stake is assigned enough chunks to reconstruct the blob.
+// The latter matches the check of the EigenDA blob verification contracts.
+func (t QuorumSecurityThresholds) Validate(blobParams *core.BlobVersionParameters, minimum core.SecurityParam) error {
+	if t.ConfirmationThreshold > 100 {
+		return errors.New("confirmation threshold exceeds 100")
+	}
+	if t.ConfirmationThreshold < minimum.ConfirmationThreshold {
+		return fmt.Errorf("confirmation threshold %d is below the minimum %d", t.ConfirmationThreshold, minimum.ConfirmationThreshold)
+	}
+	if t.AdversaryThreshold < minimum.AdversaryThreshold {
+		return fmt.Errorf("adversary threshold %d is below the minimum %d", t.AdversaryThreshold, minimum.AdversaryThreshold)
+	}
+	if t.ConfirmationThreshold <= t.AdversaryThreshold {
+		return errors.New("confirmation threshold must be greater than adversary threshold")
+	}
+	if blobParams == nil || blobParams.CodingRate == 0 {
+		return errors.New("invalid blob version parameters")
+	}
+	// Same arithmetic as EigenDABlobVerificationUtils._verifyBlobSecurityParams, in basis points
+	gamma := uint64(t.ConfirmationThreshold - t.AdversaryThreshold)
+	reconstructionBips := 1_000_000 / gamma / uint64(blobParams.CodingRate)
+	if reconstructionBips > 10_000 ||
+		(10_000-reconstructionBips)*uint64(blobParams.NumChunks) < uint64(blobParams.MaxNumOperators)*10_000 {
+		return fmt.Errorf("security assumptions are not met for confirmation threshold %d and adversary threshold %d", t.ConfirmationThreshold, t.AdversaryThreshold)
+	}
+	return nil
+}
+
+// ValidateRetentionPeriod checks that the retention period requested by the blob header, if any, is within
+// [MinBlobRetentionPeriod, maxRetentionPeriod].
+func (b *BlobHeader) ValidateRetentionPeriod(maxRetentionPeriod time.Duration) error {
+	if b.RetentionPeriod == 0 {
+		return nil
+	}
+	if b.RetentionPeriod < MinBlobRetentionPeriod {
+		return fmt.Errorf("retention period %s is shorter than the minimum %s", b.RetentionPeriod, MinBlobRetentionPeriod)
+	}
+	if b.RetentionPeriod > maxRetentionPeriod {
+		return fmt.Errorf("retention period %s is longer than the maximum %s", b.RetentionPeriod, maxRetentionPeriod)
+	}
+	return nil
+}
+
+// GetRetentionPeriod returns how long the blob should be retained given the maximum retention period.
+// A retention period that is unset or out of bounds is clamped to [MinBlobRetentionPeriod, maxRetentionPeriod].
+func (b *BlobHeader) GetRetentionPeriod(maxRetentionPeriod time.Duration) time.Duration {
+	period := b.RetentionPeriod
+	if period < MinBlobRetentionPeriod {
+		period = MinBlobRetentionPeriod
+	}
+	if b.RetentionPeriod == 0 || period > maxRetentionPeriod {
+		return maxRetentionPeriod
+	}
+	return period
+}
+
+// GetEncodingParams returns the params the blob is encoded with. The blob is encoded into the largest number of
+// chunks of its quorums, of which each quorum is assigned the first chunks of its profile.
 func (b *BlobHeader) GetEncodingParams(blobParams *core.BlobVersionParameters) (encoding.EncodingParams, error) {
 	length, err := GetChunkLength(uint32(b.BlobCommitments.Length), blobParams)
 	if err != nil {
@@ -151,7 +304,7 @@ func (b *BlobHeader) GetEncodingParams(blobParams *core.BlobVersionParameters) (
 	}
 
 	return encoding.EncodingParams{
-		NumChunks:   uint64(blobParams.NumChunks),
+		NumChunks:   uint64(blobParams.NumChunksForQuorums(b.QuorumNumbers)),
 		ChunkLength: uint64(length),
 	}, nil
 }
@@ -397,4 +550,7 @@ const (
 	// which means the max ID can not be larger than 254 (from 0 to 254, there are 255
 	// different IDs).
 	MaxQuorumID = 254
+
+	// MinBlobRetentionPeriod is the shortest retention period a blob may request
+	MinBlobRetentionPeriod = 24 * time.Hour
 )
@@ -3,6 +3,7 @@ package v2_test
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -82,7 +83,11 @@ func TestConvertBlobHeaderToFromProtobuf(t *testing.T) {
 			ReservationPeriod: 5,
 			CumulativePayment: big.NewInt(100),
 		},
-		Signature: []byte{1, 2, 3},
+		Signature:       []byte{1, 2, 3},
+		RetentionPeriod: 48 * time.Hour,
+		SecurityThresholds: []v2.QuorumSecurityThresholds{
+			{QuorumID: 1, ConfirmationThreshold: 60, AdversaryThreshold: 30},
+		},
 	}
 
 	pb, err := bh.ToProtobuf()
@@ -94,6 +99,91 @@ func TestConvertBlobHeaderToFromProtobuf(t *testing.T) {
 	assert.Equal(t, bh, newBH)
 }
 
+func TestBlobHeaderRetentionPeriod(t *testing.T) {
+	maxRetentionPeriod := 14 * 24 * time.Hour
+	bh := &v2.BlobHeader{}
+
+	// unset retention period retains the blob for the maximum period
+	assert.NoError(t, bh.ValidateRetentionPeriod(maxRetentionPeriod))
+	assert.Equal(t, maxRetentionPeriod, bh.GetRetentionPeriod(maxRetentionPeriod))
+
+	bh.RetentionPeriod = 48 * time.Hour
+	assert.NoError(t, bh.ValidateRetentionPeriod(maxRetentionPeriod))
+	assert.Equal(t, 48*time.Hour, bh.GetRetentionPeriod(maxRetentionPeriod))
+
+	bh.RetentionPeriod = v2.MinBlobRetentionPeriod - time.Second
+	assert.ErrorContains(t, bh.ValidateRetentionPeriod(maxRetentionPeriod), "shorter than the minimum")
+	assert.Equal(t, v2.MinBlobRetentionPeriod, bh.GetRetentionPeriod(maxRetentionPeriod))
+
+	bh.RetentionPeriod = maxRetentionPeriod + time.Second
+	assert.ErrorContains(t, bh.ValidateRetentionPeriod(maxRetentionPeriod), "longer than the maximum")
+	assert.Equal(t, maxRetentionPeriod, bh.GetRetentionPeriod(maxRetentionPeriod))
+
+	// the maximum retention period takes precedence over the minimum
+	bh.RetentionPeriod = time.Hour
+	assert.Equal(t, 2*time.Hour, bh.GetRetentionPeriod(2*time.Hour))
+}
+
+func TestBlobHeaderSecurityThresholds(t *testing.T) {
+	blobParams := &core.BlobVersionParameters{
+		NumChunks:       8192,
+		CodingRate:      8,
+		MaxNumOperators: 3537,
+	}
+	securityParams := map[core.QuorumID]core.SecurityParam{
+		0: {QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		1: {QuorumID: 1, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+	}
+	bh := &v2.BlobHeader{QuorumNumbers: []core.QuorumID{0, 1}}
+	assert.NoError(t, bh.ValidateSecurityThresholds(blobParams, securityParams))
+	_, ok := bh.GetSecurityThresholds(0)
+	assert.False(t, ok)
+
+	// the default thresholds satisfy the security assumptions
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{
+		{QuorumID: 0, ConfirmationThreshold: 55, AdversaryThreshold: 33},
+		{QuorumID: 1, ConfirmationThreshold: 80, AdversaryThreshold: 40},
+	}
+	assert.NoError(t, bh.ValidateSecurityThresholds(blobParams, securityParams))
+	thresholds, ok := bh.GetSecurityThresholds(1)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(80), thresholds.ConfirmationThreshold)
+
+	// the gap between the thresholds is too small for the coding rate
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 55, AdversaryThreshold: 34}}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, securityParams), "security assumptions are not met")
+
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 60, AdversaryThreshold: 60}}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, securityParams), "must be greater than adversary threshold")
+
+	// custom thresholds can't be weaker than the security params of the quorum
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 25, AdversaryThreshold: 0}}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, securityParams), "confirmation threshold 25 is below the minimum 55")
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 80, AdversaryThreshold: 20}}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, securityParams), "adversary threshold 20 is below the minimum 33")
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 1, ConfirmationThreshold: 80, AdversaryThreshold: 40}}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, map[core.QuorumID]core.SecurityParam{0: securityParams[0]}), "no security params for quorum 1")
+
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 101, AdversaryThreshold: 33}}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, securityParams), "exceeds 100")
+
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 2, ConfirmationThreshold: 55, AdversaryThreshold: 33}}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, securityParams), "not dispersed to")
+
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{
+		{QuorumID: 0, ConfirmationThreshold: 55, AdversaryThreshold: 33},
+		{QuorumID: 0, ConfirmationThreshold: 60, AdversaryThreshold: 33},
+	}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, securityParams), "duplicate")
+
+	// the thresholds of a quorum are checked against the profile of the quorum
+	blobParams.QuorumProfiles = map[core.QuorumID]core.ReedSolomonProfile{1: {CodingRate: 16, NumChunks: 16384}}
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 1, ConfirmationThreshold: 55, AdversaryThreshold: 34}}
+	assert.NoError(t, bh.ValidateSecurityThresholds(blobParams, securityParams))
+	bh.SecurityThresholds = []v2.QuorumSecurityThresholds{{QuorumID: 0, ConfirmationThreshold: 55, AdversaryThreshold: 34}}
+	assert.ErrorContains(t, bh.ValidateSecurityThresholds(blobParams, securityParams), "security assumptions are not met")
+}
+
 func TestConvertBlobCertToFromProtobuf(t *testing.T) {
 	data := codec.ConvertByPaddingEmptyByte(GETTYSBURG_ADDRESS_BYTES)
 	commitments, err := p.GetCommitmentsForPaddedLength(data)
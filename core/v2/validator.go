@@ -112,7 +112,8 @@ func (v *shardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard,
 	}
 
 	var err error
-	subBatchMap := make(map[encoding.EncodingParams]*encoding.SubBatch)
+	// The samples of each blob quorum, grouped by encoding params
+	samplesByParams := make(map[encoding.EncodingParams][][]encoding.Sample)
 	blobCommitmentList := make([]encoding.BlobCommitments, len(blobs))
 
 	for k, blob := range blobs {
@@ -143,17 +144,7 @@ func (v *shardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard,
 				return err
 			}
 
-			if err != nil {
-				return err
-			}
-
 			// Check the received chunks against the commitment
-			blobIndex := 0
-			subBatch, ok := subBatchMap[params]
-			if ok {
-				blobIndex = subBatch.NumBlobs
-			}
-
 			indices := assignment.GetIndices()
 			samples := make([]encoding.Sample, len(chunks))
 			for ind := range chunks {
@@ -161,34 +152,23 @@ func (v *shardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard,
 					Commitment:      blob.BlobHeader.BlobCommitments.Commitment,
 					Chunk:           chunks[ind],
 					AssignmentIndex: uint(indices[ind]),
-					BlobIndex:       blobIndex,
 				}
 			}
-
-			// update subBatch
-			if !ok {
-				subBatchMap[params] = &encoding.SubBatch{
-					Samples:  samples,
-					NumBlobs: 1,
-				}
-			} else {
-				subBatch.Samples = append(subBatch.Samples, samples...)
-				subBatch.NumBlobs += 1
-			}
+			samplesByParams[params] = append(samplesByParams[params], samples)
 		}
 	}
+	subBatches := splitSubBatches(samplesByParams, pool.Size())
 
 	// Parallelize the universal verification for each subBatch
-	numResult := len(subBatchMap) + len(blobCommitmentList)
+	numResult := len(subBatches) + len(blobCommitmentList)
 	// create a channel to accept results, we don't use stop
 	out := make(chan error, numResult)
 
 	// parallelize subBatch verification
-	for params, subBatch := range subBatchMap {
-		params := params
+	for _, subBatch := range subBatches {
 		subBatch := subBatch
 		pool.Submit(func() {
-			v.universalVerifyWorker(params, subBatch, out)
+			v.universalVerifyWorker(subBatch.params, subBatch.SubBatch, out)
 		})
 	}
 
@@ -215,6 +195,50 @@ func (v *shardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard,
 	return nil
 }
 
+type paramsSubBatch struct {
+	*encoding.SubBatch
+	params encoding.EncodingParams
+}
+
+// splitSubBatches groups the samples of the blob quorums sharing the same encoding params into sub-batches verified
+// together. The blob quorums of the same params are split across up to numWorkers sub-batches in total, in proportion
+// to their number, so that large batches are verified by all the workers in parallel instead of by one worker per
+// encoding params.
+func splitSubBatches(samplesByParams map[encoding.EncodingParams][][]encoding.Sample, numWorkers int) []paramsSubBatch {
+	numBlobQuorums := 0
+	for _, blobSamples := range samplesByParams {
+		numBlobQuorums += len(blobSamples)
+	}
+
+	subBatches := make([]paramsSubBatch, 0)
+	for params, blobSamples := range samplesByParams {
+		numSubBatches := max(1, min(len(blobSamples), numWorkers*len(blobSamples)/numBlobQuorums))
+		// The first len(blobSamples) % numSubBatches sub-batches take one more blob quorum
+		size := len(blobSamples) / numSubBatches
+		remainder := len(blobSamples) % numSubBatches
+		start := 0
+		for i := 0; i < numSubBatches; i++ {
+			end := start + size
+			if i < remainder {
+				end++
+			}
+			subBatch := &encoding.SubBatch{
+				Samples:  make([]encoding.Sample, 0),
+				NumBlobs: end - start,
+			}
+			for blobIndex, samples := range blobSamples[start:end] {
+				for _, sample := range samples {
+					sample.BlobIndex = blobIndex
+					subBatch.Samples = append(subBatch.Samples, sample)
+				}
+			}
+			subBatches = append(subBatches, paramsSubBatch{SubBatch: subBatch, params: params})
+			start = end
+		}
+	}
+	return subBatches
+}
+
 func (v *shardValidator) universalVerifyWorker(params encoding.EncodingParams, subBatch *encoding.SubBatch, out chan error) {
 
 	err := v.verifier.UniversalVerifySubBatch(params, subBatch.Samples, subBatch.NumBlobs)
@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Encoder_EncodeBlob_FullMethodName = "/encoder.v2.Encoder/EncodeBlob"
+	Encoder_EncodeBlob_FullMethodName   = "/encoder.v2.Encoder/EncodeBlob"
+	Encoder_GetStatus_FullMethodName    = "/encoder.v2.Encoder/GetStatus"
+	Encoder_RunBenchmark_FullMethodName = "/encoder.v2.Encoder/RunBenchmark"
 )
 
 // EncoderClient is the client API for Encoder service.
@@ -30,6 +32,15 @@ type EncoderClient interface {
 	// The blob is retrieved using the provided blob key and the encoded chunks
 	// are persisted for later retrieval.
 	EncodeBlob(ctx context.Context, in *EncodeBlobRequest, opts ...grpc.CallOption) (*EncodeBlobReply, error)
+	// GetStatus returns the load of the encoder, so that the control plane can route
+	// encoding requests to the least loaded encoder and hold them back when all the
+	// encoders are at capacity.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusReply, error)
+	// RunBenchmark encodes a standard set of random blobs on the encoder host and
+	// returns the measured throughput, so that operators can validate the sizing of
+	// the encoder hardware and the control plane can weight the routing of encoding
+	// requests by the capacity of each encoder. Only one benchmark runs at a time.
+	RunBenchmark(ctx context.Context, in *RunBenchmarkRequest, opts ...grpc.CallOption) (*RunBenchmarkReply, error)
 }
 
 type encoderClient struct {
@@ -49,6 +60,24 @@ func (c *encoderClient) EncodeBlob(ctx context.Context, in *EncodeBlobRequest, o
 	return out, nil
 }
 
+func (c *encoderClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusReply, error) {
+	out := new(GetStatusReply)
+	err := c.cc.Invoke(ctx, Encoder_GetStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *encoderClient) RunBenchmark(ctx context.Context, in *RunBenchmarkRequest, opts ...grpc.CallOption) (*RunBenchmarkReply, error) {
+	out := new(RunBenchmarkReply)
+	err := c.cc.Invoke(ctx, Encoder_RunBenchmark_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EncoderServer is the server API for Encoder service.
 // All implementations must embed UnimplementedEncoderServer
 // for forward compatibility
@@ -57,6 +86,15 @@ type EncoderServer interface {
 	// The blob is retrieved using the provided blob key and the encoded chunks
 	// are persisted for later retrieval.
 	EncodeBlob(context.Context, *EncodeBlobRequest) (*EncodeBlobReply, error)
+	// GetStatus returns the load of the encoder, so that the control plane can route
+	// encoding requests to the least loaded encoder and hold them back when all the
+	// encoders are at capacity.
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusReply, error)
+	// RunBenchmark encodes a standard set of random blobs on the encoder host and
+	// returns the measured throughput, so that operators can validate the sizing of
+	// the encoder hardware and the control plane can weight the routing of encoding
+	// requests by the capacity of each encoder. Only one benchmark runs at a time.
+	RunBenchmark(context.Context, *RunBenchmarkRequest) (*RunBenchmarkReply, error)
 	mustEmbedUnimplementedEncoderServer()
 }
 
@@ -67,6 +105,12 @@ type UnimplementedEncoderServer struct {
 func (UnimplementedEncoderServer) EncodeBlob(context.Context, *EncodeBlobRequest) (*EncodeBlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EncodeBlob not implemented")
 }
+func (UnimplementedEncoderServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedEncoderServer) RunBenchmark(context.Context, *RunBenchmarkRequest) (*RunBenchmarkReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunBenchmark not implemented")
+}
 func (UnimplementedEncoderServer) mustEmbedUnimplementedEncoderServer() {}
 
 // UnsafeEncoderServer may be embedded to opt out of forward compatibility for this service.
@@ -98,6 +142,42 @@ func _Encoder_EncodeBlob_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Encoder_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncoderServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Encoder_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncoderServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Encoder_RunBenchmark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunBenchmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncoderServer).RunBenchmark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Encoder_RunBenchmark_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncoderServer).RunBenchmark(ctx, req.(*RunBenchmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Encoder_ServiceDesc is the grpc.ServiceDesc for Encoder service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -109,6 +189,14 @@ var Encoder_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EncodeBlob",
 			Handler:    _Encoder_EncodeBlob_Handler,
 		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _Encoder_GetStatus_Handler,
+		},
+		{
+			MethodName: "RunBenchmark",
+			Handler:    _Encoder_RunBenchmark_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "encoder/v2/encoder.proto",
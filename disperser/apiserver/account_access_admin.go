@@ -0,0 +1,218 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	accountAccessAdminPathPrefix = "/v2/admin/accounts/"
+	// maxAccountAccessRequestSize is the maximum size of the body of an admin request
+	maxAccountAccessRequestSize = 4096
+)
+
+type AccountAccessAdminConfig struct {
+	// HTTPPort is the port the admin API listens on
+	HTTPPort string
+	// Token is the bearer token the admin requests must be authorized with
+	Token string
+}
+
+// AccountAccessAdminServer serves the admin API to modify the account blocklist and allowlist:
+//
+//	GET    /v2/admin/accounts/{blocklist|allowlist}            lists the accounts in the list
+//	PUT    /v2/admin/accounts/{blocklist|allowlist}/{account}  adds the account to the list, with an optional
+//	                                                           {"reason": "..."} body
+//	DELETE /v2/admin/accounts/{blocklist|allowlist}/{account}  removes the account from the list
+//
+// Requests must carry an "Authorization: Bearer <token>" header with the configured token.
+type AccountAccessAdminServer struct {
+	config     AccountAccessAdminConfig
+	controller *AccountAccessController
+	logger     logging.Logger
+}
+
+type accountAccessEntryResponse struct {
+	AccountID string `json:"account_id"`
+	Reason    string `json:"reason"`
+	UpdatedAt uint64 `json:"updated_at"`
+}
+
+type accountAccessListResponse struct {
+	Entries []accountAccessEntryResponse `json:"entries"`
+}
+
+type accountAccessRequest struct {
+	Reason string `json:"reason"`
+}
+
+type accountAccessErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewAccountAccessAdminServer(
+	config AccountAccessAdminConfig,
+	controller *AccountAccessController,
+	logger logging.Logger,
+) (*AccountAccessAdminServer, error) {
+	if config.HTTPPort == "" {
+		return nil, errors.New("http port is required")
+	}
+	if config.Token == "" {
+		return nil, errors.New("admin token is required")
+	}
+	if controller == nil {
+		return nil, errors.New("account access controller is required")
+	}
+	return &AccountAccessAdminServer{
+		config:     config,
+		controller: controller,
+		logger:     logger.With("component", "AccountAccessAdminServer"),
+	}, nil
+}
+
+// Start listens on the admin port and serves the admin API in the background until the context is cancelled.
+func (s *AccountAccessAdminServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", s.config.HTTPPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %s: %w", s.config.HTTPPort, err)
+	}
+	server := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Error("failed to shut down account access admin server", "err", err)
+		}
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("account access admin server stopped", "err", err)
+		}
+	}()
+
+	s.logger.Info("Account access admin API listening", "port", s.config.HTTPPort)
+	return nil
+}
+
+func (s *AccountAccessAdminServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeAccountAccessError(w, http.StatusUnauthorized, "missing or invalid admin token")
+		return
+	}
+
+	path, ok := strings.CutPrefix(r.URL.Path, accountAccessAdminPathPrefix)
+	if !ok {
+		writeAccountAccessError(w, http.StatusNotFound, "not found")
+		return
+	}
+	listName, accountID, hasAccount := strings.Cut(path, "/")
+	var list v2.AccountAccessList
+	switch listName {
+	case "blocklist":
+		list = v2.Blocklist
+	case "allowlist":
+		list = v2.Allowlist
+	default:
+		writeAccountAccessError(w, http.StatusNotFound, fmt.Sprintf("unknown account list %q", listName))
+		return
+	}
+
+	switch {
+	case !hasAccount && r.Method == http.MethodGet:
+		s.listAccounts(w, list)
+	case hasAccount && r.Method == http.MethodPut:
+		s.addAccount(w, r, list, accountID)
+	case hasAccount && r.Method == http.MethodDelete:
+		s.removeAccount(w, r, list, accountID)
+	default:
+		writeAccountAccessError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed on %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *AccountAccessAdminServer) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.config.Token)) == 1
+}
+
+func (s *AccountAccessAdminServer) listAccounts(w http.ResponseWriter, list v2.AccountAccessList) {
+	entries := s.controller.Entries(list)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].AccountID < entries[j].AccountID
+	})
+	response := accountAccessListResponse{
+		Entries: make([]accountAccessEntryResponse, len(entries)),
+	}
+	for i, entry := range entries {
+		response.Entries[i] = toAccountAccessEntryResponse(entry)
+	}
+	writeAccountAccessJSON(w, http.StatusOK, response)
+}
+
+func (s *AccountAccessAdminServer) addAccount(w http.ResponseWriter, r *http.Request, list v2.AccountAccessList, accountID string) {
+	var request accountAccessRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, maxAccountAccessRequestSize)).Decode(&request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		writeAccountAccessError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	entry, err := s.controller.Add(r.Context(), list, accountID, request.Reason)
+	if err != nil {
+		s.writeControllerError(w, err)
+		return
+	}
+	writeAccountAccessJSON(w, http.StatusOK, toAccountAccessEntryResponse(entry))
+}
+
+func (s *AccountAccessAdminServer) removeAccount(w http.ResponseWriter, r *http.Request, list v2.AccountAccessList, accountID string) {
+	if err := s.controller.Remove(r.Context(), list, accountID); err != nil {
+		s.writeControllerError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *AccountAccessAdminServer) writeControllerError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrInvalidAccountID) {
+		writeAccountAccessError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.logger.Error("failed to modify account access list", "err", err)
+	writeAccountAccessError(w, http.StatusInternalServerError, err.Error())
+}
+
+func toAccountAccessEntryResponse(entry *v2.AccountAccessEntry) accountAccessEntryResponse {
+	return accountAccessEntryResponse{
+		AccountID: entry.AccountID,
+		Reason:    entry.Reason,
+		UpdatedAt: entry.UpdatedAt,
+	}
+}
+
+func writeAccountAccessJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeAccountAccessError(w http.ResponseWriter, status int, msg string) {
+	writeAccountAccessJSON(w, status, accountAccessErrorResponse{Error: msg})
+}
@@ -0,0 +1,207 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+type AccountAccessConfig struct {
+	// AllowlistMode rejects the dispersals of every account that isn't in the allowlist. Otherwise, only the
+	// dispersals of the accounts in the blocklist are rejected.
+	AllowlistMode bool
+	// RefreshInterval is how often the lists are reloaded from the metadata store, which is how the changes made
+	// through other apiserver instances are picked up
+	RefreshInterval time.Duration
+}
+
+// AccountAccessStore persists the account blocklist and allowlist, so that they're shared by the apiserver instances
+// and survive restarts.
+type AccountAccessStore interface {
+	PutAccountAccessEntry(ctx context.Context, list v2.AccountAccessList, entry *v2.AccountAccessEntry) error
+	GetAccountAccessEntries(ctx context.Context, list v2.AccountAccessList) ([]*v2.AccountAccessEntry, error)
+	DeleteAccountAccessEntry(ctx context.Context, list v2.AccountAccessList, accountID string) error
+}
+
+// AccountAccessController decides which accounts may disperse blobs, from an account blocklist and, in allowlist
+// mode, an account allowlist. The lists can be modified while the apiserver is running, to respond to abuse without
+// redeploying it.
+//
+// The lists are cached in memory and reloaded in the background, so access checks don't add latency to dispersal
+// requests, at the cost of the changes made through other apiserver instances taking up to the refresh interval to
+// apply.
+type AccountAccessController struct {
+	*AccountAccessConfig
+
+	store  AccountAccessStore
+	logger logging.Logger
+
+	// refreshMu serializes the reloads of the lists, so that an older snapshot never replaces a newer one
+	refreshMu sync.Mutex
+	lists     atomic.Pointer[accountAccessLists]
+}
+
+type accountAccessLists map[v2.AccountAccessList]map[string]*v2.AccountAccessEntry
+
+func NewAccountAccessController(
+	config *AccountAccessConfig,
+	store AccountAccessStore,
+	logger logging.Logger,
+) (*AccountAccessController, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if store == nil {
+		return nil, errors.New("account access store is required")
+	}
+	if config.RefreshInterval <= 0 {
+		return nil, errors.New("refresh interval must be positive")
+	}
+	return &AccountAccessController{
+		AccountAccessConfig: config,
+		store:               store,
+		logger:              logger.With("component", "AccountAccessController"),
+	}, nil
+}
+
+// Start loads the lists, then keeps reloading them until the context is cancelled.
+func (c *AccountAccessController) Start(ctx context.Context) error {
+	if err := c.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to load account access lists: %w", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.Refresh(ctx); err != nil {
+					c.logger.Error("failed to reload account access lists", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Refresh reloads the lists from the store. The previous lists are kept if either list fails to be loaded.
+func (c *AccountAccessController) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	lists := make(accountAccessLists)
+	for _, list := range []v2.AccountAccessList{v2.Blocklist, v2.Allowlist} {
+		entries, err := c.store.GetAccountAccessEntries(ctx, list)
+		if err != nil {
+			return fmt.Errorf("failed to get %s entries: %w", list, err)
+		}
+		lists[list] = make(map[string]*v2.AccountAccessEntry, len(entries))
+		for _, entry := range entries {
+			lists[list][entry.AccountID] = entry
+		}
+	}
+
+	previous := c.lists.Swap(&lists)
+	if previous == nil || len((*previous)[v2.Blocklist]) != len(lists[v2.Blocklist]) || len((*previous)[v2.Allowlist]) != len(lists[v2.Allowlist]) {
+		c.logger.Info("loaded account access lists", "numBlocked", len(lists[v2.Blocklist]), "numAllowed", len(lists[v2.Allowlist]))
+	}
+	return nil
+}
+
+// Check returns a PermissionDenied error if the account may not disperse blobs, and nil otherwise.
+// It accepts every account if the controller is nil, so that the server can be used without access control.
+func (c *AccountAccessController) Check(accountID string) error {
+	if c == nil {
+		return nil
+	}
+	lists := c.lists.Load()
+	if lists == nil {
+		return nil
+	}
+
+	accountID = gethcommon.HexToAddress(accountID).Hex()
+	if _, ok := (*lists)[v2.Blocklist][accountID]; ok {
+		return api.NewErrorPermissionDenied(fmt.Sprintf("account %s is blocked from dispersing blobs", accountID))
+	}
+	if c.AllowlistMode {
+		if _, ok := (*lists)[v2.Allowlist][accountID]; !ok {
+			return api.NewErrorPermissionDenied(fmt.Sprintf("account %s is not allowed to disperse blobs", accountID))
+		}
+	}
+	return nil
+}
+
+// Entries returns the accounts in the list, as of the last time the lists were loaded.
+func (c *AccountAccessController) Entries(list v2.AccountAccessList) []*v2.AccountAccessEntry {
+	lists := c.lists.Load()
+	if lists == nil {
+		return nil
+	}
+	entries := make([]*v2.AccountAccessEntry, 0, len((*lists)[list]))
+	for _, entry := range (*lists)[list] {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Add adds the account to the list, or updates the reason it's in the list, and reloads the lists so that the change
+// applies to this instance right away.
+func (c *AccountAccessController) Add(ctx context.Context, list v2.AccountAccessList, accountID string, reason string) (*v2.AccountAccessEntry, error) {
+	accountID, err := normalizeAccountID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	entry := &v2.AccountAccessEntry{
+		AccountID: accountID,
+		Reason:    reason,
+		UpdatedAt: uint64(time.Now().UnixNano()),
+	}
+	if err := c.store.PutAccountAccessEntry(ctx, list, entry); err != nil {
+		return nil, fmt.Errorf("failed to add account to %s: %w", list, err)
+	}
+	c.logger.Info("added account", "list", list, "accountID", accountID, "reason", reason)
+	if err := c.Refresh(ctx); err != nil {
+		c.logger.Warn("failed to reload account access lists", "err", err)
+	}
+	return entry, nil
+}
+
+// Remove removes the account from the list, and reloads the lists so that the change applies to this instance right
+// away.
+func (c *AccountAccessController) Remove(ctx context.Context, list v2.AccountAccessList, accountID string) error {
+	accountID, err := normalizeAccountID(accountID)
+	if err != nil {
+		return err
+	}
+	if err := c.store.DeleteAccountAccessEntry(ctx, list, accountID); err != nil {
+		return fmt.Errorf("failed to remove account from %s: %w", list, err)
+	}
+	c.logger.Info("removed account", "list", list, "accountID", accountID)
+	if err := c.Refresh(ctx); err != nil {
+		c.logger.Warn("failed to reload account access lists", "err", err)
+	}
+	return nil
+}
+
+// ErrInvalidAccountID is returned when an account ID isn't a hex address
+var ErrInvalidAccountID = errors.New("account ID must be a hex address")
+
+// normalizeAccountID returns the checksummed form of the account ID, which is how accounts are keyed in the lists
+func normalizeAccountID(accountID string) (string, error) {
+	if !gethcommon.IsHexAddress(accountID) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidAccountID, accountID)
+	}
+	return gethcommon.HexToAddress(accountID).Hex(), nil
+}
@@ -0,0 +1,191 @@
+package apiserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAccountAccessStore struct {
+	mu      sync.Mutex
+	entries map[v2.AccountAccessList]map[string]*v2.AccountAccessEntry
+	err     error
+}
+
+func newFakeAccountAccessStore() *fakeAccountAccessStore {
+	return &fakeAccountAccessStore{
+		entries: map[v2.AccountAccessList]map[string]*v2.AccountAccessEntry{
+			v2.Blocklist: {},
+			v2.Allowlist: {},
+		},
+	}
+}
+
+func (s *fakeAccountAccessStore) PutAccountAccessEntry(_ context.Context, list v2.AccountAccessList, entry *v2.AccountAccessEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.entries[list][entry.AccountID] = entry
+	return nil
+}
+
+func (s *fakeAccountAccessStore) GetAccountAccessEntries(_ context.Context, list v2.AccountAccessList) ([]*v2.AccountAccessEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	entries := make([]*v2.AccountAccessEntry, 0, len(s.entries[list]))
+	for _, entry := range s.entries[list] {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *fakeAccountAccessStore) DeleteAccountAccessEntry(_ context.Context, list v2.AccountAccessList, accountID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	delete(s.entries[list], accountID)
+	return nil
+}
+
+func requirePermissionDenied(t *testing.T, err error) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.PermissionDenied, st.Code())
+}
+
+func TestAccountAccessControllerBlocklist(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeAccountAccessStore()
+	controller, err := apiserver.NewAccountAccessController(&apiserver.AccountAccessConfig{
+		RefreshInterval: time.Second,
+	}, store, logging.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, controller.Refresh(ctx))
+
+	account := gethcommon.HexToAddress("0x1234").Hex()
+	assert.NoError(t, controller.Check(account))
+
+	// accounts are matched regardless of the case of their hex address
+	_, err = controller.Add(ctx, v2.Blocklist, strings.ToLower(account), "spam")
+	require.NoError(t, err)
+	requirePermissionDenied(t, controller.Check(account))
+	requirePermissionDenied(t, controller.Check(strings.ToLower(account)))
+	assert.NoError(t, controller.Check(gethcommon.HexToAddress("0x5678").Hex()))
+
+	// failing reloads keep the last lists
+	store.err = errors.New("failure")
+	assert.Error(t, controller.Refresh(ctx))
+	requirePermissionDenied(t, controller.Check(account))
+
+	store.err = nil
+	require.NoError(t, controller.Remove(ctx, v2.Blocklist, account))
+	assert.NoError(t, controller.Check(account))
+
+	_, err = controller.Add(ctx, v2.Blocklist, "not an address", "")
+	assert.ErrorIs(t, err, apiserver.ErrInvalidAccountID)
+
+	// a nil controller accepts every account
+	var nilController *apiserver.AccountAccessController
+	assert.NoError(t, nilController.Check(account))
+}
+
+func TestAccountAccessControllerAllowlistMode(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeAccountAccessStore()
+	controller, err := apiserver.NewAccountAccessController(&apiserver.AccountAccessConfig{
+		AllowlistMode:   true,
+		RefreshInterval: time.Second,
+	}, store, logging.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, controller.Refresh(ctx))
+
+	account := gethcommon.HexToAddress("0x1234").Hex()
+	requirePermissionDenied(t, controller.Check(account))
+
+	// changes made through another instance apply once the lists are reloaded
+	require.NoError(t, store.PutAccountAccessEntry(ctx, v2.Allowlist, &v2.AccountAccessEntry{AccountID: account}))
+	requirePermissionDenied(t, controller.Check(account))
+	require.NoError(t, controller.Refresh(ctx))
+	assert.NoError(t, controller.Check(account))
+
+	// the blocklist takes precedence over the allowlist
+	_, err = controller.Add(ctx, v2.Blocklist, account, "abuse")
+	require.NoError(t, err)
+	requirePermissionDenied(t, controller.Check(account))
+}
+
+func TestAccountAccessAdminServer(t *testing.T) {
+	store := newFakeAccountAccessStore()
+	controller, err := apiserver.NewAccountAccessController(&apiserver.AccountAccessConfig{
+		RefreshInterval: time.Second,
+	}, store, logging.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, controller.Refresh(context.Background()))
+	server, err := apiserver.NewAccountAccessAdminServer(apiserver.AccountAccessAdminConfig{
+		HTTPPort: "0",
+		Token:    "secret",
+	}, controller, logging.NewNoopLogger())
+	require.NoError(t, err)
+
+	do := func(method string, path string, body string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+	account := gethcommon.HexToAddress("0x1234").Hex()
+
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/v2/admin/accounts/blocklist", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/v2/admin/accounts/blocklist", "", "wrong").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/v2/admin/accounts/denylist", "", "secret").Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/v2/admin/accounts/blocklist/0x12", "", "secret").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, do(http.MethodPost, "/v2/admin/accounts/blocklist/"+account, "", "secret").Code)
+
+	w := do(http.MethodPut, "/v2/admin/accounts/blocklist/"+account, `{"reason":"spam"}`, "secret")
+	require.Equal(t, http.StatusOK, w.Code)
+	requirePermissionDenied(t, controller.Check(account))
+
+	w = do(http.MethodGet, "/v2/admin/accounts/blocklist", "", "secret")
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Entries []struct {
+			AccountID string `json:"account_id"`
+			Reason    string `json:"reason"`
+		} `json:"entries"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Entries, 1)
+	assert.Equal(t, account, response.Entries[0].AccountID)
+	assert.Equal(t, "spam", response.Entries[0].Reason)
+
+	// the reason is optional
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/v2/admin/accounts/allowlist/"+account, "", "secret").Code)
+
+	require.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/v2/admin/accounts/blocklist/"+account, "", "secret").Code)
+	assert.NoError(t, controller.Check(account))
+}
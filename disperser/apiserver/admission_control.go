@@ -0,0 +1,148 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+type AdmissionControlConfig struct {
+	// MaxEncodingQueueDepth is the number of blobs waiting to be encoded beyond which new blobs are rejected.
+	// Zero disables the limit.
+	MaxEncodingQueueDepth int
+	// MaxDispatchQueueDepth is the number of encoded blobs waiting to be dispatched to the operators beyond which new
+	// blobs are rejected. Zero disables the limit.
+	MaxDispatchQueueDepth int
+	// PollInterval is how often the queue depths are read from the metadata store
+	PollInterval time.Duration
+}
+
+// Enabled returns true if any queue depth limit is set.
+func (c *AdmissionControlConfig) Enabled() bool {
+	return c.MaxEncodingQueueDepth > 0 || c.MaxDispatchQueueDepth > 0
+}
+
+// QueueDepthReader reads the number of blobs in a given status, which is how deep the queue of the component
+// processing blobs in that status is.
+type QueueDepthReader interface {
+	GetBlobMetadataCountByStatus(ctx context.Context, status v2.BlobStatus) (int32, error)
+}
+
+// AdmissionController sheds dispersal load when the downstream pipeline is saturated. It tracks the number of blobs
+// waiting for the encoder (Queued) and for the controller (Encoded), and rejects new blobs while either queue is
+// deeper than its limit, instead of accepting blobs that would sit unprocessed past their deadline.
+//
+// The queue depths are polled in the background, so admission decisions don't add latency to dispersal requests,
+// at the cost of lagging the actual depths by up to the poll interval.
+type AdmissionController struct {
+	*AdmissionControlConfig
+
+	queueDepthReader QueueDepthReader
+	logger           logging.Logger
+
+	encodingQueueDepth atomic.Int32
+	dispatchQueueDepth atomic.Int32
+}
+
+func NewAdmissionController(
+	config *AdmissionControlConfig,
+	queueDepthReader QueueDepthReader,
+	logger logging.Logger,
+) (*AdmissionController, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if queueDepthReader == nil {
+		return nil, errors.New("queue depth reader is required")
+	}
+	if config.MaxEncodingQueueDepth < 0 || config.MaxDispatchQueueDepth < 0 {
+		return nil, errors.New("max queue depths must not be negative")
+	}
+	if config.PollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+	return &AdmissionController{
+		AdmissionControlConfig: config,
+		queueDepthReader:       queueDepthReader,
+		logger:                 logger.With("component", "AdmissionController"),
+	}, nil
+}
+
+// Start reads the queue depths, then keeps polling them until the context is cancelled.
+func (c *AdmissionController) Start(ctx context.Context) error {
+	if err := c.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to read queue depths: %w", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.Refresh(ctx); err != nil {
+					c.logger.Error("failed to read queue depths", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Refresh reads the current queue depths. Depths that fail to be read keep their previous value.
+func (c *AdmissionController) Refresh(ctx context.Context) error {
+	encodingQueueDepth, err := c.queueDepthReader.GetBlobMetadataCountByStatus(ctx, v2.Queued)
+	if err != nil {
+		return fmt.Errorf("failed to count queued blobs: %w", err)
+	}
+	c.encodingQueueDepth.Store(encodingQueueDepth)
+
+	dispatchQueueDepth, err := c.queueDepthReader.GetBlobMetadataCountByStatus(ctx, v2.Encoded)
+	if err != nil {
+		return fmt.Errorf("failed to count encoded blobs: %w", err)
+	}
+	c.dispatchQueueDepth.Store(dispatchQueueDepth)
+	return nil
+}
+
+// QueueDepths returns the last read number of blobs waiting to be encoded and waiting to be dispatched.
+func (c *AdmissionController) QueueDepths() (encoding int, dispatch int) {
+	return int(c.encodingQueueDepth.Load()), int(c.dispatchQueueDepth.Load())
+}
+
+// Admit returns a ResourceExhausted error if the pipeline is saturated, and nil if a new blob can be accepted.
+// It accepts every blob if the controller is nil, so that the server can be used without admission control.
+//
+// The error carries a hint of how long to back off before retrying: the poll interval, which is the earliest the
+// controller can see the queue drain, scaled by how far the queue is over its limit.
+func (c *AdmissionController) Admit() error {
+	if c == nil {
+		return nil
+	}
+
+	encodingQueueDepth, dispatchQueueDepth := c.QueueDepths()
+	if c.MaxEncodingQueueDepth > 0 && encodingQueueDepth > c.MaxEncodingQueueDepth {
+		return api.NewErrorPipelineSaturated(
+			fmt.Sprintf("disperser is saturated: %d blobs are waiting to be encoded, please retry later", encodingQueueDepth),
+			c.retryAfter(encodingQueueDepth, c.MaxEncodingQueueDepth))
+	}
+	if c.MaxDispatchQueueDepth > 0 && dispatchQueueDepth > c.MaxDispatchQueueDepth {
+		return api.NewErrorPipelineSaturated(
+			fmt.Sprintf("disperser is saturated: %d blobs are waiting to be dispatched, please retry later", dispatchQueueDepth),
+			c.retryAfter(dispatchQueueDepth, c.MaxDispatchQueueDepth))
+	}
+	return nil
+}
+
+func (c *AdmissionController) retryAfter(depth int, maxDepth int) time.Duration {
+	return c.PollInterval * time.Duration(depth) / time.Duration(maxDepth)
+}
@@ -0,0 +1,104 @@
+package apiserver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeQueueDepthReader struct {
+	counts map[v2.BlobStatus]int32
+	err    error
+}
+
+func (r *fakeQueueDepthReader) GetBlobMetadataCountByStatus(_ context.Context, status v2.BlobStatus) (int32, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.counts[status], nil
+}
+
+func requireSaturated(t *testing.T, err error, expectedRetryAfter time.Duration) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	var reason string
+	var retryAfter time.Duration
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			reason = d.GetReason()
+		case *errdetails.RetryInfo:
+			retryAfter = d.GetRetryDelay().AsDuration()
+		}
+	}
+	assert.Equal(t, api.ErrorReasonPipelineSaturated, reason)
+	assert.Equal(t, expectedRetryAfter, retryAfter)
+}
+
+func TestAdmissionController(t *testing.T) {
+	ctx := context.Background()
+	reader := &fakeQueueDepthReader{counts: map[v2.BlobStatus]int32{}}
+	controller, err := apiserver.NewAdmissionController(&apiserver.AdmissionControlConfig{
+		MaxEncodingQueueDepth: 100,
+		MaxDispatchQueueDepth: 50,
+		PollInterval:          time.Second,
+	}, reader, logging.NewNoopLogger())
+	require.NoError(t, err)
+
+	require.NoError(t, controller.Refresh(ctx))
+	assert.NoError(t, controller.Admit())
+
+	// at the limit
+	reader.counts[v2.Queued] = 100
+	reader.counts[v2.Encoded] = 50
+	require.NoError(t, controller.Refresh(ctx))
+	assert.NoError(t, controller.Admit())
+
+	// encoding queue over the limit
+	reader.counts[v2.Queued] = 300
+	require.NoError(t, controller.Refresh(ctx))
+	encoding, dispatch := controller.QueueDepths()
+	assert.Equal(t, 300, encoding)
+	assert.Equal(t, 50, dispatch)
+	requireSaturated(t, controller.Admit(), 3*time.Second)
+
+	// dispatch queue over the limit
+	reader.counts[v2.Queued] = 0
+	reader.counts[v2.Encoded] = 75
+	require.NoError(t, controller.Refresh(ctx))
+	requireSaturated(t, controller.Admit(), 1500*time.Millisecond)
+
+	// failing reads keep the last depths
+	reader.err = errors.New("failure")
+	assert.Error(t, controller.Refresh(ctx))
+	requireSaturated(t, controller.Admit(), 1500*time.Millisecond)
+
+	// a nil controller admits everything
+	var nilController *apiserver.AdmissionController
+	assert.NoError(t, nilController.Admit())
+}
+
+func TestAdmissionControllerDisabledLimits(t *testing.T) {
+	reader := &fakeQueueDepthReader{counts: map[v2.BlobStatus]int32{v2.Queued: 1000, v2.Encoded: 1000}}
+	controller, err := apiserver.NewAdmissionController(&apiserver.AdmissionControlConfig{
+		PollInterval: time.Second,
+	}, reader, logging.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, controller.Refresh(context.Background()))
+	assert.NoError(t, controller.Admit())
+
+	_, err = apiserver.NewAdmissionController(&apiserver.AdmissionControlConfig{}, reader, logging.NewNoopLogger())
+	assert.Error(t, err)
+}
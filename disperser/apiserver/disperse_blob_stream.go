@@ -0,0 +1,128 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"google.golang.org/grpc/codes"
+)
+
+// DisperseBlobStream receives a blob uploaded in chunks, reassembles it and disperses it the same way as DisperseBlob.
+func (s *DispersalServer) DisperseBlobStream(stream pb.Disperser_DisperseBlobStreamServer) error {
+
+	// This uses the existing deadline of stream.Context() if it is earlier.
+	ctx, cancel := context.WithTimeout(stream.Context(), s.serverConfig.GrpcTimeout)
+	defer cancel()
+
+	req, err := s.receiveBlobStream(ctx, stream)
+	if err != nil {
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+		s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+		return api.NewErrorInvalidArg(err.Error())
+	}
+
+	blob, err := s.validateRequestAndGetBlob(ctx, req)
+	if err != nil {
+		for _, quorumID := range req.CustomQuorumNumbers {
+			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(req.GetData()), "DisperseBlobStream")
+		}
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+		return api.NewErrorInvalidArg(err.Error())
+	}
+
+	reply, err := s.disperseBlob(ctx, blob, "", "DisperseBlobStream", nil)
+	if err != nil {
+		// Note the disperseBlob already updated metrics for this error.
+		s.logger.Info("failed to disperse blob", "err", err)
+		return err
+	}
+
+	err = stream.SendAndClose(reply)
+	if err != nil {
+		s.logger.Error("failed to send back DisperseReply", "err", err)
+		return err
+	}
+
+	s.metrics.HandleSuccessfulRpcRequest("DisperseBlobStream")
+
+	return nil
+}
+
+// receiveBlobStream receives the header and the chunks of a DisperseBlobStream call until the client closes the
+// stream or ctx is done, and returns the reassembled request.
+func (s *DispersalServer) receiveBlobStream(ctx context.Context, stream pb.Disperser_DisperseBlobStreamServer) (*pb.DisperseBlobRequest, error) {
+	// Buffered so that the goroutine doesn't leak if ctx is done first: the stream is closed once the handler
+	// returns, which unblocks stream.Recv()
+	resultCh := make(chan *pb.DisperseBlobRequest, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		req, err := s.readBlobStream(stream)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		resultCh <- req
+	}()
+
+	select {
+	case req := <-resultCh:
+		return req, nil
+	case err := <-errCh:
+		return nil, err
+	case <-ctx.Done():
+		return nil, errors.New("context deadline exceeded")
+	}
+}
+
+func (s *DispersalServer) readBlobStream(stream pb.Disperser_DisperseBlobStreamServer) (*pb.DisperseBlobRequest, error) {
+	in, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("error receiving next message: %v", err)
+	}
+	header := in.GetHeader()
+	if header == nil {
+		return nil, errors.New("missing DisperseBlobStreamHeader")
+	}
+
+	// The declared blob size is checked up front, so that an oversized blob is rejected before it is received
+	blobSize := int(header.GetBlobSize())
+	if blobSize > s.maxBlobSize {
+		return nil, fmt.Errorf("blob size cannot exceed %v Bytes", s.maxBlobSize)
+	}
+	if blobSize == 0 {
+		return nil, errors.New("blob size must be greater than 0")
+	}
+
+	data := make([]byte, 0, blobSize)
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error receiving next message: %v", err)
+		}
+		chunk, ok := in.GetPayload().(*pb.DisperseBlobStreamRequest_Chunk)
+		if !ok {
+			return nil, errors.New("expected a chunk of the blob data")
+		}
+		if len(data)+len(chunk.Chunk) > blobSize {
+			return nil, fmt.Errorf("received more than the declared blob size of %d Bytes", blobSize)
+		}
+		data = append(data, chunk.Chunk...)
+	}
+	if len(data) < blobSize {
+		return nil, fmt.Errorf("received %d Bytes, less than the declared blob size of %d Bytes", len(data), blobSize)
+	}
+
+	return &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: header.GetCustomQuorumNumbers(),
+		AccountId:           header.GetAccountId(),
+	}, nil
+}
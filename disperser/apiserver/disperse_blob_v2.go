@@ -2,19 +2,36 @@ package apiserver
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	dacommon "github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// maxCallbackURLLength is the maximum length of the callback URL of a dispersal request
+	maxCallbackURLLength = 2048
+	// maxDuplicateBlobAge is how long after a blob was dispersed a dispersal of the same content is answered with it,
+	// which bounds how much earlier than requested the blob returned for a duplicate dispersal expires
+	maxDuplicateBlobAge = time.Hour
 )
 
 func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*pb.DisperseBlobReply, error) {
@@ -28,27 +45,78 @@ func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBl
 		return nil, api.NewErrorInternal("onchain state is nil")
 	}
 
-	if err := s.validateDispersalRequest(ctx, req, onchainState); err != nil {
+	// Blocked accounts are rejected before any work is done to validate their requests
+	if err := s.accountAccessController.Check(req.GetBlobHeader().GetPaymentHeader().GetAccountId()); err != nil {
+		s.metrics.reportAccountAccessRejection()
 		return nil, err
 	}
 
-	finishedValidation := time.Now()
-	s.metrics.reportValidateDispersalRequestLatency(finishedValidation.Sub(start))
-
-	s.metrics.reportDisperseBlobSize(len(req.GetData()))
+	if err := s.validateDispersalRequest(ctx, req, onchainState); err != nil {
+		return nil, err
+	}
 
 	data := req.GetData()
 	blobHeader, err := corev2.BlobHeaderFromProtobuf(req.GetBlobHeader())
 	if err != nil {
 		return nil, api.NewErrorInternal(err.Error())
 	}
+
+	// Dispersing the same blob again is answered with the existing blob, before the account is charged for it
+	contentHash, err := blobContentHash(blobHeader, onchainState.TTL)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to hash blob content: %v", err))
+	}
+	if !req.GetAllowDuplicate() {
+		lookupStart := time.Now()
+		duplicate := s.findDuplicateBlob(ctx, blobHeader.PaymentMetadata.AccountID, contentHash)
+		s.metrics.reportDuplicateBlobLookupLatency(time.Since(lookupStart))
+		if duplicate != nil {
+			blobKey, err := duplicate.BlobHeader.BlobKey()
+			if err != nil {
+				return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob key: %v", err))
+			}
+			s.logger.Debug("returning existing blob for duplicate dispersal request", "blobKey", blobKey.Hex())
+			s.metrics.reportDuplicateBlob()
+			return &pb.DisperseBlobReply{
+				Result:  duplicate.BlobStatus.ToProfobuf(),
+				BlobKey: blobKey[:],
+			}, nil
+		}
+	}
+
+	// New blobs are shed while the pipeline is saturated, before the account is charged for them
+	if err := s.admissionController.Admit(); err != nil {
+		s.metrics.reportSaturatedRejection()
+		return nil, err
+	}
+
+	if err := s.chargeDispersalRequest(ctx, req, blobHeader); err != nil {
+		return nil, err
+	}
+
+	finishedValidation := time.Now()
+	s.metrics.reportValidateDispersalRequestLatency(finishedValidation.Sub(start))
+
+	s.metrics.reportDisperseBlobSize(len(req.GetData()))
+
 	s.logger.Debug("received a new blob dispersal request", "blobSizeBytes", len(data), "quorums", req.GetBlobHeader().GetQuorumNumbers())
 
-	blobKey, err := s.StoreBlob(ctx, data, blobHeader, time.Now(), onchainState.TTL)
+	blobKey, err := s.StoreBlob(ctx, data, blobHeader, req.GetCallbackUrl(), time.Now(), onchainState.TTL)
 	if err != nil {
 		return nil, err
 	}
 
+	// The request is only recorded once it is dispersed, so that a request rejected for e.g. its payment can be
+	// resubmitted. A replay of a dispersed request is rejected by the blob store regardless, since it has the same key.
+	if err := s.authenticator.RecordBlobRequest(ctx, blobHeader); err != nil {
+		s.logger.Warn("failed to record blob request", "err", err, "blobKey", blobKey.Hex())
+	}
+
+	if err := s.blobMetadataStore.PutBlobContentKey(ctx, blobHeader.PaymentMetadata.AccountID, contentHash, blobKey); err != nil {
+		// The blob is dispersed regardless, it just won't be deduplicated
+		s.logger.Warn("failed to store blob content key", "err", err, "blobKey", blobKey.Hex())
+	}
+
 	s.metrics.reportStoreBlobLatency(time.Since(finishedValidation))
 
 	return &pb.DisperseBlobReply{
@@ -57,7 +125,7 @@ func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBl
 	}, nil
 }
 
-func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHeader *corev2.BlobHeader, requestedAt time.Time, ttl time.Duration) (corev2.BlobKey, error) {
+func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHeader *corev2.BlobHeader, callbackURL string, requestedAt time.Time, ttl time.Duration) (corev2.BlobKey, error) {
 	blobKey, err := blobHeader.BlobKey()
 	if err != nil {
 		return corev2.BlobKey{}, api.NewErrorInvalidArg(fmt.Sprintf("failed to get blob key: %v", err))
@@ -75,11 +143,12 @@ func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHead
 	blobMetadata := &dispv2.BlobMetadata{
 		BlobHeader:  blobHeader,
 		BlobStatus:  dispv2.Queued,
-		Expiry:      uint64(requestedAt.Add(ttl).Unix()),
+		Expiry:      uint64(requestedAt.Add(blobHeader.GetRetentionPeriod(ttl)).Unix()),
 		NumRetries:  0,
 		BlobSize:    uint64(len(data)),
 		RequestedAt: uint64(requestedAt.UnixNano()),
 		UpdatedAt:   uint64(requestedAt.UnixNano()),
+		CallbackURL: callbackURL,
 	}
 	err = s.blobMetadataStore.PutBlobMetadata(ctx, blobMetadata)
 	if err != nil {
@@ -143,14 +212,47 @@ func (s *DispersalServerV2) validateDispersalRequest(ctx context.Context, req *p
 		return api.NewErrorInvalidArg("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617")
 	}
 
-	if _, ok := onchainState.BlobVersionParameters.Get(corev2.BlobVersion(blobHeaderProto.GetVersion())); !ok {
+	blobParams, ok := onchainState.BlobVersionParameters.Get(corev2.BlobVersion(blobHeaderProto.GetVersion()))
+	if !ok {
 		return api.NewErrorInvalidArg(fmt.Sprintf("invalid blob version %d; valid blob versions are: %v", blobHeaderProto.GetVersion(), onchainState.BlobVersionParameters.Keys()))
 	}
 
-	if err = s.authenticator.AuthenticateBlobRequest(blobHeader); err != nil {
+	if err = blobHeader.ValidateSecurityThresholds(blobParams, onchainState.SecurityParams); err != nil {
+		return api.NewErrorInvalidArg(err.Error())
+	}
+
+	if err = blobHeader.ValidateRetentionPeriod(onchainState.TTL); err != nil {
+		return api.NewErrorInvalidArg(err.Error())
+	}
+
+	if callbackURL := req.GetCallbackUrl(); callbackURL != "" {
+		if err = validateCallbackURL(callbackURL); err != nil {
+			return api.NewErrorInvalidArg(fmt.Sprintf("invalid callback url: %s", err.Error()))
+		}
+	}
+
+	if err = s.authenticator.AuthenticateBlobRequest(ctx, blobHeader); err != nil {
 		return api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
 	}
 
+	return nil
+}
+
+// chargeDispersalRequest verifies that the blob commitment in the header of a validated dispersal request matches
+// the blob data, and then charges the payment of the request to the account.
+func (s *DispersalServerV2) chargeDispersalRequest(ctx context.Context, req *pb.DisperseBlobRequest, blobHeader *corev2.BlobHeader) error {
+	data := req.GetData()
+	blobLength := encoding.GetBlobLengthPowerOf2(uint(len(data)))
+	blobHeaderProto := req.GetBlobHeader()
+
+	commitments, err := s.prover.GetCommitmentsForPaddedLength(data)
+	if err != nil {
+		return api.NewErrorInternal(fmt.Sprintf("failed to get commitments: %v", err))
+	}
+	if !commitments.Equal(&blobHeader.BlobCommitments) {
+		return api.NewErrorInvalidArg("invalid blob commitment")
+	}
+
 	// handle payments and check rate limits
 	reservationPeriod := blobHeaderProto.GetPaymentHeader().GetReservationPeriod()
 	cumulativePayment := new(big.Int).SetBytes(blobHeaderProto.GetPaymentHeader().GetCumulativePayment())
@@ -162,18 +264,128 @@ func (s *DispersalServerV2) validateDispersalRequest(ctx context.Context, req *p
 		CumulativePayment: cumulativePayment,
 	}
 
+	var symbolsMetered uint64
+	if cumulativePayment.Sign() == 0 {
+		symbolsMetered, err = s.meterReservation(ctx, accountID, blobLength)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = s.meterer.MeterRequest(ctx, paymentHeader, blobLength, blobHeader.QuorumNumbers)
 	if err != nil {
+		// The dispersal is rejected, so it doesn't use the reservation's bandwidth
+		s.reservationMeter.Refund(time.Now(), accountID, symbolsMetered)
 		return api.NewErrorResourceExhausted(err.Error())
 	}
 
-	commitments, err := s.prover.GetCommitmentsForPaddedLength(data)
+	return nil
+}
+
+// validateCallbackURL checks that a callback URL is an absolute http or https URL that can be notified, whose host is
+// not a non-public IP address.
+func validateCallbackURL(callbackURL string) error {
+	if len(callbackURL) > maxCallbackURLLength {
+		return fmt.Errorf("url is longer than %d bytes", maxCallbackURLLength)
+	}
+	u, err := url.ParseRequestURI(callbackURL)
 	if err != nil {
-		return api.NewErrorInternal(fmt.Sprintf("failed to get commitments: %v", err))
+		return err
 	}
-	if !commitments.Equal(&blobHeader.BlobCommitments) {
-		return api.NewErrorInvalidArg("invalid blob commitment")
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q, must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("url must have a host")
+	}
+	// Host names are checked when the notification is sent, since they may resolve to a different address by then
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil && !dacommon.IsPublicIP(ip) {
+		return fmt.Errorf("host %s is not a public address", host)
+	}
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return fmt.Errorf("host %s is not a public address", host)
 	}
-
 	return nil
 }
+
+// findDuplicateBlob returns the metadata of a blob with the given content hash that the account dispersed within the
+// last maxDuplicateBlobAge, if it is still retained and has not failed. Older blobs are not returned, since a new
+// dispersal would be retained for longer than them. Otherwise, including when the lookup fails, it returns nil.
+func (s *DispersalServerV2) findDuplicateBlob(ctx context.Context, accountID string, contentHash [32]byte) *dispv2.BlobMetadata {
+	blobKey, err := s.blobMetadataStore.GetBlobContentKey(ctx, accountID, contentHash)
+	if err != nil {
+		if !errors.Is(err, common.ErrMetadataNotFound) {
+			s.logger.Warn("failed to get blob content key", "err", err, "accountID", accountID)
+		}
+		return nil
+	}
+
+	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		if !errors.Is(err, common.ErrMetadataNotFound) {
+			s.logger.Warn("failed to get blob metadata", "err", err, "blobKey", blobKey.Hex())
+		}
+		return nil
+	}
+	if metadata.BlobStatus == dispv2.Failed || metadata.BlobStatus == dispv2.InsufficientSignatures {
+		return nil
+	}
+	now := time.Now()
+	if metadata.Expiry <= uint64(now.Unix()) || metadata.RequestedAt < uint64(now.Add(-maxDuplicateBlobAge).UnixNano()) {
+		return nil
+	}
+	return metadata
+}
+
+// blobContentHash returns the hash identifying the content of a blob, which is the same for blobs that only differ
+// in their payment. Blobs with different security thresholds or retention periods are not considered the same, where
+// the retention period of blobs which don't request one is the maximum retention period ttl.
+func blobContentHash(blobHeader *corev2.BlobHeader, ttl time.Duration) ([32]byte, error) {
+	commitment, err := blobHeader.BlobCommitments.Commitment.Serialize()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	quorumNumbers := slices.Clone(blobHeader.QuorumNumbers)
+	slices.Sort(quorumNumbers)
+
+	var buf []byte
+	buf = binary.BigEndian.AppendUint16(buf, uint16(blobHeader.BlobVersion))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(blobHeader.BlobCommitments.Length))
+	buf = append(buf, quorumNumbers...)
+	buf = append(buf, commitment...)
+	securityThresholds := slices.Clone(blobHeader.SecurityThresholds)
+	slices.SortFunc(securityThresholds, func(a, b corev2.QuorumSecurityThresholds) int {
+		return int(a.QuorumID) - int(b.QuorumID)
+	})
+	for _, t := range securityThresholds {
+		buf = append(buf, t.QuorumID, t.ConfirmationThreshold, t.AdversaryThreshold)
+	}
+	buf = binary.BigEndian.AppendUint64(buf, uint64(blobHeader.GetRetentionPeriod(ttl)/time.Second))
+	return [32]byte(crypto.Keccak256(buf)), nil
+}
+
+// meterReservation charges a reservation dispersal to the account's bandwidth, returning the symbols charged, or
+// rejecting it with an over reservation error telling when the capacity frees up if the reservation doesn't have the
+// capacity for it
+func (s *DispersalServerV2) meterReservation(ctx context.Context, accountID string, blobLength uint) (uint64, error) {
+	reservation, err := s.meterer.ChainPaymentState.GetReservedPaymentByAccount(ctx, gethcommon.HexToAddress(accountID))
+	if err != nil {
+		return 0, api.NewErrorResourceExhausted(fmt.Sprintf("failed to get active reservation by account: %v", err))
+	}
+	// Inactive reservations are rejected by the meterer
+	if !reservation.IsActive(uint64(time.Now().Unix())) {
+		return 0, nil
+	}
+
+	symbolsCharged := uint64(s.meterer.SymbolsCharged(blobLength))
+	err = s.reservationMeter.Use(time.Now(), accountID, reservation, s.meterer.ChainPaymentState.GetReservationWindow(), symbolsCharged)
+	var overReservation *meterer.ErrOverReservation
+	if errors.As(err, &overReservation) {
+		return 0, api.NewErrorOverReservation(err.Error(), overReservation.RetryAfter)
+	}
+	if err != nil {
+		return 0, api.NewErrorResourceExhausted(err.Error())
+	}
+	return symbolsCharged, nil
+}
@@ -11,8 +11,12 @@ import (
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"google.golang.org/grpc/status"
 )
 
+// maxBlobStatusesKeys is the maximum number of blob keys allowed in a single GetBlobStatuses request
+const maxBlobStatusesKeys = 100
+
 func (s *DispersalServerV2) GetBlobStatus(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, error) {
 	start := time.Now()
 	defer func() {
@@ -34,6 +38,87 @@ func (s *DispersalServerV2) GetBlobStatus(ctx context.Context, req *pb.BlobStatu
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata: %s", err.Error()))
 	}
 
+	return s.getBlobStatusReply(ctx, blobKey, metadata)
+}
+
+// GetBlobStatuses returns the statuses of multiple blobs, in the same order as the requested blob keys.
+// Blobs whose status cannot be retrieved are reported with an error instead of failing the whole request.
+func (s *DispersalServerV2) GetBlobStatuses(ctx context.Context, req *pb.BlobStatusesRequest) (*pb.BlobStatusesReply, error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.reportGetBlobStatusesLatency(time.Since(start))
+	}()
+
+	if len(req.GetBlobKeys()) == 0 {
+		return nil, api.NewErrorInvalidArg("no blob keys provided")
+	}
+	if len(req.GetBlobKeys()) > maxBlobStatusesKeys {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("too many blob keys: %d, maximum is %d", len(req.GetBlobKeys()), maxBlobStatusesKeys))
+	}
+	s.metrics.reportGetBlobStatusesKeys(len(req.GetBlobKeys()))
+
+	blobKeys := make([]corev2.BlobKey, len(req.GetBlobKeys()))
+	// the same key may be requested more than once, but must only be looked up once
+	uniqueKeys := make([]corev2.BlobKey, 0, len(blobKeys))
+	seen := make(map[corev2.BlobKey]struct{}, len(blobKeys))
+	for i, key := range req.GetBlobKeys() {
+		if len(key) != 32 {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key at index %d", i))
+		}
+		blobKey, err := corev2.BytesToBlobKey(key)
+		if err != nil {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key at index %d", i))
+		}
+		blobKeys[i] = blobKey
+		if _, ok := seen[blobKey]; !ok {
+			seen[blobKey] = struct{}{}
+			uniqueKeys = append(uniqueKeys, blobKey)
+		}
+	}
+
+	metadataList, err := s.blobMetadataStore.GetBlobMetadataByKeys(ctx, uniqueKeys)
+	if err != nil {
+		s.logger.Error("failed to get blob metadata", "err", err, "numBlobs", len(uniqueKeys))
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata: %s", err.Error()))
+	}
+	metadataByKey := make(map[corev2.BlobKey]*dispv2.BlobMetadata, len(metadataList))
+	for _, metadata := range metadataList {
+		blobKey, err := metadata.BlobHeader.BlobKey()
+		if err != nil {
+			s.logger.Error("failed to get blob key from metadata", "err", err)
+			continue
+		}
+		metadataByKey[blobKey] = metadata
+	}
+
+	replies := make(map[corev2.BlobKey]*pb.BlobStatusResult, len(uniqueKeys))
+	for _, blobKey := range uniqueKeys {
+		result := &pb.BlobStatusResult{
+			BlobKey: blobKey[:],
+		}
+		metadata, ok := metadataByKey[blobKey]
+		if !ok {
+			result.Error = "blob not found"
+		} else if reply, err := s.getBlobStatusReply(ctx, blobKey, metadata); err != nil {
+			result.Error = status.Convert(err).Message()
+		} else {
+			result.Status = reply
+		}
+		replies[blobKey] = result
+	}
+
+	statuses := make([]*pb.BlobStatusResult, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		statuses[i] = replies[blobKey]
+	}
+	return &pb.BlobStatusesReply{
+		Statuses: statuses,
+	}, nil
+}
+
+// getBlobStatusReply builds the status reply of a blob from its metadata.
+// Certified blobs additionally include the signed batch and the blob verification info.
+func (s *DispersalServerV2) getBlobStatusReply(ctx context.Context, blobKey corev2.BlobKey, metadata *dispv2.BlobMetadata) (*pb.BlobStatusReply, error) {
 	if metadata.BlobStatus != dispv2.Certified {
 		return &pb.BlobStatusReply{
 			Status: metadata.BlobStatus.ToProfobuf(),
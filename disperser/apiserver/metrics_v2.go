@@ -17,11 +17,18 @@ type metricsV2 struct {
 
 	getBlobCommitmentLatency        *prometheus.SummaryVec
 	getPaymentStateLatency          *prometheus.SummaryVec
+	getOnDemandBalanceLatency       *prometheus.SummaryVec
 	disperseBlobLatency             *prometheus.SummaryVec
 	disperseBlobSize                *prometheus.GaugeVec
 	validateDispersalRequestLatency *prometheus.SummaryVec
 	storeBlobLatency                *prometheus.SummaryVec
 	getBlobStatusLatency            *prometheus.SummaryVec
+	getBlobStatusesLatency          *prometheus.SummaryVec
+	getBlobStatusesKeys             *prometheus.SummaryVec
+	duplicateBlobLookupLatency      *prometheus.SummaryVec
+	duplicateBlobs                  *prometheus.CounterVec
+	saturatedRejections             *prometheus.CounterVec
+	accountAccessRejections         *prometheus.CounterVec
 }
 
 // newAPIServerV2Metrics creates a new metricsV2 instance.
@@ -55,6 +62,16 @@ func newAPIServerV2Metrics(registry *prometheus.Registry) *metricsV2 {
 		[]string{},
 	)
 
+	getOnDemandBalanceLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "get_on_demand_balance_latency_ms",
+			Help:       "The time required to get the on-demand balance.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
 	disperseBlobLatency := promauto.With(registry).NewSummaryVec(
 		prometheus.SummaryOpts{
 			Namespace:  namespace,
@@ -104,15 +121,79 @@ func newAPIServerV2Metrics(registry *prometheus.Registry) *metricsV2 {
 		[]string{},
 	)
 
+	getBlobStatusesLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "get_blob_statuses_latency_ms",
+			Help:       "The time required to get the statuses of a batch of blobs.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
+	getBlobStatusesKeys := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "get_blob_statuses_keys",
+			Help:       "The number of blob keys per request to get the statuses of a batch of blobs.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
+	duplicateBlobLookupLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "duplicate_blob_lookup_latency_ms",
+			Help:       "The time required to look up an existing blob with the same content as a dispersal request.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
+	duplicateBlobs := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "duplicate_blobs_total",
+			Help:      "The number of dispersal requests answered with an existing blob with the same content.",
+		},
+		[]string{},
+	)
+
+	saturatedRejections := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "saturated_rejections_total",
+			Help:      "The number of dispersal requests rejected because the encoding or dispatching pipeline was saturated.",
+		},
+		[]string{},
+	)
+
+	accountAccessRejections := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "account_access_rejections_total",
+			Help:      "The number of dispersal requests rejected because the account is blocked or not allowlisted.",
+		},
+		[]string{},
+	)
+
 	return &metricsV2{
 		grpcServerOption:                grpcServerOption,
 		getBlobCommitmentLatency:        getBlobCommitmentLatency,
 		getPaymentStateLatency:          getPaymentStateLatency,
+		getOnDemandBalanceLatency:       getOnDemandBalanceLatency,
 		disperseBlobLatency:             disperseBlobLatency,
 		disperseBlobSize:                disperseBlobSize,
 		validateDispersalRequestLatency: validateDispersalRequestLatency,
 		storeBlobLatency:                storeBlobLatency,
 		getBlobStatusLatency:            getBlobStatusLatency,
+		getBlobStatusesLatency:          getBlobStatusesLatency,
+		getBlobStatusesKeys:             getBlobStatusesKeys,
+		duplicateBlobLookupLatency:      duplicateBlobLookupLatency,
+		duplicateBlobs:                  duplicateBlobs,
+		saturatedRejections:             saturatedRejections,
+		accountAccessRejections:         accountAccessRejections,
 	}
 }
 
@@ -124,6 +205,10 @@ func (m *metricsV2) reportGetPaymentStateLatency(duration time.Duration) {
 	m.getPaymentStateLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
 
+func (m *metricsV2) reportGetOnDemandBalanceLatency(duration time.Duration) {
+	m.getOnDemandBalanceLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
 func (m *metricsV2) reportDisperseBlobLatency(duration time.Duration) {
 	m.disperseBlobLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
@@ -144,3 +229,27 @@ func (m *metricsV2) reportStoreBlobLatency(duration time.Duration) {
 func (m *metricsV2) reportGetBlobStatusLatency(duration time.Duration) {
 	m.getBlobStatusLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
+
+func (m *metricsV2) reportGetBlobStatusesLatency(duration time.Duration) {
+	m.getBlobStatusesLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
+func (m *metricsV2) reportGetBlobStatusesKeys(count int) {
+	m.getBlobStatusesKeys.WithLabelValues().Observe(float64(count))
+}
+
+func (m *metricsV2) reportDuplicateBlobLookupLatency(duration time.Duration) {
+	m.duplicateBlobLookupLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
+func (m *metricsV2) reportDuplicateBlob() {
+	m.duplicateBlobs.WithLabelValues().Inc()
+}
+
+func (m *metricsV2) reportSaturatedRejection() {
+	m.saturatedRejections.WithLabelValues().Inc()
+}
+
+func (m *metricsV2) reportAccountAccessRejection() {
+	m.accountAccessRejections.WithLabelValues().Inc()
+}
@@ -329,7 +329,7 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 	}, nil
 }
 
-func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, quorumID core.QuorumID) (*PerUserRateInfo, string, error) {
+func (s *DispersalServer) getAccountRate(origin, authenticatedAddress, clientCertIdentity string, quorumID core.QuorumID) (*PerUserRateInfo, string, error) {
 	unauthRates, ok := s.rateConfig.QuorumRateInfos[quorumID]
 	if !ok {
 		return nil, "", fmt.Errorf("no configured rate exists for quorum %d", quorumID)
@@ -365,11 +365,33 @@ func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, qu
 		}
 	}
 
+	// Check if the client certificate identity is in the allowlist
+	if len(clientCertIdentity) > 0 {
+		clientCertIdentity = strings.ToLower(clientCertIdentity)
+
+		rateInfo, ok := s.rateConfig.Allowlist[clientCertIdentity][quorumID]
+		if ok {
+			key := "cert:" + clientCertIdentity
+			if rateInfo.Throughput > 0 {
+				rates.Throughput = rateInfo.Throughput
+			}
+			if rateInfo.BlobRate > 0 {
+				rates.BlobRate = rateInfo.BlobRate
+			}
+			rates.Name = rateInfo.Name
+			return rates, key, nil
+		}
+	}
+
 	// Check if the origin is in the allowlist
 
 	// If the origin is not in the allowlist, we use the origin as the account key since
-	// it is a more limited resource than an ETH public key
+	// it is a more limited resource than an ETH public key. A verified client certificate
+	// identifies the client more precisely than its origin, so it is preferred when present.
 	key := "ip:" + origin
+	if len(clientCertIdentity) > 0 {
+		key = "cert:" + clientCertIdentity
+	}
 
 	for account, rateInfoByQuorum := range s.rateConfig.Allowlist {
 		if !strings.Contains(origin, account) {
@@ -470,6 +492,7 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 	blobSize := len(blob.Data)
 	length := encoding.GetBlobLength(uint(blobSize))
 	requesterName := ""
+	clientCertIdentity := ClientCertIdentity(ctx)
 	for i, param := range blob.RequestHeader.SecurityParams {
 
 		globalRates, ok := s.rateConfig.QuorumRateInfos[param.QuorumID]
@@ -478,7 +501,7 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 			return api.NewErrorInternal(fmt.Sprintf("no configured rate exists for quorum %d", param.QuorumID))
 		}
 
-		accountRates, accountKey, err := s.getAccountRate(origin, authenticatedAddress, param.QuorumID)
+		accountRates, accountKey, err := s.getAccountRate(origin, authenticatedAddress, clientCertIdentity, param.QuorumID)
 		if err != nil {
 			s.metrics.HandleInternalFailureRpcRequest(apiMethodName)
 			return api.NewErrorInternal(err.Error())
@@ -851,13 +874,21 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 		return errors.New("could not start tcp listener")
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-
-	gs := grpc.NewServer(
-		opt,
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(1024 * 1024 * 300), // 300 MiB
 		grpc.UnaryInterceptor(
 			s.grpcMetrics.UnaryServerInterceptor(),
-		))
+		),
+	}
+	tlsOpt, err := newTLSServerOption(ctx, s.serverConfig, s.logger)
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	if tlsOpt != nil {
+		opts = append(opts, tlsOpt)
+	}
+
+	gs := grpc.NewServer(opts...)
 
 	reflection.Register(gs)
 	pb.RegisterDisperserServer(gs, s)
@@ -31,6 +31,7 @@ import (
 	"github.com/urfave/cli"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	pbmock "github.com/Layr-Labs/eigenda/api/grpc/mock"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
@@ -439,6 +440,30 @@ func TestDisperseBlobWithExceedSizeLimit(t *testing.T) {
 	assert.Equal(t, err.Error(), expectedErrMsg)
 }
 
+func TestDisperseBlobStream(t *testing.T) {
+	data := make([]byte, 3*1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	reply, err := disperseBlobStream(dispersalServer, uint32(len(data)), data, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+	assert.NotNil(t, reply.GetRequestId())
+
+	// The data received must match the declared blob size
+	_, err = disperseBlobStream(dispersalServer, uint32(len(data))-1, data, 1000)
+	assert.ErrorContains(t, err, "received more than the declared blob size")
+	_, err = disperseBlobStream(dispersalServer, uint32(len(data))+1, data, 1000)
+	assert.ErrorContains(t, err, "less than the declared blob size")
+
+	// Oversized blobs are rejected from the header
+	_, err = disperseBlobStream(dispersalServer, uint32(testMaxBlobSize)+1, nil, 1000)
+	expectedErrMsg := fmt.Sprintf("rpc error: code = InvalidArgument desc = blob size cannot exceed %v Bytes", testMaxBlobSize)
+	assert.EqualError(t, err, expectedErrMsg)
+}
+
 func TestParseAllowlist(t *testing.T) {
 	fs := flag.NewFlagSet("disperser", flag.ContinueOnError)
 	allowlistFileFlag := apiserver.AllowlistFileFlag("disperser")
@@ -878,6 +903,43 @@ func disperseBlob(t *testing.T, server *apiserver.DispersalServer, data []byte)
 	return reply.GetResult(), uint(len(data)), reply.GetRequestId()
 }
 
+func disperseBlobStream(server *apiserver.DispersalServer, blobSize uint32, data []byte, chunkSize int) (*pb.DisperseBlobReply, error) {
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+	stream := pbmock.MakeBlobStreamMock(ctx)
+
+	errorChan := make(chan error, 1)
+	go func() {
+		errorChan <- server.DisperseBlobStream(stream)
+	}()
+
+	stream.SendFromClient(&pb.DisperseBlobStreamRequest{
+		Payload: &pb.DisperseBlobStreamRequest_Header{
+			Header: &pb.DisperseBlobStreamHeader{
+				BlobSize:            blobSize,
+				CustomQuorumNumbers: []uint32{0, 1},
+			},
+		},
+	})
+	for start := 0; start < len(data); start += chunkSize {
+		end := min(start+chunkSize, len(data))
+		stream.SendFromClient(&pb.DisperseBlobStreamRequest{
+			Payload: &pb.DisperseBlobStreamRequest_Chunk{Chunk: data[start:end]},
+		})
+	}
+	stream.CloseSend()
+
+	if err := <-errorChan; err != nil {
+		return nil, err
+	}
+	return stream.RecvToClient(), nil
+}
+
 func retrieveBlob(server *apiserver.DispersalServer, requestID []byte, blobIndex uint32) ([]byte, error) {
 	p := &peer.Peer{
 		Addr: &net.TCPAddr{
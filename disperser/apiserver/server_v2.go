@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"net"
 	"sync/atomic"
 	"time"
@@ -31,6 +33,7 @@ import (
 type OnchainState struct {
 	QuorumCount           uint8
 	RequiredQuorums       []core.QuorumID
+	SecurityParams        map[core.QuorumID]core.SecurityParam
 	BlobVersionParameters *corev2.BlobVersionParameterMap
 	TTL                   time.Duration
 }
@@ -47,12 +50,21 @@ type DispersalServerV2 struct {
 	blobStore         *blobstore.BlobStore
 	blobMetadataStore *blobstore.BlobMetadataStore
 	meterer           *meterer.Meterer
+	// reservationMeter meters the bandwidth of reservation dispersals before they are charged to reservation bins
+	reservationMeter meterer.ReservationMeter
 
 	chainReader   core.Reader
 	authenticator corev2.BlobRequestAuthenticator
 	prover        encoding.Prover
 	logger        logging.Logger
 
+	// admissionController rejects dispersals while the encoding or dispatching pipeline is saturated.
+	// It is nil if admission control is disabled.
+	admissionController *AdmissionController
+	// accountAccessController rejects dispersals of blocked accounts, and of accounts that aren't allowlisted in
+	// allowlist mode. It is nil if account access control is disabled.
+	accountAccessController *AccountAccessController
+
 	// state
 	onchainState                atomic.Pointer[OnchainState]
 	maxNumSymbolsPerBlob        uint64
@@ -72,6 +84,8 @@ func NewDispersalServerV2(
 	prover encoding.Prover,
 	maxNumSymbolsPerBlob uint64,
 	onchainStateRefreshInterval time.Duration,
+	admissionController *AdmissionController,
+	accountAccessController *AccountAccessController,
 	_logger logging.Logger,
 	registry *prometheus.Registry,
 ) (*DispersalServerV2, error) {
@@ -116,6 +130,9 @@ func NewDispersalServerV2(
 		maxNumSymbolsPerBlob:        maxNumSymbolsPerBlob,
 		onchainStateRefreshInterval: onchainStateRefreshInterval,
 
+		admissionController:     admissionController,
+		accountAccessController: accountAccessController,
+
 		metrics: newAPIServerV2Metrics(registry),
 	}, nil
 }
@@ -128,23 +145,61 @@ func (s *DispersalServerV2) Start(ctx context.Context) error {
 		return errors.New("could not start tcp listener")
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(1024 * 1024 * 300), // 300 MiB
+		s.metrics.grpcServerOption,
+	}
+	tlsOpt, err := newTLSServerOption(ctx, s.serverConfig, s.logger)
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	if tlsOpt != nil {
+		opts = append(opts, tlsOpt)
+	}
 
-	gs := grpc.NewServer(opt, s.metrics.grpcServerOption)
+	gs := grpc.NewServer(opts...)
 	reflection.Register(gs)
 	pb.RegisterDisperserServer(gs, s)
 
 	// Unimplemented v1 server for grpcurl/reflection support
 	pbv1.RegisterDisperserServer(gs, &DispersalServerV1{})
 
-	// Register Server for Health Checks
+	// Register Server for Health Checks, reporting the health of the stores and the chain RPC
 	name := pb.Disperser_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	healthChecker := healthcheck.NewHealthChecker(map[string]healthcheck.Check{
+		"store": func(ctx context.Context) error {
+			if err := s.blobMetadataStore.CheckHealth(ctx); err != nil {
+				return fmt.Errorf("blob metadata store: %w", err)
+			}
+			if err := s.blobStore.CheckHealth(ctx); err != nil {
+				return fmt.Errorf("blob store: %w", err)
+			}
+			return nil
+		},
+		"chain": func(ctx context.Context) error {
+			_, err := s.chainReader.GetCurrentBlockNumber(ctx)
+			return err
+		},
+	}, healthcheck.DefaultCheckInterval, healthcheck.DefaultCheckTimeout, s.logger)
+	healthChecker.Register(name, gs)
+	healthChecker.Start(ctx)
 
 	if err := s.RefreshOnchainState(ctx); err != nil {
 		return fmt.Errorf("failed to refresh onchain quorum state: %w", err)
 	}
 
+	if s.admissionController != nil {
+		if err := s.admissionController.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start admission controller: %w", err)
+		}
+	}
+
+	if s.accountAccessController != nil {
+		if err := s.accountAccessController.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start account access controller: %w", err)
+		}
+	}
+
 	go func() {
 		ticker := time.NewTicker(s.onchainStateRefreshInterval)
 		defer ticker.Stop()
@@ -230,6 +285,14 @@ func (s *DispersalServerV2) RefreshOnchainState(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get required quorum numbers: %w", err)
 	}
+	securityParams, err := s.chainReader.GetQuorumSecurityParams(ctx, currentBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get quorum security params: %w", err)
+	}
+	securityParamsByQuorum := make(map[core.QuorumID]core.SecurityParam, len(securityParams))
+	for _, param := range securityParams {
+		securityParamsByQuorum[param.QuorumID] = param
+	}
 
 	blockStaleMeasure, err := s.chainReader.GetBlockStaleMeasure(ctx)
 	if err != nil {
@@ -247,6 +310,7 @@ func (s *DispersalServerV2) RefreshOnchainState(ctx context.Context) error {
 	onchainState := &OnchainState{
 		QuorumCount:           quorumCount,
 		RequiredQuorums:       requiredQuorums,
+		SecurityParams:        securityParamsByQuorum,
 		BlobVersionParameters: v2.NewBlobVersionParameterMap(blobParams),
 		TTL:                   time.Duration((storeDurationBlocks+blockStaleMeasure)*12) * time.Second,
 	}
@@ -258,7 +322,7 @@ func (s *DispersalServerV2) RefreshOnchainState(ctx context.Context) error {
 
 func (s *DispersalServerV2) GetPaymentState(ctx context.Context, req *pb.GetPaymentStateRequest) (*pb.GetPaymentStateReply, error) {
 	if s.meterer == nil {
-		return nil, errors.New("payment meterer is not enabled")
+		return nil, api.NewErrorUnimplemented()
 	}
 	start := time.Now()
 	defer func() {
@@ -268,7 +332,7 @@ func (s *DispersalServerV2) GetPaymentState(ctx context.Context, req *pb.GetPaym
 	accountID := gethcommon.HexToAddress(req.AccountId)
 
 	// validate the signature
-	if err := s.authenticator.AuthenticatePaymentStateRequest(req.GetSignature(), req.GetAccountId()); err != nil {
+	if err := s.authenticator.AuthenticatePaymentStateRequest(ctx, req.GetSignature(), req.GetAccountId(), req.GetTimestamp()); err != nil {
 		s.logger.Debug("failed to validate signature", "err", err, "accountID", accountID)
 		return nil, api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
 	}
@@ -342,3 +406,55 @@ func (s *DispersalServerV2) GetPaymentState(ctx context.Context, req *pb.GetPaym
 	}
 	return reply, nil
 }
+
+// GetOnDemandBalance returns the on-demand balance left for an account, i.e. the on-chain deposit
+// minus the largest cumulative payment the disperser has accepted from the account.
+func (s *DispersalServerV2) GetOnDemandBalance(ctx context.Context, req *pb.GetOnDemandBalanceRequest) (*pb.GetOnDemandBalanceReply, error) {
+	if s.meterer == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	start := time.Now()
+	defer func() {
+		s.metrics.reportGetOnDemandBalanceLatency(time.Since(start))
+	}()
+
+	accountID := gethcommon.HexToAddress(req.AccountId)
+
+	// validate the signature
+	if err := s.authenticator.AuthenticatePaymentStateRequest(ctx, req.GetSignature(), req.GetAccountId(), req.GetTimestamp()); err != nil {
+		s.logger.Debug("failed to validate signature", "err", err, "accountID", accountID)
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
+	}
+
+	onDemandPayment, err := s.meterer.ChainPaymentState.GetOnDemandPaymentByAccount(ctx, accountID)
+	if err != nil {
+		s.logger.Debug("failed to get ondemand payment", "err", err, "accountID", accountID)
+		return nil, api.NewErrorNotFound(fmt.Sprintf("no on-demand deposit found for account %s", accountID.Hex()))
+	}
+	largestCumulativePayment, err := s.meterer.OffchainStore.GetLargestCumulativePayment(ctx, req.AccountId)
+	if err != nil {
+		s.logger.Error("failed to get largest cumulative payment", "err", err, "accountID", accountID)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get cumulative payment: %s", err.Error()))
+	}
+
+	remainingBalance := new(big.Int).Sub(onDemandPayment.CumulativePayment, largestCumulativePayment)
+	if remainingBalance.Sign() < 0 {
+		remainingBalance.SetInt64(0)
+	}
+	var remainingSymbols uint64
+	if pricePerSymbol := s.meterer.ChainPaymentState.GetPricePerSymbol(); pricePerSymbol > 0 {
+		symbols := new(big.Int).Div(remainingBalance, big.NewInt(int64(pricePerSymbol)))
+		if symbols.IsUint64() {
+			remainingSymbols = symbols.Uint64()
+		} else {
+			remainingSymbols = math.MaxUint64
+		}
+	}
+
+	return &pb.GetOnDemandBalanceReply{
+		OnchainCumulativePayment: onDemandPayment.CumulativePayment.Bytes(),
+		CumulativePayment:        largestCumulativePayment.Bytes(),
+		RemainingBalance:         remainingBalance.Bytes(),
+		RemainingSymbols:         remainingSymbols,
+	}, nil
+}
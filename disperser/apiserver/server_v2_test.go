@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,13 +22,16 @@ import (
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pbcommonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
@@ -81,8 +85,9 @@ func TestV2DisperseBlob(t *testing.T) {
 
 	now := time.Now()
 	reply, err := c.DispersalServerV2.DisperseBlob(ctx, &pbv2.DisperseBlobRequest{
-		Data:       data,
-		BlobHeader: blobHeaderProto,
+		Data:        data,
+		BlobHeader:  blobHeaderProto,
+		CallbackUrl: "https://example.com/callback",
 	})
 	assert.NoError(t, err)
 
@@ -106,17 +111,91 @@ func TestV2DisperseBlob(t *testing.T) {
 	assert.Greater(t, blobMetadata.Expiry, uint64(now.Unix()))
 	assert.Greater(t, blobMetadata.RequestedAt, uint64(now.UnixNano()))
 	assert.Equal(t, blobMetadata.RequestedAt, blobMetadata.UpdatedAt)
+	assert.Equal(t, "https://example.com/callback", blobMetadata.CallbackURL)
 
 	// Try dispersing the same blob; if payment is different, blob will be considered as a differernt blob
 	// payment will cause failure before commitment check
 	reply, err = c.DispersalServerV2.DisperseBlob(ctx, &pbv2.DisperseBlobRequest{
-		Data:       data,
-		BlobHeader: blobHeaderProto,
+		Data:           data,
+		BlobHeader:     blobHeaderProto,
+		AllowDuplicate: true,
 	})
 	assert.Nil(t, reply)
 	assert.ErrorContains(t, err, "payment already exists")
 }
 
+func TestV2DisperseBlobDuplicate(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+	accountID, err := c.Signer.GetAccountID()
+	require.NoError(t, err)
+	data := make([]byte, 50)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitmentsForPaddedLength(data)
+	require.NoError(t, err)
+	commitmentProto, err := commitments.ToProtobuf()
+	require.NoError(t, err)
+
+	disperse := func(quorumNumbers []uint32, cumulativePayment int64, allowDuplicate bool) (*pbv2.DisperseBlobReply, corev2.BlobKey, error) {
+		blobHeaderProto := &pbcommonv2.BlobHeader{
+			Version:       0,
+			QuorumNumbers: quorumNumbers,
+			Commitment:    commitmentProto,
+			PaymentHeader: &pbcommon.PaymentHeader{
+				AccountId:         accountID,
+				ReservationPeriod: 5,
+				CumulativePayment: big.NewInt(cumulativePayment).Bytes(),
+			},
+		}
+		blobHeader, err := corev2.BlobHeaderFromProtobuf(blobHeaderProto)
+		require.NoError(t, err)
+		blobHeaderProto.Signature, err = c.Signer.SignBlobRequest(blobHeader)
+		require.NoError(t, err)
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		reply, err := c.DispersalServerV2.DisperseBlob(ctx, &pbv2.DisperseBlobRequest{
+			Data:           data,
+			BlobHeader:     blobHeaderProto,
+			AllowDuplicate: allowDuplicate,
+		})
+		return reply, blobKey, err
+	}
+
+	reply, blobKey, err := disperse([]uint32{0, 1}, 100, false)
+	require.NoError(t, err)
+	assert.Equal(t, blobKey[:], reply.BlobKey)
+
+	// same content with a different payment returns the existing blob without charging for it
+	reply, duplicateKey, err := disperse([]uint32{1, 0}, 200, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, blobKey, duplicateKey)
+	assert.Equal(t, pbv2.BlobStatus_QUEUED, reply.Result)
+	assert.Equal(t, blobKey[:], reply.BlobKey)
+	_, err = c.BlobMetadataStore.GetBlobMetadata(ctx, duplicateKey)
+	assert.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+
+	// the payment of the duplicate was not charged, so it can still be used
+	reply, duplicateKey, err = disperse([]uint32{0, 1}, 200, true)
+	require.NoError(t, err)
+	assert.Equal(t, duplicateKey[:], reply.BlobKey)
+
+	// a failed blob is not returned for a duplicate
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Failed)
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, duplicateKey, dispv2.Failed)
+	require.NoError(t, err)
+	reply, newKey, err := disperse([]uint32{0, 1}, 300, false)
+	require.NoError(t, err)
+	assert.Equal(t, newKey[:], reply.BlobKey)
+
+	// different content is not a duplicate
+	reply, otherKey, err := disperse([]uint32{0}, 400, false)
+	require.NoError(t, err)
+	assert.Equal(t, otherKey[:], reply.BlobKey)
+}
+
 func TestV2DisperseBlobRequestValidation(t *testing.T) {
 	c := newTestServerV2(t)
 	data := make([]byte, 50)
@@ -238,6 +317,56 @@ func TestV2DisperseBlobRequestValidation(t *testing.T) {
 	})
 	assert.ErrorContains(t, err, "invalid payment metadata")
 
+	// request with retention period out of bounds
+	for _, retentionPeriod := range []time.Duration{time.Hour, 30 * 24 * time.Hour} {
+		invalidReqProto = &pbcommonv2.BlobHeader{
+			Version:       0,
+			QuorumNumbers: []uint32{0, 1},
+			Commitment:    commitmentProto,
+			PaymentHeader: &pbcommon.PaymentHeader{
+				AccountId:         accountID,
+				ReservationPeriod: 5,
+				CumulativePayment: big.NewInt(100).Bytes(),
+			},
+			RetentionPeriodSeconds: uint64(retentionPeriod / time.Second),
+		}
+		blobHeader, err = corev2.BlobHeaderFromProtobuf(invalidReqProto)
+		assert.NoError(t, err)
+		sig, err = signer.SignBlobRequest(blobHeader)
+		assert.NoError(t, err)
+		invalidReqProto.Signature = sig
+		_, err = c.DispersalServerV2.DisperseBlob(context.Background(), &pbv2.DisperseBlobRequest{
+			Data:       data,
+			BlobHeader: invalidReqProto,
+		})
+		assert.ErrorContains(t, err, "retention period")
+	}
+
+	// request with invalid callback url
+	reqProto := &pbcommonv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: []uint32{0, 1},
+		Commitment:    commitmentProto,
+		PaymentHeader: &pbcommon.PaymentHeader{
+			AccountId:         accountID,
+			ReservationPeriod: 5,
+			CumulativePayment: big.NewInt(100).Bytes(),
+		},
+	}
+	blobHeader, err = corev2.BlobHeaderFromProtobuf(reqProto)
+	assert.NoError(t, err)
+	sig, err = signer.SignBlobRequest(blobHeader)
+	assert.NoError(t, err)
+	reqProto.Signature = sig
+	for _, callbackURL := range []string{"not a url", "ftp://example.com/callback", "https:///callback", "https://example.com/" + strings.Repeat("a", 2048), "http://127.0.0.1/callback", "http://[::1]:8080/callback", "http://169.254.169.254/latest", "http://localhost/callback"} {
+		_, err = c.DispersalServerV2.DisperseBlob(context.Background(), &pbv2.DisperseBlobRequest{
+			Data:        data,
+			BlobHeader:  reqProto,
+			CallbackUrl: callbackURL,
+		})
+		assert.ErrorContains(t, err, "invalid callback url")
+	}
+
 	// request with invalid commitment
 	invalidCommitment := commitmentProto
 	invalidCommitment.Length = commitmentProto.Length - 1
@@ -397,6 +526,74 @@ func TestV2GetBlobStatus(t *testing.T) {
 	require.Equal(t, attestationProto, reply.GetSignedBatch().GetAttestation())
 }
 
+func TestV2GetBlobStatuses(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	now := time.Now()
+	blobKeys := make([]corev2.BlobKey, 2)
+	for i, blobStatus := range []dispv2.BlobStatus{dispv2.Queued, dispv2.Failed} {
+		blobHeader := &corev2.BlobHeader{
+			BlobVersion:     0,
+			BlobCommitments: mockCommitment,
+			QuorumNumbers:   []core.QuorumID{0},
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         "0x1234",
+				ReservationPeriod: 0,
+				CumulativePayment: big.NewInt(int64(100 + i)),
+			},
+		}
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		blobKeys[i] = blobKey
+		err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+			BlobHeader: blobHeader,
+			BlobStatus: blobStatus,
+			Expiry:     uint64(now.Add(time.Hour).Unix()),
+			NumRetries: 0,
+			UpdatedAt:  uint64(now.UnixNano()),
+		})
+		require.NoError(t, err)
+	}
+	missingKey := corev2.BlobKey{1, 2, 3}
+
+	reply, err := c.DispersalServerV2.GetBlobStatuses(ctx, &pbv2.BlobStatusesRequest{
+		BlobKeys: [][]byte{blobKeys[1][:], missingKey[:], blobKeys[0][:], blobKeys[1][:]},
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.GetStatuses(), 4)
+	require.Equal(t, blobKeys[1][:], reply.GetStatuses()[0].GetBlobKey())
+	require.Equal(t, pbv2.BlobStatus_FAILED, reply.GetStatuses()[0].GetStatus().GetStatus())
+	require.Empty(t, reply.GetStatuses()[0].GetError())
+	require.Equal(t, missingKey[:], reply.GetStatuses()[1].GetBlobKey())
+	require.Nil(t, reply.GetStatuses()[1].GetStatus())
+	require.Equal(t, "blob not found", reply.GetStatuses()[1].GetError())
+	require.Equal(t, blobKeys[0][:], reply.GetStatuses()[2].GetBlobKey())
+	require.Equal(t, pbv2.BlobStatus_QUEUED, reply.GetStatuses()[2].GetStatus().GetStatus())
+	require.Equal(t, blobKeys[1][:], reply.GetStatuses()[3].GetBlobKey())
+	require.Equal(t, pbv2.BlobStatus_FAILED, reply.GetStatuses()[3].GetStatus().GetStatus())
+
+	// no blob keys
+	_, err = c.DispersalServerV2.GetBlobStatuses(ctx, &pbv2.BlobStatusesRequest{})
+	require.ErrorContains(t, err, "no blob keys provided")
+
+	// invalid blob key
+	_, err = c.DispersalServerV2.GetBlobStatuses(ctx, &pbv2.BlobStatusesRequest{
+		BlobKeys: [][]byte{blobKeys[0][:], {1, 2, 3}},
+	})
+	require.ErrorContains(t, err, "invalid blob key at index 1")
+
+	// too many blob keys
+	tooManyKeys := make([][]byte, 101)
+	for i := range tooManyKeys {
+		tooManyKeys[i] = blobKeys[0][:]
+	}
+	_, err = c.DispersalServerV2.GetBlobStatuses(ctx, &pbv2.BlobStatusesRequest{
+		BlobKeys: tooManyKeys,
+	})
+	require.ErrorContains(t, err, "too many blob keys")
+}
+
 func TestV2GetBlobCommitment(t *testing.T) {
 	c := newTestServerV2(t)
 	data := make([]byte, 50)
@@ -422,6 +619,84 @@ func TestV2GetBlobCommitment(t *testing.T) {
 	assert.Equal(t, uint32(commit.Length), reply.BlobCommitment.Length)
 }
 
+func TestV2GetOnDemandBalance(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+	accountID, err := c.Signer.GetAccountID()
+	require.NoError(t, err)
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := c.Signer.SignPaymentStateRequest(timestamp)
+	require.NoError(t, err)
+
+	// no on-demand payment made yet
+	reply, err := c.DispersalServerV2.GetOnDemandBalance(ctx, &pbv2.GetOnDemandBalanceRequest{
+		AccountId: accountID,
+		Signature: signature,
+		Timestamp: timestamp,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3864).Bytes(), reply.OnchainCumulativePayment)
+	assert.Empty(t, reply.CumulativePayment)
+	assert.Equal(t, big.NewInt(3864).Bytes(), reply.RemainingBalance)
+	assert.Equal(t, uint64(1932), reply.RemainingSymbols)
+
+	// disperse a blob paid for on-demand
+	data := make([]byte, 50)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitmentsForPaddedLength(data)
+	require.NoError(t, err)
+	commitmentProto, err := commitments.ToProtobuf()
+	require.NoError(t, err)
+	blobHeaderProto := &pbcommonv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: []uint32{0, 1},
+		Commitment:    commitmentProto,
+		PaymentHeader: &pbcommon.PaymentHeader{
+			AccountId:         accountID,
+			ReservationPeriod: 5,
+			CumulativePayment: big.NewInt(100).Bytes(),
+		},
+	}
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(blobHeaderProto)
+	require.NoError(t, err)
+	blobHeaderProto.Signature, err = c.Signer.SignBlobRequest(blobHeader)
+	require.NoError(t, err)
+	_, err = c.DispersalServerV2.DisperseBlob(ctx, &pbv2.DisperseBlobRequest{
+		Data:       data,
+		BlobHeader: blobHeaderProto,
+	})
+	require.NoError(t, err)
+
+	reply, err = c.DispersalServerV2.GetOnDemandBalance(ctx, &pbv2.GetOnDemandBalanceRequest{
+		AccountId: accountID,
+		Signature: signature,
+		Timestamp: timestamp,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3864).Bytes(), reply.OnchainCumulativePayment)
+	assert.Equal(t, big.NewInt(100).Bytes(), reply.CumulativePayment)
+	assert.Equal(t, big.NewInt(3764).Bytes(), reply.RemainingBalance)
+	assert.Equal(t, uint64(1882), reply.RemainingSymbols)
+
+	// invalid signature
+	reply, err = c.DispersalServerV2.GetOnDemandBalance(ctx, &pbv2.GetOnDemandBalanceRequest{
+		AccountId: accountID,
+		Signature: make([]byte, 65),
+		Timestamp: timestamp,
+	})
+	assert.Nil(t, reply)
+	assert.ErrorContains(t, err, "authentication failed")
+}
+
+func TestV2GetOnDemandBalanceWithoutMeterer(t *testing.T) {
+	server := &apiserver.DispersalServerV2{}
+	reply, err := server.GetOnDemandBalance(context.Background(), &pbv2.GetOnDemandBalanceRequest{})
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
 func newTestServerV2(t *testing.T) *testComponents {
 	logger := logging.NewNoopLogger()
 	// logger, err := common.NewLogger(common.DefaultLoggerConfig())
@@ -493,6 +768,10 @@ func newTestServerV2(t *testing.T) *testComponents {
 	chainReader.On("GetCurrentBlockNumber").Return(uint32(100), nil)
 	chainReader.On("GetQuorumCount").Return(uint8(2), nil)
 	chainReader.On("GetRequiredQuorumNumbers", tmock.Anything).Return([]uint8{0, 1}, nil)
+	chainReader.On("GetQuorumSecurityParams", tmock.Anything).Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 1, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+	}, nil)
 	chainReader.On("GetBlockStaleMeasure", tmock.Anything).Return(uint32(10), nil)
 	chainReader.On("GetStoreDurationBlocks", tmock.Anything).Return(uint32(100), nil)
 	chainReader.On("GetAllVersionedBlobParams", tmock.Anything).Return(map[v2.BlobVersion]*core.BlobVersionParameters{
@@ -516,6 +795,8 @@ func newTestServerV2(t *testing.T) *testComponents {
 		prover,
 		10,
 		time.Hour,
+		nil,
+		nil,
 		logger,
 		prometheus.NewRegistry())
 	assert.NoError(t, err)
@@ -0,0 +1,197 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// CertReloader serves the grpc server certificate and the client CA pool from files on disk,
+// reloading them whenever the files change so that rotated certificates are picked up without a restart.
+type CertReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	logger       logging.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  []time.Time
+}
+
+// NewCertReloader creates a CertReloader and loads the certificate, key and (optional) client CA files.
+func NewCertReloader(certFile, keyFile, clientCAFile string, logger logging.Logger) (*CertReloader, error) {
+	r := &CertReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		logger:       logger.With("component", "CertReloader"),
+	}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reloads the TLS files if any of them changed since the last load. It returns true if the files were reloaded.
+// If loading fails, the previously loaded certificate and CA pool are kept.
+func (r *CertReloader) Reload() (bool, error) {
+	modTimes, err := r.fileModTimes()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	changed := r.cert == nil || !equalTimes(modTimes, r.modTimes)
+	r.mu.RUnlock()
+	if !changed {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return false, fmt.Errorf("no valid certificates found in client CA file %s", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.mu.Unlock()
+	return true, nil
+}
+
+// Start periodically checks the TLS files for changes until the context is cancelled.
+func (r *CertReloader) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reloaded, err := r.Reload()
+				if err != nil {
+					r.logger.Error("failed to reload TLS certificates, keeping the previous ones", "err", err)
+				} else if reloaded {
+					r.logger.Info("reloaded TLS certificates", "certFile", r.certFile, "clientCAFile", r.clientCAFile)
+				}
+			}
+		}
+	}()
+}
+
+// TLSConfig returns a TLS config that always uses the most recently loaded certificate and client CA pool.
+// Client certificates are required and verified if a client CA file is configured.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+			if r.clientCAs != nil {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+				config.ClientCAs = r.clientCAs
+			}
+			return config, nil
+		},
+	}
+}
+
+func (r *CertReloader) fileModTimes() ([]time.Time, error) {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+	modTimes := make([]time.Time, len(files))
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", file, err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// newTLSServerOption returns the grpc server option that enables TLS as configured, and starts reloading
+// the certificates in the background. It returns nil if TLS is not enabled.
+func newTLSServerOption(ctx context.Context, config disperser.ServerConfig, logger logging.Logger) (grpc.ServerOption, error) {
+	if !config.TLSEnabled() {
+		return nil, nil
+	}
+	if config.TLSReloadInterval <= 0 {
+		return nil, errors.New("TLS reload interval must be positive")
+	}
+	reloader, err := NewCertReloader(config.TLSCertFile, config.TLSKeyFile, config.TLSClientCAFile, logger)
+	if err != nil {
+		return nil, err
+	}
+	reloader.Start(ctx, config.TLSReloadInterval)
+	logger.Info("Enabled TLS for grpc server", "certFile", config.TLSCertFile, "mutualTLS", config.TLSClientCAFile != "")
+	return grpc.Creds(credentials.NewTLS(reloader.TLSConfig())), nil
+}
+
+// ClientCertIdentity returns the identity of the client certificate verified during the TLS handshake of the request,
+// or an empty string if the client did not present a verified certificate. The identity is the subject common name of
+// the certificate, falling back to its first DNS or URI subject alternative name.
+func ClientCertIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+package apiserver_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, commonName string, isCA bool, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+	}
+	if isCA {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) writeFiles(t *testing.T, certFile, keyFile string) {
+	err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600)
+	require.NoError(t, err)
+	if keyFile == "" {
+		return
+	}
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	require.NoError(t, err)
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	ca := newTestCert(t, "test-ca", true, nil)
+	ca.writeFiles(t, caFile, "")
+	newTestCert(t, "server-1", false, ca).writeFiles(t, certFile, keyFile)
+	client := newTestCert(t, "client-1", false, ca)
+
+	reloader, err := apiserver.NewCertReloader(certFile, keyFile, caFile, logging.NewNoopLogger())
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", reloader.TLSConfig())
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.(*tls.Conn).Handshake()
+				_, _ = conn.Read(make([]byte, 1))
+			}()
+		}
+	}()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	dial := func(clientCerts ...tls.Certificate) (string, error) {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+			RootCAs:      rootCAs,
+			ServerName:   "localhost",
+			Certificates: clientCerts,
+		})
+		if err != nil {
+			return "", err
+		}
+		defer conn.Close()
+		// the server rejects a missing client certificate after the client handshake completes
+		if _, err := conn.Write([]byte{0}); err != nil {
+			return "", err
+		}
+		if _, err := conn.Read(make([]byte, 1)); err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, nil
+	}
+
+	serverName, err := dial(client.tlsCertificate())
+	require.NoError(t, err)
+	assert.Equal(t, "server-1", serverName)
+
+	// client certificate is required
+	_, err = dial()
+	assert.Error(t, err)
+
+	// client certificate must be signed by the client CA
+	untrusted := newTestCert(t, "client-2", false, newTestCert(t, "other-ca", true, nil))
+	_, err = dial(untrusted.tlsCertificate())
+	assert.Error(t, err)
+
+	// nothing changed
+	reloaded, err := reloader.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	// rotate the server certificate
+	newTestCert(t, "server-2", false, ca).writeFiles(t, certFile, keyFile)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	reloaded, err = reloader.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	serverName, err = dial(client.tlsCertificate())
+	require.NoError(t, err)
+	assert.Equal(t, "server-2", serverName)
+
+	// a broken rotation keeps the previous certificate
+	require.NoError(t, os.WriteFile(keyFile, []byte("invalid"), 0600))
+	later = later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+	_, err = reloader.Reload()
+	assert.Error(t, err)
+	serverName, err = dial(client.tlsCertificate())
+	require.NoError(t, err)
+	assert.Equal(t, "server-2", serverName)
+}
+
+func TestClientCertIdentity(t *testing.T) {
+	ca := newTestCert(t, "test-ca", true, nil)
+	client := newTestCert(t, "client-1", false, ca)
+
+	assert.Equal(t, "", apiserver.ClientCertIdentity(context.Background()))
+
+	// peer without TLS
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1")}})
+	assert.Equal(t, "", apiserver.ClientCertIdentity(ctx))
+
+	// TLS peer without a verified client certificate
+	ctx = peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+	assert.Equal(t, "", apiserver.ClientCertIdentity(ctx))
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{client.cert, ca.cert}}},
+	}})
+	assert.Equal(t, "client-1", apiserver.ClientCertIdentity(ctx))
+}
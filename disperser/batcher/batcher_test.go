@@ -6,7 +6,6 @@ import (
 	"errors"
 	"math/big"
 	"runtime"
-	"sync"
 	"testing"
 	"time"
 
@@ -33,8 +32,7 @@ import (
 )
 
 var (
-	gettysburgAddressBytes  = codec.ConvertByPaddingEmptyByte([]byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal. Now we are engaged in a great civil war, testing whether that nation, or any nation so conceived, and so dedicated, can long endure. We are met on a great battle-field of that war. We have come to dedicate a portion of that field, as a final resting-place for those who here gave their lives, that that nation might live. It is altogether fitting and proper that we should do this. But, in a larger sense, we cannot dedicate, we cannot consecrate—we cannot hallow—this ground. The brave men, living and dead, who struggled here, have consecrated it far above our poor power to add or detract. The world will little note, nor long remember what we say here, but it can never forget what they did here. It is for us the living, rather, to be dedicated here to the unfinished work which they who fought here have thus far so nobly advanced. It is rather for us to be here dedicated to the great task remaining before us—that from these honored dead we take increased devotion to that cause for which they here gave the last full measure of devotion—that we here highly resolve that these dead shall not have died in vain—that this nation, under God, shall have a new birth of freedom, and that government of the people, by the people, for the people, shall not perish from the earth."))
-	handleBatchLivenessChan = make(chan time.Time, 1)
+	gettysburgAddressBytes = codec.ConvertByPaddingEmptyByte([]byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal. Now we are engaged in a great civil war, testing whether that nation, or any nation so conceived, and so dedicated, can long endure. We are met on a great battle-field of that war. We have come to dedicate a portion of that field, as a final resting-place for those who here gave their lives, that that nation might live. It is altogether fitting and proper that we should do this. But, in a larger sense, we cannot dedicate, we cannot consecrate—we cannot hallow—this ground. The brave men, living and dead, who struggled here, have consecrated it far above our poor power to add or detract. The world will little note, nor long remember what we say here, but it can never forget what they did here. It is for us the living, rather, to be dedicated here to the unfinished work which they who fought here have thus far so nobly advanced. It is rather for us to be here dedicated to the great task remaining before us—that from these honored dead we take increased devotion to that cause for which they here gave the last full measure of devotion—that we here highly resolve that these dead shall not have died in vain—that this nation, under God, shall have a new birth of freedom, and that government of the people, by the people, for the people, shall not perish from the earth."))
 )
 
 type batcherComponents struct {
@@ -132,43 +130,33 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher, func() []time.
 	ethClient := &cmock.MockEthClient{}
 	txnManager := batmock.NewTxnManager()
 
-	b, err := bat.NewBatcher(config, timeoutConfig, blobStore, dispatcher, cst, asgn, encoderClient, agg, ethClient, finalizer, transactor, txnManager, logger, metrics, handleBatchLivenessChan)
+	// Each batcher gets its own heartbeat channel, buffered so that no heartbeat is dropped, and read only once the
+	// test is done with the batcher
+	heartbeatChan := make(chan time.Time, 100)
+	b, err := bat.NewBatcher(config, timeoutConfig, blobStore, dispatcher, cst, asgn, encoderClient, agg, ethClient, finalizer, transactor, txnManager, logger, metrics, heartbeatChan)
 	assert.NoError(t, err)
 
-	var mu sync.Mutex
-	var heartbeatsReceived []time.Time
-	doneListening := make(chan bool)
-
-	go func() {
+	// Make the batcher
+	return &batcherComponents{
+		transactor:       transactor,
+		txnManager:       txnManager,
+		blobStore:        blobStore,
+		encoderClient:    encoderClient,
+		encodingStreamer: b.EncodingStreamer,
+		ethClient:        ethClient,
+		dispatcher:       dispatcher,
+		chainData:        cst,
+	}, b, func() []time.Time {
+		var heartbeatsReceived []time.Time
 		for {
 			select {
-			case hb := <-b.HeartbeatChan:
-				mu.Lock() // Lock before modifying the slice
+			case hb := <-heartbeatChan:
 				heartbeatsReceived = append(heartbeatsReceived, hb)
-				mu.Unlock()
-			case <-doneListening:
-				return
+			default:
+				return heartbeatsReceived
 			}
 		}
-	}()
-
-	// Make the batcher
-	return &batcherComponents{
-			transactor:       transactor,
-			txnManager:       txnManager,
-			blobStore:        blobStore,
-			encoderClient:    encoderClient,
-			encodingStreamer: b.EncodingStreamer,
-			ethClient:        ethClient,
-			dispatcher:       dispatcher,
-			chainData:        cst,
-		}, b, func() []time.Time {
-			close(doneListening) // Stop the goroutine listening to heartbeats
-
-			mu.Lock() // Lock before reading the slice
-			defer mu.Unlock()
-			return heartbeatsReceived
-		}
+	}
 }
 
 func queueBlob(t *testing.T, ctx context.Context, blob *core.Blob, blobStore disperser.BlobStore) (uint64, disperser.BlobKey) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -85,6 +86,11 @@ func (f *finalizer) Start(ctx context.Context) {
 
 // FinalizeBlobs checks the latest finalized block and marks blobs in `confirmed` state as `finalized` if their confirmation
 // block number is less than or equal to the latest finalized block number.
+// The confirmation transaction of every confirmed batch is looked up once per run to check it wasn't reorged, whether
+// or not its confirmation block is finalized yet: blobs whose transaction moved to another block are updated with the
+// new confirmation block and wait for it to be finalized, and blobs whose transaction was reorged out of the chain are
+// put back in `processing` state to be confirmed again, up to the max number of retries per blob, without waiting for
+// the original confirmation block to be finalized.
 // If it failes to process some blobs, it will log the error, skip the failed blobs, and will not return an error. The function should be invoked again to retry.
 func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 	startTime := time.Now()
@@ -95,6 +101,7 @@ func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 	}
 	lastFinalBlock := finalizedHeader.Number.Uint64()
 
+	receipts := &confirmationReceipts{receipts: make(map[gcommon.Hash]confirmationReceipt)}
 	totalProcessed := 0
 	metadatas, exclusiveStartKey, err := f.blobStore.GetBlobMetadataByStatusWithPagination(ctx, disperser.Confirmed, f.numBlobsPerFetch, nil)
 	if err != nil {
@@ -105,7 +112,7 @@ func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 		metas := metadatas
 		f.logger.Info("finalizing blobs", "numBlobs", len(metas), "finalizedBlockNumber", lastFinalBlock)
 		pool.Submit(func() {
-			f.updateBlobs(ctx, metas, lastFinalBlock)
+			f.updateBlobs(ctx, metas, lastFinalBlock, receipts)
 		})
 		totalProcessed += len(metadatas)
 
@@ -127,7 +134,33 @@ func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 	return nil
 }
 
-func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.BlobMetadata, lastFinalBlock uint64) {
+// confirmationReceipt is the result of looking up the block of a confirmation transaction
+type confirmationReceipt struct {
+	blockNumber uint64
+	err         error
+}
+
+// confirmationReceipts holds the confirmation transactions looked up in a run of the finalizer. Blobs of the same
+// batch share the confirmation transaction, which is looked up once even if the blobs are in different pages.
+type confirmationReceipts struct {
+	mu       sync.Mutex
+	receipts map[gcommon.Hash]confirmationReceipt
+}
+
+func (f *finalizer) getConfirmationBlockNumber(ctx context.Context, receipts *confirmationReceipts, hash gcommon.Hash) (uint64, error) {
+	receipts.mu.Lock()
+	receipt, ok := receipts.receipts[hash]
+	receipts.mu.Unlock()
+	if !ok {
+		receipt.blockNumber, receipt.err = f.getTransactionBlockNumber(ctx, hash)
+		receipts.mu.Lock()
+		receipts.receipts[hash] = receipt
+		receipts.mu.Unlock()
+	}
+	return receipt.blockNumber, receipt.err
+}
+
+func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.BlobMetadata, lastFinalBlock uint64, receipts *confirmationReceipts) {
 	// Panic recovery
 	defer func() {
 		if r := recover(); r != nil {
@@ -150,6 +183,22 @@ func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.Blob
 			f.logger.Error("the blob retrieved by status Confirmed is actually", m.BlobStatus.String(), "blobKey", blobKey.String())
 			continue
 		}
+		if m.ConfirmationInfo == nil {
+			f.logger.Error("received nil ConfirmationInfo", "blobKey", blobKey.String())
+			continue
+		}
+
+		confirmationBlockNumber, receiptErr := f.getConfirmationBlockNumber(ctx, receipts, m.ConfirmationInfo.ConfirmationTxnHash)
+		reorgedOut := errors.Is(receiptErr, ethereum.NotFound)
+		if receiptErr != nil && !reorgedOut {
+			f.logger.Error("error getting transaction block number", "err", receiptErr)
+			f.metrics.IncrementNumBlobs("failed")
+			continue
+		}
+		// Blobs whose confirmation is unchanged and not finalized yet are left as they are, without reading them again
+		if !reorgedOut && confirmationBlockNumber == uint64(m.ConfirmationInfo.ConfirmationBlockNumber) && confirmationBlockNumber > lastFinalBlock {
+			continue
+		}
 
 		confirmationMetadata, err := f.blobStore.GetBlobMetadata(ctx, blobKey)
 		if err != nil {
@@ -170,32 +219,31 @@ func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.Blob
 			continue
 		}
 
-		// Leave as confirmed if the confirmation block is after the latest finalized block (not yet finalized)
-		if uint64(confirmationMetadata.ConfirmationInfo.ConfirmationBlockNumber) > lastFinalBlock {
+		// The blob may have been confirmed again by another transaction since it was listed
+		confirmationInfo := confirmationMetadata.ConfirmationInfo
+		if confirmationInfo.ConfirmationTxnHash != m.ConfirmationInfo.ConfirmationTxnHash {
 			continue
 		}
 
-		// confirmation block number may have changed due to reorg
-		confirmationBlockNumber, err := f.getTransactionBlockNumber(ctx, confirmationMetadata.ConfirmationInfo.ConfirmationTxnHash)
-		if errors.Is(err, ethereum.NotFound) {
-			// The confirmed block is finalized, but the transaction is not found. It means the transaction should be considered forked/invalid and the blob should be considered as failed.
-			f.logger.Warn("confirmed transaction not found", "blobKey", blobKey.String(), "confirmationTxnHash", confirmationMetadata.ConfirmationInfo.ConfirmationTxnHash.Hex(), "confirmationBlockNumber", confirmationMetadata.ConfirmationInfo.ConfirmationBlockNumber)
-			err := f.blobStore.MarkBlobFailed(ctx, m.GetBlobKey())
+		if reorgedOut {
+			// The transaction is not found, which means it was reorged out of the chain, so the blob is put back in
+			// the queue to be dispersed and confirmed again.
+			f.logger.Warn("confirmed transaction reorged out", "blobKey", blobKey.String(), "confirmationTxnHash", confirmationInfo.ConfirmationTxnHash.Hex(), "confirmationBlockNumber", confirmationInfo.ConfirmationBlockNumber)
+			retry, err := f.blobStore.HandleBlobFailure(ctx, confirmationMetadata, f.maxNumRetriesPerBlob)
 			if err != nil {
-				f.logger.Error("error marking blob as failed", "blobKey", blobKey.String(), "err", err)
+				f.logger.Error("error handling reorged blob", "blobKey", blobKey.String(), "err", err)
+			}
+			if retry {
+				f.metrics.IncrementNumBlobs("reorged")
+			} else {
+				f.metrics.IncrementNumBlobs("failed")
 			}
-			f.metrics.IncrementNumBlobs("failed")
-			continue
-		}
-		if err != nil {
-			f.logger.Error("error getting transaction block number", "err", err)
-			f.metrics.IncrementNumBlobs("failed")
 			continue
 		}
 
-		if confirmationBlockNumber != uint64(confirmationMetadata.ConfirmationInfo.ConfirmationBlockNumber) {
+		if confirmationBlockNumber != uint64(confirmationInfo.ConfirmationBlockNumber) {
 			// Confirmation block number has changed due to reorg. Update the confirmation block number in the metadata
-			err := f.blobStore.UpdateConfirmationBlockNumber(ctx, m, uint32(confirmationBlockNumber))
+			err := f.blobStore.UpdateConfirmationBlockNumber(ctx, confirmationMetadata, uint32(confirmationBlockNumber))
 			if err != nil {
 				f.logger.Error("error updating confirmation block number", "blobKey", blobKey.String(), "err", err)
 				f.metrics.IncrementNumBlobs("failed")
@@ -189,6 +189,8 @@ func TestUnfinalizedBlob(t *testing.T) {
 	metadatas, err := queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 1)
+	// the confirmation block is tracked before it is finalized
+	assert.Equal(t, uint32(1_000_100), metadatas[0].ConfirmationInfo.ConfirmationBlockNumber)
 
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Finalized)
 	assert.NoError(t, err)
@@ -257,17 +259,129 @@ func TestNoReceipt(t *testing.T) {
 	err = finalizer.FinalizeBlobs(context.Background())
 	assert.NoError(t, err)
 
-	// status should be kept at confirmed
+	// the confirmation was reorged out, so the blob should be put back in the queue to be confirmed again
 	metadatas, err := queue.GetBlobMetadataByStatus(ctx, disperser.Finalized)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Failed)
 	assert.NoError(t, err)
-	assert.Len(t, metadatas, 1)
+	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Processing)
 	assert.NoError(t, err)
+	assert.Len(t, metadatas, 1)
+	assert.Equal(t, uint(1), metadatas[0].NumRetries)
+	// the stale confirmation is cleared
+	assert.Nil(t, metadatas[0].ConfirmationInfo)
+
+	// the blob should fail once it is out of retries
+	m, err = queue.MarkBlobConfirmed(ctx, metadatas[0], confirmationInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, m.BlobStatus)
+	err = finalizer.FinalizeBlobs(context.Background())
+	assert.NoError(t, err)
+	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Failed)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 1)
+	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 0)
+}
+
+func TestReorgBeforeFinalization(t *testing.T) {
+	ctx := context.Background()
+	queue := inmem.NewBlobStore()
+	logger := logging.NewNoopLogger()
+	ethClient := &mock.MockEthClient{}
+	rpcClient := &mock.MockRPCEthClient{}
+
+	latestFinalBlock := int64(100)
+	rpcClient.On("CallContext", m.Anything, m.Anything, "eth_getBlockByNumber", "finalized", false).
+		Run(func(args m.Arguments) {
+			args[1].(*types.Header).Number = big.NewInt(latestFinalBlock)
+		}).Return(nil)
+	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(150),
+	}, nil).Once()
+	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(160),
+	}, nil).Twice()
+	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(nil, ethereum.NotFound)
+
+	metrics := batcher.NewMetrics("9100", logger)
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics)
+
+	requestedAt := uint64(time.Now().UnixNano())
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+	}})
+	metadataKey, err := queue.StoreBlob(ctx, &blob, requestedAt)
+	assert.NoError(t, err)
+	confirmationInfo := &disperser.ConfirmationInfo{
+		BatchHeaderHash:         [32]byte{1, 2, 3},
+		BlobIndex:               10,
+		SignatoryRecordHash:     [32]byte{0},
+		ReferenceBlockNumber:    132,
+		BatchRoot:               []byte("hello"),
+		BlobInclusionProof:      []byte{1, 2, 3, 4, 5},
+		BlobCommitment:          &encoding.BlobCommitments{},
+		BatchID:                 99,
+		ConfirmationTxnHash:     common.HexToHash("0x123"),
+		ConfirmationBlockNumber: uint32(150),
+		Fee:                     []byte{0},
+	}
+	metadata := &disperser.BlobMetadata{
+		BlobHash:     metadataKey.BlobHash,
+		MetadataHash: metadataKey.MetadataHash,
+		BlobStatus:   disperser.Processing,
+		Expiry:       uint64(time.Now().Add(time.Hour).Unix()),
+		NumRetries:   0,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: core.BlobRequestHeader{
+				SecurityParams: blob.RequestHeader.SecurityParams,
+			},
+			BlobSize:    uint(len(blob.Data)),
+			RequestedAt: requestedAt,
+		},
+	}
+	_, err = queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo)
+	assert.NoError(t, err)
+
+	// the transaction is looked up while the confirmation block isn't finalized, and left as is while unchanged
+	err = finalizer.FinalizeBlobs(ctx)
+	assert.NoError(t, err)
+	ethClient.AssertNumberOfCalls(t, "TransactionReceipt", 1)
+	metadatas, err := queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 1)
+	assert.Equal(t, uint32(150), metadatas[0].ConfirmationInfo.ConfirmationBlockNumber)
+
+	// the transaction moved to a later block before the confirmation block was finalized
+	err = finalizer.FinalizeBlobs(ctx)
+	assert.NoError(t, err)
+	ethClient.AssertNumberOfCalls(t, "TransactionReceipt", 2)
+	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 1)
+	assert.Equal(t, uint32(160), metadatas[0].ConfirmationInfo.ConfirmationBlockNumber)
+	assert.Equal(t, uint(0), metadatas[0].NumRetries)
+
+	err = finalizer.FinalizeBlobs(ctx)
+	assert.NoError(t, err)
+	ethClient.AssertNumberOfCalls(t, "TransactionReceipt", 3)
+
+	// the transaction was reorged out before any of its blocks was finalized, so the blob is requeued right away
+	err = finalizer.FinalizeBlobs(ctx)
+	assert.NoError(t, err)
+	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
+	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
+	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 1)
+	assert.Equal(t, uint(1), metadatas[0].NumRetries)
+	assert.Nil(t, metadatas[0].ConfirmationInfo)
 }
@@ -43,6 +43,8 @@ type TxnManagerMetrics struct {
 	SpeedUps prometheus.Gauge
 	TxQueue  prometheus.Gauge
 	NumTx    *prometheus.CounterVec
+	// Replacements counts the transactions sent to replace a previous transaction of the same request
+	Replacements *prometheus.CounterVec
 }
 
 type FinalizerMetrics struct {
@@ -141,6 +143,14 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"state"},
 		),
+		Replacements: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "tx_replacements_total",
+				Help:      "number of replacement transactions sent",
+			},
+			[]string{"reason"}, // possible values are "stalled", "underpriced", "nonce_gap", "nonce_too_low"
+		),
 	}
 
 	finalizerMetrics := FinalizerMetrics{
@@ -394,6 +404,10 @@ func (t *TxnManagerMetrics) IncrementTxnCount(state string) {
 	t.NumTx.WithLabelValues(state).Inc()
 }
 
+func (t *TxnManagerMetrics) IncrementReplacements(reason string) {
+	t.Replacements.WithLabelValues(reason).Inc()
+}
+
 func (f *FinalizerMetrics) IncrementNumBlobs(state string) {
 	f.NumBlobs.WithLabelValues(state).Inc()
 }
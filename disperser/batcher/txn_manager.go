@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,19 +14,58 @@ import (
 	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum"
+	gethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
-// percentage multiplier for gas price. It needs to be >= 10 to properly replace existing transaction
-// e.g. 10 means 10% increase
+// minGasBumpPercentage is the minimum percentage the fees of a transaction must be increased by for nodes to accept
+// its replacement
+const minGasBumpPercentage = 10
+
 var (
-	gasPricePercentageMultiplier = big.NewInt(10)
 	hundred                      = big.NewInt(100)
 	maxSendTransactionRetry      = 3
 	queryTickerDuration          = 3 * time.Second
 	ErrTransactionNotBroadcasted = errors.New("transaction not broadcasted")
+	// errGasCapReached is returned when a transaction can't be replaced because its fees can't be increased
+	// without exceeding the max gas fee cap
+	errGasCapReached = errors.New("transaction fees are at the max gas fee cap")
 )
 
+// FeeStrategy determines the EIP-1559 fees of the transactions sent by the TxnManager.
+type FeeStrategy string
+
+const (
+	// FeeStrategyMarket uses the fees suggested by the network
+	FeeStrategyMarket FeeStrategy = "market"
+	// FeeStrategyUrgent outbids the fees suggested by the network by the gas bump percentage, which trades a higher
+	// cost for faster inclusion
+	FeeStrategyUrgent FeeStrategy = "urgent"
+)
+
+// ParseFeeStrategy returns the fee strategy with the given name.
+func ParseFeeStrategy(name string) (FeeStrategy, error) {
+	switch FeeStrategy(name) {
+	case FeeStrategyMarket, FeeStrategyUrgent:
+		return FeeStrategy(name), nil
+	default:
+		return "", fmt.Errorf("unknown fee strategy %q, expected %q or %q", name, FeeStrategyMarket, FeeStrategyUrgent)
+	}
+}
+
+// TxnManagerConfig configures how the TxnManager prices and replaces transactions.
+type TxnManagerConfig struct {
+	// FeeStrategy determines the fees of new transactions. Defaults to FeeStrategyMarket.
+	FeeStrategy FeeStrategy
+	// GasBumpPercentage is the percentage the fees of a stalled transaction are increased by when it is replaced.
+	// Values below 10 are raised to 10, the minimum bump nodes accept for a replacement transaction.
+	GasBumpPercentage uint64
+	// MaxGasFeeCap is the max fee cap in wei of the transactions. Once a stalled transaction reaches it, the
+	// TxnManager waits for the transaction to be mined instead of replacing it. Nil means no limit.
+	MaxGasFeeCap *big.Int
+}
+
 // TxnManager receives transactions from the caller, sends them to the chain, and monitors their status.
 // It also handles the case where a transaction is not mined within a certain time. In this case, it will
 // resend the transaction with a higher gas price. It is assumed that all transactions originate from the
@@ -77,12 +117,19 @@ type txnManager struct {
 	queueSize           int
 	txnBroadcastTimeout time.Duration
 	txnRefreshInterval  time.Duration
+	config              TxnManagerConfig
 	metrics             *TxnManagerMetrics
 }
 
 var _ TxnManager = (*txnManager)(nil)
 
-func NewTxnManager(ethClient common.EthClient, wallet walletsdk.Wallet, numConfirmations, queueSize int, txnBroadcastTimeout time.Duration, txnRefreshInterval time.Duration, logger logging.Logger, metrics *TxnManagerMetrics) TxnManager {
+func NewTxnManager(ethClient common.EthClient, wallet walletsdk.Wallet, numConfirmations, queueSize int, txnBroadcastTimeout time.Duration, txnRefreshInterval time.Duration, config TxnManagerConfig, logger logging.Logger, metrics *TxnManagerMetrics) TxnManager {
+	if config.FeeStrategy == "" {
+		config.FeeStrategy = FeeStrategyMarket
+	}
+	if config.GasBumpPercentage < minGasBumpPercentage {
+		config.GasBumpPercentage = minGasBumpPercentage
+	}
 	return &txnManager{
 		ethClient:           ethClient,
 		wallet:              wallet,
@@ -93,6 +140,7 @@ func NewTxnManager(ethClient common.EthClient, wallet walletsdk.Wallet, numConfi
 		queueSize:           queueSize,
 		txnBroadcastTimeout: txnBroadcastTimeout,
 		txnRefreshInterval:  txnRefreshInterval,
+		config:              config,
 		metrics:             metrics,
 	}
 }
@@ -153,7 +201,7 @@ func (t *txnManager) ProcessTransaction(ctx context.Context, req *TxnRequest) er
 	var err error
 	retryFromFailure := 0
 	for retryFromFailure < maxSendTransactionRetry {
-		gasTipCap, gasFeeCap, err := t.ethClient.GetLatestGasCaps(ctx)
+		gasTipCap, gasFeeCap, err := t.getGasCaps(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get latest gas caps: %w", err)
 		}
@@ -172,6 +220,17 @@ func (t *txnManager) ProcessTransaction(ctx context.Context, req *TxnRequest) er
 			t.logger.Warn("failed to send txn due to timeout", "tag", req.Tag, "hash", txn.Hash().Hex(), "numRetries", retryFromFailure, "maxRetry", maxSendTransactionRetry, "err", err)
 			retryFromFailure++
 			continue
+		} else if isNonceTooLow(err) {
+			// the nonce was taken by another transaction, e.g. one sent outside of the TxnManager
+			nonce, nonceErr := t.ethClient.PendingNonceAt(ctx, t.ethClient.GetAccountAddress())
+			if nonceErr != nil {
+				return fmt.Errorf("failed to get pending nonce: %w", nonceErr)
+			}
+			t.logger.Warn("failed to send txn due to nonce too low, retrying with the pending nonce", "tag", req.Tag, "nonce", txn.Nonce(), "pendingNonce", nonce, "numRetries", retryFromFailure, "maxRetry", maxSendTransactionRetry)
+			t.metrics.IncrementReplacements("nonce_too_low")
+			req.Tx = withNonce(req.Tx, nonce)
+			retryFromFailure++
+			continue
 		} else if err != nil {
 			return fmt.Errorf("failed to send txn (%s) %s: %w", req.Tag, txn.Hash().Hex(), err)
 		} else {
@@ -341,14 +400,36 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 				t.logger.Warn("transaction has been mined, but hasn't accumulated the required number of confirmations", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce())
 				continue
 			}
-			t.logger.Warn("transaction not mined within timeout, resending with higher gas price", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce())
-			newTx, err := t.speedUpTxn(ctx, req.Tx, req.Tag)
+			t.logger.Warn("transaction not mined within timeout, replacing it", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce())
+			newTx, reason, err := t.replaceTxn(ctx, req)
+			if errors.Is(err, errGasCapReached) {
+				t.logger.Warn("transaction fees are at the max gas fee cap, waiting for the transaction to be mined", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "gasFeeCap", req.Tx.GasFeeCap(), "maxGasFeeCap", t.config.MaxGasFeeCap)
+				continue
+			}
 			if err != nil {
-				t.logger.Error("failed to speed up transaction", "err", err)
+				t.logger.Error("failed to replace transaction", "err", err)
 				t.metrics.IncrementTxnCount("failure")
 				return nil, err
 			}
-			txID, err := t.wallet.SendTransaction(ctx, newTx)
+			newTx, txID, err := t.sendReplacementTxn(ctx, newTx, req.Tag)
+			if isNonceTooLow(err) && !t.anyTransactionMined(ctx, req.txAttempts) {
+				// the nonce was taken by a transaction other than the attempts of this request, so the request is
+				// resent with the pending nonce
+				t.logger.Warn("nonce of transaction was taken by another transaction, resending with the pending nonce", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce())
+				reason = "nonce_too_low"
+				newTx, err = t.renonceTxn(ctx, req.Tx)
+				if err == nil {
+					newTx, txID, err = t.sendReplacementTxn(ctx, newTx, req.Tag)
+				}
+			}
+			if isNonceTooLow(err) {
+				// one of the attempts has been mined, and its receipt is picked up in the next round
+				continue
+			}
+			if errors.Is(err, errGasCapReached) {
+				t.logger.Warn("replacement transaction fees reached the max gas fee cap, waiting for the transaction to be mined", "tag", req.Tag, "txHash", req.Tx.Hash().Hex())
+				continue
+			}
 			if err != nil {
 				if retryFromFailure >= maxSendTransactionRetry {
 					t.logger.Warn("failed to send txn - retries exhausted", "tag", req.Tag, "txn", req.Tx.Hash().Hex(), "attempt", retryFromFailure, "maxRetry", maxSendTransactionRetry, "err", err)
@@ -367,6 +448,7 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 				TxID:        txID,
 				Transaction: newTx,
 			})
+			t.metrics.IncrementReplacements(reason)
 			numSpeedUps++
 		} else {
 			t.logger.Error("transaction failed", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "err", err)
@@ -376,18 +458,98 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 	}
 }
 
-// speedUpTxn increases the gas price of the existing transaction by specified percentage.
+// replaceTxn returns the transaction to replace the stalled transaction of the request with, along with the reason
+// of the replacement. A transaction stuck behind a nonce gap is resent with the nonce filling the gap, since no fee
+// bump gets it mined. Otherwise, the transaction is resent with higher fees.
+func (t *txnManager) replaceTxn(ctx context.Context, req *TxnRequest) (*types.Transaction, string, error) {
+	pendingNonce, err := t.ethClient.PendingNonceAt(ctx, t.ethClient.GetAccountAddress())
+	if err != nil {
+		t.logger.Warn("failed to get pending nonce, skipping nonce gap check", "tag", req.Tag, "err", err)
+	} else if pendingNonce < req.Tx.Nonce() {
+		t.logger.Warn("nonce gap detected, resending transaction with the pending nonce", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce(), "pendingNonce", pendingNonce)
+		newTx, err := t.ethClient.UpdateGas(ctx, withNonce(req.Tx, pendingNonce), req.Tx.Value(), req.Tx.GasTipCap(), req.Tx.GasFeeCap())
+		return newTx, "nonce_gap", err
+	}
+
+	newTx, err := t.speedUpTxn(ctx, req.Tx, req.Tag)
+	return newTx, "stalled", err
+}
+
+// renonceTxn returns the transaction with the pending nonce of the account.
+func (t *txnManager) renonceTxn(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	nonce, err := t.ethClient.PendingNonceAt(ctx, t.ethClient.GetAccountAddress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	return t.ethClient.UpdateGas(ctx, withNonce(tx, nonce), tx.Value(), tx.GasTipCap(), tx.GasFeeCap())
+}
+
+// sendReplacementTxn sends the replacement transaction. If nodes reject it as underpriced, e.g. because they hold a
+// transaction with the same nonce at higher fees, its fees are increased again until it is accepted or the retries
+// are exhausted. It returns the transaction that was sent.
+func (t *txnManager) sendReplacementTxn(ctx context.Context, tx *types.Transaction, tag string) (*types.Transaction, walletsdk.TxID, error) {
+	for attempt := 0; ; attempt++ {
+		txID, err := t.wallet.SendTransaction(ctx, tx)
+		if err == nil || !isReplacementUnderpriced(err) || attempt >= maxSendTransactionRetry {
+			return tx, txID, err
+		}
+		t.logger.Warn("replacement transaction underpriced, increasing gas price again", "tag", tag, "txHash", tx.Hash().Hex(), "nonce", tx.Nonce(), "attempt", attempt, "maxRetry", maxSendTransactionRetry)
+		t.metrics.IncrementReplacements("underpriced")
+		tx, err = t.speedUpTxn(ctx, tx, tag)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+}
+
+// anyTransactionMined returns true if any of the given transactions has been mined.
+func (t *txnManager) anyTransactionMined(ctx context.Context, txs []*transaction) bool {
+	for _, tx := range txs {
+		if _, err := t.wallet.GetTransactionReceipt(ctx, tx.TxID); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getGasCaps returns the gas tip cap and gas fee cap of a new transaction according to the fee strategy.
+func (t *txnManager) getGasCaps(ctx context.Context) (*big.Int, *big.Int, error) {
+	gasTipCap, gasFeeCap, err := t.ethClient.GetLatestGasCaps(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t.config.FeeStrategy == FeeStrategyUrgent {
+		gasTipCap = increaseGasPrice(gasTipCap, t.config.GasBumpPercentage)
+		gasFeeCap = increaseGasPrice(gasFeeCap, t.config.GasBumpPercentage)
+	}
+	gasTipCap, gasFeeCap = t.capGasPrices(gasTipCap, gasFeeCap)
+	return gasTipCap, gasFeeCap, nil
+}
+
+// capGasPrices limits the gas fee cap to the max gas fee cap, and the gas tip cap to the gas fee cap.
+func (t *txnManager) capGasPrices(gasTipCap, gasFeeCap *big.Int) (*big.Int, *big.Int) {
+	if t.config.MaxGasFeeCap != nil && gasFeeCap.Cmp(t.config.MaxGasFeeCap) > 0 {
+		gasFeeCap = t.config.MaxGasFeeCap
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = gasFeeCap
+	}
+	return gasTipCap, gasFeeCap
+}
+
+// speedUpTxn increases the gas price of the existing transaction by the gas bump percentage.
 // It makes sure the new gas price is not lower than the current gas price.
+// It returns errGasCapReached if the increased gas price exceeds the max gas fee cap.
 func (t *txnManager) speedUpTxn(ctx context.Context, tx *types.Transaction, tag string) (*types.Transaction, error) {
 	prevGasTipCap := tx.GasTipCap()
 	prevGasFeeCap := tx.GasFeeCap()
 	// get the gas tip cap and gas fee cap based on current network condition
-	currentGasTipCap, currentGasFeeCap, err := t.ethClient.GetLatestGasCaps(ctx)
+	currentGasTipCap, currentGasFeeCap, err := t.getGasCaps(ctx)
 	if err != nil {
 		return nil, err
 	}
-	increasedGasTipCap := increaseGasPrice(prevGasTipCap)
-	increasedGasFeeCap := increaseGasPrice(prevGasFeeCap)
+	increasedGasTipCap := increaseGasPrice(prevGasTipCap, t.config.GasBumpPercentage)
+	increasedGasFeeCap := increaseGasPrice(prevGasFeeCap, t.config.GasBumpPercentage)
 	// make sure increased gas prices are not lower than current gas prices
 	var newGasTipCap, newGasFeeCap *big.Int
 	if currentGasTipCap.Cmp(increasedGasTipCap) > 0 {
@@ -400,18 +562,91 @@ func (t *txnManager) speedUpTxn(ctx context.Context, tx *types.Transaction, tag
 	} else {
 		newGasFeeCap = increasedGasFeeCap
 	}
+	newGasTipCap, newGasFeeCap = t.capGasPrices(newGasTipCap, newGasFeeCap)
+	// nodes reject replacements that don't increase both fees by the min bump
+	if newGasTipCap.Cmp(increasedGasTipCap) < 0 || newGasFeeCap.Cmp(increasedGasFeeCap) < 0 {
+		return nil, errGasCapReached
+	}
 
 	t.logger.Info("increasing gas price", "tag", tag, "txHash", tx.Hash().Hex(), "nonce", tx.Nonce(), "prevGasTipCap", prevGasTipCap, "prevGasFeeCap", prevGasFeeCap, "newGasTipCap", newGasTipCap, "newGasFeeCap", newGasFeeCap)
 	return t.ethClient.UpdateGas(ctx, tx, tx.Value(), newGasTipCap, newGasFeeCap)
 }
 
+// withNonce returns an unsigned copy of the transaction with the given nonce.
+func withNonce(tx *types.Transaction, nonce uint64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   tx.ChainId(),
+		Nonce:     nonce,
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	})
+}
+
+// nonceTooLowMessages are the lowercased messages the execution clients and hosted RPC providers reject a
+// transaction whose nonce has already been used with: geth and erigon (and the providers running them), besu,
+// nethermind, openethereum and hardhat.
+var nonceTooLowMessages = []string{
+	"nonce too low",
+	"nonce_too_low",
+	"oldnonce",
+	"transaction nonce is too low",
+}
+
+// replacementUnderpricedMessages are the lowercased messages the execution clients and hosted RPC providers reject
+// an underpriced replacement transaction with: geth and erigon (and the providers running them), besu, nethermind
+// and openethereum.
+var replacementUnderpricedMessages = []string{
+	"replacement transaction underpriced",
+	"replacement_underpriced",
+	"replacementnotallowed",
+	"another transaction with same nonce",
+}
+
+// isNonceTooLow returns true if the error is the node rejecting a transaction whose nonce has already been used.
+func isNonceTooLow(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gethcore.ErrNonceTooLow) {
+		return true
+	}
+	// Errors returned over RPC lose their type, so they are matched by message
+	return containsAny(strings.ToLower(err.Error()), nonceTooLowMessages)
+}
+
+// isReplacementUnderpriced returns true if the error is the node rejecting a replacement transaction whose fees
+// are not high enough to replace the transaction with the same nonce it holds.
+func isReplacementUnderpriced(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, txpool.ErrReplaceUnderpriced) {
+		return true
+	}
+	// Errors returned over RPC lose their type, so they are matched by message
+	return containsAny(strings.ToLower(err.Error()), replacementUnderpricedMessages)
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
 // increaseGasPrice increases the gas price by specified percentage.
-// i.e. gasPrice + ((gasPrice * gasPricePercentageMultiplier + 99) / 100)
-func increaseGasPrice(gasPrice *big.Int) *big.Int {
+// i.e. gasPrice + ((gasPrice * percentage + 99) / 100)
+func increaseGasPrice(gasPrice *big.Int, percentage uint64) *big.Int {
 	if gasPrice == nil {
 		return nil
 	}
-	bump := new(big.Int).Mul(gasPrice, gasPricePercentageMultiplier)
+	bump := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(percentage))
 	bump = roundUpDivideBig(bump, hundred)
 	return new(big.Int).Add(gasPrice, bump)
 }
@@ -0,0 +1,60 @@
+package batcher
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	gethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNonceTooLow(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"geth error", fmt.Errorf("failed to send transaction: %w", gethcore.ErrNonceTooLow), true},
+		{"geth", errors.New("nonce too low: address 0x5FbDB2315678afecb367f032d93F642f64180aa3, tx: 5 state: 7"), true},
+		{"erigon", errors.New("nonce too low"), true},
+		{"besu", errors.New("Nonce too low"), true},
+		{"besu error code", errors.New("NONCE_TOO_LOW"), true},
+		{"nethermind", errors.New("OldNonce, Current nonce: 7, nonce of rejected tx: 5"), true},
+		{"openethereum", errors.New("Transaction nonce is too low. Try incrementing the nonce."), true},
+		{"hardhat", errors.New("Nonce too low. Expected nonce to be 7 but got 5."), true},
+		{"alchemy", errors.New("failed to send transaction: nonce too low"), true},
+		{"nonce too high", errors.New("nonce too high"), false},
+		{"replacement underpriced", txpool.ErrReplaceUnderpriced, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isNonceTooLow(tc.err))
+		})
+	}
+}
+
+func TestIsReplacementUnderpriced(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"geth error", fmt.Errorf("failed to send transaction: %w", txpool.ErrReplaceUnderpriced), true},
+		{"geth", errors.New("replacement transaction underpriced"), true},
+		{"erigon", errors.New("replacement transaction underpriced"), true},
+		{"besu", errors.New("Replacement transaction underpriced"), true},
+		{"besu error code", errors.New("REPLACEMENT_UNDERPRICED"), true},
+		{"nethermind", errors.New("ReplacementNotAllowed"), true},
+		{"openethereum", errors.New("Transaction gas price is too low. There is another transaction with same nonce in the queue. Try increasing the gas price or incrementing the nonce."), true},
+		{"infura", errors.New("failed to send transaction: replacement transaction underpriced"), true},
+		{"underpriced", txpool.ErrUnderpriced, false},
+		{"nonce too low", gethcore.ErrNonceTooLow, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isReplacementUnderpriced(tc.err))
+		})
+	}
+}
@@ -24,13 +24,15 @@ func TestProcessTransaction(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txID := "1234"
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	gomock.InOrder(
@@ -74,12 +76,14 @@ func TestReplaceGasFee(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 
@@ -110,12 +114,14 @@ func TestTransactionReplacementFailure(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
 	ethClient.On("UpdateGas").Return(txn, nil).Once()
 	// now assume that the transaction fails on retry
 	speedUpFailure := errors.New("speed up failure")
@@ -143,12 +149,14 @@ func TestSendTransactionReceiptRetry(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	txID := "1234"
@@ -181,12 +189,14 @@ func TestSendTransactionRetrySuccess(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	txID := "1234"
@@ -223,12 +233,14 @@ func TestSendTransactionRetryFailure(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	txID := "1234"
@@ -261,12 +273,14 @@ func TestTransactionNotBroadcasted(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 48*time.Second, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	txID := "1234"
@@ -286,3 +300,173 @@ func TestTransactionNotBroadcasted(t *testing.T) {
 	assert.ErrorAs(t, res.Err, &batcher.ErrTransactionNotBroadcasted)
 	assert.Nil(t, res.Receipt)
 }
+
+func TestSendTransactionNonceTooLow(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	txnManager.Start(ctx)
+	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(5), nil)
+	ethClient.On("UpdateGas").Return(txn, nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+
+	// assume that the nonce was taken by another transaction
+	txID := "1234"
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return("", errors.New("nonce too low"))
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(txID, nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), txID).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil).Times(2)
+
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:    txn,
+		Tag:   "test transaction",
+		Value: nil,
+	})
+	assert.NoError(t, err)
+	res := <-txnManager.ReceiptChan()
+	assert.NoError(t, res.Err)
+	assert.Equal(t, uint64(1), res.Receipt.BlockNumber.Uint64())
+	ethClient.AssertNumberOfCalls(t, "PendingNonceAt", 1)
+	ethClient.AssertNumberOfCalls(t, "UpdateGas", 2)
+}
+
+func TestReplaceTransactionNonceGap(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	txnManager.Start(ctx)
+	txn := types.NewTransaction(3, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	// assume that the transactions with nonces 1 and 2 were dropped
+	ethClient.On("PendingNonceAt").Return(uint64(1), nil)
+	ethClient.On("UpdateGas").Return(txn, nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+
+	stuckTxID := "1234"
+	validTxID := "4321"
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(stuckTxID, nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), stuckTxID).Return(nil, walletsdk.ErrReceiptNotYetAvailable).AnyTimes()
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(validTxID, nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), validTxID).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil)
+
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:    txn,
+		Tag:   "test transaction",
+		Value: nil,
+	})
+	<-ctx.Done()
+	assert.NoError(t, err)
+	// the transaction is resent with the nonce filling the gap, without a fee bump
+	ethClient.AssertNumberOfCalls(t, "GetLatestGasCaps", 1)
+	ethClient.AssertNumberOfCalls(t, "UpdateGas", 2)
+}
+
+func TestReplaceTransactionUnderpriced(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.TxnManagerConfig{}, logger, metrics.TxnManagerMetrics)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	txnManager.Start(ctx)
+	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	ethClient.On("UpdateGas").Return(txn, nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+
+	badTxID := "1234"
+	validTxID := "4321"
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(badTxID, nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), badTxID).Return(nil, walletsdk.ErrReceiptNotYetAvailable).AnyTimes()
+	// assume that the first replacement is rejected, so its fees are increased again
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return("", errors.New("replacement transaction underpriced"))
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(validTxID, nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), validTxID).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil)
+
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:    txn,
+		Tag:   "test transaction",
+		Value: nil,
+	})
+	<-ctx.Done()
+	assert.NoError(t, err)
+	ethClient.AssertNumberOfCalls(t, "GetLatestGasCaps", 3)
+	ethClient.AssertNumberOfCalls(t, "UpdateGas", 3)
+}
+
+func TestReplaceTransactionGasCapReached(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	config := batcher.TxnManagerConfig{
+		MaxGasFeeCap: big.NewInt(1e9),
+	}
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, config, logger, metrics.TxnManagerMetrics)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	txnManager.Start(ctx)
+	txn := types.NewTx(&types.DynamicFeeTx{
+		To:        &common.Address{1},
+		Value:     big.NewInt(1e18),
+		Gas:       100000,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(1e9),
+	})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("GetAccountAddress").Return(common.Address{})
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	ethClient.On("UpdateGas").Return(txn, nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+
+	// assume that the transaction is mined late, and never replaced since its fees are at the cap
+	txID := "1234"
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(txID, nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), txID).Return(nil, walletsdk.ErrReceiptNotYetAvailable).Times(4)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), txID).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil).AnyTimes()
+
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:    txn,
+		Tag:   "test transaction",
+		Value: nil,
+	})
+	assert.NoError(t, err)
+	res := <-txnManager.ReceiptChan()
+	assert.NoError(t, res.Err)
+	assert.Equal(t, uint64(1), res.Receipt.BlockNumber.Uint64())
+	ethClient.AssertNumberOfCalls(t, "UpdateGas", 1)
+}
+
+func TestParseFeeStrategy(t *testing.T) {
+	strategy, err := batcher.ParseFeeStrategy("urgent")
+	assert.NoError(t, err)
+	assert.Equal(t, batcher.FeeStrategyUrgent, strategy)
+	_, err = batcher.ParseFeeStrategy("cheap")
+	assert.Error(t, err)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
@@ -46,6 +48,24 @@ type Config struct {
 	MaxBlobSize                 int
 	MaxNumSymbolsPerBlob        uint
 	OnchainStateRefreshInterval time.Duration
+	QuorumProfiles              core.BlobVersionQuorumProfiles
+
+	EnablePaymentVaultWatcher bool
+	PaymentVaultPollInterval  time.Duration
+
+	EnableReplayProtection          bool
+	ReplayProtectionMaxTimeInPast   time.Duration
+	ReplayProtectionMaxTimeInFuture time.Duration
+	ReplayProtectionTableName       string
+
+	EnableTypedDataSignatures   bool
+	ContractSignaturesPerSecond float64
+
+	AdmissionControlConfig apiserver.AdmissionControlConfig
+
+	EnableAccountAccessControl bool
+	AccountAccessConfig        apiserver.AccountAccessConfig
+	AccountAccessAdminConfig   apiserver.AccountAccessAdminConfig
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -91,6 +111,10 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		}
 	}
 
+	quorumProfiles, err := coreeth.ReadQuorumProfiles(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
 	config := Config{
 		DisperserVersion: DisperserVersion(version),
 		AwsClientConfig:  aws.ReadClientConfig(ctx, flags.FlagPrefix),
@@ -99,6 +123,11 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			GrpcTimeout:   ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
 			PprofHttpPort: ctx.GlobalString(flags.PprofHttpPort.Name),
 			EnablePprof:   ctx.GlobalBool(flags.EnablePprof.Name),
+
+			TLSCertFile:       ctx.GlobalString(flags.TLSCertFileFlag.Name),
+			TLSKeyFile:        ctx.GlobalString(flags.TLSKeyFileFlag.Name),
+			TLSClientCAFile:   ctx.GlobalString(flags.TLSClientCAFileFlag.Name),
+			TLSReloadInterval: ctx.GlobalDuration(flags.TLSReloadIntervalFlag.Name),
 		},
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
@@ -125,9 +154,76 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		MaxBlobSize:                 ctx.GlobalInt(flags.MaxBlobSize.Name),
 		MaxNumSymbolsPerBlob:        ctx.GlobalUint(flags.MaxNumSymbolsPerBlob.Name),
 		OnchainStateRefreshInterval: ctx.GlobalDuration(flags.OnchainStateRefreshInterval.Name),
+		QuorumProfiles:              quorumProfiles,
+
+		EnablePaymentVaultWatcher: ctx.GlobalBool(flags.EnablePaymentVaultWatcherFlag.Name),
+		PaymentVaultPollInterval:  ctx.GlobalDuration(flags.PaymentVaultPollIntervalFlag.Name),
+
+		EnableReplayProtection:          ctx.GlobalBool(flags.EnableReplayProtectionFlag.Name),
+		ReplayProtectionMaxTimeInPast:   ctx.GlobalDuration(flags.ReplayProtectionMaxTimeInPastFlag.Name),
+		ReplayProtectionMaxTimeInFuture: ctx.GlobalDuration(flags.ReplayProtectionMaxTimeInFutureFlag.Name),
+		ReplayProtectionTableName:       ctx.GlobalString(flags.ReplayProtectionTableNameFlag.Name),
+
+		EnableTypedDataSignatures:   ctx.GlobalBool(flags.EnableTypedDataSignaturesFlag.Name),
+		ContractSignaturesPerSecond: ctx.GlobalFloat64(flags.ContractSignaturesPerSecondFlag.Name),
+
+		AdmissionControlConfig: apiserver.AdmissionControlConfig{
+			MaxEncodingQueueDepth: ctx.GlobalInt(flags.MaxEncodingQueueDepthFlag.Name),
+			MaxDispatchQueueDepth: ctx.GlobalInt(flags.MaxDispatchQueueDepthFlag.Name),
+			PollInterval:          ctx.GlobalDuration(flags.QueueDepthPollIntervalFlag.Name),
+		},
+
+		EnableAccountAccessControl: ctx.GlobalBool(flags.EnableAccountAccessControlFlag.Name),
+		AccountAccessConfig: apiserver.AccountAccessConfig{
+			AllowlistMode:   ctx.GlobalBool(flags.AccountAllowlistModeFlag.Name),
+			RefreshInterval: ctx.GlobalDuration(flags.AccountAccessRefreshIntervalFlag.Name),
+		},
+		AccountAccessAdminConfig: apiserver.AccountAccessAdminConfig{
+			HTTPPort: ctx.GlobalString(flags.AccountAccessAdminPortFlag.Name),
+			Token:    ctx.GlobalString(flags.AccountAccessAdminTokenFlag.Name),
+		},
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 	}
+
+	serverConfig := config.ServerConfig
+	if (serverConfig.TLSCertFile == "") != (serverConfig.TLSKeyFile == "") {
+		return Config{}, fmt.Errorf("both %s and %s must be set to enable TLS", flags.TLSCertFileFlag.Name, flags.TLSKeyFileFlag.Name)
+	}
+	if serverConfig.TLSClientCAFile != "" && !serverConfig.TLSEnabled() {
+		return Config{}, fmt.Errorf("%s requires %s and %s to be set", flags.TLSClientCAFileFlag.Name, flags.TLSCertFileFlag.Name, flags.TLSKeyFileFlag.Name)
+	}
+	if serverConfig.TLSEnabled() && serverConfig.TLSReloadInterval <= 0 {
+		return Config{}, fmt.Errorf("%s must be positive", flags.TLSReloadIntervalFlag.Name)
+	}
+	if config.EnableReplayProtection {
+		if config.ReplayProtectionMaxTimeInPast <= 0 {
+			return Config{}, fmt.Errorf("%s must be positive", flags.ReplayProtectionMaxTimeInPastFlag.Name)
+		}
+		if config.ReplayProtectionMaxTimeInFuture < 0 {
+			return Config{}, fmt.Errorf("%s must not be negative", flags.ReplayProtectionMaxTimeInFutureFlag.Name)
+		}
+	}
+	admissionControlConfig := config.AdmissionControlConfig
+	if admissionControlConfig.MaxEncodingQueueDepth < 0 {
+		return Config{}, fmt.Errorf("%s must not be negative", flags.MaxEncodingQueueDepthFlag.Name)
+	}
+	if admissionControlConfig.MaxDispatchQueueDepth < 0 {
+		return Config{}, fmt.Errorf("%s must not be negative", flags.MaxDispatchQueueDepthFlag.Name)
+	}
+	if admissionControlConfig.Enabled() && admissionControlConfig.PollInterval <= 0 {
+		return Config{}, fmt.Errorf("%s must be positive", flags.QueueDepthPollIntervalFlag.Name)
+	}
+	if config.EnableAccountAccessControl {
+		if config.AccountAccessConfig.RefreshInterval <= 0 {
+			return Config{}, fmt.Errorf("%s must be positive", flags.AccountAccessRefreshIntervalFlag.Name)
+		}
+		if config.AccountAccessAdminConfig.HTTPPort != "" && config.AccountAccessAdminConfig.Token == "" {
+			return Config{}, fmt.Errorf("%s is required when %s is set", flags.AccountAccessAdminTokenFlag.Name, flags.AccountAccessAdminPortFlag.Name)
+		}
+	} else if config.AccountAccessAdminConfig.HTTPPort != "" {
+		return Config{}, fmt.Errorf("%s requires %s", flags.AccountAccessAdminPortFlag.Name, flags.EnableAccountAccessControlFlag.Name)
+	}
 	return config, nil
 }
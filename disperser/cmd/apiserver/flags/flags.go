@@ -8,6 +8,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -168,6 +169,136 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_PPROF"),
 	}
+	TLSCertFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-cert-file"),
+		Usage:    "Path to the PEM encoded certificate the grpc server presents. TLS is enabled when both the certificate and key are set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TLS_CERT_FILE"),
+	}
+	TLSKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-key-file"),
+		Usage:    "Path to the PEM encoded private key of the grpc server certificate",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TLS_KEY_FILE"),
+	}
+	TLSClientCAFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-client-ca-file"),
+		Usage:    "Path to the PEM encoded CA certificates used to verify client certificates. When set, clients must present a valid certificate (mutual TLS)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TLS_CLIENT_CA_FILE"),
+	}
+	TLSReloadIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-reload-interval"),
+		Usage:    "Interval at which the TLS certificate, key and client CA files are checked for rotation",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TLS_RELOAD_INTERVAL"),
+		Value:    time.Minute,
+	}
+	EnableReplayProtectionFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-replay-protection"),
+		Usage:    "Reject dispersal requests without a signed timestamp, with a timestamp outside the replay protection window, or that were already received. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_REPLAY_PROTECTION"),
+	}
+	ReplayProtectionMaxTimeInPastFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "replay-protection-max-time-in-past"),
+		Usage:    "How old the timestamp of a dispersal request may be when replay protection is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REPLAY_PROTECTION_MAX_TIME_IN_PAST"),
+		Value:    5 * time.Minute,
+	}
+	ReplayProtectionMaxTimeInFutureFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "replay-protection-max-time-in-future"),
+		Usage:    "How far in the future the timestamp of a dispersal request may be when replay protection is enabled, to tolerate clock skew",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REPLAY_PROTECTION_MAX_TIME_IN_FUTURE"),
+		Value:    30 * time.Second,
+	}
+	ReplayProtectionTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "replay-protection-table-name"),
+		Usage:    "name of the dynamodb table to store the hashes of received dispersal requests when replay protection is enabled. If not provided, a local store will be used, which only rejects requests replayed to the same API server replica. The table must have the TTL enabled on the ExpiresAt attribute",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REPLAY_PROTECTION_TABLE_NAME"),
+	}
+	EnableTypedDataSignaturesFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-typed-data-signatures"),
+		Usage:    "Accept dispersal requests of address accounts signed over EIP-712 typed data, including smart contract wallet signatures verified with ERC-1271. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_TYPED_DATA_SIGNATURES"),
+	}
+	ContractSignaturesPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "contract-signatures-per-second"),
+		Usage:    "Maximum rate of smart contract wallet signatures verified with ERC-1271 calls to the chain when typed data signatures are enabled. Signatures beyond this rate are rejected",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONTRACT_SIGNATURES_PER_SECOND"),
+		Value:    10,
+	}
+	MaxEncodingQueueDepthFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-encoding-queue-depth"),
+		Usage:    "The number of blobs waiting to be encoded beyond which new dispersal requests are rejected. 0 disables the limit. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_ENCODING_QUEUE_DEPTH"),
+		Value:    0,
+	}
+	MaxDispatchQueueDepthFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-dispatch-queue-depth"),
+		Usage:    "The number of encoded blobs waiting to be dispatched beyond which new dispersal requests are rejected. 0 disables the limit. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_DISPATCH_QUEUE_DEPTH"),
+		Value:    0,
+	}
+	QueueDepthPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "queue-depth-poll-interval"),
+		Usage:    "How often the encoding and dispatch queue depths are read when a queue depth limit is set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "QUEUE_DEPTH_POLL_INTERVAL"),
+		Value:    5 * time.Second,
+	}
+	EnablePaymentVaultWatcherFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-payment-vault-watcher"),
+		Usage:    "Keep the payments of the meterer up to date from the payment vault events, instead of reading them from chain on refresh. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_PAYMENT_VAULT_WATCHER"),
+	}
+	PaymentVaultPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-vault-poll-interval"),
+		Usage:    "How often new payment vault events are read when the payment vault watcher is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_VAULT_POLL_INTERVAL"),
+		Value:    12 * time.Second,
+	}
+	EnableAccountAccessControlFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-account-access-control"),
+		Usage:    "Reject dispersal requests of the accounts in the account blocklist. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_ACCOUNT_ACCESS_CONTROL"),
+	}
+	AccountAllowlistModeFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-allowlist-mode"),
+		Usage:    "Reject dispersal requests of every account that isn't in the account allowlist when account access control is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ALLOWLIST_MODE"),
+	}
+	AccountAccessRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-access-refresh-interval"),
+		Usage:    "How often the account blocklist and allowlist are reloaded when account access control is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ACCESS_REFRESH_INTERVAL"),
+		Value:    30 * time.Second,
+	}
+	AccountAccessAdminPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-access-admin-port"),
+		Usage:    "Port of the admin API to modify the account blocklist and allowlist. The admin API is disabled if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ACCESS_ADMIN_PORT"),
+		Value:    "",
+	}
+	AccountAccessAdminTokenFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-access-admin-token"),
+		Usage:    "Bearer token the requests to the account access admin API must be authorized with",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ACCESS_ADMIN_TOKEN"),
+	}
 )
 
 var kzgFlags = []cli.Flag{
@@ -262,6 +393,26 @@ var optionalFlags = []cli.Flag{
 	MaxNumSymbolsPerBlob,
 	PprofHttpPort,
 	EnablePprof,
+	TLSCertFileFlag,
+	TLSKeyFileFlag,
+	TLSClientCAFileFlag,
+	TLSReloadIntervalFlag,
+	EnableReplayProtectionFlag,
+	ReplayProtectionMaxTimeInPastFlag,
+	ReplayProtectionMaxTimeInFutureFlag,
+	ReplayProtectionTableNameFlag,
+	EnableTypedDataSignaturesFlag,
+	ContractSignaturesPerSecondFlag,
+	MaxEncodingQueueDepthFlag,
+	MaxDispatchQueueDepthFlag,
+	QueueDepthPollIntervalFlag,
+	EnablePaymentVaultWatcherFlag,
+	PaymentVaultPollIntervalFlag,
+	EnableAccountAccessControlFlag,
+	AccountAllowlistModeFlag,
+	AccountAccessRefreshIntervalFlag,
+	AccountAccessAdminPortFlag,
+	AccountAccessAdminTokenFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -270,6 +421,7 @@ var Flags []cli.Flag
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
+	Flags = append(Flags, coreeth.QuorumProfilesFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
@@ -23,6 +23,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/store"
 	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
 	"github.com/Layr-Labs/eigenda/core/eth"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -75,6 +76,7 @@ func RunDisperserServer(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	transactor.SetQuorumProfiles(config.QuorumProfiles)
 	blockStaleMeasure, err := transactor.GetBlockStaleMeasure(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to get BLOCK_STALE_MEASURE: %w", err)
@@ -110,6 +112,20 @@ func RunDisperserServer(ctx *cli.Context) error {
 		if err := paymentChainState.RefreshOnchainPaymentState(context.Background()); err != nil {
 			return fmt.Errorf("failed to make initial query to the on-chain state: %w", err)
 		}
+		if config.EnablePaymentVaultWatcher {
+			paymentVaultAddr, err := transactor.PaymentVaultAddress()
+			if err != nil {
+				return err
+			}
+			watcher, err := mt.NewPaymentVaultWatcher(paymentChainState, paymentVaultAddr, client, config.PaymentVaultPollInterval, logger)
+			if err != nil {
+				return fmt.Errorf("failed to create payment vault watcher: %w", err)
+			}
+			if err := watcher.Start(context.Background()); err != nil {
+				return fmt.Errorf("failed to start payment vault watcher: %w", err)
+			}
+			logger.Info("Enabled payment vault watcher", "paymentVault", paymentVaultAddr.Hex(), "pollInterval", config.PaymentVaultPollInterval)
+		}
 
 		offchainStore, err := mt.NewOffchainStore(
 			config.AwsClientConfig,
@@ -170,16 +186,77 @@ func RunDisperserServer(ctx *cli.Context) error {
 		blobMetadataStore := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
 		blobStore := blobstorev2.NewBlobStore(bucketName, s3Client, logger)
 
+		var authenticator corev2.BlobRequestAuthenticator = authv2.NewAuthenticator()
+		var replayGuard *authv2.ReplayGuard
+		if config.EnableReplayProtection {
+			var requestStore authv2.RequestStore
+			if config.ReplayProtectionTableName != "" {
+				requestStore = authv2.NewDynamoDBRequestStore(dynamoClient, config.ReplayProtectionTableName)
+			} else {
+				logger.Warn("Replay protection uses a local store, requests replayed to other API server replicas are not rejected")
+				requestStore = authv2.NewLocalRequestStore()
+			}
+			replayGuard, err = authv2.NewReplayGuardWithStore(requestStore, config.ReplayProtectionMaxTimeInPast, config.ReplayProtectionMaxTimeInFuture)
+			if err != nil {
+				return fmt.Errorf("failed to create replay guard: %w", err)
+			}
+			authenticator = authv2.NewReplayProtectedAuthenticator(replayGuard)
+			logger.Info("Enabled replay protection", "maxTimeInPast", config.ReplayProtectionMaxTimeInPast, "maxTimeInFuture", config.ReplayProtectionMaxTimeInFuture, "tableName", config.ReplayProtectionTableName)
+		}
+		if config.EnableTypedDataSignatures {
+			chainID, err := client.ChainID(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to get chain ID: %w", err)
+			}
+			// the eth client is used to verify the signatures of smart contract wallets
+			typedDataVerifier, err := authv2.NewTypedDataVerifier(chainID, client, config.ContractSignaturesPerSecond)
+			if err != nil {
+				return fmt.Errorf("failed to create typed data verifier: %w", err)
+			}
+			authenticator = authv2.NewTypedDataAuthenticator(replayGuard, typedDataVerifier)
+			logger.Info("Enabled typed data signatures", "chainID", chainID, "contractSignaturesPerSecond", config.ContractSignaturesPerSecond)
+		}
+
+		var admissionController *apiserver.AdmissionController
+		if config.AdmissionControlConfig.Enabled() {
+			admissionController, err = apiserver.NewAdmissionController(&config.AdmissionControlConfig, blobMetadataStore, logger)
+			if err != nil {
+				return fmt.Errorf("failed to create admission controller: %w", err)
+			}
+			logger.Info("Enabled admission control", "maxEncodingQueueDepth", config.AdmissionControlConfig.MaxEncodingQueueDepth, "maxDispatchQueueDepth", config.AdmissionControlConfig.MaxDispatchQueueDepth)
+		}
+
+		var accountAccessController *apiserver.AccountAccessController
+		if config.EnableAccountAccessControl {
+			accountAccessController, err = apiserver.NewAccountAccessController(&config.AccountAccessConfig, blobMetadataStore, logger)
+			if err != nil {
+				return fmt.Errorf("failed to create account access controller: %w", err)
+			}
+			logger.Info("Enabled account access control", "allowlistMode", config.AccountAccessConfig.AllowlistMode, "refreshInterval", config.AccountAccessConfig.RefreshInterval)
+
+			if config.AccountAccessAdminConfig.HTTPPort != "" {
+				adminServer, err := apiserver.NewAccountAccessAdminServer(config.AccountAccessAdminConfig, accountAccessController, logger)
+				if err != nil {
+					return fmt.Errorf("failed to create account access admin server: %w", err)
+				}
+				if err := adminServer.Start(context.Background()); err != nil {
+					return fmt.Errorf("failed to start account access admin server: %w", err)
+				}
+			}
+		}
+
 		server, err := apiserver.NewDispersalServerV2(
 			config.ServerConfig,
 			blobStore,
 			blobMetadataStore,
 			transactor,
 			meterer,
-			authv2.NewAuthenticator(),
+			authenticator,
 			prover,
 			uint64(config.MaxNumSymbolsPerBlob),
 			config.OnchainStateRefreshInterval,
+			admissionController,
+			accountAccessController,
 			logger,
 			reg,
 		)
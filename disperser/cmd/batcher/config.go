@@ -1,6 +1,8 @@
 package main
 
 import (
+	"math/big"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
@@ -10,12 +12,14 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli"
 )
 
 type Config struct {
 	BatcherConfig    batcher.Config
 	TimeoutConfig    batcher.TimeoutConfig
+	TxnManagerConfig batcher.TxnManagerConfig
 	BlobstoreConfig  blobstore.Config
 	EthClientConfig  geth.EthClientConfig
 	AwsClientConfig  aws.ClientConfig
@@ -45,6 +49,14 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	if !kmsConfig.Disable {
 		ethClientConfig = geth.ReadEthClientConfigRPCOnly(ctx)
 	}
+	feeStrategy, err := batcher.ParseFeeStrategy(ctx.GlobalString(flags.FeeStrategyFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	var maxGasFeeCap *big.Int
+	if maxGasFeeCapGwei := ctx.GlobalUint64(flags.MaxGasFeeCapGweiFlag.Name); maxGasFeeCapGwei > 0 {
+		maxGasFeeCap = new(big.Int).Mul(new(big.Int).SetUint64(maxGasFeeCapGwei), big.NewInt(params.GWei))
+	}
 	config := Config{
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
@@ -76,6 +88,11 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			ChainStateTimeout:   ctx.GlobalDuration(flags.ChainStateTimeoutFlag.Name),
 			TxnBroadcastTimeout: ctx.GlobalDuration(flags.TransactionBroadcastTimeoutFlag.Name),
 		},
+		TxnManagerConfig: batcher.TxnManagerConfig{
+			FeeStrategy:       feeStrategy,
+			GasBumpPercentage: ctx.GlobalUint64(flags.GasBumpPercentageFlag.Name),
+			MaxGasFeeCap:      maxGasFeeCap,
+		},
 		MetricsConfig: batcher.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
@@ -193,6 +193,27 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FINALIZATION_BLOCK_DELAY"),
 		Value:    75,
 	}
+	FeeStrategyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fee-strategy"),
+		Usage:    "EIP-1559 fee strategy of the batch confirmation transactions: market uses the fees suggested by the network, urgent outbids them by the gas bump percentage",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FEE_STRATEGY"),
+		Value:    "market",
+	}
+	GasBumpPercentageFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "gas-bump-percentage"),
+		Usage:    "Percentage the fees of a stalled batch confirmation transaction are increased by when it is replaced (min 10)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GAS_BUMP_PERCENTAGE"),
+		Value:    10,
+	}
+	MaxGasFeeCapGweiFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-gas-fee-cap-gwei"),
+		Usage:    "Max fee cap in gwei of the batch confirmation transactions, past which stalled transactions are no longer replaced. 0 means no limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_GAS_FEE_CAP_GWEI"),
+		Value:    0,
+	}
 	EnableGnarkBundleEncodingFlag = cli.BoolFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "enable-gnark-bundle-encoding"),
 		Usage:    "Enable Gnark bundle encoding for chunks",
@@ -248,6 +269,9 @@ var optionalFlags = []cli.Flag{
 	MaxNodeConnectionsFlag,
 	MaxNumRetriesPerDispersalFlag,
 	EnableGnarkBundleEncodingFlag,
+	FeeStrategyFlag,
+	GasBumpPercentageFlag,
+	MaxGasFeeCapGweiFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -1,16 +1,24 @@
 package main
 
 import (
+	"crypto/ecdsa"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli"
 )
 
@@ -19,9 +27,22 @@ const MaxUint16 = ^uint16(0)
 type Config struct {
 	EncodingManagerConfig          controller.EncodingManagerConfig
 	DispatcherConfig               controller.DispatcherConfig
+	EnableStatusNotifications      bool
+	StatusNotifierConfig           controller.StatusNotifierConfig
+	EnableStaleBlobSweeper         bool
+	StaleBlobSweeperConfig         controller.StaleBlobSweeperConfig
+	EnableChunkVerification        bool
+	ChunkVerifierConfig            controller.ChunkVerifierConfig
+	ChunkStoreBucketName           string
+	KzgConfig                      kzg.KzgConfig
 	NumConcurrentEncodingRequests  int
 	NumConcurrentDispersalRequests int
 	NodeClientCacheSize            int
+	EnableEncodingQueue            bool
+	EncodingQueuePollInterval      time.Duration
+	EncoderStatusRefreshInterval   time.Duration
+	EncoderBenchmarkIterations     uint32
+	QuorumProfiles                 core.BlobVersionQuorumProfiles
 
 	DynamoDBTableName string
 
@@ -59,6 +80,50 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		}
 		relays[i] = corev2.RelayKey(relay)
 	}
+	enableStatusNotifications := ctx.GlobalBool(flags.EnableStatusNotificationsFlag.Name)
+	var statusNotificationSigningKey *ecdsa.PrivateKey
+	if enableStatusNotifications {
+		signingKey := ctx.GlobalString(flags.StatusNotificationSigningKeyFlag.Name)
+		if signingKey == "" {
+			return Config{}, fmt.Errorf("status notification signing key is required when status notifications are enabled")
+		}
+		statusNotificationSigningKey, err = crypto.HexToECDSA(strings.TrimPrefix(signingKey, "0x"))
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid status notification signing key: %w", err)
+		}
+	}
+	enableEncodingQueue := ctx.GlobalBool(flags.EnableEncodingQueueFlag.Name)
+	encoderAddresses := ctx.GlobalStringSlice(flags.EncoderAddressFlag.Name)
+	if !enableEncodingQueue && len(encoderAddresses) == 0 {
+		return Config{}, fmt.Errorf("%s is required when the encoding queue is disabled", flags.EncoderAddressFlag.Name)
+	}
+	// the threshold is validated by the dispatcher, values beyond uint8 are capped so that they're rejected there
+	earlyFinalizationThreshold := min(ctx.GlobalUint(flags.EarlyFinalizationThresholdFlag.Name), math.MaxUint8)
+	quorumProfiles, err := coreeth.ReadQuorumProfiles(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
+	samplingRate := ctx.GlobalFloat64(flags.ChunkVerificationSamplingRateFlag.Name)
+	if samplingRate < 0 || samplingRate > 1 {
+		return Config{}, fmt.Errorf("%s must be between 0 and 1", flags.ChunkVerificationSamplingRateFlag.Name)
+	}
+	enableChunkVerification := samplingRate > 0
+	chunkStoreBucketName := ctx.GlobalString(flags.ChunkStoreBucketNameFlag.Name)
+	kzgConfig := kzg.ReadCLIConfig(ctx)
+	if enableChunkVerification {
+		if chunkStoreBucketName == "" {
+			return Config{}, fmt.Errorf("%s is required when the chunk verification is enabled", flags.ChunkStoreBucketNameFlag.Name)
+		}
+		if kzgConfig.G1Path == "" {
+			return Config{}, fmt.Errorf("G1Path must be specified when the chunk verification is enabled")
+		}
+		if kzgConfig.G2Path == "" && kzgConfig.G2PowerOf2Path == "" {
+			return Config{}, fmt.Errorf("G2Path or G2PowerOf2Path must be specified when the chunk verification is enabled")
+		}
+		if kzgConfig.SRSOrder <= 0 || kzgConfig.SRSNumberToLoad <= 0 {
+			return Config{}, fmt.Errorf("SRSOrder and SRSNumberToLoad must be specified when the chunk verification is enabled")
+		}
+	}
 	config := Config{
 		DynamoDBTableName: ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
 		EthClientConfig:   ethClientConfig,
@@ -71,20 +136,59 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			NumEncodingRetries:          ctx.GlobalInt(flags.NumEncodingRetriesFlag.Name),
 			NumRelayAssignment:          uint16(numRelayAssignments),
 			AvailableRelays:             relays,
-			EncoderAddress:              ctx.GlobalString(flags.EncoderAddressFlag.Name),
+			EncoderAddresses:            encoderAddresses,
 			MaxNumBlobsPerIteration:     int32(ctx.GlobalInt(flags.MaxNumBlobsPerIterationFlag.Name)),
 			OnchainStateRefreshInterval: ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
 		},
 		DispatcherConfig: controller.DispatcherConfig{
-			PullInterval:           ctx.GlobalDuration(flags.DispatcherPullIntervalFlag.Name),
-			FinalizationBlockDelay: ctx.GlobalUint64(flags.FinalizationBlockDelayFlag.Name),
-			NodeRequestTimeout:     ctx.GlobalDuration(flags.NodeRequestTimeoutFlag.Name),
-			NumRequestRetries:      ctx.GlobalInt(flags.NumRequestRetriesFlag.Name),
-			MaxBatchSize:           int32(ctx.GlobalInt(flags.MaxBatchSizeFlag.Name)),
+			PullInterval:                   ctx.GlobalDuration(flags.DispatcherPullIntervalFlag.Name),
+			FinalizationBlockDelay:         ctx.GlobalUint64(flags.FinalizationBlockDelayFlag.Name),
+			NodeRequestTimeout:             ctx.GlobalDuration(flags.NodeRequestTimeoutFlag.Name),
+			NumRequestRetries:              ctx.GlobalInt(flags.NumRequestRetriesFlag.Name),
+			RetryInitialBackoff:            ctx.GlobalDuration(flags.RetryInitialBackoffFlag.Name),
+			RetryBackoffMultiplier:         ctx.GlobalFloat64(flags.RetryBackoffMultiplierFlag.Name),
+			RetryMaxBackoff:                ctx.GlobalDuration(flags.RetryMaxBackoffFlag.Name),
+			MaxBatchSize:                   int32(ctx.GlobalInt(flags.MaxBatchSizeFlag.Name)),
+			MinBatchSize:                   int32(ctx.GlobalInt(flags.MinBatchSizeFlag.Name)),
+			MaxBatchInterval:               ctx.GlobalDuration(flags.MaxBatchIntervalFlag.Name),
+			MaxOperatorConcurrency:         ctx.GlobalInt(flags.MaxOperatorConcurrencyFlag.Name),
+			InitialOperatorConcurrency:     ctx.GlobalInt(flags.InitialOperatorConcurrencyFlag.Name),
+			EnableEarlyFinalization:        ctx.GlobalBool(flags.EnableEarlyFinalizationFlag.Name),
+			EarlyFinalizationMinWait:       ctx.GlobalDuration(flags.EarlyFinalizationMinWaitFlag.Name),
+			EarlyFinalizationThreshold:     uint8(earlyFinalizationThreshold),
+			CircuitBreakerFailureThreshold: ctx.GlobalInt(flags.CircuitBreakerFailureThresholdFlag.Name),
+			CircuitBreakerCooldown:         ctx.GlobalDuration(flags.CircuitBreakerCooldownFlag.Name),
+		},
+		EnableStatusNotifications: enableStatusNotifications,
+		StatusNotifierConfig: controller.StatusNotifierConfig{
+			SigningKey:     statusNotificationSigningKey,
+			NumWorkers:     ctx.GlobalInt(flags.NumConcurrentStatusNotificationsFlag.Name),
+			QueueSize:      ctx.GlobalInt(flags.StatusNotificationQueueSizeFlag.Name),
+			MaxRetries:     ctx.GlobalInt(flags.StatusNotificationMaxRetriesFlag.Name),
+			RetryInterval:  ctx.GlobalDuration(flags.StatusNotificationRetryIntervalFlag.Name),
+			RequestTimeout: ctx.GlobalDuration(flags.StatusNotificationTimeoutFlag.Name),
+		},
+		EnableStaleBlobSweeper: ctx.GlobalBool(flags.EnableStaleBlobSweeperFlag.Name),
+		StaleBlobSweeperConfig: controller.StaleBlobSweeperConfig{
+			SweepInterval:       ctx.GlobalDuration(flags.StaleBlobSweepIntervalFlag.Name),
+			EncodedBlobDeadline: ctx.GlobalDuration(flags.EncodedBlobDeadlineFlag.Name),
+			MaxNumRetries:       ctx.GlobalUint(flags.StaleBlobMaxNumRetriesFlag.Name),
+			NumBlobsPerFetch:    int32(ctx.GlobalInt(flags.StaleBlobNumBlobsPerFetchFlag.Name)),
+		},
+		EnableChunkVerification: enableChunkVerification,
+		ChunkVerifierConfig: controller.ChunkVerifierConfig{
+			SamplingRate: samplingRate,
 		},
+		ChunkStoreBucketName:           chunkStoreBucketName,
+		KzgConfig:                      kzgConfig,
 		NumConcurrentEncodingRequests:  ctx.GlobalInt(flags.NumConcurrentEncodingRequestsFlag.Name),
 		NumConcurrentDispersalRequests: ctx.GlobalInt(flags.NumConcurrentDispersalRequestsFlag.Name),
 		NodeClientCacheSize:            ctx.GlobalInt(flags.NodeClientCacheNumEntriesFlag.Name),
+		EnableEncodingQueue:            enableEncodingQueue,
+		EncodingQueuePollInterval:      ctx.GlobalDuration(flags.EncodingQueuePollIntervalFlag.Name),
+		EncoderStatusRefreshInterval:   ctx.GlobalDuration(flags.EncoderStatusRefreshIntervalFlag.Name),
+		EncoderBenchmarkIterations:     uint32(ctx.GlobalUint(flags.EncoderBenchmarkIterationsFlag.Name)),
+		QuorumProfiles:                 quorumProfiles,
 		IndexerConfig:                  indexer.ReadIndexerConfig(ctx),
 		ChainStateConfig:               thegraph.ReadCLIConfig(ctx),
 		UseGraph:                       ctx.GlobalBool(flags.UseGraphFlag.Name),
@@ -1,12 +1,15 @@
 package flags
 
 import (
+	"runtime"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
@@ -61,12 +64,52 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AVAILABLE_RELAYS"),
 	}
-	EncoderAddressFlag = cli.StringFlag{
+	EncoderAddressFlag = cli.StringSliceFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "encoder-address"),
-		Usage:    "the http ip:port which the distributed encoder server is listening",
-		Required: true,
+		Usage:    "the http ip:port which the distributed encoder server is listening. May be repeated (or comma separated in the env var) to balance the encoding requests between encoder replicas. Required unless the encoding queue is enabled",
+		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_ADDRESS"),
 	}
+	EnableEncodingQueueFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-encoding-queue"),
+		Usage:    "if true, encoding jobs are written to a queue in the dynamodb table that the encoders lease them from, instead of being sent to the encoder address",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_ENCODING_QUEUE"),
+	}
+	EncodingQueuePollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-queue-poll-interval"),
+		Usage:    "Interval at which the encoding queue is checked for the results of encoding jobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_QUEUE_POLL_INTERVAL"),
+		Value:    500 * time.Millisecond,
+	}
+	EncoderStatusRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoder-status-refresh-interval"),
+		Usage:    "Interval at which the load of the encoders is requested, to route the encoding requests to the least loaded encoder",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_STATUS_REFRESH_INTERVAL"),
+		Value:    time.Second,
+	}
+	EncoderBenchmarkIterationsFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoder-benchmark-iterations"),
+		Usage:    "Number of iterations of the encoding benchmark run on the encoders at startup, to weight the routing of the encoding requests by the measured capacity of each encoder. 0 disables the benchmark",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_BENCHMARK_ITERATIONS"),
+		Value:    0,
+	}
+	ChunkVerificationSamplingRateFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-verification-sampling-rate"),
+		Usage:    "Fraction of the chunks of each blob whose proofs are verified against the blob commitments once the blob is encoded, between 0 and 1. Blobs with invalid chunks are encoded again. 0 disables the verification, which requires the chunk store bucket and the KZG flags otherwise",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_VERIFICATION_SAMPLING_RATE"),
+		Value:    0,
+	}
+	ChunkStoreBucketNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-store-bucket-name"),
+		Usage:    "Name of the S3 bucket the encoders store the chunks in, read to verify the chunks",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_STORE_BUCKET_NAME"),
+	}
 	EncodingRequestTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "encoding-request-timeout"),
 		Usage:    "Timeout for encoding requests",
@@ -150,6 +193,27 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NUM_REQUEST_RETRIES"),
 		Value:    3,
 	}
+	RetryInitialBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retry-initial-backoff"),
+		Usage:    "Wait before the first retry of a node request. 0 retries immediately",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RETRY_INITIAL_BACKOFF"),
+		Value:    1 * time.Second,
+	}
+	RetryBackoffMultiplierFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "retry-backoff-multiplier"),
+		Usage:    "Factor the wait between retries of a node request grows by with every retry",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RETRY_BACKOFF_MULTIPLIER"),
+		Value:    2,
+	}
+	RetryMaxBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retry-max-backoff"),
+		Usage:    "Maximum wait between retries of a node request. 0 leaves the wait uncapped",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RETRY_MAX_BACKOFF"),
+		Value:    30 * time.Second,
+	}
 	NumConcurrentDispersalRequestsFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "num-concurrent-dispersal-requests"),
 		Usage:    "Number of concurrent dispersal requests",
@@ -171,6 +235,152 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BATCH_SIZE"),
 		Value:    128,
 	}
+	MinBatchSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-batch-size"),
+		Usage:    "Number of blobs batches are held back for under light load. The target batch size grows towards the max batch size as the load grows. 0 disables dynamic batch sizing",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MIN_BATCH_SIZE"),
+		Value:    0,
+	}
+	MaxBatchIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-batch-interval"),
+		Usage:    "Longest time blobs are held back for to fill a batch when dynamic batch sizing is enabled. Must be at least the dispatcher pull interval",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BATCH_INTERVAL"),
+		Value:    10 * time.Second,
+	}
+	MaxOperatorConcurrencyFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-operator-concurrency"),
+		Usage:    "Most dispersal requests that may be in flight to an operator. The limit of each operator adapts to its response time and error rate. 0 disables adaptive operator concurrency",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_OPERATOR_CONCURRENCY"),
+		Value:    0,
+	}
+	InitialOperatorConcurrencyFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "initial-operator-concurrency"),
+		Usage:    "Number of dispersal requests that may be in flight to an operator before its limit is adapted. Must be at most the max operator concurrency",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "INITIAL_OPERATOR_CONCURRENCY"),
+		Value:    2,
+	}
+	EnableEarlyFinalizationFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-early-finalization"),
+		Usage:    "Attest a batch as soon as the stake signed for each of its quorums reaches the confirmation thresholds of its blobs, instead of waiting for every operator to respond",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_EARLY_FINALIZATION"),
+	}
+	EarlyFinalizationMinWaitFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "early-finalization-min-wait"),
+		Usage:    "Least time signatures are gathered for before a batch is attested early",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "EARLY_FINALIZATION_MIN_WAIT"),
+		Value:    2 * time.Second,
+	}
+	EarlyFinalizationThresholdFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "early-finalization-threshold"),
+		Usage:    "Confirmation threshold, as a percentage of the quorum stake, that quorums of blobs without custom security thresholds must reach for a batch to be attested early",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "EARLY_FINALIZATION_THRESHOLD"),
+		Value:    55,
+	}
+	CircuitBreakerFailureThresholdFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "circuit-breaker-failure-threshold"),
+		Usage:    "Number of consecutive failed requests to an operator after which it isn't sent batches until the circuit breaker cooldown passes. 0 disables the circuit breaker",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CIRCUIT_BREAKER_FAILURE_THRESHOLD"),
+		Value:    0,
+	}
+	CircuitBreakerCooldownFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "circuit-breaker-cooldown"),
+		Usage:    "How long an operator isn't sent batches for once its circuit breaker opens, before a single batch is sent to probe whether it recovered",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CIRCUIT_BREAKER_COOLDOWN"),
+		Value:    30 * time.Second,
+	}
+	// StatusNotifier Flags
+	EnableStatusNotificationsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-status-notifications"),
+		Usage:    "Whether to notify the callback URLs of dispersal requests of blob status changes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_STATUS_NOTIFICATIONS"),
+	}
+	StatusNotificationSigningKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-notification-signing-key"),
+		Usage:    "Hex encoded secp256k1 private key the status notifications are signed with. Clients verify the notifications against its address. Required if status notifications are enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATUS_NOTIFICATION_SIGNING_KEY"),
+	}
+	NumConcurrentStatusNotificationsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "num-concurrent-status-notifications"),
+		Usage:    "Number of status notifications sent concurrently",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NUM_CONCURRENT_STATUS_NOTIFICATIONS"),
+		Value:    16,
+	}
+	StatusNotificationQueueSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-notification-queue-size"),
+		Usage:    "Max number of status notifications waiting to be sent, beyond which notifications are dropped",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATUS_NOTIFICATION_QUEUE_SIZE"),
+		Value:    10_000,
+	}
+	StatusNotificationMaxRetriesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-notification-max-retries"),
+		Usage:    "Number of times a failed status notification is retried",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATUS_NOTIFICATION_MAX_RETRIES"),
+		Value:    5,
+	}
+	StatusNotificationRetryIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-notification-retry-interval"),
+		Usage:    "Delay before the first retry of a failed status notification, doubled for every following retry",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATUS_NOTIFICATION_RETRY_INTERVAL"),
+		Value:    1 * time.Second,
+	}
+	StatusNotificationTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-notification-timeout"),
+		Usage:    "Timeout of a single status notification request",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATUS_NOTIFICATION_TIMEOUT"),
+		Value:    5 * time.Second,
+	}
+	// StaleBlobSweeper Flags
+	EnableStaleBlobSweeperFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-stale-blob-sweeper"),
+		Usage:    "Whether to requeue or fail blobs stuck in the encoded status for longer than the encoded blob deadline",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_STALE_BLOB_SWEEPER"),
+	}
+	StaleBlobSweepIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stale-blob-sweep-interval"),
+		Usage:    "Interval at which stale blobs are looked for",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STALE_BLOB_SWEEP_INTERVAL"),
+		Value:    time.Minute,
+	}
+	EncodedBlobDeadlineFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoded-blob-deadline"),
+		Usage:    "How long a blob may stay encoded before it is requeued into a new batch. Must exceed the time to dispatch a batch and gather its signatures",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODED_BLOB_DEADLINE"),
+		Value:    5 * time.Minute,
+	}
+	StaleBlobMaxNumRetriesFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stale-blob-max-num-retries"),
+		Usage:    "Number of times a stale blob is requeued before it is failed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STALE_BLOB_MAX_NUM_RETRIES"),
+		Value:    3,
+	}
+	StaleBlobNumBlobsPerFetchFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stale-blob-num-blobs-per-fetch"),
+		Usage:    "Number of encoded blobs read at a time when looking for stale blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STALE_BLOB_NUM_BLOBS_PER_FETCH"),
+		Value:    1000,
+	}
+
 	MetricsPortFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "metrics-port"),
 		Usage:    "Port to expose metrics",
@@ -187,7 +397,6 @@ var requiredFlags = []cli.Flag{
 	UseGraphFlag,
 	EncodingPullIntervalFlag,
 	AvailableRelaysFlag,
-	EncoderAddressFlag,
 
 	DispatcherPullIntervalFlag,
 	NodeRequestTimeoutFlag,
@@ -195,6 +404,13 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	EncoderAddressFlag,
+	EnableEncodingQueueFlag,
+	EncodingQueuePollIntervalFlag,
+	EncoderStatusRefreshIntervalFlag,
+	EncoderBenchmarkIterationsFlag,
+	ChunkVerificationSamplingRateFlag,
+	ChunkStoreBucketNameFlag,
 	IndexerDataDirFlag,
 	EncodingRequestTimeoutFlag,
 	EncodingStoreTimeoutFlag,
@@ -206,19 +422,94 @@ var optionalFlags = []cli.Flag{
 
 	FinalizationBlockDelayFlag,
 	NumRequestRetriesFlag,
+	RetryInitialBackoffFlag,
+	RetryBackoffMultiplierFlag,
+	RetryMaxBackoffFlag,
 	NumConcurrentDispersalRequestsFlag,
 	NodeClientCacheNumEntriesFlag,
 	MaxBatchSizeFlag,
+	MinBatchSizeFlag,
+	MaxBatchIntervalFlag,
+	MaxOperatorConcurrencyFlag,
+	InitialOperatorConcurrencyFlag,
+	EnableEarlyFinalizationFlag,
+	EarlyFinalizationMinWaitFlag,
+	EarlyFinalizationThresholdFlag,
+	CircuitBreakerFailureThresholdFlag,
+	CircuitBreakerCooldownFlag,
+	EnableStatusNotificationsFlag,
+	StatusNotificationSigningKeyFlag,
+	NumConcurrentStatusNotificationsFlag,
+	StatusNotificationQueueSizeFlag,
+	StatusNotificationMaxRetriesFlag,
+	StatusNotificationRetryIntervalFlag,
+	StatusNotificationTimeoutFlag,
+	EnableStaleBlobSweeperFlag,
+	StaleBlobSweepIntervalFlag,
+	EncodedBlobDeadlineFlag,
+	StaleBlobMaxNumRetriesFlag,
+	StaleBlobNumBlobsPerFetchFlag,
 	MetricsPortFlag,
 }
 
+var kzgFlags = []cli.Flag{
+	// KZG flags for verifying the chunks
+	// These are copied from encoding/kzg/cli.go as optional flags, since they're only used when the chunk verification
+	// is enabled
+	cli.StringFlag{
+		Name:     kzg.G1PathFlagName,
+		Usage:    "Path to G1 SRS",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "G1_PATH"),
+	},
+	cli.StringFlag{
+		Name:     kzg.G2PathFlagName,
+		Usage:    "Path to G2 SRS. Either this flag or G2_POWER_OF_2_PATH needs to be specified when the chunk verification is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "G2_PATH"),
+	},
+	cli.StringFlag{
+		Name:     kzg.G2PowerOf2PathFlagName,
+		Usage:    "Path to G2 SRS points that are on power of 2. Either this flag or G2_PATH needs to be specified when the chunk verification is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "G2_POWER_OF_2_PATH"),
+	},
+	cli.StringFlag{
+		Name:     kzg.CachePathFlagName,
+		Usage:    "Path to SRS Table directory",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_PATH"),
+	},
+	cli.Uint64Flag{
+		Name:     kzg.SRSOrderFlagName,
+		Usage:    "Order of the SRS",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SRS_ORDER"),
+	},
+	cli.Uint64Flag{
+		Name:     kzg.SRSLoadingNumberFlagName,
+		Usage:    "Number of SRS points to load into memory",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SRS_LOAD"),
+	},
+	cli.Uint64Flag{
+		Name:     kzg.NumWorkerFlagName,
+		Usage:    "Number of workers for multithreading",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NUM_WORKERS"),
+		Value:    uint64(runtime.GOMAXPROCS(0)),
+	},
+}
+
 var Flags []cli.Flag
 
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
+	Flags = append(Flags, coreeth.QuorumProfilesFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, indexer.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, kzgFlags...)
 }
@@ -13,16 +13,21 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gammazero/workerpool"
 	"github.com/urfave/cli"
@@ -74,6 +79,7 @@ func RunController(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	chainReader.SetQuorumProfiles(config.QuorumProfiles)
 
 	blobMetadataStore := blobstore.NewBlobMetadataStore(
 		dynamoClient,
@@ -97,10 +103,44 @@ func RunController(ctx *cli.Context) error {
 		Handler: mux,
 	}
 
-	encoderClient, err := encoder.NewEncoderClientV2(config.EncodingManagerConfig.EncoderAddress)
+	var statusNotifier *controller.StatusNotifier
+	if config.EnableStatusNotifications {
+		statusNotifier, err = controller.NewStatusNotifier(&config.StatusNotifierConfig, logger, metricsRegistry)
+		if err != nil {
+			return fmt.Errorf("failed to create status notifier: %v", err)
+		}
+		logger.Info("Enabled status notifications", "signer", crypto.PubkeyToAddress(config.StatusNotifierConfig.SigningKey.PublicKey).Hex())
+	}
+
+	var encoderClient disperser.EncoderClientV2
+	var balancingEncoderClient *encoder.BalancingClientV2
+	if config.EnableEncodingQueue {
+		encoderClient, err = encoder.NewQueueEncoderClientV2(blobMetadataStore, config.EncodingQueuePollInterval, logger)
+	} else {
+		balancingEncoderClient, err = encoder.NewBalancingEncoderClientV2(
+			config.EncodingManagerConfig.EncoderAddresses, config.EncoderStatusRefreshInterval, logger)
+		encoderClient = balancingEncoderClient
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create encoder client: %v", err)
 	}
+	var chunkVerifier *controller.ChunkVerifier
+	if config.EnableChunkVerification {
+		s3Client, err := s3.NewClient(context.Background(), config.AwsClientConfig, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create s3 client: %v", err)
+		}
+		chunkReader := chunkstore.NewChunkReader(logger, s3Client, config.ChunkStoreBucketName)
+		kzgVerifier, err := verifier.NewVerifier(&config.KzgConfig, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create kzg verifier: %v", err)
+		}
+		chunkVerifier, err = controller.NewChunkVerifier(&config.ChunkVerifierConfig, chunkReader, kzgVerifier, logger, metricsRegistry)
+		if err != nil {
+			return fmt.Errorf("failed to create chunk verifier: %v", err)
+		}
+		logger.Info("Enabled chunk verification", "samplingRate", config.ChunkVerifierConfig.SamplingRate)
+	}
 	encodingPool := workerpool.New(config.NumConcurrentEncodingRequests)
 	encodingManager, err := controller.NewEncodingManager(
 		&config.EncodingManagerConfig,
@@ -108,6 +148,8 @@ func RunController(ctx *cli.Context) error {
 		encodingPool,
 		encoderClient,
 		chainReader,
+		statusNotifier,
+		chunkVerifier,
 		logger,
 		metricsRegistry,
 	)
@@ -148,10 +190,16 @@ func RunController(ctx *cli.Context) error {
 			return err
 		}
 	}
-	nodeClientManager, err := controller.NewNodeClientManager(config.NodeClientCacheSize, logger)
+	nodeClientManager, err := controller.NewNodeClientManager(config.NodeClientCacheSize, config.QuorumProfiles.Digest(), logger)
 	if err != nil {
 		return fmt.Errorf("failed to create node client manager: %v", err)
 	}
+	if config.DispatcherConfig.EnableEarlyFinalization {
+		err = controller.ValidateEarlyFinalizationThreshold(context.Background(), chainReader, config.DispatcherConfig.EarlyFinalizationThreshold)
+		if err != nil {
+			return fmt.Errorf("invalid early finalization config: %w", err)
+		}
+	}
 	dispatcher, err := controller.NewDispatcher(
 		&config.DispatcherConfig,
 		blobMetadataStore,
@@ -159,6 +207,7 @@ func RunController(ctx *cli.Context) error {
 		ics,
 		sigAgg,
 		nodeClientManager,
+		statusNotifier,
 		logger,
 		metricsRegistry,
 	)
@@ -166,7 +215,28 @@ func RunController(ctx *cli.Context) error {
 		return fmt.Errorf("failed to create dispatcher: %v", err)
 	}
 
+	var staleBlobSweeper *controller.StaleBlobSweeper
+	if config.EnableStaleBlobSweeper {
+		staleBlobSweeper, err = controller.NewStaleBlobSweeper(&config.StaleBlobSweeperConfig, blobMetadataStore, statusNotifier, logger, metricsRegistry)
+		if err != nil {
+			return fmt.Errorf("failed to create stale blob sweeper: %v", err)
+		}
+	}
+
 	c := context.Background()
+	if statusNotifier != nil {
+		statusNotifier.Start(c)
+		logger.Info("Enabled blob status notifications")
+	}
+
+	if balancingEncoderClient != nil {
+		balancingEncoderClient.Start(c)
+		if config.EncoderBenchmarkIterations > 0 {
+			// the requests are routed by load alone until the benchmark completes
+			go balancingEncoderClient.Benchmark(c, config.EncoderBenchmarkIterations)
+		}
+	}
+
 	err = encodingManager.Start(c)
 	if err != nil {
 		return fmt.Errorf("failed to start encoding manager: %v", err)
@@ -177,6 +247,11 @@ func RunController(ctx *cli.Context) error {
 		return fmt.Errorf("failed to start dispatcher: %v", err)
 	}
 
+	if staleBlobSweeper != nil {
+		staleBlobSweeper.Start(c)
+		logger.Info("Enabled stale blob sweeper", "encodedBlobDeadline", config.StaleBlobSweeperConfig.EncodedBlobDeadline)
+	}
+
 	go func() {
 		err := metricsServer.ListenAndServe()
 		if err != nil && !strings.Contains(err.Error(), "http: Server closed") {
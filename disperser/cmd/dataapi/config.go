@@ -2,10 +2,12 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
@@ -37,6 +39,31 @@ type Config struct {
 	DisperserHostname  string
 	ChurnerHostname    string
 	BatcherHealthEndpt string
+	RelayUseSecureGrpc bool
+	APIKeyTableName    string
+
+	RatelimiterConfig ratelimit.Config
+	IPRequestRate     uint32
+	APIKeyRequestRate uint32
+	BucketTableName   string
+
+	CompressionMinSize    int
+	ShutdownTimeout       time.Duration
+	MaintenanceRetryAfter time.Duration
+	CachePolicy           dataapi.CachePolicy
+	QueryCacheTTL         time.Duration
+	QueryCacheSize        int
+
+	TracingEndpoint    string
+	TracingSampleRatio float64
+
+	SigningRateOwner          bool
+	AlertWebhookURLs          []string
+	AlertWebhookSecret        string
+	AlertSigningRateThreshold float64
+	AlertCheckInterval        time.Duration
+
+	BackfillBlobFeedIndex bool
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -49,6 +76,10 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
+	ratelimiterConfig, err := ratelimit.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
 	ethClientConfig := geth.ReadEthClientConfig(ctx)
 	config := Config{
 		BlobstoreConfig: blobstore.Config{
@@ -70,6 +101,11 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			Username:  ctx.GlobalString(flags.PrometheusServerUsernameFlag.Name),
 			Secret:    ctx.GlobalString(flags.PrometheusServerSecretFlag.Name),
 			Cluster:   ctx.GlobalString(flags.PrometheusMetricsClusterLabelFlag.Name),
+
+			FallbackServerURL: ctx.GlobalString(flags.PrometheusFallbackServerURLFlag.Name),
+			QueryTimeout:      ctx.GlobalDuration(flags.PrometheusQueryTimeoutFlag.Name),
+			MaxRetries:        ctx.GlobalInt(flags.PrometheusMaxRetriesFlag.Name),
+			RetryBackoff:      ctx.GlobalDuration(flags.PrometheusRetryBackoffFlag.Name),
 		},
 		AllowOrigins: ctx.GlobalStringSlice(flags.AllowOriginsFlag.Name),
 
@@ -77,10 +113,37 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetricsFlag.Name),
 		},
-		DisperserHostname:  ctx.GlobalString(flags.DisperserHostnameFlag.Name),
-		ChurnerHostname:    ctx.GlobalString(flags.ChurnerHostnameFlag.Name),
-		BatcherHealthEndpt: ctx.GlobalString(flags.BatcherHealthEndptFlag.Name),
+		DisperserHostname:     ctx.GlobalString(flags.DisperserHostnameFlag.Name),
+		ChurnerHostname:       ctx.GlobalString(flags.ChurnerHostnameFlag.Name),
+		BatcherHealthEndpt:    ctx.GlobalString(flags.BatcherHealthEndptFlag.Name),
+		RelayUseSecureGrpc:    ctx.GlobalBool(flags.RelayUseSecureGrpcFlag.Name),
+		APIKeyTableName:       ctx.GlobalString(flags.APIKeyTableNameFlag.Name),
+		RatelimiterConfig:     ratelimiterConfig,
+		IPRequestRate:         uint32(ctx.GlobalUint(flags.IPRequestRateFlag.Name)),
+		APIKeyRequestRate:     uint32(ctx.GlobalUint(flags.APIKeyRequestRateFlag.Name)),
+		BucketTableName:       ctx.GlobalString(flags.BucketTableNameFlag.Name),
+		CompressionMinSize:    ctx.GlobalInt(flags.CompressionMinSizeFlag.Name),
+		ShutdownTimeout:       ctx.GlobalDuration(flags.ShutdownTimeoutFlag.Name),
+		MaintenanceRetryAfter: ctx.GlobalDuration(flags.MaintenanceRetryAfterFlag.Name),
+		CachePolicy: dataapi.CachePolicy{
+			BlobMaxAge:         ctx.GlobalDuration(flags.BlobCacheMaxAgeFlag.Name),
+			BatchMaxAge:        ctx.GlobalDuration(flags.BatchCacheMaxAgeFlag.Name),
+			StakeMaxAge:        ctx.GlobalDuration(flags.StakeCacheMaxAgeFlag.Name),
+			ReachabilityMaxAge: ctx.GlobalDuration(flags.ReachabilityCacheMaxAgeFlag.Name),
+		},
+		QueryCacheTTL:      ctx.GlobalDuration(flags.QueryCacheTTLFlag.Name),
+		QueryCacheSize:     ctx.GlobalInt(flags.QueryCacheSizeFlag.Name),
+		TracingEndpoint:    ctx.GlobalString(flags.TracingEndpointFlag.Name),
+		TracingSampleRatio: ctx.GlobalFloat64(flags.TracingSampleRatioFlag.Name),
 		ChainStateConfig:   thegraph.ReadCLIConfig(ctx),
+
+		SigningRateOwner:          ctx.GlobalBool(flags.SigningRateOwnerFlag.Name),
+		AlertWebhookURLs:          ctx.GlobalStringSlice(flags.AlertWebhookURLsFlag.Name),
+		AlertWebhookSecret:        ctx.GlobalString(flags.AlertWebhookSecretFlag.Name),
+		AlertSigningRateThreshold: ctx.GlobalFloat64(flags.AlertSigningRateThresholdFlag.Name),
+		AlertCheckInterval:        ctx.GlobalDuration(flags.AlertCheckIntervalFlag.Name),
+
+		BackfillBlobFeedIndex: ctx.GlobalBool(flags.BackfillBlobFeedIndexFlag.Name),
 	}
 	return config, nil
 }
@@ -1,9 +1,12 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/urfave/cli"
 )
@@ -57,6 +60,33 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PROMETHEUS_METRICS_CLUSTER_LABEL"),
 		Required: true,
 	}
+	PrometheusFallbackServerURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "prometheus-fallback-server-url"),
+		Usage:    "the url of the prometheus server queried when the primary one fails, with the same credentials",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PROMETHEUS_FALLBACK_SERVER_URL"),
+		Required: false,
+	}
+	PrometheusQueryTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "prometheus-query-timeout"),
+		Usage:    "the timeout of each prometheus query attempt, 0 to disable",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PROMETHEUS_QUERY_TIMEOUT"),
+		Required: false,
+		Value:    10 * time.Second,
+	}
+	PrometheusMaxRetriesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "prometheus-max-retries"),
+		Usage:    "the number of times a failed prometheus query is retried",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PROMETHEUS_MAX_RETRIES"),
+		Required: false,
+		Value:    2,
+	}
+	PrometheusRetryBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "prometheus-retry-backoff"),
+		Usage:    "the base delay before retrying a failed prometheus query, doubled on each retry",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PROMETHEUS_RETRY_BACKOFF"),
+		Required: false,
+		Value:    250 * time.Millisecond,
+	}
 	SubgraphApiBatchMetadataAddrFlag = cli.StringFlag{
 		Name: common.PrefixFlag(FlagPrefix, "sub-batch-metadata-socket-addr"),
 		//We need the socket address of the subgraph batch metadata api to pull the subgraph data from.
@@ -132,6 +162,152 @@ var (
 		Value:    "9100",
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METRICS_HTTP_PORT"),
 	}
+	RelayUseSecureGrpcFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-use-secure-grpc"),
+		Usage:    "Whether to use TLS when probing relays",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_USE_SECURE_GRPC"),
+	}
+	APIKeyTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "api-key-table-name"),
+		Usage:    "Name of the dynamodb table holding API keys. If set, v2 requests must carry a valid API key",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "API_KEY_TABLE_NAME"),
+	}
+	IPRequestRateFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "ip-request-rate"),
+		Usage:    "Max number of v2 requests per second from a client IP. 0 disables the limit",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "IP_REQUEST_RATE"),
+	}
+	APIKeyRequestRateFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "api-key-request-rate"),
+		Usage:    "Max number of v2 requests per second made with an API key. 0 disables the limit",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "API_KEY_REQUEST_RATE"),
+	}
+	BucketTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "rate-bucket-table-name"),
+		Usage:    "Name of the dynamodb table to store rate limiter buckets, shared by all replicas. If not provided, a local store will be used",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RATE_BUCKET_TABLE_NAME"),
+	}
+	CompressionMinSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "compression-min-size"),
+		Usage:    "Min size in bytes of v2 responses that are compressed (gzip or zstd, as accepted by the client). 0 disables compression",
+		Required: false,
+		Value:    1024,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "COMPRESSION_MIN_SIZE"),
+	}
+	ShutdownTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "shutdown-timeout"),
+		Usage:    "Max time the v2 server waits for in-flight requests to complete on shutdown before closing connections",
+		Required: false,
+		Value:    10 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SHUTDOWN_TIMEOUT"),
+	}
+	MaintenanceRetryAfterFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "maintenance-retry-after"),
+		Usage:    "Time after which clients are told to retry the requests rejected while the v2 server is in maintenance mode",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAINTENANCE_RETRY_AFTER"),
+	}
+	BlobCacheMaxAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-cache-max-age"),
+		Usage:    "Max age clients may cache the responses of the v2 blob routes for",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_CACHE_MAX_AGE"),
+	}
+	BatchCacheMaxAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-cache-max-age"),
+		Usage:    "Max age clients may cache the responses of the v2 batch routes for",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_CACHE_MAX_AGE"),
+	}
+	StakeCacheMaxAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stake-cache-max-age"),
+		Usage:    "Max age clients may cache the responses of the v2 operator stake routes for",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STAKE_CACHE_MAX_AGE"),
+	}
+	ReachabilityCacheMaxAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "reachability-cache-max-age"),
+		Usage:    "Max age clients may cache the responses of the v2 operator and relay reachability checks for",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REACHABILITY_CACHE_MAX_AGE"),
+	}
+	QueryCacheTTLFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "query-cache-ttl"),
+		Usage:    "How long the v2 server caches the results of subgraph queries and operator stake. 0 disables caching",
+		Required: false,
+		Value:    15 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "QUERY_CACHE_TTL"),
+	}
+	QueryCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "query-cache-size"),
+		Usage:    "Max number of query results cached by the v2 server",
+		Required: false,
+		Value:    1024,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "QUERY_CACHE_SIZE"),
+	}
+	TracingEndpointFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tracing-endpoint"),
+		Usage:    "Endpoint of the OTLP/gRPC collector the v2 server exports traces to. If not provided, tracing is disabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TRACING_ENDPOINT"),
+	}
+	TracingSampleRatioFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "tracing-sample-ratio"),
+		Usage:    "Ratio of the requests traced, among those which are not already traced by the client",
+		Required: false,
+		Value:    1,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TRACING_SAMPLE_RATIO"),
+	}
+	SigningRateOwnerFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "signing-rate-owner"),
+		Usage:    "Store the operator signing tallies of the completed hours, which all v2 servers load. Set it on a single v2 server",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SIGNING_RATE_OWNER"),
+	}
+	AlertWebhookURLsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-webhook-urls"),
+		Usage:    "URLs the v2 server posts operator signing rate and reachability alerts to. If not provided, alerting is disabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_WEBHOOK_URLS"),
+	}
+	AlertWebhookSecretFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-webhook-secret"),
+		Usage:    "Secret the operator alert payloads are signed with (HMAC-SHA256, in the X-EigenDA-Signature-256 header). If not provided, alerts are not signed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_WEBHOOK_SECRET"),
+	}
+	AlertSigningRateThresholdFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-signing-rate-threshold"),
+		Usage:    "Signing percentage over the last hour below which an operator alert fires",
+		Required: false,
+		Value:    90,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_SIGNING_RATE_THRESHOLD"),
+	}
+	AlertCheckIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-check-interval"),
+		Usage:    "How often operators are checked for alerts",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_CHECK_INTERVAL"),
+	}
+	BackfillBlobFeedIndexFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "backfill-blob-feed-index"),
+		Usage:    "Backfill the blob feed index attributes on v2 blob metadata written before the index existed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BACKFILL_BLOB_FEED_INDEX"),
+	}
 	DataApiServerVersionFlag = cli.UintFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "dataapi-version"),
 		Usage:    "DataApi server version. Options are 1 and 2.",
@@ -164,6 +340,32 @@ var optionalFlags = []cli.Flag{
 	ServerModeFlag,
 	MetricsHTTPPort,
 	DataApiServerVersionFlag,
+	RelayUseSecureGrpcFlag,
+	APIKeyTableNameFlag,
+	IPRequestRateFlag,
+	APIKeyRequestRateFlag,
+	BucketTableNameFlag,
+	CompressionMinSizeFlag,
+	ShutdownTimeoutFlag,
+	MaintenanceRetryAfterFlag,
+	BlobCacheMaxAgeFlag,
+	BatchCacheMaxAgeFlag,
+	StakeCacheMaxAgeFlag,
+	ReachabilityCacheMaxAgeFlag,
+	QueryCacheTTLFlag,
+	QueryCacheSizeFlag,
+	TracingEndpointFlag,
+	TracingSampleRatioFlag,
+	SigningRateOwnerFlag,
+	AlertWebhookURLsFlag,
+	AlertWebhookSecretFlag,
+	AlertSigningRateThresholdFlag,
+	AlertCheckIntervalFlag,
+	BackfillBlobFeedIndexFlag,
+	PrometheusFallbackServerURLFlag,
+	PrometheusQueryTimeoutFlag,
+	PrometheusMaxRetriesFlag,
+	PrometheusRetryBackoffFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -175,4 +377,5 @@ func init() {
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
 }
@@ -12,12 +12,15 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/store"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/apikey"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -131,30 +134,109 @@ func RunDataApi(ctx *cli.Context) error {
 
 	if config.ServerVersion == 2 {
 		blobMetadataStorev2 := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
+		if config.BackfillBlobFeedIndex {
+			go func() {
+				updated, err := blobMetadataStorev2.BackfillRequestedAtIndex(context.Background())
+				if err != nil {
+					logger.Error("Failed to backfill blob feed index", "updated", updated, "err", err)
+					return
+				}
+				logger.Info("Backfilled blob feed index", "updated", updated)
+			}()
+		}
+		var apiKeyStore apikey.Store
+		if config.APIKeyTableName != "" {
+			apiKeyStore = apikey.NewDynamoStore(dynamoClient, config.APIKeyTableName)
+			logger.Info("Enabled API key authentication", "table", config.APIKeyTableName)
+		}
+		var bucketStore ratelimit.BucketStore
+		if config.IPRequestRate > 0 || config.APIKeyRequestRate > 0 {
+			if config.BucketTableName != "" {
+				bucketStore = store.NewDynamoParamStore[common.RateBucketParams](dynamoClient, config.BucketTableName)
+			} else {
+				bucketStore, err = store.NewLocalParamStore[common.RateBucketParams](config.RatelimiterConfig.BucketStoreSize)
+				if err != nil {
+					return err
+				}
+			}
+			logger.Info("Enabled rate limiting", "ipRequestRate", config.IPRequestRate, "apiKeyRequestRate", config.APIKeyRequestRate)
+		}
+		var shutdownTracing func(context.Context) error
+		if config.TracingEndpoint != "" {
+			shutdownTracing, err = setupTracing(context.Background(), config.TracingEndpoint, config.TracingSampleRatio)
+			if err != nil {
+				return err
+			}
+			logger.Info("Enabled tracing", "endpoint", config.TracingEndpoint, "sampleRatio", config.TracingSampleRatio)
+		}
+		if len(config.AlertWebhookURLs) > 0 {
+			logger.Info("Enabled operator alerts", "webhooks", len(config.AlertWebhookURLs), "signingRateThreshold", config.AlertSigningRateThreshold)
+		}
 		serverv2 := dataapi.NewServerV2(
 			dataapi.Config{
-				ServerMode:         config.ServerMode,
-				SocketAddr:         config.SocketAddr,
-				AllowOrigins:       config.AllowOrigins,
-				DisperserHostname:  config.DisperserHostname,
-				ChurnerHostname:    config.ChurnerHostname,
-				BatcherHealthEndpt: config.BatcherHealthEndpt,
+				ServerMode:            config.ServerMode,
+				SocketAddr:            config.SocketAddr,
+				AllowOrigins:          config.AllowOrigins,
+				DisperserHostname:     config.DisperserHostname,
+				ChurnerHostname:       config.ChurnerHostname,
+				BatcherHealthEndpt:    config.BatcherHealthEndpt,
+				RelayUseSecureGrpc:    config.RelayUseSecureGrpc,
+				RateLimiterConfig:     config.RatelimiterConfig,
+				IPRequestRate:         config.IPRequestRate,
+				APIKeyRequestRate:     config.APIKeyRequestRate,
+				CompressionMinSize:    config.CompressionMinSize,
+				ShutdownTimeout:       config.ShutdownTimeout,
+				MaintenanceRetryAfter: config.MaintenanceRetryAfter,
+				CachePolicy:           config.CachePolicy,
+				QueryCacheTTL:         config.QueryCacheTTL,
+				QueryCacheSize:        config.QueryCacheSize,
+
+				SigningRateOwner:          config.SigningRateOwner,
+				AlertWebhookURLs:          config.AlertWebhookURLs,
+				AlertWebhookSecret:        config.AlertWebhookSecret,
+				AlertSigningRateThreshold: config.AlertSigningRateThreshold,
+				AlertCheckInterval:        config.AlertCheckInterval,
+			},
+			dataapi.ServerV2Options{
+				BlobMetadataStore: blobMetadataStorev2,
+				PromClient:        promClient,
+				SubgraphClient:    subgraphClient,
+				ChainReader:       tx,
+				ChainState:        chainState,
+				IndexedChainState: indexedChainState,
+				Logger:            logger,
+				Metrics:           metrics,
+				EthClient:         client,
+				APIKeyStore:       apiKeyStore,
+				BucketStore:       bucketStore,
 			},
-			blobMetadataStorev2,
-			promClient,
-			subgraphClient,
-			tx,
-			chainState,
-			indexedChainState,
-			logger,
-			metrics,
 		)
-		return runServer(serverv2, logger)
+		handleMaintenanceSignals(serverv2)
+		err = runServer(serverv2, logger)
+		if shutdownTracing != nil {
+			flushCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+			defer cancel()
+			if err := shutdownTracing(flushCtx); err != nil {
+				logger.Warn("Failed to flush traces", "err", err)
+			}
+		}
+		return err
 	}
 
 	return runServer(server, logger)
 }
 
+// handleMaintenanceSignals puts the server in maintenance mode on SIGUSR1, and takes it out on SIGUSR2
+func handleMaintenanceSignals(server *dataapi.ServerV2) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for sig := range signals {
+			server.SetMaintenance(sig == syscall.SIGUSR1)
+		}
+	}()
+}
+
 func runServer[T dataapi.ServerInterface](server T, logger logging.Logger) error {
 	// Setup channel to listen for termination signals
 	quit := make(chan os.Signal, 1)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
@@ -10,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/google/uuid"
 	"github.com/urfave/cli"
 )
 
@@ -29,6 +31,10 @@ type Config struct {
 	LoggerConfig     common.LoggerConfig
 	ServerConfig     *encoder.ServerConfig
 	MetricsConfig    *encoder.MetricsConfig
+
+	EnableEncodingQueue bool
+	DynamoDBTableName   string
+	QueueWorkerConfig   encoder.QueueWorkerConfig
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -41,6 +47,28 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
+
+	enableEncodingQueue := ctx.GlobalBool(flags.EnableEncodingQueueFlag.Name)
+	dynamoDBTableName := ctx.GlobalString(flags.DynamoDBTableNameFlag.Name)
+	var workerID string
+	if enableEncodingQueue {
+		if version != uint(V2) {
+			return Config{}, fmt.Errorf("the encoding queue is only supported by encoder version %d", V2)
+		}
+		if dynamoDBTableName == "" {
+			return Config{}, fmt.Errorf("%s is required when the encoding queue is enabled", flags.DynamoDBTableNameFlag.Name)
+		}
+		hostname, err := os.Hostname()
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to get hostname: %w", err)
+		}
+		// the hostname alone isn't unique if the encoder is restarted while its jobs are still leased
+		workerID = fmt.Sprintf("%s-%s", hostname, uuid.NewString())
+	}
+	sizeClasses, err := encoder.ParseSizeClasses(ctx.GlobalStringSlice(flags.SizeClassesFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
 	config := Config{
 		EncoderVersion:  EncoderVersion(version),
 		AwsClientConfig: aws.ReadClientConfig(ctx, flags.FlagPrefix),
@@ -62,11 +90,23 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			GPUEnable:                ctx.Bool(flags.GPUEnableFlag.Name),
 			PprofHttpPort:            ctx.GlobalString(flags.PprofHttpPort.Name),
 			EnablePprof:              ctx.GlobalBool(flags.EnablePprof.Name),
+			EncodingCacheSizeBytes:   ctx.GlobalUint64(flags.EncodingCacheSizeBytesFlag.Name),
+			FFTCacheDir:              ctx.GlobalString(flags.FFTCacheDirFlag.Name),
+			SizeClasses:              sizeClasses,
 		},
 		MetricsConfig: &encoder.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
 		},
+		EnableEncodingQueue: enableEncodingQueue,
+		DynamoDBTableName:   dynamoDBTableName,
+		QueueWorkerConfig: encoder.QueueWorkerConfig{
+			WorkerID:      workerID,
+			PollInterval:  ctx.GlobalDuration(flags.EncodingQueuePollIntervalFlag.Name),
+			LeaseDuration: ctx.GlobalDuration(flags.EncodingJobLeaseDurationFlag.Name),
+			MaxAttempts:   ctx.GlobalUint(flags.EncodingJobMaxAttemptsFlag.Name),
+			RetryBackoff:  ctx.GlobalDuration(flags.EncodingJobRetryBackoffFlag.Name),
+		},
 	}
 	return config, nil
 }
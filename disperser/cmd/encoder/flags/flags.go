@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -87,6 +89,66 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PREVENT_REENCODING"),
 	}
+	EncodingCacheSizeBytesFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-cache-size-bytes"),
+		Usage:    "maximum total size in bytes of the chunks of recently encoded blobs cached so that duplicate dispersals skip encoding. 0 disables the cache",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_CACHE_SIZE_BYTES"),
+	}
+	FFTCacheDirFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fft-cache-dir"),
+		Usage:    "directory the FFT roots of unity are persisted to, so that they aren't computed again after a restart. If empty, they are only cached in memory",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FFT_CACHE_DIR"),
+	}
+	SizeClassesFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "size-classes"),
+		Usage:    "classes the requests waiting for an encoding slot are queued by, given as <name>:<max number of evaluations>:<weight> in increasing order of their max number of evaluations. The classes share the encoding slots in proportion to their weights. If empty, small (up to 2^16 evaluations, weight 4), medium (up to 2^20 evaluations, weight 2) and large (weight 1) classes are used",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SIZE_CLASSES"),
+	}
+	EnableEncodingQueueFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-encoding-queue"),
+		Usage:    "if true, the encoder also leases encoding jobs from the queue in the dynamodb table written by the controller. Only supported by encoder version 2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_ENCODING_QUEUE"),
+	}
+	DynamoDBTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dynamodb-table-name"),
+		Usage:    "Name of the dynamodb table holding the encoding queue. Required if the encoding queue is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DYNAMODB_TABLE_NAME"),
+	}
+	EncodingQueuePollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-queue-poll-interval"),
+		Usage:    "Interval at which the encoding queue is checked for jobs to lease",
+		Required: false,
+		Value:    time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_QUEUE_POLL_INTERVAL"),
+	}
+	EncodingJobLeaseDurationFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-job-lease-duration"),
+		Usage:    "Duration of the lease of an encoding job, after which the job is leased by another encoder if this encoder stopped extending it",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_JOB_LEASE_DURATION"),
+	}
+	EncodingJobMaxAttemptsFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-job-max-attempts"),
+		Usage:    "Number of times an encoding job may be leased before it's failed",
+		Required: false,
+		Value:    3,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_JOB_MAX_ATTEMPTS"),
+	}
+	EncodingJobRetryBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-job-retry-backoff"),
+		Usage:    "Duration an encoding job whose encoding failed waits before it's leased again, doubling with each attempt",
+		Required: false,
+		Value:    time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_JOB_RETRY_BACKOFF"),
+	}
 	PprofHttpPort = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "pprof-http-port"),
 		Usage:    "the http port which the pprof server is listening",
@@ -119,6 +181,15 @@ var optionalFlags = []cli.Flag{
 	PreventReencodingFlag,
 	PprofHttpPort,
 	EnablePprof,
+	EncodingCacheSizeBytesFlag,
+	FFTCacheDirFlag,
+	SizeClassesFlag,
+	EnableEncodingQueueFlag,
+	DynamoDBTableNameFlag,
+	EncodingQueuePollIntervalFlag,
+	EncodingJobLeaseDurationFlag,
+	EncodingJobMaxAttemptsFlag,
+	EncodingJobRetryBackoffFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/encoder/flags"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
@@ -80,6 +81,7 @@ func RunEncoderServer(ctx *cli.Context) error {
 		BackendType: backendType,
 		GPUEnable:   config.ServerConfig.GPUEnable,
 		NumWorker:   config.EncoderConfig.NumWorker,
+		FFTCacheDir: config.ServerConfig.FFTCacheDir,
 	}
 
 	if config.EncoderVersion == V2 {
@@ -90,6 +92,7 @@ func RunEncoderServer(ctx *cli.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to create encoder: %w", err)
 		}
+		prover.EnableMetrics(reg)
 
 		s3Client, err := s3.NewClient(context.Background(), config.AwsClientConfig, logger)
 		if err != nil {
@@ -117,6 +120,20 @@ func RunEncoderServer(ctx *cli.Context) error {
 			metrics,
 		)
 
+		if config.EnableEncodingQueue {
+			dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
+			if err != nil {
+				return err
+			}
+			blobMetadataStore := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.DynamoDBTableName)
+			worker, err := encoder.NewQueueWorkerV2(config.QueueWorkerConfig, blobMetadataStore, server, logger)
+			if err != nil {
+				return fmt.Errorf("failed to create encoding queue worker: %w", err)
+			}
+			worker.Start(context.Background())
+			logger.Info("Encoding queue", "table", config.DynamoDBTableName, "workerID", config.QueueWorkerConfig.WorkerID)
+		}
+
 		return server.Start()
 	}
 
@@ -125,6 +142,7 @@ func RunEncoderServer(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create encoder: %w", err)
 	}
+	prover.EnableMetrics(reg)
 
 	server := encoder.NewEncoderServer(*config.ServerConfig, logger, prover, metrics, grpcMetrics)
 
@@ -368,6 +368,26 @@ func (s *BlobMetadataStore) UpdateConfirmationBlockNumber(ctx context.Context, e
 	return err
 }
 
+// ClearConfirmationInfo resets the confirmation info of a blob whose confirmation was dropped, so that it isn't
+// mistaken for the confirmation of a later dispersal of the blob
+func (s *BlobMetadataStore) ClearConfirmationInfo(ctx context.Context, metadataKey disperser.BlobKey) error {
+	item, err := attributevalue.MarshalMap(&disperser.ConfirmationInfo{})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: metadataKey.BlobHash,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: metadataKey.MetadataHash,
+		},
+	}, item)
+
+	return err
+}
+
 func (s *BlobMetadataStore) UpdateBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, updated *disperser.BlobMetadata) error {
 	item, err := MarshalBlobMetadata(updated)
 	if err != nil {
@@ -275,6 +275,12 @@ func (s *SharedBlobStore) GetBulkBlobMetadata(ctx context.Context, blobKeys []di
 }
 
 func (s *SharedBlobStore) HandleBlobFailure(ctx context.Context, metadata *disperser.BlobMetadata, maxRetry uint) (bool, error) {
+	// The confirmation of a blob whose confirmation was reorged out no longer holds
+	if metadata.ConfirmationInfo != nil {
+		if err := s.blobMetadataStore.ClearConfirmationInfo(ctx, metadata.GetBlobKey()); err != nil {
+			return metadata.NumRetries < maxRetry, err
+		}
+	}
 	if metadata.NumRetries < maxRetry {
 		if err := s.MarkBlobProcessing(ctx, metadata.GetBlobKey()); err != nil {
 			return true, err
@@ -344,6 +344,12 @@ func (q *BlobStore) GetBulkBlobMetadata(ctx context.Context, blobKeys []disperse
 }
 
 func (q *BlobStore) HandleBlobFailure(ctx context.Context, metadata *disperser.BlobMetadata, maxRetry uint) (bool, error) {
+	// The confirmation of a blob whose confirmation was reorged out no longer holds
+	q.mu.Lock()
+	if stored, ok := q.Metadata[metadata.GetBlobKey()]; ok {
+		stored.ConfirmationInfo = nil
+	}
+	q.mu.Unlock()
 	if metadata.NumRetries < maxRetry {
 		if err := q.MarkBlobProcessing(ctx, metadata.GetBlobKey()); err != nil {
 			return true, err
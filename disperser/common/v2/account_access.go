@@ -0,0 +1,21 @@
+package v2
+
+// AccountAccessList is a list of accounts whose dispersals the disperser treats specially
+type AccountAccessList string
+
+const (
+	// Blocklist holds the accounts whose dispersals are rejected
+	Blocklist AccountAccessList = "Blocklist"
+	// Allowlist holds the accounts whose dispersals are accepted when the disperser only serves allowlisted accounts
+	Allowlist AccountAccessList = "Allowlist"
+)
+
+// AccountAccessEntry is an account in an AccountAccessList
+type AccountAccessEntry struct {
+	// AccountID is the checksummed hex address of the account
+	AccountID string
+	// Reason is why the account was added to the list, for reference of the disperser operators
+	Reason string
+	// UpdatedAt is the Unix timestamp in nanoseconds of when the account was added to the list
+	UpdatedAt uint64
+}
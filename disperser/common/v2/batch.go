@@ -0,0 +1,15 @@
+package v2
+
+import (
+	core "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// InProgressBatch is a batch that has been dispatched to the operators, but whose signatures haven't been handled
+// yet. It is persisted so that a restarted controller can resume gathering the signatures of the batch.
+type InProgressBatch struct {
+	BatchHeaderHash [32]byte
+	// BlobKeys are the keys of the blobs in the batch, in the order of the blob certificates of the batch
+	BlobKeys []core.BlobKey
+	// CreatedAt is the Unix timestamp in nanoseconds of when the batch was dispatched
+	CreatedAt uint64
+}
@@ -85,6 +85,8 @@ type BlobMetadata struct {
 	RequestedAt uint64
 	// UpdatedAt is the Unix timestamp of when the blob was last updated in _nanoseconds_
 	UpdatedAt uint64
+	// CallbackURL is the URL notified of the status transitions of the blob, if set by the client
+	CallbackURL string
 
 	*encoding.FragmentInfo
 }
@@ -1,11 +1,14 @@
 package blobstore
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -22,22 +25,61 @@ import (
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
 	StatusIndexName            = "StatusIndex"
 	OperatorDispersalIndexName = "OperatorDispersalIndex"
 	OperatorResponseIndexName  = "OperatorResponseIndex"
+	RequestedAtIndexName       = "RequestedAtIndex"
+	AttestedAtIndexName        = "AttestedAtIndex"
+	BatchHeaderHashIndexName   = "BatchHeaderHashIndex"
+	AccountBlobIndexName       = "AccountBlobIndex"
+	ReferenceBlockIndexName    = "ReferenceBlockIndex"
+	EncodingJobIndexName       = "EncodingJobIndex"
 
 	blobKeyPrefix             = "BlobKey#"
 	dispersalKeyPrefix        = "Dispersal#"
 	batchHeaderKeyPrefix      = "BatchHeader#"
+	stakeSnapshotKeyPrefix    = "StakeSnapshot#"
+	semverSnapshotKeyPrefix   = "SemverSnapshot#"
+	throughputRollupKeyPrefix = "ThroughputRollup#"
+	signingTalliesKeyPrefix   = "SigningTallies#"
+	blobContentKeyPrefix      = "BlobContent#"
+	inProgressBatchPK         = "InProgressBatch"
+	encodingJobKeyPrefix      = "EncodingJob#"
+	accountAccessKeyPrefix    = "AccountAccess#"
 	blobMetadataSK            = "BlobMetadata"
 	blobCertSK                = "BlobCertificate"
 	dispersalRequestSKPrefix  = "DispersalRequest#"
 	dispersalResponseSKPrefix = "DispersalResponse#"
 	batchHeaderSK             = "BatchHeader"
 	attestationSK             = "Attestation"
+	stakeSnapshotSK           = "StakeSnapshot"
+	semverSnapshotSK          = "SemverSnapshot"
+	throughputRollupSK        = "ThroughputRollup"
+	signingTalliesSK          = "SigningTallies"
+	blobContentSK             = "BlobContent"
+	encodingJobSK             = "EncodingJob"
+
+	// requestedAtBucketSizeNano is the width of a RequestedAtIndex partition in nanoseconds.
+	// Blobs are spread across hourly buckets so that a feed query over a recent window
+	// only touches a handful of partitions, while a single partition stays reasonably small.
+	requestedAtBucketSizeNano = uint64(time.Hour)
+	// attestedAtBucketSizeNano is the width of an AttestedAtIndex partition in nanoseconds.
+	attestedAtBucketSizeNano = uint64(time.Hour)
+	// attestationTiePageSize is the page size used to read the attestations made at the same time as the last one of
+	// a full attestation feed page
+	attestationTiePageSize = 16
+	// requestedAtQueryParallelism is the number of RequestedAtIndex buckets queried concurrently by a feed query
+	requestedAtQueryParallelism = 8
+	// backfillPageSize is the number of blobs listed at once by BackfillRequestedAtIndex
+	backfillPageSize = 100
+	// accountBlobPageSize is the number of blobs of an account evaluated at once when filtering them by status
+	accountBlobPageSize = 100
+	// maxAccountBlobsEvaluated bounds the number of blobs of an account evaluated by a single status-filtered query
+	maxAccountBlobsEvaluated = 2000
 )
 
 var (
@@ -56,6 +98,81 @@ type StatusIndexCursor struct {
 	UpdatedAt uint64
 }
 
+// BlobFeedCursor is a position in the blob feed, which orders all blobs by (RequestedAt, BlobKey).
+// A cursor with nil BlobKey sorts before every blob requested at the same RequestedAt.
+type BlobFeedCursor struct {
+	RequestedAt uint64
+	BlobKey     *corev2.BlobKey
+}
+
+// LessThan returns true if the cursor is strictly before the other cursor in the feed
+func (c *BlobFeedCursor) LessThan(other *BlobFeedCursor) bool {
+	return c.ToCursorKey() < other.ToCursorKey()
+}
+
+// ToCursorKey encodes the cursor into the sort key used by the RequestedAtIndex
+func (c *BlobFeedCursor) ToCursorKey() string {
+	if c.BlobKey == nil {
+		return fmt.Sprintf("%020d", c.RequestedAt)
+	}
+	return fmt.Sprintf("%020d#%s", c.RequestedAt, c.BlobKey.Hex())
+}
+
+// FromCursorKey decodes a cursor previously encoded with ToCursorKey
+func (c *BlobFeedCursor) FromCursorKey(encoded string) (*BlobFeedCursor, error) {
+	parts := strings.SplitN(encoded, "#", 2)
+	requestedAt, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requestedAt in cursor key %s: %w", encoded, err)
+	}
+	cursor := &BlobFeedCursor{
+		RequestedAt: requestedAt,
+	}
+	if len(parts) == 2 {
+		bk, err := corev2.HexToBlobKey(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob key in cursor key %s: %w", encoded, err)
+		}
+		cursor.BlobKey = &bk
+	}
+	return cursor, nil
+}
+
+// AttestationFeedCursor is a position in the feed of attestations, which is ordered by AttestedAt and then by batch
+// header hash, so that the batches attested at the same time are paged through in a stable order
+type AttestationFeedCursor struct {
+	AttestedAt      uint64
+	BatchHeaderHash *[32]byte
+}
+
+// ToCursorKey encodes the cursor into a string
+func (c *AttestationFeedCursor) ToCursorKey() string {
+	if c.BatchHeaderHash == nil {
+		return fmt.Sprintf("%020d", c.AttestedAt)
+	}
+	return fmt.Sprintf("%020d#%s", c.AttestedAt, hex.EncodeToString(c.BatchHeaderHash[:]))
+}
+
+// FromCursorKey decodes a cursor previously encoded with ToCursorKey
+func (c *AttestationFeedCursor) FromCursorKey(encoded string) (*AttestationFeedCursor, error) {
+	parts := strings.SplitN(encoded, "#", 2)
+	attestedAt, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestedAt in cursor key %s: %w", encoded, err)
+	}
+	cursor := &AttestationFeedCursor{
+		AttestedAt: attestedAt,
+	}
+	if len(parts) == 2 {
+		hash, err := hex.DecodeString(parts[1])
+		if err != nil || len(hash) != 32 {
+			return nil, fmt.Errorf("invalid batch header hash in cursor key %s", encoded)
+		}
+		cursor.BatchHeaderHash = (*[32]byte)(hash)
+	}
+	return cursor, nil
+}
+
 // BlobMetadataStore is a blob metadata storage backed by DynamoDB
 type BlobMetadataStore struct {
 	dynamoDBClient commondynamodb.Client
@@ -72,6 +189,11 @@ func NewBlobMetadataStore(dynamoDBClient commondynamodb.Client, logger logging.L
 	}
 }
 
+// CheckHealth checks that the metadata table is reachable
+func (s *BlobMetadataStore) CheckHealth(ctx context.Context) error {
+	return s.dynamoDBClient.TableExists(ctx, s.tableName)
+}
+
 func (s *BlobMetadataStore) PutBlobMetadata(ctx context.Context, blobMetadata *v2.BlobMetadata) error {
 	item, err := MarshalBlobMetadata(blobMetadata)
 	if err != nil {
@@ -129,6 +251,40 @@ func (s *BlobMetadataStore) UpdateBlobStatus(ctx context.Context, blobKey corev2
 	return err
 }
 
+// RequeueBlob moves a blob to the end of the queue of its status, so that it's picked up again by the component
+// processing blobs in that status, and increments its retry count. The update is conditioned on the blob not having
+// been updated since the given metadata was read, and fails with ErrInvalidStateTransition otherwise.
+func (s *BlobMetadataStore) RequeueBlob(ctx context.Context, metadata *v2.BlobMetadata) error {
+	blobKey, err := metadata.BlobHeader.BlobKey()
+	if err != nil {
+		return fmt.Errorf("failed to get blob key: %w", err)
+	}
+
+	condition := expression.Name("BlobStatus").Equal(expression.Value(int(metadata.BlobStatus))).
+		And(expression.Name("UpdatedAt").Equal(expression.Value(metadata.UpdatedAt)))
+	_, err = s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: blobKeyPrefix + blobKey.Hex(),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: blobMetadataSK,
+		},
+	}, map[string]types.AttributeValue{
+		"NumRetries": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(uint64(metadata.NumRetries+1), 10),
+		},
+		"UpdatedAt": &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(time.Now().UnixNano(), 10),
+		},
+	}, condition)
+
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: blob was updated since it was read", ErrInvalidStateTransition)
+	}
+
+	return err
+}
+
 func (s *BlobMetadataStore) DeleteBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) error {
 	err := s.dynamoDBClient.DeleteItem(ctx, s.tableName, map[string]types.AttributeValue{
 		"PK": &types.AttributeValueMemberS{
@@ -168,6 +324,37 @@ func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey corev2.
 	return metadata, nil
 }
 
+// GetBlobMetadataByKeys returns the metadata for the given blob keys. Keys without metadata are skipped.
+// Note: the returned metadata are NOT necessarily ordered by the order of the input blob keys
+func (s *BlobMetadataStore) GetBlobMetadataByKeys(ctx context.Context, blobKeys []corev2.BlobKey) ([]*v2.BlobMetadata, error) {
+	keys := make([]map[string]types.AttributeValue, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		keys[i] = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{
+				Value: blobKeyPrefix + blobKey.Hex(),
+			},
+			"SK": &types.AttributeValueMemberS{
+				Value: blobMetadataSK,
+			},
+		}
+	}
+
+	items, err := s.dynamoDBClient.GetItems(ctx, s.tableName, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make([]*v2.BlobMetadata, len(items))
+	for i, item := range items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return metadata, nil
+}
+
 // GetBlobMetadataByStatus returns all the metadata with the given status that were updated after lastUpdatedAt
 // Because this function scans the entire index, it should only be used for status with a limited number of items.
 // Results are ordered by UpdatedAt in ascending order.
@@ -298,6 +485,318 @@ func (s *BlobMetadataStore) GetBlobMetadataByStatusPaginated(
 	return metadata, &newCursor, nil
 }
 
+// GetBlobMetadataByRequestedAt returns the metadata of blobs requested in the exclusive range (start, end),
+// ordered by (RequestedAt, BlobKey) in ascending order.
+// At most limit results are returned; limit <= 0 means no limit.
+// It also returns the cursor of the last returned blob, which can be used as the start of the next page.
+// The returned cursor is nil if there are no results.
+// The hourly buckets of the range are queried requestedAtQueryParallelism at a time, each for its share of the
+// remaining limit. The buckets holding more than their share are then read further in order, so that a query reads
+// about as many blobs as it returns rather than the limit from every bucket.
+func (s *BlobMetadataStore) GetBlobMetadataByRequestedAt(
+	ctx context.Context,
+	start BlobFeedCursor,
+	end BlobFeedCursor,
+	limit int,
+) ([]*v2.BlobMetadata, *BlobFeedCursor, error) {
+	if !start.LessThan(&end) {
+		return nil, nil, errors.New("no time point in exclusive time range (start, end)")
+	}
+
+	startKey := start.ToCursorKey()
+	endKey := end.ToCursorKey()
+	startBucket := computeBucketID(start.RequestedAt, requestedAtBucketSizeNano)
+	endBucket := computeBucketID(end.RequestedAt, requestedAtBucketSizeNano)
+
+	result := make([]*v2.BlobMetadata, 0)
+	var lastCursor *BlobFeedCursor
+	for first := startBucket; first <= endBucket; first += requestedAtQueryParallelism {
+		last := min(first+requestedAtQueryParallelism-1, endBucket)
+		numBuckets := int(last - first + 1)
+		share := 0
+		if limit > 0 {
+			share = (limit - len(result) + numBuckets - 1) / numBuckets
+		}
+
+		pages := make([]*requestedAtBucketPage, numBuckets)
+		g, groupCtx := errgroup.WithContext(ctx)
+		for bucket := first; bucket <= last; bucket++ {
+			bucket := bucket
+			g.Go(func() error {
+				page, err := s.queryRequestedAtBucket(groupCtx, bucket, startKey, endKey, share, nil)
+				pages[bucket-first] = page
+				return err
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return nil, nil, err
+		}
+
+		for i, page := range pages {
+			bucket := first + uint64(i)
+			for {
+				for j, metadata := range page.metadata {
+					result = append(result, metadata)
+					lastCursor = page.cursors[j]
+					if limit > 0 && len(result) >= limit {
+						return result, lastCursor, nil
+					}
+				}
+				if page.lastEvaluatedKey == nil {
+					break
+				}
+				var err error
+				page, err = s.queryRequestedAtBucket(ctx, bucket, startKey, endKey, limit-len(result), page.lastEvaluatedKey)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	return result, lastCursor, nil
+}
+
+// requestedAtBucketPage is a page of the blobs of a RequestedAtIndex bucket, along with their feed cursors
+type requestedAtBucketPage struct {
+	metadata []*v2.BlobMetadata
+	cursors  []*BlobFeedCursor
+	// lastEvaluatedKey is where the next page of the bucket starts, nil once the bucket is exhausted
+	lastEvaluatedKey map[string]types.AttributeValue
+}
+
+// queryRequestedAtBucket returns a page of up to limit blobs of the bucket in the exclusive range
+// (startKey, endKey), in ascending order, starting after exclusiveStartKey. limit <= 0 means no limit, in which case
+// the whole bucket is read.
+func (s *BlobMetadataStore) queryRequestedAtBucket(
+	ctx context.Context,
+	bucket uint64,
+	startKey string,
+	endKey string,
+	limit int,
+	exclusiveStartKey map[string]types.AttributeValue,
+) (*requestedAtBucketPage, error) {
+	page := &requestedAtBucketPage{
+		metadata: make([]*v2.BlobMetadata, 0),
+		cursors:  make([]*BlobFeedCursor, 0),
+	}
+	for {
+		var pageLimit int32
+		if limit > 0 {
+			// Ask for one extra item since the range boundaries are inclusive in the query
+			pageLimit = int32(limit) + 1
+		}
+		res, err := s.dynamoDBClient.QueryIndexWithPagination(
+			ctx,
+			s.tableName,
+			RequestedAtIndexName,
+			"RequestedAtBucket = :bucket AND RequestedAtBlobKey BETWEEN :start AND :end",
+			commondynamodb.ExpressionValues{
+				":bucket": &types.AttributeValueMemberS{
+					Value: strconv.FormatUint(bucket, 10),
+				},
+				":start": &types.AttributeValueMemberS{
+					Value: startKey,
+				},
+				":end": &types.AttributeValueMemberS{
+					Value: endKey,
+				},
+			},
+			pageLimit,
+			exclusiveStartKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query blob feed in bucket %d: %w", bucket, err)
+		}
+
+		for _, item := range res.Items {
+			sortKey, ok := item["RequestedAtBlobKey"].(*types.AttributeValueMemberS)
+			if !ok || sortKey.Value == startKey || sortKey.Value == endKey {
+				continue
+			}
+			metadata, err := UnmarshalBlobMetadata(item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal blob metadata: %w", err)
+			}
+			cursor, err := (&BlobFeedCursor{}).FromCursorKey(sortKey.Value)
+			if err != nil {
+				return nil, err
+			}
+			page.metadata = append(page.metadata, metadata)
+			page.cursors = append(page.cursors, cursor)
+		}
+
+		exclusiveStartKey = res.LastEvaluatedKey
+		if limit > 0 || exclusiveStartKey == nil {
+			page.lastEvaluatedKey = exclusiveStartKey
+			return page, nil
+		}
+	}
+}
+
+// BackfillRequestedAtIndex sets the RequestedAtIndex keys of the blob metadata written before the index existed,
+// so that those blobs show up in the blob feed. The blobs are listed through the StatusIndex, and the ones that
+// already have the keys are left as is, so the backfill can be run again safely. Returns the number of blobs updated.
+//
+// The index itself must be added to existing tables beforehand, e.g. with
+// `aws dynamodb update-table --global-secondary-index-updates` creating RequestedAtIndex with the
+// RequestedAtBucket hash key and the RequestedAtBlobKey range key, as in GenerateTableSchema.
+func (s *BlobMetadataStore) BackfillRequestedAtIndex(ctx context.Context) (int, error) {
+	updated := 0
+	for status := v2.Queued; status <= v2.InsufficientSignatures; status++ {
+		var exclusiveStartKey map[string]types.AttributeValue
+		for {
+			res, err := s.dynamoDBClient.QueryIndexWithPagination(ctx, s.tableName, StatusIndexName, "BlobStatus = :status", commondynamodb.ExpressionValues{
+				":status": &types.AttributeValueMemberN{
+					Value: strconv.Itoa(int(status)),
+				},
+			}, backfillPageSize, exclusiveStartKey)
+			if err != nil {
+				return updated, fmt.Errorf("failed to query blobs in status %s: %w", status.String(), err)
+			}
+
+			for _, item := range res.Items {
+				if _, ok := item["RequestedAtBucket"]; ok {
+					continue
+				}
+				metadata, err := UnmarshalBlobMetadata(item)
+				if err != nil {
+					s.logger.Errorf("failed to unmarshal blob metadata: %v", err)
+					continue
+				}
+				blobKey, err := metadata.BlobHeader.BlobKey()
+				if err != nil {
+					return updated, err
+				}
+				feedCursor := &BlobFeedCursor{RequestedAt: metadata.RequestedAt, BlobKey: &blobKey}
+				_, err = s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{
+						Value: blobKeyPrefix + blobKey.Hex(),
+					},
+					"SK": &types.AttributeValueMemberS{
+						Value: blobMetadataSK,
+					},
+				}, map[string]types.AttributeValue{
+					"RequestedAtBucket": &types.AttributeValueMemberS{
+						Value: strconv.FormatUint(computeBucketID(metadata.RequestedAt, requestedAtBucketSizeNano), 10),
+					},
+					"RequestedAtBlobKey": &types.AttributeValueMemberS{
+						Value: feedCursor.ToCursorKey(),
+					},
+				}, expression.AttributeExists(expression.Name("PK")))
+				// The blob may have expired since it was listed
+				if errors.Is(err, commondynamodb.ErrConditionFailed) {
+					continue
+				}
+				if err != nil {
+					return updated, fmt.Errorf("failed to backfill blob %s: %w", blobKey.Hex(), err)
+				}
+				updated++
+			}
+
+			if res.LastEvaluatedKey == nil {
+				break
+			}
+			exclusiveStartKey = res.LastEvaluatedKey
+		}
+	}
+	return updated, nil
+}
+
+// GetBlobMetadataByAccountID returns the metadata of blobs dispersed by the given account, newest first.
+// Only blobs requested before the cursor (exclusive) are returned; a nil cursor starts from the newest blob.
+// If statuses are given, only blobs in one of those statuses are returned. They are filtered by DynamoDB,
+// reading accountBlobPageSize blobs at a time and at most maxAccountBlobsEvaluated blobs per call.
+// At most limit results are returned, along with the cursor to fetch the next page, which is nil when
+// there are no more blobs.
+func (s *BlobMetadataStore) GetBlobMetadataByAccountID(
+	ctx context.Context,
+	accountID string,
+	cursor *BlobFeedCursor,
+	limit int,
+	statuses ...v2.BlobStatus,
+) ([]*v2.BlobMetadata, *BlobFeedCursor, error) {
+	if limit <= 0 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+
+	keyCondition := "AccountID = :accountID"
+	expressionValues := commondynamodb.ExpressionValues{
+		":accountID": &types.AttributeValueMemberS{Value: accountID},
+	}
+	if cursor != nil {
+		keyCondition += " AND RequestedAtBlobKey < :cursor"
+		expressionValues[":cursor"] = &types.AttributeValueMemberS{Value: cursor.ToCursorKey()}
+	}
+	var filterExpression *string
+	if len(statuses) > 0 {
+		placeholders := make([]string, len(statuses))
+		for i, status := range statuses {
+			placeholders[i] = fmt.Sprintf(":status%d", i)
+			expressionValues[placeholders[i]] = &types.AttributeValueMemberN{Value: strconv.Itoa(int(status))}
+		}
+		filterExpression = aws.String(fmt.Sprintf("BlobStatus IN (%s)", strings.Join(placeholders, ", ")))
+	}
+
+	result := make([]*v2.BlobMetadata, 0, limit)
+	var exclusiveStartKey commondynamodb.Key
+	evaluated := 0
+	for {
+		// Without a filter every blob read is returned, so only as many as still needed are read
+		pageSize := int32(limit - len(result))
+		if filterExpression != nil {
+			pageSize = accountBlobPageSize
+		}
+		page, err := s.dynamoDBClient.QueryWithInputPagination(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(s.tableName),
+			IndexName:                 aws.String(AccountBlobIndexName),
+			KeyConditionExpression:    aws.String(keyCondition),
+			FilterExpression:          filterExpression,
+			ExpressionAttributeValues: expressionValues,
+			ExclusiveStartKey:         exclusiveStartKey,
+			ScanIndexForward:          aws.Bool(false),
+			Limit:                     aws.Int32(pageSize),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to query blobs of account %s: %w", accountID, err)
+		}
+		evaluated += int(pageSize)
+
+		for _, item := range page.Items {
+			metadata, err := UnmarshalBlobMetadata(item)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal blob metadata: %w", err)
+			}
+			blobKey, err := metadata.BlobHeader.BlobKey()
+			if err != nil {
+				return nil, nil, err
+			}
+			result = append(result, metadata)
+			if len(result) == limit {
+				return result, &BlobFeedCursor{RequestedAt: metadata.RequestedAt, BlobKey: &blobKey}, nil
+			}
+		}
+
+		// No pagination token means the account has no more blobs
+		if len(page.LastEvaluatedKey) == 0 {
+			return result, nil, nil
+		}
+		if evaluated >= maxAccountBlobsEvaluated {
+			// The filtered out blobs up to the last one evaluated don't have to be read again
+			sortKey, ok := page.LastEvaluatedKey["RequestedAtBlobKey"].(*types.AttributeValueMemberS)
+			if !ok {
+				return nil, nil, errors.New("pagination token has no RequestedAtBlobKey")
+			}
+			next, err := new(BlobFeedCursor).FromCursorKey(sortKey.Value)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, next, nil
+		}
+		exclusiveStartKey = page.LastEvaluatedKey
+	}
+}
+
 // GetBlobMetadataCountByStatus returns the count of all the metadata with the given status
 // Because this function scans the entire index, it should only be used for status with a limited number of items.
 func (s *BlobMetadataStore) GetBlobMetadataCountByStatus(ctx context.Context, status v2.BlobStatus) (int32, error) {
@@ -400,6 +899,52 @@ func (s *BlobMetadataStore) GetBlobCertificates(ctx context.Context, blobKeys []
 	return certs, fragmentInfos, nil
 }
 
+// PutBlobContentKey records the key of the blob the account most recently dispersed with the given content hash,
+// so that dispersing the same content again can be deduplicated. It replaces any previously recorded blob key.
+func (s *BlobMetadataStore) PutBlobContentKey(ctx context.Context, accountID string, contentHash [32]byte, blobKey corev2.BlobKey) error {
+	return s.dynamoDBClient.PutItem(ctx, s.tableName, commondynamodb.Item{
+		"PK": &types.AttributeValueMemberS{
+			Value: blobContentPK(accountID, contentHash),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: blobContentSK,
+		},
+		"BlobKey": &types.AttributeValueMemberS{
+			Value: blobKey.Hex(),
+		},
+	})
+}
+
+// GetBlobContentKey returns the key of the blob the account most recently dispersed with the given content hash.
+// It returns common.ErrMetadataNotFound if the account has not dispersed a blob with the content hash.
+func (s *BlobMetadataStore) GetBlobContentKey(ctx context.Context, accountID string, contentHash [32]byte) (corev2.BlobKey, error) {
+	item, err := s.dynamoDBClient.GetItem(ctx, s.tableName, map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: blobContentPK(accountID, contentHash),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: blobContentSK,
+		},
+	})
+	if err != nil {
+		return corev2.BlobKey{}, err
+	}
+
+	if item == nil {
+		return corev2.BlobKey{}, fmt.Errorf("%w: no blob found for content hash %s", common.ErrMetadataNotFound, hex.EncodeToString(contentHash[:]))
+	}
+
+	blobKey, ok := item["BlobKey"].(*types.AttributeValueMemberS)
+	if !ok {
+		return corev2.BlobKey{}, fmt.Errorf("expected *types.AttributeValueMemberS for BlobKey, got %T", item["BlobKey"])
+	}
+	return corev2.HexToBlobKey(blobKey.Value)
+}
+
+func blobContentPK(accountID string, contentHash [32]byte) string {
+	return blobContentKeyPrefix + accountID + "#" + hex.EncodeToString(contentHash[:])
+}
+
 func (s *BlobMetadataStore) PutDispersalRequest(ctx context.Context, req *corev2.DispersalRequest) error {
 	item, err := MarshalDispersalRequest(req)
 	if err != nil {
@@ -573,6 +1118,157 @@ func (s *BlobMetadataStore) GetAttestation(ctx context.Context, batchHeaderHash
 	return attestation, nil
 }
 
+// GetAttestationByAttestedAt returns the attestations made in the exclusive time range (start, end),
+// ordered by AttestedAt and then by batch header hash in ascending order.
+// At most limit results are returned; limit <= 0 means no limit.
+func (s *BlobMetadataStore) GetAttestationByAttestedAt(
+	ctx context.Context,
+	start uint64,
+	end uint64,
+	limit int,
+) ([]*corev2.Attestation, error) {
+	attestations, _, err := s.GetAttestationFeed(ctx, AttestationFeedCursor{AttestedAt: start}, end, limit)
+	return attestations, err
+}
+
+// GetAttestationFeed returns the attestations after the start cursor (exclusive) and made before end (exclusive),
+// ordered by AttestedAt and then by batch header hash in ascending order. A start cursor without batch header hash
+// excludes all the attestations made at its time.
+// At most limit results are returned; limit <= 0 means no limit.
+// It also returns the cursor of the last returned attestation, which is nil if there are no results.
+func (s *BlobMetadataStore) GetAttestationFeed(
+	ctx context.Context,
+	start AttestationFeedCursor,
+	end uint64,
+	limit int,
+) ([]*corev2.Attestation, *AttestationFeedCursor, error) {
+	low := start.AttestedAt + 1
+	if start.BatchHeaderHash != nil {
+		low = start.AttestedAt
+	}
+	if low >= end {
+		return nil, nil, errors.New("no time point in exclusive time range (start, end)")
+	}
+
+	items := make([]attestationFeedItem, 0)
+	// boundary is the AttestedAt of the attestation the limit was reached at. The attestations made at the same time
+	// are read as well, so that the page ends at the lowest batch header hashes of that time.
+	var boundary *uint64
+
+	startBucket := computeBucketID(low, attestedAtBucketSizeNano)
+	endBucket := computeBucketID(end, attestedAtBucketSizeNano)
+	for bucket := startBucket; bucket <= endBucket; bucket++ {
+		var exclusiveStartKey map[string]types.AttributeValue
+		for {
+			var pageLimit int32
+			if limit > 0 {
+				pageLimit = int32(max(limit-len(items), attestationTiePageSize))
+			}
+			res, err := s.dynamoDBClient.QueryIndexWithPagination(
+				ctx,
+				s.tableName,
+				AttestedAtIndexName,
+				"AttestedAtBucket = :bucket AND AttestedAt BETWEEN :start AND :end",
+				commondynamodb.ExpressionValues{
+					":bucket": &types.AttributeValueMemberS{
+						Value: strconv.FormatUint(bucket, 10),
+					},
+					":start": &types.AttributeValueMemberN{
+						Value: strconv.FormatUint(low, 10),
+					},
+					":end": &types.AttributeValueMemberN{
+						Value: strconv.FormatUint(end-1, 10),
+					},
+				},
+				pageLimit,
+				exclusiveStartKey,
+			)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to query attestations in bucket %d: %w", bucket, err)
+			}
+
+			for _, item := range res.Items {
+				attestation, err := UnmarshalAttestation(item)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to unmarshal attestation: %w", err)
+				}
+				if boundary != nil && attestation.AttestedAt > *boundary {
+					return sortAttestationFeed(items, limit)
+				}
+				hash, err := attestation.BatchHeader.Hash()
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to compute batch header hash: %w", err)
+				}
+				if start.BatchHeaderHash != nil && attestation.AttestedAt == start.AttestedAt &&
+					bytes.Compare(hash[:], start.BatchHeaderHash[:]) <= 0 {
+					continue
+				}
+				items = append(items, attestationFeedItem{attestation: attestation, hash: hash})
+				if limit > 0 && boundary == nil && len(items) >= limit {
+					boundary = &attestation.AttestedAt
+				}
+			}
+
+			if res.LastEvaluatedKey == nil {
+				break
+			}
+			exclusiveStartKey = res.LastEvaluatedKey
+		}
+	}
+
+	return sortAttestationFeed(items, limit)
+}
+
+type attestationFeedItem struct {
+	attestation *corev2.Attestation
+	hash        [32]byte
+}
+
+// sortAttestationFeed orders the attestations by AttestedAt and then by batch header hash, and returns the first limit
+// of them along with the cursor of the last one
+func sortAttestationFeed(items []attestationFeedItem, limit int) ([]*corev2.Attestation, *AttestationFeedCursor, error) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].attestation.AttestedAt != items[j].attestation.AttestedAt {
+			return items[i].attestation.AttestedAt < items[j].attestation.AttestedAt
+		}
+		return bytes.Compare(items[i].hash[:], items[j].hash[:]) < 0
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	attestations := make([]*corev2.Attestation, len(items))
+	for i, item := range items {
+		attestations[i] = item.attestation
+	}
+	if len(items) == 0 {
+		return attestations, nil, nil
+	}
+	last := items[len(items)-1]
+	return attestations, &AttestationFeedCursor{AttestedAt: last.attestation.AttestedAt, BatchHeaderHash: &last.hash}, nil
+}
+
+// GetAttestationsByReferenceBlock returns the attestations of the batches anchored at the given reference block,
+// ordered by AttestedAt in ascending order.
+func (s *BlobMetadataStore) GetAttestationsByReferenceBlock(ctx context.Context, referenceBlockNumber uint64) ([]*corev2.Attestation, error) {
+	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, ReferenceBlockIndexName, "AttestedReferenceBlock = :block", commondynamodb.ExpressionValues{
+		":block": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(referenceBlockNumber, 10),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attestations at reference block %d: %w", referenceBlockNumber, err)
+	}
+
+	attestations := make([]*corev2.Attestation, len(items))
+	for i, item := range items {
+		attestations[i], err = UnmarshalAttestation(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal attestation: %w", err)
+		}
+	}
+	return attestations, nil
+}
+
 func (s *BlobMetadataStore) PutBlobVerificationInfo(ctx context.Context, verificationInfo *corev2.BlobVerificationInfo) error {
 	item, err := MarshalBlobVerificationInfo(verificationInfo)
 	if err != nil {
@@ -674,6 +1370,37 @@ func (s *BlobMetadataStore) GetBlobVerificationInfos(ctx context.Context, blobKe
 	return responses, nil
 }
 
+// GetBlobVerificationInfosByBatchHeaderHash returns the verification infos of all blobs in the batch
+func (s *BlobMetadataStore) GetBlobVerificationInfosByBatchHeaderHash(ctx context.Context, batchHeaderHash [32]byte) ([]*corev2.BlobVerificationInfo, error) {
+	responses := make([]*corev2.BlobVerificationInfo, 0)
+	var exclusiveStartKey map[string]types.AttributeValue
+	for {
+		res, err := s.dynamoDBClient.QueryIndexWithPagination(ctx, s.tableName, BatchHeaderHashIndexName, "BatchHeaderHash = :bhh", commondynamodb.ExpressionValues{
+			":bhh": &types.AttributeValueMemberS{
+				Value: hex.EncodeToString(batchHeaderHash[:]),
+			},
+		}, 0, exclusiveStartKey)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range res.Items {
+			info, err := UnmarshalBlobVerificationInfo(item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal verification info: %w", err)
+			}
+			responses = append(responses, info)
+		}
+
+		if res.LastEvaluatedKey == nil {
+			break
+		}
+		exclusiveStartKey = res.LastEvaluatedKey
+	}
+
+	return responses, nil
+}
+
 func (s *BlobMetadataStore) GetSignedBatch(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, *corev2.Attestation, error) {
 	items, err := s.dynamoDBClient.Query(ctx, s.tableName, "PK = :pk", commondynamodb.ExpressionValues{
 		":pk": &types.AttributeValueMemberS{
@@ -716,26 +1443,539 @@ func (s *BlobMetadataStore) GetSignedBatch(ctx context.Context, batchHeaderHash
 	return header, attestation, nil
 }
 
-func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
-	return &dynamodb.CreateTableInput{
-		AttributeDefinitions: []types.AttributeDefinition{
-			// PK is the composite partition key
-			{
-				AttributeName: aws.String("PK"),
-				AttributeType: types.ScalarAttributeTypeS,
-			},
-			// SK is the composite sort key
-			{
-				AttributeName: aws.String("SK"),
-				AttributeType: types.ScalarAttributeTypeS,
-			},
-			{
-				AttributeName: aws.String("BlobStatus"),
-				AttributeType: types.ScalarAttributeTypeN,
-			},
-			{
-				AttributeName: aws.String("UpdatedAt"),
-				AttributeType: types.ScalarAttributeTypeN,
+// PutInProgressBatch records that the batch has been dispatched, until DeleteInProgressBatch is called once its
+// signatures are handled.
+func (s *BlobMetadataStore) PutInProgressBatch(ctx context.Context, batch *v2.InProgressBatch) error {
+	item, err := MarshalInProgressBatch(batch)
+	if err != nil {
+		return err
+	}
+
+	err = s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(PK) AND attribute_not_exists(SK)", nil, nil)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return common.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// GetInProgressBatches returns the batches that have been dispatched but whose signatures haven't been handled,
+// ordered by CreatedAt in ascending order.
+func (s *BlobMetadataStore) GetInProgressBatches(ctx context.Context) ([]*v2.InProgressBatch, error) {
+	items, err := s.dynamoDBClient.Query(ctx, s.tableName, "PK = :pk", commondynamodb.ExpressionValues{
+		":pk": &types.AttributeValueMemberS{
+			Value: inProgressBatchPK,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]*v2.InProgressBatch, len(items))
+	for i, item := range items {
+		batches[i], err = UnmarshalInProgressBatch(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].CreatedAt < batches[j].CreatedAt
+	})
+
+	return batches, nil
+}
+
+func (s *BlobMetadataStore) DeleteInProgressBatch(ctx context.Context, batchHeaderHash [32]byte) error {
+	err := s.dynamoDBClient.DeleteItem(ctx, s.tableName, map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: inProgressBatchPK,
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: hex.EncodeToString(batchHeaderHash[:]),
+		},
+	})
+
+	return err
+}
+
+// PutEncodingJob adds the job to the encoding queue. It fails with ErrAlreadyExists if a job for the blob is already
+// in the queue.
+func (s *BlobMetadataStore) PutEncodingJob(ctx context.Context, job *v2.EncodingJob) error {
+	item, err := MarshalEncodingJob(job)
+	if err != nil {
+		return err
+	}
+
+	err = s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(PK) AND attribute_not_exists(SK)", nil, nil)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return common.ErrAlreadyExists
+	}
+
+	return err
+}
+
+func (s *BlobMetadataStore) GetEncodingJob(ctx context.Context, blobKey corev2.BlobKey) (*v2.EncodingJob, error) {
+	item, err := s.dynamoDBClient.GetItem(ctx, s.tableName, encodingJobKey(blobKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil {
+		return nil, fmt.Errorf("%w: encoding job not found for blob key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+
+	return UnmarshalEncodingJob(item)
+}
+
+// GetLeasableEncodingJobs returns up to limit jobs of the shard of the encoding queue that may be leased now, i.e.
+// queued jobs whose next attempt is due and leased jobs whose lease expired, ordered by the time they became leasable.
+// Only the pending jobs of the shard are indexed, so the finished jobs and the jobs that aren't leasable yet aren't read.
+func (s *BlobMetadataStore) GetLeasableEncodingJobs(ctx context.Context, shard uint32, limit int32) ([]*v2.EncodingJob, error) {
+	res, err := s.dynamoDBClient.QueryIndexWithPagination(ctx, s.tableName, EncodingJobIndexName, "EncodingJobQueue = :queue AND LeasableAt < :now", commondynamodb.ExpressionValues{
+		":queue": &types.AttributeValueMemberS{
+			Value: encodingJobQueue(shard, true),
+		},
+		":now": &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(time.Now().UnixNano(), 10),
+		},
+	}, limit, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*v2.EncodingJob, len(res.Items))
+	for i, item := range res.Items {
+		jobs[i], err = UnmarshalEncodingJob(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jobs, nil
+}
+
+// LeaseEncodingJob leases the job to the owner until leaseExpiry, and increments its number of attempts. The job must
+// be queued with its next attempt due or have an expired lease, and must not have been updated since it was read.
+// Otherwise, e.g. because another encoder leased it first, LeaseEncodingJob fails with ErrInvalidStateTransition.
+func (s *BlobMetadataStore) LeaseEncodingJob(ctx context.Context, job *v2.EncodingJob, owner string, leaseExpiry uint64) (*v2.EncodingJob, error) {
+	now := uint64(time.Now().UnixNano())
+	pending := expression.Name("JobStatus").In(expression.Value(int(v2.EncodingJobQueued)), expression.Value(int(v2.EncodingJobLeased)))
+	condition := expression.Name("UpdatedAt").Equal(expression.Value(job.UpdatedAt)).
+		And(pending).
+		And(expression.Name("LeasableAt").LessThan(expression.Value(now)))
+
+	leased := *job
+	leased.JobStatus = v2.EncodingJobLeased
+	leased.LeaseOwner = owner
+	leased.LeaseExpiry = leaseExpiry
+	leased.LeasableAt = leaseExpiry
+	leased.NumAttempts++
+	leased.UpdatedAt = now
+	_, err := s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, encodingJobKey(job.BlobKey), map[string]types.AttributeValue{
+		"JobStatus": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(int(leased.JobStatus)),
+		},
+		"LeaseOwner": &types.AttributeValueMemberS{
+			Value: owner,
+		},
+		"LeaseExpiry": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(leaseExpiry, 10),
+		},
+		"LeasableAt": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(leaseExpiry, 10),
+		},
+		"NumAttempts": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(uint64(leased.NumAttempts), 10),
+		},
+		"UpdatedAt": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(now, 10),
+		},
+	}, condition)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return nil, fmt.Errorf("%w: encoding job was updated or leased since it was read", ErrInvalidStateTransition)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &leased, nil
+}
+
+// ExtendEncodingJobLease extends the lease of the job held by the owner until leaseExpiry. It fails with
+// ErrInvalidStateTransition if the owner no longer holds the lease.
+func (s *BlobMetadataStore) ExtendEncodingJobLease(ctx context.Context, blobKey corev2.BlobKey, owner string, leaseExpiry uint64) error {
+	_, err := s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, encodingJobKey(blobKey), map[string]types.AttributeValue{
+		"LeaseExpiry": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(leaseExpiry, 10),
+		},
+		"LeasableAt": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(leaseExpiry, 10),
+		},
+		"UpdatedAt": &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(time.Now().UnixNano(), 10),
+		},
+	}, leaseHeldBy(owner))
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: encoding job lease is not held by %s", ErrInvalidStateTransition, owner)
+	}
+
+	return err
+}
+
+// CompleteEncodingJob publishes the result of the job leased by the owner. It fails with ErrInvalidStateTransition if
+// the owner no longer holds the lease.
+func (s *BlobMetadataStore) CompleteEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, fragmentInfo *encoding.FragmentInfo) error {
+	fragmentInfoAttr, err := attributevalue.Marshal(fragmentInfo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fragment info: %w", err)
+	}
+
+	return s.updateLeasedEncodingJob(ctx, blobKey, owner, map[string]types.AttributeValue{
+		"JobStatus": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(int(v2.EncodingJobCompleted)),
+		},
+		"EncodingJobQueue": &types.AttributeValueMemberS{
+			Value: encodingJobQueue(v2.EncodingJobShard(blobKey), false),
+		},
+		"FragmentInfo": fragmentInfoAttr,
+	})
+}
+
+// FailEncodingJob records that the job leased by the owner failed. It fails with ErrInvalidStateTransition if the
+// owner no longer holds the lease.
+func (s *BlobMetadataStore) FailEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, reason string) error {
+	return s.updateLeasedEncodingJob(ctx, blobKey, owner, map[string]types.AttributeValue{
+		"JobStatus": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(int(v2.EncodingJobFailed)),
+		},
+		"EncodingJobQueue": &types.AttributeValueMemberS{
+			Value: encodingJobQueue(v2.EncodingJobShard(blobKey), false),
+		},
+		"Error": &types.AttributeValueMemberS{
+			Value: reason,
+		},
+	})
+}
+
+// RetryEncodingJob returns the job leased by the owner to the queue after a failed attempt, so that it's leased again
+// once retryAt passes. It fails with ErrInvalidStateTransition if the owner no longer holds the lease.
+func (s *BlobMetadataStore) RetryEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, reason string, retryAt uint64) error {
+	return s.updateLeasedEncodingJob(ctx, blobKey, owner, map[string]types.AttributeValue{
+		"JobStatus": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(int(v2.EncodingJobQueued)),
+		},
+		"LeasableAt": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(retryAt, 10),
+		},
+		"Error": &types.AttributeValueMemberS{
+			Value: reason,
+		},
+	})
+}
+
+func (s *BlobMetadataStore) updateLeasedEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, item commondynamodb.Item) error {
+	item["UpdatedAt"] = &types.AttributeValueMemberN{
+		Value: strconv.FormatInt(time.Now().UnixNano(), 10),
+	}
+	_, err := s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, encodingJobKey(blobKey), item, leaseHeldBy(owner))
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: encoding job lease is not held by %s", ErrInvalidStateTransition, owner)
+	}
+
+	return err
+}
+
+// DeleteEncodingJob removes the job from the encoding queue. Deleting a job that isn't in the queue is a no-op.
+func (s *BlobMetadataStore) DeleteEncodingJob(ctx context.Context, blobKey corev2.BlobKey) error {
+	return s.dynamoDBClient.DeleteItem(ctx, s.tableName, encodingJobKey(blobKey))
+}
+
+// encodingJobKey returns the key of the job of the blob. Each job is in its own partition, so that writing the jobs
+// doesn't load a single partition.
+func encodingJobKey(blobKey corev2.BlobKey) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: encodingJobKeyPrefix + blobKey.Hex(),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: encodingJobSK,
+		},
+	}
+}
+
+// encodingJobQueue returns the EncodingJobIndex partition of the pending or finished jobs of the shard
+func encodingJobQueue(shard uint32, pending bool) string {
+	status := "Finished"
+	if pending {
+		status = "Pending"
+	}
+	return fmt.Sprintf("%s%s#%d", encodingJobKeyPrefix, status, shard)
+}
+
+func leaseHeldBy(owner string) expression.ConditionBuilder {
+	return expression.Name("JobStatus").Equal(expression.Value(int(v2.EncodingJobLeased))).
+		And(expression.Name("LeaseOwner").Equal(expression.Value(owner)))
+}
+
+// PutAccountAccessEntry adds the account to the list, replacing the existing entry of the account if any.
+func (s *BlobMetadataStore) PutAccountAccessEntry(ctx context.Context, list v2.AccountAccessList, entry *v2.AccountAccessEntry) error {
+	item, err := MarshalAccountAccessEntry(list, entry)
+	if err != nil {
+		return err
+	}
+
+	return s.dynamoDBClient.PutItem(ctx, s.tableName, item)
+}
+
+// GetAccountAccessEntries returns the accounts in the list, ordered by account ID.
+func (s *BlobMetadataStore) GetAccountAccessEntries(ctx context.Context, list v2.AccountAccessList) ([]*v2.AccountAccessEntry, error) {
+	items, err := s.dynamoDBClient.Query(ctx, s.tableName, "PK = :pk", commondynamodb.ExpressionValues{
+		":pk": &types.AttributeValueMemberS{
+			Value: accountAccessKeyPrefix + string(list),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*v2.AccountAccessEntry, len(items))
+	for i, item := range items {
+		entries[i], err = UnmarshalAccountAccessEntry(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// DeleteAccountAccessEntry removes the account from the list. Removing an account that isn't in the list is a no-op.
+func (s *BlobMetadataStore) DeleteAccountAccessEntry(ctx context.Context, list v2.AccountAccessList, accountID string) error {
+	err := s.dynamoDBClient.DeleteItem(ctx, s.tableName, map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: accountAccessKeyPrefix + string(list),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: accountID,
+		},
+	})
+
+	return err
+}
+
+func (s *BlobMetadataStore) PutStakeSnapshot(ctx context.Context, snapshot *v2.StakeSnapshot) error {
+	item, err := MarshalStakeSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(PK) AND attribute_not_exists(SK)", nil, nil)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return common.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// GetStakeSnapshots returns the stake snapshots taken for the given timestamps, ordered by timestamp in ascending order.
+// Timestamps without a snapshot are skipped.
+func (s *BlobMetadataStore) GetStakeSnapshots(ctx context.Context, timestamps []uint64) ([]*v2.StakeSnapshot, error) {
+	keys := make([]map[string]types.AttributeValue, len(timestamps))
+	for i, timestamp := range timestamps {
+		keys[i] = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{
+				Value: stakeSnapshotKeyPrefix + strconv.FormatUint(timestamp, 10),
+			},
+			"SK": &types.AttributeValueMemberS{
+				Value: stakeSnapshotSK,
+			},
+		}
+	}
+
+	items, err := s.dynamoDBClient.GetItems(ctx, s.tableName, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*v2.StakeSnapshot, len(items))
+	for i, item := range items {
+		snapshots[i], err = UnmarshalStakeSnapshot(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Timestamp < snapshots[j].Timestamp
+	})
+
+	return snapshots, nil
+}
+
+func (s *BlobMetadataStore) PutSemverSnapshot(ctx context.Context, snapshot *v2.SemverSnapshot) error {
+	item, err := MarshalSemverSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(PK) AND attribute_not_exists(SK)", nil, nil)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return common.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// GetSemverSnapshots returns the semver snapshots taken for the given timestamps, ordered by timestamp in ascending order.
+// Timestamps without a snapshot are skipped.
+func (s *BlobMetadataStore) GetSemverSnapshots(ctx context.Context, timestamps []uint64) ([]*v2.SemverSnapshot, error) {
+	keys := make([]map[string]types.AttributeValue, len(timestamps))
+	for i, timestamp := range timestamps {
+		keys[i] = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{
+				Value: semverSnapshotKeyPrefix + strconv.FormatUint(timestamp, 10),
+			},
+			"SK": &types.AttributeValueMemberS{
+				Value: semverSnapshotSK,
+			},
+		}
+	}
+
+	items, err := s.dynamoDBClient.GetItems(ctx, s.tableName, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*v2.SemverSnapshot, len(items))
+	for i, item := range items {
+		snapshots[i], err = UnmarshalSemverSnapshot(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Timestamp < snapshots[j].Timestamp
+	})
+
+	return snapshots, nil
+}
+
+func (s *BlobMetadataStore) PutThroughputRollup(ctx context.Context, rollup *v2.ThroughputRollup) error {
+	item, err := MarshalThroughputRollup(rollup)
+	if err != nil {
+		return err
+	}
+
+	err = s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(PK) AND attribute_not_exists(SK)", nil, nil)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return common.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// GetThroughputRollups returns the rollups of the given period length starting at the given timestamps, ordered by
+// timestamp in ascending order. Timestamps without a rollup are skipped.
+func (s *BlobMetadataStore) GetThroughputRollups(ctx context.Context, period v2.RollupPeriod, timestamps []uint64) ([]*v2.ThroughputRollup, error) {
+	keys := make([]map[string]types.AttributeValue, len(timestamps))
+	for i, timestamp := range timestamps {
+		keys[i] = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{
+				Value: throughputRollupPK(period, timestamp),
+			},
+			"SK": &types.AttributeValueMemberS{
+				Value: throughputRollupSK,
+			},
+		}
+	}
+
+	items, err := s.dynamoDBClient.GetItems(ctx, s.tableName, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	rollups := make([]*v2.ThroughputRollup, len(items))
+	for i, item := range items {
+		rollups[i], err = UnmarshalThroughputRollup(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(rollups, func(i, j int) bool {
+		return rollups[i].Timestamp < rollups[j].Timestamp
+	})
+
+	return rollups, nil
+}
+
+// PutSigningTallies stores the signing tallies of an hour, unless they were already stored
+func (s *BlobMetadataStore) PutSigningTallies(ctx context.Context, tallies *v2.SigningTallies) error {
+	item, err := MarshalSigningTallies(tallies)
+	if err != nil {
+		return err
+	}
+
+	err = s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(PK) AND attribute_not_exists(SK)", nil, nil)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return common.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// GetSigningTallies returns the signing tallies of the hours starting at the given timestamps, ordered by timestamp
+// in ascending order. Hours without tallies are skipped.
+func (s *BlobMetadataStore) GetSigningTallies(ctx context.Context, timestamps []uint64) ([]*v2.SigningTallies, error) {
+	keys := make([]map[string]types.AttributeValue, len(timestamps))
+	for i, timestamp := range timestamps {
+		keys[i] = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{
+				Value: signingTalliesKeyPrefix + strconv.FormatUint(timestamp, 10),
+			},
+			"SK": &types.AttributeValueMemberS{
+				Value: signingTalliesSK,
+			},
+		}
+	}
+
+	items, err := s.dynamoDBClient.GetItems(ctx, s.tableName, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	tallies := make([]*v2.SigningTallies, len(items))
+	for i, item := range items {
+		tallies[i], err = UnmarshalSigningTallies(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(tallies, func(i, j int) bool {
+		return tallies[i].Timestamp < tallies[j].Timestamp
+	})
+
+	return tallies, nil
+}
+
+func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			// PK is the composite partition key
+			{
+				AttributeName: aws.String("PK"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			// SK is the composite sort key
+			{
+				AttributeName: aws.String("SK"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("BlobStatus"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+			{
+				AttributeName: aws.String("UpdatedAt"),
+				AttributeType: types.ScalarAttributeTypeN,
 			},
 			{
 				AttributeName: aws.String("OperatorID"),
@@ -749,6 +1989,42 @@ func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacit
 				AttributeName: aws.String("RespondedAt"),
 				AttributeType: types.ScalarAttributeTypeN,
 			},
+			{
+				AttributeName: aws.String("RequestedAtBucket"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("RequestedAtBlobKey"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("AttestedAtBucket"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("AttestedAt"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+			{
+				AttributeName: aws.String("BatchHeaderHash"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("AccountID"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("AttestedReferenceBlock"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+			{
+				AttributeName: aws.String("EncodingJobQueue"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("LeasableAt"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
 		},
 		KeySchema: []types.KeySchemaElement{
 			{
@@ -822,6 +2098,126 @@ func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacit
 					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
 				},
 			},
+			{
+				IndexName: aws.String(RequestedAtIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("RequestedAtBucket"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("RequestedAtBlobKey"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
+			{
+				IndexName: aws.String(AttestedAtIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("AttestedAtBucket"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("AttestedAt"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
+			{
+				IndexName: aws.String(BatchHeaderHashIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("BatchHeaderHash"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("PK"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
+			{
+				IndexName: aws.String(AccountBlobIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("AccountID"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("RequestedAtBlobKey"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
+			{
+				IndexName: aws.String(ReferenceBlockIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("AttestedReferenceBlock"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("AttestedAt"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
+			{
+				IndexName: aws.String(EncodingJobIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("EncodingJobQueue"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("LeasableAt"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
 		},
 		ProvisionedThroughput: &types.ProvisionedThroughput{
 			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
@@ -843,6 +2239,13 @@ func MarshalBlobMetadata(metadata *v2.BlobMetadata) (commondynamodb.Item, error)
 	}
 	fields["PK"] = &types.AttributeValueMemberS{Value: blobKeyPrefix + blobKey.Hex()}
 	fields["SK"] = &types.AttributeValueMemberS{Value: blobMetadataSK}
+	fields["RequestedAtBucket"] = &types.AttributeValueMemberS{Value: strconv.FormatUint(computeBucketID(metadata.RequestedAt, requestedAtBucketSizeNano), 10)}
+	feedCursor := &BlobFeedCursor{RequestedAt: metadata.RequestedAt, BlobKey: &blobKey}
+	fields["RequestedAtBlobKey"] = &types.AttributeValueMemberS{Value: feedCursor.ToCursorKey()}
+	// Index keys must not be empty, so blobs without an account are left out of AccountBlobIndex
+	if metadata.BlobHeader.PaymentMetadata.AccountID != "" {
+		fields["AccountID"] = &types.AttributeValueMemberS{Value: metadata.BlobHeader.PaymentMetadata.AccountID}
+	}
 
 	return fields, nil
 }
@@ -1057,6 +2460,8 @@ func MarshalBlobVerificationInfo(verificationInfo *corev2.BlobVerificationInfo)
 
 	fields["PK"] = &types.AttributeValueMemberS{Value: blobKeyPrefix + verificationInfo.BlobKey.Hex()}
 	fields["SK"] = &types.AttributeValueMemberS{Value: batchHeaderKeyPrefix + hashstr}
+	// Only verification infos carry BatchHeaderHash, which keeps BatchHeaderHashIndex sparse
+	fields["BatchHeaderHash"] = &types.AttributeValueMemberS{Value: hashstr}
 
 	return fields, nil
 }
@@ -1085,6 +2490,9 @@ func MarshalAttestation(attestation *corev2.Attestation) (commondynamodb.Item, e
 
 	fields["PK"] = &types.AttributeValueMemberS{Value: batchHeaderKeyPrefix + hashstr}
 	fields["SK"] = &types.AttributeValueMemberS{Value: attestationSK}
+	fields["AttestedAtBucket"] = &types.AttributeValueMemberS{Value: strconv.FormatUint(computeBucketID(attestation.AttestedAt, attestedAtBucketSizeNano), 10)}
+	// The reference block is indexed under its own attribute, as batch headers carry ReferenceBlockNumber too
+	fields["AttestedReferenceBlock"] = &types.AttributeValueMemberN{Value: strconv.FormatUint(attestation.ReferenceBlockNumber, 10)}
 
 	return fields, nil
 }
@@ -1099,6 +2507,71 @@ func UnmarshalAttestation(item commondynamodb.Item) (*corev2.Attestation, error)
 	return &attestation, nil
 }
 
+// stakeSnapshotItem is the stored form of a stake snapshot. Stakes are kept as decimal strings
+// keyed by operator ID in hex, as big integers are not natively supported as attribute values.
+type stakeSnapshotItem struct {
+	Timestamp   uint64
+	BlockNumber uint64
+	Stakes      map[core.QuorumID]map[string]string
+}
+
+func MarshalStakeSnapshot(snapshot *v2.StakeSnapshot) (commondynamodb.Item, error) {
+	obj := stakeSnapshotItem{
+		Timestamp:   snapshot.Timestamp,
+		BlockNumber: snapshot.BlockNumber,
+		Stakes:      make(map[core.QuorumID]map[string]string, len(snapshot.Stakes)),
+	}
+	for quorumID, stakes := range snapshot.Stakes {
+		obj.Stakes[quorumID] = make(map[string]string, len(stakes))
+		for operatorID, stake := range stakes {
+			obj.Stakes[quorumID][operatorID.Hex()] = stake.String()
+		}
+	}
+	fields, err := attributevalue.MarshalMap(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stake snapshot: %w", err)
+	}
+
+	fields["PK"] = &types.AttributeValueMemberS{Value: stakeSnapshotKeyPrefix + strconv.FormatUint(snapshot.Timestamp, 10)}
+	fields["SK"] = &types.AttributeValueMemberS{Value: stakeSnapshotSK}
+
+	return fields, nil
+}
+
+func UnmarshalStakeSnapshot(item commondynamodb.Item) (*v2.StakeSnapshot, error) {
+	obj := stakeSnapshotItem{}
+	err := attributevalue.UnmarshalMap(item, &obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stake snapshot: %w", err)
+	}
+
+	snapshot := &v2.StakeSnapshot{
+		Timestamp:   obj.Timestamp,
+		BlockNumber: obj.BlockNumber,
+		Stakes:      make(map[core.QuorumID]map[core.OperatorID]*big.Int, len(obj.Stakes)),
+	}
+	for quorumID, stakes := range obj.Stakes {
+		snapshot.Stakes[quorumID] = make(map[core.OperatorID]*big.Int, len(stakes))
+		for operatorIDHex, stakeStr := range stakes {
+			operatorID, err := core.OperatorIDFromHex(operatorIDHex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid operator ID %s in stake snapshot: %w", operatorIDHex, err)
+			}
+			stake, ok := new(big.Int).SetString(stakeStr, 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid stake %s in stake snapshot", stakeStr)
+			}
+			snapshot.Stakes[quorumID][operatorID] = stake
+		}
+	}
+
+	return snapshot, nil
+}
+
+func computeBucketID(timestamp uint64, bucketSizeNano uint64) uint64 {
+	return timestamp / bucketSizeNano
+}
+
 func hexToHash(h string) ([32]byte, error) {
 	s := strings.TrimPrefix(h, "0x")
 	s = strings.TrimPrefix(s, "0X")
@@ -1108,3 +2581,196 @@ func hexToHash(h string) ([32]byte, error) {
 	}
 	return [32]byte(b), nil
 }
+
+func MarshalSemverSnapshot(snapshot *v2.SemverSnapshot) (commondynamodb.Item, error) {
+	fields, err := attributevalue.MarshalMap(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal semver snapshot: %w", err)
+	}
+
+	fields["PK"] = &types.AttributeValueMemberS{Value: semverSnapshotKeyPrefix + strconv.FormatUint(snapshot.Timestamp, 10)}
+	fields["SK"] = &types.AttributeValueMemberS{Value: semverSnapshotSK}
+
+	return fields, nil
+}
+
+func UnmarshalSemverSnapshot(item commondynamodb.Item) (*v2.SemverSnapshot, error) {
+	snapshot := v2.SemverSnapshot{}
+	err := attributevalue.UnmarshalMap(item, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal semver snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// throughputRollupItem is the stored form of a throughput rollup, with the payment kept as a decimal string
+type throughputRollupItem struct {
+	Period             v2.RollupPeriod
+	Timestamp          uint64
+	BlobCount          uint64
+	CertifiedBlobCount uint64
+	CertifiedBytes     uint64
+	OnDemandPayment    string
+}
+
+// signingTalliesItem is the stored form of SigningTallies, with the operators keyed by their hex ID
+type signingTalliesItem struct {
+	Timestamp uint64
+	Tallies   map[string]v2.SigningTally
+}
+
+func MarshalInProgressBatch(batch *v2.InProgressBatch) (commondynamodb.Item, error) {
+	fields, err := attributevalue.MarshalMap(batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal in-progress batch: %w", err)
+	}
+
+	fields["PK"] = &types.AttributeValueMemberS{Value: inProgressBatchPK}
+	fields["SK"] = &types.AttributeValueMemberS{Value: hex.EncodeToString(batch.BatchHeaderHash[:])}
+	return fields, nil
+}
+
+func UnmarshalInProgressBatch(item commondynamodb.Item) (*v2.InProgressBatch, error) {
+	batch := v2.InProgressBatch{}
+	err := attributevalue.UnmarshalMap(item, &batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal in-progress batch: %w", err)
+	}
+
+	return &batch, nil
+}
+
+func MarshalEncodingJob(job *v2.EncodingJob) (commondynamodb.Item, error) {
+	fields, err := attributevalue.MarshalMap(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal encoding job: %w", err)
+	}
+
+	fields["PK"] = &types.AttributeValueMemberS{Value: encodingJobKeyPrefix + job.BlobKey.Hex()}
+	fields["SK"] = &types.AttributeValueMemberS{Value: encodingJobSK}
+	fields["EncodingJobQueue"] = &types.AttributeValueMemberS{Value: encodingJobQueue(v2.EncodingJobShard(job.BlobKey), job.JobStatus.Pending())}
+	return fields, nil
+}
+
+func UnmarshalEncodingJob(item commondynamodb.Item) (*v2.EncodingJob, error) {
+	job := v2.EncodingJob{}
+	err := attributevalue.UnmarshalMap(item, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal encoding job: %w", err)
+	}
+
+	return &job, nil
+}
+
+func MarshalAccountAccessEntry(list v2.AccountAccessList, entry *v2.AccountAccessEntry) (commondynamodb.Item, error) {
+	fields, err := attributevalue.MarshalMap(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal account access entry: %w", err)
+	}
+
+	fields["PK"] = &types.AttributeValueMemberS{Value: accountAccessKeyPrefix + string(list)}
+	fields["SK"] = &types.AttributeValueMemberS{Value: entry.AccountID}
+	return fields, nil
+}
+
+func UnmarshalAccountAccessEntry(item commondynamodb.Item) (*v2.AccountAccessEntry, error) {
+	entry := v2.AccountAccessEntry{}
+	err := attributevalue.UnmarshalMap(item, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal account access entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+func throughputRollupPK(period v2.RollupPeriod, timestamp uint64) string {
+	return throughputRollupKeyPrefix + string(period) + "#" + strconv.FormatUint(timestamp, 10)
+}
+
+func MarshalThroughputRollup(rollup *v2.ThroughputRollup) (commondynamodb.Item, error) {
+	obj := throughputRollupItem{
+		Period:             rollup.Period,
+		Timestamp:          rollup.Timestamp,
+		BlobCount:          rollup.BlobCount,
+		CertifiedBlobCount: rollup.CertifiedBlobCount,
+		CertifiedBytes:     rollup.CertifiedBytes,
+		OnDemandPayment:    "0",
+	}
+	if rollup.OnDemandPayment != nil {
+		obj.OnDemandPayment = rollup.OnDemandPayment.String()
+	}
+	fields, err := attributevalue.MarshalMap(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal throughput rollup: %w", err)
+	}
+
+	fields["PK"] = &types.AttributeValueMemberS{Value: throughputRollupPK(rollup.Period, rollup.Timestamp)}
+	fields["SK"] = &types.AttributeValueMemberS{Value: throughputRollupSK}
+
+	return fields, nil
+}
+
+func MarshalSigningTallies(tallies *v2.SigningTallies) (commondynamodb.Item, error) {
+	obj := signingTalliesItem{
+		Timestamp: tallies.Timestamp,
+		Tallies:   make(map[string]v2.SigningTally, len(tallies.Tallies)),
+	}
+	for operatorID, tally := range tallies.Tallies {
+		obj.Tallies[operatorID.Hex()] = *tally
+	}
+	fields, err := attributevalue.MarshalMap(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signing tallies: %w", err)
+	}
+
+	fields["PK"] = &types.AttributeValueMemberS{Value: signingTalliesKeyPrefix + strconv.FormatUint(tallies.Timestamp, 10)}
+	fields["SK"] = &types.AttributeValueMemberS{Value: signingTalliesSK}
+
+	return fields, nil
+}
+
+func UnmarshalSigningTallies(item commondynamodb.Item) (*v2.SigningTallies, error) {
+	obj := signingTalliesItem{}
+	err := attributevalue.UnmarshalMap(item, &obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signing tallies: %w", err)
+	}
+
+	tallies := &v2.SigningTallies{
+		Timestamp: obj.Timestamp,
+		Tallies:   make(map[core.OperatorID]*v2.SigningTally, len(obj.Tallies)),
+	}
+	for id, tally := range obj.Tallies {
+		operatorID, err := core.OperatorIDFromHex(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operator ID %s in signing tallies: %w", id, err)
+		}
+		tallies.Tallies[operatorID] = &v2.SigningTally{
+			Batches:         tally.Batches,
+			UnsignedBatches: tally.UnsignedBatches,
+		}
+	}
+	return tallies, nil
+}
+
+func UnmarshalThroughputRollup(item commondynamodb.Item) (*v2.ThroughputRollup, error) {
+	obj := throughputRollupItem{}
+	err := attributevalue.UnmarshalMap(item, &obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal throughput rollup: %w", err)
+	}
+
+	payment, ok := new(big.Int).SetString(obj.OnDemandPayment, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid on-demand payment %s in throughput rollup", obj.OnDemandPayment)
+	}
+	return &v2.ThroughputRollup{
+		Period:             obj.Period,
+		Timestamp:          obj.Timestamp,
+		BlobCount:          obj.BlobCount,
+		CertifiedBlobCount: obj.CertifiedBlobCount,
+		CertifiedBytes:     obj.CertifiedBytes,
+		OnDemandPayment:    payment,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
@@ -55,6 +56,12 @@ func TestBlobMetadataStoreOperations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, metadata2, fetchedMetadata)
 
+	// keys without metadata are skipped
+	fetchedMetadataList, err := blobMetadataStore.GetBlobMetadataByKeys(ctx, []corev2.BlobKey{blobKey1, blobKey2, {1, 2, 3}})
+	assert.NoError(t, err)
+	assert.Len(t, fetchedMetadataList, 2)
+	assert.ElementsMatch(t, []*v2.BlobMetadata{metadata1, metadata2}, fetchedMetadataList)
+
 	queued, err := blobMetadataStore.GetBlobMetadataByStatus(ctx, v2.Queued, 0)
 	assert.NoError(t, err)
 	assert.Len(t, queued, 1)
@@ -167,6 +174,191 @@ func TestBlobMetadataStoreGetBlobMetadataByStatusPaginated(t *testing.T) {
 	deleteItems(t, dynamoKeys)
 }
 
+func TestBlobMetadataStoreGetBlobMetadataByRequestedAt(t *testing.T) {
+	ctx := context.Background()
+	numBlobs := 25
+	now := uint64(time.Now().UnixNano())
+	firstBlobTime := now - uint64(time.Hour.Nanoseconds())
+	// Spread blobs one minute apart so they span more than one requestedAt bucket
+	gap := uint64(time.Minute.Nanoseconds())
+	keys := make([]corev2.BlobKey, numBlobs)
+	dynamoKeys := make([]commondynamodb.Key, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		blobKey, blobHeader := newBlob(t)
+		metadata := &v2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			BlobStatus:  v2.Encoded,
+			Expiry:      uint64(time.Now().Add(time.Hour).Unix()),
+			NumRetries:  0,
+			RequestedAt: firstBlobTime + uint64(i)*gap,
+			UpdatedAt:   firstBlobTime + uint64(i)*gap,
+		}
+		err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+		keys[i] = blobKey
+		dynamoKeys[i] = commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		}
+	}
+	defer deleteItems(t, dynamoKeys)
+
+	// Invalid range
+	_, _, err := blobMetadataStore.GetBlobMetadataByRequestedAt(ctx, blobstore.BlobFeedCursor{RequestedAt: now}, blobstore.BlobFeedCursor{RequestedAt: now}, 10)
+	require.Error(t, err)
+
+	// Fetch the full range without limit
+	start := blobstore.BlobFeedCursor{RequestedAt: firstBlobTime}
+	end := blobstore.BlobFeedCursor{RequestedAt: now}
+	metadata, cursor, err := blobMetadataStore.GetBlobMetadataByRequestedAt(ctx, start, end, 0)
+	require.NoError(t, err)
+	require.Len(t, metadata, numBlobs)
+	require.NotNil(t, cursor)
+	assert.Equal(t, keys[numBlobs-1], *cursor.BlobKey)
+	for i := 1; i < numBlobs; i++ {
+		assert.Less(t, metadata[i-1].RequestedAt, metadata[i].RequestedAt)
+	}
+
+	// Page through the feed
+	pageSize := 10
+	fetched := 0
+	for {
+		metadata, cursor, err = blobMetadataStore.GetBlobMetadataByRequestedAt(ctx, start, end, pageSize)
+		require.NoError(t, err)
+		if len(metadata) == 0 {
+			require.Nil(t, cursor)
+			break
+		}
+		for i, m := range metadata {
+			bk, err := m.BlobHeader.BlobKey()
+			require.NoError(t, err)
+			assert.Equal(t, keys[fetched+i], bk)
+		}
+		fetched += len(metadata)
+		start = *cursor
+	}
+	assert.Equal(t, numBlobs, fetched)
+
+	// The start cursor is exclusive
+	start = blobstore.BlobFeedCursor{RequestedAt: firstBlobTime, BlobKey: &keys[0]}
+	metadata, _, err = blobMetadataStore.GetBlobMetadataByRequestedAt(ctx, start, end, 1)
+	require.NoError(t, err)
+	require.Len(t, metadata, 1)
+	bk, err := metadata[0].BlobHeader.BlobKey()
+	require.NoError(t, err)
+	assert.Equal(t, keys[1], bk)
+}
+
+func TestBlobMetadataStoreGetBlobMetadataByAccountID(t *testing.T) {
+	ctx := context.Background()
+	accountID := "0x1234567890123456789012345678901234567890"
+	numBlobs := 10
+	now := uint64(time.Now().UnixNano())
+	keys := make([]corev2.BlobKey, numBlobs)
+	dynamoKeys := make([]commondynamodb.Key, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		_, blobHeader := newBlob(t)
+		blobHeader.PaymentMetadata.AccountID = accountID
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		// Every third blob is certified, the rest are queued
+		status := v2.Queued
+		if i%3 == 0 {
+			status = v2.Certified
+		}
+		metadata := &v2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			BlobStatus:  status,
+			Expiry:      uint64(time.Now().Add(time.Hour).Unix()),
+			NumRetries:  0,
+			RequestedAt: now - uint64(numBlobs-i)*uint64(time.Second),
+			UpdatedAt:   now,
+		}
+		err = blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+		keys[i] = blobKey
+		dynamoKeys[i] = commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		}
+	}
+	// A blob of another account
+	otherKey, otherHeader := newBlob(t)
+	err := blobMetadataStore.PutBlobMetadata(ctx, &v2.BlobMetadata{
+		BlobHeader:  otherHeader,
+		BlobStatus:  v2.Queued,
+		RequestedAt: now,
+		UpdatedAt:   now,
+	})
+	require.NoError(t, err)
+	dynamoKeys = append(dynamoKeys, commondynamodb.Key{
+		"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + otherKey.Hex()},
+		"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+	})
+	defer deleteItems(t, dynamoKeys)
+
+	_, _, err = blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, nil, 0)
+	require.Error(t, err)
+
+	// Paginate through all blobs of the account, newest first
+	var cursor *blobstore.BlobFeedCursor
+	fetched := make([]corev2.BlobKey, 0, numBlobs)
+	for {
+		metadata, next, err := blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, cursor, 4)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(metadata), 4)
+		for _, m := range metadata {
+			bk, err := m.BlobHeader.BlobKey()
+			require.NoError(t, err)
+			fetched = append(fetched, bk)
+		}
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+	require.Len(t, fetched, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		assert.Equal(t, keys[numBlobs-1-i], fetched[i])
+	}
+
+	// Filter by status
+	metadata, next, err := blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, nil, 2, v2.Certified)
+	require.NoError(t, err)
+	require.Len(t, metadata, 2)
+	require.NotNil(t, next)
+	assert.Equal(t, v2.Certified, metadata[0].BlobStatus)
+	assert.Equal(t, v2.Certified, metadata[1].BlobStatus)
+	assert.Equal(t, now-uint64(time.Second), metadata[0].RequestedAt)
+	assert.Equal(t, now-4*uint64(time.Second), metadata[1].RequestedAt)
+	metadata, next, err = blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, next, 2, v2.Certified)
+	require.NoError(t, err)
+	require.Len(t, metadata, 2)
+	assert.Equal(t, now-7*uint64(time.Second), metadata[0].RequestedAt)
+	assert.Equal(t, now-10*uint64(time.Second), metadata[1].RequestedAt)
+	metadata, next, err = blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, next, 2, v2.Certified)
+	require.NoError(t, err)
+	require.Len(t, metadata, 0)
+	require.Nil(t, next)
+}
+
+func TestBlobFeedCursorEncoding(t *testing.T) {
+	blobKey, _ := newBlob(t)
+	cursor := &blobstore.BlobFeedCursor{RequestedAt: 1234567890, BlobKey: &blobKey}
+	decoded, err := (&blobstore.BlobFeedCursor{}).FromCursorKey(cursor.ToCursorKey())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	cursor = &blobstore.BlobFeedCursor{RequestedAt: 42}
+	decoded, err = (&blobstore.BlobFeedCursor{}).FromCursorKey(cursor.ToCursorKey())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+	assert.True(t, cursor.LessThan(&blobstore.BlobFeedCursor{RequestedAt: 42, BlobKey: &blobKey}))
+
+	_, err = (&blobstore.BlobFeedCursor{}).FromCursorKey("not-a-cursor")
+	assert.Error(t, err)
+}
+
 func TestBlobMetadataStoreCerts(t *testing.T) {
 	ctx := context.Background()
 	blobKey, blobHeader := newBlob(t)
@@ -289,6 +481,190 @@ func TestBlobMetadataStoreUpdateBlobStatus(t *testing.T) {
 	})
 }
 
+func TestBlobMetadataStoreRequeueBlob(t *testing.T) {
+	ctx := context.Background()
+	blobKey, blobHeader := newBlob(t)
+
+	now := time.Now()
+	metadata := &v2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: v2.Encoded,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		NumRetries: 0,
+		UpdatedAt:  uint64(now.UnixNano()),
+	}
+	err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+	assert.NoError(t, err)
+
+	err = blobMetadataStore.RequeueBlob(ctx, metadata)
+	assert.NoError(t, err)
+
+	fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, v2.Encoded, fetchedMetadata.BlobStatus)
+	assert.Equal(t, uint(1), fetchedMetadata.NumRetries)
+	assert.Greater(t, fetchedMetadata.UpdatedAt, metadata.UpdatedAt)
+
+	// the blob was updated since the metadata was read
+	err = blobMetadataStore.RequeueBlob(ctx, metadata)
+	assert.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+
+	err = blobMetadataStore.RequeueBlob(ctx, fetchedMetadata)
+	assert.NoError(t, err)
+	fetchedMetadata, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), fetchedMetadata.NumRetries)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		},
+	})
+}
+
+func TestBlobMetadataStoreInProgressBatches(t *testing.T) {
+	ctx := context.Background()
+	first := &v2.InProgressBatch{
+		BatchHeaderHash: [32]byte{1},
+		BlobKeys:        []corev2.BlobKey{{1}, {2}},
+		CreatedAt:       uint64(time.Now().UnixNano()),
+	}
+	second := &v2.InProgressBatch{
+		BatchHeaderHash: [32]byte{2},
+		BlobKeys:        []corev2.BlobKey{{3}},
+		CreatedAt:       first.CreatedAt - 1,
+	}
+	require.NoError(t, blobMetadataStore.PutInProgressBatch(ctx, first))
+	require.NoError(t, blobMetadataStore.PutInProgressBatch(ctx, second))
+	err := blobMetadataStore.PutInProgressBatch(ctx, first)
+	require.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	batches, err := blobMetadataStore.GetInProgressBatches(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*v2.InProgressBatch{second, first}, batches)
+
+	require.NoError(t, blobMetadataStore.DeleteInProgressBatch(ctx, first.BatchHeaderHash))
+	require.NoError(t, blobMetadataStore.DeleteInProgressBatch(ctx, second.BatchHeaderHash))
+	batches, err = blobMetadataStore.GetInProgressBatches(ctx)
+	require.NoError(t, err)
+	require.Empty(t, batches)
+}
+
+func TestBlobMetadataStoreAccountAccessEntries(t *testing.T) {
+	ctx := context.Background()
+	blocked := &v2.AccountAccessEntry{
+		AccountID: gethcommon.HexToAddress("0x1").Hex(),
+		Reason:    "spam",
+		UpdatedAt: uint64(time.Now().UnixNano()),
+	}
+	allowed := &v2.AccountAccessEntry{
+		AccountID: gethcommon.HexToAddress("0x2").Hex(),
+		UpdatedAt: uint64(time.Now().UnixNano()),
+	}
+	require.NoError(t, blobMetadataStore.PutAccountAccessEntry(ctx, v2.Blocklist, blocked))
+	require.NoError(t, blobMetadataStore.PutAccountAccessEntry(ctx, v2.Allowlist, allowed))
+
+	entries, err := blobMetadataStore.GetAccountAccessEntries(ctx, v2.Blocklist)
+	require.NoError(t, err)
+	require.Equal(t, []*v2.AccountAccessEntry{blocked}, entries)
+
+	// putting an account again replaces its entry
+	blocked.Reason = "abuse"
+	require.NoError(t, blobMetadataStore.PutAccountAccessEntry(ctx, v2.Blocklist, blocked))
+	entries, err = blobMetadataStore.GetAccountAccessEntries(ctx, v2.Blocklist)
+	require.NoError(t, err)
+	require.Equal(t, []*v2.AccountAccessEntry{blocked}, entries)
+
+	require.NoError(t, blobMetadataStore.DeleteAccountAccessEntry(ctx, v2.Blocklist, blocked.AccountID))
+	entries, err = blobMetadataStore.GetAccountAccessEntries(ctx, v2.Blocklist)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	entries, err = blobMetadataStore.GetAccountAccessEntries(ctx, v2.Allowlist)
+	require.NoError(t, err)
+	require.Equal(t, []*v2.AccountAccessEntry{allowed}, entries)
+
+	require.NoError(t, blobMetadataStore.DeleteAccountAccessEntry(ctx, v2.Allowlist, allowed.AccountID))
+}
+
+func TestBlobMetadataStoreEncodingJobs(t *testing.T) {
+	ctx := context.Background()
+	blobKey, _ := newBlob(t)
+	now := uint64(time.Now().UnixNano())
+	job := &v2.EncodingJob{
+		BlobKey:        blobKey,
+		EncodingParams: encoding.EncodingParams{ChunkLength: 8, NumChunks: 16},
+		JobStatus:      v2.EncodingJobQueued,
+		LeasableAt:     now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	require.NoError(t, blobMetadataStore.PutEncodingJob(ctx, job))
+	require.ErrorIs(t, blobMetadataStore.PutEncodingJob(ctx, job), common.ErrAlreadyExists)
+
+	shard := v2.EncodingJobShard(blobKey)
+	jobs, err := blobMetadataStore.GetLeasableEncodingJobs(ctx, shard, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*v2.EncodingJob{job}, jobs)
+
+	// only one of the encoders leasing the same job gets it
+	leased, err := blobMetadataStore.LeaseEncodingJob(ctx, job, "encoder1", uint64(time.Now().Add(time.Minute).UnixNano()))
+	require.NoError(t, err)
+	require.Equal(t, v2.EncodingJobLeased, leased.JobStatus)
+	require.Equal(t, uint(1), leased.NumAttempts)
+	_, err = blobMetadataStore.LeaseEncodingJob(ctx, job, "encoder2", uint64(time.Now().Add(time.Minute).UnixNano()))
+	require.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+	_, err = blobMetadataStore.LeaseEncodingJob(ctx, leased, "encoder2", uint64(time.Now().Add(time.Minute).UnixNano()))
+	require.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+	// a job being encoded isn't leasable
+	jobs, err = blobMetadataStore.GetLeasableEncodingJobs(ctx, shard, 10)
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+
+	// an expired lease is taken over by another encoder
+	require.NoError(t, blobMetadataStore.ExtendEncodingJobLease(ctx, blobKey, "encoder1", uint64(time.Now().Add(-time.Second).UnixNano())))
+	require.ErrorIs(t, blobMetadataStore.ExtendEncodingJobLease(ctx, blobKey, "encoder2", uint64(time.Now().UnixNano())), blobstore.ErrInvalidStateTransition)
+	fetched, err := blobMetadataStore.GetEncodingJob(ctx, blobKey)
+	require.NoError(t, err)
+	leased, err = blobMetadataStore.LeaseEncodingJob(ctx, fetched, "encoder2", uint64(time.Now().Add(time.Minute).UnixNano()))
+	require.NoError(t, err)
+	require.Equal(t, uint(2), leased.NumAttempts)
+
+	// a failed attempt is retried once its backoff passes
+	require.NoError(t, blobMetadataStore.RetryEncodingJob(ctx, blobKey, "encoder2", "out of memory", uint64(time.Now().Add(time.Second).UnixNano())))
+	jobs, err = blobMetadataStore.GetLeasableEncodingJobs(ctx, shard, 10)
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+	fetched, err = blobMetadataStore.GetEncodingJob(ctx, blobKey)
+	require.NoError(t, err)
+	require.Equal(t, v2.EncodingJobQueued, fetched.JobStatus)
+	require.Equal(t, "out of memory", fetched.Error)
+	_, err = blobMetadataStore.LeaseEncodingJob(ctx, fetched, "encoder2", uint64(time.Now().Add(time.Minute).UnixNano()))
+	require.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+	require.Eventually(t, func() bool {
+		jobs, err = blobMetadataStore.GetLeasableEncodingJobs(ctx, shard, 10)
+		return err == nil && len(jobs) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	leased, err = blobMetadataStore.LeaseEncodingJob(ctx, jobs[0], "encoder2", uint64(time.Now().Add(time.Minute).UnixNano()))
+	require.NoError(t, err)
+	require.Equal(t, uint(3), leased.NumAttempts)
+
+	// only the encoder holding the lease publishes the result
+	fragmentInfo := &encoding.FragmentInfo{TotalChunkSizeBytes: 100, FragmentSizeBytes: 10}
+	require.ErrorIs(t, blobMetadataStore.FailEncodingJob(ctx, blobKey, "encoder1", "encoder died"), blobstore.ErrInvalidStateTransition)
+	require.NoError(t, blobMetadataStore.CompleteEncodingJob(ctx, blobKey, "encoder2", fragmentInfo))
+	fetched, err = blobMetadataStore.GetEncodingJob(ctx, blobKey)
+	require.NoError(t, err)
+	require.Equal(t, v2.EncodingJobCompleted, fetched.JobStatus)
+	require.Equal(t, fragmentInfo, fetched.FragmentInfo)
+	_, err = blobMetadataStore.LeaseEncodingJob(ctx, fetched, "encoder1", uint64(time.Now().Add(time.Minute).UnixNano()))
+	require.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+
+	require.NoError(t, blobMetadataStore.DeleteEncodingJob(ctx, blobKey))
+	_, err = blobMetadataStore.GetEncodingJob(ctx, blobKey)
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+}
+
 func TestBlobMetadataStoreDispersals(t *testing.T) {
 	ctx := context.Background()
 	opID := core.OperatorID{0, 1}
@@ -393,6 +769,12 @@ func TestBlobMetadataStoreVerificationInfo(t *testing.T) {
 	err = blobMetadataStore.PutBlobVerificationInfos(ctx, []*corev2.BlobVerificationInfo{verificationInfo1, verificationInfo2})
 	assert.NoError(t, err)
 
+	// fetch all verification infos in the batch
+	fetchedInfos, err := blobMetadataStore.GetBlobVerificationInfosByBatchHeaderHash(ctx, bhh)
+	assert.NoError(t, err)
+	assert.Len(t, fetchedInfos, 3)
+	assert.ElementsMatch(t, []*corev2.BlobVerificationInfo{verificationInfo, verificationInfo1, verificationInfo2}, fetchedInfos)
+
 	// test retries
 	nonTransientError := errors.New("non transient error")
 	mockDynamoClient.On("PutItems", mock.Anything, mock.Anything, mock.Anything).Return(nil, nonTransientError).Once()
@@ -528,3 +910,317 @@ func newBlob(t *testing.T) (corev2.BlobKey, *corev2.BlobHeader) {
 	require.NoError(t, err)
 	return bk, bh
 }
+
+func TestBlobMetadataStoreGetAttestationByAttestedAt(t *testing.T) {
+	ctx := context.Background()
+	numBatches := 12
+	firstAttestedAt := uint64(time.Now().Add(-2 * time.Hour).UnixNano())
+	gap := uint64(15 * time.Minute)
+	dynamoKeys := make([]commondynamodb.Key, 0, numBatches)
+	for i := 0; i < numBatches; i++ {
+		h := &corev2.BatchHeader{
+			BatchRoot:            [32]byte{byte(i), 9, 9},
+			ReferenceBlockNumber: uint64(1000 + i),
+		}
+		bhh, err := h.Hash()
+		require.NoError(t, err)
+		attestation := &corev2.Attestation{
+			BatchHeader:   h,
+			AttestedAt:    firstAttestedAt + uint64(i)*gap,
+			QuorumNumbers: []core.QuorumID{0},
+			QuorumResults: map[uint8]uint8{0: 100},
+		}
+		err = blobMetadataStore.PutAttestation(ctx, attestation)
+		require.NoError(t, err)
+		dynamoKeys = append(dynamoKeys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "BatchHeader#" + hex.EncodeToString(bhh[:])},
+			"SK": &types.AttributeValueMemberS{Value: "Attestation"},
+		})
+	}
+	defer deleteItems(t, dynamoKeys)
+
+	// Invalid range
+	_, err := blobMetadataStore.GetAttestationByAttestedAt(ctx, firstAttestedAt, firstAttestedAt, 0)
+	require.Error(t, err)
+
+	// The range is exclusive on both ends
+	lastAttestedAt := firstAttestedAt + uint64(numBatches-1)*gap
+	attestations, err := blobMetadataStore.GetAttestationByAttestedAt(ctx, firstAttestedAt, lastAttestedAt, 0)
+	require.NoError(t, err)
+	require.Len(t, attestations, numBatches-2)
+	for i, a := range attestations {
+		assert.Equal(t, firstAttestedAt+uint64(i+1)*gap, a.AttestedAt)
+		assert.Equal(t, uint64(1000+i+1), a.ReferenceBlockNumber)
+	}
+
+	// Limit across buckets
+	attestations, err = blobMetadataStore.GetAttestationByAttestedAt(ctx, firstAttestedAt-1, lastAttestedAt+1, 7)
+	require.NoError(t, err)
+	require.Len(t, attestations, 7)
+	for i := 1; i < len(attestations); i++ {
+		assert.Less(t, attestations[i-1].AttestedAt, attestations[i].AttestedAt)
+	}
+}
+
+func TestBlobMetadataStoreGetAttestationsByReferenceBlock(t *testing.T) {
+	ctx := context.Background()
+	referenceBlockNumber := uint64(2000)
+	attestedAt := uint64(time.Now().UnixNano())
+	dynamoKeys := make([]commondynamodb.Key, 0, 3)
+	for i, rbn := range []uint64{referenceBlockNumber, referenceBlockNumber, referenceBlockNumber + 1} {
+		h := &corev2.BatchHeader{
+			BatchRoot:            [32]byte{byte(i), 8, 8},
+			ReferenceBlockNumber: rbn,
+		}
+		bhh, err := h.Hash()
+		require.NoError(t, err)
+		err = blobMetadataStore.PutBatchHeader(ctx, h)
+		require.NoError(t, err)
+		err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+			BatchHeader:   h,
+			AttestedAt:    attestedAt + uint64(i),
+			QuorumNumbers: []core.QuorumID{0},
+			QuorumResults: map[uint8]uint8{0: 100},
+		})
+		require.NoError(t, err)
+		dynamoKeys = append(dynamoKeys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "BatchHeader#" + hex.EncodeToString(bhh[:])},
+			"SK": &types.AttributeValueMemberS{Value: "BatchHeader"},
+		}, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "BatchHeader#" + hex.EncodeToString(bhh[:])},
+			"SK": &types.AttributeValueMemberS{Value: "Attestation"},
+		})
+	}
+	defer deleteItems(t, dynamoKeys)
+
+	// Only attestations are indexed, not the batch headers sharing the reference block
+	attestations, err := blobMetadataStore.GetAttestationsByReferenceBlock(ctx, referenceBlockNumber)
+	require.NoError(t, err)
+	require.Len(t, attestations, 2)
+	assert.Equal(t, attestedAt, attestations[0].AttestedAt)
+	assert.Equal(t, attestedAt+1, attestations[1].AttestedAt)
+
+	attestations, err = blobMetadataStore.GetAttestationsByReferenceBlock(ctx, referenceBlockNumber+2)
+	require.NoError(t, err)
+	assert.Empty(t, attestations)
+}
+
+func TestBlobMetadataStoreBlobContentKey(t *testing.T) {
+	ctx := context.Background()
+	accountID := "0x1234"
+	contentHash := [32]byte{1, 2, 3}
+	defer deleteItems(t, []commondynamodb.Key{
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobContent#" + accountID + "#" + hex.EncodeToString(contentHash[:])},
+			"SK": &types.AttributeValueMemberS{Value: "BlobContent"},
+		},
+	})
+
+	_, err := blobMetadataStore.GetBlobContentKey(ctx, accountID, contentHash)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	blobKey := corev2.BlobKey{4, 5, 6}
+	err = blobMetadataStore.PutBlobContentKey(ctx, accountID, contentHash, blobKey)
+	require.NoError(t, err)
+	fetched, err := blobMetadataStore.GetBlobContentKey(ctx, accountID, contentHash)
+	require.NoError(t, err)
+	assert.Equal(t, blobKey, fetched)
+
+	// the content hash is scoped to the account
+	_, err = blobMetadataStore.GetBlobContentKey(ctx, "0x5678", contentHash)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	// a later blob with the same content replaces the previous one
+	blobKey = corev2.BlobKey{7, 8, 9}
+	err = blobMetadataStore.PutBlobContentKey(ctx, accountID, contentHash, blobKey)
+	require.NoError(t, err)
+	fetched, err = blobMetadataStore.GetBlobContentKey(ctx, accountID, contentHash)
+	require.NoError(t, err)
+	assert.Equal(t, blobKey, fetched)
+}
+
+func TestBlobMetadataStoreStakeSnapshots(t *testing.T) {
+	ctx := context.Background()
+	day := uint64(24 * 60 * 60)
+	stake, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(t, ok)
+	snapshots := []*v2.StakeSnapshot{
+		{
+			Timestamp:   3 * day,
+			BlockNumber: 300,
+			Stakes: map[core.QuorumID]map[core.OperatorID]*big.Int{
+				0: {{1}: stake, {2}: big.NewInt(10)},
+				1: {{2}: big.NewInt(20)},
+			},
+		},
+		{
+			Timestamp:   day,
+			BlockNumber: 100,
+			Stakes: map[core.QuorumID]map[core.OperatorID]*big.Int{
+				0: {{1}: big.NewInt(5)},
+			},
+		},
+	}
+	dynamoKeys := make([]commondynamodb.Key, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		err := blobMetadataStore.PutStakeSnapshot(ctx, snapshot)
+		require.NoError(t, err)
+		dynamoKeys = append(dynamoKeys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("StakeSnapshot#%d", snapshot.Timestamp)},
+			"SK": &types.AttributeValueMemberS{Value: "StakeSnapshot"},
+		})
+	}
+	defer deleteItems(t, dynamoKeys)
+
+	// Snapshots are immutable
+	err := blobMetadataStore.PutStakeSnapshot(ctx, snapshots[0])
+	assert.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	// Missing days are skipped and the result is ordered by timestamp
+	fetched, err := blobMetadataStore.GetStakeSnapshots(ctx, []uint64{day, 2 * day, 3 * day})
+	require.NoError(t, err)
+	require.Len(t, fetched, 2)
+	assert.Equal(t, snapshots[1], fetched[0])
+	assert.Equal(t, snapshots[0], fetched[1])
+
+	fetched, err = blobMetadataStore.GetStakeSnapshots(ctx, []uint64{2 * day})
+	require.NoError(t, err)
+	assert.Empty(t, fetched)
+}
+
+func TestBlobMetadataStoreSemverSnapshots(t *testing.T) {
+	ctx := context.Background()
+	day := uint64(24 * 60 * 60)
+	snapshots := []*v2.SemverSnapshot{
+		{
+			Timestamp: 3 * day,
+			Semvers: map[string]*v2.SemverAdoption{
+				"0.8.6": {Operators: 2, QuorumStakePercentage: map[core.QuorumID]float64{0: 75, 1: 100}},
+				"0.8.5": {Operators: 1, QuorumStakePercentage: map[core.QuorumID]float64{0: 25}},
+			},
+		},
+		{
+			Timestamp: day,
+			Semvers: map[string]*v2.SemverAdoption{
+				"unreachable": {Operators: 3, QuorumStakePercentage: map[core.QuorumID]float64{}},
+			},
+		},
+	}
+	dynamoKeys := make([]commondynamodb.Key, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		err := blobMetadataStore.PutSemverSnapshot(ctx, snapshot)
+		require.NoError(t, err)
+		dynamoKeys = append(dynamoKeys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("SemverSnapshot#%d", snapshot.Timestamp)},
+			"SK": &types.AttributeValueMemberS{Value: "SemverSnapshot"},
+		})
+	}
+	defer deleteItems(t, dynamoKeys)
+
+	// Snapshots are immutable
+	err := blobMetadataStore.PutSemverSnapshot(ctx, snapshots[0])
+	assert.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	// Missing days are skipped and the result is ordered by timestamp
+	fetched, err := blobMetadataStore.GetSemverSnapshots(ctx, []uint64{day, 2 * day, 3 * day})
+	require.NoError(t, err)
+	require.Len(t, fetched, 2)
+	assert.Equal(t, snapshots[1], fetched[0])
+	assert.Equal(t, snapshots[0], fetched[1])
+}
+
+func TestBlobMetadataStoreThroughputRollups(t *testing.T) {
+	ctx := context.Background()
+	day := uint64(24 * 60 * 60)
+	rollups := []*v2.ThroughputRollup{
+		{
+			Period:             v2.DailyRollup,
+			Timestamp:          3 * day,
+			BlobCount:          10,
+			CertifiedBlobCount: 8,
+			CertifiedBytes:     8192,
+			OnDemandPayment:    big.NewInt(1234),
+		},
+		{
+			Period:             v2.DailyRollup,
+			Timestamp:          day,
+			BlobCount:          1,
+			CertifiedBlobCount: 0,
+			CertifiedBytes:     0,
+			OnDemandPayment:    big.NewInt(0),
+		},
+		{
+			Period:             v2.WeeklyRollup,
+			Timestamp:          day,
+			BlobCount:          11,
+			CertifiedBlobCount: 8,
+			CertifiedBytes:     8192,
+			OnDemandPayment:    big.NewInt(1234),
+		},
+	}
+	dynamoKeys := make([]commondynamodb.Key, 0, len(rollups))
+	for _, rollup := range rollups {
+		err := blobMetadataStore.PutThroughputRollup(ctx, rollup)
+		require.NoError(t, err)
+		dynamoKeys = append(dynamoKeys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("ThroughputRollup#%s#%d", rollup.Period, rollup.Timestamp)},
+			"SK": &types.AttributeValueMemberS{Value: "ThroughputRollup"},
+		})
+	}
+	defer deleteItems(t, dynamoKeys)
+
+	// Rollups are immutable
+	err := blobMetadataStore.PutThroughputRollup(ctx, rollups[0])
+	assert.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	// Missing days are skipped, rollups of other periods aren't returned, and the result is ordered by timestamp
+	fetched, err := blobMetadataStore.GetThroughputRollups(ctx, v2.DailyRollup, []uint64{day, 2 * day, 3 * day})
+	require.NoError(t, err)
+	require.Len(t, fetched, 2)
+	assert.Equal(t, rollups[1], fetched[0])
+	assert.Equal(t, rollups[0], fetched[1])
+
+	fetched, err = blobMetadataStore.GetThroughputRollups(ctx, v2.WeeklyRollup, []uint64{day})
+	require.NoError(t, err)
+	require.Len(t, fetched, 1)
+	assert.Equal(t, rollups[2], fetched[0])
+}
+
+func TestBlobMetadataStoreSigningTallies(t *testing.T) {
+	ctx := context.Background()
+	hour := uint64(60 * 60)
+	tallies := []*v2.SigningTallies{
+		{
+			Timestamp: 3 * hour,
+			Tallies: map[core.OperatorID]*v2.SigningTally{
+				{1}: {Batches: 10, UnsignedBatches: 2},
+				{2}: {Batches: 4, UnsignedBatches: 0},
+			},
+		},
+		{
+			Timestamp: hour,
+			Tallies:   map[core.OperatorID]*v2.SigningTally{},
+		},
+	}
+	dynamoKeys := make([]commondynamodb.Key, 0, len(tallies))
+	for _, hourTallies := range tallies {
+		err := blobMetadataStore.PutSigningTallies(ctx, hourTallies)
+		require.NoError(t, err)
+		dynamoKeys = append(dynamoKeys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: fmt.Sprintf("SigningTallies#%d", hourTallies.Timestamp)},
+			"SK": &types.AttributeValueMemberS{Value: "SigningTallies"},
+		})
+	}
+	defer deleteItems(t, dynamoKeys)
+
+	// Tallies are immutable
+	err := blobMetadataStore.PutSigningTallies(ctx, tallies[0])
+	assert.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	// Missing hours are skipped, and the result is ordered by timestamp
+	fetched, err := blobMetadataStore.GetSigningTallies(ctx, []uint64{hour, 2 * hour, 3 * hour})
+	require.NoError(t, err)
+	require.Len(t, fetched, 2)
+	assert.Equal(t, tallies[1], fetched[0])
+	assert.Equal(t, tallies[0], fetched[1])
+}
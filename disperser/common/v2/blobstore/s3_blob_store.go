@@ -54,3 +54,12 @@ func (b *BlobStore) GetBlob(ctx context.Context, key corev2.BlobKey) ([]byte, er
 	}
 	return data, nil
 }
+
+// CheckHealth checks that the blob store bucket is reachable, by looking up a blob that doesn't exist
+func (b *BlobStore) CheckHealth(ctx context.Context) error {
+	_, err := b.s3Client.HeadObject(ctx, b.bucketName, s3.ScopedBlobKey(corev2.BlobKey{}))
+	if err != nil && !errors.Is(err, s3.ErrObjectNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package v2
+
+import (
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// NumEncodingJobShards is the number of shards the encoding queue is split into, so that the leasable jobs are spread
+// over as many index partitions and encoders don't all poll the same one
+const NumEncodingJobShards = 16
+
+type EncodingJobStatus uint
+
+const (
+	// EncodingJobQueued is the status of a job waiting to be leased by an encoder
+	EncodingJobQueued EncodingJobStatus = iota
+	// EncodingJobLeased is the status of a job being encoded. The job is leased again if its lease expires, e.g.
+	// because the encoder holding it died.
+	EncodingJobLeased
+	// EncodingJobCompleted and EncodingJobFailed are the statuses of finished jobs, whose results are awaited by the
+	// controller
+	EncodingJobCompleted
+	EncodingJobFailed
+)
+
+// Pending returns true if the job isn't finished, i.e. it is either waiting to be leased or being encoded
+func (s EncodingJobStatus) Pending() bool {
+	return s == EncodingJobQueued || s == EncodingJobLeased
+}
+
+func (s EncodingJobStatus) String() string {
+	switch s {
+	case EncodingJobQueued:
+		return "Queued"
+	case EncodingJobLeased:
+		return "Leased"
+	case EncodingJobCompleted:
+		return "Completed"
+	case EncodingJobFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// EncodingJob is a request to encode a blob, written by the controller to the encoding queue shared by the encoders.
+type EncodingJob struct {
+	BlobKey        core.BlobKey
+	EncodingParams encoding.EncodingParams
+	// Reencode is whether the blob is encoded even if its chunks are already stored, replacing the stored chunks
+	Reencode  bool
+	JobStatus EncodingJobStatus
+	// LeaseOwner is the ID of the encoder that leased the job last
+	LeaseOwner string
+	// LeaseExpiry is the Unix timestamp in nanoseconds after which the lease may be taken over by another encoder
+	LeaseExpiry uint64
+	// LeasableAt is the Unix timestamp in nanoseconds after which a pending job may be leased, which is the lease
+	// expiry of a leased job and the time of the next attempt of a queued job
+	LeasableAt uint64
+	// NumAttempts is the number of times the job has been leased
+	NumAttempts uint
+	// FragmentInfo is the result of a completed job
+	FragmentInfo *encoding.FragmentInfo
+	// Error is the reason a failed job failed, or the reason the last attempt of a queued job failed
+	Error string
+	// CreatedAt is the Unix timestamp in nanoseconds of when the job was queued
+	CreatedAt uint64
+	// UpdatedAt is the Unix timestamp in nanoseconds of when the job was last updated
+	UpdatedAt uint64
+}
+
+// EncodingJobShard returns the shard of the encoding queue the job of the blob is in
+func EncodingJobShard(blobKey core.BlobKey) uint32 {
+	return uint32(blobKey[0]) % NumEncodingJobShards
+}
@@ -0,0 +1,26 @@
+package v2
+
+import "math/big"
+
+// RollupPeriod is the length of the period aggregated by a rollup
+type RollupPeriod string
+
+const (
+	DailyRollup  RollupPeriod = "daily"
+	WeeklyRollup RollupPeriod = "weekly"
+)
+
+// ThroughputRollup is the dispersal volume and the fees of the blobs requested in a period.
+type ThroughputRollup struct {
+	Period RollupPeriod
+	// Timestamp is the Unix timestamp in seconds of the start of the period
+	Timestamp uint64
+	// BlobCount is the number of blobs requested in the period
+	BlobCount uint64
+	// CertifiedBlobCount is the number of blobs requested in the period which got certified
+	CertifiedBlobCount uint64
+	// CertifiedBytes is the total size in bytes of the certified blobs
+	CertifiedBytes uint64
+	// OnDemandPayment is the total payment in wei made by the accounts for their on-demand blobs
+	OnDemandPayment *big.Int
+}
@@ -0,0 +1,18 @@
+package v2
+
+import "github.com/Layr-Labs/eigenda/core"
+
+// SemverSnapshot is the adoption of the node software versions run by the operators, as of a day.
+type SemverSnapshot struct {
+	// Timestamp is the Unix timestamp in seconds of the period the snapshot was taken for
+	Timestamp uint64
+	// Semvers is the adoption of each version reported by the operators, by version
+	Semvers map[string]*SemverAdoption
+}
+
+// SemverAdoption is the number of operators running a node version, and the stake they hold.
+type SemverAdoption struct {
+	Operators uint32
+	// QuorumStakePercentage is the percentage of the stake of each quorum held by the operators
+	QuorumStakePercentage map[core.QuorumID]float64
+}
@@ -0,0 +1,17 @@
+package v2
+
+import "github.com/Layr-Labs/eigenda/core"
+
+// SigningTally is the number of batches an operator was required to sign, and the number it didn't sign
+type SigningTally struct {
+	Batches         uint64
+	UnsignedBatches uint64
+}
+
+// SigningTallies are the signing tallies of the operators for the batches attested in an hour
+type SigningTallies struct {
+	// Timestamp is the Unix timestamp in seconds of the start of the hour
+	Timestamp uint64
+	// Tallies are the tallies of the operators registered in a quorum of at least one of the batches
+	Tallies map[core.OperatorID]*SigningTally
+}
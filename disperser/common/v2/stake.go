@@ -0,0 +1,17 @@
+package v2
+
+import (
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// StakeSnapshot is the stake distribution of the operators in each quorum, as of a block.
+type StakeSnapshot struct {
+	// Timestamp is the Unix timestamp in seconds of the period the snapshot was taken for
+	Timestamp uint64
+	// BlockNumber is the block at which the stakes were read
+	BlockNumber uint64
+	// Stakes is the stake of each operator in each quorum
+	Stakes map[core.QuorumID]map[core.OperatorID]*big.Int
+}
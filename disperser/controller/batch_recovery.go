@@ -0,0 +1,237 @@
+package controller
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+)
+
+// RecoverBatches resumes the batches that were in progress when the controller stopped, and returns once they have
+// all been handled.
+//
+// A batch that was attested before the controller stopped only has the statuses of its blobs updated. Otherwise, the
+// signatures persisted from the operators that already responded are reused, the batch is sent again to the other
+// operators, and the signatures are handled as for a new batch. Batches that can't be resumed, e.g. because their
+// operator state is no longer available, have their blobs requeued so that they're dispatched in a new batch.
+//
+// The batches keep their in-progress records until they're handled, so that the stale blob sweeper leaves their
+// blobs to the dispatcher in the meantime.
+func (d *Dispatcher) RecoverBatches(ctx context.Context) {
+	d.resumeBatches(ctx, d.claimInProgressBatches(ctx))
+}
+
+// claimInProgressBatches returns the batches that were in progress when the controller stopped, and excludes their
+// blobs from new batches until they're resumed. It is called before new batches are made, so that the blobs of the
+// batches, which are still encoded, aren't dispatched twice while the batches are resumed in the background.
+func (d *Dispatcher) claimInProgressBatches(ctx context.Context) []*v2.InProgressBatch {
+	batches, err := d.blobMetadataStore.GetInProgressBatches(ctx)
+	if err != nil {
+		d.logger.Error("failed to get in-progress batches", "err", err)
+		return nil
+	}
+
+	d.recoveringMu.Lock()
+	defer d.recoveringMu.Unlock()
+	for _, inProgress := range batches {
+		for _, blobKey := range inProgress.BlobKeys {
+			d.recoveringBlobs[blobKey] = struct{}{}
+		}
+	}
+	return batches
+}
+
+// releaseRecoveringBlobs lets the blobs of a resumed batch be included in new batches again
+func (d *Dispatcher) releaseRecoveringBlobs(blobKeys []corev2.BlobKey) {
+	d.recoveringMu.Lock()
+	defer d.recoveringMu.Unlock()
+	for _, blobKey := range blobKeys {
+		delete(d.recoveringBlobs, blobKey)
+	}
+}
+
+// isRecovering returns true if the blob is in a batch that is being resumed
+func (d *Dispatcher) isRecovering(blobKey corev2.BlobKey) bool {
+	d.recoveringMu.Lock()
+	defer d.recoveringMu.Unlock()
+	_, ok := d.recoveringBlobs[blobKey]
+	return ok
+}
+
+// resumeBatches resumes the claimed in-progress batches, and returns once their signatures have been handled
+func (d *Dispatcher) resumeBatches(ctx context.Context, batches []*v2.InProgressBatch) {
+	if len(batches) == 0 {
+		return
+	}
+	d.logger.Info("recovering in-progress batches", "numBatches", len(batches))
+
+	var wg sync.WaitGroup
+	for _, inProgress := range batches {
+		batchHeaderHash := hex.EncodeToString(inProgress.BatchHeaderHash[:])
+		batchData, err := d.loadBatch(ctx, inProgress)
+		if err != nil {
+			d.logger.Warn("failed to load in-progress batch, requeueing its blobs", "batchHeader", batchHeaderHash, "err", err)
+			d.requeueBatch(ctx, inProgress)
+			d.releaseRecoveringBlobs(inProgress.BlobKeys)
+			continue
+		}
+
+		attestation, err := d.blobMetadataStore.GetAttestation(ctx, inProgress.BatchHeaderHash)
+		if err == nil {
+			// the controller stopped between attesting the batch and updating the statuses of its blobs
+			err = d.updateBatchStatus(ctx, batchData, attestation.QuorumResults)
+			if err != nil {
+				d.logger.Error("failed to update blob statuses of attested batch", "batchHeader", batchHeaderHash, "err", err)
+			}
+			d.deleteInProgressBatch(ctx, inProgress.BatchHeaderHash)
+			d.metrics.reportRecoveredBatch("completed")
+			d.releaseRecoveringBlobs(inProgress.BlobKeys)
+			continue
+		}
+		if !errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			// the blobs stay excluded from new batches, and the batch is recovered at the next restart
+			d.logger.Error("failed to get attestation of in-progress batch", "batchHeader", batchHeaderHash, "err", err)
+			continue
+		}
+
+		sigChan, err := d.dispatchBatch(ctx, batchData, d.getReceivedSignatures(ctx, batchData))
+		if err != nil {
+			d.logger.Warn("failed to dispatch in-progress batch, requeueing its blobs", "batchHeader", batchHeaderHash, "err", err)
+			d.requeueBatch(ctx, inProgress)
+			d.releaseRecoveringBlobs(inProgress.BlobKeys)
+			continue
+		}
+		d.logger.Info("resumed in-progress batch", "batchHeader", batchHeaderHash, "numBlobs", len(batchData.BlobKeys))
+		d.metrics.reportRecoveredBatch("resumed")
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer d.releaseRecoveringBlobs(batchData.BlobKeys)
+			err := d.HandleSignatures(ctx, batchData, sigChan)
+			if err != nil {
+				d.logger.Error("failed to handle signatures of resumed batch", "batchHeader", batchHeaderHash, "err", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// loadBatch rebuilds the batch from the persisted batch header, blob certificates and blob metadata.
+func (d *Dispatcher) loadBatch(ctx context.Context, inProgress *v2.InProgressBatch) (*batchData, error) {
+	batchHeader, err := d.blobMetadataStore.GetBatchHeader(ctx, inProgress.BatchHeaderHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch header: %w", err)
+	}
+
+	certs, _, err := d.blobMetadataStore.GetBlobCertificates(ctx, inProgress.BlobKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob certificates: %w", err)
+	}
+	certs, err = orderBlobCertificates(certs, inProgress.BlobKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	metadatas, err := d.getOrderedBlobMetadata(ctx, inProgress.BlobKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := d.GetOperatorState(ctx, metadatas, batchHeader.ReferenceBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", batchHeader.ReferenceBlockNumber, err)
+	}
+
+	return &batchData{
+		Batch: &corev2.Batch{
+			BatchHeader:      batchHeader,
+			BlobCertificates: certs,
+		},
+		BatchHeaderHash: inProgress.BatchHeaderHash,
+		BlobKeys:        inProgress.BlobKeys,
+		BlobMetadatas:   metadatas,
+		OperatorState:   state,
+	}, nil
+}
+
+// getOrderedBlobMetadata returns the metadata of the blobs in the order of the given blob keys
+func (d *Dispatcher) getOrderedBlobMetadata(ctx context.Context, keys []corev2.BlobKey) ([]*v2.BlobMetadata, error) {
+	metadatas, err := d.blobMetadataStore.GetBlobMetadataByKeys(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob metadata: %w", err)
+	}
+
+	metadataMap := make(map[corev2.BlobKey]*v2.BlobMetadata, len(metadatas))
+	for _, metadata := range metadatas {
+		blobKey, err := metadata.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key: %w", err)
+		}
+		metadataMap[blobKey] = metadata
+	}
+
+	ordered := make([]*v2.BlobMetadata, len(keys))
+	for i, key := range keys {
+		metadata, ok := metadataMap[key]
+		if !ok {
+			return nil, fmt.Errorf("blob metadata not found for blob key %s", key.Hex())
+		}
+		ordered[i] = metadata
+	}
+	return ordered, nil
+}
+
+// getReceivedSignatures returns the signatures persisted from the operators that responded to the batch
+func (d *Dispatcher) getReceivedSignatures(ctx context.Context, batchData *batchData) map[core.OperatorID]*core.Signature {
+	signatures := make(map[core.OperatorID]*core.Signature)
+	for opID := range batchData.OperatorState.IndexedOperators {
+		res, err := d.blobMetadataStore.GetDispersalResponse(ctx, batchData.BatchHeaderHash, opID)
+		if err != nil || res.Error != "" {
+			continue
+		}
+		point, err := new(core.G1Point).Deserialize(res.Signature[:])
+		if err != nil {
+			d.logger.Warn("failed to deserialize persisted signature", "operator", opID.Hex(), "err", err)
+			continue
+		}
+		signatures[opID] = &core.Signature{G1Point: point}
+	}
+	return signatures
+}
+
+// requeueBatch requeues the blobs of the batch that are still encoded, so that they're dispatched in a new batch
+func (d *Dispatcher) requeueBatch(ctx context.Context, inProgress *v2.InProgressBatch) {
+	metadatas, err := d.blobMetadataStore.GetBlobMetadataByKeys(ctx, inProgress.BlobKeys)
+	if err != nil {
+		// the batch is kept in progress so that it's recovered at the next restart, and in the meantime its blobs are
+		// requeued by the stale blob sweeper if enabled
+		d.logger.Error("failed to get blob metadata of in-progress batch", "err", err)
+		return
+	}
+	for _, metadata := range metadatas {
+		if metadata.BlobStatus != v2.Encoded {
+			continue
+		}
+		err := d.blobMetadataStore.RequeueBlob(ctx, metadata)
+		if err != nil && !errors.Is(err, blobstore.ErrInvalidStateTransition) {
+			d.logger.Error("failed to requeue blob of in-progress batch", "err", err)
+		}
+	}
+	d.deleteInProgressBatch(ctx, inProgress.BatchHeaderHash)
+	d.metrics.reportRecoveredBatch("requeued")
+}
+
+func (d *Dispatcher) deleteInProgressBatch(ctx context.Context, batchHeaderHash [32]byte) {
+	err := d.blobMetadataStore.DeleteInProgressBatch(ctx, batchHeaderHash)
+	if err != nil {
+		d.logger.Error("failed to delete in-progress batch", "batchHeader", hex.EncodeToString(batchHeaderHash[:]), "err", err)
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// arrivalRateSmoothing is the weight of the latest dispatch in the moving average of the blob arrival rate
+const arrivalRateSmoothing = 0.3
+
+// BatchSizer adapts the size and cadence of batches to the load of the dispatcher.
+//
+// Under light load, batches are dispatched every pull interval with whatever blobs are available, which keeps the
+// confirmation latency low. As the load grows towards filling a maximum sized batch every pull interval, the target
+// batch size grows towards the maximum batch size and the dispatcher waits for more blobs, up to the max batch
+// interval, which amortizes the cost of a batch over more blobs.
+//
+// The load is measured as the moving average of the rate blobs are dispatched at. A batch is dispatched when it
+// reaches the target size, or when the max batch interval has passed since the previous batch.
+//
+// A nil BatchSizer dispatches every non-empty batch. BatchSizer is not thread-safe.
+type BatchSizer struct {
+	minBatchSize     int
+	maxBatchSize     int
+	pullInterval     time.Duration
+	maxBatchInterval time.Duration
+
+	// arrivalRate is the moving average of the number of blobs dispatched per second
+	arrivalRate  float64
+	lastDispatch time.Time
+}
+
+func NewBatchSizer(minBatchSize int, maxBatchSize int, pullInterval time.Duration, maxBatchInterval time.Duration) (*BatchSizer, error) {
+	if minBatchSize <= 0 || minBatchSize > maxBatchSize {
+		return nil, errors.New("min batch size must be positive and at most the max batch size")
+	}
+	if pullInterval <= 0 || maxBatchInterval < pullInterval {
+		return nil, errors.New("max batch interval must be at least the pull interval")
+	}
+	return &BatchSizer{
+		minBatchSize:     minBatchSize,
+		maxBatchSize:     maxBatchSize,
+		pullInterval:     pullInterval,
+		maxBatchInterval: maxBatchInterval,
+	}, nil
+}
+
+// TargetBatchSize returns the number of blobs a batch is held back for.
+func (s *BatchSizer) TargetBatchSize() int {
+	// load is the fraction of a maximum sized batch that arrives every pull interval
+	load := math.Min(1, s.arrivalRate*s.pullInterval.Seconds()/float64(s.maxBatchSize))
+	batchInterval := s.pullInterval.Seconds() + load*(s.maxBatchInterval-s.pullInterval).Seconds()
+	target := int(math.Round(s.arrivalRate * batchInterval))
+	return max(s.minBatchSize, min(s.maxBatchSize, target))
+}
+
+// ShouldDispatch returns true if a batch of the given number of blobs should be dispatched now, and false if the
+// blobs should be held back for a larger batch.
+func (s *BatchSizer) ShouldDispatch(numBlobs int, now time.Time) bool {
+	if numBlobs == 0 {
+		return false
+	}
+	if s == nil {
+		return true
+	}
+	return numBlobs >= s.TargetBatchSize() || now.Sub(s.lastDispatch) >= s.maxBatchInterval
+}
+
+// ReportDispatched updates the arrival rate with a dispatched batch of the given number of blobs.
+func (s *BatchSizer) ReportDispatched(numBlobs int, now time.Time) {
+	if s == nil {
+		return
+	}
+
+	if s.lastDispatch.IsZero() {
+		s.arrivalRate = float64(numBlobs) / s.pullInterval.Seconds()
+	} else {
+		// blobs dispatched at consecutive pulls arrived over at least a pull interval
+		elapsed := max(now.Sub(s.lastDispatch), s.pullInterval)
+		rate := float64(numBlobs) / elapsed.Seconds()
+		s.arrivalRate = arrivalRateSmoothing*rate + (1-arrivalRateSmoothing)*s.arrivalRate
+	}
+	s.lastDispatch = now
+}
@@ -0,0 +1,58 @@
+package controller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSizer(t *testing.T) {
+	_, err := controller.NewBatchSizer(0, 100, time.Second, 10*time.Second)
+	require.Error(t, err)
+	_, err = controller.NewBatchSizer(200, 100, time.Second, 10*time.Second)
+	require.Error(t, err)
+	_, err = controller.NewBatchSizer(1, 100, time.Second, 500*time.Millisecond)
+	require.Error(t, err)
+
+	sizer, err := controller.NewBatchSizer(1, 100, time.Second, 10*time.Second)
+	require.NoError(t, err)
+	now := time.Unix(1_000_000, 0)
+
+	// without load, any blob is dispatched
+	assert.Equal(t, 1, sizer.TargetBatchSize())
+	assert.False(t, sizer.ShouldDispatch(0, now))
+	assert.True(t, sizer.ShouldDispatch(1, now))
+
+	// under light load, batches are dispatched every pull
+	sizer.ReportDispatched(1, now)
+	assert.Equal(t, 1, sizer.TargetBatchSize())
+	assert.True(t, sizer.ShouldDispatch(1, now.Add(time.Second)))
+
+	// under heavy load, blobs are held back for a full batch, up to the max batch interval
+	for i := 0; i < 10; i++ {
+		now = now.Add(time.Second)
+		sizer.ReportDispatched(100, now)
+	}
+	assert.Equal(t, 100, sizer.TargetBatchSize())
+	assert.False(t, sizer.ShouldDispatch(50, now.Add(time.Second)))
+	assert.True(t, sizer.ShouldDispatch(100, now.Add(time.Second)))
+	assert.True(t, sizer.ShouldDispatch(50, now.Add(10*time.Second)))
+
+	// the target shrinks back as the load decreases
+	for i := 0; i < 10; i++ {
+		now = now.Add(10 * time.Second)
+		sizer.ReportDispatched(1, now)
+	}
+	assert.Less(t, sizer.TargetBatchSize(), 10)
+}
+
+func TestNilBatchSizer(t *testing.T) {
+	var sizer *controller.BatchSizer
+	now := time.Now()
+	assert.False(t, sizer.ShouldDispatch(0, now))
+	assert.True(t, sizer.ShouldDispatch(1, now))
+	sizer.ReportDispatched(1, now)
+}
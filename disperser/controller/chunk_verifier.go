@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrInvalidChunks is returned when the chunks stored by an encoder don't match the commitments of the blob
+var ErrInvalidChunks = errors.New("invalid chunks")
+
+type ChunkVerifierConfig struct {
+	// SamplingRate is the fraction of the chunks of each blob that are verified, between 0 and 1. At least one chunk
+	// of each blob is verified.
+	SamplingRate float64
+}
+
+// ChunkVerifier verifies a random sample of the chunks stored by the encoders against the commitments of their blob,
+// so that a corrupted encoding is caught before the blob is dispersed to the operators, who would reject its chunks.
+type ChunkVerifier struct {
+	*ChunkVerifierConfig
+
+	chunkReader chunkstore.ChunkReader
+	verifier    encoding.Verifier
+	logger      logging.Logger
+	metrics     *chunkVerifierMetrics
+}
+
+func NewChunkVerifier(
+	config *ChunkVerifierConfig,
+	chunkReader chunkstore.ChunkReader,
+	verifier encoding.Verifier,
+	logger logging.Logger,
+	registry *prometheus.Registry,
+) (*ChunkVerifier, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.SamplingRate <= 0 || config.SamplingRate > 1 {
+		return nil, fmt.Errorf("sampling rate must be in (0, 1], got %v", config.SamplingRate)
+	}
+	return &ChunkVerifier{
+		ChunkVerifierConfig: config,
+		chunkReader:         chunkReader,
+		verifier:            verifier,
+		logger:              logger.With("component", "ChunkVerifier"),
+		metrics:             newChunkVerifierMetrics(registry),
+	}, nil
+}
+
+// VerifyChunks reads the chunks stored for the blob, and verifies the proofs of a random sample of them against the
+// commitments of the blob. It fails with ErrInvalidChunks if the stored chunks don't match the commitments.
+func (v *ChunkVerifier) VerifyChunks(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	commitments encoding.BlobCommitments,
+	encodingParams encoding.EncodingParams,
+	fragmentInfo *encoding.FragmentInfo,
+) error {
+	start := time.Now()
+	numSamples, err := v.verifyChunks(ctx, blobKey, commitments, encodingParams, fragmentInfo)
+	switch {
+	case err == nil:
+		v.metrics.reportVerification(chunkVerificationValid, numSamples, time.Since(start))
+	case errors.Is(err, ErrInvalidChunks):
+		v.metrics.reportVerification(chunkVerificationInvalid, numSamples, time.Since(start))
+		v.logger.Error("encoder stored invalid chunks", "blobKey", blobKey.Hex(), "err", err)
+	default:
+		v.metrics.reportVerification(chunkVerificationError, numSamples, time.Since(start))
+		v.logger.Warn("failed to verify chunks", "blobKey", blobKey.Hex(), "err", err)
+	}
+	return err
+}
+
+// verifyChunks verifies the chunks of the blob, and returns the number of chunks verified
+func (v *ChunkVerifier) verifyChunks(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	commitments encoding.BlobCommitments,
+	encodingParams encoding.EncodingParams,
+	fragmentInfo *encoding.FragmentInfo,
+) (int, error) {
+	if fragmentInfo == nil {
+		return 0, fmt.Errorf("%w: no fragment info", ErrInvalidChunks)
+	}
+
+	// The coefficients are stored after the number of chunks, each prefixed by its number of coefficients
+	numChunks := int(encodingParams.NumChunks)
+	expectedSize := 4 + uint64(numChunks)*(4+encodingParams.ChunkLength*encoding.BYTES_PER_SYMBOL)
+	if uint64(fragmentInfo.TotalChunkSizeBytes) != expectedSize {
+		return 0, fmt.Errorf("%w: expected %d chunks of %d coefficients (%d bytes), got %d bytes",
+			ErrInvalidChunks, numChunks, encodingParams.ChunkLength, expectedSize, fragmentInfo.TotalChunkSizeBytes)
+	}
+
+	// Only the sampled chunks are downloaded
+	numSamples := int(math.Ceil(v.SamplingRate * float64(numChunks)))
+	numSamples = min(max(numSamples, 1), numChunks)
+	indices := make([]encoding.ChunkNumber, numSamples)
+	for i, index := range rand.Perm(numChunks)[:numSamples] {
+		indices[i] = encoding.ChunkNumber(index)
+	}
+	frames, err := v.chunkReader.GetFrames(ctx, blobKey, fragmentInfo, encodingParams.ChunkLength, indices)
+	if errors.Is(err, chunkstore.ErrMalformedChunk) {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidChunks, err)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get chunks: %w", err)
+	}
+
+	err = v.verifier.VerifyFrames(frames, indices, commitments, encodingParams)
+	if err != nil {
+		return numSamples, fmt.Errorf("%w: %w", ErrInvalidChunks, err)
+	}
+	return numSamples, nil
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const chunkVerifierNamespace = "eigenda_chunk_verifier"
+
+const (
+	chunkVerificationValid   = "valid"
+	chunkVerificationInvalid = "invalid"
+	chunkVerificationError   = "error"
+)
+
+// chunkVerifierMetrics is a struct that holds the metrics for the chunk verifier.
+type chunkVerifierMetrics struct {
+	verifications       *prometheus.CounterVec
+	verifiedChunks      *prometheus.CounterVec
+	verificationLatency *prometheus.SummaryVec
+}
+
+// newChunkVerifierMetrics sets up metrics for the chunk verifier.
+func newChunkVerifierMetrics(registry *prometheus.Registry) *chunkVerifierMetrics {
+	verifications := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: chunkVerifierNamespace,
+			Name:      "verifications_total",
+			Help:      "The number of blobs whose chunks were verified, by result (valid, invalid or error). Invalid chunks indicate a faulty encoder.",
+		},
+		[]string{"result"},
+	)
+
+	verifiedChunks := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: chunkVerifierNamespace,
+			Name:      "verified_chunks_total",
+			Help:      "The number of chunks sampled for verification.",
+		},
+		[]string{},
+	)
+
+	verificationLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  chunkVerifierNamespace,
+			Name:       "verification_latency_ms",
+			Help:       "The time required to read and verify the sampled chunks of a blob.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{},
+	)
+
+	return &chunkVerifierMetrics{
+		verifications:       verifications,
+		verifiedChunks:      verifiedChunks,
+		verificationLatency: verificationLatency,
+	}
+}
+
+func (m *chunkVerifierMetrics) reportVerification(result string, numChunks int, duration time.Duration) {
+	m.verifications.WithLabelValues(result).Inc()
+	m.verifiedChunks.WithLabelValues().Add(float64(numChunks))
+	m.verificationLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
@@ -0,0 +1,128 @@
+package controller_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkVerifier(t *testing.T) {
+	ctx := context.Background()
+	kzgConfig := &kzg.KzgConfig{
+		G1Path:          "../../inabox/resources/kzg/g1.point.300000",
+		G2Path:          "../../inabox/resources/kzg/g2.point.300000",
+		CacheDir:        "../../inabox/resources/kzg/SRSTables",
+		SRSOrder:        8192,
+		SRSNumberToLoad: 8192,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		LoadG2Points:    true,
+	}
+	p, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+	v, err := verifier.NewVerifier(kzgConfig, nil)
+	require.NoError(t, err)
+
+	encodingParams := encoding.EncodingParams{ChunkLength: 8, NumChunks: 8}
+	data := make([]byte, 32*encoding.BYTES_PER_SYMBOL)
+	for i := range data {
+		if i%encoding.BYTES_PER_SYMBOL != 0 {
+			data[i] = byte(i)
+		}
+	}
+	commitments, frames, err := p.EncodeAndProve(data, encodingParams)
+	require.NoError(t, err)
+
+	s3Client := mock.NewS3Client()
+	chunkWriter := chunkstore.NewChunkWriter(logger, s3Client, s3BucketName, 512*1024)
+	chunkReader := chunkstore.NewChunkReader(logger, s3Client, s3BucketName)
+
+	// storeChunks stores the frames of the blob, as an encoder would
+	storeChunks := func(blobKey corev2.BlobKey, frames []*encoding.Frame) *encoding.FragmentInfo {
+		proofs := make([]*encoding.Proof, len(frames))
+		coeffs := make([]*rs.Frame, len(frames))
+		for i, frame := range frames {
+			proofs[i] = &frame.Proof
+			coeffs[i] = &rs.Frame{Coeffs: frame.Coeffs}
+		}
+		require.NoError(t, chunkWriter.PutChunkProofs(ctx, blobKey, proofs))
+		fragmentInfo, err := chunkWriter.PutChunkCoefficients(ctx, blobKey, coeffs)
+		require.NoError(t, err)
+		return fragmentInfo
+	}
+
+	_, err = controller.NewChunkVerifier(&controller.ChunkVerifierConfig{SamplingRate: 0}, chunkReader, v, logger, prometheus.NewRegistry())
+	require.Error(t, err)
+	_, err = controller.NewChunkVerifier(&controller.ChunkVerifierConfig{SamplingRate: 1.5}, chunkReader, v, logger, prometheus.NewRegistry())
+	require.Error(t, err)
+	chunkVerifier, err := controller.NewChunkVerifier(&controller.ChunkVerifierConfig{SamplingRate: 1}, chunkReader, v, logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	t.Run("valid chunks", func(t *testing.T) {
+		blobKey := corev2.BlobKey{1}
+		fragmentInfo := storeChunks(blobKey, frames)
+		err := chunkVerifier.VerifyChunks(ctx, blobKey, commitments, encodingParams, fragmentInfo)
+		require.NoError(t, err)
+	})
+
+	t.Run("corrupted chunk", func(t *testing.T) {
+		blobKey := corev2.BlobKey{2}
+		corrupted := make([]*encoding.Frame, len(frames))
+		copy(corrupted, frames)
+		coeffs := make([]encoding.Symbol, len(frames[3].Coeffs))
+		copy(coeffs, frames[3].Coeffs)
+		coeffs[0].SetUint64(42)
+		corrupted[3] = &encoding.Frame{Proof: frames[3].Proof, Coeffs: coeffs}
+		fragmentInfo := storeChunks(blobKey, corrupted)
+
+		err := chunkVerifier.VerifyChunks(ctx, blobKey, commitments, encodingParams, fragmentInfo)
+		require.ErrorIs(t, err, controller.ErrInvalidChunks)
+	})
+
+	t.Run("missing chunks", func(t *testing.T) {
+		blobKey := corev2.BlobKey{3}
+		fragmentInfo := storeChunks(blobKey, frames[:len(frames)-1])
+		err := chunkVerifier.VerifyChunks(ctx, blobKey, commitments, encodingParams, fragmentInfo)
+		require.ErrorIs(t, err, controller.ErrInvalidChunks)
+	})
+
+	t.Run("chunks not stored", func(t *testing.T) {
+		blobKey := corev2.BlobKey{4}
+		err := chunkVerifier.VerifyChunks(ctx, blobKey, commitments, encodingParams, &encoding.FragmentInfo{
+			TotalChunkSizeBytes: 4 + 8*(4+8*encoding.BYTES_PER_SYMBOL),
+			FragmentSizeBytes:   512 * 1024,
+		})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, controller.ErrInvalidChunks)
+	})
+
+	t.Run("sampled chunks", func(t *testing.T) {
+		// every chunk is corrupted, so the single chunk sampled at a low rate is enough to catch it
+		sampler, err := controller.NewChunkVerifier(&controller.ChunkVerifierConfig{SamplingRate: 0.01}, chunkReader, v, logger, prometheus.NewRegistry())
+		require.NoError(t, err)
+
+		blobKey := corev2.BlobKey{5}
+		corrupted := make([]*encoding.Frame, len(frames))
+		for i := range frames {
+			corrupted[i] = &encoding.Frame{Proof: frames[(i+1)%len(frames)].Proof, Coeffs: frames[i].Coeffs}
+		}
+		fragmentInfo := storeChunks(blobKey, corrupted)
+		err = sampler.VerifyChunks(ctx, blobKey, commitments, encodingParams, fragmentInfo)
+		require.ErrorIs(t, err, controller.ErrInvalidChunks)
+
+		// only the sampled chunk is downloaded, rather than all the chunks of the blob
+		require.Zero(t, s3Client.Called["DownloadObject"])
+		require.Zero(t, s3Client.Called["FragmentedDownloadObject"])
+	})
+}
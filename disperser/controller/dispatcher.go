@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -20,16 +23,58 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-var errNoBlobsToDispatch = errors.New("no blobs to dispatch")
+var (
+	errNoBlobsToDispatch = errors.New("no blobs to dispatch")
+	errBatchHeldBack     = errors.New("blobs held back for a larger batch")
+
+	errOperatorAtConcurrencyLimit = errors.New("operator has too many requests in flight")
+	errOperatorCircuitOpen        = errors.New("operator circuit breaker is open")
+)
 
 type DispatcherConfig struct {
 	PullInterval time.Duration
 
 	FinalizationBlockDelay uint64
-	NodeRequestTimeout     time.Duration
-	NumRequestRetries      int
+	// NodeRequestTimeout is the timeout of each attempt to send chunks to an operator
+	NodeRequestTimeout time.Duration
+	// NumRequestRetries is the number of times sending chunks to an operator is retried after the first attempt fails.
+	// The retries let an operator that missed the first attempt, e.g. while it restarted, download its chunks from the
+	// relays and still sign the batch, so together with the backoff they should span the time batches are attested in.
+	NumRequestRetries int
+	// RetryInitialBackoff is the wait before the first retry to an operator. Zero retries immediately.
+	RetryInitialBackoff time.Duration
+	// RetryBackoffMultiplier is the factor the wait grows by with every retry to an operator
+	RetryBackoffMultiplier float64
+	// RetryMaxBackoff caps the wait between retries to an operator. Zero leaves the wait uncapped.
+	RetryMaxBackoff time.Duration
 	// MaxBatchSize is the maximum number of blobs to dispatch in a batch
 	MaxBatchSize int32
+	// MinBatchSize is the number of blobs batches are held back for under light load. Zero disables dynamic batch
+	// sizing, in which case the available blobs are dispatched every PullInterval.
+	MinBatchSize int32
+	// MaxBatchInterval is the longest time blobs are held back for to fill a batch when dynamic batch sizing is enabled
+	MaxBatchInterval time.Duration
+	// MaxOperatorConcurrency is the most dispersal requests that may be in flight to an operator. Zero disables
+	// adaptive operator concurrency, in which case requests to an operator are not limited.
+	MaxOperatorConcurrency int
+	// InitialOperatorConcurrency is the number of dispersal requests that may be in flight to an operator before
+	// its limit is adapted to how it responds
+	InitialOperatorConcurrency int
+	// EnableEarlyFinalization attests a batch as soon as the stake signed for each of its quorums reaches the
+	// confirmation thresholds of its blobs, instead of waiting for every operator to respond
+	EnableEarlyFinalization bool
+	// EarlyFinalizationMinWait is the least time signatures are gathered for before a batch is attested early, so that
+	// the operators responding shortly after the thresholds are reached are still counted as signers
+	EarlyFinalizationMinWait time.Duration
+	// EarlyFinalizationThreshold is the confirmation threshold of the quorums of blobs without custom security
+	// thresholds, as a percentage of the quorum stake
+	EarlyFinalizationThreshold uint8
+	// CircuitBreakerFailureThreshold is the number of consecutive failed requests to an operator after which it isn't
+	// sent batches until the circuit breaker cooldown passes. Zero disables the circuit breaker.
+	CircuitBreakerFailureThreshold int
+	// CircuitBreakerCooldown is how long an operator isn't sent batches for once its circuit breaker opens, before a
+	// single batch is sent to probe whether it recovered
+	CircuitBreakerCooldown time.Duration
 }
 
 type Dispatcher struct {
@@ -40,17 +85,28 @@ type Dispatcher struct {
 	chainState        core.IndexedChainState
 	aggregator        core.SignatureAggregator
 	nodeClientManager NodeClientManager
+	statusNotifier    *StatusNotifier
 	logger            logging.Logger
 	metrics           *dispatcherMetrics
 
-	cursor *blobstore.StatusIndexCursor
+	cursor             *blobstore.StatusIndexCursor
+	batchSizer         *BatchSizer
+	concurrencyLimiter *OperatorConcurrencyLimiter
+	circuitBreaker     *OperatorCircuitBreaker
+
+	// recoveringBlobs are the blobs of the in-progress batches being resumed after a restart, which are left out of
+	// new batches
+	recoveringMu    sync.Mutex
+	recoveringBlobs map[corev2.BlobKey]struct{}
 }
 
 type batchData struct {
 	Batch           *corev2.Batch
 	BatchHeaderHash [32]byte
 	BlobKeys        []corev2.BlobKey
-	OperatorState   *core.IndexedOperatorState
+	// BlobMetadatas are the metadata of the blobs in the batch, in the same order as BlobKeys
+	BlobMetadatas []*v2.BlobMetadata
+	OperatorState *core.IndexedOperatorState
 }
 
 func NewDispatcher(
@@ -60,6 +116,7 @@ func NewDispatcher(
 	chainState core.IndexedChainState,
 	aggregator core.SignatureAggregator,
 	nodeClientManager NodeClientManager,
+	statusNotifier *StatusNotifier,
 	logger logging.Logger,
 	registry *prometheus.Registry,
 ) (*Dispatcher, error) {
@@ -69,6 +126,44 @@ func NewDispatcher(
 	if config.PullInterval == 0 || config.NodeRequestTimeout == 0 || config.MaxBatchSize == 0 {
 		return nil, errors.New("invalid config")
 	}
+	if config.NumRequestRetries < 0 || config.RetryInitialBackoff < 0 || config.RetryMaxBackoff < 0 {
+		return nil, errors.New("invalid retry config: retries and backoffs must not be negative")
+	}
+	if config.RetryInitialBackoff > 0 && config.RetryBackoffMultiplier < 1 {
+		return nil, errors.New("invalid retry config: backoff multiplier must be at least 1")
+	}
+	if config.EnableEarlyFinalization {
+		if config.EarlyFinalizationThreshold == 0 || config.EarlyFinalizationThreshold > 100 {
+			return nil, errors.New("invalid early finalization config: threshold must be between 1 and 100")
+		}
+		if config.EarlyFinalizationMinWait < 0 {
+			return nil, errors.New("invalid early finalization config: min wait must not be negative")
+		}
+	}
+	var batchSizer *BatchSizer
+	if config.MinBatchSize > 0 {
+		var err error
+		batchSizer, err = NewBatchSizer(int(config.MinBatchSize), int(config.MaxBatchSize), config.PullInterval, config.MaxBatchInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid batch sizing config: %w", err)
+		}
+	}
+	var concurrencyLimiter *OperatorConcurrencyLimiter
+	if config.MaxOperatorConcurrency > 0 {
+		var err error
+		concurrencyLimiter, err = NewOperatorConcurrencyLimiter(config.InitialOperatorConcurrency, config.MaxOperatorConcurrency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operator concurrency config: %w", err)
+		}
+	}
+	var circuitBreaker *OperatorCircuitBreaker
+	if config.CircuitBreakerFailureThreshold > 0 {
+		var err error
+		circuitBreaker, err = NewOperatorCircuitBreaker(config.CircuitBreakerFailureThreshold, config.CircuitBreakerCooldown)
+		if err != nil {
+			return nil, fmt.Errorf("invalid circuit breaker config: %w", err)
+		}
+	}
 	return &Dispatcher{
 		DispatcherConfig: config,
 
@@ -77,10 +172,15 @@ func NewDispatcher(
 		chainState:        chainState,
 		aggregator:        aggregator,
 		nodeClientManager: nodeClientManager,
+		statusNotifier:    statusNotifier,
 		logger:            logger.With("component", "Dispatcher"),
 		metrics:           newDispatcherMetrics(registry),
 
-		cursor: nil,
+		cursor:             nil,
+		batchSizer:         batchSizer,
+		recoveringBlobs:    make(map[corev2.BlobKey]struct{}),
+		concurrencyLimiter: concurrencyLimiter,
+		circuitBreaker:     circuitBreaker,
 	}, nil
 }
 
@@ -90,6 +190,11 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start chain state: %w", err)
 	}
 
+	// The in-progress batches are claimed before new batches are made, since their blobs are still encoded, and are
+	// resumed in the background so that new batches aren't held up by them
+	recovering := d.claimInProgressBatches(ctx)
+	go d.resumeBatches(ctx, recovering)
+
 	go func() {
 		ticker := time.NewTicker(d.PullInterval)
 		defer ticker.Stop()
@@ -100,8 +205,8 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 			case <-ticker.C:
 				sigChan, batchData, err := d.HandleBatch(ctx)
 				if err != nil {
-					if errors.Is(err, errNoBlobsToDispatch) {
-						d.logger.Debug("no blobs to dispatch")
+					if errors.Is(err, errNoBlobsToDispatch) || errors.Is(err, errBatchHeldBack) {
+						d.logger.Debug(err.Error())
 					} else {
 						d.logger.Error("failed to process a batch", "err", err)
 					}
@@ -112,7 +217,8 @@ func (d *Dispatcher) Start(ctx context.Context) error {
 					if err != nil {
 						d.logger.Error("failed to handle signatures", "err", err)
 					}
-					close(sigChan)
+					// sigChan isn't closed, since the operators that respond after the batch is attested early
+					// still send on it
 					// TODO(ian-shim): handle errors and mark failed
 				}()
 			}
@@ -142,15 +248,35 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 		return nil, nil, err
 	}
 
+	sigChan, err := d.dispatchBatch(ctx, batchData, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sigChan, batchData, nil
+}
+
+// dispatchBatch sends the batch to its operators, and returns the channel their signatures are received on.
+// The signatures already received from some operators, e.g. before the controller restarted, are passed on to the
+// channel without sending the batch to these operators again.
+func (d *Dispatcher) dispatchBatch(ctx context.Context, batchData *batchData, receivedSignatures map[core.OperatorID]*core.Signature) (chan core.SigningMessage, error) {
 	batch := batchData.Batch
 	state := batchData.OperatorState
 	sigChan := make(chan core.SigningMessage, len(state.IndexedOperators))
 	for opID, op := range state.IndexedOperators {
 		opID := opID
 		op := op
-		host, dispersalPort, _, err := core.ParseOperatorSocket(op.Socket)
+		if sig, ok := receivedSignatures[opID]; ok {
+			sigChan <- core.SigningMessage{
+				Signature:       sig,
+				Operator:        opID,
+				BatchHeaderHash: batchData.BatchHeaderHash,
+			}
+			continue
+		}
+
+		host, dispersalPort, err := net.SplitHostPort(core.OperatorSocket(op.Socket).GetV2DispersalSocket())
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to parse operator socket: %w", err)
+			return nil, fmt.Errorf("failed to parse operator socket %s: %w", op.Socket, err)
 		}
 
 		client, err := d.nodeClientManager.GetClient(host, dispersalPort)
@@ -159,6 +285,18 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 			continue
 		}
 
+		if !d.circuitBreaker.Allow(opID, time.Now()) {
+			// the operator keeps failing, so it can't contribute a signature to the batch anyway
+			d.metrics.reportOperatorCircuitBreakerSkip(opID)
+			sigChan <- core.SigningMessage{
+				Signature:       nil,
+				Operator:        opID,
+				BatchHeaderHash: batchData.BatchHeaderHash,
+				Err:             errOperatorCircuitOpen,
+			}
+			continue
+		}
+
 		submissionStart := time.Now()
 
 		d.pool.Submit(func() {
@@ -173,7 +311,8 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 			}
 			putDispersalRequestStart := time.Now()
 			err := d.blobMetadataStore.PutDispersalRequest(ctx, req)
-			if err != nil {
+			// the request already exists if the batch was dispatched to the operator before the controller restarted
+			if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
 				d.logger.Error("failed to put dispersal request", "err", err)
 				sigChan <- core.SigningMessage{
 					Signature:            nil,
@@ -190,11 +329,36 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 			var i int
 			var lastErr error
 			for i = 0; i < d.NumRequestRetries+1; i++ {
+				if i > 0 && !d.circuitBreaker.Allow(opID, time.Now()) {
+					// the circuit opened while retrying
+					lastErr = errOperatorCircuitOpen
+					d.metrics.reportOperatorCircuitBreakerSkip(opID)
+					break
+				}
+				if !d.concurrencyLimiter.TryAcquire(opID) {
+					// the operator is slower than the batches are dispatched, so the batch isn't sent to it rather
+					// than waiting behind its requests for previous batches
+					lastErr = errOperatorAtConcurrencyLimit
+					d.metrics.reportOperatorRequestShed(opID)
+					break
+				}
 				sendChunksStart := time.Now()
 				sig, err := d.sendChunks(ctx, client, batch)
 				lastErr = err
 				sendChunksFinished := time.Now()
+				if d.concurrencyLimiter != nil {
+					d.concurrencyLimiter.Release(opID, sendChunksFinished.Sub(sendChunksStart), err)
+					d.metrics.reportOperatorConcurrencyLimit(opID, d.concurrencyLimiter.Limit(opID))
+				}
 				d.metrics.reportSendChunksLatency(sendChunksFinished.Sub(sendChunksStart))
+				if d.circuitBreaker != nil {
+					if err == nil {
+						d.circuitBreaker.RecordSuccess(opID)
+					} else if ctx.Err() == nil {
+						d.circuitBreaker.RecordFailure(opID, sendChunksFinished)
+					}
+					d.metrics.reportOperatorCircuitState(opID, d.circuitBreaker.State(opID))
+				}
 				if err == nil {
 					storeErr := d.blobMetadataStore.PutDispersalResponse(ctx, &corev2.DispersalResponse{
 						DispersalRequest: req,
@@ -219,11 +383,20 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 				}
 
 				d.logger.Warn("failed to send chunks", "operator", opID.Hex(), "NumAttempts", i, "batchHeader", hex.EncodeToString(batchData.BatchHeaderHash[:]), "err", err)
-				time.Sleep(time.Duration(math.Pow(2, float64(i))) * time.Second) // Wait before retrying
+				if i == d.NumRequestRetries {
+					break
+				}
+				d.metrics.reportOperatorSendChunksRetry(opID)
+				// Wait before retrying
+				if err := sleepContext(ctx, d.retryBackoff(i)); err != nil {
+					lastErr = err
+					break
+				}
 			}
 
 			if lastErr != nil {
 				d.logger.Error("failed to send chunks", "operator", opID.Hex(), "NumAttempts", i, "batchHeader", hex.EncodeToString(batchData.BatchHeaderHash[:]), "err", lastErr)
+				d.metrics.reportOperatorSendChunksFailure(opID)
 				sigChan <- core.SigningMessage{
 					Signature:            nil,
 					Operator:             opID,
@@ -238,7 +411,7 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 		d.metrics.reportPoolSubmissionLatency(time.Since(submissionStart))
 	}
 
-	return sigChan, batchData, nil
+	return sigChan, nil
 }
 
 // HandleSignatures receives signatures from operators, validates, and aggregates them
@@ -250,9 +423,22 @@ func (d *Dispatcher) HandleSignatures(ctx context.Context, batchData *batchData,
 	defer func() {
 		d.metrics.reportHandleSignaturesLatency(time.Since(handleSignaturesStart))
 	}()
+	defer func() {
+		// the batch is kept in progress if the controller is stopping, so that it's resumed after the restart
+		if ctx.Err() != nil {
+			return
+		}
+		d.deleteInProgressBatch(ctx, batchData.BatchHeaderHash)
+	}()
 
 	batchHeaderHash := hex.EncodeToString(batchData.BatchHeaderHash[:])
-	quorumAttestation, err := d.aggregator.ReceiveSignatures(ctx, batchData.OperatorState, batchData.BatchHeaderHash, sigChan)
+	var quorumAttestation *core.QuorumAttestation
+	var err error
+	if d.EnableEarlyFinalization {
+		quorumAttestation, err = d.aggregator.ReceiveSignaturesUntilThresholds(ctx, batchData.OperatorState, batchData.BatchHeaderHash, sigChan, d.confirmationThresholds(batchData), d.EarlyFinalizationMinWait)
+	} else {
+		quorumAttestation, err = d.aggregator.ReceiveSignatures(ctx, batchData.OperatorState, batchData.BatchHeaderHash, sigChan)
+	}
 	if err != nil {
 		dbErr := d.failBatch(ctx, batchData)
 		if dbErr != nil {
@@ -262,6 +448,9 @@ func (d *Dispatcher) HandleSignatures(ctx context.Context, batchData *batchData,
 	}
 	receiveSignaturesFinished := time.Now()
 	d.metrics.reportReceiveSignaturesLatency(receiveSignaturesFinished.Sub(handleSignaturesStart))
+	if quorumAttestation.NumReplies < len(batchData.OperatorState.IndexedOperators) {
+		d.metrics.reportEarlyFinalizedBatch()
+	}
 
 	nonZeroQuorums := make([]core.QuorumID, 0)
 	quorumResults := make(map[core.QuorumID]uint8)
@@ -323,6 +512,70 @@ func (d *Dispatcher) HandleSignatures(ctx context.Context, batchData *batchData,
 	return nil
 }
 
+// ValidateEarlyFinalizationThreshold checks that the early finalization threshold is at least the confirmation
+// threshold of every quorum on chain at the current block, since a batch attested below it doesn't certify its blobs.
+func ValidateEarlyFinalizationThreshold(ctx context.Context, reader core.Reader, threshold uint8) error {
+	blockNumber, err := reader.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	securityParams, err := reader.GetQuorumSecurityParams(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get quorum security params: %w", err)
+	}
+	for _, param := range securityParams {
+		if threshold < param.ConfirmationThreshold {
+			return fmt.Errorf("early finalization threshold %d is below the confirmation threshold %d of quorum %d", threshold, param.ConfirmationThreshold, param.QuorumID)
+		}
+	}
+	return nil
+}
+
+// confirmationThresholds returns the stake percentage that must sign for each quorum of the batch for all its blobs
+// to be certified, which is the highest confirmation threshold of the blobs in the quorum. Custom thresholds can only
+// raise the early finalization threshold, never lower it.
+func (d *Dispatcher) confirmationThresholds(batchData *batchData) map[core.QuorumID]uint8 {
+	thresholds := make(map[core.QuorumID]uint8)
+	for _, cert := range batchData.Batch.BlobCertificates {
+		if cert == nil || cert.BlobHeader == nil {
+			continue
+		}
+		for _, q := range cert.BlobHeader.QuorumNumbers {
+			threshold := d.EarlyFinalizationThreshold
+			if custom, ok := cert.BlobHeader.GetSecurityThresholds(q); ok {
+				threshold = max(threshold, custom.ConfirmationThreshold)
+			}
+			thresholds[q] = max(thresholds[q], threshold)
+		}
+	}
+	return thresholds
+}
+
+// excludeRecoveringBlobs leaves out the blobs of the in-progress batches being resumed
+func (d *Dispatcher) excludeRecoveringBlobs(metadatas []*v2.BlobMetadata) ([]*v2.BlobMetadata, error) {
+	d.recoveringMu.Lock()
+	numRecovering := len(d.recoveringBlobs)
+	d.recoveringMu.Unlock()
+	if numRecovering == 0 {
+		return metadatas, nil
+	}
+
+	filtered := make([]*v2.BlobMetadata, 0, len(metadatas))
+	for _, metadata := range metadatas {
+		if metadata == nil || metadata.BlobHeader == nil {
+			return nil, fmt.Errorf("invalid blob metadata")
+		}
+		blobKey, err := metadata.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key: %w", err)
+		}
+		if !d.isRecovering(blobKey) {
+			filtered = append(filtered, metadata)
+		}
+	}
+	return filtered, nil
+}
+
 // NewBatch creates a batch of blobs to dispatch
 // Warning: This function is not thread-safe
 func (d *Dispatcher) NewBatch(ctx context.Context, referenceBlockNumber uint64) (*batchData, error) {
@@ -338,9 +591,22 @@ func (d *Dispatcher) NewBatch(ctx context.Context, referenceBlockNumber uint64)
 		return nil, fmt.Errorf("failed to get blob metadata by status: %w", err)
 	}
 
+	blobMetadatas, err = d.excludeRecoveringBlobs(blobMetadatas)
+	if err != nil {
+		return nil, err
+	}
 	if len(blobMetadatas) == 0 {
+		if cursor != nil {
+			// the blobs of the fetched page are all being resumed in their in-progress batches
+			d.cursor = cursor
+		}
 		return nil, errNoBlobsToDispatch
 	}
+	if !d.batchSizer.ShouldDispatch(len(blobMetadatas), newBatchStart) {
+		// the cursor isn't advanced, so the blobs are fetched again at the next pull
+		d.metrics.reportBatchHeldBack()
+		return nil, errBatchHeldBack
+	}
 	d.logger.Debug("got new metadatas to make batch", "numBlobs", len(blobMetadatas), "referenceBlockNumber", referenceBlockNumber)
 
 	state, err := d.GetOperatorState(ctx, blobMetadatas, referenceBlockNumber)
@@ -369,27 +635,9 @@ func (d *Dispatcher) NewBatch(ctx context.Context, referenceBlockNumber uint64)
 		return nil, fmt.Errorf("failed to get blob certificates: %w", err)
 	}
 
-	if len(certs) != len(keys) {
-		return nil, fmt.Errorf("blob certificates (%d) not found for all blob keys (%d)", len(certs), len(keys))
-	}
-
-	certsMap := make(map[corev2.BlobKey]*corev2.BlobCertificate, len(certs))
-	for _, cert := range certs {
-		blobKey, err := cert.BlobHeader.BlobKey()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get blob key: %w", err)
-		}
-
-		certsMap[blobKey] = cert
-	}
-
-	// Keep the order of certs the same as the order of keys
-	for i, key := range keys {
-		c, ok := certsMap[key]
-		if !ok {
-			return nil, fmt.Errorf("blob certificate not found for blob key %s", key.Hex())
-		}
-		certs[i] = c
+	certs, err = orderBlobCertificates(certs, keys)
+	if err != nil {
+		return nil, err
 	}
 
 	batchHeader := &corev2.BatchHeader{
@@ -459,9 +707,23 @@ func (d *Dispatcher) NewBatch(ctx context.Context, referenceBlockNumber uint64)
 		return nil, fmt.Errorf("failed to put blob verification infos: %w", err)
 	}
 
+	err = d.blobMetadataStore.PutInProgressBatch(ctx, &v2.InProgressBatch{
+		BatchHeaderHash: batchHeaderHash,
+		BlobKeys:        keys,
+		CreatedAt:       uint64(time.Now().UnixNano()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to put in-progress batch: %w", err)
+	}
+
 	if cursor != nil {
 		d.cursor = cursor
 	}
+	d.batchSizer.ReportDispatched(len(certs), time.Now())
+	d.metrics.reportBatchSize(len(certs))
+	if d.batchSizer != nil {
+		d.metrics.reportTargetBatchSize(d.batchSizer.TargetBatchSize())
+	}
 
 	d.logger.Debug("new batch", "referenceBlockNumber", referenceBlockNumber, "numBlobs", len(certs))
 	return &batchData{
@@ -471,11 +733,39 @@ func (d *Dispatcher) NewBatch(ctx context.Context, referenceBlockNumber uint64)
 		},
 		BatchHeaderHash: batchHeaderHash,
 		BlobKeys:        keys,
+		BlobMetadatas:   blobMetadatas,
 		OperatorState:   state,
 	}, nil
 }
 
 // GetOperatorState returns the operator state for the given quorums at the given block number
+// orderBlobCertificates returns the certificates in the order of the given blob keys
+func orderBlobCertificates(certs []*corev2.BlobCertificate, keys []corev2.BlobKey) ([]*corev2.BlobCertificate, error) {
+	if len(certs) != len(keys) {
+		return nil, fmt.Errorf("blob certificates (%d) not found for all blob keys (%d)", len(certs), len(keys))
+	}
+
+	certsMap := make(map[corev2.BlobKey]*corev2.BlobCertificate, len(certs))
+	for _, cert := range certs {
+		blobKey, err := cert.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key: %w", err)
+		}
+
+		certsMap[blobKey] = cert
+	}
+
+	ordered := make([]*corev2.BlobCertificate, len(keys))
+	for i, key := range keys {
+		c, ok := certsMap[key]
+		if !ok {
+			return nil, fmt.Errorf("blob certificate not found for blob key %s", key.Hex())
+		}
+		ordered[i] = c
+	}
+	return ordered, nil
+}
+
 func (d *Dispatcher) GetOperatorState(ctx context.Context, metadatas []*v2.BlobMetadata, blockNumber uint64) (*core.IndexedOperatorState, error) {
 	quorums := make(map[core.QuorumID]struct{}, 0)
 	for _, m := range metadatas {
@@ -507,6 +797,30 @@ func (d *Dispatcher) sendChunks(ctx context.Context, client clients.NodeClient,
 	return sig, nil
 }
 
+// retryBackoff returns the wait after the given failed attempt to send chunks to an operator, counting from 0
+func (d *Dispatcher) retryBackoff(attempt int) time.Duration {
+	backoff := float64(d.RetryInitialBackoff) * math.Pow(d.RetryBackoffMultiplier, float64(attempt))
+	if d.RetryMaxBackoff > 0 && backoff > float64(d.RetryMaxBackoff) {
+		return d.RetryMaxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// sleepContext waits for the given duration, or until the context is done
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (d *Dispatcher) updateBatchStatus(ctx context.Context, batch *batchData, quorumResults map[core.QuorumID]uint8) error {
 	var multierr error
 	for i, cert := range batch.Batch.BlobCertificates {
@@ -516,31 +830,44 @@ func (d *Dispatcher) updateBatchStatus(ctx context.Context, batch *batchData, qu
 			err := d.blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.Failed)
 			if err != nil {
 				multierr = multierror.Append(multierr, fmt.Errorf("failed to update blob status for blob %s to failed: %w", blobKey.Hex(), err))
+				continue
 			}
+			d.statusNotifier.Notify(blobKey, batch.BlobMetadatas[i], v2.Failed)
 			continue
 		}
 
 		failed := false
 		for _, q := range cert.BlobHeader.QuorumNumbers {
-			if res, ok := quorumResults[q]; !ok || res == 0 {
+			res, ok := quorumResults[q]
+			if !ok || res == 0 {
 				d.logger.Error("quorum result not found", "quorumID", q, "blobKey", blobKey.Hex())
 				failed = true
 				break
 			}
+			// Blobs with custom security thresholds are only certified if they are met
+			if thresholds, ok := cert.BlobHeader.GetSecurityThresholds(q); ok && res < thresholds.ConfirmationThreshold {
+				d.logger.Warn("quorum result below the confirmation threshold of the blob", "quorumID", q, "blobKey", blobKey.Hex(), "percentSigned", res, "confirmationThreshold", thresholds.ConfirmationThreshold)
+				failed = true
+				break
+			}
 		}
 
 		if failed {
 			err := d.blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.InsufficientSignatures)
 			if err != nil {
 				multierr = multierror.Append(multierr, fmt.Errorf("failed to update blob status for blob %s to failed: %w", blobKey.Hex(), err))
+				continue
 			}
+			d.statusNotifier.Notify(blobKey, batch.BlobMetadatas[i], v2.InsufficientSignatures)
 			continue
 		}
 
 		err := d.blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.Certified)
 		if err != nil {
 			multierr = multierror.Append(multierr, fmt.Errorf("failed to update blob status for blob %s to certified: %w", blobKey.Hex(), err))
+			continue
 		}
+		d.statusNotifier.Notify(blobKey, batch.BlobMetadatas[i], v2.Certified)
 	}
 
 	return multierr
@@ -548,11 +875,13 @@ func (d *Dispatcher) updateBatchStatus(ctx context.Context, batch *batchData, qu
 
 func (d *Dispatcher) failBatch(ctx context.Context, batch *batchData) error {
 	var multierr error
-	for _, blobKey := range batch.BlobKeys {
+	for i, blobKey := range batch.BlobKeys {
 		err := d.blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.Failed)
 		if err != nil {
 			multierr = multierror.Append(multierr, fmt.Errorf("failed to update blob status for blob %s to failed: %w", blobKey.Hex(), err))
+			continue
 		}
+		d.statusNotifier.Notify(blobKey, batch.BlobMetadatas[i], v2.Failed)
 	}
 
 	return multierr
@@ -1,10 +1,12 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"time"
 )
 
 const dispatcherNamespace = "eigenda_dispatcher"
@@ -24,6 +26,12 @@ type dispatcherMetrics struct {
 	putDispersalRequestLatency  *prometheus.SummaryVec
 	sendChunksLatency           *prometheus.SummaryVec
 	sendChunksRetryCount        *prometheus.GaugeVec
+	operatorSendChunksRetries   *prometheus.CounterVec
+	operatorSendChunksFailures  *prometheus.CounterVec
+	operatorConcurrencyLimit    *prometheus.GaugeVec
+	operatorRequestsShed        *prometheus.CounterVec
+	operatorCircuitState        *prometheus.GaugeVec
+	operatorCircuitBreakerSkips *prometheus.CounterVec
 	putDispersalResponseLatency *prometheus.SummaryVec
 
 	handleSignaturesLatency    *prometheus.SummaryVec
@@ -31,6 +39,14 @@ type dispatcherMetrics struct {
 	aggregateSignaturesLatency *prometheus.SummaryVec
 	putAttestationLatency      *prometheus.SummaryVec
 	updateBatchStatusLatency   *prometheus.SummaryVec
+
+	batchSize       *prometheus.SummaryVec
+	targetBatchSize *prometheus.GaugeVec
+	heldBackBatches *prometheus.CounterVec
+
+	recoveredBatches *prometheus.CounterVec
+
+	earlyFinalizedBatches *prometheus.CounterVec
 }
 
 // NewDispatcherMetrics sets up metrics for the dispatcher.
@@ -166,6 +182,60 @@ func newDispatcherMetrics(registry *prometheus.Registry) *dispatcherMetrics {
 		[]string{},
 	)
 
+	operatorSendChunksRetries := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "operator_send_chunks_retries_total",
+			Help:      "The number of times sending chunks to an operator was retried.",
+		},
+		[]string{"operator_id"},
+	)
+
+	operatorSendChunksFailures := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "operator_send_chunks_failures_total",
+			Help:      "The number of batches that failed to be sent to an operator after all retries.",
+		},
+		[]string{"operator_id"},
+	)
+
+	operatorConcurrencyLimit := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "operator_concurrency_limit",
+			Help:      "The number of dispersal requests that may be in flight to an operator.",
+		},
+		[]string{"operator_id"},
+	)
+
+	operatorRequestsShed := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "operator_requests_shed_total",
+			Help:      "The number of batches not sent to an operator because it had too many requests in flight.",
+		},
+		[]string{"operator_id"},
+	)
+
+	operatorCircuitState := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "operator_circuit_state",
+			Help:      "The state of the circuit breaker of an operator: 0 is closed, 1 is open and 2 is half-open.",
+		},
+		[]string{"operator_id"},
+	)
+
+	operatorCircuitBreakerSkips := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "operator_circuit_breaker_skips_total",
+			Help:      "The number of batches not sent to an operator because its circuit breaker was open.",
+		},
+		[]string{"operator_id"},
+	)
+
 	putDispersalResponseLatency := promauto.With(registry).NewSummaryVec(
 		prometheus.SummaryOpts{
 			Namespace: dispatcherNamespace,
@@ -225,6 +295,52 @@ func newDispatcherMetrics(registry *prometheus.Registry) *dispatcherMetrics {
 		[]string{},
 	)
 
+	batchSize := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  dispatcherNamespace,
+			Name:       "batch_size",
+			Help:       "The number of blobs in the dispatched batches.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
+	targetBatchSize := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "target_batch_size",
+			Help:      "The number of blobs batches are held back for, as chosen by dynamic batch sizing.",
+		},
+		[]string{},
+	)
+
+	heldBackBatches := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "held_back_batches_total",
+			Help:      "The number of times the available blobs were held back for a larger batch.",
+		},
+		[]string{},
+	)
+
+	recoveredBatches := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "recovered_batches_total",
+			Help:      "The number of batches in progress at a restart, by how they were recovered.",
+		},
+		[]string{"outcome"}, // possible values are "completed", "resumed" and "requeued"
+	)
+
+	earlyFinalizedBatches := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "early_finalized_batches_total",
+			Help:      "The number of batches attested once their confirmation thresholds were reached, before every operator responded.",
+		},
+		[]string{},
+	)
+
 	return &dispatcherMetrics{
 		handleBatchLatency:          handleBatchLatency,
 		newBatchLatency:             newBatchLatency,
@@ -239,12 +355,23 @@ func newDispatcherMetrics(registry *prometheus.Registry) *dispatcherMetrics {
 		putDispersalRequestLatency:  putDispersalRequestLatency,
 		sendChunksLatency:           sendChunksLatency,
 		sendChunksRetryCount:        sendChunksRetryCount,
+		operatorSendChunksRetries:   operatorSendChunksRetries,
+		operatorSendChunksFailures:  operatorSendChunksFailures,
+		operatorConcurrencyLimit:    operatorConcurrencyLimit,
+		operatorRequestsShed:        operatorRequestsShed,
+		operatorCircuitState:        operatorCircuitState,
+		operatorCircuitBreakerSkips: operatorCircuitBreakerSkips,
 		putDispersalResponseLatency: putDispersalResponseLatency,
 		handleSignaturesLatency:     handleSignaturesLatency,
 		receiveSignaturesLatency:    receiveSignaturesLatency,
 		aggregateSignaturesLatency:  aggregateSignaturesLatency,
 		putAttestationLatency:       putAttestationLatency,
 		updateBatchStatusLatency:    updateBatchStatusLatency,
+		batchSize:                   batchSize,
+		targetBatchSize:             targetBatchSize,
+		heldBackBatches:             heldBackBatches,
+		recoveredBatches:            recoveredBatches,
+		earlyFinalizedBatches:       earlyFinalizedBatches,
 	}
 }
 
@@ -300,6 +427,30 @@ func (m *dispatcherMetrics) reportSendChunksRetryCount(retries float64) {
 	m.sendChunksRetryCount.WithLabelValues().Set(retries)
 }
 
+func (m *dispatcherMetrics) reportOperatorSendChunksRetry(operatorID core.OperatorID) {
+	m.operatorSendChunksRetries.WithLabelValues(operatorID.Hex()).Inc()
+}
+
+func (m *dispatcherMetrics) reportOperatorSendChunksFailure(operatorID core.OperatorID) {
+	m.operatorSendChunksFailures.WithLabelValues(operatorID.Hex()).Inc()
+}
+
+func (m *dispatcherMetrics) reportOperatorConcurrencyLimit(operatorID core.OperatorID, limit int) {
+	m.operatorConcurrencyLimit.WithLabelValues(operatorID.Hex()).Set(float64(limit))
+}
+
+func (m *dispatcherMetrics) reportOperatorRequestShed(operatorID core.OperatorID) {
+	m.operatorRequestsShed.WithLabelValues(operatorID.Hex()).Inc()
+}
+
+func (m *dispatcherMetrics) reportOperatorCircuitState(operatorID core.OperatorID, state CircuitState) {
+	m.operatorCircuitState.WithLabelValues(operatorID.Hex()).Set(float64(state))
+}
+
+func (m *dispatcherMetrics) reportOperatorCircuitBreakerSkip(operatorID core.OperatorID) {
+	m.operatorCircuitBreakerSkips.WithLabelValues(operatorID.Hex()).Inc()
+}
+
 func (m *dispatcherMetrics) reportPutDispersalResponseLatency(duration time.Duration) {
 	m.putDispersalResponseLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
@@ -323,3 +474,23 @@ func (m *dispatcherMetrics) reportPutAttestationLatency(duration time.Duration)
 func (m *dispatcherMetrics) reportUpdateBatchStatusLatency(duration time.Duration) {
 	m.updateBatchStatusLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
+
+func (m *dispatcherMetrics) reportBatchSize(numBlobs int) {
+	m.batchSize.WithLabelValues().Observe(float64(numBlobs))
+}
+
+func (m *dispatcherMetrics) reportTargetBatchSize(numBlobs int) {
+	m.targetBatchSize.WithLabelValues().Set(float64(numBlobs))
+}
+
+func (m *dispatcherMetrics) reportBatchHeldBack() {
+	m.heldBackBatches.WithLabelValues().Inc()
+}
+
+func (m *dispatcherMetrics) reportRecoveredBatch(outcome string) {
+	m.recoveredBatches.WithLabelValues(outcome).Inc()
+}
+
+func (m *dispatcherMetrics) reportEarlyFinalizedBatch() {
+	m.earlyFinalizedBatches.WithLabelValues().Inc()
+}
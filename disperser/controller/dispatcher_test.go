@@ -7,8 +7,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus"
-
 	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
@@ -18,8 +16,10 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/gammazero/workerpool"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/go-merkletree/v2"
@@ -126,6 +126,200 @@ func TestDispatcherHandleBatch(t *testing.T) {
 	deleteBlobs(t, components.BlobMetadataStore, objs.blobKeys, [][32]byte{bhh})
 }
 
+func TestDispatcherEarlyFinalization(t *testing.T) {
+	components := newDispatcherComponents(t)
+	d, err := controller.NewDispatcher(&controller.DispatcherConfig{
+		PullInterval:               1 * time.Second,
+		FinalizationBlockDelay:     finalizationBlockDelay,
+		NodeRequestTimeout:         1 * time.Second,
+		NumRequestRetries:          3,
+		MaxBatchSize:               maxBatchSize,
+		EnableEarlyFinalization:    true,
+		EarlyFinalizationThreshold: 55,
+	}, components.BlobMetadataStore, components.Pool, components.ChainState, components.SigAggregator, components.NodeClientManager, nil, logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+	objs := setupBlobCerts(t, components.BlobMetadataStore, []core.QuorumID{0, 1}, 2)
+	ctx := context.Background()
+
+	merkleTree, err := corev2.BuildMerkleTree(objs.blobCerts)
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		ReferenceBlockNumber: blockNumber - finalizationBlockDelay,
+	}
+	copy(batchHeader.BatchRoot[:], merkleTree.Root())
+	bhh, err := batchHeader.Hash()
+	require.NoError(t, err)
+
+	// operators 0 and 1 reach the thresholds of both quorums, so the batch is attested without waiting for operator 2
+	operatorState := mockChainState.GetTotalOperatorState(ctx, uint(blockNumber))
+	mockClient0 := clientsmock.NewNodeClient()
+	mockClient0.On("StoreChunks", mock.Anything, mock.Anything).Return(mockChainState.KeyPairs[opId0].SignMessage(bhh), nil)
+	components.NodeClientManager.On("GetClient", mock.Anything, operatorState.PrivateOperators[opId0].DispersalPort).Return(mockClient0, nil)
+	mockClient1 := clientsmock.NewNodeClient()
+	mockClient1.On("StoreChunks", mock.Anything, mock.Anything).Return(mockChainState.KeyPairs[opId1].SignMessage(bhh), nil)
+	components.NodeClientManager.On("GetClient", mock.Anything, operatorState.PrivateOperators[opId1].DispersalPort).Return(mockClient1, nil)
+	mockClient2 := clientsmock.NewNodeClient()
+	mockClient2.On("StoreChunks", mock.Anything, mock.Anything).After(2*time.Second).Return(mockChainState.KeyPairs[opId2].SignMessage(bhh), nil)
+	components.NodeClientManager.On("GetClient", mock.Anything, operatorState.PrivateOperators[opId2].DispersalPort).Return(mockClient2, nil)
+
+	start := time.Now()
+	sigChan, batchData, err := d.HandleBatch(ctx)
+	require.NoError(t, err)
+	err = d.HandleSignatures(ctx, batchData, sigChan)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 2*time.Second)
+
+	for _, key := range objs.blobKeys {
+		bm, err := components.BlobMetadataStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, v2.Certified, bm.BlobStatus)
+	}
+	att, err := components.BlobMetadataStore.GetAttestation(ctx, bhh)
+	require.NoError(t, err)
+	require.Len(t, att.NonSignerPubKeys, 1)
+	require.InDeltaMapValues(t, map[core.QuorumID]uint8{0: 100, 1: 80}, att.QuorumResults, 0)
+
+	deleteBlobs(t, components.BlobMetadataStore, objs.blobKeys, [][32]byte{bhh})
+}
+
+func TestDispatcherCircuitBreaker(t *testing.T) {
+	components := newDispatcherComponents(t)
+	d, err := controller.NewDispatcher(&controller.DispatcherConfig{
+		PullInterval:                   1 * time.Second,
+		FinalizationBlockDelay:         finalizationBlockDelay,
+		NodeRequestTimeout:             1 * time.Second,
+		NumRequestRetries:              3,
+		MaxBatchSize:                   maxBatchSize,
+		CircuitBreakerFailureThreshold: 1,
+		CircuitBreakerCooldown:         time.Minute,
+	}, components.BlobMetadataStore, components.Pool, components.ChainState, components.SigAggregator, components.NodeClientManager, nil, logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+	objs := setupBlobCerts(t, components.BlobMetadataStore, []core.QuorumID{0, 1}, 2)
+	ctx := context.Background()
+
+	merkleTree, err := corev2.BuildMerkleTree(objs.blobCerts)
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		ReferenceBlockNumber: blockNumber - finalizationBlockDelay,
+	}
+	copy(batchHeader.BatchRoot[:], merkleTree.Root())
+	bhh, err := batchHeader.Hash()
+	require.NoError(t, err)
+
+	operatorState := mockChainState.GetTotalOperatorState(ctx, uint(blockNumber))
+	mockClient0 := clientsmock.NewNodeClient()
+	mockClient0.On("StoreChunks", mock.Anything, mock.Anything).Return(nil, errors.New("failure"))
+	components.NodeClientManager.On("GetClient", mock.Anything, operatorState.PrivateOperators[opId0].DispersalPort).Return(mockClient0, nil)
+	mockClient1 := clientsmock.NewNodeClient()
+	mockClient1.On("StoreChunks", mock.Anything, mock.Anything).Return(mockChainState.KeyPairs[opId1].SignMessage(bhh), nil)
+	components.NodeClientManager.On("GetClient", mock.Anything, operatorState.PrivateOperators[opId1].DispersalPort).Return(mockClient1, nil)
+	mockClient2 := clientsmock.NewNodeClient()
+	mockClient2.On("StoreChunks", mock.Anything, mock.Anything).Return(mockChainState.KeyPairs[opId2].SignMessage(bhh), nil)
+	components.NodeClientManager.On("GetClient", mock.Anything, operatorState.PrivateOperators[opId2].DispersalPort).Return(mockClient2, nil)
+
+	sigChan, batchData, err := d.HandleBatch(ctx)
+	require.NoError(t, err)
+	err = d.HandleSignatures(ctx, batchData, sigChan)
+	require.NoError(t, err)
+
+	// the failing operator isn't retried once its circuit opens
+	mockClient0.AssertNumberOfCalls(t, "StoreChunks", 1)
+	mockClient1.AssertNumberOfCalls(t, "StoreChunks", 1)
+	mockClient2.AssertNumberOfCalls(t, "StoreChunks", 1)
+
+	for _, key := range objs.blobKeys {
+		bm, err := components.BlobMetadataStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, v2.Certified, bm.BlobStatus)
+	}
+
+	deleteBlobs(t, components.BlobMetadataStore, objs.blobKeys, [][32]byte{bhh})
+}
+
+func TestDispatcherRecoverBatches(t *testing.T) {
+	components := newDispatcherComponents(t)
+	objs := setupBlobCerts(t, components.BlobMetadataStore, []core.QuorumID{0, 1}, 2)
+	ctx := context.Background()
+
+	merkleTree, err := corev2.BuildMerkleTree(objs.blobCerts)
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		ReferenceBlockNumber: blockNumber - finalizationBlockDelay,
+	}
+	copy(batchHeader.BatchRoot[:], merkleTree.Root())
+	bhh, err := batchHeader.Hash()
+	require.NoError(t, err)
+
+	operatorState := mockChainState.GetTotalOperatorState(ctx, uint(blockNumber))
+	op0Port := operatorState.PrivateOperators[opId0].DispersalPort
+	op1Port := operatorState.PrivateOperators[opId1].DispersalPort
+	op2Port := operatorState.PrivateOperators[opId2].DispersalPort
+
+	// operator 2 doesn't respond before the controller stops
+	mockClient0 := clientsmock.NewNodeClient()
+	mockClient0.On("StoreChunks", mock.Anything, mock.Anything).Return(mockChainState.KeyPairs[opId0].SignMessage(bhh), nil)
+	components.NodeClientManager.On("GetClient", mock.Anything, op0Port).Return(mockClient0, nil)
+	mockClient1 := clientsmock.NewNodeClient()
+	mockClient1.On("StoreChunks", mock.Anything, mock.Anything).Return(mockChainState.KeyPairs[opId1].SignMessage(bhh), nil)
+	components.NodeClientManager.On("GetClient", mock.Anything, op1Port).Return(mockClient1, nil)
+	mockClient2 := clientsmock.NewNodeClient()
+	mockClient2.On("StoreChunks", mock.Anything, mock.Anything).Return(nil, errors.New("unavailable"))
+	components.NodeClientManager.On("GetClient", mock.Anything, op2Port).Return(mockClient2, nil)
+
+	sigChan, batchData, err := components.Dispatcher.HandleBatch(ctx)
+	require.NoError(t, err)
+	for range batchData.OperatorState.IndexedOperators {
+		<-sigChan
+	}
+	inProgress, err := components.BlobMetadataStore.GetInProgressBatches(ctx)
+	require.NoError(t, err)
+	require.Len(t, inProgress, 1)
+	require.Equal(t, bhh, inProgress[0].BatchHeaderHash)
+	require.Equal(t, objs.blobKeys, inProgress[0].BlobKeys)
+
+	// after the restart, the batch is only sent to operator 2, and the signatures of the others are reused
+	restarted := newDispatcherComponents(t)
+	restartedClient0 := clientsmock.NewNodeClient()
+	restarted.NodeClientManager.On("GetClient", mock.Anything, op0Port).Return(restartedClient0, nil)
+	restartedClient1 := clientsmock.NewNodeClient()
+	restarted.NodeClientManager.On("GetClient", mock.Anything, op1Port).Return(restartedClient1, nil)
+	restartedClient2 := clientsmock.NewNodeClient()
+	restartedClient2.On("StoreChunks", mock.Anything, mock.Anything).Return(mockChainState.KeyPairs[opId2].SignMessage(bhh), nil)
+	restarted.NodeClientManager.On("GetClient", mock.Anything, op2Port).Return(restartedClient2, nil)
+
+	restarted.Dispatcher.RecoverBatches(ctx)
+	restartedClient0.AssertNotCalled(t, "StoreChunks", mock.Anything, mock.Anything)
+	restartedClient1.AssertNotCalled(t, "StoreChunks", mock.Anything, mock.Anything)
+	restartedClient2.AssertNumberOfCalls(t, "StoreChunks", 1)
+
+	for _, key := range objs.blobKeys {
+		bm, err := restarted.BlobMetadataStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, v2.Certified, bm.BlobStatus)
+	}
+	att, err := restarted.BlobMetadataStore.GetAttestation(ctx, bhh)
+	require.NoError(t, err)
+	require.Len(t, att.NonSignerPubKeys, 0)
+	inProgress, err = restarted.BlobMetadataStore.GetInProgressBatches(ctx)
+	require.NoError(t, err)
+	require.Empty(t, inProgress)
+
+	deleteBlobs(t, components.BlobMetadataStore, objs.blobKeys, [][32]byte{bhh})
+}
+
+func TestValidateEarlyFinalizationThreshold(t *testing.T) {
+	ctx := context.Background()
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetCurrentBlockNumber").Return(uint32(blockNumber), nil)
+	chainReader.On("GetQuorumSecurityParams").Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 1, AdversaryThreshold: 33, ConfirmationThreshold: 60},
+	}, nil)
+
+	require.NoError(t, controller.ValidateEarlyFinalizationThreshold(ctx, chainReader, 60))
+	require.Error(t, controller.ValidateEarlyFinalizationThreshold(ctx, chainReader, 55))
+}
+
 func TestDispatcherInsufficientSignatures(t *testing.T) {
 	components := newDispatcherComponents(t)
 	failedObjs := setupBlobCerts(t, components.BlobMetadataStore, []core.QuorumID{0, 1}, 2)
@@ -169,6 +363,11 @@ func TestDispatcherInsufficientSignatures(t *testing.T) {
 	err = components.Dispatcher.HandleSignatures(ctx, batchData, sigChan)
 	require.NoError(t, err)
 
+	// Failing operators are retried, and successful operators aren't
+	mockClient0.AssertNumberOfCalls(t, "StoreChunks", 4)
+	mockClient1.AssertNumberOfCalls(t, "StoreChunks", 4)
+	mockClient2.AssertNumberOfCalls(t, "StoreChunks", 1)
+
 	// Test that the blob metadata status are updated
 	for _, blobKey := range failedObjs.blobKeys {
 		bm, err := components.BlobMetadataStore.GetBlobMetadata(ctx, blobKey)
@@ -269,6 +468,89 @@ func TestDispatcherInsufficientSignatures2(t *testing.T) {
 	deleteBlobs(t, components.BlobMetadataStore, objsInQuorum1.blobKeys, [][32]byte{bhh})
 }
 
+func TestDispatcherSecurityThresholds(t *testing.T) {
+	components := newDispatcherComponents(t)
+	ctx := context.Background()
+	defaultObjs := setupBlobCerts(t, components.BlobMetadataStore, []core.QuorumID{1}, 1)
+
+	// blob requiring a confirmation threshold in quorum 1 above what will be signed
+	strictKey, strictHeader := newBlob(t, []core.QuorumID{1})
+	strictHeader.SecurityThresholds = []corev2.QuorumSecurityThresholds{
+		{QuorumID: 1, ConfirmationThreshold: 55, AdversaryThreshold: 33},
+	}
+	now := time.Now()
+	err := components.BlobMetadataStore.PutBlobMetadata(ctx, &v2.BlobMetadata{
+		BlobHeader: strictHeader,
+		BlobStatus: v2.Encoded,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		UpdatedAt:  uint64(now.UnixNano()),
+	})
+	require.NoError(t, err)
+	strictCert := &corev2.BlobCertificate{
+		BlobHeader: strictHeader,
+		RelayKeys:  []corev2.RelayKey{0, 1, 2},
+	}
+	err = components.BlobMetadataStore.PutBlobCertificate(ctx, strictCert, &encoding.FragmentInfo{})
+	require.NoError(t, err)
+
+	// only op2 signs - quorum 1 will have 20%
+	mockClient0 := clientsmock.NewNodeClient()
+	mockClient0.On("StoreChunks", mock.Anything, mock.Anything).Return(nil, errors.New("failure"))
+	op0Port := mockChainState.GetTotalOperatorState(ctx, uint(blockNumber)).PrivateOperators[opId0].DispersalPort
+	op1Port := mockChainState.GetTotalOperatorState(ctx, uint(blockNumber)).PrivateOperators[opId1].DispersalPort
+	op2Port := mockChainState.GetTotalOperatorState(ctx, uint(blockNumber)).PrivateOperators[opId2].DispersalPort
+	components.NodeClientManager.On("GetClient", mock.Anything, op0Port).Return(mockClient0, nil)
+	mockClient1 := clientsmock.NewNodeClient()
+	mockClient1.On("StoreChunks", mock.Anything, mock.Anything).Return(nil, errors.New("failure"))
+	components.NodeClientManager.On("GetClient", mock.Anything, op1Port).Return(mockClient1, nil)
+	mockClient2 := clientsmock.NewNodeClient()
+	mockClient2.On("StoreChunks", mock.Anything, mock.Anything).Return(nil, nil).Run(func(args mock.Arguments) {
+		batch := args.Get(1).(*corev2.Batch)
+		bhh, err := batch.BatchHeader.Hash()
+		require.NoError(t, err)
+		mockClient2.ExpectedCalls[0].ReturnArguments = mock.Arguments{mockChainState.KeyPairs[opId2].SignMessage(bhh), nil}
+	})
+	components.NodeClientManager.On("GetClient", mock.Anything, op2Port).Return(mockClient2, nil)
+
+	sigChan, batchData, err := components.Dispatcher.HandleBatch(ctx)
+	require.NoError(t, err)
+	err = components.Dispatcher.HandleSignatures(ctx, batchData, sigChan)
+	require.NoError(t, err)
+
+	bm, err := components.BlobMetadataStore.GetBlobMetadata(ctx, defaultObjs.blobKeys[0])
+	require.NoError(t, err)
+	require.Equal(t, v2.Certified, bm.BlobStatus)
+	bm, err = components.BlobMetadataStore.GetBlobMetadata(ctx, strictKey)
+	require.NoError(t, err)
+	require.Equal(t, v2.InsufficientSignatures, bm.BlobStatus)
+
+	bhh, err := batchData.Batch.BatchHeader.Hash()
+	require.NoError(t, err)
+	deleteBlobs(t, components.BlobMetadataStore, defaultObjs.blobKeys, [][32]byte{bhh})
+	deleteBlobs(t, components.BlobMetadataStore, []corev2.BlobKey{strictKey}, [][32]byte{bhh})
+}
+
+func TestDispatcherRetryConfig(t *testing.T) {
+	newDispatcher := func(config *controller.DispatcherConfig) error {
+		config.PullInterval = time.Second
+		config.NodeRequestTimeout = time.Second
+		config.MaxBatchSize = maxBatchSize
+		_, err := controller.NewDispatcher(config, blobMetadataStore, nil, mockChainState, nil, nil, nil, logging.NewNoopLogger(), prometheus.NewRegistry())
+		return err
+	}
+
+	require.NoError(t, newDispatcher(&controller.DispatcherConfig{NumRequestRetries: 3}))
+	require.NoError(t, newDispatcher(&controller.DispatcherConfig{
+		NumRequestRetries:      3,
+		RetryInitialBackoff:    time.Second,
+		RetryBackoffMultiplier: 2,
+		RetryMaxBackoff:        30 * time.Second,
+	}))
+	require.Error(t, newDispatcher(&controller.DispatcherConfig{NumRequestRetries: -1}))
+	require.Error(t, newDispatcher(&controller.DispatcherConfig{RetryInitialBackoff: -time.Second}))
+	require.Error(t, newDispatcher(&controller.DispatcherConfig{RetryInitialBackoff: time.Second, RetryBackoffMultiplier: 0.5}))
+}
+
 func TestDispatcherMaxBatchSize(t *testing.T) {
 	components := newDispatcherComponents(t)
 	numBlobs := 12
@@ -457,6 +739,8 @@ func deleteBlobs(t *testing.T, blobMetadataStore *blobstore.BlobMetadataStore, k
 	for _, bhh := range batchHeaderHashes {
 		err := blobMetadataStore.DeleteBatchHeader(ctx, bhh)
 		require.NoError(t, err)
+		err = blobMetadataStore.DeleteInProgressBatch(ctx, bhh)
+		require.NoError(t, err)
 	}
 }
 
@@ -478,7 +762,7 @@ func newDispatcherComponents(t *testing.T) *dispatcherComponents {
 		NodeRequestTimeout:     1 * time.Second,
 		NumRequestRetries:      3,
 		MaxBatchSize:           maxBatchSize,
-	}, blobMetadataStore, pool, mockChainState, agg, nodeClientManager, logger, prometheus.NewRegistry())
+	}, blobMetadataStore, pool, mockChainState, agg, nodeClientManager, nil, logger, prometheus.NewRegistry())
 	require.NoError(t, err)
 	return &dispatcherComponents{
 		Dispatcher:        d,
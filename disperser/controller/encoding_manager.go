@@ -22,7 +22,11 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
-var errNoBlobsToEncode = errors.New("no blobs to encode")
+var (
+	errNoBlobsToEncode         = errors.New("no blobs to encode")
+	errEncodersAtCapacity      = errors.New("encoders are at capacity")
+	errChunkVerificationFailed = errors.New("chunk verification failed")
+)
 
 type EncodingManagerConfig struct {
 	PullInterval time.Duration
@@ -35,8 +39,8 @@ type EncodingManagerConfig struct {
 	NumRelayAssignment uint16
 	// AvailableRelays is a list of available relays
 	AvailableRelays []corev2.RelayKey
-	// EncoderAddress is the address of the encoder
-	EncoderAddress string
+	// EncoderAddresses are the addresses of the encoder replicas
+	EncoderAddresses []string
 	// MaxNumBlobsPerIteration is the maximum number of blobs to encode per iteration
 	MaxNumBlobsPerIteration int32
 	// OnchainStateRefreshInterval is the interval at which the onchain state is refreshed
@@ -54,6 +58,8 @@ type EncodingManager struct {
 	pool              common.WorkerPool
 	encodingClient    disperser.EncoderClientV2
 	chainReader       core.Reader
+	statusNotifier    *StatusNotifier
+	chunkVerifier     *ChunkVerifier
 	logger            logging.Logger
 
 	// state
@@ -69,6 +75,8 @@ func NewEncodingManager(
 	pool common.WorkerPool,
 	encodingClient disperser.EncoderClientV2,
 	chainReader core.Reader,
+	statusNotifier *StatusNotifier,
+	chunkVerifier *ChunkVerifier,
 	logger logging.Logger,
 	registry *prometheus.Registry,
 ) (*EncodingManager, error) {
@@ -86,6 +94,8 @@ func NewEncodingManager(
 		pool:                  pool,
 		encodingClient:        encodingClient,
 		chainReader:           chainReader,
+		statusNotifier:        statusNotifier,
+		chunkVerifier:         chunkVerifier,
 		logger:                logger.With("component", "EncodingManager"),
 		cursor:                nil,
 		metrics:               newEncodingManagerMetrics(registry),
@@ -129,6 +139,8 @@ func (e *EncodingManager) Start(ctx context.Context) error {
 				if err != nil {
 					if errors.Is(err, errNoBlobsToEncode) {
 						e.logger.Debug("no blobs to encode")
+					} else if errors.Is(err, errEncodersAtCapacity) {
+						e.logger.Debug("encoders are at capacity, holding back queued blobs")
 					} else {
 						e.logger.Error("failed to process a batch", "err", err)
 					}
@@ -141,6 +153,12 @@ func (e *EncodingManager) Start(ctx context.Context) error {
 }
 
 func (e *EncodingManager) HandleBatch(ctx context.Context) error {
+	// Leave the blobs queued while the encoders can't accept them, rather than dispatching requests that would wait
+	// for the encoders or be rejected by them
+	if loadReporter, ok := e.encodingClient.(disperser.EncoderLoadReporter); ok && !loadReporter.HasCapacity() {
+		return errEncodersAtCapacity
+	}
+
 	// Get a batch of blobs to encode
 	blobMetadatas, cursor, err := e.blobMetadataStore.GetBlobMetadataByStatusPaginated(ctx, v2.Queued, e.cursor, e.MaxNumBlobsPerIteration)
 	if err != nil {
@@ -189,13 +207,17 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 			var finishedPutBlobCertificateTime time.Time
 			var finishedUpdateBlobStatusTime time.Time
 			var success bool
+			var reencode bool
 
 			for i = 0; i < e.NumEncodingRetries+1; i++ {
 				encodingCtx, cancel := context.WithTimeout(ctx, e.EncodingRequestTimeout)
-				fragmentInfo, err := e.encodeBlob(encodingCtx, blobKey, blob, blobParams)
+				fragmentInfo, err := e.encodeBlob(encodingCtx, blobKey, blob, blobParams, reencode)
 				cancel()
 				if err != nil {
 					e.logger.Error("failed to encode blob", "blobKey", blobKey.Hex(), "err", err)
+					// Invalid chunks are replaced by the next attempt rather than reused. Chunks that couldn't be
+					// verified, e.g. because they couldn't be read, are reused.
+					reencode = reencode || errors.Is(err, ErrInvalidChunks)
 					continue
 				}
 
@@ -226,8 +248,14 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 				err = e.blobMetadataStore.UpdateBlobStatus(storeCtx, blobKey, v2.Encoded)
 				finishedUpdateBlobStatusTime = time.Now()
 				cancel()
-				if err == nil || errors.Is(err, dispcommon.ErrAlreadyExists) {
+				if err == nil {
 					// Successfully updated the status to Encoded
+					e.statusNotifier.Notify(blobKey, blob, v2.Encoded)
+					success = true
+					break
+				}
+				if errors.Is(err, dispcommon.ErrAlreadyExists) {
+					// The status was already updated to Encoded
 					success = true
 					break
 				}
@@ -254,6 +282,7 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 					e.logger.Error("failed to update blob status to Failed", "blobKey", blobKey.Hex(), "err", err)
 					return
 				}
+				e.statusNotifier.Notify(blobKey, blob, v2.Failed)
 			}
 		})
 	}
@@ -268,12 +297,32 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 	return nil
 }
 
-func (e *EncodingManager) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, blob *v2.BlobMetadata, blobParams *core.BlobVersionParameters) (*encoding.FragmentInfo, error) {
+// encodeBlob encodes the blob, and verifies a sample of its chunks if the chunk verifier is enabled. A reencode
+// replaces the stored chunks of the blob, if the encoder client supports it.
+func (e *EncodingManager) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, blob *v2.BlobMetadata, blobParams *core.BlobVersionParameters, reencode bool) (*encoding.FragmentInfo, error) {
 	encodingParams, err := blob.BlobHeader.GetEncodingParams(blobParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get encoding params: %w", err)
 	}
-	return e.encodingClient.EncodeBlob(ctx, blobKey, encodingParams)
+
+	var fragmentInfo *encoding.FragmentInfo
+	if reencoder, ok := e.encodingClient.(disperser.EncoderReencoder); ok && reencode {
+		e.logger.Info("reencoding blob", "blobKey", blobKey.Hex())
+		fragmentInfo, err = reencoder.ReencodeBlob(ctx, blobKey, encodingParams)
+	} else {
+		fragmentInfo, err = e.encodingClient.EncodeBlob(ctx, blobKey, encodingParams)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if e.chunkVerifier != nil {
+		err = e.chunkVerifier.VerifyChunks(ctx, blobKey, blob.BlobHeader.BlobCommitments, encodingParams, fragmentInfo)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errChunkVerificationFailed, err)
+		}
+	}
+	return fragmentInfo, nil
 }
 
 func (e *EncodingManager) refreshBlobVersionParams(ctx context.Context) error {
@@ -15,12 +15,14 @@ type NodeClientManager interface {
 type nodeClientManager struct {
 	// nodeClients is a cache of node clients keyed by socket address
 	nodeClients *lru.Cache[string, clients.NodeClient]
-	logger      logging.Logger
+	// quorumProfilesDigest is the digest of the quorum profiles blobs are encoded with, sent to the nodes
+	quorumProfilesDigest []byte
+	logger               logging.Logger
 }
 
 var _ NodeClientManager = (*nodeClientManager)(nil)
 
-func NewNodeClientManager(cacheSize int, logger logging.Logger) (*nodeClientManager, error) {
+func NewNodeClientManager(cacheSize int, quorumProfilesDigest []byte, logger logging.Logger) (*nodeClientManager, error) {
 	closeClient := func(socket string, value clients.NodeClient) {
 		if err := value.Close(); err != nil {
 			logger.Error("failed to close node client", "err", err)
@@ -32,8 +34,9 @@ func NewNodeClientManager(cacheSize int, logger logging.Logger) (*nodeClientMana
 	}
 
 	return &nodeClientManager{
-		nodeClients: nodeClients,
-		logger:      logger,
+		nodeClients:          nodeClients,
+		quorumProfilesDigest: quorumProfilesDigest,
+		logger:               logger,
 	}, nil
 }
 
@@ -43,8 +46,9 @@ func (m *nodeClientManager) GetClient(host, port string) (clients.NodeClient, er
 	if !ok {
 		var err error
 		client, err = clients.NewNodeClient(&clients.NodeClientConfig{
-			Hostname: host,
-			Port:     port,
+			Hostname:             host,
+			Port:                 port,
+			QuorumProfilesDigest: m.quorumProfilesDigest,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create node client at %s: %w", socket, err)
@@ -8,7 +8,7 @@ import (
 )
 
 func TestNodeClientManager(t *testing.T) {
-	m, err := controller.NewNodeClientManager(2, nil)
+	m, err := controller.NewNodeClientManager(2, nil, nil)
 	require.NoError(t, err)
 
 	client0, err := m.GetClient("localhost", "0000")
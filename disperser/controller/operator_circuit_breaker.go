@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// CircuitState is the state of the circuit breaker of an operator
+type CircuitState uint8
+
+const (
+	// CircuitClosed is the state of an operator that is sent batches
+	CircuitClosed CircuitState = iota
+	// CircuitOpen is the state of an operator that failed too many times in a row, and isn't sent batches until the
+	// cooldown passes
+	CircuitOpen
+	// CircuitHalfOpen is the state of an operator whose cooldown passed, which is sent a single probe batch to decide
+	// whether to close or reopen its circuit
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// OperatorCircuitBreaker stops sending batches to operators that keep failing, since they can't contribute
+// signatures anyway, which saves the bandwidth and the retries spent on them.
+//
+// The circuit of an operator opens after failureThreshold consecutive failed requests. Once the cooldown passes, the
+// circuit is half-open, and a single request is let through as a probe: the circuit closes if it succeeds, and opens
+// again for another cooldown if it fails. A probe that isn't recorded within the cooldown, e.g. because it couldn't be
+// sent, is replaced by a new one.
+//
+// A nil OperatorCircuitBreaker lets every request through. OperatorCircuitBreaker is thread-safe.
+type OperatorCircuitBreaker struct {
+	mu sync.Mutex
+
+	failureThreshold int
+	cooldown         time.Duration
+	operators        map[core.OperatorID]*operatorCircuit
+}
+
+type operatorCircuit struct {
+	state               CircuitState
+	consecutiveFailures int
+	// openedAt is when the circuit last opened
+	openedAt time.Time
+	// probeStartedAt is when the probe of a half-open circuit was let through
+	probeStartedAt time.Time
+}
+
+func NewOperatorCircuitBreaker(failureThreshold int, cooldown time.Duration) (*OperatorCircuitBreaker, error) {
+	if failureThreshold <= 0 {
+		return nil, errors.New("failure threshold must be positive")
+	}
+	if cooldown <= 0 {
+		return nil, errors.New("cooldown must be positive")
+	}
+	return &OperatorCircuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		operators:        make(map[core.OperatorID]*operatorCircuit),
+	}, nil
+}
+
+// Allow returns true if a request may be sent to the operator. A request let through a half-open circuit is its probe,
+// whose result must be recorded with RecordSuccess or RecordFailure.
+func (b *OperatorCircuitBreaker) Allow(operatorID core.OperatorID, now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuit, ok := b.operators[operatorID]
+	if !ok {
+		return true
+	}
+	switch circuit.state {
+	case CircuitOpen:
+		if now.Sub(circuit.openedAt) < b.cooldown {
+			return false
+		}
+		circuit.state = CircuitHalfOpen
+		circuit.probeStartedAt = now
+		return true
+	case CircuitHalfOpen:
+		if now.Sub(circuit.probeStartedAt) < b.cooldown {
+			return false
+		}
+		circuit.probeStartedAt = now
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the circuit of the operator.
+func (b *OperatorCircuitBreaker) RecordSuccess(operatorID core.OperatorID) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.operators, operatorID)
+}
+
+// RecordFailure counts a failed request to the operator, and opens its circuit if the request was a probe or if the
+// operator reached the failure threshold.
+func (b *OperatorCircuitBreaker) RecordFailure(operatorID core.OperatorID, now time.Time) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuit, ok := b.operators[operatorID]
+	if !ok {
+		circuit = &operatorCircuit{}
+		b.operators[operatorID] = circuit
+	}
+	circuit.consecutiveFailures++
+	if circuit.state == CircuitHalfOpen || circuit.consecutiveFailures >= b.failureThreshold {
+		circuit.state = CircuitOpen
+		circuit.openedAt = now
+	}
+}
+
+// State returns the state of the circuit of the operator.
+func (b *OperatorCircuitBreaker) State(operatorID core.OperatorID) CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuit, ok := b.operators[operatorID]
+	if !ok {
+		return CircuitClosed
+	}
+	return circuit.state
+}
@@ -0,0 +1,63 @@
+package controller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorCircuitBreaker(t *testing.T) {
+	_, err := controller.NewOperatorCircuitBreaker(0, time.Minute)
+	require.Error(t, err)
+	_, err = controller.NewOperatorCircuitBreaker(3, 0)
+	require.Error(t, err)
+
+	breaker, err := controller.NewOperatorCircuitBreaker(3, time.Minute)
+	require.NoError(t, err)
+	failing := core.OperatorID{1}
+	healthy := core.OperatorID{2}
+	now := time.Now()
+
+	// the circuit opens after consecutive failures only
+	breaker.RecordFailure(failing, now)
+	breaker.RecordFailure(failing, now)
+	breaker.RecordSuccess(failing)
+	breaker.RecordFailure(failing, now)
+	breaker.RecordFailure(failing, now)
+	assert.Equal(t, controller.CircuitClosed, breaker.State(failing))
+	assert.True(t, breaker.Allow(failing, now))
+	breaker.RecordFailure(failing, now)
+	assert.Equal(t, controller.CircuitOpen, breaker.State(failing))
+	assert.False(t, breaker.Allow(failing, now.Add(time.Second)))
+	assert.True(t, breaker.Allow(healthy, now))
+
+	// a single probe is let through once the cooldown passes, and the circuit opens again if it fails
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow(failing, now))
+	assert.Equal(t, controller.CircuitHalfOpen, breaker.State(failing))
+	assert.False(t, breaker.Allow(failing, now.Add(time.Second)))
+	breaker.RecordFailure(failing, now.Add(time.Second))
+	assert.Equal(t, controller.CircuitOpen, breaker.State(failing))
+	assert.False(t, breaker.Allow(failing, now.Add(time.Minute)))
+
+	// a probe that isn't recorded within the cooldown is replaced
+	now = now.Add(time.Second + time.Minute)
+	assert.True(t, breaker.Allow(failing, now))
+	assert.False(t, breaker.Allow(failing, now.Add(time.Second)))
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow(failing, now))
+
+	// the circuit closes if the probe succeeds
+	breaker.RecordSuccess(failing)
+	assert.Equal(t, controller.CircuitClosed, breaker.State(failing))
+	assert.True(t, breaker.Allow(failing, now))
+
+	// a nil breaker lets every request through
+	var nilBreaker *controller.OperatorCircuitBreaker
+	assert.True(t, nilBreaker.Allow(failing, now))
+	assert.Equal(t, controller.CircuitClosed, nilBreaker.State(failing))
+}
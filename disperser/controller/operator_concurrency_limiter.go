@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+const (
+	// rttTolerance is how many times slower than its baseline an operator may respond before its limit is decreased
+	rttTolerance = 2.0
+	// rttSmoothing is the weight of the latest response in the moving average of the round trip time
+	rttSmoothing = 0.2
+	// baselineRTTDrift is how fast the baseline round trip time follows slower responses, so that the baseline
+	// adapts to lasting changes, e.g. in batch size
+	baselineRTTDrift = 0.01
+	// errorRateSmoothing is the weight of the latest response in the moving average of the error rate
+	errorRateSmoothing = 0.2
+	// maxErrorRateForIncrease is the error rate above which the limit of an operator is no longer increased
+	maxErrorRateForIncrease = 0.1
+	// latencyBackoffRatio is the factor the limit is decreased by when an operator responds slowly
+	latencyBackoffRatio = 0.9
+	// errorBackoffRatio is the factor the limit is decreased by when a request to an operator fails
+	errorBackoffRatio = 0.5
+)
+
+// OperatorConcurrencyLimiter adapts the number of dispersal requests in flight to each operator to how the operator
+// responds, so that a slow operator doesn't accumulate requests from consecutive batches.
+//
+// The limit of an operator grows additively while it responds without errors within rttTolerance of its baseline
+// round trip time, and shrinks multiplicatively when it responds slowly or fails. Requests beyond the limit are
+// rejected rather than queued, so that they don't delay the signature gathering of the batch.
+//
+// A nil OperatorConcurrencyLimiter doesn't limit requests. OperatorConcurrencyLimiter is thread-safe.
+type OperatorConcurrencyLimiter struct {
+	mu sync.Mutex
+
+	initialLimit float64
+	maxLimit     float64
+	operators    map[core.OperatorID]*operatorConcurrency
+}
+
+type operatorConcurrency struct {
+	limit    float64
+	inFlight int
+
+	// baselineRTT is the round trip time of the operator when it isn't overloaded, zero until the first response
+	baselineRTT time.Duration
+	// smoothedRTT is the moving average of the round trip time of the operator
+	smoothedRTT time.Duration
+	// errorRate is the moving average of the fraction of failed requests to the operator
+	errorRate float64
+}
+
+func NewOperatorConcurrencyLimiter(initialLimit int, maxLimit int) (*OperatorConcurrencyLimiter, error) {
+	if initialLimit <= 0 || initialLimit > maxLimit {
+		return nil, errors.New("initial operator concurrency must be positive and at most the max operator concurrency")
+	}
+	return &OperatorConcurrencyLimiter{
+		initialLimit: float64(initialLimit),
+		maxLimit:     float64(maxLimit),
+		operators:    make(map[core.OperatorID]*operatorConcurrency),
+	}, nil
+}
+
+// TryAcquire reserves a request slot for the operator. It returns false if the operator is at its limit.
+func (l *OperatorConcurrencyLimiter) TryAcquire(operatorID core.OperatorID) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	op := l.getOperator(operatorID)
+	if op.inFlight >= int(op.limit) {
+		return false
+	}
+	op.inFlight++
+	return true
+}
+
+// Release frees the request slot of the operator, and adapts its limit to the round trip time and result of the
+// request.
+func (l *OperatorConcurrencyLimiter) Release(operatorID core.OperatorID, rtt time.Duration, err error) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	op := l.getOperator(operatorID)
+	op.inFlight = max(0, op.inFlight-1)
+
+	if err != nil {
+		op.errorRate = errorRateSmoothing + (1-errorRateSmoothing)*op.errorRate
+		op.limit = math.Max(1, op.limit*errorBackoffRatio)
+		return
+	}
+	op.errorRate = (1 - errorRateSmoothing) * op.errorRate
+
+	if op.baselineRTT == 0 || rtt < op.baselineRTT {
+		op.baselineRTT = rtt
+		op.smoothedRTT = rtt
+	} else {
+		op.baselineRTT += time.Duration(baselineRTTDrift * float64(rtt-op.baselineRTT))
+		op.smoothedRTT = time.Duration(rttSmoothing*float64(rtt) + (1-rttSmoothing)*float64(op.smoothedRTT))
+	}
+
+	if float64(op.smoothedRTT) > rttTolerance*float64(op.baselineRTT) {
+		op.limit = math.Max(1, op.limit*latencyBackoffRatio)
+	} else if op.errorRate <= maxErrorRateForIncrease {
+		op.limit = math.Min(l.maxLimit, op.limit+1/op.limit)
+	}
+}
+
+// Limit returns the number of requests that may be in flight to the operator.
+func (l *OperatorConcurrencyLimiter) Limit(operatorID core.OperatorID) int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.getOperator(operatorID).limit)
+}
+
+func (l *OperatorConcurrencyLimiter) getOperator(operatorID core.OperatorID) *operatorConcurrency {
+	op, ok := l.operators[operatorID]
+	if !ok {
+		op = &operatorConcurrency{limit: l.initialLimit}
+		l.operators[operatorID] = op
+	}
+	return op
+}
@@ -0,0 +1,71 @@
+package controller_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorConcurrencyLimiter(t *testing.T) {
+	_, err := controller.NewOperatorConcurrencyLimiter(0, 8)
+	require.Error(t, err)
+	_, err = controller.NewOperatorConcurrencyLimiter(10, 8)
+	require.Error(t, err)
+
+	limiter, err := controller.NewOperatorConcurrencyLimiter(2, 8)
+	require.NoError(t, err)
+	fast := core.OperatorID{1}
+	slow := core.OperatorID{2}
+
+	// requests beyond the limit are rejected
+	assert.True(t, limiter.TryAcquire(fast))
+	assert.True(t, limiter.TryAcquire(fast))
+	assert.False(t, limiter.TryAcquire(fast))
+	limiter.Release(fast, 100*time.Millisecond, nil)
+	assert.True(t, limiter.TryAcquire(fast))
+	limiter.Release(fast, 100*time.Millisecond, nil)
+	limiter.Release(fast, 100*time.Millisecond, nil)
+
+	// the limit grows while the operator responds quickly, up to the max
+	for i := 0; i < 100; i++ {
+		require.True(t, limiter.TryAcquire(fast))
+		limiter.Release(fast, 100*time.Millisecond, nil)
+	}
+	assert.Equal(t, 8, limiter.Limit(fast))
+
+	// the limit shrinks when the operator slows down
+	for i := 0; i < 10; i++ {
+		require.True(t, limiter.TryAcquire(slow))
+		limiter.Release(slow, 100*time.Millisecond, nil)
+	}
+	limitBefore := limiter.Limit(slow)
+	for i := 0; i < 20; i++ {
+		require.True(t, limiter.TryAcquire(slow))
+		limiter.Release(slow, time.Second, nil)
+	}
+	assert.Less(t, limiter.Limit(slow), limitBefore)
+	assert.Equal(t, 8, limiter.Limit(fast))
+
+	// the limit halves on errors, down to one request
+	require.True(t, limiter.TryAcquire(fast))
+	limiter.Release(fast, 100*time.Millisecond, errors.New("unavailable"))
+	assert.Equal(t, 4, limiter.Limit(fast))
+	for i := 0; i < 5; i++ {
+		require.True(t, limiter.TryAcquire(fast))
+		limiter.Release(fast, 100*time.Millisecond, errors.New("unavailable"))
+	}
+	assert.Equal(t, 1, limiter.Limit(fast))
+}
+
+func TestNilOperatorConcurrencyLimiter(t *testing.T) {
+	var limiter *controller.OperatorConcurrencyLimiter
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.TryAcquire(core.OperatorID{1}))
+	}
+	limiter.Release(core.OperatorID{1}, time.Second, nil)
+}
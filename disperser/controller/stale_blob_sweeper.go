@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type StaleBlobSweeperConfig struct {
+	// SweepInterval is how often stale blobs are looked for
+	SweepInterval time.Duration
+	// EncodedBlobDeadline is how long a blob may stay encoded before it is considered stuck. It must be longer than
+	// it takes to dispatch a batch and gather its signatures, since blobs stay encoded until their batch is attested.
+	EncodedBlobDeadline time.Duration
+	// MaxNumRetries is the number of times a stale blob is requeued before it is failed
+	MaxNumRetries uint
+	// NumBlobsPerFetch is the number of encoded blobs read at a time
+	NumBlobsPerFetch int32
+}
+
+// StaleBlobSweeper finds blobs stuck in the Encoded status, e.g. because the controller stopped while their batch
+// was being dispatched, and requeues them so that the dispatcher includes them in a new batch. Blobs that have
+// expired or have been requeued too many times are failed instead. Blobs of batches that are still in progress,
+// including the batches being resumed after a restart, are left to the dispatcher.
+type StaleBlobSweeper struct {
+	*StaleBlobSweeperConfig
+
+	blobMetadataStore *blobstore.BlobMetadataStore
+	statusNotifier    *StatusNotifier
+	logger            logging.Logger
+	metrics           *staleBlobSweeperMetrics
+}
+
+func NewStaleBlobSweeper(
+	config *StaleBlobSweeperConfig,
+	blobMetadataStore *blobstore.BlobMetadataStore,
+	statusNotifier *StatusNotifier,
+	logger logging.Logger,
+	registry *prometheus.Registry,
+) (*StaleBlobSweeper, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.SweepInterval <= 0 || config.EncodedBlobDeadline <= 0 {
+		return nil, errors.New("sweep interval and encoded blob deadline must be positive")
+	}
+	if config.NumBlobsPerFetch <= 0 {
+		return nil, errors.New("number of blobs per fetch must be positive")
+	}
+	return &StaleBlobSweeper{
+		StaleBlobSweeperConfig: config,
+		blobMetadataStore:      blobMetadataStore,
+		statusNotifier:         statusNotifier,
+		logger:                 logger.With("component", "StaleBlobSweeper"),
+		metrics:                newStaleBlobSweeperMetrics(registry),
+	}, nil
+}
+
+// Start sweeps stale blobs every sweep interval until the context is cancelled.
+func (s *StaleBlobSweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.SweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Sweep(ctx, time.Now()); err != nil {
+					s.logger.Error("failed to sweep stale blobs", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// Sweep requeues or fails the blobs that have been encoded for longer than the deadline at the given time.
+func (s *StaleBlobSweeper) Sweep(ctx context.Context, now time.Time) error {
+	cutoff := uint64(now.Add(-s.EncodedBlobDeadline).UnixNano())
+	numStale := 0
+	var cursor *blobstore.StatusIndexCursor
+	for {
+		metadatas, nextCursor, err := s.blobMetadataStore.GetBlobMetadataByStatusPaginated(ctx, v2.Encoded, cursor, s.NumBlobsPerFetch)
+		if err != nil {
+			return fmt.Errorf("failed to get encoded blobs: %w", err)
+		}
+		if len(metadatas) == 0 {
+			break
+		}
+		// results are ordered by UpdatedAt, so the stale blobs come first
+		stale := metadatas
+		for i, metadata := range metadatas {
+			if metadata.UpdatedAt > cutoff {
+				stale = metadatas[:i]
+				break
+			}
+		}
+		if len(stale) > 0 {
+			numStale += len(stale)
+			if err := s.sweepBlobs(ctx, stale, now); err != nil {
+				return err
+			}
+		}
+		if len(stale) < len(metadatas) {
+			break
+		}
+		cursor = nextCursor
+	}
+	s.metrics.reportStaleBlobs(numStale)
+	return nil
+}
+
+// sweepBlobs requeues or fails the given stale blobs, except the blobs of in-progress batches
+func (s *StaleBlobSweeper) sweepBlobs(ctx context.Context, metadatas []*v2.BlobMetadata, now time.Time) error {
+	// The in-progress batches are read after the blobs, so that a blob is only swept if it wasn't in a batch that was
+	// in progress when it was found stale
+	batches, err := s.blobMetadataStore.GetInProgressBatches(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get in-progress batches: %w", err)
+	}
+	inProgress := make(map[corev2.BlobKey]struct{})
+	for _, batch := range batches {
+		for _, blobKey := range batch.BlobKeys {
+			inProgress[blobKey] = struct{}{}
+		}
+	}
+
+	for _, metadata := range metadatas {
+		blobKey, err := metadata.BlobHeader.BlobKey()
+		if err != nil {
+			s.logger.Error("failed to get blob key", "err", err)
+			continue
+		}
+		if _, ok := inProgress[blobKey]; ok {
+			continue
+		}
+
+		reason := ""
+		if metadata.Expiry <= uint64(now.Unix()) {
+			reason = "expired"
+		} else if metadata.NumRetries >= s.MaxNumRetries {
+			reason = "max_retries"
+		}
+
+		if reason == "" {
+			err = s.blobMetadataStore.RequeueBlob(ctx, metadata)
+			if errors.Is(err, blobstore.ErrInvalidStateTransition) {
+				// the blob was dispatched in the meantime
+				continue
+			}
+			if err != nil {
+				s.logger.Error("failed to requeue stale blob", "blobKey", blobKey.Hex(), "err", err)
+				continue
+			}
+			s.logger.Warn("requeued stale blob", "blobKey", blobKey.Hex(), "numRetries", metadata.NumRetries+1)
+			s.metrics.reportRequeuedBlob()
+			continue
+		}
+
+		err = s.blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.Failed)
+		if errors.Is(err, blobstore.ErrInvalidStateTransition) {
+			// the blob was attested in the meantime
+			continue
+		}
+		if err != nil {
+			s.logger.Error("failed to fail stale blob", "blobKey", blobKey.Hex(), "err", err)
+			continue
+		}
+		s.logger.Warn("failed stale blob", "blobKey", blobKey.Hex(), "reason", reason, "numRetries", metadata.NumRetries)
+		s.metrics.reportFailedBlob(reason)
+		s.statusNotifier.Notify(blobKey, metadata, v2.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const staleBlobSweeperNamespace = "eigenda_stale_blob_sweeper"
+
+const (
+	staleBlobActionRequeued = "requeued"
+	staleBlobActionFailed   = "failed"
+)
+
+// staleBlobSweeperMetrics is a struct that holds the metrics for the stale blob sweeper.
+type staleBlobSweeperMetrics struct {
+	sweptBlobs *prometheus.CounterVec
+	staleBlobs *prometheus.GaugeVec
+}
+
+// newStaleBlobSweeperMetrics sets up metrics for the stale blob sweeper.
+func newStaleBlobSweeperMetrics(registry *prometheus.Registry) *staleBlobSweeperMetrics {
+	sweptBlobs := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: staleBlobSweeperNamespace,
+			Name:      "blobs_total",
+			Help:      "The number of stale blobs, by action (requeued or failed) and reason (deadline, expired or max_retries).",
+		},
+		[]string{"action", "reason"},
+	)
+
+	staleBlobs := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: staleBlobSweeperNamespace,
+			Name:      "stale_blobs",
+			Help:      "The number of blobs encoded for longer than the deadline at the last sweep.",
+		},
+		[]string{},
+	)
+
+	return &staleBlobSweeperMetrics{
+		sweptBlobs: sweptBlobs,
+		staleBlobs: staleBlobs,
+	}
+}
+
+func (m *staleBlobSweeperMetrics) reportRequeuedBlob() {
+	m.sweptBlobs.WithLabelValues(staleBlobActionRequeued, "deadline").Inc()
+}
+
+func (m *staleBlobSweeperMetrics) reportFailedBlob(reason string) {
+	m.sweptBlobs.WithLabelValues(staleBlobActionFailed, reason).Inc()
+}
+
+func (m *staleBlobSweeperMetrics) reportStaleBlobs(count int) {
+	m.staleBlobs.WithLabelValues().Set(float64(count))
+}
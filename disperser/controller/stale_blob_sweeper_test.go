@@ -0,0 +1,79 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleBlobSweeper(t *testing.T) {
+	ctx := context.Background()
+	_, err := controller.NewStaleBlobSweeper(&controller.StaleBlobSweeperConfig{
+		SweepInterval:    time.Minute,
+		NumBlobsPerFetch: 2,
+	}, blobMetadataStore, nil, logger, prometheus.NewRegistry())
+	require.Error(t, err)
+
+	sweeper, err := controller.NewStaleBlobSweeper(&controller.StaleBlobSweeperConfig{
+		SweepInterval:       time.Minute,
+		EncodedBlobDeadline: 10 * time.Minute,
+		MaxNumRetries:       2,
+		// the blobs are read in several pages
+		NumBlobsPerFetch: 2,
+	}, blobMetadataStore, nil, logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	now := time.Now()
+	stale := uint64(now.Add(-time.Hour).UnixNano())
+	blobs := []struct {
+		metadata *v2.BlobMetadata
+		expected v2.BlobStatus
+	}{
+		// stuck blob is requeued
+		{&v2.BlobMetadata{BlobStatus: v2.Encoded, Expiry: uint64(now.Add(time.Hour).Unix()), UpdatedAt: stale}, v2.Encoded},
+		// stuck blob requeued too many times is failed
+		{&v2.BlobMetadata{BlobStatus: v2.Encoded, Expiry: uint64(now.Add(time.Hour).Unix()), NumRetries: 2, UpdatedAt: stale + 1}, v2.Failed},
+		// expired stuck blob is failed
+		{&v2.BlobMetadata{BlobStatus: v2.Encoded, Expiry: uint64(now.Add(-time.Minute).Unix()), UpdatedAt: stale + 2}, v2.Failed},
+		// stuck blob of an in-progress batch is left to the dispatcher
+		{&v2.BlobMetadata{BlobStatus: v2.Encoded, Expiry: uint64(now.Add(-time.Minute).Unix()), UpdatedAt: stale + 3}, v2.Encoded},
+		// blob encoded within the deadline is left alone
+		{&v2.BlobMetadata{BlobStatus: v2.Encoded, Expiry: uint64(now.Add(time.Hour).Unix()), UpdatedAt: uint64(now.UnixNano())}, v2.Encoded},
+	}
+	keys := make([]corev2.BlobKey, len(blobs))
+	for i, b := range blobs {
+		keys[i], b.metadata.BlobHeader = newBlob(t, []core.QuorumID{0})
+		require.NoError(t, blobMetadataStore.PutBlobMetadata(ctx, b.metadata))
+	}
+	inProgressBatch := [32]byte{7, 1}
+	require.NoError(t, blobMetadataStore.PutInProgressBatch(ctx, &v2.InProgressBatch{
+		BatchHeaderHash: inProgressBatch,
+		BlobKeys:        []corev2.BlobKey{keys[3]},
+		CreatedAt:       uint64(now.UnixNano()),
+	}))
+
+	require.NoError(t, sweeper.Sweep(ctx, now))
+
+	for i, b := range blobs {
+		metadata, err := blobMetadataStore.GetBlobMetadata(ctx, keys[i])
+		require.NoError(t, err)
+		require.Equal(t, b.expected, metadata.BlobStatus)
+	}
+	requeued, err := blobMetadataStore.GetBlobMetadata(ctx, keys[0])
+	require.NoError(t, err)
+	require.Equal(t, uint(1), requeued.NumRetries)
+	require.Greater(t, requeued.UpdatedAt, uint64(now.Add(-time.Minute).UnixNano()))
+	inProgress, err := blobMetadataStore.GetBlobMetadata(ctx, keys[3])
+	require.NoError(t, err)
+	require.Equal(t, uint(0), inProgress.NumRetries)
+
+	require.NoError(t, blobMetadataStore.DeleteInProgressBatch(ctx, inProgressBatch))
+	deleteBlobs(t, blobMetadataStore, keys, nil)
+}
@@ -0,0 +1,339 @@
+package controller
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatusNotificationSignatureHeader is the HTTP header carrying the hex encoded signature of the notification body,
+// made with the signing key of the notifier over the keccak256 hash of the body.
+const StatusNotificationSignatureHeader = "X-EigenDA-Signature"
+
+type StatusNotifierConfig struct {
+	// SigningKey is the key the notifications are signed with. Clients verify the notifications against the address
+	// of the key, so that no secret has to be shared with them.
+	SigningKey *ecdsa.PrivateKey
+	// NumWorkers is the number of notifications sent concurrently
+	NumWorkers int
+	// QueueSize is the number of notifications waiting to be sent, and separately the number waiting to be retried,
+	// beyond which new notifications are dropped
+	QueueSize int
+	// MaxRetries is the number of times a failed notification is retried
+	MaxRetries int
+	// RetryInterval is the delay before the first retry, which doubles for every following retry
+	RetryInterval time.Duration
+	// RequestTimeout is the timeout of a single notification request
+	RequestTimeout time.Duration
+	// AllowPrivateAddresses allows notifying callback URLs which resolve to non-public addresses. It must only be set
+	// for tests and local deployments, since it lets clients make the notifier send requests to internal services.
+	AllowPrivateAddresses bool
+}
+
+// BlobStatusNotification is the JSON body POSTed to the callback URL of a blob when its status changes.
+type BlobStatusNotification struct {
+	// BlobKey is the hex encoded blob key
+	BlobKey string `json:"blob_key"`
+	// Status is the name of the new status of the blob, as in the disperser API (e.g. "CERTIFIED")
+	Status string `json:"status"`
+	// Timestamp is the Unix timestamp of the status change in seconds
+	Timestamp int64 `json:"timestamp"`
+}
+
+// StatusNotifier POSTs blob status changes to the callback URLs set by clients in their dispersal requests, so they
+// don't have to poll for the blob status. Notifications are sent asynchronously and retried with exponential backoff,
+// and are best effort: they are dropped if they can't be delivered, or if too many are waiting to be sent.
+//
+// Failed notifications wait for their retry in a delay queue rather than in the workers, so that callbacks which are
+// down don't hold up the notifications of other blobs.
+type StatusNotifier struct {
+	*StatusNotifierConfig
+
+	httpClient *http.Client
+	queue      chan *statusNotification
+	logger     logging.Logger
+	metrics    *statusNotifierMetrics
+
+	// retries holds the failed notifications by the time of their next attempt
+	retriesMu sync.Mutex
+	retries   retryHeap
+	// retryAdded wakes up the retry loop when a retry is scheduled
+	retryAdded chan struct{}
+}
+
+type statusNotification struct {
+	callbackURL string
+	blobKey     corev2.BlobKey
+	body        []byte
+	signature   string
+	createdAt   time.Time
+	// attempts is the number of failed attempts to send the notification
+	attempts int
+	// retryAt is the time of the next attempt, once the notification failed
+	retryAt time.Time
+}
+
+// errPermanentNotificationFailure is returned for notifications that should not be retried
+var errPermanentNotificationFailure = errors.New("permanent notification failure")
+
+func NewStatusNotifier(
+	config *StatusNotifierConfig,
+	logger logging.Logger,
+	registry *prometheus.Registry,
+) (*StatusNotifier, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.SigningKey == nil {
+		return nil, errors.New("signing key is required")
+	}
+	if config.NumWorkers < 1 || config.QueueSize < 1 || config.MaxRetries < 0 || config.RetryInterval <= 0 || config.RequestTimeout <= 0 {
+		return nil, errors.New("invalid config")
+	}
+	dialer := &net.Dialer{Timeout: config.RequestTimeout}
+	if !config.AllowPrivateAddresses {
+		dialer = common.NewPublicDialer(dialer)
+	}
+	return &StatusNotifier{
+		StatusNotifierConfig: config,
+		httpClient: &http.Client{
+			Timeout: config.RequestTimeout,
+			Transport: &http.Transport{
+				// Requests are not sent through a proxy, so that the dialer checks the address of the callback itself
+				Proxy:               nil,
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: config.RequestTimeout,
+				MaxIdleConns:        config.NumWorkers,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			// Redirects are not followed, so that a notification is only ever sent to the URL set by the client
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		queue:      make(chan *statusNotification, config.QueueSize),
+		logger:     logger.With("component", "StatusNotifier"),
+		metrics:    newStatusNotifierMetrics(registry),
+		retryAdded: make(chan struct{}, 1),
+	}, nil
+}
+
+// Start starts the workers sending the notifications, and the loop queueing the retries once they are due, which
+// run until the context is cancelled.
+func (n *StatusNotifier) Start(ctx context.Context) {
+	for i := 0; i < n.NumWorkers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case notification := <-n.queue:
+					n.metrics.reportQueueSize(len(n.queue))
+					n.send(ctx, notification)
+				}
+			}
+		}()
+	}
+	go n.retryLoop(ctx)
+}
+
+// Notify queues a notification of the new status of a blob to its callback URL, if the blob has one.
+// It does nothing if the notifier is nil, so that components can be used without notifications.
+func (n *StatusNotifier) Notify(blobKey corev2.BlobKey, metadata *v2.BlobMetadata, status v2.BlobStatus) {
+	if n == nil || metadata == nil || metadata.CallbackURL == "" {
+		return
+	}
+
+	now := time.Now()
+	body, err := json.Marshal(&BlobStatusNotification{
+		BlobKey:   blobKey.Hex(),
+		Status:    status.ToProfobuf().String(),
+		Timestamp: now.Unix(),
+	})
+	if err != nil {
+		n.logger.Error("failed to marshal blob status notification", "blobKey", blobKey.Hex(), "err", err)
+		return
+	}
+
+	n.enqueue(&statusNotification{
+		callbackURL: metadata.CallbackURL,
+		blobKey:     blobKey,
+		body:        body,
+		createdAt:   now,
+	})
+}
+
+// enqueue queues a notification to be sent by the workers, dropping it if the queue is full
+func (n *StatusNotifier) enqueue(notification *statusNotification) {
+	select {
+	case n.queue <- notification:
+		n.metrics.reportQueueSize(len(n.queue))
+	default:
+		n.logger.Warn("blob status notification queue is full, dropping notification", "blobKey", notification.blobKey.Hex())
+		n.metrics.reportNotification(notificationResultDropped)
+	}
+}
+
+// send POSTs a notification, scheduling a retry with exponential backoff if it fails with a retryable error.
+func (n *StatusNotifier) send(ctx context.Context, notification *statusNotification) {
+	// Notifications are signed by the workers, off the path of the components notifying status changes
+	if notification.signature == "" {
+		notification.signature = SignStatusNotification(n.SigningKey, notification.body)
+	}
+	err := n.post(ctx, notification.callbackURL, notification.body, notification.signature)
+	if err == nil {
+		n.metrics.reportNotification(notificationResultSuccess)
+		n.metrics.reportNotificationLatency(time.Since(notification.createdAt))
+		return
+	}
+
+	notification.attempts++
+	if errors.Is(err, errPermanentNotificationFailure) || notification.attempts > n.MaxRetries {
+		if !errors.Is(err, errPermanentNotificationFailure) {
+			err = fmt.Errorf("giving up after %d attempts: %w", notification.attempts, err)
+		}
+		n.logger.Warn("failed to send blob status notification", "blobKey", notification.blobKey.Hex(), "callbackURL", notification.callbackURL, "err", err)
+		n.metrics.reportNotification(notificationResultFailure)
+		return
+	}
+
+	notification.retryAt = time.Now().Add(n.RetryInterval * time.Duration(1<<(notification.attempts-1)))
+	n.retriesMu.Lock()
+	if n.retries.Len() >= n.QueueSize {
+		n.retriesMu.Unlock()
+		n.logger.Warn("blob status notification retry queue is full, dropping notification", "blobKey", notification.blobKey.Hex())
+		n.metrics.reportNotification(notificationResultDropped)
+		return
+	}
+	heap.Push(&n.retries, notification)
+	n.retriesMu.Unlock()
+
+	select {
+	case n.retryAdded <- struct{}{}:
+	default:
+	}
+}
+
+// retryLoop queues the failed notifications to be sent again once their retry is due
+func (n *StatusNotifier) retryLoop(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		// Queue the due retries, and wait until the next one is due or a new one is scheduled
+		n.retriesMu.Lock()
+		now := time.Now()
+		var due []*statusNotification
+		for n.retries.Len() > 0 && !n.retries[0].retryAt.After(now) {
+			due = append(due, heap.Pop(&n.retries).(*statusNotification))
+		}
+		wait := time.Hour
+		if n.retries.Len() > 0 {
+			wait = n.retries[0].retryAt.Sub(now)
+		}
+		n.retriesMu.Unlock()
+
+		for _, notification := range due {
+			n.enqueue(notification)
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-n.retryAdded:
+		}
+	}
+}
+
+func (n *StatusNotifier) post(ctx context.Context, callbackURL string, body []byte, signature string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %v", errPermanentNotificationFailure, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(StatusNotificationSignatureHeader, signature)
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	// Server errors and rate limiting are retried, other client errors won't resolve by retrying
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("callback responded with status %d", resp.StatusCode)
+	}
+	return fmt.Errorf("%w: callback responded with status %d", errPermanentNotificationFailure, resp.StatusCode)
+}
+
+// SignStatusNotification returns the hex encoded signature of a notification body by the signing key.
+func SignStatusNotification(key *ecdsa.PrivateKey, body []byte) string {
+	signature, err := crypto.Sign(crypto.Keccak256(body), key)
+	if err != nil {
+		// Only fails for a malformed key or hash, neither of which can happen here
+		panic(fmt.Sprintf("failed to sign status notification: %v", err))
+	}
+	return hex.EncodeToString(signature)
+}
+
+// VerifyStatusNotification checks that the signature of a notification body received by a callback was made by the
+// signing key of the given address, which the disperser publishes.
+func VerifyStatusNotification(signer gethcommon.Address, body []byte, signature string) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return false
+	}
+	publicKey, err := crypto.SigToPub(crypto.Keccak256(body), sig)
+	if err != nil {
+		return false
+	}
+	return crypto.PubkeyToAddress(*publicKey) == signer
+}
+
+// retryHeap is a min-heap of failed notifications ordered by the time of their next attempt.
+type retryHeap []*statusNotification
+
+func (h retryHeap) Len() int           { return len(h) }
+func (h retryHeap) Less(i, j int) bool { return h[i].retryAt.Before(h[j].retryAt) }
+func (h retryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *retryHeap) Push(x any) {
+	*h = append(*h, x.(*statusNotification))
+}
+
+func (h *retryHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
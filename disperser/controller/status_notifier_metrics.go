@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const statusNotifierNamespace = "eigenda_status_notifier"
+
+const (
+	notificationResultSuccess = "success"
+	notificationResultFailure = "failure"
+	notificationResultDropped = "dropped"
+)
+
+// statusNotifierMetrics is a struct that holds the metrics for the status notifier.
+type statusNotifierMetrics struct {
+	notificationCount   *prometheus.CounterVec
+	notificationLatency *prometheus.SummaryVec
+	queueSize           *prometheus.GaugeVec
+}
+
+// newStatusNotifierMetrics sets up metrics for the status notifier.
+func newStatusNotifierMetrics(registry *prometheus.Registry) *statusNotifierMetrics {
+	notificationCount := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: statusNotifierNamespace,
+			Name:      "notifications_total",
+			Help:      "The number of blob status notifications, by result (success, failure or dropped).",
+		},
+		[]string{"result"},
+	)
+
+	notificationLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  statusNotifierNamespace,
+			Name:       "notification_latency_ms",
+			Help:       "The time from a status change to the successful delivery of its notification, including retries.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{},
+	)
+
+	queueSize := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: statusNotifierNamespace,
+			Name:      "queue_size",
+			Help:      "The number of notifications waiting to be sent.",
+		},
+		[]string{},
+	)
+
+	return &statusNotifierMetrics{
+		notificationCount:   notificationCount,
+		notificationLatency: notificationLatency,
+		queueSize:           queueSize,
+	}
+}
+
+func (m *statusNotifierMetrics) reportNotification(result string) {
+	m.notificationCount.WithLabelValues(result).Inc()
+}
+
+func (m *statusNotifierMetrics) reportNotificationLatency(duration time.Duration) {
+	m.notificationLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
+func (m *statusNotifierMetrics) reportQueueSize(size int) {
+	m.queueSize.WithLabelValues().Set(float64(size))
+}
@@ -0,0 +1,151 @@
+package controller_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedNotification struct {
+	notification controller.BlobStatusNotification
+	validSig     bool
+}
+
+func newTestStatusNotifier(t *testing.T, key *ecdsa.PrivateKey, allowPrivateAddresses bool) *controller.StatusNotifier {
+	notifier, err := controller.NewStatusNotifier(&controller.StatusNotifierConfig{
+		SigningKey:            key,
+		NumWorkers:            2,
+		QueueSize:             10,
+		MaxRetries:            2,
+		RetryInterval:         10 * time.Millisecond,
+		RequestTimeout:        time.Second,
+		AllowPrivateAddresses: allowPrivateAddresses,
+	}, logging.NewNoopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	notifier.Start(ctx)
+	return notifier
+}
+
+func TestStatusNotifier(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	received := make(chan receivedNotification, 10)
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first attempt fails and is retried
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var notification controller.BlobStatusNotification
+		require.NoError(t, json.Unmarshal(body, &notification))
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received <- receivedNotification{
+			notification: notification,
+			validSig:     controller.VerifyStatusNotification(signer, body, r.Header.Get(controller.StatusNotificationSignatureHeader)),
+		}
+	}))
+	defer server.Close()
+
+	notifier := newTestStatusNotifier(t, key, true)
+	blobKey := corev2.BlobKey{1, 2, 3}
+	now := time.Now().Unix()
+	notifier.Notify(blobKey, &v2.BlobMetadata{CallbackURL: server.URL}, v2.Certified)
+
+	select {
+	case r := <-received:
+		assert.True(t, r.validSig)
+		assert.Equal(t, blobKey.Hex(), r.notification.BlobKey)
+		assert.Equal(t, "CERTIFIED", r.notification.Status)
+		assert.GreaterOrEqual(t, r.notification.Timestamp, now)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+	}
+	assert.Equal(t, int32(2), attempts.Load())
+
+	// blobs without a callback URL are not notified
+	notifier.Notify(blobKey, &v2.BlobMetadata{}, v2.Encoded)
+
+	// a nil notifier does nothing
+	var nilNotifier *controller.StatusNotifier
+	nilNotifier.Notify(blobKey, &v2.BlobMetadata{CallbackURL: server.URL}, v2.Failed)
+
+	select {
+	case r := <-received:
+		t.Fatalf("unexpected notification: %v", r.notification)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestStatusNotifierClientError(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	notifier := newTestStatusNotifier(t, key, true)
+	notifier.Notify(corev2.BlobKey{1}, &v2.BlobMetadata{CallbackURL: server.URL}, v2.Failed)
+
+	// client errors are not retried
+	require.Eventually(t, func() bool { return attempts.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestStatusNotifierPrivateAddress(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+	}))
+	defer server.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	notifier := newTestStatusNotifier(t, key, false)
+	notifier.Notify(corev2.BlobKey{1}, &v2.BlobMetadata{CallbackURL: server.URL}, v2.Certified)
+
+	// the test server listens on a loopback address, which the notifier refuses to connect to
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, int32(0), attempts.Load())
+}
+
+func TestStatusNotificationSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	body := []byte(`{"blob_key":"01","status":"ENCODED","timestamp":1}`)
+	signature := controller.SignStatusNotification(key, body)
+
+	assert.True(t, controller.VerifyStatusNotification(signer, body, signature))
+	assert.False(t, controller.VerifyStatusNotification(crypto.PubkeyToAddress(otherKey.PublicKey), body, signature))
+	assert.False(t, controller.VerifyStatusNotification(signer, []byte(`{}`), signature))
+	assert.False(t, controller.VerifyStatusNotification(signer, body, "not hex"))
+	assert.False(t, controller.VerifyStatusNotification(signer, body, signature[:10]))
+}
@@ -0,0 +1,101 @@
+package dataapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	disperserv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/gin-gonic/gin"
+)
+
+// FetchAccountBlobsHandler godoc
+//
+//	@Summary	Fetch blobs dispersed by an account, newest first
+//	@Tags		Account
+//	@Produce	json
+//	@Param		account_id	path		string	true	"Account ID of the disperser client"
+//	@Param		status		query		string	false	"Comma-separated blob statuses to filter by, e.g. queued,certified [default: all statuses]"
+//	@Param		cursor		query		string	false	"Pagination cursor (opaque string from previous response)"
+//	@Param		limit		query		int		false	"Maximum number of blobs to return [default: 20; max: 1000]"
+//	@Success	200			{object}	BlobFeedResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/accounts/{account_id}/blobs [get]
+func (s *ServerV2) FetchAccountBlobsHandler(c *gin.Context) {
+	accountID := c.Param("account_id")
+	if accountID == "" {
+		invalidParamsErrorResponse(c, errors.New("account ID is required"))
+		return
+	}
+	page, err := parsePageParams(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+	statuses, err := parseBlobStatuses(c.Query("status"))
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+	var cursor *blobstore.BlobFeedCursor
+	if page.cursor != "" {
+		cursor, err = decodeBlobFeedCursor(page.cursor)
+		if err != nil {
+			invalidParamsErrorResponse(c, fmt.Errorf("failed to parse cursor: %w", err))
+			return
+		}
+	}
+
+	blobs, nextCursor, err := s.blobMetadataStore.GetBlobMetadataByAccountID(c.Request.Context(), accountID, cursor, page.limit, statuses...)
+	if err != nil {
+		errorResponse(c, fmt.Errorf("failed to fetch blobs of account from blob metadata store: %w", err))
+		return
+	}
+
+	blobInfo, err := newBlobInfos(blobs)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	var paginationToken string
+	if nextCursor != nil {
+		paginationToken = encodeBlobFeedCursor(nextCursor)
+	}
+
+	response := &BlobFeedResponse{
+		Blobs:           blobInfo,
+		Pagination:      page.pagination(paginationToken),
+		PaginationToken: paginationToken,
+	}
+	setCacheMaxAge(c, maxFeedBlobsAge*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// parseBlobStatuses parses a comma-separated list of blob status names as defined in the disperser API,
+// case-insensitively; an empty string yields no statuses
+func parseBlobStatuses(param string) ([]commonv2.BlobStatus, error) {
+	if param == "" {
+		return nil, nil
+	}
+	names := strings.Split(param, ",")
+	statuses := make([]commonv2.BlobStatus, 0, len(names))
+	for _, name := range names {
+		value, ok := disperserv2.BlobStatus_value[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("invalid blob status %q", name)
+		}
+		status, err := commonv2.BlobStatusFromProtobuf(disperserv2.BlobStatus(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob status %q: %w", name, err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,101 @@
+package dataapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/apikey"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	apiKeyHeader     = "X-API-Key"
+	apiKeyQueryParam = "api_key"
+
+	quotaLimitHeader     = "X-Quota-Limit"
+	quotaRemainingHeader = "X-Quota-Remaining"
+
+	// apiKeyNameContextKey is the gin context key under which the name of the authenticated key is set
+	apiKeyNameContextKey = "api_key_name"
+)
+
+var (
+	errMissingAPIKey  = errors.New("missing API key")
+	errInvalidAPIKey  = errors.New("invalid API key")
+	errDisabledAPIKey = errors.New("API key is disabled")
+	errQuotaExceeded  = errors.New("daily quota of API key exceeded")
+)
+
+// APIKeyAuth returns a middleware which rejects requests that don't carry a valid API key, and requests made
+// with a key that has used up its daily quota. The key is read from the X-API-Key header, or from the api_key
+// query param for clients such as browsers opening a websocket, which can't set headers.
+// Requests under skipPrefix (e.g. the swagger docs) are let through.
+func (s *ServerV2) APIKeyAuth(skipPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if skipPrefix != "" && strings.HasPrefix(c.Request.URL.Path, skipPrefix) {
+			c.Next()
+			return
+		}
+
+		key := requestAPIKey(c)
+		if key == "" {
+			abortWithError(c, http.StatusUnauthorized, errMissingAPIKey)
+			return
+		}
+
+		ctx := c.Request.Context()
+		keyHash := apikey.HashKey(key)
+		apiKey, err := s.apiKeyStore.GetKey(ctx, keyHash)
+		if errors.Is(err, apikey.ErrKeyNotFound) {
+			abortWithError(c, http.StatusUnauthorized, errInvalidAPIKey)
+			return
+		}
+		if err != nil {
+			errorResponse(c, fmt.Errorf("failed to get API key: %w", err))
+			c.Abort()
+			return
+		}
+		if apiKey.Disabled {
+			abortWithError(c, http.StatusForbidden, errDisabledAPIKey)
+			return
+		}
+
+		now := time.Now().UTC()
+		day := now.Truncate(24 * time.Hour)
+		usage, err := s.apiKeyStore.IncrementUsage(ctx, keyHash, uint64(day.Unix()))
+		if err != nil {
+			errorResponse(c, fmt.Errorf("failed to count API key usage: %w", err))
+			c.Abort()
+			return
+		}
+
+		c.Set(apiKeyNameContextKey, apiKey.Name)
+		if apiKey.DailyQuota == 0 {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Set(quotaLimitHeader, strconv.FormatUint(apiKey.DailyQuota, 10))
+		if usage > apiKey.DailyQuota {
+			c.Writer.Header().Set(quotaRemainingHeader, "0")
+			retryAfter := day.Add(24 * time.Hour).Sub(now)
+			c.Writer.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			abortWithError(c, http.StatusTooManyRequests, errQuotaExceeded)
+			return
+		}
+		c.Writer.Header().Set(quotaRemainingHeader, strconv.FormatUint(apiKey.DailyQuota-usage, 10))
+		c.Next()
+	}
+}
+
+// requestAPIKey returns the API key carried by the request, or "" if there is none
+func requestAPIKey(c *gin.Context) string {
+	if key := c.GetHeader(apiKeyHeader); key != "" {
+		return key
+	}
+	return c.Query(apiKeyQueryParam)
+}
@@ -0,0 +1,127 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	keyPrefix         = "APIKey#"
+	keySK             = "Key"
+	usageSKPrefix     = "Usage#"
+	usageRequestsAttr = "Requests"
+)
+
+// dynamoStore keeps API keys and their daily usage in a DynamoDB table keyed by PK and SK.
+// Each key has one item for the key itself and one item per day it was used.
+type dynamoStore struct {
+	client    commondynamodb.Client
+	tableName string
+}
+
+func NewDynamoStore(client commondynamodb.Client, tableName string) Store {
+	return &dynamoStore{
+		client:    client,
+		tableName: tableName,
+	}
+}
+
+func (s *dynamoStore) PutKey(ctx context.Context, key *APIKey) error {
+	item, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal api key: %w", err)
+	}
+	item["PK"] = &types.AttributeValueMemberS{Value: keyPrefix + key.KeyHash}
+	item["SK"] = &types.AttributeValueMemberS{Value: keySK}
+	return s.client.PutItem(ctx, s.tableName, item)
+}
+
+func (s *dynamoStore) GetKey(ctx context.Context, keyHash string) (*APIKey, error) {
+	item, err := s.client.GetItem(ctx, s.tableName, commondynamodb.Key{
+		"PK": &types.AttributeValueMemberS{Value: keyPrefix + keyHash},
+		"SK": &types.AttributeValueMemberS{Value: keySK},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrKeyNotFound
+	}
+
+	key := &APIKey{}
+	if err := attributevalue.UnmarshalMap(item, key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal api key: %w", err)
+	}
+	return key, nil
+}
+
+func (s *dynamoStore) IncrementUsage(ctx context.Context, keyHash string, day uint64) (uint64, error) {
+	item, err := s.client.IncrementBy(ctx, s.tableName, usageKey(keyHash, day), usageRequestsAttr, 1)
+	if err != nil {
+		return 0, err
+	}
+	return parseUsage(item)
+}
+
+func (s *dynamoStore) GetUsage(ctx context.Context, keyHash string, day uint64) (uint64, error) {
+	item, err := s.client.GetItem(ctx, s.tableName, usageKey(keyHash, day))
+	if err != nil {
+		return 0, err
+	}
+	if item == nil {
+		return 0, nil
+	}
+	return parseUsage(item)
+}
+
+func usageKey(keyHash string, day uint64) commondynamodb.Key {
+	return commondynamodb.Key{
+		"PK": &types.AttributeValueMemberS{Value: keyPrefix + keyHash},
+		"SK": &types.AttributeValueMemberS{Value: usageSKPrefix + strconv.FormatUint(day, 10)},
+	}
+}
+
+func parseUsage(item commondynamodb.Item) (uint64, error) {
+	requests, ok := item[usageRequestsAttr].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("usage item has no %s attribute", usageRequestsAttr)
+	}
+	return strconv.ParseUint(requests.Value, 10, 64)
+}
+
+func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("PK"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("SK"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("PK"),
+				KeyType:       types.KeyTypeHash,
+			},
+			{
+				AttributeName: aws.String("SK"),
+				KeyType:       types.KeyTypeRange,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
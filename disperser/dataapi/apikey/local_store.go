@@ -0,0 +1,69 @@
+package apikey
+
+import (
+	"context"
+	"sync"
+)
+
+// localUsageRetentionDays is the number of days of usage kept by the local store
+const localUsageRetentionDays = 31
+
+type localUsageKey struct {
+	keyHash string
+	day     uint64
+}
+
+// localStore keeps API keys and usage in memory, for tests and single instance deployments
+type localStore struct {
+	mu    sync.Mutex
+	keys  map[string]APIKey
+	usage map[localUsageKey]uint64
+	// latest day usage was counted for
+	latestDay uint64
+}
+
+func NewLocalStore() Store {
+	return &localStore{
+		keys:  make(map[string]APIKey),
+		usage: make(map[localUsageKey]uint64),
+	}
+}
+
+func (s *localStore) PutKey(ctx context.Context, key *APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.KeyHash] = *key
+	return nil
+}
+
+func (s *localStore) GetKey(ctx context.Context, keyHash string) (*APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[keyHash]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return &key, nil
+}
+
+func (s *localStore) IncrementUsage(ctx context.Context, keyHash string, day uint64) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if day > s.latestDay {
+		s.latestDay = day
+		for k := range s.usage {
+			if k.day+localUsageRetentionDays*secondsPerDay <= day {
+				delete(s.usage, k)
+			}
+		}
+	}
+	k := localUsageKey{keyHash: keyHash, day: day}
+	s.usage[k]++
+	return s.usage[k], nil
+}
+
+func (s *localStore) GetUsage(ctx context.Context, keyHash string, day uint64) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usage[localUsageKey{keyHash: keyHash, day: day}], nil
+}
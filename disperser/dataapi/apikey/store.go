@@ -0,0 +1,46 @@
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// ErrKeyNotFound is returned when no API key matches the given hash
+var ErrKeyNotFound = errors.New("api key not found")
+
+// APIKey is a key granted to a client of the DataAPI. Only the hash of the key is stored.
+type APIKey struct {
+	// KeyHash is the hex encoded SHA-256 hash of the key
+	KeyHash string
+	// Name identifies the owner of the key in logs and usage reports
+	Name string
+	// DailyQuota is the max number of requests per UTC day; 0 means unlimited
+	DailyQuota uint64
+	// Disabled keys are rejected
+	Disabled bool
+	// CreatedAt is the Unix timestamp in seconds at which the key was created
+	CreatedAt uint64
+}
+
+// Store persists API keys and tracks their usage per UTC day.
+type Store interface {
+	// PutKey creates or replaces the API key
+	PutKey(ctx context.Context, key *APIKey) error
+	// GetKey returns the API key with the given hash, or ErrKeyNotFound
+	GetKey(ctx context.Context, keyHash string) (*APIKey, error)
+	// IncrementUsage counts a request made with the key on the given day (Unix timestamp in seconds
+	// of the start of the UTC day) and returns the number of requests made on that day so far
+	IncrementUsage(ctx context.Context, keyHash string, day uint64) (uint64, error)
+	// GetUsage returns the number of requests made with the key on the given day
+	GetUsage(ctx context.Context, keyHash string, day uint64) (uint64, error)
+}
+
+// HashKey returns the hash under which the key is stored
+func HashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,130 @@
+package apikey_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/apikey"
+	"github.com/Layr-Labs/eigenda/inabox/deploy"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	logger = logging.NewNoopLogger()
+
+	dockertestPool     *dockertest.Pool
+	dockertestResource *dockertest.Resource
+
+	deployLocalStack bool
+	localStackPort   = "4566"
+
+	dynamoStore  apikey.Store
+	keyTableName = "APIKeys"
+)
+
+func TestMain(m *testing.M) {
+	setup(m)
+	code := m.Run()
+	teardown()
+	os.Exit(code)
+}
+
+func setup(m *testing.M) {
+	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
+	if !deployLocalStack {
+		localStackPort = os.Getenv("LOCALSTACK_PORT")
+	}
+
+	if deployLocalStack {
+		var err error
+		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
+		if err != nil {
+			teardown()
+			panic("failed to start localstack container")
+		}
+	}
+
+	cfg := aws.ClientConfig{
+		Region:          "us-east-1",
+		AccessKey:       "localstack",
+		SecretAccessKey: "localstack",
+		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
+	}
+
+	_, err := test_utils.CreateTable(context.Background(), cfg, keyTableName, apikey.GenerateTableSchema(keyTableName, 10, 10))
+	if err != nil {
+		teardown()
+		panic("failed to create dynamodb table: " + err.Error())
+	}
+
+	dynamoClient, err := dynamodb.NewClient(cfg, logger)
+	if err != nil {
+		teardown()
+		panic("failed to create dynamodb client: " + err.Error())
+	}
+
+	dynamoStore = apikey.NewDynamoStore(dynamoClient, keyTableName)
+}
+
+func teardown() {
+	if deployLocalStack {
+		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
+	}
+}
+
+func TestStores(t *testing.T) {
+	stores := map[string]apikey.Store{
+		"local":  apikey.NewLocalStore(),
+		"dynamo": dynamoStore,
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			keyHash := apikey.HashKey("secret-" + name)
+
+			_, err := store.GetKey(ctx, keyHash)
+			assert.ErrorIs(t, err, apikey.ErrKeyNotFound)
+
+			key := &apikey.APIKey{
+				KeyHash:    keyHash,
+				Name:       "explorer",
+				DailyQuota: 100,
+				CreatedAt:  1700000000,
+			}
+			err = store.PutKey(ctx, key)
+			require.NoError(t, err)
+			fetched, err := store.GetKey(ctx, keyHash)
+			require.NoError(t, err)
+			assert.Equal(t, key, fetched)
+
+			day := uint64(1700006400)
+			usage, err := store.GetUsage(ctx, keyHash, day)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(0), usage)
+			for i := uint64(1); i <= 3; i++ {
+				usage, err = store.IncrementUsage(ctx, keyHash, day)
+				require.NoError(t, err)
+				assert.Equal(t, i, usage)
+			}
+			usage, err = store.GetUsage(ctx, keyHash, day)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(3), usage)
+
+			// usage is counted per day
+			usage, err = store.IncrementUsage(ctx, keyHash, day+24*60*60)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), usage)
+			usage, err = store.GetUsage(ctx, keyHash, day)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(3), usage)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/gammazero/workerpool"
+)
+
+// maxAttestationLatencyWindow bounds the window of the attestation latency, as the blobs of
+// every batch attested in it are fetched
+const maxAttestationLatencyWindow = 6 * time.Hour
+
+// getAttestationLatency computes, for each quorum, the distribution of latencies from dispersal
+// (the time the blob was requested) to attestation of the blobs in batches attested in the exclusive time range (start, end)
+func (s *ServerV2) getAttestationLatency(ctx context.Context, start, end time.Time) (*AttestationLatencyResponse, error) {
+	attestations, err := s.blobMetadataStore.GetAttestationByAttestedAt(ctx, uint64(start.UnixNano()), uint64(end.UnixNano()), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attestations: %w", err)
+	}
+
+	var (
+		mu        sync.Mutex
+		latencies = make(map[core.QuorumID][]uint64)
+		firstErr  error
+		pool      = workerpool.New(maxWorkerPoolSize)
+	)
+	for _, attestation := range attestations {
+		attestation := attestation
+		pool.Submit(func() {
+			batchLatencies, err := s.getBatchAttestationLatencies(ctx, attestation)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for q, l := range batchLatencies {
+				latencies[q] = append(latencies[q], l...)
+			}
+		})
+	}
+	pool.StopWait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	response := &AttestationLatencyResponse{
+		Start:   start.Unix(),
+		End:     end.Unix(),
+		Quorums: make(map[string]*LatencyDistribution, len(latencies)),
+	}
+	for q, l := range latencies {
+		response.Quorums[fmt.Sprintf("%d", q)] = computeLatencyDistribution(l)
+	}
+	return response, nil
+}
+
+// getBatchAttestationLatencies returns the latencies in nanoseconds of the blobs in the attested batch, by quorum
+func (s *ServerV2) getBatchAttestationLatencies(ctx context.Context, attestation *corev2.Attestation) (map[core.QuorumID][]uint64, error) {
+	batchHeaderHash, err := attestation.BatchHeader.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute batch header hash: %w", err)
+	}
+	verificationInfos, err := s.blobMetadataStore.GetBlobVerificationInfosByBatchHeaderHash(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blobs of batch %x: %w", batchHeaderHash, err)
+	}
+	if len(verificationInfos) == 0 {
+		return nil, nil
+	}
+	blobKeys := make([]corev2.BlobKey, len(verificationInfos))
+	for i, info := range verificationInfos {
+		blobKeys[i] = info.BlobKey
+	}
+	metadata, err := s.blobMetadataStore.GetBlobMetadataByKeys(ctx, blobKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob metadata of batch %x: %w", batchHeaderHash, err)
+	}
+
+	latencies := make(map[core.QuorumID][]uint64)
+	for _, m := range metadata {
+		if m.RequestedAt > attestation.AttestedAt {
+			s.logger.Warn("blob requested after its batch was attested", "batchHeaderHash", fmt.Sprintf("%x", batchHeaderHash))
+			continue
+		}
+		for _, q := range m.BlobHeader.QuorumNumbers {
+			latencies[q] = append(latencies[q], attestation.AttestedAt-m.RequestedAt)
+		}
+	}
+	return latencies, nil
+}
+
+// computeLatencyDistribution summarizes the non-empty latencies in nanoseconds, in milliseconds
+func computeLatencyDistribution(latencies []uint64) *LatencyDistribution {
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	var total float64
+	for _, l := range latencies {
+		total += float64(l)
+	}
+	toMs := func(ns float64) float64 {
+		return ns / float64(time.Millisecond)
+	}
+	return &LatencyDistribution{
+		NumBlobs: len(latencies),
+		Min:      toMs(float64(latencies[0])),
+		Mean:     toMs(total / float64(len(latencies))),
+		P50:      toMs(float64(percentile(latencies, 50))),
+		P90:      toMs(float64(percentile(latencies, 90))),
+		P95:      toMs(float64(percentile(latencies, 95))),
+		P99:      toMs(float64(percentile(latencies, 99))),
+		Max:      toMs(float64(latencies[len(latencies)-1])),
+	}
+}
@@ -0,0 +1,207 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// maxTxBatchHeaderCandidates bounds the number of batch headers looked up for a single transaction
+const maxTxBatchHeaderCandidates = 64
+
+// getReferenceBlockBatches returns the batches attested at the reference block, in the order they were attested,
+// with the result of each quorum checked against its confirmation threshold at that block
+func (s *ServerV2) getReferenceBlockBatches(ctx context.Context, referenceBlockNumber uint64) (*ReferenceBlockBatchesResponse, error) {
+	attestations, err := s.blobMetadataStore.GetAttestationsByReferenceBlock(ctx, referenceBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(attestations) == 0 {
+		return nil, fmt.Errorf("%w: no batches attested at reference block %d", errNotFound, referenceBlockNumber)
+	}
+
+	thresholds, err := s.getConfirmationThresholds(ctx, referenceBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]*BatchCertification, 0, len(attestations))
+	for _, attestation := range attestations {
+		batch, err := s.getBatchCertification(ctx, attestation, thresholds)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+
+	return &ReferenceBlockBatchesResponse{
+		ReferenceBlockNumber: referenceBlockNumber,
+		Batches:              batches,
+	}, nil
+}
+
+// getTxBatches returns the batches whose header is passed in the calldata of the transaction, such as the batches
+// of the certs verified by a call to the cert verifier. v2 batches aren't confirmed onchain by the disperser, so the
+// transactions which reference them are only known from their calldata: every pair of ABI words which may encode a
+// BatchHeaderV2, a nonzero root followed by a uint32 reference block number, is looked up as a batch header.
+func (s *ServerV2) getTxBatches(ctx context.Context, txHash gethcommon.Hash) (*TxBatchesResponse, error) {
+	tx, _, err := s.ethClient.TransactionByHash(ctx, txHash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("%w: transaction %s", errNotFound, txHash.Hex())
+		}
+		return nil, fmt.Errorf("failed to get transaction %s: %w", txHash.Hex(), err)
+	}
+
+	batches := make([]*BatchCertification, 0)
+	thresholdsByBlock := make(map[uint64]map[core.QuorumID]uint8)
+	for _, header := range batchHeaderCandidates(tx.Data()) {
+		batchHeaderHash, err := header.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute batch header hash: %w", err)
+		}
+		attestation, err := s.blobMetadataStore.GetAttestation(ctx, batchHeaderHash)
+		if err != nil {
+			if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get attestation of batch %x: %w", batchHeaderHash, err)
+		}
+		thresholds, ok := thresholdsByBlock[header.ReferenceBlockNumber]
+		if !ok {
+			thresholds, err = s.getConfirmationThresholds(ctx, header.ReferenceBlockNumber)
+			if err != nil {
+				return nil, err
+			}
+			thresholdsByBlock[header.ReferenceBlockNumber] = thresholds
+		}
+		batch, err := s.getBatchCertification(ctx, attestation, thresholds)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	if len(batches) == 0 {
+		return nil, fmt.Errorf("%w: no batches referenced by transaction %s", errNotFound, txHash.Hex())
+	}
+
+	return &TxBatchesResponse{
+		TxHash:  txHash.Hex(),
+		Batches: batches,
+	}, nil
+}
+
+// batchHeaderCandidates returns the batch headers which may be ABI encoded in the calldata, at most
+// maxTxBatchHeaderCandidates of them, in the order they appear
+func batchHeaderCandidates(data []byte) []*corev2.BatchHeader {
+	// skip the function selector
+	if len(data) < 4 {
+		return nil
+	}
+	data = data[4:]
+
+	candidates := make([]*corev2.BatchHeader, 0)
+	seen := make(map[corev2.BatchHeader]struct{})
+	for offset := 0; offset+64 <= len(data) && len(candidates) < maxTxBatchHeaderCandidates; offset += 32 {
+		var root [32]byte
+		copy(root[:], data[offset:offset+32])
+		blockWord := data[offset+32 : offset+64]
+		if root == ([32]byte{}) || !isUint32Word(blockWord) {
+			continue
+		}
+		header := corev2.BatchHeader{
+			BatchRoot:            root,
+			ReferenceBlockNumber: uint64(binary.BigEndian.Uint32(blockWord[28:])),
+		}
+		if header.ReferenceBlockNumber == 0 {
+			continue
+		}
+		if _, ok := seen[header]; ok {
+			continue
+		}
+		seen[header] = struct{}{}
+		candidates = append(candidates, &header)
+	}
+	return candidates
+}
+
+// isUint32Word returns true if the ABI word holds a uint32
+func isUint32Word(word []byte) bool {
+	for _, b := range word[:28] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// getConfirmationThresholds returns the confirmation threshold of each quorum at the reference block
+func (s *ServerV2) getConfirmationThresholds(ctx context.Context, referenceBlockNumber uint64) (map[core.QuorumID]uint8, error) {
+	securityParams, err := s.chainReader.GetQuorumSecurityParams(ctx, uint32(referenceBlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum security params at block %d: %w", referenceBlockNumber, err)
+	}
+	thresholds := make(map[core.QuorumID]uint8, len(securityParams))
+	for _, param := range securityParams {
+		thresholds[param.QuorumID] = param.ConfirmationThreshold
+	}
+	return thresholds, nil
+}
+
+// getBatchCertification returns the signed batch with its blob keys, and the result of each quorum checked against
+// its confirmation threshold
+func (s *ServerV2) getBatchCertification(ctx context.Context, attestation *corev2.Attestation, thresholds map[core.QuorumID]uint8) (*BatchCertification, error) {
+	batchHeaderHash, err := attestation.BatchHeader.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute batch header hash: %w", err)
+	}
+	verificationInfos, err := s.blobMetadataStore.GetBlobVerificationInfosByBatchHeaderHash(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blobs of batch %x: %w", batchHeaderHash, err)
+	}
+	sort.Slice(verificationInfos, func(i, j int) bool {
+		return verificationInfos[i].BlobIndex < verificationInfos[j].BlobIndex
+	})
+	blobKeys := make([]string, len(verificationInfos))
+	for i, info := range verificationInfos {
+		blobKeys[i] = info.BlobKey.Hex()
+	}
+
+	// A batch is certified only if every quorum it was attested in reached its threshold
+	certified := len(attestation.QuorumNumbers) > 0
+	quorums := make([]*BatchQuorumCertification, 0, len(attestation.QuorumNumbers))
+	for _, q := range attestation.QuorumNumbers {
+		threshold, ok := thresholds[q]
+		if !ok {
+			return nil, fmt.Errorf("no security params for quorum %d at block %d", q, attestation.ReferenceBlockNumber)
+		}
+		result := attestation.QuorumResults[q]
+		meetsThreshold := result >= threshold
+		certified = certified && meetsThreshold
+		quorums = append(quorums, &BatchQuorumCertification{
+			QuorumId:              q,
+			SignedPercentage:      result,
+			ConfirmationThreshold: threshold,
+			MeetsThreshold:        meetsThreshold,
+		})
+	}
+
+	return &BatchCertification{
+		BatchHeaderHash: hex.EncodeToString(batchHeaderHash[:]),
+		SignedBatch: &SignedBatch{
+			BatchHeader: attestation.BatchHeader,
+			Attestation: attestation,
+		},
+		Certified: certified,
+		Quorums:   quorums,
+		BlobKeys:  blobKeys,
+	}, nil
+}
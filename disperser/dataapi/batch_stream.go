@@ -0,0 +1,171 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// batchStreamPollInterval is how often the metadata store is polled for new attestations
+	batchStreamPollInterval = 2 * time.Second
+	// batchStreamSettleDelay holds back recent attestations, as they are stamped with AttestedAt
+	// before being written to the metadata store
+	batchStreamSettleDelay = 3 * time.Second
+	// batchStreamPollLimit is the max number of attestations fetched per query when polling
+	batchStreamPollLimit = 1000
+	// batchStreamSubscriberBufferSize is the number of batches buffered per subscriber before batches are dropped
+	batchStreamSubscriberBufferSize = 100
+
+	// batchSubscriptionWriteWait is the time allowed to write a message to the peer
+	batchSubscriptionWriteWait = 10 * time.Second
+	// batchSubscriptionPongWait is the time allowed to read the next pong message from the peer
+	batchSubscriptionPongWait = 60 * time.Second
+	// batchSubscriptionPingPeriod is how often pings are sent to the peer; must be less than the pong wait
+	batchSubscriptionPingPeriod = batchSubscriptionPongWait * 9 / 10
+)
+
+// batchStreamHandler fans out newly signed batches to subscribers.
+// The metadata store is only polled while there is at least one subscriber.
+type batchStreamHandler struct {
+	logger            logging.Logger
+	blobMetadataStore *blobstore.BlobMetadataStore
+
+	mu          sync.Mutex
+	subscribers map[chan *signedBatchEvent]struct{}
+	// cancels the polling loop, nil when it is not running
+	cancel context.CancelFunc
+}
+
+// signedBatchEvent is a signed batch as delivered to subscribers
+type signedBatchEvent struct {
+	batchHeaderHash string
+	attestation     *corev2.Attestation
+}
+
+func newBatchStreamHandler(logger logging.Logger, blobMetadataStore *blobstore.BlobMetadataStore) *batchStreamHandler {
+	return &batchStreamHandler{
+		logger:            logger,
+		blobMetadataStore: blobMetadataStore,
+		subscribers:       make(map[chan *signedBatchEvent]struct{}),
+	}
+}
+
+// subscribe registers a new subscriber which receives batches attested from now on
+func (h *batchStreamHandler) subscribe() chan *signedBatchEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan *signedBatchEvent, batchStreamSubscriberBufferSize)
+	h.subscribers[ch] = struct{}{}
+	if h.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+		go h.run(ctx, uint64(time.Now().UnixNano()))
+	}
+	return ch
+}
+
+// unsubscribe removes the subscriber, stopping the polling loop if it was the last one
+func (h *batchStreamHandler) unsubscribe(ch chan *signedBatchEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers, ch)
+	if len(h.subscribers) == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+// stop terminates the polling loop regardless of remaining subscribers
+func (h *batchStreamHandler) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+func (h *batchStreamHandler) run(ctx context.Context, cursor uint64) {
+	ticker := time.NewTicker(batchStreamPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		cursor = h.poll(ctx, cursor)
+	}
+}
+
+// poll fetches all batches attested after the cursor (exclusive), broadcasts them and returns the advanced cursor
+func (h *batchStreamHandler) poll(ctx context.Context, cursor uint64) uint64 {
+	end := uint64(time.Now().Add(-batchStreamSettleDelay).UnixNano())
+	for cursor+1 < end {
+		attestations, err := h.blobMetadataStore.GetAttestationByAttestedAt(ctx, cursor, end, batchStreamPollLimit)
+		if err != nil {
+			if ctx.Err() == nil {
+				h.logger.Warn("failed to poll attestations", "err", err)
+			}
+			return cursor
+		}
+
+		for _, attestation := range attestations {
+			cursor = attestation.AttestedAt
+			batchHeaderHash, err := attestation.BatchHeader.Hash()
+			if err != nil {
+				h.logger.Error("failed to compute batch header hash", "err", err)
+				continue
+			}
+			h.broadcast(ctx, &signedBatchEvent{
+				batchHeaderHash: hex.EncodeToString(batchHeaderHash[:]),
+				attestation:     attestation,
+			})
+		}
+
+		if len(attestations) < batchStreamPollLimit {
+			break
+		}
+	}
+	return cursor
+}
+
+func (h *batchStreamHandler) broadcast(ctx context.Context, batch *signedBatchEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// a stale loop may still be draining a page after it was cancelled
+	if ctx.Err() != nil {
+		return
+	}
+	for ch := range h.subscribers {
+		select {
+		case ch <- batch:
+		default:
+			h.logger.Warn("batch stream subscriber is too slow, dropping batch", "batchHeaderHash", batch.batchHeaderHash)
+		}
+	}
+}
+
+// matchesQuorums returns true if the batch contains any of the given quorums; an empty filter matches all batches
+func (e *signedBatchEvent) matchesQuorums(quorums map[core.QuorumID]struct{}) bool {
+	if len(quorums) == 0 {
+		return true
+	}
+	for _, q := range e.attestation.QuorumNumbers {
+		if _, ok := quorums[q]; ok {
+			return true
+		}
+	}
+	return false
+}
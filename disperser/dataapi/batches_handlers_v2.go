@@ -0,0 +1,645 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// FetchBatchFeedHandler godoc
+//
+//	@Summary	Fetch batch feed
+//	@Tags		Batch
+//	@Produce	json,text/csv,application/x-ndjson
+//	@Param		after	query		string	false	"Fetch batches attested after this time (exclusive) in UTC (2006-01-02T15:04:05Z) [default: before-1h; max: 7 days before before]"
+//	@Param		before	query		string	false	"Fetch batches attested before this time (exclusive) in UTC (2006-01-02T15:04:05Z) [default: now]"
+//	@Param		cursor	query		string	false	"Pagination cursor (opaque string from previous response); takes precedence over after"
+//	@Param		limit	query		int		false	"Maximum number of batches to return [default: 20; max: 1000]"
+//	@Param		format	query		string	false	"Response format: json, or csv or ndjson to stream all batches in the range, ignoring limit [default: json]"
+//	@Success	200		{object}	BatchFeedResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/batches/feed [get]
+func (s *ServerV2) FetchBatchFeedHandler(c *gin.Context) {
+	after, before, page, err := parseFeedParams(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+	format, err := parseFormat(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+
+	startCursor := blobstore.AttestationFeedCursor{AttestedAt: uint64(after.UnixNano())}
+	if page.cursor != "" {
+		cursor, err := decodeBatchFeedCursor(page.cursor)
+		if err != nil {
+			invalidParamsErrorResponse(c, fmt.Errorf("failed to parse cursor: %w", err))
+			return
+		}
+		startCursor = *cursor
+	}
+	endAttestedAt := uint64(before.UnixNano())
+	if startCursor.AttestedAt >= endAttestedAt {
+		invalidParamsErrorResponse(c, errors.New("cursor must be before the before param"))
+		return
+	}
+
+	if format != formatJSON {
+		s.exportBatchFeed(c, format, startCursor, endAttestedAt)
+		return
+	}
+
+	attestations, lastCursor, err := s.blobMetadataStore.GetAttestationFeed(c.Request.Context(), startCursor, endAttestedAt, page.limit)
+	if err != nil {
+		errorResponse(c, fmt.Errorf("failed to fetch feed from blob metadata store: %w", err))
+		return
+	}
+
+	batches, err := newBatchInfos(attestations)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	// Only hand out a cursor when the page is full, otherwise the range is exhausted
+	var paginationToken string
+	if len(attestations) == page.limit {
+		paginationToken = encodeBatchFeedCursor(lastCursor)
+	}
+
+	response := &BatchFeedResponse{
+		Batches:         batches,
+		Pagination:      page.pagination(paginationToken),
+		PaginationToken: paginationToken,
+	}
+	setCacheMaxAge(c, maxFeedBlobsAge*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// newBatchInfos summarizes the attested batches
+func newBatchInfos(attestations []*corev2.Attestation) ([]*BatchInfo, error) {
+	batches := make([]*BatchInfo, 0, len(attestations))
+	for _, at := range attestations {
+		batchHeaderHash, err := at.BatchHeader.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute batch header hash: %w", err)
+		}
+		batches = append(batches, &BatchInfo{
+			BatchHeaderHash:         hex.EncodeToString(batchHeaderHash[:]),
+			BatchHeader:             at.BatchHeader,
+			AttestedAt:              at.AttestedAt,
+			AggregatedSignature:     at.Sigma,
+			QuorumNumbers:           at.QuorumNumbers,
+			QuorumSignedPercentages: at.QuorumResults,
+		})
+	}
+	return batches, nil
+}
+
+// decodeBatchFeedCursor decodes a batch feed cursor. Cursors holding only the attestation time, handed out before
+// the batch header hash was added, are still accepted.
+func decodeBatchFeedCursor(token string) (*blobstore.AttestationFeedCursor, error) {
+	decoded, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token: %w", err)
+	}
+	return (&blobstore.AttestationFeedCursor{}).FromCursorKey(string(decoded))
+}
+
+func encodeBatchFeedCursor(cursor *blobstore.AttestationFeedCursor) string {
+	return base64.URLEncoding.EncodeToString([]byte(cursor.ToCursorKey()))
+}
+
+// SubscribeBatchesHandler godoc
+//
+//	@Summary	Subscribe to newly signed batches (WebSocket)
+//	@Tags		Batch
+//	@Produce	json
+//	@Param		quorums	query		string	false	"Comma-separated quorum IDs; only batches containing any of them are sent [default: all quorums]"
+//	@Success	101		{object}	BatchSubscriptionMessage
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Router		/batch/subscribe [get]
+func (s *ServerV2) SubscribeBatchesHandler(c *gin.Context) {
+	quorums, err := parseQuorumsFilter(c.Query("quorums"))
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+
+	// Subscribe before completing the handshake, so no batch landing right after it is missed
+	ch := s.batchStreamHandler.subscribe()
+	defer s.batchStreamHandler.unsubscribe(ch)
+
+	upgrader := websocket.Upgrader{CheckOrigin: s.checkWebSocketOrigin}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader has already replied to the client
+		s.logger.Warn("failed to upgrade batch subscription", "err", err)
+		return
+	}
+	defer conn.Close()
+
+	// Client messages are not expected, but the connection must be read to process control frames
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		_ = conn.SetReadDeadline(time.Now().Add(batchSubscriptionPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(batchSubscriptionPongWait))
+		})
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(batchSubscriptionPingPeriod)
+	defer ping.Stop()
+	shuttingDown := s.shuttingDown()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-shuttingDown:
+			_ = conn.SetWriteDeadline(time.Now().Add(batchSubscriptionWriteWait))
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"))
+			return
+		case <-ping.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(batchSubscriptionWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case batch := <-ch:
+			if !batch.matchesQuorums(quorums) {
+				continue
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(batchSubscriptionWriteWait))
+			err := conn.WriteJSON(&BatchSubscriptionMessage{
+				BatchHeaderHash: batch.batchHeaderHash,
+				SignedBatch: &SignedBatch{
+					BatchHeader: batch.attestation.BatchHeader,
+					Attestation: batch.attestation,
+				},
+			})
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseQuorumsFilter parses a comma-separated list of quorum IDs; an empty string yields an empty filter
+func parseQuorumsFilter(param string) (map[core.QuorumID]struct{}, error) {
+	quorums := make(map[core.QuorumID]struct{})
+	if param == "" {
+		return quorums, nil
+	}
+	for _, q := range strings.Split(param, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(q), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quorum ID %q: %w", q, err)
+		}
+		quorums[core.QuorumID(id)] = struct{}{}
+	}
+	return quorums, nil
+}
+
+// checkWebSocketOrigin applies the CORS allowed origins to WebSocket handshakes
+func (s *ServerV2) checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || s.serverMode != gin.ReleaseMode {
+		return true
+	}
+	for _, allowed := range s.allowOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchBatchHandler godoc
+//
+//	@Summary	Fetch batch by the batch header hash
+//	@Tags		Batch
+//	@Produce	json
+//	@Param		batch_header_hash	path		string	true	"Batch header hash in hex string"
+//	@Success	200					{object}	BatchResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/batches/{batch_header_hash} [get]
+func (s *ServerV2) FetchBatchHandler(c *gin.Context) {
+	batchHeaderHashHex := c.Param("batch_header_hash")
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(batchHeaderHashHex))
+	if err != nil {
+		invalidParamsErrorResponse(c, errors.New("invalid batch header hash"))
+		return
+	}
+	batchResponse, err := s.getBatch(c.Request.Context(), batchHeaderHash)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	batchResponse.BatchHeaderHash = batchHeaderHashHex
+	setCacheMaxAge(c, s.cachePolicy.BatchMaxAge)
+	c.JSON(http.StatusOK, batchResponse)
+}
+
+func (s *ServerV2) getBatch(ctx context.Context, batchHeaderHash [32]byte) (*BatchResponse, error) {
+	batchHeader, attestation, err := s.blobMetadataStore.GetSignedBatch(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, err
+	}
+	blobVerificationInfos, err := s.blobMetadataStore.GetBlobVerificationInfosByBatchHeaderHash(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(blobVerificationInfos, func(i, j int) bool {
+		return blobVerificationInfos[i].BlobIndex < blobVerificationInfos[j].BlobIndex
+	})
+	return &BatchResponse{
+		BatchHeaderHash: hex.EncodeToString(batchHeaderHash[:]),
+		SignedBatch: &SignedBatch{
+			BatchHeader: batchHeader,
+			Attestation: attestation,
+		},
+		BlobVerificationInfos: blobVerificationInfos,
+	}, nil
+}
+
+// FetchBatchesByReferenceBlockHandler godoc
+//
+//	@Summary	Fetch the batches anchored at a reference block, with their attestation and certification status
+//	@Tags		Batch
+//	@Produce	json
+//	@Param		block_number	path		int	true	"Reference block number"
+//	@Success	200				{object}	ReferenceBlockBatchesResponse
+//	@Failure	400				{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404				{object}	ErrorResponse	"error: Not found"
+//	@Failure	500				{object}	ErrorResponse	"error: Server error"
+//	@Router		/batches/by-reference-block/{block_number} [get]
+func (s *ServerV2) FetchBatchesByReferenceBlockHandler(c *gin.Context) {
+	referenceBlockNumber, err := strconv.ParseUint(c.Param("block_number"), 10, 32)
+	if err != nil {
+		invalidParamsErrorResponse(c, errors.New("invalid block number"))
+		return
+	}
+	response, err := s.getReferenceBlockBatches(c.Request.Context(), referenceBlockNumber)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setCacheMaxAge(c, s.cachePolicy.BatchMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// FetchBatchesByTxHashHandler godoc
+//
+//	@Summary	Fetch the batches referenced by an Ethereum transaction, with their attestation and certification status
+//	@Tags		Batch
+//	@Produce	json
+//	@Param		tx_hash	path		string	true	"Transaction hash in hex string"
+//	@Success	200		{object}	TxBatchesResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/batches/by-tx-hash/{tx_hash} [get]
+func (s *ServerV2) FetchBatchesByTxHashHandler(c *gin.Context) {
+	txHash := gethcommon.HexToHash(c.Param("tx_hash"))
+	response, err := s.getTxBatches(c.Request.Context(), txHash)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setCacheMaxAge(c, s.cachePolicy.BatchMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// FetchBatchBlobsHandler godoc
+//
+//	@Summary	Fetch the blobs included in a batch, in the order of their index in the batch
+//	@Tags		Batch
+//	@Produce	json
+//	@Param		batch_header_hash	path		string	true	"Batch header hash in hex string"
+//	@Param		cursor				query		string	false	"Pagination cursor (opaque string from previous response)"
+//	@Param		limit				query		int		false	"Maximum number of blobs to return [default: 20; max: 1000]"
+//	@Success	200					{object}	BatchBlobsResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/batches/{batch_header_hash}/blobs [get]
+func (s *ServerV2) FetchBatchBlobsHandler(c *gin.Context) {
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(c.Param("batch_header_hash")))
+	if err != nil {
+		invalidParamsErrorResponse(c, errors.New("invalid batch header hash"))
+		return
+	}
+	page, err := parsePageParams(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+	offset := 0
+	if page.cursor != "" {
+		offset, err = decodeOffsetCursor(page.cursor)
+		if err != nil {
+			invalidParamsErrorResponse(c, fmt.Errorf("failed to parse cursor: %w", err))
+			return
+		}
+	}
+
+	blobs, total, err := s.getBatchBlobs(c.Request.Context(), batchHeaderHash, offset, page.limit)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	var paginationToken string
+	if end := offset + len(blobs); end < total {
+		paginationToken = encodeOffsetCursor(end)
+	}
+	response := &BatchBlobsResponse{
+		BatchHeaderHash: hex.EncodeToString(batchHeaderHash[:]),
+		Blobs:           blobs,
+		Pagination:      page.pagination(paginationToken),
+		PaginationToken: paginationToken,
+	}
+	setCacheMaxAge(c, s.cachePolicy.BatchMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// getBatchBlobs returns at most limit blobs of the batch starting at offset in the order of their index in the batch,
+// and the total number of blobs in the batch
+func (s *ServerV2) getBatchBlobs(ctx context.Context, batchHeaderHash [32]byte, offset, limit int) ([]*BatchBlob, int, error) {
+	verificationInfos, err := s.blobMetadataStore.GetBlobVerificationInfosByBatchHeaderHash(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get blob verification infos: %w", err)
+	}
+	// A batch has at least one blob
+	if len(verificationInfos) == 0 {
+		return nil, 0, fmt.Errorf("%w: no blobs for batch %x", errNotFound, batchHeaderHash)
+	}
+	sort.Slice(verificationInfos, func(i, j int) bool {
+		return verificationInfos[i].BlobIndex < verificationInfos[j].BlobIndex
+	})
+
+	total := len(verificationInfos)
+	end := offset + limit
+	if offset > total {
+		offset = total
+	}
+	if end > total {
+		end = total
+	}
+	pageInfos := verificationInfos[offset:end]
+	if len(pageInfos) == 0 {
+		return make([]*BatchBlob, 0), total, nil
+	}
+
+	blobKeys := make([]corev2.BlobKey, len(pageInfos))
+	for i, info := range pageInfos {
+		blobKeys[i] = info.BlobKey
+	}
+	metadata, err := s.blobMetadataStore.GetBlobMetadataByKeys(ctx, blobKeys)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get blob metadata: %w", err)
+	}
+	metadataByKey := make(map[corev2.BlobKey]*commonv2.BlobMetadata, len(metadata))
+	for _, m := range metadata {
+		blobKey, err := m.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to compute blob key: %w", err)
+		}
+		metadataByKey[blobKey] = m
+	}
+
+	blobs := make([]*BatchBlob, 0, len(pageInfos))
+	for _, info := range pageInfos {
+		blob := &BatchBlob{
+			BlobKey:   info.BlobKey.Hex(),
+			BlobIndex: info.BlobIndex,
+		}
+		if m, ok := metadataByKey[info.BlobKey]; ok {
+			blob.BlobHeader = m.BlobHeader
+			blob.Status = m.BlobStatus.String()
+			blob.BlobSizeBytes = m.BlobSize
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, total, nil
+}
+
+// FetchBatchSigningInfoHandler godoc
+//
+//	@Summary	Fetch per-quorum signing info of a batch
+//	@Tags		Batch
+//	@Produce	json
+//	@Param		batch_header_hash	path		string	true	"Batch header hash in hex string"
+//	@Success	200					{object}	BatchSigningInfoResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/batches/{batch_header_hash}/signing-info [get]
+func (s *ServerV2) FetchBatchSigningInfoHandler(c *gin.Context) {
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(c.Param("batch_header_hash")))
+	if err != nil {
+		invalidParamsErrorResponse(c, errors.New("invalid batch header hash"))
+		return
+	}
+	response, err := s.getBatchSigningInfo(c.Request.Context(), batchHeaderHash)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setCacheMaxAge(c, s.cachePolicy.BatchMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// getBatchSigningInfo computes the signed stake of each quorum in the batch from its attestation
+// and the operator state at the batch reference block
+func (s *ServerV2) getBatchSigningInfo(ctx context.Context, batchHeaderHash [32]byte) (*BatchSigningInfoResponse, error) {
+	attestation, err := s.blobMetadataStore.GetAttestation(ctx, batchHeaderHash)
+	if err != nil {
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			return nil, fmt.Errorf("%w: no attestation for batch %x", errNotFound, batchHeaderHash)
+		}
+		return nil, fmt.Errorf("failed to get attestation: %w", err)
+	}
+
+	referenceBlockNumber := attestation.ReferenceBlockNumber
+	state, err := s.chainState.GetOperatorState(ctx, uint(referenceBlockNumber), attestation.QuorumNumbers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", referenceBlockNumber, err)
+	}
+	securityParams, err := s.chainReader.GetQuorumSecurityParams(ctx, uint32(referenceBlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum security params at block %d: %w", referenceBlockNumber, err)
+	}
+	thresholds := make(map[core.QuorumID]uint8, len(securityParams))
+	for _, param := range securityParams {
+		thresholds[param.QuorumID] = param.ConfirmationThreshold
+	}
+
+	nonSignerIDs := make([]core.OperatorID, len(attestation.NonSignerPubKeys))
+	nonSigners := make([]string, len(attestation.NonSignerPubKeys))
+	for i, pubKey := range attestation.NonSignerPubKeys {
+		nonSignerIDs[i] = pubKey.GetOperatorID()
+		nonSigners[i] = nonSignerIDs[i].Hex()
+	}
+
+	quorums := make([]*BatchQuorumSigningInfo, 0, len(attestation.QuorumNumbers))
+	for _, q := range attestation.QuorumNumbers {
+		total, ok := state.Totals[q]
+		if !ok || total.Stake.Sign() == 0 {
+			return nil, fmt.Errorf("no stake in quorum %d at block %d", q, referenceBlockNumber)
+		}
+		threshold, ok := thresholds[q]
+		if !ok {
+			return nil, fmt.Errorf("no security params for quorum %d at block %d", q, referenceBlockNumber)
+		}
+
+		quorumNonSigners := make([]string, 0)
+		nonSignedStake := new(big.Int)
+		for _, opID := range nonSignerIDs {
+			opInfo, ok := state.Operators[q][opID]
+			if !ok {
+				continue
+			}
+			nonSignedStake.Add(nonSignedStake, opInfo.Stake)
+			quorumNonSigners = append(quorumNonSigners, opID.Hex())
+		}
+		signedStake := new(big.Int).Sub(total.Stake, nonSignedStake)
+
+		quorums = append(quorums, &BatchQuorumSigningInfo{
+			QuorumId:              q,
+			TotalStake:            new(big.Int).Set(total.Stake),
+			SignedStake:           signedStake,
+			SignedPercentage:      core.GetSignedPercentage(state, q, new(big.Int).Set(signedStake)),
+			ConfirmationThreshold: threshold,
+			MeetsThreshold:        signedStake.Cmp(core.GetStakeThreshold(state, q, threshold)) >= 0,
+			NonSigners:            quorumNonSigners,
+		})
+	}
+
+	return &BatchSigningInfoResponse{
+		BatchHeaderHash:      hex.EncodeToString(batchHeaderHash[:]),
+		ReferenceBlockNumber: referenceBlockNumber,
+		NonSigners:           nonSigners,
+		Quorums:              quorums,
+	}, nil
+}
+
+// FetchBatchSignersHandler godoc
+//
+//	@Summary	Fetch the operators which signed a batch in each quorum, with their stake at the reference block
+//	@Tags		Batch
+//	@Produce	json
+//	@Param		batch_header_hash	path		string	true	"Batch header hash in hex string"
+//	@Success	200					{object}	BatchSignersResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/batches/{batch_header_hash}/signers [get]
+func (s *ServerV2) FetchBatchSignersHandler(c *gin.Context) {
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(c.Param("batch_header_hash")))
+	if err != nil {
+		invalidParamsErrorResponse(c, errors.New("invalid batch header hash"))
+		return
+	}
+	response, err := s.getBatchSigners(c.Request.Context(), batchHeaderHash)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setCacheMaxAge(c, s.cachePolicy.BatchMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// getBatchSigners expands the attestation of the batch into the operators of each quorum which signed it: the
+// operators registered in the quorum at the batch reference block, less the non-signers
+func (s *ServerV2) getBatchSigners(ctx context.Context, batchHeaderHash [32]byte) (*BatchSignersResponse, error) {
+	attestation, err := s.blobMetadataStore.GetAttestation(ctx, batchHeaderHash)
+	if err != nil {
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			return nil, fmt.Errorf("%w: no attestation for batch %x", errNotFound, batchHeaderHash)
+		}
+		return nil, fmt.Errorf("failed to get attestation: %w", err)
+	}
+
+	referenceBlockNumber := attestation.ReferenceBlockNumber
+	state, err := s.chainState.GetOperatorState(ctx, uint(referenceBlockNumber), attestation.QuorumNumbers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", referenceBlockNumber, err)
+	}
+
+	nonSigners := make(map[core.OperatorID]struct{}, len(attestation.NonSignerPubKeys))
+	for _, pubKey := range attestation.NonSignerPubKeys {
+		nonSigners[pubKey.GetOperatorID()] = struct{}{}
+	}
+
+	quorums := make([]*BatchQuorumSigners, 0, len(attestation.QuorumNumbers))
+	for _, q := range attestation.QuorumNumbers {
+		total, ok := state.Totals[q]
+		if !ok || total.Stake.Sign() == 0 {
+			return nil, fmt.Errorf("no stake in quorum %d at block %d", q, referenceBlockNumber)
+		}
+
+		signers := make([]*BatchSigner, 0, len(state.Operators[q]))
+		signedStake := new(big.Int)
+		for opID, opInfo := range state.Operators[q] {
+			if _, ok := nonSigners[opID]; ok {
+				continue
+			}
+			signedStake.Add(signedStake, opInfo.Stake)
+			percentage, _ := new(big.Rat).SetFrac(new(big.Int).Mul(opInfo.Stake, big.NewInt(100)), total.Stake).Float64()
+			signers = append(signers, &BatchSigner{
+				OperatorId:      opID.Hex(),
+				Stake:           new(big.Int).Set(opInfo.Stake),
+				StakePercentage: percentage,
+			})
+		}
+		sort.Slice(signers, func(i, j int) bool {
+			if c := signers[i].Stake.Cmp(signers[j].Stake); c != 0 {
+				return c > 0
+			}
+			return signers[i].OperatorId < signers[j].OperatorId
+		})
+
+		quorums = append(quorums, &BatchQuorumSigners{
+			QuorumId:    q,
+			TotalStake:  new(big.Int).Set(total.Stake),
+			SignedStake: signedStake,
+			Signers:     signers,
+		})
+	}
+
+	return &BatchSignersResponse{
+		BatchHeaderHash:      hex.EncodeToString(batchHeaderHash[:]),
+		ReferenceBlockNumber: referenceBlockNumber,
+		AttestedAt:           attestation.AttestedAt,
+		Quorums:              quorums,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+)
+
+const (
+	// blobSizeHistogramMinBucket is the upper bound in bytes of the smallest histogram bucket;
+	// each following bucket doubles the upper bound of the previous one
+	blobSizeHistogramMinBucket = 1024
+	// maxBlobSizeHistogramWindow bounds the window of a histogram, as every blob in it is scanned
+	maxBlobSizeHistogramWindow = 24 * time.Hour
+)
+
+// getBlobSizeHistogram computes the size distribution of the blobs requested in the exclusive time range (start, end)
+func (s *ServerV2) getBlobSizeHistogram(ctx context.Context, start, end time.Time) (*BlobSizeHistogram, error) {
+	blobs, _, err := s.blobMetadataStore.GetBlobMetadataByRequestedAt(
+		ctx,
+		blobstore.BlobFeedCursor{RequestedAt: uint64(start.UnixNano())},
+		blobstore.BlobFeedCursor{RequestedAt: uint64(end.UnixNano())},
+		0,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blobs requested in range: %w", err)
+	}
+
+	sizes := make([]uint64, len(blobs))
+	for i, b := range blobs {
+		sizes[i] = b.BlobSize
+	}
+	histogram := computeBlobSizeHistogram(sizes)
+	histogram.Start = start.Unix()
+	histogram.End = end.Unix()
+	return histogram, nil
+}
+
+// computeBlobSizeHistogram buckets the sizes into power of two buckets, from the smallest bucket up to
+// the bucket of the largest size, and computes nearest-rank percentiles
+func computeBlobSizeHistogram(sizes []uint64) *BlobSizeHistogram {
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i] < sizes[j] })
+
+	histogram := &BlobSizeHistogram{
+		NumBlobs: len(sizes),
+		Buckets:  make([]*BlobSizeBucket, 0),
+	}
+	if len(sizes) == 0 {
+		return histogram
+	}
+
+	bucket := &BlobSizeBucket{UpperBound: blobSizeHistogramMinBucket}
+	histogram.Buckets = append(histogram.Buckets, bucket)
+	for _, size := range sizes {
+		histogram.TotalBytes += size
+		for size > bucket.UpperBound {
+			bucket = &BlobSizeBucket{UpperBound: bucket.UpperBound * 2}
+			histogram.Buckets = append(histogram.Buckets, bucket)
+		}
+		bucket.Count++
+	}
+
+	histogram.P50 = percentile(sizes, 50)
+	histogram.P95 = percentile(sizes, 95)
+	histogram.P99 = percentile(sizes, 99)
+	return histogram
+}
+
+// percentile returns the nearest-rank p-th percentile of the sorted, non-empty values
+func percentile(sorted []uint64, p int) uint64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
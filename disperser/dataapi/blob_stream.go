@@ -0,0 +1,143 @@
+package dataapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// blobStreamPollInterval is how often the metadata store is polled for newly certified blobs
+	blobStreamPollInterval = 2 * time.Second
+	// blobStreamPollLimit is the max number of blobs fetched per page when polling
+	blobStreamPollLimit = 1000
+	// blobStreamSubscriberBufferSize is the number of events buffered per subscriber before events are dropped
+	blobStreamSubscriberBufferSize = 1000
+	// blobStreamKeepAliveInterval is how often a keep-alive comment is sent on idle streams
+	blobStreamKeepAliveInterval = 15 * time.Second
+)
+
+// blobStreamHandler fans out newly certified blobs to stream subscribers.
+// The metadata store is only polled while there is at least one subscriber.
+type blobStreamHandler struct {
+	logger            logging.Logger
+	blobMetadataStore *blobstore.BlobMetadataStore
+
+	mu          sync.Mutex
+	subscribers map[chan *BlobInfo]struct{}
+	// cancels the polling loop, nil when it is not running
+	cancel context.CancelFunc
+}
+
+func newBlobStreamHandler(logger logging.Logger, blobMetadataStore *blobstore.BlobMetadataStore) *blobStreamHandler {
+	return &blobStreamHandler{
+		logger:            logger,
+		blobMetadataStore: blobMetadataStore,
+		subscribers:       make(map[chan *BlobInfo]struct{}),
+	}
+}
+
+// subscribe registers a new subscriber which receives blobs certified from now on
+func (h *blobStreamHandler) subscribe() chan *BlobInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan *BlobInfo, blobStreamSubscriberBufferSize)
+	h.subscribers[ch] = struct{}{}
+	if h.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+		cursor := &blobstore.StatusIndexCursor{UpdatedAt: uint64(time.Now().UnixNano())}
+		go h.run(ctx, cursor)
+	}
+	return ch
+}
+
+// unsubscribe removes the subscriber, stopping the polling loop if it was the last one
+func (h *blobStreamHandler) unsubscribe(ch chan *BlobInfo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers, ch)
+	if len(h.subscribers) == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+// stop terminates the polling loop regardless of remaining subscribers
+func (h *blobStreamHandler) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+func (h *blobStreamHandler) run(ctx context.Context, cursor *blobstore.StatusIndexCursor) {
+	ticker := time.NewTicker(blobStreamPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		cursor = h.poll(ctx, cursor)
+	}
+}
+
+// poll fetches all blobs certified after the cursor, broadcasts them and returns the advanced cursor
+func (h *blobStreamHandler) poll(ctx context.Context, cursor *blobstore.StatusIndexCursor) *blobstore.StatusIndexCursor {
+	for {
+		blobs, newCursor, err := h.blobMetadataStore.GetBlobMetadataByStatusPaginated(ctx, commonv2.Certified, cursor, blobStreamPollLimit)
+		if err != nil {
+			if ctx.Err() == nil {
+				h.logger.Warn("failed to poll certified blobs", "err", err)
+			}
+			return cursor
+		}
+		if newCursor != nil {
+			cursor = newCursor
+		}
+
+		for _, metadata := range blobs {
+			blobKey, err := metadata.BlobHeader.BlobKey()
+			if err != nil {
+				h.logger.Error("failed to compute blob key", "err", err)
+				continue
+			}
+			h.broadcast(ctx, &BlobInfo{
+				BlobKey:      blobKey.Hex(),
+				BlobMetadata: metadata,
+			})
+		}
+
+		if len(blobs) < blobStreamPollLimit {
+			return cursor
+		}
+	}
+}
+
+func (h *blobStreamHandler) broadcast(ctx context.Context, blob *BlobInfo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// a stale loop may still be draining a page after it was cancelled
+	if ctx.Err() != nil {
+		return
+	}
+	for ch := range h.subscribers {
+		select {
+		case ch <- blob:
+		default:
+			h.logger.Warn("blob stream subscriber is too slow, dropping event", "blobKey", blob.BlobKey)
+		}
+	}
+}
@@ -0,0 +1,487 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/gin-gonic/gin"
+)
+
+// FetchBlobFeedHandler godoc
+//
+//	@Summary	Fetch blob feed
+//	@Tags		Blob
+//	@Produce	json,text/csv,application/x-ndjson
+//	@Param		after	query		string	false	"Fetch blobs after this time (exclusive) in UTC (2006-01-02T15:04:05Z) [default: before-1h; max: 7 days before before]"
+//	@Param		before	query		string	false	"Fetch blobs before this time (exclusive) in UTC (2006-01-02T15:04:05Z) [default: now]"
+//	@Param		cursor	query		string	false	"Pagination cursor (opaque string from previous response); takes precedence over after"
+//	@Param		limit	query		int		false	"Maximum number of blobs to return [default: 20; max: 1000]"
+//	@Param		format	query		string	false	"Response format: json, or csv or ndjson to stream all blobs in the range, ignoring limit [default: json]"
+//	@Success	200		{object}	BlobFeedResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/blobs/feed [get]
+func (s *ServerV2) FetchBlobFeedHandler(c *gin.Context) {
+	after, before, page, err := parseFeedParams(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+	format, err := parseFormat(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+
+	startCursor := blobstore.BlobFeedCursor{
+		RequestedAt: uint64(after.UnixNano()),
+	}
+	if page.cursor != "" {
+		cursor, err := decodeBlobFeedCursor(page.cursor)
+		if err != nil {
+			invalidParamsErrorResponse(c, fmt.Errorf("failed to parse cursor: %w", err))
+			return
+		}
+		startCursor = *cursor
+	}
+	endCursor := blobstore.BlobFeedCursor{
+		RequestedAt: uint64(before.UnixNano()),
+	}
+	if !startCursor.LessThan(&endCursor) {
+		invalidParamsErrorResponse(c, errors.New("cursor must be before the before param"))
+		return
+	}
+
+	if format != formatJSON {
+		s.exportBlobFeed(c, format, startCursor, endCursor)
+		return
+	}
+
+	blobs, lastCursor, err := s.blobMetadataStore.GetBlobMetadataByRequestedAt(c.Request.Context(), startCursor, endCursor, page.limit)
+	if err != nil {
+		errorResponse(c, fmt.Errorf("failed to fetch feed from blob metadata store: %w", err))
+		return
+	}
+
+	blobInfo, err := newBlobInfos(blobs)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	// Only hand out a cursor when the page is full, otherwise the range is exhausted
+	var paginationToken string
+	if lastCursor != nil && len(blobs) == page.limit {
+		paginationToken = encodeBlobFeedCursor(lastCursor)
+	}
+
+	response := &BlobFeedResponse{
+		Blobs:           blobInfo,
+		Pagination:      page.pagination(paginationToken),
+		PaginationToken: paginationToken,
+	}
+	setCacheMaxAge(c, maxFeedBlobsAge*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// parseFeedParams parses the time range and page query params shared by the feed endpoints
+func parseFeedParams(c *gin.Context) (time.Time, time.Time, pageParams, error) {
+	var err error
+	now := time.Now()
+	before := now
+	if c.Query("before") != "" {
+		before, err = time.Parse(feedTimeLayout, c.Query("before"))
+		if err != nil {
+			return time.Time{}, time.Time{}, pageParams{}, fmt.Errorf("failed to parse before param: %w", err)
+		}
+		if before.After(now) {
+			before = now
+		}
+	}
+
+	after := before.Add(-time.Hour)
+	if c.Query("after") != "" {
+		after, err = time.Parse(feedTimeLayout, c.Query("after"))
+		if err != nil {
+			return time.Time{}, time.Time{}, pageParams{}, fmt.Errorf("failed to parse after param: %w", err)
+		}
+	}
+	if !after.Before(before) {
+		return time.Time{}, time.Time{}, pageParams{}, errors.New("after must be before before")
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		return time.Time{}, time.Time{}, pageParams{}, err
+	}
+
+	return after, before, page, nil
+}
+
+// newBlobInfos keys the blob metadata by blob key
+func newBlobInfos(blobs []*commonv2.BlobMetadata) ([]BlobInfo, error) {
+	blobInfo := make([]BlobInfo, 0, len(blobs))
+	for _, metadata := range blobs {
+		blobKey, err := metadata.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob key: %w", err)
+		}
+		blobInfo = append(blobInfo, BlobInfo{
+			BlobKey:      blobKey.Hex(),
+			BlobMetadata: metadata,
+		})
+	}
+	return blobInfo, nil
+}
+
+func decodeBlobFeedCursor(token string) (*blobstore.BlobFeedCursor, error) {
+	decoded, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token: %w", err)
+	}
+	return (&blobstore.BlobFeedCursor{}).FromCursorKey(string(decoded))
+}
+
+func encodeBlobFeedCursor(cursor *blobstore.BlobFeedCursor) string {
+	return base64.URLEncoding.EncodeToString([]byte(cursor.ToCursorKey()))
+}
+
+// FetchBlobStreamHandler godoc
+//
+//	@Summary	Stream blobs as they become certified (Server-Sent Events)
+//	@Tags		Blob
+//	@Produce	text/event-stream
+//	@Success	200	{object}	BlobInfo	"event: blob"
+//	@Router		/blob/stream [get]
+func (s *ServerV2) FetchBlobStreamHandler(c *gin.Context) {
+	ch := s.blobStreamHandler.subscribe()
+	defer s.blobStreamHandler.unsubscribe(ch)
+
+	// The stream is long-lived, so lift the server-wide write timeout for this connection
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		s.logger.Warn("failed to clear write deadline for blob stream", "err", err)
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set(cacheControlParam, "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(blobStreamKeepAliveInterval)
+	defer keepAlive.Stop()
+	shuttingDown := s.shuttingDown()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-shuttingDown:
+			return false
+		case blob := <-ch:
+			c.SSEvent("blob", blob)
+			return true
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		}
+	})
+}
+
+// FetchBlobHandler godoc
+//
+//	@Summary	Fetch blob metadata by blob key
+//	@Tags		Blob
+//	@Produce	json
+//	@Param		blob_key	path		string	true	"Blob key in hex string"
+//	@Success	200			{object}	BlobResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/blobs/{blob_key} [get]
+func (s *ServerV2) FetchBlobHandler(c *gin.Context) {
+	blobKey, err := corev2.HexToBlobKey(c.Param("blob_key"))
+	if err != nil {
+		invalidParamsErrorResponse(c, fmt.Errorf("invalid blob key: %w", err))
+		return
+	}
+	response, err := s.getBlob(c.Request.Context(), blobKey)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setCacheMaxAge(c, s.cachePolicy.BlobMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+func (s *ServerV2) getBlob(ctx context.Context, blobKey corev2.BlobKey) (*BlobResponse, error) {
+	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		return nil, err
+	}
+	return &BlobResponse{
+		BlobHeader:    metadata.BlobHeader,
+		Status:        metadata.BlobStatus.String(),
+		DispersedAt:   metadata.RequestedAt,
+		BlobSizeBytes: metadata.BlobSize,
+	}, nil
+}
+
+// LookupBlobsHandler godoc
+//
+//	@Summary	Fetch the metadata of multiple blobs by blob key
+//	@Tags		Blob
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		BlobLookupRequest	true	"Blob keys in hex string, at most 100"
+//	@Success	200		{object}	BlobLookupResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/blobs/lookup [post]
+func (s *ServerV2) LookupBlobsHandler(c *gin.Context) {
+	var request BlobLookupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalidParamsErrorResponse(c, fmt.Errorf("failed to parse request: %w", err))
+		return
+	}
+	if len(request.BlobKeys) == 0 || len(request.BlobKeys) > maxBlobLookupKeys {
+		invalidParamsErrorResponse(c, fmt.Errorf("the number of blob keys must be between 1 and %d", maxBlobLookupKeys))
+		return
+	}
+
+	response, err := s.lookupBlobs(c.Request.Context(), request.BlobKeys)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// lookupBlobs fetches the metadata of the blobs at once. Invalid and unknown keys are reported in their result.
+func (s *ServerV2) lookupBlobs(ctx context.Context, hexKeys []string) (*BlobLookupResponse, error) {
+	response := &BlobLookupResponse{
+		Results: make([]*BlobLookupResult, len(hexKeys)),
+	}
+	// Keys are fetched once even if requested multiple times, as the store rejects duplicate keys
+	parsedKeys := make([]corev2.BlobKey, len(hexKeys))
+	blobKeys := make([]corev2.BlobKey, 0, len(hexKeys))
+	requested := make(map[corev2.BlobKey]bool, len(hexKeys))
+	for i, hexKey := range hexKeys {
+		response.Results[i] = &BlobLookupResult{BlobKey: hexKey}
+		blobKey, err := corev2.HexToBlobKey(hexKey)
+		if err != nil {
+			response.Results[i].Error = fmt.Sprintf("invalid blob key: %v", err)
+			continue
+		}
+		parsedKeys[i] = blobKey
+		if !requested[blobKey] {
+			requested[blobKey] = true
+			blobKeys = append(blobKeys, blobKey)
+		}
+	}
+	if len(blobKeys) == 0 {
+		return response, nil
+	}
+
+	metadata, err := s.blobMetadataStore.GetBlobMetadataByKeys(ctx, blobKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob metadata: %w", err)
+	}
+	blobs := make(map[corev2.BlobKey]*BlobResponse, len(metadata))
+	for _, m := range metadata {
+		blobKey, err := m.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob key: %w", err)
+		}
+		blobs[blobKey] = &BlobResponse{
+			BlobHeader:    m.BlobHeader,
+			Status:        m.BlobStatus.String(),
+			DispersedAt:   m.RequestedAt,
+			BlobSizeBytes: m.BlobSize,
+		}
+	}
+	for i, result := range response.Results {
+		if result.Error != "" {
+			continue
+		}
+		blob, ok := blobs[parsedKeys[i]]
+		if !ok {
+			result.Error = errNotFound.Error()
+			continue
+		}
+		result.Blob = blob
+	}
+	return response, nil
+}
+
+// FetchBlobCertificateHandler godoc
+//
+//	@Summary	Fetch blob certificate by blob key
+//	@Tags		Blob
+//	@Produce	json
+//	@Param		blob_key	path		string	true	"Blob key in hex string"
+//	@Success	200			{object}	BlobCertificateResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/blobs/{blob_key}/certificate [get]
+func (s *ServerV2) FetchBlobCertificateHandler(c *gin.Context) {
+	blobKey, err := corev2.HexToBlobKey(c.Param("blob_key"))
+	if err != nil {
+		invalidParamsErrorResponse(c, fmt.Errorf("invalid blob key: %w", err))
+		return
+	}
+	cert, _, err := s.blobMetadataStore.GetBlobCertificate(c.Request.Context(), blobKey)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	response := &BlobCertificateResponse{
+		Certificate: cert,
+		Relays:      s.getBlobRelays(c.Request.Context(), cert.RelayKeys),
+	}
+	setCacheMaxAge(c, s.cachePolicy.BlobMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// getBlobRelays resolves the relay keys to the relay URLs in the relay registry. The certificate is still useful
+// without the URLs, so they are left empty if the registry can't be read.
+func (s *ServerV2) getBlobRelays(ctx context.Context, relayKeys []corev2.RelayKey) []*BlobRelay {
+	relays := make([]*BlobRelay, len(relayKeys))
+	for i, key := range relayKeys {
+		relays[i] = &BlobRelay{RelayKey: key}
+	}
+	if len(relayKeys) == 0 {
+		return relays
+	}
+	urls, err := cachedQuery(ctx, s.queryCache, "relayURLs", func(ctx context.Context) (map[uint32]string, error) {
+		return s.chainReader.GetRelayURLs(ctx)
+	})
+	if err != nil {
+		s.logger.Warn("failed to fetch relay URLs", "err", err)
+		return relays
+	}
+	for _, relay := range relays {
+		relay.Url = urls[relay.RelayKey]
+	}
+	return relays
+}
+
+// FetchBlobVerificationInfoHandler godoc
+//
+//	@Summary	Fetch blob verification info by blob key and batch header hash
+//	@Tags		Blob
+//	@Produce	json
+//	@Param		blob_key			path		string	true	"Blob key in hex string"
+//	@Param		batch_header_hash	path		string	true	"Batch header hash in hex string"
+//
+//	@Success	200					{object}	BlobVerificationInfoResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/blobs/{blob_key}/verification-info [get]
+func (s *ServerV2) FetchBlobVerificationInfoHandler(c *gin.Context) {
+	blobKey, err := corev2.HexToBlobKey(c.Param("blob_key"))
+	if err != nil {
+		invalidParamsErrorResponse(c, fmt.Errorf("invalid blob key: %w", err))
+		return
+	}
+	batchHeaderHashHex := c.Query("batch_header_hash")
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(batchHeaderHashHex))
+	if err != nil {
+		invalidParamsErrorResponse(c, errors.New("invalid batch header hash"))
+		return
+	}
+	bvi, err := s.blobMetadataStore.GetBlobVerificationInfo(c.Request.Context(), blobKey, batchHeaderHash)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	response := &BlobVerificationInfoResponse{
+		VerificationInfo: bvi,
+	}
+	setCacheMaxAge(c, s.cachePolicy.BlobMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// FetchBlobInclusionHandler godoc
+//
+//	@Summary	Fetch the merkle inclusion proof of a blob against its batch root
+//	@Tags		Blob
+//	@Produce	json
+//	@Param		blob_key	path		string	true	"Blob key in hex string"
+//	@Success	200			{object}	BlobInclusionResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/blobs/{blob_key}/inclusion [get]
+func (s *ServerV2) FetchBlobInclusionHandler(c *gin.Context) {
+	blobKey, err := corev2.HexToBlobKey(c.Param("blob_key"))
+	if err != nil {
+		invalidParamsErrorResponse(c, fmt.Errorf("invalid blob key: %w", err))
+		return
+	}
+	response, err := s.getBlobInclusion(c.Request.Context(), blobKey)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setCacheMaxAge(c, s.cachePolicy.BlobMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// getBlobInclusion returns the inclusion proof of the blob in the first batch including it that has been attested
+func (s *ServerV2) getBlobInclusion(ctx context.Context, blobKey corev2.BlobKey) (*BlobInclusionResponse, error) {
+	cert, _, err := s.blobMetadataStore.GetBlobCertificate(ctx, blobKey)
+	if err != nil {
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			return nil, fmt.Errorf("%w: no certificate for blob %s", errNotFound, blobKey.Hex())
+		}
+		return nil, fmt.Errorf("failed to get blob certificate: %w", err)
+	}
+	certHash, err := cert.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute blob certificate hash: %w", err)
+	}
+
+	verificationInfos, err := s.blobMetadataStore.GetBlobVerificationInfos(ctx, blobKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob verification infos: %w", err)
+	}
+	for _, info := range verificationInfos {
+		batchHeaderHash, err := info.BatchHeader.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute batch header hash: %w", err)
+		}
+		// Only a batch with an attestation proves availability
+		_, err = s.blobMetadataStore.GetAttestation(ctx, batchHeaderHash)
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get attestation: %w", err)
+		}
+
+		return &BlobInclusionResponse{
+			BlobKey:             blobKey.Hex(),
+			BlobCertificateHash: hex.EncodeToString(certHash[:]),
+			BatchHeaderHash:     hex.EncodeToString(batchHeaderHash[:]),
+			BatchHeader:         info.BatchHeader,
+			BlobIndex:           info.BlobIndex,
+			InclusionProof:      hex.EncodeToString(info.InclusionProof),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: blob %s is not included in any attested batch", errNotFound, blobKey.Hex())
+}
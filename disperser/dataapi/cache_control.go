@@ -0,0 +1,46 @@
+package dataapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// withDefaults returns the policy with zero max ages replaced by the defaults
+func (p CachePolicy) withDefaults() CachePolicy {
+	if p.BlobMaxAge <= 0 {
+		p.BlobMaxAge = maxFeedBlobAge * time.Second
+	}
+	if p.BatchMaxAge <= 0 {
+		p.BatchMaxAge = maxFeedBlobAge * time.Second
+	}
+	if p.StakeMaxAge <= 0 {
+		p.StakeMaxAge = maxOperatorsStakeAge * time.Second
+	}
+	if p.ReachabilityMaxAge <= 0 {
+		p.ReachabilityMaxAge = maxOperatorPortCheckAge * time.Second
+	}
+	return p
+}
+
+// setCacheMaxAge sets how long the response may be cached for. A request sent with Cache-Control: no-store, e.g.
+// while debugging, gets a response which must not be stored either.
+func setCacheMaxAge(c *gin.Context, maxAge time.Duration) {
+	if requestsNoStore(c) {
+		c.Writer.Header().Set(cacheControlParam, "no-store")
+		return
+	}
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", int64(maxAge.Seconds())))
+}
+
+// requestsNoStore reports whether the Cache-Control header of the request has the no-store directive
+func requestsNoStore(c *gin.Context) bool {
+	for _, directive := range strings.Split(c.GetHeader(cacheControlParam), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package dataapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+)
+
+var (
+	gzipWriterPool = sync.Pool{
+		New: func() interface{} {
+			return gzip.NewWriter(io.Discard)
+		},
+	}
+	zstdWriterPool = sync.Pool{
+		New: func() interface{} {
+			w, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1))
+			return w
+		},
+	}
+)
+
+// Compress returns a middleware which compresses responses of at least minSize bytes with zstd or gzip, whichever
+// the client accepts (preferring zstd). Routes in excludedRoutes, given as registered full paths, are never
+// compressed; this is needed for streaming routes, which must reach the client as they are written.
+func (s *ServerV2) Compress(minSize int, excludedRoutes ...string) gin.HandlerFunc {
+	excluded := make(map[string]struct{}, len(excludedRoutes))
+	for _, route := range excludedRoutes {
+		excluded[route] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		if _, ok := excluded[c.FullPath()]; ok {
+			c.Next()
+			return
+		}
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       encoding,
+			minSize:        minSize,
+		}
+		c.Writer = w
+		defer func() {
+			if err := w.close(); err != nil {
+				s.logger.Warn("failed to compress response", "route", c.FullPath(), "encoding", encoding, "err", err)
+			}
+			c.Writer = w.ResponseWriter
+		}()
+		c.Next()
+	}
+}
+
+// negotiateEncoding returns the compression to use for the given Accept-Encoding header, or "" if the client
+// doesn't accept any that is supported
+func negotiateEncoding(acceptEncoding string) string {
+	var gzipOk, zstdOk bool
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case encodingZstd:
+			zstdOk = true
+		case encodingGzip:
+			gzipOk = true
+		}
+	}
+	switch {
+	case zstdOk:
+		return encodingZstd
+	case gzipOk:
+		return encodingGzip
+	default:
+		return ""
+	}
+}
+
+// compressWriter buffers the response until it reaches minSize bytes, at which point it starts compressing. Smaller
+// responses are written as is when the handler returns.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+
+	buf bytes.Buffer
+	// decided is set once the response is known to be compressed or not
+	decided bool
+	// enc is the compressor, set if the response is compressed
+	enc io.WriteCloser
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+	w.buf.Write(data)
+	if w.buf.Len() >= w.minSize {
+		if err := w.decide(w.compressible()); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush writes out what is buffered; a handler that flushes is streaming, so the response is not compressed
+// unless compression had already started.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		if f, ok := w.enc.(interface{ Flush() error }); ok {
+			_ = f.Flush()
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+// compressible reports whether the response written so far may be compressed
+func (w *compressWriter) compressible() bool {
+	header := w.ResponseWriter.Header()
+	return header.Get("Content-Encoding") == "" && w.ResponseWriter.Status() != http.StatusNoContent
+}
+
+// decide starts writing the response to the client, compressed or not, beginning with what is buffered
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+	if compress {
+		header := w.ResponseWriter.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Add("Vary", "Accept-Encoding")
+		header.Del("Content-Length")
+		switch w.encoding {
+		case encodingZstd:
+			enc := zstdWriterPool.Get().(*zstd.Encoder)
+			enc.Reset(w.ResponseWriter)
+			w.enc = enc
+		default:
+			enc := gzipWriterPool.Get().(*gzip.Writer)
+			enc.Reset(w.ResponseWriter)
+			w.enc = enc
+		}
+		_, err := w.enc.Write(w.buf.Bytes())
+		w.buf.Reset()
+		return err
+	}
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// close writes out the rest of the response
+func (w *compressWriter) close() error {
+	if !w.decided {
+		return w.decide(false)
+	}
+	if w.enc == nil {
+		return nil
+	}
+	err := w.enc.Close()
+	switch enc := w.enc.(type) {
+	case *zstd.Encoder:
+		enc.Reset(io.Discard)
+		zstdWriterPool.Put(enc)
+	case *gzip.Writer:
+		enc.Reset(io.Discard)
+		gzipWriterPool.Put(enc)
+	}
+	w.enc = nil
+	return err
+}
@@ -1,5 +1,11 @@
 package dataapi
 
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+)
+
 type Config struct {
 	SocketAddr         string
 	ServerMode         string
@@ -7,4 +13,55 @@ type Config struct {
 	DisperserHostname  string
 	ChurnerHostname    string
 	BatcherHealthEndpt string
+	// RelayUseSecureGrpc enables TLS when probing relays
+	RelayUseSecureGrpc bool
+
+	// RateLimiterConfig holds the bucket sizes of the v2 rate limiter
+	RateLimiterConfig ratelimit.Config
+	// IPRequestRate is the max number of requests per second from a client IP; 0 means unlimited
+	IPRequestRate uint32
+	// APIKeyRequestRate is the max number of requests per second made with an API key; 0 means unlimited
+	APIKeyRequestRate uint32
+
+	// CompressionMinSize is the min size in bytes of v2 responses that are compressed; 0 disables compression
+	CompressionMinSize int
+
+	// ShutdownTimeout is how long the v2 server waits for in-flight requests to complete on shutdown
+	ShutdownTimeout time.Duration
+	// MaintenanceRetryAfter is the Retry-After of the requests rejected in maintenance mode
+	MaintenanceRetryAfter time.Duration
+
+	// CachePolicy sets how long clients may cache v2 responses
+	CachePolicy CachePolicy
+
+	// QueryCacheTTL is how long the results of subgraph and operator queries are cached by the v2 server;
+	// 0 disables caching
+	QueryCacheTTL time.Duration
+	// QueryCacheSize is the max number of cached query results
+	QueryCacheSize int
+
+	// SigningRateOwner makes the server store the signing tallies of the completed hours, which all servers load.
+	// It should be set on a single server.
+	SigningRateOwner bool
+
+	// AlertWebhookURLs are the URLs operator alerts are posted to; none disables alerting
+	AlertWebhookURLs []string
+	// AlertWebhookSecret is the key of the HMAC-SHA256 signature of the alert payloads; empty disables signing
+	AlertWebhookSecret string
+	// AlertSigningRateThreshold is the signing percentage below which an operator alert fires
+	AlertSigningRateThreshold float64
+	// AlertCheckInterval is how often operators are checked for alerts
+	AlertCheckInterval time.Duration
+}
+
+// CachePolicy sets how long clients may cache v2 responses, by group of routes. A zero max age uses the default.
+type CachePolicy struct {
+	// BlobMaxAge applies to the blob, certificate, verification info and inclusion routes
+	BlobMaxAge time.Duration
+	// BatchMaxAge applies to the batch and signing info routes
+	BatchMaxAge time.Duration
+	// StakeMaxAge applies to the operator stake and stake history routes
+	StakeMaxAge time.Duration
+	// ReachabilityMaxAge applies to the operator and relay reachability checks
+	ReachabilityMaxAge time.Duration
 }
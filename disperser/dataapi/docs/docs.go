@@ -15,29 +15,47 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/batches/{batch_header_hash}": {
+        "/accounts/{account_id}/blobs": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Batch"
+                    "Account"
                 ],
-                "summary": "Fetch batch by the batch header hash",
+                "summary": "Fetch blobs dispersed by an account, newest first",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Batch header hash in hex string",
-                        "name": "batch_header_hash",
+                        "description": "Account ID of the disperser client",
+                        "name": "account_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated blob statuses to filter by, e.g. queued,certified [default: all statuses]",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor (opaque string from previous response)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of blobs to return [default: 20; max: 1000]",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.BatchResponse"
+                            "$ref": "#/definitions/dataapi.BlobFeedResponse"
                         }
                     },
                     "400": {
@@ -61,29 +79,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/blobs/{blob_key}": {
+        "/batch/subscribe": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Blob"
+                    "Batch"
                 ],
-                "summary": "Fetch blob metadata by blob key",
+                "summary": "Subscribe to newly signed batches (WebSocket)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Blob key in hex string",
-                        "name": "blob_key",
-                        "in": "path",
-                        "required": true
+                        "description": "Comma-separated quorum IDs; only batches containing any of them are sent [default: all quorums]",
+                        "name": "quorums",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "101": {
+                        "description": "Switching Protocols",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.BlobResponse"
+                            "$ref": "#/definitions/dataapi.BatchSubscriptionMessage"
                         }
                     },
                     "400": {
@@ -91,36 +108,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dataapi.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "error: Not found",
-                        "schema": {
-                            "$ref": "#/definitions/dataapi.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "error: Server error",
-                        "schema": {
-                            "$ref": "#/definitions/dataapi.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/blobs/{blob_key}/certificate": {
+        "/batches/by-reference-block/{block_number}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Blob"
+                    "Batch"
                 ],
-                "summary": "Fetch blob certificate by blob key",
+                "summary": "Fetch the batches anchored at a reference block, with their attestation and certification status",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Blob key in hex string",
-                        "name": "blob_key",
+                        "type": "integer",
+                        "description": "Reference block number",
+                        "name": "block_number",
                         "in": "path",
                         "required": true
                     }
@@ -129,7 +134,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.BlobCertificateResponse"
+                            "$ref": "#/definitions/dataapi.ReferenceBlockBatchesResponse"
                         }
                     },
                     "400": {
@@ -153,27 +158,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/blobs/{blob_key}/verification-info": {
+        "/batches/by-tx-hash/{tx_hash}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Blob"
+                    "Batch"
                 ],
-                "summary": "Fetch blob verification info by blob key and batch header hash",
+                "summary": "Fetch the batches referenced by an Ethereum transaction, with their attestation and certification status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Blob key in hex string",
-                        "name": "blob_key",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Batch header hash in hex string",
-                        "name": "batch_header_hash",
+                        "description": "Transaction hash in hex string",
+                        "name": "tx_hash",
                         "in": "path",
                         "required": true
                     }
@@ -182,7 +180,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.BlobVerificationInfoResponse"
+                            "$ref": "#/definitions/dataapi.TxBatchesResponse"
                         }
                     },
                     "400": {
@@ -206,33 +204,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/feed/batches/{batch_header_hash}/blobs": {
+        "/batches/feed": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Feed"
+                    "Batch"
                 ],
-                "summary": "Fetch blob metadata by batch header hash",
+                "summary": "Fetch batch feed",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Batch Header Hash",
-                        "name": "batch_header_hash",
-                        "in": "path",
-                        "required": true
+                        "description": "Fetch batches attested after this time (exclusive) in UTC (2006-01-02T15:04:05Z) [default: before-1h; max: 7 days before before]",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fetch batches attested before this time (exclusive) in UTC (2006-01-02T15:04:05Z) [default: now]",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor (opaque string from previous response); takes precedence over after",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Limit [default: 10]",
+                        "description": "Maximum number of batches to return [default: 20; max: 1000]",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Next page token",
-                        "name": "next_token",
+                        "description": "Response format: json, or csv or ndjson to stream all batches in the range, ignoring limit [default: json]",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
@@ -240,7 +251,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.BlobsResponse"
+                            "$ref": "#/definitions/dataapi.BatchFeedResponse"
                         }
                     },
                     "400": {
@@ -264,28 +275,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/feed/blobs": {
+        "/batches/{batch_header_hash}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Feed"
+                    "Batch"
                 ],
-                "summary": "Fetch blobs metadata list",
+                "summary": "Fetch batch by the batch header hash",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Limit [default: 10]",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Batch header hash in hex string",
+                        "name": "batch_header_hash",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.BlobsResponse"
+                            "$ref": "#/definitions/dataapi.BatchResponse"
                         }
                     },
                     "400": {
@@ -309,29 +321,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/feed/blobs/{blob_key}": {
+        "/batches/{batch_header_hash}/blobs": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Feed"
+                    "Batch"
                 ],
-                "summary": "Fetch blob metadata by blob key",
+                "summary": "Fetch the blobs included in a batch, in the order of their index in the batch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Blob Key",
-                        "name": "blob_key",
+                        "description": "Batch header hash in hex string",
+                        "name": "batch_header_hash",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor (opaque string from previous response)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of blobs to return [default: 20; max: 1000]",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.BlobMetadataResponse"
+                            "$ref": "#/definitions/dataapi.BatchBlobsResponse"
                         }
                     },
                     "400": {
@@ -355,40 +379,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics": {
+        "/batches/{batch_header_hash}/signers": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Metrics"
+                    "Batch"
                 ],
-                "summary": "Fetch metrics",
+                "summary": "Fetch the operators which signed a batch in each quorum, with their stake at the reference block",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Start unix timestamp [default: 1 hour ago]",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "End unix timestamp [default: unix time now]",
-                        "name": "end",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit [default: 10]",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Batch header hash in hex string",
+                        "name": "batch_header_hash",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.Metric"
+                            "$ref": "#/definitions/dataapi.BatchSignersResponse"
                         }
                     },
                     "400": {
@@ -412,20 +425,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/batcher-service-availability": {
+        "/batches/{batch_header_hash}/signing-info": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Batcher Availability"
+                    "Batch"
+                ],
+                "summary": "Fetch per-quorum signing info of a batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Batch header hash in hex string",
+                        "name": "batch_header_hash",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get status of EigenDA batcher.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.ServiceAvailabilityResponse"
+                            "$ref": "#/definitions/dataapi.BatchSigningInfoResponse"
                         }
                     },
                     "400": {
@@ -449,20 +471,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/churner-service-availability": {
+        "/blob/stream": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Churner ServiceAvailability"
+                    "Blob"
+                ],
+                "summary": "Stream blobs as they become certified (Server-Sent Events)",
+                "responses": {
+                    "200": {
+                        "description": "event: blob",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.BlobInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/blobs/feed": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Blob"
+                ],
+                "summary": "Fetch blob feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fetch blobs after this time (exclusive) in UTC (2006-01-02T15:04:05Z) [default: before-1h; max: 7 days before before]",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fetch blobs before this time (exclusive) in UTC (2006-01-02T15:04:05Z) [default: now]",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor (opaque string from previous response); takes precedence over after",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of blobs to return [default: 20; max: 1000]",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json, or csv or ndjson to stream all blobs in the range, ignoring limit [default: json]",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get status of EigenDA churner service.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.ServiceAvailabilityResponse"
+                            "$ref": "#/definitions/dataapi.BlobFeedResponse"
                         }
                     },
                     "400": {
@@ -486,20 +561,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/disperser-service-availability": {
-            "get": {
+        "/blobs/lookup": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceAvailability"
+                    "Blob"
+                ],
+                "summary": "Fetch the metadata of multiple blobs by blob key",
+                "parameters": [
+                    {
+                        "description": "Blob keys in hex string, at most 100",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.BlobLookupRequest"
+                        }
+                    }
                 ],
-                "summary": "Get status of EigenDA Disperser service.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.ServiceAvailabilityResponse"
+                            "$ref": "#/definitions/dataapi.BlobLookupResponse"
                         }
                     },
                     "400": {
@@ -508,12 +597,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dataapi.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "error: Not found",
-                        "schema": {
-                            "$ref": "#/definitions/dataapi.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "error: Server error",
                         "schema": {
@@ -523,31 +606,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/non-signers": {
+        "/blobs/{blob_key}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Metrics"
+                    "Blob"
                 ],
-                "summary": "Fetch non signers",
+                "summary": "Fetch blob metadata by blob key",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Interval to query for non signers in seconds [default: 3600]",
-                        "name": "interval",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Blob key in hex string",
+                        "name": "blob_key",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dataapi.NonSigner"
-                            }
+                            "$ref": "#/definitions/dataapi.BlobResponse"
                         }
                     },
                     "400": {
@@ -571,40 +652,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/operator-nonsigning-percentage": {
+        "/blobs/{blob_key}/certificate": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Metrics"
+                    "Blob"
                 ],
-                "summary": "Fetch operators non signing percentage",
+                "summary": "Fetch blob certificate by blob key",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Interval to query for operators nonsigning percentage [default: 3600]",
-                        "name": "interval",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End time (2006-01-02T15:04:05Z) to query for operators nonsigning percentage [default: now]",
-                        "name": "end",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Whether return only live nonsigners [default: true]",
-                        "name": "live_only",
-                        "in": "query"
+                        "description": "Blob key in hex string",
+                        "name": "blob_key",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.OperatorsNonsigningPercentage"
+                            "$ref": "#/definitions/dataapi.BlobCertificateResponse"
                         }
                     },
                     "400": {
@@ -628,34 +698,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/summary": {
+        "/blobs/{blob_key}/inclusion": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Metrics"
+                    "Blob"
                 ],
-                "summary": "Fetch metrics summary",
+                "summary": "Fetch the merkle inclusion proof of a blob against its batch root",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Start unix timestamp [default: 1 hour ago]",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "End unix timestamp [default: unix time now]",
-                        "name": "end",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Blob key in hex string",
+                        "name": "blob_key",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.Metric"
+                            "$ref": "#/definitions/dataapi.BlobInclusionResponse"
                         }
                     },
                     "400": {
@@ -679,37 +744,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/throughput": {
+        "/blobs/{blob_key}/verification-info": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Metrics"
+                    "Blob"
                 ],
-                "summary": "Fetch throughput time series",
+                "summary": "Fetch blob verification info by blob key and batch header hash",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Start unix timestamp [default: 1 hour ago]",
-                        "name": "start",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Blob key in hex string",
+                        "name": "blob_key",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "End unix timestamp [default: unix time now]",
-                        "name": "end",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Batch header hash in hex string",
+                        "name": "batch_header_hash",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dataapi.Throughput"
-                            }
+                            "$ref": "#/definitions/dataapi.BlobVerificationInfoResponse"
                         }
                     },
                     "400": {
@@ -733,26 +797,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/timeseries/throughput": {
+        "/feed/batches/{batch_header_hash}/blobs": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Metrics"
+                    "Feed"
                 ],
-                "summary": "Fetch throughput time series",
+                "summary": "Fetch blob metadata by batch header hash",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Batch Header Hash",
+                        "name": "batch_header_hash",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Start unix timestamp [default: 1 hour ago]",
-                        "name": "start",
+                        "description": "Limit [default: 10]",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "End unix timestamp [default: unix time now]",
-                        "name": "end",
+                        "type": "string",
+                        "description": "Next page token",
+                        "name": "next_token",
                         "in": "query"
                     }
                 ],
@@ -760,10 +831,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dataapi.Throughput"
-                            }
+                            "$ref": "#/definitions/dataapi.BlobsResponse"
                         }
                     },
                     "400": {
@@ -787,20 +855,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/operators-info/deregistered-operators": {
+        "/feed/blobs": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperatorsInfo"
+                    "Feed"
+                ],
+                "summary": "Fetch blobs metadata list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit [default: 10]",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Fetch list of operators that have been deregistered for days. Days is a query parameter with a default value of 14 and max value of 30.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.QueriedStateOperatorsResponse"
+                            "$ref": "#/definitions/dataapi.BlobsResponse"
                         }
                     },
                     "400": {
@@ -824,46 +900,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/operators-info/operator-ejections": {
+        "/feed/blobs/{blob_key}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperatorsInfo"
+                    "Feed"
                 ],
-                "summary": "Fetch list of operator ejections over last N days.",
+                "summary": "Fetch blob metadata by blob key",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lookback in days [default: 1]",
-                        "name": "days",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Operator ID filter [default: all operators]",
-                        "name": "operator_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Return first N ejections [default: 1000]",
-                        "name": "first",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Skip first N ejections [default: 0]",
-                        "name": "skip",
-                        "in": "query"
+                        "description": "Blob Key",
+                        "name": "blob_key",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.QueriedOperatorEjectionsResponse"
+                            "$ref": "#/definitions/dataapi.BlobMetadataResponse"
                         }
                     },
                     "400": {
@@ -887,29 +946,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/operators-info/operators-stake": {
-            "get": {
+        "/graphql": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperatorsStake"
+                    "GraphQL"
                 ],
-                "summary": "Operator stake distribution query",
+                "summary": "Query blobs, batches, attestations and operators with GraphQL",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Operator ID",
-                        "name": "operator_id",
-                        "in": "query",
-                        "required": true
+                        "description": "GraphQL query; see schema.graphql for the schema",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.GraphQLRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.OperatorsStakeResponse"
+                            "$ref": "#/definitions/dataapi.GraphQLResponse"
                         }
                     },
                     "400": {
@@ -917,45 +981,44 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dataapi.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "error: Not found",
-                        "schema": {
-                            "$ref": "#/definitions/dataapi.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "error: Server error",
-                        "schema": {
-                            "$ref": "#/definitions/dataapi.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/operators-info/port-check": {
+        "/metrics": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperatorsInfo"
+                    "Metrics"
                 ],
-                "summary": "Operator node reachability port check",
+                "summary": "Fetch metrics",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Operator ID",
-                        "name": "operator_id",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Start unix timestamp [default: 1 hour ago]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit [default: 10]",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.OperatorPortCheckResponse"
+                            "$ref": "#/definitions/dataapi.Metric"
                         }
                     },
                     "400": {
@@ -979,20 +1042,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/operators-info/registered-operators": {
+        "/metrics/attestation-latency": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperatorsInfo"
+                    "Metrics"
+                ],
+                "summary": "Fetch the distribution of latencies from blob dispersal to batch attestation, by quorum",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp of the attestations [default: 1 hour before end]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp of the attestations [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Fetch list of operators that have been registered for days. Days is a query parameter with a default value of 14 and max value of 30.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.QueriedStateOperatorsResponse"
+                            "$ref": "#/definitions/dataapi.AttestationLatencyResponse"
                         }
                     },
                     "400": {
@@ -1016,45 +1093,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/operators-info/semver-scan": {
+        "/metrics/batcher-service-availability": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperatorsInfo"
+                    "Batcher Availability"
                 ],
-                "summary": "Active operator semver scan",
+                "summary": "Get status of EigenDA batcher.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.SemverReportResponse"
+                            "$ref": "#/definitions/dataapi.ServiceAvailabilityResponse"
                         }
                     },
-                    "500": {
-                        "description": "error: Server error",
+                    "400": {
+                        "description": "error: Bad request",
                         "schema": {
                             "$ref": "#/definitions/dataapi.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/operators/nodeinfo": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "OperatorsNodeInfo"
-                ],
-                "summary": "Active operator semver",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "error: Not found",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.SemverReportResponse"
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1066,20 +1130,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/operators/reachability": {
+        "/metrics/blob-sizes": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperatorsReachability"
+                    "Metrics"
                 ],
-                "summary": "Operator node reachability check",
+                "summary": "Fetch the size distribution of blobs dispersed in a time window",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Operator ID in hex string [default: all operators if unspecified]",
-                        "name": "operator_id",
+                        "type": "integer",
+                        "description": "Start unix timestamp [default: 1 hour before end]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp [default: unix time now]",
+                        "name": "end",
                         "in": "query"
                     }
                 ],
@@ -1087,7 +1157,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.OperatorPortCheckResponse"
+                            "$ref": "#/definitions/dataapi.BlobSizeHistogram"
                         }
                     },
                     "400": {
@@ -1111,20 +1181,94 @@ const docTemplate = `{
                 }
             }
         },
-        "/operators/stake": {
+        "/metrics/churner-service-availability": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OperatorsStake"
+                    "Churner ServiceAvailability"
                 ],
-                "summary": "Operator stake distribution query",
+                "summary": "Get status of EigenDA churner service.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ServiceAvailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/disperser-service-availability": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ServiceAvailability"
+                ],
+                "summary": "Get status of EigenDA Disperser service.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ServiceAvailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/non-signers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch non signers",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Operator ID in hex string [default: all operators if unspecified]",
-                        "name": "operator_id",
+                        "type": "integer",
+                        "description": "Interval to query for non signers in seconds [default: 3600]",
+                        "name": "interval",
                         "in": "query"
                     }
                 ],
@@ -1132,7 +1276,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dataapi.OperatorsStakeResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dataapi.NonSigner"
+                            }
                         }
                     },
                     "400": {
@@ -1155,197 +1302,2560 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "big.Int": {
-            "type": "object"
         },
-        "core.G1Point": {
-            "type": "object",
-            "properties": {
-                "x": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+        "/metrics/operator-nonsigning-percentage": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch operators non signing percentage",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Interval to query for operators nonsigning percentage [default: 3600]",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time (2006-01-02T15:04:05Z) to query for operators nonsigning percentage [default: now]",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Whether return only live nonsigners [default: true]",
+                        "name": "live_only",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorsNonsigningPercentage"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "core.G2Point": {
-            "type": "object",
-            "properties": {
-                "x": {
-                    "$ref": "#/definitions/github_com_consensys_gnark-crypto_ecc_bn254_internal_fptower.E2"
+        "/metrics/overview": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch network metrics overview",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.MetricsOverview"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "core.PaymentMetadata": {
-            "type": "object",
-            "properties": {
-                "account_id": {
-                    "description": "AccountID is the ETH account address for the payer",
-                    "type": "string"
-                },
-                "cumulative_payment": {
-                    "description": "TODO: we are thinking the contract can use uint128 for cumulative payment,\nbut the definition on v2 uses uint64. Double check with team.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/big.Int"
+        "/metrics/rollups": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch the daily or weekly rollups of the throughput, blob counts and on-demand payments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Length of the rolled up periods, daily or weekly [default: daily]",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in UTC (2006-01-02); weeks starting on or after the Monday of that week are returned [default: 29 days before end]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in UTC (2006-01-02) [default: today]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ThroughputRollupsResponse"
                         }
-                    ]
-                },
-                "reservation_period": {
-                    "description": "ReservationPeriod represents the range of time at which the dispersal is made",
-                    "type": "integer"
-                },
-                "salt": {
-                    "description": "Allow same blob to be dispersed multiple times within the same reservation period",
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/stale-blobs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch the number of blobs stuck in the encoded status that the controller requeued or failed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp [default: 1 hour ago]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.StaleBlobCounts"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/summary": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch the headline metrics of the network: throughput, volume dispersed, operators, stake and latest batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp of the avg throughput [default: 1 hour ago]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp of the avg throughput [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.MetricSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/throughput": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch throughput time series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp [default: 1 hour ago]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dataapi.Throughput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/timeseries/throughput": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch throughput time series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp [default: 1 hour ago]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Width of each time bucket: 1m, 10m or 1h [default: 1m]",
+                        "name": "resolution",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dataapi.Throughput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators-info/deregistered-operators": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsInfo"
+                ],
+                "summary": "Fetch list of operators that have been deregistered for days. Days is a query parameter with a default value of 14 and max value of 30.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.QueriedStateOperatorsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators-info/operator-ejections": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsInfo"
+                ],
+                "summary": "Fetch list of operator ejections over last N days.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lookback in days [default: 1]",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operator ID filter [default: all operators]",
+                        "name": "operator_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return first N ejections [default: 1000]",
+                        "name": "first",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skip first N ejections [default: 0]",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.QueriedOperatorEjectionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators-info/operators-stake": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsStake"
+                ],
+                "summary": "Operator stake distribution query",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID",
+                        "name": "operator_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorsStakeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators-info/port-check": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsInfo"
+                ],
+                "summary": "Operator node reachability port check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID",
+                        "name": "operator_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorPortCheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators-info/registered-operators": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsInfo"
+                ],
+                "summary": "Fetch list of operators that have been registered for days. Days is a query parameter with a default value of 14 and max value of 30.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.QueriedStateOperatorsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators-info/semver-scan": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsInfo"
+                ],
+                "summary": "Active operator semver scan",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.SemverReportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/events": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Fetch operator registration, deregistration and churn events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fetch events at or after this time in UTC (2006-01-02T15:04:05Z) [default: before-1h; max: 7 days before before]",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fetch events at or before this time in UTC (2006-01-02T15:04:05Z) [default: now]",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor (opaque string from previous response)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events to return [default: 20; max: 1000]",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/nodeinfo": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsNodeInfo"
+                ],
+                "summary": "Active operator semver",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.SemverReportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/nodeinfo/history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsNodeInfo"
+                ],
+                "summary": "Daily snapshots of the node versions run by the operators",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day in UTC (2006-01-02) [default: 29 days before end]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in UTC (2006-01-02) [default: today]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorsNodeInfoHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/nonsigners": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Fetch operators that failed to sign batches in the lookback window",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lookback window in seconds [default: 3600; max: 2592000]",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorsNonSigningResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/reachability": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsReachability"
+                ],
+                "summary": "Operator node reachability check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID in hex string [default: all operators if unspecified]",
+                        "name": "operator_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorPortCheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/stake": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "OperatorsStake"
+                ],
+                "summary": "Operator stake distribution query",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID in hex string [default: all operators if unspecified]",
+                        "name": "operator_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json, csv or ndjson, with one operator stake per quorum per row [default: json]",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorsStakeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/stake/history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsStake"
+                ],
+                "summary": "Daily snapshots of the operator stake distribution in each quorum",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day in UTC (2006-01-02) [default: 29 days before end]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in UTC (2006-01-02) [default: today]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorsStakeHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/stake/leaderboard": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OperatorsStake"
+                ],
+                "summary": "Operators of a quorum ranked by stake, with their change in rank since the previous day",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quorum ID [default: 0]",
+                        "name": "quorum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination cursor (opaque string from previous response)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of operators to return [default: 20; max: 1000]",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorsStakeLeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/{operator_id}/signing-rate": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Fetch signing rates of an operator over rolling windows (1d, 7d and 30d)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID in hex string",
+                        "name": "operator_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorSigningRateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/cost": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Estimate the cost of dispersing a blob, on demand or with a reservation, from the on-chain payment params",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Size of the blob in bytes [max: 16MiB]",
+                        "name": "blob_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated quorum IDs to disperse to [default: the required quorums]",
+                        "name": "quorums",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.DispersalCostEstimate"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/relays/reachability": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Relays"
+                ],
+                "summary": "Relay reachability check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.RelaysReachabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search blobs, batches, operators, accounts and L1 transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blob key, batch header hash, operator ID, L1 transaction hash, or operator or account address, in hex string",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "big.Int": {
+            "type": "object"
+        },
+        "core.BlobHeader": {
+            "type": "object",
+            "properties": {
+                "accountID": {
+                    "description": "AccountID is the account that is paying for the blob to be stored",
+                    "type": "string"
+                },
+                "commitment": {
+                    "$ref": "#/definitions/encoding.G1Commitment"
+                },
+                "length": {
+                    "type": "integer"
+                },
+                "length_commitment": {
+                    "$ref": "#/definitions/encoding.G2Commitment"
+                },
+                "length_proof": {
+                    "$ref": "#/definitions/encoding.LengthProof"
+                },
+                "quorumInfos": {
+                    "description": "QuorumInfos contains the quorum specific parameters for the blob",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/core.BlobQuorumInfo"
+                    }
+                }
+            }
+        },
+        "core.BlobQuorumInfo": {
+            "type": "object",
+            "properties": {
+                "adversaryThreshold": {
+                    "description": "AdversaryThreshold is the maximum amount of stake that can be controlled by an adversary in the quorum as a percentage of the total stake in the quorum",
+                    "type": "integer"
+                },
+                "chunkLength": {
+                    "description": "ChunkLength is the number of symbols in a chunk",
+                    "type": "integer"
+                },
+                "confirmationThreshold": {
+                    "description": "ConfirmationThreshold is the amount of stake that must sign a message for it to be considered valid as a percentage of the total stake in the quorum",
+                    "type": "integer"
+                },
+                "quorumID": {
+                    "type": "integer"
+                },
+                "quorumRate": {
+                    "description": "Rate Limit. This is a temporary measure until the node can derive rates on its own using rollup authentication. This is used\nfor restricting the rate at which retrievers are able to download data from the DA node to a multiple of the rate at which the\ndata was posted to the DA node.",
+                    "type": "integer"
+                }
+            }
+        },
+        "core.G1Point": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "core.G2Point": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "$ref": "#/definitions/github_com_consensys_gnark-crypto_ecc_bn254_internal_fptower.E2"
+                }
+            }
+        },
+        "core.PaymentMetadata": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "AccountID is the ETH account address for the payer",
+                    "type": "string"
+                },
+                "cumulative_payment": {
+                    "description": "TODO: we are thinking the contract can use uint128 for cumulative payment,\nbut the definition on v2 uses uint64. Double check with team.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/big.Int"
+                        }
+                    ]
+                },
+                "reservation_period": {
+                    "description": "ReservationPeriod represents the range of time at which the dispersal is made",
+                    "type": "integer"
+                },
+                "salt": {
+                    "description": "Allow same blob to be dispersed multiple times within the same reservation period",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the time the request was created in nanoseconds since the Unix epoch, zero if not set.\nIt lets the disperser reject replayed requests.",
+                    "type": "integer"
+                }
+            }
+        },
+        "core.SecurityParam": {
+            "type": "object",
+            "properties": {
+                "adversaryThreshold": {
+                    "description": "AdversaryThreshold is the maximum amount of stake that can be controlled by an adversary in the quorum as a percentage of the total stake in the quorum",
+                    "type": "integer"
+                },
+                "confirmationThreshold": {
+                    "description": "ConfirmationThreshold is the amount of stake that must sign a message for it to be considered valid as a percentage of the total stake in the quorum",
+                    "type": "integer"
+                },
+                "quorumID": {
+                    "type": "integer"
+                },
+                "quorumRate": {
+                    "description": "Rate Limit. This is a temporary measure until the node can derive rates on its own using rollup authentication. This is used\nfor restricting the rate at which retrievers are able to download data from the DA node to a multiple of the rate at which the\ndata was posted to the DA node.",
+                    "type": "integer"
+                }
+            }
+        },
+        "core.Signature": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dataapi.AccountSearchResult": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "blobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BlobInfo"
+                    }
+                }
+            }
+        },
+        "dataapi.AttestationLatencyResponse": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "quorums": {
+                    "description": "Quorums maps each quorum ID to the latency distribution of the blobs dispersed to it",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dataapi.LatencyDistribution"
+                    }
+                },
+                "start": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.BatchBlob": {
+            "type": "object",
+            "properties": {
+                "blob_header": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobHeader"
+                },
+                "blob_index": {
+                    "type": "integer"
+                },
+                "blob_key": {
+                    "type": "string"
+                },
+                "blob_size_bytes": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BatchBlobsResponse": {
+            "type": "object",
+            "properties": {
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "blobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchBlob"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dataapi.Pagination"
+                },
+                "pagination_token": {
+                    "description": "PaginationToken is the same as Pagination.NextToken, kept for existing clients",
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BatchCertification": {
+            "type": "object",
+            "properties": {
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "blob_keys": {
+                    "description": "BlobKeys are the keys of the blobs in the batch, in the order of their index in the batch",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "certified": {
+                    "type": "boolean"
+                },
+                "quorums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchQuorumCertification"
+                    }
+                },
+                "signed_batch": {
+                    "$ref": "#/definitions/dataapi.SignedBatch"
+                }
+            }
+        },
+        "dataapi.BatchFeedResponse": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dataapi.Pagination"
+                },
+                "pagination_token": {
+                    "description": "PaginationToken is the same as Pagination.NextToken, kept for existing clients",
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BatchInfo": {
+            "type": "object",
+            "properties": {
+                "aggregated_signature": {
+                    "$ref": "#/definitions/core.Signature"
+                },
+                "attested_at": {
+                    "type": "integer"
+                },
+                "batch_header": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BatchHeader"
+                },
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "quorum_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "quorum_signed_percentages": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dataapi.BatchQuorumCertification": {
+            "type": "object",
+            "properties": {
+                "confirmation_threshold": {
+                    "type": "integer"
+                },
+                "meets_threshold": {
+                    "type": "boolean"
+                },
+                "quorum_id": {
+                    "type": "integer"
+                },
+                "signed_percentage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.BatchQuorumSigners": {
+            "type": "object",
+            "properties": {
+                "quorum_id": {
+                    "type": "integer"
+                },
+                "signed_stake": {
+                    "$ref": "#/definitions/big.Int"
+                },
+                "signers": {
+                    "description": "Signers are the operators of the quorum which signed the batch, by descending stake",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchSigner"
+                    }
+                },
+                "total_stake": {
+                    "$ref": "#/definitions/big.Int"
+                }
+            }
+        },
+        "dataapi.BatchQuorumSigningInfo": {
+            "type": "object",
+            "properties": {
+                "confirmation_threshold": {
+                    "type": "integer"
+                },
+                "meets_threshold": {
+                    "type": "boolean"
+                },
+                "nonsigners": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quorum_id": {
+                    "type": "integer"
+                },
+                "signed_percentage": {
+                    "type": "integer"
+                },
+                "signed_stake": {
+                    "$ref": "#/definitions/big.Int"
+                },
+                "total_stake": {
+                    "$ref": "#/definitions/big.Int"
+                }
+            }
+        },
+        "dataapi.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "blob_verification_infos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobVerificationInfo"
+                    }
+                },
+                "signed_batch": {
+                    "$ref": "#/definitions/dataapi.SignedBatch"
+                }
+            }
+        },
+        "dataapi.BatchSigner": {
+            "type": "object",
+            "properties": {
+                "operator_id": {
+                    "type": "string"
+                },
+                "stake": {
+                    "$ref": "#/definitions/big.Int"
+                },
+                "stake_percentage": {
+                    "description": "StakePercentage is the percentage of the stake of the quorum held by the operator",
+                    "type": "number"
+                }
+            }
+        },
+        "dataapi.BatchSignersResponse": {
+            "type": "object",
+            "properties": {
+                "attested_at": {
+                    "type": "integer"
+                },
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "quorums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchQuorumSigners"
+                    }
+                },
+                "reference_block_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.BatchSigningInfoResponse": {
+            "type": "object",
+            "properties": {
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "nonsigners": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quorums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchQuorumSigningInfo"
+                    }
+                },
+                "reference_block_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.BatchSubscriptionMessage": {
+            "type": "object",
+            "properties": {
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "signed_batch": {
+                    "$ref": "#/definitions/dataapi.SignedBatch"
+                }
+            }
+        },
+        "dataapi.BlobCertificateResponse": {
+            "type": "object",
+            "properties": {
+                "blob_certificate": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobCertificate"
+                },
+                "relays": {
+                    "description": "Relays are the relays of the certificate which serve the blob, in the order of its relay keys",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BlobRelay"
+                    }
+                }
+            }
+        },
+        "dataapi.BlobFeedResponse": {
+            "type": "object",
+            "properties": {
+                "blobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BlobInfo"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dataapi.Pagination"
+                },
+                "pagination_token": {
+                    "description": "PaginationToken is the same as Pagination.NextToken, kept for existing clients",
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BlobInclusionResponse": {
+            "type": "object",
+            "properties": {
+                "batch_header": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BatchHeader"
+                },
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "blob_certificate_hash": {
+                    "type": "string"
+                },
+                "blob_index": {
+                    "type": "integer"
+                },
+                "blob_key": {
+                    "type": "string"
+                },
+                "inclusion_proof": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BlobInfo": {
+            "type": "object",
+            "properties": {
+                "blob_key": {
+                    "type": "string"
+                },
+                "blob_metadata": {
+                    "$ref": "#/definitions/v2.BlobMetadata"
+                }
+            }
+        },
+        "dataapi.BlobLookupRequest": {
+            "type": "object",
+            "properties": {
+                "blob_keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dataapi.BlobLookupResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "description": "Results are in the order of the requested blob keys",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BlobLookupResult"
+                    }
+                }
+            }
+        },
+        "dataapi.BlobLookupResult": {
+            "type": "object",
+            "properties": {
+                "blob": {
+                    "$ref": "#/definitions/dataapi.BlobResponse"
+                },
+                "blob_key": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BlobMetadataResponse": {
+            "type": "object",
+            "properties": {
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "batch_id": {
+                    "type": "integer"
+                },
+                "batch_root": {
+                    "type": "string"
+                },
+                "blob_commitment": {
+                    "$ref": "#/definitions/encoding.BlobCommitments"
+                },
+                "blob_inclusion_proof": {
+                    "type": "string"
+                },
+                "blob_index": {
+                    "type": "integer"
+                },
+                "blob_key": {
+                    "type": "string"
+                },
+                "blob_status": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_disperser.BlobStatus"
+                },
+                "confirmation_block_number": {
+                    "type": "integer"
+                },
+                "confirmation_txn_hash": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "string"
+                },
+                "reference_block_number": {
+                    "type": "integer"
+                },
+                "requested_at": {
+                    "type": "integer"
+                },
+                "security_params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/core.SecurityParam"
+                    }
+                },
+                "signatory_record_hash": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BlobRelay": {
+            "type": "object",
+            "properties": {
+                "relay_key": {
+                    "type": "integer"
+                },
+                "url": {
+                    "description": "Url is empty if the relay is not in the relay registry, or the registry could not be read",
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BlobResponse": {
+            "type": "object",
+            "properties": {
+                "blob_header": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobHeader"
+                },
+                "blob_size_bytes": {
+                    "type": "integer"
+                },
+                "dispersed_at": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.BlobSizeBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "upper_bound": {
+                    "description": "UpperBound is the inclusive upper bound of the bucket in bytes; the lower bound is\nthe upper bound of the previous bucket (exclusive), or 0 for the first bucket",
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.BlobSizeHistogram": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BlobSizeBucket"
+                    }
+                },
+                "end": {
+                    "type": "integer"
+                },
+                "num_blobs": {
+                    "type": "integer"
+                },
+                "p50": {
+                    "type": "integer"
+                },
+                "p95": {
+                    "type": "integer"
+                },
+                "p99": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "integer"
+                },
+                "total_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.BlobVerificationInfoResponse": {
+            "type": "object",
+            "properties": {
+                "blob_verification_info": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobVerificationInfo"
+                }
+            }
+        },
+        "dataapi.BlobsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BlobMetadataResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.Meta"
+                }
+            }
+        },
+        "dataapi.DispersalCostEstimate": {
+            "type": "object",
+            "properties": {
+                "blob_size": {
+                    "type": "integer"
+                },
+                "num_symbols": {
+                    "description": "NumSymbols is the length of the blob in symbols, padded to a power of 2 as on dispersal",
+                    "type": "integer"
+                },
+                "on_demand": {
+                    "$ref": "#/definitions/dataapi.OnDemandCostEstimate"
+                },
+                "quorum_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reservation": {
+                    "$ref": "#/definitions/dataapi.ReservationCostEstimate"
+                },
+                "symbols_charged": {
+                    "description": "SymbolsCharged is the number of symbols the blob is charged for, a multiple of the min number of symbols",
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "INVALID_ARGUMENT",
+                "UNAUTHENTICATED",
+                "PERMISSION_DENIED",
+                "NOT_FOUND",
+                "RATE_LIMITED",
+                "QUOTA_EXCEEDED",
+                "UNAVAILABLE",
+                "INTERNAL"
+            ],
+            "x-enum-varnames": [
+                "ErrCodeInvalidArgument",
+                "ErrCodeUnauthenticated",
+                "ErrCodePermissionDenied",
+                "ErrCodeNotFound",
+                "ErrCodeRateLimited",
+                "ErrCodeQuotaExceeded",
+                "ErrCodeUnavailable",
+                "ErrCodeInternal"
+            ]
+        },
+        "dataapi.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code identifies the type of error, which clients can branch on",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dataapi.ErrorCode"
+                        }
+                    ]
+                },
+                "details": {
+                    "description": "Details holds extra information depending on the code, e.g. when a rate limited request may be retried",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "description": "Error is the error message, kept for existing clients",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "retryable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dataapi.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "dataapi.GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.QueryError"
+                    }
+                }
+            }
+        },
+        "dataapi.LatencyDistribution": {
+            "type": "object",
+            "properties": {
+                "max_ms": {
+                    "type": "number"
+                },
+                "mean_ms": {
+                    "type": "number"
+                },
+                "min_ms": {
+                    "type": "number"
+                },
+                "num_blobs": {
+                    "type": "integer"
+                },
+                "p50_ms": {
+                    "type": "number"
+                },
+                "p90_ms": {
+                    "type": "number"
+                },
+                "p95_ms": {
+                    "type": "number"
+                },
+                "p99_ms": {
+                    "type": "number"
+                }
+            }
+        },
+        "dataapi.Meta": {
+            "type": "object",
+            "properties": {
+                "next_token": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.Metric": {
+            "type": "object",
+            "properties": {
+                "cost_in_gas": {
+                    "type": "number"
+                },
+                "throughput": {
+                    "type": "number"
+                },
+                "total_stake": {
+                    "description": "deprecated: use TotalStakePerQuorum instead. Remove when the frontend is updated.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/big.Int"
+                        }
+                    ]
+                },
+                "total_stake_per_quorum": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/big.Int"
+                    }
+                }
+            }
+        },
+        "dataapi.MetricSummary": {
+            "type": "object",
+            "properties": {
+                "avg_throughput": {
+                    "description": "AvgThroughput is the avg throughput in bytes/sec over the queried time range",
+                    "type": "number"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "dispersed_bytes_24h": {
+                    "description": "DispersedBytes24h is the size of the blobs confirmed in the last 24 hours",
+                    "type": "integer"
+                },
+                "latest_batch": {
+                    "description": "LatestBatch is the last batch attested in the past hour, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dataapi.BatchInfo"
+                        }
+                    ]
+                },
+                "num_operators_per_quorum": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total_dispersed_bytes": {
+                    "description": "TotalDispersedBytes is the size of the blobs certified in all the days rolled up so far",
+                    "type": "integer"
+                },
+                "total_operators": {
+                    "type": "integer"
+                },
+                "total_stake_per_quorum": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/big.Int"
+                    }
+                }
+            }
+        },
+        "dataapi.MetricsOverview": {
+            "type": "object",
+            "properties": {
+                "avg_throughput": {
+                    "type": "number"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "computed_at": {
+                    "type": "integer"
+                },
+                "dispersed_bytes": {
+                    "description": "DispersedBytes is the size of the blobs requested in the window",
+                    "type": "integer"
+                },
+                "num_blobs": {
+                    "description": "NumBlobs is the number of blobs requested in the window",
+                    "type": "integer"
+                },
+                "num_operators_per_quorum": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total_dispersed_bytes": {
+                    "description": "TotalDispersedBytes is the size of the blobs certified in all the days rolled up, plus the size of the\nblobs requested since",
+                    "type": "integer"
+                },
+                "total_operators": {
+                    "type": "integer"
+                },
+                "total_stake_per_quorum": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/big.Int"
+                    }
+                },
+                "window_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.NonSigner": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "operatorId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OnDemandCostEstimate": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "description": "Allowed is whether the blob can be paid for on demand, which is limited to the required quorums",
+                    "type": "boolean"
+                },
+                "payment": {
+                    "description": "Payment is the increase of the cumulative payment of the account in wei",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/big.Int"
+                        }
+                    ]
+                },
+                "price_per_symbol": {
+                    "description": "PricePerSymbol is the price in wei of a symbol",
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.OperatorEvent": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer"
+                },
+                "block_timestamp": {
+                    "type": "integer"
+                },
+                "churned_operator_ids": {
+                    "description": "ChurnedOperatorIds are the operators ejected by a churned registration",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "event_type": {
+                    "$ref": "#/definitions/dataapi.OperatorEventType"
+                },
+                "operator_address": {
+                    "type": "string"
+                },
+                "operator_id": {
+                    "type": "string"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorEventType": {
+            "type": "string",
+            "enum": [
+                "registered",
+                "deregistered",
+                "churned"
+            ],
+            "x-enum-varnames": [
+                "OperatorRegisteredEvent",
+                "OperatorDeregisteredEvent",
+                "OperatorChurnedEvent"
+            ]
+        },
+        "dataapi.OperatorEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.OperatorEvent"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dataapi.Pagination"
+                },
+                "pagination_token": {
+                    "description": "PaginationToken is the same as Pagination.NextToken, kept for existing clients",
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorNonSigningInfo": {
+            "type": "object",
+            "properties": {
+                "operator_id": {
+                    "type": "string"
+                },
+                "quorum_unsigned_batches": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total_unsigned_batches": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.OperatorNonsigningPercentageMetrics": {
+            "type": "object",
+            "properties": {
+                "operator_address": {
+                    "type": "string"
+                },
+                "operator_id": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "quorum_id": {
+                    "type": "integer"
+                },
+                "stake_percentage": {
+                    "type": "number"
+                },
+                "total_batches": {
+                    "type": "integer"
+                },
+                "total_unsigned_batches": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.OperatorPortCheckResponse": {
+            "type": "object",
+            "properties": {
+                "dispersal_online": {
+                    "type": "boolean"
+                },
+                "dispersal_socket": {
+                    "type": "string"
+                },
+                "operator_id": {
+                    "type": "string"
+                },
+                "ports": {
+                    "description": "Ports are the results of probing each port, keyed by \"dispersal\", \"v2_dispersal\" and \"retrieval\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dataapi.OperatorPortReachability"
+                    }
+                },
+                "retrieval_online": {
+                    "type": "boolean"
+                },
+                "retrieval_socket": {
+                    "type": "string"
+                },
+                "v2_dispersal_online": {
+                    "type": "boolean"
+                },
+                "v2_dispersal_socket": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorPortReachability": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "online": {
+                    "type": "boolean"
+                },
+                "service_available": {
+                    "description": "ServiceAvailable is whether the port serves the gRPC service expected on it",
+                    "type": "boolean"
+                },
+                "services": {
+                    "description": "Services are the gRPC services the port lists through server reflection, if it's online",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "socket": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorSearchResult": {
+            "type": "object",
+            "properties": {
+                "operator_address": {
+                    "type": "string"
+                },
+                "operator_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorSigningRate": {
+            "type": "object",
+            "properties": {
+                "signing_percentage": {
+                    "type": "number"
+                },
+                "total_batches": {
+                    "type": "integer"
+                },
+                "unsigned_batches": {
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorSigningRateResponse": {
+            "type": "object",
+            "properties": {
+                "operator_id": {
+                    "type": "string"
+                },
+                "signing_rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.OperatorSigningRate"
+                    }
+                }
+            }
+        },
+        "dataapi.OperatorStake": {
+            "type": "object",
+            "properties": {
+                "operator_id": {
+                    "type": "string"
+                },
+                "quorum_id": {
+                    "type": "string"
+                },
+                "rank": {
                     "type": "integer"
+                },
+                "stake_percentage": {
+                    "type": "number"
                 }
             }
         },
-        "core.SecurityParam": {
+        "dataapi.OperatorsNodeInfoHistoryResponse": {
             "type": "object",
             "properties": {
-                "adversaryThreshold": {
-                    "description": "AdversaryThreshold is the maximum amount of stake that can be controlled by an adversary in the quorum as a percentage of the total stake in the quorum",
-                    "type": "integer"
-                },
-                "confirmationThreshold": {
-                    "description": "ConfirmationThreshold is the amount of stake that must sign a message for it to be considered valid as a percentage of the total stake in the quorum",
-                    "type": "integer"
-                },
-                "quorumID": {
-                    "type": "integer"
-                },
-                "quorumRate": {
-                    "description": "Rate Limit. This is a temporary measure until the node can derive rates on its own using rollup authentication. This is used\nfor restricting the rate at which retrievers are able to download data from the DA node to a multiple of the rate at which the\ndata was posted to the DA node.",
-                    "type": "integer"
+                "snapshots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.SemverSnapshot"
+                    }
                 }
             }
         },
-        "core.Signature": {
+        "dataapi.OperatorsNonSigningResponse": {
             "type": "object",
             "properties": {
-                "x": {
+                "nonsigners": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/dataapi.OperatorNonSigningInfo"
                     }
+                },
+                "total_batches": {
+                    "type": "integer"
                 }
             }
         },
-        "dataapi.BatchResponse": {
+        "dataapi.OperatorsNonsigningPercentage": {
             "type": "object",
             "properties": {
-                "batch_header_hash": {
-                    "type": "string"
-                },
-                "blob_verification_infos": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobVerificationInfo"
+                        "$ref": "#/definitions/dataapi.OperatorNonsigningPercentageMetrics"
                     }
                 },
-                "signed_batch": {
-                    "$ref": "#/definitions/dataapi.SignedBatch"
+                "meta": {
+                    "$ref": "#/definitions/dataapi.Meta"
                 }
             }
         },
-        "dataapi.BlobCertificateResponse": {
+        "dataapi.OperatorsStakeHistoryResponse": {
             "type": "object",
             "properties": {
-                "blob_certificate": {
-                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobCertificate"
+                "snapshots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.StakeSnapshot"
+                    }
                 }
             }
         },
-        "dataapi.BlobMetadataResponse": {
+        "dataapi.OperatorsStakeLeaderboardResponse": {
             "type": "object",
             "properties": {
-                "batch_header_hash": {
-                    "type": "string"
+                "operators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.StakeLeaderboardEntry"
+                    }
                 },
-                "batch_id": {
-                    "type": "integer"
+                "pagination": {
+                    "$ref": "#/definitions/dataapi.Pagination"
                 },
-                "batch_root": {
+                "pagination_token": {
+                    "description": "PaginationToken is the same as Pagination.NextToken, kept consistent with the other list endpoints",
                     "type": "string"
                 },
-                "blob_commitment": {
-                    "$ref": "#/definitions/encoding.BlobCommitments"
+                "previous_snapshot_timestamp": {
+                    "description": "PreviousSnapshotTimestamp is the timestamp of the snapshot the ranks are compared to, 0 if there is none",
+                    "type": "integer"
                 },
-                "blob_inclusion_proof": {
+                "quorum_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorsStakeResponse": {
+            "type": "object",
+            "properties": {
+                "stake_ranked_operators": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/dataapi.OperatorStake"
+                        }
+                    }
+                }
+            }
+        },
+        "dataapi.Pagination": {
+            "type": "object",
+            "properties": {
+                "cursor": {
                     "type": "string"
                 },
-                "blob_index": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
                     "type": "integer"
                 },
-                "blob_key": {
+                "next_token": {
                     "type": "string"
-                },
-                "blob_status": {
-                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_disperser.BlobStatus"
-                },
-                "confirmation_block_number": {
+                }
+            }
+        },
+        "dataapi.QueriedOperatorEjections": {
+            "type": "object",
+            "properties": {
+                "block_number": {
                     "type": "integer"
                 },
-                "confirmation_txn_hash": {
+                "block_timestamp": {
                     "type": "string"
                 },
-                "fee": {
+                "operator_address": {
                     "type": "string"
                 },
-                "reference_block_number": {
-                    "type": "integer"
+                "operator_id": {
+                    "type": "string"
                 },
-                "requested_at": {
+                "quorum": {
                     "type": "integer"
                 },
-                "security_params": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/core.SecurityParam"
-                    }
+                "stake_percentage": {
+                    "type": "number"
                 },
-                "signatory_record_hash": {
+                "transaction_hash": {
                     "type": "string"
                 }
             }
         },
-        "dataapi.BlobResponse": {
+        "dataapi.QueriedOperatorEjectionsResponse": {
             "type": "object",
             "properties": {
-                "blob_header": {
-                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobHeader"
-                },
-                "blob_size_bytes": {
-                    "type": "integer"
-                },
-                "dispersed_at": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
+                "ejections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.QueriedOperatorEjections"
+                    }
                 }
             }
         },
-        "dataapi.BlobVerificationInfoResponse": {
+        "dataapi.QueriedStateOperatorMetadata": {
             "type": "object",
             "properties": {
-                "blob_verification_info": {
-                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BlobVerificationInfo"
+                "block_number": {
+                    "type": "integer"
+                },
+                "is_online": {
+                    "type": "boolean"
+                },
+                "operator_id": {
+                    "type": "string"
+                },
+                "operator_process_error": {
+                    "type": "string"
+                },
+                "socket": {
+                    "type": "string"
                 }
             }
         },
-        "dataapi.BlobsResponse": {
+        "dataapi.QueriedStateOperatorsResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dataapi.BlobMetadataResponse"
+                        "$ref": "#/definitions/dataapi.QueriedStateOperatorMetadata"
                     }
                 },
                 "meta": {
@@ -1353,131 +3863,185 @@ const docTemplate = `{
                 }
             }
         },
-        "dataapi.ErrorResponse": {
+        "dataapi.QuorumStakeDistribution": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "operators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.OperatorStake"
+                    }
+                },
+                "total_stake": {
+                    "$ref": "#/definitions/big.Int"
                 }
             }
         },
-        "dataapi.Meta": {
+        "dataapi.ReferenceBlockBatchesResponse": {
             "type": "object",
             "properties": {
-                "next_token": {
-                    "type": "string"
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchCertification"
+                    }
                 },
-                "size": {
+                "reference_block_number": {
                     "type": "integer"
                 }
             }
         },
-        "dataapi.Metric": {
+        "dataapi.RelayReachability": {
             "type": "object",
             "properties": {
-                "cost_in_gas": {
+                "dial_latency_ms": {
                     "type": "number"
                 },
-                "throughput": {
-                    "type": "number"
+                "error": {
+                    "type": "string"
                 },
-                "total_stake": {
-                    "description": "deprecated: use TotalStakePerQuorum instead. Remove when the frontend is updated.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/big.Int"
-                        }
-                    ]
+                "health_status": {
+                    "description": "HealthStatus is the status reported by the relay's gRPC health service",
+                    "type": "string"
                 },
-                "total_stake_per_quorum": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/big.Int"
+                "reachable": {
+                    "description": "Reachable is whether a gRPC connection to the relay could be established",
+                    "type": "boolean"
+                },
+                "relay_key": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version is the version reported by the relay, empty if it doesn't report one",
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.RelaysReachabilityResponse": {
+            "type": "object",
+            "properties": {
+                "relays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.RelayReachability"
                     }
                 }
             }
         },
-        "dataapi.NonSigner": {
+        "dataapi.ReservationCostEstimate": {
             "type": "object",
             "properties": {
-                "count": {
+                "reservation_window": {
+                    "description": "ReservationWindow is the length in seconds of the periods over which reservation usage is metered",
                     "type": "integer"
                 },
-                "operatorId": {
-                    "type": "string"
+                "symbols_per_second": {
+                    "description": "SymbolsPerSecond is the min reserved rate needed to disperse one such blob per reservation window",
+                    "type": "integer"
                 }
             }
         },
-        "dataapi.OperatorNonsigningPercentageMetrics": {
+        "dataapi.SearchResponse": {
             "type": "object",
             "properties": {
-                "operator_address": {
+                "query": {
                     "type": "string"
                 },
-                "operator_id": {
-                    "type": "string"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.SearchResult"
+                    }
+                }
+            }
+        },
+        "dataapi.SearchResult": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/dataapi.AccountSearchResult"
                 },
-                "percentage": {
-                    "type": "number"
+                "batch": {
+                    "$ref": "#/definitions/dataapi.BatchResponse"
                 },
-                "quorum_id": {
-                    "type": "integer"
+                "blob": {
+                    "$ref": "#/definitions/dataapi.BlobResponse"
                 },
-                "stake_percentage": {
-                    "type": "number"
+                "operator": {
+                    "$ref": "#/definitions/dataapi.OperatorSearchResult"
                 },
-                "total_batches": {
+                "transaction": {
+                    "$ref": "#/definitions/dataapi.TransactionSearchResult"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.SemverAdoption": {
+            "type": "object",
+            "properties": {
+                "operators": {
                     "type": "integer"
                 },
-                "total_unsigned_batches": {
+                "stake_percentage": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "dataapi.SemverReportResponse": {
+            "type": "object",
+            "properties": {
+                "semver": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/semver.SemverMetrics"
+                    }
+                },
+                "stale_at": {
+                    "description": "StaleAt is the Unix time in seconds at which the report is due to be replaced by a new scan, set if\noperators are scanned in the background",
                     "type": "integer"
                 }
             }
         },
-        "dataapi.OperatorPortCheckResponse": {
+        "dataapi.SemverSnapshot": {
             "type": "object",
             "properties": {
-                "dispersal_online": {
-                    "type": "boolean"
-                },
-                "dispersal_socket": {
-                    "type": "string"
-                },
-                "operator_id": {
-                    "type": "string"
-                },
-                "retrieval_online": {
-                    "type": "boolean"
+                "semvers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dataapi.SemverAdoption"
+                    }
                 },
-                "retrieval_socket": {
-                    "type": "string"
+                "timestamp": {
+                    "type": "integer"
                 }
             }
         },
-        "dataapi.OperatorStake": {
+        "dataapi.ServiceAvailability": {
             "type": "object",
             "properties": {
-                "operator_id": {
+                "service_name": {
                     "type": "string"
                 },
-                "quorum_id": {
+                "service_status": {
                     "type": "string"
-                },
-                "rank": {
-                    "type": "integer"
-                },
-                "stake_percentage": {
-                    "type": "number"
                 }
             }
         },
-        "dataapi.OperatorsNonsigningPercentage": {
+        "dataapi.ServiceAvailabilityResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dataapi.OperatorNonsigningPercentageMetrics"
+                        "$ref": "#/definitions/dataapi.ServiceAvailability"
                     }
                 },
                 "meta": {
@@ -1485,146 +4049,155 @@ const docTemplate = `{
                 }
             }
         },
-        "dataapi.OperatorsStakeResponse": {
+        "dataapi.SignedBatch": {
             "type": "object",
             "properties": {
-                "stake_ranked_operators": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "$ref": "#/definitions/dataapi.OperatorStake"
-                        }
-                    }
+                "attestation": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.Attestation"
+                },
+                "batch_header": {
+                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BatchHeader"
                 }
             }
         },
-        "dataapi.QueriedOperatorEjections": {
+        "dataapi.StakeLeaderboardEntry": {
             "type": "object",
             "properties": {
-                "block_number": {
-                    "type": "integer"
-                },
-                "block_timestamp": {
+                "operator_id": {
                     "type": "string"
                 },
-                "operator_address": {
-                    "type": "string"
+                "previous_rank": {
+                    "description": "PreviousRank is the rank of the operator in the snapshot of the previous day, 0 if it wasn't ranked then",
+                    "type": "integer"
                 },
-                "operator_id": {
-                    "type": "string"
+                "rank": {
+                    "type": "integer"
                 },
-                "quorum": {
+                "rank_change": {
+                    "description": "RankChange is the number of places the operator moved up since the previous day, negative if it moved\ndown, and 0 if it wasn't ranked then",
                     "type": "integer"
                 },
                 "stake_percentage": {
                     "type": "number"
-                },
-                "transaction_hash": {
-                    "type": "string"
                 }
             }
         },
-        "dataapi.QueriedOperatorEjectionsResponse": {
+        "dataapi.StakeSnapshot": {
             "type": "object",
             "properties": {
-                "ejections": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dataapi.QueriedOperatorEjections"
+                "block_number": {
+                    "type": "integer"
+                },
+                "quorums": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dataapi.QuorumStakeDistribution"
                     }
+                },
+                "timestamp": {
+                    "type": "integer"
                 }
             }
         },
-        "dataapi.QueriedStateOperatorMetadata": {
+        "dataapi.StaleBlobCounts": {
             "type": "object",
             "properties": {
-                "block_number": {
+                "end_time": {
                     "type": "integer"
                 },
-                "is_online": {
-                    "type": "boolean"
-                },
-                "operator_id": {
-                    "type": "string"
+                "failed": {
+                    "description": "Failed is the number of blobs stuck in the encoded status that were failed, because they expired or\nwere requeued too many times",
+                    "type": "integer"
                 },
-                "operator_process_error": {
-                    "type": "string"
+                "requeued": {
+                    "description": "Requeued is the number of blobs stuck in the encoded status that were requeued into a new batch",
+                    "type": "integer"
                 },
-                "socket": {
-                    "type": "string"
+                "start_time": {
+                    "type": "integer"
                 }
             }
         },
-        "dataapi.QueriedStateOperatorsResponse": {
+        "dataapi.Throughput": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dataapi.QueriedStateOperatorMetadata"
-                    }
+                "throughput": {
+                    "type": "number"
                 },
-                "meta": {
-                    "$ref": "#/definitions/dataapi.Meta"
-                }
-            }
-        },
-        "dataapi.SemverReportResponse": {
-            "type": "object",
-            "properties": {
-                "semver": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/semver.SemverMetrics"
-                    }
+                "timestamp": {
+                    "type": "integer"
                 }
             }
         },
-        "dataapi.ServiceAvailability": {
+        "dataapi.ThroughputRollup": {
             "type": "object",
             "properties": {
-                "service_name": {
-                    "type": "string"
+                "blob_count": {
+                    "type": "integer"
                 },
-                "service_status": {
+                "certified_blob_count": {
+                    "type": "integer"
+                },
+                "certified_bytes": {
+                    "type": "integer"
+                },
+                "on_demand_payment": {
+                    "description": "OnDemandPayment is the total payment in wei made for on-demand blobs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/big.Int"
+                        }
+                    ]
+                },
+                "period": {
                     "type": "string"
+                },
+                "throughput": {
+                    "description": "Throughput is the average rate of certified bytes over the period, in bytes/sec",
+                    "type": "number"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the Unix timestamp in seconds of the start of the period",
+                    "type": "integer"
                 }
             }
         },
-        "dataapi.ServiceAvailabilityResponse": {
+        "dataapi.ThroughputRollupsResponse": {
             "type": "object",
             "properties": {
-                "data": {
+                "rollups": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dataapi.ServiceAvailability"
+                        "$ref": "#/definitions/dataapi.ThroughputRollup"
                     }
-                },
-                "meta": {
-                    "$ref": "#/definitions/dataapi.Meta"
                 }
             }
         },
-        "dataapi.SignedBatch": {
+        "dataapi.TransactionSearchResult": {
             "type": "object",
             "properties": {
-                "attestation": {
-                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.Attestation"
+                "operator_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.OperatorEvent"
+                    }
                 },
-                "batch_header": {
-                    "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.BatchHeader"
+                "transaction_hash": {
+                    "type": "string"
                 }
             }
         },
-        "dataapi.Throughput": {
+        "dataapi.TxBatchesResponse": {
             "type": "object",
             "properties": {
-                "throughput": {
-                    "type": "number"
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchCertification"
+                    }
                 },
-                "timestamp": {
-                    "type": "integer"
+                "tx_hash": {
+                    "type": "string"
                 }
             }
         },
@@ -1672,6 +4245,39 @@ const docTemplate = `{
                 }
             }
         },
+        "errors.Location": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "errors.QueryError": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.Location"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
         "github_com_Layr-Labs_eigenda_core_v2.Attestation": {
             "type": "object",
             "properties": {
@@ -1791,6 +4397,21 @@ const docTemplate = `{
                         "type": "integer"
                     }
                 },
+                "retentionPeriod": {
+                    "description": "RetentionPeriod is how long the blob should be retained. Zero retains the blob for the maximum retention period.\nIt is not part of the blob key, but it is part of the request hash, so it is covered by the signature.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "securityThresholds": {
+                    "description": "SecurityThresholds are custom security thresholds for some of the quorums of the blob. Quorums without custom\nthresholds use the protocol defaults. They are not part of the blob key, but they are part of the request hash, so\nthey are covered by the signature.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Layr-Labs_eigenda_core_v2.QuorumSecurityThresholds"
+                    }
+                },
                 "signature": {
                     "description": "Signature is the signature of the blob header by the account ID",
                     "type": "array",
@@ -1831,6 +4452,22 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_Layr-Labs_eigenda_core_v2.QuorumSecurityThresholds": {
+            "type": "object",
+            "properties": {
+                "adversaryThreshold": {
+                    "description": "AdversaryThreshold is the maximum percentage of the quorum stake assumed to be controlled by an adversary",
+                    "type": "integer"
+                },
+                "confirmationThreshold": {
+                    "description": "ConfirmationThreshold is the percentage of the quorum stake that must sign for the blob to be certified",
+                    "type": "integer"
+                },
+                "quorumID": {
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_Layr-Labs_eigenda_disperser.BlobStatus": {
             "type": "integer",
             "enum": [
@@ -1850,6 +4487,23 @@ const docTemplate = `{
                 "Dispersing"
             ]
         },
+        "github_com_Layr-Labs_eigenda_disperser_common_v2.BlobStatus": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "Queued",
+                "Encoded",
+                "Certified",
+                "Failed",
+                "InsufficientSignatures"
+            ]
+        },
         "github_com_consensys_gnark-crypto_ecc_bn254_internal_fptower.E2": {
             "type": "object",
             "properties": {
@@ -1883,6 +4537,121 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "time.Duration": {
+            "type": "integer",
+            "enum": [
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000
+            ],
+            "x-enum-varnames": [
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour"
+            ]
+        },
+        "v2.BlobMetadata": {
+            "type": "object",
+            "properties": {
+                "blobHeader": {
+                    "$ref": "#/definitions/core.BlobHeader"
+                },
+                "blobSize": {
+                    "description": "BlobSize is the size of the blob in bytes",
+                    "type": "integer"
+                },
+                "blobStatus": {
+                    "description": "BlobStatus indicates the current status of the blob",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Layr-Labs_eigenda_disperser_common_v2.BlobStatus"
+                        }
+                    ]
+                },
+                "callbackURL": {
+                    "description": "CallbackURL is the URL notified of the status transitions of the blob, if set by the client",
+                    "type": "string"
+                },
+                "expiry": {
+                    "description": "Expiry is Unix timestamp of the blob expiry in seconds from epoch",
+                    "type": "integer"
+                },
+                "fragmentSizeBytes": {
+                    "description": "FragmentSizeBytes is the maximum fragment size used to store the chunk coefficients.",
+                    "type": "integer"
+                },
+                "numRetries": {
+                    "description": "NumRetries is the number of times the blob has been retried",
+                    "type": "integer"
+                },
+                "requestedAt": {
+                    "description": "RequestedAt is the Unix timestamp of when the blob was requested in seconds",
+                    "type": "integer"
+                },
+                "totalChunkSizeBytes": {
+                    "description": "TotalChunkSizeBytes is the total size of the file containing all chunk coefficients for the blob.",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the Unix timestamp of when the blob was last updated in _nanoseconds_",
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
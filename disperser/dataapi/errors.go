@@ -0,0 +1,103 @@
+package dataapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorCode identifies the type of an error reported to clients
+type ErrorCode string
+
+const (
+	ErrCodeInvalidArgument  ErrorCode = "INVALID_ARGUMENT"
+	ErrCodeUnauthenticated  ErrorCode = "UNAUTHENTICATED"
+	ErrCodePermissionDenied ErrorCode = "PERMISSION_DENIED"
+	ErrCodeNotFound         ErrorCode = "NOT_FOUND"
+	ErrCodeRateLimited      ErrorCode = "RATE_LIMITED"
+	ErrCodeQuotaExceeded    ErrorCode = "QUOTA_EXCEEDED"
+	ErrCodeUnavailable      ErrorCode = "UNAVAILABLE"
+	ErrCodeInternal         ErrorCode = "INTERNAL"
+)
+
+// retryAfterDetail is the key of the ErrorResponse detail holding the number of seconds after which a rate limited
+// or rejected request may be retried
+const retryAfterDetail = "retry_after_seconds"
+
+func errorResponse(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, errNotFound) || errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		status = http.StatusNotFound
+	}
+	_ = c.Error(err)
+	c.JSON(status, newErrorResponse(c, status, err))
+}
+
+func invalidParamsErrorResponse(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.JSON(http.StatusBadRequest, newErrorResponse(c, http.StatusBadRequest, err))
+}
+
+func abortWithError(c *gin.Context, status int, err error) {
+	_ = c.Error(err)
+	c.AbortWithStatusJSON(status, newErrorResponse(c, status, err))
+}
+
+// abortWithInvalidParams rejects the request, reporting the error of each invalid param in the details
+func abortWithInvalidParams(c *gin.Context, errs map[string]string) {
+	params := make([]string, 0, len(errs))
+	for param := range errs {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	err := fmt.Errorf("invalid params: %s", strings.Join(params, ", "))
+	_ = c.Error(err)
+	response := newErrorResponse(c, http.StatusBadRequest, err)
+	response.Details = errs
+	c.AbortWithStatusJSON(http.StatusBadRequest, response)
+}
+
+// newErrorResponse builds the payload of an error reported with the given status. Server errors are deemed
+// transient, and so retryable.
+func newErrorResponse(c *gin.Context, status int, err error) *ErrorResponse {
+	response := &ErrorResponse{
+		Error:   err.Error(),
+		Message: err.Error(),
+	}
+	switch status {
+	case http.StatusBadRequest:
+		response.Code = ErrCodeInvalidArgument
+	case http.StatusUnauthorized:
+		response.Code = ErrCodeUnauthenticated
+	case http.StatusForbidden:
+		response.Code = ErrCodePermissionDenied
+	case http.StatusNotFound:
+		response.Code = ErrCodeNotFound
+	case http.StatusTooManyRequests:
+		response.Code = ErrCodeRateLimited
+		if errors.Is(err, errQuotaExceeded) {
+			response.Code = ErrCodeQuotaExceeded
+		}
+		response.Retryable = true
+		setRetryAfterDetail(c, response)
+	case http.StatusServiceUnavailable:
+		response.Code = ErrCodeUnavailable
+		response.Retryable = true
+		setRetryAfterDetail(c, response)
+	default:
+		response.Code = ErrCodeInternal
+		response.Retryable = true
+	}
+	return response
+}
+
+func setRetryAfterDetail(c *gin.Context, response *ErrorResponse) {
+	if retryAfter := c.Writer.Header().Get("Retry-After"); retryAfter != "" {
+		response.Details = map[string]string{retryAfterDetail: retryAfter}
+	}
+}
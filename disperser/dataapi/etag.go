@@ -0,0 +1,86 @@
+package dataapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag returns a middleware which tags successful GET responses with an ETag computed from the response body and
+// answers requests whose If-None-Match matches it with 304 Not Modified, so that polling clients don't download
+// the same payload again. The Cache-Control header set by the handler is sent along either way.
+// The tag is weak since the same payload may be sent with different content encodings.
+func (s *ServerV2) ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &bufferWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.Status() != http.StatusOK {
+			s.writeBuffered(c, w.buf.Bytes())
+			return
+		}
+		hash := sha256.Sum256(w.buf.Bytes())
+		etag := `W/"` + hex.EncodeToString(hash[:16]) + `"`
+		c.Writer.Header().Set("ETag", etag)
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Length")
+			c.Writer.WriteHeader(http.StatusNotModified)
+			c.Writer.WriteHeaderNow()
+			return
+		}
+		s.writeBuffered(c, w.buf.Bytes())
+	}
+}
+
+func (s *ServerV2) writeBuffered(c *gin.Context, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	if _, err := c.Writer.Write(body); err != nil {
+		s.logger.Warn("failed to write response", "route", c.FullPath(), "err", err)
+	}
+}
+
+// etagMatches reports whether the If-None-Match header matches the etag, using the weak comparison
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferWriter holds back the response body until the handler returns
+type bufferWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *bufferWriter) Write(data []byte) (int, error) {
+	return w.buf.Write(data)
+}
+
+func (w *bufferWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+// Flush is a no-op, since the body is only sent once the ETag is known
+func (w *bufferWriter) Flush() {}
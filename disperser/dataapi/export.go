@@ -0,0 +1,268 @@
+package dataapi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	formatJSON   = "json"
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+var (
+	blobExportColumns = []string{
+		"blob_key", "account_id", "blob_version", "quorum_numbers", "blob_status", "blob_size",
+		"requested_at", "updated_at", "expiry", "num_retries",
+	}
+	batchExportColumns = []string{
+		"batch_header_hash", "reference_block_number", "batch_root", "attested_at", "quorum_numbers",
+		"quorum_signed_percentages",
+	}
+	operatorStakeExportColumns = []string{"quorum_id", "operator_id", "stake_percentage", "rank"}
+)
+
+// parseFormat parses the format query param, which selects between the JSON response and a CSV or NDJSON export
+func parseFormat(c *gin.Context) (string, error) {
+	format := strings.ToLower(c.DefaultQuery("format", formatJSON))
+	switch format {
+	case formatJSON, formatCSV, formatNDJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("format must be one of %s, %s or %s", formatJSON, formatCSV, formatNDJSON)
+	}
+}
+
+// exporter streams records to the client as CSV, one row per record after a header row, or as NDJSON, one JSON
+// object per line. The response status and headers are sent with the first record, so errors that occur before
+// can still be reported with an error response.
+type exporter struct {
+	c       *gin.Context
+	format  string
+	name    string
+	columns []string
+
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+}
+
+func newExporter(c *gin.Context, format string, name string, columns []string) *exporter {
+	return &exporter{
+		c:       c,
+		format:  format,
+		name:    name,
+		columns: columns,
+	}
+}
+
+func (e *exporter) start() error {
+	e.started = true
+	header := e.c.Writer.Header()
+	if e.format == formatCSV {
+		header.Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		header.Set("Content-Type", "application/x-ndjson")
+	}
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.name+"."+e.format))
+	e.c.Status(http.StatusOK)
+
+	if e.format == formatCSV {
+		e.csv = csv.NewWriter(e.c.Writer)
+		return e.csv.Write(e.columns)
+	}
+	e.json = json.NewEncoder(e.c.Writer)
+	return nil
+}
+
+// write exports a record, given as the value encoded in NDJSON and as the row written in CSV
+func (e *exporter) write(record interface{}, row []string) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if e.format == formatCSV {
+		return e.csv.Write(row)
+	}
+	return e.json.Encode(record)
+}
+
+// flush sends what has been written so far to the client
+func (e *exporter) flush() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	e.c.Writer.Flush()
+	return nil
+}
+
+func blobExportRow(blob BlobInfo) []string {
+	metadata := blob.BlobMetadata
+	return []string{
+		blob.BlobKey,
+		metadata.BlobHeader.PaymentMetadata.AccountID,
+		strconv.FormatUint(uint64(metadata.BlobHeader.BlobVersion), 10),
+		formatQuorumNumbers(metadata.BlobHeader.QuorumNumbers),
+		metadata.BlobStatus.String(),
+		strconv.FormatUint(metadata.BlobSize, 10),
+		strconv.FormatUint(metadata.RequestedAt, 10),
+		strconv.FormatUint(metadata.UpdatedAt, 10),
+		strconv.FormatUint(metadata.Expiry, 10),
+		strconv.FormatUint(uint64(metadata.NumRetries), 10),
+	}
+}
+
+func batchExportRow(batch *BatchInfo) []string {
+	signed := make([]string, 0, len(batch.QuorumNumbers))
+	for _, q := range batch.QuorumNumbers {
+		signed = append(signed, fmt.Sprintf("%d:%d", q, batch.QuorumSignedPercentages[q]))
+	}
+	return []string{
+		batch.BatchHeaderHash,
+		strconv.FormatUint(batch.BatchHeader.ReferenceBlockNumber, 10),
+		fmt.Sprintf("%x", batch.BatchHeader.BatchRoot),
+		strconv.FormatUint(batch.AttestedAt, 10),
+		formatQuorumNumbers(batch.QuorumNumbers),
+		strings.Join(signed, ";"),
+	}
+}
+
+func operatorStakeExportRow(stake *OperatorStake) []string {
+	return []string{
+		stake.QuorumId,
+		stake.OperatorId,
+		strconv.FormatFloat(stake.StakePercentage, 'f', -1, 64),
+		strconv.Itoa(stake.Rank),
+	}
+}
+
+// formatQuorumNumbers joins the quorum numbers with semicolons, so they fit in a CSV field
+func formatQuorumNumbers(quorums []core.QuorumID) string {
+	numbers := make([]string, len(quorums))
+	for i, q := range quorums {
+		numbers[i] = strconv.Itoa(int(q))
+	}
+	return strings.Join(numbers, ";")
+}
+
+// exportBlobFeed streams all blobs requested in the range, page by page
+func (s *ServerV2) exportBlobFeed(c *gin.Context, format string, startCursor, endCursor blobstore.BlobFeedCursor) {
+	exp := newExporter(c, format, "blobs", blobExportColumns)
+	for {
+		blobs, lastCursor, err := s.blobMetadataStore.GetBlobMetadataByRequestedAt(c.Request.Context(), startCursor, endCursor, maxBlobFeedLimit)
+		if err != nil {
+			s.abortExport(c, exp, "FetchBlobFeed", fmt.Errorf("failed to fetch feed from blob metadata store: %w", err))
+			return
+		}
+		blobInfo, err := newBlobInfos(blobs)
+		if err != nil {
+			s.abortExport(c, exp, "FetchBlobFeed", err)
+			return
+		}
+		for _, blob := range blobInfo {
+			if err := exp.write(blob, blobExportRow(blob)); err != nil {
+				s.abortExport(c, exp, "FetchBlobFeed", err)
+				return
+			}
+		}
+		if err := exp.flush(); err != nil {
+			s.abortExport(c, exp, "FetchBlobFeed", err)
+			return
+		}
+		if lastCursor == nil || len(blobs) < maxBlobFeedLimit {
+			break
+		}
+		startCursor = *lastCursor
+	}
+}
+
+// exportBatchFeed streams all batches attested in the range, page by page
+func (s *ServerV2) exportBatchFeed(c *gin.Context, format string, start blobstore.AttestationFeedCursor, endAttestedAt uint64) {
+	exp := newExporter(c, format, "batches", batchExportColumns)
+	for {
+		attestations, cursor, err := s.blobMetadataStore.GetAttestationFeed(c.Request.Context(), start, endAttestedAt, maxBlobFeedLimit)
+		if err != nil {
+			s.abortExport(c, exp, "FetchBatchFeed", fmt.Errorf("failed to fetch feed from blob metadata store: %w", err))
+			return
+		}
+		batches, err := newBatchInfos(attestations)
+		if err != nil {
+			s.abortExport(c, exp, "FetchBatchFeed", err)
+			return
+		}
+		for _, batch := range batches {
+			if err := exp.write(batch, batchExportRow(batch)); err != nil {
+				s.abortExport(c, exp, "FetchBatchFeed", err)
+				return
+			}
+		}
+		if err := exp.flush(); err != nil {
+			s.abortExport(c, exp, "FetchBatchFeed", err)
+			return
+		}
+		if len(attestations) < maxBlobFeedLimit {
+			break
+		}
+		start = *cursor
+	}
+}
+
+// exportOperatorsStake streams the operator stakes, by quorum and then by rank
+func (s *ServerV2) exportOperatorsStake(c *gin.Context, format string, response *OperatorsStakeResponse) {
+	exp := newExporter(c, format, "operators-stake", operatorStakeExportColumns)
+	quorums := make([]string, 0, len(response.StakeRankedOperators))
+	for quorum := range response.StakeRankedOperators {
+		quorums = append(quorums, quorum)
+	}
+	// Numbered quorums first, then the "total" entry
+	sort.Slice(quorums, func(i, j int) bool {
+		qi, erri := strconv.Atoi(quorums[i])
+		qj, errj := strconv.Atoi(quorums[j])
+		if erri != nil || errj != nil {
+			return erri == nil || (errj != nil && quorums[i] < quorums[j])
+		}
+		return qi < qj
+	})
+	for _, quorum := range quorums {
+		for _, stake := range response.StakeRankedOperators[quorum] {
+			if err := exp.write(stake, operatorStakeExportRow(stake)); err != nil {
+				s.abortExport(c, exp, "FetchOperatorsStake", err)
+				return
+			}
+		}
+	}
+	if err := exp.flush(); err != nil {
+		s.abortExport(c, exp, "FetchOperatorsStake", err)
+	}
+}
+
+// abortExport reports an error with an error response if nothing was exported yet; otherwise the status is already
+// sent, so the export is cut short
+func (s *ServerV2) abortExport(c *gin.Context, exp *exporter, method string, err error) {
+	if !exp.started {
+		errorResponse(c, err)
+		return
+	}
+	s.logger.Error("failed to export", "method", method, "err", err)
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,412 @@
+package dataapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var graphqlSchema string
+
+// graphqlMaxDepth bounds the nesting of queries, as every nested list may fan out into further lookups
+const graphqlMaxDepth = 8
+
+func newGraphQLSchema(s *ServerV2) *graphql.Schema {
+	return graphql.MustParseSchema(
+		graphqlSchema,
+		&graphqlResolver{s: s},
+		graphql.MaxDepth(graphqlMaxDepth),
+		graphql.MaxParallelism(maxWorkerPoolSize),
+	)
+}
+
+// graphqlUint64 implements the Uint64 scalar
+type graphqlUint64 uint64
+
+func (graphqlUint64) ImplementsGraphQLType(name string) bool {
+	return name == "Uint64"
+}
+
+func (u *graphqlUint64) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		if v < 0 {
+			return fmt.Errorf("negative Uint64 %d", v)
+		}
+		*u = graphqlUint64(v)
+	case float64:
+		if v < 0 || v != float64(uint64(v)) {
+			return fmt.Errorf("invalid Uint64 %v", v)
+		}
+		*u = graphqlUint64(v)
+	case string:
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Uint64 %q: %w", v, err)
+		}
+		*u = graphqlUint64(n)
+	default:
+		return fmt.Errorf("invalid Uint64 input of type %T", input)
+	}
+	return nil
+}
+
+// graphqlResolver resolves the root query fields
+type graphqlResolver struct {
+	s *ServerV2
+}
+
+func (r *graphqlResolver) Blob(ctx context.Context, args struct{ Key string }) (*blobResolver, error) {
+	blobKey, err := corev2.HexToBlobKey(args.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob key: %w", err)
+	}
+	metadata, err := r.s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	if errors.Is(err, common.ErrMetadataNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &blobResolver{s: r.s, key: blobKey, metadata: metadata}, nil
+}
+
+func (r *graphqlResolver) Batch(ctx context.Context, args struct{ Hash string }) (*batchResolver, error) {
+	hash, err := ConvertHexadecimalToBytes([]byte(args.Hash))
+	if err != nil {
+		return nil, errors.New("invalid batch header hash")
+	}
+	header, err := r.s.blobMetadataStore.GetBatchHeader(ctx, hash)
+	if errors.Is(err, common.ErrMetadataNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &batchResolver{s: r.s, hash: hash, header: header}, nil
+}
+
+func (r *graphqlResolver) Batches(ctx context.Context, args struct {
+	After  *string
+	Before *string
+	Limit  int32
+}) ([]*batchResolver, error) {
+	now := time.Now()
+	before := now
+	if args.Before != nil {
+		t, err := time.Parse("2006-01-02T15:04:05Z", *args.Before)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse before: %w", err)
+		}
+		if t.Before(now) {
+			before = t
+		}
+	}
+	after := before.Add(-time.Hour)
+	if args.After != nil {
+		t, err := time.Parse("2006-01-02T15:04:05Z", *args.After)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse after: %w", err)
+		}
+		after = t
+	}
+	if !after.Before(before) {
+		return nil, errors.New("after must be before before")
+	}
+	if args.Limit <= 0 || args.Limit > maxBlobFeedLimit {
+		return nil, fmt.Errorf("limit must be an integer between 1 and %d", maxBlobFeedLimit)
+	}
+
+	attestations, err := r.s.blobMetadataStore.GetAttestationByAttestedAt(ctx, uint64(after.UnixNano()), uint64(before.UnixNano()), int(args.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attestations: %w", err)
+	}
+	batches := make([]*batchResolver, len(attestations))
+	for i, at := range attestations {
+		hash, err := at.BatchHeader.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute batch header hash: %w", err)
+		}
+		batches[i] = &batchResolver{s: r.s, hash: hash, header: at.BatchHeader, attestation: at, attestationLoaded: true}
+	}
+	return batches, nil
+}
+
+func (r *graphqlResolver) OperatorStakes(ctx context.Context, args struct{ OperatorId *string }) ([]*operatorStakeResolver, error) {
+	var operatorId string
+	if args.OperatorId != nil {
+		operatorId = *args.OperatorId
+	}
+	response, err := r.s.operatorsStake(ctx, operatorId)
+	if err != nil {
+		return nil, err
+	}
+
+	quorums := make([]string, 0, len(response.StakeRankedOperators))
+	for q := range response.StakeRankedOperators {
+		quorums = append(quorums, q)
+	}
+	sort.Strings(quorums)
+	stakes := make([]*operatorStakeResolver, 0)
+	for _, q := range quorums {
+		for _, stake := range response.StakeRankedOperators[q] {
+			stakes = append(stakes, &operatorStakeResolver{stake: stake})
+		}
+	}
+	return stakes, nil
+}
+
+type blobResolver struct {
+	s        *ServerV2
+	key      corev2.BlobKey
+	metadata *commonv2.BlobMetadata
+}
+
+func (r *blobResolver) Key() string {
+	return r.key.Hex()
+}
+
+func (r *blobResolver) Status() string {
+	return r.metadata.BlobStatus.String()
+}
+
+func (r *blobResolver) DispersedAt() graphqlUint64 {
+	return graphqlUint64(r.metadata.RequestedAt)
+}
+
+func (r *blobResolver) SizeBytes() graphqlUint64 {
+	return graphqlUint64(r.metadata.BlobSize)
+}
+
+func (r *blobResolver) Header() *blobHeaderResolver {
+	return &blobHeaderResolver{header: r.metadata.BlobHeader}
+}
+
+func (r *blobResolver) Batches(ctx context.Context) ([]*batchResolver, error) {
+	verificationInfos, err := r.s.blobMetadataStore.GetBlobVerificationInfos(ctx, r.key)
+	if errors.Is(err, common.ErrMetadataNotFound) {
+		return []*batchResolver{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]*batchResolver, len(verificationInfos))
+	for i, info := range verificationInfos {
+		hash, err := info.BatchHeader.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute batch header hash: %w", err)
+		}
+		batches[i] = &batchResolver{s: r.s, hash: hash, header: info.BatchHeader}
+	}
+	return batches, nil
+}
+
+type blobHeaderResolver struct {
+	header *corev2.BlobHeader
+}
+
+func (r *blobHeaderResolver) Version() int32 {
+	return int32(r.header.BlobVersion)
+}
+
+func (r *blobHeaderResolver) QuorumNumbers() []int32 {
+	quorums := make([]int32, len(r.header.QuorumNumbers))
+	for i, q := range r.header.QuorumNumbers {
+		quorums[i] = int32(q)
+	}
+	return quorums
+}
+
+func (r *blobHeaderResolver) AccountId() string {
+	return r.header.PaymentMetadata.AccountID
+}
+
+func (r *blobHeaderResolver) ReservationPeriod() graphqlUint64 {
+	return graphqlUint64(r.header.PaymentMetadata.ReservationPeriod)
+}
+
+func (r *blobHeaderResolver) CumulativePayment() string {
+	if r.header.PaymentMetadata.CumulativePayment == nil {
+		return "0"
+	}
+	return r.header.PaymentMetadata.CumulativePayment.String()
+}
+
+type batchResolver struct {
+	s      *ServerV2
+	hash   [32]byte
+	header *corev2.BatchHeader
+
+	// attestation is fetched on demand unless the batch was resolved from its attestation
+	attestation       *corev2.Attestation
+	attestationLoaded bool
+}
+
+func (r *batchResolver) Hash() string {
+	return hex.EncodeToString(r.hash[:])
+}
+
+func (r *batchResolver) BatchRoot() string {
+	return hex.EncodeToString(r.header.BatchRoot[:])
+}
+
+func (r *batchResolver) ReferenceBlockNumber() graphqlUint64 {
+	return graphqlUint64(r.header.ReferenceBlockNumber)
+}
+
+func (r *batchResolver) Attestation(ctx context.Context) (*attestationResolver, error) {
+	if !r.attestationLoaded {
+		attestation, err := r.s.blobMetadataStore.GetAttestation(ctx, r.hash)
+		if err != nil && !errors.Is(err, common.ErrMetadataNotFound) {
+			return nil, err
+		}
+		r.attestation = attestation
+		r.attestationLoaded = true
+	}
+	if r.attestation == nil {
+		return nil, nil
+	}
+	return &attestationResolver{attestation: r.attestation}, nil
+}
+
+func (r *batchResolver) Blobs(ctx context.Context) ([]*blobResolver, error) {
+	verificationInfos, err := r.s.blobMetadataStore.GetBlobVerificationInfosByBatchHeaderHash(ctx, r.hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(verificationInfos) == 0 {
+		return []*blobResolver{}, nil
+	}
+	sort.Slice(verificationInfos, func(i, j int) bool {
+		return verificationInfos[i].BlobIndex < verificationInfos[j].BlobIndex
+	})
+	blobKeys := make([]corev2.BlobKey, len(verificationInfos))
+	for i, info := range verificationInfos {
+		blobKeys[i] = info.BlobKey
+	}
+
+	metadata, err := r.s.blobMetadataStore.GetBlobMetadataByKeys(ctx, blobKeys)
+	if err != nil {
+		return nil, err
+	}
+	metadataByKey := make(map[corev2.BlobKey]*commonv2.BlobMetadata, len(metadata))
+	for _, m := range metadata {
+		key, err := m.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob key: %w", err)
+		}
+		metadataByKey[key] = m
+	}
+	blobs := make([]*blobResolver, 0, len(blobKeys))
+	for _, key := range blobKeys {
+		if m, ok := metadataByKey[key]; ok {
+			blobs = append(blobs, &blobResolver{s: r.s, key: key, metadata: m})
+		}
+	}
+	return blobs, nil
+}
+
+type attestationResolver struct {
+	attestation *corev2.Attestation
+}
+
+func (r *attestationResolver) AttestedAt() graphqlUint64 {
+	return graphqlUint64(r.attestation.AttestedAt)
+}
+
+func (r *attestationResolver) QuorumNumbers() []int32 {
+	quorums := make([]int32, len(r.attestation.QuorumNumbers))
+	for i, q := range r.attestation.QuorumNumbers {
+		quorums[i] = int32(q)
+	}
+	return quorums
+}
+
+func (r *attestationResolver) QuorumResults() []*quorumResultResolver {
+	results := make([]*quorumResultResolver, 0, len(r.attestation.QuorumResults))
+	for q, signed := range r.attestation.QuorumResults {
+		results = append(results, &quorumResultResolver{quorum: int32(q), signedPercentage: int32(signed)})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].quorum < results[j].quorum })
+	return results
+}
+
+func (r *attestationResolver) NonSigners() []string {
+	nonSigners := make([]string, len(r.attestation.NonSignerPubKeys))
+	for i, pubKey := range r.attestation.NonSignerPubKeys {
+		nonSigners[i] = pubKey.GetOperatorID().Hex()
+	}
+	return nonSigners
+}
+
+type quorumResultResolver struct {
+	quorum           int32
+	signedPercentage int32
+}
+
+func (r *quorumResultResolver) Quorum() int32 {
+	return r.quorum
+}
+
+func (r *quorumResultResolver) SignedPercentage() int32 {
+	return r.signedPercentage
+}
+
+type operatorStakeResolver struct {
+	stake *OperatorStake
+}
+
+func (r *operatorStakeResolver) QuorumId() string {
+	return r.stake.QuorumId
+}
+
+func (r *operatorStakeResolver) OperatorId() string {
+	return r.stake.OperatorId
+}
+
+func (r *operatorStakeResolver) StakePercentage() float64 {
+	return r.stake.StakePercentage
+}
+
+func (r *operatorStakeResolver) Rank() int32 {
+	return int32(r.stake.Rank)
+}
+
+// GraphQLHandler godoc
+//
+//	@Summary	Query blobs, batches, attestations and operators with GraphQL
+//	@Tags		GraphQL
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		GraphQLRequest	true	"GraphQL query; see schema.graphql for the schema"
+//	@Success	200		{object}	GraphQLResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Router		/graphql [post]
+func (s *ServerV2) GraphQLHandler(c *gin.Context) {
+	var request GraphQLRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalidParamsErrorResponse(c, fmt.Errorf("failed to parse request: %w", err))
+		return
+	}
+
+	// Errors, including invalid queries, are reported in the response as per the GraphQL spec
+	result := s.graphqlSchema.Exec(c.Request.Context(), request.Query, request.OperationName, request.Variables)
+	response := &GraphQLResponse{
+		Data:   result.Data,
+		Errors: result.Errors,
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,103 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+)
+
+const (
+	// healthCheckTimeout bounds the time to check a single dependency
+	healthCheckTimeout = 3 * time.Second
+
+	healthStatusOK       = "ok"
+	healthStatusDegraded = "degraded"
+)
+
+// dependencyChecks returns the checks of the dependencies of the server, by dependency name. Each check makes
+// a cheap call which fails if the dependency is unreachable.
+func (s *ServerV2) dependencyChecks() map[string]func(context.Context) error {
+	return map[string]func(context.Context) error{
+		"dynamodb": func(ctx context.Context) error {
+			now := time.Now()
+			start := blobstore.BlobFeedCursor{RequestedAt: uint64(now.Add(-time.Second).UnixNano())}
+			end := blobstore.BlobFeedCursor{RequestedAt: uint64(now.UnixNano())}
+			_, _, err := s.blobMetadataStore.GetBlobMetadataByRequestedAt(ctx, start, end, 1)
+			return err
+		},
+		"subgraph": func(ctx context.Context) error {
+			_, err := s.subgraphClient.QueryBatchesWithLimit(ctx, 1, 0)
+			return err
+		},
+		"prometheus": func(ctx context.Context) error {
+			now := time.Now()
+			_, err := s.promClient.QueryDisperserBlobSizeBytesPerSecond(ctx, now.Add(-time.Minute), now)
+			return err
+		},
+		"chain": func(ctx context.Context) error {
+			_, err := s.chainReader.GetCurrentBlockNumber(ctx)
+			return err
+		},
+	}
+}
+
+// checkDependencies checks all dependencies of the server concurrently
+func (s *ServerV2) checkDependencies(ctx context.Context) *HealthResponse {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		response = &HealthResponse{
+			Status:       healthStatusOK,
+			Dependencies: make(map[string]*DependencyHealth),
+		}
+	)
+	for name, check := range s.dependencyChecks() {
+		name, check := name, check
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health := checkDependency(ctx, check)
+			if !health.Healthy {
+				s.logger.Warn("dependency is unhealthy", "dependency", name, "err", health.Error)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			response.Dependencies[name] = health
+			if !health.Healthy {
+				response.Status = healthStatusDegraded
+			}
+		}()
+	}
+	wg.Wait()
+	return response
+}
+
+// checkDependency runs the check, failing it if it doesn't complete within the health check timeout
+func checkDependency(ctx context.Context, check func(context.Context) error) *DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+		err = fmt.Errorf("check did not complete within %v", healthCheckTimeout)
+	}
+
+	health := &DependencyHealth{
+		Healthy:   err == nil,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+	return health
+}
@@ -0,0 +1,73 @@
+package dataapi
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultMaintenanceRetryAfter = time.Minute
+	// drainPollInterval is how often the in-flight requests are checked when entering maintenance mode
+	drainPollInterval = 100 * time.Millisecond
+)
+
+var errMaintenance = errors.New("the server is under maintenance")
+
+// Maintenance returns a middleware which, while the server is in maintenance mode, rejects requests with 503 and a
+// Retry-After header. Requests already being served when maintenance mode is entered complete as usual. Routes in
+// excludedRoutes, given as registered full paths, are long-lived and are not counted as in-flight requests.
+func (s *ServerV2) Maintenance(excludedRoutes ...string) gin.HandlerFunc {
+	excluded := make(map[string]struct{}, len(excludedRoutes))
+	for _, route := range excludedRoutes {
+		excluded[route] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		// The request is counted before the mode is checked, so that once maintenance mode is entered every
+		// admitted request is visible to the drain
+		if _, ok := excluded[c.FullPath()]; !ok {
+			s.inFlightRequests.Add(1)
+			defer s.inFlightRequests.Add(-1)
+		}
+		if s.maintenance.Load() {
+			retryAfter := int(math.Ceil(s.maintenanceRetryAfter.Seconds()))
+			c.Writer.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			abortWithError(c, http.StatusServiceUnavailable, errMaintenance)
+			return
+		}
+		c.Next()
+	}
+}
+
+// SetMaintenance puts the server in or out of maintenance mode. On entering it, a log line is written once the
+// requests that were in flight have completed.
+func (s *ServerV2) SetMaintenance(enabled bool) {
+	if s.maintenance.Swap(enabled) == enabled {
+		return
+	}
+	if !enabled {
+		s.logger.Info("Leaving maintenance mode")
+		return
+	}
+	s.logger.Info("Entering maintenance mode, draining in-flight requests", "inFlight", s.inFlightRequests.Load())
+	go s.waitForDrain()
+}
+
+func (s *ServerV2) waitForDrain() {
+	start := time.Now()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !s.maintenance.Load() {
+			return
+		}
+		if s.inFlightRequests.Load() == 0 {
+			s.logger.Info("In-flight requests drained", "duration", time.Since(start))
+			return
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
@@ -28,6 +30,9 @@ type Metrics struct {
 	Latency        *prometheus.SummaryVec
 	OperatorsStake *prometheus.GaugeVec
 
+	RequestDuration  *prometheus.HistogramVec
+	InFlightRequests prometheus.Gauge
+
 	Semvers                *prometheus.GaugeVec
 	SemversStakePctQuorum0 *prometheus.GaugeVec
 	SemversStakePctQuorum1 *prometheus.GaugeVec
@@ -61,6 +66,23 @@ func NewMetrics(blobMetadataStore *blobstore.BlobMetadataStore, httpPort string,
 			},
 			[]string{"method"},
 		),
+		RequestDuration: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "http_request_duration_seconds",
+				Help:      "the duration of HTTP requests in seconds",
+				Buckets:   prometheus.DefBuckets,
+			},
+			// The "route" is the route template, e.g. /api/v2/blobs/:blob_key, to bound the cardinality
+			[]string{"method", "route", "status"},
+		),
+		InFlightRequests: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "http_requests_in_flight",
+				Help:      "the number of HTTP requests being served",
+			},
+		),
 		Semvers: promauto.With(reg).NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "node_semvers",
@@ -143,6 +165,19 @@ func (g *Metrics) IncrementNotFoundRequestNum(method string) {
 	}).Inc()
 }
 
+// IncrementRateLimitedRequestNum increments the number of requests rejected by the rate limiter
+func (g *Metrics) IncrementRateLimitedRequestNum(method string) {
+	g.NumRequests.With(prometheus.Labels{
+		"status": "rate_limited",
+		"method": method,
+	}).Inc()
+}
+
+// ObserveRequest observes the duration of a served HTTP request
+func (g *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	g.RequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
 // UpdateSemverMetrics updates the semver metrics
 func (g *Metrics) UpdateSemverCounts(semverData map[string]*semver.SemverMetrics) {
 	for semver, metrics := range semverData {
@@ -2,23 +2,42 @@ package dataapi
 
 import (
 	"context"
+	"fmt"
 	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
 )
 
 const (
 	defaultThroughputRateSecs  = 240 // 4m rate is used for < 7d window to match $__rate_interval
 	sevenDayThroughputRateSecs = 660 // 11m rate is used for >= 7d window to match $__rate_interval
+
+	// maxThroughputCacheEntries bounds the number of cached throughput time series
+	maxThroughputCacheEntries = 256
+	// maxThroughputCacheTTL bounds how long a cached throughput time series is served
+	maxThroughputCacheTTL = 10 * time.Minute
 )
 
+// throughputResolutions are the supported bucket widths of the throughput time series
+var throughputResolutions = map[string]time.Duration{
+	"1m":  time.Minute,
+	"10m": 10 * time.Minute,
+	"1h":  time.Hour,
+}
+
 // metricHandler handles operations to collect metrics about the Disperser.
 type metricsHandler struct {
 	// For accessing metrics info
 	promClient PrometheusClient
+
+	// Caches throughput time series keyed by the bucket-aligned query range
+	throughputCache *expirable.LRU[string, []*Throughput]
 }
 
 func newMetricsHandler(promClient PrometheusClient) *metricsHandler {
 	return &metricsHandler{
-		promClient: promClient,
+		promClient:      promClient,
+		throughputCache: expirable.NewLRU[string, []*Throughput](maxThroughputCacheEntries, nil, maxThroughputCacheTTL),
 	}
 }
 
@@ -36,6 +55,43 @@ func (mh *metricsHandler) getAvgThroughput(ctx context.Context, startTime int64,
 	return totalBytes / timeDuration, nil
 }
 
+// getTotalBytes returns the size of the blobs confirmed in the time range, from the growth of the blob size counter
+func (mh *metricsHandler) getTotalBytes(ctx context.Context, startTime int64, endTime int64) (uint64, error) {
+	result, err := mh.promClient.QueryDisperserBlobSizeBytesPerSecond(ctx, time.Unix(startTime, 0), time.Unix(endTime, 0))
+	if err != nil {
+		return 0, err
+	}
+	size := len(result.Values)
+	if size == 0 {
+		return 0, nil
+	}
+	totalBytes := result.Values[size-1].Value - result.Values[0].Value
+	// the counter is reset when the batcher restarts
+	if totalBytes < 0 {
+		return 0, nil
+	}
+	return uint64(totalBytes), nil
+}
+
+// getStaleBlobCount returns the number of stale blobs handled with the given action in the time range, from the
+// growth of the stale blob counter
+func (mh *metricsHandler) getStaleBlobCount(ctx context.Context, startTime int64, endTime int64, action string) (uint64, error) {
+	result, err := mh.promClient.QueryStaleBlobsTotal(ctx, time.Unix(startTime, 0), time.Unix(endTime, 0), action)
+	if err != nil {
+		return 0, err
+	}
+	size := len(result.Values)
+	if size == 0 {
+		return 0, nil
+	}
+	count := result.Values[size-1].Value - result.Values[0].Value
+	// the counter is reset when the controller restarts
+	if count < 0 {
+		return 0, nil
+	}
+	return uint64(count), nil
+}
+
 func (mh *metricsHandler) getThroughputTimeseries(ctx context.Context, startTime int64, endTime int64) ([]*Throughput, error) {
 	throughputRateSecs := uint16(defaultThroughputRateSecs)
 	if endTime-startTime >= 7*24*60*60 {
@@ -61,3 +117,39 @@ func (mh *metricsHandler) getThroughputTimeseries(ctx context.Context, startTime
 
 	return throughputs, nil
 }
+
+// getThroughputTimeseriesAtResolution returns the throughput in [startTime, endTime] bucketed by resolution.
+// The range is aligned to resolution boundaries, so that repeated requests within the same bucket are
+// served from cache instead of re-running the PromQL query.
+func (mh *metricsHandler) getThroughputTimeseriesAtResolution(ctx context.Context, startTime int64, endTime int64, resolution time.Duration) ([]*Throughput, error) {
+	resolutionSecs := int64(resolution.Seconds())
+	if resolutionSecs <= 0 {
+		return nil, fmt.Errorf("invalid resolution %v", resolution)
+	}
+	startTime = startTime - startTime%resolutionSecs
+	endTime = endTime - endTime%resolutionSecs
+	if endTime <= startTime {
+		return []*Throughput{}, nil
+	}
+
+	cacheKey := fmt.Sprintf("%d-%d-%d", startTime, endTime, resolutionSecs)
+	if ths, ok := mh.throughputCache.Get(cacheKey); ok {
+		return ths, nil
+	}
+
+	result, err := mh.promClient.QueryDisperserThroughputTimeseries(ctx, time.Unix(startTime, 0), time.Unix(endTime, 0), resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	throughputs := make([]*Throughput, 0, len(result.Values))
+	for _, v := range result.Values {
+		throughputs = append(throughputs, &Throughput{
+			Timestamp:  uint64(v.Timestamp.Unix()),
+			Throughput: v.Value,
+		})
+	}
+
+	mh.throughputCache.Add(cacheKey, throughputs)
+	return throughputs, nil
+}
@@ -0,0 +1,273 @@
+package dataapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// FetchMetricsSummaryHandler godoc
+//
+//	@Summary	Fetch the headline metrics of the network: throughput, volume dispersed, operators, stake and latest batch
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		start	query		int	false	"Start unix timestamp of the avg throughput [default: 1 hour ago]"
+//	@Param		end		query		int	false	"End unix timestamp of the avg throughput [default: unix time now]"
+//	@Success	200		{object}	MetricSummary
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/summary  [get]
+func (s *ServerV2) FetchMetricsSummaryHandler(c *gin.Context) {
+	now := time.Now()
+	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
+	if err != nil || start == 0 {
+		start = now.Add(-time.Hour * 1).Unix()
+	}
+
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end == 0 {
+		end = now.Unix()
+	}
+
+	metricSummary, err := s.getMetricsSummary(c.Request.Context(), start, end)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxMetricAage*time.Second)
+	c.JSON(http.StatusOK, metricSummary)
+}
+
+// FetchStaleBlobCountsHandler godoc
+//
+//	@Summary	Fetch the number of blobs stuck in the encoded status that the controller requeued or failed
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		start	query		int	false	"Start unix timestamp [default: 1 hour ago]"
+//	@Param		end		query		int	false	"End unix timestamp [default: unix time now]"
+//	@Success	200		{object}	StaleBlobCounts
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/stale-blobs  [get]
+func (s *ServerV2) FetchStaleBlobCountsHandler(c *gin.Context) {
+	now := time.Now()
+	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
+	if err != nil || start == 0 {
+		start = now.Add(-time.Hour * 1).Unix()
+	}
+
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end == 0 {
+		end = now.Unix()
+	}
+
+	requeued, err := s.metricsHandler.getStaleBlobCount(c.Request.Context(), start, end, "requeued")
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	failed, err := s.metricsHandler.getStaleBlobCount(c.Request.Context(), start, end, "failed")
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxMetricAage*time.Second)
+	c.JSON(http.StatusOK, &StaleBlobCounts{
+		StartTime: start,
+		EndTime:   end,
+		Requeued:  requeued,
+		Failed:    failed,
+	})
+}
+
+// FetchMetricsOverviewHandler godoc
+//
+//	@Summary	Fetch network metrics overview
+//	@Tags		Metrics
+//	@Produce	json
+//	@Success	200	{object}	MetricsOverview
+//	@Failure	500	{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/overview  [get]
+func (s *ServerV2) FetchMetricsOverviewHandler(c *gin.Context) {
+	overview, err := s.metricsOverviewHandler.getOverview(c.Request.Context())
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxMetricAage*time.Second)
+	c.JSON(http.StatusOK, overview)
+}
+
+// FetchMetricsThroughputTimeseriesHandler godoc
+//
+//	@Summary	Fetch throughput time series
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		start		query		int		false	"Start unix timestamp [default: 1 hour ago]"
+//	@Param		end			query		int		false	"End unix timestamp [default: unix time now]"
+//	@Param		resolution	query		string	false	"Width of each time bucket: 1m, 10m or 1h [default: 1m]"
+//	@Success	200			{object}	[]Throughput
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/timeseries/throughput  [get]
+func (s *ServerV2) FetchMetricsThroughputTimeseriesHandler(c *gin.Context) {
+	now := time.Now()
+	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
+	if err != nil || start == 0 {
+		start = now.Add(-time.Hour * 1).Unix()
+	}
+
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end == 0 {
+		end = now.Unix()
+	}
+
+	resolution, ok := throughputResolutions[c.DefaultQuery("resolution", "1m")]
+	if !ok {
+		invalidParamsErrorResponse(c, errors.New("resolution must be one of 1m, 10m or 1h"))
+		return
+	}
+	if end <= start {
+		invalidParamsErrorResponse(c, errors.New("start must be before end"))
+		return
+	}
+	if (end-start)/int64(resolution.Seconds()) > maxNumOfDataPoints {
+		invalidParamsErrorResponse(c, fmt.Errorf("time range too large for resolution, at most %d data points are allowed", maxNumOfDataPoints))
+		return
+	}
+
+	ths, err := s.metricsHandler.getThroughputTimeseriesAtResolution(c.Request.Context(), start, end, resolution)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxThroughputAge*time.Second)
+	c.JSON(http.StatusOK, ths)
+}
+
+// FetchBlobSizeHistogramHandler godoc
+//
+//	@Summary	Fetch the size distribution of blobs dispersed in a time window
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		start	query		int	false	"Start unix timestamp [default: 1 hour before end]"
+//	@Param		end		query		int	false	"End unix timestamp [default: unix time now]"
+//	@Success	200		{object}	BlobSizeHistogram
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/blob-sizes  [get]
+func (s *ServerV2) FetchBlobSizeHistogramHandler(c *gin.Context) {
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end == 0 {
+		end = time.Now().Unix()
+	}
+	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
+	if err != nil || start == 0 {
+		start = time.Unix(end, 0).Add(-time.Hour).Unix()
+	}
+	if end <= start {
+		invalidParamsErrorResponse(c, errors.New("start must be before end"))
+		return
+	}
+	if time.Duration(end-start)*time.Second > maxBlobSizeHistogramWindow {
+		invalidParamsErrorResponse(c, fmt.Errorf("time range must not exceed %v", maxBlobSizeHistogramWindow))
+		return
+	}
+
+	histogram, err := s.getBlobSizeHistogram(c.Request.Context(), time.Unix(start, 0), time.Unix(end, 0))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxMetricAage*time.Second)
+	c.JSON(http.StatusOK, histogram)
+}
+
+// FetchAttestationLatencyHandler godoc
+//
+//	@Summary	Fetch the distribution of latencies from blob dispersal to batch attestation, by quorum
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		start	query		int	false	"Start unix timestamp of the attestations [default: 1 hour before end]"
+//	@Param		end		query		int	false	"End unix timestamp of the attestations [default: unix time now]"
+//	@Success	200		{object}	AttestationLatencyResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/attestation-latency  [get]
+func (s *ServerV2) FetchAttestationLatencyHandler(c *gin.Context) {
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end == 0 {
+		end = time.Now().Unix()
+	}
+	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
+	if err != nil || start == 0 {
+		start = time.Unix(end, 0).Add(-time.Hour).Unix()
+	}
+	if end <= start {
+		invalidParamsErrorResponse(c, errors.New("start must be before end"))
+		return
+	}
+	if time.Duration(end-start)*time.Second > maxAttestationLatencyWindow {
+		invalidParamsErrorResponse(c, fmt.Errorf("time range must not exceed %v", maxAttestationLatencyWindow))
+		return
+	}
+
+	response, err := s.getAttestationLatency(c.Request.Context(), time.Unix(start, 0), time.Unix(end, 0))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxMetricAage*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// FetchThroughputRollupsHandler godoc
+//
+//	@Summary	Fetch the daily or weekly rollups of the throughput, blob counts and on-demand payments
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		period	query		string	false	"Length of the rolled up periods, daily or weekly [default: daily]"
+//	@Param		start	query		string	false	"First day in UTC (2006-01-02); weeks starting on or after the Monday of that week are returned [default: 29 days before end]"
+//	@Param		end		query		string	false	"Last day in UTC (2006-01-02) [default: today]"
+//	@Success	200		{object}	ThroughputRollupsResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/rollups [get]
+func (s *ServerV2) FetchThroughputRollupsHandler(c *gin.Context) {
+	period := commonv2.RollupPeriod(c.DefaultQuery("period", string(commonv2.DailyRollup)))
+	if period != commonv2.DailyRollup && period != commonv2.WeeklyRollup {
+		invalidParamsErrorResponse(c, fmt.Errorf("period must be %s or %s", commonv2.DailyRollup, commonv2.WeeklyRollup))
+		return
+	}
+	start, end, err := parseDayRange(c, defaultThroughputRollupDays, maxThroughputRollupDays)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+
+	response, err := s.throughputAggregator.getRollups(c.Request.Context(), period, start, end)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxThroughputRollupsAge*time.Second)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,252 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// metricsOverviewWindow is the trailing window over which dispersal volume is aggregated
+	metricsOverviewWindow = time.Hour
+	// metricsOverviewRefreshInterval is how often the overview is recomputed in the background
+	metricsOverviewRefreshInterval = time.Minute
+	// metricsOverviewBucketSize is the granularity at which the dispersal volume is tallied
+	metricsOverviewBucketSize = time.Minute
+	// metricsOverviewPageSize is the number of blobs read from the metadata store at once
+	metricsOverviewPageSize = 1000
+)
+
+// dispersalTally is the volume of the blobs requested in a bucket
+type dispersalTally struct {
+	numBlobs int
+	bytes    uint64
+}
+
+// metricsOverviewHandler precomputes the v2 network metrics overview in the background,
+// so that requests are served from memory instead of scanning the metadata store and chain state.
+// The dispersal volume is tallied by minute as blobs are requested, so each refresh only reads the
+// blobs requested since the last one. The lifetime volume adds the throughput rollups, which are
+// summed up again only until the last completed day is rolled up, to the tallies of the days after.
+type metricsOverviewHandler struct {
+	logger               logging.Logger
+	blobMetadataStore    *blobstore.BlobMetadataStore
+	chainReader          core.Reader
+	chainState           core.ChainState
+	throughputAggregator *throughputAggregator
+
+	// serializes refreshes; the fields below are only accessed by refresh
+	refreshMu sync.Mutex
+	// tallies by bucket start (unix nanoseconds), from tallyFrom or the window, whichever is earlier
+	tallies map[uint64]*dispersalTally
+	// cursor of the last tallied blob, nil before the first refresh
+	cursor *blobstore.BlobFeedCursor
+	// the sum of the rolled up bytes, and the start of the first day which isn't rolled up
+	rollupBytes uint64
+	tallyFrom   time.Time
+
+	mu       sync.RWMutex
+	overview *MetricsOverview
+}
+
+func newMetricsOverviewHandler(
+	logger logging.Logger,
+	blobMetadataStore *blobstore.BlobMetadataStore,
+	chainReader core.Reader,
+	chainState core.ChainState,
+	throughputAggregator *throughputAggregator,
+) *metricsOverviewHandler {
+	return &metricsOverviewHandler{
+		logger:               logger,
+		blobMetadataStore:    blobMetadataStore,
+		chainReader:          chainReader,
+		chainState:           chainState,
+		throughputAggregator: throughputAggregator,
+		tallies:              make(map[uint64]*dispersalTally),
+	}
+}
+
+// start refreshes the overview every interval until the context is cancelled
+func (h *metricsOverviewHandler) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := h.refresh(ctx); err != nil {
+				h.logger.Warn("failed to refresh metrics overview", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// getOverview returns the latest precomputed overview, computing it on demand if none is available yet
+func (h *metricsOverviewHandler) getOverview(ctx context.Context) (*MetricsOverview, error) {
+	h.mu.RLock()
+	overview := h.overview
+	h.mu.RUnlock()
+	if overview != nil {
+		return overview, nil
+	}
+	return h.refresh(ctx)
+}
+
+func (h *metricsOverviewHandler) refresh(ctx context.Context) (*MetricsOverview, error) {
+	h.refreshMu.Lock()
+	defer h.refreshMu.Unlock()
+	overview, err := h.computeOverview(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.overview = overview
+	h.mu.Unlock()
+	return overview, nil
+}
+
+// updateRollupBytes sums up the rolled up bytes, unless the last completed day was already rolled up at the last sum
+func (h *metricsOverviewHandler) updateRollupBytes(ctx context.Context, now time.Time) error {
+	today := now.UTC().Truncate(rollupDay)
+	if h.tallyFrom.Equal(today) {
+		return nil
+	}
+	// Checked before the sum, so that a rollup recorded in between is at worst counted twice until the next refresh
+	yesterday := today.Add(-rollupDay)
+	rollups, err := h.blobMetadataStore.GetThroughputRollups(ctx, commonv2.DailyRollup, []uint64{uint64(yesterday.Unix())})
+	if err != nil {
+		return fmt.Errorf("failed to get daily rollups: %w", err)
+	}
+	bytes, err := h.throughputAggregator.getLifetimeCertifiedBytes(ctx, now)
+	if err != nil {
+		return err
+	}
+	h.rollupBytes = bytes
+	h.tallyFrom = yesterday
+	if len(rollups) > 0 {
+		h.tallyFrom = today
+	}
+	return nil
+}
+
+// updateTallies tallies the blobs requested since the last update, and drops the tallies which are neither in the
+// window nor after tallyFrom
+func (h *metricsOverviewHandler) updateTallies(ctx context.Context, now time.Time) error {
+	from := h.tallyFrom
+	if windowStart := now.Add(-metricsOverviewWindow); windowStart.Before(from) {
+		from = windowStart
+	}
+	from = from.Truncate(metricsOverviewBucketSize)
+	for bucket := range h.tallies {
+		if bucket < uint64(from.UnixNano()) {
+			delete(h.tallies, bucket)
+		}
+	}
+
+	cursor := blobstore.BlobFeedCursor{RequestedAt: uint64(from.UnixNano())}
+	if h.cursor != nil && h.cursor.RequestedAt >= cursor.RequestedAt {
+		cursor = *h.cursor
+	}
+	end := blobstore.BlobFeedCursor{RequestedAt: uint64(now.UnixNano())}
+	for {
+		blobs, lastCursor, err := h.blobMetadataStore.GetBlobMetadataByRequestedAt(ctx, cursor, end, metricsOverviewPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to fetch blobs: %w", err)
+		}
+		for _, b := range blobs {
+			bucket := b.RequestedAt - b.RequestedAt%uint64(metricsOverviewBucketSize)
+			tally, ok := h.tallies[bucket]
+			if !ok {
+				tally = &dispersalTally{}
+				h.tallies[bucket] = tally
+			}
+			tally.numBlobs++
+			tally.bytes += b.BlobSize
+		}
+		if lastCursor == nil {
+			break
+		}
+		cursor = *lastCursor
+		h.cursor = lastCursor
+		if len(blobs) < metricsOverviewPageSize {
+			break
+		}
+	}
+	return nil
+}
+
+func (h *metricsOverviewHandler) computeOverview(ctx context.Context, now time.Time) (*MetricsOverview, error) {
+	if err := h.updateRollupBytes(ctx, now); err != nil {
+		return nil, err
+	}
+	if err := h.updateTallies(ctx, now); err != nil {
+		return nil, err
+	}
+	windowStart := uint64(now.Add(-metricsOverviewWindow).Truncate(metricsOverviewBucketSize).UnixNano())
+	tallyFrom := uint64(h.tallyFrom.UnixNano())
+	var numBlobs int
+	var windowBytes, unrolledBytes uint64
+	for bucket, tally := range h.tallies {
+		if bucket >= windowStart {
+			numBlobs += tally.numBlobs
+			windowBytes += tally.bytes
+		}
+		if bucket >= tallyFrom {
+			unrolledBytes += tally.bytes
+		}
+	}
+
+	blockNumber, err := h.chainReader.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	quorumCount, err := h.chainReader.GetQuorumCount(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum count: %w", err)
+	}
+	// assume quorum IDs are consequent integers starting from 0
+	quorumIDs := make([]core.QuorumID, quorumCount)
+	for i := 0; i < int(quorumCount); i++ {
+		quorumIDs[i] = core.QuorumID(i)
+	}
+	operatorState, err := h.chainState.GetOperatorState(ctx, uint(blockNumber), quorumIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", blockNumber, err)
+	}
+
+	totalStakePerQuorum := make(map[core.QuorumID]*big.Int)
+	numOperatorsPerQuorum := make(map[core.QuorumID]int)
+	operators := make(map[core.OperatorID]struct{})
+	for quorumID, opInfoByID := range operatorState.Operators {
+		totalStake := new(big.Int)
+		for opID, opInfo := range opInfoByID {
+			totalStake.Add(totalStake, opInfo.Stake)
+			operators[opID] = struct{}{}
+		}
+		totalStakePerQuorum[quorumID] = totalStake
+		numOperatorsPerQuorum[quorumID] = len(opInfoByID)
+	}
+
+	return &MetricsOverview{
+		WindowSeconds:         uint64(metricsOverviewWindow.Seconds()),
+		NumBlobs:              numBlobs,
+		DispersedBytes:        windowBytes,
+		TotalDispersedBytes:   h.rollupBytes + unrolledBytes,
+		AvgThroughput:         float64(windowBytes) / metricsOverviewWindow.Seconds(),
+		TotalStakePerQuorum:   totalStakePerQuorum,
+		NumOperatorsPerQuorum: numOperatorsPerQuorum,
+		TotalOperators:        len(operators),
+		BlockNumber:           blockNumber,
+		ComputedAt:            uint64(now.Unix()),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// latestBatchLookbacks are the windows searched in turn for the latest attested batch, so that the common case of a
+// batch attested in the last few seconds doesn't read an hour of attestations
+var latestBatchLookbacks = []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
+
+// getMetricsSummary gathers the headline numbers of the network: the avg throughput in the time range, the volume
+// dispersed in the last day and in all the days rolled up, the operators and stake of each quorum, and the latest batch
+func (s *ServerV2) getMetricsSummary(ctx context.Context, start, end int64) (*MetricSummary, error) {
+	now := time.Now()
+	avgThroughput, err := s.metricsHandler.getAvgThroughput(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	dispersed24h, err := s.metricsHandler.getTotalBytes(ctx, now.Add(-24*time.Hour).Unix(), now.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bytes dispersed in the last day: %w", err)
+	}
+	// Rollups change once per day, so the sum is cached like other expensive queries
+	lifetime, err := cachedQuery(ctx, s.queryCache, "lifetimeCertifiedBytes", func(ctx context.Context) (uint64, error) {
+		return s.throughputAggregator.getLifetimeCertifiedBytes(ctx, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	overview, err := s.metricsOverviewHandler.getOverview(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latestBatch, err := s.getLatestBatch(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSummary{
+		AvgThroughput:         avgThroughput,
+		TotalDispersedBytes:   lifetime,
+		DispersedBytes24h:     dispersed24h,
+		TotalStakePerQuorum:   overview.TotalStakePerQuorum,
+		NumOperatorsPerQuorum: overview.NumOperatorsPerQuorum,
+		TotalOperators:        overview.TotalOperators,
+		BlockNumber:           overview.BlockNumber,
+		LatestBatch:           latestBatch,
+	}, nil
+}
+
+// getLatestBatch returns the last batch attested in the past hour, or nil if there is none
+func (s *ServerV2) getLatestBatch(ctx context.Context, now time.Time) (*BatchInfo, error) {
+	for _, lookback := range latestBatchLookbacks {
+		attestations, err := s.blobMetadataStore.GetAttestationByAttestedAt(ctx, uint64(now.Add(-lookback).UnixNano()), uint64(now.UnixNano()), 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch attestations: %w", err)
+		}
+		if len(attestations) == 0 {
+			continue
+		}
+		batches, err := newBatchInfos(attestations[len(attestations)-1:])
+		if err != nil {
+			return nil, err
+		}
+		return batches[0], nil
+	}
+	return nil, nil
+}
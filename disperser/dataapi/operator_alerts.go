@@ -0,0 +1,286 @@
+package dataapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// defaultOperatorAlertCheckInterval is how often operators are checked if the interval is not configured
+	defaultOperatorAlertCheckInterval = time.Minute
+	// defaultAlertSigningRateThreshold is the signing percentage below which an alert fires if the threshold is
+	// not configured
+	defaultAlertSigningRateThreshold = 90.0
+	// operatorAlertSigningWindow is the window over which the signing rate of operators is checked
+	operatorAlertSigningWindow = time.Hour
+	// operatorAlertMinBatches is the min number of batches an operator must have been required to sign within the
+	// window for its signing rate to be checked, so that a few missed batches don't fire an alert
+	operatorAlertMinBatches = 10
+	// operatorAlertTimeout bounds the time of a single webhook delivery attempt
+	operatorAlertTimeout = 10 * time.Second
+	// operatorAlertMaxAttempts is the max number of times the delivery of an alert to a webhook is attempted
+	operatorAlertMaxAttempts = 4
+	// defaultOperatorAlertRetryBackoff is the delay before the first retry, doubled on each retry
+	defaultOperatorAlertRetryBackoff = time.Second
+
+	// operatorAlertSignatureHeader carries the hex HMAC-SHA256 of the payload, keyed with the webhook secret
+	operatorAlertSignatureHeader = "X-EigenDA-Signature-256"
+)
+
+// The types of operator alerts
+const (
+	OperatorAlertSigningRate = "signing_rate"
+	OperatorAlertUnreachable = "unreachable"
+)
+
+// The statuses of operator alerts
+const (
+	OperatorAlertFiring   = "firing"
+	OperatorAlertResolved = "resolved"
+)
+
+// unreachableSemvers are the results of a host info scan for operators which could not be reached
+var unreachableSemvers = []string{"unreachable", "refused", "timeout"}
+
+// OperatorAlert is the payload posted to the alert webhooks when an operator starts or stops misbehaving
+type OperatorAlert struct {
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	OperatorId string `json:"operator_id"`
+	// Timestamp is the Unix time in seconds of the check which changed the status of the alert
+	Timestamp uint64 `json:"timestamp"`
+
+	// Set for signing rate alerts
+	SigningPercentage float64 `json:"signing_percentage,omitempty"`
+	TotalBatches      int     `json:"total_batches,omitempty"`
+	UnsignedBatches   int     `json:"unsigned_batches,omitempty"`
+	// Set for unreachable alerts, to the result of the host info scan
+	Reason string `json:"reason,omitempty"`
+}
+
+// operatorAlerter checks the signing rate and reachability of operators, and posts an alert to the configured
+// webhooks when an operator crosses the signing rate threshold or becomes unreachable, and when it recovers.
+type operatorAlerter struct {
+	logger                logging.Logger
+	signingRateAggregator *signingRateAggregator
+	operatorHandler       *operatorHandler
+	httpClient            *http.Client
+
+	webhookURLs      []string
+	secret           []byte
+	signingThreshold float64
+	retryBackoff     time.Duration
+
+	// the firing alerts by type and operator; only accessed by the check loop
+	firing map[string]map[core.OperatorID]bool
+}
+
+func newOperatorAlerter(
+	logger logging.Logger,
+	signingRateAggregator *signingRateAggregator,
+	operatorHandler *operatorHandler,
+	config Config,
+) *operatorAlerter {
+	threshold := config.AlertSigningRateThreshold
+	if threshold <= 0 {
+		threshold = defaultAlertSigningRateThreshold
+	}
+	return &operatorAlerter{
+		logger:                logger,
+		signingRateAggregator: signingRateAggregator,
+		operatorHandler:       operatorHandler,
+		httpClient:            &http.Client{Timeout: operatorAlertTimeout},
+		webhookURLs:           config.AlertWebhookURLs,
+		secret:                []byte(config.AlertWebhookSecret),
+		signingThreshold:      threshold,
+		retryBackoff:          defaultOperatorAlertRetryBackoff,
+		firing: map[string]map[core.OperatorID]bool{
+			OperatorAlertSigningRate: make(map[core.OperatorID]bool),
+			OperatorAlertUnreachable: make(map[core.OperatorID]bool),
+		},
+	}
+}
+
+// start checks the operators every interval until the context is cancelled
+func (a *operatorAlerter) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			a.check(ctx, time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// check posts an alert for every operator whose signing rate or reachability changed since the last check
+func (a *operatorAlerter) check(ctx context.Context, now time.Time) {
+	alerts := a.checkSigningRates(now)
+	alerts = append(alerts, a.checkReachability(now)...)
+	sort.SliceStable(alerts, func(i, j int) bool { return alerts[i].OperatorId < alerts[j].OperatorId })
+	for _, alert := range alerts {
+		a.logger.Info("operator alert", "type", alert.Type, "status", alert.Status, "operatorId", alert.OperatorId)
+		a.send(ctx, alert)
+	}
+}
+
+func (a *operatorAlerter) checkSigningRates(now time.Time) []*OperatorAlert {
+	alerts := make([]*OperatorAlert, 0)
+	firing := a.firing[OperatorAlertSigningRate]
+	for operatorID, tally := range a.signingRateAggregator.getTalliesSince(now.Add(-operatorAlertSigningWindow)) {
+		// The status of operators without enough batches is left unchanged
+		if tally.batches < operatorAlertMinBatches {
+			continue
+		}
+		percentage := float64(tally.batches-tally.unsignedBatches) * 100 / float64(tally.batches)
+		below := percentage < a.signingThreshold
+		if below == firing[operatorID] {
+			continue
+		}
+		firing[operatorID] = below
+		alerts = append(alerts, &OperatorAlert{
+			Type:              OperatorAlertSigningRate,
+			Status:            alertStatus(below),
+			OperatorId:        operatorID.Hex(),
+			Timestamp:         uint64(now.Unix()),
+			SigningPercentage: percentage,
+			TotalBatches:      tally.batches,
+			UnsignedBatches:   tally.unsignedBatches,
+		})
+	}
+	return alerts
+}
+
+func (a *operatorAlerter) checkReachability(now time.Time) []*OperatorAlert {
+	report := a.operatorHandler.latestHostInfo()
+	if report == nil {
+		// Operators have not been scanned yet
+		return nil
+	}
+	unreachable := make(map[core.OperatorID]string)
+	for _, reason := range unreachableSemvers {
+		metrics, ok := report.Semver[reason]
+		if !ok {
+			continue
+		}
+		for _, id := range metrics.OperatorIds {
+			operatorID, err := core.OperatorIDFromHex(id)
+			if err != nil {
+				a.logger.Warn("invalid operator id in host info report", "operatorId", id, "err", err)
+				continue
+			}
+			unreachable[operatorID] = reason
+		}
+	}
+
+	alerts := make([]*OperatorAlert, 0)
+	firing := a.firing[OperatorAlertUnreachable]
+	for operatorID, reason := range unreachable {
+		if firing[operatorID] {
+			continue
+		}
+		firing[operatorID] = true
+		alerts = append(alerts, &OperatorAlert{
+			Type:       OperatorAlertUnreachable,
+			Status:     OperatorAlertFiring,
+			OperatorId: operatorID.Hex(),
+			Timestamp:  uint64(now.Unix()),
+			Reason:     reason,
+		})
+	}
+	for operatorID := range firing {
+		if _, ok := unreachable[operatorID]; ok {
+			continue
+		}
+		delete(firing, operatorID)
+		alerts = append(alerts, &OperatorAlert{
+			Type:       OperatorAlertUnreachable,
+			Status:     OperatorAlertResolved,
+			OperatorId: operatorID.Hex(),
+			Timestamp:  uint64(now.Unix()),
+		})
+	}
+	return alerts
+}
+
+// send posts the alert to every webhook, retrying with exponential backoff on network errors, rate limiting and
+// server errors. The alert is dropped for a webhook once all attempts fail.
+func (a *operatorAlerter) send(ctx context.Context, alert *OperatorAlert) {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		a.logger.Error("failed to encode operator alert", "err", err)
+		return
+	}
+	var signature string
+	if len(a.secret) > 0 {
+		mac := hmac.New(sha256.New, a.secret)
+		mac.Write(payload)
+		signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	for _, url := range a.webhookURLs {
+		backoff := a.retryBackoff
+		for attempt := 1; ; attempt++ {
+			retryable, err := a.post(ctx, url, payload, signature)
+			if err == nil {
+				break
+			}
+			if !retryable || attempt == operatorAlertMaxAttempts {
+				a.logger.Warn("failed to send operator alert", "url", url, "attempts", attempt, "err", err)
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+}
+
+// post makes a single delivery attempt, and returns whether a failed attempt may be retried
+func (a *operatorAlerter) post(ctx context.Context, url string, payload []byte, signature string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(operatorAlertSignatureHeader, signature)
+	}
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode/100 == 2 {
+		return false, nil
+	}
+	retryable := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return retryable, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+}
+
+func alertStatus(firing bool) string {
+	if firing {
+		return OperatorAlertFiring
+	}
+	return OperatorAlertResolved
+}
@@ -3,14 +3,21 @@ package dataapi
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser/common/semver"
 	"github.com/Layr-Labs/eigenda/operators"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"golang.org/x/sync/singleflight"
 )
 
+// operatorsHostInfoRefreshInterval is how often the operators host info is scanned in the background
+const operatorsHostInfoRefreshInterval = 5 * time.Minute
+
 // operatorHandler handles operations to collect and process operators info.
 type operatorHandler struct {
 	// For visibility
@@ -22,6 +29,13 @@ type operatorHandler struct {
 	chainState        core.ChainState
 	indexedChainState core.IndexedChainState
 	subgraphClient    SubgraphClient
+
+	// The latest host info report, refreshed in the background once started
+	hostInfoMu              sync.RWMutex
+	hostInfoReport          *SemverReportResponse
+	hostInfoRefreshInterval time.Duration
+	// hostInfoScans coalesces on-demand scans made before the first report is available
+	hostInfoScans singleflight.Group
 }
 
 func newOperatorHandler(logger logging.Logger, metrics *Metrics, chainReader core.Reader, chainState core.ChainState, indexedChainState core.IndexedChainState, subgraphClient SubgraphClient) *operatorHandler {
@@ -42,20 +56,46 @@ func (oh *operatorHandler) probeOperatorHosts(ctx context.Context, operatorId st
 		return &OperatorPortCheckResponse{}, err
 	}
 
+	// Each port is probed independently, as operators may split the services across ports
 	operatorSocket := core.OperatorSocket(operatorInfo.Socket)
-	retrievalSocket := operatorSocket.GetRetrievalSocket()
-	retrievalOnline := checkIsOperatorOnline(retrievalSocket, 3, oh.logger)
-
-	dispersalSocket := operatorSocket.GetDispersalSocket()
-	dispersalOnline := checkIsOperatorOnline(dispersalSocket, 3, oh.logger)
+	sockets := map[string]string{
+		dispersalPortName:   operatorSocket.GetDispersalSocket(),
+		v2DispersalPortName: operatorSocket.GetV2DispersalSocket(),
+		retrievalPortName:   operatorSocket.GetRetrievalSocket(),
+	}
+	expectedServices := map[string]string{
+		dispersalPortName:   pb.Dispersal_ServiceDesc.ServiceName,
+		v2DispersalPortName: pbv2.Dispersal_ServiceDesc.ServiceName,
+		retrievalPortName:   pb.Retrieval_ServiceDesc.ServiceName,
+	}
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		ports = make(map[string]*OperatorPortReachability, len(sockets))
+	)
+	for name, socket := range sockets {
+		name, socket := name, socket
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reachability := probeOperatorPort(ctx, socket, expectedServices[name], oh.logger)
+			mu.Lock()
+			defer mu.Unlock()
+			ports[name] = reachability
+		}()
+	}
+	wg.Wait()
 
 	// Create the metadata regardless of online status
 	portCheckResponse := &OperatorPortCheckResponse{
-		OperatorId:      operatorId,
-		DispersalSocket: dispersalSocket,
-		RetrievalSocket: retrievalSocket,
-		DispersalOnline: dispersalOnline,
-		RetrievalOnline: retrievalOnline,
+		OperatorId:        operatorId,
+		DispersalSocket:   sockets[dispersalPortName],
+		RetrievalSocket:   sockets[retrievalPortName],
+		V2DispersalSocket: sockets[v2DispersalPortName],
+		DispersalOnline:   ports[dispersalPortName].Online,
+		RetrievalOnline:   ports[retrievalPortName].Online,
+		V2DispersalOnline: ports[v2DispersalPortName].Online,
+		Ports:             ports,
 	}
 
 	// Log the online status
@@ -109,6 +149,66 @@ func (oh *operatorHandler) getOperatorsStake(ctx context.Context, operatorId str
 	}, nil
 }
 
+// startHostInfoRefresh scans the operators host info every interval until the context is cancelled,
+// so that requests are served the latest report instantly
+func (oh *operatorHandler) startHostInfoRefresh(ctx context.Context, interval time.Duration) {
+	oh.hostInfoMu.Lock()
+	oh.hostInfoRefreshInterval = interval
+	oh.hostInfoMu.Unlock()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := oh.refreshHostInfo(ctx); err != nil {
+				oh.logger.Warn("failed to refresh operators host info", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// getOperatorsHostInfo returns the latest host info report, scanning the operators on demand if none is available yet
+func (oh *operatorHandler) getOperatorsHostInfo(ctx context.Context) (*SemverReportResponse, error) {
+	oh.hostInfoMu.RLock()
+	report := oh.hostInfoReport
+	oh.hostInfoMu.RUnlock()
+	if report != nil {
+		return report, nil
+	}
+	result, err, _ := oh.hostInfoScans.Do("hostInfo", func() (interface{}, error) {
+		return oh.refreshHostInfo(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*SemverReportResponse), nil
+}
+
+// latestHostInfo returns the latest host info report, or nil if the operators have not been scanned yet
+func (oh *operatorHandler) latestHostInfo() *SemverReportResponse {
+	oh.hostInfoMu.RLock()
+	defer oh.hostInfoMu.RUnlock()
+	return oh.hostInfoReport
+}
+
+func (oh *operatorHandler) refreshHostInfo(ctx context.Context) (*SemverReportResponse, error) {
+	report, err := oh.scanOperatorsHostInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	oh.hostInfoMu.Lock()
+	defer oh.hostInfoMu.Unlock()
+	if oh.hostInfoRefreshInterval > 0 {
+		report.StaleAt = uint64(time.Now().Add(oh.hostInfoRefreshInterval).Unix())
+	}
+	oh.hostInfoReport = report
+	return report, nil
+}
+
 func (s *operatorHandler) scanOperatorsHostInfo(ctx context.Context) (*SemverReportResponse, error) {
 	currentBlock, err := s.indexedChainState.GetCurrentBlockNumber()
 	if err != nil {
@@ -0,0 +1,84 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+// operatorPortProbeTimeout bounds the time to connect to an operator port, and to list its gRPC services
+const operatorPortProbeTimeout = 3 * time.Second
+
+// The keys of the operator ports in OperatorPortCheckResponse.Ports
+const (
+	dispersalPortName   = "dispersal"
+	v2DispersalPortName = "v2_dispersal"
+	retrievalPortName   = "retrieval"
+)
+
+// probeOperatorPort checks that the socket accepts connections and, if so, lists the gRPC services it serves to
+// tell whether the expected service is available on it
+func probeOperatorPort(ctx context.Context, socket string, expectedService string, logger logging.Logger) *OperatorPortReachability {
+	reachability := &OperatorPortReachability{
+		Socket:   socket,
+		Services: make([]string, 0),
+	}
+	reachability.Online = checkIsOperatorOnline(socket, int(operatorPortProbeTimeout/time.Second), logger)
+	if !reachability.Online {
+		return reachability
+	}
+
+	services, err := listGrpcServices(ctx, socket)
+	if err != nil {
+		reachability.Error = fmt.Sprintf("failed to list gRPC services: %v", err)
+		return reachability
+	}
+	reachability.Services = services
+	reachability.ServiceAvailable = slices.Contains(services, expectedService)
+	return reachability
+}
+
+// listGrpcServices lists the services of the gRPC server at the socket through server reflection, which nodes
+// register on all their ports
+func listGrpcServices(ctx context.Context, socket string) ([]string, error) {
+	conn, err := grpc.NewClient(socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, operatorPortProbeTimeout)
+	defer cancel()
+	stream, err := grpc_reflection_v1.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{ListServices: "*"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	_ = stream.CloseSend()
+	if errRes := res.GetErrorResponse(); errRes != nil {
+		return nil, fmt.Errorf("reflection error %d: %s", errRes.GetErrorCode(), errRes.GetErrorMessage())
+	}
+
+	services := make([]string, 0, len(res.GetListServicesResponse().GetService()))
+	for _, service := range res.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	sort.Strings(services)
+	return services, nil
+}
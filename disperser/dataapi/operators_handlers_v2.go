@@ -0,0 +1,555 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/gin-gonic/gin"
+)
+
+// FetchOperatorsStake godoc
+//
+//	@Summary	Operator stake distribution query
+//	@Tags		OperatorsStake
+//	@Produce	json,text/csv,application/x-ndjson
+//	@Param		operator_id	query		string	false	"Operator ID in hex string [default: all operators if unspecified]"
+//	@Param		format		query		string	false	"Response format: json, csv or ndjson, with one operator stake per quorum per row [default: json]"
+//	@Success	200			{object}	OperatorsStakeResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/stake [get]
+func (s *ServerV2) FetchOperatorsStake(c *gin.Context) {
+	operatorId := c.DefaultQuery("operator_id", "")
+	format, err := parseFormat(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+	s.logger.Info("getting operators stake distribution", "operatorId", operatorId)
+
+	operatorsStakeResponse, err := s.operatorsStake(c.Request.Context(), operatorId)
+	if err != nil {
+		errorResponse(c, fmt.Errorf("failed to get operator stake - %s", err))
+		return
+	}
+
+	setCacheMaxAge(c, s.cachePolicy.StakeMaxAge)
+	if format != formatJSON {
+		s.exportOperatorsStake(c, format, operatorsStakeResponse)
+		return
+	}
+	c.JSON(http.StatusOK, operatorsStakeResponse)
+}
+
+// FetchOperatorsStakeHistory godoc
+//
+//	@Summary	Daily snapshots of the operator stake distribution in each quorum
+//	@Tags		OperatorsStake
+//	@Produce	json
+//	@Param		start	query		string	false	"First day in UTC (2006-01-02) [default: 29 days before end]"
+//	@Param		end		query		string	false	"Last day in UTC (2006-01-02) [default: today]"
+//	@Success	200		{object}	OperatorsStakeHistoryResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/stake/history [get]
+func (s *ServerV2) FetchOperatorsStakeHistory(c *gin.Context) {
+	start, end, err := parseDayRange(c, defaultStakeHistoryDays, maxStakeHistoryDays)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+
+	response, err := s.stakeSnapshotter.getStakeHistory(c.Request.Context(), start, end)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, s.cachePolicy.StakeMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// FetchOperatorsStakeLeaderboard godoc
+//
+//	@Summary	Operators of a quorum ranked by stake, with their change in rank since the previous day
+//	@Tags		OperatorsStake
+//	@Produce	json
+//	@Param		quorum	query		int		false	"Quorum ID [default: 0]"
+//	@Param		cursor	query		string	false	"Pagination cursor (opaque string from previous response)"
+//	@Param		limit	query		int		false	"Maximum number of operators to return [default: 20; max: 1000]"
+//	@Success	200		{object}	OperatorsStakeLeaderboardResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/stake/leaderboard [get]
+func (s *ServerV2) FetchOperatorsStakeLeaderboard(c *gin.Context) {
+	quorum, err := strconv.ParseUint(c.DefaultQuery("quorum", "0"), 10, 8)
+	if err != nil {
+		invalidParamsErrorResponse(c, fmt.Errorf("invalid quorum ID: %w", err))
+		return
+	}
+	page, err := parsePageParams(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+	offset := 0
+	if page.cursor != "" {
+		offset, err = decodeOffsetCursor(page.cursor)
+		if err != nil {
+			invalidParamsErrorResponse(c, fmt.Errorf("failed to parse cursor: %w", err))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	stake, err := s.operatorsStake(ctx, "")
+	if err != nil {
+		errorResponse(c, fmt.Errorf("failed to get operator stake: %w", err))
+		return
+	}
+	quorumId := fmt.Sprintf("%d", quorum)
+	ranked, ok := stake.StakeRankedOperators[quorumId]
+	if !ok {
+		errorResponse(c, fmt.Errorf("%w: quorum %s has no operators", errNotFound, quorumId))
+		return
+	}
+	leaderboard, timestamp, err := s.stakeSnapshotter.getStakeLeaderboard(ctx, core.QuorumID(quorum), ranked, time.Now())
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	end := offset + page.limit
+	if offset > len(leaderboard) {
+		offset = len(leaderboard)
+	}
+	if end > len(leaderboard) {
+		end = len(leaderboard)
+	}
+	var paginationToken string
+	if end < len(leaderboard) {
+		paginationToken = encodeOffsetCursor(end)
+	}
+
+	response := &OperatorsStakeLeaderboardResponse{
+		QuorumId:                  quorumId,
+		PreviousSnapshotTimestamp: timestamp,
+		Operators:                 leaderboard[offset:end],
+		Pagination:                page.pagination(paginationToken),
+		PaginationToken:           paginationToken,
+	}
+	setCacheMaxAge(c, s.cachePolicy.StakeMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// parseDayRange parses the start and end days of a daily history, in UTC. The range ends today and spans
+// defaultDays if not given, and must span at most maxDays.
+func parseDayRange(c *gin.Context, defaultDays, maxDays int) (time.Time, time.Time, error) {
+	var err error
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	if c.Query("end") != "" {
+		end, err = time.Parse(time.DateOnly, c.Query("end"))
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("failed to parse end param: %w", err)
+		}
+	}
+	start := end.AddDate(0, 0, -(defaultDays - 1))
+	if c.Query("start") != "" {
+		start, err = time.Parse(time.DateOnly, c.Query("start"))
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("failed to parse start param: %w", err)
+		}
+	}
+	if start.After(end) {
+		return time.Time{}, time.Time{}, errors.New("start must not be after end")
+	}
+	if end.Sub(start) >= time.Duration(maxDays)*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("range must not exceed %d days", maxDays)
+	}
+	return start, end, nil
+}
+
+// FetchOperatorsNodeInfo godoc
+//
+//	@Summary	Active operator semver
+//	@Tags		OperatorsNodeInfo
+//	@Produce	json
+//	@Success	200	{object}	SemverReportResponse
+//	@Failure	500	{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/nodeinfo [get]
+func (s *ServerV2) FetchOperatorsNodeInfo(c *gin.Context) {
+	report, err := s.operatorHandler.getOperatorsHostInfo(c.Request.Context())
+	if err != nil {
+		s.logger.Error("failed to scan operators host info", "error", err)
+		errorResponse(c, err)
+		return
+	}
+	setCacheMaxAge(c, maxOperatorPortCheckAge*time.Second)
+	c.JSON(http.StatusOK, report)
+}
+
+// FetchOperatorsNodeInfoHistory godoc
+//
+//	@Summary	Daily snapshots of the node versions run by the operators
+//	@Tags		OperatorsNodeInfo
+//	@Produce	json
+//	@Param		start	query		string	false	"First day in UTC (2006-01-02) [default: 29 days before end]"
+//	@Param		end		query		string	false	"Last day in UTC (2006-01-02) [default: today]"
+//	@Success	200		{object}	OperatorsNodeInfoHistoryResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/nodeinfo/history [get]
+func (s *ServerV2) FetchOperatorsNodeInfoHistory(c *gin.Context) {
+	start, end, err := parseDayRange(c, defaultSemverHistoryDays, maxSemverHistoryDays)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+
+	response, err := s.semverSnapshotter.getSemverHistory(c.Request.Context(), start, end)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxOperatorsStakeAge*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// CheckOperatorsReachability godoc
+//
+//	@Summary	Operator node reachability check
+//	@Tags		OperatorsReachability
+//	@Produce	json
+//	@Param		operator_id	query		string	false	"Operator ID in hex string [default: all operators if unspecified]"
+//	@Success	200			{object}	OperatorPortCheckResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/reachability [get]
+func (s *ServerV2) CheckOperatorsReachability(c *gin.Context) {
+	operatorId := c.DefaultQuery("operator_id", "")
+	s.logger.Info("checking operator ports", "operatorId", operatorId)
+	portCheckResponse, err := s.operatorHandler.probeOperatorHosts(c.Request.Context(), operatorId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			err = errNotFound
+			s.logger.Warn("operator not found", "operatorId", operatorId)
+		} else {
+			s.logger.Error("operator port check failed", "error", err)
+		}
+		errorResponse(c, err)
+		return
+	}
+	setCacheMaxAge(c, s.cachePolicy.ReachabilityMaxAge)
+	c.JSON(http.StatusOK, portCheckResponse)
+}
+
+// CheckRelaysReachability godoc
+//
+//	@Summary	Relay reachability check
+//	@Tags		Relays
+//	@Produce	json
+//	@Success	200	{object}	RelaysReachabilityResponse
+//	@Failure	400	{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404	{object}	ErrorResponse	"error: Not found"
+//	@Failure	500	{object}	ErrorResponse	"error: Server error"
+//	@Router		/relays/reachability [get]
+func (s *ServerV2) CheckRelaysReachability(c *gin.Context) {
+	response, err := s.probeRelays(c.Request.Context())
+	if err != nil {
+		s.logger.Error("relay reachability check failed", "error", err)
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, s.cachePolicy.ReachabilityMaxAge)
+	c.JSON(http.StatusOK, response)
+}
+
+// FetchNonSigners godoc
+//
+//	@Summary	Fetch operators that failed to sign batches in the lookback window
+//	@Tags		Operators
+//	@Produce	json
+//	@Param		interval	query		int	false	"Lookback window in seconds [default: 3600; max: 2592000]"
+//	@Success	200			{object}	OperatorsNonSigningResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/nonsigners [get]
+func (s *ServerV2) FetchNonSigners(c *gin.Context) {
+	interval, err := strconv.ParseInt(c.DefaultQuery("interval", "3600"), 10, 64)
+	if err != nil || interval <= 0 {
+		invalidParamsErrorResponse(c, errors.New("interval must be a positive integer"))
+		return
+	}
+	if interval > int64(maxNonSignerInterval/time.Second) {
+		invalidParamsErrorResponse(c, fmt.Errorf("interval must be at most %d seconds", int64(maxNonSignerInterval/time.Second)))
+		return
+	}
+
+	end := time.Now()
+	start := end.Add(-time.Duration(interval) * time.Second)
+	response, err := s.getNonSigners(c.Request.Context(), uint64(start.UnixNano()), uint64(end.UnixNano()))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxNonSignerAge*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// getNonSigners counts, for every operator that failed to sign at least one batch attested
+// in (start, end), the number of batches it failed to sign in each quorum it was registered in
+// at the batch's reference block. The attestations are read nonSignerPageSize at a time.
+func (s *ServerV2) getNonSigners(ctx context.Context, start, end uint64) (*OperatorsNonSigningResponse, error) {
+	nonSigners := make(map[core.OperatorID]*OperatorNonSigningInfo)
+	totalBatches := 0
+	cursor := blobstore.AttestationFeedCursor{AttestedAt: start}
+	for {
+		attestations, lastCursor, err := s.blobMetadataStore.GetAttestationFeed(ctx, cursor, end, nonSignerPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch attestations: %w", err)
+		}
+		totalBatches += len(attestations)
+
+		for _, at := range attestations {
+			if len(at.NonSignerPubKeys) == 0 {
+				continue
+			}
+			state, err := s.getOperatorState(ctx, at.ReferenceBlockNumber, at.QuorumNumbers)
+			if err != nil {
+				return nil, err
+			}
+			for _, pubkey := range at.NonSignerPubKeys {
+				operatorID := pubkey.GetOperatorID()
+				info, ok := nonSigners[operatorID]
+				if !ok {
+					info = &OperatorNonSigningInfo{
+						OperatorId:            operatorID.Hex(),
+						QuorumUnsignedBatches: make(map[core.QuorumID]int),
+					}
+					nonSigners[operatorID] = info
+				}
+				info.TotalUnsignedBatches++
+				for _, q := range at.QuorumNumbers {
+					if _, registered := state.Operators[q][operatorID]; registered {
+						info.QuorumUnsignedBatches[q]++
+					}
+				}
+			}
+		}
+
+		if len(attestations) < nonSignerPageSize {
+			break
+		}
+		cursor = *lastCursor
+	}
+
+	result := make([]*OperatorNonSigningInfo, 0, len(nonSigners))
+	for _, info := range nonSigners {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalUnsignedBatches != result[j].TotalUnsignedBatches {
+			return result[i].TotalUnsignedBatches > result[j].TotalUnsignedBatches
+		}
+		return result[i].OperatorId < result[j].OperatorId
+	})
+
+	return &OperatorsNonSigningResponse{
+		TotalBatches: totalBatches,
+		NonSigners:   result,
+	}, nil
+}
+
+// FetchOperatorEventsHandler godoc
+//
+//	@Summary	Fetch operator registration, deregistration and churn events
+//	@Tags		Operators
+//	@Produce	json
+//	@Param		after	query		string	false	"Fetch events at or after this time in UTC (2006-01-02T15:04:05Z) [default: before-1h; max: 7 days before before]"
+//	@Param		before	query		string	false	"Fetch events at or before this time in UTC (2006-01-02T15:04:05Z) [default: now]"
+//	@Param		cursor	query		string	false	"Pagination cursor (opaque string from previous response)"
+//	@Param		limit	query		int		false	"Maximum number of events to return [default: 20; max: 1000]"
+//	@Success	200		{object}	OperatorEventsResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/events [get]
+func (s *ServerV2) FetchOperatorEventsHandler(c *gin.Context) {
+	after, before, page, err := parseFeedParams(c)
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+
+	// The first page pins the range end into the cursor, and later pages only query the subgraph from the last
+	// returned event onwards, so pages neither shift nor re-fetch the events already returned
+	start, end := uint64(after.Unix()), uint64(before.Unix())
+	var cursor *operatorEventCursor
+	if page.cursor != "" {
+		cursor, err = decodeOperatorEventCursor(page.cursor)
+		if err != nil {
+			invalidParamsErrorResponse(c, fmt.Errorf("failed to parse cursor: %w", err))
+			return
+		}
+		start, end = cursor.BlockTimestamp, cursor.Before
+	}
+
+	events, err := s.subgraphClient.QueryOperatorRegistrationEvents(c.Request.Context(), start, end)
+	if err != nil {
+		errorResponse(c, fmt.Errorf("failed to fetch operator events: %w", err))
+		return
+	}
+
+	pageEvents := make([]*OperatorEvent, 0, page.limit)
+	var paginationToken string
+	for _, e := range events {
+		if cursor != nil && !cursor.before(e) {
+			continue
+		}
+		if len(pageEvents) == page.limit {
+			last := pageEvents[len(pageEvents)-1]
+			paginationToken = encodeOperatorEventCursor(&operatorEventCursor{
+				Before:          end,
+				BlockTimestamp:  last.BlockTimestamp,
+				BlockNumber:     last.BlockNumber,
+				TransactionHash: last.TransactionHash,
+				OperatorId:      last.OperatorId,
+			})
+			break
+		}
+		pageEvents = append(pageEvents, newOperatorEvent(e))
+	}
+
+	response := &OperatorEventsResponse{
+		Events:          pageEvents,
+		Pagination:      page.pagination(paginationToken),
+		PaginationToken: paginationToken,
+	}
+	setCacheMaxAge(c, maxDeregisteredOperatorAge*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// operatorEventCursor is the keyset cursor of the operator events: the end of the range fetched by the first page,
+// and the position of the last event returned
+type operatorEventCursor struct {
+	Before          uint64
+	BlockTimestamp  uint64
+	BlockNumber     uint64
+	TransactionHash string
+	OperatorId      string
+}
+
+// before reports whether the cursor position is before the event, in the order of QueryOperatorRegistrationEvents
+func (c *operatorEventCursor) before(e *OperatorRegistrationEvent) bool {
+	if c.BlockNumber != e.Operator.BlockNumber {
+		return c.BlockNumber < e.Operator.BlockNumber
+	}
+	if c.TransactionHash != e.Operator.TransactionHash {
+		return c.TransactionHash < e.Operator.TransactionHash
+	}
+	return c.OperatorId < e.Operator.OperatorId
+}
+
+func decodeOperatorEventCursor(token string) (*operatorEventCursor, error) {
+	decoded, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token: %w", err)
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 5 {
+		return nil, errors.New("invalid cursor")
+	}
+	cursor := &operatorEventCursor{TransactionHash: parts[3], OperatorId: parts[4]}
+	for i, field := range []*uint64{&cursor.Before, &cursor.BlockTimestamp, &cursor.BlockNumber} {
+		*field, err = strconv.ParseUint(parts[i], 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+	}
+	if cursor.BlockTimestamp > cursor.Before {
+		return nil, errors.New("invalid cursor")
+	}
+	return cursor, nil
+}
+
+func encodeOperatorEventCursor(cursor *operatorEventCursor) string {
+	key := fmt.Sprintf("%d:%d:%d:%s:%s", cursor.Before, cursor.BlockTimestamp, cursor.BlockNumber, cursor.TransactionHash, cursor.OperatorId)
+	return base64.URLEncoding.EncodeToString([]byte(key))
+}
+
+func newOperatorEvent(e *OperatorRegistrationEvent) *OperatorEvent {
+	event := &OperatorEvent{
+		EventType:       e.EventType,
+		OperatorId:      e.Operator.OperatorId,
+		OperatorAddress: e.Operator.Operator,
+		BlockNumber:     e.Operator.BlockNumber,
+		BlockTimestamp:  e.Operator.BlockTimestamp,
+		TransactionHash: e.Operator.TransactionHash,
+	}
+	for _, churned := range e.ChurnedOperators {
+		event.ChurnedOperatorIds = append(event.ChurnedOperatorIds, churned.OperatorId)
+	}
+	return event
+}
+
+// FetchOperatorSigningRateHandler godoc
+//
+//	@Summary	Fetch signing rates of an operator over rolling windows (1d, 7d and 30d)
+//	@Tags		Operators
+//	@Produce	json
+//	@Param		operator_id	path		string	true	"Operator ID in hex string"
+//	@Success	200			{object}	OperatorSigningRateResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/{operator_id}/signing-rate [get]
+func (s *ServerV2) FetchOperatorSigningRateHandler(c *gin.Context) {
+	operatorID, err := core.OperatorIDFromHex(c.Param("operator_id"))
+	if err != nil {
+		invalidParamsErrorResponse(c, errors.New("invalid operator id"))
+		return
+	}
+	rates, err := s.signingRateAggregator.getSigningRates(c.Request.Context(), operatorID, time.Now())
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	response := &OperatorSigningRateResponse{
+		OperatorId:   operatorID.Hex(),
+		SigningRates: rates,
+	}
+	setCacheMaxAge(c, maxNonSignerAge*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// getOperatorState returns the state of the operators of the quorums at the reference block, caching it across requests
+func (s *ServerV2) getOperatorState(ctx context.Context, referenceBlockNumber uint64, quorums []core.QuorumID) (*core.OperatorState, error) {
+	key := fmt.Sprintf("%d/%v", referenceBlockNumber, quorums)
+	if state, ok := s.operatorStateCache.Get(key); ok {
+		return state, nil
+	}
+	state, err := s.chainState.GetOperatorState(ctx, uint(referenceBlockNumber), quorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", referenceBlockNumber, err)
+	}
+	s.operatorStateCache.Add(key, state)
+	return state, nil
+}
@@ -0,0 +1,62 @@
+package dataapi
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 20
+	// maxCursorLength bounds the cursor param; cursors handed out by the server are far shorter
+	maxCursorLength = 512
+)
+
+// pageParams are the cursor and limit query params shared by the list endpoints
+type pageParams struct {
+	cursor string
+	limit  int
+}
+
+// parsePageParams parses and validates the cursor and limit query params
+func parsePageParams(c *gin.Context) (pageParams, error) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if err != nil || limit <= 0 || limit > maxBlobFeedLimit {
+		return pageParams{}, fmt.Errorf("limit must be an integer between 1 and %d", maxBlobFeedLimit)
+	}
+	cursor := c.Query("cursor")
+	if len(cursor) > maxCursorLength {
+		return pageParams{}, fmt.Errorf("cursor must be at most %d characters", maxCursorLength)
+	}
+	return pageParams{cursor: cursor, limit: limit}, nil
+}
+
+// pagination returns the pagination of the page fetched with these params; nextToken is empty if it's the last page
+func (p pageParams) pagination(nextToken string) Pagination {
+	return Pagination{
+		Cursor:    p.cursor,
+		Limit:     p.limit,
+		HasMore:   nextToken != "",
+		NextToken: nextToken,
+	}
+}
+
+// decodeOffsetCursor decodes the cursor of a list paginated by offset
+func decodeOffsetCursor(token string) (int, error) {
+	decoded, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode token: %w", err)
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid offset")
+	}
+	return offset, nil
+}
+
+func encodeOffsetCursor(offset int) string {
+	return base64.URLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
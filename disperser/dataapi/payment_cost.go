@@ -0,0 +1,154 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/gin-gonic/gin"
+)
+
+// maxCostEstimateBlobSize bounds the blob size of a cost estimate to the max size of a v2 blob
+const maxCostEstimateBlobSize = 16 * 1024 * 1024
+
+// paymentParams are the payment vault params a dispersal is charged with
+type paymentParams struct {
+	minNumSymbols     uint32
+	pricePerSymbol    uint32
+	reservationWindow uint32
+}
+
+// getPaymentParams reads the payment params from the payment vault. They are rarely updated, so they're cached like
+// other expensive queries.
+func (s *ServerV2) getPaymentParams(ctx context.Context) (*paymentParams, error) {
+	return cachedQuery(ctx, s.queryCache, "paymentParams", func(ctx context.Context) (*paymentParams, error) {
+		minNumSymbols, err := s.chainReader.GetMinNumSymbols(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get min number of symbols: %w", err)
+		}
+		pricePerSymbol, err := s.chainReader.GetPricePerSymbol(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get price per symbol: %w", err)
+		}
+		reservationWindow, err := s.chainReader.GetReservationWindow(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reservation window: %w", err)
+		}
+		return &paymentParams{
+			minNumSymbols:     minNumSymbols,
+			pricePerSymbol:    pricePerSymbol,
+			reservationWindow: reservationWindow,
+		}, nil
+	})
+}
+
+// estimateDispersalCost estimates the cost of dispersing a blob of the given size to the quorums, which default to
+// the required quorums. It's charged the way the disperser meters requests: the blob length is padded to a power of 2
+// symbols, then rounded up to a multiple of the min number of symbols.
+func (s *ServerV2) estimateDispersalCost(ctx context.Context, blobSize uint64, quorums []core.QuorumID) (*DispersalCostEstimate, error) {
+	blockNumber, err := s.chainReader.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	// On-demand dispersals are limited to the required quorums
+	requiredQuorums, err := s.chainReader.GetRequiredQuorumNumbers(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required quorums: %w", err)
+	}
+	if len(quorums) == 0 {
+		quorums = requiredQuorums
+	} else {
+		quorumCount, err := s.chainReader.GetQuorumCount(ctx, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quorum count: %w", err)
+		}
+		for _, q := range quorums {
+			if q >= quorumCount {
+				return nil, fmt.Errorf("%w: quorum %d is not registered", errNotFound, q)
+			}
+		}
+	}
+	params, err := s.getPaymentParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	numSymbols := uint64(encoding.GetBlobLengthPowerOf2(uint(blobSize)))
+	minNumSymbols := uint64(params.minNumSymbols)
+	symbolsCharged := numSymbols
+	if minNumSymbols > 0 {
+		symbolsCharged = encoding.RoundUpDivide(numSymbols, minNumSymbols) * minNumSymbols
+	}
+
+	onDemandAllowed := true
+	for _, q := range quorums {
+		if !slices.Contains(requiredQuorums, q) {
+			onDemandAllowed = false
+		}
+	}
+	var symbolsPerSecond uint64
+	if params.reservationWindow > 0 {
+		symbolsPerSecond = encoding.RoundUpDivide(symbolsCharged, uint64(params.reservationWindow))
+	}
+
+	return &DispersalCostEstimate{
+		BlobSize:       blobSize,
+		QuorumNumbers:  quorums,
+		NumSymbols:     numSymbols,
+		SymbolsCharged: symbolsCharged,
+		OnDemand: &OnDemandCostEstimate{
+			Allowed:        onDemandAllowed,
+			PricePerSymbol: params.pricePerSymbol,
+			Payment:        new(big.Int).Mul(new(big.Int).SetUint64(symbolsCharged), big.NewInt(int64(params.pricePerSymbol))),
+		},
+		Reservation: &ReservationCostEstimate{
+			ReservationWindow: params.reservationWindow,
+			SymbolsPerSecond:  symbolsPerSecond,
+		},
+	}, nil
+}
+
+// EstimateDispersalCostHandler godoc
+//
+//	@Summary	Estimate the cost of dispersing a blob, on demand or with a reservation, from the on-chain payment params
+//	@Tags		Payments
+//	@Produce	json
+//	@Param		blob_size	query		int		true	"Size of the blob in bytes [max: 16MiB]"
+//	@Param		quorums		query		string	false	"Comma-separated quorum IDs to disperse to [default: the required quorums]"
+//	@Success	200			{object}	DispersalCostEstimate
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/payments/cost [get]
+func (s *ServerV2) EstimateDispersalCostHandler(c *gin.Context) {
+	blobSize, err := strconv.ParseUint(c.Query("blob_size"), 10, 64)
+	if err != nil || blobSize == 0 || blobSize > maxCostEstimateBlobSize {
+		invalidParamsErrorResponse(c, fmt.Errorf("blob_size must be an integer between 1 and %d", maxCostEstimateBlobSize))
+		return
+	}
+	quorumSet, err := parseQuorumsFilter(c.Query("quorums"))
+	if err != nil {
+		invalidParamsErrorResponse(c, err)
+		return
+	}
+	quorums := make([]core.QuorumID, 0, len(quorumSet))
+	for q := range quorumSet {
+		quorums = append(quorums, q)
+	}
+	slices.Sort(quorums)
+
+	estimate, err := s.estimateDispersalCost(c.Request.Context(), blobSize, quorums)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	setCacheMaxAge(c, maxMetricAage*time.Second)
+	c.JSON(http.StatusOK, estimate)
+}
@@ -13,7 +13,7 @@ import (
 
 var (
 	clientOnce sync.Once
-	apiIntance *prometheusApi
+	apiIntance Api
 )
 
 type Api interface {
@@ -26,27 +26,42 @@ type prometheusApi struct {
 
 var _ Api = (*prometheusApi)(nil)
 
-func NewApi(config Config) (*prometheusApi, error) {
+// NewApi returns the Api querying the Prometheus server in config, retrying failed queries and falling back to
+// the fallback server if one is configured
+func NewApi(config Config) (Api, error) {
 	var err error
 	clientOnce.Do(func() {
-		roundTripper := promconfig.NewBasicAuthRoundTripper(config.Username, promconfig.Secret(config.Secret), "", "", api.DefaultRoundTripper)
-		client, errN := api.NewClient(api.Config{
-			Address:      config.ServerURL,
-			RoundTripper: roundTripper,
-		})
+		primary, errN := newPrometheusApi(config.ServerURL, config)
 		if errN != nil {
 			err = errN
 			return
 		}
-		v1api := v1.NewAPI(client)
-		apiIntance = &prometheusApi{
-			api: v1api,
+		var fallback Api
+		if config.FallbackServerURL != "" {
+			fallback, errN = newPrometheusApi(config.FallbackServerURL, config)
+			if errN != nil {
+				err = errN
+				return
+			}
 		}
+		apiIntance = NewRetryingApi(primary, fallback, config)
 	})
 
 	return apiIntance, err
 }
 
+func newPrometheusApi(address string, config Config) (*prometheusApi, error) {
+	roundTripper := promconfig.NewBasicAuthRoundTripper(config.Username, promconfig.Secret(config.Secret), "", "", api.DefaultRoundTripper)
+	client, err := api.NewClient(api.Config{
+		Address:      address,
+		RoundTripper: roundTripper,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &prometheusApi{api: v1.NewAPI(client)}, nil
+}
+
 func (p *prometheusApi) QueryRange(
 	ctx context.Context,
 	query string,
@@ -1,8 +1,20 @@
 package prometheus
 
+import "time"
+
 type Config struct {
 	ServerURL string
 	Username  string
 	Secret    string
 	Cluster   string
+
+	// FallbackServerURL is the url of a Prometheus server queried when the primary one fails; empty disables it.
+	// It is queried with the same credentials as the primary server.
+	FallbackServerURL string
+	// QueryTimeout bounds each query attempt; 0 disables the timeout
+	QueryTimeout time.Duration
+	// MaxRetries is the number of times a failed query is retried
+	MaxRetries int
+	// RetryBackoff is the base delay before a retry, doubled on each retry and jittered
+	RetryBackoff time.Duration
 }
@@ -0,0 +1,105 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// retryingApi is an Api which bounds each query attempt by a timeout, and retries failed queries with jittered
+// exponential backoff. Each attempt queries the primary server, then the fallback server if the primary one failed.
+type retryingApi struct {
+	primary  Api
+	fallback Api
+
+	queryTimeout time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+var _ Api = (*retryingApi)(nil)
+
+// NewRetryingApi returns an Api querying primary, and fallback when primary fails. fallback may be nil.
+func NewRetryingApi(primary Api, fallback Api, config Config) Api {
+	return &retryingApi{
+		primary:      primary,
+		fallback:     fallback,
+		queryTimeout: config.QueryTimeout,
+		maxRetries:   config.MaxRetries,
+		retryBackoff: config.RetryBackoff,
+	}
+}
+
+func (r *retryingApi) QueryRange(
+	ctx context.Context,
+	query string,
+	start time.Time,
+	end time.Time,
+	step time.Duration,
+) (model.Value, v1.Warnings, error) {
+	apis := []Api{r.primary}
+	if r.fallback != nil {
+		apis = append(apis, r.fallback)
+	}
+
+	var err error
+	for attempt := 0; attempt <= r.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, nil, errors.Join(err, ctx.Err())
+			case <-time.After(r.backoff(attempt)):
+			}
+		}
+		for _, api := range apis {
+			var (
+				value    model.Value
+				warnings v1.Warnings
+			)
+			value, warnings, err = r.queryRange(ctx, api, query, start, end, step)
+			if err == nil {
+				return value, warnings, nil
+			}
+			if !retryable(err) || ctx.Err() != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return nil, nil, err
+}
+
+func (r *retryingApi) queryRange(
+	ctx context.Context,
+	api Api,
+	query string,
+	start time.Time,
+	end time.Time,
+	step time.Duration,
+) (model.Value, v1.Warnings, error) {
+	if r.queryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.queryTimeout)
+		defer cancel()
+	}
+	return api.QueryRange(ctx, query, start, end, step)
+}
+
+// backoff returns the delay before the given retry: the base backoff doubled on each retry, scaled by a random
+// factor in [0.5, 1.5) so that concurrent queries don't retry in lockstep
+func (r *retryingApi) backoff(attempt int) time.Duration {
+	backoff := r.retryBackoff << (attempt - 1)
+	return time.Duration(float64(backoff) * (0.5 + rand.Float64()))
+}
+
+// retryable returns false for errors the server will return again, such as a malformed query
+func retryable(err error) bool {
+	var apiErr *v1.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Type != v1.ErrBadData
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package prometheus_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeApi answers queries with the result of fn, counting the calls
+type fakeApi struct {
+	calls atomic.Int32
+	fn    func(ctx context.Context, call int32) (model.Value, error)
+}
+
+func (f *fakeApi) QueryRange(ctx context.Context, query string, start time.Time, end time.Time, step time.Duration) (model.Value, v1.Warnings, error) {
+	value, err := f.fn(ctx, f.calls.Add(1))
+	return value, nil, err
+}
+
+var (
+	result = model.Matrix{}
+	config = prometheus.Config{
+		QueryTimeout: 50 * time.Millisecond,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}
+)
+
+func query(api prometheus.Api) (model.Value, error) {
+	value, _, err := api.QueryRange(context.Background(), "up", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+	return value, err
+}
+
+func failing(err error) *fakeApi {
+	return &fakeApi{fn: func(context.Context, int32) (model.Value, error) { return nil, err }}
+}
+
+func TestRetryingApi(t *testing.T) {
+	t.Run("retries until success", func(t *testing.T) {
+		primary := &fakeApi{fn: func(_ context.Context, call int32) (model.Value, error) {
+			if call < 3 {
+				return nil, errors.New("unavailable")
+			}
+			return result, nil
+		}}
+		value, err := query(prometheus.NewRetryingApi(primary, nil, config))
+		require.NoError(t, err)
+		assert.Equal(t, result, value)
+		assert.Equal(t, int32(3), primary.calls.Load())
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		primary := failing(errors.New("unavailable"))
+		_, err := query(prometheus.NewRetryingApi(primary, nil, config))
+		assert.EqualError(t, err, "unavailable")
+		assert.Equal(t, int32(3), primary.calls.Load())
+	})
+
+	t.Run("does not retry bad queries", func(t *testing.T) {
+		primary := failing(&v1.Error{Type: v1.ErrBadData, Msg: "parse error"})
+		fallback := failing(errors.New("unreachable"))
+		_, err := query(prometheus.NewRetryingApi(primary, fallback, config))
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), primary.calls.Load())
+		assert.Equal(t, int32(0), fallback.calls.Load())
+	})
+
+	t.Run("falls back when the primary is slow", func(t *testing.T) {
+		primary := &fakeApi{fn: func(ctx context.Context, _ int32) (model.Value, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}}
+		fallback := &fakeApi{fn: func(context.Context, int32) (model.Value, error) { return result, nil }}
+		value, err := query(prometheus.NewRetryingApi(primary, fallback, config))
+		require.NoError(t, err)
+		assert.Equal(t, result, value)
+		assert.Equal(t, int32(1), primary.calls.Load())
+		assert.Equal(t, int32(1), fallback.calls.Load())
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		primary := failing(errors.New("unavailable"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, err := prometheus.NewRetryingApi(primary, nil, config).QueryRange(ctx, "up", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), primary.calls.Load())
+	})
+}
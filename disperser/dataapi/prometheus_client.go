@@ -18,6 +18,8 @@ type (
 	PrometheusClient interface {
 		QueryDisperserBlobSizeBytesPerSecond(ctx context.Context, start time.Time, end time.Time) (*PrometheusResult, error)
 		QueryDisperserAvgThroughputBlobSizeBytes(ctx context.Context, start time.Time, end time.Time, windowSizeInSec uint16) (*PrometheusResult, error)
+		QueryDisperserThroughputTimeseries(ctx context.Context, start time.Time, end time.Time, resolution time.Duration) (*PrometheusResult, error)
+		QueryStaleBlobsTotal(ctx context.Context, start time.Time, end time.Time, action string) (*PrometheusResult, error)
 	}
 
 	PrometheusResultValues struct {
@@ -51,14 +53,31 @@ func (pc *prometheusClient) QueryDisperserAvgThroughputBlobSizeBytes(ctx context
 	return pc.queryRange(ctx, query, start, end)
 }
 
+// QueryDisperserThroughputTimeseries returns the throughput in bytes/sec with one data point per resolution,
+// where each data point is the rate over the preceding resolution.
+func (pc *prometheusClient) QueryDisperserThroughputTimeseries(ctx context.Context, start time.Time, end time.Time, resolution time.Duration) (*PrometheusResult, error) {
+	query := fmt.Sprintf("sum(rate(eigenda_batcher_blobs_total{state=\"confirmed\",data=\"size\",cluster=\"%s\"}[%ds]))", pc.cluster, int64(resolution.Seconds()))
+	return pc.queryRangeWithStep(ctx, query, start, end, resolution)
+}
+
+// QueryStaleBlobsTotal returns the number of stale blobs the controller handled with the given action
+// (requeued or failed) since it started.
+func (pc *prometheusClient) QueryStaleBlobsTotal(ctx context.Context, start time.Time, end time.Time, action string) (*PrometheusResult, error) {
+	query := fmt.Sprintf("sum(eigenda_stale_blob_sweeper_blobs_total{action=\"%s\",cluster=\"%s\"})", action, pc.cluster)
+	return pc.queryRange(ctx, query, start, end)
+}
+
 func (pc *prometheusClient) queryRange(ctx context.Context, query string, start time.Time, end time.Time) (*PrometheusResult, error) {
 	numSecondsInTimeRange := end.Sub(start).Seconds()
 	step := uint64(numSecondsInTimeRange / maxNumOfDataPoints)
 	if step < 1 {
 		step = 1
 	}
+	return pc.queryRangeWithStep(ctx, query, start, end, time.Duration(step)*time.Second)
+}
 
-	v, _, err := pc.api.QueryRange(ctx, query, start, end, time.Duration(step)*time.Second)
+func (pc *prometheusClient) queryRangeWithStep(ctx context.Context, query string, start time.Time, end time.Time, step time.Duration) (*PrometheusResult, error) {
+	v, _, err := pc.api.QueryRange(ctx, query, start, end, step)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,156 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// defaultQueryCacheSize is the number of query results cached if the size is not configured
+	defaultQueryCacheSize = 1024
+	// cachedQueryTimeout bounds the time of a query shared by concurrent requests
+	cachedQueryTimeout = 30 * time.Second
+)
+
+// queryCache caches the results of expensive queries for a TTL, and coalesces concurrent queries with the same key
+// into a single call, so that a burst of requests doesn't multiply the load on the subgraph and the chain.
+type queryCache struct {
+	results *expirable.LRU[string, interface{}]
+	group   singleflight.Group
+}
+
+func newQueryCache(size int, ttl time.Duration) *queryCache {
+	if size <= 0 {
+		size = defaultQueryCacheSize
+	}
+	return &queryCache{
+		results: expirable.NewLRU[string, interface{}](size, nil, ttl),
+	}
+}
+
+// cachedQuery returns the cached result of the query with the given key, or runs the query and caches its result.
+// Errors are not cached. If the cache is nil, the query is always run.
+func cachedQuery[T any](ctx context.Context, c *queryCache, key string, query func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if c == nil {
+		return query(ctx)
+	}
+	if result, ok := c.results.Get(key); ok {
+		return result.(T), nil
+	}
+
+	resultChan := c.group.DoChan(key, func() (interface{}, error) {
+		// The query is shared by all requests waiting on it, so it isn't canceled when the request that started it is
+		queryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cachedQueryTimeout)
+		defer cancel()
+		result, err := query(queryCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.results.Add(key, result)
+		return result, nil
+	})
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-resultChan:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
+
+// operatorsStake returns the stake distribution of the operators, cached
+func (s *ServerV2) operatorsStake(ctx context.Context, operatorId string) (*OperatorsStakeResponse, error) {
+	return cachedQuery(ctx, s.queryCache, "operatorsStake:"+operatorId, func(ctx context.Context) (*OperatorsStakeResponse, error) {
+		return s.operatorHandler.getOperatorsStake(ctx, operatorId)
+	})
+}
+
+// cachedSubgraphClient is a SubgraphClient which caches query results
+type cachedSubgraphClient struct {
+	client SubgraphClient
+	cache  *queryCache
+}
+
+var _ SubgraphClient = (*cachedSubgraphClient)(nil)
+
+func newCachedSubgraphClient(client SubgraphClient, cache *queryCache) *cachedSubgraphClient {
+	return &cachedSubgraphClient{client: client, cache: cache}
+}
+
+func (c *cachedSubgraphClient) QueryBatchesWithLimit(ctx context.Context, limit, skip int) ([]*Batch, error) {
+	key := fmt.Sprintf("QueryBatchesWithLimit:%d:%d", limit, skip)
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) ([]*Batch, error) {
+		return c.client.QueryBatchesWithLimit(ctx, limit, skip)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryOperatorsWithLimit(ctx context.Context, limit int) ([]*Operator, error) {
+	key := fmt.Sprintf("QueryOperatorsWithLimit:%d", limit)
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) ([]*Operator, error) {
+		return c.client.QueryOperatorsWithLimit(ctx, limit)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) (map[string]int, error) {
+	key := fmt.Sprintf("QueryBatchNonSigningOperatorIdsInInterval:%d", intervalSeconds)
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) (map[string]int, error) {
+		return c.client.QueryBatchNonSigningOperatorIdsInInterval(ctx, intervalSeconds)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryBatchNonSigningInfoInInterval(ctx context.Context, startTime, endTime int64) ([]*BatchNonSigningInfo, error) {
+	key := fmt.Sprintf("QueryBatchNonSigningInfoInInterval:%d:%d", startTime, endTime)
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) ([]*BatchNonSigningInfo, error) {
+		return c.client.QueryBatchNonSigningInfoInInterval(ctx, startTime, endTime)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryOperatorQuorumEvent(ctx context.Context, startBlock, endBlock uint32) (*OperatorQuorumEvents, error) {
+	key := fmt.Sprintf("QueryOperatorQuorumEvent:%d:%d", startBlock, endBlock)
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) (*OperatorQuorumEvents, error) {
+		return c.client.QueryOperatorQuorumEvent(ctx, startBlock, endBlock)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryIndexedOperatorsWithStateForTimeWindow(ctx context.Context, days int32, state OperatorState) (*IndexedQueriedOperatorInfo, error) {
+	key := fmt.Sprintf("QueryIndexedOperatorsWithStateForTimeWindow:%d:%d", days, state)
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) (*IndexedQueriedOperatorInfo, error) {
+		return c.client.QueryIndexedOperatorsWithStateForTimeWindow(ctx, days, state)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryOperatorInfoByOperatorId(ctx context.Context, operatorId string) (*core.IndexedOperatorInfo, error) {
+	key := "QueryOperatorInfoByOperatorId:" + operatorId
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) (*core.IndexedOperatorInfo, error) {
+		return c.client.QueryOperatorInfoByOperatorId(ctx, operatorId)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryOperatorEjectionsForTimeWindow(ctx context.Context, days int32, operatorId string, first uint, skip uint) ([]*QueriedOperatorEjections, error) {
+	key := fmt.Sprintf("QueryOperatorEjectionsForTimeWindow:%d:%s:%d:%d", days, operatorId, first, skip)
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) ([]*QueriedOperatorEjections, error) {
+		return c.client.QueryOperatorEjectionsForTimeWindow(ctx, days, operatorId, first, skip)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryOperatorRegistrationEvents(ctx context.Context, startTimestamp, endTimestamp uint64) ([]*OperatorRegistrationEvent, error) {
+	key := fmt.Sprintf("QueryOperatorRegistrationEvents:%d:%d", startTimestamp, endTimestamp)
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) ([]*OperatorRegistrationEvent, error) {
+		return c.client.QueryOperatorRegistrationEvents(ctx, startTimestamp, endTimestamp)
+	})
+}
+
+func (c *cachedSubgraphClient) QueryOperatorRegistrationEventsByTransactionHash(ctx context.Context, txHash string) ([]*OperatorRegistrationEvent, error) {
+	key := "QueryOperatorRegistrationEventsByTransactionHash:" + txHash
+	return cachedQuery(ctx, c.cache, key, func(ctx context.Context) ([]*OperatorRegistrationEvent, error) {
+		return c.client.QueryOperatorRegistrationEventsByTransactionHash(ctx, txHash)
+	})
+}
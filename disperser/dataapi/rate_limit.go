@@ -0,0 +1,85 @@
+package dataapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/apikey"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// The rate limiter takes an integer rate, so a request is counted as requestRateMultiplier units and
+	// the rates in requests/sec are scaled by the same amount.
+	requestRateMultiplier = 1e6
+
+	ipRequesterPrefix     = "ip:"
+	apiKeyRequesterPrefix = "apikey:"
+)
+
+var errRateLimited = errors.New("request ratelimited")
+
+// RateLimit returns a middleware which limits the rate of requests per client IP and, for requests carrying an
+// API key, per key. Requests over the limit are rejected with 429 and a Retry-After header.
+func (s *ServerV2) RateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		params := make([]common.RequestParams, 0, 2)
+		if s.ipRequestRate > 0 {
+			params = append(params, common.RequestParams{
+				RequesterID: ipRequesterPrefix + c.ClientIP(),
+				BlobSize:    requestRateMultiplier,
+				Rate:        s.ipRequestRate * requestRateMultiplier,
+				Info:        "client IP",
+			})
+		}
+		if key := requestAPIKey(c); key != "" && s.apiKeyRequestRate > 0 {
+			params = append(params, common.RequestParams{
+				RequesterID: apiKeyRequesterPrefix + apikey.HashKey(key),
+				BlobSize:    requestRateMultiplier,
+				Rate:        s.apiKeyRequestRate * requestRateMultiplier,
+				Info:        "API key",
+			})
+		}
+		if len(params) == 0 {
+			c.Next()
+			return
+		}
+
+		allowed, param, err := s.ratelimiter.AllowRequest(c.Request.Context(), params)
+		if err != nil {
+			errorResponse(c, fmt.Errorf("ratelimiter error: %w", err))
+			c.Abort()
+			return
+		}
+		if !allowed {
+			s.metrics.IncrementRateLimitedRequestNum(c.FullPath())
+			c.Writer.Header().Set("Retry-After", strconv.Itoa(s.retryAfterSeconds(param.Rate)))
+			err := errRateLimited
+			if info, ok := param.Info.(string); ok {
+				err = fmt.Errorf("%w: %s", errRateLimited, info)
+			}
+			abortWithError(c, http.StatusTooManyRequests, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// retryAfterSeconds returns the time it takes for the emptiest bucket to refill the cost of one request
+func (s *ServerV2) retryAfterSeconds(rate common.RateParam) int {
+	minMultiplier := float32(1)
+	for i, m := range s.rateLimiterParams.Multipliers {
+		if i == 0 || m < minMultiplier {
+			minMultiplier = m
+		}
+	}
+	seconds := math.Ceil(requestRateMultiplier / (float64(rate) * float64(minMultiplier)))
+	if seconds < 1 {
+		return 1
+	}
+	return int(seconds)
+}
@@ -0,0 +1,98 @@
+package dataapi
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/gammazero/workerpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// relayProbeTimeout bounds the time to connect to a relay and check its health
+const relayProbeTimeout = 3 * time.Second
+
+// probeRelays checks the reachability of every relay registered in the relay registry
+func (s *ServerV2) probeRelays(ctx context.Context) (*RelaysReachabilityResponse, error) {
+	relayURLs, err := s.chainReader.GetRelayURLs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch relay URLs: %w", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		response = &RelaysReachabilityResponse{
+			Relays: make([]*RelayReachability, 0, len(relayURLs)),
+		}
+		pool = workerpool.New(maxWorkerPoolSize)
+	)
+	for key, url := range relayURLs {
+		key, url := key, url
+		pool.Submit(func() {
+			reachability := probeRelay(ctx, key, url, s.relayUseSecureGrpc)
+			mu.Lock()
+			defer mu.Unlock()
+			response.Relays = append(response.Relays, reachability)
+		})
+	}
+	pool.StopWait()
+
+	sort.Slice(response.Relays, func(i, j int) bool {
+		return response.Relays[i].RelayKey < response.Relays[j].RelayKey
+	})
+	return response, nil
+}
+
+// probeRelay dials the relay and, once connected, checks its gRPC health service and reads the version it reports
+func probeRelay(ctx context.Context, key uint32, url string, useSecureGrpc bool) *RelayReachability {
+	reachability := &RelayReachability{
+		RelayKey:     key,
+		Url:          url,
+		HealthStatus: grpc_health_v1.HealthCheckResponse_UNKNOWN.String(),
+	}
+
+	creds := insecure.NewCredentials()
+	if useSecureGrpc {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		reachability.Error = err.Error()
+		return reachability
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, relayProbeTimeout)
+	defer cancel()
+	start := time.Now()
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if !conn.WaitForStateChange(ctx, state) {
+			reachability.Error = fmt.Sprintf("failed to connect within %v, last state %s", relayProbeTimeout, state)
+			return reachability
+		}
+	}
+	reachability.Reachable = true
+	reachability.DialLatencyMs = float64(time.Since(start)) / float64(time.Millisecond)
+
+	var header metadata.MD
+	health, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header))
+	if versions := header.Get(healthcheck.VersionHeader); len(versions) > 0 {
+		reachability.Version = versions[0]
+	}
+	if err != nil {
+		reachability.Error = fmt.Sprintf("health check failed: %v", err)
+		return reachability
+	}
+	reachability.HealthStatus = health.Status.String()
+	return reachability
+}
@@ -0,0 +1,28 @@
+package dataapi
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute is the route label of requests which match no route
+const unmatchedRoute = "unmatched"
+
+// RecordMetrics is a middleware which records the rate, errors and duration of the requests by route and status,
+// and the number of requests in flight.
+func (s *ServerV2) RecordMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s.metrics.InFlightRequests.Inc()
+		defer s.metrics.InFlightRequests.Dec()
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		s.metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
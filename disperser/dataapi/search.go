@@ -0,0 +1,220 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gammazero/workerpool"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	searchTypeBlob        = "blob"
+	searchTypeBatch       = "batch"
+	searchTypeOperator    = "operator"
+	searchTypeAccount     = "account"
+	searchTypeTransaction = "transaction"
+
+	// searchAccountBlobsLimit is the number of most recent blobs returned with a matching account
+	searchAccountBlobsLimit = 20
+)
+
+// searchLookup looks up the entity of one type matching a search, returning nil if there is none
+type searchLookup func(ctx context.Context) (*SearchResult, error)
+
+// SearchHandler godoc
+//
+//	@Summary	Search blobs, batches, operators, accounts and L1 transactions
+//	@Tags		Search
+//	@Produce	json
+//	@Param		q	query		string	true	"Blob key, batch header hash, operator ID, L1 transaction hash, or operator or account address, in hex string"
+//	@Success	200	{object}	SearchResponse
+//	@Failure	400	{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404	{object}	ErrorResponse	"error: Not found"
+//	@Failure	500	{object}	ErrorResponse	"error: Server error"
+//	@Router		/search [get]
+func (s *ServerV2) SearchHandler(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		invalidParamsErrorResponse(c, errors.New("search query is required"))
+		return
+	}
+	value, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(query, "0x"), "0X"))
+	if err != nil {
+		invalidParamsErrorResponse(c, fmt.Errorf("search query must be in hex: %w", err))
+		return
+	}
+
+	// The type of a value can't be told from its length alone: blob keys, batch header hashes, operator IDs and
+	// transaction hashes are all 32 bytes, and an address may be an operator or a disperser client account
+	var lookups []searchLookup
+	switch len(value) {
+	case 32:
+		var hash [32]byte
+		copy(hash[:], value)
+		lookups = []searchLookup{
+			func(ctx context.Context) (*SearchResult, error) { return s.searchBlob(ctx, hash) },
+			func(ctx context.Context) (*SearchResult, error) { return s.searchBatch(ctx, hash) },
+			func(ctx context.Context) (*SearchResult, error) { return s.searchOperatorByID(ctx, hash) },
+			func(ctx context.Context) (*SearchResult, error) { return s.searchTransaction(ctx, hash) },
+		}
+	case gethcommon.AddressLength:
+		address := gethcommon.BytesToAddress(value)
+		lookups = []searchLookup{
+			func(ctx context.Context) (*SearchResult, error) { return s.searchOperatorByAddress(ctx, address) },
+			func(ctx context.Context) (*SearchResult, error) { return s.searchAccount(ctx, address) },
+		}
+	default:
+		invalidParamsErrorResponse(c, fmt.Errorf("search query must be a 32-byte hash or ID, or a 20-byte address, got %d bytes", len(value)))
+		return
+	}
+
+	results, err := runSearchLookups(c.Request.Context(), lookups)
+	if err != nil {
+		errorResponse(c, fmt.Errorf("failed to search: %w", err))
+		return
+	}
+	if len(results) == 0 {
+		errorResponse(c, fmt.Errorf("%w: nothing matches %s", errNotFound, query))
+		return
+	}
+	setCacheMaxAge(c, maxFeedBlobsAge*time.Second)
+	c.JSON(http.StatusOK, &SearchResponse{
+		Query:   query,
+		Results: results,
+	})
+}
+
+// runSearchLookups runs the lookups concurrently, and returns their matches in the order of the lookups
+func runSearchLookups(ctx context.Context, lookups []searchLookup) ([]*SearchResult, error) {
+	var (
+		matches = make([]*SearchResult, len(lookups))
+		errs    = make([]error, len(lookups))
+		pool    = workerpool.New(maxWorkerPoolSize)
+	)
+	for i, lookup := range lookups {
+		i, lookup := i, lookup
+		pool.Submit(func() {
+			matches[i], errs[i] = lookup(ctx)
+		})
+	}
+	pool.StopWait()
+
+	results := make([]*SearchResult, 0, len(lookups))
+	for i, match := range matches {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if match != nil {
+			results = append(results, match)
+		}
+	}
+	return results, nil
+}
+
+func (s *ServerV2) searchBlob(ctx context.Context, blobKey [32]byte) (*SearchResult, error) {
+	blob, err := s.getBlob(ctx, blobKey)
+	if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob: %w", err)
+	}
+	return &SearchResult{Type: searchTypeBlob, Blob: blob}, nil
+}
+
+func (s *ServerV2) searchBatch(ctx context.Context, batchHeaderHash [32]byte) (*SearchResult, error) {
+	batch, err := s.getBatch(ctx, batchHeaderHash)
+	if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch batch: %w", err)
+	}
+	return &SearchResult{Type: searchTypeBatch, Batch: batch}, nil
+}
+
+func (s *ServerV2) searchOperatorByID(ctx context.Context, operatorId core.OperatorID) (*SearchResult, error) {
+	address, err := s.chainReader.OperatorIDToAddress(ctx, operatorId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch operator address: %w", err)
+	}
+	// Operators which never registered have the zero address
+	if address == (gethcommon.Address{}) {
+		return nil, nil
+	}
+	return newOperatorSearchResult(operatorId, address), nil
+}
+
+func (s *ServerV2) searchOperatorByAddress(ctx context.Context, address gethcommon.Address) (*SearchResult, error) {
+	operatorId, err := s.chainReader.OperatorAddressToID(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch operator ID: %w", err)
+	}
+	if operatorId == (core.OperatorID{}) {
+		return nil, nil
+	}
+	return newOperatorSearchResult(operatorId, address), nil
+}
+
+func newOperatorSearchResult(operatorId core.OperatorID, address gethcommon.Address) *SearchResult {
+	return &SearchResult{
+		Type: searchTypeOperator,
+		Operator: &OperatorSearchResult{
+			OperatorId:      operatorId.Hex(),
+			OperatorAddress: address.Hex(),
+		},
+	}
+}
+
+func (s *ServerV2) searchAccount(ctx context.Context, address gethcommon.Address) (*SearchResult, error) {
+	// Account IDs are stored as checksummed addresses
+	blobs, _, err := s.blobMetadataStore.GetBlobMetadataByAccountID(ctx, address.Hex(), nil, searchAccountBlobsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blobs of account: %w", err)
+	}
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	blobInfos, err := newBlobInfos(blobs)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResult{
+		Type: searchTypeAccount,
+		Account: &AccountSearchResult{
+			AccountId: address.Hex(),
+			Blobs:     blobInfos,
+		},
+	}, nil
+}
+
+func (s *ServerV2) searchTransaction(ctx context.Context, txHash [32]byte) (*SearchResult, error) {
+	hash := "0x" + hex.EncodeToString(txHash[:])
+	events, err := s.subgraphClient.QueryOperatorRegistrationEventsByTransactionHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch operator events of transaction: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	operatorEvents := make([]*OperatorEvent, len(events))
+	for i, e := range events {
+		operatorEvents[i] = newOperatorEvent(e)
+	}
+	return &SearchResult{
+		Type: searchTypeTransaction,
+		Transaction: &TransactionSearchResult{
+			TransactionHash: hash,
+			OperatorEvents:  operatorEvents,
+		},
+	}, nil
+}
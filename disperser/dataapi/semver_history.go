@@ -0,0 +1,147 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/semver"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// semverSnapshotPeriod is the granularity of the node version history; one snapshot is taken per period
+	semverSnapshotPeriod = 24 * time.Hour
+	// semverSnapshotCheckInterval is how often the snapshotter checks whether the current period has a snapshot
+	semverSnapshotCheckInterval = 10 * time.Minute
+	// defaultSemverHistoryDays is the number of daily snapshots returned when no start is given
+	defaultSemverHistoryDays = 30
+	// maxSemverHistoryDays is the max number of daily snapshots returned by a single request
+	maxSemverHistoryDays = 90
+)
+
+// semverSnapshotter records the node versions run by the operators once per day in the metadata store,
+// so that the adoption of a node release can be followed over time.
+type semverSnapshotter struct {
+	logger            logging.Logger
+	blobMetadataStore *blobstore.BlobMetadataStore
+	operatorHandler   *operatorHandler
+
+	// start of the period the last snapshot was recorded for; only accessed by the snapshotting loop
+	lastSnapshot time.Time
+}
+
+func newSemverSnapshotter(
+	logger logging.Logger,
+	blobMetadataStore *blobstore.BlobMetadataStore,
+	operatorHandler *operatorHandler,
+) *semverSnapshotter {
+	return &semverSnapshotter{
+		logger:            logger,
+		blobMetadataStore: blobMetadataStore,
+		operatorHandler:   operatorHandler,
+	}
+}
+
+// start records a snapshot for the current period every interval, if none was recorded yet, until the context is cancelled
+func (s *semverSnapshotter) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := s.snapshot(ctx, time.Now()); err != nil {
+				s.logger.Warn("failed to snapshot operator node versions", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// snapshot scans the node versions of the operators and records them for the period containing now.
+// The scan dials every operator, so it is skipped if another instance already recorded the period.
+func (s *semverSnapshotter) snapshot(ctx context.Context, now time.Time) error {
+	period := now.UTC().Truncate(semverSnapshotPeriod)
+	if period.Equal(s.lastSnapshot) {
+		return nil
+	}
+	timestamp := uint64(period.Unix())
+
+	existing, err := s.blobMetadataStore.GetSemverSnapshots(ctx, []uint64{timestamp})
+	if err != nil {
+		return fmt.Errorf("failed to get semver snapshot: %w", err)
+	}
+	if len(existing) == 0 {
+		report, err := s.operatorHandler.scanOperatorsHostInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to scan operator node versions: %w", err)
+		}
+		err = s.blobMetadataStore.PutSemverSnapshot(ctx, newSemverSnapshot(timestamp, report.Semver))
+		if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
+			return fmt.Errorf("failed to store semver snapshot: %w", err)
+		}
+	}
+
+	s.lastSnapshot = period
+	return nil
+}
+
+func newSemverSnapshot(timestamp uint64, semvers map[string]*semver.SemverMetrics) *commonv2.SemverSnapshot {
+	snapshot := &commonv2.SemverSnapshot{
+		Timestamp: timestamp,
+		Semvers:   make(map[string]*commonv2.SemverAdoption, len(semvers)),
+	}
+	for version, metrics := range semvers {
+		adoption := &commonv2.SemverAdoption{
+			Operators:             uint32(len(metrics.OperatorIds)),
+			QuorumStakePercentage: make(map[core.QuorumID]float64, len(metrics.QuorumStakePercentage)),
+		}
+		for quorum, stakePercentage := range metrics.QuorumStakePercentage {
+			adoption.QuorumStakePercentage[quorum] = stakePercentage
+		}
+		snapshot.Semvers[version] = adoption
+	}
+	return snapshot
+}
+
+// getSemverHistory returns the recorded daily snapshots for the days in the inclusive range [start, end]
+func (s *semverSnapshotter) getSemverHistory(ctx context.Context, start, end time.Time) (*OperatorsNodeInfoHistoryResponse, error) {
+	timestamps := make([]uint64, 0)
+	for day := start.UTC().Truncate(semverSnapshotPeriod); !day.After(end); day = day.Add(semverSnapshotPeriod) {
+		timestamps = append(timestamps, uint64(day.Unix()))
+	}
+	snapshots, err := s.blobMetadataStore.GetSemverSnapshots(ctx, timestamps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get semver snapshots: %w", err)
+	}
+
+	response := &OperatorsNodeInfoHistoryResponse{
+		Snapshots: make([]*SemverSnapshot, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		semvers := make(map[string]*SemverAdoption, len(snapshot.Semvers))
+		for version, adoption := range snapshot.Semvers {
+			stakePercentage := make(map[string]float64, len(adoption.QuorumStakePercentage))
+			for quorum, percentage := range adoption.QuorumStakePercentage {
+				stakePercentage[fmt.Sprintf("%d", quorum)] = percentage
+			}
+			semvers[version] = &SemverAdoption{
+				Operators:       adoption.Operators,
+				StakePercentage: stakePercentage,
+			}
+		}
+		response.Snapshots[i] = &SemverSnapshot{
+			Timestamp: snapshot.Timestamp,
+			Semvers:   semvers,
+		}
+	}
+	return response, nil
+}
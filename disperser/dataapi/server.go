@@ -53,6 +53,20 @@ const (
 	maxChurnerAvailabilityAge           = 3
 	maxBatcherAvailabilityAge           = 3
 	maxOperatorsStakeAge                = 300 // not expect the stake change to happen frequently
+	maxThroughputRollupsAge             = 300 // rollups of completed periods don't change
+
+	// Max number of items a single feed request can return
+	maxBlobFeedLimit = 1000
+	// Max number of blob keys a single lookup request can carry
+	maxBlobLookupKeys = 100
+
+	// Default time the v2 server waits for in-flight requests to complete on shutdown
+	defaultShutdownTimeout = 10 * time.Second
+
+	// Number of attestations the nonsigners endpoint reads per page
+	nonSignerPageSize = 1000
+	// Number of operator states the v2 server caches by reference block number and quorums
+	operatorStateCacheSize = 256
 )
 
 var errNotFound = errors.New("not found")
@@ -175,18 +189,41 @@ type (
 	}
 
 	OperatorPortCheckResponse struct {
-		OperatorId      string `json:"operator_id"`
-		DispersalSocket string `json:"dispersal_socket"`
-		RetrievalSocket string `json:"retrieval_socket"`
-		DispersalOnline bool   `json:"dispersal_online"`
-		RetrievalOnline bool   `json:"retrieval_online"`
+		OperatorId        string `json:"operator_id"`
+		DispersalSocket   string `json:"dispersal_socket"`
+		RetrievalSocket   string `json:"retrieval_socket"`
+		V2DispersalSocket string `json:"v2_dispersal_socket"`
+		DispersalOnline   bool   `json:"dispersal_online"`
+		RetrievalOnline   bool   `json:"retrieval_online"`
+		V2DispersalOnline bool   `json:"v2_dispersal_online"`
+		// Ports are the results of probing each port, keyed by "dispersal", "v2_dispersal" and "retrieval"
+		Ports map[string]*OperatorPortReachability `json:"ports"`
+	}
+	OperatorPortReachability struct {
+		Socket string `json:"socket"`
+		Online bool   `json:"online"`
+		// Services are the gRPC services the port lists through server reflection, if it's online
+		Services []string `json:"services"`
+		// ServiceAvailable is whether the port serves the gRPC service expected on it
+		ServiceAvailable bool   `json:"service_available"`
+		Error            string `json:"error,omitempty"`
 	}
 	SemverReportResponse struct {
 		Semver map[string]*semver.SemverMetrics `json:"semver"`
+		// StaleAt is the Unix time in seconds at which the report is due to be replaced by a new scan, set if
+		// operators are scanned in the background
+		StaleAt uint64 `json:"stale_at,omitempty"`
 	}
 
 	ErrorResponse struct {
+		// Error is the error message, kept for existing clients
 		Error string `json:"error"`
+		// Code identifies the type of error, which clients can branch on
+		Code      ErrorCode `json:"code"`
+		Message   string    `json:"message"`
+		Retryable bool      `json:"retryable"`
+		// Details holds extra information depending on the code, e.g. when a rate limited request may be retried
+		Details map[string]string `json:"details,omitempty"`
 	}
 
 	server struct {
@@ -1127,20 +1164,6 @@ func (s *server) FetchBatcherAvailability(c *gin.Context) {
 	})
 }
 
-func errorResponse(c *gin.Context, err error) {
-	_ = c.Error(err)
-	var code int
-	switch {
-	case errors.Is(err, errNotFound):
-		code = http.StatusNotFound
-	default:
-		code = http.StatusInternalServerError
-	}
-	c.JSON(code, ErrorResponse{
-		Error: err.Error(),
-	})
-}
-
 func run(logger logging.Logger, httpServer *http.Server) <-chan error {
 	errChan := make(chan error, 1)
 	ctx, stop := signal.NotifyContext(
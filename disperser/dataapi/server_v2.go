@@ -1,23 +1,32 @@
 package dataapi
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"os"
-	"strconv"
-	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/apikey"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/docs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/logger"
 	"github.com/gin-gonic/gin"
-	"github.com/prometheus/client_golang/prometheus"
+	"github.com/graph-gophers/graphql-go"
+	graphqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	swaggerfiles "github.com/swaggo/files"
 	ginswagger "github.com/swaggo/gin-swagger"
 )
@@ -35,8 +44,65 @@ type (
 		BlobSizeBytes uint64             `json:"blob_size_bytes"`
 	}
 
+	BlobLookupRequest struct {
+		BlobKeys []string `json:"blob_keys"`
+	}
+
+	// BlobLookupResult holds either the blob, or the error looking it up
+	BlobLookupResult struct {
+		BlobKey string        `json:"blob_key"`
+		Blob    *BlobResponse `json:"blob,omitempty"`
+		Error   string        `json:"error,omitempty"`
+	}
+
+	BlobLookupResponse struct {
+		// Results are in the order of the requested blob keys
+		Results []*BlobLookupResult `json:"results"`
+	}
+
+	BlobInfo struct {
+		BlobKey      string                 `json:"blob_key"`
+		BlobMetadata *commonv2.BlobMetadata `json:"blob_metadata"`
+	}
+
+	// Pagination describes a page of a list response. To fetch the next page, pass NextToken as the cursor
+	// query param; HasMore is false on the last page.
+	Pagination struct {
+		Cursor    string `json:"cursor"`
+		Limit     int    `json:"limit"`
+		HasMore   bool   `json:"has_more"`
+		NextToken string `json:"next_token"`
+	}
+
+	BlobFeedResponse struct {
+		Blobs      []BlobInfo `json:"blobs"`
+		Pagination Pagination `json:"pagination"`
+		// PaginationToken is the same as Pagination.NextToken, kept for existing clients
+		PaginationToken string `json:"pagination_token"`
+	}
+
 	BlobCertificateResponse struct {
 		Certificate *corev2.BlobCertificate `json:"blob_certificate"`
+		// Relays are the relays of the certificate which serve the blob, in the order of its relay keys
+		Relays []*BlobRelay `json:"relays"`
+	}
+
+	BlobRelay struct {
+		RelayKey uint32 `json:"relay_key"`
+		// Url is empty if the relay is not in the relay registry, or the registry could not be read
+		Url string `json:"url"`
+	}
+
+	// BlobInclusionResponse proves inclusion of a blob in a batch: the keccak merkle path from the leaf,
+	// which is the blob certificate hash, to the batch root in the batch header.
+	// The proof is the concatenation of the 32-byte sibling hashes from the leaf level upwards.
+	BlobInclusionResponse struct {
+		BlobKey             string              `json:"blob_key"`
+		BlobCertificateHash string              `json:"blob_certificate_hash"`
+		BatchHeaderHash     string              `json:"batch_header_hash"`
+		BatchHeader         *corev2.BatchHeader `json:"batch_header"`
+		BlobIndex           uint32              `json:"blob_index"`
+		InclusionProof      string              `json:"inclusion_proof"`
 	}
 
 	BlobVerificationInfoResponse struct {
@@ -49,8 +115,405 @@ type (
 		BlobVerificationInfos []*corev2.BlobVerificationInfo `json:"blob_verification_infos"`
 	}
 
+	// BatchQuorumCertification is the outcome of the attestation of a batch in one quorum
+	BatchQuorumCertification struct {
+		QuorumId              core.QuorumID `json:"quorum_id"`
+		SignedPercentage      uint8         `json:"signed_percentage"`
+		ConfirmationThreshold uint8         `json:"confirmation_threshold"`
+		MeetsThreshold        bool          `json:"meets_threshold"`
+	}
+
+	// BatchCertification is an attested batch with its certification status. It is the v2 counterpart of a v1 batch
+	// confirmed on Ethereum: a v2 batch is anchored at its reference block and certified once its attestation
+	// reaches the confirmation threshold of every quorum, without any L1 transaction.
+	BatchCertification struct {
+		BatchHeaderHash string                      `json:"batch_header_hash"`
+		SignedBatch     *SignedBatch                `json:"signed_batch"`
+		Certified       bool                        `json:"certified"`
+		Quorums         []*BatchQuorumCertification `json:"quorums"`
+		// BlobKeys are the keys of the blobs in the batch, in the order of their index in the batch
+		BlobKeys []string `json:"blob_keys"`
+	}
+
+	ReferenceBlockBatchesResponse struct {
+		ReferenceBlockNumber uint64                `json:"reference_block_number"`
+		Batches              []*BatchCertification `json:"batches"`
+	}
+
+	TxBatchesResponse struct {
+		TxHash  string                `json:"tx_hash"`
+		Batches []*BatchCertification `json:"batches"`
+	}
+
+	// BatchBlob is a blob included in a batch. The header, status and size are empty if the blob's metadata
+	// is no longer stored.
+	BatchBlob struct {
+		BlobKey       string             `json:"blob_key"`
+		BlobIndex     uint32             `json:"blob_index"`
+		BlobHeader    *corev2.BlobHeader `json:"blob_header"`
+		Status        string             `json:"status"`
+		BlobSizeBytes uint64             `json:"blob_size_bytes"`
+	}
+
+	BatchBlobsResponse struct {
+		BatchHeaderHash string       `json:"batch_header_hash"`
+		Blobs           []*BatchBlob `json:"blobs"`
+		Pagination      Pagination   `json:"pagination"`
+		// PaginationToken is the same as Pagination.NextToken, kept for existing clients
+		PaginationToken string `json:"pagination_token"`
+	}
+
+	BatchInfo struct {
+		BatchHeaderHash         string                  `json:"batch_header_hash"`
+		BatchHeader             *corev2.BatchHeader     `json:"batch_header"`
+		AttestedAt              uint64                  `json:"attested_at"`
+		AggregatedSignature     *core.Signature         `json:"aggregated_signature"`
+		QuorumNumbers           []core.QuorumID         `json:"quorum_numbers"`
+		QuorumSignedPercentages map[core.QuorumID]uint8 `json:"quorum_signed_percentages"`
+	}
+
+	BatchQuorumSigningInfo struct {
+		QuorumId              core.QuorumID `json:"quorum_id"`
+		TotalStake            *big.Int      `json:"total_stake"`
+		SignedStake           *big.Int      `json:"signed_stake"`
+		SignedPercentage      uint8         `json:"signed_percentage"`
+		ConfirmationThreshold uint8         `json:"confirmation_threshold"`
+		MeetsThreshold        bool          `json:"meets_threshold"`
+		NonSigners            []string      `json:"nonsigners"`
+	}
+
+	BatchSigningInfoResponse struct {
+		BatchHeaderHash      string                    `json:"batch_header_hash"`
+		ReferenceBlockNumber uint64                    `json:"reference_block_number"`
+		NonSigners           []string                  `json:"nonsigners"`
+		Quorums              []*BatchQuorumSigningInfo `json:"quorums"`
+	}
+
+	BatchSigner struct {
+		OperatorId string   `json:"operator_id"`
+		Stake      *big.Int `json:"stake"`
+		// StakePercentage is the percentage of the stake of the quorum held by the operator
+		StakePercentage float64 `json:"stake_percentage"`
+	}
+
+	BatchQuorumSigners struct {
+		QuorumId    core.QuorumID `json:"quorum_id"`
+		TotalStake  *big.Int      `json:"total_stake"`
+		SignedStake *big.Int      `json:"signed_stake"`
+		// Signers are the operators of the quorum which signed the batch, by descending stake
+		Signers []*BatchSigner `json:"signers"`
+	}
+
+	BatchSignersResponse struct {
+		BatchHeaderHash      string                `json:"batch_header_hash"`
+		ReferenceBlockNumber uint64                `json:"reference_block_number"`
+		AttestedAt           uint64                `json:"attested_at"`
+		Quorums              []*BatchQuorumSigners `json:"quorums"`
+	}
+
+	BatchSubscriptionMessage struct {
+		BatchHeaderHash string       `json:"batch_header_hash"`
+		SignedBatch     *SignedBatch `json:"signed_batch"`
+	}
+
+	BatchFeedResponse struct {
+		Batches    []*BatchInfo `json:"batches"`
+		Pagination Pagination   `json:"pagination"`
+		// PaginationToken is the same as Pagination.NextToken, kept for existing clients
+		PaginationToken string `json:"pagination_token"`
+	}
+
+	OperatorNonSigningInfo struct {
+		OperatorId            string                `json:"operator_id"`
+		TotalUnsignedBatches  int                   `json:"total_unsigned_batches"`
+		QuorumUnsignedBatches map[core.QuorumID]int `json:"quorum_unsigned_batches"`
+	}
+
+	OperatorsNonSigningResponse struct {
+		TotalBatches int                       `json:"total_batches"`
+		NonSigners   []*OperatorNonSigningInfo `json:"nonsigners"`
+	}
+
+	OperatorEvent struct {
+		EventType       OperatorEventType `json:"event_type"`
+		OperatorId      string            `json:"operator_id"`
+		OperatorAddress string            `json:"operator_address"`
+		// ChurnedOperatorIds are the operators ejected by a churned registration
+		ChurnedOperatorIds []string `json:"churned_operator_ids,omitempty"`
+		BlockNumber        uint64   `json:"block_number"`
+		BlockTimestamp     uint64   `json:"block_timestamp"`
+		TransactionHash    string   `json:"transaction_hash"`
+	}
+
+	OperatorEventsResponse struct {
+		Events     []*OperatorEvent `json:"events"`
+		Pagination Pagination       `json:"pagination"`
+		// PaginationToken is the same as Pagination.NextToken, kept for existing clients
+		PaginationToken string `json:"pagination_token"`
+	}
+
+	QuorumStakeDistribution struct {
+		TotalStake *big.Int         `json:"total_stake"`
+		Operators  []*OperatorStake `json:"operators"`
+	}
+
+	StakeSnapshot struct {
+		Timestamp   uint64                              `json:"timestamp"`
+		BlockNumber uint64                              `json:"block_number"`
+		Quorums     map[string]*QuorumStakeDistribution `json:"quorums"`
+	}
+
+	OperatorsStakeHistoryResponse struct {
+		Snapshots []*StakeSnapshot `json:"snapshots"`
+	}
+
+	StakeLeaderboardEntry struct {
+		OperatorId      string  `json:"operator_id"`
+		StakePercentage float64 `json:"stake_percentage"`
+		Rank            int     `json:"rank"`
+		// PreviousRank is the rank of the operator in the snapshot of the previous day, 0 if it wasn't ranked then
+		PreviousRank int `json:"previous_rank"`
+		// RankChange is the number of places the operator moved up since the previous day, negative if it moved
+		// down, and 0 if it wasn't ranked then
+		RankChange int `json:"rank_change"`
+	}
+
+	OperatorsStakeLeaderboardResponse struct {
+		QuorumId string `json:"quorum_id"`
+		// PreviousSnapshotTimestamp is the timestamp of the snapshot the ranks are compared to, 0 if there is none
+		PreviousSnapshotTimestamp uint64                   `json:"previous_snapshot_timestamp"`
+		Operators                 []*StakeLeaderboardEntry `json:"operators"`
+		Pagination                Pagination               `json:"pagination"`
+		// PaginationToken is the same as Pagination.NextToken, kept consistent with the other list endpoints
+		PaginationToken string `json:"pagination_token"`
+	}
+
+	// SemverAdoption is the number of operators running a node version, and the percentage of the stake of each
+	// quorum they hold
+	SemverAdoption struct {
+		Operators       uint32             `json:"operators"`
+		StakePercentage map[string]float64 `json:"stake_percentage"`
+	}
+
+	SemverSnapshot struct {
+		Timestamp uint64                     `json:"timestamp"`
+		Semvers   map[string]*SemverAdoption `json:"semvers"`
+	}
+
+	OperatorsNodeInfoHistoryResponse struct {
+		Snapshots []*SemverSnapshot `json:"snapshots"`
+	}
+
+	OperatorSigningRate struct {
+		Window            string  `json:"window"`
+		TotalBatches      int     `json:"total_batches"`
+		UnsignedBatches   int     `json:"unsigned_batches"`
+		SigningPercentage float64 `json:"signing_percentage"`
+	}
+
+	OperatorSigningRateResponse struct {
+		OperatorId   string                 `json:"operator_id"`
+		SigningRates []*OperatorSigningRate `json:"signing_rates"`
+	}
+
+	OnDemandCostEstimate struct {
+		// Allowed is whether the blob can be paid for on demand, which is limited to the required quorums
+		Allowed bool `json:"allowed"`
+		// PricePerSymbol is the price in wei of a symbol
+		PricePerSymbol uint32 `json:"price_per_symbol"`
+		// Payment is the increase of the cumulative payment of the account in wei
+		Payment *big.Int `json:"payment"`
+	}
+
+	ReservationCostEstimate struct {
+		// ReservationWindow is the length in seconds of the periods over which reservation usage is metered
+		ReservationWindow uint32 `json:"reservation_window"`
+		// SymbolsPerSecond is the min reserved rate needed to disperse one such blob per reservation window
+		SymbolsPerSecond uint64 `json:"symbols_per_second"`
+	}
+
+	DispersalCostEstimate struct {
+		BlobSize      uint64          `json:"blob_size"`
+		QuorumNumbers []core.QuorumID `json:"quorum_numbers"`
+		// NumSymbols is the length of the blob in symbols, padded to a power of 2 as on dispersal
+		NumSymbols uint64 `json:"num_symbols"`
+		// SymbolsCharged is the number of symbols the blob is charged for, a multiple of the min number of symbols
+		SymbolsCharged uint64                   `json:"symbols_charged"`
+		OnDemand       *OnDemandCostEstimate    `json:"on_demand"`
+		Reservation    *ReservationCostEstimate `json:"reservation"`
+	}
+
 	MetricSummary struct {
+		// AvgThroughput is the avg throughput in bytes/sec over the queried time range
 		AvgThroughput float64 `json:"avg_throughput"`
+		// TotalDispersedBytes is the size of the blobs certified in all the days rolled up so far
+		TotalDispersedBytes uint64 `json:"total_dispersed_bytes"`
+		// DispersedBytes24h is the size of the blobs confirmed in the last 24 hours
+		DispersedBytes24h     uint64                     `json:"dispersed_bytes_24h"`
+		TotalStakePerQuorum   map[core.QuorumID]*big.Int `json:"total_stake_per_quorum"`
+		NumOperatorsPerQuorum map[core.QuorumID]int      `json:"num_operators_per_quorum"`
+		TotalOperators        int                        `json:"total_operators"`
+		BlockNumber           uint32                     `json:"block_number"`
+		// LatestBatch is the last batch attested in the past hour, if any
+		LatestBatch *BatchInfo `json:"latest_batch,omitempty"`
+	}
+
+	StaleBlobCounts struct {
+		StartTime int64 `json:"start_time"`
+		EndTime   int64 `json:"end_time"`
+		// Requeued is the number of blobs stuck in the encoded status that were requeued into a new batch
+		Requeued uint64 `json:"requeued"`
+		// Failed is the number of blobs stuck in the encoded status that were failed, because they expired or
+		// were requeued too many times
+		Failed uint64 `json:"failed"`
+	}
+
+	MetricsOverview struct {
+		WindowSeconds uint64 `json:"window_seconds"`
+		// NumBlobs is the number of blobs requested in the window
+		NumBlobs int `json:"num_blobs"`
+		// DispersedBytes is the size of the blobs requested in the window
+		DispersedBytes uint64 `json:"dispersed_bytes"`
+		// TotalDispersedBytes is the size of the blobs certified in all the days rolled up, plus the size of the
+		// blobs requested since
+		TotalDispersedBytes   uint64                     `json:"total_dispersed_bytes"`
+		AvgThroughput         float64                    `json:"avg_throughput"`
+		TotalStakePerQuorum   map[core.QuorumID]*big.Int `json:"total_stake_per_quorum"`
+		NumOperatorsPerQuorum map[core.QuorumID]int      `json:"num_operators_per_quorum"`
+		TotalOperators        int                        `json:"total_operators"`
+		BlockNumber           uint32                     `json:"block_number"`
+		ComputedAt            uint64                     `json:"computed_at"`
+	}
+
+	BlobSizeBucket struct {
+		// UpperBound is the inclusive upper bound of the bucket in bytes; the lower bound is
+		// the upper bound of the previous bucket (exclusive), or 0 for the first bucket
+		UpperBound uint64 `json:"upper_bound"`
+		Count      int    `json:"count"`
+	}
+
+	BlobSizeHistogram struct {
+		Start      int64             `json:"start"`
+		End        int64             `json:"end"`
+		NumBlobs   int               `json:"num_blobs"`
+		TotalBytes uint64            `json:"total_bytes"`
+		Buckets    []*BlobSizeBucket `json:"buckets"`
+		P50        uint64            `json:"p50"`
+		P95        uint64            `json:"p95"`
+		P99        uint64            `json:"p99"`
+	}
+
+	// LatencyDistribution summarizes a set of latencies in milliseconds
+	LatencyDistribution struct {
+		NumBlobs int     `json:"num_blobs"`
+		Min      float64 `json:"min_ms"`
+		Mean     float64 `json:"mean_ms"`
+		P50      float64 `json:"p50_ms"`
+		P90      float64 `json:"p90_ms"`
+		P95      float64 `json:"p95_ms"`
+		P99      float64 `json:"p99_ms"`
+		Max      float64 `json:"max_ms"`
+	}
+
+	AttestationLatencyResponse struct {
+		Start int64 `json:"start"`
+		End   int64 `json:"end"`
+		// Quorums maps each quorum ID to the latency distribution of the blobs dispersed to it
+		Quorums map[string]*LatencyDistribution `json:"quorums"`
+	}
+
+	// ThroughputRollup is the dispersal volume and the fees of the blobs requested in a day or a week
+	ThroughputRollup struct {
+		Period string `json:"period"`
+		// Timestamp is the Unix timestamp in seconds of the start of the period
+		Timestamp          uint64 `json:"timestamp"`
+		BlobCount          uint64 `json:"blob_count"`
+		CertifiedBlobCount uint64 `json:"certified_blob_count"`
+		CertifiedBytes     uint64 `json:"certified_bytes"`
+		// Throughput is the average rate of certified bytes over the period, in bytes/sec
+		Throughput float64 `json:"throughput"`
+		// OnDemandPayment is the total payment in wei made for on-demand blobs
+		OnDemandPayment *big.Int `json:"on_demand_payment"`
+	}
+
+	ThroughputRollupsResponse struct {
+		Rollups []*ThroughputRollup `json:"rollups"`
+	}
+
+	RelayReachability struct {
+		RelayKey uint32 `json:"relay_key"`
+		Url      string `json:"url"`
+		// Reachable is whether a gRPC connection to the relay could be established
+		Reachable     bool    `json:"reachable"`
+		DialLatencyMs float64 `json:"dial_latency_ms"`
+		// HealthStatus is the status reported by the relay's gRPC health service
+		HealthStatus string `json:"health_status"`
+		// Version is the version reported by the relay, empty if it doesn't report one
+		Version string `json:"version,omitempty"`
+		Error   string `json:"error,omitempty"`
+	}
+
+	RelaysReachabilityResponse struct {
+		Relays []*RelayReachability `json:"relays"`
+	}
+
+	// OperatorSearchResult is an operator matching a search
+	OperatorSearchResult struct {
+		OperatorId      string `json:"operator_id"`
+		OperatorAddress string `json:"operator_address"`
+	}
+
+	// AccountSearchResult is an account matching a search, with its most recent blobs
+	AccountSearchResult struct {
+		AccountId string     `json:"account_id"`
+		Blobs     []BlobInfo `json:"blobs"`
+	}
+
+	// TransactionSearchResult is an L1 transaction matching a search, with the operator events it emitted
+	TransactionSearchResult struct {
+		TransactionHash string           `json:"transaction_hash"`
+		OperatorEvents  []*OperatorEvent `json:"operator_events"`
+	}
+
+	// SearchResult is an entity matching a search. Type is one of blob, batch, operator, account or transaction,
+	// and tells which of the other fields is set.
+	SearchResult struct {
+		Type        string                   `json:"type"`
+		Blob        *BlobResponse            `json:"blob,omitempty"`
+		Batch       *BatchResponse           `json:"batch,omitempty"`
+		Operator    *OperatorSearchResult    `json:"operator,omitempty"`
+		Account     *AccountSearchResult     `json:"account,omitempty"`
+		Transaction *TransactionSearchResult `json:"transaction,omitempty"`
+	}
+
+	SearchResponse struct {
+		Query   string          `json:"query"`
+		Results []*SearchResult `json:"results"`
+	}
+
+	// DependencyHealth is the result of checking a dependency of the server
+	DependencyHealth struct {
+		Healthy   bool   `json:"healthy"`
+		LatencyMs int64  `json:"latency_ms"`
+		Error     string `json:"error,omitempty"`
+	}
+
+	// HealthResponse reports the health of each dependency of the server, by dependency name. Status is "ok" if
+	// all dependencies are healthy, and "degraded" otherwise.
+	HealthResponse struct {
+		Status       string                       `json:"status"`
+		Dependencies map[string]*DependencyHealth `json:"dependencies"`
+	}
+
+	GraphQLRequest struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+
+	GraphQLResponse struct {
+		Data   json.RawMessage             `json:"data,omitempty" swaggertype:"object"`
+		Errors []*graphqlerrors.QueryError `json:"errors,omitempty"`
 	}
 )
 
@@ -60,50 +523,149 @@ type ServerInterface interface {
 }
 
 type ServerV2 struct {
-	serverMode   string
-	socketAddr   string
-	allowOrigins []string
-	logger       logging.Logger
-
-	blobMetadataStore *blobstore.BlobMetadataStore
+	serverMode         string
+	socketAddr         string
+	allowOrigins       []string
+	relayUseSecureGrpc bool
+	compressionMinSize int
+	shutdownTimeout    time.Duration
+	cachePolicy        CachePolicy
+	queryCache         *queryCache
+	logger             logging.Logger
+
+	// operatorStateCache caches operator states by reference block number and quorums, which don't change
+	operatorStateCache *expirable.LRU[string, *core.OperatorState]
+
+	blobMetadataStore *tracedBlobMetadataStore
 	subgraphClient    SubgraphClient
 	chainReader       core.Reader
+	ethClient         common.EthClient
 	chainState        core.ChainState
 	indexedChainState core.IndexedChainState
 	promClient        PrometheusClient
 	metrics           *Metrics
 
-	operatorHandler *operatorHandler
-	metricsHandler  *metricsHandler
+	operatorHandler        *operatorHandler
+	metricsHandler         *metricsHandler
+	metricsOverviewHandler *metricsOverviewHandler
+	blobStreamHandler      *blobStreamHandler
+	batchStreamHandler     *batchStreamHandler
+	signingRateAggregator  *signingRateAggregator
+	stakeSnapshotter       *stakeSnapshotter
+	semverSnapshotter      *semverSnapshotter
+	throughputAggregator   *throughputAggregator
+	graphqlSchema          *graphql.Schema
+
+	// operatorAlerter posts operator alerts to webhooks; nil if no webhook is configured
+	operatorAlerter    *operatorAlerter
+	alertCheckInterval time.Duration
+
+	// apiKeyStore holds the API keys clients must authenticate with; nil disables authentication
+	apiKeyStore apikey.Store
+
+	// ratelimiter limits the rate of requests per client IP and API key; nil disables rate limiting
+	ratelimiter       common.RateLimiter
+	rateLimiterParams common.GlobalRateParams
+	ipRequestRate     uint32
+	apiKeyRequestRate uint32
+
+	// maintenance is set while the server is in maintenance mode, rejecting requests to the v2 API
+	maintenance           atomic.Bool
+	maintenanceRetryAfter time.Duration
+	// inFlightRequests is the number of v2 API requests being served, excluding streams
+	inFlightRequests atomic.Int64
+
+	mu sync.Mutex
+	// httpServer is the server run by Start, nil when not running
+	httpServer *http.Server
+	// shutdownCh is closed when the server starts shutting down, to end long-lived streams
+	shutdownCh chan struct{}
+	// cancels background work started by Start
+	cancel context.CancelFunc
 }
 
-func NewServerV2(
-	config Config,
-	blobMetadataStore *blobstore.BlobMetadataStore,
-	promClient PrometheusClient,
-	subgraphClient SubgraphClient,
-	chainReader core.Reader,
-	chainState core.ChainState,
-	indexedChainState core.IndexedChainState,
-	logger logging.Logger,
-	metrics *Metrics,
-) *ServerV2 {
-	l := logger.With("component", "DataAPIServerV2")
-	return &ServerV2{
-		logger:            l,
-		serverMode:        config.ServerMode,
-		socketAddr:        config.SocketAddr,
-		allowOrigins:      config.AllowOrigins,
-		blobMetadataStore: blobMetadataStore,
-		promClient:        promClient,
-		subgraphClient:    subgraphClient,
-		chainReader:       chainReader,
-		chainState:        chainState,
-		indexedChainState: indexedChainState,
-		metrics:           metrics,
-		operatorHandler:   newOperatorHandler(l, metrics, chainReader, chainState, indexedChainState, subgraphClient),
-		metricsHandler:    newMetricsHandler(promClient),
+// ServerV2Options are the dependencies of the v2 DataAPI server
+type ServerV2Options struct {
+	BlobMetadataStore *blobstore.BlobMetadataStore
+	PromClient        PrometheusClient
+	SubgraphClient    SubgraphClient
+	ChainReader       core.Reader
+	ChainState        core.ChainState
+	IndexedChainState core.IndexedChainState
+	Logger            logging.Logger
+	Metrics           *Metrics
+
+	// EthClient looks up the transactions which reference batches
+	EthClient common.EthClient
+
+	// APIKeyStore holds the API keys clients must authenticate with; nil disables authentication
+	APIKeyStore apikey.Store
+	// BucketStore holds the rate limiter buckets; nil disables rate limiting
+	BucketStore ratelimit.BucketStore
+}
+
+func NewServerV2(config Config, opts ServerV2Options) *ServerV2 {
+	l := opts.Logger.With("component", "DataAPIServerV2")
+	// The cache wraps the traced client, so that only the queries actually sent to the subgraph are traced
+	var subgraphClient SubgraphClient = newTracedSubgraphClient(opts.SubgraphClient)
+	var cache *queryCache
+	if config.QueryCacheTTL > 0 {
+		cache = newQueryCache(config.QueryCacheSize, config.QueryCacheTTL)
+		subgraphClient = newCachedSubgraphClient(subgraphClient, cache)
+	}
+	throughputAggregator := newThroughputAggregator(l, opts.BlobMetadataStore)
+	s := &ServerV2{
+		logger:                 l,
+		serverMode:             config.ServerMode,
+		socketAddr:             config.SocketAddr,
+		allowOrigins:           config.AllowOrigins,
+		relayUseSecureGrpc:     config.RelayUseSecureGrpc,
+		compressionMinSize:     config.CompressionMinSize,
+		shutdownTimeout:        config.ShutdownTimeout,
+		maintenanceRetryAfter:  config.MaintenanceRetryAfter,
+		cachePolicy:            config.CachePolicy.withDefaults(),
+		queryCache:             cache,
+		operatorStateCache:     expirable.NewLRU[string, *core.OperatorState](operatorStateCacheSize, nil, 0),
+		blobMetadataStore:      newTracedBlobMetadataStore(opts.BlobMetadataStore),
+		promClient:             opts.PromClient,
+		subgraphClient:         subgraphClient,
+		chainReader:            opts.ChainReader,
+		ethClient:              opts.EthClient,
+		chainState:             opts.ChainState,
+		indexedChainState:      opts.IndexedChainState,
+		metrics:                opts.Metrics,
+		operatorHandler:        newOperatorHandler(l, opts.Metrics, opts.ChainReader, opts.ChainState, opts.IndexedChainState, subgraphClient),
+		metricsHandler:         newMetricsHandler(opts.PromClient),
+		metricsOverviewHandler: newMetricsOverviewHandler(l, opts.BlobMetadataStore, opts.ChainReader, opts.ChainState, throughputAggregator),
+		blobStreamHandler:      newBlobStreamHandler(l, opts.BlobMetadataStore),
+		batchStreamHandler:     newBatchStreamHandler(l, opts.BlobMetadataStore),
+		signingRateAggregator:  newSigningRateAggregator(l, opts.BlobMetadataStore, opts.ChainState, config.SigningRateOwner),
+		stakeSnapshotter:       newStakeSnapshotter(l, opts.BlobMetadataStore, opts.ChainReader, opts.ChainState),
+		throughputAggregator:   throughputAggregator,
+		apiKeyStore:            opts.APIKeyStore,
+		rateLimiterParams:      config.RateLimiterConfig.GlobalRateParams,
+		ipRequestRate:          config.IPRequestRate,
+		apiKeyRequestRate:      config.APIKeyRequestRate,
+	}
+	s.semverSnapshotter = newSemverSnapshotter(l, opts.BlobMetadataStore, s.operatorHandler)
+	if len(config.AlertWebhookURLs) > 0 {
+		s.operatorAlerter = newOperatorAlerter(l, s.signingRateAggregator, s.operatorHandler, config)
+		s.alertCheckInterval = config.AlertCheckInterval
+		if s.alertCheckInterval <= 0 {
+			s.alertCheckInterval = defaultOperatorAlertCheckInterval
+		}
+	}
+	if s.shutdownTimeout <= 0 {
+		s.shutdownTimeout = defaultShutdownTimeout
 	}
+	if s.maintenanceRetryAfter <= 0 {
+		s.maintenanceRetryAfter = defaultMaintenanceRetryAfter
+	}
+	if opts.BucketStore != nil {
+		s.ratelimiter = ratelimit.NewRateLimiter(opts.Metrics.registry, s.rateLimiterParams, opts.BucketStore, l)
+	}
+	s.graphqlSchema = newGraphQLSchema(s)
+	return s
 }
 
 func (s *ServerV2) Start() error {
@@ -112,36 +674,97 @@ func (s *ServerV2) Start() error {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	s.metricsOverviewHandler.start(ctx, metricsOverviewRefreshInterval)
+	s.signingRateAggregator.start(ctx, signingRateRefreshInterval)
+	s.stakeSnapshotter.start(ctx, stakeSnapshotCheckInterval)
+	s.semverSnapshotter.start(ctx, semverSnapshotCheckInterval)
+	s.throughputAggregator.start(ctx, throughputRollupCheckInterval)
+	s.operatorHandler.startHostInfoRefresh(ctx, operatorsHostInfoRefreshInterval)
+	if s.operatorAlerter != nil {
+		s.operatorAlerter.start(ctx, s.alertCheckInterval)
+	}
+
 	router := gin.New()
 	basePath := "/api/v2"
 	docs.SwaggerInfo.BasePath = basePath
 	docs.SwaggerInfo.Host = os.Getenv("SWAGGER_HOST")
 	v2 := router.Group(basePath)
+	v2.Use(s.Trace())
+	v2.Use(s.RecordMetrics())
+	v2.Use(s.Maintenance(basePath+"/blob/stream", basePath+"/batch/subscribe"))
+	if s.ratelimiter != nil {
+		v2.Use(s.RateLimit())
+	}
+	if s.apiKeyStore != nil {
+		v2.Use(s.APIKeyAuth(basePath + "/swagger"))
+	}
+	if s.compressionMinSize > 0 {
+		// Streaming routes are sent as they are written
+		v2.Use(s.Compress(s.compressionMinSize, basePath+"/blob/stream", basePath+"/batch/subscribe"))
+	}
+	etag := s.ETag()
+	blobKeyParam := hexPathParam("blob_key", 32)
+	batchHeaderHashParam := hexPathParam("batch_header_hash", 32)
+	feedParams := validateParams(pageParamsRule, feedRangeRule)
 	{
 		blob := v2.Group("/blob")
 		{
-			blob.GET("/blobs/feed", s.FetchBlobFeedHandler)
-			blob.GET("/blobs/:blob_key", s.FetchBlobHandler)
-			blob.GET("/blobs/:blob_key/certificate", s.FetchBlobCertificateHandler)
-			blob.GET("/blobs/:blob_key/verification-info", s.FetchBlobVerificationInfoHandler)
+			blob.GET("/stream", s.FetchBlobStreamHandler)
+			blob.GET("/blobs/feed", feedParams, s.FetchBlobFeedHandler)
+			blob.GET("/blobs/:blob_key", validateParams(blobKeyParam), etag, s.FetchBlobHandler)
+			blob.POST("/blobs/lookup", s.LookupBlobsHandler)
+			blob.GET("/blobs/:blob_key/certificate", validateParams(blobKeyParam), etag, s.FetchBlobCertificateHandler)
+			blob.GET("/blobs/:blob_key/verification-info", validateParams(blobKeyParam, hexQueryParam("batch_header_hash", 32)), etag, s.FetchBlobVerificationInfoHandler)
+			blob.GET("/blobs/:blob_key/inclusion", validateParams(blobKeyParam), etag, s.FetchBlobInclusionHandler)
 		}
 		batch := v2.Group("/batch")
 		{
-			batch.GET("/batches/feed", s.FetchBatchFeedHandler)
-			batch.GET("/batches/:batch_header_hash", s.FetchBatchHandler)
+			batch.GET("/subscribe", s.SubscribeBatchesHandler)
+			batch.GET("/batches/feed", feedParams, s.FetchBatchFeedHandler)
+			batch.GET("/batches/by-reference-block/:block_number", etag, s.FetchBatchesByReferenceBlockHandler)
+			batch.GET("/batches/by-tx-hash/:tx_hash", validateParams(hexPathParam("tx_hash", 32)), etag, s.FetchBatchesByTxHashHandler)
+			batch.GET("/batches/:batch_header_hash", validateParams(batchHeaderHashParam), etag, s.FetchBatchHandler)
+			batch.GET("/batches/:batch_header_hash/signing-info", validateParams(batchHeaderHashParam), etag, s.FetchBatchSigningInfoHandler)
+			batch.GET("/batches/:batch_header_hash/signers", validateParams(batchHeaderHashParam), etag, s.FetchBatchSignersHandler)
+			batch.GET("/batches/:batch_header_hash/blobs", validateParams(batchHeaderHashParam, pageParamsRule), etag, s.FetchBatchBlobsHandler)
+		}
+		accounts := v2.Group("/accounts")
+		{
+			accounts.GET("/:account_id/blobs", validateParams(pageParamsRule), s.FetchAccountBlobsHandler)
 		}
 		operators := v2.Group("/operators")
 		{
-			operators.GET("/nonsigners", s.FetchNonSingers)
-			operators.GET("/stake", s.FetchOperatorsStake)
+			operators.GET("/nonsigners", validateParams(intervalRule("interval", maxNonSignerInterval)), s.FetchNonSigners)
+			operators.GET("/stake", etag, s.FetchOperatorsStake)
+			operators.GET("/stake/history", etag, s.FetchOperatorsStakeHistory)
+			operators.GET("/stake/leaderboard", validateParams(pageParamsRule), etag, s.FetchOperatorsStakeLeaderboard)
 			operators.GET("/nodeinfo", s.FetchOperatorsNodeInfo)
+			operators.GET("/nodeinfo/history", etag, s.FetchOperatorsNodeInfoHistory)
 			operators.GET("/reachability", s.CheckOperatorsReachability)
+			operators.GET("/events", feedParams, s.FetchOperatorEventsHandler)
+			operators.GET("/:operator_id/signing-rate", validateParams(hexPathParam("operator_id", 32)), s.FetchOperatorSigningRateHandler)
+		}
+		relays := v2.Group("/relays")
+		{
+			relays.GET("/reachability", s.CheckRelaysReachability)
 		}
 		metrics := v2.Group("/metrics")
 		{
-			metrics.GET("/summary", s.FetchMetricsSummaryHandler)
-			metrics.GET("/timeseries/throughput", s.FetchMetricsThroughputTimeseriesHandler)
+			metrics.GET("/summary", validateParams(unixRangeRule(maxMetricsTimeRange)), s.FetchMetricsSummaryHandler)
+			metrics.GET("/overview", s.FetchMetricsOverviewHandler)
+			metrics.GET("/timeseries/throughput", validateParams(unixRangeRule(maxMetricsTimeRange)), s.FetchMetricsThroughputTimeseriesHandler)
+			metrics.GET("/blob-sizes", validateParams(unixRangeRule(maxBlobSizeHistogramWindow)), s.FetchBlobSizeHistogramHandler)
+			metrics.GET("/attestation-latency", validateParams(unixRangeRule(maxAttestationLatencyWindow)), s.FetchAttestationLatencyHandler)
+			metrics.GET("/rollups", etag, s.FetchThroughputRollupsHandler)
+			metrics.GET("/stale-blobs", validateParams(unixRangeRule(maxMetricsTimeRange)), s.FetchStaleBlobCountsHandler)
 		}
+		payments := v2.Group("/payments")
+		{
+			payments.GET("/cost", etag, s.EstimateDispersalCostHandler)
+		}
+		v2.GET("/search", s.SearchHandler)
+		v2.POST("/graphql", s.GraphQLHandler)
 		swagger := v2.Group("/swagger")
 		{
 			swagger.GET("/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
@@ -151,9 +774,11 @@ func (s *ServerV2) Start() error {
 	router.GET("/", func(g *gin.Context) {
 		g.JSON(http.StatusAccepted, gin.H{"status": "OK"})
 	})
+	router.GET("/healthz", s.CheckHealth)
+	router.GET("/readyz", s.CheckReadiness)
 
 	router.Use(logger.SetLogger(
-		logger.WithSkipPath([]string{"/"}),
+		logger.WithSkipPath([]string{"/", "/healthz", "/readyz"}),
 	))
 
 	config := cors.DefaultConfig()
@@ -174,355 +799,77 @@ func (s *ServerV2) Start() error {
 		WriteTimeout:      20 * time.Second,
 		IdleTimeout:       120 * time.Second,
 	}
+	shutdownCh := make(chan struct{})
+	// Streams hijack or hold their connection, so Shutdown doesn't wait on them; they are told to end instead
+	srv.RegisterOnShutdown(func() {
+		close(shutdownCh)
+	})
 
-	errChan := run(s.logger, srv)
-	return <-errChan
-}
+	s.mu.Lock()
+	s.httpServer = srv
+	s.shutdownCh = shutdownCh
+	s.cancel = cancel
+	s.mu.Unlock()
 
-func (s *ServerV2) Shutdown() error {
+	s.logger.Info("server running", "addr", srv.Addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		cancel()
+		return err
+	}
 	return nil
 }
 
-func (s *ServerV2) FetchBlobFeedHandler(c *gin.Context) {
-	errorResponse(c, errors.New("FetchBlobFeedHandler unimplemented"))
-}
-
-// FetchBlobHandler godoc
-//
-//	@Summary	Fetch blob metadata by blob key
-//	@Tags		Blob
-//	@Produce	json
-//	@Param		blob_key	path		string	true	"Blob key in hex string"
-//	@Success	200			{object}	BlobResponse
-//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404			{object}	ErrorResponse	"error: Not found"
-//	@Failure	500			{object}	ErrorResponse	"error: Server error"
-//	@Router		/blobs/{blob_key} [get]
-func (s *ServerV2) FetchBlobHandler(c *gin.Context) {
-	start := time.Now()
-	blobKey, err := corev2.HexToBlobKey(c.Param("blob_key"))
-	if err != nil {
-		s.metrics.IncrementInvalidArgRequestNum("FetchBlob")
-		errorResponse(c, err)
-		return
-	}
-	metadata, err := s.blobMetadataStore.GetBlobMetadata(c.Request.Context(), blobKey)
-	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchBlob")
-		errorResponse(c, err)
-		return
-	}
-	response := &BlobResponse{
-		BlobHeader:    metadata.BlobHeader,
-		Status:        metadata.BlobStatus.String(),
-		DispersedAt:   metadata.RequestedAt,
-		BlobSizeBytes: metadata.BlobSize,
-	}
-	s.metrics.IncrementSuccessfulRequestNum("FetchBlob")
-	s.metrics.ObserveLatency("FetchBlob", float64(time.Since(start).Milliseconds()))
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxFeedBlobAge))
-	c.JSON(http.StatusOK, response)
-}
-
-// FetchBlobCertificateHandler godoc
-//
-//	@Summary	Fetch blob certificate by blob key
-//	@Tags		Blob
-//	@Produce	json
-//	@Param		blob_key	path		string	true	"Blob key in hex string"
-//	@Success	200			{object}	BlobCertificateResponse
-//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404			{object}	ErrorResponse	"error: Not found"
-//	@Failure	500			{object}	ErrorResponse	"error: Server error"
-//	@Router		/blobs/{blob_key}/certificate [get]
-func (s *ServerV2) FetchBlobCertificateHandler(c *gin.Context) {
-	start := time.Now()
-	blobKey, err := corev2.HexToBlobKey(c.Param("blob_key"))
-	if err != nil {
-		s.metrics.IncrementInvalidArgRequestNum("FetchBlobCertificate")
-		errorResponse(c, err)
-		return
-	}
-	cert, _, err := s.blobMetadataStore.GetBlobCertificate(c.Request.Context(), blobKey)
-	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchBlobCertificate")
-		errorResponse(c, err)
-		return
-	}
-	response := &BlobCertificateResponse{
-		Certificate: cert,
-	}
-	s.metrics.IncrementSuccessfulRequestNum("FetchBlobCertificate")
-	s.metrics.ObserveLatency("FetchBlobCertificate", float64(time.Since(start).Milliseconds()))
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxFeedBlobAge))
-	c.JSON(http.StatusOK, response)
-}
-
-// FetchBlobVerificationInfoHandler godoc
-//
-//	@Summary	Fetch blob verification info by blob key and batch header hash
-//	@Tags		Blob
-//	@Produce	json
-//	@Param		blob_key			path		string	true	"Blob key in hex string"
-//	@Param		batch_header_hash	path		string	true	"Batch header hash in hex string"
-//
-//	@Success	200					{object}	BlobVerificationInfoResponse
-//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404					{object}	ErrorResponse	"error: Not found"
-//	@Failure	500					{object}	ErrorResponse	"error: Server error"
-//	@Router		/blobs/{blob_key}/verification-info [get]
-func (s *ServerV2) FetchBlobVerificationInfoHandler(c *gin.Context) {
-	start := time.Now()
-	blobKey, err := corev2.HexToBlobKey(c.Param("blob_key"))
-	if err != nil {
-		s.metrics.IncrementInvalidArgRequestNum("FetchBlobVerificationInfo")
-		errorResponse(c, err)
-		return
-	}
-	batchHeaderHashHex := c.Query("batch_header_hash")
-	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(batchHeaderHashHex))
-	if err != nil {
-		s.metrics.IncrementInvalidArgRequestNum("FetchBlobVerificationInfo")
-		errorResponse(c, err)
-		return
-	}
-	bvi, err := s.blobMetadataStore.GetBlobVerificationInfo(c.Request.Context(), blobKey, batchHeaderHash)
-	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchBlobVerificationInfo")
-		errorResponse(c, err)
-		return
-	}
-	response := &BlobVerificationInfoResponse{
-		VerificationInfo: bvi,
-	}
-	s.metrics.IncrementSuccessfulRequestNum("FetchBlobVerificationInfo")
-	s.metrics.ObserveLatency("FetchBlobVerificationInfo", float64(time.Since(start).Milliseconds()))
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxFeedBlobAge))
-	c.JSON(http.StatusOK, response)
-}
-
-func (s *ServerV2) FetchBatchFeedHandler(c *gin.Context) {
-	errorResponse(c, errors.New("FetchBatchFeedHandler unimplemented"))
-}
-
-// FetchBatchHandler godoc
-//
-//	@Summary	Fetch batch by the batch header hash
-//	@Tags		Batch
-//	@Produce	json
-//	@Param		batch_header_hash	path		string	true	"Batch header hash in hex string"
-//	@Success	200					{object}	BatchResponse
-//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404					{object}	ErrorResponse	"error: Not found"
-//	@Failure	500					{object}	ErrorResponse	"error: Server error"
-//	@Router		/batches/{batch_header_hash} [get]
-func (s *ServerV2) FetchBatchHandler(c *gin.Context) {
-	start := time.Now()
-	batchHeaderHashHex := c.Param("batch_header_hash")
-	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(batchHeaderHashHex))
-	if err != nil {
-		s.metrics.IncrementInvalidArgRequestNum("FetchBatch")
-		errorResponse(c, errors.New("invalid batch header hash"))
-		return
-	}
-	batchHeader, attestation, err := s.blobMetadataStore.GetSignedBatch(c.Request.Context(), batchHeaderHash)
-	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchBatch")
-		errorResponse(c, err)
-		return
-	}
-	// TODO: support fetch of blob verification info
-	batchResponse := &BatchResponse{
-		BatchHeaderHash: batchHeaderHashHex,
-		SignedBatch: &SignedBatch{
-			BatchHeader: batchHeader,
-			Attestation: attestation,
-		},
-	}
-	s.metrics.IncrementSuccessfulRequestNum("FetchBatch")
-	s.metrics.ObserveLatency("FetchBatch", float64(time.Since(start).Milliseconds()))
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxFeedBlobAge))
-	c.JSON(http.StatusOK, batchResponse)
-}
-
-// FetchOperatorsStake godoc
-//
-//	@Summary	Operator stake distribution query
-//	@Tags		OperatorsStake
-//	@Produce	json
-//	@Param		operator_id	query		string	false	"Operator ID in hex string [default: all operators if unspecified]"
-//	@Success	200			{object}	OperatorsStakeResponse
-//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404			{object}	ErrorResponse	"error: Not found"
-//	@Failure	500			{object}	ErrorResponse	"error: Server error"
-//	@Router		/operators/stake [get]
-func (s *ServerV2) FetchOperatorsStake(c *gin.Context) {
-	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
-		s.metrics.ObserveLatency("FetchOperatorsStake", f*1000) // make milliseconds
-	}))
-	defer timer.ObserveDuration()
-
-	operatorId := c.DefaultQuery("operator_id", "")
-	s.logger.Info("getting operators stake distribution", "operatorId", operatorId)
-
-	operatorsStakeResponse, err := s.operatorHandler.getOperatorsStake(c.Request.Context(), operatorId)
-	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchOperatorsStake")
-		errorResponse(c, fmt.Errorf("failed to get operator stake - %s", err))
-		return
-	}
-
-	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorsStake")
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorsStakeAge))
-	c.JSON(http.StatusOK, operatorsStakeResponse)
-}
+// Shutdown stops the background workers and the server, waiting up to the shutdown timeout for in-flight
+// requests to complete before closing the remaining connections. The server can be started again afterwards.
+func (s *ServerV2) Shutdown() error {
+	s.mu.Lock()
+	srv, cancel := s.httpServer, s.cancel
+	s.httpServer, s.cancel = nil, nil
+	s.mu.Unlock()
 
-// FetchOperatorsNodeInfo godoc
-//
-//	@Summary	Active operator semver
-//	@Tags		OperatorsNodeInfo
-//	@Produce	json
-//	@Success	200	{object}	SemverReportResponse
-//	@Failure	500	{object}	ErrorResponse	"error: Server error"
-//	@Router		/operators/nodeinfo [get]
-func (s *ServerV2) FetchOperatorsNodeInfo(c *gin.Context) {
-	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
-		s.metrics.ObserveLatency("FetchOperatorsNodeInfo", f*1000) // make milliseconds
-	}))
-	defer timer.ObserveDuration()
-
-	report, err := s.operatorHandler.scanOperatorsHostInfo(c.Request.Context())
-	if err != nil {
-		s.logger.Error("failed to scan operators host info", "error", err)
-		s.metrics.IncrementFailedRequestNum("FetchOperatorsNodeInfo")
-		errorResponse(c, err)
-	}
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorPortCheckAge))
-	c.JSON(http.StatusOK, report)
-}
+	if cancel != nil {
+		cancel()
+	}
+	s.blobStreamHandler.stop()
+	s.batchStreamHandler.stop()
+	if srv == nil {
+		return nil
+	}
 
-// CheckOperatorsReachability godoc
-//
-//	@Summary	Operator node reachability check
-//	@Tags		OperatorsReachability
-//	@Produce	json
-//	@Param		operator_id	query		string	false	"Operator ID in hex string [default: all operators if unspecified]"
-//	@Success	200			{object}	OperatorPortCheckResponse
-//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404			{object}	ErrorResponse	"error: Not found"
-//	@Failure	500			{object}	ErrorResponse	"error: Server error"
-//	@Router		/operators/reachability [get]
-func (s *ServerV2) CheckOperatorsReachability(c *gin.Context) {
-	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
-		s.metrics.ObserveLatency("OperatorPortCheck", f*1000) // make milliseconds
-	}))
-	defer timer.ObserveDuration()
-
-	operatorId := c.DefaultQuery("operator_id", "")
-	s.logger.Info("checking operator ports", "operatorId", operatorId)
-	portCheckResponse, err := s.operatorHandler.probeOperatorHosts(c.Request.Context(), operatorId)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			err = errNotFound
-			s.logger.Warn("operator not found", "operatorId", operatorId)
-			s.metrics.IncrementNotFoundRequestNum("OperatorPortCheck")
-		} else {
-			s.logger.Error("operator port check failed", "error", err)
-			s.metrics.IncrementFailedRequestNum("OperatorPortCheck")
-		}
-		errorResponse(c, err)
-		return
+	ctx, cancelTimeout := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancelTimeout()
+	if err := srv.Shutdown(ctx); err != nil {
+		s.logger.Warn("requests did not complete before the shutdown timeout, closing connections", "timeout", s.shutdownTimeout)
+		_ = srv.Close()
+		return fmt.Errorf("failed to shut down server gracefully: %w", err)
 	}
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorPortCheckAge))
-	c.JSON(http.StatusOK, portCheckResponse)
+	s.logger.Info("server shut down")
+	return nil
 }
 
-func (s *ServerV2) FetchNonSingers(c *gin.Context) {
-	errorResponse(c, errors.New("FetchNonSingers unimplemented"))
+// shuttingDown returns a channel that is closed when the server starts shutting down
+func (s *ServerV2) shuttingDown() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shutdownCh
 }
 
-// FetchMetricsSummaryHandler godoc
-//
-//	@Summary	Fetch metrics summary
-//	@Tags		Metrics
-//	@Produce	json
-//	@Param		start	query		int	false	"Start unix timestamp [default: 1 hour ago]"
-//	@Param		end		query		int	false	"End unix timestamp [default: unix time now]"
-//	@Success	200		{object}	Metric
-//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404		{object}	ErrorResponse	"error: Not found"
-//	@Failure	500		{object}	ErrorResponse	"error: Server error"
-//	@Router		/metrics/summary  [get]
-func (s *ServerV2) FetchMetricsSummaryHandler(c *gin.Context) {
-	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
-		s.metrics.ObserveLatency("FetchMetricsSummary", f*1000) // make milliseconds
-	}))
-	defer timer.ObserveDuration()
-
-	now := time.Now()
-	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
-	if err != nil || start == 0 {
-		start = now.Add(-time.Hour * 1).Unix()
-	}
-
-	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
-	if err != nil || end == 0 {
-		end = now.Unix()
-	}
-
-	avgThroughput, err := s.metricsHandler.getAvgThroughput(c.Request.Context(), start, end)
-	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchMetricsSummary")
-		errorResponse(c, err)
-		return
-	}
-
-	metricSummary := &MetricSummary{
-		AvgThroughput: avgThroughput,
-	}
-
-	s.metrics.IncrementSuccessfulRequestNum("FetchMetricsSummary")
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxMetricAage))
-	c.JSON(http.StatusOK, metricSummary)
+// CheckHealth reports the health of each dependency of the server. It responds 200 as long as the server is up,
+// so that an outage of a dependency doesn't get the server restarted.
+func (s *ServerV2) CheckHealth(c *gin.Context) {
+	response := s.checkDependencies(c.Request.Context())
+	c.Writer.Header().Set(cacheControlParam, "no-cache")
+	c.JSON(http.StatusOK, response)
 }
 
-// FetchMetricsThroughputTimeseriesHandler godoc
-//
-//	@Summary	Fetch throughput time series
-//	@Tags		Metrics
-//	@Produce	json
-//	@Param		start	query		int	false	"Start unix timestamp [default: 1 hour ago]"
-//	@Param		end		query		int	false	"End unix timestamp [default: unix time now]"
-//	@Success	200		{object}	[]Throughput
-//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404		{object}	ErrorResponse	"error: Not found"
-//	@Failure	500		{object}	ErrorResponse	"error: Server error"
-//	@Router		/metrics/timeseries/throughput  [get]
-func (s *ServerV2) FetchMetricsThroughputTimeseriesHandler(c *gin.Context) {
-	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
-		s.metrics.ObserveLatency("FetchMetricsThroughputTimeseriesHandler", f*1000) // make milliseconds
-	}))
-	defer timer.ObserveDuration()
-
-	now := time.Now()
-	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
-	if err != nil || start == 0 {
-		start = now.Add(-time.Hour * 1).Unix()
-	}
-
-	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
-	if err != nil || end == 0 {
-		end = now.Unix()
-	}
-
-	ths, err := s.metricsHandler.getThroughputTimeseries(c.Request.Context(), start, end)
-	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchMetricsThroughputTimeseriesHandler")
-		errorResponse(c, err)
-		return
-	}
-
-	s.metrics.IncrementSuccessfulRequestNum("FetchMetricsThroughputTimeseriesHandler")
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxThroughputAge))
-	c.JSON(http.StatusOK, ths)
+// CheckReadiness reports the health of each dependency of the server like CheckHealth, but responds 503 if any
+// dependency is unhealthy, so that traffic is routed to other replicas.
+func (s *ServerV2) CheckReadiness(c *gin.Context) {
+	response := s.checkDependencies(c.Request.Context())
+	code := http.StatusOK
+	if response.Status != healthStatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	c.Writer.Header().Set(cacheControlParam, "no-cache")
+	c.JSON(code, response)
 }
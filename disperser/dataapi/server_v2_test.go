@@ -1,37 +1,78 @@
 package dataapi_test
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	nodegrpc "github.com/Layr-Labs/eigenda/api/grpc/node"
+	nodegrpcv2 "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/store"
 	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/apikey"
+	prommock "github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus/mock"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	subgraphmock "github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph/mock"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/inabox/deploy"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/klauspost/compress/zstd"
 	"github.com/ory/dockertest/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/model"
+	"github.com/shurcooL/graphql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-merkletree/v2"
+	"github.com/wealdtech/go-merkletree/v2/keccak256"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 var (
@@ -95,7 +136,28 @@ func setup(m *testing.M) {
 		panic("failed to create dynamodb client: " + err.Error())
 	}
 	blobMetadataStore = blobstorev2.NewBlobMetadataStore(dynamoClient, logger, metadataTableName)
-	testDataApiServerV2 = dataapi.NewServerV2(config, blobMetadataStore, prometheusClient, subgraphClient, mockTx, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger))
+	testDataApiServerV2 = dataapi.NewServerV2(config, testServerV2Options())
+}
+
+// testServerV2Options returns the options of the shared test server, for the tests to override
+func testServerV2Options() dataapi.ServerV2Options {
+	return dataapi.ServerV2Options{
+		BlobMetadataStore: blobMetadataStore,
+		PromClient:        prometheusClient,
+		SubgraphClient:    subgraphClient,
+		ChainReader:       mockTx,
+		ChainState:        mockChainState,
+		IndexedChainState: mockIndexedChainState,
+		Logger:            mockLogger,
+		Metrics:           dataapi.NewMetrics(nil, "9001", mockLogger),
+	}
+}
+
+// decodeErrorResponse decodes the error reported in the response
+func decodeErrorResponse(t *testing.T, w *httptest.ResponseRecorder) *dataapi.ErrorResponse {
+	var response dataapi.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return &response
 }
 
 // makeCommitment returns a test hardcoded BlobCommitments
@@ -196,6 +258,365 @@ func TestFetchBlobHandlerV2(t *testing.T) {
 	assert.Equal(t, blobHeader.PaymentMetadata.AccountID, response.BlobHeader.PaymentMetadata.AccountID)
 	assert.Equal(t, blobHeader.PaymentMetadata.ReservationPeriod, response.BlobHeader.PaymentMetadata.ReservationPeriod)
 	assert.Equal(t, blobHeader.PaymentMetadata.CumulativePayment, response.BlobHeader.PaymentMetadata.CumulativePayment)
+
+	// Errors carry a code
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/blobs/xyz", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	errResponse := decodeErrorResponse(t, w)
+	assert.Equal(t, dataapi.ErrCodeInvalidArgument, errResponse.Code)
+	assert.False(t, errResponse.Retryable)
+	assert.Equal(t, errResponse.Message, errResponse.Error)
+
+	unknownKey, err := makeBlobHeaderV2(t).BlobKey()
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/blobs/"+unknownKey.Hex(), nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, dataapi.ErrCodeNotFound, decodeErrorResponse(t, w).Code)
+}
+
+func TestLookupBlobsHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Set up the metadata of two blobs, and the key of a blob not in the store
+	now := time.Now()
+	keys := make([]string, 0)
+	statuses := []commonv2.BlobStatus{commonv2.Queued, commonv2.Certified}
+	for _, status := range statuses {
+		blobHeader := makeBlobHeaderV2(t)
+		err := blobMetadataStore.PutBlobMetadata(ctx, &commonv2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			BlobStatus:  status,
+			Expiry:      uint64(now.Add(time.Hour).Unix()),
+			BlobSize:    1024,
+			RequestedAt: uint64(now.UnixNano()),
+			UpdatedAt:   uint64(now.UnixNano()),
+		})
+		require.NoError(t, err)
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		keys = append(keys, blobKey.Hex())
+	}
+	unknownKey, err := makeBlobHeaderV2(t).BlobKey()
+	require.NoError(t, err)
+
+	r.POST("/v2/blobs/lookup", testDataApiServerV2.LookupBlobsHandler)
+	lookup := func(blobKeys []string) (int, *dataapi.BlobLookupResponse) {
+		body, err := json.Marshal(dataapi.BlobLookupRequest{BlobKeys: blobKeys})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v2/blobs/lookup", strings.NewReader(string(body)))
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		var response dataapi.BlobLookupResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, &response
+	}
+
+	// Results are in the order of the keys, with an error for invalid and unknown keys
+	requested := []string{keys[1], "xyz", unknownKey.Hex(), keys[0], keys[1]}
+	code, response := lookup(requested)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Results, len(requested))
+	for i, result := range response.Results {
+		assert.Equal(t, requested[i], result.BlobKey)
+	}
+	for _, i := range []int{0, 4} {
+		require.NotNil(t, response.Results[i].Blob)
+		assert.Empty(t, response.Results[i].Error)
+		assert.Equal(t, "Certified", response.Results[i].Blob.Status)
+		assert.Equal(t, uint64(1024), response.Results[i].Blob.BlobSizeBytes)
+	}
+	assert.Nil(t, response.Results[1].Blob)
+	assert.Contains(t, response.Results[1].Error, "invalid blob key")
+	assert.Nil(t, response.Results[2].Blob)
+	assert.Equal(t, "not found", response.Results[2].Error)
+	require.NotNil(t, response.Results[3].Blob)
+	assert.Equal(t, "Queued", response.Results[3].Blob.Status)
+
+	// Requests without keys, or with too many keys, are rejected
+	code, _ = lookup(nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = keys[0]
+	}
+	code, _ = lookup(tooMany)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v2/blobs/lookup", strings.NewReader("not json")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFetchBlobFeedHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Set up blobs requested one second apart, ending one minute ago
+	numBlobs := 30
+	now := time.Now()
+	firstBlobTime := now.Add(-time.Minute).Add(-time.Duration(numBlobs) * time.Second)
+	keys := make([]corev2.BlobKey, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		blobHeader := makeBlobHeaderV2(t)
+		requestedAt := uint64(firstBlobTime.Add(time.Duration(i) * time.Second).UnixNano())
+		metadata := &commonv2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			BlobStatus:  commonv2.Encoded,
+			Expiry:      uint64(now.Add(time.Hour).Unix()),
+			NumRetries:  0,
+			RequestedAt: requestedAt,
+			UpdatedAt:   requestedAt,
+		}
+		err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+		keys[i], err = blobHeader.BlobKey()
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/blobs/feed", testDataApiServerV2.FetchBlobFeedHandler)
+
+	fetchFeed := func(query string) (int, dataapi.BlobFeedResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/blobs/feed"+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var response dataapi.BlobFeedResponse
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(data, &response))
+		}
+		return res.StatusCode, response
+	}
+
+	// Invalid params
+	code, _ := fetchFeed("?limit=0")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetchFeed("?before=yesterday")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetchFeed("?cursor=@@@")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetchFeed("?cursor=" + strings.Repeat("a", 513))
+	assert.Equal(t, http.StatusBadRequest, code)
+	futureCursor := (&blobstorev2.BlobFeedCursor{RequestedAt: uint64(now.Add(time.Hour).UnixNano())}).ToCursorKey()
+	code, _ = fetchFeed("?cursor=" + base64.URLEncoding.EncodeToString([]byte(futureCursor)))
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Page through the feed in a window that contains exactly the test blobs
+	after := firstBlobTime.Add(-time.Second).UTC().Format("2006-01-02T15:04:05Z")
+	before := now.Add(-time.Second).UTC().Format("2006-01-02T15:04:05Z")
+	query := fmt.Sprintf("?after=%s&before=%s&limit=20", after, before)
+	code, response := fetchFeed(query)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Blobs, 20)
+	require.NotEmpty(t, response.PaginationToken)
+	assert.Equal(t, dataapi.Pagination{Limit: 20, HasMore: true, NextToken: response.PaginationToken}, response.Pagination)
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, keys[i].Hex(), response.Blobs[i].BlobKey)
+	}
+
+	cursor := response.Pagination.NextToken
+	code, response = fetchFeed(query + "&cursor=" + cursor)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Blobs, numBlobs-20)
+	assert.Empty(t, response.PaginationToken)
+	assert.Equal(t, dataapi.Pagination{Cursor: cursor, Limit: 20}, response.Pagination)
+	for i := 20; i < numBlobs; i++ {
+		assert.Equal(t, keys[i].Hex(), response.Blobs[i-20].BlobKey)
+	}
+
+	// Exports stream the whole range, regardless of limit
+	export := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/blobs/feed"+query, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w
+	}
+	code, _ = fetchFeed("?format=xml")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	w := export(query + "&format=csv")
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, numBlobs+1)
+	assert.Equal(t, "blob_key", records[0][0])
+	for i := 0; i < numBlobs; i++ {
+		assert.Equal(t, keys[i].Hex(), records[i+1][0])
+		assert.Equal(t, "Encoded", records[i+1][4])
+	}
+
+	w = export(query + "&format=ndjson")
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, numBlobs)
+	for i, line := range lines {
+		var blob dataapi.BlobInfo
+		require.NoError(t, json.Unmarshal([]byte(line), &blob))
+		assert.Equal(t, keys[i].Hex(), blob.BlobKey)
+	}
+}
+
+func TestFetchBlobStreamHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	r.GET("/v2/blob/stream", testDataApiServerV2.FetchBlobStreamHandler)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	streamCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, ts.URL+"/v2/blob/stream", nil)
+	require.NoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	// Blobs certified after subscribing are pushed to the stream
+	now := time.Now()
+	blobHeader := makeBlobHeaderV2(t)
+	metadata := &commonv2.BlobMetadata{
+		BlobHeader:  blobHeader,
+		BlobStatus:  commonv2.Encoded,
+		Expiry:      uint64(now.Add(time.Hour).Unix()),
+		NumRetries:  0,
+		RequestedAt: uint64(now.UnixNano()),
+		UpdatedAt:   uint64(now.UnixNano()),
+	}
+	err = blobMetadataStore.PutBlobMetadata(ctx, metadata)
+	require.NoError(t, err)
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	err = blobMetadataStore.UpdateBlobStatus(ctx, blobKey, commonv2.Certified)
+	require.NoError(t, err)
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	event := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "event:") {
+			event = strings.TrimPrefix(line, "event:")
+			continue
+		}
+		if event != "blob" || !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		var blob dataapi.BlobInfo
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &blob))
+		if blob.BlobKey != blobKey.Hex() {
+			continue
+		}
+		assert.Equal(t, commonv2.Certified, blob.BlobMetadata.BlobStatus)
+		assert.Equal(t, metadata.RequestedAt, blob.BlobMetadata.RequestedAt)
+		return
+	}
+	t.Fatal("stream ended before the certified blob was received")
+}
+
+func TestFetchAccountBlobsHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Set up blobs of one account requested one second apart, every other one certified
+	accountID := "0x0123456789abcdef0123456789abcdef01234567"
+	numBlobs := 6
+	now := time.Now()
+	keys := make([]corev2.BlobKey, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		blobHeader := makeBlobHeaderV2(t)
+		blobHeader.PaymentMetadata.AccountID = accountID
+		status := commonv2.Queued
+		if i%2 == 1 {
+			status = commonv2.Certified
+		}
+		requestedAt := uint64(now.Add(-time.Duration(numBlobs-i) * time.Second).UnixNano())
+		metadata := &commonv2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			BlobStatus:  status,
+			Expiry:      uint64(now.Add(time.Hour).Unix()),
+			NumRetries:  0,
+			RequestedAt: requestedAt,
+			UpdatedAt:   requestedAt,
+		}
+		err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+		keys[i], err = blobHeader.BlobKey()
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/accounts/:account_id/blobs", testDataApiServerV2.FetchAccountBlobsHandler)
+
+	fetchBlobs := func(account, query string) (int, dataapi.BlobFeedResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/accounts/"+account+"/blobs"+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var response dataapi.BlobFeedResponse
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(data, &response))
+		}
+		return res.StatusCode, response
+	}
+
+	// Invalid params
+	code, _ := fetchBlobs(accountID, "?limit=0")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetchBlobs(accountID, "?status=pending")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetchBlobs(accountID, "?cursor=@@@")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Unknown account has no blobs
+	code, response := fetchBlobs("0xffffffffffffffffffffffffffffffffffffffff", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, response.Blobs, 0)
+	assert.Empty(t, response.PaginationToken)
+
+	// Paginate through all blobs, newest first
+	code, response = fetchBlobs(accountID, "?limit=4")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Blobs, 4)
+	require.NotEmpty(t, response.PaginationToken)
+	assert.True(t, response.Pagination.HasMore)
+	assert.Equal(t, response.PaginationToken, response.Pagination.NextToken)
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, keys[numBlobs-1-i].Hex(), response.Blobs[i].BlobKey)
+	}
+	code, response = fetchBlobs(accountID, "?limit=4&cursor="+response.PaginationToken)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Blobs, 2)
+	assert.Empty(t, response.PaginationToken)
+	assert.False(t, response.Pagination.HasMore)
+	assert.Equal(t, 4, response.Pagination.Limit)
+	assert.Equal(t, keys[1].Hex(), response.Blobs[0].BlobKey)
+	assert.Equal(t, keys[0].Hex(), response.Blobs[1].BlobKey)
+
+	// Filter by status
+	code, response = fetchBlobs(accountID, "?status=Certified")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Blobs, 3)
+	for i, blob := range response.Blobs {
+		assert.Equal(t, keys[numBlobs-1-2*i].Hex(), blob.BlobKey)
+		assert.Equal(t, commonv2.Certified, blob.BlobMetadata.BlobStatus)
+	}
+	code, response = fetchBlobs(accountID, "?status=queued,certified")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, response.Blobs, numBlobs)
 }
 
 func TestFetchBlobCertificateHandler(t *testing.T) {
@@ -216,6 +637,13 @@ func TestFetchBlobCertificateHandler(t *testing.T) {
 	err = blobMetadataStore.PutBlobCertificate(context.Background(), blobCert, fragmentInfo)
 	require.NoError(t, err)
 
+	// Relay 4 is not in the registry
+	mockTx.On("GetRelayURLs").Return(map[uint32]string{
+		0: "relay0.eigenda.xyz:443",
+		1: "relay1.eigenda.xyz:443",
+		2: "relay2.eigenda.xyz:443",
+	}, nil).Once()
+
 	r.GET("/v2/blobs/:blob_key/certificate", testDataApiServerV2.FetchBlobCertificateHandler)
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/v2/blobs/"+blobKey.Hex()+"/certificate", nil)
@@ -234,6 +662,16 @@ func TestFetchBlobCertificateHandler(t *testing.T) {
 	assert.Equal(t, blobCert.RelayKeys, response.Certificate.RelayKeys)
 	assert.Equal(t, uint16(0), response.Certificate.BlobHeader.BlobVersion)
 	assert.Equal(t, blobHeader.Signature, response.Certificate.BlobHeader.Signature)
+	assert.Equal(t, []*dataapi.BlobRelay{
+		{RelayKey: 0, Url: "relay0.eigenda.xyz:443"},
+		{RelayKey: 2, Url: "relay2.eigenda.xyz:443"},
+		{RelayKey: 4, Url: ""},
+	}, response.Relays)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/blobs/xyz/certificate", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestFetchBlobVerificationInfoHandler(t *testing.T) {
@@ -284,6 +722,109 @@ func TestFetchBlobVerificationInfoHandler(t *testing.T) {
 	assert.Equal(t, verificationInfo.InclusionProof, response.VerificationInfo.InclusionProof)
 }
 
+func TestFetchBlobInclusionHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Set up a batch of blob certificates along with its merkle tree
+	numBlobs := 3
+	certs := make([]*corev2.BlobCertificate, numBlobs)
+	keys := make([]corev2.BlobKey, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		blobHeader := makeBlobHeaderV2(t)
+		certs[i] = &corev2.BlobCertificate{
+			BlobHeader: blobHeader,
+			RelayKeys:  []corev2.RelayKey{0},
+		}
+		err := blobMetadataStore.PutBlobCertificate(ctx, certs[i], &encoding.FragmentInfo{})
+		require.NoError(t, err)
+		keys[i], err = blobHeader.BlobKey()
+		require.NoError(t, err)
+	}
+	tree, err := corev2.BuildMerkleTree(certs)
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		ReferenceBlockNumber: 4000,
+	}
+	copy(batchHeader.BatchRoot[:], tree.Root())
+	err = blobMetadataStore.PutBatchHeader(ctx, batchHeader)
+	require.NoError(t, err)
+	batchHeaderHash, err := batchHeader.Hash()
+	require.NoError(t, err)
+	for i := 0; i < numBlobs; i++ {
+		proof, err := tree.GenerateProofWithIndex(uint64(i), 0)
+		require.NoError(t, err)
+		err = blobMetadataStore.PutBlobVerificationInfo(ctx, &corev2.BlobVerificationInfo{
+			BatchHeader:    batchHeader,
+			BlobKey:        keys[i],
+			BlobIndex:      uint32(i),
+			InclusionProof: core.SerializeMerkleProof(proof),
+		})
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/blobs/:blob_key/inclusion", testDataApiServerV2.FetchBlobInclusionHandler)
+
+	fetchInclusion := func(blobKey string) (int, dataapi.BlobInclusionResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/blobs/"+blobKey+"/inclusion", nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var response dataapi.BlobInclusionResponse
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(data, &response))
+		}
+		return res.StatusCode, response
+	}
+
+	// Invalid blob key
+	code, _ := fetchInclusion("xyz")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Unknown blob
+	code, _ = fetchInclusion(corev2.BlobKey{9, 9, 9}.Hex())
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// The batch has not been attested yet
+	code, _ = fetchInclusion(keys[1].Hex())
+	assert.Equal(t, http.StatusNotFound, code)
+
+	err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader:   batchHeader,
+		AttestedAt:    uint64(time.Now().UnixNano()),
+		QuorumNumbers: []core.QuorumID{0, 1},
+		QuorumResults: map[core.QuorumID]uint8{0: 100, 1: 100},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(2), big.NewInt(0)),
+		},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < numBlobs; i++ {
+		code, response := fetchInclusion(keys[i].Hex())
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, keys[i].Hex(), response.BlobKey)
+		assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.BatchHeaderHash)
+		assert.Equal(t, batchHeader.BatchRoot, response.BatchHeader.BatchRoot)
+		assert.Equal(t, uint32(i), response.BlobIndex)
+
+		// The proof verifies the certificate hash against the batch root
+		certHash, err := certs[i].Hash()
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(certHash[:]), response.BlobCertificateHash)
+		proofBytes, err := hex.DecodeString(response.InclusionProof)
+		require.NoError(t, err)
+		proof, err := core.DeserializeMerkleProof(proofBytes, uint64(response.BlobIndex))
+		require.NoError(t, err)
+		verified, err := merkletree.VerifyProofUsing(certHash[:], false, proof, [][]byte{response.BatchHeader.BatchRoot[:]}, keccak256.New())
+		require.NoError(t, err)
+		assert.True(t, verified)
+	}
+}
+
 func TestFetchBatchHandlerV2(t *testing.T) {
 	r := setUpRouter()
 
@@ -319,6 +860,24 @@ func TestFetchBatchHandlerV2(t *testing.T) {
 	err = blobMetadataStore.PutAttestation(context.Background(), attestation)
 	require.NoError(t, err)
 
+	// Set up blob verification infos in metadata store
+	verificationInfos := []*corev2.BlobVerificationInfo{
+		{
+			BatchHeader:    batchHeader,
+			BlobKey:        corev2.BlobKey{1, 0, 2, 4, 1},
+			BlobIndex:      1,
+			InclusionProof: []byte("proof 1"),
+		},
+		{
+			BatchHeader:    batchHeader,
+			BlobKey:        corev2.BlobKey{1, 0, 2, 4, 0},
+			BlobIndex:      0,
+			InclusionProof: []byte("proof 0"),
+		},
+	}
+	err = blobMetadataStore.PutBlobVerificationInfos(context.Background(), verificationInfos)
+	require.NoError(t, err)
+
 	r.GET("/v2/batches/:batch_header_hash", testDataApiServerV2.FetchBatchHandler)
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/v2/batches/"+batchHeaderHash, nil)
@@ -339,92 +898,1878 @@ func TestFetchBatchHandlerV2(t *testing.T) {
 	assert.Equal(t, batchHeader.ReferenceBlockNumber, response.SignedBatch.BatchHeader.ReferenceBlockNumber)
 	assert.Equal(t, attestation.AttestedAt, response.SignedBatch.Attestation.AttestedAt)
 	assert.Equal(t, attestation.QuorumNumbers, response.SignedBatch.Attestation.QuorumNumbers)
+	require.Len(t, response.BlobVerificationInfos, 2)
+	assert.Equal(t, verificationInfos[1].BlobKey, response.BlobVerificationInfos[0].BlobKey)
+	assert.Equal(t, uint32(0), response.BlobVerificationInfos[0].BlobIndex)
+	assert.Equal(t, verificationInfos[1].InclusionProof, response.BlobVerificationInfos[0].InclusionProof)
+	assert.Equal(t, verificationInfos[0].BlobKey, response.BlobVerificationInfos[1].BlobKey)
+	assert.Equal(t, uint32(1), response.BlobVerificationInfos[1].BlobIndex)
 }
 
-func TestCheckOperatorsReachability(t *testing.T) {
+func TestFetchBatchBlobsHandler(t *testing.T) {
 	r := setUpRouter()
+	ctx := context.Background()
 
-	mockSubgraphApi.ExpectedCalls = nil
-	mockSubgraphApi.Calls = nil
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1, 0, 2, 4, 8},
+		ReferenceBlockNumber: 1024,
+	}
+	batchHeaderHashBytes, err := batchHeader.Hash()
+	require.NoError(t, err)
+	batchHeaderHash := hex.EncodeToString(batchHeaderHashBytes[:])
 
-	operator_id := "0xa96bfb4a7ca981ad365220f336dc5a3de0816ebd5130b79bbc85aca94bc9b6ab"
-	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(operatorInfo, nil)
+	// Three blobs in the batch, the last of which has no metadata stored
+	numBlobs := 3
+	blobKeys := make([]corev2.BlobKey, numBlobs)
+	verificationInfos := make([]*corev2.BlobVerificationInfo, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		blobHeader := makeBlobHeaderV2(t)
+		blobKeys[i], err = blobHeader.BlobKey()
+		require.NoError(t, err)
+		if i < numBlobs-1 {
+			err = blobMetadataStore.PutBlobMetadata(ctx, &commonv2.BlobMetadata{
+				BlobHeader: blobHeader,
+				BlobStatus: commonv2.Certified,
+				BlobSize:   uint64(1000 * (i + 1)),
+				Expiry:     uint64(time.Now().Add(time.Hour).Unix()),
+				UpdatedAt:  uint64(time.Now().UnixNano()),
+			})
+			require.NoError(t, err)
+		}
+		// Stored out of order
+		verificationInfos[numBlobs-1-i] = &corev2.BlobVerificationInfo{
+			BatchHeader:    batchHeader,
+			BlobKey:        blobKeys[i],
+			BlobIndex:      uint32(i),
+			InclusionProof: []byte("proof"),
+		}
+	}
+	err = blobMetadataStore.PutBlobVerificationInfos(ctx, verificationInfos)
+	require.NoError(t, err)
 
-	r.GET("/v2/operators/reachability", testDataApiServerV2.CheckOperatorsReachability)
+	r.GET("/v2/batches/:batch_header_hash/blobs", testDataApiServerV2.FetchBatchBlobsHandler)
+	fetch := func(query string) (int, dataapi.BatchBlobsResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/batches/"+query, nil)
+		r.ServeHTTP(w, req)
+		var response dataapi.BatchBlobsResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response
+	}
 
-	w := httptest.NewRecorder()
-	reqStr := fmt.Sprintf("/v2/operators/reachability?operator_id=%v", operator_id)
-	req := httptest.NewRequest(http.MethodGet, reqStr, nil)
-	ctxWithDeadline, cancel := context.WithTimeout(req.Context(), 500*time.Microsecond)
-	defer cancel()
-	req = req.WithContext(ctxWithDeadline)
-	r.ServeHTTP(w, req)
-	assert.Equal(t, w.Code, http.StatusOK)
+	code, response := fetch(batchHeaderHash + "/blobs?limit=2")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, batchHeaderHash, response.BatchHeaderHash)
+	require.Len(t, response.Blobs, 2)
+	for i, blob := range response.Blobs {
+		assert.Equal(t, blobKeys[i].Hex(), blob.BlobKey)
+		assert.Equal(t, uint32(i), blob.BlobIndex)
+		assert.Equal(t, "Certified", blob.Status)
+		assert.Equal(t, uint64(1000*(i+1)), blob.BlobSizeBytes)
+		require.NotNil(t, blob.BlobHeader)
+		assert.Equal(t, []core.QuorumID{0, 1}, blob.BlobHeader.QuorumNumbers)
+	}
+	assert.True(t, response.Pagination.HasMore)
+	assert.Equal(t, response.Pagination.NextToken, response.PaginationToken)
+
+	code, response = fetch(batchHeaderHash + "/blobs?limit=2&cursor=" + response.Pagination.NextToken)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Blobs, 1)
+	assert.Equal(t, blobKeys[2].Hex(), response.Blobs[0].BlobKey)
+	assert.Equal(t, uint32(2), response.Blobs[0].BlobIndex)
+	assert.Empty(t, response.Blobs[0].Status)
+	assert.Nil(t, response.Blobs[0].BlobHeader)
+	assert.False(t, response.Pagination.HasMore)
+	assert.Empty(t, response.PaginationToken)
+
+	code, _ = fetch(batchHeaderHash + "/blobs?cursor=invalid")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetch("invalid/blobs")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetch(hex.EncodeToString(make([]byte, 32)) + "/blobs")
+	assert.Equal(t, http.StatusNotFound, code)
+}
 
-	res := w.Result()
-	defer res.Body.Close()
+func TestGraphQLHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
 
-	data, err := io.ReadAll(res.Body)
-	assert.NoError(t, err)
+	// Set up a signed batch with one blob in metadata store
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{3, 1, 4, 1},
+		ReferenceBlockNumber: 2048,
+	}
+	err := blobMetadataStore.PutBatchHeader(ctx, batchHeader)
+	require.NoError(t, err)
+	batchHeaderHashBytes, err := batchHeader.Hash()
+	require.NoError(t, err)
+	batchHeaderHash := hex.EncodeToString(batchHeaderHashBytes[:])
 
-	var response dataapi.OperatorPortCheckResponse
-	err = json.Unmarshal(data, &response)
-	assert.NoError(t, err)
-	assert.NotNil(t, response)
+	commitment := makeCommitment(t)
+	nonSigner := core.NewG1Point(big.NewInt(1), big.NewInt(2))
+	attestation := &corev2.Attestation{
+		BatchHeader:      batchHeader,
+		AttestedAt:       uint64(time.Now().UnixNano()),
+		NonSignerPubKeys: []*core.G1Point{nonSigner},
+		APKG2: &core.G2Point{
+			G2Affine: &bn254.G2Affine{
+				X: commitment.LengthCommitment.X,
+				Y: commitment.LengthCommitment.Y,
+			},
+		},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(2), big.NewInt(0)),
+		},
+		QuorumNumbers: []core.QuorumID{0, 1},
+		QuorumResults: map[core.QuorumID]uint8{0: 100, 1: 80},
+	}
+	err = blobMetadataStore.PutAttestation(ctx, attestation)
+	require.NoError(t, err)
 
-	assert.Equal(t, "23.93.76.1:32005", response.DispersalSocket)
-	assert.Equal(t, false, response.DispersalOnline)
-	assert.Equal(t, "23.93.76.1:32006", response.RetrievalSocket)
-	assert.Equal(t, false, response.RetrievalOnline)
+	blobHeader := makeBlobHeaderV2(t)
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	metadata := &commonv2.BlobMetadata{
+		BlobHeader:  blobHeader,
+		BlobStatus:  commonv2.Certified,
+		Expiry:      uint64(time.Now().Add(time.Hour).Unix()),
+		BlobSize:    4096,
+		RequestedAt: 123,
+		UpdatedAt:   456,
+	}
+	err = blobMetadataStore.PutBlobMetadata(ctx, metadata)
+	require.NoError(t, err)
+	err = blobMetadataStore.PutBlobVerificationInfos(ctx, []*corev2.BlobVerificationInfo{
+		{
+			BatchHeader:    batchHeader,
+			BlobKey:        blobKey,
+			BlobIndex:      0,
+			InclusionProof: []byte("proof"),
+		},
+	})
+	require.NoError(t, err)
 
-	mockSubgraphApi.ExpectedCalls = nil
-	mockSubgraphApi.Calls = nil
+	r.POST("/v2/graphql", testDataApiServerV2.GraphQLHandler)
+
+	query := func(t *testing.T, q string, variables map[string]interface{}) map[string]interface{} {
+		body, err := json.Marshal(dataapi.GraphQLRequest{Query: q, Variables: variables})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v2/graphql", strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		return response
+	}
+
+	t.Run("batch with nested blobs", func(t *testing.T) {
+		response := query(t, `query ($hash: String!) {
+			batch(hash: $hash) {
+				hash
+				referenceBlockNumber
+				attestation { quorumNumbers quorumResults { quorum signedPercentage } nonSigners }
+				blobs { key status dispersedAt sizeBytes header { quorumNumbers accountId } batches { hash } }
+			}
+		}`, map[string]interface{}{"hash": batchHeaderHash})
+		require.Nil(t, response["errors"])
+
+		batch := response["data"].(map[string]interface{})["batch"].(map[string]interface{})
+		assert.Equal(t, batchHeaderHash, batch["hash"])
+		assert.Equal(t, float64(2048), batch["referenceBlockNumber"])
+
+		at := batch["attestation"].(map[string]interface{})
+		assert.Equal(t, []interface{}{float64(0), float64(1)}, at["quorumNumbers"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"quorum": float64(0), "signedPercentage": float64(100)},
+			map[string]interface{}{"quorum": float64(1), "signedPercentage": float64(80)},
+		}, at["quorumResults"])
+		assert.Equal(t, []interface{}{nonSigner.GetOperatorID().Hex()}, at["nonSigners"])
+
+		blobs := batch["blobs"].([]interface{})
+		require.Len(t, blobs, 1)
+		blob := blobs[0].(map[string]interface{})
+		assert.Equal(t, blobKey.Hex(), blob["key"])
+		assert.Equal(t, "Certified", blob["status"])
+		assert.Equal(t, float64(123), blob["dispersedAt"])
+		assert.Equal(t, float64(4096), blob["sizeBytes"])
+		header := blob["header"].(map[string]interface{})
+		assert.Equal(t, []interface{}{float64(0), float64(1)}, header["quorumNumbers"])
+		assert.Equal(t, blobHeader.PaymentMetadata.AccountID, header["accountId"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"hash": batchHeaderHash}}, blob["batches"])
+	})
+
+	t.Run("missing blob", func(t *testing.T) {
+		response := query(t, `{ blob(key: "`+corev2.BlobKey{9}.Hex()+`") { key } }`, nil)
+		require.Nil(t, response["errors"])
+		assert.Equal(t, map[string]interface{}{"blob": nil}, response["data"])
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		response := query(t, `{ blob { key } }`, nil)
+		assert.NotEmpty(t, response["errors"])
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v2/graphql", strings.NewReader("not json"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
-func TestFetchOperatorsStake(t *testing.T) {
+func TestAPIKeyAuth(t *testing.T) {
+	ctx := context.Background()
+	store := apikey.NewLocalStore()
+	err := store.PutKey(ctx, &apikey.APIKey{KeyHash: apikey.HashKey("limited"), Name: "limited", DailyQuota: 2})
+	require.NoError(t, err)
+	err = store.PutKey(ctx, &apikey.APIKey{KeyHash: apikey.HashKey("unlimited"), Name: "unlimited"})
+	require.NoError(t, err)
+	err = store.PutKey(ctx, &apikey.APIKey{KeyHash: apikey.HashKey("disabled"), Name: "disabled", Disabled: true})
+	require.NoError(t, err)
+
+	opts := testServerV2Options()
+	opts.APIKeyStore = store
+	server := dataapi.NewServerV2(config, opts)
 	r := setUpRouter()
+	v2 := r.Group("/v2")
+	v2.Use(server.APIKeyAuth("/v2/swagger"))
+	v2.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+	v2.GET("/swagger/index.html", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(path string, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
 
-	mockIndexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+	t.Run("missing key", func(t *testing.T) {
+		w := get("/v2/ping", "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		response := decodeErrorResponse(t, w)
+		assert.Equal(t, dataapi.ErrCodeUnauthenticated, response.Code)
+		assert.Equal(t, "missing API key", response.Message)
+		assert.False(t, response.Retryable)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, get("/v2/ping", "unknown").Code)
+	})
+
+	t.Run("disabled key", func(t *testing.T) {
+		w := get("/v2/ping", "disabled")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, dataapi.ErrCodePermissionDenied, decodeErrorResponse(t, w).Code)
+	})
+
+	t.Run("swagger is not authenticated", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, get("/v2/swagger/index.html", "").Code)
+	})
+
+	t.Run("key in query param", func(t *testing.T) {
+		w := get("/v2/ping?api_key=unlimited", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("X-Quota-Limit"))
+	})
+
+	t.Run("quota", func(t *testing.T) {
+		w := get("/v2/ping", "limited")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-Quota-Limit"))
+		assert.Equal(t, "1", w.Header().Get("X-Quota-Remaining"))
+		w = get("/v2/ping", "limited")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "0", w.Header().Get("X-Quota-Remaining"))
+		w = get("/v2/ping", "limited")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+		response := decodeErrorResponse(t, w)
+		assert.Equal(t, dataapi.ErrCodeQuotaExceeded, response.Code)
+		assert.True(t, response.Retryable)
+		assert.Equal(t, w.Header().Get("Retry-After"), response.Details["retry_after_seconds"])
+
+		day := uint64(time.Now().UTC().Truncate(24 * time.Hour).Unix())
+		usage, err := store.GetUsage(ctx, apikey.HashKey("limited"), day)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), usage)
+	})
+}
 
-	r.GET("/v2/operators/stake", testDataApiServerV2.FetchOperatorsStake)
-	w := httptest.NewRecorder()
-	req := httptest.NewRequest(http.MethodGet, "/v2/operators/stake", nil)
-	r.ServeHTTP(w, req)
-	assert.Equal(t, w.Code, http.StatusOK)
-	res := w.Result()
-	defer res.Body.Close()
-	data, err := io.ReadAll(res.Body)
-	assert.NoError(t, err)
+func TestRateLimit(t *testing.T) {
+	newRouter := func(ipRate, keyRate uint32) *gin.Engine {
+		bucketStore, err := store.NewLocalParamStore[common.RateBucketParams](100)
+		require.NoError(t, err)
+		rateLimitConfig := config
+		rateLimitConfig.RateLimiterConfig = ratelimit.Config{
+			GlobalRateParams: common.GlobalRateParams{
+				BucketSizes: []time.Duration{2 * time.Second},
+				Multipliers: []float32{1},
+			},
+		}
+		rateLimitConfig.IPRequestRate = ipRate
+		rateLimitConfig.APIKeyRequestRate = keyRate
+		opts := testServerV2Options()
+		opts.BucketStore = bucketStore
+		server := dataapi.NewServerV2(rateLimitConfig, opts)
+		r := setUpRouter()
+		r.Use(server.RateLimit())
+		r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+		return r
+	}
+	get := func(r *gin.Engine, ip string, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("X-Forwarded-For", ip)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
 
-	var response dataapi.OperatorsStakeResponse
-	err = json.Unmarshal(data, &response)
-	assert.NoError(t, err)
-	assert.NotNil(t, response)
+	t.Run("per IP", func(t *testing.T) {
+		// A 2s bucket at 2 requests/sec lets through a burst of at least 3 requests
+		r := newRouter(2, 0)
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, get(r, "10.0.0.1", "").Code)
+		}
+		var w *httptest.ResponseRecorder
+		for i := 0; i < 3; i++ {
+			w = get(r, "10.0.0.1", "")
+		}
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		response := decodeErrorResponse(t, w)
+		assert.Equal(t, dataapi.ErrCodeRateLimited, response.Code)
+		assert.True(t, response.Retryable)
+		assert.Equal(t, map[string]string{"retry_after_seconds": "1"}, response.Details)
+		// Other clients are not limited
+		assert.Equal(t, http.StatusOK, get(r, "10.0.0.2", "").Code)
+	})
+
+	t.Run("per API key", func(t *testing.T) {
+		r := newRouter(0, 2)
+		var w *httptest.ResponseRecorder
+		for i := 0; i < 6; i++ {
+			w = get(r, fmt.Sprintf("10.0.1.%d", i), "key")
+		}
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		// Requests without a key and with other keys are not limited
+		for i := 0; i < 6; i++ {
+			assert.Equal(t, http.StatusOK, get(r, "10.0.1.1", "").Code)
+		}
+		assert.Equal(t, http.StatusOK, get(r, "10.0.1.1", "other").Code)
+	})
+}
 
-	// The quorums and the operators in the quorum are defined in "mockChainState"
-	// There are 3 quorums (0, 1) and a "total" entry for TotalQuorumStake
-	assert.Equal(t, 3, len(response.StakeRankedOperators))
-	// Quorum 0
-	ops, ok := response.StakeRankedOperators["0"]
-	assert.True(t, ok)
-	assert.Equal(t, 2, len(ops))
-	assert.Equal(t, opId0.Hex(), ops[0].OperatorId)
-	assert.Equal(t, opId1.Hex(), ops[1].OperatorId)
-	// Quorum 1
-	ops, ok = response.StakeRankedOperators["1"]
-	assert.True(t, ok)
-	assert.Equal(t, 2, len(ops))
-	assert.Equal(t, opId1.Hex(), ops[0].OperatorId)
-	assert.Equal(t, opId0.Hex(), ops[1].OperatorId)
-	// "total"
-	ops, ok = response.StakeRankedOperators["total"]
-	assert.True(t, ok)
-	assert.Equal(t, 2, len(ops))
-	assert.Equal(t, opId1.Hex(), ops[0].OperatorId)
-	assert.Equal(t, opId0.Hex(), ops[1].OperatorId)
+func TestCompress(t *testing.T) {
+	server := dataapi.NewServerV2(config, testServerV2Options())
+	large := strings.Repeat("eigenda", 1000)
+	r := setUpRouter()
+	r.Use(server.Compress(1024, "/stream"))
+	r.GET("/large", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"data": large}) })
+	r.GET("/small", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"data": "eigenda"}) })
+	r.GET("/stream", func(c *gin.Context) { c.String(http.StatusOK, large) })
+
+	get := func(path string, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	decodeLarge := func(t *testing.T, body io.Reader) {
+		var response map[string]string
+		err := json.NewDecoder(body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, large, response["data"])
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		w := get("/large", "gzip, deflate")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Less(t, w.Body.Len(), len(large))
+		reader, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		decodeLarge(t, reader)
+	})
+
+	t.Run("zstd is preferred", func(t *testing.T) {
+		w := get("/large", "gzip, zstd")
+		assert.Equal(t, "zstd", w.Header().Get("Content-Encoding"))
+		reader, err := zstd.NewReader(w.Body)
+		require.NoError(t, err)
+		defer reader.Close()
+		decodeLarge(t, reader)
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		w := get("/large", "zstd;q=0, br")
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		decodeLarge(t, w.Body)
+	})
+
+	t.Run("small response", func(t *testing.T) {
+		w := get("/small", "gzip")
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, `{"data":"eigenda"}`, w.Body.String())
+	})
+
+	t.Run("excluded route", func(t *testing.T) {
+		w := get("/stream", "gzip")
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, w.Body.String())
+	})
 }
 
-func TestFetchMetricsSummaryHandler(t *testing.T) {
+func TestMaintenance(t *testing.T) {
+	maintenanceConfig := config
+	maintenanceConfig.MaintenanceRetryAfter = 30 * time.Second
+	server := dataapi.NewServerV2(maintenanceConfig, testServerV2Options())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r := setUpRouter()
+	r.Use(server.Maintenance())
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/slow", func(c *gin.Context) {
+		close(started)
+		<-release
+		c.Status(http.StatusOK)
+	})
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, get("/ping").Code)
+
+	// A request in flight when maintenance mode is entered completes
+	slow := make(chan int)
+	go func() { slow <- get("/slow").Code }()
+	<-started
+	server.SetMaintenance(true)
+
+	w := get("/ping")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	response := decodeErrorResponse(t, w)
+	assert.Equal(t, dataapi.ErrCodeUnavailable, response.Code)
+	assert.True(t, response.Retryable)
+	assert.Equal(t, map[string]string{"retry_after_seconds": "30"}, response.Details)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-slow)
+
+	server.SetMaintenance(false)
+	assert.Equal(t, http.StatusOK, get("/ping").Code)
+}
+
+func TestETag(t *testing.T) {
+	server := dataapi.NewServerV2(config, testServerV2Options())
+	payload := gin.H{"data": strings.Repeat("eigenda", 1000)}
+	r := setUpRouter()
+	r.Use(server.Compress(1024))
+	r.GET("/payload", server.ETag(), func(c *gin.Context) {
+		c.Writer.Header().Set("Cache-Control", "max-age=60")
+		c.JSON(http.StatusOK, payload)
+	})
+	r.GET("/missing", server.ETag(), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	})
+
+	get := func(path string, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/payload", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	var response gin.H
+	require.NoError(t, json.NewDecoder(reader).Decode(&response))
+	assert.Equal(t, payload, response)
+
+	// Same payload gets the same tag
+	assert.Equal(t, etag, get("/payload", "").Header().Get("ETag"))
+
+	w = get("/payload", etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, 0, w.Body.Len())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Equal(t, "max-age=60", w.Header().Get("Cache-Control"))
+
+	w = get("/payload", `"other", `+strings.TrimPrefix(etag, "W/"))
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = get("/payload", `"other"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = get("/missing", "*")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Equal(t, `{"error":"not found"}`, w.Body.String())
+}
+
+func TestFetchBatchSigningInfoHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	keyPairs := make([]*core.KeyPair, 3)
+	ops := make([]core.OperatorID, 3)
+	for i := range keyPairs {
+		var err error
+		keyPairs[i], err = core.GenRandomBlsKeys()
+		require.NoError(t, err)
+		ops[i] = keyPairs[i].GetPubKeyG1().GetOperatorID()
+	}
+	chainState, err := coremock.NewChainDataMock(map[uint8]map[core.OperatorID]int{
+		0: {ops[0]: 1, ops[1]: 3},
+		1: {ops[1]: 2, ops[2]: 2},
+	})
+	require.NoError(t, err)
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetQuorumSecurityParams").Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 1, AdversaryThreshold: 33, ConfirmationThreshold: 50},
+	}, nil)
+	opts := testServerV2Options()
+	opts.ChainReader = chainReader
+	opts.ChainState = chainState
+	server := dataapi.NewServerV2(config, opts)
+
+	// ops[1] does not sign, which holds 3/4 of quorum 0 and 1/2 of quorum 1
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{5, 1, 3},
+		ReferenceBlockNumber: 5000,
+	}
+	batchHeaderHash, err := batchHeader.Hash()
+	require.NoError(t, err)
+	err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader:      batchHeader,
+		AttestedAt:       uint64(time.Now().UnixNano()),
+		NonSignerPubKeys: []*core.G1Point{keyPairs[1].GetPubKeyG1()},
+		QuorumNumbers:    []core.QuorumID{0, 1},
+		QuorumResults:    map[core.QuorumID]uint8{0: 25, 1: 50},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(2), big.NewInt(0)),
+		},
+	})
+	require.NoError(t, err)
+
+	r.GET("/v2/batches/:batch_header_hash/signing-info", server.FetchBatchSigningInfoHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/batches/xyz/signing-info", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/batches/"+hex.EncodeToString(make([]byte, 32))+"/signing-info", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/batches/"+hex.EncodeToString(batchHeaderHash[:])+"/signing-info", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.BatchSigningInfoResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.BatchHeaderHash)
+	assert.Equal(t, uint64(5000), response.ReferenceBlockNumber)
+	assert.Equal(t, []string{ops[1].Hex()}, response.NonSigners)
+	require.Len(t, response.Quorums, 2)
+
+	q0 := response.Quorums[0]
+	assert.Equal(t, core.QuorumID(0), q0.QuorumId)
+	assert.Equal(t, big.NewInt(4), q0.TotalStake)
+	assert.Equal(t, big.NewInt(1), q0.SignedStake)
+	assert.Equal(t, uint8(25), q0.SignedPercentage)
+	assert.Equal(t, uint8(55), q0.ConfirmationThreshold)
+	assert.False(t, q0.MeetsThreshold)
+	assert.Equal(t, []string{ops[1].Hex()}, q0.NonSigners)
+
+	q1 := response.Quorums[1]
+	assert.Equal(t, core.QuorumID(1), q1.QuorumId)
+	assert.Equal(t, big.NewInt(4), q1.TotalStake)
+	assert.Equal(t, big.NewInt(2), q1.SignedStake)
+	assert.Equal(t, uint8(50), q1.SignedPercentage)
+	assert.Equal(t, uint8(50), q1.ConfirmationThreshold)
+	assert.True(t, q1.MeetsThreshold)
+	assert.Equal(t, []string{ops[1].Hex()}, q1.NonSigners)
+}
+
+func TestFetchBatchesByReferenceBlockHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetQuorumSecurityParams").Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 1, AdversaryThreshold: 33, ConfirmationThreshold: 50},
+	}, nil)
+	opts := testServerV2Options()
+	opts.ChainReader = chainReader
+	server := dataapi.NewServerV2(config, opts)
+
+	// Two batches anchored at the same reference block, the second falling short in quorum 1
+	referenceBlockNumber := uint64(6000)
+	attestedAt := uint64(time.Now().UnixNano())
+	batchHeaderHashes := make([][32]byte, 2)
+	for i, results := range []map[core.QuorumID]uint8{{0: 80, 1: 70}, {0: 60, 1: 40}} {
+		batchHeader := &corev2.BatchHeader{
+			BatchRoot:            [32]byte{6, 0, byte(i)},
+			ReferenceBlockNumber: referenceBlockNumber,
+		}
+		var err error
+		batchHeaderHashes[i], err = batchHeader.Hash()
+		require.NoError(t, err)
+		err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+			BatchHeader:   batchHeader,
+			AttestedAt:    attestedAt + uint64(i),
+			QuorumNumbers: []core.QuorumID{0, 1},
+			QuorumResults: results,
+		})
+		require.NoError(t, err)
+		for j := 1; j >= 0; j-- {
+			err = blobMetadataStore.PutBlobVerificationInfo(ctx, &corev2.BlobVerificationInfo{
+				BatchHeader:    batchHeader,
+				BlobKey:        corev2.BlobKey{6, byte(i), byte(j)},
+				BlobIndex:      uint32(j),
+				InclusionProof: []byte("proof"),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	r.GET("/v2/batches/by-reference-block/:block_number", server.FetchBatchesByReferenceBlockHandler)
+
+	fetch := func(blockNumber string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/batches/by-reference-block/"+blockNumber, nil)
+		r.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, http.StatusBadRequest, fetch("latest").Code)
+	assert.Equal(t, http.StatusNotFound, fetch("6001").Code)
+
+	w := fetch("6000")
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.ReferenceBlockBatchesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, referenceBlockNumber, response.ReferenceBlockNumber)
+	require.Len(t, response.Batches, 2)
+
+	certified := response.Batches[0]
+	assert.Equal(t, hex.EncodeToString(batchHeaderHashes[0][:]), certified.BatchHeaderHash)
+	assert.True(t, certified.Certified)
+	assert.Equal(t, []string{corev2.BlobKey{6, 0, 0}.Hex(), corev2.BlobKey{6, 0, 1}.Hex()}, certified.BlobKeys)
+	assert.Equal(t, referenceBlockNumber, certified.SignedBatch.BatchHeader.ReferenceBlockNumber)
+
+	uncertified := response.Batches[1]
+	assert.Equal(t, hex.EncodeToString(batchHeaderHashes[1][:]), uncertified.BatchHeaderHash)
+	assert.False(t, uncertified.Certified)
+	require.Len(t, uncertified.Quorums, 2)
+	assert.Equal(t, dataapi.BatchQuorumCertification{QuorumId: 0, SignedPercentage: 60, ConfirmationThreshold: 55, MeetsThreshold: true}, *uncertified.Quorums[0])
+	assert.Equal(t, dataapi.BatchQuorumCertification{QuorumId: 1, SignedPercentage: 40, ConfirmationThreshold: 50, MeetsThreshold: false}, *uncertified.Quorums[1])
+}
+
+func TestFetchBatchesByTxHashHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetQuorumSecurityParams").Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+	}, nil)
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{7, 1},
+		ReferenceBlockNumber: 7000,
+	}
+	batchHeaderHash, err := batchHeader.Hash()
+	require.NoError(t, err)
+	err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader:   batchHeader,
+		AttestedAt:    uint64(time.Now().UnixNano()),
+		QuorumNumbers: []core.QuorumID{0},
+		QuorumResults: map[core.QuorumID]uint8{0: 70},
+	})
+	require.NoError(t, err)
+	err = blobMetadataStore.PutBlobVerificationInfo(ctx, &corev2.BlobVerificationInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        corev2.BlobKey{7, 1},
+		BlobIndex:      0,
+		InclusionProof: []byte("proof"),
+	})
+	require.NoError(t, err)
+
+	// A call passing the batch header as its first argument, followed by other words, as the cert verifier does
+	calldata := []byte{0xde, 0xad, 0xbe, 0xef}
+	calldata = append(calldata, batchHeader.BatchRoot[:]...)
+	calldata = append(calldata, gethcommon.LeftPadBytes(big.NewInt(int64(batchHeader.ReferenceBlockNumber)).Bytes(), 32)...)
+	calldata = append(calldata, gethcommon.LeftPadBytes([]byte{0x40}, 32)...)
+	certTxHash := gethcommon.Hash{7, 0xa}
+	otherTxHash := gethcommon.Hash{7, 0xb}
+	missingTxHash := gethcommon.Hash{7, 0xc}
+	ethClient := &commonmock.MockEthClient{}
+	ethClient.On("TransactionByHash", certTxHash).Return(types.NewTx(&types.LegacyTx{Data: calldata}), false, nil)
+	ethClient.On("TransactionByHash", otherTxHash).Return(types.NewTx(&types.LegacyTx{Data: []byte{1, 2, 3, 4}}), false, nil)
+	ethClient.On("TransactionByHash", missingTxHash).Return((*types.Transaction)(nil), false, ethereum.NotFound)
+	opts := testServerV2Options()
+	opts.ChainReader = chainReader
+	opts.EthClient = ethClient
+	server := dataapi.NewServerV2(config, opts)
+
+	r.GET("/v2/batches/by-tx-hash/:tx_hash", server.FetchBatchesByTxHashHandler)
+
+	fetch := func(txHash gethcommon.Hash) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/batches/by-tx-hash/"+txHash.Hex(), nil)
+		r.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, http.StatusNotFound, fetch(missingTxHash).Code)
+	assert.Equal(t, http.StatusNotFound, fetch(otherTxHash).Code)
+
+	w := fetch(certTxHash)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.TxBatchesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, certTxHash.Hex(), response.TxHash)
+	require.Len(t, response.Batches, 1)
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.Batches[0].BatchHeaderHash)
+	assert.True(t, response.Batches[0].Certified)
+	assert.Equal(t, []string{corev2.BlobKey{7, 1}.Hex()}, response.Batches[0].BlobKeys)
+}
+
+func TestFetchBatchSignersHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	keyPairs := make([]*core.KeyPair, 3)
+	ops := make([]core.OperatorID, 3)
+	for i := range keyPairs {
+		var err error
+		keyPairs[i], err = core.GenRandomBlsKeys()
+		require.NoError(t, err)
+		ops[i] = keyPairs[i].GetPubKeyG1().GetOperatorID()
+	}
+	chainState, err := coremock.NewChainDataMock(map[uint8]map[core.OperatorID]int{
+		0: {ops[0]: 1, ops[1]: 3, ops[2]: 4},
+		1: {ops[1]: 2, ops[2]: 2},
+	})
+	require.NoError(t, err)
+	opts := testServerV2Options()
+	opts.ChainState = chainState
+	server := dataapi.NewServerV2(config, opts)
+
+	// ops[1] does not sign
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{5, 1, 4},
+		ReferenceBlockNumber: 5100,
+	}
+	batchHeaderHash, err := batchHeader.Hash()
+	require.NoError(t, err)
+	attestedAt := uint64(time.Now().UnixNano())
+	err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader:      batchHeader,
+		AttestedAt:       attestedAt,
+		NonSignerPubKeys: []*core.G1Point{keyPairs[1].GetPubKeyG1()},
+		QuorumNumbers:    []core.QuorumID{0, 1},
+		QuorumResults:    map[core.QuorumID]uint8{0: 62, 1: 50},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(2), big.NewInt(0)),
+		},
+	})
+	require.NoError(t, err)
+
+	r.GET("/v2/batches/:batch_header_hash/signers", server.FetchBatchSignersHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/batches/xyz/signers", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/batches/"+hex.EncodeToString(make([]byte, 32))+"/signers", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/batches/"+hex.EncodeToString(batchHeaderHash[:])+"/signers", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.BatchSignersResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.BatchHeaderHash)
+	assert.Equal(t, uint64(5100), response.ReferenceBlockNumber)
+	assert.Equal(t, attestedAt, response.AttestedAt)
+	require.Len(t, response.Quorums, 2)
+
+	// The signers are sorted by descending stake
+	q0 := response.Quorums[0]
+	assert.Equal(t, core.QuorumID(0), q0.QuorumId)
+	assert.Equal(t, big.NewInt(8), q0.TotalStake)
+	assert.Equal(t, big.NewInt(5), q0.SignedStake)
+	require.Len(t, q0.Signers, 2)
+	assert.Equal(t, ops[2].Hex(), q0.Signers[0].OperatorId)
+	assert.Equal(t, big.NewInt(4), q0.Signers[0].Stake)
+	assert.Equal(t, 50.0, q0.Signers[0].StakePercentage)
+	assert.Equal(t, ops[0].Hex(), q0.Signers[1].OperatorId)
+	assert.Equal(t, big.NewInt(1), q0.Signers[1].Stake)
+	assert.Equal(t, 12.5, q0.Signers[1].StakePercentage)
+
+	q1 := response.Quorums[1]
+	assert.Equal(t, core.QuorumID(1), q1.QuorumId)
+	assert.Equal(t, big.NewInt(2), q1.SignedStake)
+	require.Len(t, q1.Signers, 1)
+	assert.Equal(t, ops[2].Hex(), q1.Signers[0].OperatorId)
+}
+
+func TestFetchBatchFeedHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Set up attestations made one second apart, ending one minute ago
+	numBatches := 12
+	now := time.Now()
+	firstAttestedAt := now.Add(-time.Minute).Add(-time.Duration(numBatches) * time.Second)
+	batchHeaderHashes := make([]string, numBatches)
+	for i := 0; i < numBatches; i++ {
+		batchHeader := &corev2.BatchHeader{
+			BatchRoot:            [32]byte{1, 2, byte(i)},
+			ReferenceBlockNumber: uint64(2000 + i),
+		}
+		bhh, err := batchHeader.Hash()
+		require.NoError(t, err)
+		batchHeaderHashes[i] = hex.EncodeToString(bhh[:])
+		attestation := &corev2.Attestation{
+			BatchHeader:   batchHeader,
+			AttestedAt:    uint64(firstAttestedAt.Add(time.Duration(i) * time.Second).UnixNano()),
+			QuorumNumbers: []core.QuorumID{0, 1},
+			QuorumResults: map[core.QuorumID]uint8{0: 100, 1: uint8(50 + i)},
+			Sigma: &core.Signature{
+				G1Point: core.NewG1Point(big.NewInt(2), big.NewInt(0)),
+			},
+		}
+		err = blobMetadataStore.PutAttestation(ctx, attestation)
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/batches/feed", testDataApiServerV2.FetchBatchFeedHandler)
+
+	fetchFeed := func(query string) (int, dataapi.BatchFeedResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/batches/feed"+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var response dataapi.BatchFeedResponse
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(data, &response))
+		}
+		return res.StatusCode, response
+	}
+
+	code, _ := fetchFeed("?limit=1001")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetchFeed("?cursor=abc")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	after := firstAttestedAt.Add(-time.Second).UTC().Format("2006-01-02T15:04:05Z")
+	before := now.Add(-time.Second).UTC().Format("2006-01-02T15:04:05Z")
+	query := fmt.Sprintf("?after=%s&before=%s&limit=10", after, before)
+	code, response := fetchFeed(query)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Batches, 10)
+	require.NotEmpty(t, response.PaginationToken)
+	assert.Equal(t, dataapi.Pagination{Limit: 10, HasMore: true, NextToken: response.PaginationToken}, response.Pagination)
+	for i, batch := range response.Batches {
+		assert.Equal(t, batchHeaderHashes[i], batch.BatchHeaderHash)
+		assert.Equal(t, uint64(2000+i), batch.BatchHeader.ReferenceBlockNumber)
+		assert.Equal(t, uint8(50+i), batch.QuorumSignedPercentages[1])
+	}
+
+	code, response = fetchFeed(query + "&cursor=" + response.PaginationToken)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Batches, numBatches-10)
+	assert.Empty(t, response.PaginationToken)
+	assert.False(t, response.Pagination.HasMore)
+	assert.Equal(t, batchHeaderHashes[10], response.Batches[0].BatchHeaderHash)
+
+	// Exports stream the whole range, regardless of limit
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/batches/feed"+query+"&format=csv", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, numBatches+1)
+	assert.Equal(t, "batch_header_hash", records[0][0])
+	for i := 0; i < numBatches; i++ {
+		assert.Equal(t, batchHeaderHashes[i], records[i+1][0])
+		assert.Equal(t, fmt.Sprintf("%d", 2000+i), records[i+1][1])
+		assert.Equal(t, fmt.Sprintf("0:100;1:%d", 50+i), records[i+1][5])
+	}
+}
+
+func TestFetchBatchFeedHandlerSameAttestedAt(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Set up attestations made at the same time, two hours ago
+	numBatches := 5
+	attestedAt := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	batchHeaderHashes := make([]string, numBatches)
+	for i := 0; i < numBatches; i++ {
+		batchHeader := &corev2.BatchHeader{
+			BatchRoot:            [32]byte{3, 4, byte(i)},
+			ReferenceBlockNumber: uint64(3000 + i),
+		}
+		bhh, err := batchHeader.Hash()
+		require.NoError(t, err)
+		batchHeaderHashes[i] = hex.EncodeToString(bhh[:])
+		err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+			BatchHeader:   batchHeader,
+			AttestedAt:    uint64(attestedAt.UnixNano()),
+			QuorumNumbers: []core.QuorumID{0},
+			QuorumResults: map[core.QuorumID]uint8{0: 100},
+			Sigma: &core.Signature{
+				G1Point: core.NewG1Point(big.NewInt(2), big.NewInt(0)),
+			},
+		})
+		require.NoError(t, err)
+	}
+	sort.Strings(batchHeaderHashes)
+
+	r.GET("/v2/batches/feed", testDataApiServerV2.FetchBatchFeedHandler)
+	fetchFeed := func(query string) (int, dataapi.BatchFeedResponse) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/batches/feed"+query, nil))
+		var response dataapi.BatchFeedResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response
+	}
+
+	// The batches attested at the same time are paged through by batch header hash, none are skipped
+	after := attestedAt.Add(-time.Second).UTC().Format("2006-01-02T15:04:05Z")
+	before := attestedAt.Add(time.Second).UTC().Format("2006-01-02T15:04:05Z")
+	query := fmt.Sprintf("?after=%s&before=%s&limit=2", after, before)
+	fetched := make([]string, 0, numBatches)
+	cursor := ""
+	for {
+		code, response := fetchFeed(query + cursor)
+		require.Equal(t, http.StatusOK, code)
+		for _, batch := range response.Batches {
+			fetched = append(fetched, batch.BatchHeaderHash)
+		}
+		if response.PaginationToken == "" {
+			break
+		}
+		cursor = "&cursor=" + response.PaginationToken
+	}
+	assert.Equal(t, batchHeaderHashes, fetched)
+
+	// A cursor at or after before is rejected
+	late := base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%020d", attestedAt.Add(time.Hour).UnixNano())))
+	code, _ := fetchFeed(query + "&cursor=" + late)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestSubscribeBatchesHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	r.GET("/v2/batch/subscribe", testDataApiServerV2.SubscribeBatchesHandler)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	// Invalid quorum filter is rejected before the handshake
+	res, err := http.Get(ts.URL + "/v2/batch/subscribe?quorums=0,abc")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/v2/batch/subscribe?quorums=1"
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Batches attested after subscribing are pushed if they contain a subscribed quorum
+	putAttestation := func(batchRoot byte, quorums []core.QuorumID) string {
+		batchHeader := &corev2.BatchHeader{
+			BatchRoot:            [32]byte{3, 1, batchRoot},
+			ReferenceBlockNumber: 3000,
+		}
+		bhh, err := batchHeader.Hash()
+		require.NoError(t, err)
+		attestation := &corev2.Attestation{
+			BatchHeader:   batchHeader,
+			AttestedAt:    uint64(time.Now().UnixNano()),
+			QuorumNumbers: quorums,
+			QuorumResults: map[core.QuorumID]uint8{},
+			Sigma: &core.Signature{
+				G1Point: core.NewG1Point(big.NewInt(2), big.NewInt(0)),
+			},
+		}
+		for _, q := range quorums {
+			attestation.QuorumResults[q] = 100
+		}
+		err = blobMetadataStore.PutAttestation(ctx, attestation)
+		require.NoError(t, err)
+		return hex.EncodeToString(bhh[:])
+	}
+	filteredOut := putAttestation(0, []core.QuorumID{0})
+	expected := putAttestation(1, []core.QuorumID{0, 1})
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(30*time.Second)))
+	for {
+		var msg dataapi.BatchSubscriptionMessage
+		require.NoError(t, conn.ReadJSON(&msg))
+		require.NotEqual(t, filteredOut, msg.BatchHeaderHash)
+		if msg.BatchHeaderHash != expected {
+			continue
+		}
+		assert.Equal(t, uint64(3000), msg.SignedBatch.BatchHeader.ReferenceBlockNumber)
+		assert.Equal(t, []core.QuorumID{0, 1}, msg.SignedBatch.Attestation.QuorumNumbers)
+		assert.Equal(t, uint8(100), msg.SignedBatch.Attestation.QuorumResults[1])
+		return
+	}
+}
+
+func TestFetchNonSigners(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Operators whose IDs are derived from their public keys, as they are onchain
+	keyPair0, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	keyPair1, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	op0 := keyPair0.GetPubKeyG1().GetOperatorID()
+	op1 := keyPair1.GetPubKeyG1().GetOperatorID()
+	chainState, err := coremock.NewChainDataMock(map[uint8]map[core.OperatorID]int{
+		0: {op0: 1, op1: 1},
+		1: {op1: 1},
+	})
+	require.NoError(t, err)
+	opts := testServerV2Options()
+	opts.ChainState = chainState
+	server := dataapi.NewServerV2(config, opts)
+
+	// op1 misses two batches, op0 misses one
+	now := time.Now()
+	nonSigners := [][]*core.G1Point{
+		{keyPair0.GetPubKeyG1(), keyPair1.GetPubKeyG1()},
+		{keyPair1.GetPubKeyG1()},
+		{},
+	}
+	for i, ns := range nonSigners {
+		attestation := &corev2.Attestation{
+			BatchHeader: &corev2.BatchHeader{
+				BatchRoot:            [32]byte{3, 3, byte(i)},
+				ReferenceBlockNumber: 3000,
+			},
+			AttestedAt:       uint64(now.Add(-time.Duration(i+1) * time.Minute).UnixNano()),
+			NonSignerPubKeys: ns,
+			QuorumNumbers:    []core.QuorumID{0, 1},
+			QuorumResults:    map[core.QuorumID]uint8{0: 50, 1: 100},
+		}
+		err = blobMetadataStore.PutAttestation(ctx, attestation)
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/operators/nonsigners", server.FetchNonSigners)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/operators/nonsigners?interval=-1", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// More than 30 days
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/operators/nonsigners?interval=2592001", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/operators/nonsigners?interval=600", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	res := w.Result()
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var response dataapi.OperatorsNonSigningResponse
+	err = json.Unmarshal(data, &response)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, response.TotalBatches, len(nonSigners))
+	require.Len(t, response.NonSigners, 2)
+	assert.Equal(t, op1.Hex(), response.NonSigners[0].OperatorId)
+	assert.Equal(t, 2, response.NonSigners[0].TotalUnsignedBatches)
+	assert.Equal(t, 2, response.NonSigners[0].QuorumUnsignedBatches[0])
+	assert.Equal(t, 2, response.NonSigners[0].QuorumUnsignedBatches[1])
+	assert.Equal(t, op0.Hex(), response.NonSigners[1].OperatorId)
+	assert.Equal(t, 1, response.NonSigners[1].TotalUnsignedBatches)
+	assert.Equal(t, 1, response.NonSigners[1].QuorumUnsignedBatches[0])
+	assert.Equal(t, 0, response.NonSigners[1].QuorumUnsignedBatches[1])
+}
+
+func TestFetchOperatorSigningRateHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	keyPair0, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	keyPair1, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	op0 := keyPair0.GetPubKeyG1().GetOperatorID()
+	op1 := keyPair1.GetPubKeyG1().GetOperatorID()
+	// Use a quorum no other test attests to, so that their batches are not tallied for these operators
+	chainState, err := coremock.NewChainDataMock(map[uint8]map[core.OperatorID]int{
+		7: {op0: 1, op1: 1},
+	})
+	require.NoError(t, err)
+	opts := testServerV2Options()
+	opts.ChainState = chainState
+	server := dataapi.NewServerV2(config, opts)
+
+	// op0 misses the batch 30 minutes ago, which is tallied from the attestations
+	now := time.Now()
+	err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader: &corev2.BatchHeader{
+			BatchRoot:            [32]byte{7, 7},
+			ReferenceBlockNumber: 7000,
+		},
+		AttestedAt:       uint64(now.Add(-30 * time.Minute).UnixNano()),
+		NonSignerPubKeys: []*core.G1Point{keyPair0.GetPubKeyG1()},
+		QuorumNumbers:    []core.QuorumID{7},
+		QuorumResults:    map[core.QuorumID]uint8{7: 50},
+	})
+	require.NoError(t, err)
+	// The earlier hours are loaded from the stored tallies: op0 misses the batch 10 days ago, and the one 40 days ago
+	// is out of every window
+	hours := []struct {
+		age     time.Duration
+		tallies map[core.OperatorID]*commonv2.SigningTally
+	}{
+		{2 * 24 * time.Hour, map[core.OperatorID]*commonv2.SigningTally{op0: {Batches: 1}, op1: {Batches: 1}}},
+		{10 * 24 * time.Hour, map[core.OperatorID]*commonv2.SigningTally{op0: {Batches: 1, UnsignedBatches: 1}, op1: {Batches: 1}}},
+		{40 * 24 * time.Hour, map[core.OperatorID]*commonv2.SigningTally{op0: {Batches: 1, UnsignedBatches: 1}, op1: {Batches: 1}}},
+	}
+	for _, h := range hours {
+		err = blobMetadataStore.PutSigningTallies(ctx, &commonv2.SigningTallies{
+			Timestamp: uint64(now.Add(-h.age).Truncate(time.Hour).Unix()),
+			Tallies:   h.tallies,
+		})
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/operators/:operator_id/signing-rate", server.FetchOperatorSigningRateHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/operators/xyz/signing-rate", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	fetch := func(operatorID core.OperatorID) *dataapi.OperatorSigningRateResponse {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/operators/"+operatorID.Hex()+"/signing-rate", nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		var response dataapi.OperatorSigningRateResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return &response
+	}
+
+	response := fetch(op0)
+	assert.Equal(t, op0.Hex(), response.OperatorId)
+	assert.Equal(t, []*dataapi.OperatorSigningRate{
+		{Window: "1d", TotalBatches: 1, UnsignedBatches: 1, SigningPercentage: 0},
+		{Window: "7d", TotalBatches: 2, UnsignedBatches: 1, SigningPercentage: 50},
+		{Window: "30d", TotalBatches: 3, UnsignedBatches: 2, SigningPercentage: 100.0 / 3},
+	}, response.SigningRates)
+
+	response = fetch(op1)
+	assert.Equal(t, op1.Hex(), response.OperatorId)
+	assert.Equal(t, []*dataapi.OperatorSigningRate{
+		{Window: "1d", TotalBatches: 1, UnsignedBatches: 0, SigningPercentage: 100},
+		{Window: "7d", TotalBatches: 2, UnsignedBatches: 0, SigningPercentage: 100},
+		{Window: "30d", TotalBatches: 3, UnsignedBatches: 0, SigningPercentage: 100},
+	}, response.SigningRates)
+
+	// An operator that never had to sign has no batches in any window
+	response = fetch(core.OperatorID{1, 2, 3})
+	for _, rate := range response.SigningRates {
+		assert.Equal(t, 0, rate.TotalBatches)
+		assert.Equal(t, float64(0), rate.SigningPercentage)
+	}
+}
+
+func TestFetchOperatorsStakeHistory(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	op0 := core.OperatorID{1}
+	op1 := core.OperatorID{2}
+	day0, err := time.Parse(time.DateOnly, "2020-01-01")
+	require.NoError(t, err)
+	day2 := day0.AddDate(0, 0, 2)
+	snapshots := []*commonv2.StakeSnapshot{
+		{
+			Timestamp:   uint64(day0.Unix()),
+			BlockNumber: 100,
+			Stakes: map[core.QuorumID]map[core.OperatorID]*big.Int{
+				0: {op0: big.NewInt(1), op1: big.NewInt(3)},
+				1: {op1: big.NewInt(5)},
+			},
+		},
+		// no snapshot on the second day
+		{
+			Timestamp:   uint64(day2.Unix()),
+			BlockNumber: 300,
+			Stakes: map[core.QuorumID]map[core.OperatorID]*big.Int{
+				0: {op0: big.NewInt(3), op1: big.NewInt(1)},
+			},
+		},
+	}
+	for _, snapshot := range snapshots {
+		err = blobMetadataStore.PutStakeSnapshot(ctx, snapshot)
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/operators/stake/history", testDataApiServerV2.FetchOperatorsStakeHistory)
+
+	for _, query := range []string{"start=2020-01-03&end=2020-01-01", "start=2020-01-01&end=2020-06-01", "start=xyz"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/operators/stake/history?"+query, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/operators/stake/history?start=2020-01-01&end=2020-01-03", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.OperatorsStakeHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Snapshots, 2)
+
+	first := response.Snapshots[0]
+	assert.Equal(t, uint64(day0.Unix()), first.Timestamp)
+	assert.Equal(t, uint64(100), first.BlockNumber)
+	require.Len(t, first.Quorums, 2)
+	assert.Equal(t, big.NewInt(4), first.Quorums["0"].TotalStake)
+	require.Len(t, first.Quorums["0"].Operators, 2)
+	assert.Equal(t, op1.Hex(), first.Quorums["0"].Operators[0].OperatorId)
+	assert.Equal(t, 75.0, first.Quorums["0"].Operators[0].StakePercentage)
+	assert.Equal(t, 1, first.Quorums["0"].Operators[0].Rank)
+	assert.Equal(t, op0.Hex(), first.Quorums["0"].Operators[1].OperatorId)
+	assert.Equal(t, 25.0, first.Quorums["0"].Operators[1].StakePercentage)
+	assert.Equal(t, 2, first.Quorums["0"].Operators[1].Rank)
+	assert.Equal(t, big.NewInt(5), first.Quorums["1"].TotalStake)
+	require.Len(t, first.Quorums["1"].Operators, 1)
+	assert.Equal(t, 100.0, first.Quorums["1"].Operators[0].StakePercentage)
+
+	second := response.Snapshots[1]
+	assert.Equal(t, uint64(day2.Unix()), second.Timestamp)
+	assert.Equal(t, uint64(300), second.BlockNumber)
+	require.Len(t, second.Quorums, 1)
+	assert.Equal(t, op0.Hex(), second.Quorums["0"].Operators[0].OperatorId)
+	assert.Equal(t, 75.0, second.Quorums["0"].Operators[0].StakePercentage)
+}
+
+func TestFetchOperatorsNodeInfoHistory(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	day0, err := time.Parse(time.DateOnly, "2020-02-01")
+	require.NoError(t, err)
+	day2 := day0.AddDate(0, 0, 2)
+	snapshots := []*commonv2.SemverSnapshot{
+		{
+			Timestamp: uint64(day0.Unix()),
+			Semvers: map[string]*commonv2.SemverAdoption{
+				"0.8.5": {Operators: 3, QuorumStakePercentage: map[core.QuorumID]float64{0: 100, 1: 100}},
+			},
+		},
+		// no snapshot on the second day
+		{
+			Timestamp: uint64(day2.Unix()),
+			Semvers: map[string]*commonv2.SemverAdoption{
+				"0.8.5": {Operators: 1, QuorumStakePercentage: map[core.QuorumID]float64{0: 40}},
+				"0.8.6": {Operators: 2, QuorumStakePercentage: map[core.QuorumID]float64{0: 60, 1: 100}},
+			},
+		},
+	}
+	for _, snapshot := range snapshots {
+		err = blobMetadataStore.PutSemverSnapshot(ctx, snapshot)
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/operators/nodeinfo/history", testDataApiServerV2.FetchOperatorsNodeInfoHistory)
+
+	for _, query := range []string{"start=2020-02-03&end=2020-02-01", "start=2020-02-01&end=2020-07-01", "end=xyz"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/operators/nodeinfo/history?"+query, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/operators/nodeinfo/history?start=2020-02-01&end=2020-02-03", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.OperatorsNodeInfoHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Snapshots, 2)
+
+	first := response.Snapshots[0]
+	assert.Equal(t, uint64(day0.Unix()), first.Timestamp)
+	require.Len(t, first.Semvers, 1)
+	assert.Equal(t, uint32(3), first.Semvers["0.8.5"].Operators)
+	assert.Equal(t, map[string]float64{"0": 100, "1": 100}, first.Semvers["0.8.5"].StakePercentage)
+
+	// The new release is adopted by the majority of the stake
+	second := response.Snapshots[1]
+	assert.Equal(t, uint64(day2.Unix()), second.Timestamp)
+	require.Len(t, second.Semvers, 2)
+	assert.Equal(t, uint32(1), second.Semvers["0.8.5"].Operators)
+	assert.Equal(t, uint32(2), second.Semvers["0.8.6"].Operators)
+	assert.Equal(t, map[string]float64{"0": 60, "1": 100}, second.Semvers["0.8.6"].StakePercentage)
+}
+
+func TestFetchThroughputRollups(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// A Monday
+	day0, err := time.Parse(time.DateOnly, "2020-03-02")
+	require.NoError(t, err)
+	day2 := day0.AddDate(0, 0, 2)
+	rollups := []*commonv2.ThroughputRollup{
+		{
+			Period:             commonv2.DailyRollup,
+			Timestamp:          uint64(day0.Unix()),
+			BlobCount:          12,
+			CertifiedBlobCount: 10,
+			CertifiedBytes:     86400 * 100,
+			OnDemandPayment:    big.NewInt(1000),
+		},
+		// no rollup on the second day
+		{
+			Period:             commonv2.DailyRollup,
+			Timestamp:          uint64(day2.Unix()),
+			BlobCount:          1,
+			CertifiedBlobCount: 1,
+			CertifiedBytes:     86400,
+			OnDemandPayment:    big.NewInt(0),
+		},
+		{
+			Period:             commonv2.WeeklyRollup,
+			Timestamp:          uint64(day0.Unix()),
+			BlobCount:          13,
+			CertifiedBlobCount: 11,
+			CertifiedBytes:     86400 * 101,
+			OnDemandPayment:    big.NewInt(1000),
+		},
+	}
+	for _, rollup := range rollups {
+		err = blobMetadataStore.PutThroughputRollup(ctx, rollup)
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/metrics/rollups", testDataApiServerV2.FetchThroughputRollupsHandler)
+	fetch := func(query string) (int, dataapi.ThroughputRollupsResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/metrics/rollups?"+query, nil)
+		r.ServeHTTP(w, req)
+		var response dataapi.ThroughputRollupsResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response
+	}
+
+	for _, query := range []string{"period=monthly", "start=2020-03-04&end=2020-03-02", "start=2019-01-01&end=2020-03-02", "start=xyz"} {
+		code, _ := fetch(query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+
+	code, response := fetch("start=2020-03-02&end=2020-03-04")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Rollups, 2)
+	assert.Equal(t, "daily", response.Rollups[0].Period)
+	assert.Equal(t, uint64(day0.Unix()), response.Rollups[0].Timestamp)
+	assert.Equal(t, uint64(12), response.Rollups[0].BlobCount)
+	assert.Equal(t, uint64(10), response.Rollups[0].CertifiedBlobCount)
+	assert.Equal(t, uint64(86400*100), response.Rollups[0].CertifiedBytes)
+	assert.Equal(t, 100.0, response.Rollups[0].Throughput)
+	assert.Equal(t, big.NewInt(1000), response.Rollups[0].OnDemandPayment)
+	assert.Equal(t, uint64(day2.Unix()), response.Rollups[1].Timestamp)
+	assert.Equal(t, 1.0, response.Rollups[1].Throughput)
+
+	// Weeks are returned from the Monday of the week of the start day
+	code, response = fetch("period=weekly&start=2020-03-04&end=2020-03-08")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Rollups, 1)
+	assert.Equal(t, "weekly", response.Rollups[0].Period)
+	assert.Equal(t, uint64(day0.Unix()), response.Rollups[0].Timestamp)
+	assert.Equal(t, uint64(13), response.Rollups[0].BlobCount)
+	assert.Equal(t, 101.0/7, response.Rollups[0].Throughput)
+}
+
+func TestFetchOperatorEventsHandler(t *testing.T) {
+	r := setUpRouter()
+
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+
+	registered := []*subgraph.Operator{
+		{OperatorId: "operator-1", Operator: "address-1", BlockTimestamp: "1696975449", BlockNumber: "87", TransactionHash: "tx-1"},
+		{OperatorId: "operator-3", Operator: "address-3", BlockTimestamp: "1696975470", BlockNumber: "90", TransactionHash: "tx-3"},
+	}
+	deregistered := []*subgraph.Operator{
+		{OperatorId: "operator-2", Operator: "address-2", BlockTimestamp: "1696975459", BlockNumber: "88", TransactionHash: "tx-2"},
+		{OperatorId: "operator-4", Operator: "address-4", BlockTimestamp: "1696975470", BlockNumber: "90", TransactionHash: "tx-3"},
+	}
+	mockSubgraphApi.On("QueryRegisteredOperatorsByBlockTimestampRange").Return(registered, nil)
+	mockSubgraphApi.On("QueryDeregisteredOperatorsByBlockTimestampRange").Return(deregistered, nil)
+
+	r.GET("/v2/operators/events", testDataApiServerV2.FetchOperatorEventsHandler)
+
+	fetch := func(query string) *dataapi.OperatorEventsResponse {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/operators/events?"+query, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		var response dataapi.OperatorEventsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return &response
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/operators/events?cursor=xyz", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// First page
+	response := fetch("limit=2")
+	require.Equal(t, 2, len(response.Events))
+	assert.Equal(t, dataapi.OperatorRegisteredEvent, response.Events[0].EventType)
+	assert.Equal(t, "operator-1", response.Events[0].OperatorId)
+	assert.Equal(t, "address-1", response.Events[0].OperatorAddress)
+	assert.Equal(t, uint64(87), response.Events[0].BlockNumber)
+	assert.Equal(t, uint64(1696975449), response.Events[0].BlockTimestamp)
+	assert.Equal(t, "tx-1", response.Events[0].TransactionHash)
+	assert.Equal(t, dataapi.OperatorDeregisteredEvent, response.Events[1].EventType)
+	assert.Equal(t, "operator-2", response.Events[1].OperatorId)
+	require.NotEmpty(t, response.PaginationToken)
+	assert.Equal(t, dataapi.Pagination{Limit: 2, HasMore: true, NextToken: response.PaginationToken}, response.Pagination)
+
+	// Last page
+	response = fetch("limit=2&cursor=" + response.PaginationToken)
+	require.Equal(t, 1, len(response.Events))
+	assert.Equal(t, dataapi.OperatorChurnedEvent, response.Events[0].EventType)
+	assert.Equal(t, "operator-3", response.Events[0].OperatorId)
+	assert.Equal(t, []string{"operator-4"}, response.Events[0].ChurnedOperatorIds)
+	assert.Empty(t, response.PaginationToken)
+	assert.False(t, response.Pagination.HasMore)
+
+	// The cursor pins the end of the range, so the pages don't shift as time passes
+	response = fetch("limit=1&before=2023-10-10T22:04:19Z")
+	require.Equal(t, 1, len(response.Events))
+	token := response.PaginationToken
+	response = fetch("limit=1&cursor=" + token)
+	require.Equal(t, 1, len(response.Events))
+	assert.Equal(t, "operator-2", response.Events[0].OperatorId)
+	decoded, err := base64.URLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	assert.Equal(t, "1696975459:1696975449:87:tx-1:operator-1", string(decoded))
+
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+}
+
+func TestCheckOperatorsReachability(t *testing.T) {
+	r := setUpRouter()
+
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+
+	operator_id := "0xa96bfb4a7ca981ad365220f336dc5a3de0816ebd5130b79bbc85aca94bc9b6ab"
+	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(operatorInfo, nil)
+
+	r.GET("/v2/operators/reachability", testDataApiServerV2.CheckOperatorsReachability)
+
+	w := httptest.NewRecorder()
+	reqStr := fmt.Sprintf("/v2/operators/reachability?operator_id=%v", operator_id)
+	req := httptest.NewRequest(http.MethodGet, reqStr, nil)
+	ctxWithDeadline, cancel := context.WithTimeout(req.Context(), 500*time.Microsecond)
+	defer cancel()
+	req = req.WithContext(ctxWithDeadline)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusOK)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+
+	var response dataapi.OperatorPortCheckResponse
+	err = json.Unmarshal(data, &response)
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+
+	assert.Equal(t, "23.93.76.1:32005", response.DispersalSocket)
+	assert.Equal(t, false, response.DispersalOnline)
+	assert.Equal(t, "23.93.76.1:32006", response.RetrievalSocket)
+	assert.Equal(t, false, response.RetrievalOnline)
+	assert.Equal(t, "23.93.76.1:32005", response.V2DispersalSocket)
+	assert.Equal(t, false, response.V2DispersalOnline)
+	require.Len(t, response.Ports, 3)
+	assert.Equal(t, "23.93.76.1:32005", response.Ports["v2_dispersal"].Socket)
+	assert.False(t, response.Ports["v2_dispersal"].Online)
+	assert.False(t, response.Ports["v2_dispersal"].ServiceAvailable)
+
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+}
+
+func TestCheckOperatorsReachabilitySplitPorts(t *testing.T) {
+	r := setUpRouter()
+
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+
+	// The v1 and v2 dispersal services are served on separate ports, and nothing listens on the retrieval port
+	serve := func(register func(*grpc.Server)) string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		grpcServer := grpc.NewServer()
+		register(grpcServer)
+		reflection.Register(grpcServer)
+		go func() {
+			_ = grpcServer.Serve(listener)
+		}()
+		t.Cleanup(grpcServer.Stop)
+		_, port, err := net.SplitHostPort(listener.Addr().String())
+		require.NoError(t, err)
+		return port
+	}
+	dispersalPort := serve(func(s *grpc.Server) {
+		nodegrpc.RegisterDispersalServer(s, &nodegrpc.UnimplementedDispersalServer{})
+	})
+	v2DispersalPort := serve(func(s *grpc.Server) {
+		nodegrpcv2.RegisterDispersalServer(s, &nodegrpcv2.UnimplementedDispersalServer{})
+	})
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, retrievalPort, err := net.SplitHostPort(closedListener.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, closedListener.Close())
+
+	splitPortsOperatorInfo := *operatorInfo
+	splitPortsOperatorInfo.SocketUpdates = []subgraph.SocketUpdates{
+		{Socket: graphql.String(fmt.Sprintf("127.0.0.1:%s;%s;%s", dispersalPort, retrievalPort, v2DispersalPort))},
+	}
+	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(&splitPortsOperatorInfo, nil)
+
+	r.GET("/v2/operators/reachability", testDataApiServerV2.CheckOperatorsReachability)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/operators/reachability?operator_id="+opId0.Hex(), nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response dataapi.OperatorPortCheckResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, "127.0.0.1:"+dispersalPort, response.DispersalSocket)
+	assert.True(t, response.DispersalOnline)
+	assert.Equal(t, "127.0.0.1:"+v2DispersalPort, response.V2DispersalSocket)
+	assert.True(t, response.V2DispersalOnline)
+	assert.Equal(t, "127.0.0.1:"+retrievalPort, response.RetrievalSocket)
+	assert.False(t, response.RetrievalOnline)
+
+	require.Len(t, response.Ports, 3)
+	dispersal := response.Ports["dispersal"]
+	assert.True(t, dispersal.ServiceAvailable)
+	assert.Contains(t, dispersal.Services, "node.Dispersal")
+	assert.NotContains(t, dispersal.Services, "node.v2.Dispersal")
+	assert.Empty(t, dispersal.Error)
+
+	v2Dispersal := response.Ports["v2_dispersal"]
+	assert.True(t, v2Dispersal.ServiceAvailable)
+	assert.Contains(t, v2Dispersal.Services, "node.v2.Dispersal")
+	assert.NotContains(t, v2Dispersal.Services, "node.Dispersal")
+
+	retrieval := response.Ports["retrieval"]
+	assert.False(t, retrieval.Online)
+	assert.False(t, retrieval.ServiceAvailable)
+	assert.Empty(t, retrieval.Services)
+
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+}
+
+func TestCheckRelaysReachability(t *testing.T) {
+	r := setUpRouter()
+
+	// A relay serving the gRPC health service
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(healthcheck.VersionUnaryInterceptor("v1.2.3")))
+	healthcheck.RegisterHealthServer("relay", grpcServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	defer grpcServer.Stop()
+
+	// A relay nothing listens on
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closedListener.Addr().String()
+	require.NoError(t, closedListener.Close())
+
+	mockTx.On("GetRelayURLs").Return(map[uint32]string{
+		0: listener.Addr().String(),
+		1: closedAddr,
+	}, nil).Once()
+
+	r.GET("/v2/relays/reachability", testDataApiServerV2.CheckRelaysReachability)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/relays/reachability", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	res := w.Result()
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var response dataapi.RelaysReachabilityResponse
+	err = json.Unmarshal(data, &response)
+	require.NoError(t, err)
+
+	require.Len(t, response.Relays, 2)
+	assert.Equal(t, uint32(0), response.Relays[0].RelayKey)
+	assert.Equal(t, listener.Addr().String(), response.Relays[0].Url)
+	assert.True(t, response.Relays[0].Reachable)
+	assert.Greater(t, response.Relays[0].DialLatencyMs, float64(0))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING.String(), response.Relays[0].HealthStatus)
+	assert.Equal(t, "v1.2.3", response.Relays[0].Version)
+	assert.Empty(t, response.Relays[0].Error)
+
+	assert.Equal(t, uint32(1), response.Relays[1].RelayKey)
+	assert.Equal(t, closedAddr, response.Relays[1].Url)
+	assert.False(t, response.Relays[1].Reachable)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_UNKNOWN.String(), response.Relays[1].HealthStatus)
+	assert.NotEmpty(t, response.Relays[1].Error)
+}
+
+func TestFetchOperatorsStake(t *testing.T) {
+	r := setUpRouter()
+
+	mockIndexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+
+	r.GET("/v2/operators/stake", testDataApiServerV2.FetchOperatorsStake)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/operators/stake", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusOK)
+	res := w.Result()
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+
+	var response dataapi.OperatorsStakeResponse
+	err = json.Unmarshal(data, &response)
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+
+	// The quorums and the operators in the quorum are defined in "mockChainState"
+	// There are 3 quorums (0, 1) and a "total" entry for TotalQuorumStake
+	assert.Equal(t, 3, len(response.StakeRankedOperators))
+	// Quorum 0
+	ops, ok := response.StakeRankedOperators["0"]
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(ops))
+	assert.Equal(t, opId0.Hex(), ops[0].OperatorId)
+	assert.Equal(t, opId1.Hex(), ops[1].OperatorId)
+	// Quorum 1
+	ops, ok = response.StakeRankedOperators["1"]
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(ops))
+	assert.Equal(t, opId1.Hex(), ops[0].OperatorId)
+	assert.Equal(t, opId0.Hex(), ops[1].OperatorId)
+	// "total"
+	ops, ok = response.StakeRankedOperators["total"]
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(ops))
+	assert.Equal(t, opId1.Hex(), ops[0].OperatorId)
+	assert.Equal(t, opId0.Hex(), ops[1].OperatorId)
+
+	// CSV export has one row per operator per quorum, with the total last
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v2/operators/stake?format=csv", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 7)
+	assert.Equal(t, []string{"quorum_id", "operator_id", "stake_percentage", "rank"}, records[0])
+	assert.Equal(t, []string{"0", opId0.Hex()}, records[1][:2])
+	assert.Equal(t, []string{"1", opId1.Hex()}, records[3][:2])
+	assert.Equal(t, []string{"total", opId1.Hex()}, records[5][:2])
+}
+
+func TestFetchOperatorsStakeLeaderboard(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	mockIndexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+
+	// Yesterday opId1 led quorum 0, which opId0 leads in "mockChainState"; quorum 1 was not snapshotted
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	err := blobMetadataStore.PutStakeSnapshot(ctx, &commonv2.StakeSnapshot{
+		Timestamp:   uint64(yesterday.Unix()),
+		BlockNumber: 100,
+		Stakes: map[core.QuorumID]map[core.OperatorID]*big.Int{
+			0: {opId0: big.NewInt(1), opId1: big.NewInt(3)},
+		},
+	})
+	require.NoError(t, err)
+
+	r.GET("/v2/operators/stake/leaderboard", testDataApiServerV2.FetchOperatorsStakeLeaderboard)
+
+	fetch := func(query string, status int) *dataapi.OperatorsStakeLeaderboardResponse {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/operators/stake/leaderboard?"+query, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, status, w.Code, query)
+		var response dataapi.OperatorsStakeLeaderboardResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return &response
+	}
+
+	fetch("quorum=xyz", http.StatusBadRequest)
+	fetch("cursor=!", http.StatusBadRequest)
+	fetch("quorum=9", http.StatusNotFound)
+
+	response := fetch("", http.StatusOK)
+	assert.Equal(t, "0", response.QuorumId)
+	assert.Equal(t, uint64(yesterday.Unix()), response.PreviousSnapshotTimestamp)
+	require.Len(t, response.Operators, 2)
+	assert.Equal(t, opId0.Hex(), response.Operators[0].OperatorId)
+	assert.Equal(t, 1, response.Operators[0].Rank)
+	assert.Equal(t, 2, response.Operators[0].PreviousRank)
+	assert.Equal(t, 1, response.Operators[0].RankChange)
+	assert.Equal(t, opId1.Hex(), response.Operators[1].OperatorId)
+	assert.Equal(t, 2, response.Operators[1].Rank)
+	assert.Equal(t, 1, response.Operators[1].PreviousRank)
+	assert.Equal(t, -1, response.Operators[1].RankChange)
+	assert.False(t, response.Pagination.HasMore)
+
+	// Operators not ranked the previous day have no rank change
+	response = fetch("quorum=1&limit=1", http.StatusOK)
+	require.Len(t, response.Operators, 1)
+	assert.Equal(t, opId1.Hex(), response.Operators[0].OperatorId)
+	assert.Equal(t, 0, response.Operators[0].PreviousRank)
+	assert.Equal(t, 0, response.Operators[0].RankChange)
+	assert.True(t, response.Pagination.HasMore)
+	assert.Equal(t, response.Pagination.NextToken, response.PaginationToken)
+
+	response = fetch("quorum=1&limit=1&cursor="+response.PaginationToken, http.StatusOK)
+	require.Len(t, response.Operators, 1)
+	assert.Equal(t, opId0.Hex(), response.Operators[0].OperatorId)
+	assert.Equal(t, 2, response.Operators[0].Rank)
+	assert.False(t, response.Pagination.HasMore)
+	assert.Empty(t, response.PaginationToken)
+}
+
+func TestEstimateDispersalCostHandler(t *testing.T) {
+	r := setUpRouter()
+
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetCurrentBlockNumber").Return(uint32(1), nil)
+	chainReader.On("GetRequiredQuorumNumbers").Return([]uint8{0, 1}, nil)
+	chainReader.On("GetQuorumCount").Return(uint8(3), nil)
+	chainReader.On("GetMinNumSymbols").Return(uint32(4096), nil)
+	chainReader.On("GetPricePerSymbol").Return(uint32(447), nil)
+	chainReader.On("GetReservationWindow").Return(uint32(300), nil)
+	opts := testServerV2Options()
+	opts.ChainReader = chainReader
+	server := dataapi.NewServerV2(config, opts)
+	r.GET("/v2/payments/cost", server.EstimateDispersalCostHandler)
+
+	fetch := func(query string, status int) *dataapi.DispersalCostEstimate {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/payments/cost?"+query, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, status, w.Code, query)
+		var response dataapi.DispersalCostEstimate
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return &response
+	}
+
+	for _, query := range []string{"", "blob_size=0", "blob_size=xyz", "blob_size=16777217", "blob_size=1000&quorums=xyz"} {
+		fetch(query, http.StatusBadRequest)
+	}
+	fetch("blob_size=1000&quorums=0,5", http.StatusNotFound)
+
+	// 1000 bytes are 32 symbols, charged as the min number of symbols, to the required quorums by default
+	response := fetch("blob_size=1000", http.StatusOK)
+	assert.Equal(t, uint64(1000), response.BlobSize)
+	assert.Equal(t, []core.QuorumID{0, 1}, response.QuorumNumbers)
+	assert.Equal(t, uint64(32), response.NumSymbols)
+	assert.Equal(t, uint64(4096), response.SymbolsCharged)
+	assert.True(t, response.OnDemand.Allowed)
+	assert.Equal(t, uint32(447), response.OnDemand.PricePerSymbol)
+	assert.Equal(t, big.NewInt(4096*447), response.OnDemand.Payment)
+	assert.Equal(t, uint32(300), response.Reservation.ReservationWindow)
+	assert.Equal(t, uint64(14), response.Reservation.SymbolsPerSecond)
+
+	// 200000 bytes are 6250 symbols, padded to 8192; quorum 2 can't be paid for on demand
+	response = fetch("blob_size=200000&quorums=2,0", http.StatusOK)
+	assert.Equal(t, []core.QuorumID{0, 2}, response.QuorumNumbers)
+	assert.Equal(t, uint64(8192), response.NumSymbols)
+	assert.Equal(t, uint64(8192), response.SymbolsCharged)
+	assert.False(t, response.OnDemand.Allowed)
+	assert.Equal(t, big.NewInt(8192*447), response.OnDemand.Payment)
+	assert.Equal(t, uint64(28), response.Reservation.SymbolsPerSecond)
+}
+
+func TestFetchMetricsSummaryHandler(t *testing.T) {
 	r := setUpRouter()
 
 	s := new(model.SampleStream)
@@ -433,7 +2778,39 @@ func TestFetchMetricsSummaryHandler(t *testing.T) {
 
 	matrix := make(model.Matrix, 0)
 	matrix = append(matrix, s)
-	mockPrometheusApi.On("QueryRange").Return(matrix, nil, nil).Once()
+	// Queried for the avg throughput, then for the bytes dispersed in the last day
+	mockPrometheusApi.On("QueryRange").Return(matrix, nil, nil).Twice()
+	mockTx.On("GetCurrentBlockNumber").Return(uint32(1), nil)
+	mockTx.On("GetQuorumCount").Return(uint8(2), nil)
+
+	// The week before last is rolled up, so only the days after it are added
+	ctx := context.Background()
+	lastWeek := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -7)
+	for lastWeek.Weekday() != time.Monday {
+		lastWeek = lastWeek.AddDate(0, 0, -1)
+	}
+	rollups := []*commonv2.ThroughputRollup{
+		{Period: commonv2.WeeklyRollup, Timestamp: uint64(lastWeek.AddDate(0, 0, -7).Unix()), CertifiedBytes: 1000},
+		{Period: commonv2.DailyRollup, Timestamp: uint64(lastWeek.AddDate(0, 0, -1).Unix()), CertifiedBytes: 10},
+		{Period: commonv2.DailyRollup, Timestamp: uint64(lastWeek.Unix()), CertifiedBytes: 100},
+		{Period: commonv2.DailyRollup, Timestamp: uint64(lastWeek.AddDate(0, 0, 1).Unix()), CertifiedBytes: 200},
+	}
+	for _, rollup := range rollups {
+		rollup.OnDemandPayment = big.NewInt(0)
+		require.NoError(t, blobMetadataStore.PutThroughputRollup(ctx, rollup))
+	}
+
+	attestedAt := uint64(time.Now().UnixNano())
+	err = blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader: &corev2.BatchHeader{
+			BatchRoot:            [32]byte{4, 7},
+			ReferenceBlockNumber: 4700,
+		},
+		AttestedAt:    attestedAt,
+		QuorumNumbers: []core.QuorumID{0},
+		QuorumResults: map[core.QuorumID]uint8{0: 100},
+	})
+	require.NoError(t, err)
 
 	r.GET("/v2/metrics/summary", testDataApiServerV2.FetchMetricsSummaryHandler)
 
@@ -455,6 +2832,296 @@ func TestFetchMetricsSummaryHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 	assert.Equal(t, 16555.555555555555, response.AvgThroughput)
+	assert.Equal(t, uint64(59600000), response.DispersedBytes24h)
+	assert.Equal(t, uint64(1300), response.TotalDispersedBytes)
+	// The quorums and the operators in the quorum are defined in "mockChainState"
+	assert.Equal(t, map[core.QuorumID]int{0: 2, 1: 2}, response.NumOperatorsPerQuorum)
+	assert.Equal(t, 2, response.TotalOperators)
+	require.NotNil(t, response.LatestBatch)
+	assert.GreaterOrEqual(t, response.LatestBatch.AttestedAt, attestedAt)
+}
+
+func TestFetchMetricsOverviewHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Blobs dispersed in the overview window
+	now := time.Now()
+	blobSizes := []uint64{1000, 2000, 3000}
+	for i, size := range blobSizes {
+		requestedAt := uint64(now.Add(-time.Duration(i+1) * time.Minute).UnixNano())
+		metadata := &commonv2.BlobMetadata{
+			BlobHeader:  makeBlobHeaderV2(t),
+			BlobStatus:  commonv2.Certified,
+			Expiry:      uint64(now.Add(time.Hour).Unix()),
+			BlobSize:    size,
+			RequestedAt: requestedAt,
+			UpdatedAt:   requestedAt,
+		}
+		err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+	}
+
+	mockTx.On("GetCurrentBlockNumber").Return(uint32(1), nil)
+	mockTx.On("GetQuorumCount").Return(uint8(2), nil)
+
+	// Use a fresh server so the overview is not served from another test's cache
+	server := dataapi.NewServerV2(config, testServerV2Options())
+	r.GET("/v2/metrics/overview", server.FetchMetricsOverviewHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/metrics/overview", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	res := w.Result()
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var response dataapi.MetricsOverview
+	err = json.Unmarshal(data, &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(3600), response.WindowSeconds)
+	assert.GreaterOrEqual(t, response.NumBlobs, len(blobSizes))
+	assert.GreaterOrEqual(t, response.DispersedBytes, uint64(6000))
+	assert.Equal(t, float64(response.DispersedBytes)/3600, response.AvgThroughput)
+	// The quorums and the operators in the quorum are defined in "mockChainState"
+	assert.Equal(t, 2, response.TotalOperators)
+	assert.Equal(t, 2, response.NumOperatorsPerQuorum[0])
+	assert.Equal(t, 2, response.NumOperatorsPerQuorum[1])
+	assert.Equal(t, int64(2), response.TotalStakePerQuorum[0].Int64())
+	assert.Equal(t, int64(4), response.TotalStakePerQuorum[1].Int64())
+}
+
+func TestFetchBlobSizeHistogramHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Blobs dispersed in a past window, so blobs of other tests are not counted
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	blobSizes := []uint64{500, 1024, 1500, 5000, 100000}
+	for i, size := range blobSizes {
+		requestedAt := uint64(start.Add(time.Duration(i+1) * time.Minute).UnixNano())
+		metadata := &commonv2.BlobMetadata{
+			BlobHeader:  makeBlobHeaderV2(t),
+			BlobStatus:  commonv2.Certified,
+			Expiry:      uint64(time.Now().Add(time.Hour).Unix()),
+			BlobSize:    size,
+			RequestedAt: requestedAt,
+			UpdatedAt:   requestedAt,
+		}
+		err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+	}
+
+	r.GET("/v2/metrics/blob-sizes", testDataApiServerV2.FetchBlobSizeHistogramHandler)
+
+	t.Run("histogram", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reqStr := fmt.Sprintf("/v2/metrics/blob-sizes?start=%d&end=%d", start.Unix(), start.Add(time.Hour).Unix())
+		req := httptest.NewRequest(http.MethodGet, reqStr, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		var response dataapi.BlobSizeHistogram
+		err = json.Unmarshal(data, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, start.Unix(), response.Start)
+		assert.Equal(t, start.Add(time.Hour).Unix(), response.End)
+		assert.Equal(t, 5, response.NumBlobs)
+		assert.Equal(t, uint64(108024), response.TotalBytes)
+		// Buckets double from 1KiB up to the bucket of the largest blob
+		require.Equal(t, 8, len(response.Buckets))
+		expectedCounts := []int{2, 1, 0, 1, 0, 0, 0, 1}
+		for i, bucket := range response.Buckets {
+			assert.Equal(t, uint64(1024)<<i, bucket.UpperBound)
+			assert.Equal(t, expectedCounts[i], bucket.Count)
+		}
+		assert.Equal(t, uint64(1500), response.P50)
+		assert.Equal(t, uint64(100000), response.P95)
+		assert.Equal(t, uint64(100000), response.P99)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reqStr := fmt.Sprintf("/v2/metrics/blob-sizes?start=%d&end=%d", start.Add(-time.Hour).Unix(), start.Unix())
+		req := httptest.NewRequest(http.MethodGet, reqStr, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		var response dataapi.BlobSizeHistogram
+		err = json.Unmarshal(data, &response)
+		require.NoError(t, err)
+		assert.Equal(t, 0, response.NumBlobs)
+		assert.Equal(t, 0, len(response.Buckets))
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reqStr := fmt.Sprintf("/v2/metrics/blob-sizes?start=%d&end=%d", start.Unix(), start.Add(48*time.Hour).Unix())
+		req := httptest.NewRequest(http.MethodGet, reqStr, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		reqStr = fmt.Sprintf("/v2/metrics/blob-sizes?start=%d&end=%d", start.Unix(), start.Unix())
+		req = httptest.NewRequest(http.MethodGet, reqStr, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestFetchAttestationLatencyHandler(t *testing.T) {
+	r := setUpRouter()
+	ctx := context.Background()
+
+	// Batches attested in a past window, so attestations of other tests are not counted
+	start := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	putBatch := func(batchRoot [32]byte, attestedAt time.Time, blobHeaders []*corev2.BlobHeader, requestedAts []time.Time) {
+		batchHeader := &corev2.BatchHeader{
+			BatchRoot:            batchRoot,
+			ReferenceBlockNumber: 1024,
+		}
+		commitment := makeCommitment(t)
+		attestation := &corev2.Attestation{
+			BatchHeader:      batchHeader,
+			AttestedAt:       uint64(attestedAt.UnixNano()),
+			NonSignerPubKeys: []*core.G1Point{},
+			APKG2: &core.G2Point{
+				G2Affine: &bn254.G2Affine{
+					X: commitment.LengthCommitment.X,
+					Y: commitment.LengthCommitment.Y,
+				},
+			},
+			Sigma: &core.Signature{
+				G1Point: core.NewG1Point(big.NewInt(2), big.NewInt(0)),
+			},
+			QuorumNumbers: []core.QuorumID{0, 1},
+		}
+		err := blobMetadataStore.PutAttestation(ctx, attestation)
+		require.NoError(t, err)
+
+		verificationInfos := make([]*corev2.BlobVerificationInfo, len(blobHeaders))
+		for i, blobHeader := range blobHeaders {
+			blobKey, err := blobHeader.BlobKey()
+			require.NoError(t, err)
+			metadata := &commonv2.BlobMetadata{
+				BlobHeader:  blobHeader,
+				BlobStatus:  commonv2.Certified,
+				Expiry:      uint64(time.Now().Add(time.Hour).Unix()),
+				RequestedAt: uint64(requestedAts[i].UnixNano()),
+				UpdatedAt:   uint64(attestedAt.UnixNano()),
+			}
+			err = blobMetadataStore.PutBlobMetadata(ctx, metadata)
+			require.NoError(t, err)
+			verificationInfos[i] = &corev2.BlobVerificationInfo{
+				BatchHeader:    batchHeader,
+				BlobKey:        blobKey,
+				BlobIndex:      uint32(i),
+				InclusionProof: []byte("proof"),
+			}
+		}
+		err = blobMetadataStore.PutBlobVerificationInfos(ctx, verificationInfos)
+		require.NoError(t, err)
+	}
+
+	// Blobs in quorums 0 and 1 attested after 3s and 2s
+	attestedAt := start.Add(10 * time.Minute)
+	putBatch(
+		[32]byte{2, 1, 0, 1},
+		attestedAt,
+		[]*corev2.BlobHeader{makeBlobHeaderV2(t), makeBlobHeaderV2(t)},
+		[]time.Time{attestedAt.Add(-3 * time.Second), attestedAt.Add(-2 * time.Second)},
+	)
+	// Blob in quorum 0 only attested after 1s
+	attestedAt = start.Add(20 * time.Minute)
+	blobHeader := makeBlobHeaderV2(t)
+	blobHeader.QuorumNumbers = []core.QuorumID{0}
+	putBatch(
+		[32]byte{2, 1, 0, 2},
+		attestedAt,
+		[]*corev2.BlobHeader{blobHeader},
+		[]time.Time{attestedAt.Add(-time.Second)},
+	)
+
+	r.GET("/v2/metrics/attestation-latency", testDataApiServerV2.FetchAttestationLatencyHandler)
+
+	t.Run("latency by quorum", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reqStr := fmt.Sprintf("/v2/metrics/attestation-latency?start=%d&end=%d", start.Unix(), start.Add(time.Hour).Unix())
+		req := httptest.NewRequest(http.MethodGet, reqStr, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		var response dataapi.AttestationLatencyResponse
+		err = json.Unmarshal(data, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, start.Unix(), response.Start)
+		assert.Equal(t, start.Add(time.Hour).Unix(), response.End)
+		require.Len(t, response.Quorums, 2)
+		assert.Equal(t, &dataapi.LatencyDistribution{
+			NumBlobs: 3,
+			Min:      1000,
+			Mean:     2000,
+			P50:      2000,
+			P90:      3000,
+			P95:      3000,
+			P99:      3000,
+			Max:      3000,
+		}, response.Quorums["0"])
+		assert.Equal(t, &dataapi.LatencyDistribution{
+			NumBlobs: 2,
+			Min:      2000,
+			Mean:     2500,
+			P50:      2000,
+			P90:      3000,
+			P95:      3000,
+			P99:      3000,
+			Max:      3000,
+		}, response.Quorums["1"])
+	})
+
+	t.Run("partial window", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reqStr := fmt.Sprintf("/v2/metrics/attestation-latency?start=%d&end=%d", start.Add(15*time.Minute).Unix(), start.Add(time.Hour).Unix())
+		req := httptest.NewRequest(http.MethodGet, reqStr, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		var response dataapi.AttestationLatencyResponse
+		err = json.Unmarshal(data, &response)
+		require.NoError(t, err)
+		require.Len(t, response.Quorums, 1)
+		assert.Equal(t, 1, response.Quorums["0"].NumBlobs)
+		assert.Equal(t, float64(1000), response.Quorums["0"].P99)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reqStr := fmt.Sprintf("/v2/metrics/attestation-latency?start=%d&end=%d", start.Unix(), start.Add(24*time.Hour).Unix())
+		req := httptest.NewRequest(http.MethodGet, reqStr, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestFetchMetricsThroughputTimeseriesHandler(t *testing.T) {
@@ -496,3 +3163,638 @@ func TestFetchMetricsThroughputTimeseriesHandler(t *testing.T) {
 	assert.Equal(t, uint64(1701292920), response[0].Timestamp)
 	assert.Equal(t, float64(3.503022666666651e+07), totalThroughput)
 }
+
+func TestFetchMetricsThroughputTimeseriesHandlerWithResolution(t *testing.T) {
+	r := setUpRouter()
+
+	s := new(model.SampleStream)
+	err := s.UnmarshalJSON([]byte(mockPrometheusRespAvgThroughput))
+	assert.NoError(t, err)
+
+	matrix := make(model.Matrix, 0)
+	matrix = append(matrix, s)
+	// Only one query is expected to hit Prometheus, the repeated request must be served from cache
+	mockPrometheusApi.On("QueryRange").Return(matrix, nil, nil).Once()
+
+	r.GET("/v2/metrics/timeseries/throughput", testDataApiServerV2.FetchMetricsThroughputTimeseriesHandler)
+
+	fetch := func(query string) (int, []*dataapi.Throughput) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/metrics/timeseries/throughput"+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var response []*dataapi.Throughput
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(data, &response))
+		}
+		return res.StatusCode, response
+	}
+
+	code, _ := fetch("?resolution=5m")
+	assert.Equal(t, http.StatusBadRequest, code)
+	// 30 days at 1m resolution exceeds the max number of data points
+	code, _ = fetch("?start=1698700000&end=1701292000&resolution=1m")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	query := "?start=1701270000&end=1701292920&resolution=10m"
+	code, response := fetch(query)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 3361, len(response))
+	assert.Equal(t, float64(12000), response[0].Throughput)
+	assert.Equal(t, uint64(1701292920), response[0].Timestamp)
+
+	code, cached := fetch(query)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, response, cached)
+}
+
+func TestFetchOperatorsNodeInfo(t *testing.T) {
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetCurrentBlockNumber").Return(uint32(0), fmt.Errorf("unavailable"))
+	chainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	require.NoError(t, err)
+	chainState.On("GetCurrentBlockNumber").Return(uint(0), fmt.Errorf("unavailable"))
+	indexedChainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	require.NoError(t, err)
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	nodeInfoConfig := config
+	nodeInfoConfig.SocketAddr = addr
+	opts := testServerV2Options()
+	opts.ChainReader = chainReader
+	opts.ChainState = chainState
+	opts.IndexedChainState = indexedChainState
+	server := dataapi.NewServerV2(nodeInfoConfig, opts)
+	go func() {
+		_ = server.Start()
+	}()
+	defer func() {
+		assert.NoError(t, server.Shutdown())
+	}()
+
+	fetch := func() *dataapi.SemverReportResponse {
+		res, err := http.Get("http://" + addr + "/api/v2/operators/nodeinfo")
+		if err != nil {
+			return nil
+		}
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var response dataapi.SemverReportResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+		return &response
+	}
+	var report *dataapi.SemverReportResponse
+	require.Eventually(t, func() bool {
+		report = fetch()
+		return report != nil
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// The report is scanned in the background, and is due to be refreshed later
+	assert.NotEmpty(t, report.Semver)
+	assert.Greater(t, report.StaleAt, uint64(time.Now().Unix()))
+	assert.LessOrEqual(t, report.StaleAt, uint64(time.Now().Add(10*time.Minute).Unix()))
+	assert.Equal(t, report, fetch())
+}
+
+func TestOperatorAlerts(t *testing.T) {
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetCurrentBlockNumber").Return(uint32(0), fmt.Errorf("unavailable"))
+	chainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	require.NoError(t, err)
+	chainState.On("GetCurrentBlockNumber").Return(uint(0), fmt.Errorf("unavailable"))
+	indexedChainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	require.NoError(t, err)
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+	operators, err := indexedChainState.GetIndexedOperators(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, operators, 1)
+	var operatorID core.OperatorID
+	for id := range operators {
+		operatorID = id
+	}
+
+	// The webhook fails the first delivery, which is retried
+	secret := "webhook-secret"
+	var mu sync.Mutex
+	attempts := 0
+	alerts := make([]*dataapi.OperatorAlert, 0)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-EigenDA-Signature-256"))
+
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var alert dataapi.OperatorAlert
+		assert.NoError(t, json.Unmarshal(body, &alert))
+		alerts = append(alerts, &alert)
+	}))
+	defer webhook.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	alertConfig := config
+	alertConfig.SocketAddr = addr
+	alertConfig.AlertWebhookURLs = []string{webhook.URL}
+	alertConfig.AlertWebhookSecret = secret
+	alertConfig.AlertCheckInterval = 50 * time.Millisecond
+	opts := testServerV2Options()
+	opts.ChainReader = chainReader
+	opts.ChainState = chainState
+	opts.IndexedChainState = indexedChainState
+	server := dataapi.NewServerV2(alertConfig, opts)
+	go func() {
+		_ = server.Start()
+	}()
+	defer func() {
+		assert.NoError(t, server.Shutdown())
+	}()
+
+	// The operator socket accepts no connections, so the operator is reported unreachable once
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(alerts) > 0
+	}, 10*time.Second, 20*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, attempts)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, dataapi.OperatorAlertUnreachable, alerts[0].Type)
+	assert.Equal(t, dataapi.OperatorAlertFiring, alerts[0].Status)
+	assert.Equal(t, operatorID.Hex(), alerts[0].OperatorId)
+	assert.Contains(t, []string{"unreachable", "refused", "timeout"}, alerts[0].Reason)
+}
+
+func TestValidateParams(t *testing.T) {
+	// Background workers fail to read the chain and retry later, which is fine for this test
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetCurrentBlockNumber").Return(uint32(0), fmt.Errorf("unavailable"))
+	chainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	require.NoError(t, err)
+	chainState.On("GetCurrentBlockNumber").Return(uint(0), fmt.Errorf("unavailable"))
+	indexedChainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	require.NoError(t, err)
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(0), fmt.Errorf("unavailable"))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	validationConfig := config
+	validationConfig.SocketAddr = addr
+	opts := testServerV2Options()
+	opts.ChainReader = chainReader
+	opts.ChainState = chainState
+	opts.IndexedChainState = indexedChainState
+	server := dataapi.NewServerV2(validationConfig, opts)
+	go func() {
+		_ = server.Start()
+	}()
+	defer func() {
+		assert.NoError(t, server.Shutdown())
+	}()
+
+	get := func(path string) (int, *dataapi.ErrorResponse) {
+		res, err := http.Get("http://" + addr + "/api/v2" + path)
+		if err != nil {
+			return 0, nil
+		}
+		defer res.Body.Close()
+		var response dataapi.ErrorResponse
+		if res.StatusCode != http.StatusOK {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+		}
+		return res.StatusCode, &response
+	}
+	require.Eventually(t, func() bool {
+		code, _ := get("/blob/blobs/xyz")
+		return code != 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	now := time.Now().UTC()
+	for _, tc := range []struct {
+		path   string
+		errors map[string]string
+	}{
+		{"/blob/blobs/xyz", map[string]string{"blob_key": "must be a hex string"}},
+		{"/blob/blobs/0x0102/certificate", map[string]string{"blob_key": "must be 32 bytes, got 2"}},
+		{"/blob/blobs/" + strings.Repeat("ab", 32) + "/verification-info?batch_header_hash=xyz", map[string]string{"batch_header_hash": "must be a hex string"}},
+		{"/batch/batches/abc", map[string]string{"batch_header_hash": "must be a hex string"}},
+		{"/batch/batches/" + strings.Repeat("ab", 32) + "/blobs?limit=0", map[string]string{"limit": "must be an integer between 1 and 1000"}},
+		{"/operators/nonsigners?interval=2592001", map[string]string{"interval": "must be a number of seconds between 1 and 2592000"}},
+		{"/operators/" + strings.Repeat("ab", 31) + "/signing-rate", map[string]string{"operator_id": "must be 32 bytes, got 31"}},
+		{"/metrics/summary?start=abc", map[string]string{"start": "must be a Unix timestamp in seconds"}},
+		{"/metrics/blob-sizes?start=1700000000&end=1700200000", map[string]string{"start": "must be within 24h0m0s of end"}},
+		// Every invalid param is reported
+		{
+			"/blob/blobs/feed?limit=5000&after=" + now.Add(-8*24*time.Hour).Format("2006-01-02T15:04:05Z"),
+			map[string]string{"limit": "must be an integer between 1 and 1000", "after": "must be within 168h0m0s of before"},
+		},
+		{"/batch/batches/feed?before=yesterday", map[string]string{"before": "must be a UTC time in the format 2006-01-02T15:04:05Z"}},
+	} {
+		code, response := get(tc.path)
+		require.Equal(t, http.StatusBadRequest, code, tc.path)
+		assert.Equal(t, dataapi.ErrCodeInvalidArgument, response.Code, tc.path)
+		assert.Equal(t, tc.errors, response.Details, tc.path)
+	}
+
+	// Valid params reach the handler
+	code, response := get("/blob/blobs/0x" + strings.Repeat("ab", 32))
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, dataapi.ErrCodeNotFound, response.Code)
+}
+
+func TestServerV2Shutdown(t *testing.T) {
+	// Background workers fail to read the chain and retry later, which is fine for this test
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetCurrentBlockNumber").Return(uint32(0), fmt.Errorf("unavailable"))
+	chainState, err := coremock.NewChainDataMock(map[uint8]map[core.OperatorID]int{0: {opId0: 1}})
+	require.NoError(t, err)
+	chainState.On("GetCurrentBlockNumber").Return(uint(0), fmt.Errorf("unavailable"))
+	indexedChainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	require.NoError(t, err)
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(0), fmt.Errorf("unavailable"))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	shutdownConfig := config
+	shutdownConfig.SocketAddr = addr
+	shutdownConfig.ShutdownTimeout = 2 * time.Second
+	opts := testServerV2Options()
+	opts.ChainReader = chainReader
+	opts.ChainState = chainState
+	opts.IndexedChainState = indexedChainState
+	server := dataapi.NewServerV2(shutdownConfig, opts)
+
+	// The server can be stopped and started again
+	for i := 0; i < 2; i++ {
+		started := make(chan error, 1)
+		go func() {
+			started <- server.Start()
+		}()
+		require.Eventually(t, func() bool {
+			res, err := http.Get("http://" + addr + "/")
+			if err != nil {
+				return false
+			}
+			res.Body.Close()
+			return res.StatusCode == http.StatusAccepted
+		}, 5*time.Second, 20*time.Millisecond)
+
+		// An open blob stream doesn't hold up the shutdown
+		res, err := http.Get("http://" + addr + "/api/v2/blob/stream")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		start := time.Now()
+		require.NoError(t, server.Shutdown())
+		assert.Less(t, time.Since(start), shutdownConfig.ShutdownTimeout)
+		select {
+		case err := <-started:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Start did not return after Shutdown")
+		}
+		_, err = io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		res.Body.Close()
+
+		_, err = http.Get("http://" + addr + "/")
+		assert.Error(t, err)
+	}
+
+	// Shutting down a stopped server is a no-op
+	assert.NoError(t, server.Shutdown())
+}
+
+func TestHealthProbes(t *testing.T) {
+	promApi := &prommock.MockPrometheusApi{}
+	promApi.On("QueryRange").Return(model.Matrix{}, nil, nil)
+	subgraphApi := &subgraphmock.MockSubgraphApi{}
+	subgraphApi.On("QueryBatches").Return([]*subgraph.Batches{}, nil)
+	chainReader := &coremock.MockWriter{}
+	opts := testServerV2Options()
+	opts.PromClient = dataapi.NewPrometheusClient(promApi, "test-cluster")
+	opts.SubgraphClient = dataapi.NewSubgraphClient(subgraphApi, mockLogger)
+	opts.ChainReader = chainReader
+	server := dataapi.NewServerV2(config, opts)
+
+	r := setUpRouter()
+	r.GET("/healthz", server.CheckHealth)
+	r.GET("/readyz", server.CheckReadiness)
+	probe := func(path string) (int, dataapi.HealthResponse) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var response dataapi.HealthResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	chainReader.On("GetCurrentBlockNumber").Return(uint32(1), nil).Twice()
+	for _, path := range []string{"/healthz", "/readyz"} {
+		code, response := probe(path)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ok", response.Status)
+		require.Len(t, response.Dependencies, 4)
+		for name, dependency := range response.Dependencies {
+			assert.True(t, dependency.Healthy, name)
+			assert.Empty(t, dependency.Error, name)
+		}
+	}
+
+	// An unhealthy dependency makes the server not ready, but it is still alive
+	chainReader.On("GetCurrentBlockNumber").Return(uint32(0), fmt.Errorf("rpc unavailable"))
+	code, response := probe("/healthz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "degraded", response.Status)
+	assert.False(t, response.Dependencies["chain"].Healthy)
+	assert.Equal(t, "rpc unavailable", response.Dependencies["chain"].Error)
+	assert.True(t, response.Dependencies["dynamodb"].Healthy)
+
+	code, response = probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "degraded", response.Status)
+	assert.False(t, response.Dependencies["chain"].Healthy)
+}
+
+func TestCachePolicy(t *testing.T) {
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("GetRelayURLs").Return(map[uint32]string{}, nil)
+	newRouter := func(cachePolicy dataapi.CachePolicy) *gin.Engine {
+		cacheConfig := config
+		cacheConfig.CachePolicy = cachePolicy
+		opts := testServerV2Options()
+		opts.ChainReader = chainReader
+		server := dataapi.NewServerV2(cacheConfig, opts)
+		r := setUpRouter()
+		r.GET("/v2/relays/reachability", server.CheckRelaysReachability)
+		return r
+	}
+	get := func(r *gin.Engine, cacheControl string) string {
+		req := httptest.NewRequest(http.MethodGet, "/v2/relays/reachability", nil)
+		if cacheControl != "" {
+			req.Header.Set("Cache-Control", cacheControl)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Header().Get("Cache-Control")
+	}
+
+	// Zero max ages use the defaults
+	assert.Equal(t, "max-age=60", get(newRouter(dataapi.CachePolicy{}), ""))
+
+	r := newRouter(dataapi.CachePolicy{ReachabilityMaxAge: 15 * time.Second})
+	assert.Equal(t, "max-age=15", get(r, ""))
+	assert.Equal(t, "max-age=15", get(r, "no-cache"))
+	assert.Equal(t, "no-store", get(r, "no-store"))
+	assert.Equal(t, "no-store", get(r, "max-age=0, No-Store"))
+}
+
+func TestQueryCache(t *testing.T) {
+	indexedChainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	require.NoError(t, err)
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(0), fmt.Errorf("rpc unavailable")).Once()
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+
+	cacheConfig := config
+	cacheConfig.QueryCacheTTL = time.Minute
+	opts := testServerV2Options()
+	opts.IndexedChainState = indexedChainState
+	server := dataapi.NewServerV2(cacheConfig, opts)
+	r := setUpRouter()
+	r.GET("/v2/operators/stake", server.FetchOperatorsStake)
+	get := func(query string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/operators/stake"+query, nil))
+		return w.Code
+	}
+
+	// Errors are not cached
+	assert.Equal(t, http.StatusInternalServerError, get(""))
+	indexedChainState.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 1)
+
+	// A burst of requests queries the chain once
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, http.StatusOK, get(""))
+		}()
+	}
+	wg.Wait()
+	indexedChainState.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 2)
+
+	// Results are cached by query params
+	assert.Equal(t, http.StatusOK, get("?operator_id="+opId0.Hex()))
+	assert.Equal(t, http.StatusOK, get("?operator_id="+opId0.Hex()))
+	indexedChainState.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 3)
+}
+
+func TestRecordMetrics(t *testing.T) {
+	metrics := dataapi.NewMetrics(nil, "9001", mockLogger)
+	opts := testServerV2Options()
+	opts.Metrics = metrics
+	server := dataapi.NewServerV2(config, opts)
+	r := setUpRouter()
+	r.Use(server.RecordMetrics())
+	r.GET("/v2/blobs/:blob_key", func(c *gin.Context) {
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.InFlightRequests))
+		if c.Param("blob_key") == "missing" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	get := func(path string) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	get("/v2/blobs/a")
+	get("/v2/blobs/b")
+	get("/v2/blobs/missing")
+	get("/v2/unknown")
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.InFlightRequests))
+	for _, labels := range [][]string{
+		{http.MethodGet, "/v2/blobs/:blob_key", "200"},
+		{http.MethodGet, "/v2/blobs/:blob_key", "404"},
+		{http.MethodGet, "unmatched", "404"},
+	} {
+		_, err := metrics.RequestDuration.GetMetricWithLabelValues(labels...)
+		require.NoError(t, err)
+	}
+	// Requests are labeled by route template, so that the blob keys don't make a series each
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.RequestDuration))
+}
+
+func TestTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	blobHeader := makeBlobHeaderV2(t)
+	metadata := &commonv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: commonv2.Queued,
+		Expiry:     uint64(time.Now().Add(time.Hour).Unix()),
+		UpdatedAt:  uint64(time.Now().UnixNano()),
+	}
+	require.NoError(t, blobMetadataStore.PutBlobMetadata(context.Background(), metadata))
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+
+	r := setUpRouter()
+	r.GET("/v2/blobs/:blob_key", testDataApiServerV2.Trace(), testDataApiServerV2.FetchBlobHandler)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v2/blobs/"+blobKey.Hex(), nil)
+	// The request is part of a trace started by the client
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	storeSpan, requestSpan := spans[0], spans[1]
+
+	assert.Equal(t, "GET /v2/blobs/:blob_key", requestSpan.Name())
+	assert.Equal(t, traceID, requestSpan.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", requestSpan.Parent().SpanID().String())
+	assert.Contains(t, requestSpan.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+	assert.Contains(t, requestSpan.Attributes(), attribute.String("http.route", "/v2/blobs/:blob_key"))
+
+	assert.Equal(t, "BlobMetadataStore.GetBlobMetadata", storeSpan.Name())
+	assert.Equal(t, traceID, storeSpan.SpanContext().TraceID().String())
+	assert.Equal(t, requestSpan.SpanContext().SpanID(), storeSpan.Parent().SpanID())
+	assert.Equal(t, codes.Unset, storeSpan.Status().Code)
+}
+
+func TestSearch(t *testing.T) {
+	subgraphApi := &subgraphmock.MockSubgraphApi{}
+	subgraphApi.On("QueryRegisteredOperatorsByTransactionHash").Return([]*subgraph.Operator{}, nil)
+	subgraphApi.On("QueryDeregisteredOperatorsByTransactionHash").Return([]*subgraph.Operator{}, nil)
+	chainReader := &coremock.MockWriter{}
+	chainReader.On("OperatorIDToAddress").Return(gethcommon.Address{}, nil)
+	opts := testServerV2Options()
+	opts.SubgraphClient = dataapi.NewSubgraphClient(subgraphApi, mockLogger)
+	opts.ChainReader = chainReader
+	server := dataapi.NewServerV2(config, opts)
+
+	r := setUpRouter()
+	r.GET("/v2/search", server.SearchHandler)
+	search := func(query string) (int, *dataapi.SearchResponse) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/search?q="+query, nil))
+		var response dataapi.SearchResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, &response
+	}
+
+	for _, query := range []string{"", "xyz", "0x1234"} {
+		code, _ := search(query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+
+	// Nothing matches
+	code, _ := search("0x" + strings.Repeat("ff", 32))
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// A blob key
+	account := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+	now := time.Now()
+	blobHeader := makeBlobHeaderV2(t)
+	blobHeader.PaymentMetadata.AccountID = account.Hex()
+	metadata := &commonv2.BlobMetadata{
+		BlobHeader:  blobHeader,
+		BlobStatus:  commonv2.Queued,
+		Expiry:      uint64(now.Add(time.Hour).Unix()),
+		RequestedAt: uint64(now.UnixNano()),
+		UpdatedAt:   uint64(now.UnixNano()),
+	}
+	require.NoError(t, blobMetadataStore.PutBlobMetadata(context.Background(), metadata))
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+
+	code, response := search(blobKey.Hex())
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, blobKey.Hex(), response.Query)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, "blob", response.Results[0].Type)
+	assert.Equal(t, "Queued", response.Results[0].Blob.Status)
+
+	// An operator ID
+	operatorId := core.OperatorID{1}
+	operatorAddress := gethcommon.HexToAddress("0x0000000000000000000000000000000000000abc")
+	chainReader.ExpectedCalls = nil
+	chainReader.On("OperatorIDToAddress").Return(operatorAddress, nil)
+	code, response = search("0x" + operatorId.Hex())
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, "operator", response.Results[0].Type)
+	assert.Equal(t, operatorId.Hex(), response.Results[0].Operator.OperatorId)
+	assert.Equal(t, operatorAddress.Hex(), response.Results[0].Operator.OperatorAddress)
+
+	// An address may be both an operator and an account
+	chainReader.On("OperatorAddressToID").Return(operatorId, nil)
+	code, response = search(strings.ToLower(account.Hex()))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, "operator", response.Results[0].Type)
+	assert.Equal(t, account.Hex(), response.Results[0].Operator.OperatorAddress)
+	assert.Equal(t, "account", response.Results[1].Type)
+	assert.Equal(t, account.Hex(), response.Results[1].Account.AccountId)
+	require.Len(t, response.Results[1].Account.Blobs, 1)
+	assert.Equal(t, blobKey.Hex(), response.Results[1].Account.Blobs[0].BlobKey)
+
+	// An L1 transaction hash
+	txHash := "0x" + strings.Repeat("ab", 32)
+	chainReader.ExpectedCalls = nil
+	chainReader.On("OperatorIDToAddress").Return(gethcommon.Address{}, nil)
+	subgraphApi.ExpectedCalls = nil
+	subgraphApi.On("QueryRegisteredOperatorsByTransactionHash").Return([]*subgraph.Operator{
+		{OperatorId: "operator-1", Operator: "address-1", BlockTimestamp: "1696975449", BlockNumber: "87", TransactionHash: graphql.String(txHash)},
+	}, nil)
+	subgraphApi.On("QueryDeregisteredOperatorsByTransactionHash").Return([]*subgraph.Operator{
+		{OperatorId: "operator-2", Operator: "address-2", BlockTimestamp: "1696975449", BlockNumber: "87", TransactionHash: graphql.String(txHash)},
+	}, nil)
+	code, response = search(txHash)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, "transaction", response.Results[0].Type)
+	assert.Equal(t, txHash, response.Results[0].Transaction.TransactionHash)
+	require.Len(t, response.Results[0].Transaction.OperatorEvents, 1)
+	event := response.Results[0].Transaction.OperatorEvents[0]
+	assert.Equal(t, dataapi.OperatorChurnedEvent, event.EventType)
+	assert.Equal(t, "operator-1", event.OperatorId)
+	assert.Equal(t, []string{"operator-2"}, event.ChurnedOperatorIds)
+}
@@ -0,0 +1,377 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	// signingRateBucketSize is the granularity at which signing tallies are kept
+	signingRateBucketSize = time.Hour
+	// signingRateRetention is how long signing tallies are kept, which bounds the largest window
+	signingRateRetention = 30 * 24 * time.Hour
+	// signingRateRefreshInterval is how often new attestations are tallied in the background
+	signingRateRefreshInterval = time.Minute
+	// signingRateStoreInterval is how often the owner checks for completed hours whose tallies aren't stored
+	signingRateStoreInterval = 10 * time.Minute
+	// signingRateSettleDelay holds back recent attestations, as they are stamped with AttestedAt
+	// before being written to the metadata store
+	signingRateSettleDelay = 3 * time.Second
+	// signingRatePageSize is the max number of attestations fetched per query when tallying
+	signingRatePageSize = 1000
+	// signingRateStateCacheSize is the number of operator states cached by reference block number and quorums
+	signingRateStateCacheSize = 64
+)
+
+// signingRateWindows are the rolling windows signing rates are reported over
+var signingRateWindows = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1d", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+}
+
+// signingTally counts the batches an operator was required to sign and those it did not sign
+type signingTally struct {
+	batches         int
+	unsignedBatches int
+}
+
+// signingRateAggregator tallies v2 attestations per operator in hourly buckets, so that signing rates over rolling
+// windows can be served without scanning raw batches per request. The tallies of the completed hours are stored in
+// the metadata store by the instance owning them, in the background, and loaded by every instance. Each instance only
+// tallies the attestations made since the hour before it started.
+type signingRateAggregator struct {
+	logger            logging.Logger
+	blobMetadataStore *blobstore.BlobMetadataStore
+	chainState        core.ChainState
+	stateCache        *expirable.LRU[string, *core.OperatorState]
+	// owner stores the tallies of the completed hours
+	owner bool
+
+	// serializes updates
+	updateMu sync.Mutex
+
+	mu sync.RWMutex
+	// tallies by bucket start (unix nanoseconds) and operator; a loaded bucket without batches has an empty map
+	tallies map[uint64]map[core.OperatorID]*signingTally
+	// start of the first bucket tallied from the attestations, zero before the first update
+	liveFrom uint64
+	// AttestedAt of the last tallied attestation
+	cursor uint64
+}
+
+func newSigningRateAggregator(
+	logger logging.Logger,
+	blobMetadataStore *blobstore.BlobMetadataStore,
+	chainState core.ChainState,
+	owner bool,
+) *signingRateAggregator {
+	return &signingRateAggregator{
+		logger:            logger,
+		blobMetadataStore: blobMetadataStore,
+		chainState:        chainState,
+		stateCache:        expirable.NewLRU[string, *core.OperatorState](signingRateStateCacheSize, nil, 0),
+		owner:             owner,
+		tallies:           make(map[uint64]map[core.OperatorID]*signingTally),
+	}
+}
+
+// start tallies new attestations every interval until the context is cancelled. The owner also stores the tallies of
+// the completed hours every signingRateStoreInterval.
+func (a *signingRateAggregator) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := a.update(ctx, time.Now()); err != nil {
+				a.logger.Warn("failed to update signing rates", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	if !a.owner {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(signingRateStoreInterval)
+		defer ticker.Stop()
+		for {
+			if err := a.store(ctx, time.Now()); err != nil {
+				a.logger.Warn("failed to store signing tallies", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// update tallies all attestations made since the last update, loads the stored tallies of the earlier hours which
+// aren't loaded yet, and drops buckets past retention.
+func (a *signingRateAggregator) update(ctx context.Context, now time.Time) error {
+	a.updateMu.Lock()
+	defer a.updateMu.Unlock()
+
+	end := uint64(now.Add(-signingRateSettleDelay).UnixNano())
+	a.mu.Lock()
+	if a.liveFrom == 0 {
+		// the tallies of the previous hour may not be stored yet
+		a.liveFrom = end - end%uint64(signingRateBucketSize) - uint64(signingRateBucketSize)
+		a.cursor = a.liveFrom - 1
+	}
+	cursor := a.cursor
+	liveFrom := a.liveFrom
+	a.mu.Unlock()
+
+	err := a.forEachAttestation(ctx, cursor, end, func(at *corev2.Attestation, state *core.OperatorState) {
+		bucket := at.AttestedAt - at.AttestedAt%uint64(signingRateBucketSize)
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		tallies, ok := a.tallies[bucket]
+		if !ok {
+			tallies = make(map[core.OperatorID]*signingTally)
+			a.tallies[bucket] = tallies
+		}
+		countBatch(tallies, at.QuorumNumbers, at.NonSignerPubKeys, state)
+		// advance the cursor along with the tallies, so that a failed update resumes without double counting
+		a.cursor = at.AttestedAt
+	})
+	if err != nil {
+		return err
+	}
+
+	retentionStart := uint64(now.Add(-signingRateRetention).UnixNano())
+	if err := a.load(ctx, retentionStart, liveFrom); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// all attestations up to the end of the range have been tallied
+	a.cursor = end - 1
+	for bucket := range a.tallies {
+		if bucket+uint64(signingRateBucketSize) <= retentionStart {
+			delete(a.tallies, bucket)
+		}
+	}
+	return nil
+}
+
+// load adds the stored tallies of the buckets in the retention period before liveFrom which aren't loaded yet
+func (a *signingRateAggregator) load(ctx context.Context, retentionStart, liveFrom uint64) error {
+	missing := make([]uint64, 0)
+	a.mu.RLock()
+	for bucket := retentionStart - retentionStart%uint64(signingRateBucketSize); bucket < liveFrom; bucket += uint64(signingRateBucketSize) {
+		if _, ok := a.tallies[bucket]; !ok {
+			missing = append(missing, bucket/uint64(time.Second))
+		}
+	}
+	a.mu.RUnlock()
+	if len(missing) == 0 {
+		return nil
+	}
+
+	stored, err := a.blobMetadataStore.GetSigningTallies(ctx, missing)
+	if err != nil {
+		return fmt.Errorf("failed to get signing tallies: %w", err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, hour := range stored {
+		tallies := make(map[core.OperatorID]*signingTally, len(hour.Tallies))
+		for opID, t := range hour.Tallies {
+			tallies[opID] = &signingTally{batches: int(t.Batches), unsignedBatches: int(t.UnsignedBatches)}
+		}
+		a.tallies[hour.Timestamp*uint64(time.Second)] = tallies
+	}
+	return nil
+}
+
+// store tallies and stores the completed hours in the retention period which aren't stored yet, newest first.
+// Tallies stored by another instance are left untouched.
+func (a *signingRateAggregator) store(ctx context.Context, now time.Time) error {
+	end := uint64(now.Add(-signingRateSettleDelay).UnixNano())
+	lastCompleted := end - end%uint64(signingRateBucketSize) - uint64(signingRateBucketSize)
+	retentionStart := uint64(now.Add(-signingRateRetention).UnixNano())
+	timestamps := make([]uint64, 0)
+	for bucket := retentionStart - retentionStart%uint64(signingRateBucketSize); bucket <= lastCompleted; bucket += uint64(signingRateBucketSize) {
+		timestamps = append(timestamps, bucket/uint64(time.Second))
+	}
+
+	stored, err := a.blobMetadataStore.GetSigningTallies(ctx, timestamps)
+	if err != nil {
+		return fmt.Errorf("failed to get signing tallies: %w", err)
+	}
+	isStored := make(map[uint64]bool, len(stored))
+	for _, hour := range stored {
+		isStored[hour.Timestamp] = true
+	}
+	for i := len(timestamps) - 1; i >= 0; i-- {
+		if isStored[timestamps[i]] {
+			continue
+		}
+		bucket := timestamps[i] * uint64(time.Second)
+		tallies := make(map[core.OperatorID]*signingTally)
+		err := a.forEachAttestation(ctx, bucket-1, bucket+uint64(signingRateBucketSize), func(at *corev2.Attestation, state *core.OperatorState) {
+			countBatch(tallies, at.QuorumNumbers, at.NonSignerPubKeys, state)
+		})
+		if err != nil {
+			return err
+		}
+		hour := &commonv2.SigningTallies{
+			Timestamp: timestamps[i],
+			Tallies:   make(map[core.OperatorID]*commonv2.SigningTally, len(tallies)),
+		}
+		for opID, t := range tallies {
+			hour.Tallies[opID] = &commonv2.SigningTally{Batches: uint64(t.batches), UnsignedBatches: uint64(t.unsignedBatches)}
+		}
+		err = a.blobMetadataStore.PutSigningTallies(ctx, hour)
+		if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
+			return fmt.Errorf("failed to store signing tallies: %w", err)
+		}
+	}
+	return nil
+}
+
+// forEachAttestation calls fn with every attestation made in (start, end), in order, and the operator state at its
+// reference block
+func (a *signingRateAggregator) forEachAttestation(ctx context.Context, start, end uint64, fn func(*corev2.Attestation, *core.OperatorState)) error {
+	cursor := start
+	for cursor+1 < end {
+		attestations, err := a.blobMetadataStore.GetAttestationByAttestedAt(ctx, cursor, end, signingRatePageSize)
+		if err != nil {
+			return fmt.Errorf("failed to fetch attestations: %w", err)
+		}
+		for _, at := range attestations {
+			state, err := a.getOperatorState(ctx, at.ReferenceBlockNumber, at.QuorumNumbers)
+			if err != nil {
+				return err
+			}
+			fn(at, state)
+			cursor = at.AttestedAt
+		}
+		if len(attestations) < signingRatePageSize {
+			break
+		}
+	}
+	return nil
+}
+
+func (a *signingRateAggregator) getOperatorState(ctx context.Context, referenceBlockNumber uint64, quorums []core.QuorumID) (*core.OperatorState, error) {
+	// consecutive batches usually share the reference block and quorums
+	key := fmt.Sprintf("%d/%v", referenceBlockNumber, quorums)
+	if state, ok := a.stateCache.Get(key); ok {
+		return state, nil
+	}
+	state, err := a.chainState.GetOperatorState(ctx, uint(referenceBlockNumber), quorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", referenceBlockNumber, err)
+	}
+	a.stateCache.Add(key, state)
+	return state, nil
+}
+
+// countBatch counts one batch for every operator in any of its quorums, and an unsigned batch for its non-signers
+func countBatch(tallies map[core.OperatorID]*signingTally, quorums []core.QuorumID, nonSignerPubKeys []*core.G1Point, state *core.OperatorState) {
+	nonSigners := make(map[core.OperatorID]struct{}, len(nonSignerPubKeys))
+	for _, pubKey := range nonSignerPubKeys {
+		nonSigners[pubKey.GetOperatorID()] = struct{}{}
+	}
+	operators := make(map[core.OperatorID]struct{})
+	for _, q := range quorums {
+		for opID := range state.Operators[q] {
+			operators[opID] = struct{}{}
+		}
+	}
+
+	for opID := range operators {
+		t, ok := tallies[opID]
+		if !ok {
+			t = &signingTally{}
+			tallies[opID] = t
+		}
+		t.batches++
+		if _, ok := nonSigners[opID]; ok {
+			t.unsignedBatches++
+		}
+	}
+}
+
+// getSigningRates returns the signing rates of the operator over each window ending at now.
+// Windows are aligned to the bucket size, so they may include up to one extra bucket of history.
+// Before the first background update, the tallies are updated on demand, which only reads the attestations of the
+// last hours and the stored tallies.
+func (a *signingRateAggregator) getSigningRates(ctx context.Context, operatorID core.OperatorID, now time.Time) ([]*OperatorSigningRate, error) {
+	a.mu.RLock()
+	initialized := a.liveFrom != 0
+	a.mu.RUnlock()
+	if !initialized {
+		if err := a.update(ctx, now); err != nil {
+			return nil, err
+		}
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	rates := make([]*OperatorSigningRate, len(signingRateWindows))
+	for i, window := range signingRateWindows {
+		windowStart := uint64(now.Add(-window.duration).UnixNano())
+		windowStart -= windowStart % uint64(signingRateBucketSize)
+		rate := &OperatorSigningRate{Window: window.name}
+		for bucket, tallies := range a.tallies {
+			t, ok := tallies[operatorID]
+			if !ok || bucket < windowStart {
+				continue
+			}
+			rate.TotalBatches += t.batches
+			rate.UnsignedBatches += t.unsignedBatches
+		}
+		if rate.TotalBatches > 0 {
+			rate.SigningPercentage = float64(rate.TotalBatches-rate.UnsignedBatches) * 100 / float64(rate.TotalBatches)
+		}
+		rates[i] = rate
+	}
+	return rates, nil
+}
+
+// getTalliesSince returns the batches each operator was required to sign, and did not sign, since the start of the
+// bucket containing since
+func (a *signingRateAggregator) getTalliesSince(since time.Time) map[core.OperatorID]signingTally {
+	start := uint64(since.UnixNano())
+	start -= start % uint64(signingRateBucketSize)
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	totals := make(map[core.OperatorID]signingTally)
+	for bucket, tallies := range a.tallies {
+		if bucket < start {
+			continue
+		}
+		for opID, t := range tallies {
+			total := totals[opID]
+			total.batches += t.batches
+			total.unsignedBatches += t.unsignedBatches
+			totals[opID] = total
+		}
+	}
+	return totals
+}
@@ -0,0 +1,224 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/operators"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// stakeSnapshotPeriod is the granularity of the stake history; one snapshot is taken per period
+	stakeSnapshotPeriod = 24 * time.Hour
+	// stakeSnapshotCheckInterval is how often the snapshotter checks whether the current period has a snapshot
+	stakeSnapshotCheckInterval = 10 * time.Minute
+	// defaultStakeHistoryDays is the number of daily snapshots returned when no start is given
+	defaultStakeHistoryDays = 30
+	// maxStakeHistoryDays is the max number of daily snapshots returned by a single request
+	maxStakeHistoryDays = 90
+)
+
+// stakeSnapshotter records the stake distribution of all quorums once per day in the metadata store,
+// so that the history can be served without replaying operator state at historical blocks.
+type stakeSnapshotter struct {
+	logger            logging.Logger
+	blobMetadataStore *blobstore.BlobMetadataStore
+	chainReader       core.Reader
+	chainState        core.ChainState
+
+	// start of the period the last snapshot was recorded for; only accessed by the snapshotting loop
+	lastSnapshot time.Time
+}
+
+func newStakeSnapshotter(
+	logger logging.Logger,
+	blobMetadataStore *blobstore.BlobMetadataStore,
+	chainReader core.Reader,
+	chainState core.ChainState,
+) *stakeSnapshotter {
+	return &stakeSnapshotter{
+		logger:            logger,
+		blobMetadataStore: blobMetadataStore,
+		chainReader:       chainReader,
+		chainState:        chainState,
+	}
+}
+
+// start records a snapshot for the current period every interval, if none was recorded yet, until the context is cancelled
+func (s *stakeSnapshotter) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := s.snapshot(ctx, time.Now()); err != nil {
+				s.logger.Warn("failed to snapshot stake distribution", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// snapshot records the current stake distribution for the period containing now.
+// A snapshot recorded for the period by another instance is left untouched.
+func (s *stakeSnapshotter) snapshot(ctx context.Context, now time.Time) error {
+	period := now.UTC().Truncate(stakeSnapshotPeriod)
+	if period.Equal(s.lastSnapshot) {
+		return nil
+	}
+
+	blockNumber, err := s.chainState.GetCurrentBlockNumber()
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	quorumCount, err := s.chainReader.GetQuorumCount(ctx, uint32(blockNumber))
+	if err != nil {
+		return fmt.Errorf("failed to get quorum count at block %d: %w", blockNumber, err)
+	}
+	quorumIDs := make([]core.QuorumID, quorumCount)
+	for i := range quorumIDs {
+		quorumIDs[i] = core.QuorumID(i)
+	}
+	state, err := s.chainState.GetOperatorState(ctx, blockNumber, quorumIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get operator state at block %d: %w", blockNumber, err)
+	}
+
+	snapshot := &commonv2.StakeSnapshot{
+		Timestamp:   uint64(period.Unix()),
+		BlockNumber: uint64(blockNumber),
+		Stakes:      make(map[core.QuorumID]map[core.OperatorID]*big.Int, len(state.Operators)),
+	}
+	for q, ops := range state.Operators {
+		snapshot.Stakes[q] = make(map[core.OperatorID]*big.Int, len(ops))
+		for opID, info := range ops {
+			snapshot.Stakes[q][opID] = info.Stake
+		}
+	}
+	err = s.blobMetadataStore.PutStakeSnapshot(ctx, snapshot)
+	if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
+		return fmt.Errorf("failed to store stake snapshot: %w", err)
+	}
+
+	s.lastSnapshot = period
+	return nil
+}
+
+// getStakeHistory returns the recorded daily snapshots for the days in the inclusive range [start, end]
+func (s *stakeSnapshotter) getStakeHistory(ctx context.Context, start, end time.Time) (*OperatorsStakeHistoryResponse, error) {
+	timestamps := make([]uint64, 0)
+	for day := start.UTC().Truncate(stakeSnapshotPeriod); !day.After(end); day = day.Add(stakeSnapshotPeriod) {
+		timestamps = append(timestamps, uint64(day.Unix()))
+	}
+	snapshots, err := s.blobMetadataStore.GetStakeSnapshots(ctx, timestamps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stake snapshots: %w", err)
+	}
+
+	response := &OperatorsStakeHistoryResponse{
+		Snapshots: make([]*StakeSnapshot, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		response.Snapshots[i] = convertStakeSnapshot(snapshot)
+	}
+	return response, nil
+}
+
+// getStakeLeaderboard compares the current ranks of the operators of the quorum with their ranks in the snapshot of
+// the previous day, and returns the leaderboard along with the timestamp of that snapshot. Operators are not compared
+// if the snapshot was not recorded, in which case the timestamp is 0.
+func (s *stakeSnapshotter) getStakeLeaderboard(
+	ctx context.Context,
+	quorum core.QuorumID,
+	ranked []*OperatorStake,
+	now time.Time,
+) ([]*StakeLeaderboardEntry, uint64, error) {
+	previousDay := now.UTC().Truncate(stakeSnapshotPeriod).Add(-stakeSnapshotPeriod)
+	snapshots, err := s.blobMetadataStore.GetStakeSnapshots(ctx, []uint64{uint64(previousDay.Unix())})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get stake snapshot: %w", err)
+	}
+
+	var timestamp uint64
+	previousRanks := make(map[string]int)
+	if len(snapshots) > 0 {
+		previous := convertStakeSnapshot(snapshots[0])
+		timestamp = previous.Timestamp
+		if distribution, ok := previous.Quorums[fmt.Sprintf("%d", quorum)]; ok {
+			for _, op := range distribution.Operators {
+				previousRanks[op.OperatorId] = op.Rank
+			}
+		}
+	}
+
+	leaderboard := make([]*StakeLeaderboardEntry, len(ranked))
+	for i, op := range ranked {
+		entry := &StakeLeaderboardEntry{
+			OperatorId:      op.OperatorId,
+			StakePercentage: op.StakePercentage,
+			Rank:            op.Rank,
+		}
+		if rank, ok := previousRanks[op.OperatorId]; ok {
+			entry.PreviousRank = rank
+			entry.RankChange = rank - op.Rank
+		}
+		leaderboard[i] = entry
+	}
+	return leaderboard, timestamp, nil
+}
+
+// convertStakeSnapshot ranks the operators of each quorum in the snapshot by stake share
+func convertStakeSnapshot(snapshot *commonv2.StakeSnapshot) *StakeSnapshot {
+	state := &core.OperatorState{
+		Operators: make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo, len(snapshot.Stakes)),
+		Totals:    make(map[core.QuorumID]*core.OperatorInfo, len(snapshot.Stakes)),
+	}
+	for q, stakes := range snapshot.Stakes {
+		total := big.NewInt(0)
+		state.Operators[q] = make(map[core.OperatorID]*core.OperatorInfo, len(stakes))
+		for opID, stake := range stakes {
+			state.Operators[q][opID] = &core.OperatorInfo{Stake: stake}
+			total.Add(total, stake)
+		}
+		state.Totals[q] = &core.OperatorInfo{Stake: total}
+		// shares are undefined without stake
+		if total.Sign() == 0 {
+			delete(state.Operators, q)
+		}
+	}
+
+	_, quorumsStake := operators.GetRankedOperators(state)
+	result := &StakeSnapshot{
+		Timestamp:   snapshot.Timestamp,
+		BlockNumber: snapshot.BlockNumber,
+		Quorums:     make(map[string]*QuorumStakeDistribution, len(snapshot.Stakes)),
+	}
+	for q := range snapshot.Stakes {
+		quorum := fmt.Sprintf("%d", q)
+		distribution := &QuorumStakeDistribution{
+			TotalStake: state.Totals[q].Stake,
+			Operators:  make([]*OperatorStake, 0, len(quorumsStake[q])),
+		}
+		for i, op := range quorumsStake[q] {
+			distribution.Operators = append(distribution.Operators, &OperatorStake{
+				QuorumId:        quorum,
+				OperatorId:      op.OperatorId.Hex(),
+				StakePercentage: op.StakeShare / 100.0,
+				Rank:            i + 1,
+			})
+		}
+		result.Quorums[quorum] = distribution
+	}
+	return result
+}
@@ -24,6 +24,10 @@ type (
 		QueryBatchNonSigningInfo(ctx context.Context, startTime, endTime int64) ([]*BatchNonSigningInfo, error)
 		QueryDeregisteredOperatorsGreaterThanBlockTimestamp(ctx context.Context, blockTimestamp uint64) ([]*Operator, error)
 		QueryRegisteredOperatorsGreaterThanBlockTimestamp(ctx context.Context, blockTimestamp uint64) ([]*Operator, error)
+		QueryRegisteredOperatorsByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*Operator, error)
+		QueryDeregisteredOperatorsByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*Operator, error)
+		QueryRegisteredOperatorsByTransactionHash(ctx context.Context, txHash string) ([]*Operator, error)
+		QueryDeregisteredOperatorsByTransactionHash(ctx context.Context, txHash string) ([]*Operator, error)
 		QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context.Context, operatorId string, blockNumber uint32) (*IndexedOperatorInfo, error)
 		QueryOperatorAddedToQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*OperatorQuorum, error)
 		QueryOperatorRemovedFromQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*OperatorQuorum, error)
@@ -196,6 +200,88 @@ func (a *api) QueryDeregisteredOperatorsGreaterThanBlockTimestamp(ctx context.Co
 	return query.OperatorDeregistereds, nil
 }
 
+// QueryRegisteredOperatorsByBlockTimestampRange finds operator registrations with block timestamp in range [start, end].
+func (a *api) QueryRegisteredOperatorsByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*Operator, error) {
+	variables := map[string]any{
+		"blockTimestamp_gte": graphql.Int(start),
+		"blockTimestamp_lte": graphql.Int(end),
+	}
+	skip := 0
+	query := new(queryOperatorRegisteredsByBlockTimestampRange)
+	result := make([]*Operator, 0)
+	for {
+		variables["first"] = graphql.Int(maxEntriesPerQuery)
+		variables["skip"] = graphql.Int(skip)
+
+		err := a.operatorStateGql.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(query.OperatorRegistereds) == 0 {
+			break
+		}
+		result = append(result, query.OperatorRegistereds...)
+		skip += maxEntriesPerQuery
+	}
+
+	return result, nil
+}
+
+// QueryDeregisteredOperatorsByBlockTimestampRange finds operator deregistrations with block timestamp in range [start, end].
+func (a *api) QueryDeregisteredOperatorsByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*Operator, error) {
+	variables := map[string]any{
+		"blockTimestamp_gte": graphql.Int(start),
+		"blockTimestamp_lte": graphql.Int(end),
+	}
+	skip := 0
+	query := new(queryOperatorDeregisteredsByBlockTimestampRange)
+	result := make([]*Operator, 0)
+	for {
+		variables["first"] = graphql.Int(maxEntriesPerQuery)
+		variables["skip"] = graphql.Int(skip)
+
+		err := a.operatorStateGql.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(query.OperatorDeregistereds) == 0 {
+			break
+		}
+		result = append(result, query.OperatorDeregistereds...)
+		skip += maxEntriesPerQuery
+	}
+
+	return result, nil
+}
+
+// QueryRegisteredOperatorsByTransactionHash finds operator registrations made in the transaction.
+func (a *api) QueryRegisteredOperatorsByTransactionHash(ctx context.Context, txHash string) ([]*Operator, error) {
+	variables := map[string]any{
+		"transactionHash": graphql.String(txHash),
+	}
+	query := new(queryOperatorRegisteredsByTransactionHash)
+	err := a.operatorStateGql.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, err
+	}
+	return query.OperatorRegistereds, nil
+}
+
+// QueryDeregisteredOperatorsByTransactionHash finds operator deregistrations made in the transaction.
+func (a *api) QueryDeregisteredOperatorsByTransactionHash(ctx context.Context, txHash string) ([]*Operator, error) {
+	variables := map[string]any{
+		"transactionHash": graphql.String(txHash),
+	}
+	query := new(queryOperatorDeregisteredsByTransactionHash)
+	err := a.operatorStateGql.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, err
+	}
+	return query.OperatorDeregistereds, nil
+}
+
 func (a *api) QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context.Context, operatorId string, blockNumber uint32) (*IndexedOperatorInfo, error) {
 	var (
 		query     queryOperatorById
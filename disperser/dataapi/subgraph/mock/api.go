@@ -129,6 +129,50 @@ func (m *MockSubgraphApi) QueryDeregisteredOperatorsGreaterThanBlockTimestamp(ct
 	return value, args.Error(1)
 }
 
+func (m *MockSubgraphApi) QueryRegisteredOperatorsByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*subgraph.Operator, error) {
+	args := m.Called()
+
+	var value []*subgraph.Operator
+	if args.Get(0) != nil {
+		value = args.Get(0).([]*subgraph.Operator)
+	}
+
+	return value, args.Error(1)
+}
+
+func (m *MockSubgraphApi) QueryDeregisteredOperatorsByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*subgraph.Operator, error) {
+	args := m.Called()
+
+	var value []*subgraph.Operator
+	if args.Get(0) != nil {
+		value = args.Get(0).([]*subgraph.Operator)
+	}
+
+	return value, args.Error(1)
+}
+
+func (m *MockSubgraphApi) QueryRegisteredOperatorsByTransactionHash(ctx context.Context, txHash string) ([]*subgraph.Operator, error) {
+	args := m.Called()
+
+	var value []*subgraph.Operator
+	if args.Get(0) != nil {
+		value = args.Get(0).([]*subgraph.Operator)
+	}
+
+	return value, args.Error(1)
+}
+
+func (m *MockSubgraphApi) QueryDeregisteredOperatorsByTransactionHash(ctx context.Context, txHash string) ([]*subgraph.Operator, error) {
+	args := m.Called()
+
+	var value []*subgraph.Operator
+	if args.Get(0) != nil {
+		value = args.Get(0).([]*subgraph.Operator)
+	}
+
+	return value, args.Error(1)
+}
+
 func (m *MockSubgraphApi) QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context.Context, operatorId string, blockNumber uint32) (*subgraph.IndexedOperatorInfo, error) {
 	args := m.Called()
 
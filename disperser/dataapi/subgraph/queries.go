@@ -103,6 +103,18 @@ type (
 	queryOperatorDeregisteredsGTBlockTimestamp struct {
 		OperatorDeregistereds []*Operator `graphql:"operatorDeregistereds(orderBy: blockTimestamp, where: {blockTimestamp_gt: $blockTimestamp_gt})"`
 	}
+	queryOperatorRegisteredsByBlockTimestampRange struct {
+		OperatorRegistereds []*Operator `graphql:"operatorRegistereds(first: $first, skip: $skip, orderBy: blockTimestamp, where: {and: [{blockTimestamp_gte: $blockTimestamp_gte}, {blockTimestamp_lte: $blockTimestamp_lte}]})"`
+	}
+	queryOperatorDeregisteredsByBlockTimestampRange struct {
+		OperatorDeregistereds []*Operator `graphql:"operatorDeregistereds(first: $first, skip: $skip, orderBy: blockTimestamp, where: {and: [{blockTimestamp_gte: $blockTimestamp_gte}, {blockTimestamp_lte: $blockTimestamp_lte}]})"`
+	}
+	queryOperatorRegisteredsByTransactionHash struct {
+		OperatorRegistereds []*Operator `graphql:"operatorRegistereds(where: {transactionHash: $transactionHash})"`
+	}
+	queryOperatorDeregisteredsByTransactionHash struct {
+		OperatorDeregistereds []*Operator `graphql:"operatorDeregistereds(where: {transactionHash: $transactionHash})"`
+	}
 	queryOperatorById struct {
 		Operator IndexedOperatorInfo `graphql:"operator(id: $id)"`
 	}
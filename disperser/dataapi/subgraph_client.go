@@ -27,6 +27,16 @@ const (
 	Registered
 )
 
+// OperatorEventType is the kind of an operator registration event.
+type OperatorEventType string
+
+const (
+	OperatorRegisteredEvent   OperatorEventType = "registered"
+	OperatorDeregisteredEvent OperatorEventType = "deregistered"
+	// OperatorChurnedEvent is a registration that ejected other operators in the same transaction
+	OperatorChurnedEvent OperatorEventType = "churned"
+)
+
 type (
 	SubgraphClient interface {
 		QueryBatchesWithLimit(ctx context.Context, limit, skip int) ([]*Batch, error)
@@ -37,6 +47,8 @@ type (
 		QueryIndexedOperatorsWithStateForTimeWindow(ctx context.Context, days int32, state OperatorState) (*IndexedQueriedOperatorInfo, error)
 		QueryOperatorInfoByOperatorId(ctx context.Context, operatorId string) (*core.IndexedOperatorInfo, error)
 		QueryOperatorEjectionsForTimeWindow(ctx context.Context, days int32, operatorId string, first uint, skip uint) ([]*QueriedOperatorEjections, error)
+		QueryOperatorRegistrationEvents(ctx context.Context, startTimestamp, endTimestamp uint64) ([]*OperatorRegistrationEvent, error)
+		QueryOperatorRegistrationEventsByTransactionHash(ctx context.Context, txHash string) ([]*OperatorRegistrationEvent, error)
 	}
 	Batch struct {
 		Id              []byte
@@ -67,6 +79,13 @@ type (
 		BlockNumber    uint32
 		BlockTimestamp uint64
 	}
+	OperatorRegistrationEvent struct {
+		EventType OperatorEventType
+		// Operator is the registered or deregistered operator
+		Operator *Operator
+		// ChurnedOperators are the operators deregistered to make room for the registered operator, only set for churns
+		ChurnedOperators []*Operator
+	}
 	OperatorQuorumEvents struct {
 		// AddedToQuorum is mapping from operator address to a list of sorted events
 		// (ascending by BlockNumber) where the operator was added to quorums.
@@ -244,6 +263,107 @@ func (sc *subgraphClient) QueryOperatorQuorumEvent(ctx context.Context, startBlo
 	}, nil
 }
 
+// QueryOperatorRegistrationEvents returns the operator registrations and deregistrations with block timestamp
+// in range [startTimestamp, endTimestamp], sorted ascending by block number, transaction hash and operator id.
+// Deregistrations made in the same transaction as a registration are the result of churn, and are folded into a
+// single churned event.
+func (sc *subgraphClient) QueryOperatorRegistrationEvents(ctx context.Context, startTimestamp, endTimestamp uint64) ([]*OperatorRegistrationEvent, error) {
+	var (
+		registered, deregistered     []*subgraph.Operator
+		registeredErr, deregisterErr error
+		pool                         = workerpool.New(maxWorkerPoolSize)
+	)
+
+	pool.Submit(func() {
+		registered, registeredErr = sc.api.QueryRegisteredOperatorsByBlockTimestampRange(ctx, startTimestamp, endTimestamp)
+	})
+	pool.Submit(func() {
+		deregistered, deregisterErr = sc.api.QueryDeregisteredOperatorsByBlockTimestampRange(ctx, startTimestamp, endTimestamp)
+	})
+	pool.StopWait()
+
+	if registeredErr != nil {
+		return nil, registeredErr
+	}
+	if deregisterErr != nil {
+		return nil, deregisterErr
+	}
+	return newOperatorRegistrationEvents(registered, deregistered)
+}
+
+// QueryOperatorRegistrationEventsByTransactionHash returns the operator registration and deregistration events
+// of the transaction, in the same form as QueryOperatorRegistrationEvents.
+func (sc *subgraphClient) QueryOperatorRegistrationEventsByTransactionHash(ctx context.Context, txHash string) ([]*OperatorRegistrationEvent, error) {
+	var (
+		registered, deregistered     []*subgraph.Operator
+		registeredErr, deregisterErr error
+		pool                         = workerpool.New(maxWorkerPoolSize)
+	)
+
+	pool.Submit(func() {
+		registered, registeredErr = sc.api.QueryRegisteredOperatorsByTransactionHash(ctx, txHash)
+	})
+	pool.Submit(func() {
+		deregistered, deregisterErr = sc.api.QueryDeregisteredOperatorsByTransactionHash(ctx, txHash)
+	})
+	pool.StopWait()
+
+	if registeredErr != nil {
+		return nil, registeredErr
+	}
+	if deregisterErr != nil {
+		return nil, deregisterErr
+	}
+	return newOperatorRegistrationEvents(registered, deregistered)
+}
+
+// newOperatorRegistrationEvents converts the registrations and deregistrations to events sorted ascending by block
+// number, transaction hash and operator id, folding the deregistrations made in the same transaction as a
+// registration into a churned event.
+func newOperatorRegistrationEvents(registered, deregistered []*subgraph.Operator) ([]*OperatorRegistrationEvent, error) {
+	events := make([]*OperatorRegistrationEvent, 0, len(registered)+len(deregistered))
+	registrationsByTx := make(map[string]*OperatorRegistrationEvent, len(registered))
+	for _, op := range registered {
+		operator, err := convertOperator(op)
+		if err != nil {
+			return nil, err
+		}
+		event := &OperatorRegistrationEvent{
+			EventType: OperatorRegisteredEvent,
+			Operator:  operator,
+		}
+		registrationsByTx[operator.TransactionHash] = event
+		events = append(events, event)
+	}
+	for _, op := range deregistered {
+		operator, err := convertOperator(op)
+		if err != nil {
+			return nil, err
+		}
+		if registration, ok := registrationsByTx[operator.TransactionHash]; ok {
+			registration.EventType = OperatorChurnedEvent
+			registration.ChurnedOperators = append(registration.ChurnedOperators, operator)
+			continue
+		}
+		events = append(events, &OperatorRegistrationEvent{
+			EventType: OperatorDeregisteredEvent,
+			Operator:  operator,
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i].Operator, events[j].Operator
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		if a.TransactionHash != b.TransactionHash {
+			return a.TransactionHash < b.TransactionHash
+		}
+		return a.OperatorId < b.OperatorId
+	})
+	return events, nil
+}
+
 func (sc *subgraphClient) QueryIndexedOperatorsWithStateForTimeWindow(ctx context.Context, days int32, state OperatorState) (*IndexedQueriedOperatorInfo, error) {
 	// Query all operators in the last N days.
 	lastNDayInSeconds := uint64(time.Now().Add(-time.Duration(days) * 24 * time.Hour).Unix())
@@ -632,3 +632,59 @@ func TestQueryOperatorQuorumEvent(t *testing.T) {
 	assert.Equal(t, 1, len(removed2[0].QuorumNumbers))
 	assert.Equal(t, uint8(2), removed2[0].QuorumNumbers[0])
 }
+
+func TestQueryOperatorRegistrationEvents(t *testing.T) {
+	registered := []*subgraph.Operator{
+		{
+			OperatorId:      "operator-3",
+			Operator:        "address-3",
+			BlockTimestamp:  "1696975470",
+			BlockNumber:     "90",
+			TransactionHash: "tx-3",
+		},
+		{
+			OperatorId:      "operator-1",
+			Operator:        "address-1",
+			BlockTimestamp:  "1696975449",
+			BlockNumber:     "87",
+			TransactionHash: "tx-1",
+		},
+	}
+	deregistered := []*subgraph.Operator{
+		{
+			OperatorId:      "operator-2",
+			Operator:        "address-2",
+			BlockTimestamp:  "1696975459",
+			BlockNumber:     "88",
+			TransactionHash: "tx-2",
+		},
+		// Deregistered in the same transaction operator-3 registered in
+		{
+			OperatorId:      "operator-4",
+			Operator:        "address-4",
+			BlockTimestamp:  "1696975470",
+			BlockNumber:     "90",
+			TransactionHash: "tx-3",
+		},
+	}
+	mockSubgraphApi := &subgraphmock.MockSubgraphApi{}
+	mockSubgraphApi.On("QueryRegisteredOperatorsByBlockTimestampRange").Return(registered, nil)
+	mockSubgraphApi.On("QueryDeregisteredOperatorsByBlockTimestampRange").Return(deregistered, nil)
+	subgraphClient := dataapi.NewSubgraphClient(mockSubgraphApi, logging.NewNoopLogger())
+	events, err := subgraphClient.QueryOperatorRegistrationEvents(context.Background(), 1696975400, 1696975500)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, dataapi.OperatorRegisteredEvent, events[0].EventType)
+	assert.Equal(t, "operator-1", events[0].Operator.OperatorId)
+	assert.Equal(t, uint64(87), events[0].Operator.BlockNumber)
+	assert.Empty(t, events[0].ChurnedOperators)
+	assert.Equal(t, dataapi.OperatorDeregisteredEvent, events[1].EventType)
+	assert.Equal(t, "operator-2", events[1].Operator.OperatorId)
+	assert.Equal(t, uint64(88), events[1].Operator.BlockNumber)
+	assert.Equal(t, dataapi.OperatorChurnedEvent, events[2].EventType)
+	assert.Equal(t, "operator-3", events[2].Operator.OperatorId)
+	assert.Equal(t, "tx-3", events[2].Operator.TransactionHash)
+	assert.Equal(t, 1, len(events[2].ChurnedOperators))
+	assert.Equal(t, "operator-4", events[2].ChurnedOperators[0].OperatorId)
+}
@@ -0,0 +1,306 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// rollupDay and rollupWeek are the lengths of the periods rolled up; weeks start on Monday
+	rollupDay  = 24 * time.Hour
+	rollupWeek = 7 * rollupDay
+	// throughputRollupCheckInterval is how often the aggregator checks for completed days without a rollup
+	throughputRollupCheckInterval = 10 * time.Minute
+	// throughputRollupDelay is how long after the end of a day it is rolled up, so that the blobs requested at the
+	// end of the day have been certified
+	throughputRollupDelay = time.Hour
+	// throughputRollupBackfillDays is how many completed days the aggregator rolls up when it starts, so that the
+	// last completed week is always covered
+	throughputRollupBackfillDays = 14
+	// throughputRollupPageSize is the number of blobs read from the metadata store at once
+	throughputRollupPageSize = 1000
+	// defaultThroughputRollupDays is the number of days of rollups returned when no start is given
+	defaultThroughputRollupDays = 30
+	// maxThroughputRollupDays is the max number of days of rollups returned by a single request
+	maxThroughputRollupDays = 366
+	// lifetimeRollupBatchWeeks is the number of weekly rollups read at once when summing up all the rollups
+	lifetimeRollupBatchWeeks = 52
+)
+
+// throughputAggregator rolls up the blobs requested each day, and each week, into the metadata store, so that
+// the throughput over long ranges can be served without scanning the blobs or querying Prometheus.
+type throughputAggregator struct {
+	logger            logging.Logger
+	blobMetadataStore *blobstore.BlobMetadataStore
+
+	// start of the last day rolled up; only accessed by the aggregation loop
+	lastDay time.Time
+}
+
+func newThroughputAggregator(logger logging.Logger, blobMetadataStore *blobstore.BlobMetadataStore) *throughputAggregator {
+	return &throughputAggregator{
+		logger:            logger,
+		blobMetadataStore: blobMetadataStore,
+	}
+}
+
+// start rolls up the completed days and weeks every interval, until the context is cancelled
+func (a *throughputAggregator) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := a.aggregate(ctx, time.Now()); err != nil {
+				a.logger.Warn("failed to roll up throughput", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// aggregate rolls up the days completed as of now, after throughputRollupDelay, which have no rollup yet, going back
+// at most throughputRollupBackfillDays, then the weeks ending on one of those days.
+// Rollups recorded by another instance are left untouched.
+func (a *throughputAggregator) aggregate(ctx context.Context, now time.Time) error {
+	lastCompleted := now.Add(-throughputRollupDelay).UTC().Truncate(rollupDay).Add(-rollupDay)
+	if !lastCompleted.After(a.lastDay) {
+		return nil
+	}
+	first := lastCompleted.AddDate(0, 0, -(throughputRollupBackfillDays - 1))
+	if !a.lastDay.IsZero() && a.lastDay.Add(rollupDay).After(first) {
+		first = a.lastDay.Add(rollupDay)
+	}
+
+	existing, err := a.blobMetadataStore.GetThroughputRollups(ctx, commonv2.DailyRollup, periodTimestamps(first, lastCompleted, rollupDay))
+	if err != nil {
+		return fmt.Errorf("failed to get daily rollups: %w", err)
+	}
+	rolledUp := make(map[uint64]bool, len(existing))
+	for _, rollup := range existing {
+		rolledUp[rollup.Timestamp] = true
+	}
+	for day := first; !day.After(lastCompleted); day = day.Add(rollupDay) {
+		if rolledUp[uint64(day.Unix())] {
+			continue
+		}
+		rollup, err := a.rollupDay(ctx, day)
+		if err != nil {
+			return fmt.Errorf("failed to roll up day %s: %w", day.Format(time.DateOnly), err)
+		}
+		err = a.blobMetadataStore.PutThroughputRollup(ctx, rollup)
+		if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
+			return fmt.Errorf("failed to store daily rollup: %w", err)
+		}
+	}
+
+	for day := first; !day.After(lastCompleted); day = day.Add(rollupDay) {
+		if day.Weekday() != time.Sunday {
+			continue
+		}
+		if err := a.rollupWeek(ctx, day.Add(rollupDay).Add(-rollupWeek)); err != nil {
+			return fmt.Errorf("failed to roll up week ending %s: %w", day.Format(time.DateOnly), err)
+		}
+	}
+
+	a.lastDay = lastCompleted
+	return nil
+}
+
+// rollupDay aggregates the blobs requested in the day starting at start
+func (a *throughputAggregator) rollupDay(ctx context.Context, start time.Time) (*commonv2.ThroughputRollup, error) {
+	rollup := &commonv2.ThroughputRollup{
+		Period:          commonv2.DailyRollup,
+		Timestamp:       uint64(start.Unix()),
+		OnDemandPayment: big.NewInt(0),
+	}
+
+	// The range is exclusive, and cursors without a blob key sort before all blobs requested at the same time,
+	// so this covers the blobs requested in [start, end)
+	cursor := blobstore.BlobFeedCursor{RequestedAt: uint64(start.UnixNano())}
+	end := blobstore.BlobFeedCursor{RequestedAt: uint64(start.Add(rollupDay).UnixNano())}
+	// The last cumulative payment of each account with on-demand blobs in the day
+	payments := make(map[string]*big.Int)
+	for {
+		blobs, lastCursor, err := a.blobMetadataStore.GetBlobMetadataByRequestedAt(ctx, cursor, end, throughputRollupPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blobs: %w", err)
+		}
+		for _, blob := range blobs {
+			rollup.BlobCount++
+			if blob.BlobStatus == commonv2.Certified {
+				rollup.CertifiedBlobCount++
+				rollup.CertifiedBytes += blob.BlobSize
+			}
+			payment := blob.BlobHeader.PaymentMetadata.CumulativePayment
+			if payment == nil || payment.Sign() <= 0 {
+				continue
+			}
+			accountID := blob.BlobHeader.PaymentMetadata.AccountID
+			if last, ok := payments[accountID]; !ok || payment.Cmp(last) > 0 {
+				payments[accountID] = payment
+			}
+		}
+		if lastCursor == nil || len(blobs) < throughputRollupPageSize {
+			break
+		}
+		cursor = *lastCursor
+	}
+
+	// Cumulative payments only grow, so an account paid the difference with its last payment before the day
+	for accountID, payment := range payments {
+		previous, err := a.lastOnDemandPayment(ctx, accountID, start)
+		if err != nil {
+			return nil, err
+		}
+		if payment.Cmp(previous) > 0 {
+			rollup.OnDemandPayment.Add(rollup.OnDemandPayment, new(big.Int).Sub(payment, previous))
+		}
+	}
+	return rollup, nil
+}
+
+// lastOnDemandPayment returns the cumulative payment of the last on-demand blob the account requested before the
+// given time, or zero if it had none
+func (a *throughputAggregator) lastOnDemandPayment(ctx context.Context, accountID string, before time.Time) (*big.Int, error) {
+	cursor := &blobstore.BlobFeedCursor{RequestedAt: uint64(before.UnixNano())}
+	for cursor != nil {
+		blobs, next, err := a.blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, cursor, throughputRollupPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blobs of account %s: %w", accountID, err)
+		}
+		for _, blob := range blobs {
+			payment := blob.BlobHeader.PaymentMetadata.CumulativePayment
+			if payment != nil && payment.Sign() > 0 {
+				return payment, nil
+			}
+		}
+		cursor = next
+	}
+	return big.NewInt(0), nil
+}
+
+// rollupWeek sums up the daily rollups of the week starting at start. The week is skipped if a day has no rollup.
+func (a *throughputAggregator) rollupWeek(ctx context.Context, start time.Time) error {
+	days, err := a.blobMetadataStore.GetThroughputRollups(ctx, commonv2.DailyRollup, periodTimestamps(start, start.Add(rollupWeek-rollupDay), rollupDay))
+	if err != nil {
+		return fmt.Errorf("failed to get daily rollups: %w", err)
+	}
+	if len(days) < int(rollupWeek/rollupDay) {
+		a.logger.Warn("skipping weekly rollup of an incomplete week", "week", start.Format(time.DateOnly), "days", len(days))
+		return nil
+	}
+
+	rollup := &commonv2.ThroughputRollup{
+		Period:          commonv2.WeeklyRollup,
+		Timestamp:       uint64(start.Unix()),
+		OnDemandPayment: big.NewInt(0),
+	}
+	for _, day := range days {
+		rollup.BlobCount += day.BlobCount
+		rollup.CertifiedBlobCount += day.CertifiedBlobCount
+		rollup.CertifiedBytes += day.CertifiedBytes
+		rollup.OnDemandPayment.Add(rollup.OnDemandPayment, day.OnDemandPayment)
+	}
+	err = a.blobMetadataStore.PutThroughputRollup(ctx, rollup)
+	if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
+		return fmt.Errorf("failed to store weekly rollup: %w", err)
+	}
+	return nil
+}
+
+// getRollups returns the recorded rollups of the given period length which start in the inclusive range [start, end]
+func (a *throughputAggregator) getRollups(ctx context.Context, period commonv2.RollupPeriod, start, end time.Time) (*ThroughputRollupsResponse, error) {
+	length := rollupDay
+	if period == commonv2.WeeklyRollup {
+		length = rollupWeek
+		start = startOfWeek(start)
+	}
+	rollups, err := a.blobMetadataStore.GetThroughputRollups(ctx, period, periodTimestamps(start, end, length))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s rollups: %w", period, err)
+	}
+
+	response := &ThroughputRollupsResponse{
+		Rollups: make([]*ThroughputRollup, len(rollups)),
+	}
+	for i, rollup := range rollups {
+		response.Rollups[i] = &ThroughputRollup{
+			Period:             string(rollup.Period),
+			Timestamp:          rollup.Timestamp,
+			BlobCount:          rollup.BlobCount,
+			CertifiedBlobCount: rollup.CertifiedBlobCount,
+			CertifiedBytes:     rollup.CertifiedBytes,
+			Throughput:         float64(rollup.CertifiedBytes) / length.Seconds(),
+			OnDemandPayment:    rollup.OnDemandPayment,
+		}
+	}
+	return response, nil
+}
+
+// getLifetimeCertifiedBytes returns the size of the blobs certified in all the days rolled up as of now. The weekly
+// rollups are read back a year at a time until a year without any, then the days after the last week are added.
+func (a *throughputAggregator) getLifetimeCertifiedBytes(ctx context.Context, now time.Time) (uint64, error) {
+	var total uint64
+	var lastWeek time.Time
+	for end := startOfWeek(now).Add(-rollupWeek); ; end = end.Add(-lifetimeRollupBatchWeeks * rollupWeek) {
+		start := end.Add(-(lifetimeRollupBatchWeeks - 1) * rollupWeek)
+		weeks, err := a.blobMetadataStore.GetThroughputRollups(ctx, commonv2.WeeklyRollup, periodTimestamps(start, end, rollupWeek))
+		if err != nil {
+			return 0, fmt.Errorf("failed to get weekly rollups: %w", err)
+		}
+		if len(weeks) == 0 {
+			break
+		}
+		for _, week := range weeks {
+			total += week.CertifiedBytes
+			if t := time.Unix(int64(week.Timestamp), 0).UTC(); t.After(lastWeek) {
+				lastWeek = t
+			}
+		}
+	}
+
+	// Without any weekly rollup, the days rolled up are at most the ones backfilled
+	lastDay := now.UTC().Truncate(rollupDay).Add(-rollupDay)
+	firstDay := lastDay.AddDate(0, 0, -(throughputRollupBackfillDays - 1))
+	if !lastWeek.IsZero() {
+		firstDay = lastWeek.Add(rollupWeek)
+	}
+	if firstDay.After(lastDay) {
+		return total, nil
+	}
+	days, err := a.blobMetadataStore.GetThroughputRollups(ctx, commonv2.DailyRollup, periodTimestamps(firstDay, lastDay, rollupDay))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get daily rollups: %w", err)
+	}
+	for _, day := range days {
+		total += day.CertifiedBytes
+	}
+	return total, nil
+}
+
+// periodTimestamps returns the Unix timestamps of the periods of the given length starting in [start, end]
+func periodTimestamps(start, end time.Time, length time.Duration) []uint64 {
+	timestamps := make([]uint64, 0)
+	for t := start; !t.After(end); t = t.Add(length) {
+		timestamps = append(timestamps, uint64(t.Unix()))
+	}
+	return timestamps
+}
+
+// startOfWeek returns the start of the Monday of the week containing the day
+func startOfWeek(day time.Time) time.Time {
+	day = day.UTC().Truncate(rollupDay)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
@@ -0,0 +1,282 @@
+package dataapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	commonv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans recorded by the DataAPI
+const tracerName = "github.com/Layr-Labs/eigenda/disperser/dataapi"
+
+// tracer records spans with the global tracer provider, which is a no-op unless one is set up
+var tracer = otel.Tracer(tracerName)
+
+// Trace returns a middleware which records a span for each request, as a child of the trace propagated by the
+// client if any. The request context carries the span, so that the calls made by the handler are recorded in
+// the same trace.
+func (s *ServerV2) Trace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		route := c.FullPath()
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.HTTPRoute(route),
+			),
+		)
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
+	}
+}
+
+// startSpan starts a span for a call to a dependency of the server
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// endSpan ends the span, recording the error the call failed with if any
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedBlobMetadataStore records a span for each read of the blob metadata store made while serving requests
+type tracedBlobMetadataStore struct {
+	*blobstore.BlobMetadataStore
+}
+
+func newTracedBlobMetadataStore(store *blobstore.BlobMetadataStore) *tracedBlobMetadataStore {
+	return &tracedBlobMetadataStore{BlobMetadataStore: store}
+}
+
+func (s *tracedBlobMetadataStore) GetAttestation(ctx context.Context, batchHeaderHash [32]byte) (*corev2.Attestation, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetAttestation")
+	attestation, err := s.BlobMetadataStore.GetAttestation(ctx, batchHeaderHash)
+	endSpan(span, err)
+	return attestation, err
+}
+
+func (s *tracedBlobMetadataStore) GetAttestationByAttestedAt(ctx context.Context, start uint64, end uint64, limit int) ([]*corev2.Attestation, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetAttestationByAttestedAt", attribute.Int("limit", limit))
+	attestations, err := s.BlobMetadataStore.GetAttestationByAttestedAt(ctx, start, end, limit)
+	endSpan(span, err)
+	return attestations, err
+}
+
+func (s *tracedBlobMetadataStore) GetAttestationFeed(ctx context.Context, start blobstore.AttestationFeedCursor, end uint64, limit int) ([]*corev2.Attestation, *blobstore.AttestationFeedCursor, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetAttestationFeed", attribute.Int("limit", limit))
+	attestations, cursor, err := s.BlobMetadataStore.GetAttestationFeed(ctx, start, end, limit)
+	endSpan(span, err)
+	return attestations, cursor, err
+}
+
+func (s *tracedBlobMetadataStore) GetAttestationsByReferenceBlock(ctx context.Context, referenceBlockNumber uint64) ([]*corev2.Attestation, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetAttestationsByReferenceBlock", attribute.Int64("reference_block_number", int64(referenceBlockNumber)))
+	attestations, err := s.BlobMetadataStore.GetAttestationsByReferenceBlock(ctx, referenceBlockNumber)
+	endSpan(span, err)
+	return attestations, err
+}
+
+func (s *tracedBlobMetadataStore) GetBatchHeader(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBatchHeader")
+	header, err := s.BlobMetadataStore.GetBatchHeader(ctx, batchHeaderHash)
+	endSpan(span, err)
+	return header, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobCertificate(ctx context.Context, blobKey corev2.BlobKey) (*corev2.BlobCertificate, *encoding.FragmentInfo, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobCertificate")
+	cert, fragmentInfo, err := s.BlobMetadataStore.GetBlobCertificate(ctx, blobKey)
+	endSpan(span, err)
+	return cert, fragmentInfo, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) (*commonv2.BlobMetadata, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobMetadata")
+	metadata, err := s.BlobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	endSpan(span, err)
+	return metadata, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobMetadataByAccountID(
+	ctx context.Context,
+	accountID string,
+	cursor *blobstore.BlobFeedCursor,
+	limit int,
+	statuses ...commonv2.BlobStatus,
+) ([]*commonv2.BlobMetadata, *blobstore.BlobFeedCursor, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobMetadataByAccountID", attribute.Int("limit", limit))
+	metadata, lastCursor, err := s.BlobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, cursor, limit, statuses...)
+	endSpan(span, err)
+	return metadata, lastCursor, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobMetadataByKeys(ctx context.Context, blobKeys []corev2.BlobKey) ([]*commonv2.BlobMetadata, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobMetadataByKeys", attribute.Int("keys", len(blobKeys)))
+	metadata, err := s.BlobMetadataStore.GetBlobMetadataByKeys(ctx, blobKeys)
+	endSpan(span, err)
+	return metadata, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobMetadataByRequestedAt(
+	ctx context.Context,
+	start blobstore.BlobFeedCursor,
+	end blobstore.BlobFeedCursor,
+	limit int,
+) ([]*commonv2.BlobMetadata, *blobstore.BlobFeedCursor, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobMetadataByRequestedAt", attribute.Int("limit", limit))
+	metadata, lastCursor, err := s.BlobMetadataStore.GetBlobMetadataByRequestedAt(ctx, start, end, limit)
+	endSpan(span, err)
+	return metadata, lastCursor, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobMetadataCountByStatus(ctx context.Context, status commonv2.BlobStatus) (int32, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobMetadataCountByStatus", attribute.String("status", status.String()))
+	count, err := s.BlobMetadataStore.GetBlobMetadataCountByStatus(ctx, status)
+	endSpan(span, err)
+	return count, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobVerificationInfo(ctx context.Context, blobKey corev2.BlobKey, batchHeaderHash [32]byte) (*corev2.BlobVerificationInfo, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobVerificationInfo")
+	info, err := s.BlobMetadataStore.GetBlobVerificationInfo(ctx, blobKey, batchHeaderHash)
+	endSpan(span, err)
+	return info, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobVerificationInfos(ctx context.Context, blobKey corev2.BlobKey) ([]*corev2.BlobVerificationInfo, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobVerificationInfos")
+	infos, err := s.BlobMetadataStore.GetBlobVerificationInfos(ctx, blobKey)
+	endSpan(span, err)
+	return infos, err
+}
+
+func (s *tracedBlobMetadataStore) GetBlobVerificationInfosByBatchHeaderHash(ctx context.Context, batchHeaderHash [32]byte) ([]*corev2.BlobVerificationInfo, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetBlobVerificationInfosByBatchHeaderHash")
+	infos, err := s.BlobMetadataStore.GetBlobVerificationInfosByBatchHeaderHash(ctx, batchHeaderHash)
+	endSpan(span, err)
+	return infos, err
+}
+
+func (s *tracedBlobMetadataStore) GetSignedBatch(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, *corev2.Attestation, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetSignedBatch")
+	header, attestation, err := s.BlobMetadataStore.GetSignedBatch(ctx, batchHeaderHash)
+	endSpan(span, err)
+	return header, attestation, err
+}
+
+func (s *tracedBlobMetadataStore) GetStakeSnapshots(ctx context.Context, timestamps []uint64) ([]*commonv2.StakeSnapshot, error) {
+	ctx, span := startSpan(ctx, "BlobMetadataStore.GetStakeSnapshots", attribute.Int("timestamps", len(timestamps)))
+	snapshots, err := s.BlobMetadataStore.GetStakeSnapshots(ctx, timestamps)
+	endSpan(span, err)
+	return snapshots, err
+}
+
+// tracedSubgraphClient is a SubgraphClient which records a span for each query
+type tracedSubgraphClient struct {
+	client SubgraphClient
+}
+
+var _ SubgraphClient = (*tracedSubgraphClient)(nil)
+
+func newTracedSubgraphClient(client SubgraphClient) *tracedSubgraphClient {
+	return &tracedSubgraphClient{client: client}
+}
+
+func (c *tracedSubgraphClient) QueryBatchesWithLimit(ctx context.Context, limit, skip int) ([]*Batch, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryBatchesWithLimit", attribute.Int("limit", limit), attribute.Int("skip", skip))
+	batches, err := c.client.QueryBatchesWithLimit(ctx, limit, skip)
+	endSpan(span, err)
+	return batches, err
+}
+
+func (c *tracedSubgraphClient) QueryOperatorsWithLimit(ctx context.Context, limit int) ([]*Operator, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryOperatorsWithLimit", attribute.Int("limit", limit))
+	operators, err := c.client.QueryOperatorsWithLimit(ctx, limit)
+	endSpan(span, err)
+	return operators, err
+}
+
+func (c *tracedSubgraphClient) QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) (map[string]int, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryBatchNonSigningOperatorIdsInInterval", attribute.Int64("interval_seconds", intervalSeconds))
+	nonSigners, err := c.client.QueryBatchNonSigningOperatorIdsInInterval(ctx, intervalSeconds)
+	endSpan(span, err)
+	return nonSigners, err
+}
+
+func (c *tracedSubgraphClient) QueryBatchNonSigningInfoInInterval(ctx context.Context, startTime, endTime int64) ([]*BatchNonSigningInfo, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryBatchNonSigningInfoInInterval")
+	infos, err := c.client.QueryBatchNonSigningInfoInInterval(ctx, startTime, endTime)
+	endSpan(span, err)
+	return infos, err
+}
+
+func (c *tracedSubgraphClient) QueryOperatorQuorumEvent(ctx context.Context, startBlock, endBlock uint32) (*OperatorQuorumEvents, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryOperatorQuorumEvent")
+	events, err := c.client.QueryOperatorQuorumEvent(ctx, startBlock, endBlock)
+	endSpan(span, err)
+	return events, err
+}
+
+func (c *tracedSubgraphClient) QueryIndexedOperatorsWithStateForTimeWindow(ctx context.Context, days int32, state OperatorState) (*IndexedQueriedOperatorInfo, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryIndexedOperatorsWithStateForTimeWindow", attribute.Int("days", int(days)))
+	info, err := c.client.QueryIndexedOperatorsWithStateForTimeWindow(ctx, days, state)
+	endSpan(span, err)
+	return info, err
+}
+
+func (c *tracedSubgraphClient) QueryOperatorInfoByOperatorId(ctx context.Context, operatorId string) (*core.IndexedOperatorInfo, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryOperatorInfoByOperatorId")
+	info, err := c.client.QueryOperatorInfoByOperatorId(ctx, operatorId)
+	endSpan(span, err)
+	return info, err
+}
+
+func (c *tracedSubgraphClient) QueryOperatorEjectionsForTimeWindow(ctx context.Context, days int32, operatorId string, first uint, skip uint) ([]*QueriedOperatorEjections, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryOperatorEjectionsForTimeWindow", attribute.Int("days", int(days)))
+	ejections, err := c.client.QueryOperatorEjectionsForTimeWindow(ctx, days, operatorId, first, skip)
+	endSpan(span, err)
+	return ejections, err
+}
+
+func (c *tracedSubgraphClient) QueryOperatorRegistrationEvents(ctx context.Context, startTimestamp, endTimestamp uint64) ([]*OperatorRegistrationEvent, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryOperatorRegistrationEvents")
+	events, err := c.client.QueryOperatorRegistrationEvents(ctx, startTimestamp, endTimestamp)
+	endSpan(span, err)
+	return events, err
+}
+
+func (c *tracedSubgraphClient) QueryOperatorRegistrationEventsByTransactionHash(ctx context.Context, txHash string) ([]*OperatorRegistrationEvent, error) {
+	ctx, span := startSpan(ctx, "SubgraphClient.QueryOperatorRegistrationEventsByTransactionHash")
+	events, err := c.client.QueryOperatorRegistrationEventsByTransactionHash(ctx, txHash)
+	endSpan(span, err)
+	return events, err
+}
@@ -0,0 +1,171 @@
+package dataapi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// feedTimeLayout is the layout of the time range params of the feed endpoints
+	feedTimeLayout = "2006-01-02T15:04:05Z"
+	// maxFeedTimeRange bounds the time range of the feed endpoints, so that a query doesn't walk the whole index
+	maxFeedTimeRange = 7 * 24 * time.Hour
+	// maxNonSignerInterval bounds the lookback window of the nonsigners endpoint
+	maxNonSignerInterval = 30 * 24 * time.Hour
+	// maxMetricsTimeRange bounds the time range of the metrics endpoints backed by Prometheus
+	maxMetricsTimeRange = 90 * 24 * time.Hour
+)
+
+// paramErrors collects the validation errors of a request, by param
+type paramErrors map[string]string
+
+// paramRule checks some params of a request, adding an error for each invalid one
+type paramRule func(c *gin.Context, errs paramErrors)
+
+// validateParams returns a middleware which rejects the requests breaking any of the rules with a 400, before they
+// reach the handler and the metadata store. Every invalid param is reported in the error details.
+func validateParams(rules ...paramRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		errs := make(paramErrors)
+		for _, rule := range rules {
+			rule(c, errs)
+		}
+		if len(errs) > 0 {
+			abortWithInvalidParams(c, errs)
+			return
+		}
+		c.Next()
+	}
+}
+
+// hexPathParam requires the path param to be a hex string, with an optional 0x prefix, of the given number of bytes
+func hexPathParam(name string, size int) paramRule {
+	return func(c *gin.Context, errs paramErrors) {
+		if err := checkHex(c.Param(name), size); err != nil {
+			errs[name] = err.Error()
+		}
+	}
+}
+
+// hexQueryParam requires the query param, if set, to be a hex string of the given number of bytes
+func hexQueryParam(name string, size int) paramRule {
+	return func(c *gin.Context, errs paramErrors) {
+		value, ok := c.GetQuery(name)
+		if !ok {
+			return
+		}
+		if err := checkHex(value, size); err != nil {
+			errs[name] = err.Error()
+		}
+	}
+}
+
+func checkHex(value string, size int) error {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return errors.New("must be a hex string")
+	}
+	if len(b) != size {
+		return fmt.Errorf("must be %d bytes, got %d", size, len(b))
+	}
+	return nil
+}
+
+// pageParamsRule checks the limit and cursor query params of the list endpoints, as parsed by parsePageParams
+func pageParamsRule(c *gin.Context, errs paramErrors) {
+	if value, ok := c.GetQuery("limit"); ok {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxBlobFeedLimit {
+			errs["limit"] = fmt.Sprintf("must be an integer between 1 and %d", maxBlobFeedLimit)
+		}
+	}
+	if len(c.Query("cursor")) > maxCursorLength {
+		errs["cursor"] = fmt.Sprintf("must be at most %d characters", maxCursorLength)
+	}
+}
+
+// feedRangeRule checks the after and before query params of the feed endpoints, as parsed by parseFeedParams,
+// and bounds the range between them
+func feedRangeRule(c *gin.Context, errs paramErrors) {
+	now := time.Now()
+	before := now
+	if value, ok := c.GetQuery("before"); ok {
+		t, err := time.Parse(feedTimeLayout, value)
+		if err != nil {
+			errs["before"] = "must be a UTC time in the format " + feedTimeLayout
+			return
+		}
+		if t.Before(now) {
+			before = t
+		}
+	}
+	value, ok := c.GetQuery("after")
+	if !ok {
+		return
+	}
+	after, err := time.Parse(feedTimeLayout, value)
+	if err != nil {
+		errs["after"] = "must be a UTC time in the format " + feedTimeLayout
+		return
+	}
+	if !after.Before(before) {
+		errs["after"] = "must be before before"
+	} else if before.Sub(after) > maxFeedTimeRange {
+		errs["after"] = fmt.Sprintf("must be within %v of before", maxFeedTimeRange)
+	}
+}
+
+// unixRangeRule checks the start and end query params, in Unix seconds, and bounds the range between them.
+// As in the handlers, end defaults to now and start to an hour before end.
+func unixRangeRule(maxRange time.Duration) paramRule {
+	return func(c *gin.Context, errs paramErrors) {
+		end := time.Now().Unix()
+		if value, ok := c.GetQuery("end"); ok {
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || t < 0 {
+				errs["end"] = "must be a Unix timestamp in seconds"
+				return
+			}
+			if t != 0 {
+				end = t
+			}
+		}
+		start := end - int64(time.Hour/time.Second)
+		if value, ok := c.GetQuery("start"); ok {
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || t < 0 {
+				errs["start"] = "must be a Unix timestamp in seconds"
+				return
+			}
+			if t != 0 {
+				start = t
+			}
+		}
+		if start >= end {
+			errs["start"] = "must be before end"
+		} else if time.Duration(end-start)*time.Second > maxRange {
+			errs["start"] = fmt.Sprintf("must be within %v of end", maxRange)
+		}
+	}
+}
+
+// intervalRule checks the lookback window in seconds of the given query param
+func intervalRule(name string, maxInterval time.Duration) paramRule {
+	return func(c *gin.Context, errs paramErrors) {
+		value, ok := c.GetQuery(name)
+		if !ok {
+			return
+		}
+		interval, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || interval <= 0 || interval > int64(maxInterval/time.Second) {
+			errs[name] = fmt.Sprintf("must be a number of seconds between 1 and %d", int64(maxInterval/time.Second))
+		}
+	}
+}
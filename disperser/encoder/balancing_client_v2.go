@@ -0,0 +1,287 @@
+package encoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// encoderReplica is the state of an encoder replica as known by the BalancingClientV2
+type encoderReplica struct {
+	client *clientV2
+	// status is the last load reported by the replica, or nil if the replica doesn't report its load
+	status *disperser.EncoderStatus
+	// pending is the number of requests sent to the replica that haven't returned yet
+	pending uint32
+	// full is set when the replica rejects a request because its queue is full, until it reports its load again
+	full bool
+	// reachable is unset when the replica can't be reached, until it reports its load again
+	reachable bool
+	// weight is the capacity of the replica measured by the benchmark, relative to the other replicas. It is 1 for
+	// the replicas that weren't benchmarked.
+	weight float64
+}
+
+// queueDepth returns the number of requests queued on the replica, counting the requests sent since its last report
+func (r *encoderReplica) queueDepth() uint32 {
+	if r.status == nil || r.status.QueueDepth < r.pending {
+		return r.pending
+	}
+	return r.status.QueueDepth
+}
+
+func (r *encoderReplica) estimatedWait() time.Duration {
+	if r.status == nil {
+		return 0
+	}
+	return r.status.EstimatedWait
+}
+
+// loadScore returns the load of the replica relative to its capacity, counting the request being routed
+func (r *encoderReplica) loadScore() float64 {
+	return float64(r.queueDepth()+1) / r.weight
+}
+
+func (r *encoderReplica) hasCapacity() bool {
+	if !r.reachable || r.full {
+		return false
+	}
+	return r.status == nil || r.status.QueueCapacity == 0 || r.queueDepth() < r.status.QueueCapacity
+}
+
+// BalancingClientV2 routes encoding requests between encoder replicas based on the load they report. Each request
+// is sent to the replica with the shortest estimated wait, or the lowest load relative to its capacity if the waits
+// are equal, and waits for a replica to have capacity if all of them are at capacity, so that the encoders aren't
+// sent requests they would reject.
+type BalancingClientV2 struct {
+	replicas              []*encoderReplica
+	statusRefreshInterval time.Duration
+	logger                logging.Logger
+
+	mu sync.Mutex
+	// loadDecreased is closed and replaced when the load of a replica decreases, to wake up the requests waiting for
+	// a replica with capacity
+	loadDecreased chan struct{}
+}
+
+var _ disperser.EncoderClientV2 = (*BalancingClientV2)(nil)
+var _ disperser.EncoderLoadReporter = (*BalancingClientV2)(nil)
+var _ disperser.EncoderReencoder = (*BalancingClientV2)(nil)
+
+func NewBalancingEncoderClientV2(addrs []string, statusRefreshInterval time.Duration, logger logging.Logger) (*BalancingClientV2, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("no encoder addresses")
+	}
+	if statusRefreshInterval <= 0 {
+		return nil, errors.New("status refresh interval must be positive")
+	}
+
+	replicas := make([]*encoderReplica, len(addrs))
+	for i, addr := range addrs {
+		replicas[i] = &encoderReplica{
+			client:    &clientV2{addr: addr},
+			reachable: true,
+			weight:    1,
+		}
+	}
+
+	return &BalancingClientV2{
+		replicas:              replicas,
+		statusRefreshInterval: statusRefreshInterval,
+		logger:                logger.With("component", "EncoderBalancingClient"),
+		loadDecreased:         make(chan struct{}),
+	}, nil
+}
+
+// Start refreshes the load of the replicas periodically until the context is canceled
+func (c *BalancingClientV2) Start(ctx context.Context) {
+	c.RefreshStatus(ctx)
+
+	go func() {
+		ticker := time.NewTicker(c.statusRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.RefreshStatus(ctx)
+			}
+		}
+	}()
+}
+
+// RefreshStatus requests the load of all the replicas
+func (c *BalancingClientV2) RefreshStatus(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, replica := range c.replicas {
+		wg.Add(1)
+		go func(replica *encoderReplica) {
+			defer wg.Done()
+
+			statusCtx, cancel := context.WithTimeout(ctx, c.statusRefreshInterval)
+			defer cancel()
+			replicaStatus, err := replica.client.getStatus(statusCtx)
+			if status.Code(err) == codes.Unimplemented {
+				// the replica doesn't report its load
+				replicaStatus, err = nil, nil
+			}
+			if err != nil {
+				c.logger.Warn("failed to get encoder status", "address", replica.client.addr, "err", err)
+			}
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if err != nil {
+				replica.reachable = false
+				return
+			}
+			replica.status = replicaStatus
+			replica.reachable = true
+			replica.full = false
+			c.notifyLoadDecreased()
+		}(replica)
+	}
+	wg.Wait()
+}
+
+// Benchmark runs the encoding benchmark on all the replicas, and weights the routing of the requests by the
+// throughput they measure. The replicas that fail to run the benchmark keep the average weight.
+func (c *BalancingClientV2) Benchmark(ctx context.Context, numIterations uint32) {
+	throughputs := make([]float64, len(c.replicas))
+	var wg sync.WaitGroup
+	for i, replica := range c.replicas {
+		wg.Add(1)
+		go func(i int, replica *encoderReplica) {
+			defer wg.Done()
+
+			throughput, err := replica.client.runBenchmark(ctx, numIterations)
+			if err != nil {
+				c.logger.Warn("failed to benchmark encoder", "address", replica.client.addr, "err", err)
+				return
+			}
+			c.logger.Info("benchmarked encoder", "address", replica.client.addr, "throughputBytesPerSecond", throughput)
+			throughputs[i] = throughput
+		}(i, replica)
+	}
+	wg.Wait()
+
+	var total float64
+	var numBenchmarked int
+	for _, throughput := range throughputs {
+		if throughput > 0 {
+			total += throughput
+			numBenchmarked++
+		}
+	}
+	if numBenchmarked == 0 {
+		return
+	}
+	avgThroughput := total / float64(numBenchmarked)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, replica := range c.replicas {
+		if throughputs[i] > 0 {
+			replica.weight = throughputs[i] / avgThroughput
+		}
+	}
+}
+
+// HasCapacity returns whether a replica can accept another request
+func (c *BalancingClientV2) HasCapacity() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, replica := range c.replicas {
+		if replica.hasCapacity() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *BalancingClientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+	return c.encodeBlob(ctx, blobKey, encodingParams, false)
+}
+
+func (c *BalancingClientV2) ReencodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+	return c.encodeBlob(ctx, blobKey, encodingParams, true)
+}
+
+func (c *BalancingClientV2) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, reencode bool) (*encoding.FragmentInfo, error) {
+	replica, err := c.reserveReplica(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fragmentInfo, replicaStatus, err := replica.client.encodeBlob(ctx, blobKey, encodingParams, reencode)
+	c.releaseReplica(replica, replicaStatus, err)
+	return fragmentInfo, err
+}
+
+// reserveReplica waits for a replica with capacity, and returns the one with the shortest estimated wait
+func (c *BalancingClientV2) reserveReplica(ctx context.Context) (*encoderReplica, error) {
+	for {
+		c.mu.Lock()
+		var best *encoderReplica
+		for _, replica := range c.replicas {
+			if !replica.hasCapacity() {
+				continue
+			}
+			if best == nil ||
+				replica.estimatedWait() < best.estimatedWait() ||
+				(replica.estimatedWait() == best.estimatedWait() && replica.loadScore() < best.loadScore()) {
+				best = replica
+			}
+		}
+		if best != nil {
+			best.pending++
+			c.mu.Unlock()
+			return best, nil
+		}
+		loadDecreased := c.loadDecreased
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no encoder has capacity: %w", ctx.Err())
+		case <-loadDecreased:
+		}
+	}
+}
+
+// releaseReplica records the outcome of a request sent to the replica
+func (c *BalancingClientV2) releaseReplica(replica *encoderReplica, replicaStatus *disperser.EncoderStatus, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	replica.pending--
+	switch status.Code(err) {
+	case codes.OK:
+		if replicaStatus != nil {
+			replica.status = replicaStatus
+			replica.full = false
+		}
+	case codes.ResourceExhausted:
+		replica.full = true
+	case codes.Unavailable:
+		replica.reachable = false
+	}
+	c.notifyLoadDecreased()
+}
+
+func (c *BalancingClientV2) notifyLoadDecreased() {
+	close(c.loadDecreased)
+	c.loadDecreased = make(chan struct{})
+}
@@ -0,0 +1,200 @@
+package encoder_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder/v2"
+	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeEncoderServer reports a configurable load and throughput, and counts the encoding requests it receives
+type fakeEncoderServer struct {
+	pb.UnimplementedEncoderServer
+
+	mu         sync.Mutex
+	status     *pb.EncoderStatus
+	throughput float64
+	requests   int
+}
+
+func (s *fakeEncoderServer) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequest) (*pb.EncodeBlobReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	return &pb.EncodeBlobReply{
+		FragmentInfo: &pb.FragmentInfo{
+			TotalChunkSizeBytes: 1024,
+			FragmentSizeBytes:   512,
+		},
+		Status: s.status,
+	}, nil
+}
+
+func (s *fakeEncoderServer) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.GetStatusReply{Status: s.status}, nil
+}
+
+func (s *fakeEncoderServer) RunBenchmark(ctx context.Context, req *pb.RunBenchmarkRequest) (*pb.RunBenchmarkReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.throughput == 0 {
+		return nil, status.Error(codes.Unimplemented, "benchmark not supported")
+	}
+	return &pb.RunBenchmarkReply{ThroughputBytesPerSecond: s.throughput}, nil
+}
+
+func (s *fakeEncoderServer) setStatus(status *pb.EncoderStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *fakeEncoderServer) numRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func startFakeEncoderServer(t *testing.T, status *pb.EncoderStatus) (*fakeEncoderServer, string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeEncoderServer{status: status}
+	gs := grpc.NewServer()
+	pb.RegisterEncoderServer(gs, server)
+	go func() {
+		_ = gs.Serve(listener)
+	}()
+	t.Cleanup(gs.Stop)
+
+	return server, listener.Addr().String()
+}
+
+func TestBalancingClientV2(t *testing.T) {
+	ctx := context.Background()
+	blobKey := corev2.BlobKey{1}
+	encodingParams := encoding.EncodingParams{ChunkLength: 4, NumChunks: 8}
+
+	t.Run("routes requests to the least loaded encoder", func(t *testing.T) {
+		busy, busyAddr := startFakeEncoderServer(t, &pb.EncoderStatus{QueueDepth: 3, QueueCapacity: 10, EstimatedWaitMs: 300})
+		idle, idleAddr := startFakeEncoderServer(t, &pb.EncoderStatus{QueueDepth: 0, QueueCapacity: 10, EstimatedWaitMs: 0})
+
+		client, err := encoder.NewBalancingEncoderClientV2([]string{busyAddr, idleAddr}, time.Second, logger)
+		require.NoError(t, err)
+		client.RefreshStatus(ctx)
+		require.True(t, client.HasCapacity())
+
+		fragmentInfo, err := client.EncodeBlob(ctx, blobKey, encodingParams)
+		require.NoError(t, err)
+		require.Equal(t, &encoding.FragmentInfo{TotalChunkSizeBytes: 1024, FragmentSizeBytes: 512}, fragmentInfo)
+		require.Equal(t, 0, busy.numRequests())
+		require.Equal(t, 1, idle.numRequests())
+
+		// the load reported with the reply is taken into account by the next request
+		idle.setStatus(&pb.EncoderStatus{QueueDepth: 5, QueueCapacity: 10, EstimatedWaitMs: 500})
+		_, err = client.EncodeBlob(ctx, blobKey, encodingParams)
+		require.NoError(t, err)
+		require.Equal(t, 0, busy.numRequests())
+		require.Equal(t, 2, idle.numRequests())
+
+		_, err = client.EncodeBlob(ctx, blobKey, encodingParams)
+		require.NoError(t, err)
+		require.Equal(t, 1, busy.numRequests())
+		require.Equal(t, 2, idle.numRequests())
+	})
+
+	t.Run("waits for an encoder with capacity", func(t *testing.T) {
+		server, addr := startFakeEncoderServer(t, &pb.EncoderStatus{QueueDepth: 2, QueueCapacity: 2, EstimatedWaitMs: 100})
+
+		client, err := encoder.NewBalancingEncoderClientV2([]string{addr}, time.Second, logger)
+		require.NoError(t, err)
+		client.RefreshStatus(ctx)
+		require.False(t, client.HasCapacity())
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = client.EncodeBlob(timeoutCtx, blobKey, encodingParams)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 0, server.numRequests())
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.EncodeBlob(ctx, blobKey, encodingParams)
+			done <- err
+		}()
+
+		server.setStatus(&pb.EncoderStatus{QueueDepth: 1, QueueCapacity: 2, EstimatedWaitMs: 50})
+		client.RefreshStatus(ctx)
+		require.True(t, client.HasCapacity())
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("request wasn't sent once the encoder had capacity")
+		}
+		require.Equal(t, 1, server.numRequests())
+	})
+
+	t.Run("skips unreachable encoders", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		unreachableAddr := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		server, addr := startFakeEncoderServer(t, &pb.EncoderStatus{QueueDepth: 4, QueueCapacity: 10, EstimatedWaitMs: 400})
+
+		client, err := encoder.NewBalancingEncoderClientV2([]string{unreachableAddr, addr}, time.Second, logger)
+		require.NoError(t, err)
+		client.RefreshStatus(ctx)
+
+		_, err = client.EncodeBlob(ctx, blobKey, encodingParams)
+		require.NoError(t, err)
+		require.Equal(t, 1, server.numRequests())
+	})
+
+	t.Run("weights the routing by the benchmarked capacity", func(t *testing.T) {
+		fast, fastAddr := startFakeEncoderServer(t, &pb.EncoderStatus{QueueDepth: 1, QueueCapacity: 10})
+		fast.throughput = 300
+		slow, slowAddr := startFakeEncoderServer(t, &pb.EncoderStatus{QueueDepth: 0, QueueCapacity: 10})
+		slow.throughput = 100
+		_, unbenchmarkedAddr := startFakeEncoderServer(t, &pb.EncoderStatus{QueueDepth: 5, QueueCapacity: 10})
+
+		client, err := encoder.NewBalancingEncoderClientV2([]string{fastAddr, slowAddr, unbenchmarkedAddr}, time.Second, logger)
+		require.NoError(t, err)
+		client.RefreshStatus(ctx)
+
+		// without weights, the request goes to the replica with the shortest queue
+		_, err = client.EncodeBlob(ctx, blobKey, encodingParams)
+		require.NoError(t, err)
+		require.Equal(t, 0, fast.numRequests())
+		require.Equal(t, 1, slow.numRequests())
+
+		// the fast replica has three times the capacity of the slow one, so its longer queue drains sooner
+		client.Benchmark(ctx, 1)
+		_, err = client.EncodeBlob(ctx, blobKey, encodingParams)
+		require.NoError(t, err)
+		require.Equal(t, 1, fast.numRequests())
+		require.Equal(t, 1, slow.numRequests())
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := encoder.NewBalancingEncoderClientV2(nil, time.Second, logger)
+		require.Error(t, err)
+		_, err = encoder.NewBalancingEncoderClientV2([]string{"localhost:34000"}, 0, logger)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package encoder
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBenchmarkIterations is the maximum number of times a benchmark encodes each of its blobs
+const maxBenchmarkIterations = 10
+
+// BenchmarkCase is a blob encoded by the encoder benchmark
+type BenchmarkCase struct {
+	BlobSizeBytes  uint32
+	EncodingParams encoding.EncodingParams
+}
+
+// DefaultBenchmarkCases are the blobs encoded by the encoder benchmark. They are encoded with the params of blob
+// version 0, 8192 chunks at coding rate 8, so that the results of different hosts are comparable.
+var DefaultBenchmarkCases = []BenchmarkCase{
+	{BlobSizeBytes: 128 * 1024, EncodingParams: encoding.EncodingParams{ChunkLength: 4, NumChunks: 8192}},
+	{BlobSizeBytes: 1024 * 1024, EncodingParams: encoding.EncodingParams{ChunkLength: 32, NumChunks: 8192}},
+	{BlobSizeBytes: 8 * 1024 * 1024, EncodingParams: encoding.EncodingParams{ChunkLength: 256, NumChunks: 8192}},
+}
+
+// RunBenchmark encodes the benchmark blobs and returns the measured throughput. The benchmark takes an encoding
+// slot, so that it doesn't overload an encoder serving requests, and only one benchmark runs at a time.
+func (s *EncoderServerV2) RunBenchmark(ctx context.Context, req *pb.RunBenchmarkRequest) (*pb.RunBenchmarkReply, error) {
+	numIterations := max(req.GetNumIterations(), 1)
+	if numIterations > maxBenchmarkIterations {
+		return nil, status.Errorf(codes.InvalidArgument, "number of iterations %d exceeds the maximum of %d", numIterations, maxBenchmarkIterations)
+	}
+
+	if !s.benchmarkMu.TryLock() {
+		return nil, status.Error(codes.ResourceExhausted, "a benchmark is already running")
+	}
+	defer s.benchmarkMu.Unlock()
+
+	cases := s.config.BenchmarkCases
+	if len(cases) == 0 {
+		cases = DefaultBenchmarkCases
+	}
+
+	// the benchmark is scheduled as its largest blob
+	numEvaluations := uint64(0)
+	for _, benchmarkCase := range cases {
+		numEvaluations = max(numEvaluations, benchmarkCase.EncodingParams.NumEvaluations())
+	}
+	release, err := s.scheduler.acquire(ctx, numEvaluations)
+	if err != nil {
+		return nil, status.Error(codes.Canceled, "request was canceled")
+	}
+	defer release()
+
+	reply := &pb.RunBenchmarkReply{}
+	var totalBytes uint64
+	var totalDuration time.Duration
+	for _, benchmarkCase := range cases {
+		if ctx.Err() != nil {
+			return nil, status.Error(codes.Canceled, "request was canceled")
+		}
+
+		duration, err := s.runBenchmarkCase(benchmarkCase, numIterations)
+		if err != nil {
+			s.logger.Error("benchmark failed", "blobSizeBytes", benchmarkCase.BlobSizeBytes, "err", err)
+			return nil, status.Errorf(codes.Internal, "benchmark of %d byte blob failed: %v", benchmarkCase.BlobSizeBytes, err)
+		}
+		avgDuration := duration / time.Duration(numIterations)
+
+		s.logger.Info("benchmark encoded blob", "blobSizeBytes", benchmarkCase.BlobSizeBytes,
+			"numChunks", benchmarkCase.EncodingParams.NumChunks, "chunkLength", benchmarkCase.EncodingParams.ChunkLength,
+			"avgDuration", avgDuration)
+		reply.Results = append(reply.Results, &pb.BenchmarkResult{
+			BlobSizeBytes: benchmarkCase.BlobSizeBytes,
+			EncodingParams: &pb.EncodingParams{
+				ChunkLength: benchmarkCase.EncodingParams.ChunkLength,
+				NumChunks:   benchmarkCase.EncodingParams.NumChunks,
+			},
+			AvgEncodingDurationUs:    uint64(avgDuration.Microseconds()),
+			ThroughputBytesPerSecond: float64(benchmarkCase.BlobSizeBytes) / avgDuration.Seconds(),
+		})
+
+		totalBytes += uint64(benchmarkCase.BlobSizeBytes) * uint64(numIterations)
+		totalDuration += duration
+	}
+	reply.ThroughputBytesPerSecond = float64(totalBytes) / totalDuration.Seconds()
+
+	return reply, nil
+}
+
+// runBenchmarkCase returns the time taken to encode a random blob of the benchmark case numIterations times. The
+// blob is encoded once beforehand, so that setting up the prover for the params isn't measured.
+func (s *EncoderServerV2) runBenchmarkCase(benchmarkCase BenchmarkCase, numIterations uint32) (time.Duration, error) {
+	data, err := randomBlob(benchmarkCase.BlobSizeBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = s.prover.GetFrames(data, benchmarkCase.EncodingParams)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	for i := uint32(0); i < numIterations; i++ {
+		_, err = s.prover.GetFrames(data, benchmarkCase.EncodingParams)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return time.Since(start), nil
+}
+
+// randomBlob returns random blob data of the given size, each symbol of which is a valid field element
+func randomBlob(sizeBytes uint32) ([]byte, error) {
+	data := make([]byte, sizeBytes)
+	_, err := rand.Read(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random blob: %w", err)
+	}
+	for i := 0; i < len(data); i += encoding.BYTES_PER_SYMBOL {
+		data[i] = 0
+	}
+	return data, nil
+}
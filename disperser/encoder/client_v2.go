@@ -3,6 +3,7 @@ package encoder
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -16,6 +17,8 @@ type clientV2 struct {
 	addr string
 }
 
+var _ disperser.EncoderReencoder = (*clientV2)(nil)
+
 func NewEncoderClientV2(addr string) (disperser.EncoderClientV2, error) {
 	return &clientV2{
 		addr: addr,
@@ -23,13 +26,25 @@ func NewEncoderClientV2(addr string) (disperser.EncoderClientV2, error) {
 }
 
 func (c *clientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+	fragmentInfo, _, err := c.encodeBlob(ctx, blobKey, encodingParams, false)
+	return fragmentInfo, err
+}
+
+func (c *clientV2) ReencodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+	fragmentInfo, _, err := c.encodeBlob(ctx, blobKey, encodingParams, true)
+	return fragmentInfo, err
+}
+
+// encodeBlob encodes the blob, and returns the load of the encoder once the blob is encoded. The status is nil if
+// the encoder doesn't report its load. A reencode replaces the chunks of the blob even if they're already stored.
+func (c *clientV2) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, reencode bool) (*encoding.FragmentInfo, *disperser.EncoderStatus, error) {
 	// Establish connection
 	conn, err := grpc.NewClient(
 		c.addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to dial encoder: %w", err)
+		return nil, nil, fmt.Errorf("failed to dial encoder: %w", err)
 	}
 	defer conn.Close()
 
@@ -43,17 +58,66 @@ func (c *clientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encod
 			ChunkLength: encodingParams.ChunkLength,
 			NumChunks:   encodingParams.NumChunks,
 		},
+		Reencode: reencode,
 	}
 
 	// Make the RPC call
 	reply, err := client.EncodeBlob(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encode blob: %w", err)
+		return nil, nil, fmt.Errorf("failed to encode blob: %w", err)
 	}
 
 	// Extract and return fragment info
 	return &encoding.FragmentInfo{
 		TotalChunkSizeBytes: reply.FragmentInfo.TotalChunkSizeBytes,
 		FragmentSizeBytes:   reply.FragmentInfo.FragmentSizeBytes,
-	}, nil
+	}, statusFromProto(reply.Status), nil
+}
+
+// getStatus returns the load of the encoder
+func (c *clientV2) getStatus(ctx context.Context) (*disperser.EncoderStatus, error) {
+	conn, err := grpc.NewClient(
+		c.addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial encoder: %w", err)
+	}
+	defer conn.Close()
+
+	reply, err := pb.NewEncoderClient(conn).GetStatus(ctx, &pb.GetStatusRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encoder status: %w", err)
+	}
+	return statusFromProto(reply.Status), nil
+}
+
+// runBenchmark runs the encoding benchmark on the encoder, and returns the number of bytes of blob data it encodes
+// per second
+func (c *clientV2) runBenchmark(ctx context.Context, numIterations uint32) (float64, error) {
+	conn, err := grpc.NewClient(
+		c.addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to dial encoder: %w", err)
+	}
+	defer conn.Close()
+
+	reply, err := pb.NewEncoderClient(conn).RunBenchmark(ctx, &pb.RunBenchmarkRequest{NumIterations: numIterations})
+	if err != nil {
+		return 0, fmt.Errorf("failed to run encoder benchmark: %w", err)
+	}
+	return reply.ThroughputBytesPerSecond, nil
+}
+
+func statusFromProto(status *pb.EncoderStatus) *disperser.EncoderStatus {
+	if status == nil {
+		return nil
+	}
+	return &disperser.EncoderStatus{
+		QueueDepth:    status.QueueDepth,
+		QueueCapacity: status.QueueCapacity,
+		EstimatedWait: time.Duration(status.EstimatedWaitMs) * time.Millisecond,
+	}
 }
@@ -14,4 +14,12 @@ type ServerConfig struct {
 	GPUEnable                bool
 	PprofHttpPort            string
 	EnablePprof              bool
+	// EncodingCacheSizeBytes is the maximum total size of the frames in the encoding cache, zero disables the cache
+	EncodingCacheSizeBytes uint64
+	// FFTCacheDir is the directory the FFT roots of unity are persisted to, empty only caches them in memory
+	FFTCacheDir string
+	// BenchmarkCases are the blobs encoded by the encoder benchmark, DefaultBenchmarkCases if empty
+	BenchmarkCases []BenchmarkCase
+	// SizeClasses are the classes the requests waiting for an encoding slot are queued by, DefaultSizeClasses if empty
+	SizeClasses []SizeClass
 }
@@ -0,0 +1,81 @@
+package encoder
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/cache"
+)
+
+// encodingCacheKey identifies the result of encoding a blob with the given params. The blob is identified by the
+// hash of its data, which determines its commitment, rather than by its blob key, which also covers the payment of
+// the dispersal. This way, duplicate dispersals of the same data hit the cache, and computing the key doesn't require
+// an MSM as computing the commitment would.
+type encodingCacheKey struct {
+	dataHash [32]byte
+	params   encoding.EncodingParams
+}
+
+// encodingCache holds the frames of recently encoded blobs, so that retried or duplicate dispersals skip the
+// encoding. The frames are evicted in the order they were added once the total size of the cached frames exceeds the
+// size of the cache.
+//
+// A nil encodingCache doesn't cache anything. encodingCache is thread-safe.
+type encodingCache struct {
+	mu      sync.Mutex
+	cache   cache.Cache[encodingCacheKey, []*encoding.Frame]
+	metrics *Metrics
+}
+
+// newEncodingCache creates a cache holding up to maxSizeBytes of frames, or returns nil if maxSizeBytes is zero.
+func newEncodingCache(maxSizeBytes uint64, metrics *Metrics) *encodingCache {
+	if maxSizeBytes == 0 {
+		return nil
+	}
+	return &encodingCache{
+		cache:   cache.NewFIFOCache[encodingCacheKey, []*encoding.Frame](maxSizeBytes, computeFramesSize),
+		metrics: metrics,
+	}
+}
+
+func computeFramesSize(_ encodingCacheKey, frames []*encoding.Frame) uint64 {
+	size := uint64(0)
+	for _, frame := range frames {
+		size += frame.Size()
+	}
+	return size
+}
+
+func (c *encodingCache) Key(data []byte, params encoding.EncodingParams) encodingCacheKey {
+	if c == nil {
+		return encodingCacheKey{}
+	}
+	return encodingCacheKey{
+		dataHash: sha256.Sum256(data),
+		params:   params,
+	}
+}
+
+func (c *encodingCache) Get(key encodingCacheKey) ([]*encoding.Frame, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frames, ok := c.cache.Get(key)
+	c.metrics.ReportEncodingCacheLookup(ok)
+	return frames, ok
+}
+
+func (c *encodingCache) Put(key encodingCacheKey, frames []*encoding.Frame) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache.Put(key, frames)
+	c.metrics.SetEncodingCacheSize(c.cache.Weight())
+}
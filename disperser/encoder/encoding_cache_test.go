@@ -0,0 +1,121 @@
+package encoder_test
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+// countingProver counts the blobs encoded by the prover
+type countingProver struct {
+	encoding.Prover
+	numEncoded atomic.Int32
+}
+
+func (p *countingProver) GetFrames(data []byte, params encoding.EncodingParams) ([]*encoding.Frame, error) {
+	p.numEncoded.Add(1)
+	return p.Prover.GetFrames(data, params)
+}
+
+func TestEncodeBlobCache(t *testing.T) {
+	ctx := context.Background()
+	p, err := makeTestProver(300000)
+	require.NoError(t, err)
+
+	data := make([]byte, 16*1024)
+	_, err = rand.New(rand.NewSource(42)).Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	encodingParams := &pb.EncodingParams{
+		ChunkLength: 32,
+		NumChunks:   32,
+	}
+
+	// two dispersals of the same data have different blob keys, since their payments differ
+	newRequest := func(t *testing.T, blobStore *blobstore.BlobStore, cumulativePayment int64) *pb.EncodeBlobRequest {
+		blobHeader := createTestBlobHeader(t)
+		blobHeader.PaymentMetadata.CumulativePayment = big.NewInt(cumulativePayment)
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		require.NoError(t, blobStore.StoreBlob(ctx, blobKey, data))
+		return &pb.EncodeBlobRequest{
+			BlobKey:        blobKey[:],
+			EncodingParams: encodingParams,
+		}
+	}
+
+	newServer := func(cacheSizeBytes uint64) (*encoder.EncoderServerV2, *blobstore.BlobStore, *countingProver, *encoder.Metrics) {
+		prover := &countingProver{Prover: p}
+		metrics := encoder.NewMetrics(prometheus.NewRegistry(), "9000", logger)
+		s3Client := mock.NewS3Client()
+		blobStore := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
+		chunkStoreWriter := chunkstore.NewChunkWriter(logger, s3Client, s3BucketName, 512*1024)
+		server := encoder.NewEncoderServerV2(encoder.ServerConfig{
+			GrpcPort:               "8080",
+			MaxConcurrentRequests:  10,
+			RequestPoolSize:        5,
+			EncodingCacheSizeBytes: cacheSizeBytes,
+		}, blobStore, chunkStoreWriter, logger, prover, metrics)
+		return server, blobStore, prover, metrics
+	}
+
+	t.Run("duplicate dispersal skips encoding", func(t *testing.T) {
+		server, blobStore, prover, metrics := newServer(1024 * 1024)
+
+		first, err := server.EncodeBlob(ctx, newRequest(t, blobStore, 1))
+		require.NoError(t, err)
+		second, err := server.EncodeBlob(ctx, newRequest(t, blobStore, 2))
+		require.NoError(t, err)
+
+		require.Equal(t, int32(1), prover.numEncoded.Load())
+		require.Equal(t, first.FragmentInfo.TotalChunkSizeBytes, second.FragmentInfo.TotalChunkSizeBytes)
+		require.Equal(t, 1.0, testutil.ToFloat64(metrics.EncodingCacheLookups.WithLabelValues("hit")))
+		require.Equal(t, 1.0, testutil.ToFloat64(metrics.EncodingCacheLookups.WithLabelValues("miss")))
+		require.Positive(t, testutil.ToFloat64(metrics.EncodingCacheSize))
+
+		// the same data encoded with other params isn't a hit
+		req := newRequest(t, blobStore, 3)
+		req.EncodingParams = &pb.EncodingParams{
+			ChunkLength: 128,
+			NumChunks:   8,
+		}
+		_, err = server.EncodeBlob(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, int32(2), prover.numEncoded.Load())
+	})
+
+	t.Run("frames larger than the cache aren't cached", func(t *testing.T) {
+		server, blobStore, prover, _ := newServer(1)
+
+		_, err := server.EncodeBlob(ctx, newRequest(t, blobStore, 1))
+		require.NoError(t, err)
+		_, err = server.EncodeBlob(ctx, newRequest(t, blobStore, 2))
+		require.NoError(t, err)
+
+		require.Equal(t, int32(2), prover.numEncoded.Load())
+	})
+
+	t.Run("cache is disabled by default", func(t *testing.T) {
+		server, blobStore, prover, _ := newServer(0)
+
+		_, err := server.EncodeBlob(ctx, newRequest(t, blobStore, 1))
+		require.NoError(t, err)
+		_, err = server.EncodeBlob(ctx, newRequest(t, blobStore, 2))
+		require.NoError(t, err)
+
+		require.Equal(t, int32(2), prover.numEncoded.Load())
+	})
+}
@@ -0,0 +1,63 @@
+package encoder
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// encodingDurationWeight is the weight of the latest encoding in the moving average of the encoding durations
+const encodingDurationWeight = 0.2
+
+// encoderLoad tracks the requests waiting for an encoding slot and how long encodings take, to estimate how long a
+// new request waits before its encoding starts. The estimate is reported to the controller, which routes requests
+// to the least loaded encoder.
+type encoderLoad struct {
+	maxConcurrentRequests int
+	waitingRequests       atomic.Int64
+
+	mu                  sync.Mutex
+	avgEncodingDuration time.Duration
+}
+
+func newEncoderLoad(maxConcurrentRequests int) *encoderLoad {
+	return &encoderLoad{
+		maxConcurrentRequests: maxConcurrentRequests,
+	}
+}
+
+func (l *encoderLoad) startWaiting() {
+	l.waitingRequests.Add(1)
+}
+
+func (l *encoderLoad) stopWaiting() {
+	l.waitingRequests.Add(-1)
+}
+
+// reportEncoding adds the duration of an encoding to the moving average of the encoding durations
+func (l *encoderLoad) reportEncoding(duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.avgEncodingDuration == 0 {
+		l.avgEncodingDuration = duration
+		return
+	}
+	l.avgEncodingDuration = time.Duration(
+		encodingDurationWeight*float64(duration) + (1-encodingDurationWeight)*float64(l.avgEncodingDuration))
+}
+
+// estimateWait estimates how long a new request waits before its encoding starts, given the number of requests
+// being encoded. A new request waits for the requests ahead of it to take the slots freed by the running requests.
+func (l *encoderLoad) estimateWait(numRunning int) time.Duration {
+	if l.maxConcurrentRequests <= 0 || numRunning < l.maxConcurrentRequests {
+		return 0
+	}
+
+	l.mu.Lock()
+	avgEncodingDuration := l.avgEncodingDuration
+	l.mu.Unlock()
+
+	numAhead := l.waitingRequests.Load() + 1
+	return avgEncodingDuration * time.Duration(numAhead) / time.Duration(l.maxConcurrentRequests)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -29,6 +30,10 @@ type Metrics struct {
 	BlobQueue             *prometheus.GaugeVec
 	QueueCapacity         prometheus.Gauge
 	QueueUtilization      prometheus.Gauge
+	EncodingCacheLookups  *prometheus.CounterVec
+	EncodingCacheSize     prometheus.Gauge
+	QueueWait             *prometheus.SummaryVec
+	SizeClassQueue        *prometheus.GaugeVec
 }
 
 func NewMetrics(reg *prometheus.Registry, httpPort string, logger logging.Logger) *Metrics {
@@ -86,6 +91,38 @@ func NewMetrics(reg *prometheus.Registry, httpPort string, logger logging.Logger
 				Help:      "Current utilization of request pool (total across all buckets)",
 			},
 		),
+		EncodingCacheLookups: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "eigenda_encoder",
+				Name:      "encoding_cache_lookups_total",
+				Help:      "the number of lookups of encoding results in the encoding cache per result",
+			},
+			[]string{"result"}, // result is either hit or miss
+		),
+		EncodingCacheSize: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "eigenda_encoder",
+				Name:      "encoding_cache_size_bytes",
+				Help:      "the total size in bytes of the frames in the encoding cache",
+			},
+		),
+		QueueWait: promauto.With(reg).NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  "eigenda_encoder",
+				Name:       "queue_wait_ms",
+				Help:       "the time in milliseconds a request waits for an encoding slot per size class",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"size_class"},
+		),
+		SizeClassQueue: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "eigenda_encoder",
+				Name:      "size_class_queue",
+				Help:      "the number of requests waiting for an encoding slot per size class",
+			},
+			[]string{"size_class"},
+		),
 	}
 }
 
@@ -134,6 +171,26 @@ func (m *Metrics) SetQueueCapacity(capacity int) {
 	m.QueueCapacity.Set(float64(capacity))
 }
 
+func (m *Metrics) ReportEncodingCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.EncodingCacheLookups.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) SetEncodingCacheSize(sizeBytes uint64) {
+	m.EncodingCacheSize.Set(float64(sizeBytes))
+}
+
+func (m *Metrics) ObserveQueueWait(sizeClass string, duration time.Duration) {
+	m.QueueWait.WithLabelValues(sizeClass).Observe(common.ToMilliseconds(duration))
+}
+
+func (m *Metrics) SetSizeClassQueue(sizeClass string, numWaiting int) {
+	m.SizeClassQueue.WithLabelValues(sizeClass).Set(float64(numWaiting))
+}
+
 func (m *Metrics) Start(ctx context.Context) {
 	m.logger.Info("Starting metrics server at ", "port", m.httpPort)
 
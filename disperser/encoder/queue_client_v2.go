@@ -0,0 +1,103 @@
+package encoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// queueCleanupTimeout bounds the removal of a job from the queue once its result is no longer awaited
+const queueCleanupTimeout = 10 * time.Second
+
+// queueClientV2 encodes blobs by writing jobs to the encoding queue shared by the encoders, and waiting for one of
+// them to publish the result. Unlike clientV2, the encoding capacity isn't tied to a static encoder address.
+type queueClientV2 struct {
+	queue        EncodingJobQueue
+	pollInterval time.Duration
+	logger       logging.Logger
+}
+
+var _ disperser.EncoderReencoder = (*queueClientV2)(nil)
+
+func NewQueueEncoderClientV2(queue EncodingJobQueue, pollInterval time.Duration, logger logging.Logger) (disperser.EncoderClientV2, error) {
+	if pollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+
+	return &queueClientV2{
+		queue:        queue,
+		pollInterval: pollInterval,
+		logger:       logger.With("component", "EncoderQueueClient"),
+	}, nil
+}
+
+func (c *queueClientV2) EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+	return c.encodeBlob(ctx, blobKey, encodingParams, false)
+}
+
+func (c *queueClientV2) ReencodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+	return c.encodeBlob(ctx, blobKey, encodingParams, true)
+}
+
+func (c *queueClientV2) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, reencode bool) (*encoding.FragmentInfo, error) {
+	now := uint64(time.Now().UnixNano())
+	job := &v2.EncodingJob{
+		BlobKey:        blobKey,
+		EncodingParams: encodingParams,
+		Reencode:       reencode,
+		JobStatus:      v2.EncodingJobQueued,
+		LeasableAt:     now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	err := c.queue.PutEncodingJob(ctx, job)
+	if errors.Is(err, common.ErrAlreadyExists) {
+		// the job of a previous attempt wasn't removed, it's replaced so that the blob is encoded with these params
+		err = c.queue.DeleteEncodingJob(ctx, blobKey)
+		if err == nil {
+			err = c.queue.PutEncodingJob(ctx, job)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue encoding job: %w", err)
+	}
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), queueCleanupTimeout)
+		defer cancel()
+		err := c.queue.DeleteEncodingJob(cleanupCtx, blobKey)
+		if err != nil {
+			c.logger.Warn("failed to delete encoding job", "blobKey", blobKey.Hex(), "err", err)
+		}
+	}()
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to encode blob: %w", ctx.Err())
+		case <-ticker.C:
+			job, err := c.queue.GetEncodingJob(ctx, blobKey)
+			if err != nil {
+				c.logger.Warn("failed to get encoding job", "blobKey", blobKey.Hex(), "err", err)
+				continue
+			}
+
+			switch job.JobStatus {
+			case v2.EncodingJobCompleted:
+				return job.FragmentInfo, nil
+			case v2.EncodingJobFailed:
+				return nil, fmt.Errorf("failed to encode blob: %s", job.Error)
+			}
+		}
+	}
+}
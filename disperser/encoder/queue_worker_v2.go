@@ -0,0 +1,218 @@
+package encoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// EncodingJobQueue is the queue of encoding jobs shared by the controller, which writes the jobs, and the encoders,
+// which lease and encode them.
+type EncodingJobQueue interface {
+	PutEncodingJob(ctx context.Context, job *v2.EncodingJob) error
+	GetEncodingJob(ctx context.Context, blobKey corev2.BlobKey) (*v2.EncodingJob, error)
+	GetLeasableEncodingJobs(ctx context.Context, shard uint32, limit int32) ([]*v2.EncodingJob, error)
+	LeaseEncodingJob(ctx context.Context, job *v2.EncodingJob, owner string, leaseExpiry uint64) (*v2.EncodingJob, error)
+	ExtendEncodingJobLease(ctx context.Context, blobKey corev2.BlobKey, owner string, leaseExpiry uint64) error
+	CompleteEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, fragmentInfo *encoding.FragmentInfo) error
+	FailEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, reason string) error
+	RetryEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, reason string, retryAt uint64) error
+	DeleteEncodingJob(ctx context.Context, blobKey corev2.BlobKey) error
+}
+
+var _ EncodingJobQueue = (*blobstore.BlobMetadataStore)(nil)
+
+// leasableJobsPageSize is the number of leasable jobs of a shard read at once
+const leasableJobsPageSize = 32
+
+type QueueWorkerConfig struct {
+	// WorkerID identifies the encoder in the leases of the jobs, and must be unique among the encoders
+	WorkerID string
+	// PollInterval is how often the queue is checked for jobs to lease
+	PollInterval time.Duration
+	// LeaseDuration is how long a job is leased for. The lease is extended while the job is encoded, so that the job
+	// is only leased by another encoder if this encoder dies.
+	LeaseDuration time.Duration
+	// MaxAttempts is the number of times a job may be leased before it's failed, so that a job that makes encoders
+	// die isn't retried forever
+	MaxAttempts uint
+	// RetryBackoff is how long a job whose encoding failed waits before it's leased again. It doubles with each
+	// attempt.
+	RetryBackoff time.Duration
+}
+
+// QueueWorkerV2 encodes the jobs it leases from the encoding queue, in addition to the requests received by the
+// encoder server. Both share the concurrency limit of the server.
+type QueueWorkerV2 struct {
+	config QueueWorkerConfig
+	queue  EncodingJobQueue
+	server *EncoderServerV2
+	logger logging.Logger
+}
+
+func NewQueueWorkerV2(config QueueWorkerConfig, queue EncodingJobQueue, server *EncoderServerV2, logger logging.Logger) (*QueueWorkerV2, error) {
+	if config.WorkerID == "" {
+		return nil, errors.New("worker ID is required")
+	}
+	if config.PollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+	if config.LeaseDuration <= 0 {
+		return nil, errors.New("lease duration must be positive")
+	}
+	if config.MaxAttempts == 0 {
+		return nil, errors.New("max attempts must be positive")
+	}
+	if config.RetryBackoff < 0 {
+		return nil, errors.New("retry backoff must not be negative")
+	}
+
+	return &QueueWorkerV2{
+		config: config,
+		queue:  queue,
+		server: server,
+		logger: logger.With("component", "EncoderQueueWorker", "workerID", config.WorkerID),
+	}, nil
+}
+
+func (w *QueueWorkerV2) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.LeaseJobs(ctx)
+			}
+		}
+	}()
+}
+
+// LeaseJobs leases the queued jobs and the jobs whose lease expired until the encoder is at its concurrency limit, and
+// encodes them in the background. The shards of the queue are visited from a random one, so that the encoders don't
+// all compete for the jobs of the same shard.
+func (w *QueueWorkerV2) LeaseJobs(ctx context.Context) {
+	firstShard := rand.Intn(v2.NumEncodingJobShards)
+	for i := 0; i < v2.NumEncodingJobShards; i++ {
+		shard := uint32((firstShard + i) % v2.NumEncodingJobShards)
+		if !w.leaseShardJobs(ctx, shard) {
+			return
+		}
+	}
+}
+
+// leaseShardJobs leases the leasable jobs of the shard, oldest first. It returns false once the encoder is at its
+// concurrency limit.
+func (w *QueueWorkerV2) leaseShardJobs(ctx context.Context, shard uint32) bool {
+	jobs, err := w.queue.GetLeasableEncodingJobs(ctx, shard, leasableJobsPageSize)
+	if err != nil {
+		w.logger.Error("failed to get leasable encoding jobs", "shard", shard, "err", err)
+		return true
+	}
+
+	for _, job := range jobs {
+		release, ok := w.server.scheduler.tryAcquire(job.EncodingParams.NumEvaluations())
+		if !ok {
+			// the encoder is at its concurrency limit, the remaining jobs are left to the other encoders
+			return false
+		}
+
+		leased, err := w.queue.LeaseEncodingJob(ctx, job, w.config.WorkerID, w.leaseExpiry())
+		if err != nil {
+			release()
+			if !errors.Is(err, blobstore.ErrInvalidStateTransition) {
+				w.logger.Error("failed to lease encoding job", "blobKey", job.BlobKey.Hex(), "err", err)
+			}
+			continue
+		}
+
+		if leased.NumAttempts > w.config.MaxAttempts {
+			release()
+			w.logger.Warn("encoding job exceeded max attempts", "blobKey", job.BlobKey.Hex(), "numAttempts", leased.NumAttempts-1)
+			err = w.queue.FailEncodingJob(ctx, job.BlobKey, w.config.WorkerID, fmt.Sprintf("exceeded max attempts %d", w.config.MaxAttempts))
+			if err != nil {
+				w.logger.Error("failed to fail encoding job", "blobKey", job.BlobKey.Hex(), "err", err)
+			}
+			continue
+		}
+
+		go func() {
+			defer release()
+			w.encodeJob(ctx, leased)
+		}()
+	}
+	return true
+}
+
+// encodeJob encodes the blob of the leased job, extending the lease until the result is published
+func (w *QueueWorkerV2) encodeJob(ctx context.Context, job *v2.EncodingJob) {
+	start := time.Now()
+	encodeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(w.config.LeaseDuration / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-encodeCtx.Done():
+				return
+			case <-ticker.C:
+				err := w.queue.ExtendEncodingJobLease(encodeCtx, job.BlobKey, w.config.WorkerID, w.leaseExpiry())
+				if errors.Is(err, blobstore.ErrInvalidStateTransition) {
+					// the job was removed or taken over, so its result would no longer be published
+					w.logger.Warn("lost lease of encoding job", "blobKey", job.BlobKey.Hex())
+					cancel()
+					return
+				}
+				if err != nil {
+					w.logger.Warn("failed to extend lease of encoding job", "blobKey", job.BlobKey.Hex(), "err", err)
+				}
+			}
+		}
+	}()
+
+	fragmentInfo, err := w.server.encodeToChunkStore(encodeCtx, job.BlobKey, job.EncodingParams, job.Reencode)
+	if encodeCtx.Err() != nil {
+		w.server.metrics.IncrementCanceledBlobRequestNum(1)
+		return
+	}
+	if err != nil {
+		w.server.metrics.IncrementFailedBlobRequestNum(1)
+		w.logger.Warn("failed to encode blob of encoding job", "blobKey", job.BlobKey.Hex(), "attempt", job.NumAttempts, "err", err)
+		if job.NumAttempts < w.config.MaxAttempts {
+			err = w.queue.RetryEncodingJob(ctx, job.BlobKey, w.config.WorkerID, err.Error(), w.retryAt(job.NumAttempts))
+		} else {
+			err = w.queue.FailEncodingJob(ctx, job.BlobKey, w.config.WorkerID, err.Error())
+		}
+	} else {
+		w.server.metrics.IncrementSuccessfulBlobRequestNum(1)
+		w.server.metrics.ObserveLatency("total", time.Since(start))
+		err = w.queue.CompleteEncodingJob(ctx, job.BlobKey, w.config.WorkerID, fragmentInfo)
+	}
+	if err != nil {
+		w.logger.Error("failed to publish result of encoding job", "blobKey", job.BlobKey.Hex(), "err", err)
+	}
+}
+
+// retryAt returns when a job is leased again after its attempt failed, backing off exponentially with the attempts
+func (w *QueueWorkerV2) retryAt(attempt uint) uint64 {
+	backoff := w.config.RetryBackoff << min(attempt-1, 16)
+	return uint64(time.Now().Add(backoff).UnixNano())
+}
+
+func (w *QueueWorkerV2) leaseExpiry() uint64 {
+	return uint64(time.Now().Add(w.config.LeaseDuration).UnixNano())
+}
@@ -0,0 +1,269 @@
+package encoder_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+// memoryJobQueue is an in-memory EncodingJobQueue with the same leasing semantics as the blob metadata store
+type memoryJobQueue struct {
+	mu   sync.Mutex
+	jobs map[corev2.BlobKey]*v2.EncodingJob
+}
+
+var _ encoder.EncodingJobQueue = (*memoryJobQueue)(nil)
+
+func newMemoryJobQueue() *memoryJobQueue {
+	return &memoryJobQueue{jobs: make(map[corev2.BlobKey]*v2.EncodingJob)}
+}
+
+func (q *memoryJobQueue) PutEncodingJob(ctx context.Context, job *v2.EncodingJob) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.jobs[job.BlobKey]; ok {
+		return common.ErrAlreadyExists
+	}
+	copied := *job
+	q.jobs[job.BlobKey] = &copied
+	return nil
+}
+
+func (q *memoryJobQueue) GetEncodingJob(ctx context.Context, blobKey corev2.BlobKey) (*v2.EncodingJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job, ok := q.jobs[blobKey]
+	if !ok {
+		return nil, common.ErrMetadataNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (q *memoryJobQueue) GetLeasableEncodingJobs(ctx context.Context, shard uint32, limit int32) ([]*v2.EncodingJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := uint64(time.Now().UnixNano())
+	jobs := make([]*v2.EncodingJob, 0)
+	for _, job := range q.jobs {
+		if v2.EncodingJobShard(job.BlobKey) == shard && job.JobStatus.Pending() && job.LeasableAt < now {
+			copied := *job
+			jobs = append(jobs, &copied)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].LeasableAt < jobs[j].LeasableAt
+	})
+	if len(jobs) > int(limit) {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+func (q *memoryJobQueue) LeaseEncodingJob(ctx context.Context, job *v2.EncodingJob, owner string, leaseExpiry uint64) (*v2.EncodingJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stored, ok := q.jobs[job.BlobKey]
+	now := uint64(time.Now().UnixNano())
+	if !ok || stored.UpdatedAt != job.UpdatedAt || !stored.JobStatus.Pending() || stored.LeasableAt >= now {
+		return nil, blobstore.ErrInvalidStateTransition
+	}
+	stored.JobStatus = v2.EncodingJobLeased
+	stored.LeaseOwner = owner
+	stored.LeaseExpiry = leaseExpiry
+	stored.LeasableAt = leaseExpiry
+	stored.NumAttempts++
+	stored.UpdatedAt = now
+	copied := *stored
+	return &copied, nil
+}
+
+func (q *memoryJobQueue) ExtendEncodingJobLease(ctx context.Context, blobKey corev2.BlobKey, owner string, leaseExpiry uint64) error {
+	return q.update(blobKey, owner, func(job *v2.EncodingJob) {
+		job.LeaseExpiry = leaseExpiry
+		job.LeasableAt = leaseExpiry
+	})
+}
+
+func (q *memoryJobQueue) CompleteEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, fragmentInfo *encoding.FragmentInfo) error {
+	return q.update(blobKey, owner, func(job *v2.EncodingJob) {
+		job.JobStatus = v2.EncodingJobCompleted
+		job.FragmentInfo = fragmentInfo
+	})
+}
+
+func (q *memoryJobQueue) FailEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, reason string) error {
+	return q.update(blobKey, owner, func(job *v2.EncodingJob) {
+		job.JobStatus = v2.EncodingJobFailed
+		job.Error = reason
+	})
+}
+
+func (q *memoryJobQueue) RetryEncodingJob(ctx context.Context, blobKey corev2.BlobKey, owner string, reason string, retryAt uint64) error {
+	return q.update(blobKey, owner, func(job *v2.EncodingJob) {
+		job.JobStatus = v2.EncodingJobQueued
+		job.LeasableAt = retryAt
+		job.Error = reason
+	})
+}
+
+func (q *memoryJobQueue) DeleteEncodingJob(ctx context.Context, blobKey corev2.BlobKey) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.jobs, blobKey)
+	return nil
+}
+
+func (q *memoryJobQueue) update(blobKey corev2.BlobKey, owner string, update func(job *v2.EncodingJob)) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job, ok := q.jobs[blobKey]
+	if !ok || job.JobStatus != v2.EncodingJobLeased || job.LeaseOwner != owner {
+		return blobstore.ErrInvalidStateTransition
+	}
+	update(job)
+	job.UpdatedAt = uint64(time.Now().UnixNano())
+	return nil
+}
+
+func TestQueueWorkerV2(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	c := createTestComponents(t)
+	queue := newMemoryJobQueue()
+	worker, err := encoder.NewQueueWorkerV2(encoder.QueueWorkerConfig{
+		WorkerID:      "encoder1",
+		PollInterval:  10 * time.Millisecond,
+		LeaseDuration: time.Minute,
+		MaxAttempts:   2,
+		RetryBackoff:  10 * time.Millisecond,
+	}, queue, c.encoderServer, logger)
+	require.NoError(t, err)
+
+	data := make([]byte, 16*1024)
+	_, err = rand.New(rand.NewSource(42)).Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	blobKey, err := createTestBlobHeader(t).BlobKey()
+	require.NoError(t, err)
+	require.NoError(t, c.blobStore.StoreBlob(ctx, blobKey, data))
+
+	blobLength := encoding.GetBlobLength(uint(len(data)))
+	chunkLength, err := corev2.GetChunkLength(core.NextPowerOf2(uint32(blobLength)), blobParams)
+	require.NoError(t, err)
+	encodingParams := encoding.EncodingParams{
+		ChunkLength: uint64(chunkLength),
+		NumChunks:   uint64(blobParams.NumChunks),
+	}
+	expectedFragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: 294916,
+		FragmentSizeBytes:   512 * 1024,
+	}
+
+	t.Run("job of a dead encoder is taken over", func(t *testing.T) {
+		expired := uint64(time.Now().Add(-time.Second).UnixNano())
+		require.NoError(t, queue.PutEncodingJob(ctx, &v2.EncodingJob{
+			BlobKey:        blobKey,
+			EncodingParams: encodingParams,
+			JobStatus:      v2.EncodingJobLeased,
+			LeaseOwner:     "encoder0",
+			LeaseExpiry:    expired,
+			LeasableAt:     expired,
+			NumAttempts:    1,
+			UpdatedAt:      expired,
+		}))
+
+		worker.LeaseJobs(ctx)
+		require.Eventually(t, func() bool {
+			job, err := queue.GetEncodingJob(ctx, blobKey)
+			return err == nil && job.JobStatus == v2.EncodingJobCompleted
+		}, 50*time.Second, 10*time.Millisecond)
+
+		job, err := queue.GetEncodingJob(ctx, blobKey)
+		require.NoError(t, err)
+		require.Equal(t, "encoder1", job.LeaseOwner)
+		require.Equal(t, uint(2), job.NumAttempts)
+		require.Equal(t, expectedFragmentInfo, job.FragmentInfo)
+		require.NoError(t, queue.DeleteEncodingJob(ctx, blobKey))
+	})
+
+	t.Run("job exceeding max attempts is failed", func(t *testing.T) {
+		expired := uint64(time.Now().Add(-time.Second).UnixNano())
+		require.NoError(t, queue.PutEncodingJob(ctx, &v2.EncodingJob{
+			BlobKey:        blobKey,
+			EncodingParams: encodingParams,
+			JobStatus:      v2.EncodingJobLeased,
+			LeaseOwner:     "encoder0",
+			LeaseExpiry:    expired,
+			LeasableAt:     expired,
+			NumAttempts:    2,
+			UpdatedAt:      expired,
+		}))
+
+		worker.LeaseJobs(ctx)
+		job, err := queue.GetEncodingJob(ctx, blobKey)
+		require.NoError(t, err)
+		require.Equal(t, v2.EncodingJobFailed, job.JobStatus)
+		require.Equal(t, fmt.Sprintf("exceeded max attempts %d", 2), job.Error)
+		require.NoError(t, queue.DeleteEncodingJob(ctx, blobKey))
+	})
+
+	t.Run("failed job is retried until max attempts", func(t *testing.T) {
+		missingBlobKey := corev2.BlobKey{1, 2, 3}
+		now := uint64(time.Now().UnixNano())
+		require.NoError(t, queue.PutEncodingJob(ctx, &v2.EncodingJob{
+			BlobKey:        missingBlobKey,
+			EncodingParams: encodingParams,
+			JobStatus:      v2.EncodingJobQueued,
+			LeasableAt:     now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}))
+
+		worker.LeaseJobs(ctx)
+		require.Eventually(t, func() bool {
+			job, err := queue.GetEncodingJob(ctx, missingBlobKey)
+			return err == nil && job.JobStatus == v2.EncodingJobQueued && job.NumAttempts == 1 && job.Error != ""
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// the job is leased again once its backoff passes, and failed after its last attempt
+		require.Eventually(t, func() bool {
+			worker.LeaseJobs(ctx)
+			job, err := queue.GetEncodingJob(ctx, missingBlobKey)
+			return err == nil && job.JobStatus == v2.EncodingJobFailed
+		}, 5*time.Second, 10*time.Millisecond)
+		job, err := queue.GetEncodingJob(ctx, missingBlobKey)
+		require.NoError(t, err)
+		require.Equal(t, uint(2), job.NumAttempts)
+		require.NoError(t, queue.DeleteEncodingJob(ctx, missingBlobKey))
+	})
+
+	t.Run("client waits for the result published by the worker", func(t *testing.T) {
+		client, err := encoder.NewQueueEncoderClientV2(queue, 10*time.Millisecond, logger)
+		require.NoError(t, err)
+		worker.Start(ctx)
+
+		fragmentInfo, err := client.EncodeBlob(ctx, blobKey, encodingParams)
+		require.NoError(t, err)
+		require.Equal(t, expectedFragmentInfo, fragmentInfo)
+
+		// the job is removed once its result is received
+		_, err = queue.GetEncodingJob(ctx, blobKey)
+		require.ErrorIs(t, err, common.ErrMetadataNotFound)
+	})
+}
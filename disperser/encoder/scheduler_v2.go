@@ -0,0 +1,215 @@
+package encoder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SizeClass is a class of encoding requests, by the number of evaluations, chunk length times number of chunks,
+// of the encoded blob. Each class has its own queue, and the classes share the encoding slots in proportion to
+// their weights.
+type SizeClass struct {
+	Name string
+	// MaxNumEvaluations is the largest number of evaluations of the requests of the class. The requests larger
+	// than the largest class belong to the largest class.
+	MaxNumEvaluations uint64
+	Weight            uint32
+}
+
+// DefaultSizeClasses are the size classes of the encoding requests. At coding rate 8, small blobs are up to 256KiB
+// and medium blobs up to 4MiB.
+var DefaultSizeClasses = []SizeClass{
+	{Name: "small", MaxNumEvaluations: 1 << 16, Weight: 4},
+	{Name: "medium", MaxNumEvaluations: 1 << 20, Weight: 2},
+	{Name: "large", MaxNumEvaluations: math.MaxUint64, Weight: 1},
+}
+
+// ParseSizeClasses parses size classes given as <name>:<max number of evaluations>:<weight>, in increasing order
+// of their max number of evaluations
+func ParseSizeClasses(specs []string) ([]SizeClass, error) {
+	classes := make([]SizeClass, 0, len(specs))
+	for _, spec := range specs {
+		fields := strings.Split(spec, ":")
+		if len(fields) != 3 || fields[0] == "" {
+			return nil, fmt.Errorf("invalid size class %q: expected <name>:<max number of evaluations>:<weight>", spec)
+		}
+		maxNumEvaluations, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size class %q: %w", spec, err)
+		}
+		weight, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size class %q: %w", spec, err)
+		}
+		if weight == 0 {
+			return nil, fmt.Errorf("invalid size class %q: weight must be greater than 0", spec)
+		}
+		if len(classes) > 0 && maxNumEvaluations <= classes[len(classes)-1].MaxNumEvaluations {
+			return nil, fmt.Errorf("invalid size class %q: size classes must be in increasing order of their max number of evaluations", spec)
+		}
+		classes = append(classes, SizeClass{Name: fields[0], MaxNumEvaluations: maxNumEvaluations, Weight: uint32(weight)})
+	}
+	return classes, nil
+}
+
+// encodingScheduler hands out the encoding slots of the encoder. The requests waiting for a slot are queued by size
+// class, and the freed slots are shared between the classes with waiting requests by stride scheduling: each class
+// advances by the inverse of its weight when it takes a slot, and the class that advanced the least takes the next
+// slot. Small blobs then aren't stuck behind a backlog of large blobs, while the large blobs still make progress.
+type encodingScheduler struct {
+	classes  []SizeClass
+	maxSlots int
+	metrics  *Metrics
+
+	mu         sync.Mutex
+	numRunning int
+	queues     [][]*slotRequest
+	// pass is how far each class advanced, and virtualTime is the pass of the class that last took a slot
+	pass        []float64
+	virtualTime float64
+}
+
+// slotRequest is a request waiting for an encoding slot
+type slotRequest struct {
+	class    int
+	enqueued time.Time
+	granted  chan struct{}
+}
+
+func newEncodingScheduler(classes []SizeClass, maxSlots int, metrics *Metrics) *encodingScheduler {
+	if len(classes) == 0 {
+		classes = DefaultSizeClasses
+	}
+	return &encodingScheduler{
+		classes:  classes,
+		maxSlots: maxSlots,
+		metrics:  metrics,
+		queues:   make([][]*slotRequest, len(classes)),
+		pass:     make([]float64, len(classes)),
+	}
+}
+
+// classOf returns the index of the size class of a request encoding the given number of evaluations
+func (s *encodingScheduler) classOf(numEvaluations uint64) int {
+	for i, class := range s.classes {
+		if numEvaluations <= class.MaxNumEvaluations {
+			return i
+		}
+	}
+	return len(s.classes) - 1
+}
+
+// acquire waits for an encoding slot for a request encoding the given number of evaluations, and returns the
+// function releasing the slot
+func (s *encodingScheduler) acquire(ctx context.Context, numEvaluations uint64) (func(), error) {
+	request := &slotRequest{
+		class:    s.classOf(numEvaluations),
+		enqueued: time.Now(),
+		granted:  make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	if len(s.queues[request.class]) == 0 {
+		// an idle class doesn't bank the slots it didn't take while it was idle
+		s.pass[request.class] = max(s.pass[request.class], s.virtualTime)
+	}
+	s.queues[request.class] = append(s.queues[request.class], request)
+	s.reportQueue(request.class)
+	s.dispatch()
+	s.mu.Unlock()
+
+	select {
+	case <-request.granted:
+		return s.release, nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-request.granted:
+		// the slot was granted concurrently with the cancellation, so it's handed to the next request
+		s.numRunning--
+		s.dispatch()
+	default:
+		queue := s.queues[request.class]
+		for i, queued := range queue {
+			if queued == request {
+				s.queues[request.class] = append(queue[:i], queue[i+1:]...)
+				break
+			}
+		}
+		s.reportQueue(request.class)
+	}
+	return nil, ctx.Err()
+}
+
+// tryAcquire takes an encoding slot for a request encoding the given number of evaluations if one is free and no
+// request is waiting for one, and returns the function releasing the slot
+func (s *encodingScheduler) tryAcquire(numEvaluations uint64) (func(), bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.numRunning >= s.maxSlots {
+		return nil, false
+	}
+	for _, queue := range s.queues {
+		if len(queue) > 0 {
+			return nil, false
+		}
+	}
+
+	s.numRunning++
+	s.metrics.ObserveQueueWait(s.classes[s.classOf(numEvaluations)].Name, 0)
+	return s.release, true
+}
+
+func (s *encodingScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.numRunning--
+	s.dispatch()
+}
+
+// running returns the number of requests holding an encoding slot
+func (s *encodingScheduler) running() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.numRunning
+}
+
+// dispatch grants the free slots to the waiting requests. The caller must hold the lock.
+func (s *encodingScheduler) dispatch() {
+	for s.numRunning < s.maxSlots {
+		class := -1
+		for i, queue := range s.queues {
+			if len(queue) > 0 && (class < 0 || s.pass[i] < s.pass[class]) {
+				class = i
+			}
+		}
+		if class < 0 {
+			return
+		}
+
+		request := s.queues[class][0]
+		s.queues[class] = s.queues[class][1:]
+		s.virtualTime = s.pass[class]
+		s.pass[class] += 1 / float64(s.classes[class].Weight)
+		s.numRunning++
+		close(request.granted)
+
+		s.metrics.ObserveQueueWait(s.classes[class].Name, time.Since(request.enqueued))
+		s.reportQueue(class)
+	}
+}
+
+// reportQueue reports the number of requests waiting in the queue of the class. The caller must hold the lock.
+func (s *encodingScheduler) reportQueue(class int) {
+	s.metrics.SetSizeClassQueue(s.classes[class].Name, len(s.queues[class]))
+}
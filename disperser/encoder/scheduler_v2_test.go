@@ -0,0 +1,141 @@
+package encoder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var testSizeClasses = []SizeClass{
+	{Name: "small", MaxNumEvaluations: 1 << 10, Weight: 4},
+	{Name: "large", MaxNumEvaluations: 1 << 20, Weight: 1},
+}
+
+func newTestScheduler(maxSlots int) (*encodingScheduler, *Metrics) {
+	metrics := NewMetrics(prometheus.NewRegistry(), "9000", logger)
+	return newEncodingScheduler(testSizeClasses, maxSlots, metrics), metrics
+}
+
+// waitForQueue waits until the given number of requests are queued in the class
+func waitForQueue(t *testing.T, s *encodingScheduler, class int, numWaiting int) {
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.queues[class]) == numWaiting
+	}, time.Second, time.Millisecond)
+}
+
+func TestEncodingScheduler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("slots are shared by weight between the size classes", func(t *testing.T) {
+		s, metrics := newTestScheduler(1)
+		release, ok := s.tryAcquire(1 << 20)
+		require.True(t, ok)
+
+		// a backlog of large blobs builds up before the small blobs arrive
+		granted := make(chan string, 13)
+		enqueue := func(name string, numEvaluations uint64, class int, numWaiting int) {
+			go func() {
+				release, err := s.acquire(ctx, numEvaluations)
+				require.NoError(t, err)
+				granted <- name
+				release()
+			}()
+			waitForQueue(t, s, class, numWaiting)
+		}
+		for i := 0; i < 5; i++ {
+			enqueue("large", 1<<20, 1, i+1)
+		}
+		for i := 0; i < 8; i++ {
+			enqueue("small", 1<<10, 0, i+1)
+		}
+		require.Equal(t, 5.0, testutil.ToFloat64(metrics.SizeClassQueue.WithLabelValues("large")))
+		require.Equal(t, 8.0, testutil.ToFloat64(metrics.SizeClassQueue.WithLabelValues("small")))
+
+		release()
+		order := make([]string, 0, 13)
+		for i := 0; i < 13; i++ {
+			order = append(order, <-granted)
+		}
+		// the small blobs take four slots for each slot of the large blobs, until they run out
+		require.Equal(t, []string{
+			"small", "large",
+			"small", "small", "small", "small", "large",
+			"small", "small", "small", "large",
+			"large", "large",
+		}, order)
+		require.Equal(t, 0, s.running())
+		require.Equal(t, 0.0, testutil.ToFloat64(metrics.SizeClassQueue.WithLabelValues("large")))
+		require.Equal(t, 2, testutil.CollectAndCount(metrics.QueueWait))
+	})
+
+	t.Run("canceled requests leave the queue", func(t *testing.T) {
+		s, _ := newTestScheduler(1)
+		release, ok := s.tryAcquire(1)
+		require.True(t, ok)
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() {
+			_, err := s.acquire(cancelCtx, 1)
+			done <- err
+		}()
+		waitForQueue(t, s, 0, 1)
+
+		// requests don't jump ahead of the queued ones
+		_, ok = s.tryAcquire(1)
+		require.False(t, ok)
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+		waitForQueue(t, s, 0, 0)
+
+		// the slot is still held
+		_, ok = s.tryAcquire(1)
+		require.False(t, ok)
+		release()
+		require.Equal(t, 0, s.running())
+
+		release, ok = s.tryAcquire(1)
+		require.True(t, ok)
+		require.Equal(t, 1, s.running())
+		release()
+	})
+
+	t.Run("requests larger than the largest class belong to it", func(t *testing.T) {
+		s, _ := newTestScheduler(1)
+		require.Equal(t, 0, s.classOf(0))
+		require.Equal(t, 0, s.classOf(1<<10))
+		require.Equal(t, 1, s.classOf(1<<10+1))
+		require.Equal(t, 1, s.classOf(1<<30))
+	})
+}
+
+func TestParseSizeClasses(t *testing.T) {
+	classes, err := ParseSizeClasses([]string{"small:65536:4", "large:18446744073709551615:1"})
+	require.NoError(t, err)
+	require.Equal(t, []SizeClass{
+		{Name: "small", MaxNumEvaluations: 1 << 16, Weight: 4},
+		{Name: "large", MaxNumEvaluations: 1<<64 - 1, Weight: 1},
+	}, classes)
+
+	classes, err = ParseSizeClasses(nil)
+	require.NoError(t, err)
+	require.Empty(t, classes)
+
+	for _, specs := range [][]string{
+		{"small:65536"},
+		{":65536:4"},
+		{"small:65536:0"},
+		{"small:a:4"},
+		{"small:65536:4", "large:65536:1"},
+	} {
+		_, err = ParseSizeClasses(specs)
+		require.Error(t, err, specs)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
@@ -13,7 +14,6 @@ import (
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
-	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"google.golang.org/grpc"
@@ -33,8 +33,14 @@ type EncoderServerV2 struct {
 	metrics     *Metrics
 	close       func()
 
-	runningRequests chan struct{}
-	requestPool     chan struct{}
+	encodingCache *encodingCache
+	load          *encoderLoad
+	scheduler     *encodingScheduler
+
+	requestPool chan struct{}
+
+	// benchmarkMu is held while a benchmark runs
+	benchmarkMu sync.Mutex
 }
 
 func NewEncoderServerV2(config ServerConfig, blobStore *blobstore.BlobStore, chunkWriter chunkstore.ChunkWriter, logger logging.Logger, prover encoding.Prover, metrics *Metrics) *EncoderServerV2 {
@@ -46,8 +52,10 @@ func NewEncoderServerV2(config ServerConfig, blobStore *blobstore.BlobStore, chu
 		prover:      prover,
 		metrics:     metrics,
 
-		runningRequests: make(chan struct{}, config.MaxConcurrentRequests),
-		requestPool:     make(chan struct{}, config.RequestPoolSize),
+		encodingCache: newEncodingCache(config.EncodingCacheSizeBytes, metrics),
+		load:          newEncoderLoad(config.MaxConcurrentRequests),
+		scheduler:     newEncodingScheduler(config.SizeClasses, config.MaxConcurrentRequests, metrics),
+		requestPool:   make(chan struct{}, config.RequestPoolSize),
 	}
 }
 
@@ -63,11 +71,17 @@ func (s *EncoderServerV2) Start() error {
 	reflection.Register(gs)
 	pb.RegisterEncoderServer(gs, s)
 
-	// Register Server for Health Checks
+	// Register Server for Health Checks, reporting the health of the blob store the blobs are read from
 	name := pb.Encoder_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	healthCtx, cancelHealthChecks := context.WithCancel(context.Background())
+	healthChecker := healthcheck.NewHealthChecker(map[string]healthcheck.Check{
+		"store": s.blobStore.CheckHealth,
+	}, healthcheck.DefaultCheckInterval, healthcheck.DefaultCheckTimeout, s.logger)
+	healthChecker.Register(name, gs)
+	healthChecker.Start(healthCtx)
 
 	s.close = func() {
+		cancelHealthChecks()
 		err := listener.Close()
 		if err != nil {
 			log.Printf("failed to close listener: %v", err)
@@ -79,7 +93,7 @@ func (s *EncoderServerV2) Start() error {
 	return gs.Serve(listener)
 }
 
-func (s *EncoderServerV2) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequest) (*pb.EncodeBlobReply, error) {
+func (s *EncoderServerV2) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequest) (reply *pb.EncodeBlobReply, err error) {
 	totalStart := time.Now()
 	defer func() {
 		s.metrics.ObserveLatency("total", time.Since(totalStart))
@@ -95,16 +109,39 @@ func (s *EncoderServerV2) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequ
 		return nil, status.Error(codes.ResourceExhausted, "request pool is full")
 	}
 
-	// Limit the number of concurrent requests
-	s.runningRequests <- struct{}{}
-	defer s.popRequest()
+	// Report the load of the encoder once the request leaves it
+	defer func() {
+		if reply != nil {
+			reply.Status = s.status()
+		}
+	}()
+	defer func() {
+		<-s.requestPool
+	}()
+
+	// Validate the request first, its encoding params determine how it's scheduled
+	blobKey, encodingParams, err := s.validateAndParseRequest(req)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum(1)
+		return nil, err
+	}
+
+	// Limit the number of concurrent requests, sharing the encoding slots between the size classes of the blobs
+	s.load.startWaiting()
+	release, err := s.scheduler.acquire(ctx, encodingParams.NumEvaluations())
+	s.load.stopWaiting()
+	if err != nil {
+		s.metrics.IncrementCanceledBlobRequestNum(1)
+		return nil, status.Error(codes.Canceled, "request was canceled")
+	}
+	defer release()
 	if ctx.Err() != nil {
 		s.metrics.IncrementCanceledBlobRequestNum(1)
 		return nil, status.Error(codes.Canceled, "request was canceled")
 	}
 
 	s.metrics.ObserveLatency("queuing", time.Since(totalStart))
-	reply, err := s.handleEncodingToChunkStore(ctx, req)
+	reply, err = s.handleEncodingToChunkStore(ctx, blobKey, encodingParams, req.GetReencode())
 	if err != nil {
 		s.metrics.IncrementFailedBlobRequestNum(1)
 	} else {
@@ -114,26 +151,47 @@ func (s *EncoderServerV2) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequ
 	return reply, err
 }
 
-func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, req *pb.EncodeBlobRequest) (*pb.EncodeBlobReply, error) {
-	// Validate request first
-	blobKey, encodingParams, err := s.validateAndParseRequest(req)
+// GetStatus returns the load of the encoder
+func (s *EncoderServerV2) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusReply, error) {
+	return &pb.GetStatusReply{
+		Status: s.status(),
+	}, nil
+}
+
+func (s *EncoderServerV2) status() *pb.EncoderStatus {
+	return &pb.EncoderStatus{
+		QueueDepth:      uint32(len(s.requestPool)),
+		QueueCapacity:   uint32(cap(s.requestPool)),
+		EstimatedWaitMs: uint64(s.load.estimateWait(s.scheduler.running()).Milliseconds()),
+	}
+}
+
+func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, reencode bool) (*pb.EncodeBlobReply, error) {
+	fragmentInfo, err := s.encodeToChunkStore(ctx, blobKey, encodingParams, reencode)
 	if err != nil {
 		return nil, err
 	}
 
-	s.logger.Info("Preparing to encode", "blobKey", blobKey.Hex(), "encodingParams", encodingParams)
+	return &pb.EncodeBlobReply{
+		FragmentInfo: &pb.FragmentInfo{
+			TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+			FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+		},
+	}, nil
+}
+
+// encodeToChunkStore encodes the blob and stores its chunks in the chunk store. A reencode encodes the blob even if
+// its chunks are already stored or cached, since those chunks failed verification.
+func (s *EncoderServerV2) encodeToChunkStore(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams, reencode bool) (*encoding.FragmentInfo, error) {
+	s.logger.Info("Preparing to encode", "blobKey", blobKey.Hex(), "encodingParams", encodingParams, "reencode", reencode)
+	start := time.Now()
 
 	// Check if the blob has already been encoded
-	if s.config.PreventReencoding && s.chunkWriter.ProofExists(ctx, blobKey) {
+	if !reencode && s.config.PreventReencoding && s.chunkWriter.ProofExists(ctx, blobKey) {
 		coefExist, fragmentInfo := s.chunkWriter.CoefficientsExists(ctx, blobKey)
 		if coefExist {
 			s.logger.Info("blob already encoded", "blobKey", blobKey.Hex())
-			return &pb.EncodeBlobReply{
-				FragmentInfo: &pb.FragmentInfo{
-					TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
-					FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
-				},
-			}, nil
+			return fragmentInfo, nil
 		}
 	}
 
@@ -148,22 +206,34 @@ func (s *EncoderServerV2) handleEncodingToChunkStore(ctx context.Context, req *p
 	}
 	s.logger.Info("fetched blob", "duration", time.Since(fetchStart).String())
 
-	// Encode the data
-	encodingStart := time.Now()
-	frames, err := s.prover.GetFrames(data, encodingParams)
-	if err != nil {
-		s.logger.Error("failed to encode frames", "error", err)
-		return nil, status.Errorf(codes.Internal, "encoding failed: %v", err)
+	// Encode the data, unless the same data was recently encoded with the same params
+	cacheKey := s.encodingCache.Key(data, encodingParams)
+	var frames []*encoding.Frame
+	ok := false
+	if !reencode {
+		frames, ok = s.encodingCache.Get(cacheKey)
+	}
+	if ok {
+		s.logger.Info("found encoded frames in cache", "blobKey", blobKey.Hex())
+	} else {
+		encodingStart := time.Now()
+		frames, err = s.prover.GetFrames(data, encodingParams)
+		if err != nil {
+			s.logger.Error("failed to encode frames", "error", err)
+			return nil, status.Errorf(codes.Internal, "encoding failed: %v", err)
+		}
+		s.logger.Info("encoding frames", "duration", time.Since(encodingStart).String())
+		s.encodingCache.Put(cacheKey, frames)
 	}
-	s.logger.Info("encoding frames", "duration", time.Since(encodingStart).String())
 
 	// Process and store results
-	return s.processAndStoreResults(ctx, blobKey, frames)
-}
+	fragmentInfo, err := s.processAndStoreResults(ctx, blobKey, frames)
+	if err != nil {
+		return nil, err
+	}
+	s.load.reportEncoding(time.Since(start))
 
-func (s *EncoderServerV2) popRequest() {
-	<-s.requestPool
-	<-s.runningRequests
+	return fragmentInfo, nil
 }
 
 func (s *EncoderServerV2) validateAndParseRequest(req *pb.EncodeBlobRequest) (corev2.BlobKey, encoding.EncodingParams, error) {
@@ -213,41 +283,17 @@ func (s *EncoderServerV2) validateAndParseRequest(req *pb.EncodeBlobRequest) (co
 	return blobKey, params, nil
 }
 
-func (s *EncoderServerV2) processAndStoreResults(ctx context.Context, blobKey corev2.BlobKey, frames []*encoding.Frame) (*pb.EncodeBlobReply, error) {
-	proofs, coeffs := extractProofsAndCoeffs(frames)
-
-	// Store proofs
+// processAndStoreResults uploads the proofs and the coefficients of the encoded chunks, serializing the chunks one at a
+// time as they're uploaded
+func (s *EncoderServerV2) processAndStoreResults(ctx context.Context, blobKey corev2.BlobKey, frames []*encoding.Frame) (*encoding.FragmentInfo, error) {
 	storeStart := time.Now()
-	if err := s.chunkWriter.PutChunkProofs(ctx, blobKey, proofs); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to upload chunk proofs: %v", err)
-	}
-	s.logger.Info("stored proofs", "duration", time.Since(storeStart).String())
-
-	// Store coefficients
-	coeffStart := time.Now()
-	fragmentInfo, err := s.chunkWriter.PutChunkCoefficients(ctx, blobKey, coeffs)
+	fragmentInfo, err := s.chunkWriter.PutFrames(ctx, blobKey, frames)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to upload chunk coefficients: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to upload chunks: %v", err)
 	}
-	s.logger.Info("stored coefficients", "duration", time.Since(coeffStart).String())
+	s.logger.Info("stored chunks", "duration", time.Since(storeStart).String())
 
-	return &pb.EncodeBlobReply{
-		FragmentInfo: &pb.FragmentInfo{
-			TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
-			FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
-		},
-	}, nil
-}
-
-func extractProofsAndCoeffs(frames []*encoding.Frame) ([]*encoding.Proof, []*rs.Frame) {
-	proofs := make([]*encoding.Proof, len(frames))
-	coeffs := make([]*rs.Frame, len(frames))
-
-	for i, frame := range frames {
-		proofs[i] = &frame.Proof
-		coeffs[i] = &rs.Frame{Coeffs: frame.Coeffs}
-	}
-	return proofs, coeffs
+	return fragmentInfo, nil
 }
 
 func (s *EncoderServerV2) Close() {
@@ -128,17 +128,17 @@ func TestEncodeBlob(t *testing.T) {
 	}
 
 	expectedUploadCalls := 1
-	expectedFragmentedUploadObjectCalls := 0
+	expectedFragmentedUploadStreamCalls := 0
 	assert.Equal(t, c.s3Client.Called["UploadObject"], expectedUploadCalls)
-	assert.Equal(t, c.s3Client.Called["FragmentedUploadObject"], expectedFragmentedUploadObjectCalls)
+	assert.Equal(t, c.s3Client.Called["FragmentedUploadStream"], expectedFragmentedUploadStreamCalls)
 	resp, err := server.EncodeBlob(ctx, req)
 	if !assert.NoError(t, err, "EncodeBlob failed") {
 		t.FailNow()
 	}
 	expectedUploadCalls++
-	expectedFragmentedUploadObjectCalls++
+	expectedFragmentedUploadStreamCalls++
 	assert.Equal(t, c.s3Client.Called["UploadObject"], expectedUploadCalls)
-	assert.Equal(t, c.s3Client.Called["FragmentedUploadObject"], expectedFragmentedUploadObjectCalls)
+	assert.Equal(t, c.s3Client.Called["FragmentedUploadStream"], expectedFragmentedUploadStreamCalls)
 
 	// Verify encoding results
 	t.Run("Verify Encoding Results", func(t *testing.T) {
@@ -147,6 +147,16 @@ func TestEncodeBlob(t *testing.T) {
 		assert.Equal(t, uint32(512*1024), resp.FragmentInfo.FragmentSizeBytes, "Unexpected fragment size")
 	})
 
+	t.Run("Verify Encoder Status", func(t *testing.T) {
+		assert.Equal(t, uint32(0), resp.Status.QueueDepth, "Unexpected queue depth")
+		assert.Equal(t, uint32(5), resp.Status.QueueCapacity, "Unexpected queue capacity")
+		assert.Equal(t, uint64(0), resp.Status.EstimatedWaitMs, "Unexpected estimated wait")
+
+		statusReply, err := server.GetStatus(ctx, &pb.GetStatusRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, resp.Status.QueueCapacity, statusReply.Status.QueueCapacity)
+	})
+
 	expectedFragmentInfo := &encoding.FragmentInfo{
 		TotalChunkSizeBytes: resp.FragmentInfo.TotalChunkSizeBytes,
 		FragmentSizeBytes:   resp.FragmentInfo.FragmentSizeBytes,
@@ -172,7 +182,7 @@ func TestEncodeBlob(t *testing.T) {
 
 	t.Run("Verify Re-encoding is prevented", func(t *testing.T) {
 		assert.Equal(t, c.s3Client.Called["UploadObject"], expectedUploadCalls)
-		assert.Equal(t, c.s3Client.Called["FragmentedUploadObject"], expectedFragmentedUploadObjectCalls)
+		assert.Equal(t, c.s3Client.Called["FragmentedUploadStream"], expectedFragmentedUploadStreamCalls)
 		// Create and execute encoding request again
 		resp, err := server.EncodeBlob(ctx, req)
 		assert.NoError(t, err)
@@ -185,10 +195,57 @@ func TestEncodeBlob(t *testing.T) {
 		assert.Equal(t, uint32(294916), resp.FragmentInfo.TotalChunkSizeBytes, "Unexpected total chunk size")
 		assert.Equal(t, uint32(512*1024), resp.FragmentInfo.FragmentSizeBytes, "Unexpected fragment size")
 		assert.Equal(t, c.s3Client.Called["UploadObject"], expectedUploadCalls)
-		assert.Equal(t, c.s3Client.Called["FragmentedUploadObject"], expectedFragmentedUploadObjectCalls)
+		assert.Equal(t, c.s3Client.Called["FragmentedUploadStream"], expectedFragmentedUploadStreamCalls)
+	})
+
+	t.Run("Verify Re-encoding replaces the stored chunks when requested", func(t *testing.T) {
+		reencodeReq := &pb.EncodeBlobRequest{
+			BlobKey:        req.BlobKey,
+			EncodingParams: req.EncodingParams,
+			Reencode:       true,
+		}
+		resp, err := server.EncodeBlob(ctx, reencodeReq)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Equal(t, expectedFragmentInfo.TotalChunkSizeBytes, resp.FragmentInfo.TotalChunkSizeBytes, "Unexpected total chunk size")
+		assert.Equal(t, c.s3Client.Called["UploadObject"], expectedUploadCalls+1)
+		assert.Equal(t, c.s3Client.Called["FragmentedUploadStream"], expectedFragmentedUploadStreamCalls+1)
 	})
 }
 
+func TestRunBenchmark(t *testing.T) {
+	ctx := context.Background()
+	benchmarkCase := encoder.BenchmarkCase{
+		BlobSizeBytes:  16 * 1024,
+		EncodingParams: encoding.EncodingParams{ChunkLength: 32, NumChunks: 32},
+	}
+	c := createTestComponentsWithConfig(t, encoder.ServerConfig{
+		GrpcPort:              "8080",
+		MaxConcurrentRequests: 1,
+		RequestPoolSize:       1,
+		BenchmarkCases:        []encoder.BenchmarkCase{benchmarkCase},
+	})
+
+	reply, err := c.encoderServer.RunBenchmark(ctx, &pb.RunBenchmarkRequest{NumIterations: 2})
+	require.NoError(t, err)
+	require.Len(t, reply.Results, 1)
+	result := reply.Results[0]
+	require.Equal(t, benchmarkCase.BlobSizeBytes, result.BlobSizeBytes)
+	require.Equal(t, benchmarkCase.EncodingParams.ChunkLength, result.EncodingParams.ChunkLength)
+	require.Equal(t, benchmarkCase.EncodingParams.NumChunks, result.EncodingParams.NumChunks)
+	require.Positive(t, result.AvgEncodingDurationUs)
+	require.Positive(t, result.ThroughputBytesPerSecond)
+	require.InDelta(t, result.ThroughputBytesPerSecond, reply.ThroughputBytesPerSecond, result.ThroughputBytesPerSecond*0.01)
+
+	// the benchmark doesn't touch the stores
+	require.Zero(t, c.s3Client.Called["UploadObject"])
+	require.Zero(t, c.s3Client.Called["FragmentedUploadStream"])
+
+	_, err = c.encoderServer.RunBenchmark(ctx, &pb.RunBenchmarkRequest{NumIterations: 11})
+	require.Error(t, err)
+}
+
 // Helper function to create test blob header
 func createTestBlobHeader(t *testing.T) *corev2.BlobHeader {
 	t.Helper()
@@ -206,6 +263,16 @@ func createTestBlobHeader(t *testing.T) *corev2.BlobHeader {
 
 // Helper function to initialize encoder
 func createTestComponents(t *testing.T) *testComponents {
+	t.Helper()
+	return createTestComponentsWithConfig(t, encoder.ServerConfig{
+		GrpcPort:              "8080",
+		MaxConcurrentRequests: 10,
+		RequestPoolSize:       5,
+		PreventReencoding:     true,
+	})
+}
+
+func createTestComponentsWithConfig(t *testing.T, config encoder.ServerConfig) *testComponents {
 	t.Helper()
 	prover, err := makeTestProver(300000)
 	require.NoError(t, err, "Failed to create prover")
@@ -215,12 +282,7 @@ func createTestComponents(t *testing.T) *testComponents {
 	blobStore := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
 	chunkStoreWriter := chunkstore.NewChunkWriter(logger, s3Client, s3BucketName, 512*1024)
 	chunkStoreReader := chunkstore.NewChunkReader(logger, s3Client, s3BucketName)
-	encoderServer := encoder.NewEncoderServerV2(encoder.ServerConfig{
-		GrpcPort:              "8080",
-		MaxConcurrentRequests: 10,
-		RequestPoolSize:       5,
-		PreventReencoding:     true,
-	}, blobStore, chunkStoreWriter, logger, prover, metrics)
+	encoderServer := encoder.NewEncoderServerV2(config, blobStore, chunkStoreWriter, logger, prover, metrics)
 
 	return &testComponents{
 		encoderServer:    encoderServer,
@@ -2,6 +2,7 @@ package disperser
 
 import (
 	"context"
+	"time"
 
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -10,3 +11,27 @@ import (
 type EncoderClientV2 interface {
 	EncodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error)
 }
+
+// EncoderStatus describes the load of an encoder
+type EncoderStatus struct {
+	// QueueDepth is the number of requests accepted by the encoder that aren't encoded yet
+	QueueDepth uint32
+	// QueueCapacity is the number of requests the encoder accepts at once
+	QueueCapacity uint32
+	// EstimatedWait is the estimated time a new request waits before its encoding starts
+	EstimatedWait time.Duration
+}
+
+// EncoderLoadReporter is implemented by the encoder clients that track the load of their encoders, so that blobs
+// are only dispatched for encoding while an encoder has capacity for them.
+type EncoderLoadReporter interface {
+	// HasCapacity returns whether an encoder can accept another request
+	HasCapacity() bool
+}
+
+// EncoderReencoder is implemented by the encoder clients that can have a blob encoded again, so that the stored
+// chunks of a blob that failed verification are replaced rather than reused.
+type EncoderReencoder interface {
+	// ReencodeBlob encodes the blob even if its chunks are already stored, and replaces the stored chunks
+	ReencodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error)
+}
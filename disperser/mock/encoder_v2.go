@@ -14,6 +14,7 @@ type MockEncoderClientV2 struct {
 }
 
 var _ disperser.EncoderClientV2 = (*MockEncoderClientV2)(nil)
+var _ disperser.EncoderReencoder = (*MockEncoderClientV2)(nil)
 
 func NewMockEncoderClientV2() *MockEncoderClientV2 {
 	return &MockEncoderClientV2{}
@@ -27,3 +28,12 @@ func (m *MockEncoderClientV2) EncodeBlob(ctx context.Context, blobKey corev2.Blo
 	}
 	return fragmentInfo, args.Error(1)
 }
+
+func (m *MockEncoderClientV2) ReencodeBlob(ctx context.Context, blobKey corev2.BlobKey, encodingParams encoding.EncodingParams) (*encoding.FragmentInfo, error) {
+	args := m.Called()
+	var fragmentInfo *encoding.FragmentInfo
+	if args.Get(0) != nil {
+		fragmentInfo = args.Get(0).(*encoding.FragmentInfo)
+	}
+	return fragmentInfo, args.Error(1)
+}
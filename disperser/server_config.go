@@ -12,4 +12,17 @@ type ServerConfig struct {
 
 	PprofHttpPort string
 	EnablePprof   bool
+
+	// TLSCertFile and TLSKeyFile enable TLS on the grpc server when both are set
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile, when set, requires clients to present a certificate signed by one of the CAs in the file
+	TLSClientCAFile string
+	// TLSReloadInterval is how often the TLS files are checked for rotation
+	TLSReloadInterval time.Duration
+}
+
+// TLSEnabled returns true if the grpc server should serve over TLS.
+func (c ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
 }
@@ -19,6 +19,9 @@ type Config struct {
 	BackendType BackendType
 	GPUEnable   bool
 	Verbose     bool
+	// FFTCacheDir is the directory the roots of unity of the FFT domains are persisted to, so that they are
+	// computed once across restarts. They are only cached in memory if empty.
+	FFTCacheDir string
 }
 
 // DefaultConfig returns a Config struct with default values
@@ -0,0 +1,155 @@
+package fft
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// bytesPerElement is the size of a field element in the FFT settings files, which hold the limbs of the elements in
+// Montgomery form so that loading them doesn't require any field arithmetic
+const bytesPerElement = fr.Limbs * 8
+
+// SettingsCache holds the FFT settings of each scale, so that the roots of unity of a domain are computed once and
+// shared by all the encoding parameters, and the encoders, provers and verifiers, using this domain. If the cache
+// has a directory, the roots of unity are persisted to it when first computed, and loaded from it afterwards, so
+// that a restarted process doesn't compute them again.
+//
+// The settings returned by the cache are shared, and must not be modified.
+type SettingsCache struct {
+	dir string
+
+	mu       sync.Mutex
+	settings map[uint8]*FFTSettings
+}
+
+// NewSettingsCache creates a cache persisting the FFT settings to dir. The settings are only held in memory if dir
+// is empty.
+func NewSettingsCache(dir string) *SettingsCache {
+	return &SettingsCache{
+		dir:      dir,
+		settings: make(map[uint8]*FFTSettings),
+	}
+}
+
+var defaultSettingsCache = NewSettingsCache("")
+
+// GetFFTSettings returns the FFT settings of the given scale from a process wide in memory cache
+func GetFFTSettings(maxScale uint8) *FFTSettings {
+	return defaultSettingsCache.Get(maxScale)
+}
+
+// Get returns the FFT settings of the given scale, computing them or loading them from the cache directory if they
+// aren't cached in memory yet. Failing to load or persist the settings only costs computing them, so it is logged
+// rather than returned.
+func (c *SettingsCache) Get(maxScale uint8) *FFTSettings {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if fs, ok := c.settings[maxScale]; ok {
+		return fs
+	}
+
+	var fs *FFTSettings
+	if len(c.dir) != 0 {
+		var err error
+		fs, err = c.load(maxScale)
+		if err != nil && !os.IsNotExist(err) {
+			slog.Warn("Failed to load FFT settings, computing them", "scale", maxScale, "err", err)
+		}
+	}
+	if fs == nil {
+		fs = NewFFTSettings(maxScale)
+		if len(c.dir) != 0 {
+			if err := c.persist(maxScale, fs); err != nil {
+				slog.Warn("Failed to persist FFT settings", "scale", maxScale, "err", err)
+			}
+		}
+	}
+
+	c.settings[maxScale] = fs
+	return fs
+}
+
+func (c *SettingsCache) path(maxScale uint8) string {
+	return filepath.Join(c.dir, fmt.Sprintf("roots.scale%d", maxScale))
+}
+
+// load reads the expanded roots of unity of the given scale from the cache directory
+func (c *SettingsCache) load(maxScale uint8) (*FFTSettings, error) {
+	data, err := os.ReadFile(c.path(maxScale))
+	if err != nil {
+		return nil, err
+	}
+
+	width := uint64(1) << maxScale
+	if uint64(len(data)) != (width+1)*bytesPerElement {
+		return nil, fmt.Errorf("file holds %d bytes, expected %d for scale %d", len(data), (width+1)*bytesPerElement, maxScale)
+	}
+
+	rootz := make([]fr.Element, width+1)
+	for i := range rootz {
+		for j := range rootz[i] {
+			offset := i*bytesPerElement + j*8
+			rootz[i][j] = binary.LittleEndian.Uint64(data[offset : offset+8])
+		}
+	}
+
+	// the domain starts and ends with 1 and is generated by the root of unity of the scale. Checking its ends
+	// catches the files written for another scale or truncated by a crash.
+	root := &encoding.Scale2RootOfUnity[maxScale]
+	if !rootz[0].IsOne() || !rootz[width].IsOne() || (width > 1 && !rootz[1].Equal(root)) {
+		return nil, fmt.Errorf("file doesn't hold the roots of unity of scale %d", maxScale)
+	}
+
+	rootzReverse := make([]fr.Element, len(rootz))
+	for i := range rootz {
+		rootzReverse[i] = rootz[len(rootz)-1-i]
+	}
+
+	return &FFTSettings{
+		MaxWidth:             width,
+		RootOfUnity:          root,
+		ExpandedRootsOfUnity: rootz,
+		ReverseRootsOfUnity:  rootzReverse,
+	}, nil
+}
+
+// persist writes the expanded roots of unity of the given scale to the cache directory. The file is written under
+// a temporary name and renamed, so that a concurrent or interrupted write never leaves a partial file behind.
+func (c *SettingsCache) persist(maxScale uint8, fs *FFTSettings) error {
+	err := os.MkdirAll(c.dir, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("cannot create cache directory: %w", err)
+	}
+
+	data := make([]byte, len(fs.ExpandedRootsOfUnity)*bytesPerElement)
+	for i := range fs.ExpandedRootsOfUnity {
+		for j := range fs.ExpandedRootsOfUnity[i] {
+			offset := i*bytesPerElement + j*8
+			binary.LittleEndian.PutUint64(data[offset:offset+8], fs.ExpandedRootsOfUnity[i][j])
+		}
+	}
+
+	tmp, err := os.CreateTemp(c.dir, filepath.Base(c.path(maxScale))+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path(maxScale))
+}
@@ -0,0 +1,55 @@
+package fft
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsCache(t *testing.T) {
+	t.Run("settings are shared", func(t *testing.T) {
+		cache := NewSettingsCache("")
+		fs := cache.Get(5)
+		require.Equal(t, NewFFTSettings(5), fs)
+		require.Same(t, fs, cache.Get(5))
+		require.Equal(t, NewFFTSettings(3), cache.Get(3))
+
+		require.Same(t, GetFFTSettings(4), GetFFTSettings(4))
+	})
+
+	t.Run("settings are persisted", func(t *testing.T) {
+		dir := t.TempDir()
+		for scale := uint8(0); scale <= 6; scale++ {
+			require.Equal(t, NewFFTSettings(scale), NewSettingsCache(dir).Get(scale))
+			_, err := os.Stat(NewSettingsCache(dir).path(scale))
+			require.NoError(t, err)
+
+			loaded, err := NewSettingsCache(dir).load(scale)
+			require.NoError(t, err)
+			require.Equal(t, NewFFTSettings(scale), loaded)
+		}
+	})
+
+	t.Run("invalid files are replaced", func(t *testing.T) {
+		dir := t.TempDir()
+		cache := NewSettingsCache(dir)
+
+		// roots of another scale
+		require.NoError(t, os.WriteFile(cache.path(4), make([]byte, 17*bytesPerElement), 0644))
+		_, err := cache.load(4)
+		require.Error(t, err)
+
+		// truncated file
+		require.NoError(t, os.WriteFile(cache.path(5), make([]byte, 3*bytesPerElement), 0644))
+		_, err = cache.load(5)
+		require.Error(t, err)
+
+		require.Equal(t, NewFFTSettings(4), cache.Get(4))
+		require.Equal(t, NewFFTSettings(5), cache.Get(5))
+
+		loaded, err := NewSettingsCache(dir).load(5)
+		require.NoError(t, err)
+		require.Equal(t, NewFFTSettings(5), loaded)
+	})
+}
@@ -18,6 +18,8 @@ const (
 	CacheEncodedBlobsFlagName = "cache-encoded-blobs"
 	SRSLoadingNumberFlagName  = "kzg.srs-load"
 	G2PowerOf2PathFlagName    = "kzg.g2-power-of-2-path"
+	LazyLoadSRSFlagName       = "kzg.lazy-load-srs"
+	SRSPreloadNumberFlagName  = "kzg.srs-preload"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -54,7 +56,7 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		},
 		cli.Uint64Flag{
 			Name:     NumWorkerFlagName,
-			Usage:    "Number of workers for multithreading",
+			Usage:    "Number of workers for loading the SRS and the SRS tables. The prover sizes the workers of each proof generation job at runtime, based on the available CPUs, the size of the job and the number of concurrent jobs",
 			Required: false,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_WORKERS"),
 			Value:    uint64(runtime.GOMAXPROCS(0)),
@@ -83,6 +85,18 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Required: false,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "G2_POWER_OF_2_PATH"),
 		},
+		cli.BoolFlag{
+			Name:     LazyLoadSRSFlagName,
+			Usage:    "Set to memory map the SRS files and load the SRS points when first used, instead of loading SRS_LOAD points into memory at startup",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "LAZY_LOAD_SRS"),
+		},
+		cli.Uint64Flag{
+			Name:     SRSPreloadNumberFlagName,
+			Usage:    "Number of SRS points to load into memory at startup when the SRS is lazily loaded",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "SRS_PRELOAD"),
+		},
 	}
 }
 
@@ -97,6 +111,8 @@ func ReadCLIConfig(ctx *cli.Context) KzgConfig {
 	cfg.Verbose = ctx.GlobalBool(VerboseFlagName)
 	cfg.PreloadEncoder = ctx.GlobalBool(PreloadEncoderFlagName)
 	cfg.G2PowerOf2Path = ctx.GlobalString(G2PowerOf2PathFlagName)
+	cfg.LazyLoadSRS = ctx.GlobalBool(LazyLoadSRSFlagName)
+	cfg.SRSNumberToPreload = ctx.GlobalUint64(SRSPreloadNumberFlagName)
 
 	return cfg
 }
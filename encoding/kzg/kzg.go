@@ -53,8 +53,12 @@ func NewKZGSettings(fs *fft.FFTSettings, srs *SRS) (*KZGSettings, error) {
 
 // KZG commitment to polynomial in coefficient form
 func (ks *KZGSettings) CommitToPoly(coeffs []fr.Element) (*bn254.G1Affine, error) {
+	s1, err := ks.Srs.G1Points(uint64(len(coeffs)))
+	if err != nil {
+		return nil, err
+	}
 	var commit bn254.G1Affine
-	_, err := commit.MultiExp(ks.Srs.G1[:len(coeffs)], coeffs, ecc.MultiExpConfig{})
+	_, err = commit.MultiExp(s1, coeffs, ecc.MultiExpConfig{})
 	return &commit, err
 }
 
@@ -12,4 +12,9 @@ type KzgConfig struct {
 	Verbose         bool
 	PreloadEncoder  bool
 	LoadG2Points    bool
+	// LazyLoadSRS memory maps the SRS files and decodes the points when first used, instead of reading
+	// SRSNumberToLoad points into memory at startup
+	LazyLoadSRS bool
+	// SRSNumberToPreload is the number of points decoded at startup when LazyLoadSRS is set
+	SRSNumberToPreload uint64
 }
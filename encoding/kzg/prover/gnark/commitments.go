@@ -42,8 +42,12 @@ func (p *KzgCommitmentsGnarkBackend) ComputeLengthProofForLength(coeffs []fr.Ele
 func (p *KzgCommitmentsGnarkBackend) ComputeCommitment(coeffs []fr.Element) (*bn254.G1Affine, error) {
 	// compute commit for the full poly
 	config := ecc.MultiExpConfig{}
+	s1, err := p.Srs.G1Points(uint64(len(coeffs)))
+	if err != nil {
+		return nil, err
+	}
 	var commitment bn254.G1Affine
-	_, err := commitment.MultiExp(p.Srs.G1[:len(coeffs)], coeffs, config)
+	_, err = commitment.MultiExp(s1, coeffs, config)
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +57,12 @@ func (p *KzgCommitmentsGnarkBackend) ComputeCommitment(coeffs []fr.Element) (*bn
 func (p *KzgCommitmentsGnarkBackend) ComputeLengthCommitment(coeffs []fr.Element) (*bn254.G2Affine, error) {
 	config := ecc.MultiExpConfig{}
 
+	s2, err := p.Srs.G2Points(uint64(len(coeffs)))
+	if err != nil {
+		return nil, err
+	}
 	var lengthCommitment bn254.G2Affine
-	_, err := lengthCommitment.MultiExp(p.Srs.G2[:len(coeffs)], coeffs, config)
+	_, err = lengthCommitment.MultiExp(s2, coeffs, config)
 	if err != nil {
 		return nil, err
 	}
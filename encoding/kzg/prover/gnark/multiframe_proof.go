@@ -38,19 +38,29 @@ func (p *KzgMultiProofGnarkBackend) ComputeMultiFrameProof(polyFr []fr.Element,
 	}
 	preprocessDone := time.Now()
 
-	// compute proof by multi scaler multiplication
+	// compute proof by multi scaler multiplication, the workers splitting the msms between them
 	sumVec := make([]bn254.G1Affine, dimE*2)
-	msmErrors := make(chan error, dimE*2)
-	for i := uint64(0); i < dimE*2; i++ {
-
-		go func(k uint64) {
-			_, err := sumVec[k].MultiExp(p.FFTPointsT[k], coeffStore[k], ecc.MultiExpConfig{})
-			// handle error
+	msmJobs := make(chan uint64, dimE*2)
+	for k := uint64(0); k < dimE*2; k++ {
+		msmJobs <- k
+	}
+	close(msmJobs)
+
+	numMsmWorker := min(numWorker, dimE*2)
+	msmErrors := make(chan error, numMsmWorker)
+	for w := uint64(0); w < numMsmWorker; w++ {
+		go func() {
+			var err error
+			for k := range msmJobs {
+				if _, msmErr := sumVec[k].MultiExp(p.FFTPointsT[k], coeffStore[k], ecc.MultiExpConfig{NbTasks: 1}); msmErr != nil {
+					err = msmErr
+				}
+			}
 			msmErrors <- err
-		}(i)
+		}()
 	}
 
-	for i := uint64(0); i < dimE*2; i++ {
+	for w := uint64(0); w < numMsmWorker; w++ {
 		err := <-msmErrors
 		if err != nil {
 			fmt.Println("Error. MSM while adding points", err)
@@ -27,11 +27,16 @@ func CreateIcicleBackendProver(p *Prover, params encoding.EncodingParams, fs *ff
 	if err != nil {
 		return nil, err
 	}
+	// the device holds all the G1 points to be loaded
+	s1, err := p.Srs.G1Points(p.KzgConfig.SRSNumberToLoad)
+	if err != nil {
+		return nil, err
+	}
 	icicleDevice, err := icicle.NewIcicleDevice(icicle.IcicleDeviceConfig{
 		GPUEnable:  p.Config.GPUEnable,
 		NTTSize:    MAX_NTT_SIZE,
 		FFTPointsT: fftPointsT,
-		SRSG1:      p.Srs.G1[:p.KzgConfig.SRSNumberToLoad],
+		SRSG1:      s1,
 	})
 	if err != nil {
 		return nil, err
@@ -39,7 +44,10 @@ func CreateIcicleBackendProver(p *Prover, params encoding.EncodingParams, fs *ff
 
 	// Create subgroup FFT settings
 	t := uint8(math.Log2(float64(2 * params.NumChunks)))
-	sfs := fft.NewFFTSettings(t)
+	sfs := p.encoder.GetFFTSettings(t)
+
+	// The device is shared by the multiproof and commitments backends
+	gpuLock := &sync.Mutex{}
 
 	// Set up icicle multiproof backend
 	multiproofBackend := &icicleprover.KzgMultiProofIcicleBackend{
@@ -52,14 +60,20 @@ func CreateIcicleBackendProver(p *Prover, params encoding.EncodingParams, fs *ff
 		MsmCfg:         icicleDevice.MsmCfg,
 		KzgConfig:      p.KzgConfig,
 		Device:         icicleDevice.Device,
-		GpuLock:        sync.Mutex{},
+		GpuLock:        gpuLock,
 	}
 
-	// Set up gnark commitments backend
-	commitmentsBackend := &gnarkprover.KzgCommitmentsGnarkBackend{
-		Srs:        p.Srs,
-		G2Trailing: p.G2Trailing,
-		KzgConfig:  p.KzgConfig,
+	// Set up icicle commitments backend, G2 commitments are computed with gnark
+	commitmentsBackend := &icicleprover.KzgCommitmentsIcicleBackend{
+		KzgCommitmentsGnarkBackend: &gnarkprover.KzgCommitmentsGnarkBackend{
+			Srs:        p.Srs,
+			G2Trailing: p.G2Trailing,
+			KzgConfig:  p.KzgConfig,
+		},
+		SRSIcicle: icicleDevice.SRSG1Icicle,
+		MsmCfg:    icicleDevice.MsmCfg,
+		Device:    icicleDevice.Device,
+		GpuLock:   gpuLock,
 	}
 
 	return &ParametrizedProver{
@@ -69,5 +83,6 @@ func CreateIcicleBackendProver(p *Prover, params encoding.EncodingParams, fs *ff
 		Ks:                    ks,
 		KzgMultiProofBackend:  multiproofBackend,
 		KzgCommitmentsBackend: commitmentsBackend,
+		parallelism:           p.parallelism,
 	}, nil
 }
@@ -0,0 +1,71 @@
+//go:build icicle
+
+package icicle
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/encoding/icicle"
+	gnarkprover "github.com/Layr-Labs/eigenda/encoding/kzg/prover/gnark"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ingonyama-zk/icicle/v3/wrappers/golang/core"
+	iciclebn254 "github.com/ingonyama-zk/icicle/v3/wrappers/golang/curves/bn254"
+	"github.com/ingonyama-zk/icicle/v3/wrappers/golang/curves/bn254/msm"
+	"github.com/ingonyama-zk/icicle/v3/wrappers/golang/runtime"
+)
+
+// KzgCommitmentsIcicleBackend computes the G1 commitment with an MSM on the icicle device. The G2 length commitment
+// and length proofs are computed by the embedded gnark backend.
+type KzgCommitmentsIcicleBackend struct {
+	*gnarkprover.KzgCommitmentsGnarkBackend
+	SRSIcicle []iciclebn254.Affine
+	MsmCfg    core.MSMConfig
+	Device    runtime.Device
+	// GpuLock is shared with the multiproof backend using the same device
+	GpuLock *sync.Mutex
+}
+
+func (p *KzgCommitmentsIcicleBackend) ComputeCommitment(coeffs []fr.Element) (*bn254.G1Affine, error) {
+	if len(coeffs) > len(p.SRSIcicle) {
+		return nil, fmt.Errorf("number of coefficients %d exceeds the number of loaded SRS points %d", len(coeffs), len(p.SRSIcicle))
+	}
+
+	scalars := core.HostSliceFromElements[iciclebn254.ScalarField](icicle.ConvertFrToScalarFieldsBytes(coeffs))
+	points := core.HostSliceFromElements[iciclebn254.Affine](p.SRSIcicle[:len(coeffs)])
+	results := make(core.HostSlice[iciclebn254.Projective], 1)
+
+	// the result is copied to the host, so the MSM must complete before returning
+	cfg := p.MsmCfg
+	cfg.IsAsync = false
+
+	p.GpuLock.Lock()
+	defer p.GpuLock.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	var icicleErr error
+	runtime.RunOnDevice(&p.Device, func(args ...any) {
+		defer wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				icicleErr = fmt.Errorf("GPU operation panic: %v", r)
+			}
+		}()
+
+		err := msm.Msm(scalars, points, &cfg, results)
+		if err != runtime.Success {
+			icicleErr = fmt.Errorf("msm error: %v", err.AsString())
+		}
+	})
+	wg.Wait()
+
+	if icicleErr != nil {
+		return nil, icicleErr
+	}
+
+	commitment := icicle.IcicleProjectiveToGnarkAffine(results[0])
+	return &commitment, nil
+}
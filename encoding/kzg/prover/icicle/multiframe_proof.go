@@ -29,7 +29,7 @@ type KzgMultiProofIcicleBackend struct {
 	NttCfg         core.NTTConfig[[iciclebn254.SCALAR_LIMBS]uint32]
 	MsmCfg         core.MSMConfig
 	Device         runtime.Device
-	GpuLock        sync.Mutex
+	GpuLock        *sync.Mutex
 }
 
 type WorkerResult struct {
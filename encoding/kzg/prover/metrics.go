@@ -0,0 +1,69 @@
+package prover
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const proverNamespace = "eigenda_prover"
+
+// Metrics holds the metrics of the proof generation jobs. A nil Metrics reports nothing.
+type Metrics struct {
+	activeProofJobs prometheus.Gauge
+	proofWorkers    prometheus.Summary
+	proofCPUTime    prometheus.Summary
+}
+
+// NewMetrics sets up the metrics of the proof generation jobs.
+func NewMetrics(registry *prometheus.Registry) *Metrics {
+	activeProofJobs := promauto.With(registry).NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: proverNamespace,
+			Name:      "active_proof_jobs",
+			Help:      "The number of proof generation jobs running concurrently.",
+		},
+	)
+
+	proofWorkers := promauto.With(registry).NewSummary(
+		prometheus.SummaryOpts{
+			Namespace:  proverNamespace,
+			Name:       "proof_workers",
+			Help:       "The number of workers given to a proof generation job.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+	)
+
+	proofCPUTime := promauto.With(registry).NewSummary(
+		prometheus.SummaryOpts{
+			Namespace:  proverNamespace,
+			Name:       "proof_cpu_time_ms",
+			Help:       "The CPU time taken by a proof generation job, the number of its workers times its duration.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+	)
+
+	return &Metrics{
+		activeProofJobs: activeProofJobs,
+		proofWorkers:    proofWorkers,
+		proofCPUTime:    proofCPUTime,
+	}
+}
+
+func (m *Metrics) reportProofJobStarted(activeJobs int64) {
+	if m == nil {
+		return
+	}
+	m.activeProofJobs.Set(float64(activeJobs))
+}
+
+func (m *Metrics) reportProofJobFinished(activeJobs int64, numWorker uint64, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.activeProofJobs.Set(float64(activeJobs))
+	m.proofWorkers.Observe(float64(numWorker))
+	m.proofCPUTime.Observe(float64(numWorker) * common.ToMilliseconds(duration))
+}
@@ -0,0 +1,60 @@
+package prover
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// minEvaluationsPerProofWorker is the smallest number of evaluations given to a proof worker, so that the small
+// jobs don't pay for coordinating workers that have little to do
+const minEvaluationsPerProofWorker = 1 << 12
+
+// proofParallelism sizes the worker pool of each proof generation job when it starts. A job gets an equal share of
+// the CPUs available to the process between the jobs running at the time, capped by its size, so that a huge blob
+// doesn't starve the small blobs encoded concurrently.
+type proofParallelism struct {
+	activeJobs atomic.Int64
+	metrics    *Metrics
+}
+
+// proofJob is a running proof generation job
+type proofJob struct {
+	parallelism *proofParallelism
+	numWorker   uint64
+	start       time.Time
+}
+
+func newProofParallelism() *proofParallelism {
+	return &proofParallelism{}
+}
+
+// startJob returns the job computing the proofs of a blob encoded with the given params. The job must be finished
+// once the proofs are computed.
+func (p *proofParallelism) startJob(params encoding.EncodingParams) *proofJob {
+	concurrentJobs := p.activeJobs.Add(1)
+	numWorker := numProofWorkers(uint64(runtime.GOMAXPROCS(0)), uint64(concurrentJobs), params.NumEvaluations())
+	p.metrics.reportProofJobStarted(concurrentJobs)
+
+	return &proofJob{
+		parallelism: p,
+		numWorker:   numWorker,
+		start:       time.Now(),
+	}
+}
+
+// finish reports the resources used by the job
+func (j *proofJob) finish() {
+	concurrentJobs := j.parallelism.activeJobs.Add(-1)
+	j.parallelism.metrics.reportProofJobFinished(concurrentJobs, j.numWorker, time.Since(j.start))
+}
+
+// numProofWorkers returns the number of workers of a job computing the proofs of numEvaluations evaluations, given
+// the number of CPUs available and the number of jobs running, this job included
+func numProofWorkers(numCPU, concurrentJobs, numEvaluations uint64) uint64 {
+	numWorker := (numCPU + concurrentJobs - 1) / max(concurrentJobs, 1)
+	numWorker = min(numWorker, (numEvaluations+minEvaluationsPerProofWorker-1)/minEvaluationsPerProofWorker)
+	return max(numWorker, 1)
+}
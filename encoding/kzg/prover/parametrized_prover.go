@@ -23,6 +23,9 @@ type ParametrizedProver struct {
 
 	KzgMultiProofBackend  KzgMultiProofsBackend
 	KzgCommitmentsBackend KzgCommitmentsBackend
+
+	// parallelism sizes the worker pools of the proof generation jobs, KzgConfig.NumWorker workers are used if nil
+	parallelism *proofParallelism
 }
 
 type rsEncodeResult struct {
@@ -217,7 +220,7 @@ func (g *ParametrizedProver) GetFrames(inputFr []fr.Element) ([]encoding.Frame,
 			flatpaddedCoeffs = append(flatpaddedCoeffs, paddedCoeffs...)
 		}
 
-		proofs, err := g.KzgMultiProofBackend.ComputeMultiFrameProof(flatpaddedCoeffs, g.NumChunks, g.ChunkLength, g.KzgConfig.NumWorker)
+		proofs, err := g.computeMultiFrameProof(flatpaddedCoeffs)
 		proofChan <- proofsResult{
 			Proofs:   proofs,
 			Err:      err,
@@ -269,7 +272,7 @@ func (g *ParametrizedProver) GetMultiFrameProofs(inputFr []fr.Element) ([]encodi
 	copy(paddedCoeffs, inputFr)
 	paddingEnd := time.Since(paddingStart)
 
-	proofs, err := g.KzgMultiProofBackend.ComputeMultiFrameProof(paddedCoeffs, g.NumChunks, g.ChunkLength, g.KzgConfig.NumWorker)
+	proofs, err := g.computeMultiFrameProof(paddedCoeffs)
 
 	end := time.Since(start)
 
@@ -286,6 +289,17 @@ func (g *ParametrizedProver) GetMultiFrameProofs(inputFr []fr.Element) ([]encodi
 	return proofs, err
 }
 
+// computeMultiFrameProof computes the proofs of the padded coefficients with the workers given to the job
+func (g *ParametrizedProver) computeMultiFrameProof(paddedCoeffs []fr.Element) ([]bn254.G1Affine, error) {
+	if g.parallelism == nil {
+		return g.KzgMultiProofBackend.ComputeMultiFrameProof(paddedCoeffs, g.NumChunks, g.ChunkLength, g.KzgConfig.NumWorker)
+	}
+
+	job := g.parallelism.startJob(g.EncodingParams)
+	defer job.finish()
+	return g.KzgMultiProofBackend.ComputeMultiFrameProof(paddedCoeffs, g.NumChunks, g.ChunkLength, job.numWorker)
+}
+
 func (g *ParametrizedProver) validateInput(inputFr []fr.Element) error {
 	if len(inputFr) > int(g.KzgConfig.SRSNumberToLoad) {
 		return fmt.Errorf("poly Coeff length %v is greater than Loaded SRS points %v", len(inputFr), int(g.KzgConfig.SRSNumberToLoad))
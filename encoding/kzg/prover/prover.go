@@ -18,6 +18,7 @@ import (
 	gnarkprover "github.com/Layr-Labs/eigenda/encoding/kzg/prover/gnark"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/prometheus/client_golang/prometheus"
 	_ "go.uber.org/automaxprocs"
 )
 
@@ -30,6 +31,9 @@ type Prover struct {
 	G2Trailing []bn254.G2Affine
 	mu         sync.Mutex
 
+	// parallelism sizes the worker pools of the proof generation jobs of all the parametrized provers
+	parallelism *proofParallelism
+
 	ParametrizedProvers map[encoding.EncodingParams]*ParametrizedProver
 }
 
@@ -44,11 +48,15 @@ func NewProver(kzgConfig *kzg.KzgConfig, encoderConfig *encoding.Config) (*Prove
 		return nil, errors.New("SRSOrder is less than srsNumberToLoad")
 	}
 
-	// read the whole order, and treat it as entire SRS for low degree proof
-	s1, err := kzg.ReadG1Points(kzgConfig.G1Path, kzgConfig.SRSNumberToLoad, kzgConfig.NumWorker)
-	if err != nil {
-		log.Println("failed to read G1 points", err)
-		return nil, err
+	var err error
+	s1 := make([]bn254.G1Affine, 0)
+	if !kzgConfig.LazyLoadSRS {
+		// read the whole order, and treat it as entire SRS for low degree proof
+		s1, err = kzg.ReadG1Points(kzgConfig.G1Path, kzgConfig.SRSNumberToLoad, kzgConfig.NumWorker)
+		if err != nil {
+			log.Println("failed to read G1 points", err)
+			return nil, err
+		}
 	}
 
 	s2 := make([]bn254.G2Affine, 0)
@@ -60,10 +68,12 @@ func NewProver(kzgConfig *kzg.KzgConfig, encoderConfig *encoding.Config) (*Prove
 			return nil, errors.New("G2Path is empty. However, object needs to load G2Points")
 		}
 
-		s2, err = kzg.ReadG2Points(kzgConfig.G2Path, kzgConfig.SRSNumberToLoad, kzgConfig.NumWorker)
-		if err != nil {
-			log.Println("failed to read G2 points", err)
-			return nil, err
+		if !kzgConfig.LazyLoadSRS {
+			s2, err = kzg.ReadG2Points(kzgConfig.G2Path, kzgConfig.SRSNumberToLoad, kzgConfig.NumWorker)
+			if err != nil {
+				log.Println("failed to read G2 points", err)
+				return nil, err
+			}
 		}
 
 		g2Trailing, err = kzg.ReadG2PointSection(
@@ -82,7 +92,12 @@ func NewProver(kzgConfig *kzg.KzgConfig, encoderConfig *encoding.Config) (*Prove
 		}
 	}
 
-	srs, err := kzg.NewSrs(s1, s2)
+	var srs *kzg.SRS
+	if kzgConfig.LazyLoadSRS {
+		srs, err = newLazySrs(kzgConfig)
+	} else {
+		srs, err = kzg.NewSrs(s1, s2)
+	}
 	if err != nil {
 		log.Println("Could not create srs", err)
 		return nil, err
@@ -103,6 +118,7 @@ func NewProver(kzgConfig *kzg.KzgConfig, encoderConfig *encoding.Config) (*Prove
 		KzgConfig:           kzgConfig,
 		Srs:                 srs,
 		G2Trailing:          g2Trailing,
+		parallelism:         newProofParallelism(),
 		ParametrizedProvers: make(map[encoding.EncodingParams]*ParametrizedProver),
 	}
 
@@ -123,6 +139,29 @@ func NewProver(kzgConfig *kzg.KzgConfig, encoderConfig *encoding.Config) (*Prove
 	return encoderGroup, nil
 }
 
+// newLazySrs maps the SRS points to be loaded, and decodes the hot prefix of SRSNumberToPreload points
+func newLazySrs(kzgConfig *kzg.KzgConfig) (*kzg.SRS, error) {
+	g2Path := ""
+	if kzgConfig.LoadG2Points {
+		g2Path = kzgConfig.G2Path
+	}
+	srs, err := kzg.NewLazySrs(kzgConfig.G1Path, g2Path, kzgConfig.SRSNumberToLoad, kzgConfig.NumWorker)
+	if err != nil {
+		return nil, err
+	}
+	err = srs.Preload(kzgConfig.SRSNumberToPreload)
+	if err != nil {
+		return nil, err
+	}
+	return srs, nil
+}
+
+// EnableMetrics reports the metrics of the proof generation jobs to the registry. It must be called before the
+// prover is used.
+func (p *Prover) EnableMetrics(registry *prometheus.Registry) {
+	p.parallelism.metrics = NewMetrics(registry)
+}
+
 func (g *Prover) PreloadAllEncoders() error {
 	paramsAll, err := GetAllPrecomputedSrsMap(g.KzgConfig.CacheDir)
 	if err != nil {
@@ -362,7 +401,7 @@ func (p *Prover) newProver(params encoding.EncodingParams) (*ParametrizedProver,
 	if params.ChunkLength == 1 {
 		n = uint8(math.Log2(float64(2 * params.NumChunks)))
 	}
-	fs := fft.NewFFTSettings(n)
+	fs := p.encoder.GetFFTSettings(n)
 
 	// Create base KZG settings
 	ks, err := kzg.NewKZGSettings(fs, p.Srs)
@@ -374,7 +413,13 @@ func (p *Prover) newProver(params encoding.EncodingParams) (*ParametrizedProver,
 	case encoding.GnarkBackend:
 		return p.createGnarkBackendProver(params, fs, ks)
 	case encoding.IcicleBackend:
-		return p.createIcicleBackendProver(params, fs, ks)
+		prover, err := p.createIcicleBackendProver(params, fs, ks)
+		if err != nil {
+			// fall back to the CPU so that proving keeps working, e.g. on a host without a usable icicle runtime
+			slog.Warn("Could not create icicle backend prover, falling back to gnark backend", "err", err)
+			return p.newGnarkBackendProver(params, fs, ks)
+		}
+		return prover, nil
 	default:
 		return nil, fmt.Errorf("unsupported backend type: %v", p.Config.BackendType)
 	}
@@ -385,7 +430,10 @@ func (p *Prover) createGnarkBackendProver(params encoding.EncodingParams, fs *ff
 	if p.Config.GPUEnable {
 		return nil, errors.New("GPU is not supported in gnark backend")
 	}
+	return p.newGnarkBackendProver(params, fs, ks)
+}
 
+func (p *Prover) newGnarkBackendProver(params encoding.EncodingParams, fs *fft.FFTSettings, ks *kzg.KZGSettings) (*ParametrizedProver, error) {
 	_, fftPointsT, err := p.SetupFFTPoints(params)
 	if err != nil {
 		return nil, err
@@ -393,7 +441,7 @@ func (p *Prover) createGnarkBackendProver(params encoding.EncodingParams, fs *ff
 
 	// Create subgroup FFT settings
 	t := uint8(math.Log2(float64(2 * params.NumChunks)))
-	sfs := fft.NewFFTSettings(t)
+	sfs := p.encoder.GetFFTSettings(t)
 
 	// Set KZG Prover gnark backend
 	multiproofBackend := &gnarkprover.KzgMultiProofGnarkBackend{
@@ -417,6 +465,7 @@ func (p *Prover) createGnarkBackendProver(params encoding.EncodingParams, fs *ff
 		Ks:                    ks,
 		KzgMultiProofBackend:  multiproofBackend,
 		KzgCommitmentsBackend: commitmentsBackend,
+		parallelism:           p.parallelism,
 	}, nil
 }
 
@@ -426,7 +475,12 @@ func (p *Prover) createIcicleBackendProver(params encoding.EncodingParams, fs *f
 
 // Helper methods for setup
 func (p *Prover) SetupFFTPoints(params encoding.EncodingParams) ([][]bn254.G1Affine, [][]bn254.G1Affine, error) {
-	subTable, err := NewSRSTable(p.KzgConfig.CacheDir, p.Srs.G1, p.KzgConfig.NumWorker)
+	// precomputing the table of a blob reads the G1 points up to its size, which are used for its commitments anyway
+	s1, err := p.Srs.G1Points(min(params.NumChunks*params.ChunkLength, p.KzgConfig.SRSNumberToLoad))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get G1 points: %w", err)
+	}
+	subTable, err := NewSRSTable(p.KzgConfig.CacheDir, s1, p.KzgConfig.NumWorker)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create SRS table: %w", err)
 	}
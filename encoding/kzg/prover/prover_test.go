@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"os"
 	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -13,6 +14,7 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -115,6 +117,33 @@ func TestEncoder(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, decoded)
 }
 
+// Without the icicle build tag or a usable icicle device, the prover falls back to the gnark backend
+func TestEncoderIcicleBackendFallback(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, &encoding.Config{
+		NumWorker:   uint64(runtime.GOMAXPROCS(0)),
+		BackendType: encoding.IcicleBackend,
+		GPUEnable:   true,
+	})
+	require.NoError(t, err)
+
+	v, err := verifier.NewVerifier(kzgConfig, nil)
+	require.NoError(t, err)
+
+	params := encoding.ParamsFromMins(5, 5)
+	commitments, chunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+	require.NoError(t, err)
+
+	indices := []encoding.ChunkNumber{
+		0, 1, 2, 3, 4, 5, 6, 7,
+	}
+	err = v.VerifyFrames(chunks, indices, commitments, params)
+	assert.NoError(t, err)
+
+	decoded, err := p.Decode(chunks, indices, params, uint64(len(gettysburgAddressBytes)))
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, decoded)
+}
+
 // Ballpark number for 400KiB blob encoding
 //
 // goos: darwin
@@ -146,3 +175,89 @@ func BenchmarkEncode(b *testing.B) {
 		_, _, _ = p.EncodeAndProve(blobs[i%numSamples], params)
 	}
 }
+
+func TestEncoderLazySrs(t *testing.T) {
+	lazyConfig := *kzgConfig
+	lazyConfig.LazyLoadSRS = true
+	lazyConfig.SRSNumberToPreload = 64
+
+	p, err := prover.NewProver(&lazyConfig, nil)
+	require.NoError(t, err)
+	require.Empty(t, p.Srs.G1)
+
+	v, err := verifier.NewVerifier(&lazyConfig, nil)
+	require.NoError(t, err)
+
+	eagerProver, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+
+	params := encoding.ParamsFromMins(5, 5)
+	commitments, chunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+	require.NoError(t, err)
+
+	// the commitments don't depend on how the SRS is loaded
+	expectedCommitments, _, err := eagerProver.EncodeAndProve(gettysburgAddressBytes, params)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommitments, commitments)
+
+	indices := []encoding.ChunkNumber{
+		0, 1, 2, 3, 4, 5, 6, 7,
+	}
+	err = v.VerifyFrames(chunks, indices, commitments, params)
+	assert.NoError(t, err)
+	err = v.VerifyBlobLength(commitments)
+	assert.NoError(t, err)
+}
+
+func TestEncoderConcurrentJobs(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+	registry := prometheus.NewRegistry()
+	p.EnableMetrics(registry)
+
+	v, err := verifier.NewVerifier(kzgConfig, nil)
+	require.NoError(t, err)
+
+	paramsAll := []encoding.EncodingParams{
+		encoding.ParamsFromMins(5, 5),
+		{NumChunks: 32, ChunkLength: 32},
+		{NumChunks: 32, ChunkLength: 16},
+		{NumChunks: 8, ChunkLength: 128},
+	}
+
+	var wg sync.WaitGroup
+	for _, params := range paramsAll {
+		wg.Add(1)
+		go func(params encoding.EncodingParams) {
+			defer wg.Done()
+			commitments, chunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+			assert.NoError(t, err)
+
+			indices := make([]encoding.ChunkNumber, len(chunks))
+			for i := range indices {
+				indices[i] = encoding.ChunkNumber(i)
+			}
+			assert.NoError(t, v.VerifyFrames(chunks, indices, commitments, params))
+		}(params)
+	}
+	wg.Wait()
+
+	metricFamilies, err := registry.Gather()
+	require.NoError(t, err)
+	metrics := make(map[string]float64)
+	for _, family := range metricFamilies {
+		metric := family.GetMetric()[0]
+		if metric.GetSummary() != nil {
+			metrics[family.GetName()+"_count"] = float64(metric.GetSummary().GetSampleCount())
+			metrics[family.GetName()+"_sum"] = metric.GetSummary().GetSampleSum()
+		} else {
+			metrics[family.GetName()] = metric.GetGauge().GetValue()
+		}
+	}
+
+	require.Equal(t, 0.0, metrics["eigenda_prover_active_proof_jobs"])
+	require.Equal(t, float64(len(paramsAll)), metrics["eigenda_prover_proof_workers_count"])
+	// the jobs are too small to be split between several workers
+	require.Equal(t, float64(len(paramsAll)), metrics["eigenda_prover_proof_workers_sum"])
+	require.Equal(t, float64(len(paramsAll)), metrics["eigenda_prover_proof_cpu_time_ms_count"])
+}
@@ -28,12 +28,18 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 )
 
+// SRS holds the G1 and G2 points of the structured reference string. The points of an SRS created by NewSrs are
+// held in G1 and G2. The points of an SRS created by NewLazySrs are decoded from the memory mapped SRS files when
+// first needed, and G1 and G2 are left empty. G1Points and G2Points work for both.
 type SRS struct {
 
 	// [b.multiply(b.G1, pow(s, i, MODULUS)) for i in range(WIDTH+1)],
 	G1 []bn254.G1Affine
 	// [b.multiply(b.G2, pow(s, i, MODULUS)) for i in range(WIDTH+1)],
 	G2 []bn254.G2Affine
+
+	g1 *lazyPoints[bn254.G1Affine]
+	g2 *lazyPoints[bn254.G2Affine]
 }
 
 func NewSrs(G1 []bn254.G1Affine, G2 []bn254.G2Affine) (*SRS, error) {
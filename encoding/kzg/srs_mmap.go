@@ -0,0 +1,193 @@
+package kzg
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"golang.org/x/exp/mmap"
+)
+
+// NewLazySrs creates an SRS holding the first numPoints points of the G1 and G2 SRS files, without reading them.
+// The files are memory mapped, and a prefix of the points is only decoded when first requested by G1Points or
+// G2Points, so that the pages of the files holding points that are never used are never read, and the process
+// doesn't hold the decoded points it doesn't use. The G2 points aren't loaded if g2Path is empty.
+func NewLazySrs(g1Path, g2Path string, numPoints uint64, numWorker uint64) (*SRS, error) {
+	g1, err := newLazyPoints(g1Path, G1PointBytes, numPoints, numWorker, func(p *bn254.G1Affine, b []byte) error {
+		_, err := p.SetBytes(b)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to map G1 points: %w", err)
+	}
+
+	srs := &SRS{g1: g1}
+	if len(g2Path) != 0 {
+		srs.g2, err = newLazyPoints(g2Path, G2PointBytes, numPoints, numWorker, func(p *bn254.G2Affine, b []byte) error {
+			_, err := p.SetBytes(b)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to map G2 points: %w", err)
+		}
+	}
+
+	return srs, nil
+}
+
+// G1Points returns the first n G1 points of the SRS. The returned slice must not be modified.
+func (s *SRS) G1Points(n uint64) ([]bn254.G1Affine, error) {
+	if s.g1 != nil {
+		return s.g1.prefix(n)
+	}
+	if n > uint64(len(s.G1)) {
+		return nil, fmt.Errorf("requested %d G1 points, but the SRS holds %d", n, len(s.G1))
+	}
+	return s.G1[:n:n], nil
+}
+
+// G2Points returns the first n G2 points of the SRS. The returned slice must not be modified.
+func (s *SRS) G2Points(n uint64) ([]bn254.G2Affine, error) {
+	if s.g2 != nil {
+		return s.g2.prefix(n)
+	}
+	if n > uint64(len(s.G2)) {
+		return nil, fmt.Errorf("requested %d G2 points, but the SRS holds %d", n, len(s.G2))
+	}
+	return s.G2[:n:n], nil
+}
+
+// Preload decodes the first n points of a lazy SRS up front, so that the requests for this hot prefix don't pay
+// for decoding it. The G2 points are only preloaded if the SRS holds them. Preload is a no-op for an SRS holding
+// all of its points in memory.
+func (s *SRS) Preload(n uint64) error {
+	if s.g1 != nil {
+		if _, err := s.g1.prefix(n); err != nil {
+			return fmt.Errorf("failed to preload G1 points: %w", err)
+		}
+	}
+	if s.g2 != nil {
+		if _, err := s.g2.prefix(n); err != nil {
+			return fmt.Errorf("failed to preload G2 points: %w", err)
+		}
+	}
+	return nil
+}
+
+// lazyPoints decodes the points of a memory mapped SRS file on demand. The decoded points always form a prefix of
+// the file, which is extended when a longer prefix is requested.
+type lazyPoints[T any] struct {
+	reader     *mmap.ReaderAt
+	pointBytes uint64
+	numPoints  uint64
+	numWorker  uint64
+	decode     func(*T, []byte) error
+
+	// growMu serializes the extensions of the decoded prefix
+	growMu sync.Mutex
+	// mu guards points. The decoded points are never modified, so the prefix can be read while it is extended.
+	mu     sync.RWMutex
+	points []T
+}
+
+func newLazyPoints[T any](path string, pointBytes, numPoints, numWorker uint64, decode func(*T, []byte) error) (*lazyPoints[T], error) {
+	reader, err := mmap.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open points file %s: %w", path, err)
+	}
+	if uint64(reader.Len()) < numPoints*pointBytes {
+		_ = reader.Close()
+		return nil, fmt.Errorf("points file %s holds %d bytes, need %d for %d points", path, reader.Len(), numPoints*pointBytes, numPoints)
+	}
+	if numWorker == 0 {
+		numWorker = 1
+	}
+
+	return &lazyPoints[T]{
+		reader:     reader,
+		pointBytes: pointBytes,
+		numPoints:  numPoints,
+		numWorker:  numWorker,
+		decode:     decode,
+	}, nil
+}
+
+func (l *lazyPoints[T]) prefix(n uint64) ([]T, error) {
+	if n > l.numPoints {
+		return nil, fmt.Errorf("requested %d points, but the SRS holds %d", n, l.numPoints)
+	}
+
+	l.mu.RLock()
+	points := l.points
+	l.mu.RUnlock()
+	if n <= uint64(len(points)) {
+		return points[:n:n], nil
+	}
+
+	l.growMu.Lock()
+	defer l.growMu.Unlock()
+
+	// only the extensions, which are serialized by growMu, modify the points
+	points = l.points
+	if n <= uint64(len(points)) {
+		return points[:n:n], nil
+	}
+
+	// the readers of the current prefix don't access the points past it, even if they share the backing array
+	decoded := uint64(len(points))
+	points = slices.Grow(points, int(n-decoded))[:n]
+	err := l.decodeRange(points, decoded, n)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.points = points
+	l.mu.Unlock()
+
+	return points[:n:n], nil
+}
+
+// decodeRange decodes the points [from, to) of the file into outs, splitting the range between the workers
+func (l *lazyPoints[T]) decodeRange(outs []T, from, to uint64) error {
+	numWorker := l.numWorker
+	if to-from < numWorker {
+		numWorker = to - from
+	}
+	size := (to - from) / numWorker
+
+	results := make(chan error, numWorker)
+	for i := uint64(0); i < numWorker; i++ {
+		start := from + i*size
+		end := start + size
+		if i == numWorker-1 {
+			end = to
+		}
+
+		go func() {
+			buf := make([]byte, l.pointBytes)
+			for j := start; j < end; j++ {
+				_, err := l.reader.ReadAt(buf, int64(j*l.pointBytes))
+				if err != nil {
+					results <- fmt.Errorf("failed to read point %d: %w", j, err)
+					return
+				}
+				err = l.decode(&outs[j], buf)
+				if err != nil {
+					results <- fmt.Errorf("failed to decode point %d: %w", j, err)
+					return
+				}
+			}
+			results <- nil
+		}()
+	}
+
+	var err error
+	for i := uint64(0); i < numWorker; i++ {
+		if workerErr := <-results; workerErr != nil && err == nil {
+			err = workerErr
+		}
+	}
+	return err
+}
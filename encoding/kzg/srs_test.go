@@ -0,0 +1,85 @@
+package kzg_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	g1Path    = "../../inabox/resources/kzg/g1.point"
+	g2Path    = "../../inabox/resources/kzg/g2.point"
+	numPoints = 3000
+)
+
+func TestLazySrs(t *testing.T) {
+	g1, err := kzg.ReadG1Points(g1Path, numPoints, 4)
+	require.NoError(t, err)
+	g2, err := kzg.ReadG2Points(g2Path, numPoints, 4)
+	require.NoError(t, err)
+
+	t.Run("points are decoded on demand", func(t *testing.T) {
+		srs, err := kzg.NewLazySrs(g1Path, g2Path, numPoints, 4)
+		require.NoError(t, err)
+		require.Empty(t, srs.G1)
+		require.Empty(t, srs.G2)
+
+		s1, err := srs.G1Points(10)
+		require.NoError(t, err)
+		require.Equal(t, g1[:10], s1)
+
+		// extending the decoded prefix leaves the points returned before untouched
+		s1Longer, err := srs.G1Points(numPoints)
+		require.NoError(t, err)
+		require.Equal(t, g1, s1Longer)
+		require.Equal(t, g1[:10], s1)
+
+		s2, err := srs.G2Points(100)
+		require.NoError(t, err)
+		require.Equal(t, g2[:100], s2)
+
+		_, err = srs.G1Points(numPoints + 1)
+		require.Error(t, err)
+	})
+
+	t.Run("concurrent requests", func(t *testing.T) {
+		srs, err := kzg.NewLazySrs(g1Path, "", numPoints, 4)
+		require.NoError(t, err)
+		require.NoError(t, srs.Preload(16))
+
+		var wg sync.WaitGroup
+		for i := uint64(1); i <= 16; i++ {
+			wg.Add(1)
+			go func(n uint64) {
+				defer wg.Done()
+				s1, err := srs.G1Points(n)
+				require.NoError(t, err)
+				require.Equal(t, g1[:n], s1)
+			}(i * numPoints / 16)
+		}
+		wg.Wait()
+
+		_, err = srs.G2Points(1)
+		require.Error(t, err)
+	})
+
+	t.Run("file too short", func(t *testing.T) {
+		_, err := kzg.NewLazySrs(g1Path, g2Path, numPoints+1, 4)
+		require.Error(t, err)
+	})
+
+	t.Run("srs in memory", func(t *testing.T) {
+		srs, err := kzg.NewSrs(g1, g2)
+		require.NoError(t, err)
+		require.NoError(t, srs.Preload(numPoints))
+
+		s1, err := srs.G1Points(20)
+		require.NoError(t, err)
+		require.Equal(t, g1[:20], s1)
+
+		_, err = srs.G2Points(numPoints + 1)
+		require.Error(t, err)
+	})
+}
@@ -85,7 +85,11 @@ func genRhsG1(samples []Sample, randomsFr []fr.Element, m int, params encoding.E
 
 	// All samples in a subBatch has identical chunkLen
 	var aggPolyG1 bn254.G1Affine
-	_, err = aggPolyG1.MultiExp(ks.Srs.G1[:D], aggPolyCoeffs, ecc.MultiExpConfig{})
+	s1, err := ks.Srs.G1Points(D)
+	if err != nil {
+		return nil, err
+	}
+	_, err = aggPolyG1.MultiExp(s1, aggPolyCoeffs, ecc.MultiExpConfig{})
 	if err != nil {
 		return nil, err
 	}
@@ -39,10 +39,14 @@ func NewVerifier(config *kzg.KzgConfig, encoderConfig *encoding.Config) (*Verifi
 		return nil, errors.New("SRSOrder is less than srsNumberToLoad")
 	}
 
-	// read the whole order, and treat it as entire SRS for low degree proof
-	s1, err := kzg.ReadG1Points(config.G1Path, config.SRSNumberToLoad, config.NumWorker)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read %d G1 points from %s: %v", config.SRSNumberToLoad, config.G1Path, err)
+	var err error
+	s1 := make([]bn254.G1Affine, 0)
+	if !config.LazyLoadSRS {
+		// read the whole order, and treat it as entire SRS for low degree proof
+		s1, err = kzg.ReadG1Points(config.G1Path, config.SRSNumberToLoad, config.NumWorker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %d G1 points from %s: %v", config.SRSNumberToLoad, config.G1Path, err)
+		}
 	}
 
 	s2 := make([]bn254.G2Affine, 0)
@@ -54,9 +58,11 @@ func NewVerifier(config *kzg.KzgConfig, encoderConfig *encoding.Config) (*Verifi
 			return nil, errors.New("G2Path is empty. However, object needs to load G2Points")
 		}
 
-		s2, err = kzg.ReadG2Points(config.G2Path, config.SRSNumberToLoad, config.NumWorker)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read %d G2 points from %s: %v", config.SRSNumberToLoad, config.G2Path, err)
+		if !config.LazyLoadSRS {
+			s2, err = kzg.ReadG2Points(config.G2Path, config.SRSNumberToLoad, config.NumWorker)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %d G2 points from %s: %v", config.SRSNumberToLoad, config.G2Path, err)
+			}
 		}
 
 		g2Trailing, err = kzg.ReadG2PointSection(
@@ -87,7 +93,12 @@ func NewVerifier(config *kzg.KzgConfig, encoderConfig *encoding.Config) (*Verifi
 			log.Println("verifier requires accesses to entire g2 points. It is a legacy usage. For most operators, it is likely because G2_POWER_OF_2_PATH is improperly configured.")
 		}
 	}
-	srs, err := kzg.NewSrs(s1, s2)
+	var srs *kzg.SRS
+	if config.LazyLoadSRS {
+		srs, err = newLazySrs(config)
+	} else {
+		srs, err = kzg.NewSrs(s1, s2)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SRS: %v", err)
 	}
@@ -110,6 +121,23 @@ func NewVerifier(config *kzg.KzgConfig, encoderConfig *encoding.Config) (*Verifi
 	return encoderGroup, nil
 }
 
+// newLazySrs maps the SRS points to be loaded, and decodes the hot prefix of SRSNumberToPreload points
+func newLazySrs(config *kzg.KzgConfig) (*kzg.SRS, error) {
+	g2Path := ""
+	if config.LoadG2Points {
+		g2Path = config.G2Path
+	}
+	srs, err := kzg.NewLazySrs(config.G1Path, g2Path, config.SRSNumberToLoad, config.NumWorker)
+	if err != nil {
+		return nil, err
+	}
+	err = srs.Preload(config.SRSNumberToPreload)
+	if err != nil {
+		return nil, err
+	}
+	return srs, nil
+}
+
 type ParametrizedVerifier struct {
 	*kzg.KzgConfig
 	Srs *kzg.SRS
@@ -150,7 +178,7 @@ func (v *Verifier) newKzgVerifier(params encoding.EncodingParams) (*Parametrized
 
 	// Create FFT settings based on params
 	n := uint8(math.Log2(float64(params.NumEvaluations())))
-	fs := fft.NewFFTSettings(n)
+	fs := v.encoder.GetFFTSettings(n)
 
 	// Create KZG settings
 	ks, err := kzg.NewKZGSettings(fs, v.Srs)
@@ -272,7 +300,11 @@ func VerifyFrame(f *encoding.Frame, ks *kzg.KZGSettings, commitment *bn254.G1Aff
 	// [interpolation_polynomial(s)]_1
 	var is1 bn254.G1Affine
 	config := ecc.MultiExpConfig{}
-	_, err := is1.MultiExp(ks.Srs.G1[:len(f.Coeffs)], f.Coeffs, config)
+	s1, err := ks.Srs.G1Points(uint64(len(f.Coeffs)))
+	if err != nil {
+		return err
+	}
+	_, err = is1.MultiExp(s1, f.Coeffs, config)
 	if err != nil {
 		return err
 	}
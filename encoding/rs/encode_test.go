@@ -2,6 +2,7 @@ package rs_test
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -35,6 +36,42 @@ func TestEncodeDecode_InvertsWhenSamplingAllFrames(t *testing.T) {
 	assert.Equal(t, data, GETTYSBURG_ADDRESS_BYTES)
 }
 
+func TestEncodeDecode_PersistedFFTSettings(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := encoding.ParamsFromSysPar(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	inputFr, err := rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES)
+	require.Nil(t, err)
+
+	defaultEnc, err := rs.NewEncoder(encoding.DefaultConfig())
+	require.Nil(t, err)
+	expectedFrames, _, err := defaultEnc.Encode(inputFr, params)
+	require.Nil(t, err)
+
+	cfg := encoding.DefaultConfig()
+	cfg.FFTCacheDir = t.TempDir()
+
+	// the first encoder computes and persists the roots of unity, the second one loads them
+	for i := 0; i < 2; i++ {
+		enc, err := rs.NewEncoder(cfg)
+		require.Nil(t, err)
+
+		frames, _, err := enc.Encode(inputFr, params)
+		require.Nil(t, err)
+		require.Equal(t, expectedFrames, frames)
+
+		samples, indices := sampleFrames(frames, uint64(len(frames)-1))
+		data, err := enc.Decode(samples, indices, uint64(len(GETTYSBURG_ADDRESS_BYTES)), params)
+		require.Nil(t, err)
+		require.Equal(t, GETTYSBURG_ADDRESS_BYTES, data)
+	}
+
+	files, err := os.ReadDir(cfg.FFTCacheDir)
+	require.Nil(t, err)
+	require.NotEmpty(t, files)
+}
+
 func TestEncodeDecode_InvertsWhenSamplingMissingFrame(t *testing.T) {
 	teardownSuite := setupSuite(t)
 	defer teardownSuite(t)
@@ -3,6 +3,7 @@ package rs
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 	"sync"
 
@@ -16,6 +17,9 @@ import (
 type Encoder struct {
 	Config *encoding.Config
 
+	// fftSettings caches the FFT settings shared by the parametrized encoders, nil uses the process wide cache
+	fftSettings *fft.SettingsCache
+
 	mu                  sync.Mutex
 	ParametrizedEncoder map[encoding.EncodingParams]*ParametrizedEncoder
 }
@@ -31,8 +35,14 @@ func NewEncoder(config *encoding.Config) (*Encoder, error) {
 		config = encoding.DefaultConfig()
 	}
 
+	var fftSettings *fft.SettingsCache
+	if len(config.FFTCacheDir) != 0 {
+		fftSettings = fft.NewSettingsCache(config.FFTCacheDir)
+	}
+
 	e := &Encoder{
 		Config:              config,
+		fftSettings:         fftSettings,
 		mu:                  sync.Mutex{},
 		ParametrizedEncoder: make(map[encoding.EncodingParams]*ParametrizedEncoder),
 	}
@@ -78,7 +88,13 @@ func (e *Encoder) newEncoder(params encoding.EncodingParams) (*ParametrizedEncod
 	case encoding.GnarkBackend:
 		return e.createGnarkBackendEncoder(params, fs)
 	case encoding.IcicleBackend:
-		return e.createIcicleBackendEncoder(params, fs)
+		enc, err := e.createIcicleBackendEncoder(params, fs)
+		if err != nil {
+			// fall back to the CPU so that encoding keeps working, e.g. on a host without a usable icicle runtime
+			slog.Warn("Could not create icicle backend encoder, falling back to gnark backend", "err", err)
+			return e.newGnarkBackendEncoder(params, fs), nil
+		}
+		return enc, nil
 	default:
 		return nil, fmt.Errorf("unsupported backend type: %v", e.Config.BackendType)
 	}
@@ -86,20 +102,32 @@ func (e *Encoder) newEncoder(params encoding.EncodingParams) (*ParametrizedEncod
 
 func (e *Encoder) CreateFFTSettings(params encoding.EncodingParams) *fft.FFTSettings {
 	n := uint8(math.Log2(float64(params.NumEvaluations())))
-	return fft.NewFFTSettings(n)
+	return e.GetFFTSettings(n)
+}
+
+// GetFFTSettings returns the cached FFT settings of the given scale. The returned settings are shared and must not
+// be modified.
+func (e *Encoder) GetFFTSettings(maxScale uint8) *fft.FFTSettings {
+	if e.fftSettings == nil {
+		return fft.GetFFTSettings(maxScale)
+	}
+	return e.fftSettings.Get(maxScale)
 }
 
 func (e *Encoder) createGnarkBackendEncoder(params encoding.EncodingParams, fs *fft.FFTSettings) (*ParametrizedEncoder, error) {
 	if e.Config.GPUEnable {
 		return nil, errors.New("GPU is not supported in gnark backend")
 	}
+	return e.newGnarkBackendEncoder(params, fs), nil
+}
 
+func (e *Encoder) newGnarkBackendEncoder(params encoding.EncodingParams, fs *fft.FFTSettings) *ParametrizedEncoder {
 	return &ParametrizedEncoder{
 		Config:            e.Config,
 		EncodingParams:    params,
 		Fs:                fs,
 		RSEncoderComputer: &gnarkencoder.RsGnarkBackend{Fs: fs},
-	}, nil
+	}
 }
 
 func (e *Encoder) createIcicleBackendEncoder(params encoding.EncodingParams, fs *fft.FFTSettings) (*ParametrizedEncoder, error) {
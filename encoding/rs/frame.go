@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"encoding/gob"
 	"fmt"
+	"io"
 
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -50,13 +51,7 @@ func Decode(b []byte) (Frame, error) {
 //
 // Where relevant, big endian encoding is used.
 func GnarkEncodeFrames(frames []*Frame) ([]byte, error) {
-
-	// Count the number of bytes.
-	encodedSize := uint32(4) // stores the number of frames
-	for _, frame := range frames {
-		encodedSize += 4                     // stores the size of the frame
-		encodedSize += GnarkFrameSize(frame) // size of the frame
-	}
+	encodedSize := GnarkEncodedFramesSize(frames)
 
 	serializedBytes := make([]byte, encodedSize)
 	binary.BigEndian.PutUint32(serializedBytes, uint32(len(frames)))
@@ -74,6 +69,54 @@ func GnarkEncodeFrames(frames []*Frame) ([]byte, error) {
 	return serializedBytes, nil
 }
 
+// GnarkEncodedFramesSize returns the size in bytes of a slice of frames serialized by GnarkEncodeFrames.
+func GnarkEncodedFramesSize(frames []*Frame) uint32 {
+	encodedSize := uint32(4) // stores the number of frames
+	for _, frame := range frames {
+		encodedSize += 4                     // stores the size of the frame
+		encodedSize += GnarkFrameSize(frame) // size of the frame
+	}
+	return encodedSize
+}
+
+// GnarkWriteFrames serializes a slice of frames into a writer, in the format of GnarkEncodeFrames. Unlike
+// GnarkEncodeFrames, only one serialized frame is held in memory at a time.
+func GnarkWriteFrames(w io.Writer, frames []*Frame) error {
+	return GnarkWriteFramesFunc(w, len(frames), func(i int) *Frame {
+		return frames[i]
+	})
+}
+
+// GnarkWriteFramesFunc serializes numFrames frames into a writer, in the format of GnarkEncodeFrames, getting each
+// frame from the frame function as it's written. This lets frames be serialized from another representation, e.g.
+// the coefficients of encoded chunks, without first building a slice of all of them.
+func GnarkWriteFramesFunc(w io.Writer, numFrames int, frame func(i int) *Frame) error {
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(numFrames))
+	_, err := w.Write(header)
+	if err != nil {
+		return fmt.Errorf("failed to write frame count: %w", err)
+	}
+
+	var buf []byte
+	for i := 0; i < numFrames; i++ {
+		frame := frame(i)
+		frameSize := 4 + GnarkFrameSize(frame)
+		if uint32(cap(buf)) < frameSize {
+			buf = make([]byte, frameSize)
+		}
+		buf = buf[:frameSize]
+		GnarkEncodeFrame(frame, buf)
+
+		_, err = w.Write(buf)
+		if err != nil {
+			return fmt.Errorf("failed to write frame %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
 // GnarkEncodeFrame serializes a frame into a target byte slice. Returns the number of bytes written.
 func GnarkEncodeFrame(frame *Frame, target []byte) uint32 {
 	binary.BigEndian.PutUint32(target, uint32(len(frame.Coeffs)))
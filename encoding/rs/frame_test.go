@@ -1,6 +1,7 @@
 package rs_test
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -87,3 +88,30 @@ func TestGnarkEncodeDecodeFrames_AreInverses(t *testing.T) {
 		assert.Equal(t, *framesPointers[i], *decodedFrames[i])
 	}
 }
+
+func TestGnarkWriteFrames_MatchesGnarkEncodeFrames(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := encoding.ParamsFromSysPar(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	cfg := encoding.DefaultConfig()
+	enc, err := rs.NewEncoder(cfg)
+	assert.Nil(t, err)
+
+	frames, _, err := enc.EncodeBytes(GETTYSBURG_ADDRESS_BYTES, params)
+	assert.NoError(t, err)
+
+	framesPointers := make([]*rs.Frame, len(frames))
+	for i := range frames {
+		framesPointers[i] = &frames[i]
+	}
+
+	encodedFrames, err := rs.GnarkEncodeFrames(framesPointers)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(len(encodedFrames)), rs.GnarkEncodedFramesSize(framesPointers))
+
+	var buf bytes.Buffer
+	err = rs.GnarkWriteFrames(&buf, framesPointers)
+	assert.NoError(t, err)
+	assert.Equal(t, encodedFrames, buf.Bytes())
+}
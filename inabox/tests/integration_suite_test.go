@@ -213,7 +213,7 @@ func setupRetrievalClient(testConfig *deploy.Config) error {
 	if err != nil {
 		return err
 	}
-	retrievalClientV2 = clientsv2.NewRetrievalClient(logger, chainReader, ics, v, 10)
+	retrievalClientV2 = clientsv2.NewRetrievalClient(logger, chainReader, ics, v, 10, nil)
 
 	return ics.Start(context.Background())
 }
@@ -0,0 +1,344 @@
+package node
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	adminPathPrefix = "/admin/v2/"
+	// defaultAdminBatchListLimit is the number of batches listed when the request doesn't set a limit
+	defaultAdminBatchListLimit = 100
+	// maxAdminRequestSize is the maximum size of the body of the admin requests
+	maxAdminRequestSize = 1 << 20
+)
+
+// AdminServer serves the admin API of the node, which lets operators inspect the content of the v2 store:
+//
+//	GET /admin/v2/batches?limit=N    lists the stored batches with their expiry times (100 by default)
+//	GET /admin/v2/blobs/{blobKey}    lists the stored bundles of a blob, with their number of chunks, size and expiry
+//	GET /admin/v2/storage            reports the number and size of the stored bundles of each quorum, along with
+//	                                 the storage quota of the quorum
+//	GET /admin/v2/snapshot           streams a snapshot of the stores, which can be imported on another machine
+//	GET /admin/v2/config             reports the settings that can be changed without a restart
+//	POST /admin/v2/config            changes the settings of the JSON body without a restart
+//	GET /admin/v2/performance        reports the batches signed and missed, the bytes stored by quorum, the
+//	                                 retrievals served, and the registration and stake of the operator on chain
+//
+// The API only listens on localhost, and requests must carry an "Authorization: Bearer <token>" header with the
+// configured token.
+type AdminServer struct {
+	port      string
+	token     string
+	inspector StoreV2Inspector
+	// snapshotter exports the snapshots of the stores. Snapshots are not served if nil.
+	snapshotter SnapshotExporter
+	// reloader changes the settings of the node. The config is not served if nil.
+	reloader ConfigReloader
+	// performance reports the performance of the node. The performance is not served if nil.
+	performance PerformanceReporter
+	// quotas are the storage quotas reported with the storage usage. No quota is reported if nil.
+	quotas *QuotaTracker
+	logger logging.Logger
+}
+
+type adminBatchResponse struct {
+	BatchHeaderHash      string `json:"batch_header_hash"`
+	BatchRoot            string `json:"batch_root"`
+	ReferenceBlockNumber uint64 `json:"reference_block_number"`
+	ExpiresAt            int64  `json:"expires_at"`
+}
+
+type adminBatchListResponse struct {
+	Batches []adminBatchResponse `json:"batches"`
+}
+
+type adminBundleResponse struct {
+	QuorumID  uint8  `json:"quorum_id"`
+	NumChunks int    `json:"num_chunks"`
+	SizeBytes uint64 `json:"size_bytes"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type adminBlobResponse struct {
+	BlobKey string                `json:"blob_key"`
+	Bundles []adminBundleResponse `json:"bundles"`
+}
+
+type adminQuorumUsageResponse struct {
+	QuorumID   uint8  `json:"quorum_id"`
+	NumBundles uint64 `json:"num_bundles"`
+	SizeBytes  uint64 `json:"size_bytes"`
+	// SoftLimitBytes and HardLimitBytes are the storage quota of the quorum, 0 if unlimited
+	SoftLimitBytes uint64 `json:"soft_limit_bytes"`
+	HardLimitBytes uint64 `json:"hard_limit_bytes"`
+}
+
+type adminStorageResponse struct {
+	Quorums        []adminQuorumUsageResponse `json:"quorums"`
+	TotalSizeBytes uint64                     `json:"total_size_bytes"`
+}
+
+type adminErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewAdminServer(
+	port string,
+	token string,
+	inspector StoreV2Inspector,
+	snapshotter SnapshotExporter,
+	reloader ConfigReloader,
+	performance PerformanceReporter,
+	quotas *QuotaTracker,
+	logger logging.Logger) (*AdminServer, error) {
+
+	if port == "" {
+		return nil, errors.New("admin api port is required")
+	}
+	if token == "" {
+		return nil, errors.New("admin api token is required")
+	}
+	if inspector == nil {
+		return nil, errors.New("store inspector is required")
+	}
+	return &AdminServer{
+		port:        port,
+		token:       token,
+		inspector:   inspector,
+		snapshotter: snapshotter,
+		reloader:    reloader,
+		performance: performance,
+		quotas:      quotas,
+		logger:      logger.With("component", "AdminServer"),
+	}, nil
+}
+
+// Start listens on the admin port of localhost and serves the admin API in the background until the context is
+// cancelled.
+func (s *AdminServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%s", s.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %s: %w", s.port, err)
+	}
+	server := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Error("failed to shut down admin server", "err", err)
+		}
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("admin server stopped", "err", err)
+		}
+	}()
+
+	s.logger.Info("Admin API listening", "address", listener.Addr().String())
+	return nil
+}
+
+func (s *AdminServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeAdminError(w, http.StatusUnauthorized, "missing or invalid admin token")
+		return
+	}
+	path, ok := strings.CutPrefix(r.URL.Path, adminPathPrefix)
+	if !ok {
+		writeAdminError(w, http.StatusNotFound, "not found")
+		return
+	}
+	resource, id, _ := strings.Cut(path, "/")
+	if resource == "config" && id == "" && s.reloader != nil && r.Method == http.MethodPost {
+		s.reloadConfig(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed on %s", r.Method, r.URL.Path))
+		return
+	}
+
+	switch {
+	case resource == "batches" && id == "":
+		s.listBatches(w, r)
+	case resource == "blobs" && id != "":
+		s.getBlob(w, id)
+	case resource == "storage" && id == "":
+		s.getStorage(w)
+	case resource == "snapshot" && id == "" && s.snapshotter != nil:
+		s.getSnapshot(w)
+	case resource == "config" && id == "" && s.reloader != nil:
+		writeAdminJSON(w, http.StatusOK, s.reloader.GetReloadableConfig())
+	case resource == "performance" && id == "" && s.performance != nil:
+		writeAdminJSON(w, http.StatusOK, s.performance.GetPerformanceReport(r.Context()))
+	default:
+		writeAdminError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *AdminServer) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func (s *AdminServer) listBatches(w http.ResponseWriter, r *http.Request) {
+	limit := defaultAdminBatchListLimit
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			writeAdminError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit %q", limitParam))
+			return
+		}
+	}
+
+	batches, err := s.inspector.ListBatches(limit)
+	if err != nil {
+		s.writeInternalError(w, "failed to list batches", err)
+		return
+	}
+	response := adminBatchListResponse{
+		Batches: make([]adminBatchResponse, len(batches)),
+	}
+	for i, batch := range batches {
+		response.Batches[i] = adminBatchResponse{
+			BatchHeaderHash:      hex.EncodeToString(batch.BatchHeaderHash[:]),
+			BatchRoot:            hex.EncodeToString(batch.BatchHeader.BatchRoot[:]),
+			ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber,
+			ExpiresAt:            unixOrZero(batch.ExpiresAt),
+		}
+	}
+	writeAdminJSON(w, http.StatusOK, response)
+}
+
+func (s *AdminServer) getBlob(w http.ResponseWriter, blobKeyHex string) {
+	blobKey, err := corev2.HexToBlobKey(blobKeyHex)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Sprintf("invalid blob key %q: %v", blobKeyHex, err))
+		return
+	}
+
+	bundles, err := s.inspector.GetBundles(blobKey)
+	if err != nil {
+		s.writeInternalError(w, "failed to get bundles", err)
+		return
+	}
+	if len(bundles) == 0 {
+		writeAdminError(w, http.StatusNotFound, fmt.Sprintf("no chunks stored for blob %s", blobKey.Hex()))
+		return
+	}
+	response := adminBlobResponse{
+		BlobKey: blobKey.Hex(),
+		Bundles: make([]adminBundleResponse, len(bundles)),
+	}
+	for i, bundle := range bundles {
+		response.Bundles[i] = adminBundleResponse{
+			QuorumID:  bundle.QuorumID,
+			NumChunks: bundle.NumChunks,
+			SizeBytes: bundle.SizeBytes,
+			ExpiresAt: unixOrZero(bundle.ExpiresAt),
+		}
+	}
+	writeAdminJSON(w, http.StatusOK, response)
+}
+
+func (s *AdminServer) getStorage(w http.ResponseWriter) {
+	usage, err := s.inspector.GetStorageUsage()
+	if err != nil {
+		s.writeInternalError(w, "failed to get storage usage", err)
+		return
+	}
+	response := adminStorageResponse{
+		Quorums: make([]adminQuorumUsageResponse, 0, len(usage)),
+	}
+	for quorum, quorumUsage := range usage {
+		quota := s.quotas.Quota(quorum)
+		response.Quorums = append(response.Quorums, adminQuorumUsageResponse{
+			QuorumID:       quorum,
+			NumBundles:     quorumUsage.NumBundles,
+			SizeBytes:      quorumUsage.SizeBytes,
+			SoftLimitBytes: quota.SoftLimitBytes,
+			HardLimitBytes: quota.HardLimitBytes,
+		})
+		response.TotalSizeBytes += quorumUsage.SizeBytes
+	}
+	sort.Slice(response.Quorums, func(i, j int) bool {
+		return response.Quorums[i].QuorumID < response.Quorums[j].QuorumID
+	})
+	writeAdminJSON(w, http.StatusOK, response)
+}
+
+func (s *AdminServer) getSnapshot(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"node-snapshot-%d.bin\"", time.Now().Unix()))
+	w.WriteHeader(http.StatusOK)
+
+	start := time.Now()
+	// The status has been sent once the snapshot starts streaming, so a failure can only be reported by cutting the
+	// snapshot short. Truncated snapshots are rejected by the import.
+	if err := s.snapshotter.ExportSnapshot(w); err != nil {
+		s.logger.Error("failed to export snapshot", "err", err)
+		return
+	}
+	s.logger.Info("Exported snapshot", "duration", time.Since(start))
+}
+
+func (s *AdminServer) reloadConfig(w http.ResponseWriter, r *http.Request) {
+	config := &ReloadableConfig{}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminRequestSize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Sprintf("invalid config: %v", err))
+		return
+	}
+
+	if err := s.reloader.Reload(r.Context(), config); err != nil {
+		s.logger.Warn("failed to reload config", "err", err)
+		writeAdminError(w, http.StatusBadRequest, fmt.Sprintf("failed to reload config: %v", err))
+		return
+	}
+	s.logger.Info("Reloaded the node config")
+	writeAdminJSON(w, http.StatusOK, s.reloader.GetReloadableConfig())
+}
+
+func (s *AdminServer) writeInternalError(w http.ResponseWriter, msg string, err error) {
+	s.logger.Error(msg, "err", err)
+	writeAdminError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", msg, err))
+}
+
+// unixOrZero returns the unix timestamp of the time, or 0 for the zero time
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func writeAdminJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeAdminError(w http.ResponseWriter, status int, msg string) {
+	writeAdminJSON(w, status, adminErrorResponse{Error: msg})
+}
@@ -0,0 +1,240 @@
+package node_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/limiter"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminServer(t *testing.T) {
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+
+	s, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	_, _, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	inspector, ok := s.(node.StoreV2Inspector)
+	require.True(t, ok)
+	rateLimiter, err := limiter.NewRetrievalRateLimiter(&limiter.Config{}, nil)
+	require.NoError(t, err)
+	tx := &coremock.MockWriter{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(0), errors.New("chain unavailable"))
+	n := &node.Node{
+		Config: &node.Config{
+			QuorumIDList: []core.QuorumID{0, 1},
+			LogLevel:     new(slog.LevelVar),
+		},
+		StoreV2:              s,
+		Logger:               logging.NewNoopLogger(),
+		RetrievalRateLimiter: rateLimiter,
+		Performance:          node.NewPerformanceTracker(),
+		Transactor:           tx,
+	}
+	_, err = node.NewAdminServer("9999", "", inspector, n, n, n, nil, logging.NewNoopLogger())
+	require.Error(t, err)
+	quotas := node.NewQuotaTracker(map[core.QuorumID]node.StorageQuota{
+		1: {SoftLimitBytes: 1000, HardLimitBytes: 2000},
+	}, logging.NewNoopLogger(), nil)
+	server, err := node.NewAdminServer("9999", "secret", inspector, n, n, n, quotas, logging.NewNoopLogger())
+	require.NoError(t, err)
+
+	get := func(path string, token string, response any) int {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		if response != nil {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(response))
+		}
+		return w.Code
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, get("/admin/v2/storage", "", nil))
+		require.Equal(t, http.StatusUnauthorized, get("/admin/v2/storage", "wrong", nil))
+	})
+
+	t.Run("list batches", func(t *testing.T) {
+		var response struct {
+			Batches []struct {
+				BatchHeaderHash      string `json:"batch_header_hash"`
+				ReferenceBlockNumber uint64 `json:"reference_block_number"`
+				ExpiresAt            int64  `json:"expires_at"`
+			} `json:"batches"`
+		}
+		require.Equal(t, http.StatusOK, get("/admin/v2/batches", "secret", &response))
+		require.Len(t, response.Batches, 1)
+		batchHeaderHash, err := batch.BatchHeader.Hash()
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.Batches[0].BatchHeaderHash)
+		require.Equal(t, batch.BatchHeader.ReferenceBlockNumber, response.Batches[0].ReferenceBlockNumber)
+		// the test store keeps the data for 10 seconds
+		require.InDelta(t, time.Now().Add(10*time.Second).Unix(), response.Batches[0].ExpiresAt, 5)
+
+		require.Equal(t, http.StatusBadRequest, get("/admin/v2/batches?limit=x", "secret", nil))
+	})
+
+	t.Run("get blob", func(t *testing.T) {
+		var response struct {
+			BlobKey string `json:"blob_key"`
+			Bundles []struct {
+				QuorumID  uint8 `json:"quorum_id"`
+				NumChunks int   `json:"num_chunks"`
+				SizeBytes int   `json:"size_bytes"`
+				ExpiresAt int64 `json:"expires_at"`
+			} `json:"bundles"`
+		}
+		require.Equal(t, http.StatusOK, get("/admin/v2/blobs/"+blobKeys[2].Hex(), "secret", &response))
+		require.Equal(t, blobKeys[2].Hex(), response.BlobKey)
+		require.Len(t, response.Bundles, 2)
+		require.Equal(t, uint8(1), response.Bundles[0].QuorumID)
+		require.Equal(t, len(bundles[2][1]), response.Bundles[0].NumChunks)
+		require.Equal(t, len(rawBundles[2].Bundles[1]), response.Bundles[0].SizeBytes)
+		require.Equal(t, uint8(2), response.Bundles[1].QuorumID)
+		require.Equal(t, len(bundles[2][2]), response.Bundles[1].NumChunks)
+		require.NotZero(t, response.Bundles[1].ExpiresAt)
+
+		require.Equal(t, http.StatusNotFound, get("/admin/v2/blobs/"+corev2.BlobKey{}.Hex(), "secret", nil))
+		require.Equal(t, http.StatusBadRequest, get("/admin/v2/blobs/xyz", "secret", nil))
+	})
+
+	t.Run("storage usage", func(t *testing.T) {
+		var response struct {
+			Quorums []struct {
+				QuorumID       uint8  `json:"quorum_id"`
+				NumBundles     uint64 `json:"num_bundles"`
+				SizeBytes      uint64 `json:"size_bytes"`
+				SoftLimitBytes uint64 `json:"soft_limit_bytes"`
+				HardLimitBytes uint64 `json:"hard_limit_bytes"`
+			} `json:"quorums"`
+			TotalSizeBytes uint64 `json:"total_size_bytes"`
+		}
+		require.Equal(t, http.StatusOK, get("/admin/v2/storage", "secret", &response))
+
+		expected := make(map[uint8][2]uint64)
+		var total uint64
+		for _, blobBundles := range rawBundles {
+			for quorum, bundle := range blobBundles.Bundles {
+				usage := expected[quorum]
+				expected[quorum] = [2]uint64{usage[0] + 1, usage[1] + uint64(len(bundle))}
+				total += uint64(len(bundle))
+			}
+		}
+		require.Len(t, response.Quorums, len(expected))
+		for _, quorum := range response.Quorums {
+			require.Equal(t, expected[quorum.QuorumID], [2]uint64{quorum.NumBundles, quorum.SizeBytes})
+			if quorum.QuorumID == 1 {
+				require.Equal(t, [2]uint64{1000, 2000}, [2]uint64{quorum.SoftLimitBytes, quorum.HardLimitBytes})
+			} else {
+				require.Zero(t, quorum.HardLimitBytes)
+			}
+		}
+		require.Equal(t, total, response.TotalSizeBytes)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/admin/v2/snapshot", nil)
+		r.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// The snapshot can be imported into the stores of a new node
+		dbPath := t.TempDir()
+		err := node.ImportSnapshot(logging.NewNoopLogger(), w.Body, dbPath, node.PebbleDBBackend, 2)
+		require.NoError(t, err)
+		config := tablestore.DefaultPebbleDBConfig(node.StoreV2Path(dbPath, node.PebbleDBBackend))
+		config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName}
+		imported, err := tablestore.Start(logging.NewNoopLogger(), config)
+		require.NoError(t, err)
+		defer func() {
+			_ = imported.Shutdown()
+		}()
+		chunks, err := node.NewLevelDBStoreV2(imported, logging.NewNoopLogger(), time.Hour).GetChunks(blobKeys[0], 0)
+		require.NoError(t, err)
+		require.Len(t, chunks, len(bundles[0][0]))
+	})
+
+	t.Run("config", func(t *testing.T) {
+		var response node.ReloadableConfig
+		require.Equal(t, http.StatusOK, get("/admin/v2/config", "secret", &response))
+		require.Equal(t, "info", *response.LogLevel)
+		require.Equal(t, []uint32{0, 1}, response.QuorumIDs)
+
+		post := func(body string, response any) int {
+			r := httptest.NewRequest(http.MethodPost, "/admin/v2/config", strings.NewReader(body))
+			r.Header.Set("Authorization", "Bearer secret")
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, r)
+			require.NoError(t, json.NewDecoder(w.Body).Decode(response))
+			return w.Code
+		}
+		body := `{"log_level": "debug", "retrieval_rate_limits": {"max_requests_per_second_client": 10}}`
+		require.Equal(t, http.StatusOK, post(body, &response))
+		require.Equal(t, "debug", *response.LogLevel)
+		require.Equal(t, 10.0, response.RetrievalRateLimits.MaxRequestsPerSecondClient)
+		require.True(t, rateLimiter.Enabled())
+
+		var errorResponse struct {
+			Error string `json:"error"`
+		}
+		require.Equal(t, http.StatusBadRequest, post(`{"log_level": "verbose"}`, &errorResponse))
+		require.NotEmpty(t, errorResponse.Error)
+		require.Equal(t, http.StatusBadRequest, post(`{"unknown": 1}`, &errorResponse))
+		require.Equal(t, http.StatusOK, get("/admin/v2/config", "secret", &response))
+		require.Equal(t, "debug", *response.LogLevel)
+	})
+
+	t.Run("performance", func(t *testing.T) {
+		n.Performance.RecordBatch("v2", true, map[core.QuorumID]uint64{0: 100})
+		n.Performance.RecordRetrieval("v2", true, 50)
+
+		var response node.PerformanceReport
+		require.Equal(t, http.StatusOK, get("/admin/v2/performance", "secret", &response))
+		require.Equal(t, uint64(1), response.V2.BatchesSigned)
+		require.Equal(t, uint64(100), response.V2.BytesStoredByQuorum[0])
+		require.Equal(t, uint64(50), response.V2.BytesServed)
+		require.Zero(t, response.V1.BatchesSigned)
+		// The counters are reported even if the chain can't be read
+		require.Nil(t, response.Chain)
+		require.Contains(t, response.ChainError, "chain unavailable")
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, get("/admin/v2/chunks", "secret", nil))
+		require.Equal(t, http.StatusNotFound, get("/other", "secret", nil))
+	})
+}
@@ -94,6 +94,13 @@ func NodeMain(ctx *cli.Context) error {
 		return fmt.Errorf("failed to create server v2: %v", err)
 	}
 	err = nodegrpc.RunServers(server, serverV2, config, logger)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// The self-check dials back the node, so it runs once RunServers has bound the listeners of the servers.
+	if config.StartupSelfCheck {
+		return node.SelfCheck(context.Background())
+	}
+	return nil
 }
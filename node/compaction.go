@@ -0,0 +1,110 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+)
+
+const (
+	storeV1Label = "v1"
+	storeV2Label = "v2"
+)
+
+// StoreCompactor is implemented by the stores of the node whose database can be compacted on demand, to reclaim the
+// disk space of the data that has been deleted from it.
+type StoreCompactor interface {
+	// Compact compacts the database of the store, and returns the size of the database on disk before and after
+	// the compaction.
+	Compact() (sizeBefore uint64, sizeAfter uint64, err error)
+}
+
+var _ StoreCompactor = &Store{}
+var _ StoreCompactor = &storeV2{}
+
+// Compact compacts the database of the store, and returns the size of the database on disk before and after
+// the compaction.
+func (s *Store) Compact() (uint64, uint64, error) {
+	return compact(s.db)
+}
+
+// Compact compacts the database of the store, and returns the size of the database on disk before and after
+// the compaction.
+func (s *storeV2) Compact() (uint64, uint64, error) {
+	return compact(s.db)
+}
+
+// compact compacts the given database if it supports compaction.
+func compact(db any) (uint64, uint64, error) {
+	compactor, ok := db.(kvstore.Compactor)
+	if !ok {
+		return 0, 0, errors.New("database does not support compaction")
+	}
+
+	sizeBefore, err := compactor.DiskSize()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+	err = compactor.Compact()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compact database: %w", err)
+	}
+	sizeAfter, err := compactor.DiskSize()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+	return sizeBefore, sizeAfter, nil
+}
+
+// compactionLoop periodically compacts the databases of the node. The expired data is deleted by the expiration
+// loops, but LevelDB only reclaims the disk space of deleted data when it compacts the tables holding it, which it
+// may not do for a long time once the writes settle. The expired keys are spread over the whole key space, so the
+// whole database is compacted, which is why the loop only runs if a compaction interval is configured.
+func (n *Node) compactionLoop(ctx context.Context) {
+	n.Logger.Info("Start compactionLoop goroutine in background to periodically compact the databases of the node", "interval", n.Config.CompactionInterval)
+	ticker := time.NewTicker(n.Config.CompactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.compactStores()
+		}
+	}
+}
+
+// compactStores compacts the databases of the node one after the other, and reports the reclaimed disk space.
+func (n *Node) compactStores() {
+	if n.Store != nil {
+		n.compactStore(storeV1Label, n.Store)
+	}
+	if compactor, ok := n.StoreV2.(StoreCompactor); ok {
+		n.compactStore(storeV2Label, compactor)
+	}
+}
+
+func (n *Node) compactStore(label string, compactor StoreCompactor) {
+	start := time.Now()
+	sizeBefore, sizeAfter, err := compactor.Compact()
+	if err != nil {
+		n.Logger.Error("Failed to compact database, which will be retried in next cycle", "store", label, "err", err)
+		if n.Metrics != nil {
+			n.Metrics.RecordCompactionFailure(label)
+		}
+		return
+	}
+
+	var reclaimed uint64
+	if sizeBefore > sizeAfter {
+		reclaimed = sizeBefore - sizeAfter
+	}
+	n.Logger.Info("Compacted database", "store", label, "sizeBefore", sizeBefore, "sizeAfter", sizeAfter, "reclaimedBytes", reclaimed, "duration", time.Since(start))
+	if n.Metrics != nil {
+		n.Metrics.RecordCompaction(label, sizeBefore, sizeAfter, time.Since(start))
+	}
+}
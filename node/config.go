@@ -3,8 +3,11 @@ package node
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -12,8 +15,10 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/node/flags"
+	"github.com/Layr-Labs/eigenda/node/limiter"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 
@@ -62,6 +67,7 @@ type Config struct {
 	OverrideStoreDurationBlocks    int64
 	QuorumIDList                   []core.QuorumID
 	DbPath                         string
+	DbBackend                      string
 	LogPath                        string
 	PrivateBls                     string
 	ID                             core.OperatorID
@@ -84,6 +90,8 @@ type Config struct {
 	BLSPublicKeyHex          string
 	BLSKeyPassword           string
 	BLSSignerTLSCertFilePath string
+	// BLSSignerHealthCheckInterval is the interval at which the remote signer is checked, 0 to only check it at startup
+	BLSSignerHealthCheckInterval time.Duration
 
 	EthClientConfig geth.EthClientConfig
 	LoggerConfig    common.LoggerConfig
@@ -92,9 +100,65 @@ type Config struct {
 	EnableV2                    bool
 	OnchainStateRefreshInterval time.Duration
 	ChunkDownloadTimeout        time.Duration
+	CompactionInterval          time.Duration
+	QuorumProfiles              core.BlobVersionQuorumProfiles
 
 	PprofHttpPort string
 	EnablePprof   bool
+
+	AdminApiPort  string
+	AdminApiToken string
+
+	RetrievalRateLimits limiter.Config
+
+	// LogLevel is the level of the node logger, which can be changed while the node runs
+	LogLevel *slog.LevelVar
+	// ReloadConfigFile is the file of the settings applied when the node receives SIGHUP
+	ReloadConfigFile string
+
+	// The ports of the dedicated v2 servers. Empty ports mean the v2 traffic is served with the v1 traffic.
+	V2DispersalPort         string
+	V2RetrievalPort         string
+	InternalV2DispersalPort string
+	InternalV2RetrievalPort string
+	// V2NumBatchValidators is the number of parallel workers used to validate a v2 batch
+	V2NumBatchValidators int
+	// V1RequestBudget and V2RequestBudget bound the requests of each version handled concurrently, so that a surge
+	// of the traffic of one version can't starve the other
+	V1RequestBudget RequestBudget
+	V2RequestBudget RequestBudget
+
+	// ChunkStorePaths are the mount points across which the v2 bundles are sharded. If empty, the bundles are stored
+	// in the DbPath with the batch headers.
+	ChunkStorePaths               []string
+	ChunkStoreHealthCheckInterval time.Duration
+
+	// ChunkScrubberRate is the number of stored v2 blobs re-verified per second by the chunk scrubber, 0 to disable it
+	ChunkScrubberRate float64
+	// ChunkScrubberRefetch is whether the corrupted bundles found by the scrubber are fetched again from the relays
+	ChunkScrubberRefetch bool
+
+	// StorageQuotas are the disk quotas of the v2 bundles of each quorum
+	StorageQuotas map[core.QuorumID]StorageQuota
+	// StorageUsageRefreshInterval is the interval at which the usage of the quotas is synced with the store
+	StorageUsageRefreshInterval time.Duration
+
+	// StartupSelfCheck is whether the node checks its registration, reachability and clock at startup
+	StartupSelfCheck bool
+	// NTPServer is the server the clock of the node is compared with by the self-check, empty to skip the check
+	NTPServer string
+	// MaxClockSkew is the maximum offset of the clock of the node from the NTP server
+	MaxClockSkew time.Duration
+}
+
+// RequestBudget bounds the gRPC requests of a protocol version handled concurrently by the node. Requests exceeding
+// the budget are rejected. Zero values disable the corresponding limit.
+type RequestBudget struct {
+	// MaxConcurrentRequests is the maximum number of requests handled concurrently
+	MaxConcurrentRequests int
+	// MaxInflightRequestBytes is the maximum total size of the requests handled concurrently. A request larger than
+	// the budget is only handled when no other request uses the budget.
+	MaxInflightRequestBytes int
 }
 
 // NewConfig parses the Config from the provided flags or environment variables and
@@ -118,6 +182,52 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		return nil, errors.New("no quorum ids provided")
 	}
 
+	dbBackend := ctx.GlobalString(flags.DbBackendFlag.Name)
+	if err := ValidateDBBackend(dbBackend); err != nil {
+		return nil, err
+	}
+
+	chunkStorePaths := make([]string, 0)
+	for _, path := range strings.Split(ctx.GlobalString(flags.ChunkStorePathsFlag.Name), ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if slices.Contains(chunkStorePaths, filepath.Clean(path)) {
+			return nil, fmt.Errorf("duplicate chunk store path %s", path)
+		}
+		chunkStorePaths = append(chunkStorePaths, filepath.Clean(path))
+	}
+	chunkStoreHealthCheckInterval := ctx.GlobalDuration(flags.ChunkStoreHealthCheckIntervalFlag.Name)
+	if len(chunkStorePaths) > 0 && chunkStoreHealthCheckInterval <= 0 {
+		return nil, errors.New("the chunk-store-health-check-interval flag must be positive")
+	}
+
+	chunkScrubberRate := ctx.GlobalFloat64(flags.ChunkScrubberRateFlag.Name)
+	if chunkScrubberRate < 0 {
+		return nil, errors.New("the chunk-scrubber-rate flag must not be negative")
+	}
+
+	storageQuotas, err := ParseStorageQuotas(ctx.GlobalString(flags.StorageQuotasFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	storageUsageRefreshInterval := ctx.GlobalDuration(flags.StorageUsageRefreshIntervalFlag.Name)
+	if len(storageQuotas) > 0 && storageUsageRefreshInterval <= 0 {
+		return nil, errors.New("the storage-usage-refresh-interval flag must be positive")
+	}
+
+	maxClockSkew := ctx.GlobalDuration(flags.MaxClockSkewFlag.Name)
+	if maxClockSkew <= 0 {
+		return nil, errors.New("the max-clock-skew flag must be positive")
+	}
+
+	adminApiPort := ctx.GlobalString(flags.AdminApiPortFlag.Name)
+	adminApiToken := ctx.GlobalString(flags.AdminApiTokenFlag.Name)
+	if adminApiPort != "" && adminApiToken == "" {
+		return nil, errors.New("the admin-api-token flag is required when the admin API is enabled")
+	}
+
 	expirationPollIntervalSec := ctx.GlobalUint64(flags.ExpirationPollIntervalSecFlag.Name)
 	if expirationPollIntervalSec < minExpirationPollIntervalSec {
 		return nil, fmt.Errorf("the expiration-poll-interval flag must be >= %d seconds", minExpirationPollIntervalSec)
@@ -193,10 +303,68 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		internalRetrievalFlag = ctx.GlobalString(flags.RetrievalPortFlag.Name)
 	}
 
+	v2DispersalPort := ctx.GlobalString(flags.V2DispersalPortFlag.Name)
+	v2RetrievalPort := ctx.GlobalString(flags.V2RetrievalPortFlag.Name)
+	internalV2DispersalPort := ctx.GlobalString(flags.InternalV2DispersalPortFlag.Name)
+	internalV2RetrievalPort := ctx.GlobalString(flags.InternalV2RetrievalPortFlag.Name)
+	if internalV2DispersalPort == "" {
+		internalV2DispersalPort = v2DispersalPort
+	} else if v2DispersalPort == "" {
+		return nil, errors.New("the internal v2 dispersal port requires the v2 dispersal port")
+	}
+	if v2RetrievalPort != "" && !ctx.GlobalBool(flags.V2RetrievalPortClientsUpgradedFlag.Name) {
+		return nil, fmt.Errorf("the v2 retrieval port is registered in the socket of the operator, which clients "+
+			"that predate it can't parse: upgrade the dispersers, retrievers and data APIs, then set --%s",
+			flags.V2RetrievalPortClientsUpgradedFlag.Name)
+	}
+	if internalV2RetrievalPort == "" {
+		internalV2RetrievalPort = v2RetrievalPort
+	} else if v2RetrievalPort == "" {
+		return nil, errors.New("the internal v2 retrieval port requires the v2 retrieval port")
+	}
+	if internalV2DispersalPort != "" &&
+		(internalV2DispersalPort == internalDispersalFlag || internalV2DispersalPort == internalRetrievalFlag) {
+		return nil, fmt.Errorf("the v2 dispersal port %s must differ from the v1 ports", internalV2DispersalPort)
+	}
+	if internalV2RetrievalPort != "" &&
+		(internalV2RetrievalPort == internalDispersalFlag || internalV2RetrievalPort == internalRetrievalFlag ||
+			internalV2RetrievalPort == internalV2DispersalPort) {
+		return nil, fmt.Errorf("the v2 retrieval port %s must differ from the other ports", internalV2RetrievalPort)
+	}
+
+	numBatchValidators := ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name)
+	v2NumBatchValidators := ctx.GlobalInt(flags.V2NumBatchValidatorsFlag.Name)
+	if v2NumBatchValidators <= 0 {
+		v2NumBatchValidators = numBatchValidators
+	}
+
+	v1RequestBudget := RequestBudget{
+		MaxConcurrentRequests:   ctx.GlobalInt(flags.V1MaxConcurrentRequestsFlag.Name),
+		MaxInflightRequestBytes: ctx.GlobalInt(flags.V1MaxInflightRequestBytesFlag.Name),
+	}
+	v2RequestBudget := RequestBudget{
+		MaxConcurrentRequests:   ctx.GlobalInt(flags.V2MaxConcurrentRequestsFlag.Name),
+		MaxInflightRequestBytes: ctx.GlobalInt(flags.V2MaxInflightRequestBytesFlag.Name),
+	}
+	for _, budget := range []RequestBudget{v1RequestBudget, v2RequestBudget} {
+		if budget.MaxConcurrentRequests < 0 || budget.MaxInflightRequestBytes < 0 {
+			return nil, errors.New("request budgets must not be negative")
+		}
+	}
+
 	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
 	if err != nil {
 		return nil, err
 	}
+	// The logger reads its level from a variable, so that the level can be reloaded without a restart
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(loggerConfig.HandlerOpts.Level.Level())
+	loggerConfig.HandlerOpts.Level = logLevel
+
+	quorumProfiles, err := coreeth.ReadQuorumProfiles(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Config{
 		Hostname:                       ctx.GlobalString(flags.HostnameFlag.Name),
@@ -218,6 +386,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		OverrideStoreDurationBlocks:    ctx.GlobalInt64(flags.OverrideStoreDurationBlocksFlag.Name),
 		QuorumIDList:                   ids,
 		DbPath:                         ctx.GlobalString(flags.DbPathFlag.Name),
+		DbBackend:                      dbBackend,
 		PrivateBls:                     privateBls,
 		EthClientConfig:                ethClientConfig,
 		EncoderConfig:                  kzg.ReadCLIConfig(ctx),
@@ -228,7 +397,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		PubIPCheckInterval:             pubIPCheckInterval,
 		ChurnerUrl:                     ctx.GlobalString(flags.ChurnerUrlFlag.Name),
 		DataApiUrl:                     ctx.GlobalString(flags.DataApiUrlFlag.Name),
-		NumBatchValidators:             ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name),
+		NumBatchValidators:             numBatchValidators,
 		NumBatchDeserializationWorkers: ctx.GlobalInt(flags.NumBatchDeserializationWorkersFlag.Name),
 		EnableGnarkBundleEncoding:      ctx.Bool(flags.EnableGnarkBundleEncodingFlag.Name),
 		ClientIPHeader:                 ctx.GlobalString(flags.ClientIPHeaderFlag.Name),
@@ -239,10 +408,39 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		BLSKeyPassword:                 ctx.GlobalString(flags.BlsKeyPasswordFlag.Name),
 		BLSSignerTLSCertFilePath:       ctx.GlobalString(flags.BLSSignerCertFileFlag.Name),
 		BLSRemoteSignerEnabled:         blsRemoteSignerEnabled,
+		BLSSignerHealthCheckInterval:   ctx.GlobalDuration(flags.BLSRemoteSignerHealthCheckIntervalFlag.Name),
 		EnableV2:                       ctx.GlobalBool(flags.EnableV2Flag.Name),
 		OnchainStateRefreshInterval:    ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
 		ChunkDownloadTimeout:           ctx.GlobalDuration(flags.ChunkDownloadTimeoutFlag.Name),
+		CompactionInterval:             ctx.GlobalDuration(flags.CompactionIntervalFlag.Name),
+		QuorumProfiles:                 quorumProfiles,
 		PprofHttpPort:                  ctx.GlobalString(flags.PprofHttpPort.Name),
 		EnablePprof:                    ctx.GlobalBool(flags.EnablePprof.Name),
+		AdminApiPort:                   adminApiPort,
+		AdminApiToken:                  adminApiToken,
+		LogLevel:                       logLevel,
+		ReloadConfigFile:               ctx.GlobalString(flags.ReloadConfigFileFlag.Name),
+		V2DispersalPort:                v2DispersalPort,
+		V2RetrievalPort:                v2RetrievalPort,
+		InternalV2DispersalPort:        internalV2DispersalPort,
+		InternalV2RetrievalPort:        internalV2RetrievalPort,
+		V2NumBatchValidators:           v2NumBatchValidators,
+		V1RequestBudget:                v1RequestBudget,
+		V2RequestBudget:                v2RequestBudget,
+		ChunkStorePaths:                chunkStorePaths,
+		ChunkStoreHealthCheckInterval:  chunkStoreHealthCheckInterval,
+		ChunkScrubberRate:              chunkScrubberRate,
+		ChunkScrubberRefetch:           ctx.GlobalBool(flags.ChunkScrubberRefetchFlag.Name),
+		StorageQuotas:                  storageQuotas,
+		StorageUsageRefreshInterval:    storageUsageRefreshInterval,
+		StartupSelfCheck:               ctx.GlobalBoolT(flags.StartupSelfCheckFlag.Name),
+		NTPServer:                      ctx.GlobalString(flags.NTPServerFlag.Name),
+		MaxClockSkew:                   maxClockSkew,
+		RetrievalRateLimits: limiter.Config{
+			MaxRequestsPerSecondClient: ctx.GlobalFloat64(flags.RetrievalRequestsPerSecondClientFlag.Name),
+			RequestBurstinessClient:    ctx.GlobalInt(flags.RetrievalRequestBurstinessClientFlag.Name),
+			MaxBytesPerSecondClient:    ctx.GlobalFloat64(flags.RetrievalBytesPerSecondClientFlag.Name),
+			BytesBurstinessClient:      ctx.GlobalInt(flags.RetrievalBytesBurstinessClientFlag.Name),
+		},
 	}, nil
 }
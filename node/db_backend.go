@@ -0,0 +1,126 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// The database backends of the node's stores.
+const (
+	LevelDBBackend  = "leveldb"
+	PebbleDBBackend = "pebble"
+)
+
+// The directories of the stores under the DB path of the node. The stores of different backends are kept in
+// different directories, so that a node switching backend never opens the data of the other backend.
+const (
+	storeDir          = "chunk"
+	storeV2Dir        = "chunk_v2"
+	pebbleStoreSuffix = "_pebble"
+)
+
+// ValidateDBBackend returns an error if the backend is not a supported database backend.
+func ValidateDBBackend(backend string) error {
+	switch backend {
+	case LevelDBBackend, PebbleDBBackend:
+		return nil
+	default:
+		return fmt.Errorf("unsupported db backend %q, must be %q or %q", backend, LevelDBBackend, PebbleDBBackend)
+	}
+}
+
+// StorePath returns the path of the v1 store of the node for the given backend.
+func StorePath(dbPath string, backend string) string {
+	if backend == PebbleDBBackend {
+		return dbPath + "/" + storeDir + pebbleStoreSuffix
+	}
+	return dbPath + "/" + storeDir
+}
+
+// StoreV2Path returns the path of the v2 store of the node for the given backend.
+func StoreV2Path(dbPath string, backend string) string {
+	if backend == PebbleDBBackend {
+		return dbPath + "/" + storeV2Dir + pebbleStoreSuffix
+	}
+	return dbPath + "/" + storeV2Dir
+}
+
+// newBaseStore opens the database of the given backend at the path.
+func newBaseStore(backend string, logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+	switch backend {
+	case LevelDBBackend:
+		return leveldb.NewStore(logger, path)
+	case PebbleDBBackend:
+		return pebble.NewStore(logger, path)
+	default:
+		return nil, ValidateDBBackend(backend)
+	}
+}
+
+// tableStoreType returns the type of table store of the given backend.
+func tableStoreType(backend string) tablestore.StoreType {
+	if backend == PebbleDBBackend {
+		return tablestore.PebbleDB
+	}
+	return tablestore.LevelDB
+}
+
+// MigrateToPebble copies the LevelDB stores of the node at the DB path into new Pebble stores, which are used once
+// the node is restarted with the Pebble backend. The LevelDB stores are left untouched, and can be deleted once the
+// node runs with the Pebble stores. The node must not be running during the migration.
+func MigrateToPebble(logger logging.Logger, dbPath string, batchSize uint32) error {
+	for _, paths := range [][2]string{
+		{StorePath(dbPath, LevelDBBackend), StorePath(dbPath, PebbleDBBackend)},
+		{StoreV2Path(dbPath, LevelDBBackend), StoreV2Path(dbPath, PebbleDBBackend)},
+	} {
+		err := migrateStore(logger, paths[0], paths[1], batchSize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateStore copies the LevelDB store at the source path into a new Pebble store at the destination path.
+func migrateStore(logger logging.Logger, sourcePath string, destinationPath string, batchSize uint32) error {
+	if _, err := os.Stat(sourcePath); errors.Is(err, os.ErrNotExist) {
+		logger.Info("No LevelDB store to migrate", "path", sourcePath)
+		return nil
+	}
+	if _, err := os.Stat(destinationPath); err == nil {
+		return fmt.Errorf("pebble store already exists at %s", destinationPath)
+	}
+
+	source, err := leveldb.NewStore(logger, sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to open LevelDB store at %s: %w", sourcePath, err)
+	}
+	defer func() {
+		_ = source.Shutdown()
+	}()
+	destination, err := pebble.NewStore(logger, destinationPath)
+	if err != nil {
+		return fmt.Errorf("failed to create Pebble store at %s: %w", destinationPath, err)
+	}
+
+	logger.Info("Migrating LevelDB store to Pebble", "source", sourcePath, "destination", destinationPath)
+	count, err := kvstore.CopyStore(source, destination, batchSize)
+	if err != nil {
+		// Don't leave a partial copy behind, it would be picked up by the node.
+		_ = destination.Destroy()
+		return fmt.Errorf("failed to migrate LevelDB store at %s: %w", sourcePath, err)
+	}
+	err = destination.Shutdown()
+	if err != nil {
+		return fmt.Errorf("failed to close Pebble store at %s: %w", destinationPath, err)
+	}
+	logger.Info("Migrated LevelDB store to Pebble", "source", sourcePath, "destination", destinationPath, "numKeys", count)
+	return nil
+}
@@ -0,0 +1,84 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateToPebble(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewNoopLogger()
+	dbPath := t.TempDir()
+	m := node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), logger, ":9090", [32]byte{}, -1, &coremock.MockWriter{}, nil)
+
+	// Fill the LevelDB stores
+	s, err := node.NewStore(node.LevelDBBackend, node.StorePath(dbPath, node.LevelDBBackend), logger, m, staleMeasure, storeDuration)
+	require.NoError(t, err)
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	_, err = s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	require.NoError(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	require.NoError(t, err)
+
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+	schema := []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName}
+	config := tablestore.DefaultLevelDBConfig(node.StoreV2Path(dbPath, node.LevelDBBackend))
+	config.Schema = schema
+	db, err := tablestore.Start(logger, config)
+	require.NoError(t, err)
+	_, _, err = node.NewLevelDBStoreV2(db, logger, time.Hour).StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	// The node must not be running during the migration
+	require.NoError(t, s.Shutdown())
+	require.NoError(t, db.Shutdown())
+
+	err = node.MigrateToPebble(logger, dbPath, 2)
+	require.NoError(t, err)
+
+	// The Pebble stores have the data of the LevelDB stores
+	s, err = node.NewStore(node.PebbleDBBackend, node.StorePath(dbPath, node.PebbleDBBackend), logger, m, staleMeasure, storeDuration)
+	require.NoError(t, err)
+	chunks, format, err := s.GetChunks(ctx, batchHeaderHash, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, pb.ChunkEncodingFormat_GOB, format)
+	require.Equal(t, blobsProto[1].Bundles[0].Chunks, chunks)
+	require.NoError(t, s.Shutdown())
+
+	config = tablestore.DefaultPebbleDBConfig(node.StoreV2Path(dbPath, node.PebbleDBBackend))
+	config.Schema = schema
+	db, err = tablestore.Start(logger, config)
+	require.NoError(t, err)
+	chunksV2, err := node.NewLevelDBStoreV2(db, logger, time.Hour).GetChunks(blobKeys[2], 2)
+	require.NoError(t, err)
+	require.Len(t, chunksV2, len(bundles[2][2]))
+	require.NoError(t, db.Shutdown())
+
+	// The migration doesn't overwrite existing Pebble stores
+	err = node.MigrateToPebble(logger, dbPath, 2)
+	require.Error(t, err)
+}
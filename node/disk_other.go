@@ -0,0 +1,10 @@
+//go:build !unix
+
+package node
+
+import "errors"
+
+// freeDiskBytes returns the space available to the node on the disk holding the path.
+func freeDiskBytes(path string) (uint64, error) {
+	return 0, errors.New("free disk space is not supported on this platform")
+}
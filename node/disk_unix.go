@@ -0,0 +1,14 @@
+//go:build unix
+
+package node
+
+import "syscall"
+
+// freeDiskBytes returns the space available to the node on the disk holding the path.
+func freeDiskBytes(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
 )
@@ -98,6 +99,25 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DB_PATH"),
 	}
+	DbBackendFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "db-backend"),
+		Usage:    "The database backend of the chunk stores, either leveldb or pebble. Existing LevelDB data can be migrated to Pebble with the dbmigrate tool (default: leveldb)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DB_BACKEND"),
+		Value:    "leveldb",
+	}
+	AdminApiPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-api-port"),
+		Usage:    "The localhost port of the admin API, used to inspect the stored batches and chunks. The admin API is disabled if not set. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ADMIN_API_PORT"),
+	}
+	AdminApiTokenFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-api-token"),
+		Usage:    "The bearer token that the admin API requests must be authorized with. Required if the admin API is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ADMIN_API_TOKEN"),
+	}
 	// The files for encrypted private keys.
 	BlsKeyFileFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "bls-key-file"),
@@ -233,11 +253,180 @@ var (
 	}
 	ChunkDownloadTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "chunk-download-timeout"),
-		Usage:    "The timeout for downloading chunks from the relay (default: 30s)",
+		Usage:    "The timeout for downloading the chunks of a batch from the relays, including the fallbacks to other relays (default: 30s)",
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_DOWNLOAD_TIMEOUT"),
 		Value:    20 * time.Second,
 	}
+	CompactionIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "compaction-interval"),
+		Usage:    "The interval at which the databases are compacted to reclaim the disk space of expired data. A compaction rewrites the whole database, which competes with the writes and reads of dispersals and retrievals, so it is disabled by default (0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "COMPACTION_INTERVAL"),
+		Value:    0,
+	}
+
+	RetrievalRequestsPerSecondClientFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-requests-per-second-client"),
+		Usage:    "Max number of chunk retrieval requests per second for a single client. Set to 0 to disable the limit (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_REQUESTS_PER_SECOND_CLIENT"),
+		Value:    0,
+	}
+	RetrievalRequestBurstinessClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-request-burstiness-client"),
+		Usage:    "Burstiness of the chunk retrieval request rate limiter of a single client. Defaults to the requests per second if set to 0 (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_REQUEST_BURSTINESS_CLIENT"),
+		Value:    0,
+	}
+	RetrievalBytesPerSecondClientFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-bytes-per-second-client"),
+		Usage:    "Max bandwidth for chunk retrievals in bytes per second for a single client. Set to 0 to disable the limit (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_BYTES_PER_SECOND_CLIENT"),
+		Value:    0,
+	}
+	RetrievalBytesBurstinessClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-bytes-burstiness-client"),
+		Usage:    "Burstiness of the chunk retrieval bandwidth rate limiter of a single client. Defaults to the bytes per second if set to 0 (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_BYTES_BURSTINESS_CLIENT"),
+		Value:    0,
+	}
+
+	ReloadConfigFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "reload-config-file"),
+		Usage:    "Path to a JSON file with the settings (log_level, retrieval_rate_limits, quorum_ids) applied without a restart when the node receives SIGHUP. Settings missing from the file are left unchanged",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RELOAD_CONFIG_FILE"),
+	}
+
+	V2DispersalPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v2-dispersal-port"),
+		Usage:    "Port at which node registers to listen for v2 dispersal calls on a dedicated server. If not set, v2 dispersal is served on the dispersal port",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V2_DISPERSAL_PORT"),
+	}
+	V2RetrievalPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v2-retrieval-port"),
+		Usage:    "Port at which node registers to listen for v2 retrieval calls on a dedicated server. If not set, v2 retrieval is served on the retrieval port",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V2_RETRIEVAL_PORT"),
+	}
+	V2RetrievalPortClientsUpgradedFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v2-retrieval-port-clients-upgraded"),
+		Usage:    "Confirms that the dispersers, retrievers and data APIs can parse sockets with a v2 retrieval port, which is required to set the v2 retrieval port. Older releases fail to parse such sockets, v1 ports included, so they must be upgraded before any node registers a v2 retrieval port",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V2_RETRIEVAL_PORT_CLIENTS_UPGRADED"),
+	}
+	InternalV2DispersalPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "internal-v2-dispersal-port"),
+		Usage:    "Port at which node listens for v2 dispersal calls (used when node is behind NGINX). Defaults to the v2 dispersal port",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "INTERNAL_V2_DISPERSAL_PORT"),
+	}
+	InternalV2RetrievalPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "internal-v2-retrieval-port"),
+		Usage:    "Port at which node listens for v2 retrieval calls (used when node is behind NGINX). Defaults to the v2 retrieval port",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "INTERNAL_V2_RETRIEVAL_PORT"),
+	}
+	V2NumBatchValidatorsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v2-num-batch-validators"),
+		Usage:    "Maximum number of parallel workers used to validate a v2 batch. Defaults to num-batch-validators if set to 0 (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V2_NUM_BATCH_VALIDATORS"),
+		Value:    0,
+	}
+	V1MaxConcurrentRequestsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v1-max-concurrent-requests"),
+		Usage:    "Maximum number of v1 dispersal and retrieval requests handled concurrently, further requests are rejected. Set to 0 to disable the limit (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V1_MAX_CONCURRENT_REQUESTS"),
+		Value:    0,
+	}
+	V2MaxConcurrentRequestsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v2-max-concurrent-requests"),
+		Usage:    "Maximum number of v2 dispersal and retrieval requests handled concurrently, further requests are rejected. Set to 0 to disable the limit (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V2_MAX_CONCURRENT_REQUESTS"),
+		Value:    0,
+	}
+	V1MaxInflightRequestBytesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v1-max-inflight-request-bytes"),
+		Usage:    "Maximum total size in bytes of the v1 requests handled concurrently, further requests are rejected. Set to 0 to disable the limit (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V1_MAX_INFLIGHT_REQUEST_BYTES"),
+		Value:    0,
+	}
+	V2MaxInflightRequestBytesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v2-max-inflight-request-bytes"),
+		Usage:    "Maximum total size in bytes of the v2 requests handled concurrently, further requests are rejected. Set to 0 to disable the limit (default: 0)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V2_MAX_INFLIGHT_REQUEST_BYTES"),
+		Value:    0,
+	}
+	ChunkStorePathsFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-store-paths"),
+		Usage:    "Comma separated mount points across which the v2 chunks are sharded, each on its own disk. The chunks are rebalanced when paths are added. If empty, the chunks are stored in the db path",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_STORE_PATHS"),
+	}
+	ChunkStoreHealthCheckIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-store-health-check-interval"),
+		Usage:    "The interval at which the disks of the chunk store paths are checked. Chunks aren't written to unhealthy disks (default: 30s)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_STORE_HEALTH_CHECK_INTERVAL"),
+		Value:    30 * time.Second,
+	}
+	ChunkScrubberRateFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-scrubber-rate"),
+		Usage:    "The number of stored v2 blobs per second whose chunks are re-verified against their commitments in the background. Set to 0 to disable the scrubber (default: 1)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_SCRUBBER_RATE"),
+		Value:    1,
+	}
+	ChunkScrubberRefetchFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-scrubber-refetch"),
+		Usage:    "Whether the corrupted chunks found by the scrubber are replaced by chunks fetched from the relays",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_SCRUBBER_REFETCH"),
+	}
+
+	StorageQuotasFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-quotas"),
+		Usage:    "Comma separated disk quotas of the v2 chunks of each quorum, as <quorum>:<soft limit bytes>:<hard limit bytes>. The node warns when a soft limit is exceeded, and rejects the batches exceeding a hard limit. A limit of 0 is unlimited",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_QUOTAS"),
+	}
+	StorageUsageRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-usage-refresh-interval"),
+		Usage:    "The interval at which the usage of the storage quotas is synced with the store, which scans the stored chunks (default: 10m)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_USAGE_REFRESH_INTERVAL"),
+		Value:    10 * time.Minute,
+	}
+	StartupSelfCheckFlag = cli.BoolTFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "startup-self-check"),
+		Usage:    "Whether the node checks its quorum registration, its reachability and the skew of its clock at startup, and exits with the actions to take if a check fails (default: true)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STARTUP_SELF_CHECK"),
+	}
+	NTPServerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "ntp-server"),
+		Usage:    "The NTP server the clock of the node is compared with by the startup self-check. Set to empty to skip the clock check (default: pool.ntp.org)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NTP_SERVER"),
+		Value:    "pool.ntp.org",
+	}
+	MaxClockSkewFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-clock-skew"),
+		Usage:    "The maximum offset of the clock of the node from the NTP server accepted by the startup self-check (default: 2s)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_CLOCK_SKEW"),
+		Value:    2 * time.Second,
+	}
 
 	// Test only, DO NOT USE the following flags in production
 
@@ -320,6 +509,13 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_SIGNER_CERT_FILE"),
 	}
+	BLSRemoteSignerHealthCheckIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-remote-signer-health-check-interval"),
+		Usage:    "The interval at which the BLS remote signer is checked by signing a probe message. Set to 0 to only check it at startup (default: 1m)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_REMOTE_SIGNER_HEALTH_CHECK_INTERVAL"),
+		Value:    time.Minute,
+	}
 	PprofHttpPort = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "pprof-http-port"),
 		Usage:    "the http port which the pprof server is listening",
@@ -379,9 +575,38 @@ var optionalFlags = []cli.Flag{
 	BLSRemoteSignerUrlFlag,
 	BLSPublicKeyHexFlag,
 	BLSSignerCertFileFlag,
+	BLSRemoteSignerHealthCheckIntervalFlag,
 	EnableV2Flag,
 	OnchainStateRefreshIntervalFlag,
 	ChunkDownloadTimeoutFlag,
+	CompactionIntervalFlag,
+	DbBackendFlag,
+	AdminApiPortFlag,
+	AdminApiTokenFlag,
+	RetrievalRequestsPerSecondClientFlag,
+	RetrievalRequestBurstinessClientFlag,
+	RetrievalBytesPerSecondClientFlag,
+	RetrievalBytesBurstinessClientFlag,
+	ReloadConfigFileFlag,
+	V2DispersalPortFlag,
+	V2RetrievalPortFlag,
+	V2RetrievalPortClientsUpgradedFlag,
+	InternalV2DispersalPortFlag,
+	InternalV2RetrievalPortFlag,
+	V2NumBatchValidatorsFlag,
+	V1MaxConcurrentRequestsFlag,
+	V2MaxConcurrentRequestsFlag,
+	V1MaxInflightRequestBytesFlag,
+	V2MaxInflightRequestBytesFlag,
+	ChunkStorePathsFlag,
+	ChunkStoreHealthCheckIntervalFlag,
+	ChunkScrubberRateFlag,
+	ChunkScrubberRefetchFlag,
+	StorageQuotasFlag,
+	StorageUsageRefreshIntervalFlag,
+	StartupSelfCheckFlag,
+	NTPServerFlag,
+	MaxClockSkewFlag,
 	PprofHttpPort,
 	EnablePprof,
 }
@@ -390,6 +615,7 @@ func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, kzg.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(EnvVarPrefix)...)
+	Flags = append(Flags, coreeth.QuorumProfilesFlags(EnvVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(EnvVarPrefix, FlagPrefix)...)
 }
 
@@ -19,11 +19,15 @@ type MetricsV2 struct {
 	registry         *prometheus.Registry
 	grpcServerOption grpc.ServerOption
 
-	storeChunksLatency     *prometheus.SummaryVec
-	storeChunksRequestSize *prometheus.GaugeVec
+	storeChunksLatency      *prometheus.SummaryVec
+	storeChunksStageLatency *prometheus.SummaryVec
+	storeChunksRequestSize  *prometheus.GaugeVec
 
 	getChunksLatency  *prometheus.SummaryVec
 	getChunksDataSize *prometheus.GaugeVec
+
+	inflightRequests      *prometheus.GaugeVec
+	requestBudgetExceeded *prometheus.CounterVec
 }
 
 // NewV2Metrics creates a new MetricsV2 instance. dbSizePollPeriod is the period at which the database size is polled.
@@ -50,6 +54,16 @@ func NewV2Metrics(logger logging.Logger, registry *prometheus.Registry) (*Metric
 		[]string{},
 	)
 
+	storeChunksStageLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "store_chunks_stage_latency_ms",
+			Help:       "The latency of each stage of a StoreChunks() RPC call: state_fetch, download, deserialize, validate_header, kzg_verify, store, store_wait and sign.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"stage"},
+	)
+
 	storeChunksRequestSize := promauto.With(registry).NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -78,14 +92,35 @@ func NewV2Metrics(logger logging.Logger, registry *prometheus.Registry) (*Metric
 		[]string{},
 	)
 
+	inflightRequests := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "inflight_requests",
+			Help:      "The number of dispersal and retrieval requests being handled, by protocol version.",
+		},
+		[]string{"version"},
+	)
+
+	requestBudgetExceeded := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_budget_exceeded_total",
+			Help:      "The number of requests rejected by the request budget of their protocol version.",
+		},
+		[]string{"version", "budget"},
+	)
+
 	return &MetricsV2{
-		logger:                 logger,
-		registry:               registry,
-		grpcServerOption:       grpcServerOption,
-		storeChunksLatency:     storeChunksLatency,
-		storeChunksRequestSize: storeChunksRequestSize,
-		getChunksLatency:       getChunksLatency,
-		getChunksDataSize:      getChunksDataSize,
+		logger:                  logger,
+		registry:                registry,
+		grpcServerOption:        grpcServerOption,
+		storeChunksLatency:      storeChunksLatency,
+		storeChunksStageLatency: storeChunksStageLatency,
+		storeChunksRequestSize:  storeChunksRequestSize,
+		getChunksLatency:        getChunksLatency,
+		getChunksDataSize:       getChunksDataSize,
+		inflightRequests:        inflightRequests,
+		requestBudgetExceeded:   requestBudgetExceeded,
 	}, nil
 }
 
@@ -98,6 +133,10 @@ func (m *MetricsV2) ReportStoreChunksLatency(latency time.Duration) {
 	m.storeChunksLatency.WithLabelValues().Observe(common.ToMilliseconds(latency))
 }
 
+func (m *MetricsV2) ReportStoreChunksStageLatency(stage string, latency time.Duration) {
+	m.storeChunksStageLatency.WithLabelValues(stage).Observe(common.ToMilliseconds(latency))
+}
+
 func (m *MetricsV2) ReportStoreChunksRequestSize(size uint64) {
 	m.storeChunksRequestSize.WithLabelValues().Set(float64(size))
 }
@@ -109,3 +148,11 @@ func (m *MetricsV2) ReportGetChunksLatency(latency time.Duration) {
 func (m *MetricsV2) ReportGetChunksDataSize(size int) {
 	m.getChunksDataSize.WithLabelValues().Set(float64(size))
 }
+
+func (m *MetricsV2) ReportInflightRequests(version string, delta int) {
+	m.inflightRequests.WithLabelValues(version).Add(float64(delta))
+}
+
+func (m *MetricsV2) ReportRequestBudgetExceeded(version string, budget string) {
+	m.requestBudgetExceeded.WithLabelValues(version, budget).Inc()
+}
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
+	"github.com/Layr-Labs/eigenda/node"
+	"golang.org/x/sync/semaphore"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// requestBudget enforces the node.RequestBudget of a protocol version. Requests exceeding the budget are rejected
+// rather than queued, so that they don't pile up goroutines and memory while waiting.
+type requestBudget struct {
+	version string
+	// requests bounds the number of requests handled concurrently, nil if unlimited
+	requests *semaphore.Weighted
+	// bytes bounds the total size of the requests handled concurrently, nil if unlimited
+	bytes    *semaphore.Weighted
+	maxBytes int64
+	metrics  *MetricsV2
+}
+
+func newRequestBudget(version string, config node.RequestBudget, metrics *MetricsV2) *requestBudget {
+	budget := &requestBudget{
+		version: version,
+		metrics: metrics,
+	}
+	if config.MaxConcurrentRequests > 0 {
+		budget.requests = semaphore.NewWeighted(int64(config.MaxConcurrentRequests))
+	}
+	if config.MaxInflightRequestBytes > 0 {
+		budget.maxBytes = int64(config.MaxInflightRequestBytes)
+		budget.bytes = semaphore.NewWeighted(budget.maxBytes)
+	}
+	return budget
+}
+
+// acquire reserves the budget for the request, and returns the function releasing it once the request is handled.
+// Requests larger than the bytes budget consume the whole budget.
+func (b *requestBudget) acquire(req any) (func(), error) {
+	if b.requests != nil && !b.requests.TryAcquire(1) {
+		b.metrics.ReportRequestBudgetExceeded(b.version, "requests")
+		return nil, api.NewErrorResourceExhausted(
+			fmt.Sprintf("the node is handling too many %s requests, try again later", b.version))
+	}
+
+	var size int64
+	if b.bytes != nil {
+		if msg, ok := req.(proto.Message); ok {
+			size = min(int64(proto.Size(msg)), b.maxBytes)
+		}
+		if !b.bytes.TryAcquire(size) {
+			if b.requests != nil {
+				b.requests.Release(1)
+			}
+			b.metrics.ReportRequestBudgetExceeded(b.version, "bytes")
+			return nil, api.NewErrorResourceExhausted(
+				fmt.Sprintf("the node is handling too much %s data, try again later", b.version))
+		}
+	}
+
+	b.metrics.ReportInflightRequests(b.version, 1)
+	return func() {
+		b.metrics.ReportInflightRequests(b.version, -1)
+		if b.bytes != nil {
+			b.bytes.Release(size)
+		}
+		if b.requests != nil {
+			b.requests.Release(1)
+		}
+	}, nil
+}
+
+// requestBudgetInterceptor enforces the budget of the protocol version of each request, so that the versions are
+// isolated from each other even when served by the same gRPC server. Requests to other services, e.g. health checks,
+// are not subject to any budget.
+func requestBudgetInterceptor(v1 *requestBudget, v2 *requestBudget) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var budget *requestBudget
+		switch methodService(info.FullMethod) {
+		case pb.Dispersal_ServiceDesc.ServiceName, pb.Retrieval_ServiceDesc.ServiceName:
+			budget = v1
+		case pbv2.Dispersal_ServiceDesc.ServiceName, pbv2.Retrieval_ServiceDesc.ServiceName:
+			budget = v2
+		default:
+			return handler(ctx, req)
+		}
+
+		release, err := budget.acquire(req)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// methodService returns the service of a full gRPC method name of the form "/service/method".
+func methodService(fullMethod string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestMetrics(t *testing.T) *MetricsV2 {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+	metrics, err := NewV2Metrics(logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+	return metrics
+}
+
+func TestRequestBudgetRequests(t *testing.T) {
+	metrics := newTestMetrics(t)
+	v1 := newRequestBudget("v1", node.RequestBudget{MaxConcurrentRequests: 1}, metrics)
+	v2 := newRequestBudget("v2", node.RequestBudget{MaxConcurrentRequests: 2}, metrics)
+	interceptor := requestBudgetInterceptor(v1, v2)
+
+	// The handler blocks until released, so that the requests stay in flight
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blockingHandler := func(ctx context.Context, req any) (any, error) {
+		started <- struct{}{}
+		<-release
+		return nil, nil
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	}
+	v1Info := &grpc.UnaryServerInfo{FullMethod: "/node.Dispersal/StoreChunks"}
+	v2Info := &grpc.UnaryServerInfo{FullMethod: "/node.v2.Dispersal/StoreChunks"}
+	healthInfo := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+
+	errs := make(chan error, 3)
+	go func() {
+		_, err := interceptor(context.Background(), &pb.StoreChunksRequest{}, v1Info, blockingHandler)
+		errs <- err
+	}()
+	<-started
+
+	// The v1 budget is exhausted
+	_, err := interceptor(context.Background(), &pb.StoreChunksRequest{}, v1Info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The v2 budget is independent of the v1 budget
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := interceptor(context.Background(), &pbv2.StoreChunksRequest{}, v2Info, blockingHandler)
+			errs <- err
+		}()
+		<-started
+	}
+	_, err = interceptor(context.Background(), &pbv2.StoreChunksRequest{}, v2Info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Other services aren't subject to the budgets
+	_, err = interceptor(context.Background(), nil, healthInfo, handler)
+	require.NoError(t, err)
+
+	// The budgets are released once the requests are handled
+	close(release)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, <-errs)
+	}
+	_, err = interceptor(context.Background(), &pb.StoreChunksRequest{}, v1Info, handler)
+	require.NoError(t, err)
+	_, err = interceptor(context.Background(), &pbv2.StoreChunksRequest{}, v2Info, handler)
+	require.NoError(t, err)
+}
+
+func TestRequestBudgetBytes(t *testing.T) {
+	request := &pbv2.GetChunksRequest{BlobKey: make([]byte, 100)}
+	size := proto.Size(request)
+	budget := newRequestBudget("v2", node.RequestBudget{MaxInflightRequestBytes: size + 10}, newTestMetrics(t))
+
+	releaseFirst, err := budget.acquire(request)
+	require.NoError(t, err)
+
+	// The second request doesn't fit in the remaining budget
+	_, err = budget.acquire(request)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	releaseFirst()
+	releaseSecond, err := budget.acquire(request)
+	require.NoError(t, err)
+	releaseSecond()
+
+	// Requests larger than the budget consume the whole budget
+	large := &pbv2.GetChunksRequest{BlobKey: make([]byte, 1000)}
+	releaseLarge, err := budget.acquire(large)
+	require.NoError(t, err)
+	_, err = budget.acquire(&pbv2.GetChunksRequest{})
+	require.NoError(t, err)
+	_, err = budget.acquire(request)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	releaseLarge()
+}
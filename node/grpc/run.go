@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -16,6 +17,8 @@ import (
 
 const localhost = "0.0.0.0"
 
+// RunServers runs the gRPC servers of the node in the background. It returns once their listeners are bound, so that
+// the node accepts connections by then.
 func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logger logging.Logger) error {
 	if serverV1 == nil {
 		return errors.New("node V1 server is not configured")
@@ -24,58 +27,120 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 		return errors.New("node V2 server is not configured")
 	}
 
-	go func() {
-		for {
-			addr := fmt.Sprintf("%s:%s", localhost, config.InternalDispersalPort)
-			listener, err := net.Listen("tcp", addr)
-			if err != nil {
-				logger.Fatalf("Could not start tcp listener: %v", err)
-			}
-
-			opt := grpc.MaxRecvMsgSize(60 * 1024 * 1024 * 1024) // 60 GiB
-			gs := grpc.NewServer(opt, serverV2.metrics.GetGRPCServerOption())
-
-			// Register reflection service on gRPC server
-			// This makes "grpcurl -plaintext localhost:9000 list" command work
-			reflection.Register(gs)
+	// Both servers report the health of the chain RPC
+	healthChecker := healthcheck.NewHealthChecker(map[string]healthcheck.Check{
+		"chain": func(ctx context.Context) error {
+			_, err := serverV2.node.Transactor.GetCurrentBlockNumber(ctx)
+			return err
+		},
+	}, healthcheck.DefaultCheckInterval, healthcheck.DefaultCheckTimeout, logger)
+	healthChecker.Start(context.Background())
+
+	// The budgets isolate the v1 and v2 traffic from each other, whether they share servers or not
+	v1Budget := newRequestBudget("v1", config.V1RequestBudget, serverV2.metrics)
+	v2Budget := newRequestBudget("v2", config.V2RequestBudget, serverV2.metrics)
+	opts := []grpc.ServerOption{
+		serverV2.metrics.GetGRPCServerOption(),
+		grpc.ChainUnaryInterceptor(requestBudgetInterceptor(v1Budget, v2Budget)),
+	}
 
-			pb.RegisterDispersalServer(gs, serverV1)
-			pbv2.RegisterDispersalServer(gs, serverV2)
+	dispersalOpts := append([]grpc.ServerOption{grpc.MaxRecvMsgSize(60 * 1024 * 1024 * 1024)}, opts...) // 60 GiB
+	retrievalOpts := append([]grpc.ServerOption{grpc.MaxRecvMsgSize(1024 * 1024 * 300)}, opts...)       // 300 MiB
+
+	// The v2 services get servers of their own if the node has ports for them, so that a surge of the traffic of
+	// one version can't exhaust the connections and streams of the other.
+	var servers []serverSpec
+	if config.InternalV2DispersalPort != "" {
+		servers = append(servers,
+			serverSpec{"dispersal", config.InternalDispersalPort, dispersalOpts, func(gs *grpc.Server) {
+				pb.RegisterDispersalServer(gs, serverV1)
+				healthChecker.Register("node.Dispersal", gs)
+			}},
+			serverSpec{"v2 dispersal", config.InternalV2DispersalPort, dispersalOpts, func(gs *grpc.Server) {
+				pbv2.RegisterDispersalServer(gs, serverV2)
+				healthChecker.Register("node.v2.Dispersal", gs)
+			}})
+	} else {
+		servers = append(servers,
+			serverSpec{"dispersal", config.InternalDispersalPort, dispersalOpts, func(gs *grpc.Server) {
+				pb.RegisterDispersalServer(gs, serverV1)
+				pbv2.RegisterDispersalServer(gs, serverV2)
+				healthChecker.Register("node.Dispersal", gs)
+			}})
+	}
 
-			healthcheck.RegisterHealthServer("node.Dispersal", gs)
+	if config.InternalV2RetrievalPort != "" {
+		servers = append(servers,
+			serverSpec{"retrieval", config.InternalRetrievalPort, retrievalOpts, func(gs *grpc.Server) {
+				pb.RegisterRetrievalServer(gs, serverV1)
+				healthChecker.Register("node.Retrieval", gs)
+			}},
+			serverSpec{"v2 retrieval", config.InternalV2RetrievalPort, retrievalOpts, func(gs *grpc.Server) {
+				pbv2.RegisterRetrievalServer(gs, serverV2)
+				healthChecker.Register("node.v2.Retrieval", gs)
+			}})
+	} else {
+		servers = append(servers,
+			serverSpec{"retrieval", config.InternalRetrievalPort, retrievalOpts, func(gs *grpc.Server) {
+				pb.RegisterRetrievalServer(gs, serverV1)
+				pbv2.RegisterRetrievalServer(gs, serverV2)
+				healthChecker.Register("node.Retrieval", gs)
+			}})
+	}
 
-			logger.Info("port", config.InternalDispersalPort, "address", listener.Addr().String(), "GRPC Listening")
-			if err := gs.Serve(listener); err != nil {
-				logger.Error("dispersal server failed; restarting.", "err", err)
+	listeners := make([]net.Listener, len(servers))
+	for i, server := range servers {
+		listener, err := listen(server.port)
+		if err != nil {
+			for _, bound := range listeners[:i] {
+				_ = bound.Close()
 			}
+			return fmt.Errorf("could not start tcp listener of the %s server: %w", server.name, err)
 		}
-	}()
-
-	go func() {
-		for {
-			addr := fmt.Sprintf("%s:%s", localhost, config.InternalRetrievalPort)
-			listener, err := net.Listen("tcp", addr)
-			if err != nil {
-				logger.Fatalf("Could not start tcp listener: %v", err)
-			}
+		listeners[i] = listener
+	}
+	for i, server := range servers {
+		go runServer(server, listeners[i], logger)
+	}
+
+	return nil
+}
 
-			opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-			gs := grpc.NewServer(opt, serverV2.metrics.GetGRPCServerOption())
+// serverSpec describes a gRPC server of the node.
+type serverSpec struct {
+	name string
+	port string
+	opts []grpc.ServerOption
+	// register registers the services of the server
+	register func(gs *grpc.Server)
+}
 
-			// Register reflection service on gRPC server
-			// This makes "grpcurl -plaintext localhost:9000 list" command work
-			reflection.Register(gs)
+func listen(port string) (net.Listener, error) {
+	return net.Listen("tcp", fmt.Sprintf("%s:%s", localhost, port))
+}
 
-			pb.RegisterRetrievalServer(gs, serverV1)
-			pbv2.RegisterRetrievalServer(gs, serverV2)
-			healthcheck.RegisterHealthServer("node.Retrieval", gs)
+// runServer serves the services of the server on the listener, and restarts the server on a new listener whenever it
+// fails.
+func runServer(server serverSpec, listener net.Listener, logger logging.Logger) {
+	for {
+		gs := grpc.NewServer(server.opts...)
 
-			logger.Info("port", config.InternalRetrievalPort, "address", listener.Addr().String(), "GRPC Listening")
-			if err := gs.Serve(listener); err != nil {
-				logger.Error("retrieval server failed; restarting.", "err", err)
-			}
+		// Register reflection service on gRPC server
+		// This makes "grpcurl -plaintext localhost:9000 list" command work
+		reflection.Register(gs)
+
+		server.register(gs)
+
+		logger.Info("port", server.port, "address", listener.Addr().String(), "server", server.name, "GRPC Listening")
+		if err := gs.Serve(listener); err != nil {
+			logger.Error(server.name+" server failed; restarting.", "err", err)
 		}
-	}()
 
-	return nil
+		// The listener is closed once the server stops serving
+		var err error
+		listener, err = listen(server.port)
+		if err != nil {
+			logger.Fatalf("Could not start tcp listener: %v", err)
+		}
+	}
 }
@@ -159,9 +159,11 @@ func (s *Server) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*p
 	// Record metrics.
 	if err != nil {
 		s.node.Metrics.RecordRPCRequest("StoreChunks", "failure", time.Since(start))
+		s.node.Performance.RecordBatch("v1", false, nil)
 		s.node.Logger.Error("StoreChunks RPC failed", "duration", time.Since(start), "err", err)
 	} else {
 		s.node.Metrics.RecordRPCRequest("StoreChunks", "success", time.Since(start))
+		s.node.Performance.RecordBatch("v1", true, bundleSizesByQuorum(in))
 		s.node.Logger.Info("StoreChunks RPC succeeded", "duration", time.Since(start))
 	}
 
@@ -176,8 +178,11 @@ func (s *Server) AttestBatch(ctx context.Context, in *pb.AttestBatchRequest) (*p
 	return &pb.AttestBatchReply{}, api.NewErrorUnimplemented()
 }
 
-func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksRequest) (*pb.RetrieveChunksReply, error) {
+func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksRequest) (reply *pb.RetrieveChunksReply, err error) {
 	start := time.Now()
+	defer func() {
+		s.node.Performance.RecordRetrieval("v1", err == nil, chunksSize(reply.GetChunks()))
+	}()
 
 	if in.GetQuorumId() > core.MaxQuorumID {
 		return nil, fmt.Errorf("invalid request: quorum ID must be in range [0, %d], but found %d", core.MaxQuorumID, in.GetQuorumId())
@@ -195,6 +200,9 @@ func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksReques
 	if err != nil {
 		return nil, err
 	}
+	if err := s.node.RetrievalRateLimiter.BeginRequest(time.Now(), retrieverID); err != nil {
+		return nil, err
+	}
 
 	quorumInfo := blobHeader.GetQuorumInfo(uint8(in.GetQuorumId()))
 	if quorumInfo == nil {
@@ -247,6 +255,13 @@ func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksReques
 		}
 		chunks = gobChunks
 	}
+	size := 0
+	for _, chunk := range chunks {
+		size += len(chunk)
+	}
+	if err := s.node.RetrievalRateLimiter.RequestBandwidth(time.Now(), retrieverID, size); err != nil {
+		return nil, err
+	}
 	s.node.Metrics.RecordRPCRequest("RetrieveChunks", "success", time.Since(start))
 	return &pb.RetrieveChunksReply{Chunks: chunks, ChunkEncodingFormat: format}, nil
 }
@@ -363,3 +378,27 @@ func (s *Server) getBlobHeader(ctx context.Context, batchHeaderHash [32]byte, bl
 	return blobHeader, &protoBlobHeader, nil
 
 }
+
+// bundleSizesByQuorum returns the size of the chunks of the request by quorum.
+func bundleSizesByQuorum(in *pb.StoreChunksRequest) map[core.QuorumID]uint64 {
+	sizes := make(map[core.QuorumID]uint64)
+	for _, blob := range in.GetBlobs() {
+		for i, quorumHeader := range blob.GetHeader().GetQuorumHeaders() {
+			if i >= len(blob.GetBundles()) {
+				break
+			}
+			bundle := blob.GetBundles()[i]
+			sizes[core.QuorumID(quorumHeader.GetQuorumId())] += uint64(len(bundle.GetBundle())) + chunksSize(bundle.GetChunks())
+		}
+	}
+	return sizes
+}
+
+// chunksSize returns the total size of the chunks.
+func chunksSize(chunks [][]byte) uint64 {
+	size := uint64(0)
+	for _, chunk := range chunks {
+		size += uint64(len(chunk))
+	}
+	return size
+}
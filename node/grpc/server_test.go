@@ -79,6 +79,7 @@ func makeTestComponents() (encoding.Prover, encoding.Verifier, error) {
 func makeConfig(t *testing.T) *node.Config {
 	return &node.Config{
 		Timeout:                   10 * time.Second,
+		ChunkDownloadTimeout:      10 * time.Second,
 		ExpirationPollIntervalSec: 1,
 		QuorumIDList:              []core.QuorumID{0},
 		DbPath:                    t.TempDir(),
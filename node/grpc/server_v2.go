@@ -1,8 +1,10 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"runtime"
 	"time"
@@ -29,6 +31,9 @@ type ServerV2 struct {
 	ratelimiter common.RateLimiter
 	logger      logging.Logger
 	metrics     *MetricsV2
+
+	// quorumProfilesDigest is the digest of the quorum profiles of the node, which dispersers must encode blobs with
+	quorumProfilesDigest []byte
 }
 
 // NewServerV2 creates a new Server instance with the provided parameters.
@@ -50,6 +55,8 @@ func NewServerV2(
 		ratelimiter: ratelimiter,
 		logger:      logger,
 		metrics:     metrics,
+
+		quorumProfilesDigest: config.QuorumProfiles.Digest(),
 	}, nil
 }
 
@@ -67,8 +74,12 @@ func (s *ServerV2) NodeInfo(ctx context.Context, in *pb.NodeInfoRequest) (*pb.No
 	return &pb.NodeInfoReply{Semver: node.SemVer, Os: runtime.GOOS, Arch: runtime.GOARCH, NumCpu: uint32(runtime.GOMAXPROCS(0)), MemBytes: memBytes}, nil
 }
 
-func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
+func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (reply *pb.StoreChunksReply, err error) {
 	start := time.Now()
+	var bytesByQuorum map[core.QuorumID]uint64
+	defer func() {
+		s.node.Performance.RecordBatch("v2", err == nil, bytesByQuorum)
+	}()
 
 	if !s.config.EnableV2 {
 		return nil, api.NewErrorInvalidArg("v2 API is disabled")
@@ -78,11 +89,6 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		return nil, api.NewErrorInternal("v2 store not initialized")
 	}
 
-	// TODO(ian-shim): support remote signer
-	if s.node.KeyPair == nil {
-		return nil, api.NewErrorInternal("missing key pair")
-	}
-
 	batch, err := s.validateStoreChunksRequest(in)
 	if err != nil {
 		return nil, err
@@ -94,23 +100,55 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 	}
 
 	s.logger.Info("new StoreChunks request", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "numBlobs", len(batch.BlobCertificates), "referenceBlockNumber", batch.BatchHeader.ReferenceBlockNumber)
+	// The stages are timed so that operators can tell which stage makes the node miss the signing window of batches
+	stageStart := time.Now()
 	operatorState, err := s.node.ChainState.GetOperatorStateByOperator(ctx, uint(batch.BatchHeader.ReferenceBlockNumber), s.node.Config.ID)
 	if err != nil {
 		return nil, err
 	}
+	s.metrics.ReportStoreChunksStageLatency("state_fetch", time.Since(stageStart))
 
-	blobShards, rawBundles, err := s.node.DownloadBundles(ctx, batch, operatorState)
+	stageStart = time.Now()
+	blobShards, rawBundles, err := s.node.DownloadBundles(ctx, batch, operatorState, s.metrics.ReportStoreChunksStageLatency)
 	if err != nil {
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to download batch: %v", err))
 	}
+	s.metrics.ReportStoreChunksStageLatency("download", time.Since(stageStart))
+	bytesByQuorum = make(map[core.QuorumID]uint64)
+	for _, bundles := range rawBundles {
+		for quorum, bundle := range bundles.Bundles {
+			bytesByQuorum[quorum] += uint64(len(bundle))
+		}
+	}
+	// The storage quotas are reserved until the batch is stored, and released if it isn't
+	reservation, err := s.node.StorageQuotas.Reserve(bytesByQuorum)
+	if err != nil {
+		return nil, api.NewErrorResourceExhausted(err.Error())
+	}
 
 	type storeResult struct {
 		keys []kvstore.Key
-		err  error
+		// alreadyStored is set when the batch was stored by a previous request, so its space isn't counted again
+		alreadyStored bool
+		err           error
 	}
 	storeChan := make(chan storeResult)
+	// The batch is stored while it is validated, since storing is mostly IO and validating is mostly CPU
 	go func() {
+		storeStart := time.Now()
 		keys, size, err := s.node.StoreV2.StoreBatch(batch, rawBundles)
+		if errors.Is(err, node.ErrBatchAlreadyExist) {
+			// The batch is dispatched again when the node didn't respond in time the first time around, e.g. because
+			// it restarted after storing the batch. The chunks it just downloaded are still validated and the batch
+			// signed, so that the node catches up on the batch instead of becoming a non-signer for it.
+			s.logger.Info("batch already stored, signing it again", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]))
+			storeChan <- storeResult{
+				keys:          nil,
+				alreadyStored: true,
+				err:           nil,
+			}
+			return
+		}
 		if err != nil {
 			storeChan <- storeResult{
 				keys: nil,
@@ -120,6 +158,7 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		}
 
 		s.metrics.ReportStoreChunksRequestSize(size)
+		s.metrics.ReportStoreChunksStageLatency("store", time.Since(storeStart))
 
 		storeChan <- storeResult{
 			keys: keys,
@@ -127,9 +166,10 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		}
 	}()
 
-	err = s.node.ValidateBatchV2(ctx, batch, blobShards, operatorState)
+	err = s.node.ValidateBatchV2(ctx, batch, blobShards, operatorState, s.metrics.ReportStoreChunksStageLatency)
 	if err != nil {
 		res := <-storeChan
+		reservation.Release()
 		if len(res.keys) > 0 {
 			if deleteErr := s.node.StoreV2.DeleteKeys(res.keys); deleteErr != nil {
 				s.logger.Error("failed to delete keys", "err", deleteErr, "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]))
@@ -138,17 +178,32 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to validate batch: %v", err))
 	}
 
+	// The time spent waiting for the batch to be stored once it is validated
+	stageStart = time.Now()
 	res := <-storeChan
 	if res.err != nil {
+		reservation.Release()
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to store batch: %v", res.err))
 	}
+	if res.alreadyStored {
+		reservation.Release()
+	} else {
+		reservation.Commit()
+	}
+	s.metrics.ReportStoreChunksStageLatency("store_wait", time.Since(stageStart))
 
-	sig := s.node.KeyPair.SignMessage(batchHeaderHash).Bytes()
+	stageStart = time.Now()
+	sig, err := s.node.SignMessage(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to sign batch: %v", err))
+	}
+	s.metrics.ReportStoreChunksStageLatency("sign", time.Since(stageStart))
+	sigBytes := sig.Bytes()
 
 	s.metrics.ReportStoreChunksLatency(time.Since(start))
 
 	return &pb.StoreChunksReply{
-		Signature: sig[:],
+		Signature: sigBytes[:],
 	}, nil
 }
 
@@ -158,6 +213,11 @@ func (s *ServerV2) validateStoreChunksRequest(req *pb.StoreChunksRequest) (*core
 		return nil, api.NewErrorInvalidArg("missing batch in request")
 	}
 
+	// The chunks of a blob dispersed with other quorum profiles wouldn't match the assignments of the node
+	if !bytes.Equal(req.GetQuorumProfilesDigest(), s.quorumProfilesDigest) {
+		return nil, api.NewErrorInvalidArg("quorum profiles mismatch: the disperser and the node must use the same quorum profiles")
+	}
+
 	batch, err := corev2.BatchFromProtobuf(req.GetBatch())
 	if err != nil {
 		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to deserialize batch: %v", err))
@@ -166,8 +226,11 @@ func (s *ServerV2) validateStoreChunksRequest(req *pb.StoreChunksRequest) (*core
 	return batch, nil
 }
 
-func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.GetChunksReply, error) {
+func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (reply *pb.GetChunksReply, err error) {
 	start := time.Now()
+	defer func() {
+		s.node.Performance.RecordRetrieval("v2", err == nil, chunksSize(reply.GetChunks()))
+	}()
 
 	if !s.config.EnableV2 {
 		return nil, api.NewErrorInvalidArg("v2 API is disabled")
@@ -186,6 +249,18 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 		return nil, api.NewErrorInvalidArg("invalid quorum ID")
 	}
 	quorumID := core.QuorumID(in.GetQuorumId())
+
+	var clientID string
+	if s.node.RetrievalRateLimiter.Enabled() {
+		clientID, err = common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, false)
+		if err != nil {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to get client address: %v", err))
+		}
+	}
+	if err := s.node.RetrievalRateLimiter.BeginRequest(time.Now(), clientID); err != nil {
+		return nil, api.NewErrorResourceExhausted(err.Error())
+	}
+
 	chunks, err := s.node.StoreV2.GetChunks(blobKey, quorumID)
 	if err != nil {
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get chunks: %v", err))
@@ -196,6 +271,9 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 		size = len(chunks[0]) * len(chunks)
 	}
 	s.metrics.ReportGetChunksDataSize(size)
+	if err := s.node.RetrievalRateLimiter.RequestBandwidth(time.Now(), clientID, size); err != nil {
+		return nil, api.NewErrorResourceExhausted(err.Error())
+	}
 
 	s.metrics.ReportGetChunksLatency(time.Since(start))
 
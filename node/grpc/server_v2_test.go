@@ -3,6 +3,7 @@ package grpc_test
 import (
 	"context"
 	"errors"
+	"net"
 	"os"
 	"sync/atomic"
 	"testing"
@@ -20,6 +21,7 @@ import (
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigenda/node/grpc"
+	"github.com/Layr-Labs/eigenda/node/limiter"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,6 +29,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -144,6 +147,36 @@ func TestV2StoreChunksInputValidation(t *testing.T) {
 	requireErrorStatus(t, err, codes.InvalidArgument)
 }
 
+func TestV2StoreChunksQuorumProfilesMismatch(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	config.QuorumProfiles = core.BlobVersionQuorumProfiles{
+		0: {1: {CodingRate: 4, NumChunks: 32}},
+	}
+	c := newTestComponents(t, config)
+	_, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	// A disperser without quorum profiles
+	_, err = c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+	})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+	require.Contains(t, err.Error(), "quorum profiles mismatch")
+
+	// A disperser with other quorum profiles
+	_, err = c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+		QuorumProfilesDigest: core.BlobVersionQuorumProfiles{
+			0: {1: {CodingRate: 2, NumChunks: 16}},
+		}.Digest(),
+	})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+	require.Contains(t, err.Error(), "quorum profiles mismatch")
+	c.relayClient.AssertNotCalled(t, "GetChunksByRange")
+}
+
 func TestV2StoreChunksSuccess(t *testing.T) {
 	config := makeConfig(t)
 	config.EnableV2 = true
@@ -264,6 +297,48 @@ func TestV2StoreChunksStorageFailure(t *testing.T) {
 	requireErrorStatus(t, err, codes.Internal)
 }
 
+func TestV2StoreChunksAlreadyStored(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+
+	_, batch, bundles := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles01Bytes, err := bundles[0][1].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles11Bytes, err := bundles[1][1].Serialize()
+	require.NoError(t, err)
+	bundles21Bytes, err := bundles[2][1].Serialize()
+	require.NoError(t, err)
+	bundles22Bytes, err := bundles[2][2].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles01Bytes, bundles21Bytes, bundles22Bytes}, nil)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes, bundles11Bytes}, nil)
+	// the node stored the batch when it was first dispatched, but didn't get to respond
+	c.store.On("StoreBatch", batch, mock.Anything).Return(nil, node.ErrBatchAlreadyExist)
+	reply, err := c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+	})
+	require.NoError(t, err)
+	point, err := new(core.Signature).Deserialize(reply.GetSignature())
+	require.NoError(t, err)
+	sig := &core.Signature{G1Point: point}
+	bhh, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+	require.True(t, sig.Verify(c.node.KeyPair.GetPubKeyG2(), bhh))
+	c.validator.AssertCalled(t, "ValidateBlobs", mock.Anything, mock.Anything, mock.Anything)
+	c.store.AssertNotCalled(t, "DeleteKeys", mock.Anything)
+}
+
 func TestV2StoreChunksValidationFailure(t *testing.T) {
 	config := makeConfig(t)
 	config.EnableV2 = true
@@ -313,6 +388,44 @@ func TestV2StoreChunksValidationFailure(t *testing.T) {
 	c.store.AssertCalled(t, "DeleteKeys", mock.Anything, mock.Anything)
 }
 
+func TestV2StoreChunksQuotaExceeded(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	c.node.StorageQuotas = node.NewQuotaTracker(map[core.QuorumID]node.StorageQuota{
+		2: {HardLimitBytes: 1},
+	}, c.node.Logger, c.node.Metrics)
+
+	_, batch, bundles := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles01Bytes, err := bundles[0][1].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles11Bytes, err := bundles[1][1].Serialize()
+	require.NoError(t, err)
+	bundles21Bytes, err := bundles[2][1].Serialize()
+	require.NoError(t, err)
+	bundles22Bytes, err := bundles[2][2].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles01Bytes, bundles21Bytes, bundles22Bytes}, nil)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes, bundles11Bytes}, nil)
+	reply, err := c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+	require.ErrorContains(t, err, "quorum 2")
+	c.store.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+	require.Zero(t, c.node.StorageQuotas.Usage(0))
+}
+
 func TestV2GetChunksInputValidation(t *testing.T) {
 	config := makeConfig(t)
 	config.EnableV2 = true
@@ -334,6 +447,47 @@ func TestV2GetChunksInputValidation(t *testing.T) {
 	requireErrorStatus(t, err, codes.InvalidArgument)
 }
 
+func TestV2GetChunksRateLimit(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	rateLimiter, err := limiter.NewRetrievalRateLimiter(&limiter.Config{
+		MaxRequestsPerSecondClient: 0.001,
+		RequestBurstinessClient:    2,
+	}, nil)
+	require.NoError(t, err)
+	c.node.RetrievalRateLimiter = rateLimiter
+
+	blobKey := v2.BlobKey{1}
+	c.store.On("GetChunks", blobKey, core.QuorumID(0)).Return([][]byte{{1, 2, 3}}, nil)
+	newContext := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 3000},
+		})
+	}
+	req := &pbv2.GetChunksRequest{
+		BlobKey:  blobKey[:],
+		QuorumId: 0,
+	}
+
+	// The client can burst up to 2 requests
+	for i := 0; i < 2; i++ {
+		reply, err := c.server.GetChunks(newContext("1.1.1.1"), req)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{{1, 2, 3}}, reply.GetChunks())
+	}
+	_, err = c.server.GetChunks(newContext("1.1.1.1"), req)
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+
+	// Other clients have their own budget
+	_, err = c.server.GetChunks(newContext("2.2.2.2"), req)
+	require.NoError(t, err)
+
+	// The client is identified by its address
+	_, err = c.server.GetChunks(context.Background(), req)
+	requireErrorStatus(t, err, codes.InvalidArgument)
+}
+
 func requireErrorStatus(t *testing.T, err error, code codes.Code) {
 	require.Error(t, err)
 	s, ok := status.FromError(err)
@@ -0,0 +1,39 @@
+package limiter
+
+import "errors"
+
+// Config is the configuration for the per-client rate limiting of chunk retrievals on the node.
+type Config struct {
+
+	// MaxRequestsPerSecondClient is the maximum permitted number of chunk retrieval requests per second for a
+	// single client. Set to 0 to disable the request rate limit.
+	MaxRequestsPerSecondClient float64 `json:"max_requests_per_second_client"`
+	// The burstiness of the MaxRequestsPerSecondClient rate limiter. This is the maximum burst size that happen
+	// within a short time window. Defaults to MaxRequestsPerSecondClient (and at least 1) if not set.
+	RequestBurstinessClient int `json:"request_burstiness_client"`
+
+	// MaxBytesPerSecondClient is the maximum bandwidth, in bytes, that the chunk retrievals of a single client are
+	// permitted to consume per second. Set to 0 to disable the bandwidth limit.
+	MaxBytesPerSecondClient float64 `json:"max_bytes_per_second_client"`
+	// The burstiness of the MaxBytesPerSecondClient rate limiter. This is the maximum burst size that happen within
+	// a short time window. Responses larger than the burst are only served when the budget of the client is full.
+	// Defaults to MaxBytesPerSecondClient if not set.
+	BytesBurstinessClient int `json:"bytes_burstiness_client"`
+
+	// MaxClients is the maximum number of clients whose budgets are tracked. When exceeded, the budgets of the
+	// least recently seen clients are dropped. Default is 10000.
+	MaxClients int `json:"max_clients"`
+}
+
+// Enabled returns true if the config enables any of the retrieval rate limits.
+func (c *Config) Enabled() bool {
+	return c.MaxRequestsPerSecondClient > 0 || c.MaxBytesPerSecondClient > 0
+}
+
+// Verify returns an error if the config is invalid.
+func (c *Config) Verify() error {
+	if c.MaxRequestsPerSecondClient < 0 || c.MaxBytesPerSecondClient < 0 {
+		return errors.New("retrieval rate limits must not be negative")
+	}
+	return nil
+}
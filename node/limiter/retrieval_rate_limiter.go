@@ -0,0 +1,167 @@
+package limiter
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+const defaultMaxClients = 10000
+
+// RetrievalRateLimiter enforces per-client rate limits on the chunk retrievals served by the node, so that a single
+// retriever cannot saturate the egress of the operator. Clients are identified by their address. A nil
+// RetrievalRateLimiter does not enforce any limit. The limits can be changed while the node runs with UpdateConfig.
+type RetrievalRateLimiter struct {
+	config atomic.Pointer[Config]
+
+	// clients holds the limiters of the recently seen clients
+	clients *lru.Cache[string, *clientLimiters]
+
+	// rateLimited counts the rejected requests by reason
+	rateLimited *prometheus.CounterVec
+}
+
+// clientLimiters are the limiters of a single client. A nil limiter means the corresponding limit is disabled.
+type clientLimiters struct {
+	requests  *rate.Limiter
+	bandwidth *rate.Limiter
+}
+
+// NewRetrievalRateLimiter creates a new RetrievalRateLimiter. The registry is optional, and is used to report the
+// rate limited requests.
+func NewRetrievalRateLimiter(config *Config, registry *prometheus.Registry) (*RetrievalRateLimiter, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	clients, err := lru.New[string, *clientLimiters](maxClients(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client cache: %w", err)
+	}
+
+	var rateLimited *prometheus.CounterVec
+	if registry != nil {
+		rateLimited = promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "eigenda_node",
+				Name:      "retrieval_rate_limited_total",
+				Help:      "The number of chunk retrieval requests rejected by the per-client rate limits.",
+			},
+			[]string{"reason"},
+		)
+	}
+
+	l := &RetrievalRateLimiter{
+		clients:     clients,
+		rateLimited: rateLimited,
+	}
+	configCopy := *config
+	l.config.Store(&configCopy)
+	return l, nil
+}
+
+// Enabled returns true if any of the retrieval rate limits is enforced.
+func (l *RetrievalRateLimiter) Enabled() bool {
+	return l != nil && l.config.Load().Enabled()
+}
+
+// Config returns the current configuration of the rate limiter.
+func (l *RetrievalRateLimiter) Config() Config {
+	return *l.config.Load()
+}
+
+// UpdateConfig changes the limits enforced by the rate limiter. The budgets of the clients are reset to the burst
+// of the new limits.
+func (l *RetrievalRateLimiter) UpdateConfig(config *Config) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+	configCopy := *config
+	l.config.Store(&configCopy)
+	l.clients.Purge()
+	l.clients.Resize(maxClients(config))
+	return nil
+}
+
+func maxClients(config *Config) int {
+	if config.MaxClients <= 0 {
+		return defaultMaxClients
+	}
+	return config.MaxClients
+}
+
+// BeginRequest should be called when a client starts a chunk retrieval request. If it returns an error, the request
+// should be rejected.
+func (l *RetrievalRateLimiter) BeginRequest(now time.Time, clientID string) error {
+	if !l.Enabled() {
+		return nil
+	}
+
+	limiter := l.getClientLimiters(clientID).requests
+	if limiter != nil && !limiter.AllowN(now, 1) {
+		l.reportRateLimited("client rate")
+		return fmt.Errorf("client rate limit %0.1fhz exceeded for chunk retrievals, try again later",
+			float64(limiter.Limit()))
+	}
+	return nil
+}
+
+// RequestBandwidth should be called before the retrieved chunks are sent to the client. If it returns an error, the
+// chunks should not be sent. Responses larger than the bandwidth burst consume the whole budget of the client.
+func (l *RetrievalRateLimiter) RequestBandwidth(now time.Time, clientID string, bytes int) error {
+	if !l.Enabled() {
+		return nil
+	}
+
+	limiter := l.getClientLimiters(clientID).bandwidth
+	if limiter == nil {
+		return nil
+	}
+	if !limiter.AllowN(now, min(bytes, limiter.Burst())) {
+		l.reportRateLimited("client bandwidth")
+		return fmt.Errorf("client rate limit %dKiB/s exceeded for chunk retrieval bandwidth, try again later",
+			int(limiter.Limit()/1024))
+	}
+	return nil
+}
+
+func (l *RetrievalRateLimiter) getClientLimiters(clientID string) *clientLimiters {
+	if limiters, ok := l.clients.Get(clientID); ok {
+		return limiters
+	}
+
+	config := l.config.Load()
+	limiters := &clientLimiters{}
+	if config.MaxRequestsPerSecondClient > 0 {
+		burst := config.RequestBurstinessClient
+		if burst <= 0 {
+			burst = max(1, int(math.Ceil(config.MaxRequestsPerSecondClient)))
+		}
+		limiters.requests = rate.NewLimiter(rate.Limit(config.MaxRequestsPerSecondClient), burst)
+	}
+	if config.MaxBytesPerSecondClient > 0 {
+		burst := config.BytesBurstinessClient
+		if burst <= 0 {
+			burst = max(1, int(config.MaxBytesPerSecondClient))
+		}
+		limiters.bandwidth = rate.NewLimiter(rate.Limit(config.MaxBytesPerSecondClient), burst)
+	}
+
+	// Another request of the same client may have raced us, in which case its limiters win.
+	if existing, ok, _ := l.clients.PeekOrAdd(clientID, limiters); ok {
+		return existing
+	}
+	return limiters
+}
+
+func (l *RetrievalRateLimiter) reportRateLimited(reason string) {
+	if l.rateLimited != nil {
+		l.rateLimited.WithLabelValues(reason).Inc()
+	}
+}
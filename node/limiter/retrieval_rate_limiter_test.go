@@ -0,0 +1,144 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrievalRateLimiterDisabled(t *testing.T) {
+	rateLimiter, err := NewRetrievalRateLimiter(&Config{}, nil)
+	require.NoError(t, err)
+	require.False(t, rateLimiter.Enabled())
+
+	// A disabled or nil rate limiter doesn't enforce any limit
+	now := time.Now()
+	for _, rateLimiter := range []*RetrievalRateLimiter{rateLimiter, nil} {
+		for i := 0; i < 100; i++ {
+			require.NoError(t, rateLimiter.BeginRequest(now, "client"))
+			require.NoError(t, rateLimiter.RequestBandwidth(now, "client", 1024*1024))
+		}
+	}
+
+	_, err = NewRetrievalRateLimiter(&Config{MaxBytesPerSecondClient: -1}, nil)
+	require.Error(t, err)
+}
+
+func TestRetrievalRequestRateLimit(t *testing.T) {
+	rateLimiter, err := NewRetrievalRateLimiter(&Config{
+		MaxRequestsPerSecondClient: 2,
+		RequestBurstinessClient:    4,
+	}, nil)
+	require.NoError(t, err)
+
+	// time starts at current time, but advances manually afterward
+	now := time.Now()
+
+	// The client can burst up to the burstiness
+	for i := 0; i < 4; i++ {
+		require.NoError(t, rateLimiter.BeginRequest(now, "client"))
+	}
+	require.Error(t, rateLimiter.BeginRequest(now, "client"))
+
+	// Other clients are not affected
+	require.NoError(t, rateLimiter.BeginRequest(now, "other"))
+
+	// The budget refills at the configured rate
+	now = now.Add(time.Second)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, rateLimiter.BeginRequest(now, "client"))
+	}
+	require.Error(t, rateLimiter.BeginRequest(now, "client"))
+
+	// The bandwidth is not limited
+	require.NoError(t, rateLimiter.RequestBandwidth(now, "client", 1024*1024))
+}
+
+func TestRetrievalBandwidthLimit(t *testing.T) {
+	rateLimiter, err := NewRetrievalRateLimiter(&Config{
+		MaxBytesPerSecondClient: 1024,
+		BytesBurstinessClient:   2048,
+	}, nil)
+	require.NoError(t, err)
+
+	// time starts at current time, but advances manually afterward
+	now := time.Now()
+
+	require.NoError(t, rateLimiter.RequestBandwidth(now, "client", 1500))
+	require.Error(t, rateLimiter.RequestBandwidth(now, "client", 1000))
+	require.NoError(t, rateLimiter.RequestBandwidth(now, "client", 500))
+	require.NoError(t, rateLimiter.RequestBandwidth(now, "other", 2048))
+
+	// Responses larger than the burst are served when the budget of the client is full
+	now = now.Add(time.Second)
+	require.Error(t, rateLimiter.RequestBandwidth(now, "client", 4096))
+	now = now.Add(time.Second)
+	require.NoError(t, rateLimiter.RequestBandwidth(now, "client", 4096))
+	require.Error(t, rateLimiter.RequestBandwidth(now, "client", 1))
+
+	// The request rate is not limited
+	for i := 0; i < 100; i++ {
+		require.NoError(t, rateLimiter.BeginRequest(now, "client"))
+	}
+}
+
+func TestRetrievalRateLimiterMaxClients(t *testing.T) {
+	rateLimiter, err := NewRetrievalRateLimiter(&Config{
+		MaxRequestsPerSecondClient: 1,
+		RequestBurstinessClient:    1,
+		MaxClients:                 2,
+	}, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, rateLimiter.BeginRequest(now, "a"))
+	require.Error(t, rateLimiter.BeginRequest(now, "a"))
+	require.NoError(t, rateLimiter.BeginRequest(now, "b"))
+	require.NoError(t, rateLimiter.BeginRequest(now, "c"))
+
+	// The budget of the least recently seen client has been dropped
+	require.NoError(t, rateLimiter.BeginRequest(now, "a"))
+}
+
+func TestRetrievalRateLimiterUpdateConfig(t *testing.T) {
+	rateLimiter, err := NewRetrievalRateLimiter(&Config{}, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, rateLimiter.BeginRequest(now, "client"))
+
+	// Enabling the limits applies them to all clients
+	err = rateLimiter.UpdateConfig(&Config{
+		MaxRequestsPerSecondClient: 1,
+		RequestBurstinessClient:    2,
+	})
+	require.NoError(t, err)
+	require.True(t, rateLimiter.Enabled())
+	require.Equal(t, 2, rateLimiter.Config().RequestBurstinessClient)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, rateLimiter.BeginRequest(now, "client"))
+	}
+	require.Error(t, rateLimiter.BeginRequest(now, "client"))
+
+	// Changing the limits resets the budgets of the clients
+	err = rateLimiter.UpdateConfig(&Config{
+		MaxRequestsPerSecondClient: 1,
+		RequestBurstinessClient:    3,
+	})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, rateLimiter.BeginRequest(now, "client"))
+	}
+	require.Error(t, rateLimiter.BeginRequest(now, "client"))
+
+	// Invalid limits are rejected and leave the limits unchanged
+	err = rateLimiter.UpdateConfig(&Config{MaxRequestsPerSecondClient: -1})
+	require.Error(t, err)
+	require.Error(t, rateLimiter.BeginRequest(now, "client"))
+
+	// Disabling the limits stops enforcing them
+	err = rateLimiter.UpdateConfig(&Config{})
+	require.NoError(t, err)
+	require.NoError(t, rateLimiter.BeginRequest(now, "client"))
+}
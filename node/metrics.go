@@ -48,6 +48,34 @@ type Metrics struct {
 	ReachabilityGauge *prometheus.GaugeVec
 	// The throughput (bytes per second) at which the data is written to database.
 	DBWriteThroughput prometheus.Gauge
+	// Accumulated number of database compactions, by store and status.
+	AccuDBCompactions *prometheus.CounterVec
+	// Accumulated number of bytes of disk space reclaimed by database compactions, by store.
+	AccuDBReclaimedBytes *prometheus.CounterVec
+	// The size (in bytes) of the database on disk, by store.
+	DBSize *prometheus.GaugeVec
+	// The latency (in ms) of database compactions, by store.
+	DBCompactionLatency *prometheus.SummaryVec
+	// Whether the disk of a chunk store path is healthy (1) or not (0), by path.
+	ChunkStoreHealthy *prometheus.GaugeVec
+	// The free space (in bytes) of the disk of a chunk store path, by path.
+	ChunkStoreFreeBytes *prometheus.GaugeVec
+	// Accumulated number of BLS signings, by signer and status.
+	AccuSignings *prometheus.CounterVec
+	// The latency (in ms) of BLS signings, by signer.
+	SigningLatency *prometheus.SummaryVec
+	// Whether the BLS remote signer is healthy (1) or not (0), as of the last health check.
+	RemoteSignerHealthy prometheus.Gauge
+	// Accumulated number of stored bundles re-verified by the chunk scrubber, by status.
+	AccuScrubbedBundles *prometheus.CounterVec
+	// The disk space (in bytes) used by the v2 bundles of a quorum, by quorum.
+	QuorumStorageUsage *prometheus.GaugeVec
+	// The storage quota (in bytes) of a quorum, by quorum and limit (soft or hard).
+	QuorumStorageQuota *prometheus.GaugeVec
+	// Whether the storage used by a quorum exceeds its quota (1) or not (0), by quorum and limit (soft or hard).
+	QuorumStorageQuotaExceeded *prometheus.GaugeVec
+	// Accumulated number of batches rejected because they would exceed the hard storage quota of a quorum, by quorum.
+	AccuQuotaRejectedBatches *prometheus.CounterVec
 
 	registry *prometheus.Registry
 	// socketAddr is the address at which the metrics server will be listening.
@@ -157,6 +185,120 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the throughput (bytes per second) at which the data is written to database",
 			},
 		),
+		// The "status" label has values: success, failure.
+		AccuDBCompactions: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_db_compactions_total",
+				Help:      "the total number of database compactions run by the DA node",
+			},
+			[]string{"store", "status"},
+		),
+		AccuDBReclaimedBytes: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_db_reclaimed_bytes_total",
+				Help:      "the total number of bytes of disk space reclaimed by database compactions",
+			},
+			[]string{"store"},
+		),
+		DBSize: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "db_size_bytes",
+				Help:      "the size (in bytes) of the database on disk, as of the last compaction",
+			},
+			[]string{"store"},
+		),
+		DBCompactionLatency: promauto.With(reg).NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  Namespace,
+				Name:       "db_compaction_latency_ms",
+				Help:       "latency summary in milliseconds of database compactions",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"store"},
+		),
+		ChunkStoreHealthy: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "chunk_store_healthy",
+				Help:      "whether the disk of the chunk store path is healthy (1) or not (0)",
+			},
+			[]string{"path"},
+		),
+		ChunkStoreFreeBytes: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "chunk_store_free_bytes",
+				Help:      "the free space (in bytes) of the disk of the chunk store path",
+			},
+			[]string{"path"},
+		),
+		AccuSignings: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_signings_total",
+				Help:      "the total number of BLS signings, by signer (local or remote) and status",
+			},
+			[]string{"signer", "status"},
+		),
+		SigningLatency: promauto.With(reg).NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  Namespace,
+				Name:       "signing_latency_ms",
+				Help:       "latency summary in milliseconds of BLS signings, by signer (local or remote)",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"signer"},
+		),
+		RemoteSignerHealthy: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "remote_signer_healthy",
+				Help:      "whether the BLS remote signer is healthy (1) or not (0), as of the last health check",
+			},
+		),
+		AccuScrubbedBundles: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "scrubbed_bundles_total",
+				Help:      "the number of stored bundles re-verified by the chunk scrubber, by status (valid, corrupted, repaired, repair_failed)",
+			},
+			[]string{"status"},
+		),
+		QuorumStorageUsage: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "quorum_storage_usage_bytes",
+				Help:      "the disk space in bytes used by the v2 bundles of a quorum",
+			},
+			[]string{"quorum"},
+		),
+		QuorumStorageQuota: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "quorum_storage_quota_bytes",
+				Help:      "the storage quota in bytes of a quorum, by limit (soft or hard), 0 if unlimited",
+			},
+			[]string{"quorum", "limit"},
+		),
+		QuorumStorageQuotaExceeded: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "quorum_storage_quota_exceeded",
+				Help:      "whether the storage used by a quorum exceeds its quota (1) or not (0), by limit (soft or hard)",
+			},
+			[]string{"quorum", "limit"},
+		),
+		AccuQuotaRejectedBatches: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "quota_rejected_batches_total",
+				Help:      "the number of batches rejected because they would exceed the hard storage quota of a quorum",
+			},
+			[]string{"quorum"},
+		),
 
 		EigenMetrics:           eigenMetrics,
 		logger:                 logger.With("component", "NodeMetrics"),
@@ -223,6 +365,69 @@ func (g *Metrics) RecordStoreChunksStage(stage string, dataSize uint64, latency
 	g.ObserveLatency("StoreChunks", stage, float64(latency.Milliseconds()))
 }
 
+func (g *Metrics) RecordCompaction(store string, sizeBefore uint64, sizeAfter uint64, latency time.Duration) {
+	g.AccuDBCompactions.WithLabelValues(store, "success").Inc()
+	if sizeBefore > sizeAfter {
+		g.AccuDBReclaimedBytes.WithLabelValues(store).Add(float64(sizeBefore - sizeAfter))
+	}
+	g.DBSize.WithLabelValues(store).Set(float64(sizeAfter))
+	g.DBCompactionLatency.WithLabelValues(store).Observe(float64(latency.Milliseconds()))
+}
+
+func (g *Metrics) RecordCompactionFailure(store string) {
+	g.AccuDBCompactions.WithLabelValues(store, "failure").Inc()
+}
+
+func (g *Metrics) RecordChunkStoreHealth(health ChunkShardHealth) {
+	healthy := 0.0
+	if health.Healthy {
+		healthy = 1
+		g.ChunkStoreFreeBytes.WithLabelValues(health.Path).Set(float64(health.FreeBytes))
+	}
+	g.ChunkStoreHealthy.WithLabelValues(health.Path).Set(healthy)
+}
+
+func (g *Metrics) RecordSigning(signer string, success bool, latency time.Duration) {
+	status := "success"
+	if !success {
+		status = "failure"
+	}
+	g.AccuSignings.WithLabelValues(signer, status).Inc()
+	g.SigningLatency.WithLabelValues(signer).Observe(float64(latency.Milliseconds()))
+}
+
+func (g *Metrics) RecordRemoteSignerHealth(healthy bool) {
+	if healthy {
+		g.RemoteSignerHealthy.Set(1)
+	} else {
+		g.RemoteSignerHealthy.Set(0)
+	}
+}
+
+func (g *Metrics) RecordScrubbedBundle(status string) {
+	g.AccuScrubbedBundles.WithLabelValues(status).Inc()
+}
+
+func (g *Metrics) RecordQuorumStorage(quorumId core.QuorumID, usage uint64, quota StorageQuota) {
+	quorum := strconv.Itoa(int(quorumId))
+	g.QuorumStorageUsage.WithLabelValues(quorum).Set(float64(usage))
+	g.QuorumStorageQuota.WithLabelValues(quorum, "soft").Set(float64(quota.SoftLimitBytes))
+	g.QuorumStorageQuota.WithLabelValues(quorum, "hard").Set(float64(quota.HardLimitBytes))
+	softExceeded, hardExceeded := 0.0, 0.0
+	if quota.SoftLimitBytes > 0 && usage > quota.SoftLimitBytes {
+		softExceeded = 1
+	}
+	if quota.HardLimitBytes > 0 && usage >= quota.HardLimitBytes {
+		hardExceeded = 1
+	}
+	g.QuorumStorageQuotaExceeded.WithLabelValues(quorum, "soft").Set(softExceeded)
+	g.QuorumStorageQuotaExceeded.WithLabelValues(quorum, "hard").Set(hardExceeded)
+}
+
+func (g *Metrics) RecordQuotaRejection(quorumId core.QuorumID) {
+	g.AccuQuotaRejectedBatches.WithLabelValues(strconv.Itoa(int(quorumId))).Inc()
+}
+
 func (g *Metrics) collectOnchainMetrics() {
 	ticker := time.NewTicker(time.Duration(g.onchainMetricsInterval) * time.Second)
 	defer ticker.Stop()
@@ -13,14 +13,18 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	"github.com/Layr-Labs/eigenda/common/pprof"
 	"github.com/Layr-Labs/eigenda/common/pubip"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -34,12 +38,14 @@ import (
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node/limiter"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	rpccalls "github.com/Layr-Labs/eigensdk-go/metrics/collectors/rpc_calls"
@@ -78,12 +84,30 @@ type Node struct {
 	OperatorSocketsFilterer indexer.OperatorSocketsFilterer
 	ChainID                 *big.Int
 	BLSSigner               blssignerV1.SignerClient
+	// RetrievalRateLimiter enforces the per-client limits on chunk retrievals.
+	RetrievalRateLimiter *limiter.RetrievalRateLimiter
+	// Performance tracks the batches signed and the chunks stored and served by the node.
+	Performance *PerformanceTracker
+	// StorageQuotas enforces the storage quotas of the quorums on the v2 batches stored by the node.
+	StorageQuotas *QuotaTracker
+	// ValidationPoolV2 and DeserializationPoolV2 are shared by the v2 batches being validated, so that the CPU used by
+	// concurrent batches is bounded. Each batch uses its own pools if nil.
+	ValidationPoolV2      common.WorkerPool
+	DeserializationPoolV2 common.WorkerPool
+	// ChunkVerifier verifies the chunks stored by the node against the commitments of their blobs when scrubbing them
+	ChunkVerifier encoding.Verifier
 
 	RelayClient atomic.Value
 
 	mu            sync.Mutex
 	CurrentSocket string
 
+	// reloadMu serializes the reloads of the node config
+	reloadMu sync.Mutex
+	// quorumIDsMu guards Config.QuorumIDList, which can be changed by a reload while the node runs. Unlike reloadMu,
+	// it is never held during chain calls, so reading the quorums doesn't wait for a reload to opt into quorums.
+	quorumIDsMu sync.RWMutex
+
 	// BlobVersionParams is a map of blob version parameters loaded from the chain.
 	// It is used to determine blob parameters based on the version number.
 	BlobVersionParams atomic.Pointer[corev2.BlobVersionParameterMap]
@@ -128,6 +152,7 @@ func NewNode(
 	if err != nil {
 		return nil, err
 	}
+	tx.SetQuorumProfiles(config.QuorumProfiles)
 
 	// Create ChainState Client
 	cst := eth.NewChainState(tx, client)
@@ -209,11 +234,23 @@ func NewNode(
 		storeDurationBlocks = storeDuration
 	}
 	// Create new store
-	store, err := NewLevelDBStore(config.DbPath+"/chunk", logger, metrics, blockStaleMeasure, storeDurationBlocks)
+	if config.DbBackend == PebbleDBBackend {
+		_, levelDBErr := os.Stat(StorePath(config.DbPath, LevelDBBackend))
+		_, pebbleErr := os.Stat(StorePath(config.DbPath, PebbleDBBackend))
+		if levelDBErr == nil && os.IsNotExist(pebbleErr) {
+			logger.Warn("The node has LevelDB data that has not been migrated to Pebble, and will start with an empty Pebble store", "dbPath", config.DbPath)
+		}
+	}
+	store, err := NewStore(config.DbBackend, StorePath(config.DbPath, config.DbBackend), logger, metrics, blockStaleMeasure, storeDurationBlocks)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new store: %w", err)
 	}
 
+	retrievalRateLimiter, err := limiter.NewRetrievalRateLimiter(&config.RetrievalRateLimits, reg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create retrieval rate limiter: %w", err)
+	}
+
 	eigenDAServiceManagerAddr := gethcommon.HexToAddress(config.EigenDAServiceManagerAddr)
 	socketsFilterer, err := indexer.NewOperatorSocketsFilterer(eigenDAServiceManagerAddr, client)
 	if err != nil {
@@ -236,10 +273,14 @@ func NewNode(
 		Transactor:              tx,
 		Validator:               validator,
 		ValidatorV2:             validatorV2,
+		ChunkVerifier:           v,
 		PubIPProvider:           pubIPProvider,
 		OperatorSocketsFilterer: socketsFilterer,
 		ChainID:                 chainID,
 		BLSSigner:               blsClient,
+		RetrievalRateLimiter:    retrievalRateLimiter,
+		Performance:             NewPerformanceTracker(),
+		StorageQuotas:           NewQuotaTracker(config.StorageQuotas, nodeLogger, metrics),
 	}
 
 	if !config.EnableV2 {
@@ -249,20 +290,39 @@ func NewNode(
 	var storeV2 StoreV2
 	var blobVersionParams *corev2.BlobVersionParameterMap
 	if config.EnableV2 {
-		v2Path := config.DbPath + "/chunk_v2"
-		dbV2, err := tablestore.Start(logger, &tablestore.Config{
-			Type:                       tablestore.LevelDB,
-			Path:                       &v2Path,
-			GarbageCollectionEnabled:   true,
-			GarbageCollectionInterval:  time.Duration(config.ExpirationPollIntervalSec) * time.Second,
-			GarbageCollectionBatchSize: 1024,
-			Schema:                     []string{BatchHeaderTableName, BlobCertificateTableName, BundleTableName},
-		})
+		dbV2, err := startTableStore(logger, config, config.DbPath,
+			[]string{BatchHeaderTableName, BlobCertificateTableName, BundleTableName, BundleSizeTableName})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create new tablestore: %w", err)
 		}
-		timeToExpire := (blockStaleMeasure + storeDurationBlocks) * 12 // 12s per block
-		storeV2 = NewLevelDBStoreV2(dbV2, logger, time.Duration(timeToExpire)*time.Second)
+		n.ValidationPoolV2 = workerpool.New(config.V2NumBatchValidators)
+		n.DeserializationPoolV2 = workerpool.New(config.NumBatchDeserializationWorkers)
+
+		timeToExpire := time.Duration((blockStaleMeasure+storeDurationBlocks)*12) * time.Second // 12s per block
+		if len(config.ChunkStorePaths) == 0 {
+			store := NewLevelDBStoreV2(dbV2, logger, timeToExpire)
+			if err = store.BackfillBundleSizes(); err != nil {
+				return nil, fmt.Errorf("failed to backfill bundle sizes: %w", err)
+			}
+			storeV2 = store
+		} else {
+			shards := make([]*ChunkShard, len(config.ChunkStorePaths))
+			for i, path := range config.ChunkStorePaths {
+				db, err := startTableStore(logger, config, path, []string{BundleTableName})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create chunk store at %s: %w", path, err)
+				}
+				shards[i] = NewChunkShard(path, db, logger, timeToExpire)
+			}
+			store, err := NewShardedStoreV2(dbV2, shards, logger, timeToExpire)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create sharded chunk store: %w", err)
+			}
+			if err = store.BackfillBundleSizes(); err != nil {
+				return nil, fmt.Errorf("failed to backfill bundle sizes: %w", err)
+			}
+			storeV2 = store
+		}
 
 		blobParams, err := tx.GetAllVersionedBlobParams(context.Background())
 		if err != nil {
@@ -296,6 +356,60 @@ func NewNode(
 	return n, nil
 }
 
+// startTableStore starts the v2 table store with the given tables in the dbPath.
+func startTableStore(logger logging.Logger, config *Config, dbPath string, schema []string) (kvstore.TableStore, error) {
+	path := StoreV2Path(dbPath, config.DbBackend)
+	return tablestore.Start(logger, &tablestore.Config{
+		Type:                       tableStoreType(config.DbBackend),
+		Path:                       &path,
+		GarbageCollectionEnabled:   true,
+		GarbageCollectionInterval:  time.Duration(config.ExpirationPollIntervalSec) * time.Second,
+		GarbageCollectionBatchSize: 1024,
+		Schema:                     schema,
+	})
+}
+
+// chunkStoreHealthLoop periodically checks the disks of the chunk stores, so that bundles aren't written to a
+// failing disk.
+func (n *Node) chunkStoreHealthLoop(ctx context.Context, store *shardedStoreV2) {
+	ticker := time.NewTicker(n.Config.ChunkStoreHealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, health := range store.CheckHealth() {
+			if n.Metrics != nil {
+				n.Metrics.RecordChunkStoreHealth(health)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// rebalanceLoop rebalances the bundles across the chunk stores, retrying at every health check until all bundles are
+// in their owning shard, e.g. once an unhealthy disk recovered.
+func (n *Node) rebalanceLoop(ctx context.Context, store *shardedStoreV2) {
+	ticker := time.NewTicker(n.Config.ChunkStoreHealthCheckInterval)
+	defer ticker.Stop()
+
+	layoutPath := filepath.Join(n.Config.DbPath, chunkShardLayoutFile)
+	for {
+		err := store.Rebalance(ctx, layoutPath)
+		if err == nil {
+			return
+		}
+		n.Logger.Warn("Failed to rebalance bundles across chunk stores, retrying", "err", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // Start starts the Node. If the node is not registered, register it on chain, otherwise just
 // update its socket on chain.
 func (n *Node) Start(ctx context.Context) error {
@@ -314,34 +428,70 @@ func (n *Node) Start(ctx context.Context) error {
 	}
 
 	go n.expireLoop()
+	if n.Config.CompactionInterval > 0 {
+		go n.compactionLoop(ctx)
+	}
 	go n.checkNodeReachability()
+	go n.reloadOnSignal(ctx)
+	if n.Config.BLSRemoteSignerEnabled {
+		if err := n.CheckRemoteSigner(ctx); err != nil {
+			n.Logger.Error("The BLS remote signer is unhealthy, the node can't sign batches until it is fixed",
+				"url", n.Config.BLSRemoteSignerUrl, "err", err)
+		}
+		if n.Config.BLSSignerHealthCheckInterval > 0 {
+			go n.remoteSignerHealthLoop(ctx)
+		}
+	}
+	if sharded, ok := n.StoreV2.(*shardedStoreV2); ok {
+		go n.chunkStoreHealthLoop(ctx, sharded)
+		go n.rebalanceLoop(ctx, sharded)
+	}
 
 	if n.Config.EnableV2 {
 		go func() {
 			_ = n.RefreshOnchainState(ctx)
 		}()
 	}
+	if n.Config.EnableV2 && len(n.Config.StorageQuotas) > 0 {
+		inspector, ok := n.StoreV2.(StoreV2Inspector)
+		if !ok {
+			return errors.New("the v2 store does not report its storage usage, which the storage quotas require")
+		}
+		go n.storageUsageLoop(ctx, inspector)
+	}
+	if n.Config.EnableV2 && n.Config.ChunkScrubberRate > 0 {
+		if store, ok := n.StoreV2.(ScrubbableStoreV2); ok {
+			go n.scrubLoop(ctx, store)
+		} else {
+			n.Logger.Warn("The v2 store does not support scrubbing, the chunk scrubber is disabled")
+		}
+	}
+	if n.Config.EnableV2 && n.Config.AdminApiPort != "" {
+		inspector, ok := n.StoreV2.(StoreV2Inspector)
+		if !ok {
+			return errors.New("the v2 store does not support inspection by the admin API")
+		}
+		adminServer, err := NewAdminServer(n.Config.AdminApiPort, n.Config.AdminApiToken, inspector, n, n, n, n.StorageQuotas, n.Logger)
+		if err != nil {
+			return fmt.Errorf("failed to create admin server: %w", err)
+		}
+		if err := adminServer.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start admin server: %w", err)
+		}
+	}
 
 	// Build the socket based on the hostname/IP provided in the CLI
-	socket := string(core.MakeOperatorSocket(n.Config.Hostname, n.Config.DispersalPort, n.Config.RetrievalPort))
+	socket := string(core.MakeOperatorSocketWithV2Ports(n.Config.Hostname, n.Config.DispersalPort, n.Config.RetrievalPort,
+		n.Config.V2DispersalPort, n.Config.V2RetrievalPort))
 	var operator *Operator
 	if n.Config.RegisterNodeAtStart {
 		n.Logger.Info("Registering node on chain with the following parameters:", "operatorId",
 			n.Config.ID.Hex(), "hostname", n.Config.Hostname, "dispersalPort", n.Config.DispersalPort,
-			"retrievalPort", n.Config.RetrievalPort, "churnerUrl", n.Config.ChurnerUrl, "quorumIds", fmt.Sprint(n.Config.QuorumIDList))
-		privateKey, err := crypto.HexToECDSA(n.Config.EthClientConfig.PrivateKeyString)
+			"retrievalPort", n.Config.RetrievalPort, "churnerUrl", n.Config.ChurnerUrl, "quorumIds", fmt.Sprint(n.QuorumIDs()))
+		var err error
+		operator, err = n.newOperator(socket, n.QuorumIDs())
 		if err != nil {
-			return fmt.Errorf("NewClient: cannot parse private key: %w", err)
-		}
-		operator = &Operator{
-			Address:             crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
-			Socket:              socket,
-			Timeout:             10 * time.Second,
-			PrivKey:             privateKey,
-			KeyPair:             n.KeyPair,
-			OperatorId:          n.Config.ID,
-			QuorumIDs:           n.Config.QuorumIDList,
-			RegisterNodeAtStart: n.Config.RegisterNodeAtStart,
+			return err
 		}
 		churnerClient := NewChurnerClient(n.Config.ChurnerUrl, n.Config.UseSecureGrpc, n.Config.Timeout, n.Logger)
 		err = RegisterOperator(ctx, operator, n.Transactor, churnerClient, n.Logger)
@@ -438,43 +588,68 @@ func (n *Node) RefreshOnchainState(ctx context.Context) error {
 				n.Logger.Error("error fetching blob params", "err", err)
 			}
 
-			existingRelayClient, ok := n.RelayClient.Load().(clients.RelayClient)
-			if !ok {
-				n.Logger.Error("error fetching relay client")
-				continue
+			if err := n.refreshRelayClient(ctx); err != nil {
+				n.Logger.Error("error refreshing relay client", "err", err)
 			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
 
-			existingURLs := map[v2.RelayKey]string{}
-			if existingRelayClient != nil {
-				existingURLs = existingRelayClient.GetSockets()
-			}
-			relayURLs, err := n.Transactor.GetRelayURLs(ctx)
-			if err != nil {
-				n.Logger.Error("error fetching relay URLs", "err", err)
-				continue
-			}
+// refreshRelayClient fetches the relay URLs from the chain, and replaces the relay client if they changed.
+func (n *Node) refreshRelayClient(ctx context.Context) error {
+	existingRelayClient, ok := n.RelayClient.Load().(clients.RelayClient)
+	if !ok {
+		return errors.New("error fetching relay client")
+	}
 
-			if maps.Equal(existingURLs, relayURLs) {
-				n.Logger.Info("No change in relay URLs")
-				continue
-			}
+	existingURLs := map[v2.RelayKey]string{}
+	if existingRelayClient != nil {
+		existingURLs = existingRelayClient.GetSockets()
+	}
+	relayURLs, err := n.Transactor.GetRelayURLs(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching relay URLs: %w", err)
+	}
 
-			relayClient, err := clients.NewRelayClient(&clients.RelayClientConfig{
-				Sockets:           relayURLs,
-				UseSecureGrpcFlag: n.Config.UseSecureGrpc,
-				OperatorID:        &n.Config.ID,
-				MessageSigner:     n.SignMessage,
-			}, n.Logger)
-			if err != nil {
-				n.Logger.Error("error creating relay client", "err", err)
-				continue
-			}
+	if maps.Equal(existingURLs, relayURLs) {
+		n.Logger.Info("No change in relay URLs")
+		return nil
+	}
 
-			n.RelayClient.Store(clients.RelayClient(relayClient))
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+	relayClient, err := clients.NewRelayClient(&clients.RelayClientConfig{
+		Sockets:           relayURLs,
+		UseSecureGrpcFlag: n.Config.UseSecureGrpc,
+		OperatorID:        &n.Config.ID,
+		MessageSigner:     n.SignMessage,
+	}, n.Logger)
+	if err != nil {
+		return fmt.Errorf("error creating relay client: %w", err)
 	}
+
+	n.Logger.Info("Relay URLs changed, replacing the relay client", "relayURLs", relayURLs)
+	n.RelayClient.Store(clients.RelayClient(relayClient))
+	return nil
+}
+
+// newOperator returns the operator of the node with the given socket and quorums, which can be used to register the
+// node on chain. The node must be configured with the private key of the operator.
+func (n *Node) newOperator(socket string, quorumIDs []core.QuorumID) (*Operator, error) {
+	privateKey, err := crypto.HexToECDSA(n.Config.EthClientConfig.PrivateKeyString)
+	if err != nil {
+		return nil, fmt.Errorf("NewClient: cannot parse private key: %w", err)
+	}
+	return &Operator{
+		Address:             crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+		Socket:              socket,
+		Timeout:             10 * time.Second,
+		PrivKey:             privateKey,
+		KeyPair:             n.KeyPair,
+		OperatorId:          n.Config.ID,
+		QuorumIDs:           quorumIDs,
+		RegisterNodeAtStart: n.Config.RegisterNodeAtStart,
+	}, nil
 }
 
 // ProcessBatch validates the batch is correct, stores data into the node's Store, and then returns a signature for the entire batch.
@@ -595,31 +770,6 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 	return signature, nil
 }
 
-func (n *Node) SignMessage(ctx context.Context, data [32]byte) (*core.Signature, error) {
-	if n.Config.BLSRemoteSignerEnabled {
-		sigResp, err := n.BLSSigner.SignGeneric(
-			ctx,
-			&blssignerV1.SignGenericRequest{
-				PublicKey: n.Config.BLSPublicKeyHex,
-				Password:  n.Config.BLSKeyPassword,
-				Data:      data[:],
-			},
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to sign data: %w", err)
-		}
-		sig := new(core.Signature)
-		g, err := sig.Deserialize(sigResp.Signature)
-		if err != nil {
-			return nil, fmt.Errorf("failed to deserialize signature: %w", err)
-		}
-		return &core.Signature{
-			G1Point: g,
-		}, nil
-	}
-	return n.KeyPair.SignMessage(data), nil
-}
-
 func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage) error {
 	start := time.Now()
 	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, n.Config.ID)
@@ -646,6 +796,13 @@ func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blob
 	return nil
 }
 
+// QuorumIDs returns a copy of the quorums the node is opted into, which can change while the node runs.
+func (n *Node) QuorumIDs() []core.QuorumID {
+	n.quorumIDsMu.RLock()
+	defer n.quorumIDsMu.RUnlock()
+	return slices.Clone(n.Config.QuorumIDList)
+}
+
 func (n *Node) updateSocketAddress(ctx context.Context, newSocketAddr string) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -695,7 +852,8 @@ func (n *Node) checkCurrentNodeIp(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-t.C:
-			newSocketAddr, err := SocketAddress(ctx, n.PubIPProvider, n.Config.DispersalPort, n.Config.RetrievalPort)
+			newSocketAddr, err := SocketAddress(ctx, n.PubIPProvider, n.Config.DispersalPort, n.Config.RetrievalPort,
+				n.Config.V2DispersalPort, n.Config.V2RetrievalPort)
 			if err != nil {
 				n.Logger.Error("failed to get socket address", "err", err)
 				continue
@@ -47,6 +47,7 @@ func newComponents(t *testing.T) *components {
 	}
 	config := &node.Config{
 		Timeout:                   10 * time.Second,
+		ChunkDownloadTimeout:      10 * time.Second,
 		ExpirationPollIntervalSec: 1,
 		QuorumIDList:              []core.QuorumID{0},
 		DbPath:                    dbPath,
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/core"
@@ -28,12 +29,43 @@ type response struct {
 	err      error
 }
 
+type deserializedBundle struct {
+	metadata *requestMetadata
+	bundle   core.Bundle
+	err      error
+}
+
+// StageLatencyReporter reports the latency of a stage of the handling of a batch, e.g. to a metric. A nil
+// StageLatencyReporter reports nothing.
+type StageLatencyReporter func(stage string, latency time.Duration)
+
+func (r StageLatencyReporter) report(stage string, start time.Time) {
+	if r != nil {
+		r(stage, time.Since(start))
+	}
+}
+
 type RawBundles struct {
 	BlobCertificate *corev2.BlobCertificate
 	Bundles         map[core.QuorumID][]byte
+	// Assignments are the chunks of the blob assigned to the node in each quorum. The bundles stored with their
+	// assignments can be re-verified by the chunk scrubber.
+	Assignments map[core.QuorumID]corev2.Assignment
 }
 
-func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operatorState *core.OperatorState) ([]*corev2.BlobShard, []*RawBundles, error) {
+// DownloadBundles downloads the bundles of the batch assigned to the node from the relays. The bundles of each blob
+// are requested to one of the relays of the blob chosen at random. If a relay fails, e.g. because it was restarted,
+// the bundles are requested to the next relay of the blob, so that the node can still sign the batch. Fails once all
+// the relays of a blob have failed, or the chunk download timeout passes across all the attempts. This only fails
+// over between relays: a batch the node missed while it was down is only stored if the disperser retries sending it.
+// Since the bundles are deserialized while the others are downloaded, the latency of the deserialize stage is the time
+// spent deserializing once all the bundles are downloaded.
+func (n *Node) DownloadBundles(
+	ctx context.Context,
+	batch *corev2.Batch,
+	operatorState *core.OperatorState,
+	reportLatency StageLatencyReporter,
+) ([]*corev2.BlobShard, []*RawBundles, error) {
 	relayClient, ok := n.RelayClient.Load().(clients.RelayClient)
 	if !ok || relayClient == nil {
 		return nil, nil, fmt.Errorf("relay client is not set")
@@ -46,7 +78,10 @@ func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operato
 
 	blobShards := make([]*corev2.BlobShard, len(batch.BlobCertificates))
 	rawBundles := make([]*RawBundles, len(batch.BlobCertificates))
+	// relayOrders are the relays of each blob in the order they are requested
+	relayOrders := make([][]corev2.RelayKey, len(batch.BlobCertificates))
 	requests := make(map[corev2.RelayKey]*relayRequest)
+	numBundles := 0
 	for i, cert := range batch.BlobCertificates {
 		blobKey, err := cert.BlobHeader.BlobKey()
 		if err != nil {
@@ -63,9 +98,12 @@ func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operato
 		rawBundles[i] = &RawBundles{
 			BlobCertificate: cert,
 			Bundles:         make(map[core.QuorumID][]byte),
+			Assignments:     make(map[core.QuorumID]corev2.Assignment),
+		}
+		relayOrders[i] = make([]corev2.RelayKey, len(cert.RelayKeys))
+		for j, relayIndex := range rand.Perm(len(cert.RelayKeys)) {
+			relayOrders[i][j] = cert.RelayKeys[relayIndex]
 		}
-		relayIndex := rand.Intn(len(cert.RelayKeys))
-		relayKey := cert.RelayKeys[relayIndex]
 		for _, quorum := range cert.BlobHeader.QuorumNumbers {
 			blobParams, ok := blobVersionParams.Get(cert.BlobHeader.BlobVersion)
 			if !ok {
@@ -75,88 +113,189 @@ func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operato
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to get assignments: %v", err)
 			}
+			rawBundles[i].Assignments[quorum] = assgn
 
-			req, ok := requests[relayKey]
-			if !ok {
-				req = &relayRequest{
-					chunkRequests: make([]*clients.ChunkRequestByRange, 0),
-					metadata:      make([]*requestMetadata, 0),
-				}
-				requests[relayKey] = req
-			}
 			// Chunks from one blob are requested to the same relay
-			req.chunkRequests = append(req.chunkRequests, &clients.ChunkRequestByRange{
+			addRelayRequest(requests, relayOrders[i][0], &clients.ChunkRequestByRange{
 				BlobKey: blobKey,
 				Start:   assgn.StartIndex,
 				End:     assgn.StartIndex + assgn.NumChunks,
-			})
-			req.metadata = append(req.metadata, &requestMetadata{
+			}, &requestMetadata{
 				blobShardIndex: i,
 				quorum:         quorum,
 			})
+			numBundles++
+		}
+	}
+
+	// The bundles of each relay are deserialized as soon as they are downloaded, while the bundles of the other
+	// relays are still being downloaded
+	deserializationPool := n.DeserializationPoolV2
+	if deserializationPool == nil {
+		deserializationPool = workerpool.New(n.Config.NumBatchDeserializationWorkers)
+		defer deserializationPool.Stop()
+	}
+	deserializedChan := make(chan deserializedBundle, numBundles)
+	// attempts is the number of relays of each blob that have failed
+	attempts := make([]int, len(batch.BlobCertificates))
+	// The fallbacks to other relays share the deadline of the first requests, so that falling back doesn't delay
+	// the response to the disperser past the download timeout
+	downloadCtx, cancel := context.WithTimeout(ctx, n.Config.ChunkDownloadTimeout)
+	defer cancel()
+	for len(requests) > 0 {
+		retries := make(map[corev2.RelayKey]*relayRequest)
+		err := n.requestRelays(downloadCtx, relayClient, requests, func(relayKey corev2.RelayKey, req *relayRequest, resp response) error {
+			if resp.err != nil {
+				// The bundles are requested to the next relay of their blobs
+				failedBlobs := make(map[int]struct{})
+				for i, metadata := range req.metadata {
+					if _, ok := failedBlobs[metadata.blobShardIndex]; !ok {
+						failedBlobs[metadata.blobShardIndex] = struct{}{}
+						attempts[metadata.blobShardIndex]++
+					}
+					relays := relayOrders[metadata.blobShardIndex]
+					if attempts[metadata.blobShardIndex] >= len(relays) {
+						return fmt.Errorf("failed to get chunks from relays: %v", resp.err)
+					}
+					addRelayRequest(retries, relays[attempts[metadata.blobShardIndex]], req.chunkRequests[i], metadata)
+				}
+				n.Logger.Warn("Failed to get chunks from relay, falling back to other relays", "relayKey", relayKey, "numBlobs", len(failedBlobs), "err", resp.err)
+				return nil
+			}
+			if len(resp.bundles) != len(resp.metadata) {
+				return fmt.Errorf("relay returned %d bundles, expected %d", len(resp.bundles), len(resp.metadata))
+			}
+			for i, bundle := range resp.bundles {
+				metadata := resp.metadata[i]
+				bundle := bundle
+				rawBundles[metadata.blobShardIndex].Bundles[metadata.quorum] = bundle
+				deserializationPool.Submit(func() {
+					deserialized, err := new(core.Bundle).Deserialize(bundle)
+					deserializedChan <- deserializedBundle{
+						metadata: metadata,
+						bundle:   deserialized,
+						err:      err,
+					}
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
 		}
+		requests = retries
 	}
 
+	deserializeStart := time.Now()
+	for i := 0; i < numBundles; i++ {
+		deserialized := <-deserializedChan
+		if deserialized.err != nil {
+			return nil, nil, fmt.Errorf("failed to deserialize bundle: %v", deserialized.err)
+		}
+		blobShards[deserialized.metadata.blobShardIndex].Bundles[deserialized.metadata.quorum] = deserialized.bundle
+	}
+	reportLatency.report("deserialize", deserializeStart)
+
+	return blobShards, rawBundles, nil
+}
+
+func addRelayRequest(
+	requests map[corev2.RelayKey]*relayRequest,
+	relayKey corev2.RelayKey,
+	chunkRequest *clients.ChunkRequestByRange,
+	metadata *requestMetadata) {
+
+	req, ok := requests[relayKey]
+	if !ok {
+		req = &relayRequest{
+			chunkRequests: make([]*clients.ChunkRequestByRange, 0),
+			metadata:      make([]*requestMetadata, 0),
+		}
+		requests[relayKey] = req
+	}
+	req.chunkRequests = append(req.chunkRequests, chunkRequest)
+	req.metadata = append(req.metadata, metadata)
+}
+
+// requestRelays sends the requests to the relays in parallel, and calls handle with the response of each relay as
+// soon as it is received. The requests still in flight are canceled if handle returns an error.
+func (n *Node) requestRelays(
+	ctx context.Context,
+	relayClient clients.RelayClient,
+	requests map[corev2.RelayKey]*relayRequest,
+	handle func(corev2.RelayKey, *relayRequest, response) error) error {
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to get chunks from relays: %w", err)
+	}
 	pool := workerpool.New(len(requests))
-	bundleChan := make(chan response, len(requests))
+	defer pool.Stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type relayResponse struct {
+		relayKey corev2.RelayKey
+		response
+	}
+	bundleChan := make(chan relayResponse, len(requests))
 	for relayKey := range requests {
 		relayKey := relayKey
 		req := requests[relayKey]
 		pool.Submit(func() {
-			ctxTimeout, cancel := context.WithTimeout(ctx, n.Config.ChunkDownloadTimeout)
-			defer cancel()
-			bundles, err := relayClient.GetChunksByRange(ctxTimeout, relayKey, req.chunkRequests)
+			bundles, err := relayClient.GetChunksByRange(ctx, relayKey, req.chunkRequests)
 			if err != nil {
 				n.Logger.Errorf("failed to get chunks from relays: %v", err)
-				bundleChan <- response{
-					metadata: nil,
-					bundles:  nil,
-					err:      err,
-				}
+				bundleChan <- relayResponse{relayKey: relayKey, response: response{err: err}}
 				return
 			}
-			bundleChan <- response{
-				metadata: req.metadata,
-				bundles:  bundles,
-				err:      nil,
+			bundleChan <- relayResponse{
+				relayKey: relayKey,
+				response: response{
+					metadata: req.metadata,
+					bundles:  bundles,
+					err:      nil,
+				},
 			}
 		})
 	}
-	pool.StopWait()
 
-	var err error
 	for i := 0; i < len(requests); i++ {
 		resp := <-bundleChan
-		if resp.err != nil {
-			return nil, nil, fmt.Errorf("failed to get chunks from relays: %v", resp.err)
-		}
-		for i, bundle := range resp.bundles {
-			metadata := resp.metadata[i]
-			blobShards[metadata.blobShardIndex].Bundles[metadata.quorum], err = new(core.Bundle).Deserialize(bundle)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to deserialize bundle: %v", err)
-			}
-			rawBundles[metadata.blobShardIndex].Bundles[metadata.quorum] = bundle
+		if err := handle(resp.relayKey, requests[resp.relayKey], resp.response); err != nil {
+			return err
 		}
 	}
-
-	return blobShards, rawBundles, nil
+	return nil
 }
 
+// ValidateBatchV2 validates the batch header against the blob certificates, and the chunks of the blobs against their
+// commitments. The latency of the KZG verify stage includes checking the chunks match the assignments of the node.
 func (n *Node) ValidateBatchV2(
 	ctx context.Context,
 	batch *corev2.Batch,
 	blobShards []*corev2.BlobShard,
 	operatorState *core.OperatorState,
+	reportLatency StageLatencyReporter,
 ) error {
 	if n.ValidatorV2 == nil {
 		return fmt.Errorf("store v2 is not set")
 	}
 
+	stageStart := time.Now()
 	if err := n.ValidatorV2.ValidateBatchHeader(ctx, batch.BatchHeader, batch.BlobCertificates); err != nil {
 		return fmt.Errorf("failed to validate batch header: %v", err)
 	}
-	pool := workerpool.New(n.Config.NumBatchValidators)
+	reportLatency.report("validate_header", stageStart)
+	pool := n.ValidationPoolV2
+	if pool == nil {
+		pool = workerpool.New(n.Config.V2NumBatchValidators)
+		defer pool.Stop()
+	}
 	blobVersionParams := n.BlobVersionParams.Load()
-	return n.ValidatorV2.ValidateBlobs(ctx, blobShards, blobVersionParams, pool, operatorState)
+	stageStart = time.Now()
+	if err := n.ValidatorV2.ValidateBlobs(ctx, blobShards, blobVersionParams, pool, operatorState); err != nil {
+		return err
+	}
+	reportLatency.report("kzg_verify", stageStart)
+	return nil
 }
@@ -2,15 +2,18 @@ package node_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/core"
+	coremockv2 "github.com/Layr-Labs/eigenda/core/mock/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/gammazero/workerpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -51,8 +54,12 @@ func TestDownloadBundles(t *testing.T) {
 	})
 	state, err := c.node.ChainState.GetOperatorState(ctx, uint(10), []core.QuorumID{0, 1, 2})
 	require.NoError(t, err)
-	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state)
+	latencies := make(map[string]time.Duration)
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, func(stage string, latency time.Duration) {
+		latencies[stage] = latency
+	})
 	require.NoError(t, err)
+	require.Contains(t, latencies, "deserialize")
 	require.Len(t, blobShards, 3)
 	require.Equal(t, blobCerts[0], blobShards[0].BlobCertificate)
 	require.Equal(t, blobCerts[1], blobShards[1].BlobCertificate)
@@ -120,12 +127,168 @@ func TestDownloadBundlesFail(t *testing.T) {
 	})
 	state, err := c.node.ChainState.GetOperatorState(ctx, uint(10), []core.QuorumID{0, 1, 2})
 	require.NoError(t, err)
-	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state)
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
 	require.Error(t, err)
 	require.Nil(t, blobShards)
 	require.Nil(t, rawBundles)
 }
 
+func TestDownloadBundlesRelayFallback(t *testing.T) {
+	c := newComponents(t)
+	c.node.RelayClient.Store(c.relayClient)
+	ctx := context.Background()
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	// The second blob can be downloaded from relays 1 and 2
+	batch.BlobCertificates[1].RelayKeys = []v2.RelayKey{1, 2}
+
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles01Bytes, err := bundles[0][1].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles11Bytes, err := bundles[1][1].Serialize()
+	require.NoError(t, err)
+	bundles21Bytes, err := bundles[2][1].Serialize()
+	require.NoError(t, err)
+	bundles22Bytes, err := bundles[2][2].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles01Bytes, bundles21Bytes, bundles22Bytes}, nil)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return(nil, fmt.Errorf("relay unavailable"))
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(2), mock.Anything).Return([][]byte{bundles10Bytes, bundles11Bytes}, nil).Run(func(args mock.Arguments) {
+		requests := args.Get(2).([]*clients.ChunkRequestByRange)
+		require.Len(t, requests, 2)
+		require.Equal(t, blobKeys[1], requests[0].BlobKey)
+		require.Equal(t, blobKeys[1], requests[1].BlobKey)
+	})
+	state, err := c.node.ChainState.GetOperatorState(ctx, uint(10), []core.QuorumID{0, 1, 2})
+	require.NoError(t, err)
+
+	// The relay of the second blob is chosen at random, the bundles are downloaded from relay 2 either way
+	for i := 0; i < 10; i++ {
+		blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+		require.NoError(t, err)
+		require.Len(t, blobShards, 3)
+		bundleEqual(t, bundles[1][0], blobShards[1].Bundles[0])
+		bundleEqual(t, bundles[1][1], blobShards[1].Bundles[1])
+		require.Equal(t, bundles10Bytes, rawBundles[1].Bundles[0])
+		require.Equal(t, bundles11Bytes, rawBundles[1].Bundles[1])
+	}
+	callsByRelay := make(map[v2.RelayKey]int)
+	for _, call := range c.relayClient.Calls {
+		callsByRelay[call.Arguments.Get(1).(v2.RelayKey)]++
+	}
+	require.Equal(t, 10, callsByRelay[0])
+	require.Equal(t, 10, callsByRelay[2])
+
+	// Fails once all the relays of the blob have failed
+	batch.BlobCertificates[1].RelayKeys = []v2.RelayKey{1}
+	_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.ErrorContains(t, err, "relay unavailable")
+}
+
+func TestDownloadBundlesRelayFallbackDeadline(t *testing.T) {
+	c := newComponents(t)
+	c.node.RelayClient.Store(c.relayClient)
+	ctx := context.Background()
+	_, batch, bundles := nodemock.MockBatch(t)
+	batch.BlobCertificates[1].RelayKeys = []v2.RelayKey{1, 2}
+
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles01Bytes, err := bundles[0][1].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles11Bytes, err := bundles[1][1].Serialize()
+	require.NoError(t, err)
+	bundles21Bytes, err := bundles[2][1].Serialize()
+	require.NoError(t, err)
+	bundles22Bytes, err := bundles[2][2].Serialize()
+	require.NoError(t, err)
+	deadlines := make([]time.Time, 0)
+	recordDeadline := func(args mock.Arguments) {
+		deadline, ok := args.Get(0).(context.Context).Deadline()
+		require.True(t, ok)
+		deadlines = append(deadlines, deadline)
+	}
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles01Bytes, bundles21Bytes, bundles22Bytes}, nil)
+	// The relay of the second blob chosen first fails, the other one serves the bundles
+	c.relayClient.On("GetChunksByRange", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("relay unavailable")).Run(recordDeadline).Once()
+	c.relayClient.On("GetChunksByRange", mock.Anything, mock.Anything, mock.Anything).Return([][]byte{bundles10Bytes, bundles11Bytes}, nil).Run(recordDeadline).Once()
+	state, err := c.node.ChainState.GetOperatorState(ctx, uint(10), []core.QuorumID{0, 1, 2})
+	require.NoError(t, err)
+
+	_, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+	require.NoError(t, err)
+	require.Equal(t, bundles10Bytes, rawBundles[1].Bundles[0])
+
+	// The fallback shares the deadline of the first request
+	require.Len(t, deadlines, 2)
+	require.Equal(t, deadlines[0], deadlines[1])
+}
+
+func TestDownloadBundlesInvalidBundle(t *testing.T) {
+	c := newComponents(t)
+	c.node.RelayClient.Store(c.relayClient)
+	c.node.DeserializationPoolV2 = workerpool.New(4)
+	defer c.node.DeserializationPoolV2.StopWait()
+	ctx := context.Background()
+	_, batch, bundles := nodemock.MockBatch(t)
+
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles01Bytes, err := bundles[0][1].Serialize()
+	require.NoError(t, err)
+	bundles21Bytes, err := bundles[2][1].Serialize()
+	require.NoError(t, err)
+	bundles22Bytes, err := bundles[2][2].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles01Bytes, bundles21Bytes, bundles22Bytes}, nil)
+	state, err := c.node.ChainState.GetOperatorState(ctx, uint(10), []core.QuorumID{0, 1, 2})
+	require.NoError(t, err)
+
+	// A bundle of the second relay can't be deserialized
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes, {1, 2, 3}}, nil).Once()
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+	require.ErrorContains(t, err, "failed to deserialize bundle")
+	require.Nil(t, blobShards)
+	require.Nil(t, rawBundles)
+
+	// The second relay returns fewer bundles than requested
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes}, nil).Once()
+	blobShards, rawBundles, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.ErrorContains(t, err, "relay returned 1 bundles, expected 2")
+	require.Nil(t, blobShards)
+	require.Nil(t, rawBundles)
+}
+
+func TestValidateBatchV2StageLatencies(t *testing.T) {
+	c := newComponents(t)
+	validator := coremockv2.NewMockShardValidator()
+	c.node.ValidatorV2 = validator
+	_, batch, _ := nodemock.MockBatch(t)
+	latencies := make(map[string]time.Duration)
+	reportLatency := func(stage string, latency time.Duration) {
+		latencies[stage] = latency
+	}
+
+	validator.On("ValidateBatchHeader").Return(nil)
+	validator.On("ValidateBlobs").Return(nil).Once()
+	require.NoError(t, c.node.ValidateBatchV2(context.Background(), batch, nil, nil, reportLatency))
+	require.Contains(t, latencies, "validate_header")
+	require.Contains(t, latencies, "kzg_verify")
+
+	// The latency of a failed stage isn't reported
+	clear(latencies)
+	validator.On("ValidateBlobs").Return(errors.New("invalid chunks"))
+	require.ErrorContains(t, c.node.ValidateBatchV2(context.Background(), batch, nil, nil, reportLatency), "invalid chunks")
+	require.Contains(t, latencies, "validate_header")
+	require.NotContains(t, latencies, "kzg_verify")
+}
+
 func TestRefreshOnchainStateFailure(t *testing.T) {
 	c := newComponents(t)
 	c.node.Config.EnableV2 = true
@@ -0,0 +1,92 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the unix epoch (1970)
+	ntpEpochOffset = 2208988800
+	// ntpPacketSize is the size of the NTP packets without extensions
+	ntpPacketSize = 48
+	// ntpDefaultPort is the port of the NTP servers given without port
+	ntpDefaultPort = "123"
+	// ntpQueryTimeout bounds the time an NTP query takes when the context has no deadline
+	ntpQueryTimeout = 5 * time.Second
+)
+
+// queryClockOffset queries the time of the NTP server with SNTP (RFC 4330), and returns the offset of the local clock
+// from the clock of the server. The offset is positive if the local clock is behind the server.
+func queryClockOffset(ctx context.Context, server string) (time.Duration, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, ntpDefaultPort)
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, fmt.Errorf("failed to dial NTP server %s: %w", server, err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(ntpQueryTimeout)
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		return 0, fmt.Errorf("failed to set NTP query deadline: %w", err)
+	}
+
+	request := make([]byte, ntpPacketSize)
+	// Leap indicator 0, version 4, client mode
+	request[0] = 0<<6 | 4<<3 | 3
+	sent := time.Now()
+	// The transmit timestamp of the request is echoed by the server as the origin timestamp of the response
+	binary.BigEndian.PutUint64(request[40:], toNTPTime(sent))
+	if _, err = conn.Write(request); err != nil {
+		return 0, fmt.Errorf("failed to send NTP request to %s: %w", server, err)
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	received := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read NTP response from %s: %w", server, err)
+	}
+	if n < ntpPacketSize {
+		return 0, fmt.Errorf("NTP response from %s is too short (%d bytes)", server, n)
+	}
+	if mode := response[0] & 0x7; mode != 4 {
+		return 0, fmt.Errorf("NTP response from %s has mode %d, expected server mode", server, mode)
+	}
+	if stratum := response[1]; stratum == 0 {
+		return 0, fmt.Errorf("NTP server %s refused the request (kiss code %q)", server, response[12:16])
+	}
+	if !bytes.Equal(response[24:32], request[40:48]) {
+		return 0, errors.New("NTP response doesn't match the request")
+	}
+
+	serverReceived := fromNTPTime(binary.BigEndian.Uint64(response[32:]))
+	serverSent := fromNTPTime(binary.BigEndian.Uint64(response[40:]))
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+}
+
+// toNTPTime converts the time to an NTP timestamp, i.e. 32 bits of seconds since the NTP epoch followed by 32 bits of
+// fraction of second.
+func toNTPTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+func fromNTPTime(ntpTime uint64) time.Time {
+	seconds := int64(ntpTime>>32) - ntpEpochOffset
+	nanoseconds := (ntpTime & 0xffffffff) * uint64(time.Second) >> 32
+	return time.Unix(seconds, int64(nanoseconds))
+}
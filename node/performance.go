@@ -0,0 +1,243 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/operators"
+)
+
+// The statuses of the on-chain registration of the operator.
+const (
+	// OperatorRegistered means the operator is registered in all the quorums the node is configured for
+	OperatorRegistered = "registered"
+	// OperatorPartiallyRegistered means the operator is missing from some of the quorums the node is configured
+	// for, e.g. because it was ejected from them
+	OperatorPartiallyRegistered = "partially_registered"
+	// OperatorNotRegistered means the operator isn't registered in any quorum, e.g. because it was ejected
+	OperatorNotRegistered = "not_registered"
+)
+
+// PerformanceTracker counts the batches signed and missed, the bytes stored and the chunk retrievals served by the
+// node since it started, by protocol version. Only the batches the node received are counted, batches that never
+// reached the node are invisible to it. A nil PerformanceTracker doesn't track anything.
+type PerformanceTracker struct {
+	mu        sync.Mutex
+	startTime time.Time
+	versions  map[string]*VersionPerformance
+}
+
+// VersionPerformance is the performance of the node for a single protocol version.
+type VersionPerformance struct {
+	// BatchesSigned is the number of batches the node stored and signed
+	BatchesSigned uint64 `json:"batches_signed"`
+	// BatchesMissed is the number of batches the node received but failed to sign
+	BatchesMissed uint64 `json:"batches_missed"`
+	// BytesStoredByQuorum is the size of the chunks of the signed batches, by quorum
+	BytesStoredByQuorum map[core.QuorumID]uint64 `json:"bytes_stored_by_quorum"`
+	// RetrievalsServed is the number of chunk retrieval requests served
+	RetrievalsServed uint64 `json:"retrievals_served"`
+	// RetrievalsFailed is the number of chunk retrieval requests that failed or were rejected
+	RetrievalsFailed uint64 `json:"retrievals_failed"`
+	// BytesServed is the size of the chunks served to retrievers
+	BytesServed uint64 `json:"bytes_served"`
+}
+
+// QuorumStake is the stake of the operator in a quorum.
+type QuorumStake struct {
+	QuorumID core.QuorumID `json:"quorum_id"`
+	// Stake is the stake of the operator, in wei
+	Stake string `json:"stake"`
+	// StakeShare is the share of the operator in the total stake of the quorum, in basis points
+	StakeShare float64 `json:"stake_share"`
+	// Rank is the rank of the operator by stake in the quorum, starting at 1
+	Rank int `json:"rank"`
+	// NumOperators is the number of operators registered in the quorum
+	NumOperators int `json:"num_operators"`
+}
+
+// OperatorChainStatus is the on-chain registration and stake of the operator.
+type OperatorChainStatus struct {
+	BlockNumber uint32 `json:"block_number"`
+	// Status is one of OperatorRegistered, OperatorPartiallyRegistered and OperatorNotRegistered
+	Status string `json:"status"`
+	// RegisteredQuorums are the quorums the operator is registered in
+	RegisteredQuorums []core.QuorumID `json:"registered_quorums"`
+	// MissingQuorums are the quorums the node is configured for, but the operator isn't registered in
+	MissingQuorums []core.QuorumID `json:"missing_quorums"`
+	Stakes         []QuorumStake   `json:"stakes"`
+}
+
+// PerformanceReport summarizes the performance of the node, so that operators can monitor their service level from
+// a single place.
+type PerformanceReport struct {
+	// StartTime is the unix timestamp at which the node started tracking its performance
+	StartTime     int64               `json:"start_time"`
+	UptimeSeconds int64               `json:"uptime_seconds"`
+	V1            *VersionPerformance `json:"v1"`
+	V2            *VersionPerformance `json:"v2"`
+	// Chain is the on-chain status of the operator, nil if it couldn't be read
+	Chain *OperatorChainStatus `json:"chain,omitempty"`
+	// ChainError is the reason the on-chain status couldn't be read
+	ChainError string `json:"chain_error,omitempty"`
+}
+
+// PerformanceReporter is implemented by nodes that report their performance.
+type PerformanceReporter interface {
+	// GetPerformanceReport returns the performance of the node, along with the on-chain status of the operator.
+	GetPerformanceReport(ctx context.Context) *PerformanceReport
+}
+
+var _ PerformanceReporter = &Node{}
+
+func NewPerformanceTracker() *PerformanceTracker {
+	return &PerformanceTracker{
+		startTime: time.Now(),
+		versions:  make(map[string]*VersionPerformance),
+	}
+}
+
+// RecordBatch records a batch of the protocol version received by the node. The bytes of the batch by quorum are
+// only counted as stored if the batch was signed.
+func (t *PerformanceTracker) RecordBatch(version string, signed bool, bytesByQuorum map[core.QuorumID]uint64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	performance := t.getVersion(version)
+	if !signed {
+		performance.BatchesMissed++
+		return
+	}
+	performance.BatchesSigned++
+	for quorum, bytes := range bytesByQuorum {
+		performance.BytesStoredByQuorum[quorum] += bytes
+	}
+}
+
+// RecordRetrieval records a chunk retrieval request of the protocol version, and the bytes served if it succeeded.
+func (t *PerformanceTracker) RecordRetrieval(version string, served bool, bytes uint64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	performance := t.getVersion(version)
+	if !served {
+		performance.RetrievalsFailed++
+		return
+	}
+	performance.RetrievalsServed++
+	performance.BytesServed += bytes
+}
+
+// Report returns the performance recorded so far, without the on-chain status of the operator.
+func (t *PerformanceTracker) Report(now time.Time) *PerformanceReport {
+	if t == nil {
+		return &PerformanceReport{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return &PerformanceReport{
+		StartTime:     t.startTime.Unix(),
+		UptimeSeconds: int64(now.Sub(t.startTime).Seconds()),
+		V1:            t.copyVersion("v1"),
+		V2:            t.copyVersion("v2"),
+	}
+}
+
+func (t *PerformanceTracker) getVersion(version string) *VersionPerformance {
+	performance, ok := t.versions[version]
+	if !ok {
+		performance = &VersionPerformance{
+			BytesStoredByQuorum: make(map[core.QuorumID]uint64),
+		}
+		t.versions[version] = performance
+	}
+	return performance
+}
+
+func (t *PerformanceTracker) copyVersion(version string) *VersionPerformance {
+	performance := *t.getVersion(version)
+	performance.BytesStoredByQuorum = make(map[core.QuorumID]uint64, len(t.versions[version].BytesStoredByQuorum))
+	for quorum, bytes := range t.versions[version].BytesStoredByQuorum {
+		performance.BytesStoredByQuorum[quorum] = bytes
+	}
+	return &performance
+}
+
+// GetPerformanceReport returns the performance of the node since it started. The on-chain status of the operator is
+// read from the chain, and the report carries the error instead if the chain can't be read.
+func (n *Node) GetPerformanceReport(ctx context.Context) *PerformanceReport {
+	report := n.Performance.Report(time.Now())
+	chain, err := n.getOperatorChainStatus(ctx)
+	if err != nil {
+		report.ChainError = err.Error()
+	} else {
+		report.Chain = chain
+	}
+	return report
+}
+
+func (n *Node) getOperatorChainStatus(ctx context.Context) (*OperatorChainStatus, error) {
+	blockNumber, err := n.Transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	bitmaps, err := n.Transactor.GetQuorumBitmapForOperatorsAtBlockNumber(ctx, []core.OperatorID{n.Config.ID}, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum bitmap at block %d: %w", blockNumber, err)
+	}
+	status := &OperatorChainStatus{
+		BlockNumber:       blockNumber,
+		RegisteredQuorums: eth.BitmapToQuorumIds(bitmaps[0]),
+		MissingQuorums:    make([]core.QuorumID, 0),
+		Stakes:            make([]QuorumStake, 0),
+	}
+
+	for _, quorum := range n.QuorumIDs() {
+		if !slices.Contains(status.RegisteredQuorums, quorum) {
+			status.MissingQuorums = append(status.MissingQuorums, quorum)
+		}
+	}
+	switch {
+	case len(status.RegisteredQuorums) == 0:
+		status.Status = OperatorNotRegistered
+		return status, nil
+	case len(status.MissingQuorums) > 0:
+		status.Status = OperatorPartiallyRegistered
+	default:
+		status.Status = OperatorRegistered
+	}
+
+	state, err := n.ChainState.GetOperatorState(ctx, uint(blockNumber), status.RegisteredQuorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", blockNumber, err)
+	}
+	_, rankedOperators := operators.GetRankedOperators(state)
+	for _, quorum := range status.RegisteredQuorums {
+		for i, op := range rankedOperators[quorum] {
+			if op.OperatorId != n.Config.ID {
+				continue
+			}
+			status.Stakes = append(status.Stakes, QuorumStake{
+				QuorumID:     quorum,
+				Stake:        state.Operators[quorum][op.OperatorId].Stake.String(),
+				StakeShare:   op.StakeShare,
+				Rank:         i + 1,
+				NumOperators: len(rankedOperators[quorum]),
+			})
+			break
+		}
+	}
+	return status, nil
+}
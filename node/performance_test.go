@@ -0,0 +1,109 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerformanceTracker(t *testing.T) {
+	tracker := node.NewPerformanceTracker()
+	tracker.RecordBatch("v1", true, map[core.QuorumID]uint64{0: 10, 1: 20})
+	tracker.RecordBatch("v1", true, map[core.QuorumID]uint64{0: 5})
+	tracker.RecordBatch("v1", false, map[core.QuorumID]uint64{0: 1000})
+	tracker.RecordBatch("v2", false, nil)
+	tracker.RecordRetrieval("v1", true, 7)
+	tracker.RecordRetrieval("v2", true, 3)
+	tracker.RecordRetrieval("v2", false, 0)
+
+	report := tracker.Report(time.Now().Add(time.Minute))
+	require.InDelta(t, 60, report.UptimeSeconds, 1)
+	require.Equal(t, &node.VersionPerformance{
+		BatchesSigned:       2,
+		BatchesMissed:       1,
+		BytesStoredByQuorum: map[core.QuorumID]uint64{0: 15, 1: 20},
+		RetrievalsServed:    1,
+		BytesServed:         7,
+	}, report.V1)
+	require.Equal(t, &node.VersionPerformance{
+		BatchesMissed:       1,
+		BytesStoredByQuorum: map[core.QuorumID]uint64{},
+		RetrievalsServed:    1,
+		RetrievalsFailed:    1,
+		BytesServed:         3,
+	}, report.V2)
+
+	// The report is a copy of the counters
+	report.V1.BytesStoredByQuorum[0] = 0
+	require.Equal(t, uint64(15), tracker.Report(time.Now()).V1.BytesStoredByQuorum[0])
+
+	// A nil tracker doesn't track anything
+	var nilTracker *node.PerformanceTracker
+	nilTracker.RecordBatch("v1", true, nil)
+	nilTracker.RecordRetrieval("v1", true, 1)
+	require.NotNil(t, nilTracker.Report(time.Now()))
+}
+
+func TestGetPerformanceReport(t *testing.T) {
+	chainState, err := coremock.MakeChainDataMock(map[uint8]int{
+		0: 3,
+		1: 3,
+	})
+	require.NoError(t, err)
+	tx := &coremock.MockWriter{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	// The operator is only registered in quorum 0, e.g. after an ejection from quorum 1
+	tx.On("GetQuorumBitmapForOperatorsAtBlockNumber").Return([]*big.Int{big.NewInt(1)}, nil)
+
+	n := &node.Node{
+		Config: &node.Config{
+			ID:           coremock.MakeOperatorId(1),
+			QuorumIDList: []core.QuorumID{0, 1},
+		},
+		Logger:      logging.NewNoopLogger(),
+		ChainState:  chainState,
+		Transactor:  tx,
+		Performance: node.NewPerformanceTracker(),
+	}
+	n.Performance.RecordBatch("v2", true, map[core.QuorumID]uint64{0: 10})
+
+	report := n.GetPerformanceReport(context.Background())
+	require.Empty(t, report.ChainError)
+	require.Equal(t, uint64(1), report.V2.BatchesSigned)
+	require.Equal(t, uint32(100), report.Chain.BlockNumber)
+	require.Equal(t, node.OperatorPartiallyRegistered, report.Chain.Status)
+	require.Equal(t, []core.QuorumID{0}, report.Chain.RegisteredQuorums)
+	require.Equal(t, []core.QuorumID{1}, report.Chain.MissingQuorums)
+	require.Len(t, report.Chain.Stakes, 1)
+	// The stakes of the operators of quorum 0 are 1, 2 and 3
+	require.Equal(t, core.QuorumID(0), report.Chain.Stakes[0].QuorumID)
+	require.Equal(t, "2", report.Chain.Stakes[0].Stake)
+	require.InDelta(t, 3333.33, report.Chain.Stakes[0].StakeShare, 0.01)
+	require.Equal(t, 2, report.Chain.Stakes[0].Rank)
+	require.Equal(t, 3, report.Chain.Stakes[0].NumOperators)
+
+	// The operator isn't registered in any quorum
+	tx.ExpectedCalls = nil
+	tx.On("GetCurrentBlockNumber").Return(uint32(101), nil)
+	tx.On("GetQuorumBitmapForOperatorsAtBlockNumber").Return([]*big.Int{big.NewInt(0)}, nil)
+	report = n.GetPerformanceReport(context.Background())
+	require.Equal(t, node.OperatorNotRegistered, report.Chain.Status)
+	require.Empty(t, report.Chain.RegisteredQuorums)
+	require.Equal(t, []core.QuorumID{0, 1}, report.Chain.MissingQuorums)
+
+	// The counters are reported even if the chain can't be read
+	tx.ExpectedCalls = nil
+	tx.On("GetCurrentBlockNumber").Return(uint32(0), errors.New("chain unavailable"))
+	report = n.GetPerformanceReport(context.Background())
+	require.Nil(t, report.Chain)
+	require.Contains(t, report.ChainError, "chain unavailable")
+	require.Equal(t, uint64(1), report.V2.BatchesSigned)
+}
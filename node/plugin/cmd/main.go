@@ -28,6 +28,7 @@ func main() {
 		plugin.EcdsaKeyPasswordFlag,
 		plugin.BlsKeyPasswordFlag,
 		plugin.SocketFlag,
+		plugin.V2RetrievalPortClientsUpgradedFlag,
 		plugin.QuorumIDListFlag,
 		plugin.ChainRpcUrlFlag,
 		plugin.BlsOperatorStateRetrieverFlag,
@@ -111,7 +112,10 @@ func pluginOps(ctx *cli.Context) {
 	socket := config.Socket
 	if isLocalhost(socket) {
 		pubIPProvider := pubip.ProviderOrDefault(config.PubIPProvider)
-		socket, err = node.SocketAddress(context.Background(), pubIPProvider, dispersalPort, retrievalPort)
+		// The v2 ports, if any, follow the retrieval port
+		v2Ports := append(strings.Split(config.Socket, ";")[2:], "", "")
+		socket, err = node.SocketAddress(
+			context.Background(), pubIPProvider, dispersalPort, retrievalPort, v2Ports[0], v2Ports[1])
 		if err != nil {
 			log.Printf("Error: failed to get socket address from ip provider: %v", err)
 			return
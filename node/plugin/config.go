@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -71,6 +72,12 @@ var (
 		Usage:    "The socket of the EigenDA Node for serving dispersal and retrieval",
 		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "SOCKET"),
 	}
+	V2RetrievalPortClientsUpgradedFlag = cli.BoolFlag{
+		Name:     "v2-retrieval-port-clients-upgraded",
+		Usage:    "Confirms that the dispersers, retrievers and data APIs can parse sockets with a v2 retrieval port, which is required to register a socket with one",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "V2_RETRIEVAL_PORT_CLIENTS_UPGRADED"),
+	}
 	QuorumIDListFlag = cli.StringFlag{
 		Name:     "quorum-id-list",
 		Usage:    "Comma separated list of quorum IDs that the node will opt-in or opt-out, depending on the OperationFlag. If OperationFlag is opt-in, all quorums should not have been registered already; if it's opt-out, all quorums should have been registered already",
@@ -150,6 +157,12 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		return nil, errors.New("unsupported operation type")
 	}
 
+	socket := ctx.GlobalString(SocketFlag.Name)
+	if core.OperatorSocket(socket).HasV2RetrievalPort() && !ctx.GlobalBool(V2RetrievalPortClientsUpgradedFlag.Name) {
+		return nil, fmt.Errorf("the socket has a v2 retrieval port, which clients that predate it can't parse: "+
+			"upgrade the dispersers, retrievers and data APIs, then set --%s", V2RetrievalPortClientsUpgradedFlag.Name)
+	}
+
 	return &Config{
 		PubIPProvider:                 ctx.GlobalString(PubIPProviderFlag.Name),
 		Operation:                     op,
@@ -157,7 +170,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		BlsKeyPassword:                ctx.GlobalString(BlsKeyPasswordFlag.Name),
 		EcdsaKeyFile:                  ctx.GlobalString(EcdsaKeyFileFlag.Name),
 		BlsKeyFile:                    ctx.GlobalString(BlsKeyFileFlag.Name),
-		Socket:                        ctx.GlobalString(SocketFlag.Name),
+		Socket:                        socket,
 		QuorumIDList:                  ids,
 		ChainRpcUrl:                   ctx.GlobalString(ChainRpcUrlFlag.Name),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(BlsOperatorStateRetrieverFlag.Name),
@@ -0,0 +1,257 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// ErrStorageQuotaExceeded is returned when storing a batch would exceed the hard storage quota of a quorum.
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+// StorageQuota bounds the disk space used by the v2 bundles of a quorum. Zero values disable the corresponding limit.
+type StorageQuota struct {
+	// SoftLimitBytes is the usage above which the node warns the operator, while still storing the bundles
+	SoftLimitBytes uint64
+	// HardLimitBytes is the usage above which the batches with bundles of the quorum are rejected
+	HardLimitBytes uint64
+}
+
+// ParseStorageQuotas parses comma separated quotas of the form <quorum>:<soft limit bytes>:<hard limit bytes>.
+func ParseStorageQuotas(s string) (map[core.QuorumID]StorageQuota, error) {
+	quotas := make(map[core.QuorumID]StorageQuota)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid storage quota %q, expected <quorum>:<soft limit bytes>:<hard limit bytes>", entry)
+		}
+		quorum, err := strconv.ParseUint(parts[0], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quorum in storage quota %q: %w", entry, err)
+		}
+		soft, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid soft limit in storage quota %q: %w", entry, err)
+		}
+		hard, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hard limit in storage quota %q: %w", entry, err)
+		}
+		if soft > 0 && hard > 0 && soft > hard {
+			return nil, fmt.Errorf("the soft limit of storage quota %q is above its hard limit", entry)
+		}
+		if _, ok := quotas[core.QuorumID(quorum)]; ok {
+			return nil, fmt.Errorf("duplicate storage quota for quorum %d", quorum)
+		}
+		quotas[core.QuorumID(quorum)] = StorageQuota{SoftLimitBytes: soft, HardLimitBytes: hard}
+	}
+	return quotas, nil
+}
+
+// QuotaTracker tracks the disk space used by the v2 bundles of each quorum against the storage quotas. The usage is
+// the usage of the store, periodically synced since the expired bundles are deleted by the store without the tracker
+// knowing, plus the batches stored since and the batches being stored. The usage is thus over-estimated between
+// syncs, so that the hard limits are never exceeded. A nil QuotaTracker enforces no quota.
+type QuotaTracker struct {
+	mu     sync.Mutex
+	quotas map[core.QuorumID]StorageQuota
+	// stored is the usage of the store as of the last sync, plus the batches stored since
+	stored map[core.QuorumID]uint64
+	// reserved is the usage of the batches being stored
+	reserved map[core.QuorumID]uint64
+	// storedDuringSync is the usage of the batches stored while the usage of the store is read, which the usage read
+	// may miss. It is nil when no sync is in progress.
+	storedDuringSync map[core.QuorumID]uint64
+	// overSoftLimit are the quorums whose usage is above their soft limit, so that a breach is only logged once
+	overSoftLimit map[core.QuorumID]bool
+
+	logger  logging.Logger
+	metrics *Metrics
+}
+
+func NewQuotaTracker(quotas map[core.QuorumID]StorageQuota, logger logging.Logger, metrics *Metrics) *QuotaTracker {
+	return &QuotaTracker{
+		quotas:        quotas,
+		stored:        make(map[core.QuorumID]uint64),
+		reserved:      make(map[core.QuorumID]uint64),
+		overSoftLimit: make(map[core.QuorumID]bool),
+		logger:        logger,
+		metrics:       metrics,
+	}
+}
+
+// QuotaReservation is the space reserved for a batch while it is stored. Either Commit or Release must be called once
+// the batch is stored or fails to be.
+type QuotaReservation struct {
+	tracker       *QuotaTracker
+	bytesByQuorum map[core.QuorumID]uint64
+	done          bool
+}
+
+// Reserve reserves the bytes of the bundles of a batch about to be stored, by quorum. Fails with
+// ErrStorageQuotaExceeded, without reserving anything, if the batch would exceed the hard limit of a quorum.
+func (t *QuotaTracker) Reserve(bytesByQuorum map[core.QuorumID]uint64) (*QuotaReservation, error) {
+	if t == nil {
+		return &QuotaReservation{}, nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for quorum, bytes := range bytesByQuorum {
+		quota := t.quotas[quorum]
+		usage := t.usage(quorum)
+		if quota.HardLimitBytes > 0 && usage+bytes > quota.HardLimitBytes {
+			if t.metrics != nil {
+				t.metrics.RecordQuotaRejection(quorum)
+			}
+			t.logger.Error("Rejecting batch exceeding the storage quota of a quorum", "quorum", quorum,
+				"usageBytes", usage, "batchBytes", bytes, "hardLimitBytes", quota.HardLimitBytes)
+			return nil, fmt.Errorf("%w: quorum %d uses %d bytes, storing %d more bytes would exceed its hard limit of %d bytes",
+				ErrStorageQuotaExceeded, quorum, usage, bytes, quota.HardLimitBytes)
+		}
+	}
+	for quorum, bytes := range bytesByQuorum {
+		t.reserved[quorum] += bytes
+		t.update(quorum)
+	}
+	return &QuotaReservation{tracker: t, bytesByQuorum: bytesByQuorum}, nil
+}
+
+// Commit counts the reserved space as used by the store, once the batch is stored.
+func (r *QuotaReservation) Commit() {
+	r.end(true)
+}
+
+// Release frees the reserved space, if the batch ends up not being stored.
+func (r *QuotaReservation) Release() {
+	r.end(false)
+}
+
+func (r *QuotaReservation) end(stored bool) {
+	t := r.tracker
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if r.done {
+		return
+	}
+	r.done = true
+
+	for quorum, bytes := range r.bytesByQuorum {
+		t.reserved[quorum] -= min(bytes, t.reserved[quorum])
+		if stored {
+			t.stored[quorum] += bytes
+			if t.storedDuringSync != nil {
+				t.storedDuringSync[quorum] += bytes
+			}
+		}
+		t.update(quorum)
+	}
+}
+
+// SyncUsage replaces the usage of the store by the usage read by getUsage. The batches being stored keep their
+// reservations, and the batches stored while the usage is read are still counted in case the usage read misses them.
+func (t *QuotaTracker) SyncUsage(getUsage func() (map[core.QuorumID]*QuorumStorageUsage, error)) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	t.storedDuringSync = make(map[core.QuorumID]uint64)
+	t.mu.Unlock()
+
+	usage, err := getUsage()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	storedDuringSync := t.storedDuringSync
+	t.storedDuringSync = nil
+	if err != nil {
+		return err
+	}
+
+	for quorum := range t.stored {
+		if _, ok := usage[quorum]; !ok {
+			t.stored[quorum] = storedDuringSync[quorum]
+			t.update(quorum)
+		}
+	}
+	for quorum, quorumUsage := range usage {
+		t.stored[quorum] = quorumUsage.SizeBytes + storedDuringSync[quorum]
+		t.update(quorum)
+	}
+	return nil
+}
+
+// Usage returns the tracked usage of the quorum, in bytes.
+func (t *QuotaTracker) Usage(quorum core.QuorumID) uint64 {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.usage(quorum)
+}
+
+// usage returns the usage of the quorum, including the reserved space. Must be called with the lock held.
+func (t *QuotaTracker) usage(quorum core.QuorumID) uint64 {
+	return t.stored[quorum] + t.reserved[quorum]
+}
+
+// Quota returns the storage quota of the quorum, zero if it has none.
+func (t *QuotaTracker) Quota(quorum core.QuorumID) StorageQuota {
+	if t == nil {
+		return StorageQuota{}
+	}
+	return t.quotas[quorum]
+}
+
+// update logs the soft limit breaches of the quorum and records its usage. Must be called with the lock held.
+func (t *QuotaTracker) update(quorum core.QuorumID) {
+	quota := t.quotas[quorum]
+	usage := t.usage(quorum)
+	overSoftLimit := quota.SoftLimitBytes > 0 && usage > quota.SoftLimitBytes
+	if overSoftLimit != t.overSoftLimit[quorum] {
+		t.overSoftLimit[quorum] = overSoftLimit
+		if overSoftLimit {
+			t.logger.Warn("The storage used by a quorum is above its soft limit, add disk space or raise its quota before "+
+				"its hard limit is reached and batches are rejected", "quorum", quorum, "usageBytes", usage,
+				"softLimitBytes", quota.SoftLimitBytes, "hardLimitBytes", quota.HardLimitBytes)
+		} else {
+			t.logger.Info("The storage used by a quorum is back below its soft limit", "quorum", quorum,
+				"usageBytes", usage, "softLimitBytes", quota.SoftLimitBytes)
+		}
+	}
+	if t.metrics != nil {
+		t.metrics.RecordQuorumStorage(quorum, usage, quota)
+	}
+}
+
+// storageUsageLoop periodically syncs the storage quotas with the usage of the store.
+func (n *Node) storageUsageLoop(ctx context.Context, inspector StoreV2Inspector) {
+	ticker := time.NewTicker(n.Config.StorageUsageRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.StorageQuotas.SyncUsage(inspector.GetStorageUsage); err != nil {
+			n.Logger.Error("Failed to get the storage usage", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
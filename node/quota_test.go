@@ -0,0 +1,94 @@
+package node_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStorageQuotas(t *testing.T) {
+	quotas, err := node.ParseStorageQuotas("0:100:200, 1:0:50,2:10:0")
+	require.NoError(t, err)
+	require.Equal(t, map[core.QuorumID]node.StorageQuota{
+		0: {SoftLimitBytes: 100, HardLimitBytes: 200},
+		1: {SoftLimitBytes: 0, HardLimitBytes: 50},
+		2: {SoftLimitBytes: 10, HardLimitBytes: 0},
+	}, quotas)
+
+	quotas, err = node.ParseStorageQuotas("")
+	require.NoError(t, err)
+	require.Empty(t, quotas)
+
+	for _, invalid := range []string{"0:100", "256:1:2", "x:1:2", "0:-1:2", "0:200:100", "0:1:2,0:3:4"} {
+		_, err = node.ParseStorageQuotas(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestQuotaTracker(t *testing.T) {
+	tracker := node.NewQuotaTracker(map[core.QuorumID]node.StorageQuota{
+		0: {SoftLimitBytes: 50, HardLimitBytes: 100},
+	}, logging.NewNoopLogger(), nil)
+
+	reservation, err := tracker.Reserve(map[core.QuorumID]uint64{0: 60, 1: 1000})
+	require.NoError(t, err)
+	require.Equal(t, uint64(60), tracker.Usage(0))
+	require.Equal(t, uint64(1000), tracker.Usage(1))
+
+	// Batches exceeding the hard limit of a quorum are rejected without reserving anything
+	_, err = tracker.Reserve(map[core.QuorumID]uint64{0: 41, 1: 10})
+	require.ErrorIs(t, err, node.ErrStorageQuotaExceeded)
+	require.Equal(t, uint64(60), tracker.Usage(0))
+	require.Equal(t, uint64(1000), tracker.Usage(1))
+	inFlight, err := tracker.Reserve(map[core.QuorumID]uint64{0: 40})
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), tracker.Usage(0))
+
+	// Releasing a reservation frees its space, only once
+	reservation.Release()
+	reservation.Release()
+	require.Equal(t, uint64(40), tracker.Usage(0))
+	require.Zero(t, tracker.Usage(1))
+
+	// The usage of the store replaces the tracked usage, keeping the reservations in flight
+	err = tracker.SyncUsage(func() (map[core.QuorumID]*node.QuorumStorageUsage, error) {
+		return map[core.QuorumID]*node.QuorumStorageUsage{1: {NumBundles: 1, SizeBytes: 5}}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(40), tracker.Usage(0))
+	require.Equal(t, uint64(5), tracker.Usage(1))
+
+	// A committed reservation is counted as stored
+	inFlight.Commit()
+	inFlight.Release()
+	require.Equal(t, uint64(40), tracker.Usage(0))
+
+	// The batches stored while the usage is read are counted even if the usage read misses them
+	stored, err := tracker.Reserve(map[core.QuorumID]uint64{1: 7})
+	require.NoError(t, err)
+	err = tracker.SyncUsage(func() (map[core.QuorumID]*node.QuorumStorageUsage, error) {
+		stored.Commit()
+		return map[core.QuorumID]*node.QuorumStorageUsage{1: {NumBundles: 1, SizeBytes: 5}}, nil
+	})
+	require.NoError(t, err)
+	require.Zero(t, tracker.Usage(0))
+	require.Equal(t, uint64(12), tracker.Usage(1))
+
+	// The tracked usage is kept if the usage of the store can't be read
+	err = tracker.SyncUsage(func() (map[core.QuorumID]*node.QuorumStorageUsage, error) {
+		return nil, errors.New("error")
+	})
+	require.Error(t, err)
+	require.Equal(t, uint64(12), tracker.Usage(1))
+
+	// A nil tracker enforces no quota
+	var nilTracker *node.QuotaTracker
+	reservation, err = nilTracker.Reserve(map[core.QuorumID]uint64{0: 1 << 40})
+	require.NoError(t, err)
+	reservation.Release()
+	require.Equal(t, node.StorageQuota{}, nilTracker.Quota(0))
+}
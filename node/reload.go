@@ -0,0 +1,221 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node/limiter"
+)
+
+// ReloadableConfig holds the settings of the node that can be changed while the node runs, either on SIGHUP from the
+// reload config file or through the admin API. Settings left empty are not changed.
+type ReloadableConfig struct {
+	// LogLevel is the lowest level of the logs, one of "debug", "info", "warn" and "error"
+	LogLevel *string `json:"log_level,omitempty"`
+	// RetrievalRateLimits are the per-client limits on the chunk retrievals served by the node
+	RetrievalRateLimits *limiter.Config `json:"retrieval_rate_limits,omitempty"`
+	// QuorumIDs are the quorums the node is opted into. Quorums can only be added, and only by nodes that register
+	// themselves on chain, in which case the node opts into the added quorums. Opting out of a quorum requires
+	// deregistering the operator with the node plugin.
+	QuorumIDs []uint32 `json:"quorum_ids,omitempty"`
+}
+
+// ConfigReloader is implemented by nodes whose settings can be changed while they run.
+type ConfigReloader interface {
+	// GetReloadableConfig returns the current values of the settings that can be reloaded.
+	GetReloadableConfig() *ReloadableConfig
+	// Reload applies the settings of the config. No setting is changed if the config is invalid.
+	Reload(ctx context.Context, config *ReloadableConfig) error
+}
+
+var _ ConfigReloader = &Node{}
+
+// LoadReloadableConfig reads the reloadable settings from a JSON file.
+func LoadReloadableConfig(path string) (*ReloadableConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open reload config file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	config := &ReloadableConfig{}
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("failed to parse reload config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+func (n *Node) GetReloadableConfig() *ReloadableConfig {
+	n.reloadMu.Lock()
+	defer n.reloadMu.Unlock()
+
+	quorumIDs := n.QuorumIDs()
+	config := &ReloadableConfig{
+		QuorumIDs: make([]uint32, len(quorumIDs)),
+	}
+	if n.Config.LogLevel != nil {
+		logLevel := strings.ToLower(n.Config.LogLevel.Level().String())
+		config.LogLevel = &logLevel
+	}
+	if n.RetrievalRateLimiter != nil {
+		rateLimits := n.RetrievalRateLimiter.Config()
+		config.RetrievalRateLimits = &rateLimits
+	}
+	for i, quorumID := range quorumIDs {
+		config.QuorumIDs[i] = uint32(quorumID)
+	}
+	return config
+}
+
+// Reload applies the settings of the config, and refreshes the relay endpoints from the chain.
+func (n *Node) Reload(ctx context.Context, config *ReloadableConfig) error {
+	n.reloadMu.Lock()
+	defer n.reloadMu.Unlock()
+
+	// Verify all the settings before changing any of them.
+	var logLevel slog.Level
+	if config.LogLevel != nil {
+		if n.Config.LogLevel == nil {
+			return errors.New("the log level of the node can't be reloaded")
+		}
+		if err := logLevel.UnmarshalText([]byte(*config.LogLevel)); err != nil {
+			return fmt.Errorf("invalid log level %q: %w", *config.LogLevel, err)
+		}
+	}
+	if config.RetrievalRateLimits != nil {
+		if n.RetrievalRateLimiter == nil {
+			return errors.New("the retrieval rate limits of the node can't be reloaded")
+		}
+		if err := config.RetrievalRateLimits.Verify(); err != nil {
+			return err
+		}
+	}
+	var quorumIDs, addedQuorumIDs []core.QuorumID
+	if config.QuorumIDs != nil {
+		var err error
+		quorumIDs, addedQuorumIDs, err = n.verifyQuorumIDs(config.QuorumIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Opting into quorums is the only change that can fail, so it is done first.
+	if len(addedQuorumIDs) > 0 {
+		n.Logger.Info("Opting into quorums", "quorumIds", fmt.Sprint(addedQuorumIDs))
+		n.mu.Lock()
+		socket := n.CurrentSocket
+		n.mu.Unlock()
+		operator, err := n.newOperator(socket, quorumIDs)
+		if err != nil {
+			return err
+		}
+		churnerClient := NewChurnerClient(n.Config.ChurnerUrl, n.Config.UseSecureGrpc, n.Config.Timeout, n.Logger)
+		if err = RegisterOperator(ctx, operator, n.Transactor, churnerClient, n.Logger); err != nil {
+			return fmt.Errorf("failed to opt into quorums %v: %w", addedQuorumIDs, err)
+		}
+	}
+	if quorumIDs != nil {
+		n.quorumIDsMu.Lock()
+		n.Config.QuorumIDList = quorumIDs
+		n.quorumIDsMu.Unlock()
+	}
+	if config.LogLevel != nil {
+		n.Config.LogLevel.Set(logLevel)
+		n.Logger.Info("Reloaded log level", "level", logLevel.String())
+	}
+	if config.RetrievalRateLimits != nil {
+		if err := n.RetrievalRateLimiter.UpdateConfig(config.RetrievalRateLimits); err != nil {
+			return err
+		}
+		n.Config.RetrievalRateLimits = *config.RetrievalRateLimits
+		n.Logger.Info("Reloaded retrieval rate limits", "limits", fmt.Sprintf("%+v", *config.RetrievalRateLimits))
+	}
+
+	if n.Config.EnableV2 {
+		// The relay endpoints are registered on chain, refreshing them doesn't fail the reload.
+		if err := n.refreshRelayClient(ctx); err != nil {
+			n.Logger.Warn("Failed to refresh the relay endpoints", "err", err)
+		}
+	}
+	return nil
+}
+
+// verifyQuorumIDs checks that the node can move to the given quorums, and returns them along with the quorums that
+// the node isn't opted into yet.
+func (n *Node) verifyQuorumIDs(ids []uint32) ([]core.QuorumID, []core.QuorumID, error) {
+	if len(ids) == 0 {
+		return nil, nil, errors.New("an operator should be in at least one quorum to be useful")
+	}
+	quorumIDs := make([]core.QuorumID, 0, len(ids))
+	for _, id := range ids {
+		if id > uint32(core.MaxQuorumID) {
+			return nil, nil, fmt.Errorf("invalid quorum ID %d, must be in range [0, %d]", id, core.MaxQuorumID)
+		}
+		if !slices.Contains(quorumIDs, core.QuorumID(id)) {
+			quorumIDs = append(quorumIDs, core.QuorumID(id))
+		}
+	}
+
+	currentQuorumIDs := n.QuorumIDs()
+	for _, quorumID := range currentQuorumIDs {
+		if !slices.Contains(quorumIDs, quorumID) {
+			return nil, nil, fmt.Errorf(
+				"can't opt out of quorum %d while the node runs, deregister the operator with the node plugin", quorumID)
+		}
+	}
+	addedQuorumIDs := make([]core.QuorumID, 0)
+	for _, quorumID := range quorumIDs {
+		if !slices.Contains(currentQuorumIDs, quorumID) {
+			addedQuorumIDs = append(addedQuorumIDs, quorumID)
+		}
+	}
+	if len(addedQuorumIDs) > 0 && !n.Config.RegisterNodeAtStart {
+		return nil, nil, fmt.Errorf(
+			"the node doesn't register itself on chain, opt into quorums %v with the node plugin", addedQuorumIDs)
+	}
+	return quorumIDs, addedQuorumIDs, nil
+}
+
+// reloadOnSignal reloads the settings of the reload config file every time the node receives SIGHUP, until the
+// context is cancelled. The relay endpoints are refreshed even if there is no reload config file.
+func (n *Node) reloadOnSignal(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			n.Logger.Info("Received SIGHUP, reloading the node config", "file", n.Config.ReloadConfigFile)
+			config := &ReloadableConfig{}
+			if n.Config.ReloadConfigFile != "" {
+				var err error
+				config, err = LoadReloadableConfig(n.Config.ReloadConfigFile)
+				if err != nil {
+					n.Logger.Error("Failed to reload the node config", "err", err)
+					continue
+				}
+			}
+			if err := n.Reload(ctx, config); err != nil {
+				n.Logger.Error("Failed to reload the node config", "err", err)
+				continue
+			}
+			n.Logger.Info("Reloaded the node config")
+		}
+	}
+}
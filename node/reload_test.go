@@ -0,0 +1,79 @@
+package node_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/limiter"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	rateLimiter, err := limiter.NewRetrievalRateLimiter(&limiter.Config{}, nil)
+	require.NoError(t, err)
+	logLevel := new(slog.LevelVar)
+	n := &node.Node{
+		Config: &node.Config{
+			QuorumIDList: []core.QuorumID{0, 1},
+			LogLevel:     logLevel,
+		},
+		Logger:               logging.NewNoopLogger(),
+		RetrievalRateLimiter: rateLimiter,
+	}
+
+	// The settings are loaded from a JSON file
+	path := filepath.Join(t.TempDir(), "reload.json")
+	err = os.WriteFile(path, []byte(`{
+		"log_level": "warn",
+		"retrieval_rate_limits": {"max_bytes_per_second_client": 1048576},
+		"quorum_ids": [1, 0]
+	}`), 0600)
+	require.NoError(t, err)
+	config, err := node.LoadReloadableConfig(path)
+	require.NoError(t, err)
+	require.NoError(t, n.Reload(ctx, config))
+	require.Equal(t, slog.LevelWarn, logLevel.Level())
+	require.True(t, rateLimiter.Enabled())
+	require.Equal(t, 1048576.0, rateLimiter.Config().MaxBytesPerSecondClient)
+	require.Equal(t, []core.QuorumID{1, 0}, n.Config.QuorumIDList)
+
+	// Settings missing from the config are not changed
+	debug := "debug"
+	require.NoError(t, n.Reload(ctx, &node.ReloadableConfig{LogLevel: &debug}))
+	require.Equal(t, slog.LevelDebug, logLevel.Level())
+	require.True(t, rateLimiter.Enabled())
+
+	// Invalid configs don't change any setting
+	info := "info"
+	invalidConfigs := []*node.ReloadableConfig{
+		// opting out of a quorum
+		{LogLevel: &info, QuorumIDs: []uint32{0}},
+		// opting into a quorum on a node that doesn't register itself
+		{LogLevel: &info, QuorumIDs: []uint32{0, 1, 2}},
+		{LogLevel: &info, QuorumIDs: []uint32{0, 1, 256}},
+		{LogLevel: &info, RetrievalRateLimits: &limiter.Config{MaxRequestsPerSecondClient: -1}},
+	}
+	for _, config := range invalidConfigs {
+		require.Error(t, n.Reload(ctx, config))
+		require.Equal(t, slog.LevelDebug, logLevel.Level())
+		require.Equal(t, []core.QuorumID{1, 0}, n.Config.QuorumIDList)
+		require.True(t, rateLimiter.Enabled())
+	}
+
+	err = os.WriteFile(path, []byte(`{"log_level": "info", "unknown": true}`), 0600)
+	require.NoError(t, err)
+	_, err = node.LoadReloadableConfig(path)
+	require.Error(t, err)
+
+	current := n.GetReloadableConfig()
+	require.Equal(t, "debug", *current.LogLevel)
+	require.Equal(t, []uint32{1, 0}, current.QuorumIDs)
+	require.Equal(t, 1048576.0, current.RetrievalRateLimits.MaxBytesPerSecondClient)
+}
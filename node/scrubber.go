@@ -0,0 +1,193 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// scrubPageSize is the number of blobs listed from the store at once by the chunk scrubber
+const scrubPageSize = 64
+
+// The statuses of the bundles re-verified by the chunk scrubber.
+const (
+	// ScrubValid means the chunks of the bundle match the commitment of their blob
+	ScrubValid = "valid"
+	// ScrubCorrupted means the bundle is corrupted, and was left as is
+	ScrubCorrupted = "corrupted"
+	// ScrubRepaired means the bundle was corrupted, and was replaced by a valid bundle fetched from the relays
+	ScrubRepaired = "repaired"
+	// ScrubRepairFailed means the bundle is corrupted, and no valid bundle could be fetched from the relays
+	ScrubRepairFailed = "repair_failed"
+)
+
+// scrubLoop re-verifies the stored bundles against the commitments of their blobs, one blob at a time at the
+// configured rate so that the scrubber doesn't compete with the batches being stored for CPU and disk. The store is
+// scanned again from the start once all its blobs were verified.
+func (n *Node) scrubLoop(ctx context.Context, store ScrubbableStoreV2) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / n.Config.ChunkScrubberRate))
+	defer ticker.Stop()
+
+	var after *corev2.BlobKey
+	for {
+		blobs, err := store.ListBlobs(after, scrubPageSize)
+		if err != nil {
+			n.Logger.Error("Failed to list the blobs to scrub", "err", err)
+		}
+		if len(blobs) == 0 {
+			after = nil
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			continue
+		}
+		for _, blob := range blobs {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			n.ScrubBlob(ctx, store, blob)
+			after = &blob.BlobKey
+		}
+	}
+}
+
+// ScrubBlob re-verifies the stored bundles of the blob against its commitment, and returns the status of each bundle
+// verified. Corrupted bundles are replaced by bundles fetched from the relays of the blob if enabled. The bundles that
+// are no longer in the store, e.g. because they expired, are skipped.
+func (n *Node) ScrubBlob(ctx context.Context, store ScrubbableStoreV2, blob *StoredBlob) map[core.QuorumID]string {
+	statuses := make(map[core.QuorumID]string, len(blob.Assignments))
+	for quorum := range blob.Assignments {
+		bundle, err := store.GetBundle(blob.BlobKey, quorum)
+		if errors.Is(err, kvstore.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			n.Logger.Error("Failed to get the bundle to scrub", "blobKey", blob.BlobKey.Hex(), "quorum", quorum, "err", err)
+			continue
+		}
+
+		err = n.verifyStoredBundle(blob, quorum, bundle)
+		if err == nil {
+			statuses[quorum] = ScrubValid
+		} else {
+			n.Logger.Error("Stored bundle is corrupted", "blobKey", blob.BlobKey.Hex(), "quorum", quorum, "err", err)
+			statuses[quorum] = ScrubCorrupted
+			if n.Config.ChunkScrubberRefetch {
+				statuses[quorum] = n.repairBundle(ctx, store, blob, quorum)
+			}
+		}
+		if n.Metrics != nil {
+			n.Metrics.RecordScrubbedBundle(statuses[quorum])
+		}
+	}
+	return statuses
+}
+
+// repairBundle replaces the corrupted bundle of the blob by a valid bundle fetched from the relays of the blob.
+func (n *Node) repairBundle(ctx context.Context, store ScrubbableStoreV2, blob *StoredBlob, quorum core.QuorumID) string {
+	bundle, err := n.refetchBundle(ctx, blob, quorum)
+	if err == nil {
+		err = store.ReplaceBundle(blob.BlobKey, quorum, bundle)
+	}
+	if err != nil {
+		n.Logger.Error("Failed to repair corrupted bundle", "blobKey", blob.BlobKey.Hex(), "quorum", quorum, "err", err)
+		return ScrubRepairFailed
+	}
+	n.Logger.Info("Repaired corrupted bundle", "blobKey", blob.BlobKey.Hex(), "quorum", quorum)
+	return ScrubRepaired
+}
+
+// refetchBundle fetches the bundle of the blob assigned to the node from the relays of the blob, in random order,
+// until one returns a valid bundle.
+func (n *Node) refetchBundle(ctx context.Context, blob *StoredBlob, quorum core.QuorumID) ([]byte, error) {
+	relayClient, ok := n.RelayClient.Load().(clients.RelayClient)
+	if !ok || relayClient == nil {
+		return nil, fmt.Errorf("relay client is not set")
+	}
+	assignment := blob.Assignments[quorum]
+	relayKeys := blob.BlobCertificate.RelayKeys
+	if len(relayKeys) == 0 {
+		return nil, fmt.Errorf("no relay keys in the certificate")
+	}
+
+	var errs []error
+	for _, i := range rand.Perm(len(relayKeys)) {
+		ctxTimeout, cancel := context.WithTimeout(ctx, n.Config.ChunkDownloadTimeout)
+		bundles, err := relayClient.GetChunksByRange(ctxTimeout, relayKeys[i], []*clients.ChunkRequestByRange{{
+			BlobKey: blob.BlobKey,
+			Start:   assignment.StartIndex,
+			End:     assignment.StartIndex + assignment.NumChunks,
+		}})
+		cancel()
+		if err == nil && len(bundles) != 1 {
+			err = fmt.Errorf("relay returned %d bundles, expected 1", len(bundles))
+		}
+		if err == nil {
+			err = n.verifyStoredBundle(blob, quorum, bundles[0])
+		}
+		if err == nil {
+			return bundles[0], nil
+		}
+		errs = append(errs, fmt.Errorf("relay %d: %w", relayKeys[i], err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// verifyStoredBundle verifies that the chunks of the raw bundle are the chunks of the blob assigned to the node in the
+// quorum, by checking their proofs against the commitment of the blob.
+func (n *Node) verifyStoredBundle(blob *StoredBlob, quorum core.QuorumID, rawBundle []byte) error {
+	if n.ChunkVerifier == nil {
+		return fmt.Errorf("chunk verifier is not set")
+	}
+	blobVersionParams := n.BlobVersionParams.Load()
+	if blobVersionParams == nil {
+		return fmt.Errorf("blob version params is nil")
+	}
+	blobHeader := blob.BlobCertificate.BlobHeader
+	blobParams, ok := blobVersionParams.Get(blobHeader.BlobVersion)
+	if !ok {
+		return fmt.Errorf("blob version %d not found", blobHeader.BlobVersion)
+	}
+	params, err := blobHeader.GetEncodingParams(blobParams)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := new(core.Bundle).Deserialize(rawBundle)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize bundle: %w", err)
+	}
+	assignment, ok := blob.Assignments[quorum]
+	if !ok {
+		return fmt.Errorf("no assignment for quorum %d", quorum)
+	}
+	if assignment.NumChunks != uint32(len(bundle)) {
+		return fmt.Errorf("number of chunks (%d) does not match assignment (%d)", len(bundle), assignment.NumChunks)
+	}
+
+	indices := assignment.GetIndices()
+	samples := make([]encoding.Sample, len(bundle))
+	for i, chunk := range bundle {
+		if uint64(chunk.Length()) != params.ChunkLength {
+			return fmt.Errorf("chunk length (%d) does not match blob (%d)", chunk.Length(), params.ChunkLength)
+		}
+		samples[i] = encoding.Sample{
+			Commitment:      blobHeader.BlobCommitments.Commitment,
+			Chunk:           chunk,
+			AssignmentIndex: uint(indices[i]),
+		}
+	}
+	return n.ChunkVerifier.UniversalVerifySubBatch(params, samples, 1)
+}
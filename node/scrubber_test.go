@@ -0,0 +1,202 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// corruptedCoeff marks the chunks the mock verifier rejects
+var corruptedCoeff = fr.Element{666}
+
+// scrubTestBundle returns a bundle of chunks of length 2, which is the chunk length of a blob of length 2048 with the
+// test blob params. The chunks are corrupted if corrupted is true.
+func scrubTestBundle(t *testing.T, numChunks int, corrupted bool) []byte {
+	bundle := make(core.Bundle, numChunks)
+	for i := range bundle {
+		bundle[i] = &encoding.Frame{
+			Proof:  encoding.Proof(*core.NewG1Point(big.NewInt(1), big.NewInt(2)).G1Affine),
+			Coeffs: []fr.Element{{uint64(i)}, {uint64(i + 1)}},
+		}
+		if corrupted {
+			bundle[i].Coeffs[0] = corruptedCoeff
+		}
+	}
+	bundleBytes, err := bundle.Serialize()
+	require.NoError(t, err)
+	return bundleBytes
+}
+
+// storeScrubTestBlob stores a blob with bundles in quorums 0 and 1, along with the assignments of the node.
+func storeScrubTestBlob(t *testing.T, s node.StoreV2) (*v2.BlobCertificate, map[core.QuorumID]v2.Assignment) {
+	_, batch, _ := nodemock.MockBatch(t)
+	cert := batch.BlobCertificates[0]
+	cert.BlobHeader.BlobCommitments.Length = 2048
+	cert.RelayKeys = []v2.RelayKey{0, 1}
+	batch.BlobCertificates = batch.BlobCertificates[:1]
+
+	assignments := map[core.QuorumID]v2.Assignment{
+		0: {StartIndex: 0, NumChunks: 2},
+		1: {StartIndex: 5, NumChunks: 1},
+	}
+	keys, _, err := s.StoreBatch(batch, []*node.RawBundles{{
+		BlobCertificate: cert,
+		Bundles: map[core.QuorumID][]byte{
+			0: scrubTestBundle(t, 2, false),
+			1: scrubTestBundle(t, 1, false),
+		},
+		Assignments: assignments,
+	}})
+	require.NoError(t, err)
+	// The batch header, the bundles, their sizes and the blob record
+	require.Len(t, keys, 6)
+	return cert, assignments
+}
+
+func newScrubTestNode(t *testing.T) (*node.Node, *clientsmock.MockRelayClient, *encmock.MockEncoder) {
+	c := newComponents(t)
+	c.node.Config.ChunkDownloadTimeout = time.Second
+	c.node.RelayClient.Store(c.relayClient)
+
+	verifier := &encmock.MockEncoder{}
+	isCorrupted := func(samples []encoding.Sample) bool {
+		for _, sample := range samples {
+			if sample.Chunk.Coeffs[0] == corruptedCoeff {
+				return true
+			}
+		}
+		return false
+	}
+	verifier.On("UniversalVerifySubBatch", mock.Anything, mock.MatchedBy(isCorrupted), 1).Return(errors.New("invalid proof"))
+	verifier.On("UniversalVerifySubBatch", mock.Anything, mock.Anything, 1).Return(nil)
+	c.node.ChunkVerifier = verifier
+	return c.node, c.relayClient, verifier
+}
+
+func TestScrubbableStoreV2(t *testing.T) {
+	logger := logging.NewNoopLogger()
+	schema := []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName}
+	shard, _ := newTestChunkShard(t, t.TempDir())
+	sharded, err := node.NewShardedStoreV2(startTestTableStore(t, schema...), []*node.ChunkShard{shard}, logger, 10*time.Second)
+	require.NoError(t, err)
+	stores := map[string]node.ScrubbableStoreV2{
+		"leveldb": node.NewLevelDBStoreV2(startTestTableStore(t, schema...), logger, 10*time.Second),
+		"sharded": sharded,
+	}
+
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			cert, assignments := storeScrubTestBlob(t, s.(node.StoreV2))
+			blobKey, err := cert.BlobHeader.BlobKey()
+			require.NoError(t, err)
+
+			blobs, err := s.ListBlobs(nil, 0)
+			require.NoError(t, err)
+			require.Len(t, blobs, 1)
+			require.Equal(t, blobKey, blobs[0].BlobKey)
+			require.Equal(t, assignments, blobs[0].Assignments)
+			require.Equal(t, cert.RelayKeys, blobs[0].BlobCertificate.RelayKeys)
+			blobs, err = s.ListBlobs(&blobKey, 0)
+			require.NoError(t, err)
+			require.Empty(t, blobs)
+
+			corrupted := scrubTestBundle(t, 1, true)
+			require.NoError(t, s.ReplaceBundle(blobKey, 1, corrupted))
+			bundle, err := s.GetBundle(blobKey, 1)
+			require.NoError(t, err)
+			require.Equal(t, corrupted, bundle)
+
+			// Only bundles held by the store can be replaced
+			require.ErrorIs(t, s.ReplaceBundle(blobKey, 2, corrupted), kvstore.ErrNotFound)
+			_, err = s.GetBundle(blobKey, 2)
+			require.ErrorIs(t, err, kvstore.ErrNotFound)
+		})
+	}
+}
+
+func TestScrubBlob(t *testing.T) {
+	n, relayClient, verifier := newScrubTestNode(t)
+	s := node.NewLevelDBStoreV2(
+		startTestTableStore(t, node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName),
+		logging.NewNoopLogger(), 10*time.Second)
+	cert, _ := storeScrubTestBlob(t, s)
+	blobKey, err := cert.BlobHeader.BlobKey()
+	require.NoError(t, err)
+	blobs, err := s.ListBlobs(nil, 0)
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+
+	statuses := n.ScrubBlob(context.Background(), s, blobs[0])
+	require.Equal(t, map[core.QuorumID]string{0: node.ScrubValid, 1: node.ScrubValid}, statuses)
+	// The chunks are verified at their indices in the blob
+	for _, call := range verifier.Calls {
+		samples := call.Arguments.Get(1).([]encoding.Sample)
+		if len(samples) == 1 {
+			require.Equal(t, uint(5), samples[0].AssignmentIndex)
+		} else {
+			require.Equal(t, uint(0), samples[0].AssignmentIndex)
+			require.Equal(t, uint(1), samples[1].AssignmentIndex)
+		}
+	}
+
+	// Corrupted bundles are reported, and left as is unless refetching is enabled
+	corrupted := scrubTestBundle(t, 1, true)
+	require.NoError(t, s.ReplaceBundle(blobKey, 1, corrupted))
+	statuses = n.ScrubBlob(context.Background(), s, blobs[0])
+	require.Equal(t, map[core.QuorumID]string{0: node.ScrubValid, 1: node.ScrubCorrupted}, statuses)
+	bundle, err := s.GetBundle(blobKey, 1)
+	require.NoError(t, err)
+	require.Equal(t, corrupted, bundle)
+
+	// Bundles that can't be deserialized are corrupted too
+	require.NoError(t, s.ReplaceBundle(blobKey, 0, []byte{1, 2, 3}))
+	statuses = n.ScrubBlob(context.Background(), s, blobs[0])
+	require.Equal(t, map[core.QuorumID]string{0: node.ScrubCorrupted, 1: node.ScrubCorrupted}, statuses)
+
+	// The corrupted bundles are fetched again from the relays of the blob, falling back to the other relays
+	n.Config.ChunkScrubberRefetch = true
+	valid0 := scrubTestBundle(t, 2, false)
+	valid1 := scrubTestBundle(t, 1, false)
+	chunkRange := func(start, end uint32) any {
+		return mock.MatchedBy(func(requests []*clients.ChunkRequestByRange) bool {
+			return len(requests) == 1 && requests[0].BlobKey == blobKey &&
+				requests[0].Start == start && requests[0].End == end
+		})
+	}
+	relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return(nil, errors.New("relay unavailable"))
+	relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), chunkRange(0, 2)).Return([][]byte{valid0}, nil).Once()
+	relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), chunkRange(5, 6)).Return([][]byte{valid1}, nil).Once()
+	statuses = n.ScrubBlob(context.Background(), s, blobs[0])
+	require.Equal(t, map[core.QuorumID]string{0: node.ScrubRepaired, 1: node.ScrubRepaired}, statuses)
+	bundle, err = s.GetBundle(blobKey, 0)
+	require.NoError(t, err)
+	require.Equal(t, valid0, bundle)
+	bundle, err = s.GetBundle(blobKey, 1)
+	require.NoError(t, err)
+	require.Equal(t, valid1, bundle)
+
+	// The bundles fetched again are verified too
+	require.NoError(t, s.ReplaceBundle(blobKey, 1, corrupted))
+	relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{corrupted}, nil)
+	statuses = n.ScrubBlob(context.Background(), s, blobs[0])
+	require.Equal(t, map[core.QuorumID]string{0: node.ScrubValid, 1: node.ScrubRepairFailed}, statuses)
+	bundle, err = s.GetBundle(blobKey, 1)
+	require.NoError(t, err)
+	require.Equal(t, corrupted, bundle)
+}
@@ -0,0 +1,195 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/node/flags"
+)
+
+// The startup checks run by the node self-check.
+const (
+	SelfCheckClock        = "clock"
+	SelfCheckRegistration = "registration"
+	SelfCheckSocket       = "socket"
+	SelfCheckReachability = "reachability"
+)
+
+// SelfCheckFailure is a failed startup check, along with the action the operator should take to fix it.
+type SelfCheckFailure struct {
+	Check string
+	Err   error
+	Fix   string
+}
+
+// SelfCheckError is returned by the self-check when some of the startup checks failed.
+type SelfCheckError struct {
+	Failures []SelfCheckFailure
+}
+
+func (e *SelfCheckError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		msgs[i] = fmt.Sprintf("%s check failed: %v. Fix: %s", failure.Check, failure.Err, failure.Fix)
+	}
+	return "node self-check failed: " + strings.Join(msgs, "; ")
+}
+
+// SelfCheck verifies that the node can be dispersed to, i.e. that its clock is in sync, that the operator is
+// registered in the quorums of the node with the socket of the node, and that the registered socket is reachable from
+// the outside. Returns a *SelfCheckError listing the failed checks if any, so that the node fails fast instead of
+// silently never being dispersed to. The checks that can't conclude, e.g. because the NTP server or the data API is
+// unavailable, are logged without failing. Must be called once the gRPC servers are started.
+func (n *Node) SelfCheck(ctx context.Context) error {
+	failures := make([]SelfCheckFailure, 0)
+	failures = append(failures, n.checkClockSkew(ctx)...)
+	failures = append(failures, n.checkRegistration(ctx)...)
+	failures = append(failures, n.checkReachability(ctx)...)
+	if len(failures) > 0 {
+		for _, failure := range failures {
+			n.Logger.Error("Node self-check failed", "check", failure.Check, "err", failure.Err, "fix", failure.Fix)
+		}
+		return &SelfCheckError{Failures: failures}
+	}
+	n.Logger.Info("Node self-check passed")
+	return nil
+}
+
+// checkClockSkew checks that the clock of the node is within the max skew of the clock of the NTP server.
+func (n *Node) checkClockSkew(ctx context.Context) []SelfCheckFailure {
+	if n.Config.NTPServer == "" {
+		n.Logger.Warn("Skipping the clock self-check, no NTP server is configured")
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, ntpQueryTimeout)
+	defer cancel()
+	offset, err := queryClockOffset(ctx, n.Config.NTPServer)
+	if err != nil {
+		n.Logger.Warn("Unable to check the clock skew of the node", "ntpServer", n.Config.NTPServer, "err", err)
+		return nil
+	}
+	if offset.Abs() > n.Config.MaxClockSkew {
+		return []SelfCheckFailure{{
+			Check: SelfCheckClock,
+			Err: fmt.Errorf("the clock of the node is off by %s from NTP server %s, more than the max skew of %s",
+				offset, n.Config.NTPServer, n.Config.MaxClockSkew),
+			Fix: "sync the clock of the machine with NTP, e.g. with chrony or systemd-timesyncd",
+		}}
+	}
+	n.Logger.Info("Clock skew of the node is within bounds", "offset", offset, "ntpServer", n.Config.NTPServer)
+	return nil
+}
+
+// checkRegistration checks that the operator is registered in all the quorums of the node, with the socket of the
+// node.
+func (n *Node) checkRegistration(ctx context.Context) []SelfCheckFailure {
+	status, err := n.getOperatorChainStatus(ctx)
+	if err != nil {
+		n.Logger.Warn("Unable to check the registration of the operator", "err", err)
+		return nil
+	}
+	switch status.Status {
+	case OperatorNotRegistered:
+		return []SelfCheckFailure{{
+			Check: SelfCheckRegistration,
+			Err: fmt.Errorf("operator %s is not registered in any quorum at block %d",
+				n.Config.ID.Hex(), status.BlockNumber),
+			Fix: fmt.Sprintf("register the operator with the EigenDA operator setup, or start the node with --%s",
+				flags.RegisterAtNodeStartFlag.Name),
+		}}
+	case OperatorPartiallyRegistered:
+		return []SelfCheckFailure{{
+			Check: SelfCheckRegistration,
+			Err: fmt.Errorf("operator %s is not registered in quorums %v of the node at block %d, e.g. because it "+
+				"was ejected from them", n.Config.ID.Hex(), status.MissingQuorums, status.BlockNumber),
+			Fix: fmt.Sprintf("register the operator in these quorums again, or remove them from --%s",
+				flags.QuorumIDListFlag.Name),
+		}}
+	}
+
+	registeredSocket, err := n.Transactor.GetOperatorSocket(ctx, n.Config.ID)
+	if err != nil {
+		n.Logger.Warn("Unable to check the socket registered by the operator", "err", err)
+		return nil
+	}
+	n.mu.Lock()
+	socket := n.CurrentSocket
+	n.mu.Unlock()
+	if registeredSocket != socket {
+		return []SelfCheckFailure{{
+			Check: SelfCheckSocket,
+			Err: fmt.Errorf("the socket registered by the operator %q doesn't match the socket of the node %q",
+				registeredSocket, socket),
+			Fix: fmt.Sprintf("update the socket of the operator with the EigenDA operator setup, or set --%s for "+
+				"the node to update it when its public IP changes", flags.PubIPCheckIntervalFlag.Name),
+		}}
+	}
+	return nil
+}
+
+// checkReachability checks that the registered dispersal and retrieval sockets of the node are reachable from the
+// outside, by asking the data API to dial back the node.
+func (n *Node) checkReachability(ctx context.Context) []SelfCheckFailure {
+	if n.Config.DataApiUrl == "" {
+		n.Logger.Warn("Skipping the reachability self-check, the data API URL is not configured")
+		return nil
+	}
+	checkURL, err := GetReachabilityURL(n.Config.DataApiUrl, n.Config.ID.Hex())
+	if err != nil {
+		n.Logger.Warn("Unable to check the reachability of the node", "err", err)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.Config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checkURL, nil)
+	if err != nil {
+		n.Logger.Warn("Unable to check the reachability of the node", "err", err)
+		return nil
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		n.Logger.Warn("Unable to check the reachability of the node", "url", checkURL, "err", err)
+		return nil
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		n.Logger.Warn("Unable to check the reachability of the node", "url", checkURL, "status", resp.StatusCode)
+		return nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		n.Logger.Warn("Unable to check the reachability of the node", "url", checkURL, "err", err)
+		return nil
+	}
+	var reachability OperatorReachabilityResponse
+	if err = json.Unmarshal(data, &reachability); err != nil {
+		n.Logger.Warn("Unable to check the reachability of the node", "url", checkURL, "err", err)
+		return nil
+	}
+
+	failures := make([]SelfCheckFailure, 0)
+	if !reachability.DispersalOnline {
+		failures = append(failures, SelfCheckFailure{
+			Check: SelfCheckReachability,
+			Err:   fmt.Errorf("the dispersal socket %s is unreachable from the outside", reachability.DispersalSocket),
+			Fix: fmt.Sprintf("allow inbound TCP traffic to port %s and forward it to port %s of the node, and check "+
+				"that the registered hostname resolves to the node", n.Config.DispersalPort, n.Config.InternalDispersalPort),
+		})
+	}
+	if !reachability.RetrievalOnline {
+		failures = append(failures, SelfCheckFailure{
+			Check: SelfCheckReachability,
+			Err:   fmt.Errorf("the retrieval socket %s is unreachable from the outside", reachability.RetrievalSocket),
+			Fix: fmt.Sprintf("allow inbound TCP traffic to port %s and forward it to port %s of the node, and check "+
+				"that the registered hostname resolves to the node", n.Config.RetrievalPort, n.Config.InternalRetrievalPort),
+		})
+	}
+	return failures
+}
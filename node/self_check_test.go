@@ -0,0 +1,179 @@
+package node_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// startTestNTPServer starts an SNTP server whose clock is ahead of the local clock by offset.
+func startTestNTPServer(t *testing.T, offset time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	toNTPTime := func(t time.Time) uint64 {
+		seconds := uint64(t.Unix() + 2208988800)
+		return seconds<<32 | uint64(t.Nanosecond())<<32/uint64(time.Second)
+	}
+	go func() {
+		request := make([]byte, 48)
+		for {
+			_, addr, err := conn.ReadFrom(request)
+			if err != nil {
+				return
+			}
+			now := toNTPTime(time.Now().Add(offset))
+			response := make([]byte, 48)
+			// Leap indicator 0, version 4, server mode, stratum 1
+			response[0] = 4<<3 | 4
+			response[1] = 1
+			copy(response[24:32], request[40:48])
+			binary.BigEndian.PutUint64(response[32:], now)
+			binary.BigEndian.PutUint64(response[40:], now)
+			_, _ = conn.WriteTo(response, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+// startTestDataAPI starts a data API reporting the reachability of the node.
+func startTestDataAPI(t *testing.T, reachability node.OperatorReachabilityResponse) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/operators-info/port-check" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(reachability)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func newSelfCheckTestNode(t *testing.T) (*node.Node, *coremock.MockWriter) {
+	chainState, err := coremock.MakeChainDataMock(map[uint8]int{
+		0: 3,
+		1: 3,
+	})
+	require.NoError(t, err)
+	tx := &coremock.MockWriter{}
+	n := &node.Node{
+		Config: &node.Config{
+			ID:                    coremock.MakeOperatorId(1),
+			QuorumIDList:          []core.QuorumID{0, 1},
+			Timeout:               time.Second,
+			DispersalPort:         "32005",
+			RetrievalPort:         "32004",
+			InternalDispersalPort: "32005",
+			InternalRetrievalPort: "32004",
+			NTPServer:             startTestNTPServer(t, 0),
+			MaxClockSkew:          time.Second,
+		},
+		Logger:        logging.NewNoopLogger(),
+		ChainState:    chainState,
+		Transactor:    tx,
+		CurrentSocket: "localhost:32005;32004;32007;32006",
+	}
+	return n, tx
+}
+
+func mockRegistration(tx *coremock.MockWriter, quorumBitmap int64, socket string) {
+	tx.ExpectedCalls = nil
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumBitmapForOperatorsAtBlockNumber").Return([]*big.Int{big.NewInt(quorumBitmap)}, nil)
+	tx.On("GetOperatorSocket").Return(socket, nil)
+}
+
+func requireSelfCheckFailures(t *testing.T, err error, checks ...string) {
+	var selfCheckErr *node.SelfCheckError
+	require.ErrorAs(t, err, &selfCheckErr)
+	failedChecks := make([]string, len(selfCheckErr.Failures))
+	for i, failure := range selfCheckErr.Failures {
+		failedChecks[i] = failure.Check
+		require.NotEmpty(t, failure.Fix)
+	}
+	require.Equal(t, checks, failedChecks)
+}
+
+func TestSelfCheck(t *testing.T) {
+	n, tx := newSelfCheckTestNode(t)
+	mockRegistration(tx, 0b11, n.CurrentSocket)
+	n.Config.DataApiUrl = startTestDataAPI(t, node.OperatorReachabilityResponse{
+		DispersalOnline: true,
+		RetrievalOnline: true,
+	})
+	require.NoError(t, n.SelfCheck(context.Background()))
+
+	// The checks that can't conclude don't fail the self-check
+	n.Config.DataApiUrl = ""
+	n.Config.NTPServer = ""
+	require.NoError(t, n.SelfCheck(context.Background()))
+	tx.ExpectedCalls = nil
+	tx.On("GetCurrentBlockNumber").Return(uint32(0), context.DeadlineExceeded)
+	require.NoError(t, n.SelfCheck(context.Background()))
+}
+
+func TestSelfCheckClockSkew(t *testing.T) {
+	n, tx := newSelfCheckTestNode(t)
+	mockRegistration(tx, 0b11, n.CurrentSocket)
+	n.Config.NTPServer = startTestNTPServer(t, -5*time.Second)
+	err := n.SelfCheck(context.Background())
+	requireSelfCheckFailures(t, err, node.SelfCheckClock)
+	require.ErrorContains(t, err, "the clock of the node is off by")
+
+	// The skew is tolerated up to the max skew
+	n.Config.MaxClockSkew = 10 * time.Second
+	require.NoError(t, n.SelfCheck(context.Background()))
+}
+
+func TestSelfCheckRegistration(t *testing.T) {
+	n, tx := newSelfCheckTestNode(t)
+
+	mockRegistration(tx, 0, n.CurrentSocket)
+	err := n.SelfCheck(context.Background())
+	requireSelfCheckFailures(t, err, node.SelfCheckRegistration)
+	require.ErrorContains(t, err, "register-at-node-start")
+
+	// The operator was ejected from quorum 1
+	mockRegistration(tx, 0b01, n.CurrentSocket)
+	err = n.SelfCheck(context.Background())
+	requireSelfCheckFailures(t, err, node.SelfCheckRegistration)
+	require.ErrorContains(t, err, "not registered in quorums [1]")
+
+	mockRegistration(tx, 0b11, "1.2.3.4:32005;32004;32007;32006")
+	err = n.SelfCheck(context.Background())
+	requireSelfCheckFailures(t, err, node.SelfCheckSocket)
+	require.ErrorContains(t, err, "public-ip-check-interval")
+}
+
+func TestSelfCheckReachability(t *testing.T) {
+	n, tx := newSelfCheckTestNode(t)
+	mockRegistration(tx, 0b11, n.CurrentSocket)
+	n.Config.DataApiUrl = startTestDataAPI(t, node.OperatorReachabilityResponse{
+		DispersalSocket: "localhost:32005",
+		RetrievalSocket: "localhost:32004",
+		DispersalOnline: false,
+		RetrievalOnline: true,
+	})
+	err := n.SelfCheck(context.Background())
+	requireSelfCheckFailures(t, err, node.SelfCheckReachability)
+	require.ErrorContains(t, err, "the dispersal socket localhost:32005 is unreachable")
+
+	// The self-check doesn't fail if the data API doesn't know the operator
+	n.Config.DataApiUrl += "/unknown"
+	require.NoError(t, n.SelfCheck(context.Background()))
+}
@@ -0,0 +1,105 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	blssignerV1 "github.com/Layr-Labs/cerberus-api/pkg/api/v1"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	localSignerLabel  = "local"
+	remoteSignerLabel = "remote"
+)
+
+// remoteSignerProbe is the message signed to check the health of the remote signer. It is the hash of a domain
+// specific string, so that it can't collide with the hash of a batch header.
+var remoteSignerProbe = [32]byte(crypto.Keccak256([]byte("eigenda-node-remote-signer-health-check")))
+
+// SignMessage signs the data with the BLS key of the operator, with the remote signer if enabled and the local key
+// pair otherwise.
+func (n *Node) SignMessage(ctx context.Context, data [32]byte) (*core.Signature, error) {
+	signer := localSignerLabel
+	if n.Config.BLSRemoteSignerEnabled {
+		signer = remoteSignerLabel
+	}
+	start := time.Now()
+	sig, err := n.signMessage(ctx, data)
+	if n.Metrics != nil {
+		n.Metrics.RecordSigning(signer, err == nil, time.Since(start))
+	}
+	return sig, err
+}
+
+func (n *Node) signMessage(ctx context.Context, data [32]byte) (*core.Signature, error) {
+	if n.Config.BLSRemoteSignerEnabled {
+		sigResp, err := n.BLSSigner.SignGeneric(
+			ctx,
+			&blssignerV1.SignGenericRequest{
+				PublicKey: n.Config.BLSPublicKeyHex,
+				Password:  n.Config.BLSKeyPassword,
+				Data:      data[:],
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign data: %w", err)
+		}
+		sig := new(core.Signature)
+		g, err := sig.Deserialize(sigResp.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize signature: %w", err)
+		}
+		return &core.Signature{
+			G1Point: g,
+		}, nil
+	}
+	if n.KeyPair == nil {
+		return nil, errors.New("missing key pair")
+	}
+	return n.KeyPair.SignMessage(data), nil
+}
+
+// CheckRemoteSigner checks that the remote signer is reachable and signs with the key of the operator, by signing a
+// probe message.
+func (n *Node) CheckRemoteSigner(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, n.Config.Timeout)
+	defer cancel()
+
+	sig, err := n.SignMessage(ctx, remoteSignerProbe)
+	healthy := err == nil
+	if healthy && (sig.G1Point == nil || sig.IsInfinity()) {
+		err = errors.New("the remote signer returned an empty signature")
+		healthy = false
+	}
+	if n.Metrics != nil {
+		n.Metrics.RecordRemoteSignerHealth(healthy)
+	}
+	return err
+}
+
+// remoteSignerHealthLoop periodically checks the health of the remote signer, so that a signer the node can't
+// sign batches with is noticed before the node misses batches.
+func (n *Node) remoteSignerHealthLoop(ctx context.Context) {
+	ticker := time.NewTicker(n.Config.BLSSignerHealthCheckInterval)
+	defer ticker.Stop()
+
+	healthy := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := n.CheckRemoteSigner(ctx)
+			if err != nil {
+				n.Logger.Error("The BLS remote signer is unhealthy", "url", n.Config.BLSRemoteSignerUrl, "err", err)
+			} else if !healthy {
+				n.Logger.Info("The BLS remote signer is healthy again", "url", n.Config.BLSRemoteSignerUrl)
+			}
+			healthy = err == nil
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	blssignerV1 "github.com/Layr-Labs/cerberus-api/pkg/api/v1"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeSigner signs with the key pair, or fails with err if set.
+type fakeSigner struct {
+	keyPair *core.KeyPair
+	err     error
+}
+
+func (s *fakeSigner) SignGeneric(ctx context.Context, in *blssignerV1.SignGenericRequest, opts ...grpc.CallOption) (*blssignerV1.SignGenericResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	sig := s.keyPair.SignMessage([32]byte(in.GetData())).Bytes()
+	return &blssignerV1.SignGenericResponse{Signature: sig[:]}, nil
+}
+
+func newSignerTestNode(t *testing.T, remote bool) (*node.Node, *fakeSigner) {
+	keyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	logger := logging.NewNoopLogger()
+	n := &node.Node{
+		Config: &node.Config{
+			Timeout:                time.Second,
+			BLSRemoteSignerEnabled: remote,
+		},
+		Logger:  logger,
+		Metrics: node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), logger, ":9090", [32]byte{}, -1, &coremock.MockWriter{}, nil),
+	}
+	signer := &fakeSigner{keyPair: keyPair}
+	if remote {
+		n.BLSSigner = signer
+	} else {
+		n.KeyPair = keyPair
+	}
+	return n, signer
+}
+
+func TestSignMessage(t *testing.T) {
+	message := [32]byte{1, 2, 3}
+	for _, remote := range []bool{false, true} {
+		n, signer := newSignerTestNode(t, remote)
+		label := "local"
+		if remote {
+			label = "remote"
+		}
+
+		sig, err := n.SignMessage(context.Background(), message)
+		require.NoError(t, err)
+		require.True(t, sig.Verify(signer.keyPair.GetPubKeyG2(), message))
+		require.Equal(t, 1.0, testutil.ToFloat64(n.Metrics.AccuSignings.WithLabelValues(label, "success")))
+	}
+
+	// Signing fails without a key pair or a remote signer
+	n, _ := newSignerTestNode(t, false)
+	n.KeyPair = nil
+	_, err := n.SignMessage(context.Background(), message)
+	require.Error(t, err)
+	require.Equal(t, 1.0, testutil.ToFloat64(n.Metrics.AccuSignings.WithLabelValues("local", "failure")))
+}
+
+func TestCheckRemoteSigner(t *testing.T) {
+	n, signer := newSignerTestNode(t, true)
+	require.NoError(t, n.CheckRemoteSigner(context.Background()))
+	require.Equal(t, 1.0, testutil.ToFloat64(n.Metrics.RemoteSignerHealthy))
+
+	signer.err = errors.New("connection refused")
+	require.ErrorContains(t, n.CheckRemoteSigner(context.Background()), "connection refused")
+	require.Equal(t, 0.0, testutil.ToFloat64(n.Metrics.RemoteSignerHealthy))
+	require.Equal(t, 1.0, testutil.ToFloat64(n.Metrics.AccuSignings.WithLabelValues("remote", "failure")))
+
+	signer.err = nil
+	require.NoError(t, n.CheckRemoteSigner(context.Background()))
+	require.Equal(t, 1.0, testutil.ToFloat64(n.Metrics.RemoteSignerHealthy))
+}
@@ -0,0 +1,244 @@
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// nodeSnapshotMagic starts every node snapshot, and identifies the version of the node snapshot format.
+var nodeSnapshotMagic = []byte("EIGENDA-NODE-SNAPSHOT-1")
+
+// A node snapshot is made of the node snapshot magic followed by one section per store, and terminated by an empty
+// section name. Each section is the length (a single byte) and bytes of the name of the store directory, followed by
+// the snapshot of the store in the format of kvstore.ExportStore. Stores that don't exist are not part of the
+// snapshot.
+
+// SnapshotExporter is implemented by nodes that can export a snapshot of their stores while running.
+type SnapshotExporter interface {
+	// ExportSnapshot writes a snapshot of the stores of the node to the writer.
+	ExportSnapshot(w io.Writer) error
+}
+
+// errSnapshotShardedStore is returned when exporting the snapshot of a node sharding its chunks across chunk store
+// paths, whose bundles are spread over stores on other disks that the snapshot format doesn't have sections for.
+var errSnapshotShardedStore = errors.New(
+	"snapshots of nodes sharding their chunks across chunk store paths are not supported")
+
+// snapshotSection exports the snapshot of a store.
+type snapshotSection struct {
+	name   string
+	export func(w io.Writer) (int, error)
+}
+
+var _ SnapshotExporter = &Node{}
+
+// ExportSnapshot writes a snapshot of the stores of the running node to the writer. The snapshot of each store is
+// consistent, and can be imported on another machine with ImportSnapshot.
+func (n *Node) ExportSnapshot(w io.Writer) error {
+	sections := make([]snapshotSection, 0, 2)
+	if n.Store != nil {
+		sections = append(sections, snapshotSection{
+			name: storeDir,
+			export: func(w io.Writer) (int, error) {
+				return kvstore.ExportStore(n.Store.db, w)
+			},
+		})
+	}
+	if n.StoreV2 != nil {
+		if _, ok := n.StoreV2.(*shardedStoreV2); ok {
+			return errSnapshotShardedStore
+		}
+		s, ok := n.StoreV2.(*storeV2)
+		if !ok {
+			return errors.New("the v2 store does not support snapshots")
+		}
+		exporter, ok := s.db.(kvstore.Exporter)
+		if !ok {
+			return errors.New("the v2 store does not support snapshots")
+		}
+		sections = append(sections, snapshotSection{
+			name:   storeV2Dir,
+			export: exporter.Export,
+		})
+	}
+	return writeSnapshot(n.Logger, w, sections)
+}
+
+// ExportSnapshotFromDisk writes a snapshot of the stores of the given backend at the DB path to the writer. The
+// node must not be running, use the admin API of the node to export a snapshot of a running node. Nodes that shard
+// their chunks across chunk store paths can't be exported.
+func ExportSnapshotFromDisk(logger logging.Logger, dbPath string, backend string, w io.Writer) error {
+	// The layout file is recorded once the bundles have been balanced across the chunk stores
+	if _, err := os.Stat(filepath.Join(dbPath, chunkShardLayoutFile)); err == nil {
+		return errSnapshotShardedStore
+	}
+	sections := make([]snapshotSection, 0, 2)
+	for _, store := range [][2]string{
+		{storeDir, StorePath(dbPath, backend)},
+		{storeV2Dir, StoreV2Path(dbPath, backend)},
+	} {
+		name, path := store[0], store[1]
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			logger.Info("No store to export", "path", path)
+			continue
+		}
+		db, err := newBaseStore(backend, logger, path)
+		if err != nil {
+			return fmt.Errorf("failed to open store at %s: %w", path, err)
+		}
+		defer func() {
+			_ = db.Shutdown()
+		}()
+		sections = append(sections, snapshotSection{
+			name: name,
+			export: func(w io.Writer) (int, error) {
+				return kvstore.ExportStore(db, w)
+			},
+		})
+	}
+	return writeSnapshot(logger, w, sections)
+}
+
+func writeSnapshot(logger logging.Logger, w io.Writer, sections []snapshotSection) error {
+	writer := bufio.NewWriter(w)
+	if _, err := writer.Write(nodeSnapshotMagic); err != nil {
+		return fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	for _, section := range sections {
+		if err := writeSnapshotName(writer, section.name); err != nil {
+			return fmt.Errorf("failed to write snapshot section: %w", err)
+		}
+		count, err := section.export(writer)
+		if err != nil {
+			return fmt.Errorf("failed to export store %s: %w", section.name, err)
+		}
+		logger.Info("Exported store", "store", section.name, "numKeys", count)
+	}
+	if err := writeSnapshotName(writer, ""); err != nil {
+		return fmt.Errorf("failed to write snapshot trailer: %w", err)
+	}
+	return writer.Flush()
+}
+
+// ImportSnapshot reads a snapshot written by ExportSnapshot or ExportSnapshotFromDisk, and creates the stores of the
+// given backend at the DB path with its content. The stores must not exist yet. The node must not be running during
+// the import, and picks up the imported data once started with the same DB path and backend.
+func ImportSnapshot(logger logging.Logger, r io.Reader, dbPath string, backend string, batchSize uint32) error {
+	if err := ValidateDBBackend(backend); err != nil {
+		return err
+	}
+	paths := map[string]string{
+		storeDir:   StorePath(dbPath, backend),
+		storeV2Dir: StoreV2Path(dbPath, backend),
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("store already exists at %s", path)
+		}
+	}
+
+	reader := bufio.NewReader(r)
+	magic := make([]byte, len(nodeSnapshotMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil {
+		return fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	if !bytes.Equal(magic, nodeSnapshotMagic) {
+		return fmt.Errorf("invalid snapshot header %q", magic)
+	}
+
+	imported, err := importSections(logger, reader, backend, paths, batchSize)
+	if err != nil {
+		// Don't leave the stores of a partial snapshot behind, they would be picked up by the node.
+		for _, path := range imported {
+			_ = os.RemoveAll(path)
+		}
+		return err
+	}
+	return nil
+}
+
+// importSections imports the sections of the snapshot into the stores at the paths. Returns the paths of the
+// imported stores, including when an error is returned.
+func importSections(
+	logger logging.Logger,
+	reader *bufio.Reader,
+	backend string,
+	paths map[string]string,
+	batchSize uint32) ([]string, error) {
+
+	imported := make([]string, 0, len(paths))
+	for {
+		name, err := readSnapshotName(reader)
+		if err != nil {
+			return imported, fmt.Errorf("failed to read snapshot section: %w", err)
+		}
+		if name == "" {
+			return imported, nil
+		}
+		path, ok := paths[name]
+		if !ok {
+			return imported, fmt.Errorf("unknown or duplicate store %q in snapshot", name)
+		}
+		delete(paths, name)
+
+		err = importStore(logger, reader, backend, name, path, batchSize)
+		if err != nil {
+			return imported, err
+		}
+		imported = append(imported, path)
+	}
+}
+
+// importStore creates the store at the path with the content of the next section of the snapshot.
+func importStore(
+	logger logging.Logger,
+	reader *bufio.Reader,
+	backend string,
+	name string,
+	path string,
+	batchSize uint32) error {
+
+	store, err := newBaseStore(backend, logger, path)
+	if err != nil {
+		return fmt.Errorf("failed to create store at %s: %w", path, err)
+	}
+	count, err := kvstore.ImportStore(reader, store, batchSize)
+	if err != nil {
+		// Don't leave a partial store behind, it would be picked up by the node.
+		_ = store.Destroy()
+		return fmt.Errorf("failed to import store %s: %w", name, err)
+	}
+	if err = store.Shutdown(); err != nil {
+		return fmt.Errorf("failed to close store at %s: %w", path, err)
+	}
+	logger.Info("Imported store", "store", name, "path", path, "numKeys", count)
+	return nil
+}
+
+func writeSnapshotName(w *bufio.Writer, name string) error {
+	if err := w.WriteByte(byte(len(name))); err != nil {
+		return err
+	}
+	_, err := w.WriteString(name)
+	return err
+}
+
+func readSnapshotName(r *bufio.Reader) (string, error) {
+	length, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	name := make([]byte, length)
+	if _, err = io.ReadFull(r, name); err != nil {
+		return "", err
+	}
+	return string(name), nil
+}
@@ -0,0 +1,102 @@
+package node_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportSnapshot(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewNoopLogger()
+	dbPath := t.TempDir()
+	m := node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), logger, ":9090", [32]byte{}, -1, &coremock.MockWriter{}, nil)
+	schema := []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName}
+
+	// Fill the stores of the exported node
+	s, err := node.NewStore(node.LevelDBBackend, node.StorePath(dbPath, node.LevelDBBackend), logger, m, staleMeasure, storeDuration)
+	require.NoError(t, err)
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	_, err = s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	require.NoError(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	require.NoError(t, err)
+
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+	config := tablestore.DefaultLevelDBConfig(node.StoreV2Path(dbPath, node.LevelDBBackend))
+	config.Schema = schema
+	db, err := tablestore.Start(logger, config)
+	require.NoError(t, err)
+	_, _, err = node.NewLevelDBStoreV2(db, logger, time.Hour).StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	require.NoError(t, s.Shutdown())
+	require.NoError(t, db.Shutdown())
+
+	snapshot := &bytes.Buffer{}
+	err = node.ExportSnapshotFromDisk(logger, dbPath, node.LevelDBBackend, snapshot)
+	require.NoError(t, err)
+
+	// A truncated snapshot is rejected, and doesn't leave any store behind
+	importPath := t.TempDir()
+	truncated := bytes.NewReader(snapshot.Bytes()[:snapshot.Len()-10])
+	err = node.ImportSnapshot(logger, truncated, importPath, node.LevelDBBackend, 2)
+	require.Error(t, err)
+	_, err = os.Stat(node.StorePath(importPath, node.LevelDBBackend))
+	require.True(t, os.IsNotExist(err))
+
+	err = node.ImportSnapshot(logger, bytes.NewReader(snapshot.Bytes()), importPath, node.LevelDBBackend, 2)
+	require.NoError(t, err)
+
+	// The imported stores have the data of the exported stores
+	s, err = node.NewStore(node.LevelDBBackend, node.StorePath(importPath, node.LevelDBBackend), logger, m, staleMeasure, storeDuration)
+	require.NoError(t, err)
+	chunks, format, err := s.GetChunks(ctx, batchHeaderHash, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, pb.ChunkEncodingFormat_GOB, format)
+	require.Equal(t, blobsProto[1].Bundles[0].Chunks, chunks)
+	require.NoError(t, s.Shutdown())
+
+	config = tablestore.DefaultLevelDBConfig(node.StoreV2Path(importPath, node.LevelDBBackend))
+	config.Schema = schema
+	db, err = tablestore.Start(logger, config)
+	require.NoError(t, err)
+	chunksV2, err := node.NewLevelDBStoreV2(db, logger, time.Hour).GetChunks(blobKeys[2], 2)
+	require.NoError(t, err)
+	require.Len(t, chunksV2, len(bundles[2][2]))
+	require.NoError(t, db.Shutdown())
+
+	// The import doesn't overwrite existing stores
+	err = node.ImportSnapshot(logger, bytes.NewReader(snapshot.Bytes()), importPath, node.LevelDBBackend, 2)
+	require.Error(t, err)
+
+	// The bundles of a node sharding its chunks are not in its stores, so it can't be exported
+	require.NoError(t, os.WriteFile(filepath.Join(dbPath, "chunk_shards.json"), []byte(`["/mnt/disk0"]`), 0644))
+	err = node.ExportSnapshotFromDisk(logger, dbPath, node.LevelDBBackend, &bytes.Buffer{})
+	require.ErrorContains(t, err, "not supported")
+}
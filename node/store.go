@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/kvstore"
-	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/core"
@@ -40,14 +39,16 @@ type Store struct {
 	metrics *Metrics
 }
 
-// NewLevelDBStore creates a new Store object with a db at the provided path and the given logger.
-// TODO(jianoaix): parameterize this so we can switch between different database backends.
+// NewLevelDBStore creates a new Store object with a LevelDB db at the provided path and the given logger.
 func NewLevelDBStore(path string, logger logging.Logger, metrics *Metrics, blockStaleMeasure, storeDurationBlocks uint32) (*Store, error) {
-	// Create the db at the path. This is currently hardcoded to use
-	// levelDB.
-	db, err := leveldb.NewStore(logger, path)
+	return NewStore(LevelDBBackend, path, logger, metrics, blockStaleMeasure, storeDurationBlocks)
+}
+
+// NewStore creates a new Store object with a db of the given backend at the provided path and the given logger.
+func NewStore(backend string, path string, logger logging.Logger, metrics *Metrics, blockStaleMeasure, storeDurationBlocks uint32) (*Store, error) {
+	db, err := newBaseStore(backend, logger, path)
 	if err != nil {
-		logger.Error("Could not create leveldb database", "err", err)
+		logger.Error("Could not create database", "backend", backend, "err", err)
 		return nil, err
 	}
 
@@ -623,6 +624,11 @@ func (s *Store) DeleteKeys(ctx context.Context, keys *[][]byte) error {
 	return batch.Apply()
 }
 
+// Shutdown closes the database of the store.
+func (s *Store) Shutdown() error {
+	return s.db.Shutdown()
+}
+
 // Flattens an array of byte arrays (chunks) into a single byte array
 //
 // EncodeChunks(chunks) = (len(chunks[0]), chunks[0], len(chunks[1]), chunks[1], ...)
@@ -16,6 +16,9 @@ const (
 	BatchHeaderTableName     = "batch_headers"
 	BlobCertificateTableName = "blob_certificates"
 	BundleTableName          = "bundles"
+	// BundleSizeTableName holds the size of each bundle under the key of the bundle, so that the storage used by the
+	// bundles is known without reading them
+	BundleSizeTableName = "bundle_sizes"
 )
 
 type StoreV2 interface {
@@ -95,6 +98,8 @@ func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]k
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to get blob key: %v", err)
 		}
+		// Blobs may request to be retained for less than the default TTL
+		ttl := bundles.BlobCertificate.BlobHeader.GetRetentionPeriod(s.ttl)
 
 		// Store bundles
 		for quorum, bundle := range bundles.Bundles {
@@ -109,8 +114,25 @@ func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]k
 			}
 
 			keys = append(keys, bundlesKeyBuilder.Key(k))
-			dbBatch.PutWithTTL(bundlesKeyBuilder.Key(k), bundle, s.ttl)
+			dbBatch.PutWithTTL(bundlesKeyBuilder.Key(k), bundle, ttl)
 			size += uint64(len(bundle))
+
+			sizeKey, err := putBundleSize(s.db, dbBatch, k, len(bundle), ttl)
+			if err != nil {
+				return nil, 0, err
+			}
+			keys = append(keys, sizeKey)
+			size += bundleSizeBytes
+		}
+
+		// Store the blob record, so that the chunks of the blob can be scrubbed
+		recordKey, record, err := putBlobRecord(s.db, dbBatch, blobKey, bundles, ttl)
+		if err != nil {
+			return nil, 0, err
+		}
+		if recordKey != nil {
+			keys = append(keys, recordKey)
+			size += uint64(len(record))
 		}
 	}
 
@@ -142,7 +164,7 @@ func (s *storeV2) GetChunks(blobKey corev2.BlobKey, quorum core.QuorumID) ([][]b
 
 	bundle, err := s.db.Get(bundlesKeyBuilder.Key(k))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get bundle: %v", err)
+		return nil, fmt.Errorf("failed to get bundle: %w", err)
 	}
 
 	chunks, _, err := DecodeChunks(bundle)
@@ -0,0 +1,282 @@
+package node
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// StoreV2Inspector is implemented by the v2 stores whose content can be inspected by the node admin API. The
+// inspection methods scan the store, so they are meant for debugging rather than for serving requests.
+type StoreV2Inspector interface {
+	// ListBatches returns up to limit batches held by the store, in the order of their batch header hashes.
+	ListBatches(limit int) ([]*StoredBatch, error)
+	// GetBundles returns the bundles stored for the blob, in the order of their quorums. Returns an empty list if
+	// the store has no bundle of the blob.
+	GetBundles(blobKey corev2.BlobKey) ([]*StoredBundle, error)
+	// GetStorageUsage returns the number and size of the bundles held by the store for each quorum. It only reads the
+	// bundle size table, so it is cheap enough to be polled by the storage quotas.
+	GetStorageUsage() (map[core.QuorumID]*QuorumStorageUsage, error)
+}
+
+// StoredBatch is a batch held by the store.
+type StoredBatch struct {
+	BatchHeaderHash [32]byte
+	BatchHeader     *corev2.BatchHeader
+	// ExpiresAt is the time at which the batch header is deleted from the store
+	ExpiresAt time.Time
+}
+
+// StoredBundle is the bundle of chunks of a blob held by the store for a quorum.
+type StoredBundle struct {
+	QuorumID  core.QuorumID
+	NumChunks int
+	SizeBytes uint64
+	// ExpiresAt is the time at which the bundle is deleted from the store
+	ExpiresAt time.Time
+}
+
+// QuorumStorageUsage is the storage used by the bundles of a quorum.
+type QuorumStorageUsage struct {
+	NumBundles uint64
+	SizeBytes  uint64
+}
+
+var _ StoreV2Inspector = &storeV2{}
+
+func (s *storeV2) ListBatches(limit int) ([]*StoredBatch, error) {
+	batchHeaderKeyBuilder, err := s.db.GetKeyBuilder(BatchHeaderTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for batch header: %w", err)
+	}
+	it, err := s.db.NewTableIterator(batchHeaderKeyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over batch headers: %w", err)
+	}
+	defer it.Release()
+
+	batches := make([]*StoredBatch, 0)
+	keys := make([]kvstore.Key, 0)
+	for (limit <= 0 || len(batches) < limit) && it.Next() {
+		batchHeader, err := corev2.DeserializeBatchHeader(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize batch header: %w", err)
+		}
+		batch := &StoredBatch{
+			BatchHeader: batchHeader,
+		}
+		copy(batch.BatchHeaderHash[:], it.Key())
+		batches = append(batches, batch)
+		keys = append(keys, batchHeaderKeyBuilder.Key(batch.BatchHeaderHash[:]))
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over batch headers: %w", err)
+	}
+
+	expirationTimes, err := s.db.GetExpirationTimes(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiration times: %w", err)
+	}
+	for i, batch := range batches {
+		batch.ExpiresAt = expirationTimes[i]
+	}
+	return batches, nil
+}
+
+func (s *storeV2) GetBundles(blobKey corev2.BlobKey) ([]*StoredBundle, error) {
+	bundlesKeyBuilder, err := s.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for bundles: %w", err)
+	}
+	it, err := s.db.NewIterator(bundlesKeyBuilder.Key(blobKey[:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over bundles: %w", err)
+	}
+	defer it.Release()
+
+	bundles := make([]*StoredBundle, 0)
+	keys := make([]kvstore.Key, 0)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(blobKey)+1 {
+			continue
+		}
+		chunks, _, err := DecodeChunks(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode chunks: %w", err)
+		}
+		bundles = append(bundles, &StoredBundle{
+			QuorumID:  key[len(blobKey)],
+			NumChunks: len(chunks),
+			SizeBytes: uint64(len(it.Value())),
+		})
+		keys = append(keys, bundlesKeyBuilder.Key(append([]byte{}, key...)))
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over bundles: %w", err)
+	}
+
+	expirationTimes, err := s.db.GetExpirationTimes(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiration times: %w", err)
+	}
+	for i, bundle := range bundles {
+		bundle.ExpiresAt = expirationTimes[i]
+	}
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].QuorumID < bundles[j].QuorumID
+	})
+	return bundles, nil
+}
+
+func (s *storeV2) GetStorageUsage() (map[core.QuorumID]*QuorumStorageUsage, error) {
+	keyBuilder, err := s.db.GetKeyBuilder(BundleSizeTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for bundle sizes: %w", err)
+	}
+	it, err := s.db.NewTableIterator(keyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over bundle sizes: %w", err)
+	}
+	defer it.Release()
+
+	usage := make(map[core.QuorumID]*QuorumStorageUsage)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(corev2.BlobKey{})+1 || len(it.Value()) != bundleSizeBytes {
+			continue
+		}
+		quorum := key[len(key)-1]
+		if _, ok := usage[quorum]; !ok {
+			usage[quorum] = &QuorumStorageUsage{}
+		}
+		usage[quorum].NumBundles++
+		usage[quorum].SizeBytes += binary.LittleEndian.Uint64(it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over bundle sizes: %w", err)
+	}
+	return usage, nil
+}
+
+// bundleSizesBackfillBatchSize is the number of bundle sizes added at once when backfilling the bundle size table
+const bundleSizesBackfillBatchSize = 1024
+
+// bundleSizeBytes is the length of the values of the bundle size table
+const bundleSizeBytes = 8
+
+// bundleSizesBackfilledKey marks, in the bundle size table, that the sizes of the bundles stored before the table
+// existed were added. It is shorter than the bundle keys, so it is never mistaken for the size of a bundle.
+var bundleSizesBackfilledKey = []byte("backfilled")
+
+// putBundleSize adds the size of the bundle to the batch, expiring along with the bundle. Returns the key of the size.
+func putBundleSize(
+	db kvstore.TableStore,
+	dbBatch kvstore.TTLBatch[kvstore.Key],
+	bundleKey []byte,
+	size int,
+	ttl time.Duration) (kvstore.Key, error) {
+
+	keyBuilder, err := db.GetKeyBuilder(BundleSizeTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for bundle sizes: %v", err)
+	}
+	key := keyBuilder.Key(bundleKey)
+	dbBatch.PutWithTTL(key, binary.LittleEndian.AppendUint64(nil, uint64(size)), ttl)
+	return key, nil
+}
+
+// BackfillBundleSizes adds the sizes of the bundles stored before the bundle size table existed to the table of the
+// sizes store. It reads every bundle of the stores, so it only runs the first time the node starts with the table.
+func (s *storeV2) BackfillBundleSizes() error {
+	return backfillBundleSizes(s, []*storeV2{s})
+}
+
+func backfillBundleSizes(sizes *storeV2, stores []*storeV2) error {
+	sizesKeyBuilder, err := sizes.db.GetKeyBuilder(BundleSizeTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get key builder for bundle sizes: %w", err)
+	}
+	markerKey := sizesKeyBuilder.Key(bundleSizesBackfilledKey)
+	if _, err = sizes.db.Get(markerKey); err == nil {
+		return nil
+	} else if !errors.Is(err, kvstore.ErrNotFound) {
+		return fmt.Errorf("failed to get bundle sizes marker: %w", err)
+	}
+
+	for _, store := range stores {
+		if err = backfillStoreBundleSizes(sizes, sizesKeyBuilder, store); err != nil {
+			return err
+		}
+	}
+	if err = sizes.db.Put(markerKey, []byte{}); err != nil {
+		return fmt.Errorf("failed to put bundle sizes marker: %w", err)
+	}
+	return nil
+}
+
+// backfillStoreBundleSizes adds the sizes of the bundles of the store, with the expiration times of the bundles.
+func backfillStoreBundleSizes(sizes *storeV2, sizesKeyBuilder kvstore.KeyBuilder, store *storeV2) error {
+	bundlesKeyBuilder, err := store.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get key builder for bundles: %w", err)
+	}
+	it, err := store.db.NewTableIterator(bundlesKeyBuilder)
+	if err != nil {
+		return fmt.Errorf("failed to iterate over bundles: %w", err)
+	}
+	defer it.Release()
+
+	keys := make([]kvstore.Key, 0, bundleSizesBackfillBatchSize)
+	bundleSizes := make([]int, 0, bundleSizesBackfillBatchSize)
+	flush := func() error {
+		expirationTimes, err := store.db.GetExpirationTimes(keys)
+		if err != nil {
+			return fmt.Errorf("failed to get expiration times: %w", err)
+		}
+		dbBatch := sizes.db.NewTTLBatch()
+		for i, key := range keys {
+			sizeKey := sizesKeyBuilder.Key(key.Bytes())
+			sizeBytes := binary.LittleEndian.AppendUint64(nil, uint64(bundleSizes[i]))
+			if expirationTimes[i].IsZero() {
+				dbBatch.PutWithTTL(sizeKey, sizeBytes, sizes.ttl)
+			} else {
+				dbBatch.PutWithExpiration(sizeKey, sizeBytes, expirationTimes[i])
+			}
+		}
+		if err := dbBatch.Apply(); err != nil {
+			return fmt.Errorf("failed to put bundle sizes: %w", err)
+		}
+		keys = keys[:0]
+		bundleSizes = bundleSizes[:0]
+		return nil
+	}
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(corev2.BlobKey{})+1 {
+			continue
+		}
+		keys = append(keys, bundlesKeyBuilder.Key(slices.Clone(key)))
+		bundleSizes = append(bundleSizes, len(it.Value()))
+		if len(keys) == bundleSizesBackfillBatchSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err = it.Error(); err != nil {
+		return fmt.Errorf("failed to iterate over bundles: %w", err)
+	}
+	if len(keys) > 0 {
+		return flush()
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package node
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// ScrubbableStoreV2 is implemented by the v2 stores whose bundles can be re-verified by the chunk scrubber. Only the
+// blobs stored along with the assignments of the node can be scrubbed, since the chunk indices are needed to verify
+// the chunk proofs.
+type ScrubbableStoreV2 interface {
+	// ListBlobs returns up to limit blobs held by the store whose blob keys are greater than after, in the order of
+	// their blob keys. All blobs are listed from the start if after is nil.
+	ListBlobs(after *corev2.BlobKey, limit int) ([]*StoredBlob, error)
+	// GetBundle returns the raw bundle of the blob for the quorum.
+	GetBundle(blobKey corev2.BlobKey, quorum core.QuorumID) ([]byte, error)
+	// ReplaceBundle replaces the raw bundle of the blob for the quorum, keeping its expiration time. Fails with
+	// kvstore.ErrNotFound if the store has no bundle of the blob for the quorum.
+	ReplaceBundle(blobKey corev2.BlobKey, quorum core.QuorumID, bundle []byte) error
+}
+
+// StoredBlob is a blob whose bundles are held by the store, along with the chunks assigned to the node in each
+// quorum.
+type StoredBlob struct {
+	BlobKey         corev2.BlobKey
+	BlobCertificate *corev2.BlobCertificate
+	Assignments     map[core.QuorumID]corev2.Assignment
+}
+
+// storedBlobRecord is the serialized form of a StoredBlob in the blob certificate table.
+type storedBlobRecord struct {
+	BlobCertificate []byte
+	Assignments     map[core.QuorumID]corev2.Assignment
+}
+
+var _ ScrubbableStoreV2 = &storeV2{}
+var _ ScrubbableStoreV2 = &shardedStoreV2{}
+
+// putBlobRecord adds the record of the blob to the batch, if the bundles were stored along with the assignments of
+// the node. Returns the key of the record, nil if there is none.
+func putBlobRecord(
+	db kvstore.TableStore,
+	dbBatch kvstore.TTLBatch[kvstore.Key],
+	blobKey corev2.BlobKey,
+	bundles *RawBundles,
+	ttl time.Duration) (kvstore.Key, []byte, error) {
+
+	if len(bundles.Assignments) == 0 {
+		return nil, nil, nil
+	}
+	keyBuilder, err := db.GetKeyBuilder(BlobCertificateTableName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get key builder for blob certificates: %v", err)
+	}
+	certBytes, err := bundles.BlobCertificate.Serialize()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize blob certificate: %v", err)
+	}
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(storedBlobRecord{
+		BlobCertificate: certBytes,
+		Assignments:     bundles.Assignments,
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize blob record: %v", err)
+	}
+	key := keyBuilder.Key(blobKey[:])
+	dbBatch.PutWithTTL(key, buf.Bytes(), ttl)
+	return key, buf.Bytes(), nil
+}
+
+func (s *storeV2) ListBlobs(after *corev2.BlobKey, limit int) ([]*StoredBlob, error) {
+	keyBuilder, err := s.db.GetKeyBuilder(BlobCertificateTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for blob certificates: %w", err)
+	}
+	it, err := s.db.NewTableIterator(keyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over blob certificates: %w", err)
+	}
+	defer it.Release()
+
+	blobs := make([]*StoredBlob, 0)
+	valid := it.First()
+	if after != nil {
+		valid = it.Seek(after[:])
+		if valid && bytes.Equal(it.Key(), after[:]) {
+			valid = it.Next()
+		}
+	}
+	for ; valid && (limit <= 0 || len(blobs) < limit); valid = it.Next() {
+		if len(it.Key()) != len(corev2.BlobKey{}) {
+			continue
+		}
+		var record storedBlobRecord
+		if err := gob.NewDecoder(bytes.NewReader(it.Value())).Decode(&record); err != nil {
+			return nil, fmt.Errorf("failed to deserialize blob record: %w", err)
+		}
+		cert, err := corev2.DeserializeBlobCertificate(record.BlobCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize blob certificate: %w", err)
+		}
+		blob := &StoredBlob{
+			BlobCertificate: cert,
+			Assignments:     record.Assignments,
+		}
+		copy(blob.BlobKey[:], it.Key())
+		blobs = append(blobs, blob)
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over blob certificates: %w", err)
+	}
+	return blobs, nil
+}
+
+func (s *storeV2) GetBundle(blobKey corev2.BlobKey, quorum core.QuorumID) ([]byte, error) {
+	key, err := s.bundleKey(blobKey, quorum)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := s.db.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bundle: %w", err)
+	}
+	return bundle, nil
+}
+
+func (s *storeV2) ReplaceBundle(blobKey corev2.BlobKey, quorum core.QuorumID, bundle []byte) error {
+	key, err := s.bundleKey(blobKey, quorum)
+	if err != nil {
+		return err
+	}
+	if _, err = s.db.Get(key); err != nil {
+		return fmt.Errorf("failed to get bundle: %w", err)
+	}
+	expirationTimes, err := s.db.GetExpirationTimes([]kvstore.Key{key})
+	if err != nil {
+		return fmt.Errorf("failed to get expiration time: %w", err)
+	}
+	dbBatch := s.db.NewTTLBatch()
+	if expirationTimes[0].IsZero() {
+		dbBatch.PutWithTTL(key, bundle, s.ttl)
+	} else {
+		dbBatch.PutWithExpiration(key, bundle, expirationTimes[0])
+	}
+	if err = dbBatch.Apply(); err != nil {
+		return fmt.Errorf("failed to replace bundle: %w", err)
+	}
+	return nil
+}
+
+func (s *storeV2) bundleKey(blobKey corev2.BlobKey, quorum core.QuorumID) (kvstore.Key, error) {
+	bundlesKeyBuilder, err := s.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for bundles: %w", err)
+	}
+	k, err := BundleKey(blobKey, quorum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key for bundles: %w", err)
+	}
+	return bundlesKeyBuilder.Key(k), nil
+}
+
+// ListBlobs lists the blobs of the main store, which holds the blob records.
+func (s *shardedStoreV2) ListBlobs(after *corev2.BlobKey, limit int) ([]*StoredBlob, error) {
+	return s.main.ListBlobs(after, limit)
+}
+
+func (s *shardedStoreV2) GetBundle(blobKey corev2.BlobKey, quorum core.QuorumID) ([]byte, error) {
+	for _, store := range s.lookupStores(blobKey) {
+		bundle, err := store.GetBundle(blobKey, quorum)
+		if err == nil {
+			return bundle, nil
+		}
+		if !errors.Is(err, kvstore.ErrNotFound) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("failed to get bundle: %w", kvstore.ErrNotFound)
+}
+
+// ReplaceBundle replaces the bundle in the store holding it.
+func (s *shardedStoreV2) ReplaceBundle(blobKey corev2.BlobKey, quorum core.QuorumID, bundle []byte) error {
+	for _, store := range s.lookupStores(blobKey) {
+		err := store.ReplaceBundle(blobKey, quorum, bundle)
+		if !errors.Is(err, kvstore.ErrNotFound) {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to get bundle: %w", kvstore.ErrNotFound)
+}
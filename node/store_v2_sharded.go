@@ -0,0 +1,577 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// chunkShardLayoutFile records, in the DB path of the node, the chunk store paths the bundles were last
+	// balanced across
+	chunkShardLayoutFile = "chunk_shards.json"
+	// chunkShardProbeFile is written and read back in the chunk store paths to check the health of their disks
+	chunkShardProbeFile = ".health_probe"
+	// rebalanceBatchSize is the number of bundles moved between chunk stores at once
+	rebalanceBatchSize = 64
+)
+
+// ChunkShard is a store holding a share of the bundles of the node, on its own disk.
+type ChunkShard struct {
+	// Path is the mount point of the disk of the shard
+	Path  string
+	store *storeV2
+	// healthy is false once a health check of the disk failed, until a later one succeeds
+	healthy atomic.Bool
+}
+
+// NewChunkShard creates a shard of the bundles of the node at the path, backed by the given store. The store only
+// needs the bundle table.
+func NewChunkShard(path string, db kvstore.TableStore, logger logging.Logger, ttl time.Duration) *ChunkShard {
+	shard := &ChunkShard{
+		Path:  filepath.Clean(path),
+		store: NewLevelDBStoreV2(db, logger, ttl),
+	}
+	shard.healthy.Store(true)
+	return shard
+}
+
+// ChunkShardHealth is the result of the health check of the disk of a chunk shard.
+type ChunkShardHealth struct {
+	Path    string
+	Healthy bool
+	// FreeBytes is the free space of the disk, 0 if unknown
+	FreeBytes uint64
+	// Err is the reason the disk is unhealthy
+	Err error
+}
+
+// shardedStoreV2 is a StoreV2 sharding the bundles across the disks of several chunk shards, so that the node isn't
+// bounded by the size of a single volume. The bundles of a blob are assigned to the shards by rendezvous hashing of
+// the blob key, so adding a shard only moves the share of the bundles the new shard takes over. The batch headers
+// and blob records are kept in the main store. Bundles are written to the next shard in the order of the blob when a
+// disk is unhealthy, and are looked up in the owning shard first, falling back to the other shards in that order on a
+// miss, then to the main store which holds the bundles stored before the node was sharded.
+type shardedStoreV2 struct {
+	main   *storeV2
+	shards []*ChunkShard
+	logger logging.Logger
+	ttl    time.Duration
+}
+
+var _ StoreV2 = &shardedStoreV2{}
+var _ StoreV2Inspector = &shardedStoreV2{}
+var _ StoreCompactor = &shardedStoreV2{}
+
+// NewShardedStoreV2 creates a StoreV2 keeping the batch headers in the main store, and sharding the bundles across
+// the chunk shards.
+func NewShardedStoreV2(
+	main kvstore.TableStore,
+	shards []*ChunkShard,
+	logger logging.Logger,
+	ttl time.Duration) (*shardedStoreV2, error) {
+
+	if len(shards) == 0 {
+		return nil, errors.New("at least one chunk shard is required")
+	}
+	paths := make(map[string]struct{}, len(shards))
+	for _, shard := range shards {
+		if _, ok := paths[shard.Path]; ok {
+			return nil, fmt.Errorf("duplicate chunk store path %s", shard.Path)
+		}
+		paths[shard.Path] = struct{}{}
+	}
+	return &shardedStoreV2{
+		main:   NewLevelDBStoreV2(main, logger, ttl),
+		shards: shards,
+		logger: logger,
+		ttl:    ttl,
+	}, nil
+}
+
+// rankShards returns the shards in the order of preference of the blob, the first one owning its bundles.
+func (s *shardedStoreV2) rankShards(blobKey corev2.BlobKey) []*ChunkShard {
+	scores := make(map[*ChunkShard]uint64, len(s.shards))
+	for _, shard := range s.shards {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(shard.Path))
+		_, _ = h.Write(blobKey[:])
+		scores[shard] = h.Sum64()
+	}
+	ranked := slices.Clone(s.shards)
+	sort.Slice(ranked, func(i, j int) bool {
+		if scores[ranked[i]] == scores[ranked[j]] {
+			return ranked[i].Path < ranked[j].Path
+		}
+		return scores[ranked[i]] > scores[ranked[j]]
+	})
+	return ranked
+}
+
+// writeShard returns the shard the bundles of the blob are written to, which is the first healthy one of the blob.
+func (s *shardedStoreV2) writeShard(blobKey corev2.BlobKey) (*ChunkShard, error) {
+	for _, shard := range s.rankShards(blobKey) {
+		if shard.healthy.Load() {
+			return shard, nil
+		}
+	}
+	return nil, errors.New("no healthy chunk store")
+}
+
+func (s *shardedStoreV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]kvstore.Key, uint64, error) {
+	if len(rawBundles) == 0 {
+		return nil, 0, fmt.Errorf("no raw bundles")
+	}
+	if len(rawBundles) != len(batch.BlobCertificates) {
+		return nil, 0, fmt.Errorf("mismatch between raw bundles (%d) and blob certificates (%d)", len(rawBundles), len(batch.BlobCertificates))
+	}
+
+	batchHeaderKeyBuilder, err := s.main.db.GetKeyBuilder(BatchHeaderTableName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get key builder for batch header: %v", err)
+	}
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to hash batch header: %v", err)
+	}
+	batchHeaderKey := batchHeaderKeyBuilder.Key(batchHeaderHash[:])
+	if _, err = s.main.db.Get(batchHeaderKey); err == nil {
+		return nil, 0, ErrBatchAlreadyExist
+	}
+	batchHeaderBytes, err := batch.BatchHeader.Serialize()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to serialize batch header: %v", err)
+	}
+
+	// The bundles are written to their shards first, so that the batch header is only stored once its bundles are.
+	size := uint64(len(batchHeaderBytes))
+	keys := []kvstore.Key{batchHeaderKey}
+	shardBatches := make(map[*ChunkShard]kvstore.TTLBatch[kvstore.Key])
+	mainBatch := s.main.db.NewTTLBatch()
+	for _, bundles := range rawBundles {
+		blobKey, err := bundles.BlobCertificate.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get blob key: %v", err)
+		}
+		// Blobs may request to be retained for less than the default TTL
+		ttl := bundles.BlobCertificate.BlobHeader.GetRetentionPeriod(s.ttl)
+
+		shard, err := s.writeShard(blobKey)
+		if err != nil {
+			return nil, 0, err
+		}
+		bundlesKeyBuilder, err := shard.store.db.GetKeyBuilder(BundleTableName)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get key builder for bundles: %v", err)
+		}
+		if _, ok := shardBatches[shard]; !ok {
+			shardBatches[shard] = shard.store.db.NewTTLBatch()
+		}
+		for quorum, bundle := range bundles.Bundles {
+			k, err := BundleKey(blobKey, quorum)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to get key for bundles: %v", err)
+			}
+			keys = append(keys, bundlesKeyBuilder.Key(k))
+			shardBatches[shard].PutWithTTL(bundlesKeyBuilder.Key(k), bundle, ttl)
+			size += uint64(len(bundle))
+
+			// The sizes are kept in the main store, so that they aren't moved along with the bundles
+			sizeKey, err := putBundleSize(s.main.db, mainBatch, k, len(bundle), ttl)
+			if err != nil {
+				return nil, 0, err
+			}
+			keys = append(keys, sizeKey)
+			size += bundleSizeBytes
+		}
+
+		recordKey, record, err := putBlobRecord(s.main.db, mainBatch, blobKey, bundles, ttl)
+		if err != nil {
+			return nil, 0, err
+		}
+		if recordKey != nil {
+			keys = append(keys, recordKey)
+			size += uint64(len(record))
+		}
+	}
+
+	for shard, shardBatch := range shardBatches {
+		if err := shardBatch.Apply(); err != nil {
+			if deleteErr := s.DeleteKeys(keys); deleteErr != nil {
+				s.logger.Error("failed to delete the bundles of a partially stored batch", "err", deleteErr)
+			}
+			return nil, 0, fmt.Errorf("failed to apply batch to chunk store %s: %v", shard.Path, err)
+		}
+	}
+
+	mainBatch.PutWithTTL(batchHeaderKey, batchHeaderBytes, s.ttl)
+	if err := mainBatch.Apply(); err != nil {
+		if deleteErr := s.DeleteKeys(keys); deleteErr != nil {
+			s.logger.Error("failed to delete the bundles of a partially stored batch", "err", deleteErr)
+		}
+		return nil, 0, fmt.Errorf("failed to store batch header: %v", err)
+	}
+
+	return keys, size, nil
+}
+
+// DeleteKeys deletes the keys from the main store and all shards. The bundles are deleted from every shard, since
+// they may have been moved by a rebalancing.
+func (s *shardedStoreV2) DeleteKeys(keys []kvstore.Key) error {
+	bundleKeys := make([][]byte, 0, len(keys))
+	mainKeys := make([]kvstore.Key, 0, len(keys))
+	for _, key := range keys {
+		if key.Builder().TableName() == BundleTableName {
+			bundleKeys = append(bundleKeys, key.Bytes())
+		} else {
+			mainKeys = append(mainKeys, key)
+		}
+	}
+
+	var errs []error
+	if len(mainKeys) > 0 {
+		if err := s.main.DeleteKeys(mainKeys); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete keys from main store: %w", err))
+		}
+	}
+	if len(bundleKeys) > 0 {
+		for _, shard := range s.shards {
+			if err := shard.deleteBundles(bundleKeys); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete keys from chunk store %s: %w", shard.Path, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *shardedStoreV2) GetChunks(blobKey corev2.BlobKey, quorum core.QuorumID) ([][]byte, error) {
+	var lastErr error
+	for _, store := range s.lookupStores(blobKey) {
+		chunks, err := store.GetChunks(blobKey, quorum)
+		if err == nil {
+			return chunks, nil
+		}
+		if !errors.Is(err, kvstore.ErrNotFound) {
+			s.logger.Warn("Failed to get chunks from chunk store", "blobKey", blobKey.Hex(), "err", err)
+			lastErr = err
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("failed to get bundle: %w", kvstore.ErrNotFound)
+}
+
+// lookupStores returns the stores the bundles of the blob are looked up in, in order: the owning shard first, then
+// the shards the bundles may have been written to while the owner was unhealthy, then the main store.
+func (s *shardedStoreV2) lookupStores(blobKey corev2.BlobKey) []*storeV2 {
+	stores := make([]*storeV2, 0, len(s.shards)+1)
+	for _, shard := range s.rankShards(blobKey) {
+		stores = append(stores, shard.store)
+	}
+	return append(stores, s.main)
+}
+
+func (s *shardedStoreV2) ListBatches(limit int) ([]*StoredBatch, error) {
+	return s.main.ListBatches(limit)
+}
+
+// GetBundles returns the bundles of the blob from the first store holding any, starting with the owning shard. The
+// bundles of a blob are written to and moved between stores together, so they're never split across stores.
+func (s *shardedStoreV2) GetBundles(blobKey corev2.BlobKey) ([]*StoredBundle, error) {
+	for _, store := range s.lookupStores(blobKey) {
+		bundles, err := store.GetBundles(blobKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(bundles) > 0 {
+			return bundles, nil
+		}
+	}
+	return []*StoredBundle{}, nil
+}
+
+// GetStorageUsage returns the usage of the main store, which holds the sizes of the bundles of all shards.
+func (s *shardedStoreV2) GetStorageUsage() (map[core.QuorumID]*QuorumStorageUsage, error) {
+	return s.main.GetStorageUsage()
+}
+
+// BackfillBundleSizes adds the sizes of the bundles of the main store and the shards stored before the bundle size
+// table existed to the main store.
+func (s *shardedStoreV2) BackfillBundleSizes() error {
+	stores := []*storeV2{s.main}
+	for _, shard := range s.shards {
+		stores = append(stores, shard.store)
+	}
+	return backfillBundleSizes(s.main, stores)
+}
+
+// Compact compacts the main store and the chunk stores, and returns their total size on disk before and after the
+// compaction.
+func (s *shardedStoreV2) Compact() (uint64, uint64, error) {
+	sizeBefore, sizeAfter, err := s.main.Compact()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, shard := range s.shards {
+		before, after, err := shard.store.Compact()
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to compact chunk store %s: %w", shard.Path, err)
+		}
+		sizeBefore += before
+		sizeAfter += after
+	}
+	return sizeBefore, sizeAfter, nil
+}
+
+// CheckHealth checks the disk of each shard by writing, reading back and removing a probe file. Bundles are not
+// written to the shards whose disks are unhealthy until a later check succeeds.
+func (s *shardedStoreV2) CheckHealth() []ChunkShardHealth {
+	health := make([]ChunkShardHealth, len(s.shards))
+	for i, shard := range s.shards {
+		health[i] = shard.checkHealth()
+		if health[i].Healthy != shard.healthy.Swap(health[i].Healthy) {
+			if health[i].Healthy {
+				s.logger.Info("Chunk store disk is healthy again", "path", shard.Path)
+			} else {
+				s.logger.Error("Chunk store disk is unhealthy, its bundles are written to other disks",
+					"path", shard.Path, "err", health[i].Err)
+			}
+		}
+	}
+	return health
+}
+
+func (c *ChunkShard) checkHealth() ChunkShardHealth {
+	health := ChunkShardHealth{Path: c.Path}
+	probe := []byte(time.Now().UTC().Format(time.RFC3339Nano))
+	probePath := filepath.Join(c.Path, chunkShardProbeFile)
+	if err := os.WriteFile(probePath, probe, 0600); err != nil {
+		health.Err = fmt.Errorf("failed to write probe file: %w", err)
+		return health
+	}
+	read, err := os.ReadFile(probePath)
+	if err != nil {
+		health.Err = fmt.Errorf("failed to read probe file: %w", err)
+		return health
+	}
+	if string(read) != string(probe) {
+		health.Err = errors.New("probe file read back with different content")
+		return health
+	}
+	if err = os.Remove(probePath); err != nil {
+		health.Err = fmt.Errorf("failed to remove probe file: %w", err)
+		return health
+	}
+	health.Healthy = true
+	if free, err := freeDiskBytes(c.Path); err == nil {
+		health.FreeBytes = free
+	}
+	return health
+}
+
+func (c *ChunkShard) deleteBundles(keys [][]byte) error {
+	keyBuilder, err := c.store.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get key builder for bundles: %w", err)
+	}
+	dbBatch := c.store.db.NewTTLBatch()
+	for _, key := range keys {
+		dbBatch.Delete(keyBuilder.Key(key))
+	}
+	return dbBatch.Apply()
+}
+
+// Rebalance moves the bundles that are not in their owning shard, e.g. after shards were added or before the node
+// was sharded, if the chunk store paths changed since the last rebalancing. The bundles remain readable while they
+// are moved. The paths are recorded in the layout file once all bundles are in place, so the bundles whose owner is
+// unhealthy are left where they are and an error is returned for the rebalancing to be retried.
+func (s *shardedStoreV2) Rebalance(ctx context.Context, layoutPath string) error {
+	paths := make([]string, len(s.shards))
+	for i, shard := range s.shards {
+		paths[i] = shard.Path
+	}
+	slices.Sort(paths)
+
+	previous, err := readChunkShardLayout(layoutPath)
+	if err != nil {
+		return err
+	}
+	if slices.Equal(previous, paths) {
+		return nil
+	}
+	for _, path := range previous {
+		if !slices.Contains(paths, path) {
+			s.logger.Warn("Chunk store path was removed, its bundles are no longer served", "path", path)
+		}
+	}
+
+	start := time.Now()
+	s.logger.Info("Rebalancing bundles across chunk stores", "paths", paths, "previousPaths", previous)
+	moved, skipped, err := s.moveBundles(ctx, s.main, nil)
+	if err != nil {
+		return err
+	}
+	for _, shard := range s.shards {
+		shardMoved, shardSkipped, err := s.moveBundles(ctx, shard.store, shard)
+		if err != nil {
+			return err
+		}
+		moved += shardMoved
+		skipped += shardSkipped
+	}
+	if skipped > 0 {
+		return fmt.Errorf("moved %d bundles, %d bundles whose chunk store is unhealthy are pending", moved, skipped)
+	}
+
+	if err = writeChunkShardLayout(layoutPath, paths); err != nil {
+		return err
+	}
+	s.logger.Info("Rebalanced bundles across chunk stores", "movedBundles", moved, "duration", time.Since(start))
+	return nil
+}
+
+// moveBundles moves the bundles of the store that belong to another shard to their owner, keeping their expiration
+// times. The source shard is nil for the main store, whose bundles all belong to a shard. The bundles of a blob are
+// moved in the same batch. Returns the number of bundles moved, and of bundles not moved because their owner is
+// unhealthy.
+func (s *shardedStoreV2) moveBundles(
+	ctx context.Context,
+	source *storeV2,
+	sourceShard *ChunkShard,
+) (int, int, error) {
+	keyBuilder, err := source.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get key builder for bundles: %w", err)
+	}
+	it, err := source.db.NewTableIterator(keyBuilder)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to iterate over bundles: %w", err)
+	}
+	defer it.Release()
+
+	moved := 0
+	skipped := 0
+	pending := make(map[*ChunkShard][][2][]byte)
+	flush := func() error {
+		for owner, bundles := range pending {
+			if err := s.moveBatch(source, owner, bundles); err != nil {
+				return err
+			}
+			moved += len(bundles)
+		}
+		clear(pending)
+		return nil
+	}
+
+	numPending := 0
+	var lastBlobKey corev2.BlobKey
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return moved, skipped, err
+		}
+		key := it.Key()
+		if len(key) != len(corev2.BlobKey{})+1 {
+			continue
+		}
+		blobKey := corev2.BlobKey(key[:len(key)-1])
+		owner := s.rankShards(blobKey)[0]
+		if owner == sourceShard {
+			continue
+		}
+		if !owner.healthy.Load() {
+			skipped++
+			continue
+		}
+		// The keys are sorted by blob key, so only flush between blobs
+		if numPending >= rebalanceBatchSize && blobKey != lastBlobKey {
+			if err := flush(); err != nil {
+				return moved, skipped, err
+			}
+			numPending = 0
+		}
+		pending[owner] = append(pending[owner], [2][]byte{slices.Clone(key), slices.Clone(it.Value())})
+		numPending++
+		lastBlobKey = blobKey
+	}
+	if err := it.Error(); err != nil {
+		return moved, skipped, fmt.Errorf("failed to iterate over bundles: %w", err)
+	}
+	return moved, skipped, flush()
+}
+
+// moveBatch writes the bundles to the owner with their expiration times, then deletes them from the source.
+func (s *shardedStoreV2) moveBatch(source *storeV2, owner *ChunkShard, bundles [][2][]byte) error {
+	sourceKeyBuilder, err := source.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get key builder for bundles: %w", err)
+	}
+	ownerKeyBuilder, err := owner.store.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get key builder for bundles: %w", err)
+	}
+
+	sourceKeys := make([]kvstore.Key, len(bundles))
+	for i, bundle := range bundles {
+		sourceKeys[i] = sourceKeyBuilder.Key(bundle[0])
+	}
+	expirationTimes, err := source.db.GetExpirationTimes(sourceKeys)
+	if err != nil {
+		return fmt.Errorf("failed to get expiration times: %w", err)
+	}
+
+	ownerBatch := owner.store.db.NewTTLBatch()
+	for i, bundle := range bundles {
+		if expirationTimes[i].IsZero() {
+			ownerBatch.PutWithTTL(ownerKeyBuilder.Key(bundle[0]), bundle[1], s.ttl)
+		} else {
+			ownerBatch.PutWithExpiration(ownerKeyBuilder.Key(bundle[0]), bundle[1], expirationTimes[i])
+		}
+	}
+	if err = ownerBatch.Apply(); err != nil {
+		return fmt.Errorf("failed to write bundles to chunk store %s: %w", owner.Path, err)
+	}
+	if err = source.DeleteKeys(sourceKeys); err != nil {
+		return fmt.Errorf("failed to delete moved bundles: %w", err)
+	}
+	return nil
+}
+
+func readChunkShardLayout(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chunk store layout: %w", err)
+	}
+	var paths []string
+	if err = json.Unmarshal(data, &paths); err != nil {
+		return nil, fmt.Errorf("failed to parse chunk store layout %s: %w", path, err)
+	}
+	return paths, nil
+}
+
+func writeChunkShardLayout(path string, paths []string) error {
+	data, err := json.Marshal(paths)
+	if err != nil {
+		return fmt.Errorf("failed to serialize chunk store layout: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write chunk store layout: %w", err)
+	}
+	return nil
+}
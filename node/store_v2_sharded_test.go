@@ -0,0 +1,286 @@
+package node_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func mockRawBundles(t *testing.T) (*corev2.Batch, []*node.RawBundles) {
+	_, batch, bundles := nodemock.MockBatch(t)
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[uint8][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+	return batch, rawBundles
+}
+
+func startTestTableStore(t *testing.T, schema ...string) kvstore.TableStore {
+	config := tablestore.DefaultLevelDBConfig(t.TempDir())
+	config.Schema = schema
+	db, err := tablestore.Start(logging.NewNoopLogger(), config)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Shutdown()
+	})
+	return db
+}
+
+func newTestChunkShard(t *testing.T, path string) (*node.ChunkShard, kvstore.TableStore) {
+	db := startTestTableStore(t, node.BundleTableName)
+	return node.NewChunkShard(path, db, logging.NewNoopLogger(), 10*time.Second), db
+}
+
+// bundleLocations returns, for each bundle of the batch, the index of the store holding it. Fails if a bundle is in
+// more than one store. Nil stores are skipped.
+func bundleLocations(t *testing.T, rawBundles []*node.RawBundles, dbs ...kvstore.TableStore) map[string]int {
+	locations := make(map[string]int)
+	for _, bundles := range rawBundles {
+		blobKey, err := bundles.BlobCertificate.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		for quorum := range bundles.Bundles {
+			k, err := node.BundleKey(blobKey, quorum)
+			require.NoError(t, err)
+			for i, db := range dbs {
+				if db == nil {
+					continue
+				}
+				keyBuilder, err := db.GetKeyBuilder(node.BundleTableName)
+				require.NoError(t, err)
+				if _, err := db.Get(keyBuilder.Key(k)); err == nil {
+					_, found := locations[string(k)]
+					require.False(t, found, "bundle stored in more than one store")
+					locations[string(k)] = i
+				}
+			}
+		}
+	}
+	return locations
+}
+
+func requireChunks(t *testing.T, s node.StoreV2, rawBundles []*node.RawBundles) {
+	for _, bundles := range rawBundles {
+		blobKey, err := bundles.BlobCertificate.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		for quorum := range bundles.Bundles {
+			chunks, err := s.GetChunks(blobKey, quorum)
+			require.NoError(t, err)
+			require.NotEmpty(t, chunks)
+		}
+	}
+}
+
+func TestShardedStoreV2(t *testing.T) {
+	batch, rawBundles := mockRawBundles(t)
+	mainDB := startTestTableStore(t, node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName)
+	shard0, db0 := newTestChunkShard(t, t.TempDir())
+	shard1, db1 := newTestChunkShard(t, t.TempDir())
+	s, err := node.NewShardedStoreV2(mainDB, []*node.ChunkShard{shard0, shard1}, logging.NewNoopLogger(), 10*time.Second)
+	require.NoError(t, err)
+
+	keys, _, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	// The batch header, the bundles and their sizes
+	require.Len(t, keys, 13)
+
+	// The bundles of a blob are all in the same shard, and none are in the main store
+	locations := bundleLocations(t, rawBundles, mainDB, db0, db1)
+	require.Len(t, locations, 6)
+	for _, bundles := range rawBundles {
+		blobKey, err := bundles.BlobCertificate.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		shard := -1
+		for quorum := range bundles.Bundles {
+			k, err := node.BundleKey(blobKey, quorum)
+			require.NoError(t, err)
+			require.NotEqual(t, 0, locations[string(k)])
+			if shard == -1 {
+				shard = locations[string(k)]
+			}
+			require.Equal(t, shard, locations[string(k)])
+		}
+	}
+	requireChunks(t, s, rawBundles)
+
+	// The batch header is in the main store
+	batches, err := s.ListBatches(10)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	_, _, err = s.StoreBatch(batch, rawBundles)
+	require.ErrorIs(t, err, node.ErrBatchAlreadyExist)
+
+	usage, err := s.GetStorageUsage()
+	require.NoError(t, err)
+	numBundles := uint64(0)
+	for _, quorumUsage := range usage {
+		numBundles += quorumUsage.NumBundles
+	}
+	require.Equal(t, uint64(6), numBundles)
+	blobKey, err := rawBundles[0].BlobCertificate.BlobHeader.BlobKey()
+	require.NoError(t, err)
+	storedBundles, err := s.GetBundles(blobKey)
+	require.NoError(t, err)
+	require.Len(t, storedBundles, len(rawBundles[0].Bundles))
+
+	// Deleting the keys deletes the bundles from the shards
+	require.NoError(t, s.DeleteKeys(keys))
+	require.Empty(t, bundleLocations(t, rawBundles, mainDB, db0, db1))
+	_, err = s.GetChunks(blobKey, 0)
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+}
+
+func TestShardedStoreV2UnhealthyShard(t *testing.T) {
+	batch, rawBundles := mockRawBundles(t)
+	mainDB := startTestTableStore(t, node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName)
+	path0 := t.TempDir()
+	shard0, db0 := newTestChunkShard(t, path0)
+	shard1, db1 := newTestChunkShard(t, t.TempDir())
+	s, err := node.NewShardedStoreV2(mainDB, []*node.ChunkShard{shard0, shard1}, logging.NewNoopLogger(), 10*time.Second)
+	require.NoError(t, err)
+
+	health := s.CheckHealth()
+	require.Len(t, health, 2)
+	require.True(t, health[0].Healthy)
+	require.True(t, health[1].Healthy)
+
+	// The probe file of the first shard can't be written
+	require.NoError(t, os.Mkdir(filepath.Join(path0, ".health_probe"), 0700))
+	health = s.CheckHealth()
+	require.False(t, health[0].Healthy)
+	require.Error(t, health[0].Err)
+	require.True(t, health[1].Healthy)
+
+	_, _, err = s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	for _, location := range bundleLocations(t, rawBundles, mainDB, db0, db1) {
+		require.Equal(t, 2, location)
+	}
+	requireChunks(t, s, rawBundles)
+
+	// The shard is used again once healthy
+	require.NoError(t, os.Remove(filepath.Join(path0, ".health_probe")))
+	health = s.CheckHealth()
+	require.True(t, health[0].Healthy)
+}
+
+func TestShardedStoreV2Rebalance(t *testing.T) {
+	batch, rawBundles := mockRawBundles(t)
+	logger := logging.NewNoopLogger()
+	mainDB := startTestTableStore(t, node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName)
+	layoutPath := filepath.Join(t.TempDir(), "chunk_shards.json")
+
+	// The bundles stored before the node was sharded are in the main store
+	_, _, err := node.NewLevelDBStoreV2(mainDB, logger, 10*time.Second).StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	path0 := t.TempDir()
+	shard0, db0 := newTestChunkShard(t, path0)
+	s, err := node.NewShardedStoreV2(mainDB, []*node.ChunkShard{shard0}, logger, 10*time.Second)
+	require.NoError(t, err)
+	requireChunks(t, s, rawBundles)
+	require.NoError(t, s.Rebalance(context.Background(), layoutPath))
+	for _, location := range bundleLocations(t, rawBundles, mainDB, db0) {
+		require.Equal(t, 1, location)
+	}
+	requireChunks(t, s, rawBundles)
+
+	// Adding a shard moves the bundles it owns
+	path1 := t.TempDir()
+	shard0 = node.NewChunkShard(path0, db0, logger, 10*time.Second)
+	shard1, db1 := newTestChunkShard(t, path1)
+	s, err = node.NewShardedStoreV2(mainDB, []*node.ChunkShard{shard0, shard1}, logger, 10*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, s.Rebalance(context.Background(), layoutPath))
+	requireChunks(t, s, rawBundles)
+	locations := bundleLocations(t, rawBundles, mainDB, db0, db1)
+	require.Len(t, locations, 6)
+
+	// The bundles are where a store with the same paths writes them
+	refShard0, refDB0 := newTestChunkShard(t, path0)
+	refShard1, refDB1 := newTestChunkShard(t, path1)
+	ref, err := node.NewShardedStoreV2(
+		startTestTableStore(t, node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName),
+		[]*node.ChunkShard{refShard0, refShard1}, logger, 10*time.Second)
+	require.NoError(t, err)
+	_, _, err = ref.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	refLocations := bundleLocations(t, rawBundles, nil, refDB0, refDB1)
+	require.Equal(t, refLocations, locations)
+
+	// The moved bundles keep their expiration time
+	bundlesKeyBuilder, err := db1.GetKeyBuilder(node.BundleTableName)
+	require.NoError(t, err)
+	for _, bundles := range rawBundles {
+		blobKey, err := bundles.BlobCertificate.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		for quorum := range bundles.Bundles {
+			k, err := node.BundleKey(blobKey, quorum)
+			require.NoError(t, err)
+			if locations[string(k)] != 2 {
+				continue
+			}
+			expirations, err := db1.GetExpirationTimes([]kvstore.Key{bundlesKeyBuilder.Key(k)})
+			require.NoError(t, err)
+			require.False(t, expirations[0].IsZero())
+			require.True(t, expirations[0].Before(time.Now().Add(10*time.Second)))
+		}
+	}
+
+	// Rebalancing again with the same paths is a no-op
+	require.NoError(t, s.Rebalance(context.Background(), layoutPath))
+	require.Equal(t, locations, bundleLocations(t, rawBundles, mainDB, db0, db1))
+}
+
+func TestShardedStoreV2RebalanceUnhealthyShard(t *testing.T) {
+	batch, rawBundles := mockRawBundles(t)
+	logger := logging.NewNoopLogger()
+	mainDB := startTestTableStore(t, node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName)
+	layoutPath := filepath.Join(t.TempDir(), "chunk_shards.json")
+	_, _, err := node.NewLevelDBStoreV2(mainDB, logger, 10*time.Second).StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	path0 := t.TempDir()
+	shard0, db0 := newTestChunkShard(t, path0)
+	s, err := node.NewShardedStoreV2(mainDB, []*node.ChunkShard{shard0}, logger, 10*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(path0, ".health_probe"), 0700))
+	require.False(t, s.CheckHealth()[0].Healthy)
+
+	// The bundles owned by the unhealthy shard stay in the main store, and the layout isn't recorded
+	require.Error(t, s.Rebalance(context.Background(), layoutPath))
+	for _, location := range bundleLocations(t, rawBundles, mainDB, db0) {
+		require.Equal(t, 0, location)
+	}
+	_, err = os.Stat(layoutPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	requireChunks(t, s, rawBundles)
+
+	// The rebalancing completes once the shard is healthy again
+	require.NoError(t, os.Remove(filepath.Join(path0, ".health_probe")))
+	require.True(t, s.CheckHealth()[0].Healthy)
+	require.NoError(t, s.Rebalance(context.Background(), layoutPath))
+	for _, location := range bundleLocations(t, rawBundles, mainDB, db0) {
+		require.Equal(t, 1, location)
+	}
+	_, err = os.Stat(layoutPath)
+	require.NoError(t, err)
+	requireChunks(t, s, rawBundles)
+}
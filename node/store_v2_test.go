@@ -44,10 +44,11 @@ func TestStoreBatchV2(t *testing.T) {
 	}()
 	keys, _, err := s.StoreBatch(batch, rawBundles)
 	require.NoError(t, err)
-	require.Len(t, keys, 7)
+	// The batch header, the bundles and their sizes
+	require.Len(t, keys, 13)
 
 	tables := db.GetTables()
-	require.ElementsMatch(t, []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName}, tables)
+	require.ElementsMatch(t, []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName}, tables)
 
 	// Check batch header
 	bhh, err := batch.BatchHeader.Hash()
@@ -164,9 +165,110 @@ func TestGetChunks(t *testing.T) {
 func createStoreV2(t *testing.T) (node.StoreV2, kvstore.TableStore) {
 	logger := logging.NewNoopLogger()
 	config := tablestore.DefaultLevelDBConfig(t.TempDir())
-	config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName}
+	config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.BundleSizeTableName}
 	tStore, err := tablestore.Start(logger, config)
 	require.NoError(t, err)
 	s := node.NewLevelDBStoreV2(tStore, logger, 10*time.Second)
 	return s, tStore
 }
+
+func TestCompactStoreV2(t *testing.T) {
+	_, batch, bundles := nodemock.MockBatch(t)
+
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+
+	s, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	compactor, ok := s.(node.StoreCompactor)
+	require.True(t, ok)
+
+	keys, _, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	_, sizeStored, err := compactor.Compact()
+	require.NoError(t, err)
+	require.Greater(t, sizeStored, uint64(0))
+
+	err = s.DeleteKeys(keys)
+	require.NoError(t, err)
+	sizeBefore, sizeAfter, err := compactor.Compact()
+	require.NoError(t, err)
+	require.Equal(t, sizeStored, sizeBefore)
+	require.Less(t, sizeAfter, sizeBefore)
+}
+
+func TestStoreV2BundleSizes(t *testing.T) {
+	_, batch, bundles := nodemock.MockBatch(t)
+
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	expectedUsage := make(map[core.QuorumID]*node.QuorumStorageUsage)
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+			if _, ok := expectedUsage[quorum]; !ok {
+				expectedUsage[quorum] = &node.QuorumStorageUsage{}
+			}
+			expectedUsage[quorum].NumBundles++
+			expectedUsage[quorum].SizeBytes += uint64(len(bundleBytes))
+		}
+	}
+
+	s, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	inspector, ok := s.(interface {
+		node.StoreV2Inspector
+		BackfillBundleSizes() error
+	})
+	require.True(t, ok)
+
+	// The usage is read from the sizes stored along with the bundles
+	keys, _, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	usage, err := inspector.GetStorageUsage()
+	require.NoError(t, err)
+	require.Equal(t, expectedUsage, usage)
+
+	// The sizes of the bundles stored before the bundle size table existed are backfilled
+	deleteSizes := func() {
+		for _, key := range keys {
+			if key.Builder().TableName() == node.BundleSizeTableName {
+				require.NoError(t, db.Delete(key))
+			}
+		}
+	}
+	deleteSizes()
+	usage, err = inspector.GetStorageUsage()
+	require.NoError(t, err)
+	require.Empty(t, usage)
+	require.NoError(t, inspector.BackfillBundleSizes())
+	usage, err = inspector.GetStorageUsage()
+	require.NoError(t, err)
+	require.Equal(t, expectedUsage, usage)
+
+	// The backfill only runs once
+	deleteSizes()
+	require.NoError(t, inspector.BackfillBundleSizes())
+	usage, err = inspector.GetStorageUsage()
+	require.NoError(t, err)
+	require.Empty(t, usage)
+}
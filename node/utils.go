@@ -121,12 +121,21 @@ func ValidatePointsFromBlobHeader(h *pb.BlobHeader) error {
 	return nil
 }
 
-func SocketAddress(ctx context.Context, provider pubip.Provider, dispersalPort string, retrievalPort string) (string, error) {
+// SocketAddress returns the socket of the node at its public IP address. The v2 ports are optional, see
+// core.MakeOperatorSocketWithV2Ports.
+func SocketAddress(
+	ctx context.Context,
+	provider pubip.Provider,
+	dispersalPort string,
+	retrievalPort string,
+	v2DispersalPort string,
+	v2RetrievalPort string) (string, error) {
+
 	ip, err := provider.PublicIPAddress(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get public ip address from IP provider: %w", err)
 	}
-	socket := core.MakeOperatorSocket(ip, dispersalPort, retrievalPort)
+	socket := core.MakeOperatorSocketWithV2Ports(ip, dispersalPort, retrievalPort, v2DispersalPort, v2RetrievalPort)
 	return socket.String(), nil
 }
 
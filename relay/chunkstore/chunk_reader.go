@@ -2,7 +2,11 @@ package chunkstore
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -10,6 +14,7 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"golang.org/x/sync/errgroup"
 )
 
 // ChunkReader reads chunks written by ChunkWriter.
@@ -22,8 +27,23 @@ type ChunkReader interface {
 		ctx context.Context,
 		blobKey corev2.BlobKey,
 		fragmentInfo *encoding.FragmentInfo) ([]*rs.Frame, error)
+	// GetFrames reads the proofs and the coefficients of the chunks with the given indices from the chunk store,
+	// downloading only the bytes of those chunks rather than all the chunks of the blob. Each chunk is expected to
+	// have chunkLength coefficients. The frames are returned in the order of the indices.
+	GetFrames(
+		ctx context.Context,
+		blobKey corev2.BlobKey,
+		fragmentInfo *encoding.FragmentInfo,
+		chunkLength uint64,
+		indices []encoding.ChunkNumber) ([]*encoding.Frame, error)
 }
 
+// ErrMalformedChunk is returned when the stored bytes of a chunk can't be decoded into a chunk of the expected length
+var ErrMalformedChunk = errors.New("malformed chunk")
+
+// maxConcurrentRangeReads is the maximum number of ranges of chunks GetFrames reads concurrently
+const maxConcurrentRangeReads = 32
+
 var _ ChunkReader = (*chunkReader)(nil)
 
 type chunkReader struct {
@@ -104,3 +124,86 @@ func (r *chunkReader) GetChunkCoefficients(
 
 	return frames, nil
 }
+
+func (r *chunkReader) GetFrames(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	fragmentInfo *encoding.FragmentInfo,
+	chunkLength uint64,
+	indices []encoding.ChunkNumber) ([]*encoding.Frame, error) {
+
+	// The proofs are stored back to back, and the coefficients after the number of chunks, each prefixed by
+	// its number of coefficients, as serialized by ChunkWriter. Consecutive chunks are read with a single request.
+	sorted := slices.Clone(indices)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	proofSize := uint64(bn254.SizeOfG1AffineCompressed)
+	coeffsSize := 4 + chunkLength*encoding.BYTES_PER_SYMBOL
+
+	frames := make(map[encoding.ChunkNumber]*encoding.Frame, len(sorted))
+	framesLock := sync.Mutex{}
+
+	pool, poolCtx := errgroup.WithContext(ctx)
+	pool.SetLimit(maxConcurrentRangeReads)
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && sorted[end] == sorted[end-1]+1 {
+			end++
+		}
+		first, count := uint64(sorted[start]), uint64(end-start)
+		start = end
+
+		pool.Go(func() error {
+			proofBytes, err := r.client.DownloadObjectRange(
+				poolCtx, r.bucket, s3.ScopedProofKey(blobKey), int(first*proofSize), int(count*proofSize))
+			if err != nil {
+				return fmt.Errorf("failed to download proofs of chunks [%d, %d): %w", first, first+count, err)
+			}
+			coeffBytes, err := r.client.FragmentedDownloadObjectRange(
+				poolCtx,
+				r.bucket,
+				s3.ScopedChunkKey(blobKey),
+				int(fragmentInfo.TotalChunkSizeBytes),
+				int(fragmentInfo.FragmentSizeBytes),
+				int(4+first*coeffsSize),
+				int(count*coeffsSize))
+			if err != nil {
+				return fmt.Errorf("failed to download coefficients of chunks [%d, %d): %w", first, first+count, err)
+			}
+
+			for i := uint64(0); i < count; i++ {
+				frame := &encoding.Frame{}
+				err = frame.Proof.Unmarshal(proofBytes[i*proofSize : (i+1)*proofSize])
+				if err != nil {
+					return fmt.Errorf("%w: failed to unmarshal proof of chunk %d: %w", ErrMalformedChunk, first+i, err)
+				}
+
+				serializedCoeffs := coeffBytes[i*coeffsSize : (i+1)*coeffsSize]
+				if length := binary.BigEndian.Uint32(serializedCoeffs); uint64(length) != chunkLength {
+					return fmt.Errorf("%w: chunk %d has %d coefficients, expected %d",
+						ErrMalformedChunk, first+i, length, chunkLength)
+				}
+				coeffs, _, err := rs.GnarkDecodeFrame(serializedCoeffs)
+				if err != nil {
+					return fmt.Errorf("%w: failed to decode coefficients of chunk %d: %w", ErrMalformedChunk, first+i, err)
+				}
+				frame.Coeffs = coeffs.Coeffs
+
+				framesLock.Lock()
+				frames[encoding.ChunkNumber(first+i)] = frame
+				framesLock.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := pool.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*encoding.Frame, len(indices))
+	for i, index := range indices {
+		result[i] = frames[index]
+	}
+	return result, nil
+}
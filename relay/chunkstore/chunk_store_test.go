@@ -291,3 +291,95 @@ func TestCheckProofCoefficientsExist(t *testing.T) {
 		require.Equal(t, metadata, fragmentInfo)
 	}
 }
+
+func TestPutFrames(t *testing.T) {
+	tu.InitializeRandom()
+	client := mock.NewS3Client()
+	logger := logging.NewNoopLogger()
+
+	chunkSize := uint64(rand.Intn(1024) + 100)
+	fragmentSize := int(chunkSize / 2)
+
+	params := encoding.ParamsFromSysPar(3, 1, chunkSize)
+	encoder, err := rs.NewEncoder(encoding.DefaultConfig())
+	require.NoError(t, err)
+
+	writer := NewChunkWriter(logger, client, bucket, fragmentSize)
+	reader := NewChunkReader(logger, client, bucket)
+	ctx := context.Background()
+	key := corev2.BlobKey(tu.RandomBytes(32))
+
+	coefficients := generateRandomFrames(t, encoder, int(chunkSize), params)
+	proofs := getProofs(t, len(coefficients))
+	frames := make([]*encoding.Frame, len(coefficients))
+	for i := range frames {
+		frames[i] = &encoding.Frame{Proof: *proofs[i], Coeffs: coefficients[i].Coeffs}
+	}
+
+	// the chunks are stored as if their proofs and coefficients were written separately
+	fragmentInfo, err := writer.PutFrames(ctx, key, frames)
+	require.NoError(t, err)
+	require.True(t, writer.ProofExists(ctx, key))
+	exist, existingFragmentInfo := writer.CoefficientsExists(ctx, key)
+	require.True(t, exist)
+	require.Equal(t, fragmentInfo, existingFragmentInfo)
+
+	readProofs, err := reader.GetChunkProofs(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, proofs, readProofs)
+	readCoefficients, err := reader.GetChunkCoefficients(ctx, key, fragmentInfo)
+	require.NoError(t, err)
+	require.Equal(t, coefficients, readCoefficients)
+}
+
+func TestGetFrames(t *testing.T) {
+	tu.InitializeRandom()
+	client := mock.NewS3Client()
+	logger := logging.NewNoopLogger()
+
+	chunkSize := uint64(rand.Intn(1024) + 100)
+	fragmentSize := int(chunkSize / 2)
+
+	params := encoding.ParamsFromSysPar(3, 1, chunkSize)
+	encoder, err := rs.NewEncoder(encoding.DefaultConfig())
+	require.NoError(t, err)
+
+	writer := NewChunkWriter(logger, client, bucket, fragmentSize)
+	reader := NewChunkReader(logger, client, bucket)
+	ctx := context.Background()
+	key := corev2.BlobKey(tu.RandomBytes(32))
+
+	coefficients := generateRandomFrames(t, encoder, int(chunkSize), params)
+	proofs := getProofs(t, len(coefficients))
+	frames := make([]*encoding.Frame, len(coefficients))
+	for i := range frames {
+		frames[i] = &encoding.Frame{Proof: *proofs[i], Coeffs: coefficients[i].Coeffs}
+	}
+	fragmentInfo, err := writer.PutFrames(ctx, key, frames)
+	require.NoError(t, err)
+	chunkLength := uint64(len(frames[0].Coeffs))
+
+	// a random sample of the chunks, with runs of consecutive chunks and duplicates
+	indices := make([]encoding.ChunkNumber, 0)
+	for i := 0; i < 10; i++ {
+		indices = append(indices, encoding.ChunkNumber(rand.Intn(len(frames))))
+	}
+	indices = append(indices, 0, 1, 2, indices[0], encoding.ChunkNumber(len(frames)-1))
+
+	readFrames, err := reader.GetFrames(ctx, key, fragmentInfo, chunkLength, indices)
+	require.NoError(t, err)
+	require.Len(t, readFrames, len(indices))
+	for i, index := range indices {
+		require.Equal(t, frames[index], readFrames[i])
+	}
+	require.Zero(t, client.Called["DownloadObject"])
+	require.Zero(t, client.Called["FragmentedDownloadObject"])
+
+	// chunks of another length
+	_, err = reader.GetFrames(ctx, key, fragmentInfo, chunkLength/2, []encoding.ChunkNumber{0})
+	require.ErrorIs(t, err, ErrMalformedChunk)
+
+	// chunks past the stored ones
+	_, err = reader.GetFrames(ctx, key, fragmentInfo, chunkLength, []encoding.ChunkNumber{encoding.ChunkNumber(len(frames))})
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package chunkstore
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -21,6 +22,10 @@ type ChunkWriter interface {
 		ctx context.Context,
 		blobKey corev2.BlobKey,
 		frames []*rs.Frame) (*encoding.FragmentInfo, error)
+	// PutFrames writes the proofs and the coefficients of encoded chunks to the chunk store, as PutChunkProofs and
+	// PutChunkCoefficients would. The chunks are serialized one at a time as they're uploaded, rather than being
+	// split into slices of proofs and frames and serialized all at once.
+	PutFrames(ctx context.Context, blobKey corev2.BlobKey, frames []*encoding.Frame) (*encoding.FragmentInfo, error)
 	// ProofExists checks if the proofs for the blob key exist in the chunk store.
 	ProofExists(ctx context.Context, blobKey corev2.BlobKey) bool
 	// CoefficientsExists checks if the coefficients for the blob key exist in the chunk store.
@@ -79,20 +84,75 @@ func (c *chunkWriter) PutChunkCoefficients(
 	if len(frames) == 0 {
 		return nil, fmt.Errorf("no frames to upload")
 	}
-	bytes, err := rs.GnarkEncodeFrames(frames)
+
+	// The frames are serialized as the fragments are uploaded, so the serialized frames are never held in memory
+	// all at once.
+	size := rs.GnarkEncodedFramesSize(frames)
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(rs.GnarkWriteFrames(writer, frames))
+	}()
+	// unblock the serialization if the upload stops before reading all the frames
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	err := c.s3Client.FragmentedUploadStream(
+		ctx, c.bucketName, s3.ScopedChunkKey(blobKey), reader, int(size), c.fragmentSize)
+	if err != nil {
+		c.logger.Errorf("Failed to upload chunk coefficients to S3: %v", err)
+		return nil, fmt.Errorf("failed to upload chunk coefficients to S3: %v", err)
+	}
+
+	return &encoding.FragmentInfo{
+		TotalChunkSizeBytes: size,
+		FragmentSizeBytes:   uint32(c.fragmentSize),
+	}, nil
+}
+
+func (c *chunkWriter) PutFrames(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	frames []*encoding.Frame) (*encoding.FragmentInfo, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames to upload")
+	}
+
+	proofBytes := make([]byte, 0, bn254.SizeOfG1AffineCompressed*len(frames))
+	for _, frame := range frames {
+		serializedProof := frame.Proof.Bytes()
+		proofBytes = append(proofBytes, serializedProof[:]...)
+	}
+	err := c.s3Client.UploadObject(ctx, c.bucketName, s3.ScopedProofKey(blobKey), proofBytes)
 	if err != nil {
-		c.logger.Error("Failed to encode frames", "err", err)
-		return nil, fmt.Errorf("failed to encode frames: %v", err)
+		c.logger.Errorf("Failed to upload chunk proofs to S3: %v", err)
+		return nil, fmt.Errorf("failed to upload chunk proofs to S3: %v", err)
 	}
 
-	err = c.s3Client.FragmentedUploadObject(ctx, c.bucketName, s3.ScopedChunkKey(blobKey), bytes, c.fragmentSize)
+	size := uint32(4) // the number of frames
+	for _, frame := range frames {
+		size += 4 + uint32(encoding.BYTES_PER_SYMBOL*len(frame.Coeffs))
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(rs.GnarkWriteFramesFunc(writer, len(frames), func(i int) *rs.Frame {
+			return &rs.Frame{Coeffs: frames[i].Coeffs}
+		}))
+	}()
+	// unblock the serialization if the upload stops before reading all the frames
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	err = c.s3Client.FragmentedUploadStream(
+		ctx, c.bucketName, s3.ScopedChunkKey(blobKey), reader, int(size), c.fragmentSize)
 	if err != nil {
 		c.logger.Errorf("Failed to upload chunk coefficients to S3: %v", err)
 		return nil, fmt.Errorf("failed to upload chunk coefficients to S3: %v", err)
 	}
 
 	return &encoding.FragmentInfo{
-		TotalChunkSizeBytes: uint32(len(bytes)),
+		TotalChunkSizeBytes: size,
 		FragmentSizeBytes:   uint32(c.fragmentSize),
 	}, nil
 }
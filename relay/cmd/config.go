@@ -6,6 +6,8 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	dacore "github.com/Layr-Labs/eigenda/core"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/relay"
@@ -37,6 +39,9 @@ type Config struct {
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 	ChainStateConfig              thegraph.Config
+
+	// QuorumProfiles are the quorum profiles of each blob version, which must match those of the disperser and the nodes.
+	QuorumProfiles dacore.BlobVersionQuorumProfiles
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -45,6 +50,10 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, err
 	}
 	awsClientConfig := aws.ReadClientConfig(ctx, flags.FlagPrefix)
+	quorumProfiles, err := coreeth.ReadQuorumProfiles(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
 	relayIDs := ctx.IntSlice(flags.RelayIDsFlag.Name)
 	if len(relayIDs) == 0 {
 		return Config{}, fmt.Errorf("no relay IDs specified")
@@ -100,6 +109,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		BLSOperatorStateRetrieverAddr: ctx.String(flags.BlsOperatorStateRetrieverAddrFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.String(flags.EigenDAServiceManagerAddrFlag.Name),
 		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		QuorumProfiles:                quorumProfiles,
 	}
 	for i, id := range relayIDs {
 		config.RelayConfig.RelayIDs[i] = core.RelayKey(id)
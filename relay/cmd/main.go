@@ -54,6 +54,7 @@ func RunRelay(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create logger: %w", err)
 	}
+	config.RelayConfig.Version = ctx.App.Version
 	logger.Info(fmt.Sprintf("Relay configuration: %#v", config))
 
 	dynamoClient, err := dynamodb.NewClient(config.AWS, logger)
@@ -78,6 +79,7 @@ func RunRelay(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create eth writer: %w", err)
 	}
+	tx.SetQuorumProfiles(config.QuorumProfiles)
 
 	cs := coreeth.NewChainState(tx, client)
 	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
@@ -25,6 +25,14 @@ type blobMetadata struct {
 	totalChunkSizeBytes uint32
 	// the fragment size used for uploading the encoded chunks
 	fragmentSizeBytes uint32
+	// the Unix timestamp in seconds after which the blob is no longer served, or 0 if the blob
+	// is retained for the default period
+	expiry uint64
+}
+
+// isExpired returns true if the blob requested a retention period that has elapsed.
+func (m *blobMetadata) isExpired(now time.Time) bool {
+	return m.expiry > 0 && uint64(now.Unix()) >= m.expiry
 }
 
 // metadataProvider encapsulates logic for fetching metadata for blobs. Utilized by the relay Server.
@@ -126,6 +134,9 @@ func (m *metadataProvider) GetMetadataForBlobs(ctx context.Context, keys []v2.Bl
 		boundKey := key
 		go func() {
 			metadata, err := m.metadataCache.Get(ctx, boundKey)
+			if err == nil && metadata.isExpired(time.Now()) {
+				err = fmt.Errorf("blob %s has expired", boundKey.Hex())
+			}
 			if err != nil {
 				// Intentionally log at debug level. External users can force this condition to trigger
 				// by requesting metadata for a blob that does not exist, and so it's important to avoid
@@ -190,6 +201,17 @@ func (m *metadataProvider) fetchMetadata(key v2.BlobKey) (*blobMetadata, error)
 		}
 	}
 
+	// Blobs with a custom retention period stop being served once they expire. The expiry is only
+	// recorded in the blob metadata, so it is looked up for these blobs alone.
+	var expiry uint64
+	if cert.BlobHeader.RetentionPeriod > 0 {
+		storedMetadata, err := m.metadataStore.GetBlobMetadata(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving expiry for blob %s: %w", key.Hex(), err)
+		}
+		expiry = storedMetadata.Expiry
+	}
+
 	// TODO(cody-littley): blob size is not correct https://github.com/Layr-Labs/eigenda/pull/906#discussion_r1847396530
 	blobSize := uint32(cert.BlobHeader.BlobCommitments.Length) * encoding.BYTES_PER_SYMBOL
 	blobParams, ok := blobParamsMap.Get(cert.BlobHeader.BlobVersion)
@@ -207,6 +229,7 @@ func (m *metadataProvider) fetchMetadata(key v2.BlobKey) (*blobMetadata, error)
 		chunkSizeBytes:      chunkSize,
 		totalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
 		fragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+		expiry:              expiry,
 	}
 
 	return metadata, nil
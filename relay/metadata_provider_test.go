@@ -8,6 +8,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -127,6 +128,69 @@ func TestFetchingIndividualMetadata(t *testing.T) {
 	}
 }
 
+func TestFetchingExpiredMetadata(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+	metadataStore := buildMetadataStore(t)
+
+	now := time.Now()
+	expiries := map[string]time.Time{
+		"live":    now.Add(time.Hour),
+		"expired": now.Add(-time.Second),
+	}
+	blobKeys := make(map[string]v2.BlobKey)
+	for name, expiry := range expiries {
+		header, _ := randomBlob(t)
+		header.RetentionPeriod = 48 * time.Hour
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+		blobKeys[name] = blobKey
+
+		err = metadataStore.PutBlobMetadata(context.Background(), &dispv2.BlobMetadata{
+			BlobHeader:  header,
+			BlobStatus:  dispv2.Encoded,
+			Expiry:      uint64(expiry.Unix()),
+			RequestedAt: uint64(now.UnixNano()),
+			UpdatedAt:   uint64(now.UnixNano()),
+		})
+		require.NoError(t, err)
+		err = metadataStore.PutBlobCertificate(
+			context.Background(),
+			&v2.BlobCertificate{
+				BlobHeader: header,
+			},
+			&encoding.FragmentInfo{
+				TotalChunkSizeBytes: 1024,
+				FragmentSizeBytes:   512,
+			})
+		require.NoError(t, err)
+	}
+
+	server, err := newMetadataProvider(
+		context.Background(),
+		logger,
+		metadataStore,
+		1024*1024,
+		32,
+		nil,
+		10*time.Second,
+		v2.NewBlobVersionParameterMap(mockBlobParamsMap()),
+		nil)
+	require.NoError(t, err)
+
+	mMap, err := server.GetMetadataForBlobs(context.Background(), []v2.BlobKey{blobKeys["live"]})
+	require.NoError(t, err)
+	require.Equal(t, uint64(expiries["live"].Unix()), mMap[blobKeys["live"]].expiry)
+
+	_, err = server.GetMetadataForBlobs(context.Background(), []v2.BlobKey{blobKeys["expired"]})
+	require.ErrorContains(t, err, "has expired")
+}
+
 func TestBatchedFetch(t *testing.T) {
 	tu.InitializeRandom()
 
@@ -120,6 +120,9 @@ type Config struct {
 
 	// MetricsPort is the port that the relay metrics server listens on.
 	MetricsPort int
+
+	// Version is the version of the relay, reported in the header of every gRPC response.
+	Version string
 }
 
 // NewServer creates a new relay Server.
@@ -484,7 +487,10 @@ func (s *Server) Start(ctx context.Context) error {
 
 	opt := grpc.MaxRecvMsgSize(s.config.MaxGRPCMessageSize)
 
-	s.grpcServer = grpc.NewServer(opt, s.metrics.GetGRPCServerOption())
+	s.grpcServer = grpc.NewServer(
+		opt,
+		s.metrics.GetGRPCServerOption(),
+		grpc.ChainUnaryInterceptor(healthcheck.VersionUnaryInterceptor(s.config.Version)))
 	reflection.Register(s.grpcServer)
 	pb.RegisterRelayServer(s.grpcServer, s)
 
@@ -210,7 +210,7 @@ func mustMakeDisperser(t *testing.T, cst core.IndexedChainState, store disperser
 		GrpcPort: fmt.Sprint(disperserGrpcPort),
 	}
 	tx := &coremock.MockWriter{}
-	tx.On("GetCurrentBlockNumber").Return(uint64(100), nil)
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
 	tx.On("GetQuorumCount").Return(1, nil)
 
 	// this is disperser client's private key used in tests
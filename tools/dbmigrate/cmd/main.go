@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/tools/dbmigrate"
+	"github.com/Layr-Labs/eigenda/tools/dbmigrate/flags"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "dbmigrate"
+	app.Description = "one-time migration of the LevelDB stores of a DA node to Pebble. " +
+		"Stop the node before migrating, then restart it with the pebble db backend."
+	app.Usage = ""
+	app.Flags = flags.Flags
+	app.Action = RunMigration
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func RunMigration(ctx *cli.Context) error {
+	config, err := dbmigrate.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+
+	err = node.MigrateToPebble(logger, config.DbPath, config.BatchSize)
+	if err != nil {
+		return err
+	}
+	logger.Info("Migration complete, the node can be restarted with the pebble db backend. "+
+		"The LevelDB stores can be deleted once the node runs with the Pebble stores", "dbPath", config.DbPath)
+	return nil
+}
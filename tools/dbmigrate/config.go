@@ -0,0 +1,31 @@
+package dbmigrate
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/tools/dbmigrate/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	LoggerConfig common.LoggerConfig
+	DbPath       string
+	BatchSize    uint32
+}
+
+func ReadConfig(ctx *cli.Context) *Config {
+	return &Config{
+		DbPath:    ctx.String(flags.DbPathFlag.Name),
+		BatchSize: uint32(ctx.Uint(flags.BatchSizeFlag.Name)),
+	}
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	config := ReadConfig(ctx)
+	config.LoggerConfig = *loggerConfig
+	return config, nil
+}
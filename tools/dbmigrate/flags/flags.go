@@ -0,0 +1,45 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "DBMIGRATE"
+)
+
+var (
+	/* Required Flags*/
+	DbPathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "db-path"),
+		Usage:    "The db path of the DA node, as passed to the node with its db-path flag",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DB_PATH"),
+	}
+	/* Optional Flags*/
+	BatchSizeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-size"),
+		Usage:    "The number of key-value pairs written to the Pebble stores in each batch",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BATCH_SIZE"),
+		Value:    1024,
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	DbPathFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	BatchSizeFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+}
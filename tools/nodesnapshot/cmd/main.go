@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/tools/nodesnapshot"
+	"github.com/Layr-Labs/eigenda/tools/nodesnapshot/flags"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "nodesnapshot"
+	app.Description = "export and import snapshots of the stores of a DA node, to move a node to a new machine " +
+		"without losing the chunks it holds. A running node can also export a snapshot with the " +
+		"GET /admin/v2/snapshot endpoint of its admin API."
+	app.Usage = ""
+	app.Flags = flags.LoggerFlags
+	app.Commands = []cli.Command{
+		{
+			Name:   "export",
+			Usage:  "export a snapshot of the stores of a stopped node into the snapshot file",
+			Flags:  flags.Flags,
+			Action: RunExport,
+		},
+		{
+			Name:   "import",
+			Usage:  "create the stores of a new node from the snapshot file, the node must not be running",
+			Flags:  flags.Flags,
+			Action: RunImport,
+		},
+	}
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func RunExport(ctx *cli.Context) error {
+	config, err := nodesnapshot.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(config.SnapshotFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	err = node.ExportSnapshotFromDisk(logger, config.DbPath, config.DbBackend, file)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(config.SnapshotFile)
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot file: %w", err)
+	}
+	logger.Info("Export complete", "dbPath", config.DbPath, "snapshotFile", config.SnapshotFile)
+	return nil
+}
+
+func RunImport(ctx *cli.Context) error {
+	config, err := nodesnapshot.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(config.SnapshotFile)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	err = node.ImportSnapshot(logger, file, config.DbPath, config.DbBackend, config.BatchSize)
+	if err != nil {
+		return err
+	}
+	logger.Info("Import complete, the node can be started with the same db path and db backend",
+		"dbPath", config.DbPath, "dbBackend", config.DbBackend)
+	return nil
+}
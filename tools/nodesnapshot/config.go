@@ -0,0 +1,39 @@
+package nodesnapshot
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/tools/nodesnapshot/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	LoggerConfig common.LoggerConfig
+	DbPath       string
+	DbBackend    string
+	SnapshotFile string
+	BatchSize    uint32
+}
+
+func ReadConfig(ctx *cli.Context) *Config {
+	return &Config{
+		DbPath:       ctx.String(flags.DbPathFlag.Name),
+		DbBackend:    ctx.String(flags.DbBackendFlag.Name),
+		SnapshotFile: ctx.String(flags.SnapshotFileFlag.Name),
+		BatchSize:    uint32(ctx.Uint(flags.BatchSizeFlag.Name)),
+	}
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	config := ReadConfig(ctx)
+	config.LoggerConfig = *loggerConfig
+	if err := node.ValidateDBBackend(config.DbBackend); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,63 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "NODESNAPSHOT"
+)
+
+var (
+	/* Required Flags*/
+	DbPathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "db-path"),
+		Usage:    "The db path of the DA node, as passed to the node with its db-path flag",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DB_PATH"),
+	}
+	SnapshotFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "snapshot-file"),
+		Usage:    "The path of the snapshot file to write when exporting, or to read when importing",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SNAPSHOT_FILE"),
+	}
+	/* Optional Flags*/
+	DbBackendFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "db-backend"),
+		Usage:    "The database backend of the node stores, as passed to the node with its db-backend flag (leveldb or pebble)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DB_BACKEND"),
+		Value:    "leveldb",
+	}
+	BatchSizeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-size"),
+		Usage:    "The number of key-value pairs written to the stores in each batch when importing",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BATCH_SIZE"),
+		Value:    1024,
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	DbPathFlag,
+	SnapshotFileFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	DbBackendFlag,
+	BatchSizeFlag,
+}
+
+// Flags contains the list of configuration options of the export and import commands.
+var Flags []cli.Flag
+
+// LoggerFlags contains the logger options, which are global options of the binary.
+var LoggerFlags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	LoggerFlags = common.LoggerCLIFlags(envPrefix, FlagPrefix)
+}